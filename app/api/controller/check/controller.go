@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -72,5 +73,9 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
 
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
 	return repo, nil
 }
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	defaultStatusContext = "default"
+
+	// combinedStatusPageSize is the page size used when reading all status checks of a commit.
+	combinedStatusPageSize = 100
+)
+
+// StatusInput is used by external CI/CD systems to report a commit status.
+// A commit status is stored as a status check, identified by its context,
+// so it counts toward the status checks required by the branch rules.
+type StatusInput struct {
+	Context     string           `json:"context"`
+	State       enum.CheckStatus `json:"state"`
+	TargetURL   string           `json:"target_url"`
+	Description string           `json:"description"`
+}
+
+func (in *StatusInput) Sanitize() error {
+	in.Context = strings.TrimSpace(in.Context)
+	in.TargetURL = strings.TrimSpace(in.TargetURL)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if in.Context == "" {
+		in.Context = defaultStatusContext
+	}
+
+	if in.State == "" {
+		return usererror.BadRequest("State is missing")
+	}
+
+	return nil
+}
+
+// ReportStatus creates or updates the status of a commit, identified by the status context.
+func (c *Controller) ReportStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *StatusInput,
+) (*types.Check, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.Report(ctx, session, repoRef, commitSHA, &ReportInput{
+		Identifier: in.Context,
+		Status:     in.State,
+		Summary:    in.Description,
+		Link:       in.TargetURL,
+		Payload: types.CheckPayload{
+			// the raw payload kind is used because, unlike the empty payload kind, it doesn't require the link.
+			Kind: enum.CheckPayloadKindRaw,
+		},
+	}, map[string]string{})
+}
+
+// CombinedStatus returns the aggregated status of all status checks reported for a commit.
+func (c *Controller) CombinedStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+) (*types.CombinedCheckStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !git.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	checks := make([]types.Check, 0)
+	for page := 1; ; page++ {
+		list, errList := c.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: combinedStatusPageSize},
+			},
+		})
+		if errList != nil {
+			return nil, fmt.Errorf("failed to list status check results for repo=%s: %w", repo.Identifier, errList)
+		}
+
+		checks = append(checks, list...)
+
+		if len(list) < combinedStatusPageSize {
+			break
+		}
+	}
+
+	return &types.CombinedCheckStatus{
+		CommitSHA:  commitSHA,
+		Status:     combineStatuses(checks),
+		TotalCount: len(checks),
+		Checks:     checks,
+	}, nil
+}
+
+// combineStatuses aggregates the statuses of the checks into a single status.
+// Errors take precedence over failures, and failures over checks that are not yet completed.
+// The combined status is success only if all checks succeeded, and pending if there are no checks.
+func combineStatuses(checks []types.Check) enum.CheckStatus {
+	if len(checks) == 0 {
+		return enum.CheckStatusPending
+	}
+
+	var hasFailure, hasPending, hasRunning bool
+	for _, check := range checks {
+		switch check.Status {
+		case enum.CheckStatusError:
+			return enum.CheckStatusError
+		case enum.CheckStatusFailure:
+			hasFailure = true
+		case enum.CheckStatusPending:
+			hasPending = true
+		case enum.CheckStatusRunning:
+			hasRunning = true
+		case enum.CheckStatusSuccess:
+		}
+	}
+
+	switch {
+	case hasFailure:
+		return enum.CheckStatusFailure
+	case hasRunning:
+		return enum.CheckStatusRunning
+	case hasPending:
+		return enum.CheckStatusPending
+	default:
+		return enum.CheckStatusSuccess
+	}
+}
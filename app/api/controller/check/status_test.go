@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func Test_combineStatuses(t *testing.T) {
+	checks := func(statuses ...enum.CheckStatus) []types.Check {
+		list := make([]types.Check, len(statuses))
+		for i, status := range statuses {
+			list[i] = types.Check{Status: status}
+		}
+		return list
+	}
+
+	tests := []struct {
+		name   string
+		checks []types.Check
+		want   enum.CheckStatus
+	}{
+		{
+			name:   "no checks",
+			checks: nil,
+			want:   enum.CheckStatusPending,
+		},
+		{
+			name:   "all success",
+			checks: checks(enum.CheckStatusSuccess, enum.CheckStatusSuccess),
+			want:   enum.CheckStatusSuccess,
+		},
+		{
+			name:   "pending",
+			checks: checks(enum.CheckStatusSuccess, enum.CheckStatusPending),
+			want:   enum.CheckStatusPending,
+		},
+		{
+			name:   "running before pending",
+			checks: checks(enum.CheckStatusPending, enum.CheckStatusRunning, enum.CheckStatusSuccess),
+			want:   enum.CheckStatusRunning,
+		},
+		{
+			name:   "failure before running",
+			checks: checks(enum.CheckStatusRunning, enum.CheckStatusFailure, enum.CheckStatusSuccess),
+			want:   enum.CheckStatusFailure,
+		},
+		{
+			name:   "error before failure",
+			checks: checks(enum.CheckStatusFailure, enum.CheckStatusError, enum.CheckStatusPending),
+			want:   enum.CheckStatusError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := combineStatuses(test.checks); got != test.want {
+				t.Errorf("combineStatuses() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListArtifacts lists the artifacts uploaded by the steps of the execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) ([]*types.PipelineArtifact, error) {
+	_, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.artifactService.List(ctx, execution)
+}
+
+// UploadArtifact stores the file as an artifact of the execution, it's used by the steps of the execution.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	name, err := pipelineartifact.SanitizeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.artifactService.Upload(ctx, repo, execution, name, file)
+}
+
+// DownloadArtifact returns the artifact of the execution along with either a signed url or the file itself.
+// The caller is responsible for closing the file.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) (*types.PipelineArtifact, string, io.ReadCloser, error) {
+	name, err := pipelineartifact.SanitizeName(name)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	repo, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return c.artifactService.Download(ctx, repo, execution, name)
+}
+
+// DeleteArtifact deletes the artifact of the execution.
+func (c *Controller) DeleteArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) error {
+	name, err := pipelineartifact.SanitizeName(name)
+	if err != nil {
+		return err
+	}
+
+	repo, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.artifactService.Delete(ctx, repo, execution, name)
+}
+
+func (c *Controller) getExecutionCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	permission enum.Permission,
+) (*types.Repository, *types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, permission)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return repo, execution, nil
+}
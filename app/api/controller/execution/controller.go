@@ -18,22 +18,30 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	repoStore      store.RepoStore
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
+	tx               dbtx.Transactor
+	authorizer       authz.Authorizer
+	executionStore   store.ExecutionStore
+	checkStore       store.CheckStore
+	canceler         canceler.Canceler
+	commitService    commit.Service
+	triggerer        triggerer.Triggerer
+	repoStore        store.RepoStore
+	stageStore       store.StageStore
+	pipelineStore    store.PipelineStore
+	fileService      file.Service
+	artifactService  *pipelineartifact.Service
+	converterService converter.Service
+	publicAccess     publicaccess.Service
 }
 
 func NewController(
@@ -47,17 +55,25 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	fileService file.Service,
+	artifactService *pipelineartifact.Service,
+	converterService converter.Service,
+	publicAccess publicaccess.Service,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		repoStore:      repoStore,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
+		tx:               tx,
+		authorizer:       authorizer,
+		executionStore:   executionStore,
+		checkStore:       checkStore,
+		canceler:         canceler,
+		commitService:    commitService,
+		triggerer:        triggerer,
+		repoStore:        repoStore,
+		stageStore:       stageStore,
+		pipelineStore:    pipelineStore,
+		fileService:      fileService,
+		artifactService:  artifactService,
+		converterService: converterService,
+		publicAccess:     publicAccess,
 	}
 }
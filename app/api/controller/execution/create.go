@@ -27,12 +27,19 @@ import (
 	"github.com/drone/go-scm/scm"
 )
 
+// CreateInput is used for creating an execution manually.
+type CreateInput struct {
+	// Inputs are the values of the input parameters defined in the pipeline YAML.
+	Inputs map[string]string `json:"inputs"`
+}
+
 func (c *Controller) Create(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	pipelineIdentifier string,
 	branch string,
+	in *CreateInput,
 ) (*types.Execution, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -66,6 +73,12 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to fetch commit: %w", err)
 	}
 
+	// Validate the inputs upfront, to reject the request instead of creating a failed execution.
+	inputs, err := c.resolveInputs(ctx, repo, pipeline, commit.SHA, in.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create manual hook for execution.
 	hook := &triggerer.Hook{
 		Trigger:     session.Principal.UID, // who/what triggered the build, different from commit author
@@ -82,6 +95,7 @@ func (c *Controller) Create(
 		Source:      branch,
 		Target:      branch,
 		Params:      map[string]string{},
+		Inputs:      inputs,
 		Timestamp:   commit.Author.When.UnixMilli(),
 	}
 
@@ -43,6 +43,18 @@ func (c *Controller) Delete(
 	if err != nil {
 		return fmt.Errorf("failed to find pipeline: %w", err)
 	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	// the artifacts are deleted along with the execution, their files have to be removed from the blob store.
+	err = c.artifactService.DeleteAll(ctx, repo, execution)
+	if err != nil {
+		return fmt.Errorf("failed to delete execution artifacts: %w", err)
+	}
+
 	err = c.executionStore.Delete(ctx, pipeline.ID, executionNum)
 	if err != nil {
 		return fmt.Errorf("could not delete execution: %w", err)
@@ -0,0 +1,271 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	graphKindPipeline  = "pipeline"
+	graphDefaultName   = "default"
+	graphCloneStepName = "clone"
+)
+
+var v1YamlFinder = regexp.MustCompilePOSIX(`^spec:`)
+
+// graphDocument is a drone YAML document, only the fields needed for the execution graph are parsed.
+type graphDocument struct {
+	Kind     string       `yaml:"kind"`
+	Name     string       `yaml:"name"`
+	Services []*graphStep `yaml:"services"`
+	Steps    []*graphStep `yaml:"steps"`
+}
+
+type graphStep struct {
+	Name      string   `yaml:"name"`
+	Image     string   `yaml:"image"`
+	Detach    bool     `yaml:"detach"`
+	DependsOn []string `yaml:"depends_on"`
+}
+
+// Graph returns the dependency graph of the stages and steps of the execution.
+// The steps of the stages that didn't start yet are resolved from the pipeline YAML
+// of the execution, after expanding matrices and pipeline templates.
+func (c *Controller) Graph(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) (*types.ExecutionGraph, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stages, err := c.stageStore.ListWithSteps(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not query stage information for execution %d: %w",
+			executionNum, err)
+	}
+
+	var planned map[string][]*types.Step
+	for _, stage := range stages {
+		if len(stage.Steps) > 0 {
+			continue
+		}
+
+		planned, err = c.resolveSteps(ctx, repo, pipeline, execution)
+		if err != nil {
+			// the graph of the stages is still useful if the pipeline YAML can't be resolved anymore.
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to resolve the steps of execution %d", executionNum)
+		}
+		break
+	}
+
+	return buildGraph(stages, planned), nil
+}
+
+// resolveSteps returns the steps of the pipelines of the converted YAML of the execution by pipeline name.
+// Steps of v1 YAML are only known once their stage started.
+func (c *Controller) resolveSteps(
+	ctx context.Context,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+) (map[string][]*types.Step, error) {
+	file, err := c.fileService.Get(ctx, repo, pipeline.ConfigPath, execution.After)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pipeline yaml: %w", err)
+	}
+
+	if v1YamlFinder.Match(file.Data) {
+		return nil, nil
+	}
+
+	repoIsPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if repo is public: %w", err)
+	}
+
+	file, err = c.converterService.Convert(ctx, &converter.ConvertArgs{
+		Repo:         repo,
+		RepoIsPublic: repoIsPublic,
+		Pipeline:     pipeline,
+		Execution:    execution,
+		File:         file,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pipeline yaml: %w", err)
+	}
+
+	return parsePlannedSteps(file.Data)
+}
+
+// parsePlannedSteps returns the services and steps of the pipelines of the drone YAML by pipeline name.
+func parsePlannedSteps(data []byte) (map[string][]*types.Step, error) {
+	planned := map[string][]*types.Step{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := &graphDocument{}
+		err := decoder.Decode(doc)
+		if errors.Is(err, io.EOF) {
+			return planned, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pipeline yaml: %w", err)
+		}
+
+		if doc.Kind != graphKindPipeline {
+			continue
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = graphDefaultName
+		}
+
+		steps := make([]*types.Step, 0, len(doc.Services)+len(doc.Steps))
+		for i, src := range append(doc.Services, doc.Steps...) {
+			if src == nil {
+				continue
+			}
+			steps = append(steps, &types.Step{
+				Number: int64(len(steps) + 1),
+				Name:   src.Name,
+				Status: enum.CIStatusPending,
+				Image:  src.Image,
+				// services are detached.
+				Detached:  src.Detach || i < len(doc.Services),
+				DependsOn: src.DependsOn,
+			})
+		}
+
+		planned[name] = steps
+	}
+}
+
+// buildGraph returns the graph of the stages, stages without steps get the planned steps of their pipeline.
+func buildGraph(stages []*types.Stage, planned map[string][]*types.Step) *types.ExecutionGraph {
+	numbers := make(map[string]int64, len(stages))
+	for _, stage := range stages {
+		numbers[stage.Name] = stage.Number
+	}
+
+	graph := &types.ExecutionGraph{
+		Stages: make([]*types.ExecutionGraphStage, len(stages)),
+	}
+	for i, stage := range stages {
+		steps := stage.Steps
+		isPlanned := false
+		if len(steps) == 0 && len(planned[stage.Name]) > 0 {
+			steps = planned[stage.Name]
+			isPlanned = true
+		}
+
+		graph.Stages[i] = &types.ExecutionGraphStage{
+			Number:    stage.Number,
+			Name:      stage.Name,
+			Status:    stage.Status,
+			Matrix:    stage.Matrix,
+			DependsOn: resolveDependencies(stage.DependsOn, numbers),
+			Planned:   isPlanned,
+			Steps:     graphSteps(steps),
+		}
+	}
+
+	return graph
+}
+
+// graphSteps returns the steps with the dependencies they are executed with by the runners:
+// if none of the steps defines dependencies the steps run in order,
+// otherwise the steps without dependencies depend on the clone step.
+func graphSteps(steps []*types.Step) []*types.ExecutionGraphStep {
+	numbers := make(map[string]int64, len(steps))
+	serial := true
+	for _, step := range steps {
+		numbers[step.Name] = step.Number
+		if len(step.DependsOn) > 0 {
+			serial = false
+		}
+	}
+	clone, hasClone := numbers[graphCloneStepName]
+
+	out := make([]*types.ExecutionGraphStep, len(steps))
+	for i, step := range steps {
+		dependsOn := []int64{}
+		switch {
+		case i == 0:
+		case len(step.DependsOn) > 0:
+			dependsOn = resolveDependencies(step.DependsOn, numbers)
+		case serial:
+			dependsOn = []int64{steps[i-1].Number}
+		case hasClone && step.Name != graphCloneStepName:
+			dependsOn = []int64{clone}
+		}
+
+		out[i] = &types.ExecutionGraphStep{
+			Number:    step.Number,
+			Name:      step.Name,
+			Status:    step.Status,
+			Image:     step.Image,
+			Detached:  step.Detached,
+			DependsOn: dependsOn,
+		}
+	}
+
+	return out
+}
+
+// resolveDependencies returns the numbers of the dependencies, unknown dependencies are ignored.
+func resolveDependencies(names []string, numbers map[string]int64) []int64 {
+	dependsOn := make([]int64, 0, len(names))
+	for _, name := range names {
+		if number, ok := numbers[name]; ok {
+			dependsOn = append(dependsOn, number)
+		}
+	}
+	return dependsOn
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlannedSteps(t *testing.T) {
+	const data = `kind: pipeline
+name: test (1.22)
+services:
+- name: db
+  image: postgres
+steps:
+- name: build
+  image: golang:1.22
+- name: test
+  image: golang:1.22
+  depends_on: [build]
+---
+kind: secret
+name: token
+---
+kind: pipeline
+steps:
+- name: deploy
+  image: alpine
+  detach: true
+`
+	planned, err := parsePlannedSteps([]byte(data))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string][]*types.Step{
+		"test (1.22)": {
+			{Number: 1, Name: "db", Status: enum.CIStatusPending, Image: "postgres", Detached: true},
+			{Number: 2, Name: "build", Status: enum.CIStatusPending, Image: "golang:1.22"},
+			{
+				Number: 3, Name: "test", Status: enum.CIStatusPending, Image: "golang:1.22",
+				DependsOn: []string{"build"},
+			},
+		},
+		"default": {
+			{Number: 1, Name: "deploy", Status: enum.CIStatusPending, Image: "alpine", Detached: true},
+		},
+	}, planned)
+}
+
+func TestBuildGraph(t *testing.T) {
+	stages := []*types.Stage{
+		{
+			Number: 1, Name: "build", Status: enum.CIStatusSuccess,
+			Steps: []*types.Step{
+				{Number: 1, Name: "clone", Status: enum.CIStatusSuccess},
+				{Number: 2, Name: "compile", Status: enum.CIStatusSuccess},
+				{Number: 3, Name: "vet", Status: enum.CIStatusSuccess, DependsOn: []string{"clone"}},
+				{Number: 4, Name: "report", Status: enum.CIStatusSuccess, DependsOn: []string{"compile", "vet"}},
+			},
+		},
+		{
+			Number: 2, Name: "test (linux)", Status: enum.CIStatusPending,
+			Matrix: map[string]string{"os": "linux"}, DependsOn: []string{"build", "unknown"},
+		},
+		{Number: 3, Name: "test (windows)", Status: enum.CIStatusPending, DependsOn: []string{"build"}},
+	}
+	planned := map[string][]*types.Step{
+		"test (linux)": {
+			{Number: 1, Name: "unit", Status: enum.CIStatusPending, Image: "golang"},
+			{Number: 2, Name: "e2e", Status: enum.CIStatusPending, Image: "golang"},
+		},
+	}
+
+	graph := buildGraph(stages, planned)
+
+	require.Equal(t, &types.ExecutionGraph{
+		Stages: []*types.ExecutionGraphStage{
+			{
+				Number: 1, Name: "build", Status: enum.CIStatusSuccess, DependsOn: []int64{},
+				Steps: []*types.ExecutionGraphStep{
+					{Number: 1, Name: "clone", Status: enum.CIStatusSuccess, DependsOn: []int64{}},
+					{Number: 2, Name: "compile", Status: enum.CIStatusSuccess, DependsOn: []int64{1}},
+					{Number: 3, Name: "vet", Status: enum.CIStatusSuccess, DependsOn: []int64{1}},
+					{Number: 4, Name: "report", Status: enum.CIStatusSuccess, DependsOn: []int64{2, 3}},
+				},
+			},
+			{
+				Number: 2, Name: "test (linux)", Status: enum.CIStatusPending,
+				Matrix: map[string]string{"os": "linux"}, DependsOn: []int64{1}, Planned: true,
+				Steps: []*types.ExecutionGraphStep{
+					{Number: 1, Name: "unit", Status: enum.CIStatusPending, Image: "golang", DependsOn: []int64{}},
+					{Number: 2, Name: "e2e", Status: enum.CIStatusPending, Image: "golang", DependsOn: []int64{1}},
+				},
+			},
+			{
+				Number: 3, Name: "test (windows)", Status: enum.CIStatusPending, DependsOn: []int64{1},
+				Steps: []*types.ExecutionGraphStep{},
+			},
+		},
+	}, graph)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// ListInputs returns the input parameters defined in the pipeline YAML on the branch,
+// that can be provided when an execution is created manually.
+func (c *Controller) ListInputs(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	branch string,
+) ([]*types.PipelineInput, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path,
+		pipelineIdentifier, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	if branch == "" {
+		branch = pipeline.DefaultBranch
+		if branch == "" {
+			branch = repo.DefaultBranch
+		}
+	}
+
+	commit, err := c.commitService.FindRef(ctx, repo, scm.ExpandRef(branch, "refs/heads"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	inputs, err := c.parseInputs(ctx, repo, pipeline, commit.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputs == nil {
+		inputs = []*types.PipelineInput{}
+	}
+
+	return inputs, nil
+}
+
+// resolveInputs validates the values of the input parameters against their definitions in the pipeline YAML
+// at the commit and completes them with the defaults.
+func (c *Controller) resolveInputs(
+	ctx context.Context,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	sha string,
+	values map[string]string,
+) (map[string]string, error) {
+	inputs, err := c.parseInputs(ctx, repo, pipeline, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	return triggerer.ResolveInputs(inputs, values, true)
+}
+
+func (c *Controller) parseInputs(
+	ctx context.Context,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	sha string,
+) ([]*types.PipelineInput, error) {
+	// templates don't support input parameters.
+	if converter.IsTemplate(pipeline.ConfigPath) {
+		return nil, nil
+	}
+
+	file, err := c.fileService.Get(ctx, repo, pipeline.ConfigPath, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline yaml: %w", err)
+	}
+
+	inputs, err := triggerer.ParseInputs(file.Data)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid input parameters in pipeline yaml: %s", err)
+	}
+
+	return inputs, nil
+}
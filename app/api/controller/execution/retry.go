@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RetryInput is used for retrying an execution.
+type RetryInput struct {
+	// FailedOnly retries only the failed and canceled stages of the execution,
+	// the results of its successful stages are reused.
+	FailedOnly bool `json:"failed_only"`
+}
+
+// Retry triggers a new execution of the commit of the execution with the same parameters.
+func (c *Controller) Retry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	in *RetryInput,
+) (*types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path,
+		pipelineIdentifier, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if !execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Only completed executions can be retried.")
+	}
+
+	retry, err := c.triggerer.Retry(ctx, pipeline, execution, &session.Principal, in.FailedOnly)
+	if errors.Is(err, triggerer.ErrNothingToRetry) {
+		return nil, usererror.BadRequest("The execution has no failed stages to retry.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry execution %d: %w", executionNum, err)
+	}
+
+	return retry, nil
+}
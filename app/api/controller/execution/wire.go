@@ -18,7 +18,11 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -41,7 +45,12 @@ func ProvideController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	fileService file.Service,
+	artifactService *pipelineartifact.Service,
+	converterService converter.Service,
+	publicAccess publicaccess.Service,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
+		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore, fileService, artifactService,
+		converterService, publicAccess)
 }
@@ -25,6 +25,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/replication"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,6 +50,7 @@ type Controller struct {
 	protectionManager   *protection.Manager
 	limiter             limiter.ResourceLimiter
 	settings            *settings.Service
+	replicationSvc      *replication.Service
 	preReceiveExtender  PreReceiveExtender
 	updateExtender      UpdateExtender
 	postReceiveExtender PostReceiveExtender
@@ -66,6 +68,7 @@ func NewController(
 	protectionManager *protection.Manager,
 	limiter limiter.ResourceLimiter,
 	settings *settings.Service,
+	replicationSvc *replication.Service,
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
@@ -82,6 +85,7 @@ func NewController(
 		protectionManager:   protectionManager,
 		limiter:             limiter,
 		settings:            settings,
+		replicationSvc:      replicationSvc,
 		preReceiveExtender:  preReceiveExtender,
 		updateExtender:      updateExtender,
 		postReceiveExtender: postReceiveExtender,
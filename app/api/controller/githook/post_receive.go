@@ -61,6 +61,12 @@ func (c *Controller) PostReceive(
 	// as the branch could be different than the configured default value.
 	c.handleEmptyRepoPush(ctx, repo, in.PostReceiveInput, &out)
 
+	// make the ref updates recorded by the pre-receive hook available to external replication tools.
+	// NOTE: the refs are already updated, records that fail here get reconciled by the replication cleanup.
+	if err := c.replicationSvc.MarkPackAvailable(ctx, repo, in.RefUpdates); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to mark reference updates as available for replication")
+	}
+
 	// report ref events if repo is in an active state (best effort)
 	if repo.State == enum.RepoStateActive {
 		c.reportReferenceEvents(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput)
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
@@ -47,7 +48,7 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
-	if !in.Internal && repo.State != enum.RepoStateActive && repo.State != enum.RepoStateMigrateGitPush {
+	if !isPushAllowedInState(repo.State, in.Internal) {
 		output.Error = ptr.String("Push not allowed in the current repository state")
 		return output, nil
 	}
@@ -70,6 +71,11 @@ func (c *Controller) PreReceive(
 
 	refUpdates := groupRefsByAction(in.RefUpdates, forced)
 
+	if repo.Archived && !isPushAllowedToArchived(refUpdates, in.Internal) {
+		output.Error = ptr.String("Push not allowed to an archived repository")
+		return output, nil
+	}
+
 	if slices.Contains(refUpdates.branches.deleted, repo.DefaultBranch) {
 		// Default branch mustn't be deleted.
 		output.Error = ptr.String(usererror.ErrDefaultBranchCantBeDeleted.Error())
@@ -125,9 +131,43 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
+	// record all ref updates for external replication tools, independent of the repo state.
+	// The push is rejected if they can't be recorded, so replicas never miss an update.
+	if err = c.replicationSvc.Record(ctx, repo, in.RefUpdates); err != nil {
+		return hook.Output{}, fmt.Errorf("failed to record reference updates for replication: %w", err)
+	}
+
 	return output, nil
 }
 
+// isPushAllowedInState returns true if the repository state allows pushes.
+// Internal pushes are needed to import and migrate repositories, but no writes are allowed
+// while the git repository is being moved to another storage pool.
+func isPushAllowedInState(state enum.RepoState, internal bool) bool {
+	switch state {
+	case enum.RepoStateActive, enum.RepoStateMigrateGitPush:
+		return true
+	case enum.RepoStateGitImport, enum.RepoStateMigrateDataImport:
+		return internal
+	case enum.RepoStateStorageMove:
+		return false
+	default:
+		return false
+	}
+}
+
+// isPushAllowedToArchived returns true if the reference updates are allowed in an archived repository.
+// Branches and tags of an archived repository can't be modified, not even by the application itself
+// (e.g. merges, commits or branch operations of services), only the internal pull request references
+// of the remaining pull requests are still maintained.
+func isPushAllowedToArchived(refUpdates changedRefs, internal bool) bool {
+	if !internal {
+		return false
+	}
+
+	return refUpdates.branches.isEmpty() && refUpdates.tags.isEmpty()
+}
+
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.RepoState) bool {
 	if state == enum.RepoStateMigrateGitPush {
 		return false
@@ -161,6 +201,7 @@ func (c *Controller) checkProtectionRules(
 	}
 
 	var ruleViolations []types.RuleViolations
+	var violatedBranches []string
 	var errCheckAction error
 
 	//nolint:unparam
@@ -184,6 +225,9 @@ func (c *Controller) checkProtectionRules(
 		}
 
 		ruleViolations = append(ruleViolations, violations...)
+		if len(violations) > 0 {
+			violatedBranches = append(violatedBranches, names...)
+		}
 	}
 
 	checkAction(protection.RefActionCreate, protection.RefTypeBranch, refUpdates.branches.created)
@@ -214,6 +258,16 @@ func (c *Controller) checkProtectionRules(
 		output.Error = ptr.String("Blocked by protection rules.")
 	}
 
+	if len(ruleViolations) > 0 {
+		c.repoReporter.RuleViolated(ctx, &eventsrepo.RuleViolatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: session.Principal.ID,
+			Branches:    violatedBranches,
+			Blocked:     criticalViolation,
+			Violations:  ruleViolations,
+		})
+	}
+
 	return nil
 }
 
@@ -241,6 +295,10 @@ func (c *changes) groupByAction(
 	}
 }
 
+func (c *changes) isEmpty() bool {
+	return len(c.created) == 0 && len(c.deleted) == 0 && len(c.updated) == 0 && len(c.forced) == 0
+}
+
 type changedRefs struct {
 	branches changes
 	tags     changes
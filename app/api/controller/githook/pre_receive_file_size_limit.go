@@ -46,10 +46,10 @@ func (c *Controller) checkFileSizeLimit(
 		return nil
 	}
 
-	sizeLimit, err := settings.RepoGet(
+	sizeLimit, err := settings.RepoGetInherited(
 		ctx,
 		c.settings,
-		repo.ID,
+		repo,
 		settings.KeyFileSizeLimit,
 		settings.DefaultFileSizeLimit,
 	)
@@ -42,10 +42,10 @@ func (c *Controller) scanSecrets(
 	output *hook.Output,
 ) error {
 	// check if scanning is enabled on the repo
-	scanningEnabled, err := settings.RepoGet(
+	scanningEnabled, err := settings.RepoGetInherited(
 		ctx,
 		c.settings,
-		repo.ID,
+		repo,
 		settings.KeySecretScanningEnabled,
 		settings.DefaultSecretScanningEnabled,
 	)
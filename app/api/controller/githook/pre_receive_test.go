@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestIsPushAllowedInState(t *testing.T) {
+	tests := []struct {
+		state        enum.RepoState
+		wantExternal bool
+		wantInternal bool
+	}{
+		{state: enum.RepoStateActive, wantExternal: true, wantInternal: true},
+		{state: enum.RepoStateMigrateGitPush, wantExternal: true, wantInternal: true},
+		{state: enum.RepoStateGitImport, wantExternal: false, wantInternal: true},
+		{state: enum.RepoStateMigrateDataImport, wantExternal: false, wantInternal: true},
+		{state: enum.RepoStateStorageMove, wantExternal: false, wantInternal: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.state.String(), func(t *testing.T) {
+			if got := isPushAllowedInState(test.state, false); got != test.wantExternal {
+				t.Errorf("external push allowed = %t, want %t", got, test.wantExternal)
+			}
+			if got := isPushAllowedInState(test.state, true); got != test.wantInternal {
+				t.Errorf("internal push allowed = %t, want %t", got, test.wantInternal)
+			}
+		})
+	}
+}
+
+func TestIsPushAllowedToArchived(t *testing.T) {
+	oldSHA := sha.Must("1111111111111111111111111111111111111111")
+	newSHA := sha.Must("2222222222222222222222222222222222222222")
+
+	tests := []struct {
+		name        string
+		refUpdates  []hook.ReferenceUpdate
+		internal    bool
+		wantAllowed bool
+	}{
+		{
+			name:       "external branch update",
+			refUpdates: []hook.ReferenceUpdate{{Ref: "refs/heads/main", Old: oldSHA, New: newSHA}},
+		},
+		{
+			name:       "internal branch update",
+			refUpdates: []hook.ReferenceUpdate{{Ref: "refs/heads/main", Old: oldSHA, New: newSHA}},
+			internal:   true,
+		},
+		{
+			name:       "internal branch creation",
+			refUpdates: []hook.ReferenceUpdate{{Ref: "refs/heads/feature", Old: sha.Nil, New: newSHA}},
+			internal:   true,
+		},
+		{
+			name:       "internal tag deletion",
+			refUpdates: []hook.ReferenceUpdate{{Ref: "refs/tags/v1.0.0", Old: oldSHA, New: sha.Nil}},
+			internal:   true,
+		},
+		{
+			name:       "external pull request ref update",
+			refUpdates: []hook.ReferenceUpdate{{Ref: "refs/pullreq/1/head", Old: oldSHA, New: newSHA}},
+		},
+		{
+			name:        "internal pull request ref update",
+			refUpdates:  []hook.ReferenceUpdate{{Ref: "refs/pullreq/1/merge", Old: oldSHA, New: newSHA}},
+			internal:    true,
+			wantAllowed: true,
+		},
+		{
+			name: "internal pull request and branch update",
+			refUpdates: []hook.ReferenceUpdate{
+				{Ref: "refs/pullreq/1/head", Old: oldSHA, New: newSHA},
+				{Ref: "refs/heads/main", Old: oldSHA, New: newSHA},
+			},
+			internal: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refUpdates := groupRefsByAction(test.refUpdates, make([]bool, len(test.refUpdates)))
+			if got := isPushAllowedToArchived(refUpdates, test.internal); got != test.wantAllowed {
+				t.Errorf("push allowed = %t, want %t", got, test.wantAllowed)
+			}
+		})
+	}
+}
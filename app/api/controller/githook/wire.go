@@ -20,6 +20,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/replication"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -53,6 +54,7 @@ func ProvideController(
 	githookFactory hook.ClientFactory,
 	limiter limiter.ResourceLimiter,
 	settings *settings.Service,
+	replicationSvc *replication.Service,
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
@@ -69,6 +71,7 @@ func ProvideController(
 		protectionManager,
 		limiter,
 		settings,
+		replicationSvc,
 		preReceiveExtender,
 		updateExtender,
 		postReceiveExtender,
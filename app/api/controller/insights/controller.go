@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxWeeks        = 5 * 52
+	maxContributors = 100
+)
+
+type Controller struct {
+	authorizer  authz.Authorizer
+	repoStore   store.RepoStore
+	insightsSvc *insights.Service
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	insightsSvc *insights.Service,
+) *Controller {
+	return &Controller{
+		authorizer:  authorizer,
+		repoStore:   repoStore,
+		insightsSvc: insightsSvc,
+	}
+}
+
+// Get returns the commit activity, the top contributors and the pull request throughput of a repository.
+// The statistics are computed in the background, so they might not include the most recent changes.
+func (c *Controller) Get(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter types.RepoInsightsFilter,
+) (*types.RepoInsights, error) {
+	if filter.Weeks > maxWeeks {
+		return nil, usererror.BadRequestf("The number of weeks can't exceed %d.", maxWeeks)
+	}
+
+	if filter.Contributors > maxContributors {
+		return nil, usererror.BadRequestf("The number of contributors can't exceed %d.", maxContributors)
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return c.insightsSvc.Get(ctx, repo, filter)
+}
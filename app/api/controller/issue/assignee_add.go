@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AssigneeAddInput struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// AssigneeAdd assigns a user to an issue.
+func (c *Controller) AssigneeAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *AssigneeAddInput,
+) (*types.Issue, error) {
+	if in.AssigneeID <= 0 {
+		return nil, usererror.BadRequest("Must specify the assignee.")
+	}
+
+	_, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, err
+	}
+
+	assignee, err := c.principalStore.Find(ctx, in.AssigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assignee: %w", err)
+	}
+
+	if assignee.Type != enum.PrincipalTypeUser {
+		return nil, usererror.BadRequest("Only users can be assigned to issues.")
+	}
+
+	err = c.issueAssigneeStore.Assign(ctx, issue.ID, assignee.ID, session.Principal.ID, time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign user to issue: %w", err)
+	}
+
+	if err = c.backfillDetails(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AssigneeDelete removes a user from the assignees of an issue.
+func (c *Controller) AssigneeDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	assigneeID int64,
+) error {
+	_, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoReview)
+	if err != nil {
+		return err
+	}
+
+	if err = c.issueAssigneeStore.Unassign(ctx, issue.ID, assigneeID); err != nil {
+		return fmt.Errorf("failed to unassign user from issue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentCreateInput struct {
+	Text string `json:"text"`
+}
+
+func (in *CommentCreateInput) Sanitize() error {
+	in.Text = strings.TrimSpace(in.Text)
+
+	return validateComment(in.Text)
+}
+
+// CommentCreate creates a new comment on an issue.
+func (c *Controller) CommentCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *CommentCreateInput,
+) (*types.IssueComment, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, err
+	}
+
+	in.Text, err = c.attachmentSvc.ProcessMarkdown(ctx, repo.ID, in.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	comment := &types.IssueComment{
+		IssueID:   issue.ID,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Text:      in.Text,
+		Author:    *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.issueCommentStore.Create(ctx, comment); err != nil {
+			return fmt.Errorf("failed to create issue comment: %w", err)
+		}
+
+		_, err := c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+			issue.CommentCount++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue comment count: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.eventReporter.IssueCommentCreated(ctx, &repoevents.IssueCommentCreatedPayload{
+		IssueBase: repoevents.IssueBase{
+			RepoID:      issue.RepoID,
+			PrincipalID: session.Principal.ID,
+			IssueID:     issue.ID,
+			IssueNumber: issue.Number,
+		},
+		CommentID: comment.ID,
+	})
+
+	return comment, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// CommentDelete marks an issue comment as deleted. Only the author of the comment can delete it.
+func (c *Controller) CommentDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+) error {
+	_, comment, err := c.getCommentOfAuthor(ctx, session, repoRef, issueNum, commentID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	comment.Deleted = &now
+	comment.Text = ""
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.issueCommentStore.Update(ctx, comment); err != nil {
+			return fmt.Errorf("failed to delete issue comment: %w", err)
+		}
+
+		issue, err := c.issueStore.Find(ctx, comment.IssueID)
+		if err != nil {
+			return fmt.Errorf("failed to find issue: %w", err)
+		}
+
+		_, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+			issue.CommentCount--
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue comment count: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
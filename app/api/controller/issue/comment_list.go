@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommentList returns the comments of an issue, in the order they were created.
+func (c *Controller) CommentList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	page, size int,
+) ([]*types.IssueComment, error) {
+	_, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := c.issueCommentStore.List(ctx, issue.ID, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue comments: %w", err)
+	}
+
+	return comments, nil
+}
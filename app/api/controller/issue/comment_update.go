@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentUpdateInput struct {
+	Text string `json:"text"`
+}
+
+func (in *CommentUpdateInput) Sanitize() error {
+	in.Text = strings.TrimSpace(in.Text)
+
+	return validateComment(in.Text)
+}
+
+// CommentUpdate updates the text of an issue comment. Only the author of the comment can change it.
+func (c *Controller) CommentUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+	in *CommentUpdateInput,
+) (*types.IssueComment, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, comment, err := c.getCommentOfAuthor(ctx, session, repoRef, issueNum, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Text, err = c.attachmentSvc.ProcessMarkdown(ctx, repo.ID, in.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
+	if err = c.issueCommentStore.Update(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to update issue comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (c *Controller) getCommentOfAuthor(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+) (*types.Repository, *types.IssueComment, error) {
+	repo, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment, err := c.issueCommentStore.Find(ctx, commentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find issue comment: %w", err)
+	}
+
+	if comment.IssueID != issue.ID {
+		return nil, nil, usererror.BadRequest("The comment doesn't belong to the issue.")
+	}
+
+	if comment.Deleted != nil {
+		return nil, nil, usererror.BadRequest("Can't modify a deleted comment.")
+	}
+
+	if comment.CreatedBy != session.Principal.ID {
+		return nil, nil, usererror.Forbidden("Only the author of the comment can modify it.")
+	}
+
+	return repo, comment, nil
+}
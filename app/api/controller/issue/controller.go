@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/attachment"
+	"github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	issueStore         store.IssueStore
+	issueCommentStore  store.IssueCommentStore
+	issueAssigneeStore store.IssueAssigneeStore
+	issueSvc           *issue.Service
+	labelSvc           *label.Service
+	eventReporter      *repoevents.Reporter
+	attachmentSvc      *attachment.Service
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	issueStore store.IssueStore,
+	issueCommentStore store.IssueCommentStore,
+	issueAssigneeStore store.IssueAssigneeStore,
+	issueSvc *issue.Service,
+	labelSvc *label.Service,
+	eventReporter *repoevents.Reporter,
+	attachmentSvc *attachment.Service,
+) *Controller {
+	return &Controller{
+		tx:                 tx,
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		issueStore:         issueStore,
+		issueCommentStore:  issueCommentStore,
+		issueAssigneeStore: issueAssigneeStore,
+		issueSvc:           issueSvc,
+		labelSvc:           labelSvc,
+		eventReporter:      eventReporter,
+		attachmentSvc:      attachmentSvc,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) getIssueCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, issueNum int64, reqPermission enum.Permission,
+) (*types.Repository, *types.Issue, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	return repo, issue, nil
+}
+
+// checkAuthorOrPush verifies that the principal is either the author of the resource
+// or is allowed to push to the repository.
+func (c *Controller) checkAuthorOrPush(ctx context.Context,
+	session *auth.Session, repo *types.Repository, authorID int64,
+) error {
+	if session.Principal.ID == authorID {
+		return nil
+	}
+
+	if err := apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+		return fmt.Errorf("access check failed: %w", err)
+	}
+
+	return nil
+}
+
+// backfillDetails sets the assignees and the labels of the issue.
+func (c *Controller) backfillDetails(ctx context.Context, issue *types.Issue) error {
+	assigneeIDs, err := c.issueAssigneeStore.ListIDs(ctx, issue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list issue assignees: %w", err)
+	}
+
+	infoMap, err := c.principalInfoCache.Map(ctx, assigneeIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load issue assignee infos: %w", err)
+	}
+
+	issue.Assignees = make([]*types.PrincipalInfo, 0, len(assigneeIDs))
+	for _, id := range assigneeIDs {
+		if info, ok := infoMap[id]; ok {
+			issue.Assignees = append(issue.Assignees, info)
+		}
+	}
+
+	issue.Labels, err = c.labelSvc.ListIssueLabels(ctx, issue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list issue labels: %w", err)
+	}
+
+	return nil
+}
+
+func validateTitle(title string) error {
+	if title == "" {
+		return usererror.BadRequest("issue title can't be empty")
+	}
+
+	const maxLen = 256
+	if utf8.RuneCountInString(title) > maxLen {
+		return usererror.BadRequestf("issue title is too long (maximum is %d characters)", maxLen)
+	}
+
+	return nil
+}
+
+func validateDescription(desc string) error {
+	const maxLen = 64 << 10 // 64K
+	if len(desc) > maxLen {
+		return usererror.BadRequest("issue description is too long")
+	}
+
+	return nil
+}
+
+func validateComment(text string) error {
+	if text == "" {
+		return usererror.BadRequest("issue comment can't be empty")
+	}
+
+	const maxLen = 16 << 10 // 16K
+	if len(text) > maxLen {
+		return usererror.BadRequest("issue comment is too long")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (in *CreateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	if err := validateDescription(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Create creates a new issue.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	in.Description, err = c.attachmentSvc.ProcessMarkdown(ctx, repo.ID, in.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
+	issue, err := c.issueSvc.Create(ctx, repo, session.Principal.ID, in.Title, in.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Find returns an issue with its assignees and labels.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+) (*types.Issue, error) {
+	_, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.backfillDetails(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
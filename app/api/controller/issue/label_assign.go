@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AssignLabel assigns a label to an issue.
+func (c *Controller) AssignLabel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *types.PullReqCreateInput,
+) (*types.IssueLabel, error) {
+	if err := in.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate input: %w", err)
+	}
+
+	repo, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, err
+	}
+
+	issueLabel, err := c.labelSvc.AssignToIssue(ctx, session.Principal.ID, issue.ID, repo.ID, repo.ParentID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign label to issue: %w", err)
+	}
+
+	return issueLabel, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := validateTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := validateDescription(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates the title and the description of an issue.
+// Only the author of the issue and the users who can push to the repository are allowed to do it.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *UpdateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, issue, err := c.getIssueCheckAccess(ctx, session, repoRef, issueNum, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.checkAuthorOrPush(ctx, session, repo, issue.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	if in.Description != nil {
+		var description string
+		description, err = c.attachmentSvc.ProcessMarkdown(ctx, repo.ID, *in.Description)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process attachments: %w", err)
+		}
+		in.Description = &description
+	}
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		if in.Title != nil {
+			issue.Title = *in.Title
+		}
+		if in.Description != nil {
+			issue.Description = *in.Description
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	return issue, nil
+}
@@ -19,25 +19,38 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
 )
 
 type Controller struct {
-	authorizer authz.Authorizer
-	repoCtrl   *repo.Controller
-	searcher   keywordsearch.Searcher
-	spaceCtrl  *space.Controller
+	authorizer     authz.Authorizer
+	repoCtrl       *repo.Controller
+	searcher       keywordsearch.Searcher
+	repoStore      store.RepoStore
+	spaceStore     store.SpaceStore
+	pullReqStore   store.PullReqStore
+	principalStore store.PrincipalStore
+	spaceCtrl      *space.Controller
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	searcher keywordsearch.Searcher,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	pullReqStore store.PullReqStore,
+	principalStore store.PrincipalStore,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
 ) *Controller {
 	return &Controller{
-		authorizer: authorizer,
-		searcher:   searcher,
-		repoCtrl:   repoCtrl,
-		spaceCtrl:  spaceCtrl,
+		authorizer:     authorizer,
+		searcher:       searcher,
+		repoStore:      repoStore,
+		spaceStore:     spaceStore,
+		pullReqStore:   pullReqStore,
+		principalStore: principalStore,
+		repoCtrl:       repoCtrl,
+		spaceCtrl:      spaceCtrl,
 	}
 }
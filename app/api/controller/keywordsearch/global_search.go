@@ -0,0 +1,306 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// globalSearchPageSize is the number of candidates loaded at once while looking for visible results.
+	globalSearchPageSize = 50
+
+	// globalSearchMaxPages limits the number of candidate pages loaded per result type,
+	// so that a query matching mostly inaccessible resources doesn't scan the whole table.
+	globalSearchMaxPages = 5
+)
+
+// GlobalSearch searches repositories, spaces, pull requests, users and code with a single query.
+// Every result type is searched independently and only results visible to the caller are returned.
+func (c *Controller) GlobalSearch(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) (*types.GlobalSearchResult, error) {
+	if filter.Query == "" {
+		return nil, usererror.BadRequest("query cannot be empty.")
+	}
+
+	result := &types.GlobalSearchResult{
+		Repositories: []*types.Repository{},
+		Spaces:       []*types.Space{},
+		PullReqs:     []types.PullReqRepo{},
+		Users:        []*types.PrincipalInfo{},
+		Code:         []types.FileMatch{},
+	}
+
+	var err error
+
+	if searchesType(filter, enum.SearchResultTypeRepository) {
+		result.Repositories, err = c.searchRepos(ctx, session, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search repositories: %w", err)
+		}
+	}
+
+	if searchesType(filter, enum.SearchResultTypeSpace) {
+		result.Spaces, err = c.searchSpaces(ctx, session, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search spaces: %w", err)
+		}
+	}
+
+	if searchesType(filter, enum.SearchResultTypePullReq) {
+		result.PullReqs, err = c.searchPullReqs(ctx, session, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search pull requests: %w", err)
+		}
+	}
+
+	if searchesType(filter, enum.SearchResultTypeUser) {
+		result.Users, err = c.searchUsers(ctx, session, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+	}
+
+	if searchesType(filter, enum.SearchResultTypeCode) {
+		codeResult, err := c.SearchCode(ctx, session, &types.CodeSearchFilter{
+			Query:      filter.Query,
+			IgnoreCase: true,
+			Limit:      filter.Limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search code: %w", err)
+		}
+
+		result.Code = codeResult.FileMatches
+	}
+
+	return result, nil
+}
+
+func searchesType(filter *types.GlobalSearchFilter, t enum.SearchResultType) bool {
+	return len(filter.Types) == 0 || slices.Contains(filter.Types, t)
+}
+
+func (c *Controller) searchRepos(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) ([]*types.Repository, error) {
+	result := make([]*types.Repository, 0, filter.Limit)
+
+	for page := 1; page <= globalSearchMaxPages; page++ {
+		repos, err := c.repoStore.Search(ctx, &types.RepoFilter{
+			Page:  page,
+			Size:  globalSearchPageSize,
+			Query: filter.Query,
+			Sort:  enum.RepoAttrIdentifier,
+			Order: enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+			if errors.Is(err, apiauth.ErrNotAuthorized) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to check access to repository: %w", err)
+			}
+
+			result = append(result, repo)
+			if len(result) >= filter.Limit {
+				return result, nil
+			}
+		}
+
+		if len(repos) < globalSearchPageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Controller) searchSpaces(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) ([]*types.Space, error) {
+	result := make([]*types.Space, 0, filter.Limit)
+
+	for page := 1; page <= globalSearchMaxPages; page++ {
+		spaces, err := c.spaceStore.Search(ctx, &types.SpaceFilter{
+			Page:  page,
+			Size:  globalSearchPageSize,
+			Query: filter.Query,
+			Sort:  enum.SpaceAttrIdentifier,
+			Order: enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search spaces: %w", err)
+		}
+
+		for _, space := range spaces {
+			err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView)
+			if errors.Is(err, apiauth.ErrNotAuthorized) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to check access to space: %w", err)
+			}
+
+			result = append(result, space)
+			if len(result) >= filter.Limit {
+				return result, nil
+			}
+		}
+
+		if len(spaces) < globalSearchPageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// searchPullReqs returns the most recently updated pull requests with matching titles
+// that target repositories the caller can read.
+func (c *Controller) searchPullReqs(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) ([]types.PullReqRepo, error) {
+	result := make([]types.PullReqRepo, 0, filter.Limit)
+
+	// nil value marks repositories that don't exist or that the caller can't read.
+	repoMap := make(map[int64]*types.Repository)
+
+	for page := 1; page <= globalSearchMaxPages; page++ {
+		pullReqs, err := c.pullReqStore.List(ctx, &types.PullReqFilter{
+			Page:  page,
+			Size:  globalSearchPageSize,
+			Query: filter.Query,
+			Sort:  enum.PullReqSortUpdated,
+			Order: enum.OrderDesc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search pull requests: %w", err)
+		}
+
+		for _, pullReq := range pullReqs {
+			repo, checked := repoMap[pullReq.TargetRepoID]
+			if !checked {
+				repo, err = c.findReadableRepo(ctx, session, pullReq.TargetRepoID)
+				if err != nil {
+					return nil, err
+				}
+				repoMap[pullReq.TargetRepoID] = repo
+			}
+
+			if repo == nil {
+				continue
+			}
+
+			result = append(result, types.PullReqRepo{
+				PullRequest: pullReq,
+				Repository:  repo,
+			})
+			if len(result) >= filter.Limit {
+				return result, nil
+			}
+		}
+
+		if len(pullReqs) < globalSearchPageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// findReadableRepo returns the repository if it exists and the caller can read it, otherwise it returns nil.
+func (c *Controller) findReadableRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+) (*types.Repository, error) {
+	repo, err := c.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check access to repository: %w", err)
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) searchUsers(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) ([]*types.PrincipalInfo, error) {
+	err := apiauth.Check(ctx, c.authorizer, session,
+		&types.Scope{},
+		&types.Resource{Type: enum.ResourceTypeUser},
+		enum.PermissionUserView,
+	)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return []*types.PrincipalInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check access to users: %w", err)
+	}
+
+	principals, err := c.principalStore.List(ctx, &types.PrincipalFilter{
+		Page:  1,
+		Size:  filter.Limit,
+		Query: filter.Query,
+		Types: []enum.PrincipalType{enum.PrincipalTypeUser},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*types.PrincipalInfo, len(principals))
+	for i := range principals {
+		users[i] = principals[i].ToPrincipalInfo()
+	}
+
+	return users, nil
+}
@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeAuthorizer denies access to the listed resources, identified by the resource type and identifier,
+// and to the listed resource types.
+type fakeAuthorizer struct {
+	deniedResources map[types.Resource]bool
+	deniedTypes     map[enum.ResourceType]bool
+}
+
+func (a *fakeAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	resource *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return !a.deniedTypes[resource.Type] && !a.deniedResources[*resource], nil
+}
+
+func (a *fakeAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos    []*types.Repository
+	searches int
+}
+
+func (s *fakeRepoStore) Search(_ context.Context, filter *types.RepoFilter) ([]*types.Repository, error) {
+	s.searches++
+	return page(s.repos, filter.Page, filter.Size), nil
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	for _, repo := range s.repos {
+		if repo.ID == id {
+			return repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces []*types.Space
+}
+
+func (s *fakeSpaceStore) Search(_ context.Context, filter *types.SpaceFilter) ([]*types.Space, error) {
+	return page(s.spaces, filter.Page, filter.Size), nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s *fakePullReqStore) List(_ context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	return page(s.prs, filter.Page, filter.Size), nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals []*types.Principal
+}
+
+func (s *fakePrincipalStore) List(_ context.Context, filter *types.PrincipalFilter) ([]*types.Principal, error) {
+	return page(s.principals, filter.Page, filter.Size), nil
+}
+
+func page[T any](items []T, page, size int) []T {
+	first := min((page-1)*size, len(items))
+	last := min(first+size, len(items))
+	return items[first:last]
+}
+
+func newTestRepos(n int) []*types.Repository {
+	repos := make([]*types.Repository, n)
+	for i := range repos {
+		repos[i] = &types.Repository{
+			ID:         int64(i + 1),
+			Identifier: fmt.Sprintf("repo-%d", i+1),
+			Path:       fmt.Sprintf("space/repo-%d", i+1),
+		}
+	}
+	return repos
+}
+
+func repoResource(repo *types.Repository) types.Resource {
+	return types.Resource{Type: enum.ResourceTypeRepo, Identifier: repo.Identifier}
+}
+
+var testSession = &auth.Session{Principal: types.Principal{ID: 1, Type: enum.PrincipalTypeUser}}
+
+func TestController_GlobalSearchEmptyQuery(t *testing.T) {
+	c := &Controller{}
+
+	_, err := c.GlobalSearch(context.Background(), testSession, &types.GlobalSearchFilter{Limit: 10})
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request, got %v", err)
+	}
+}
+
+func TestController_GlobalSearchRepositories(t *testing.T) {
+	repos := newTestRepos(3 * globalSearchPageSize)
+
+	// the whole first page is hidden from the caller.
+	denied := make(map[types.Resource]bool)
+	for _, repo := range repos[:globalSearchPageSize] {
+		denied[repoResource(repo)] = true
+	}
+
+	repoStore := &fakeRepoStore{repos: repos}
+	c := &Controller{
+		authorizer: &fakeAuthorizer{deniedResources: denied},
+		repoStore:  repoStore,
+	}
+
+	result, err := c.GlobalSearch(context.Background(), testSession, &types.GlobalSearchFilter{
+		Query: "repo",
+		Types: []enum.SearchResultType{enum.SearchResultTypeRepository},
+		Limit: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := repos[globalSearchPageSize : globalSearchPageSize+2]
+	if !reflect.DeepEqual(result.Repositories, want) {
+		t.Errorf("repositories = %v, want %v", result.Repositories, want)
+	}
+	if repoStore.searches != 2 {
+		t.Errorf("expected 2 pages to be searched, got %d", repoStore.searches)
+	}
+
+	// the other result types aren't searched, but are returned as empty lists.
+	if result.Spaces == nil || result.PullReqs == nil || result.Users == nil || result.Code == nil {
+		t.Errorf("expected empty lists for the result types that aren't searched, got %+v", result)
+	}
+}
+
+func TestController_GlobalSearchMaxPages(t *testing.T) {
+	repos := newTestRepos((globalSearchMaxPages + 2) * globalSearchPageSize)
+
+	denied := make(map[types.Resource]bool)
+	for _, repo := range repos {
+		denied[repoResource(repo)] = true
+	}
+
+	repoStore := &fakeRepoStore{repos: repos}
+	c := &Controller{
+		authorizer: &fakeAuthorizer{deniedResources: denied},
+		repoStore:  repoStore,
+	}
+
+	result, err := c.GlobalSearch(context.Background(), testSession, &types.GlobalSearchFilter{
+		Query: "repo",
+		Types: []enum.SearchResultType{enum.SearchResultTypeRepository},
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Repositories) != 0 {
+		t.Errorf("expected no visible repositories, got %d", len(result.Repositories))
+	}
+	if repoStore.searches != globalSearchMaxPages {
+		t.Errorf("expected %d pages to be searched, got %d", globalSearchMaxPages, repoStore.searches)
+	}
+}
+
+func TestController_GlobalSearchSpaces(t *testing.T) {
+	spaces := []*types.Space{
+		{ID: 1, Identifier: "visible", Path: "visible"},
+		{ID: 2, Identifier: "hidden", Path: "hidden"},
+	}
+
+	c := &Controller{
+		authorizer: &fakeAuthorizer{deniedResources: map[types.Resource]bool{
+			{Type: enum.ResourceTypeSpace, Identifier: "hidden"}: true,
+		}},
+		spaceStore: &fakeSpaceStore{spaces: spaces},
+	}
+
+	result, err := c.GlobalSearch(context.Background(), testSession, &types.GlobalSearchFilter{
+		Query: "space",
+		Types: []enum.SearchResultType{enum.SearchResultTypeSpace},
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := spaces[:1]; !reflect.DeepEqual(result.Spaces, want) {
+		t.Errorf("spaces = %v, want %v", result.Spaces, want)
+	}
+}
+
+func TestController_GlobalSearchPullReqs(t *testing.T) {
+	repos := newTestRepos(2)
+
+	// the first repository is visible, the second is hidden and the third one doesn't exist.
+	prs := []*types.PullReq{
+		{ID: 1, TargetRepoID: 1},
+		{ID: 2, TargetRepoID: 2},
+		{ID: 3, TargetRepoID: 3},
+		{ID: 4, TargetRepoID: 1},
+	}
+
+	c := &Controller{
+		authorizer:   &fakeAuthorizer{deniedResources: map[types.Resource]bool{repoResource(repos[1]): true}},
+		repoStore:    &fakeRepoStore{repos: repos},
+		pullReqStore: &fakePullReqStore{prs: prs},
+	}
+
+	result, err := c.GlobalSearch(context.Background(), testSession, &types.GlobalSearchFilter{
+		Query: "fix",
+		Types: []enum.SearchResultType{enum.SearchResultTypePullReq},
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []types.PullReqRepo{
+		{PullRequest: prs[0], Repository: repos[0]},
+		{PullRequest: prs[3], Repository: repos[0]},
+	}
+	if !reflect.DeepEqual(result.PullReqs, want) {
+		t.Errorf("pull requests = %v, want %v", result.PullReqs, want)
+	}
+}
+
+func TestController_GlobalSearchUsers(t *testing.T) {
+	principals := []*types.Principal{
+		{ID: 1, UID: "john", Type: enum.PrincipalTypeUser},
+		{ID: 2, UID: "johnny", Type: enum.PrincipalTypeUser},
+	}
+
+	tests := []struct {
+		name      string
+		authz     *fakeAuthorizer
+		wantUsers int
+	}{
+		{
+			name:      "allowed",
+			authz:     &fakeAuthorizer{},
+			wantUsers: 2,
+		},
+		{
+			name:      "not allowed to view users",
+			authz:     &fakeAuthorizer{deniedTypes: map[enum.ResourceType]bool{enum.ResourceTypeUser: true}},
+			wantUsers: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				authorizer:     test.authz,
+				principalStore: &fakePrincipalStore{principals: principals},
+			}
+
+			result, err := c.GlobalSearch(context.Background(), testSession, &types.GlobalSearchFilter{
+				Query: "john",
+				Types: []enum.SearchResultType{enum.SearchResultTypeUser},
+				Limit: 10,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(result.Users) != test.wantUsers {
+				t.Errorf("expected %d users, got %d", test.wantUsers, len(result.Users))
+			}
+		})
+	}
+}
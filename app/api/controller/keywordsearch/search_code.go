@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SearchCode searches the code of default branches of all indexed repositories the caller can read.
+func (c *Controller) SearchCode(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.CodeSearchFilter,
+) (types.SearchResult, error) {
+	if filter.Query == "" {
+		return types.SearchResult{}, usererror.BadRequest("query cannot be empty.")
+	}
+
+	if filter.Regex {
+		if _, err := regexp.Compile(filter.Query); err != nil {
+			return types.SearchResult{}, usererror.BadRequestf("invalid regular expression: %s", err)
+		}
+	}
+
+	repoIDToPathMap, err := c.getReadableIndexedRepos(ctx, session, strings.Trim(filter.Repo, "/"))
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	if len(repoIDToPathMap) == 0 {
+		return types.SearchResult{FileMatches: []types.FileMatch{}}, nil
+	}
+
+	repoIDs := make([]int64, 0, len(repoIDToPathMap))
+	for repoID := range repoIDToPathMap {
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	result, err := c.searcher.SearchCode(ctx, repoIDs, filter)
+	if err != nil {
+		return types.SearchResult{}, fmt.Errorf("failed to search code: %w", err)
+	}
+
+	for idx, fileMatch := range result.FileMatches {
+		result.FileMatches[idx].RepoPath = repoIDToPathMap[fileMatch.RepoID]
+	}
+
+	return result, nil
+}
+
+// getReadableIndexedRepos returns paths of all indexed repositories that the user can read,
+// optionally restricted to a repository path or to repositories under a space path.
+func (c *Controller) getReadableIndexedRepos(
+	ctx context.Context,
+	session *auth.Session,
+	pathFilter string,
+) (map[int64]string, error) {
+	repoIDs, err := c.searcher.IndexedRepoIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed repositories: %w", err)
+	}
+
+	repoIDToPathMap := make(map[int64]string)
+	for _, repoID := range repoIDs {
+		repo, err := c.repoStore.Find(ctx, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		if pathFilter != "" && !strings.EqualFold(repo.Path, pathFilter) &&
+			!strings.HasPrefix(strings.ToLower(repo.Path), strings.ToLower(pathFilter)+"/") {
+			continue
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check access to repository: %w", err)
+		}
+
+		repoIDToPathMap[repo.ID] = repo.Path
+	}
+
+	return repoIDToPathMap, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
 )
@@ -31,8 +32,13 @@ var WireSet = wire.NewSet(
 func ProvideController(
 	authorizer authz.Authorizer,
 	searcher keywordsearch.Searcher,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	pullReqStore store.PullReqStore,
+	principalStore store.PrincipalStore,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
 ) *Controller {
-	return NewController(authorizer, searcher, repoCtrl, spaceCtrl)
+	return NewController(authorizer, searcher, repoStore, spaceStore, pullReqStore, principalStore,
+		repoCtrl, spaceCtrl)
 }
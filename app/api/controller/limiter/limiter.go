@@ -22,6 +22,7 @@ import (
 
 var ErrMaxNumReposReached = errors.New("maximum number of repositories reached")
 var ErrMaxRepoSizeReached = errors.New("maximum size of repository reached")
+var ErrMaxPipelineMinutesReached = errors.New("maximum number of pipeline minutes reached")
 
 // ResourceLimiter is an interface for managing resource limitation.
 type ResourceLimiter interface {
@@ -30,6 +31,9 @@ type ResourceLimiter interface {
 
 	// RepoSize allows repository growth up to a limit for the given repoID.
 	RepoSize(ctx context.Context, repoID int64) error
+
+	// PipelineMinutes allows pipeline executions in the given repoID up to a limit of execution minutes.
+	PipelineMinutes(ctx context.Context, repoID int64) error
 }
 
 var _ ResourceLimiter = Unlimited{}
@@ -49,3 +53,7 @@ func (Unlimited) RepoCount(context.Context, int64, int) error {
 func (Unlimited) RepoSize(context.Context, int64) error {
 	return nil
 }
+
+func (Unlimited) PipelineMinutes(context.Context, int64) error {
+	return nil
+}
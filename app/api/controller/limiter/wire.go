@@ -15,6 +15,8 @@
 package limiter
 
 import (
+	"github.com/harness/gitness/app/services/quota"
+
 	"github.com/google/wire"
 )
 
@@ -23,8 +25,9 @@ var WireSet = wire.NewSet(
 	ProvideGitspaceLimiter,
 )
 
-func ProvideLimiter() (ResourceLimiter, error) {
-	return NewResourceLimiter(), nil
+// ProvideLimiter provides a resource limiter that enforces the quotas of the spaces.
+func ProvideLimiter(quotaSvc *quota.Service) (ResourceLimiter, error) {
+	return quotaSvc, nil
 }
 
 func ProvideGitspaceLimiter() Gitspace {
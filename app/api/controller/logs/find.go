@@ -64,7 +64,12 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to find step: %w", err)
 	}
 
-	rc, err := c.logStore.Find(ctx, step.ID)
+	return c.readLogs(ctx, step.ID)
+}
+
+// readLogs reads the persisted log lines of the step.
+func (c *Controller) readLogs(ctx context.Context, stepID int64) ([]*livelog.Line, error) {
+	rc, err := c.logStore.Find(ctx, stepID)
 	if err != nil {
 		return nil, fmt.Errorf("could not find logs: %w", err)
 	}
@@ -24,6 +24,9 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// Tail streams the log lines of the step, starting at the line at the offset position.
+// While the step is running its live log is streamed, once it's complete its persisted log.
+// Nil channels are returned if the step didn't start yet.
 func (c *Controller) Tail(
 	ctx context.Context,
 	session *auth.Session,
@@ -32,6 +35,7 @@ func (c *Controller) Tail(
 	executionNum int64,
 	stageNum int,
 	stepNum int,
+	offset int,
 ) (<-chan *livelog.Line, <-chan error, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -62,5 +66,62 @@ func (c *Controller) Tail(
 	}
 
 	linec, errc := c.logStream.Tail(ctx, step.ID)
+	if linec != nil {
+		if offset > 0 {
+			linec = skipLines(ctx, linec, offset)
+		}
+		return linec, errc, nil
+	}
+
+	if !step.Status.IsDone() {
+		return nil, nil, nil
+	}
+
+	lines, err := c.readLogs(ctx, step.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	linec, errc = replayLines(ctx, lines, offset)
 	return linec, errc, nil
 }
+
+// skipLines forwards the lines starting at the offset position until the line channel is closed.
+func skipLines(ctx context.Context, linec <-chan *livelog.Line, offset int) <-chan *livelog.Line {
+	out := make(chan *livelog.Line)
+	go func() {
+		defer close(out)
+		for line := range linec {
+			if line.Number < offset {
+				continue
+			}
+			select {
+			case out <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// replayLines streams the persisted lines starting at the offset position and closes the channels afterwards.
+func replayLines(ctx context.Context, lines []*livelog.Line, offset int) (<-chan *livelog.Line, <-chan error) {
+	linec := make(chan *livelog.Line)
+	errc := make(chan error)
+	go func() {
+		defer close(errc)
+		defer close(linec)
+		for _, line := range lines {
+			if line.Number < offset {
+				continue
+			}
+			select {
+			case linec <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return linec, errc
+}
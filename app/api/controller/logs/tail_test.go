@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/livelog"
+)
+
+func TestReplayLines(t *testing.T) {
+	lines := []*livelog.Line{{Number: 0}, {Number: 1}, {Number: 2}}
+
+	linec, errc := replayLines(context.Background(), lines, 1)
+
+	var got []int
+	for line := range linec {
+		got = append(got, line.Number)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got lines %v, want %v", got, want)
+	}
+	if _, ok := <-errc; ok {
+		t.Error("expected error channel to be closed")
+	}
+}
+
+func TestSkipLines(t *testing.T) {
+	in := make(chan *livelog.Line, 3)
+	in <- &livelog.Line{Number: 0}
+	in <- &livelog.Line{Number: 1}
+	in <- &livelog.Line{Number: 2}
+	close(in)
+
+	var got []int
+	for line := range skipLines(context.Background(), in, 2) {
+		got = append(got, line.Number)
+	}
+	if want := []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got lines %v, want %v", got, want)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
@@ -49,6 +50,7 @@ type Controller struct {
 	tx              dbtx.Transactor
 	spaceStore      store.SpaceStore
 	repoStore       store.RepoStore
+	storagePoolSvc  *storagepool.Service
 }
 
 func NewController(
@@ -65,6 +67,7 @@ func NewController(
 	tx dbtx.Transactor,
 	spaceStore store.SpaceStore,
 	repoStore store.RepoStore,
+	storagePoolSvc *storagepool.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:      authorizer,
@@ -80,6 +83,7 @@ func NewController(
 		tx:              tx,
 		spaceStore:      spaceStore,
 		repoStore:       repoStore,
+		storagePoolSvc:  storagePoolSvc,
 	}
 }
 
@@ -56,6 +56,11 @@ func (c *Controller) CreateRepo(
 		return nil, fmt.Errorf("failed to check auth in parent '%s': %w", in.ParentRef, err)
 	}
 
+	storagePool, err := c.storagePoolSvc.Resolve(ctx, parentSpace.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage pool: %w", err)
+	}
+
 	// generate envars (add everything githook CLI needs for execution)
 	envVars, err := githook.GenerateEnvironmentVariables(
 		ctx,
@@ -80,6 +85,7 @@ func (c *Controller) CreateRepo(
 		Actor:         *actor,
 		EnvVars:       envVars,
 		DefaultBranch: in.DefaultBranch,
+		StoragePool:   storagePool,
 		Files:         nil,
 		Author:        actor,
 		AuthorDate:    &now,
@@ -110,6 +116,7 @@ func (c *Controller) CreateRepo(
 			ParentID:      parentSpace.ID,
 			Identifier:    in.Identifier,
 			GitUID:        gitResp.UID,
+			StoragePool:   storagePool,
 			CreatedBy:     session.Principal.ID,
 			Created:       now.UnixMilli(),
 			Updated:       now.UnixMilli(),
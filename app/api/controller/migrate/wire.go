@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
@@ -48,6 +49,7 @@ func ProvideController(
 	tx dbtx.Transactor,
 	spaceStore store.SpaceStore,
 	repoStore store.RepoStore,
+	storagePoolSvc *storagepool.Service,
 ) *Controller {
 	return NewController(
 		authorizer,
@@ -63,5 +65,6 @@ func ProvideController(
 		tx,
 		spaceStore,
 		repoStore,
+		storagePoolSvc,
 	)
 }
@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AssignPullReq assigns a pull request to the milestone.
+// The pull request is removed from the milestone it was previously assigned to.
+func (c *Controller) AssignPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	repo, milestone, err := c.getMilestoneCheckAccess(ctx, session, repoRef, milestoneID, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := c.pullReqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	pr, err = c.pullReqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.MilestoneID = &milestone.ID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign pull request to milestone: %w", err)
+	}
+
+	return pr, nil
+}
+
+// UnassignPullReq removes a pull request from the milestone.
+func (c *Controller) UnassignPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	pullreqNum int64,
+) error {
+	repo, milestone, err := c.getMilestoneCheckAccess(ctx, session, repoRef, milestoneID, enum.PermissionRepoReview)
+	if err != nil {
+		return err
+	}
+
+	pr, err := c.pullReqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.MilestoneID == nil || *pr.MilestoneID != milestone.ID {
+		return nil
+	}
+
+	_, err = c.pullReqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.MilestoneID != nil && *pr.MilestoneID == milestone.ID {
+			pr.MilestoneID = nil
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove pull request from milestone: %w", err)
+	}
+
+	return nil
+}
+
+// AssignIssue assigns an issue to the milestone.
+// The issue is removed from the milestone it was previously assigned to.
+func (c *Controller) AssignIssue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	issueNum int64,
+) (*types.Issue, error) {
+	repo, milestone, err := c.getMilestoneCheckAccess(ctx, session, repoRef, milestoneID, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		issue.MilestoneID = &milestone.ID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign issue to milestone: %w", err)
+	}
+
+	return issue, nil
+}
+
+// UnassignIssue removes an issue from the milestone.
+func (c *Controller) UnassignIssue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	issueNum int64,
+) error {
+	repo, milestone, err := c.getMilestoneCheckAccess(ctx, session, repoRef, milestoneID, enum.PermissionRepoReview)
+	if err != nil {
+		return err
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	if issue.MilestoneID == nil || *issue.MilestoneID != milestone.ID {
+		return nil
+	}
+
+	_, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		if issue.MilestoneID != nil && *issue.MilestoneID == milestone.ID {
+			issue.MilestoneID = nil
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove issue from milestone: %w", err)
+	}
+
+	return nil
+}
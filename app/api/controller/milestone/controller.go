@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer     authz.Authorizer
+	repoStore      store.RepoStore
+	milestoneStore store.MilestoneStore
+	pullReqStore   store.PullReqStore
+	issueStore     store.IssueStore
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	milestoneStore store.MilestoneStore,
+	pullReqStore store.PullReqStore,
+	issueStore store.IssueStore,
+) *Controller {
+	return &Controller{
+		authorizer:     authorizer,
+		repoStore:      repoStore,
+		milestoneStore: milestoneStore,
+		pullReqStore:   pullReqStore,
+		issueStore:     issueStore,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) getMilestoneCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, milestoneID int64, reqPermission enum.Permission,
+) (*types.Repository, *types.Milestone, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	milestone, err := c.milestoneStore.FindInRepo(ctx, repo.ID, milestoneID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find milestone: %w", err)
+	}
+
+	return repo, milestone, nil
+}
+
+func validateTitle(title string) error {
+	if title == "" {
+		return usererror.BadRequest("milestone title can't be empty")
+	}
+
+	const maxLen = 256
+	if utf8.RuneCountInString(title) > maxLen {
+		return usererror.BadRequestf("milestone title is too long (maximum is %d characters)", maxLen)
+	}
+
+	return nil
+}
+
+func validateDescription(desc string) error {
+	const maxLen = 16 << 10 // 16K
+	if len(desc) > maxLen {
+		return usererror.BadRequest("milestone description is too long")
+	}
+
+	return nil
+}
+
+func validateDueDate(dueDate *int64) error {
+	if dueDate != nil && *dueDate < 0 {
+		return usererror.BadRequest("milestone due date must be a valid timestamp")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     *int64 `json:"due_date"`
+}
+
+func (in *CreateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	if err := validateDescription(in.Description); err != nil {
+		return err
+	}
+
+	return validateDueDate(in.DueDate)
+}
+
+// Create creates a new milestone in the repository.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Milestone, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	milestone := &types.Milestone{
+		Version:     0,
+		RepoID:      repo.ID,
+		Title:       in.Title,
+		Description: in.Description,
+		DueDate:     in.DueDate,
+		State:       enum.MilestoneStateOpen,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.milestoneStore.Create(ctx, milestone); err != nil {
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return milestone, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes a milestone. Pull requests and issues assigned to it are left without a milestone.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+) error {
+	_, milestone, err := c.getMilestoneCheckAccess(ctx, session, repoRef, milestoneID, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.milestoneStore.Delete(ctx, milestone.ID); err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List returns a list of milestones of the repository together with their progress.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.milestoneStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count milestones: %w", err)
+	}
+
+	milestones, err := c.milestoneStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	return milestones, count, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	// DueDate sets the due date of the milestone. Value zero removes the due date.
+	DueDate *int64               `json:"due_date"`
+	State   *enum.MilestoneState `json:"state"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := validateTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := validateDescription(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if err := validateDueDate(in.DueDate); err != nil {
+		return err
+	}
+
+	if in.State != nil {
+		state, ok := in.State.Sanitize()
+		if !ok {
+			return usererror.BadRequest("Milestone state must be either open or closed.")
+		}
+		in.State = &state
+	}
+
+	return nil
+}
+
+// Update updates the details of a milestone. It is also used to close and to reopen the milestone.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	in *UpdateInput,
+) (*types.Milestone, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	_, milestone, err := c.getMilestoneCheckAccess(ctx, session, repoRef, milestoneID, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone, err = c.milestoneStore.UpdateOptLock(ctx, milestone, func(milestone *types.Milestone) error {
+		if in.Title != nil {
+			milestone.Title = *in.Title
+		}
+		if in.Description != nil {
+			milestone.Description = *in.Description
+		}
+		if in.DueDate != nil {
+			milestone.DueDate = in.DueDate
+			if *in.DueDate == 0 {
+				milestone.DueDate = nil
+			}
+		}
+		if in.State != nil && *in.State != milestone.State {
+			milestone.State = *in.State
+			milestone.Closed = nil
+			if milestone.State == enum.MilestoneStateClosed {
+				now := time.Now().UnixMilli()
+				milestone.Closed = &now
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	return milestone, nil
+}
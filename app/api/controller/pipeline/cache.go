@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pipelinecache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CacheUsage lists the build cache entries of the pipelines of the repository along with their total size.
+func (c *Controller) CacheUsage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PipelineCacheUsage, error) {
+	repo, err := c.getRepoCheckCacheAccess(ctx, session, repoRef, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cacheService.Usage(ctx, repo)
+}
+
+// CacheRestore returns the archive of the build cache entry, it's used by the restore cache steps.
+// The caller is responsible for closing the archive.
+func (c *Controller) CacheRestore(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+) (*types.PipelineCache, io.ReadCloser, error) {
+	repo, err := c.getRepoCheckCacheAccess(ctx, session, repoRef, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.cacheService.Restore(ctx, repo, key)
+}
+
+// CacheSave stores the archive as the build cache entry, it's used by the save cache steps.
+func (c *Controller) CacheSave(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+	archive io.Reader,
+) (*types.PipelineCache, error) {
+	if err := pipelinecache.ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckCacheAccess(ctx, session, repoRef, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cacheService.Save(ctx, repo, key, archive)
+}
+
+// CacheDelete deletes the build cache entry.
+func (c *Controller) CacheDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+) error {
+	repo, err := c.getRepoCheckCacheAccess(ctx, session, repoRef, enum.PermissionPipelineEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.cacheService.Delete(ctx, repo, key)
+}
+
+func (c *Controller) getRepoCheckCacheAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	permission enum.Permission,
+) (*types.Repository, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	// the build cache is shared by all pipelines of the repository.
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	return repo, nil
+}
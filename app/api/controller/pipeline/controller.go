@@ -17,6 +17,7 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/store"
 )
 
@@ -27,6 +28,10 @@ type Controller struct {
 	authorizer    authz.Authorizer
 	pipelineStore store.PipelineStore
 	reporter      events.Reporter
+	cacheService  *pipelinecache.Service
+	spaceStore    store.SpaceStore
+	secretStore   store.SecretStore
+	templateStore store.TemplateStore
 }
 
 func NewController(
@@ -35,6 +40,10 @@ func NewController(
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
 	reporter events.Reporter,
+	cacheService *pipelinecache.Service,
+	spaceStore store.SpaceStore,
+	secretStore store.SecretStore,
+	templateStore store.TemplateStore,
 ) *Controller {
 	return &Controller{
 		repoStore:     repoStore,
@@ -42,5 +51,9 @@ func NewController(
 		authorizer:    authorizer,
 		pipelineStore: pipelineStore,
 		reporter:      reporter,
+		cacheService:  cacheService,
+		spaceStore:    spaceStore,
+		secretStore:   secretStore,
+		templateStore: templateStore,
 	}
 }
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/lint"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxValidateSize is the maximum size of a pipeline YAML that can be validated.
+const maxValidateSize = 1 << 20 // 1 MiB
+
+type ValidateInput struct {
+	YAML string `json:"yaml"`
+}
+
+// Validate validates the pipeline YAML before it's committed to the repository.
+// Besides syntax and schema errors and unknown keys, it reports references to secrets
+// and pipeline templates that don't exist in the space of the repository or its ancestors.
+func (c *Controller) Validate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ValidateInput,
+) (*types.PipelineValidation, error) {
+	if strings.TrimSpace(in.YAML) == "" {
+		return nil, usererror.BadRequest("Pipeline YAML is required.")
+	}
+	if len(in.YAML) > maxValidateSize {
+		return nil, usererror.BadRequestf("Pipeline YAML exceeds the maximum size of %d bytes.", maxValidateSize)
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", enum.PermissionPipelineEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	result := lint.Lint([]byte(in.YAML))
+	problems := result.Problems
+
+	if len(result.Secrets) > 0 || len(result.Templates) > 0 {
+		missing, err := c.findMissingReferences(ctx, repo, result)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, missing...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+
+	validation := &types.PipelineValidation{
+		Valid:    true,
+		Problems: problems,
+	}
+	if validation.Problems == nil {
+		validation.Problems = []*types.PipelineProblem{}
+	}
+	for _, problem := range problems {
+		if problem.Severity == enum.PipelineProblemSeverityError {
+			validation.Valid = false
+			break
+		}
+	}
+
+	return validation, nil
+}
+
+// findMissingReferences returns the problems of the references to secrets and pipeline templates
+// that don't exist in the space of the repository or its ancestors.
+func (c *Controller) findMissingReferences(
+	ctx context.Context,
+	repo *types.Repository,
+	result *lint.Result,
+) ([]*types.PipelineProblem, error) {
+	spaces, err := c.spaceStore.GetAncestors(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces: %w", err)
+	}
+
+	spaceIDs := make([]int64, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+	}
+
+	var problems []*types.PipelineProblem
+
+	if len(result.Secrets) > 0 {
+		secrets, err := c.secretStore.ListAllInSpaces(ctx, spaceIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+
+		identifiers := make(map[string]struct{}, len(secrets))
+		for _, secret := range secrets {
+			identifiers[secret.Identifier] = struct{}{}
+		}
+
+		for _, ref := range result.Secrets {
+			if _, ok := identifiers[ref.Name]; ok {
+				continue
+			}
+			problems = append(problems, &types.PipelineProblem{
+				Type:     enum.PipelineProblemTypeMissingSecret,
+				Severity: enum.PipelineProblemSeverityError,
+				Line:     ref.Line,
+				Column:   ref.Column,
+				Message:  fmt.Sprintf("secret %q doesn't exist", ref.Name),
+			})
+		}
+	}
+
+	for _, ref := range result.Templates {
+		found, err := c.templateExists(ctx, spaceIDs, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			continue
+		}
+		problems = append(problems, &types.PipelineProblem{
+			Type:     enum.PipelineProblemTypeMissingTemplate,
+			Severity: enum.PipelineProblemSeverityError,
+			Line:     ref.Line,
+			Column:   ref.Column,
+			Message:  fmt.Sprintf("pipeline template %q doesn't exist", ref.Name),
+		})
+	}
+
+	return problems, nil
+}
+
+func (c *Controller) templateExists(ctx context.Context, spaceIDs []int64, identifier string) (bool, error) {
+	for _, spaceID := range spaceIDs {
+		_, err := c.templateStore.FindByIdentifierAndType(ctx, spaceID, identifier, enum.ResolverTypePipeline)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find pipeline template: %w", err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -17,6 +17,7 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -33,6 +34,10 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	pipelineStore store.PipelineStore,
 	reporter *events.Reporter,
+	cacheService *pipelinecache.Service,
+	spaceStore store.SpaceStore,
+	secretStore store.SecretStore,
+	templateStore store.TemplateStore,
 ) *Controller {
 	return NewController(
 		authorizer,
@@ -40,5 +45,9 @@ func ProvideController(
 		triggerStore,
 		pipelineStore,
 		*reporter,
+		cacheService,
+		spaceStore,
+		secretStore,
+		templateStore,
 	)
 }
@@ -16,6 +16,7 @@ package principal
 
 import (
 	"context"
+	"io"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -28,4 +29,6 @@ type Controller interface {
 	List(ctx context.Context, session *auth.Session, opts *types.PrincipalFilter) ([]*types.PrincipalInfo, error)
 	Find(ctx context.Context, session *auth.Session, principalID int64) (*types.PrincipalInfo, error)
 	CheckExistenceByEmails(ctx context.Context, session *auth.Session, input *CheckUsersInput) (*CheckUsersOutput, error)
+	// Avatar returns either the URL to redirect to or a reader of the avatar image of the principal.
+	Avatar(ctx context.Context, session *auth.Session, principalID int64) (string, io.ReadCloser, error)
 }
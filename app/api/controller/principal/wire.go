@@ -16,6 +16,7 @@ package principal
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -26,6 +27,10 @@ var WireSet = wire.NewSet(
 	ProvideController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, authorizer authz.Authorizer) Controller {
-	return newController(principalStore, authorizer)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	avatarService *avatar.Service,
+) Controller {
+	return newController(principalStore, authorizer, avatarService)
 }
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AutoMergeInput struct {
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	if in.Method == "" {
+		in.Method = enum.MergeMethodMerge
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	in.SourceSHA = strings.TrimSpace(in.SourceSHA)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if (in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodFastForward) &&
+		(in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables the auto-merge of a pull request. The pull request gets merged with the provided
+// merge method as soon as the protection rules allow it, on behalf of the principal who enabled the auto-merge.
+// The auto-merge is bound to the current source SHA of the pull request, it's canceled if new commits are pushed.
+// Enabling the auto-merge again replaces the merge method, the source SHA and the principal.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.PullReqAutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoMerge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Auto-merge can be enabled only for open pull requests.")
+	}
+	if pr.IsDraft {
+		return nil, usererror.BadRequest("Auto-merge can't be enabled for draft pull requests.")
+	}
+	if in.SourceSHA != "" && in.SourceSHA != pr.SourceSHA {
+		return nil, usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	autoMerge := &types.PullReqAutoMerge{
+		PullReqID: pr.ID,
+		RepoID:    repo.ID,
+		Method:    in.Method,
+		SourceSHA: pr.SourceSHA,
+		Title:     in.Title,
+		Message:   in.Message,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+		EnabledBy: session.Principal.ToPrincipalInfo(),
+	}
+
+	if err = c.autoMergeStore.Upsert(ctx, autoMerge); err != nil {
+		return nil, fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+
+	return autoMerge, nil
+}
+
+// AutoMergeFind returns the auto-merge of a pull request.
+func (c *Controller) AutoMergeFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqAutoMerge, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	autoMerge.EnabledBy, err = c.principalInfoCache.Get(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal info of the auto-merge: %w", err)
+	}
+
+	return autoMerge, nil
+}
+
+// AutoMergeCancel cancels the auto-merge of a pull request.
+func (c *Controller) AutoMergeCancel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoMerge)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	canceled, err := c.autoMergeStore.Delete(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel auto-merge: %w", err)
+	}
+
+	if !canceled {
+		return usererror.NotFound("Auto-merge isn't enabled for the pull request.")
+	}
+
+	return nil
+}
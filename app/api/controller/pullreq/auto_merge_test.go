@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAutoMergeStore struct {
+	store.PullReqAutoMergeStore
+	upserted []*types.PullReqAutoMerge
+}
+
+func (s *fakeAutoMergeStore) Upsert(_ context.Context, autoMerge *types.PullReqAutoMerge) error {
+	s.upserted = append(s.upserted, autoMerge)
+	return nil
+}
+
+func TestController_AutoMergeEnable(t *testing.T) {
+	const (
+		currentSHA = "1111111111111111111111111111111111111111"
+		olderSHA   = "2222222222222222222222222222222222222222"
+	)
+
+	tests := []struct {
+		name       string
+		sourceSHA  string
+		wantStatus int
+	}{
+		{
+			name: "pinned to the current source SHA",
+		},
+		{
+			name:      "current source SHA provided",
+			sourceSHA: " " + currentSHA + " ",
+		},
+		{
+			name:       "newer commit available",
+			sourceSHA:  olderSHA,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{
+				ID:           1,
+				Number:       1,
+				State:        enum.PullReqStateOpen,
+				SourceRepoID: testRepo.ID,
+				TargetRepoID: testRepo.ID,
+				SourceSHA:    currentSHA,
+			}
+
+			autoMergeStore := &fakeAutoMergeStore{}
+
+			c := newTestController(t, nil, pr)
+			c.autoMergeStore = autoMergeStore
+
+			autoMerge, err := c.AutoMergeEnable(context.Background(), testSession, testRepo.Path, pr.Number,
+				&AutoMergeInput{Method: enum.MergeMethodSquash, SourceSHA: test.sourceSHA})
+			if test.wantStatus != 0 {
+				if status := userErrorStatus(err); status != test.wantStatus {
+					t.Errorf("status = %d, want %d (err: %v)", status, test.wantStatus, err)
+				}
+				if len(autoMergeStore.upserted) != 0 {
+					t.Errorf("expected the auto-merge not to be enabled")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if autoMerge.SourceSHA != currentSHA || autoMerge.CreatedBy != testSession.Principal.ID {
+				t.Errorf("auto-merge = %+v, want pinned to %s by the caller", autoMerge, currentSHA)
+			}
+			if len(autoMergeStore.upserted) != 1 {
+				t.Errorf("got %d stored auto-merges, want 1", len(autoMergeStore.upserted))
+			}
+		})
+	}
+}
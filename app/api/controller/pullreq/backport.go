@@ -0,0 +1,245 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxBackportBranches is the max number of target branches a pull request can be backported to at once.
+const maxBackportBranches = 10
+
+type BackportInput struct {
+	// TargetBranches are the branches (e.g. maintenance branches) the pull request is backported to.
+	TargetBranches []string `json:"target_branches"`
+
+	// Label is assigned to all created backport pull requests (optional).
+	Label *types.PullReqCreateInput `json:"label"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *BackportInput) sanitize() error {
+	branches := make([]string, 0, len(in.TargetBranches))
+	seen := make(map[string]struct{}, len(in.TargetBranches))
+	for _, branch := range in.TargetBranches {
+		branch = strings.TrimSpace(branch)
+		if branch == "" {
+			continue
+		}
+		if _, ok := seen[branch]; ok {
+			continue
+		}
+		seen[branch] = struct{}{}
+		branches = append(branches, branch)
+	}
+
+	if len(branches) == 0 {
+		return usererror.BadRequest("At least one target branch must be provided")
+	}
+	if len(branches) > maxBackportBranches {
+		return usererror.BadRequestf("A pull request can be backported to at most %d branches at once",
+			maxBackportBranches)
+	}
+
+	in.TargetBranches = branches
+
+	if in.Label != nil {
+		if err := in.Label.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Backport cherry-picks the changes of a merged pull request onto each of the target branches.
+// For every target branch a new branch with the cherry-picked commits is created and a backport pull request
+// is opened for it. A backport that is blocked by conflicts or protection rules doesn't stop the others,
+// the outcome of each backport is returned in the response.
+func (c *Controller) Backport(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *BackportInput,
+) (*types.BackportResponse, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+		return nil, usererror.BadRequest("Only merged pull requests can be backported")
+	}
+
+	for _, targetBranch := range in.TargetBranches {
+		if targetBranch == pr.TargetBranch {
+			return nil, usererror.BadRequestf("The pull request is already merged to the branch %q", targetBranch)
+		}
+		if _, err = c.verifyBranchExistence(ctx, repo, targetBranch); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the commits of the pull request, as they were added to its target branch by the merge.
+	revisions := *pr.MergeTargetSHA + ".." + *pr.MergeSHA
+
+	out := &types.BackportResponse{
+		Results: make([]types.BackportResult, len(in.TargetBranches)),
+	}
+
+	for i, targetBranch := range in.TargetBranches {
+		result := &out.Results[i]
+		result.TargetBranch = targetBranch
+		result.Branch = fmt.Sprintf("backport-pullreq-%d-%s", pr.Number, targetBranch)
+
+		violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+			ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+			Actor:              &session.Principal,
+			AllowBypass:        in.BypassRules,
+			IsRepoOwner:        isRepoOwner,
+			Repo:               repo,
+			RefAction:          protection.RefActionCreate,
+			RefType:            protection.RefTypeBranch,
+			RefNames:           []string{result.Branch},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+		}
+
+		result.RuleViolations = violations
+
+		if protection.IsCritical(violations) {
+			result.Message = protection.GenerateErrorMessageForBlockingViolations(violations)
+			continue
+		}
+
+		result.PullReq, err = c.backport(ctx, session, repoRef, pr, writeParams, revisions, result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to backport pull request to branch %q: %w", targetBranch, err)
+		}
+
+		if result.PullReq == nil || in.Label == nil {
+			continue
+		}
+
+		if _, err = c.AssignLabel(ctx, session, repoRef, result.PullReq.Number, in.Label); err != nil {
+			return nil, fmt.Errorf("failed to assign label to backport pull request: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+// backport cherry-picks the commits onto a new branch created from the target branch
+// and opens the backport pull request. It returns nil if the commits can't be applied because of conflicts.
+func (c *Controller) backport(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pr *types.PullReq,
+	writeParams git.WriteParams,
+	revisions string,
+	result *types.BackportResult,
+) (*types.PullReq, error) {
+	now := time.Now()
+	committer := identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+
+	// check on the target branch first, so no branch is left behind if the commits can't be applied.
+	dryRunOutput, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:   writeParams,
+		Revisions:     revisions,
+		Branch:        result.TargetBranch,
+		Committer:     committer,
+		CommitterDate: &now,
+		DryRun:        true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cherry-pick check failed: %w", err)
+	}
+
+	if dryRunOutput.HasConflicts() {
+		result.Message = "Backport blocked by conflicting files"
+		result.Commits = controller.MapCherryPickCommits(dryRunOutput.Commits)
+		return nil, nil
+	}
+
+	_, err = c.git.CreateBranch(ctx, &git.CreateBranchParams{
+		WriteParams: writeParams,
+		BranchName:  result.Branch,
+		Target:      dryRunOutput.BaseSHA.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backport branch: %w", err)
+	}
+
+	output, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:       writeParams,
+		Revisions:         revisions,
+		Branch:            result.Branch,
+		BranchExpectedSHA: dryRunOutput.BaseSHA,
+		Committer:         committer,
+		CommitterDate:     &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cherry-pick execution failed: %w", err)
+	}
+
+	result.Commits = controller.MapCherryPickCommits(output.Commits)
+
+	backportPR, err := c.Create(ctx, session, repoRef, &CreateInput{
+		Title:        fmt.Sprintf("[Backport %s] %s", result.TargetBranch, pr.Title),
+		Description:  fmt.Sprintf("Backport of #%d to `%s`.", pr.Number, result.TargetBranch),
+		SourceBranch: result.Branch,
+		TargetBranch: result.TargetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backport pull request: %w", err)
+	}
+
+	return backportPR, nil
+}
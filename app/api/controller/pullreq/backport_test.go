@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+// branchGit knows the branches of the test repository and fails any write operation.
+type branchGit struct {
+	git.Interface
+	branches map[string]string
+}
+
+func (g *branchGit) GetRef(_ context.Context, params git.GetRefParams) (git.GetRefResponse, error) {
+	branchSHA, ok := g.branches[params.Name]
+	if !ok {
+		return git.GetRefResponse{}, errors.NotFound("branch %q not found", params.Name)
+	}
+	return git.GetRefResponse{SHA: sha.Must(branchSHA)}, nil
+}
+
+func TestBackportInput_Sanitize(t *testing.T) {
+	tooMany := make([]string, maxBackportBranches+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("release-%d", i)
+	}
+
+	tests := []struct {
+		name     string
+		branches []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "trimmed and deduplicated",
+			branches: []string{" release-1 ", "release-2", "", "release-1"},
+			want:     []string{"release-1", "release-2"},
+		},
+		{
+			name:     "no branches",
+			branches: []string{" ", ""},
+			wantErr:  true,
+		},
+		{
+			name:     "too many branches",
+			branches: tooMany,
+			wantErr:  true,
+		},
+		{
+			name:     "max branches with duplicates",
+			branches: append(tooMany[:maxBackportBranches:maxBackportBranches], tooMany[0]),
+			want:     tooMany[:maxBackportBranches],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &BackportInput{TargetBranches: test.branches}
+
+			err := in.sanitize()
+			if test.wantErr {
+				if userErrorStatus(err) != http.StatusBadRequest {
+					t.Errorf("expected bad request, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(in.TargetBranches, test.want) {
+				t.Errorf("target branches = %v, want %v", in.TargetBranches, test.want)
+			}
+		})
+	}
+}
+
+func TestController_BackportRejected(t *testing.T) {
+	const (
+		mergeSHA  = "1111111111111111111111111111111111111111"
+		targetSHA = "2222222222222222222222222222222222222222"
+	)
+
+	merged := func(pr *types.PullReq) {
+		pr.State = enum.PullReqStateMerged
+		pr.MergeSHA = ptr.String(mergeSHA)
+		pr.MergeTargetSHA = ptr.String(targetSHA)
+	}
+
+	tests := []struct {
+		name     string
+		pr       func(pr *types.PullReq)
+		branches []string
+	}{
+		{
+			name:     "open pull request",
+			pr:       func(*types.PullReq) {},
+			branches: []string{"release-1"},
+		},
+		{
+			name: "merged without merge commit",
+			pr: func(pr *types.PullReq) {
+				merged(pr)
+				pr.MergeSHA = nil
+			},
+			branches: []string{"release-1"},
+		},
+		{
+			name:     "target branch of the pull request",
+			pr:       merged,
+			branches: []string{"release-1", "main"},
+		},
+		{
+			name:     "unknown branch",
+			pr:       merged,
+			branches: []string{"release-1", "release-9"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{
+				ID:           1,
+				Number:       1,
+				State:        enum.PullReqStateOpen,
+				SourceRepoID: testRepo.ID,
+				TargetRepoID: testRepo.ID,
+				SourceBranch: "feature",
+				TargetBranch: "main",
+			}
+			test.pr(pr)
+
+			g := &branchGit{branches: map[string]string{"main": targetSHA, "release-1": targetSHA}}
+			c := newTestController(t, g, pr)
+
+			_, err := c.Backport(context.Background(), testSession, testRepo.Path, pr.Number,
+				&BackportInput{TargetBranches: test.branches})
+			if userErrorStatus(err) != http.StatusBadRequest {
+				t.Errorf("expected bad request, got %v", err)
+			}
+		})
+	}
+}
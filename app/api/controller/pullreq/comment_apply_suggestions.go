@@ -149,6 +149,9 @@ func (c *Controller) CommentApplySuggestions(
 	}
 	activityUpdates := map[int64]activityUpdate{}
 
+	// authors of the applied suggestions are attributed as co-authors of the commit
+	coAuthors := []types.PrincipalInfo{}
+
 	// cache file shas to reduce number of git calls (use commit as some code comments can be temp out of sync)
 	getFileSHAKey := func(commitID string, path string) string { return commitID + ":" + path }
 	fileSHACache := map[string]sha.SHA{}
@@ -264,6 +267,10 @@ func (c *Controller) CommentApplySuggestions(
 				)),
 			})
 
+		if activity.CreatedBy != session.Principal.ID {
+			coAuthors = append(coAuthors, activity.Author)
+		}
+
 		activityUpdates[activity.ID] = activityUpdate{
 			act:      activity,
 			checksum: suggestionToApply.checkSum,
@@ -302,7 +309,7 @@ func (c *Controller) CommentApplySuggestions(
 	commitOut, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
 		WriteParams:   writeParams,
 		Title:         in.Title,
-		Message:       in.Message,
+		Message:       appendCoAuthorTrailers(in.Message, coAuthors),
 		Branch:        pr.SourceBranch,
 		Committer:     identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
 		CommitterDate: &now,
@@ -125,6 +125,11 @@ func (c *Controller) CommentCreate(
 		}
 	}
 
+	in.Text, err = c.attachmentSvc.ProcessMarkdown(ctx, repo.ID, in.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
 	// fetch code snippet from git for code comments
 	var cut git.DiffCutOutput
 	if in.IsCodeComment() {
@@ -66,6 +66,7 @@ func (c *Controller) CommentStatus(
 
 	var pr *types.PullReq
 	var act *types.PullReqActivity
+	var changed bool
 
 	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
 		pr, err = c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
@@ -82,7 +83,8 @@ func (c *Controller) CommentStatus(
 			return fmt.Errorf("failed to get comment: %w", err)
 		}
 
-		if !in.hasChanges(act, session.Principal.ID) {
+		changed = in.hasChanges(act, session.Principal.ID)
+		if !changed {
 			return nil
 		}
 
@@ -113,18 +115,32 @@ func (c *Controller) CommentStatus(
 		}
 
 		pr.UnresolvedCount = unresolvedCount
+		pr.ActivitySeq++ // because we need to add the activity entry of the status change
 
 		err = c.pullreqStore.Update(ctx, pr)
 		if err != nil {
 			return fmt.Errorf("failed to update pull request's unresolved comment count: %w", err)
 		}
 
+		// The activity is written in the same transaction, so the reserved activity sequence number is never lost.
+		payload := &types.PullRequestActivityPayloadCommentStatus{
+			CommentID: act.ID,
+			Status:    in.Status,
+		}
+		if _, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, nil); err != nil {
+			return fmt.Errorf("failed to write pull request activity after comment status change: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if !changed {
+		return act, nil
+	}
+
 	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
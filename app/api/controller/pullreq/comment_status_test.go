@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeTransactor records whether a transaction is in progress.
+type fakeTransactor struct {
+	inTx bool
+}
+
+func (t *fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...any) error {
+	t.inTx = true
+	defer func() { t.inTx = false }()
+	return txFn(ctx)
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	tx        *fakeTransactor
+	comments  map[int64]*types.PullReqActivity
+	created   []*types.PullReqActivity
+	createErr error
+}
+
+func (s *fakeActivityStore) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	comment, ok := s.comments[id]
+	if !ok {
+		return nil, errors.New("comment not found")
+	}
+	return comment, nil
+}
+
+func (s *fakeActivityStore) Update(_ context.Context, act *types.PullReqActivity) error {
+	s.comments[act.ID] = act
+	return nil
+}
+
+func (s *fakeActivityStore) CountUnresolved(context.Context, int64) (int, error) {
+	count := 0
+	for _, comment := range s.comments {
+		if comment.Resolved == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	if !s.tx.inTx {
+		return nil, errors.New("activity created outside of the transaction")
+	}
+	if s.createErr != nil {
+		return nil, s.createErr
+	}
+
+	act := &types.PullReqActivity{
+		CreatedBy: principalID,
+		PullReqID: pr.ID,
+		Order:     pr.ActivitySeq,
+		Type:      payload.ActivityType(),
+		Kind:      enum.PullReqActivityKindSystem,
+	}
+	_ = act.SetPayload(payload)
+	s.created = append(s.created, act)
+
+	return act, nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+	published int
+}
+
+func (s *fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	s.published++
+	return nil
+}
+
+func TestController_CommentStatus(t *testing.T) {
+	const commentID = 10
+
+	tests := []struct {
+		name         string
+		status       enum.PullReqCommentStatus
+		resolved     bool
+		createErr    error
+		wantActivity bool
+		wantErr      bool
+	}{
+		{
+			name:         "resolve",
+			status:       enum.PullReqCommentStatusResolved,
+			wantActivity: true,
+		},
+		{
+			name:         "reopen",
+			status:       enum.PullReqCommentStatusActive,
+			resolved:     true,
+			wantActivity: true,
+		},
+		{
+			name:   "unchanged",
+			status: enum.PullReqCommentStatusActive,
+		},
+		{
+			name:      "activity fails",
+			status:    enum.PullReqCommentStatusResolved,
+			createErr: errors.New("database is down"),
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{ID: 1, Number: 1, TargetRepoID: testRepo.ID, ActivitySeq: 5}
+
+			comment := &types.PullReqActivity{
+				ID:        commentID,
+				RepoID:    testRepo.ID,
+				PullReqID: pr.ID,
+				Type:      enum.PullReqActivityTypeComment,
+				Kind:      enum.PullReqActivityKindComment,
+			}
+			if test.resolved {
+				resolved, resolvedBy := int64(1), int64(1)
+				comment.Resolved, comment.ResolvedBy = &resolved, &resolvedBy
+			}
+
+			tx := &fakeTransactor{}
+			activityStore := &fakeActivityStore{
+				tx:        tx,
+				comments:  map[int64]*types.PullReqActivity{commentID: comment},
+				createErr: test.createErr,
+			}
+			streamer := &fakeStreamer{}
+
+			c := newTestController(t, nil, pr)
+			c.tx = tx
+			c.activityStore = activityStore
+			c.sseStreamer = streamer
+
+			_, err := c.CommentStatus(context.Background(), testSession, testRepo.Path, pr.Number, commentID,
+				&CommentStatusInput{Status: test.status})
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if streamer.published != 0 {
+					t.Errorf("expected no event for a failed status change")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !test.wantActivity {
+				if len(activityStore.created) != 0 || pr.ActivitySeq != 5 {
+					t.Errorf("expected no activity, got %d, activity seq %d", len(activityStore.created), pr.ActivitySeq)
+				}
+				return
+			}
+
+			if len(activityStore.created) != 1 {
+				t.Fatalf("got %d activities, want 1", len(activityStore.created))
+			}
+
+			act := activityStore.created[0]
+			if act.Order != 6 || pr.ActivitySeq != 6 {
+				t.Errorf("activity order = %d, activity seq = %d, want 6", act.Order, pr.ActivitySeq)
+			}
+
+			payload, err := act.GetPayload()
+			if err != nil {
+				t.Fatalf("failed to get payload: %v", err)
+			}
+			statusPayload, ok := payload.(*types.PullRequestActivityPayloadCommentStatus)
+			if !ok || statusPayload.CommentID != commentID || statusPayload.Status != test.status {
+				t.Errorf("payload = %+v, want comment %d with status %s", payload, commentID, test.status)
+			}
+
+			if streamer.published != 1 {
+				t.Errorf("got %d events, want 1", streamer.published)
+			}
+		})
+	}
+}
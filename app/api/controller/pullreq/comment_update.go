@@ -73,6 +73,11 @@ func (c *Controller) CommentUpdate(
 		return nil, fmt.Errorf("failed to get comment: %w", err)
 	}
 
+	in.Text, err = c.attachmentSvc.ProcessMarkdown(ctx, repo.ID, in.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
 	if !in.hasChanges(act) {
 		return act, nil
 	}
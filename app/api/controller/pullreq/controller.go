@@ -20,10 +20,13 @@ import (
 	"unicode/utf8"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/attachment"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
@@ -32,11 +35,13 @@ import (
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
@@ -62,10 +67,13 @@ type Controller struct {
 	userGroupStore         store.UserGroupStore
 	principalInfoCache     store.PrincipalInfoCache
 	fileViewStore          store.PullReqFileViewStore
+	autoMergeStore         store.PullReqAutoMergeStore
+	dependencyStore        store.PullReqDependencyStore
 	membershipStore        store.MembershipStore
 	checkStore             store.CheckStore
 	git                    git.Interface
 	eventReporter          *pullreqevents.Reporter
+	repoReporter           *repoevents.Reporter
 	codeCommentMigrator    *codecomments.Migrator
 	pullreqService         *pullreq.Service
 	pullreqListService     *pullreq.ListService
@@ -77,6 +85,9 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	attachmentSvc          *attachment.Service
+	settings               *settings.Service
+	diffFileCache          cache.Cache[diffFileKey, *git.FileDiff]
 }
 
 func NewController(
@@ -95,10 +106,13 @@ func NewController(
 	userGroupReviewerStore store.UserGroupReviewersStore,
 	principalInfoCache store.PrincipalInfoCache,
 	fileViewStore store.PullReqFileViewStore,
+	autoMergeStore store.PullReqAutoMergeStore,
+	dependencyStore store.PullReqDependencyStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
 	git git.Interface,
 	eventReporter *pullreqevents.Reporter,
+	repoReporter *repoevents.Reporter,
 	codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service,
 	pullreqListService *pullreq.ListService,
@@ -110,6 +124,8 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	attachmentSvc *attachment.Service,
+	settings *settings.Service,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -127,11 +143,14 @@ func NewController(
 		userGroupReviewerStore: userGroupReviewerStore,
 		principalInfoCache:     principalInfoCache,
 		fileViewStore:          fileViewStore,
+		autoMergeStore:         autoMergeStore,
+		dependencyStore:        dependencyStore,
 		membershipStore:        membershipStore,
 		checkStore:             checkStore,
 		git:                    git,
 		codeCommentMigrator:    codeCommentMigrator,
 		eventReporter:          eventReporter,
+		repoReporter:           repoReporter,
 		pullreqService:         pullreqService,
 		pullreqListService:     pullreqListService,
 		protectionManager:      protectionManager,
@@ -142,6 +161,12 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		attachmentSvc:          attachmentSvc,
+		settings:               settings,
+		diffFileCache: cache.New[diffFileKey, *git.FileDiff](
+			diffFileGetter{git: git},
+			diffFileCacheDuration,
+		),
 	}
 }
 
@@ -200,6 +225,10 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
 
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
 	return repo, nil
 }
 
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeAuthorizer permits everything, unless denied for the principal, the repository or the permission.
+type fakeAuthorizer struct {
+	deniedPrincipals  map[int64]bool
+	deniedRepos       map[string]bool
+	deniedPermissions map[enum.Permission]bool
+}
+
+func (a *fakeAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if a.deniedPrincipals[session.Principal.ID] || a.deniedPermissions[permission] {
+		return false, nil
+	}
+	if resource.Type == enum.ResourceTypeRepo && a.deniedRepos[resource.Identifier] {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (a *fakeAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		check := &permissionChecks[i]
+		ok, err := a.Check(ctx, session, &check.Scope, &check.Resource, check.Permission)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, errors.NotFound("repository %d not found", id)
+	}
+	return repo, nil
+}
+
+func (s *fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	for _, repo := range s.repos {
+		if repo.Path == repoRef {
+			return repo, nil
+		}
+	}
+	return nil, errors.NotFound("repository %q not found", repoRef)
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s *fakePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, errors.NotFound("pull request %d not found", id)
+	}
+	return pr, nil
+}
+
+func (s *fakePullReqStore) FindByNumber(_ context.Context, repoID, number int64) (*types.PullReq, error) {
+	for _, pr := range s.prs {
+		if pr.TargetRepoID == repoID && pr.Number == number {
+			return pr, nil
+		}
+	}
+	return nil, errors.NotFound("pull request %d not found", number)
+}
+
+func (s *fakePullReqStore) Update(_ context.Context, pr *types.PullReq) error {
+	s.prs[pr.ID] = pr
+	return nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, errors.NotFound("principal %d not found", id)
+	}
+	return principal, nil
+}
+
+// fakeRuleStore has no protection rules.
+type fakeRuleStore struct {
+	store.RuleStore
+}
+
+func (fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return nil, nil
+}
+
+type fakeUserGroupService struct {
+	usergroup.SearchService
+}
+
+func (fakeUserGroupService) ListUserIDsByGroupIDs(context.Context, []int64) ([]int64, error) {
+	return nil, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000"
+}
+
+// testRepo is the repository all pull requests of the controller tests target.
+var testRepo = &types.Repository{
+	ID:     1,
+	Path:   "space/repo",
+	GitUID: "repo-uid",
+	State:  enum.RepoStateActive,
+}
+
+// newTestController returns a controller backed by the fake stores, the provided pull requests
+// and the provided git implementation. Fields needed by a single test are set by the test itself.
+func newTestController(t *testing.T, g git.Interface, prs ...*types.PullReq) *Controller {
+	t.Helper()
+
+	prMap := make(map[int64]*types.PullReq, len(prs))
+	for _, pr := range prs {
+		prMap[pr.ID] = pr
+	}
+
+	return &Controller{
+		urlProvider:       fakeURLProvider{},
+		authorizer:        &fakeAuthorizer{},
+		repoStore:         &fakeRepoStore{repos: map[int64]*types.Repository{testRepo.ID: testRepo}},
+		pullreqStore:      &fakePullReqStore{prs: prMap},
+		git:               g,
+		protectionManager: protection.NewManager(fakeRuleStore{}),
+		userGroupService:  fakeUserGroupService{},
+	}
+}
+
+// testSession is the session of the user calling the controller in the tests.
+var testSession = &auth.Session{
+	Principal: types.Principal{ID: 100, UID: "caller", Type: enum.PrincipalTypeUser},
+}
+
+// userErrorStatus returns the HTTP status of the user facing error, zero for any other error.
+func userErrorStatus(err error) int {
+	var uErr *usererror.Error
+	if errors.As(err, &uErr) {
+		return uErr.Status
+	}
+	return 0
+}
@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxDependencyGraphSize is the max number of pull requests in a dependency graph.
+const maxDependencyGraphSize = 100
+
+type DependencyAddInput struct {
+	// RepoRef is the repository of the pull request the pull request depends on.
+	// It must be in the same space, the repository of the pull request is used by default.
+	RepoRef string `json:"repo_ref"`
+	Number  int64  `json:"number"`
+}
+
+// DependencyAdd declares that a pull request depends on another pull request.
+// The pull request can't be merged before all pull requests it depends on are merged.
+func (c *Controller) DependencyAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *DependencyAddInput,
+) (*types.PullReqDependency, error) {
+	if in.Number <= 0 {
+		return nil, usererror.BadRequest("A valid pull request number must be provided")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Dependencies can be added only to open pull requests")
+	}
+
+	dependsOnRepo := repo
+	if in.RepoRef != "" {
+		dependsOnRepo, err = c.getRepoCheckAccess(ctx, session, in.RepoRef, enum.PermissionRepoView)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire access to the repo of the dependency: %w", err)
+		}
+	}
+
+	if dependsOnRepo.ParentID != repo.ParentID {
+		return nil, usererror.BadRequest("A pull request can only depend on pull requests in the same space")
+	}
+
+	dependsOn, err := c.pullreqStore.FindByNumber(ctx, dependsOnRepo.ID, in.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the pull request of the dependency: %w", err)
+	}
+
+	if dependsOn.ID == pr.ID {
+		return nil, usererror.BadRequest("A pull request can't depend on itself")
+	}
+
+	nodeIDs, _, err := c.dependencyGraph(ctx, dependsOn.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nodeID := range nodeIDs {
+		if nodeID == pr.ID {
+			return nil, usererror.BadRequest("The dependency would create a dependency cycle")
+		}
+	}
+
+	dependency := &types.PullReqDependency{
+		PullReqID:   pr.ID,
+		DependsOnID: dependsOn.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.dependencyStore.Create(ctx, dependency)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("The pull request already depends on the pull request")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request dependency: %w", err)
+	}
+
+	return dependency, nil
+}
+
+// DependencyRemove removes the dependency of a pull request on another pull request.
+func (c *Controller) DependencyRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	dependsOnID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	removed, err := c.dependencyStore.Delete(ctx, pr.ID, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pull request dependency: %w", err)
+	}
+
+	if !removed {
+		return usererror.NotFound("The pull request doesn't depend on the pull request")
+	}
+
+	return nil
+}
+
+// DependencyGraph returns the graph of all pull requests a pull request (transitively) depends on.
+func (c *Controller) DependencyGraph(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqDependencyGraph, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	nodeIDs, edges, err := c.dependencyGraph(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := c.dependencyNodes(ctx, session, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &types.PullReqDependencyGraph{
+		Nodes: nodes,
+		Edges: make([]types.PullReqDependency, len(edges)),
+	}
+	for i, edge := range edges {
+		graph.Edges[i] = *edge
+	}
+
+	return graph, nil
+}
+
+// dependencyGraph walks the dependencies of a pull request breadth-first.
+// It returns the IDs of all visited pull requests, starting with the provided one, and the traversed dependencies.
+func (c *Controller) dependencyGraph(
+	ctx context.Context,
+	pullReqID int64,
+) ([]int64, []*types.PullReqDependency, error) {
+	nodeIDs := []int64{pullReqID}
+	visited := map[int64]struct{}{pullReqID: {}}
+	var edges []*types.PullReqDependency
+
+	for frontier := nodeIDs; len(frontier) > 0; {
+		dependencies, err := c.dependencyStore.List(ctx, frontier)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+		}
+
+		frontier = nil
+		for _, dependency := range dependencies {
+			edges = append(edges, dependency)
+
+			if _, ok := visited[dependency.DependsOnID]; ok {
+				continue
+			}
+			if len(nodeIDs) >= maxDependencyGraphSize {
+				return nil, nil, usererror.BadRequestf(
+					"The dependency graph exceeds the max size of %d pull requests", maxDependencyGraphSize)
+			}
+
+			visited[dependency.DependsOnID] = struct{}{}
+			nodeIDs = append(nodeIDs, dependency.DependsOnID)
+			frontier = append(frontier, dependency.DependsOnID)
+		}
+	}
+
+	return nodeIDs, edges, nil
+}
+
+// dependencyNodes returns the nodes of the pull requests.
+// The nodes of pull requests in repositories the caller isn't allowed to view are redacted.
+func (c *Controller) dependencyNodes(
+	ctx context.Context,
+	session *auth.Session,
+	pullReqIDs []int64,
+) ([]types.PullReqDependencyNode, error) {
+	repos := make(map[int64]*types.Repository)
+	visible := make(map[int64]bool)
+	nodes := make([]types.PullReqDependencyNode, len(pullReqIDs))
+
+	for i, pullReqID := range pullReqIDs {
+		pr, err := c.pullreqStore.Find(ctx, pullReqID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		repo, ok := repos[pr.TargetRepoID]
+		if !ok {
+			repo, err = c.repoStore.Find(ctx, pr.TargetRepoID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find repository of pull request: %w", err)
+			}
+			repos[pr.TargetRepoID] = repo
+
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+			if err != nil && !errors.Is(err, apiauth.ErrNotAuthorized) {
+				return nil, fmt.Errorf("failed to check access to repository of pull request: %w", err)
+			}
+			visible[repo.ID] = err == nil
+		}
+
+		if !visible[repo.ID] {
+			nodes[i] = types.PullReqDependencyNode{
+				ID:       pr.ID,
+				State:    pr.State,
+				Redacted: true,
+			}
+			continue
+		}
+
+		nodes[i] = types.PullReqDependencyNode{
+			ID:       pr.ID,
+			RepoID:   repo.ID,
+			RepoPath: repo.Path,
+			Number:   pr.Number,
+			Title:    pr.Title,
+			State:    pr.State,
+		}
+	}
+
+	return nodes, nil
+}
+
+// unmergedDependencies returns the pull requests the pull request directly depends on which aren't merged yet.
+func (c *Controller) unmergedDependencies(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+) ([]types.PullReqDependencyNode, error) {
+	dependencies, err := c.dependencyStore.List(ctx, []int64{pr.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+	}
+
+	if len(dependencies) == 0 {
+		return nil, nil
+	}
+
+	pullReqIDs := make([]int64, len(dependencies))
+	for i, dependency := range dependencies {
+		pullReqIDs[i] = dependency.DependsOnID
+	}
+
+	nodes, err := c.dependencyNodes(ctx, session, pullReqIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	unmerged := make([]types.PullReqDependencyNode, 0, len(nodes))
+	for _, node := range nodes {
+		if node.State != enum.PullReqStateMerged {
+			unmerged = append(unmerged, node)
+		}
+	}
+
+	return unmerged, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeDependencyStore struct {
+	store.PullReqDependencyStore
+	dependencies []*types.PullReqDependency
+}
+
+func (s *fakeDependencyStore) List(_ context.Context, pullReqIDs []int64) ([]*types.PullReqDependency, error) {
+	var result []*types.PullReqDependency
+	for _, dependency := range s.dependencies {
+		if slices.Contains(pullReqIDs, dependency.PullReqID) {
+			result = append(result, dependency)
+		}
+	}
+	return result, nil
+}
+
+func TestController_DependencyGraph(t *testing.T) {
+	secretRepo := &types.Repository{ID: 2, Path: "space/secret", State: enum.RepoStateActive}
+
+	// the pull request depends on a pull request in a repository the caller can't view,
+	// which depends on another pull request of the same repository.
+	prs := []*types.PullReq{
+		{ID: 1, Number: 1, Title: "feature", TargetRepoID: testRepo.ID, State: enum.PullReqStateOpen},
+		{ID: 2, Number: 7, Title: "secret", TargetRepoID: secretRepo.ID, State: enum.PullReqStateOpen},
+		{ID: 3, Number: 3, Title: "base", TargetRepoID: testRepo.ID, State: enum.PullReqStateMerged},
+	}
+
+	c := newTestController(t, nil, prs...)
+	c.repoStore = &fakeRepoStore{repos: map[int64]*types.Repository{testRepo.ID: testRepo, secretRepo.ID: secretRepo}}
+	c.authorizer = &fakeAuthorizer{deniedRepos: map[string]bool{"secret": true}}
+	c.dependencyStore = &fakeDependencyStore{dependencies: []*types.PullReqDependency{
+		{PullReqID: 1, DependsOnID: 2},
+		{PullReqID: 2, DependsOnID: 3},
+	}}
+
+	graph, err := c.DependencyGraph(context.Background(), testSession, testRepo.Path, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []types.PullReqDependencyNode{
+		{ID: 1, RepoID: testRepo.ID, RepoPath: testRepo.Path, Number: 1, Title: "feature", State: enum.PullReqStateOpen},
+		{ID: 2, State: enum.PullReqStateOpen, Redacted: true},
+		{ID: 3, RepoID: testRepo.ID, RepoPath: testRepo.Path, Number: 3, Title: "base", State: enum.PullReqStateMerged},
+	}
+	if !slices.Equal(graph.Nodes, want) {
+		t.Errorf("nodes = %+v, want %+v", graph.Nodes, want)
+	}
+	if len(graph.Edges) != 2 {
+		t.Errorf("got %d edges, want 2", len(graph.Edges))
+	}
+}
+
+func TestController_UnmergedDependenciesOfHiddenRepo(t *testing.T) {
+	secretRepo := &types.Repository{ID: 2, Path: "space/secret", State: enum.RepoStateActive}
+
+	pr := &types.PullReq{ID: 1, Number: 1, TargetRepoID: testRepo.ID, State: enum.PullReqStateOpen}
+	dependsOn := &types.PullReq{ID: 2, Number: 7, Title: "secret", TargetRepoID: secretRepo.ID,
+		State: enum.PullReqStateOpen}
+
+	c := newTestController(t, nil, pr, dependsOn)
+	c.repoStore = &fakeRepoStore{repos: map[int64]*types.Repository{testRepo.ID: testRepo, secretRepo.ID: secretRepo}}
+	c.authorizer = &fakeAuthorizer{deniedRepos: map[string]bool{"secret": true}}
+	c.dependencyStore = &fakeDependencyStore{dependencies: []*types.PullReqDependency{
+		{PullReqID: 1, DependsOnID: 2},
+	}}
+
+	// the dependency still blocks the merge, without revealing the pull request.
+	unmerged, err := c.unmergedDependencies(context.Background(), testSession, pr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []types.PullReqDependencyNode{{ID: 2, State: enum.PullReqStateOpen, Redacted: true}}
+	if !slices.Equal(unmerged, want) {
+		t.Errorf("unmerged dependencies = %+v, want %+v", unmerged, want)
+	}
+}
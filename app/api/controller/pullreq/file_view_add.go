@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -36,8 +37,14 @@ func (f *FileViewAddInput) Validate() error {
 	if f.Path == "" {
 		return usererror.BadRequest("path can't be empty")
 	}
-	if !git.ValidateCommitSHA(f.CommitSHA) {
-		return usererror.BadRequest("commit_sha is invalid")
+
+	// the commit sha is optional, the latest commit of the pull request is used if it's not provided.
+	if f.CommitSHA != "" {
+		commitSHA, err := sha.New(f.CommitSHA)
+		if err != nil {
+			return usererror.BadRequest("commit_sha is invalid")
+		}
+		f.CommitSHA = commitSHA.String()
 	}
 
 	return nil
@@ -46,9 +53,8 @@ func (f *FileViewAddInput) Validate() error {
 // FileViewAdd marks a file as viewed.
 // NOTE:
 // We take the commit SHA from the user to ensure we mark as viewed only what the user actually sees.
-// The downside is that the caller could provide a SHA that never was part of the PR in the first place.
-// We can't block against that with our current data, as the existence of force push makes it impossible to verify
-// whether the commit ever was part of the PR - it would require us to store the full pr.SourceSHA history.
+// The commit has to be part of the current changes of the PR - commits that were removed by a force push
+// can't be used anymore, as we don't store the full pr.SourceSHA history.
 //
 //nolint:gocognit // refactor if needed.
 func (c *Controller) FileViewAdd(
@@ -72,6 +78,12 @@ func (c *Controller) FileViewAdd(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	if in.CommitSHA == "" {
+		in.CommitSHA = pr.SourceSHA
+	} else if err = c.checkCommitOfPullReq(ctx, repo, pr, in.CommitSHA); err != nil {
+		return nil, err
+	}
+
 	// retrieve file from both provided SHA and mergeBaseSHA to validate user input
 
 	inNode, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
@@ -134,9 +146,9 @@ func (c *Controller) FileViewAdd(
 	}
 
 	// in case of deleted file set sha to nilsha - that's how git diff treats it, too.
-	sha := types.NilSHA
+	fileSHA := sha.Must(in.CommitSHA).ObjectFormat().Nil().String()
 	if inNode != nil {
-		sha = inNode.Node.SHA
+		fileSHA = inNode.Node.SHA
 	}
 
 	now := time.Now().UnixMilli()
@@ -144,8 +156,9 @@ func (c *Controller) FileViewAdd(
 		PullReqID:   pr.ID,
 		PrincipalID: session.Principal.ID,
 
-		Path: in.Path,
-		SHA:  sha,
+		Path:      in.Path,
+		SHA:       fileSHA,
+		CommitSHA: in.CommitSHA,
 
 		// always add as non-obsolete, even if the file view is derived from a non-latest commit sha.
 		// The file sha ensures that the user's review is out of date in case the file changed in the meanwhile.
@@ -164,3 +177,57 @@ func (c *Controller) FileViewAdd(
 
 	return fileView, nil
 }
+
+// checkCommitOfPullReq verifies that the commit is part of the changes of the pull request,
+// meaning it's reachable from the source SHA but not from the merge base.
+func (c *Controller) checkCommitOfPullReq(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	commitSHA string,
+) error {
+	if commitSHA == pr.SourceSHA {
+		return nil
+	}
+
+	isAncestor := func(ancestor, descendant string) (bool, error) {
+		ancestorSHA, err := sha.New(ancestor)
+		if err != nil {
+			return false, err
+		}
+		descendantSHA, err := sha.New(descendant)
+		if err != nil {
+			return false, err
+		}
+
+		out, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   ancestorSHA,
+			DescendantCommitSHA: descendantSHA,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to check if '%s' is ancestor of '%s': %w", ancestor, descendant, err)
+		}
+
+		return out.Ancestor, nil
+	}
+
+	ofSource, err := isAncestor(commitSHA, pr.SourceSHA)
+	if err != nil {
+		return err
+	}
+
+	ofMergeBase := false
+	if ofSource && pr.MergeBaseSHA != "" {
+		ofMergeBase, err = isAncestor(commitSHA, pr.MergeBaseSHA)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !ofSource || ofMergeBase {
+		return usererror.BadRequestf("Commit '%s' is not part of the pull request.", commitSHA)
+	}
+
+	return nil
+}
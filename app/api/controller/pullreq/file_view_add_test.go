@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	fileViewMergeBaseSHA = "1111111111111111111111111111111111111111"
+	fileViewOldSHA       = "2222222222222222222222222222222222222222"
+	fileViewSourceSHA    = "3333333333333333333333333333333333333333"
+	fileViewOtherSHA     = "4444444444444444444444444444444444444444"
+)
+
+// fileViewGit has the history merge base -> old -> source, the other commit isn't related.
+// The file has a different content at every commit.
+type fileViewGit struct {
+	git.Interface
+}
+
+func (fileViewGit) IsAncestor(_ context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error) {
+	order := map[string]int{fileViewMergeBaseSHA: 1, fileViewOldSHA: 2, fileViewSourceSHA: 3}
+
+	ancestor, ok := order[params.AncestorCommitSHA.String()]
+	descendant := order[params.DescendantCommitSHA.String()]
+
+	return git.IsAncestorOutput{Ancestor: ok && ancestor <= descendant}, nil
+}
+
+func (fileViewGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	if params.Path != "file.txt" {
+		return nil, errors.NotFound("path not found")
+	}
+	return &git.GetTreeNodeOutput{Node: git.TreeNode{
+		Type: git.TreeNodeTypeBlob,
+		SHA:  "blob-at-" + params.GitREF,
+		Path: params.Path,
+	}}, nil
+}
+
+type fakeFileViewStore struct {
+	store.PullReqFileViewStore
+	upserted []*types.PullReqFileView
+}
+
+func (s *fakeFileViewStore) Upsert(_ context.Context, fileView *types.PullReqFileView) error {
+	s.upserted = append(s.upserted, fileView)
+	return nil
+}
+
+func TestController_FileViewAdd(t *testing.T) {
+	tests := []struct {
+		name          string
+		commitSHA     string
+		wantStatus    int
+		wantCommitSHA string
+	}{
+		{
+			name:          "latest commit",
+			commitSHA:     fileViewSourceSHA,
+			wantCommitSHA: fileViewSourceSHA,
+		},
+		{
+			name:          "older commit of the pull request",
+			commitSHA:     " " + fileViewOldSHA + " ",
+			wantCommitSHA: fileViewOldSHA,
+		},
+		{
+			name:          "derived from the pull request",
+			wantCommitSHA: fileViewSourceSHA,
+		},
+		{
+			name:       "invalid commit",
+			commitSHA:  "main",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "commit of another branch",
+			commitSHA:  fileViewOtherSHA,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "commit of the target branch",
+			commitSHA:  fileViewMergeBaseSHA,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{
+				ID:           1,
+				Number:       1,
+				SourceRepoID: testRepo.ID,
+				TargetRepoID: testRepo.ID,
+				SourceSHA:    fileViewSourceSHA,
+				MergeBaseSHA: fileViewMergeBaseSHA,
+			}
+
+			fileViewStore := &fakeFileViewStore{}
+
+			c := newTestController(t, fileViewGit{}, pr)
+			c.fileViewStore = fileViewStore
+
+			fileView, err := c.FileViewAdd(context.Background(), testSession, testRepo.Path, pr.Number,
+				&FileViewAddInput{Path: "file.txt", CommitSHA: test.commitSHA})
+			if test.wantStatus != 0 {
+				if status := userErrorStatus(err); status != test.wantStatus {
+					t.Errorf("status = %d, want %d (err: %v)", status, test.wantStatus, err)
+				}
+				if len(fileViewStore.upserted) != 0 {
+					t.Errorf("expected the file view not to be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fileView.CommitSHA != test.wantCommitSHA || fileView.SHA != "blob-at-"+test.wantCommitSHA {
+				t.Errorf("file view = %+v, want viewed at %s", fileView, test.wantCommitSHA)
+			}
+			if len(fileViewStore.upserted) != 1 {
+				t.Errorf("got %d stored file views, want 1", len(fileViewStore.upserted))
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -26,10 +27,12 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
@@ -107,7 +110,7 @@ func (c *Controller) Merge(
 		return nil, nil, err
 	}
 
-	requiredPermission := enum.PermissionRepoPush
+	requiredPermission := enum.PermissionRepoMerge
 	if in.DryRun {
 		requiredPermission = enum.PermissionRepoView
 	}
@@ -221,6 +224,30 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	// the merge methods can be restricted by the repository settings or the settings of its spaces.
+	settingsMethods, err := settings.RepoGetInherited(ctx, c.settings, targetRepo,
+		settings.KeyMergeMethods, enum.MergeMethods)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get allowed merge methods: %w", err)
+	}
+
+	allowedMethods := make([]enum.MergeMethod, 0, len(ruleOut.AllowedMethods))
+	for _, method := range ruleOut.AllowedMethods {
+		if slices.Contains(settingsMethods, method) {
+			allowedMethods = append(allowedMethods, method)
+		}
+	}
+	ruleOut.AllowedMethods = allowedMethods
+
+	if !in.DryRun && !slices.Contains(settingsMethods, in.Method) {
+		return nil, nil, usererror.BadRequestf("Merge method %q is not allowed for the repository.", in.Method)
+	}
+
+	unmergedDependencies, err := c.unmergedDependencies(ctx, session, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// we want to complete the merge independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state.
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -335,11 +362,22 @@ func (c *Controller) Merge(
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
+			UnmergedDependencies:                unmergedDependencies,
 		}
 
 		return out, nil, nil
 	}
 
+	if len(unmergedDependencies) > 0 {
+		log.Ctx(ctx).Info().Msg("aborting pull request merge because of unmerged dependencies")
+
+		return nil, &types.MergeViolations{
+			UnmergedDependencies: unmergedDependencies,
+			RuleViolations:       violations,
+			Message:              "Merge blocked by pull requests that aren't merged yet",
+		}, nil
+	}
+
 	if protection.IsCritical(violations) {
 		sb := strings.Builder{}
 		for i, ruleViolation := range violations {
@@ -359,6 +397,8 @@ func (c *Controller) Merge(
 
 		log.Ctx(ctx).Info().Msgf("aborting pull request merge because of rule violations: %s", sb.String())
 
+		c.reportMergeRuleViolations(ctx, session, targetRepo, pr, violations, true)
+
 		return nil, &types.MergeViolations{
 			RuleViolations: violations,
 			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
@@ -557,6 +597,8 @@ func (c *Controller) Merge(
 	}
 
 	if protection.IsBypassed(violations) {
+		c.reportMergeRuleViolations(ctx, session, targetRepo, pr, violations, false)
+
 		err = c.auditService.Log(ctx,
 			session.Principal,
 			audit.NewResource(
@@ -604,3 +646,22 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// reportMergeRuleViolations reports the protection rule violations of a pull request merge.
+func (c *Controller) reportMergeRuleViolations(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	violations []types.RuleViolations,
+	blocked bool,
+) {
+	c.repoReporter.RuleViolated(ctx, &repoevents.RuleViolatedPayload{
+		RepoID:        targetRepo.ID,
+		PrincipalID:   session.Principal.ID,
+		PullReqNumber: pr.Number,
+		Branches:      []string{pr.TargetBranch},
+		Blocked:       blocked,
+		Violations:    violations,
+	})
+}
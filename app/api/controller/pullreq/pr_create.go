@@ -17,18 +17,23 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
@@ -69,7 +74,8 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	// Pull requests from a fork only require read access to the target repository.
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -82,7 +88,21 @@ func (c *Controller) Create(
 		}
 	}
 
-	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
+	isFork := sourceRepo.ID != targetRepo.ID
+
+	if !isFork {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		}
+	} else if sourceRepo.ForkID != targetRepo.ID {
+		return nil, usererror.BadRequest("The source repository must be a fork of the target repository")
+	}
+
+	if err = controller.CheckRepoNotArchived(targetRepo, enum.PermissionRepoPush); err != nil {
+		return nil, err
+	}
+
+	if !isFork && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
 
@@ -100,9 +120,28 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	in.Description, err = c.attachmentSvc.ProcessMarkdown(ctx, targetRepo.ID, in.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
+	var fetchRef string
+	if isFork {
+		// The commits of the fork are pulled into a temporary ref of the target repository first,
+		// so that a failed fetch or an invalid pull request doesn't consume a pull request number.
+		fetchRef = "fork-" + uuid.NewString()
+
+		sourceSHA, err = c.fetchForkBranch(ctx, session, sourceRepo, targetRepo, in.SourceBranch, fetchRef)
+		if err != nil {
+			return nil, err
+		}
+
+		defer c.deleteForkFetchRef(ctx, session, targetRepo, fetchRef)
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA.String(),
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -124,12 +163,17 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to fetch PR diff stats: %w", err)
 	}
 
-	targetRepo, err = c.repoStore.UpdateOptLock(ctx, targetRepo, func(repo *types.Repository) error {
-		repo.PullReqSeq++
-		return nil
-	})
+	targetRepo, err = c.acquirePullReqSeq(ctx, targetRepo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire PullReqSeq number: %w", err)
+		return nil, err
+	}
+
+	if isFork {
+		// the head ref of a pull request from a fork isn't created by the pull request event handlers.
+		err = c.createForkHeadRef(ctx, session, targetRepo, targetRepo.PullReqSeq, sourceSHA)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	pr := newPullReq(session, targetRepo.PullReqSeq, sourceRepo, targetRepo, in, sourceSHA, mergeBaseSHA)
@@ -172,6 +216,97 @@ func (c *Controller) Create(
 	return pr, nil
 }
 
+// acquirePullReqSeq increments the pull request sequence of the repository.
+// The new number is available in the PullReqSeq field of the returned repository.
+func (c *Controller) acquirePullReqSeq(ctx context.Context, repo *types.Repository) (*types.Repository, error) {
+	repo, err := c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.PullReqSeq++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire PullReqSeq number: %w", err)
+	}
+
+	return repo, nil
+}
+
+// fetchForkBranch fetches the source branch of a fork into the pull request ref with the provided name
+// in the target repository.
+func (c *Controller) fetchForkBranch(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sourceBranch string,
+	refName string,
+) (sha.SHA, error) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	out, err := c.git.FetchRef(ctx, git.FetchRefParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		SourceBranch:  sourceBranch,
+		Type:          gitenum.RefTypePullReqHead,
+		Name:          refName,
+	})
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to fetch source branch of fork: %w", err)
+	}
+
+	return out.SHA, nil
+}
+
+// createForkHeadRef points the head ref of a pull request from a fork at the already fetched source commit.
+func (c *Controller) createForkHeadRef(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	number int64,
+	sourceSHA sha.SHA,
+) error {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypePullReqHead,
+		Name:        strconv.FormatInt(number, 10),
+		NewValue:    sourceSHA,
+		OldValue:    sha.None,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create PR head ref: %w", err)
+	}
+
+	return nil
+}
+
+// deleteForkFetchRef deletes the temporary ref the source branch of a fork has been fetched into.
+func (c *Controller) deleteForkFetchRef(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	fetchRef string,
+) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err == nil {
+		err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+			WriteParams: writeParams,
+			Type:        gitenum.RefTypePullReqHead,
+			Name:        fetchRef,
+			NewValue:    sha.None,
+		})
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete temporary fork ref '%s'", fetchRef)
+	}
+}
+
 // newPullReq creates new pull request object.
 func newPullReq(
 	session *auth.Session,
@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// diffFileCacheDuration is how long the diff of a single file of a pull request version is cached.
+	diffFileCacheDuration = 10 * time.Minute
+)
+
+// diffFileKey identifies the diff of a single file of a pull request version.
+type diffFileKey struct {
+	repoUID      string
+	mergeBaseSHA string
+	sourceSHA    string
+	path         string
+}
+
+// diffFileGetter produces the diff of a single file, it's used as the getter of the diff file cache.
+type diffFileGetter struct {
+	git git.Interface
+}
+
+func (g diffFileGetter) Find(ctx context.Context, key diffFileKey) (*git.FileDiff, error) {
+	reader := git.NewStreamReader(g.git.Diff(ctx, &git.DiffParams{
+		ReadParams:   git.ReadParams{RepoUID: key.repoUID},
+		BaseRef:      key.mergeBaseSHA,
+		HeadRef:      key.sourceSHA,
+		MergeBase:    true,
+		IncludePatch: true,
+	}, gittypes.FileDiffRequest{Path: key.path}))
+
+	for {
+		fileDiff, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, usererror.NotFoundf("File %q isn't changed by the pull request.", key.path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file diff: %w", err)
+		}
+
+		if fileDiff.Path == key.path || fileDiff.OldPath == key.path {
+			return fileDiff, nil
+		}
+	}
+}
+
+// DiffFiles returns a page of the files changed by the pull request, without the patches.
+// Together with DiffFile it allows clients to load diffs of large pull requests lazily, one file at a time.
+func (c *Controller) DiffFiles(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	setSHAs func(sourceSHA, mergeBaseSHA string),
+	page int,
+	limit int,
+) ([]*git.FileDiff, int, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if setSHAs != nil {
+		setSHAs(pr.SourceSHA, pr.MergeBaseSHA)
+	}
+
+	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
+		ReadParams:   git.CreateReadParams(repo),
+		BaseRef:      pr.MergeBaseSHA,
+		HeadRef:      pr.SourceSHA,
+		MergeBase:    true,
+		IncludePatch: false,
+	}))
+
+	first := (page - 1) * limit
+	files := make([]*git.FileDiff, 0, limit)
+
+	var total int
+	for {
+		fileDiff, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read file diff: %w", err)
+		}
+
+		if total >= first && len(files) < limit {
+			files = append(files, fileDiff)
+		}
+
+		total++
+	}
+
+	return files, total, nil
+}
+
+// DiffFile returns the diff of a single file changed by the pull request.
+// The diffs are cached per merge base SHA, source SHA and file path.
+func (c *Controller) DiffFile(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	setSHAs func(sourceSHA, mergeBaseSHA string),
+	path string,
+) (*git.FileDiff, error) {
+	if path == "" {
+		return nil, usererror.BadRequest("File path is required.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if setSHAs != nil {
+		setSHAs(pr.SourceSHA, pr.MergeBaseSHA)
+	}
+
+	fileDiff, err := c.diffFileCache.Get(ctx, diffFileKey{
+		repoUID:      repo.GitUID,
+		mergeBaseSHA: pr.MergeBaseSHA,
+		sourceSHA:    pr.SourceSHA,
+		path:         path,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file diff: %w", err)
+	}
+
+	return fileDiff, nil
+}
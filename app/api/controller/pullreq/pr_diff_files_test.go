@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	diffSourceSHA    = "1111111111111111111111111111111111111111"
+	diffMergeBaseSHA = "2222222222222222222222222222222222222222"
+)
+
+// diffGit streams the changed files, filtered by the requested paths the way git does it.
+type diffGit struct {
+	git.Interface
+	files []*git.FileDiff
+	calls int
+}
+
+func (g *diffGit) Diff(
+	_ context.Context,
+	_ *git.DiffParams,
+	files ...gittypes.FileDiffRequest,
+) (<-chan *git.FileDiff, <-chan error) {
+	g.calls++
+
+	chData := make(chan *git.FileDiff, len(g.files))
+	chErr := make(chan error)
+
+	for _, fileDiff := range g.files {
+		if len(files) > 0 && files[0].Path != fileDiff.Path && files[0].Path != fileDiff.OldPath {
+			continue
+		}
+		chData <- fileDiff
+	}
+	close(chData)
+
+	return chData, chErr
+}
+
+func newDiffPullReq() *types.PullReq {
+	return &types.PullReq{
+		ID:           1,
+		Number:       1,
+		State:        enum.PullReqStateOpen,
+		SourceRepoID: testRepo.ID,
+		TargetRepoID: testRepo.ID,
+		SourceSHA:    diffSourceSHA,
+		MergeBaseSHA: diffMergeBaseSHA,
+	}
+}
+
+func newDiffTestController(t *testing.T, g *diffGit) *Controller {
+	t.Helper()
+
+	c := newTestController(t, g, newDiffPullReq())
+
+	diffFileCache := cache.New[diffFileKey, *git.FileDiff](diffFileGetter{git: g}, time.Minute)
+	t.Cleanup(diffFileCache.Stop)
+	c.diffFileCache = diffFileCache
+
+	return c
+}
+
+func TestController_DiffFiles(t *testing.T) {
+	g := &diffGit{}
+	for _, path := range []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt"} {
+		g.files = append(g.files, &git.FileDiff{Path: path})
+	}
+
+	tests := []struct {
+		name      string
+		page      int
+		limit     int
+		wantPaths []string
+	}{
+		{
+			name:      "first page",
+			page:      1,
+			limit:     2,
+			wantPaths: []string{"a.txt", "b.txt"},
+		},
+		{
+			name:      "middle page",
+			page:      2,
+			limit:     2,
+			wantPaths: []string{"c.txt", "d.txt"},
+		},
+		{
+			name:      "last page",
+			page:      3,
+			limit:     2,
+			wantPaths: []string{"e.txt"},
+		},
+		{
+			name:      "past the last page",
+			page:      4,
+			limit:     2,
+			wantPaths: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newDiffTestController(t, g)
+
+			var sourceSHA, mergeBaseSHA string
+			setSHAs := func(source, mergeBase string) {
+				sourceSHA, mergeBaseSHA = source, mergeBase
+			}
+
+			files, total, err := c.DiffFiles(context.Background(), testSession, testRepo.Path, 1,
+				setSHAs, test.page, test.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if total != len(g.files) {
+				t.Errorf("total = %d, want %d", total, len(g.files))
+			}
+
+			paths := make([]string, len(files))
+			for i, file := range files {
+				paths[i] = file.Path
+			}
+			if len(paths) != len(test.wantPaths) {
+				t.Fatalf("files = %v, want %v", paths, test.wantPaths)
+			}
+			for i := range paths {
+				if paths[i] != test.wantPaths[i] {
+					t.Errorf("files = %v, want %v", paths, test.wantPaths)
+					break
+				}
+			}
+
+			if sourceSHA != diffSourceSHA || mergeBaseSHA != diffMergeBaseSHA {
+				t.Errorf("SHAs = (%s, %s), want (%s, %s)", sourceSHA, mergeBaseSHA, diffSourceSHA, diffMergeBaseSHA)
+			}
+		})
+	}
+}
+
+func TestController_DiffFile(t *testing.T) {
+	g := &diffGit{files: []*git.FileDiff{
+		{Path: "a.txt", Patch: []byte("patch a")},
+		{Path: "new.txt", OldPath: "old.txt", Patch: []byte("patch renamed")},
+	}}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantPath   string
+		wantStatus int
+	}{
+		{
+			name:       "missing path",
+			path:       "",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "changed file",
+			path:     "a.txt",
+			wantPath: "a.txt",
+		},
+		{
+			name:     "old path of a renamed file",
+			path:     "old.txt",
+			wantPath: "new.txt",
+		},
+		{
+			name:       "unchanged file",
+			path:       "b.txt",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newDiffTestController(t, g)
+
+			fileDiff, err := c.DiffFile(context.Background(), testSession, testRepo.Path, 1, nil, test.path)
+			if test.wantStatus != 0 {
+				if userErrorStatus(err) != test.wantStatus {
+					t.Errorf("expected status %d, got %v", test.wantStatus, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fileDiff.Path != test.wantPath {
+				t.Errorf("path = %s, want %s", fileDiff.Path, test.wantPath)
+			}
+		})
+	}
+}
+
+func TestController_DiffFileCached(t *testing.T) {
+	g := &diffGit{files: []*git.FileDiff{{Path: "a.txt", Patch: []byte("patch a")}}}
+	c := newDiffTestController(t, g)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.DiffFile(context.Background(), testSession, testRepo.Path, 1, nil, "a.txt"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if g.calls != 1 {
+		t.Errorf("expected the file diff to be read from git once, got %d", g.calls)
+	}
+
+	// a new version of the pull request isn't served from the cache.
+	pr, _ := c.pullreqStore.FindByNumber(context.Background(), testRepo.ID, 1)
+	pr.SourceSHA = "3333333333333333333333333333333333333333"
+
+	if _, err := c.DiffFile(context.Background(), testSession, testRepo.Path, 1, nil, "a.txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if g.calls != 2 {
+		t.Errorf("expected the file diff of the new version to be read from git, got %d reads", g.calls)
+	}
+}
@@ -56,5 +56,9 @@ func (c *Controller) Find(
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to backfill PR stats")
 	}
 
+	if err := c.pullreqListService.BackfillRisk(ctx, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to backfill PR risk")
+	}
+
 	return pr, nil
 }
@@ -17,6 +17,7 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
@@ -121,9 +122,18 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		// The commits of a fork are pulled into the hidden PR head ref of the target repository.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			headRef := strconv.FormatInt(pr.Number, 10)
+			sourceSHA, err = c.fetchForkBranch(ctx, session, sourceRepo, targetRepo, pr.SourceBranch, headRef)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA.String(),
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
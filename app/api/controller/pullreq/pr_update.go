@@ -80,6 +80,11 @@ func (c *Controller) Update(ctx context.Context,
 		}
 	}
 
+	in.Description, err = c.attachmentSvc.ProcessMarkdown(ctx, targetRepo.ID, in.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process attachments: %w", err)
+	}
+
 	titleOld := pr.Title
 	descriptionOld := pr.Description
 
@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RebaseInput struct {
+	SourceSHA string `json:"source_sha"`
+
+	DryRun      bool `json:"dry_run"`
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RebaseInput) validate() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("Source SHA must be provided")
+	}
+
+	return nil
+}
+
+// Rebase rebases the source branch of a pull request onto the latest commit of its target branch.
+// The source branch is updated through the git hooks, so the pull request gets updated like after a push.
+// If the rebase is blocked by conflicts, the conflicting files are returned as merge violations.
+func (c *Controller) Rebase(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *RebaseInput,
+) (*types.RebaseResponse, *types.MergeViolations, error) {
+	if err := in.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceRepoID != pr.TargetRepoID {
+		return nil, nil, usererror.BadRequest("Rebasing pull requests from forked repositories isn't supported")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil, usererror.BadRequest("A newer commit is available. Only the latest commit can be rebased.")
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          protection.RefActionUpdateForce,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return &types.RebaseResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	sourceSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse source SHA: %w", err)
+	}
+
+	isAncestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   targetBranch.Branch.SHA,
+		DescendantCommitSHA: sourceSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed check ancestor: %w", err)
+	}
+
+	if isAncestor.Ancestor {
+		// The source branch already contains the latest commit from the target branch - nothing to do.
+		return &types.RebaseResponse{
+			AlreadyAncestor: true,
+			RuleViolations:  violations,
+		}, nil, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	refType := gitenum.RefTypeBranch
+	refName := pr.SourceBranch
+	if in.DryRun {
+		refType = gitenum.RefTypeUndefined
+		refName = ""
+	}
+
+	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      pr.TargetBranch,
+		HeadRepoUID:     repo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		RefType:         refType,
+		RefName:         refName,
+		HeadExpectedSHA: sourceSHA,
+		Method:          gitenum.MergeMethodRebase,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("rebase execution failed: %w", err)
+	}
+
+	if in.DryRun {
+		return &types.RebaseResponse{
+			RuleViolations: violations,
+			DryRun:         true,
+			ConflictFiles:  mergeOutput.ConflictFiles,
+		}, nil, nil
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  mergeOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("Rebase blocked by conflicting files: %v", mergeOutput.ConflictFiles),
+		}, nil
+	}
+
+	return &types.RebaseResponse{
+		NewHeadBranchSHA: mergeOutput.MergeSHA,
+		RuleViolations:   violations,
+	}, nil, nil
+}
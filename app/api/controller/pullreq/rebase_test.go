@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	rebaseSourceSHA = "1111111111111111111111111111111111111111"
+	rebaseTargetSHA = "2222222222222222222222222222222222222222"
+	rebaseNewSHA    = "3333333333333333333333333333333333333333"
+)
+
+type rebaseGit struct {
+	git.Interface
+	ancestor    bool
+	mergeOutput git.MergeOutput
+	merges      []*git.MergeParams
+}
+
+func (g *rebaseGit) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{Name: params.BranchName, SHA: sha.Must(rebaseTargetSHA)}}, nil
+}
+
+func (g *rebaseGit) IsAncestor(_ context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error) {
+	if params.AncestorCommitSHA.String() != rebaseTargetSHA || params.DescendantCommitSHA.String() != rebaseSourceSHA {
+		return git.IsAncestorOutput{}, errors.InvalidArgument("unexpected commits")
+	}
+	return git.IsAncestorOutput{Ancestor: g.ancestor}, nil
+}
+
+func (g *rebaseGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.merges = append(g.merges, params)
+	return g.mergeOutput, nil
+}
+
+func newRebasePullReq() *types.PullReq {
+	return &types.PullReq{
+		ID:           1,
+		Number:       1,
+		State:        enum.PullReqStateOpen,
+		SourceRepoID: testRepo.ID,
+		TargetRepoID: testRepo.ID,
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		SourceSHA:    rebaseSourceSHA,
+	}
+}
+
+func TestController_RebaseRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		pr    func(pr *types.PullReq)
+		input RebaseInput
+	}{
+		{
+			name:  "missing source SHA",
+			input: RebaseInput{},
+		},
+		{
+			name:  "closed pull request",
+			pr:    func(pr *types.PullReq) { pr.State = enum.PullReqStateClosed },
+			input: RebaseInput{SourceSHA: rebaseSourceSHA},
+		},
+		{
+			name:  "fork pull request",
+			pr:    func(pr *types.PullReq) { pr.SourceRepoID = 2 },
+			input: RebaseInput{SourceSHA: rebaseSourceSHA},
+		},
+		{
+			name:  "outdated source SHA",
+			input: RebaseInput{SourceSHA: rebaseTargetSHA},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := newRebasePullReq()
+			if test.pr != nil {
+				test.pr(pr)
+			}
+
+			g := &rebaseGit{}
+			c := newTestController(t, g, pr)
+
+			_, _, err := c.Rebase(context.Background(), testSession, testRepo.Path, pr.Number, &test.input)
+			if userErrorStatus(err) != http.StatusBadRequest {
+				t.Errorf("expected bad request, got %v", err)
+			}
+			if len(g.merges) > 0 {
+				t.Errorf("expected no rebase, got %d", len(g.merges))
+			}
+		})
+	}
+}
+
+func TestController_Rebase(t *testing.T) {
+	tests := []struct {
+		name           string
+		ancestor       bool
+		dryRun         bool
+		mergeOutput    git.MergeOutput
+		wantResponse   *types.RebaseResponse
+		wantViolations *types.MergeViolations
+		wantRefType    gitenum.RefType
+	}{
+		{
+			name:         "already up to date",
+			ancestor:     true,
+			wantResponse: &types.RebaseResponse{AlreadyAncestor: true},
+		},
+		{
+			name:         "dry run with conflicts",
+			dryRun:       true,
+			mergeOutput:  git.MergeOutput{ConflictFiles: []string{"a.txt"}},
+			wantResponse: &types.RebaseResponse{DryRun: true, ConflictFiles: []string{"a.txt"}},
+			wantRefType:  gitenum.RefTypeUndefined,
+		},
+		{
+			name:        "conflicts",
+			mergeOutput: git.MergeOutput{ConflictFiles: []string{"a.txt"}},
+			wantViolations: &types.MergeViolations{
+				ConflictFiles: []string{"a.txt"},
+				Message:       "Rebase blocked by conflicting files: [a.txt]",
+			},
+			wantRefType: gitenum.RefTypeBranch,
+		},
+		{
+			name:         "rebased",
+			mergeOutput:  git.MergeOutput{MergeSHA: sha.Must(rebaseNewSHA)},
+			wantResponse: &types.RebaseResponse{NewHeadBranchSHA: sha.Must(rebaseNewSHA)},
+			wantRefType:  gitenum.RefTypeBranch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := newRebasePullReq()
+			g := &rebaseGit{ancestor: test.ancestor, mergeOutput: test.mergeOutput}
+			c := newTestController(t, g, pr)
+
+			response, violations, err := c.Rebase(context.Background(), testSession, testRepo.Path, pr.Number,
+				&RebaseInput{SourceSHA: rebaseSourceSHA, DryRun: test.dryRun})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(response, test.wantResponse) {
+				t.Errorf("response = %+v, want %+v", response, test.wantResponse)
+			}
+			if !reflect.DeepEqual(violations, test.wantViolations) {
+				t.Errorf("violations = %+v, want %+v", violations, test.wantViolations)
+			}
+
+			if test.ancestor {
+				if len(g.merges) > 0 {
+					t.Errorf("expected no rebase of an up to date branch, got %d", len(g.merges))
+				}
+				return
+			}
+
+			if len(g.merges) != 1 {
+				t.Fatalf("expected a single rebase, got %d", len(g.merges))
+			}
+
+			merge := g.merges[0]
+			if merge.Method != gitenum.MergeMethodRebase {
+				t.Errorf("merge method = %s, want %s", merge.Method, gitenum.MergeMethodRebase)
+			}
+			if merge.RefType != test.wantRefType {
+				t.Errorf("ref type = %v, want %v", merge.RefType, test.wantRefType)
+			}
+			if merge.HeadExpectedSHA.String() != rebaseSourceSHA {
+				t.Errorf("expected head SHA = %s, want %s", merge.HeadExpectedSHA, rebaseSourceSHA)
+			}
+		})
+	}
+}
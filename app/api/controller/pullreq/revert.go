@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RevertInput struct {
+	// RevertBranch is the name of the new branch holding the revert commit, "revert-pullreq-<number>" by default.
+	RevertBranch string `json:"revert_branch"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RevertInput) sanitize(pr *types.PullReq) {
+	in.RevertBranch = strings.TrimSpace(in.RevertBranch)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.RevertBranch == "" {
+		in.RevertBranch = fmt.Sprintf("revert-pullreq-%d", pr.Number)
+	}
+
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("Revert %q", pr.Title)
+	}
+
+	if in.Message == "" {
+		in.Message = fmt.Sprintf("This reverts pull request #%d.", pr.Number)
+	}
+}
+
+// Revert reverts the changes of a merged pull request: it creates a new branch with a commit that undoes
+// the merge commit (or the squashed or rebased commits) of the pull request on top of the latest commit
+// of the target branch, and opens a new pull request for it.
+// If the changes can't be reverted because of conflicts, the conflicting files are returned as merge violations.
+func (c *Controller) Revert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *RevertInput,
+) (*types.PullReq, *types.MergeViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+		return nil, nil, usererror.BadRequest("Only merged pull requests can be reverted")
+	}
+
+	in.sanitize(pr)
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          protection.RefActionCreate,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{in.RevertBranch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	message := in.Title
+	if in.Message != "" {
+		message += "\n\n" + in.Message
+	}
+
+	now := time.Now()
+	revertOutput, err := c.git.Revert(ctx, &git.RevertParams{
+		WriteParams:     writeParams,
+		ParentCommitSHA: targetBranch.Branch.SHA,
+		FromCommitSHA:   sha.Must(*pr.MergeTargetSHA),
+		ToCommitSHA:     sha.Must(*pr.MergeSHA),
+		RevertBranch:    in.RevertBranch,
+		Message:         message,
+		Committer:       identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
+		CommitterDate:   &now,
+		AuthorDate:      &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("revert execution failed: %w", err)
+	}
+
+	if len(revertOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  revertOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("Revert blocked by conflicting files: %v", revertOutput.ConflictFiles),
+		}, nil
+	}
+
+	revertPR, err := c.Create(ctx, session, repoRef, &CreateInput{
+		Title:        in.Title,
+		Description:  fmt.Sprintf("Reverts #%d\n\n%s", pr.Number, in.Message),
+		SourceBranch: in.RevertBranch,
+		TargetBranch: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create revert pull request: %w", err)
+	}
+
+	return revertPR, nil, nil
+}
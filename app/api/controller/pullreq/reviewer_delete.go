@@ -21,6 +21,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -101,5 +102,10 @@ func (c *Controller) ReviewerDelete(
 		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after reviewer removal")
 	}
 
+	c.eventReporter.ReviewerRemoved(ctx, &events.ReviewerRemovedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ReviewerID: reviewer.PrincipalID,
+	})
+
 	return nil
 }
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReviewerRequest explicitly requests a review of the pull request from a user or a service account.
+// Unlike ReviewerAdd, the caller can't request a review from themselves.
+func (c *Controller) ReviewerRequest(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	reviewerID int64,
+) (*types.PullReqReviewer, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Can't request review for a pull request that isn't open")
+	}
+
+	if reviewerID == session.Principal.ID {
+		return nil, usererror.BadRequest("Can't request a review from yourself.")
+	}
+
+	if reviewerID == pr.CreatedBy {
+		return nil, usererror.BadRequest("Pull request author can't be added as a reviewer.")
+	}
+
+	reviewerPrincipal, err := c.principalStore.Find(ctx, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviewer principal: %w", err)
+	}
+
+	if reviewerPrincipal.Type != enum.PrincipalTypeUser && reviewerPrincipal.Type != enum.PrincipalTypeServiceAccount {
+		return nil, usererror.BadRequest("Review can only be requested from a user or a service account.")
+	}
+
+	if reviewerPrincipal.Blocked {
+		return nil, usererror.BadRequest("Can't request a review from a blocked principal.")
+	}
+
+	reviewerInfo := reviewerPrincipal.ToPrincipalInfo()
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+		Principal: *reviewerPrincipal,
+		Metadata:  nil,
+	}, repo, enum.PermissionRepoReview); err != nil {
+		log.Ctx(ctx).Info().Msgf("Reviewer principal: %s access error: %s", reviewerInfo.UID, err)
+		return nil, usererror.BadRequest("The reviewer doesn't have enough permissions for the repository.")
+	}
+
+	reviewer, _, err := c.pullreqService.AddReviewer(ctx, &session.Principal, repo, pr, reviewerInfo,
+		enum.PullReqReviewerTypeRequested)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request pull request review: %w", err)
+	}
+
+	return reviewer, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_ReviewerRequestRejected(t *testing.T) {
+	const (
+		authorID       = 1
+		userID         = 2
+		serviceID      = 3
+		blockedID      = 4
+		noPermissionID = 5
+	)
+
+	principals := map[int64]*types.Principal{
+		authorID:       {ID: authorID, UID: "author", Type: enum.PrincipalTypeUser},
+		userID:         {ID: userID, UID: "user", Type: enum.PrincipalTypeUser},
+		serviceID:      {ID: serviceID, UID: "service", Type: enum.PrincipalTypeService},
+		blockedID:      {ID: blockedID, UID: "blocked", Type: enum.PrincipalTypeUser, Blocked: true},
+		noPermissionID: {ID: noPermissionID, UID: "no-permission", Type: enum.PrincipalTypeUser},
+	}
+
+	tests := []struct {
+		name       string
+		state      enum.PullReqState
+		reviewerID int64
+	}{
+		{
+			name:       "closed pull request",
+			state:      enum.PullReqStateClosed,
+			reviewerID: userID,
+		},
+		{
+			name:       "caller",
+			state:      enum.PullReqStateOpen,
+			reviewerID: testSession.Principal.ID,
+		},
+		{
+			name:       "author",
+			state:      enum.PullReqStateOpen,
+			reviewerID: authorID,
+		},
+		{
+			name:       "service",
+			state:      enum.PullReqStateOpen,
+			reviewerID: serviceID,
+		},
+		{
+			name:       "blocked",
+			state:      enum.PullReqStateOpen,
+			reviewerID: blockedID,
+		},
+		{
+			name:       "without permission",
+			state:      enum.PullReqStateOpen,
+			reviewerID: noPermissionID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{
+				ID:           1,
+				Number:       1,
+				State:        test.state,
+				CreatedBy:    authorID,
+				SourceRepoID: testRepo.ID,
+				TargetRepoID: testRepo.ID,
+			}
+
+			c := newTestController(t, nil, pr)
+			c.principalStore = &fakePrincipalStore{principals: principals}
+			c.authorizer = &fakeAuthorizer{deniedPrincipals: map[int64]bool{noPermissionID: true}}
+
+			// the pull request service isn't set, so any request that passes the checks panics.
+			_, err := c.ReviewerRequest(context.Background(), testSession, testRepo.Path, pr.Number, test.reviewerID)
+			if userErrorStatus(err) != http.StatusBadRequest {
+				t.Errorf("expected bad request, got %v", err)
+			}
+		})
+	}
+}
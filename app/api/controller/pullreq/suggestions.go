@@ -18,6 +18,8 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"strings"
+
+	"github.com/harness/gitness/types"
 )
 
 type suggestion struct {
@@ -199,3 +201,44 @@ func cutLongestPrefix(s string, c rune) (string, string) {
 
 	return s[:i], s[i:]
 }
+
+// appendCoAuthorTrailers appends a Co-authored-by trailer for each of the provided principals to the commit message.
+// Principals without an email address and duplicates are skipped.
+func appendCoAuthorTrailers(message string, coAuthors []types.PrincipalInfo) string {
+	trailers := strings.Builder{}
+	seen := map[string]struct{}{}
+
+	for _, coAuthor := range coAuthors {
+		email := strings.TrimSpace(coAuthor.Email)
+		if email == "" {
+			continue
+		}
+
+		key := strings.ToLower(email)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		name := strings.TrimSpace(coAuthor.DisplayName)
+		if name == "" {
+			name = coAuthor.UID
+		}
+
+		if trailers.Len() > 0 {
+			trailers.WriteByte('\n')
+		}
+		fmt.Fprintf(&trailers, "Co-authored-by: %s <%s>", name, email)
+	}
+
+	if trailers.Len() == 0 {
+		return message
+	}
+
+	// trailers have to be in the last paragraph of the commit message
+	if message == "" {
+		return trailers.String()
+	}
+
+	return message + "\n\n" + trailers.String()
+}
@@ -17,6 +17,8 @@ package pullreq
 import (
 	"reflect"
 	"testing"
+
+	"github.com/harness/gitness/types"
 )
 
 func Test_parseSuggestions(t *testing.T) {
@@ -415,3 +417,45 @@ func Test_parseSuggestions(t *testing.T) {
 		})
 	}
 }
+
+func Test_appendCoAuthorTrailers(t *testing.T) {
+	jane := types.PrincipalInfo{UID: "jane", DisplayName: "Jane Doe", Email: "jane@example.com"}
+	john := types.PrincipalInfo{UID: "john", Email: "john@example.com"}
+
+	tests := []struct {
+		name      string
+		message   string
+		coAuthors []types.PrincipalInfo
+		want      string
+	}{
+		{
+			name:    "no co-authors",
+			message: "body",
+			want:    "body",
+		},
+		{
+			name:      "empty message",
+			coAuthors: []types.PrincipalInfo{jane},
+			want:      "Co-authored-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:      "appended as last paragraph",
+			message:   "body",
+			coAuthors: []types.PrincipalInfo{jane, john},
+			want:      "body\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: john <john@example.com>",
+		},
+		{
+			name:      "duplicates and principals without email are skipped",
+			message:   "body",
+			coAuthors: []types.PrincipalInfo{jane, {UID: "nomail"}, {DisplayName: "Jane", Email: "JANE@example.com"}},
+			want:      "body\n\nCo-authored-by: Jane Doe <jane@example.com>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendCoAuthorTrailers(tt.message, tt.coAuthors); got != tt.want {
+				t.Errorf("appendCoAuthorTrailers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
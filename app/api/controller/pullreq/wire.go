@@ -17,6 +17,8 @@ package pullreq
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/attachment"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
@@ -25,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -55,15 +58,20 @@ func ProvideController(
 	userGroupReviewerStore store.UserGroupReviewersStore,
 	principalInfoCache store.PrincipalInfoCache,
 	fileViewStore store.PullReqFileViewStore,
+	autoMergeStore store.PullReqAutoMergeStore,
+	dependencyStore store.PullReqDependencyStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
-	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, codeCommentMigrator *codecomments.Migrator,
+	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, repoReporter *repoevents.Reporter,
+	codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, pullreqListService *pullreq.ListService,
 	ruleManager *protection.Manager, sseStreamer sse.Streamer,
 	codeOwners *codeowners.Service, locker *locker.Locker, importer *migrate.PullReq,
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	attachmentSvc *attachment.Service,
+	settings *settings.Service,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -80,10 +88,13 @@ func ProvideController(
 		userGroupReviewerStore,
 		principalInfoCache,
 		fileViewStore,
+		autoMergeStore,
+		dependencyStore,
 		membershipStore,
 		checkStore,
 		rpcClient,
 		eventReporter,
+		repoReporter,
 		codeCommentMigrator,
 		pullreqService,
 		pullreqListService,
@@ -95,5 +106,7 @@ func ProvideController(
 		labelSvc,
 		instrumentation,
 		userGroupService,
+		attachmentSvc,
+		settings,
 	)
 }
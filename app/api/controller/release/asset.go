@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	releasesvc "github.com/harness/gitness/app/services/release"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	MaxAssetSize = 2 << 30 // 2 GiB file limit set in Handler
+
+	maxAssetNameLength = 256
+	peekBytes          = 512
+)
+
+// UploadAsset attaches a file to the release. The content type of the file is detected from its content.
+func (c *Controller) UploadAsset(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	releaseID int64,
+	name string,
+	file io.Reader,
+) (*types.ReleaseAsset, error) {
+	name, err := sanitizeAssetName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, release, err := c.getReleaseCheckAccess(ctx, session, repoRef, releaseID, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	// the files of the repository are copied to a different blob store while its storage pool changes.
+	if repo.State == enum.RepoStateStorageMove {
+		return nil, usererror.BadRequest("Repository is being moved to a different storage pool.")
+	}
+
+	if file == nil {
+		return nil, usererror.BadRequest("no file provided")
+	}
+
+	bufReader := bufio.NewReader(file)
+	buf, err := bufReader.Peek(peekBytes)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	mType := mimetype.Detect(buf)
+
+	fileName := uuid.New().String()
+	counter := blob.NewCountingReader(bufReader, 0)
+
+	filePath := releasesvc.AssetBucketPath(repo.ID, fileName)
+	blobStore := c.blobStore.Get(repo.StoragePool)
+	if err = blobStore.Upload(ctx, counter, filePath); err != nil {
+		return nil, fmt.Errorf("failed to upload release asset: %w", err)
+	}
+
+	asset := &types.ReleaseAsset{
+		ReleaseID:   release.ID,
+		RepoID:      repo.ID,
+		Name:        name,
+		FileName:    fileName,
+		ContentType: mType.String(),
+		Size:        counter.N(),
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if err = c.releaseAssetStore.Create(ctx, asset); err != nil {
+		if dErr := blobStore.Delete(ctx, filePath); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msgf("failed to delete release asset file %q", filePath)
+		}
+		return nil, fmt.Errorf("failed to store release asset details: %w", err)
+	}
+
+	return asset, nil
+}
+
+// DownloadAsset returns the signed URL of the release asset file if the blob store supports it,
+// otherwise it returns the content of the file.
+func (c *Controller) DownloadAsset(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	releaseID int64,
+	assetID int64,
+) (*types.ReleaseAsset, string, io.ReadCloser, error) {
+	repo, release, err := c.getReleaseCheckAccess(ctx, session, repoRef, releaseID, enum.PermissionRepoView)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	asset, err := c.releaseAssetStore.FindInRelease(ctx, release.ID, assetID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find release asset: %w", err)
+	}
+
+	filePath := releasesvc.AssetBucketPath(repo.ID, asset.FileName)
+	blobStore := c.blobStore.Get(repo.StoragePool)
+
+	signedURL, err := blobStore.GetSignedURL(ctx, filePath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return asset, signedURL, nil, nil
+	}
+
+	file, err := blobStore.Download(ctx, filePath)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download release asset from blobstore: %w", err)
+	}
+
+	return asset, "", file, nil
+}
+
+// DeleteAsset removes the file from the release.
+func (c *Controller) DeleteAsset(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	releaseID int64,
+	assetID int64,
+) error {
+	repo, release, err := c.getReleaseCheckAccess(ctx, session, repoRef, releaseID, enum.PermissionRepoPush)
+	if err != nil {
+		return err
+	}
+
+	asset, err := c.releaseAssetStore.FindInRelease(ctx, release.ID, assetID)
+	if err != nil {
+		return fmt.Errorf("failed to find release asset: %w", err)
+	}
+
+	if err = c.releaseAssetStore.Delete(ctx, asset.ID); err != nil {
+		return fmt.Errorf("failed to delete release asset: %w", err)
+	}
+
+	filePath := releasesvc.AssetBucketPath(repo.ID, asset.FileName)
+	if err = c.blobStore.Get(repo.StoragePool).Delete(ctx, filePath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete release asset file %q", filePath)
+	}
+
+	return nil
+}
+
+func sanitizeAssetName(name string) (string, error) {
+	name = path.Base(strings.TrimSpace(name))
+	if name == "" || name == "." || name == "/" {
+		return "", usererror.BadRequest("release asset name can't be empty")
+	}
+
+	if len(name) > maxAssetNameLength {
+		return "", usererror.BadRequestf("release asset name is too long (maximum is %d characters)",
+			maxAssetNameLength)
+	}
+
+	for _, r := range name {
+		if r < 32 || r == 127 {
+			return "", usererror.BadRequest("release asset name contains invalid characters")
+		}
+	}
+
+	return name, nil
+}
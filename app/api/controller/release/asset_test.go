@@ -0,0 +1,297 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testRepoID = 1
+
+	publishedReleaseID = 1
+	draftReleaseID     = 2
+
+	pusherID = 100
+	viewerID = 101
+)
+
+// fakeAuthorizer permits everything, except pushes of the viewer.
+type fakeAuthorizer struct{}
+
+func (fakeAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return session.Principal.ID != viewerID || permission == enum.PermissionRepoView, nil
+}
+
+func (a fakeAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		check := &permissionChecks[i]
+		if ok, err := a.Check(ctx, session, &check.Scope, &check.Resource, check.Permission); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return &types.Repository{ID: testRepoID, Identifier: "repo", Path: "space/repo"}, nil
+}
+
+type fakeReleaseStore struct {
+	store.ReleaseStore
+}
+
+func (fakeReleaseStore) FindInRepo(_ context.Context, repoID, id int64) (*types.Release, error) {
+	if repoID != testRepoID || (id != publishedReleaseID && id != draftReleaseID) {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Release{ID: id, RepoID: repoID, IsDraft: id == draftReleaseID}, nil
+}
+
+type fakeReleaseAssetStore struct {
+	store.ReleaseAssetStore
+	assets    []*types.ReleaseAsset
+	createErr error
+}
+
+func (s *fakeReleaseAssetStore) Create(_ context.Context, asset *types.ReleaseAsset) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+	asset.ID = int64(len(s.assets) + 1)
+	s.assets = append(s.assets, asset)
+	return nil
+}
+
+func (s *fakeReleaseAssetStore) FindInRelease(_ context.Context, releaseID, id int64) (*types.ReleaseAsset, error) {
+	for _, asset := range s.assets {
+		if asset.ReleaseID == releaseID && asset.ID == id {
+			return asset, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// fakeBlobStore keeps the files in memory and doesn't support signed URLs.
+type fakeBlobStore struct {
+	files map[string][]byte
+}
+
+func (s *fakeBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	s.files[filePath] = data
+	return nil
+}
+
+func (s *fakeBlobStore) GetSignedURL(context.Context, string) (string, error) {
+	return "", blob.ErrNotSupported
+}
+
+func (s *fakeBlobStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeBlobStore) Delete(_ context.Context, filePath string) error {
+	delete(s.files, filePath)
+	return nil
+}
+
+func newTestController(assetStore *fakeReleaseAssetStore, blobStore *fakeBlobStore) *Controller {
+	return NewController(fakeAuthorizer{}, fakeRepoStore{}, fakeReleaseStore{}, assetStore, nil,
+		blob.NewPoolStore(blobStore, nil))
+}
+
+func testSession(principalID int64) *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: principalID, Type: enum.PrincipalTypeUser}}
+}
+
+func userErrorStatus(err error) int {
+	var uErr *usererror.Error
+	if errors.As(err, &uErr) {
+		return uErr.Status
+	}
+	return 0
+}
+
+func TestSanitizeAssetName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", input: "app.tar.gz", want: "app.tar.gz"},
+		{name: "trimmed", input: "  app.zip ", want: "app.zip"},
+		{name: "directories are dropped", input: "../../bin/app", want: "app"},
+		{name: "empty", input: " ", wantErr: true},
+		{name: "root", input: "/", wantErr: true},
+		{name: "current directory", input: ".", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", maxAssetNameLength+1), wantErr: true},
+		{name: "control character", input: "app\x00.zip", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sanitizeAssetName(test.input)
+			if test.wantErr {
+				if userErrorStatus(err) != http.StatusBadRequest {
+					t.Errorf("expected bad request, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("name = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestController_UploadAsset(t *testing.T) {
+	ctx := context.Background()
+	assetStore := &fakeReleaseAssetStore{}
+	blobStore := &fakeBlobStore{files: map[string][]byte{}}
+	c := newTestController(assetStore, blobStore)
+
+	content := "#!/bin/sh\necho hello\n"
+
+	asset, err := c.UploadAsset(ctx, testSession(pusherID), "space/repo", publishedReleaseID,
+		"bin/hello.sh", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if asset.Name != "hello.sh" || asset.Size != int64(len(content)) || asset.CreatedBy != pusherID {
+		t.Errorf("asset = %+v, want hello.sh of %d bytes created by %d", asset, len(content), pusherID)
+	}
+	if !strings.HasPrefix(asset.ContentType, "text/") {
+		t.Errorf("content type = %q, want a text type", asset.ContentType)
+	}
+	if len(blobStore.files) != 1 {
+		t.Errorf("expected a single uploaded file, got %d", len(blobStore.files))
+	}
+
+	_, err = c.UploadAsset(ctx, testSession(viewerID), "space/repo", publishedReleaseID,
+		"hello.sh", strings.NewReader(content))
+	if err == nil {
+		t.Errorf("expected the upload of a principal without push permission to fail")
+	}
+
+	// the uploaded file is deleted if the asset can't be stored.
+	assetStore.createErr = errors.New("db error")
+	_, err = c.UploadAsset(ctx, testSession(pusherID), "space/repo", publishedReleaseID,
+		"other.sh", strings.NewReader(content))
+	if err == nil {
+		t.Fatalf("expected an error when the asset can't be stored")
+	}
+	if len(blobStore.files) != 1 {
+		t.Errorf("expected the uploaded file to be deleted, got %d files", len(blobStore.files))
+	}
+}
+
+func TestController_DownloadAsset(t *testing.T) {
+	ctx := context.Background()
+	assetStore := &fakeReleaseAssetStore{}
+	blobStore := &fakeBlobStore{files: map[string][]byte{}}
+	c := newTestController(assetStore, blobStore)
+
+	for _, releaseID := range []int64{publishedReleaseID, draftReleaseID} {
+		_, err := c.UploadAsset(ctx, testSession(pusherID), "space/repo", releaseID,
+			"notes.txt", strings.NewReader("release notes"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		principalID  int64
+		releaseID    int64
+		assetID      int64
+		wantNotFound bool
+	}{
+		{name: "published release", principalID: viewerID, releaseID: publishedReleaseID, assetID: 1},
+		{name: "draft release", principalID: pusherID, releaseID: draftReleaseID, assetID: 2},
+		{
+			name:         "draft release without push permission",
+			principalID:  viewerID,
+			releaseID:    draftReleaseID,
+			assetID:      2,
+			wantNotFound: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asset, signedURL, file, err := c.DownloadAsset(ctx, testSession(test.principalID), "space/repo",
+				test.releaseID, test.assetID)
+			if test.wantNotFound {
+				if userErrorStatus(err) != http.StatusNotFound {
+					t.Errorf("expected not found, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer file.Close()
+
+			data, err := io.ReadAll(file)
+			if err != nil {
+				t.Fatalf("failed to read asset: %v", err)
+			}
+			if asset.ID != test.assetID || signedURL != "" || string(data) != "release notes" {
+				t.Errorf("asset %d, signed URL %q, content %q", asset.ID, signedURL, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer        authz.Authorizer
+	repoStore         store.RepoStore
+	releaseStore      store.ReleaseStore
+	releaseAssetStore store.ReleaseAssetStore
+	git               git.Interface
+	blobStore         *blob.PoolStore
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	releaseStore store.ReleaseStore,
+	releaseAssetStore store.ReleaseAssetStore,
+	git git.Interface,
+	blobStore *blob.PoolStore,
+) *Controller {
+	return &Controller{
+		authorizer:        authorizer,
+		repoStore:         repoStore,
+		releaseStore:      releaseStore,
+		releaseAssetStore: releaseAssetStore,
+		git:               git,
+		blobStore:         blobStore,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// getReleaseCheckAccess fetches the release and checks the access to its repository.
+// Draft releases are visible only to the principals allowed to push to the repository.
+func (c *Controller) getReleaseCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, releaseID int64, reqPermission enum.Permission,
+) (*types.Repository, *types.Release, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release, err := c.releaseStore.FindInRepo(ctx, repo.ID, releaseID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find release: %w", err)
+	}
+
+	if release.IsDraft && reqPermission != enum.PermissionRepoPush {
+		if !c.canPush(ctx, session, repo) {
+			return nil, nil, usererror.ErrNotFound
+		}
+	}
+
+	return repo, release, nil
+}
+
+func (c *Controller) canPush(ctx context.Context, session *auth.Session, repo *types.Repository) bool {
+	return apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush) == nil
+}
+
+// backfillAssets sets the assets of the releases.
+func (c *Controller) backfillAssets(ctx context.Context, releases ...*types.Release) error {
+	releaseIDs := make([]int64, len(releases))
+	releaseMap := make(map[int64]*types.Release, len(releases))
+	for i, release := range releases {
+		releaseIDs[i] = release.ID
+		releaseMap[release.ID] = release
+	}
+
+	assets, err := c.releaseAssetStore.ListByReleases(ctx, releaseIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list release assets: %w", err)
+	}
+
+	for _, asset := range assets {
+		release := releaseMap[asset.ReleaseID]
+		release.Assets = append(release.Assets, asset)
+	}
+
+	return nil
+}
+
+func validateTitle(title string) error {
+	const maxLen = 256
+	if utf8.RuneCountInString(title) > maxLen {
+		return usererror.BadRequestf("release title is too long (maximum is %d characters)", maxLen)
+	}
+
+	return nil
+}
+
+func validateNotes(notes string) error {
+	const maxLen = 64 << 10 // 64K
+	if len(notes) > maxLen {
+		return usererror.BadRequest("release notes are too long")
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	TagName      string `json:"tag_name"`
+	Title        string `json:"title"`
+	Notes        string `json:"notes"`
+	IsDraft      bool   `json:"is_draft"`
+	IsPrerelease bool   `json:"is_prerelease"`
+}
+
+func (in *CreateInput) Sanitize() error {
+	in.TagName = strings.TrimSpace(in.TagName)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Notes = strings.TrimSpace(in.Notes)
+
+	if in.TagName == "" {
+		return usererror.BadRequest("release tag name can't be empty")
+	}
+
+	if in.Title == "" {
+		in.Title = in.TagName
+	}
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	return validateNotes(in.Notes)
+}
+
+// Create creates a new release of an existing tag.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Release, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.verifyTagExistence(ctx, repo, in.TagName); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	release := &types.Release{
+		Version:      0,
+		RepoID:       repo.ID,
+		TagName:      in.TagName,
+		Title:        in.Title,
+		Notes:        in.Notes,
+		IsDraft:      in.IsDraft,
+		IsPrerelease: in.IsPrerelease,
+		CreatedBy:    session.Principal.ID,
+		Created:      now,
+		Updated:      now,
+		Assets:       []*types.ReleaseAsset{},
+	}
+	if !in.IsDraft {
+		release.Published = &now
+	}
+
+	if err = c.releaseStore.Create(ctx, release); err != nil {
+		return nil, fmt.Errorf("failed to create release: %w", err)
+	}
+
+	return release, nil
+}
+
+func (c *Controller) verifyTagExistence(ctx context.Context, repo *types.Repository, tagName string) error {
+	_, err := c.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.CreateReadParams(repo),
+		Name:       tagName,
+		Type:       gitenum.RefTypeTag,
+	})
+	if errors.AsStatus(err) == errors.StatusNotFound {
+		return usererror.BadRequestf("tag %q does not exist in the repository", tagName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check existence of the tag %q: %w", tagName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	releasesvc "github.com/harness/gitness/app/services/release"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delete deletes a release together with its assets. The tag of the release is left unchanged.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	releaseID int64,
+) error {
+	repo, release, err := c.getReleaseCheckAccess(ctx, session, repoRef, releaseID, enum.PermissionRepoPush)
+	if err != nil {
+		return err
+	}
+
+	if err = c.backfillAssets(ctx, release); err != nil {
+		return err
+	}
+
+	if err = c.releaseStore.Delete(ctx, release.ID); err != nil {
+		return fmt.Errorf("failed to delete release: %w", err)
+	}
+
+	// the files are removed after the release, orphaned files are better than assets without the content.
+	blobStore := c.blobStore.Get(repo.StoragePool)
+	for _, asset := range release.Assets {
+		filePath := releasesvc.AssetBucketPath(repo.ID, asset.FileName)
+		if err = blobStore.Delete(ctx, filePath); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete release asset file %q", filePath)
+		}
+	}
+
+	return nil
+}
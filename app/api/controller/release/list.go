@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the releases of a repository.
+// Draft releases are included only for the principals allowed to push to the repository.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.ReleaseFilter,
+) ([]*types.Release, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.IncludeDrafts && !c.canPush(ctx, session, repo) {
+		filter.IncludeDrafts = false
+	}
+
+	count, err := c.releaseStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count releases: %w", err)
+	}
+
+	releases, err := c.releaseStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	if err = c.backfillAssets(ctx, releases...); err != nil {
+		return nil, 0, err
+	}
+
+	return releases, count, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Title *string `json:"title"`
+	Notes *string `json:"notes"`
+	// IsDraft set to false publishes a draft release.
+	IsDraft      *bool `json:"is_draft"`
+	IsPrerelease *bool `json:"is_prerelease"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := validateTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Notes != nil {
+		*in.Notes = strings.TrimSpace(*in.Notes)
+		if err := validateNotes(*in.Notes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates a release.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	releaseID int64,
+	in *UpdateInput,
+) (*types.Release, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	_, release, err := c.getReleaseCheckAccess(ctx, session, repoRef, releaseID, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err = c.releaseStore.UpdateOptLock(ctx, release, func(release *types.Release) error {
+		if in.Title != nil {
+			release.Title = *in.Title
+			if release.Title == "" {
+				release.Title = release.TagName
+			}
+		}
+		if in.Notes != nil {
+			release.Notes = *in.Notes
+		}
+		if in.IsPrerelease != nil {
+			release.IsPrerelease = *in.IsPrerelease
+		}
+		if in.IsDraft != nil && *in.IsDraft != release.IsDraft {
+			release.IsDraft = *in.IsDraft
+			release.Published = nil
+			if !release.IsDraft {
+				now := time.Now().UnixMilli()
+				release.Published = &now
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update release: %w", err)
+	}
+
+	if err = c.backfillAssets(ctx, release); err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+type ConsumerCreateInput struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+}
+
+type ConsumerAckInput struct {
+	// Seq is the sequence number of the last replication record the consumer applied.
+	Seq int64 `json:"seq"`
+}
+
+// CreateConsumer creates a new replication consumer starting at the latest replication record.
+func (c *Controller) CreateConsumer(
+	ctx context.Context,
+	session *auth.Session,
+	in *ConsumerCreateInput,
+) (*types.ReplicationConsumer, error) {
+	return c.replicationSvc.CreateConsumer(ctx, session.Principal.ID, in.Identifier, in.Description)
+}
+
+// FindConsumer returns the replication consumer with its lag.
+func (c *Controller) FindConsumer(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) (*types.ReplicationConsumer, error) {
+	return c.replicationSvc.FindConsumer(ctx, identifier)
+}
+
+// ListConsumers lists all replication consumers with their lag.
+func (c *Controller) ListConsumers(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.ReplicationConsumer, error) {
+	return c.replicationSvc.ListConsumers(ctx)
+}
+
+// AckConsumer acknowledges all replication records up to the provided record on behalf of the consumer.
+func (c *Controller) AckConsumer(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+	in *ConsumerAckInput,
+) (*types.ReplicationConsumer, error) {
+	return c.replicationSvc.Ack(ctx, identifier, in.Seq)
+}
+
+// DeleteConsumer deletes the replication consumer.
+func (c *Controller) DeleteConsumer(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) error {
+	return c.replicationSvc.DeleteConsumer(ctx, identifier)
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package usergroup
+package replication
 
 import (
 	"context"
@@ -21,14 +21,11 @@ import (
 	"github.com/harness/gitness/types"
 )
 
-func (s *searchService) ListUsers(
-	_ context.Context,
+// ListRecords lists the replication records in the order the reference updates were applied.
+func (c *Controller) ListRecords(
+	ctx context.Context,
 	_ *auth.Session,
-	_ *types.UserGroup,
-) ([]string, error) {
-	return nil, nil
-}
-
-func (s *searchService) ListUserIDsByGroupIDs(_ context.Context, _ []int64) ([]int64, error) {
-	return nil, nil
+	filter types.ReplicationRecordFilter,
+) ([]*types.ReplicationRecord, error) {
+	return c.replicationSvc.ListRecords(ctx, filter)
 }
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MaxApplyPatchRequestSize is the maximum size of the apply patch request body.
+// It leaves room for the base64 encoding and the JSON escaping of a patch of the maximum size.
+const MaxApplyPatchRequestSize = 2 * git.MaxPatchSize
+
+// ApplyPatchOptions holds the data for the apply patch operation.
+type ApplyPatchOptions struct {
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Branch    string `json:"branch"`
+	NewBranch string `json:"new_branch"`
+
+	// Patch is a unified diff, as produced by git diff or git format-patch.
+	Patch    string                   `json:"patch"`
+	Encoding enum.ContentEncodingType `json:"encoding"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *ApplyPatchOptions) sanitize() error {
+	if in.Patch == "" {
+		return usererror.BadRequest("Patch must be provided")
+	}
+
+	if in.Title == "" {
+		in.Title = "Apply patch"
+	}
+
+	return nil
+}
+
+// ApplyPatch applies a patch to a branch and commits the result.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) ApplyPatch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ApplyPatchOptions,
+) (types.CommitFilesResponse, []types.RuleViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	refAction := protection.RefActionUpdate
+	branchName := in.Branch
+	if branchName == "" {
+		branchName = repo.DefaultBranch
+	}
+	if in.NewBranch != "" {
+		refAction = protection.RefActionCreate
+		branchName = in.NewBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          refAction,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{branchName},
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return types.CommitFilesResponse{
+			DryRunRulesOutput: types.DryRunRulesOutput{
+				DryRunRules:    true,
+				RuleViolations: violations,
+			},
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return types.CommitFilesResponse{}, violations, nil
+	}
+
+	var patch []byte
+	switch in.Encoding {
+	case enum.ContentEncodingTypeBase64:
+		patch, err = base64.StdEncoding.DecodeString(in.Patch)
+		if err != nil {
+			return types.CommitFilesResponse{}, nil, usererror.BadRequest("Failed to decode base64 patch")
+		}
+	case enum.ContentEncodingTypeUTF8:
+		fallthrough
+	default:
+		patch = []byte(in.Patch)
+	}
+
+	if len(patch) > git.MaxPatchSize {
+		return types.CommitFilesResponse{}, nil,
+			usererror.BadRequestf("Patch exceeds the maximum size of %d bytes", git.MaxPatchSize)
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	output, err := c.git.ApplyPatch(ctx, &git.ApplyPatchParams{
+		WriteParams:   writeParams,
+		Title:         in.Title,
+		Message:       in.Message,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		Patch:         patch,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	if protection.IsBypassed(violations) {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(
+				audit.ResourceTypeRepository,
+				repo.Identifier,
+				audit.RepoPath,
+				repo.Path,
+				audit.BypassAction,
+				audit.BypassActionCommitted,
+				audit.BypassedResourceType,
+				audit.BypassedResourceTypeCommit,
+				audit.BypassedResourceName,
+				output.CommitID.String(),
+			),
+			audit.ActionBypassed,
+			paths.Parent(repo.Path),
+			audit.WithNewObject(audit.CommitObject{
+				CommitSHA:      output.CommitID.String(),
+				RepoPath:       repo.Path,
+				RuleViolations: violations,
+			}),
+		)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for apply patch operation: %s", err)
+	}
+
+	return types.CommitFilesResponse{
+		CommitID: output.CommitID.String(),
+		DryRunRulesOutput: types.DryRunRulesOutput{
+			RuleViolations: violations,
+		},
+	}, nil, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// BlameStream streams the git blame hunks of a file as server sent events, as soon as they are produced by git.
+func (c *Controller) BlameStream(ctx context.Context,
+	session *auth.Session,
+	repoRef, gitRef, path string,
+	lineFrom, lineTo int,
+) (<-chan *sse.Event, <-chan error, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, nil, usererror.BadRequest("File path needs to specified.")
+	}
+
+	if lineTo > 0 && lineFrom > lineTo {
+		return nil, nil, usererror.BadRequest("Line range must be valid.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	// the ref and path are resolved before the stream starts, so invalid input is reported as an error response.
+	hunkCh, hunkErrCh, err := c.git.BlameIncremental(ctx, &git.BlameParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitRef:     gitRef,
+		Path:       path,
+		LineFrom:   lineFrom,
+		LineTo:     lineTo,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventCh := make(chan *sse.Event)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		for hunk := range hunkCh {
+			data, err := json.Marshal(hunk)
+			if err != nil {
+				errCh <- fmt.Errorf("failed to marshal blame hunk: %w", err)
+				return
+			}
+
+			select {
+			case eventCh <- &sse.Event{Type: enum.SSETypeBlameHunk, Data: data}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := <-hunkErrCh; err != nil {
+			errCh <- err
+		}
+	}()
+
+	return eventCh, errCh, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CherryPickInput struct {
+	// Revisions is either a single commit or a commit range in the form "A..B".
+	Revisions string `json:"revisions"`
+	// Branch is the target branch onto which the commits are applied.
+	Branch string `json:"branch"`
+	// BranchCommitSHA is the expected latest commit of the target branch (optional).
+	BranchCommitSHA sha.SHA `json:"branch_commit_sha"`
+
+	DryRun      bool `json:"dry_run"`
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CherryPickInput) validate() error {
+	if in.Revisions == "" {
+		return usererror.BadRequest("Commit or commit range must be provided")
+	}
+
+	if in.Branch == "" {
+		return usererror.BadRequest("Branch name must be provided")
+	}
+
+	return nil
+}
+
+// CherryPick applies a single commit or a range of commits onto a branch.
+func (c *Controller) CherryPick(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CherryPickInput,
+) (*types.CherryPickResponse, *types.CherryPickViolations, error) {
+	if err := in.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          protection.RefActionUpdate,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{in.Branch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		// DryRunRules is true: Just return rule violations and don't attempt to cherry-pick.
+		return &types.CherryPickResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.CherryPickViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	committer := identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal)
+
+	output, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:       writeParams,
+		Revisions:         in.Revisions,
+		Branch:            in.Branch,
+		BranchExpectedSHA: in.BranchCommitSHA,
+		Committer:         committer,
+		CommitterDate:     &now,
+		DryRun:            in.DryRun,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cherry-pick execution failed: %w", err)
+	}
+
+	commits := controller.MapCherryPickCommits(output.Commits)
+
+	if output.HasConflicts() {
+		return nil, &types.CherryPickViolations{
+			Message:        "Cherry-pick blocked by conflicting files",
+			Commits:        commits,
+			RuleViolations: violations,
+		}, nil
+	}
+
+	if in.DryRun {
+		// DryRun is true: No reference is updated, so don't return the resulting commit SHAs.
+		for i := range commits {
+			commits[i].NewSHA = nil
+		}
+
+		return &types.CherryPickResponse{
+			Commits:        commits,
+			RuleViolations: violations,
+			DryRun:         true,
+		}, nil, nil
+	}
+
+	return &types.CherryPickResponse{
+		NewBranchSHA:   &output.NewSHA,
+		Commits:        commits,
+		RuleViolations: violations,
+	}, nil, nil
+}
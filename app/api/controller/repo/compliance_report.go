@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ComplianceReport scans the repository at the provided git reference and returns the compliance report.
+func (c *Controller) ComplianceReport(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) (*types.ComplianceReport, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.IsEmpty {
+		return nil, usererror.BadRequest("Repository is empty.")
+	}
+
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	report, err := c.complianceScanner.Scan(ctx, repo, gitRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan repository: %w", err)
+	}
+
+	return report, nil
+}
@@ -98,6 +98,7 @@ func (c *Controller) GetContent(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	gitRef string,
+	at int64,
 	repoPath string,
 	includeLatestCommit bool,
 ) (*GetContentOutput, error) {
@@ -111,6 +112,11 @@ func (c *Controller) GetContent(ctx context.Context,
 		gitRef = repo.DefaultBranch
 	}
 
+	gitRef, err = c.resolveGitRefAt(ctx, repo, gitRef, at)
+	if err != nil {
+		return nil, err
+	}
+
 	// create read params once
 	readParams := git.CreateReadParams(repo)
 
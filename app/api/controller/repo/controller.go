@@ -28,15 +28,23 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/filetemplate"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/markdown"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/role"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/wiki"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
@@ -72,33 +80,45 @@ func (r RepositoryOutput) MarshalJSON() ([]byte, error) {
 type Controller struct {
 	defaultBranch string
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	settings           *settings.Service
-	principalInfoCache store.PrincipalInfoCache
-	userGroupStore     store.UserGroupStore
-	userGroupService   usergroup.SearchService
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	locker             *locker.Locker
-	auditService       audit.Service
-	mtxManager         lock.MutexManager
-	identifierCheck    check.RepoIdentifier
-	repoCheck          Check
-	publicAccess       publicaccess.Service
-	labelSvc           *label.Service
-	instrumentation    instrument.Service
+	tx                  dbtx.Transactor
+	urlProvider         url.Provider
+	authorizer          authz.Authorizer
+	repoStore           store.RepoStore
+	spaceStore          store.SpaceStore
+	pipelineStore       store.PipelineStore
+	principalStore      store.PrincipalStore
+	ruleStore           store.RuleStore
+	settings            *settings.Service
+	principalInfoCache  store.PrincipalInfoCache
+	userGroupStore      store.UserGroupStore
+	userGroupService    usergroup.SearchService
+	protectionManager   *protection.Manager
+	git                 git.Interface
+	importer            *importer.Repository
+	codeOwners          *codeowners.Service
+	eventReporter       *repoevents.Reporter
+	indexer             keywordsearch.Indexer
+	resourceLimiter     limiter.ResourceLimiter
+	locker              *locker.Locker
+	auditService        audit.Service
+	mtxManager          lock.MutexManager
+	identifierCheck     check.RepoIdentifier
+	repoCheck           Check
+	publicAccess        publicaccess.Service
+	labelSvc            *label.Service
+	instrumentation     instrument.Service
+	repoTrafficStore    store.RepoTrafficStore
+	repoTemplateSvc     *repotemplate.Service
+	complianceScanner   *compliance.Scanner
+	storagePoolSvc      *storagepool.Service
+	repoRedirectStore   store.RepoRedirectStore
+	repoStarStore       store.RepoStarStore
+	markdownRenderer    *markdown.Renderer
+	wikiService         *wiki.Service
+	fileTemplateSvc     *filetemplate.Service
+	publicKeySvc        publickey.Service
+	repoMembershipStore store.RepoMembershipStore
+	roleSvc             *role.Service
 }
 
 func NewController(
@@ -130,36 +150,60 @@ func NewController(
 	instrumentation instrument.Service,
 	userGroupStore store.UserGroupStore,
 	userGroupService usergroup.SearchService,
+	repoTrafficStore store.RepoTrafficStore,
+	repoTemplateSvc *repotemplate.Service,
+	complianceScanner *compliance.Scanner,
+	storagePoolSvc *storagepool.Service,
+	repoRedirectStore store.RepoRedirectStore,
+	repoStarStore store.RepoStarStore,
+	markdownRenderer *markdown.Renderer,
+	wikiService *wiki.Service,
+	fileTemplateSvc *filetemplate.Service,
+	publicKeySvc publickey.Service,
+	repoMembershipStore store.RepoMembershipStore,
+	roleSvc *role.Service,
 ) *Controller {
 	return &Controller{
-		defaultBranch:      config.Git.DefaultBranch,
-		tx:                 tx,
-		urlProvider:        urlProvider,
-		authorizer:         authorizer,
-		repoStore:          repoStore,
-		spaceStore:         spaceStore,
-		pipelineStore:      pipelineStore,
-		principalStore:     principalStore,
-		ruleStore:          ruleStore,
-		settings:           settings,
-		principalInfoCache: principalInfoCache,
-		protectionManager:  protectionManager,
-		git:                git,
-		importer:           importer,
-		codeOwners:         codeOwners,
-		eventReporter:      eventReporter,
-		indexer:            indexer,
-		resourceLimiter:    limiter,
-		locker:             locker,
-		auditService:       auditService,
-		mtxManager:         mtxManager,
-		identifierCheck:    identifierCheck,
-		repoCheck:          repoCheck,
-		publicAccess:       publicAccess,
-		labelSvc:           labelSvc,
-		instrumentation:    instrumentation,
-		userGroupStore:     userGroupStore,
-		userGroupService:   userGroupService,
+		defaultBranch:       config.Git.DefaultBranch,
+		tx:                  tx,
+		urlProvider:         urlProvider,
+		authorizer:          authorizer,
+		repoStore:           repoStore,
+		spaceStore:          spaceStore,
+		pipelineStore:       pipelineStore,
+		principalStore:      principalStore,
+		ruleStore:           ruleStore,
+		settings:            settings,
+		principalInfoCache:  principalInfoCache,
+		protectionManager:   protectionManager,
+		git:                 git,
+		importer:            importer,
+		codeOwners:          codeOwners,
+		eventReporter:       eventReporter,
+		indexer:             indexer,
+		resourceLimiter:     limiter,
+		locker:              locker,
+		auditService:        auditService,
+		mtxManager:          mtxManager,
+		identifierCheck:     identifierCheck,
+		repoCheck:           repoCheck,
+		publicAccess:        publicAccess,
+		labelSvc:            labelSvc,
+		instrumentation:     instrumentation,
+		userGroupStore:      userGroupStore,
+		userGroupService:    userGroupService,
+		repoTrafficStore:    repoTrafficStore,
+		repoTemplateSvc:     repoTemplateSvc,
+		complianceScanner:   complianceScanner,
+		storagePoolSvc:      storagePoolSvc,
+		repoRedirectStore:   repoRedirectStore,
+		repoStarStore:       repoStarStore,
+		markdownRenderer:    markdownRenderer,
+		wikiService:         wikiService,
+		fileTemplateSvc:     fileTemplateSvc,
+		publicKeySvc:        publicKeySvc,
+		repoMembershipStore: repoMembershipStore,
+		roleSvc:             roleSvc,
 	}
 }
 
@@ -19,6 +19,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,9 +31,10 @@ import (
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
-	"github.com/harness/gitness/resources"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -49,21 +51,36 @@ var (
 type CreateInput struct {
 	ParentRef string `json:"parent_ref"`
 	// TODO [CODE-1363]: remove after identifier migration.
-	UID           string `json:"uid" deprecated:"true"`
-	Identifier    string `json:"identifier"`
-	DefaultBranch string `json:"default_branch"`
-	Description   string `json:"description"`
-	IsPublic      bool   `json:"is_public"`
-	ForkID        int64  `json:"fork_id"`
-	Readme        bool   `json:"readme"`
-	License       string `json:"license"`
-	GitIgnore     string `json:"git_ignore"`
+	UID           string   `json:"uid" deprecated:"true"`
+	Identifier    string   `json:"identifier"`
+	DefaultBranch string   `json:"default_branch"`
+	Description   string   `json:"description"`
+	IsPublic      bool     `json:"is_public"`
+	ForkID        int64    `json:"fork_id"`
+	Readme        bool     `json:"readme"`
+	License       string   `json:"license"`
+	GitIgnore     string   `json:"git_ignore"`
+	Topics        []string `json:"topics"`
+
+	// ObjectFormat is the hash algorithm used by the repository (sha1 or sha256, default: sha1).
+	ObjectFormat sha.ObjectFormat `json:"object_format"`
 }
 
 // Create creates a new repository.
-//
-//nolint:gocognit
 func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*RepositoryOutput, error) {
+	return c.create(ctx, session, in, nil)
+}
+
+// create creates a new repository. If the template is provided,
+// the initial commit of the repository contains the files of the template repository.
+//
+//nolint:gocognit,gocyclo,cyclop
+func (c *Controller) create(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
+	template *templateSource,
+) (*RepositoryOutput, error) {
 	if err := c.sanitizeCreateInput(in); err != nil {
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
@@ -73,6 +90,15 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
+	if in.DefaultBranch == "" {
+		// the default branch of new repositories can be configured for the space or any of its ancestors.
+		in.DefaultBranch, err = settings.SpaceGetInherited(ctx, c.settings, parentSpace.ID,
+			settings.KeyDefaultBranch, c.defaultBranch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve default branch: %w", err)
+		}
+	}
+
 	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -90,7 +116,40 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
-	gitResp, isEmpty, err := c.createGitRepository(ctx, session, in)
+	var forkedRepo *types.Repository
+	if in.ForkID != 0 {
+		forkedRepo, err = c.getRepoCheckAccess(ctx, session, strconv.FormatInt(in.ForkID, 10), enum.PermissionRepoView)
+		if err != nil {
+			return nil, fmt.Errorf("failed to access the repository to fork: %w", err)
+		}
+
+		// the fork borrows objects from the git repository of the forked repository and changes its config,
+		// hence the same permission as for creating a repository in its space is required.
+		_, err = c.getSpaceCheckAuthRepoCreation(ctx, session, strconv.FormatInt(forkedRepo.ParentID, 10))
+		if err != nil {
+			return nil, fmt.Errorf("failed to authorize forking the repository: %w", err)
+		}
+
+		in.DefaultBranch = forkedRepo.DefaultBranch
+		// a fork shares the objects of its parent, hence it has to use the same object format.
+		in.ObjectFormat = forkedRepo.ObjectFormat
+	}
+
+	storagePool, err := c.storagePoolSvc.Resolve(ctx, parentSpace.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage pool: %w", err)
+	}
+
+	var templateFiles []git.File
+	if template != nil {
+		templateFiles, err = c.readTemplateFiles(ctx, template, in.Identifier, parentSpace.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gitResp, isEmpty, err := c.createGitRepository(ctx, session, parentSpace.ID, in, forkedRepo, templateFiles,
+		storagePool)
 	if err != nil {
 		return nil, fmt.Errorf("error creating repository on git: %w", err)
 	}
@@ -113,6 +172,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			ParentID:      parentSpace.ID,
 			Identifier:    in.Identifier,
 			GitUID:        gitResp.UID,
+			StoragePool:   storagePool,
+			ObjectFormat:  in.ObjectFormat,
 			Description:   in.Description,
 			CreatedBy:     session.Principal.ID,
 			Created:       now,
@@ -120,9 +181,26 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			ForkID:        in.ForkID,
 			DefaultBranch: in.DefaultBranch,
 			IsEmpty:       isEmpty,
+			Topics:        in.Topics,
+		}
+
+		if err := c.repoStore.Create(ctx, repo); err != nil {
+			return err
+		}
+
+		if forkedRepo == nil {
+			return nil
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, forkedRepo, func(r *types.Repository) error {
+			r.NumForks++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update number of forks of the forked repository: %w", err)
 		}
 
-		return c.repoStore.Create(ctx, repo)
+		return nil
 	}, sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
 		// best effort cleanup
@@ -178,6 +256,11 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for create repository operation: %s", err)
 	}
+	_, err = c.repoTemplateSvc.Apply(ctx, session.Principal.ID, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to apply repo templates")
+	}
+
 	// index repository if files are created
 	if !repo.IsEmpty {
 		err = c.indexer.Index(ctx, repo)
@@ -234,20 +317,38 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
-	if in.DefaultBranch == "" {
-		in.DefaultBranch = c.defaultBranch
+	topics, err := sanitizeTopics(in.Topics)
+	if err != nil {
+		return err
+	}
+	in.Topics = topics
+
+	if in.ForkID != 0 && (in.Readme || (in.License != "" && in.License != "none") || in.GitIgnore != "") {
+		return usererror.BadRequest("A fork can't be initialized with a readme, license or gitignore file.")
 	}
 
+	objectFormat, err := sha.ParseObjectFormat(string(in.ObjectFormat))
+	if err != nil {
+		return err
+	}
+	in.ObjectFormat = objectFormat
+
 	return nil
 }
 
-func (c *Controller) createGitRepository(ctx context.Context, session *auth.Session,
-	in *CreateInput) (*git.CreateRepositoryOutput, bool, error) {
+func (c *Controller) createGitRepository(ctx context.Context, session *auth.Session, spaceID int64,
+	in *CreateInput, forkedRepo *types.Repository, templateFiles []git.File, storagePool string,
+) (*git.CreateRepositoryOutput, bool, error) {
+	if forkedRepo != nil {
+		return c.forkGitRepository(ctx, session, in, forkedRepo, storagePool)
+	}
+
 	var (
 		err     error
 		content []byte
 	)
-	files := make([]git.File, 0, 3) // readme, gitignore, licence
+	files := make([]git.File, 0, len(templateFiles)+3) // template files, readme, gitignore, licence
+	files = append(files, templateFiles...)
 	if in.Readme {
 		content = createReadme(in.Identifier, in.Description)
 		files = append(files, git.File{
@@ -256,7 +357,7 @@ func (c *Controller) createGitRepository(ctx context.Context, session *auth.Sess
 		})
 	}
 	if in.License != "" && in.License != "none" {
-		content, err = resources.ReadLicense(in.License)
+		content, err = c.fileTemplateSvc.Read(ctx, spaceID, enum.FileTemplateTypeLicense, in.License)
 		if err != nil {
 			return nil, false, fmt.Errorf("failed to read license '%s': %w", in.License, err)
 		}
@@ -266,7 +367,7 @@ func (c *Controller) createGitRepository(ctx context.Context, session *auth.Sess
 		})
 	}
 	if in.GitIgnore != "" {
-		content, err = resources.ReadGitIgnore(in.GitIgnore)
+		content, err = c.fileTemplateSvc.Read(ctx, spaceID, enum.FileTemplateTypeGitIgnore, in.GitIgnore)
 		if err != nil {
 			return nil, false, fmt.Errorf("failed to read git ignore '%s': %w", in.GitIgnore, err)
 		}
@@ -296,6 +397,8 @@ func (c *Controller) createGitRepository(ctx context.Context, session *auth.Sess
 		Actor:         *actor,
 		EnvVars:       envVars,
 		DefaultBranch: in.DefaultBranch,
+		StoragePool:   storagePool,
+		ObjectFormat:  in.ObjectFormat,
 		Files:         files,
 		Author:        actor,
 		AuthorDate:    &now,
@@ -309,6 +412,36 @@ func (c *Controller) createGitRepository(ctx context.Context, session *auth.Sess
 	return resp, len(files) == 0, nil
 }
 
+// forkGitRepository creates the git repository of a fork.
+// The objects of the forked repository are shared with the fork via alternates.
+func (c *Controller) forkGitRepository(ctx context.Context, session *auth.Session,
+	in *CreateInput, forkedRepo *types.Repository, storagePool string) (*git.CreateRepositoryOutput, bool, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(ctx),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	resp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:         *identityFromPrincipal(session.Principal),
+		EnvVars:       envVars,
+		ParentRepoUID: forkedRepo.GitUID,
+		DefaultBranch: in.DefaultBranch,
+		StoragePool:   storagePool,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fork repo: %w", err)
+	}
+
+	return resp, forkedRepo.IsEmpty, nil
+}
+
 func createReadme(name, description string) []byte {
 	content := bytes.Buffer{}
 	content.WriteString("# " + name + "\n")
@@ -16,6 +16,7 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
@@ -33,7 +34,9 @@ func (c *Controller) RawDiff(
 	w io.Writer,
 	session *auth.Session,
 	repoRef string,
+	sourceRepoRef string,
 	path string,
+	at int64,
 	files ...gittypes.FileDiffRequest,
 ) error {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
@@ -41,7 +44,7 @@ func (c *Controller) RawDiff(
 		return err
 	}
 
-	info, err := parseDiffPath(path)
+	repo, info, err := c.parseCompare(ctx, session, repo, sourceRepoRef, path, at)
 	if err != nil {
 		return err
 	}
@@ -78,6 +81,85 @@ type CompareInfo struct {
 	MergeBase bool
 }
 
+// parseCompare parses the diff path and returns the repository in which the comparison is executed.
+// If a source repository is provided, the head reference of the diff path is taken from the source repository,
+// which has to be a fork of the repository. As a fork borrows all objects of its parent repository,
+// the comparison is executed in the fork, with the base reference resolved to a commit of the parent.
+func (c *Controller) parseCompare(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	sourceRepoRef string,
+	path string,
+	at int64,
+) (*types.Repository, CompareInfo, error) {
+	if sourceRepoRef == "" {
+		info, err := c.parseDiffPathAt(ctx, repo, path, at)
+		return repo, info, err
+	}
+
+	sourceRepo, err := c.getRepoCheckAccess(ctx, session, sourceRepoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, CompareInfo{}, fmt.Errorf("failed to acquire access to source repo: %w", err)
+	}
+
+	if sourceRepo.ID == repo.ID {
+		info, err := c.parseDiffPathAt(ctx, repo, path, at)
+		return repo, info, err
+	}
+
+	if sourceRepo.ForkID != repo.ID {
+		return nil, CompareInfo{}, usererror.BadRequest("The source repository must be a fork of the repository")
+	}
+
+	info, err := parseDiffPath(path)
+	if err != nil {
+		return nil, CompareInfo{}, err
+	}
+
+	if info.BaseRef, err = c.resolveGitRefAt(ctx, repo, info.BaseRef, at); err != nil {
+		return nil, CompareInfo{}, err
+	}
+
+	baseCommit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   info.BaseRef,
+	})
+	if err != nil {
+		return nil, CompareInfo{}, fmt.Errorf("failed to resolve base reference %q: %w", info.BaseRef, err)
+	}
+
+	info.BaseRef = baseCommit.Commit.SHA.String()
+
+	if info.HeadRef, err = c.resolveGitRefAt(ctx, sourceRepo, info.HeadRef, at); err != nil {
+		return nil, CompareInfo{}, err
+	}
+
+	return sourceRepo, info, nil
+}
+
+// parseDiffPathAt parses the diff path and resolves both of its references at the provided time (unix millis).
+func (c *Controller) parseDiffPathAt(
+	ctx context.Context,
+	repo *types.Repository,
+	path string,
+	at int64,
+) (CompareInfo, error) {
+	info, err := parseDiffPath(path)
+	if err != nil {
+		return CompareInfo{}, err
+	}
+
+	if info.BaseRef, err = c.resolveGitRefAt(ctx, repo, info.BaseRef, at); err != nil {
+		return CompareInfo{}, err
+	}
+	if info.HeadRef, err = c.resolveGitRefAt(ctx, repo, info.HeadRef, at); err != nil {
+		return CompareInfo{}, err
+	}
+
+	return info, nil
+}
+
 func parseDiffPath(path string) (CompareInfo, error) {
 	infos := strings.SplitN(path, "...", 2)
 	if len(infos) != 2 {
@@ -97,7 +179,9 @@ func (c *Controller) DiffStats(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
+	sourceRepoRef string,
 	path string,
+	at int64,
 ) (types.DiffStats, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -108,7 +192,7 @@ func (c *Controller) DiffStats(
 		return types.DiffStats{}, err
 	}
 
-	info, err := parseDiffPath(path)
+	repo, info, err := c.parseCompare(ctx, session, repo, sourceRepoRef, path, at)
 	if err != nil {
 		return types.DiffStats{}, err
 	}
@@ -130,7 +214,9 @@ func (c *Controller) Diff(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
+	sourceRepoRef string,
 	path string,
+	at int64,
 	includePatch bool,
 	files ...gittypes.FileDiffRequest,
 ) (types.Stream[*git.FileDiff], error) {
@@ -143,7 +229,7 @@ func (c *Controller) Diff(
 		return nil, err
 	}
 
-	info, err := parseDiffPath(path)
+	repo, info, err := c.parseCompare(ctx, session, repo, sourceRepoRef, path, at)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxTemplateFiles is the maximum number of files a repository can be generated from.
+	maxTemplateFiles = 1000
+	// maxTemplateSize is the maximum total size of the files a repository can be generated from.
+	maxTemplateSize = 50 << 20
+
+	placeholderRepoName  = "{{repo_name}}"
+	placeholderRepoOwner = "{{repo_owner}}"
+)
+
+var (
+	errRepoNotTemplate = usererror.BadRequest(
+		"The repository is not a template repository.")
+	errTemplateTooLarge = usererror.BadRequestf(
+		"Template repositories with more than %d files or %d MiB of content are not supported.",
+		maxTemplateFiles, maxTemplateSize>>20)
+)
+
+type GenerateInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	// SubstitutePlaceholders replaces the {{repo_name}} and {{repo_owner}} placeholders
+	// in the text files of the template with the identifier and the parent path of the new repository.
+	SubstitutePlaceholders bool `json:"substitute_placeholders"`
+}
+
+// templateSource describes the content of a newly created repository generated from a template repository.
+type templateSource struct {
+	repo                   *types.Repository
+	substitutePlaceholders bool
+}
+
+// Generate creates a new repository from the content of a template repository.
+// The new repository starts with a single commit, its history is not related to the history of the template.
+func (c *Controller) Generate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *GenerateInput,
+) (*RepositoryOutput, error) {
+	templateRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if !templateRepo.IsTemplate {
+		return nil, errRepoNotTemplate
+	}
+
+	return c.create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: templateRepo.DefaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		ObjectFormat:  templateRepo.ObjectFormat,
+	}, &templateSource{
+		repo:                   templateRepo,
+		substitutePlaceholders: in.SubstitutePlaceholders,
+	})
+}
+
+// readTemplateFiles reads all files from the default branch of the template repository.
+// Symlinks and submodules are skipped.
+func (c *Controller) readTemplateFiles(
+	ctx context.Context,
+	template *templateSource,
+	repoName string,
+	repoOwner string,
+) ([]git.File, error) {
+	if template.repo.IsEmpty {
+		return nil, nil
+	}
+
+	readParams := git.CreateReadParams(template.repo)
+
+	paths, err := c.git.ListPaths(ctx, &git.ListPathsParams{
+		ReadParams:         readParams,
+		GitREF:             template.repo.DefaultBranch,
+		IncludeDirectories: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paths of the template repository: %w", err)
+	}
+
+	if len(paths.Files) > maxTemplateFiles {
+		return nil, errTemplateTooLarge
+	}
+
+	// list the nodes of the root and of every directory to get the mode and the blob SHA of every file.
+	dirs := append([]string{""}, paths.Directories...)
+	files := make([]git.File, 0, len(paths.Files))
+	totalSize := int64(0)
+
+	for _, dir := range dirs {
+		nodes, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     template.repo.DefaultBranch,
+			Path:       dir,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tree nodes of the template directory %q: %w", dir, err)
+		}
+
+		for _, node := range nodes.Nodes {
+			if node.Mode != git.TreeNodeModeFile && node.Mode != git.TreeNodeModeExec {
+				continue
+			}
+
+			content, size, err := c.readTemplateBlob(ctx, readParams, node.SHA, maxTemplateSize-totalSize)
+			if err != nil {
+				return nil, err
+			}
+
+			totalSize += size
+			if totalSize > maxTemplateSize {
+				return nil, errTemplateTooLarge
+			}
+
+			if template.substitutePlaceholders {
+				content = substitutePlaceholders(content, repoName, repoOwner)
+			}
+
+			files = append(files, git.File{
+				Path:       node.Path,
+				Content:    content,
+				Executable: node.Mode == git.TreeNodeModeExec,
+			})
+		}
+	}
+
+	return files, nil
+}
+
+func (c *Controller) readTemplateBlob(
+	ctx context.Context,
+	readParams git.ReadParams,
+	blobSHA string,
+	sizeLimit int64,
+) ([]byte, int64, error) {
+	if sizeLimit <= 0 {
+		return nil, 0, errTemplateTooLarge
+	}
+
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  sizeLimit,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get template file content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > sizeLimit {
+		return nil, 0, errTemplateTooLarge
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read template file content: %w", err)
+	}
+
+	return content, output.Size, nil
+}
+
+// substitutePlaceholders replaces the placeholders in the content of text files.
+// Files containing a NUL byte are considered binary and are left unchanged.
+func substitutePlaceholders(content []byte, repoName, repoOwner string) []byte {
+	if bytes.IndexByte(content, 0) >= 0 {
+		return content
+	}
+
+	content = bytes.ReplaceAll(content, []byte(placeholderRepoName), []byte(repoName))
+	content = bytes.ReplaceAll(content, []byte(placeholderRepoOwner), []byte(repoOwner))
+
+	return content
+}
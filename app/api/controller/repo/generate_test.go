@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// templateGit serves the tree of a template repository from memory.
+type templateGit struct {
+	git.Interface
+	dirs  []string
+	nodes map[string][]git.TreeNode
+	blobs map[string]string
+}
+
+func (g *templateGit) ListPaths(context.Context, *git.ListPathsParams) (*git.ListPathsOutput, error) {
+	out := &git.ListPathsOutput{Directories: g.dirs}
+	for _, nodes := range g.nodes {
+		for _, node := range nodes {
+			if node.Mode != git.TreeNodeModeTree {
+				out.Files = append(out.Files, node.Path)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (g *templateGit) ListTreeNodes(
+	_ context.Context,
+	params *git.ListTreeNodeParams,
+) (*git.ListTreeNodeOutput, error) {
+	return &git.ListTreeNodeOutput{Nodes: g.nodes[params.Path]}, nil
+}
+
+func (g *templateGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content, ok := g.blobs[params.SHA]
+	if !ok {
+		return nil, errors.NotFound("blob %s not found", params.SHA)
+	}
+
+	size := int64(len(content))
+	if size > params.SizeLimit {
+		content = content[:params.SizeLimit]
+	}
+
+	return &git.GetBlobOutput{
+		Size:        size,
+		ContentSize: int64(len(content)),
+		Content:     io.NopCloser(bytes.NewBufferString(content)),
+	}, nil
+}
+
+func newTemplateGit() *templateGit {
+	return &templateGit{
+		dirs: []string{"scripts"},
+		nodes: map[string][]git.TreeNode{
+			"": {
+				{Mode: git.TreeNodeModeFile, SHA: "readme", Path: "README.md"},
+				{Mode: git.TreeNodeModeFile, SHA: "binary", Path: "logo.png"},
+				{Mode: git.TreeNodeModeSymlink, SHA: "link", Path: "link"},
+				{Mode: git.TreeNodeModeCommit, SHA: "submodule", Path: "vendor"},
+				{Mode: git.TreeNodeModeTree, SHA: "scripts", Path: "scripts"},
+			},
+			"scripts": {
+				{Mode: git.TreeNodeModeExec, SHA: "build", Path: "scripts/build.sh"},
+			},
+		},
+		blobs: map[string]string{
+			"readme": "# {{repo_name}} by {{repo_owner}}",
+			"binary": "\x00{{repo_name}}",
+			"link":   "README.md",
+			"build":  "echo {{repo_name}}",
+		},
+	}
+}
+
+func TestController_ReadTemplateFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		substitute bool
+		want       []git.File
+	}{
+		{
+			name: "copied as is",
+			want: []git.File{
+				{Path: "README.md", Content: []byte("# {{repo_name}} by {{repo_owner}}")},
+				{Path: "logo.png", Content: []byte("\x00{{repo_name}}")},
+				{Path: "scripts/build.sh", Content: []byte("echo {{repo_name}}"), Executable: true},
+			},
+		},
+		{
+			name:       "placeholders substituted in text files",
+			substitute: true,
+			want: []git.File{
+				{Path: "README.md", Content: []byte("# new-repo by space/child")},
+				{Path: "logo.png", Content: []byte("\x00{{repo_name}}")},
+				{Path: "scripts/build.sh", Content: []byte("echo new-repo"), Executable: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{git: newTemplateGit()}
+
+			files, err := c.readTemplateFiles(context.Background(), &templateSource{
+				repo:                   &types.Repository{GitUID: "template", DefaultBranch: "main"},
+				substitutePlaceholders: test.substitute,
+			}, "new-repo", "space/child")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(files, test.want) {
+				t.Errorf("files = %+v, want %+v", files, test.want)
+			}
+		})
+	}
+}
+
+func TestController_ReadTemplateFilesEmpty(t *testing.T) {
+	c := &Controller{}
+
+	files, err := c.readTemplateFiles(context.Background(), &templateSource{
+		repo: &types.Repository{GitUID: "template", IsEmpty: true},
+	}, "new-repo", "space")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no files of an empty template, got %d", len(files))
+	}
+}
+
+func TestController_ReadTemplateFilesTooLarge(t *testing.T) {
+	tooManyFiles := newTemplateGit()
+	for i := 0; i <= maxTemplateFiles; i++ {
+		tooManyFiles.nodes["scripts"] = append(tooManyFiles.nodes["scripts"],
+			git.TreeNode{Mode: git.TreeNodeModeFile, SHA: "build", Path: "scripts/build.sh"})
+	}
+
+	tooLargeContent := newTemplateGit()
+	tooLargeContent.blobs["build"] = string(make([]byte, maxTemplateSize))
+
+	tests := []struct {
+		name string
+		git  *templateGit
+	}{
+		{
+			name: "too many files",
+			git:  tooManyFiles,
+		},
+		{
+			name: "too large content",
+			git:  tooLargeContent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{git: test.git}
+
+			_, err := c.readTemplateFiles(context.Background(), &templateSource{
+				repo: &types.Repository{GitUID: "template", DefaultBranch: "main"},
+			}, "new-repo", "space")
+			if !errors.Is(err, errTemplateTooLarge) {
+				t.Errorf("expected template too large error, got %v", err)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/wiki"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types/enum"
 )
@@ -33,13 +34,24 @@ func (c *Controller) GitInfoRefs(
 	gitProtocol string,
 	w io.Writer,
 ) error {
-	repo, err := c.getRepoCheckAccessForGit(ctx, session, repoRef, enum.PermissionRepoView)
+	repo, isWiki, err := c.getGitTargetCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	readParams := git.CreateReadParams(repo)
+	if isWiki {
+		// the wiki has to exist before the refs are advertised for the first push.
+		isWriteOperation := service == enum.GitServiceTypeReceivePack
+		if err = c.prepareWikiForGit(ctx, session, repo, isWriteOperation); err != nil {
+			return err
+		}
+
+		readParams = wiki.ReadParams(repo)
+	}
+
 	if err = c.git.GetInfoRefs(ctx, w, &git.InfoRefsParams{
-		ReadParams: git.CreateReadParams(repo),
+		ReadParams: readParams,
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		Service:     string(service),
 		Options:     nil,
@@ -20,8 +20,10 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/wiki"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -40,11 +42,15 @@ func (c *Controller) GitServicePack(
 		permission = enum.PermissionRepoPush
 	}
 
-	repo, err := c.getRepoCheckAccessForGit(ctx, session, repoRef, permission)
+	repo, isWiki, err := c.getGitTargetCheckAccess(ctx, session, repoRef, permission)
 	if err != nil {
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	if isWiki {
+		return c.gitWikiServicePack(ctx, session, repo, options, isWriteOperation)
+	}
+
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		ServicePackOptions: options,
@@ -63,9 +69,60 @@ func (c *Controller) GitServicePack(
 		params.ReadParams = &readParams
 	}
 
+	// inspect the data of read operations to keep track of clones and fetches of the repository.
+	var sniffer *gitTrafficSniffer
+	if !isWriteOperation {
+		sniffer = &gitTrafficSniffer{}
+		params.Stdin = sniffer.wrapReader(params.Stdin)
+		params.Stdout = sniffer.wrapWriter(params.Stdout)
+	}
+
 	if err = c.git.ServicePack(ctx, params); err != nil {
 		return fmt.Errorf("failed service pack operation %q  on git: %w", options.Service, err)
 	}
 
+	if sniffer != nil {
+		transport := enum.GitTransportSSH
+		if options.StatelessRPC {
+			transport = enum.GitTransportHTTP
+		}
+		c.recordGitTraffic(ctx, session, repo, transport, sniffer)
+	}
+
+	return nil
+}
+
+// gitWikiServicePack executes the service pack operation on the wiki of the repository.
+// Pushes to the wiki bypass the githooks, as branch rules, events and webhooks apply only to the repository itself.
+func (c *Controller) gitWikiServicePack(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	options api.ServicePackOptions,
+	isWriteOperation bool,
+) error {
+	if err := c.prepareWikiForGit(ctx, session, repo, isWriteOperation); err != nil {
+		return err
+	}
+
+	params := &git.ServicePackParams{
+		ServicePackOptions: options,
+	}
+
+	if isWriteOperation {
+		writeParams, err := c.wikiService.WriteParams(ctx, &session.Principal, repo)
+		if err != nil {
+			return err
+		}
+		params.WriteParams = &writeParams
+	} else {
+		readParams := wiki.ReadParams(repo)
+		params.ReadParams = &readParams
+	}
+
+	if err := c.git.ServicePack(ctx, params); err != nil {
+		return fmt.Errorf("failed service pack operation %q on wiki git: %w", options.Service, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// gitTrafficSniffLimit is the max number of bytes of the upload-pack request and response that are inspected.
+// The negotiation lines and the start of the pack we are interested in are at the very beginning of the data.
+const gitTrafficSniffLimit = 64 * 1024
+
+// gitTrafficSniffer keeps a copy of the first bytes of the upload-pack request and response,
+// which are used afterwards to classify the git operation executed by the client.
+type gitTrafficSniffer struct {
+	request  []byte
+	response []byte
+}
+
+func (s *gitTrafficSniffer) wrapReader(r io.Reader) io.Reader {
+	return &gitTrafficReader{reader: r, buf: &s.request}
+}
+
+func (s *gitTrafficSniffer) wrapWriter(w io.Writer) io.Writer {
+	return &gitTrafficWriter{writer: w, buf: &s.response}
+}
+
+type gitTrafficReader struct {
+	reader io.Reader
+	buf    *[]byte
+}
+
+func (r *gitTrafficReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	sniff(r.buf, p[:n])
+	return n, err
+}
+
+type gitTrafficWriter struct {
+	writer io.Writer
+	buf    *[]byte
+}
+
+func (w *gitTrafficWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	sniff(w.buf, p[:n])
+	return n, err
+}
+
+func sniff(buf *[]byte, p []byte) {
+	if remaining := gitTrafficSniffLimit - len(*buf); len(p) > 0 && remaining > 0 {
+		*buf = append(*buf, p[:min(len(p), remaining)]...)
+	}
+}
+
+// classify parses the pkt-lines sent by the client and returns the executed operation
+// and whether the operation was shallow.
+// False is returned in case no pack was sent to the client - for example in case of protocol v2 ls-refs
+// or intermediate negotiation rounds of the stateless http protocol.
+func (s *gitTrafficSniffer) classify() (enum.GitTrafficOperation, bool, bool) {
+	if !bytes.Contains(s.response, []byte("PACK")) {
+		return "", false, false
+	}
+
+	var hasWant, hasHave, isShallow bool
+
+	data := s.request
+	for len(data) >= 4 {
+		length, err := strconv.ParseUint(string(data[:4]), 16, 16)
+		if err != nil {
+			break
+		}
+
+		// flush, delimiter and response-end packets don't carry any data.
+		if length < 4 {
+			data = data[4:]
+			continue
+		}
+
+		if int(length) > len(data) {
+			break
+		}
+
+		line := data[4:length]
+		data = data[length:]
+
+		switch {
+		case bytes.HasPrefix(line, []byte("want ")):
+			hasWant = true
+		case bytes.HasPrefix(line, []byte("have ")):
+			hasHave = true
+		case bytes.HasPrefix(line, []byte("deepen")), bytes.HasPrefix(line, []byte("shallow ")):
+			isShallow = true
+		}
+	}
+
+	if !hasWant {
+		return "", false, false
+	}
+
+	if hasHave {
+		return enum.GitTrafficOperationFetch, isShallow, true
+	}
+
+	return enum.GitTrafficOperationClone, isShallow, true
+}
+
+// recordGitTraffic stores the clone or fetch executed by the client (best effort).
+func (c *Controller) recordGitTraffic(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	transport enum.GitTransport,
+	sniffer *gitTrafficSniffer,
+) {
+	operation, shallow, ok := sniffer.classify()
+	if !ok {
+		return
+	}
+
+	err := c.repoTrafficStore.Record(ctx, &types.RepoTrafficEvent{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		Day:         gitTrafficDay(time.Now()),
+		Transport:   transport,
+		Operation:   operation,
+		Shallow:     shallow,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repo.ID).
+			Msg("failed to record git traffic")
+	}
+}
+
+// gitTrafficDay returns the day the traffic at the provided time is stored under (midnight UTC in milliseconds).
+func gitTrafficDay(t time.Time) int64 {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).UnixMilli()
+}
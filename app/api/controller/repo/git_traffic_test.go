@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const trafficTestSHA = "0123456789abcdef0123456789abcdef01234567"
+
+func pktLines(lines ...string) string {
+	b := strings.Builder{}
+	for _, line := range lines {
+		// special packets (flush, delimiter) are passed as is.
+		if len(line) == 4 && strings.HasPrefix(line, "000") {
+			b.WriteString(line)
+			continue
+		}
+		fmt.Fprintf(&b, "%04x%s", len(line)+4, line)
+	}
+	return b.String()
+}
+
+func TestGitTrafficSniffer_Classify(t *testing.T) {
+	packResponse := pktLines("NAK\n") + "PACK"
+
+	tests := []struct {
+		name        string
+		request     string
+		response    string
+		wantOp      enum.GitTrafficOperation
+		wantShallow bool
+		wantOK      bool
+	}{
+		{
+			name:     "clone",
+			request:  pktLines("want "+trafficTestSHA+" multi_ack side-band-64k\n", "0000", "done\n"),
+			response: packResponse,
+			wantOp:   enum.GitTrafficOperationClone,
+			wantOK:   true,
+		},
+		{
+			name: "fetch",
+			request: pktLines("want "+trafficTestSHA+"\n", "0000",
+				"have "+trafficTestSHA+"\n", "done\n"),
+			response: packResponse,
+			wantOp:   enum.GitTrafficOperationFetch,
+			wantOK:   true,
+		},
+		{
+			name:        "shallow clone",
+			request:     pktLines("want "+trafficTestSHA+"\n", "deepen 1\n", "0000", "done\n"),
+			response:    packResponse,
+			wantOp:      enum.GitTrafficOperationClone,
+			wantShallow: true,
+			wantOK:      true,
+		},
+		{
+			name: "shallow fetch",
+			request: pktLines("want "+trafficTestSHA+"\n", "shallow "+trafficTestSHA+"\n", "0000",
+				"have "+trafficTestSHA+"\n", "done\n"),
+			response:    packResponse,
+			wantOp:      enum.GitTrafficOperationFetch,
+			wantShallow: true,
+			wantOK:      true,
+		},
+		{
+			name: "protocol v2 fetch",
+			request: pktLines("command=fetch\n", "agent=git/2.45.0\n", "0001",
+				"want "+trafficTestSHA+"\n", "done\n", "0000"),
+			response: packResponse,
+			wantOp:   enum.GitTrafficOperationClone,
+			wantOK:   true,
+		},
+		{
+			name:     "protocol v2 ls-refs",
+			request:  pktLines("command=ls-refs\n", "0001", "peel\n", "0000"),
+			response: pktLines(trafficTestSHA+" HEAD\n", "0000"),
+		},
+		{
+			name:     "negotiation without pack",
+			request:  pktLines("want "+trafficTestSHA+"\n", "0000", "have "+trafficTestSHA+"\n", "0000"),
+			response: pktLines("NAK\n"),
+		},
+		{
+			name:     "malformed request",
+			request:  "zzzzwant " + trafficTestSHA,
+			response: packResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sniffer := &gitTrafficSniffer{request: []byte(tt.request), response: []byte(tt.response)}
+
+			op, shallow, ok := sniffer.classify()
+			if op != tt.wantOp || shallow != tt.wantShallow || ok != tt.wantOK {
+				t.Errorf("classify() = (%q, %t, %t), want (%q, %t, %t)",
+					op, shallow, ok, tt.wantOp, tt.wantShallow, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGitTrafficSniffer_Wrap(t *testing.T) {
+	sniffer := &gitTrafficSniffer{}
+
+	request := strings.Repeat("r", gitTrafficSniffLimit+100)
+	read, err := io.ReadAll(sniffer.wrapReader(strings.NewReader(request)))
+	if err != nil {
+		t.Fatalf("failed to read: %s", err)
+	}
+	if string(read) != request {
+		t.Errorf("wrapped reader returned %d bytes, want %d", len(read), len(request))
+	}
+	if string(sniffer.request) != request[:gitTrafficSniffLimit] {
+		t.Errorf("sniffed %d bytes of request, want %d", len(sniffer.request), gitTrafficSniffLimit)
+	}
+
+	response := strings.Repeat("w", gitTrafficSniffLimit+100)
+	written := &bytes.Buffer{}
+	w := sniffer.wrapWriter(written)
+	for _, chunk := range []string{response[:10], response[10:]} {
+		if _, err = w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("failed to write: %s", err)
+		}
+	}
+	if written.String() != response {
+		t.Errorf("wrapped writer wrote %d bytes, want %d", written.Len(), len(response))
+	}
+	if string(sniffer.response) != response[:gitTrafficSniffLimit] {
+		t.Errorf("sniffed %d bytes of response, want %d", len(sniffer.response), gitTrafficSniffLimit)
+	}
+}
+
+func TestGitTrafficDay(t *testing.T) {
+	loc := time.FixedZone("UTC+5", 5*60*60)
+	tm := time.Date(2024, 3, 10, 2, 30, 0, 0, loc) // 2024-03-09 21:30 UTC
+
+	want := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC).UnixMilli()
+	if got := gitTrafficDay(tm); got != want {
+		t.Errorf("gitTrafficDay() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// getGitTargetCheckAccess returns the repository targeted by a git operation and whether the operation targets
+// the wiki of the repository. Wikis are addressed by appending ".wiki" to the path of the repository
+// (e.g. "space/repo.wiki.git"), an existing repository with that exact path takes precedence.
+func (c *Controller) getGitTargetCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.Repository, bool, error) {
+	repo, err := c.getRepoCheckAccessForGit(ctx, session, repoRef, reqPermission)
+
+	parentRef, isWikiRef := strings.CutSuffix(repoRef, wiki.RepoRefSuffix)
+	if !isWikiRef || !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return repo, false, err
+	}
+
+	repo, err = c.getRepoCheckAccessForGit(ctx, session, parentRef, reqPermission)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repo, true, nil
+}
+
+// prepareWikiForGit ensures the wiki of the repository is ready for the git operation.
+// The wiki is created on the first push to it, while read operations require the wiki to exist already.
+func (c *Controller) prepareWikiForGit(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	isWriteOperation bool,
+) error {
+	if isWriteOperation {
+		if err := apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+			return fmt.Errorf("access check failed: %w", err)
+		}
+
+		return c.wikiService.Init(ctx, &session.Principal, repo)
+	}
+
+	exists, err := c.wikiService.Exists(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return usererror.NotFound("The repository doesn't have a wiki yet.")
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Grep searches the files of the repository for lines matching the query.
+// If no git ref is provided, the default branch is searched.
+func (c *Controller) Grep(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.GrepFilter,
+) (*types.GrepResult, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Query == "" {
+		return nil, usererror.BadRequest("Search query can't be empty.")
+	}
+
+	gitRef := filter.GitRef
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	paths := make([]string, 0, len(filter.Paths))
+	for _, path := range filter.Paths {
+		path = strings.Trim(strings.TrimSpace(path), "/")
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	output, err := c.git.Grep(ctx, &git.GrepParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ref:        gitRef,
+		Pattern:    filter.Query,
+		Regex:      filter.Regex,
+		IgnoreCase: filter.IgnoreCase,
+		Paths:      paths,
+		MaxResults: filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to grep repository: %w", err)
+	}
+
+	matches := make([]types.GrepMatch, len(output.Matches))
+	for i, match := range output.Matches {
+		matches[i] = types.GrepMatch{
+			Path:       match.Path,
+			LineNumber: match.LineNumber,
+			Column:     match.Column,
+			Line:       match.Line,
+		}
+	}
+
+	return &types.GrepResult{
+		Matches:   matches,
+		Truncated: output.Truncated,
+	}, nil
+}
@@ -19,11 +19,13 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -75,6 +77,10 @@ func GetRepoCheckAccess(
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
 
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
 	return repo, nil
 }
 
@@ -106,3 +112,27 @@ func GetRepoOutputWithAccess(
 		Importing:  repo.State != enum.RepoStateActive,
 	}
 }
+
+// resolveGitRefAt resolves the git reference to the commit it pointed to at the provided time (unix millis).
+// The git reference is returned unchanged in case no time is provided.
+func (c *Controller) resolveGitRefAt(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	at int64,
+) (string, error) {
+	if at == 0 {
+		return gitRef, nil
+	}
+
+	out, err := c.git.ResolveRevisionAt(ctx, &git.ResolveRevisionAtParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   gitRef,
+		At:         at,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git reference %q at %d: %w", gitRef, at, err)
+	}
+
+	return out.SHA.String(), nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -39,6 +40,9 @@ type ImportInput struct {
 	ProviderRepo string            `json:"provider_repo"`
 
 	Pipelines importer.PipelineOption `json:"pipelines"`
+
+	// ObjectFormat is the hash algorithm used by the remote repository (sha1 or sha256, default: sha1).
+	ObjectFormat sha.ObjectFormat `json:"object_format"`
 }
 
 // Import creates a new empty repository and starts git import to it from a remote repository.
@@ -64,6 +68,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		in.Description,
 		&session.Principal,
 	)
+	repo.ObjectFormat = in.ObjectFormat
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
@@ -149,5 +154,11 @@ func (c *Controller) sanitizeImportInput(in *ImportInput) error {
 		in.Pipelines = importer.PipelineOptionConvert
 	}
 
+	objectFormat, err := sha.ParseObjectFormat(string(in.ObjectFormat))
+	if err != nil {
+		return err
+	}
+	in.ObjectFormat = objectFormat
+
 	return nil
 }
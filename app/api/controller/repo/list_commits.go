@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -68,6 +69,13 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	if filter.IncludeSignatureStatus {
+		err = c.setSignatureStatuses(ctx, repo, rpcOut.Commits, commits)
+		if err != nil {
+			return types.ListCommitResponse{}, err
+		}
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
@@ -82,3 +90,35 @@ func (c *Controller) ListCommits(ctx context.Context,
 		TotalCommits:  rpcOut.TotalCommits,
 	}, nil
 }
+
+// setSignatureStatuses verifies the signatures of the commits against the signing keys registered by the committers.
+func (c *Controller) setSignatureStatuses(
+	ctx context.Context,
+	repo *types.Repository,
+	gitCommits []git.Commit,
+	commits []types.Commit,
+) error {
+	commitSHAs := make([]sha.SHA, len(gitCommits))
+	for i := range gitCommits {
+		commitSHAs[i] = gitCommits[i].SHA
+	}
+
+	signaturesOut, err := c.git.GetCommitSignatures(ctx, &git.GetCommitSignaturesParams{
+		ReadParams: git.CreateReadParams(repo),
+		CommitSHAs: commitSHAs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	statuses, err := c.publicKeySvc.VerifyCommitSignatures(ctx, signaturesOut.Signatures)
+	if err != nil {
+		return fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	for i := range commits {
+		commits[i].SignatureStatus = statuses[commits[i].SHA]
+	}
+
+	return nil
+}
@@ -33,6 +33,7 @@ func (c *Controller) ListPaths(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	gitRef string,
+	at int64,
 	includeDirectories bool,
 ) (ListPathsOutput, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
@@ -45,6 +46,11 @@ func (c *Controller) ListPaths(ctx context.Context,
 		gitRef = repo.DefaultBranch
 	}
 
+	gitRef, err = c.resolveGitRefAt(ctx, repo, gitRef, at)
+	if err != nil {
+		return ListPathsOutput{}, err
+	}
+
 	rpcOut, err := c.git.ListPaths(ctx, &git.ListPathsParams{
 		ReadParams:         git.CreateReadParams(repo),
 		GitREF:             gitRef,
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipList lists all memberships of a repository.
+// Members of the parent spaces aren't included, they are listed with the space memberships.
+func (c *Controller) MembershipList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.RepoMembershipUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := c.repoMembershipStore.ListUsers(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships for repo: %w", err)
+	}
+
+	return memberships, nil
+}
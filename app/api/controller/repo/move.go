@@ -17,12 +17,18 @@ package repo
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // MoveInput is used for moving a repo.
@@ -30,20 +36,23 @@ type MoveInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
 	UID        *string `json:"uid" deprecated:"true"`
 	Identifier *string `json:"identifier"`
+	// ParentRef is the space the repo is transferred to (optional, default: current space of the repo).
+	ParentRef *string `json:"parent_ref"`
 }
 
-func (i *MoveInput) hasChanges(repo *types.Repository) bool {
+func (i *MoveInput) hasChanges(repo *types.Repository, parentID int64) bool {
 	if i.Identifier != nil && *i.Identifier != repo.Identifier {
 		return true
 	}
 
-	return false
+	return parentID != repo.ParentID
 }
 
-// Move moves a repository to a new identifier.
-// TODO: Add support for moving to other parents and aliases.
+// Move moves a repository to a new identifier and/or transfers it to another space.
+// The git data, webhooks and all other data of the repo are kept, as they aren't bound to the repo path.
+// The former path of the repo is redirected to the repo, so that old clone URLs and API calls keep working.
 //
-//nolint:gocognit // refactor if needed
+//nolint:gocognit,gocyclo,cyclop // refactor if needed
 func (c *Controller) Move(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -66,10 +75,33 @@ func (c *Controller) Move(ctx context.Context,
 		return nil, err
 	}
 
-	if !in.hasChanges(repo) {
+	parentID := repo.ParentID
+	if in.ParentRef != nil {
+		parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, *in.ParentRef)
+		if err != nil {
+			return nil, err
+		}
+
+		parentID = parentSpace.ID
+	}
+
+	if !in.hasChanges(repo, parentID) {
 		return GetRepoOutput(ctx, c.publicAccess, repo)
 	}
 
+	if parentID != repo.ParentID {
+		// transferring a repo out of its space removes it from the space, similar to a deletion.
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoDelete); err != nil {
+			return nil, err
+		}
+
+		if err = c.resourceLimiter.RepoCount(ctx, parentID, 1); err != nil {
+			return nil, fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+	}
+
+	repoClone := repo.Clone()
+	oldParentID := repo.ParentID
 	oldIdentifier := repo.Identifier
 
 	isPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
@@ -87,14 +119,43 @@ func (c *Controller) Move(ctx context.Context,
 	}
 
 	// TODO add a repo level lock here to avoid racing condition or partial repo update w/o setting repo public access
-	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
-		if in.Identifier != nil {
-			r.Identifier = *in.Identifier
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		movedRepo, err := c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+			if in.Identifier != nil {
+				r.Identifier = *in.Identifier
+			}
+			r.ParentID = parentID
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repo: %w", err)
+		}
+
+		// the repo takes precedence over any redirect of its new path.
+		if err = c.repoRedirectStore.Delete(ctx, movedRepo.ParentID, movedRepo.Identifier); err != nil {
+			return fmt.Errorf("failed to delete redirect of new repo path: %w", err)
+		}
+
+		// paths are case-insensitive, so a case-only rename doesn't require a redirect.
+		if movedRepo.ParentID != oldParentID || !strings.EqualFold(movedRepo.Identifier, oldIdentifier) {
+			err = c.repoRedirectStore.Upsert(ctx, &types.RepositoryRedirect{
+				SpaceID:    oldParentID,
+				Identifier: oldIdentifier,
+				RepoID:     movedRepo.ID,
+				CreatedBy:  session.Principal.ID,
+				Created:    movedRepo.Updated,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create redirect of old repo path: %w", err)
+			}
 		}
+
+		repo = movedRepo
+
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update repo: %w", err)
+		return nil, err
 	}
 
 	// set public access for the new repo path
@@ -104,10 +165,11 @@ func (c *Controller) Move(ctx context.Context,
 			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
 		}
 
-		// revert identifier changes first
+		// revert identifier and parent changes first
 		var dErr error
 		repo, dErr = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
 			r.Identifier = oldIdentifier
+			r.ParentID = oldParentID
 			return nil
 		})
 		if dErr != nil {
@@ -118,6 +180,14 @@ func (c *Controller) Move(ctx context.Context,
 			)
 		}
 
+		if dErr = c.repoRedirectStore.Delete(ctx, oldParentID, oldIdentifier); dErr != nil {
+			return nil, fmt.Errorf(
+				"failed to set public access for new path (and reverting of redirect: %w): %w",
+				dErr,
+				err,
+			)
+		}
+
 		// revert public access changes only after we successfully restored original path
 		if dErr = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, isPublic); dErr != nil {
 			return nil, fmt.Errorf(
@@ -130,6 +200,18 @@ func (c *Controller) Move(ctx context.Context,
 		return nil, fmt.Errorf("failed to set repo public access for new path (cleanup successful): %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(repoClone),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for move repository operation: %s", err)
+	}
+
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
@@ -148,5 +230,12 @@ func (c *Controller) sanitizeMoveInput(in *MoveInput) error {
 		}
 	}
 
+	if in.ParentRef != nil {
+		*in.ParentRef = strings.Trim(*in.ParentRef, "/")
+		if *in.ParentRef == "" {
+			return usererror.BadRequest("The parent space can't be empty.")
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	ccTrayPageSize = 100
+
+	ccActivityBuilding = "Building"
+	ccActivitySleeping = "Sleeping"
+
+	ccStatusSuccess   = "Success"
+	ccStatusFailure   = "Failure"
+	ccStatusException = "Exception"
+	ccStatusUnknown   = "Unknown"
+)
+
+// PipelinesCCTray returns the status of the latest executions of the pipelines
+// of the repository as a CCTray XML feed.
+func (c *Controller) PipelinesCCTray(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.CCProjects, error) {
+	repo, err := c.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+	if err := apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", enum.PermissionPipelineView); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	projects := &types.CCProjects{
+		Project: []*types.CCProject{},
+	}
+
+	filter := types.ListQueryFilter{
+		Pagination: types.Pagination{Page: 1, Size: ccTrayPageSize},
+	}
+	for {
+		pipelines, err := c.pipelineStore.ListLatest(ctx, repo.ID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list latest pipelines: %w", err)
+		}
+
+		for _, pipeline := range pipelines {
+			webURL := c.urlProvider.GenerateUIRepoURL(ctx, repo.Path)
+			if pipeline.Execution != nil {
+				webURL = c.urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier,
+					pipeline.Execution.Number)
+			}
+
+			projects.Project = append(projects.Project,
+				ccProject(repo.Path+"/"+pipeline.Identifier, webURL, pipeline.Execution))
+		}
+
+		if len(pipelines) < filter.Size {
+			break
+		}
+		filter.Page++
+	}
+
+	return projects, nil
+}
+
+// ccProject returns the CCTray project status of a pipeline based on its latest execution.
+// While the execution is in progress, its status is unknown.
+func ccProject(name string, webURL string, execution *types.Execution) *types.CCProject {
+	project := &types.CCProject{
+		Name:            name,
+		Activity:        ccActivitySleeping,
+		LastBuildStatus: ccStatusUnknown,
+		LastBuildLabel:  ccStatusUnknown,
+		WebURL:          webURL,
+	}
+	if execution == nil {
+		return project
+	}
+
+	switch execution.Status {
+	case enum.CIStatusPending, enum.CIStatusRunning, enum.CIStatusBlocked, enum.CIStatusWaitingOnDeps:
+		project.Activity = ccActivityBuilding
+		return project
+	case enum.CIStatusSuccess:
+		project.LastBuildStatus = ccStatusSuccess
+	case enum.CIStatusFailure:
+		project.LastBuildStatus = ccStatusFailure
+	case enum.CIStatusError, enum.CIStatusKilled, enum.CIStatusDeclined:
+		project.LastBuildStatus = ccStatusException
+	case enum.CIStatusSkipped:
+	}
+
+	started := execution.Started
+	if started == 0 {
+		started = execution.Created
+	}
+	project.LastBuildLabel = strconv.FormatInt(execution.Number, 10)
+	project.LastBuildTime = time.UnixMilli(started).UTC().Format(time.RFC3339)
+
+	return project
+}
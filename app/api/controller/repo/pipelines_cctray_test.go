@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCCProject(t *testing.T) {
+	started := int64(1700000000000)
+
+	tests := []struct {
+		name      string
+		execution *types.Execution
+		want      types.CCProject
+	}{
+		{
+			name: "no execution",
+			want: types.CCProject{Activity: "Sleeping", LastBuildStatus: "Unknown", LastBuildLabel: "Unknown"},
+		},
+		{
+			name:      "running",
+			execution: &types.Execution{Number: 3, Status: enum.CIStatusRunning, Started: started},
+			want:      types.CCProject{Activity: "Building", LastBuildStatus: "Unknown", LastBuildLabel: "Unknown"},
+		},
+		{
+			name:      "success",
+			execution: &types.Execution{Number: 3, Status: enum.CIStatusSuccess, Started: started},
+			want: types.CCProject{Activity: "Sleeping", LastBuildStatus: "Success", LastBuildLabel: "3",
+				LastBuildTime: "2023-11-14T22:13:20Z"},
+		},
+		{
+			name:      "failure",
+			execution: &types.Execution{Number: 4, Status: enum.CIStatusFailure, Started: started},
+			want: types.CCProject{Activity: "Sleeping", LastBuildStatus: "Failure", LastBuildLabel: "4",
+				LastBuildTime: "2023-11-14T22:13:20Z"},
+		},
+		{
+			name:      "killed before start",
+			execution: &types.Execution{Number: 5, Status: enum.CIStatusKilled, Created: started},
+			want: types.CCProject{Activity: "Sleeping", LastBuildStatus: "Exception", LastBuildLabel: "5",
+				LastBuildTime: "2023-11-14T22:13:20Z"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.want.Name = "space/repo/build"
+			test.want.WebURL = "http://localhost/space/repo"
+
+			got := ccProject("space/repo/build", "http://localhost/space/repo", test.execution)
+			if *got != test.want {
+				t.Errorf("got %+v, want %+v", *got, test.want)
+			}
+		})
+	}
+}
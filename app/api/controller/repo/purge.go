@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -72,6 +73,11 @@ func (c *Controller) PurgeNoAuth(
 		}
 	}
 
+	// forks borrow objects from the repository, they have to be detached before the git repository is removed.
+	if err := c.DetachForks(ctx, session, repo); err != nil {
+		return fmt.Errorf("failed to detach forks: %w", err)
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
@@ -80,6 +86,14 @@ func (c *Controller) PurgeNoAuth(
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
 
+	if err := c.wikiService.Delete(ctx, &session.Principal, repo); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to remove wiki git repository")
+	}
+
+	if err := c.RestoreForkParentPruning(ctx, session, repo); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to restore pruning of the forked repository")
+	}
+
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
@@ -95,6 +109,116 @@ func (c *Controller) DeleteGitRepository(
 	gitUID string,
 ) error {
 	// create custom write params for delete as repo might or might not exist in db (similar to create).
+	writeParams, err := c.createWriteParamsWithoutRepo(ctx, session, gitUID)
+	if err != nil {
+		return err
+	}
+
+	err = c.git.DeleteRepository(ctx, &git.DeleteRepositoryParams{
+		WriteParams: writeParams,
+	})
+
+	// deletion should not fail if repo dir does not exist.
+	if errors.IsNotFound(err) {
+		log.Ctx(ctx).Warn().Str("repo.git_uid", gitUID).
+			Msg("git repository directory does not exist")
+	} else if err != nil {
+		return fmt.Errorf("failed to remove git repository %s: %w", gitUID, err)
+	}
+	return nil
+}
+
+// DetachForks copies all objects the forks of the repository borrow from it into the forks,
+// which allows to remove the git repository without corrupting its forks.
+// Afterwards, the pruning of unreachable objects (disabled while the repository has forks) is restored.
+func (c *Controller) DetachForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	forks, err := c.repoStore.ListForkGitInfos(ctx, &repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := c.createWriteParamsWithoutRepo(ctx, session, fork.GitUID)
+		if err != nil {
+			return err
+		}
+
+		err = c.git.DetachAlternates(ctx, &git.DetachAlternatesParams{
+			WriteParams: writeParams,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to detach fork %d: %w", fork.ID, err)
+		}
+	}
+
+	if len(forks) == 0 {
+		return nil
+	}
+
+	return c.restorePruning(ctx, session, repo.GitUID)
+}
+
+// RestoreForkParentPruning restores the pruning of unreachable objects of the repository the purged fork
+// was forked from, in case the forked repository doesn't have any forks left.
+func (c *Controller) RestoreForkParentPruning(
+	ctx context.Context,
+	session *auth.Session,
+	fork *types.Repository,
+) error {
+	if fork.ForkID == 0 {
+		return nil
+	}
+
+	forks, err := c.repoStore.ListForkGitInfos(ctx, &fork.ForkID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks of the forked repository: %w", err)
+	}
+	if len(forks) > 0 {
+		return nil
+	}
+
+	parent, err := c.repoStore.Find(ctx, fork.ForkID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the forked repository is deleted, its git repository gets removed once it's purged.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find the forked repository: %w", err)
+	}
+
+	return c.restorePruning(ctx, session, parent.GitUID)
+}
+
+func (c *Controller) restorePruning(
+	ctx context.Context,
+	session *auth.Session,
+	gitUID string,
+) error {
+	writeParams, err := c.createWriteParamsWithoutRepo(ctx, session, gitUID)
+	if err != nil {
+		return err
+	}
+
+	err = c.git.RestorePruning(ctx, &git.RestorePruningParams{
+		WriteParams: writeParams,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore pruning: %w", err)
+	}
+
+	return nil
+}
+
+// createWriteParamsWithoutRepo creates git write params that don't depend on the repo being present in the db.
+func (c *Controller) createWriteParamsWithoutRepo(
+	ctx context.Context,
+	session *auth.Session,
+	gitUID string,
+) (git.WriteParams, error) {
 	envVars, err := githook.GenerateEnvironmentVariables(
 		ctx,
 		c.urlProvider.GetInternalAPIURL(ctx),
@@ -104,27 +228,15 @@ func (c *Controller) DeleteGitRepository(
 		true,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
 	}
-	writeParams := git.WriteParams{
+
+	return git.WriteParams{
 		Actor: git.Identity{
 			Name:  session.Principal.DisplayName,
 			Email: session.Principal.Email,
 		},
 		RepoUID: gitUID,
 		EnvVars: envVars,
-	}
-
-	err = c.git.DeleteRepository(ctx, &git.DeleteRepositoryParams{
-		WriteParams: writeParams,
-	})
-
-	// deletion should not fail if repo dir does not exist.
-	if errors.IsNotFound(err) {
-		log.Ctx(ctx).Warn().Str("repo.git_uid", gitUID).
-			Msg("git repository directory does not exist")
-	} else if err != nil {
-		return fmt.Errorf("failed to remove git repository %s: %w", gitUID, err)
-	}
-	return nil
+	}, nil
 }
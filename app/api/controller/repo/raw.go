@@ -26,6 +26,38 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// RawOutput holds the raw content of a file and the information required to serve it.
+type RawOutput struct {
+	// GitRef is the git reference the file was read from.
+	GitRef string
+	// Path is the path of the file within the repository.
+	Path    string
+	BlobSHA sha.SHA
+	Size    int64
+	// Immutable is true if the file was referenced by a full commit SHA, hence its content can never change.
+	Immutable bool
+	IsPublic  bool
+	// Content is the reader of the blob content, it has to be closed by the caller.
+	Content io.ReadCloser
+
+	readParams git.ReadParams
+	git        git.Interface
+}
+
+// Reopen returns a new reader of the blob content positioned at the start of the blob.
+func (o *RawOutput) Reopen(ctx context.Context) (io.ReadCloser, error) {
+	blob, err := o.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: o.readParams,
+		SHA:        o.BlobSHA.String(),
+		SizeLimit:  0, // no size limit, we stream whatever data there is
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	return blob.Content, nil
+}
+
 // Raw finds the file of the repo at the given path and returns its raw content.
 // If no gitRef is provided, the content is retrieved from the default branch.
 func (c *Controller) Raw(ctx context.Context,
@@ -33,10 +65,10 @@ func (c *Controller) Raw(ctx context.Context,
 	repoRef string,
 	gitRef string,
 	path string,
-) (io.ReadCloser, int64, sha.SHA, error) {
+) (*RawOutput, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
-		return nil, 0, sha.Nil, err
+		return nil, err
 	}
 
 	// set gitRef to default branch in case an empty reference was provided
@@ -46,31 +78,65 @@ func (c *Controller) Raw(ctx context.Context,
 
 	// create read params once
 	readParams := git.CreateReadParams(repo)
-	treeNodeOutput, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
-		ReadParams:          readParams,
-		GitREF:              gitRef,
-		Path:                path,
-		IncludeLatestCommit: false,
-	})
+
+	node, err := c.getRawTreeNode(ctx, readParams, gitRef, path)
 	if err != nil {
-		return nil, 0, sha.Nil, fmt.Errorf("failed to read tree node: %w", err)
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
 	}
 
 	// viewing Raw content is only supported for blob content
-	if treeNodeOutput.Node.Type != git.TreeNodeTypeBlob {
-		return nil, 0, sha.Nil, usererror.BadRequestf(
+	if node.Type != git.TreeNodeTypeBlob {
+		return nil, usererror.BadRequestf(
 			"Object in '%s' at '/%s' is of type '%s'. Only objects of type %s support raw viewing.",
-			gitRef, path, treeNodeOutput.Node.Type, git.TreeNodeTypeBlob)
+			gitRef, path, node.Type, git.TreeNodeTypeBlob)
 	}
 
 	blobReader, err := c.git.GetBlob(ctx, &git.GetBlobParams{
 		ReadParams: readParams,
-		SHA:        treeNodeOutput.Node.SHA,
+		SHA:        node.SHA,
 		SizeLimit:  0, // no size limit, we stream whatever data there is
 	})
 	if err != nil {
-		return nil, 0, sha.Nil, fmt.Errorf("failed to read blob: %w", err)
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	isPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
+	if err != nil {
+		_ = blobReader.Content.Close()
+		return nil, fmt.Errorf("failed to check if repo is public: %w", err)
+	}
+
+	refSHA, err := sha.New(gitRef)
+	immutable := err == nil && refSHA.IsFull()
+
+	return &RawOutput{
+		GitRef:     gitRef,
+		Path:       path,
+		BlobSHA:    blobReader.SHA,
+		Size:       blobReader.ContentSize,
+		Immutable:  immutable,
+		IsPublic:   isPublic,
+		Content:    blobReader.Content,
+		readParams: readParams,
+		git:        c.git,
+	}, nil
+}
+
+func (c *Controller) getRawTreeNode(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+	path string,
+) (*git.TreeNode, error) {
+	treeNodeOutput, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams:          readParams,
+		GitREF:              gitRef,
+		Path:                path,
+		IncludeLatestCommit: false,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return blobReader.Content, blobReader.ContentSize, blobReader.SHA, nil
+	return &treeNodeOutput.Node, nil
 }
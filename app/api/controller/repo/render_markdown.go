@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxMarkdownSize is the maximum size of a markdown document that can be rendered.
+const maxMarkdownSize = 1 << 20 // 1 MiB
+
+// RenderMarkdownInput is the input for rendering a markdown document of the repository.
+type RenderMarkdownInput struct {
+	// Text is the markdown to render. If empty, the file at Path is rendered.
+	Text string `json:"text"`
+
+	// GitRef is the git reference used to resolve relative links and images, defaults to the default branch.
+	GitRef string `json:"git_ref"`
+
+	// Path is the path of the markdown document in the repository, relative links are resolved against it.
+	Path string `json:"path"`
+}
+
+func (in *RenderMarkdownInput) sanitize() error {
+	in.Path = strings.Trim(in.Path, "/")
+
+	if in.Text == "" && in.Path == "" {
+		return usererror.BadRequest("Either the text or the path of the markdown document is required.")
+	}
+
+	if len(in.Text) > maxMarkdownSize {
+		return usererror.BadRequestf("Markdown text can't be larger than %d bytes.", maxMarkdownSize)
+	}
+
+	return nil
+}
+
+// RenderMarkdownOutput holds the rendered markdown document.
+type RenderMarkdownOutput struct {
+	HTML string `json:"html"`
+}
+
+// RenderMarkdown converts the markdown (e.g. a README or a pull request description) to sanitized HTML.
+// Relative links and images are resolved to the files of the repository,
+// and user mentions and pull request references are auto-linked.
+func (c *Controller) RenderMarkdown(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RenderMarkdownInput,
+) (*RenderMarkdownOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.GitRef == "" {
+		in.GitRef = repo.DefaultBranch
+	}
+
+	text := in.Text
+	if text == "" {
+		text, err = c.readMarkdownFile(ctx, repo, in.GitRef, in.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	html, err := c.markdownRenderer.Render(ctx, repo, in.GitRef, in.Path, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return &RenderMarkdownOutput{HTML: html}, nil
+}
+
+func (c *Controller) readMarkdownFile(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	path string,
+) (string, error) {
+	readParams := git.CreateReadParams(repo)
+
+	node, err := c.getRawTreeNode(ctx, readParams, gitRef, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read tree node: %w", err)
+	}
+
+	if node.Type != git.TreeNodeTypeBlob {
+		return "", usererror.BadRequestf("Object in '%s' at '/%s' is of type '%s', not a markdown document.",
+			gitRef, path, node.Type)
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.SHA,
+		SizeLimit:  maxMarkdownSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if blob.Size > maxMarkdownSize {
+		return "", usererror.BadRequestf("Markdown document can't be larger than %d bytes.", maxMarkdownSize)
+	}
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to read markdown document: %w", err)
+	}
+
+	return string(content), nil
+}
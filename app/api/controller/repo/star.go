@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Star stars the repository for the current principal.
+func (c *Controller) Star(ctx context.Context, session *auth.Session, repoRef string) (*RepositoryOutput, error) {
+	return c.updateStar(ctx, session, repoRef, true)
+}
+
+// Unstar removes the star of the current principal from the repository.
+func (c *Controller) Unstar(ctx context.Context, session *auth.Session, repoRef string) (*RepositoryOutput, error) {
+	return c.updateStar(ctx, session, repoRef, false)
+}
+
+func (c *Controller) updateStar(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	star bool,
+) (*RepositoryOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var changed bool
+		if star {
+			changed, err = c.repoStarStore.Create(ctx, session.Principal.ID, repo.ID, time.Now().UnixMilli())
+		} else {
+			changed, err = c.repoStarStore.Delete(ctx, session.Principal.ID, repo.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update repository star: %w", err)
+		}
+
+		if !changed {
+			return nil
+		}
+
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+			if star {
+				r.NumStars++
+			} else if r.NumStars > 0 {
+				r.NumStars--
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update number of stars of the repository: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// backfill GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	return GetRepoOutput(ctx, c.publicAccess, repo)
+}
+
+// ListStarred lists the repositories starred by the current principal, the most recently starred first.
+// Repositories the principal lost access to are omitted from the list.
+func (c *Controller) ListStarred(
+	ctx context.Context,
+	session *auth.Session,
+	pagination types.Pagination,
+) ([]*RepositoryOutput, int64, error) {
+	var (
+		repoIDs []int64
+		count   int64
+	)
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		count, err = c.repoStarStore.Count(ctx, session.Principal.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count starred repositories: %w", err)
+		}
+
+		repoIDs, err = c.repoStarStore.ListRepoIDs(ctx, session.Principal.ID, pagination)
+		if err != nil {
+			return fmt.Errorf("failed to list starred repositories: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reposOut := make([]*RepositoryOutput, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		repo, err := c.repoStore.Find(ctx, repoID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to find starred repository: %w", err)
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check access to starred repository: %w", err)
+		}
+
+		// backfill URLs
+		repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+		repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+		repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get repo %q output: %w", repo.Path, err)
+		}
+
+		reposOut = append(reposOut, repoOut)
+	}
+
+	return reposOut, count, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TemplateDrift returns the differences between the repository and the repo templates of its spaces.
+func (c *Controller) TemplateDrift(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.RepoTemplateDrift, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := c.repoTemplateSvc.Drift(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo template drift: %w", err)
+	}
+
+	return drift, nil
+}
+
+// TemplateApply (re)applies the repo templates of its spaces to the repository.
+// Missing rules and webhooks are created, modified ones are overwritten.
+func (c *Controller) TemplateApply(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.RepoTemplateDrift, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := c.repoTemplateSvc.Apply(ctx, session.Principal.ID, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply repo templates: %w", err)
+	}
+
+	return drift, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// trafficDefaultPeriod is the period reported in case the caller didn't provide a start of the period.
+const trafficDefaultPeriod = 14 * 24 * time.Hour
+
+// Traffic returns the daily clone and fetch statistics of a repository.
+func (c *Controller) Traffic(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter types.RepoTrafficFilter,
+) (*types.RepoTraffic, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.After == 0 {
+		// traffic is stored per day, so the period has to start at midnight to include the whole first day.
+		filter.After = gitTrafficDay(time.Now().Add(-trafficDefaultPeriod))
+	}
+	if filter.Before != 0 && filter.Before < filter.After {
+		return nil, usererror.BadRequest("The end of the period must not be before its start.")
+	}
+
+	days, err := c.repoTrafficStore.ListDays(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo traffic: %w", err)
+	}
+
+	uniqueCallers, err := c.repoTrafficStore.CountUniqueCallers(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unique callers of repo: %w", err)
+	}
+
+	traffic := &types.RepoTraffic{
+		UniqueCallers: uniqueCallers,
+		Days:          days,
+	}
+	for _, day := range days {
+		traffic.Clones += day.Clones
+		traffic.Fetches += day.Fetches
+	}
+
+	return traffic, nil
+}
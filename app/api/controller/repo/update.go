@@ -17,6 +17,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/harness/gitness/app/auth"
@@ -31,11 +32,15 @@ import (
 
 // UpdateInput is used for updating a repo.
 type UpdateInput struct {
-	Description *string `json:"description"`
+	Description *string   `json:"description"`
+	IsTemplate  *bool     `json:"is_template"`
+	Topics      *[]string `json:"topics"`
 }
 
 func (in *UpdateInput) hasChanges(repo *types.Repository) bool {
-	return in.Description != nil && *in.Description != repo.Description
+	return in.Description != nil && *in.Description != repo.Description ||
+		in.IsTemplate != nil && *in.IsTemplate != repo.IsTemplate ||
+		in.Topics != nil && !slices.Equal(*in.Topics, repo.Topics)
 }
 
 // Update updates a repository.
@@ -64,6 +69,12 @@ func (c *Controller) Update(ctx context.Context,
 		if in.Description != nil {
 			repo.Description = *in.Description
 		}
+		if in.IsTemplate != nil {
+			repo.IsTemplate = *in.IsTemplate
+		}
+		if in.Topics != nil {
+			repo.Topics = *in.Topics
+		}
 
 		return nil
 	})
@@ -98,5 +109,30 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Topics != nil {
+		topics, err := sanitizeTopics(*in.Topics)
+		if err != nil {
+			return err
+		}
+		in.Topics = &topics
+	}
+
 	return nil
 }
+
+// sanitizeTopics normalizes the topics to lower case, removes duplicates and validates them.
+func sanitizeTopics(topics []string) ([]string, error) {
+	res := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if !slices.Contains(res, topic) {
+			res = append(res, topic)
+		}
+	}
+
+	if err := check.RepoTopics(res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UpdateArchivedInput struct {
+	Archived bool `json:"archived"`
+}
+
+// UpdateArchived archives or unarchives a repo. Archived repos are read-only.
+func (c *Controller) UpdateArchived(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *UpdateArchivedInput,
+) (*RepositoryOutput, error) {
+	// the repo is fetched directly, as the access check of archived repos rejects the edit permission.
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.State != enum.RepoStateActive {
+		return nil, usererror.BadRequest("Repository is not ready to use.")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit); err != nil {
+		return nil, err
+	}
+
+	// no op
+	if repo.Archived == in.Archived {
+		return GetRepoOutput(ctx, c.publicAccess, repo)
+	}
+
+	repoClone := repo.Clone()
+
+	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.Archived = in.Archived
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repo: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(repoClone),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository operation: %s", err)
+	}
+
+	// backfill GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	return GetRepoOutput(ctx, c.publicAccess, repo)
+}
@@ -19,15 +19,23 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/filetemplate"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/markdown"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/role"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/wiki"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
@@ -74,13 +82,28 @@ func ProvideController(
 	instrumentation instrument.Service,
 	userGroupStore store.UserGroupStore,
 	userGroupService usergroup.SearchService,
+	repoTrafficStore store.RepoTrafficStore,
+	repoTemplateSvc *repotemplate.Service,
+	complianceScanner *compliance.Scanner,
+	storagePoolSvc *storagepool.Service,
+	repoRedirectStore store.RepoRedirectStore,
+	repoStarStore store.RepoStarStore,
+	markdownRenderer *markdown.Renderer,
+	wikiService *wiki.Service,
+	fileTemplateSvc *filetemplate.Service,
+	publicKeySvc publickey.Service,
+	repoMembershipStore store.RepoMembershipStore,
+	roleSvc *role.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
 		repoStore, spaceStore, pipelineStore,
 		principalStore, ruleStore, settings, principalInfoCache, protectionManager, rpcClient, importer,
 		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
-		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService)
+		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
+		repoTrafficStore, repoTemplateSvc, complianceScanner, storagePoolSvc, repoRedirectStore,
+		repoStarStore, markdownRenderer, wikiService, fileTemplateSvc, publicKeySvc,
+		repoMembershipStore, roleSvc)
 }
 
 func ProvideRepoCheck() Check {
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/descriptiontemplate"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/audit"
@@ -32,6 +33,7 @@ type Controller struct {
 	repoStore    store.RepoStore
 	settings     *settings.Service
 	auditService audit.Service
+	templates    *descriptiontemplate.Service
 }
 
 func NewController(
@@ -39,12 +41,14 @@ func NewController(
 	repoStore store.RepoStore,
 	settings *settings.Service,
 	auditService audit.Service,
+	templates *descriptiontemplate.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:   authorizer,
 		repoStore:    repoStore,
 		settings:     settings,
 		auditService: auditService,
+		templates:    templates,
 	}
 }
 
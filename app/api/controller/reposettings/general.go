@@ -15,7 +15,12 @@
 package reposettings
 
 import (
+	"slices"
+
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
 )
@@ -23,22 +28,65 @@ import (
 // GeneralSettings represent the general repository settings as exposed externally.
 type GeneralSettings struct {
 	FileSizeLimit *int64 `json:"file_size_limit" yaml:"file_size_limit"`
+	// CodeOwnersRequestReview requests reviews of pull requests from the code owners of the changed files.
+	CodeOwnersRequestReview *bool `json:"code_owners_request_review" yaml:"code_owners_request_review"`
+	// MergeMethods are the merge methods allowed for pull requests of the repository.
+	MergeMethods []enum.MergeMethod `json:"merge_methods" yaml:"merge_methods"`
+	// PipelineClone configures the implicit clone step of the pipelines of the repository.
+	PipelineClone *types.PipelineClone `json:"pipeline_clone" yaml:"pipeline_clone"`
+}
+
+func (s *GeneralSettings) sanitize() error {
+	if s.FileSizeLimit != nil && *s.FileSizeLimit <= 0 {
+		return check.NewValidationError("File size limit must be a positive number.")
+	}
+
+	if s.PipelineClone != nil {
+		if err := s.PipelineClone.Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	if s.MergeMethods == nil {
+		return nil
+	}
+
+	if len(s.MergeMethods) == 0 {
+		return check.NewValidationError("At least one merge method must be allowed.")
+	}
+
+	for _, method := range s.MergeMethods {
+		if _, ok := method.Sanitize(); !ok || method == "" {
+			return check.NewValidationErrorf("Unsupported merge method %q.", method)
+		}
+	}
+
+	slices.Sort(s.MergeMethods)
+	s.MergeMethods = slices.Compact(s.MergeMethods)
+
+	return nil
 }
 
 func GetDefaultGeneralSettings() *GeneralSettings {
 	return &GeneralSettings{
-		FileSizeLimit: ptr.Int64(settings.DefaultFileSizeLimit),
+		FileSizeLimit:           ptr.Int64(settings.DefaultFileSizeLimit),
+		CodeOwnersRequestReview: ptr.Bool(settings.DefaultCodeOwnersRequestReview),
+		MergeMethods:            slices.Clone(enum.MergeMethods),
+		PipelineClone:           &types.PipelineClone{},
 	}
 }
 
 func GetGeneralSettingsMappings(s *GeneralSettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeyFileSizeLimit, s.FileSizeLimit),
+		settings.Mapping(settings.KeyCodeOwnersRequestReview, s.CodeOwnersRequestReview),
+		settings.Mapping(settings.KeyMergeMethods, &s.MergeMethods),
+		settings.Mapping(settings.KeyPipelineClone, s.PipelineClone),
 	}
 }
 
 func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 4)
 
 	if s.FileSizeLimit != nil {
 		kvs = append(kvs, settings.KeyValue{
@@ -46,5 +94,26 @@ func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
 			Value: s.FileSizeLimit,
 		})
 	}
+
+	if s.CodeOwnersRequestReview != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyCodeOwnersRequestReview,
+			Value: s.CodeOwnersRequestReview,
+		})
+	}
+
+	if s.MergeMethods != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyMergeMethods,
+			Value: s.MergeMethods,
+		})
+	}
+
+	if s.PipelineClone != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyPipelineClone,
+			Value: s.PipelineClone,
+		})
+	}
 	return kvs
 }
@@ -33,6 +33,10 @@ func (c *Controller) GeneralUpdate(
 	repoRef string,
 	in *GeneralSettings,
 ) (*GeneralSettings, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
 	if err != nil {
 		return nil, err
@@ -41,7 +45,7 @@ func (c *Controller) GeneralUpdate(
 	// read old settings values
 	old := GetDefaultGeneralSettings()
 	oldMappings := GetGeneralSettingsMappings(old)
-	err = c.settings.RepoMap(ctx, repo.ID, oldMappings...)
+	err = c.settings.RepoMapInherited(ctx, repo, oldMappings...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map settings (old): %w", err)
 	}
@@ -54,7 +58,7 @@ func (c *Controller) GeneralUpdate(
 	// read all settings and return complete config
 	out := GetDefaultGeneralSettings()
 	mappings := GetGeneralSettingsMappings(out)
-	err = c.settings.RepoMap(ctx, repo.ID, mappings...)
+	err = c.settings.RepoMapInherited(ctx, repo, mappings...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map settings: %w", err)
 	}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	// maxDefaultReviewers defines the max allowed number of default reviewers of a repository.
+	maxDefaultReviewers = 32
+	// maxDefaultReviewerPaths defines the max allowed number of path patterns of a single default reviewer.
+	maxDefaultReviewerPaths = 32
+)
+
+// ReviewerSettings represents the reviewer related part of repository settings as exposed externally.
+type ReviewerSettings struct {
+	// DefaultReviewers are requested to review every new pull request of the repository.
+	DefaultReviewers *[]types.DefaultReviewer `json:"default_reviewers" yaml:"default_reviewers"`
+}
+
+func (s *ReviewerSettings) sanitize() error {
+	if s.DefaultReviewers == nil {
+		return nil
+	}
+
+	if len(*s.DefaultReviewers) > maxDefaultReviewers {
+		return check.NewValidationErrorf("A repository can have at most %d default reviewers.", maxDefaultReviewers)
+	}
+
+	for _, reviewer := range *s.DefaultReviewers {
+		if (reviewer.PrincipalID > 0) == (reviewer.UserGroupID > 0) {
+			return check.NewValidationError("A default reviewer must be either a principal or a user group.")
+		}
+
+		if len(reviewer.Paths) > maxDefaultReviewerPaths {
+			return check.NewValidationErrorf("A default reviewer can have at most %d path patterns.",
+				maxDefaultReviewerPaths)
+		}
+
+		for _, pattern := range reviewer.Paths {
+			if pattern == "" || !doublestar.ValidatePattern(pattern) {
+				return check.NewValidationErrorf("The provided path pattern '%s' is invalid.", pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+func GetDefaultReviewerSettings() *ReviewerSettings {
+	return &ReviewerSettings{
+		DefaultReviewers: &[]types.DefaultReviewer{},
+	}
+}
+
+func GetReviewerSettingsMappings(s *ReviewerSettings) []settings.SettingHandler {
+	return []settings.SettingHandler{
+		settings.Mapping(settings.KeyDefaultReviewers, s.DefaultReviewers),
+	}
+}
+
+func GetReviewerSettingsAsKeyValues(s *ReviewerSettings) []settings.KeyValue {
+	kvs := make([]settings.KeyValue, 0, 1)
+	if s.DefaultReviewers != nil {
+		kvs = append(kvs, settings.KeyValue{Key: settings.KeyDefaultReviewers, Value: *s.DefaultReviewers})
+	}
+	return kvs
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestReviewerSettings_Sanitize(t *testing.T) {
+	tooMany := make([]types.DefaultReviewer, maxDefaultReviewers+1)
+	for i := range tooMany {
+		tooMany[i] = types.DefaultReviewer{PrincipalID: int64(i + 1)}
+	}
+
+	tooManyPaths := make([]string, maxDefaultReviewerPaths+1)
+	for i := range tooManyPaths {
+		tooManyPaths[i] = "docs/**"
+	}
+
+	tests := []struct {
+		name      string
+		reviewers *[]types.DefaultReviewer
+		wantErr   bool
+	}{
+		{
+			name:      "not-provided",
+			reviewers: nil,
+		},
+		{
+			name: "valid",
+			reviewers: &[]types.DefaultReviewer{
+				{PrincipalID: 1},
+				{UserGroupID: 2, Paths: []string{"docs/**", "*.md"}},
+			},
+		},
+		{
+			name:      "too-many-reviewers",
+			reviewers: &tooMany,
+			wantErr:   true,
+		},
+		{
+			name:      "neither-principal-nor-group",
+			reviewers: &[]types.DefaultReviewer{{Paths: []string{"docs/**"}}},
+			wantErr:   true,
+		},
+		{
+			name:      "both-principal-and-group",
+			reviewers: &[]types.DefaultReviewer{{PrincipalID: 1, UserGroupID: 2}},
+			wantErr:   true,
+		},
+		{
+			name:      "too-many-paths",
+			reviewers: &[]types.DefaultReviewer{{PrincipalID: 1, Paths: tooManyPaths}},
+			wantErr:   true,
+		},
+		{
+			name:      "empty-path",
+			reviewers: &[]types.DefaultReviewer{{PrincipalID: 1, Paths: []string{""}}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid-path",
+			reviewers: &[]types.DefaultReviewer{{PrincipalID: 1, Paths: []string{"docs/[a"}}},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &ReviewerSettings{DefaultReviewers: test.reviewers}
+			if err := s.sanitize(); (err != nil) != test.wantErr {
+				t.Errorf("want error=%t got=%v", test.wantErr, err)
+			}
+		})
+	}
+}
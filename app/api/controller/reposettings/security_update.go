@@ -41,7 +41,7 @@ func (c *Controller) SecurityUpdate(
 	// read old settings values
 	old := GetDefaultSecuritySettings()
 	oldMappings := GetSecuritySettingsMappings(old)
-	err = c.settings.RepoMap(ctx, repo.ID, oldMappings...)
+	err = c.settings.RepoMapInherited(ctx, repo, oldMappings...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map settings (old): %w", err)
 	}
@@ -54,7 +54,7 @@ func (c *Controller) SecurityUpdate(
 	// read all settings and return complete config
 	out := GetDefaultSecuritySettings()
 	mappings := GetSecuritySettingsMappings(out)
-	err = c.settings.RepoMap(ctx, repo.ID, mappings...)
+	err = c.settings.RepoMapInherited(ctx, repo, mappings...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map settings: %w", err)
 	}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TemplatesList returns the pull request and issue description templates stored on the default branch of a repo.
+func (c *Controller) TemplatesList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	templateType enum.DescriptionTemplateType,
+) ([]types.DescriptionTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := c.templates.List(ctx, repo, templateType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list description templates: %w", err)
+	}
+
+	return templates, nil
+}
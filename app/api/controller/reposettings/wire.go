@@ -16,6 +16,7 @@ package reposettings
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/descriptiontemplate"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/audit"
@@ -33,6 +34,7 @@ func ProvideController(
 	repoStore store.RepoStore,
 	settings *settings.Service,
 	auditService audit.Service,
+	templates *descriptiontemplate.Service,
 ) *Controller {
-	return NewController(authorizer, repoStore, settings, auditService)
+	return NewController(authorizer, repoStore, settings, auditService, templates)
 }
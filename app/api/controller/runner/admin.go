@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// List lists all runners with their health and the number of stages assigned to them.
+func (c *Controller) List(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.Runner, error) {
+	return c.runnerSvc.List(ctx)
+}
+
+// Delete deletes the runner, its accepted stages are rescheduled and its running stages fail.
+func (c *Controller) Delete(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) error {
+	return c.runnerSvc.Delete(ctx, identifier)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/manager"
+	runnersvc "github.com/harness/gitness/app/services/runner"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/runner-go/client"
+)
+
+// Controller implements the API external runners use to execute the pipeline stages delegated to them.
+// All calls are authenticated with the token the runner received on registration,
+// and a runner can only access the stages assigned to it.
+type Controller struct {
+	runnerSvc  *runnersvc.Service
+	manager    manager.ExecutionManager
+	client     client.Client
+	stageStore store.StageStore
+	stepStore  store.StepStore
+}
+
+func NewController(
+	runnerSvc *runnersvc.Service,
+	manager manager.ExecutionManager,
+	client client.Client,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+) *Controller {
+	return &Controller{
+		runnerSvc:  runnerSvc,
+		manager:    manager,
+		client:     client,
+		stageStore: stageStore,
+		stepStore:  stepStore,
+	}
+}
+
+// findStage returns the stage if it's assigned to the runner.
+func (c *Controller) findStage(ctx context.Context, runner *types.Runner, stageID int64) (*types.Stage, error) {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Stage not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Machine != runner.Machine() {
+		return nil, usererror.Forbidden("The stage isn't assigned to the runner.")
+	}
+
+	return stage, nil
+}
+
+// findStep returns the step of the stage if the stage is assigned to the runner.
+func (c *Controller) findStep(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+	stepNumber int,
+) (*types.Stage, *types.Step, error) {
+	stage, err := c.findStage(ctx, runner, stageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	step, err := c.stepStore.FindByNumber(ctx, stage.ID, stepNumber)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, usererror.NotFound("Step not found")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find step: %w", err)
+	}
+
+	return stage, step, nil
+}
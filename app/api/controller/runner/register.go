@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+)
+
+type RegisterInput struct {
+	Identifier string   `json:"identifier"`
+	Tags       []string `json:"tags"`
+	// Capacity is the max number of stages the runner executes concurrently, one if not set.
+	Capacity int    `json:"capacity"`
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+}
+
+// Register registers the runner with the registration secret and returns the token of the runner.
+func (c *Controller) Register(
+	ctx context.Context,
+	secret string,
+	in *RegisterInput,
+) (*types.RunnerRegistration, error) {
+	return c.runnerSvc.RegisterRunner(ctx, secret, &types.Runner{
+		Identifier: in.Identifier,
+		Tags:       in.Tags,
+		Capacity:   in.Capacity,
+		Version:    in.Version,
+		OS:         in.OS,
+		Arch:       in.Arch,
+	})
+}
+
+// Heartbeat marks the runner as alive, runners without heartbeat don't get any stages.
+func (c *Controller) Heartbeat(ctx context.Context, token string) (*types.Runner, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.runnerSvc.Heartbeat(ctx, runner); err != nil {
+		return nil, err
+	}
+
+	return runner, nil
+}
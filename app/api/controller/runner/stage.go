@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/manager"
+	runnersvc "github.com/harness/gitness/app/services/runner"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+)
+
+const (
+	// requestTimeout is the max time a runner waits for a stage within a single request.
+	requestTimeout = 30 * time.Second
+
+	// watchTimeout is the max time a runner waits for the cancellation of an execution within a single request.
+	watchTimeout = 30 * time.Second
+)
+
+type RequestInput struct {
+	Kind    string            `json:"kind"`
+	Type    string            `json:"type"`
+	Variant string            `json:"variant"`
+	Kernel  string            `json:"kernel"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+type WatchOutput struct {
+	Cancelled bool `json:"cancelled"`
+}
+
+// Request waits for the next stage the runner can execute. It returns nil if no stage got ready in time.
+// The platform and the tags of the registration of the runner are used to select the stage.
+func (c *Controller) Request(ctx context.Context, token string, in *RequestInput) (*drone.Stage, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.runnerSvc.CheckAvailable(ctx, runner); err != nil {
+		return nil, err
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	stage, err := c.manager.Request(requestCtx, &manager.Request{
+		Kind:    in.Kind,
+		Type:    in.Type,
+		OS:      runner.OS,
+		Arch:    runner.Arch,
+		Variant: in.Variant,
+		Kernel:  in.Kernel,
+		Labels:  in.Labels,
+		Tags:    runner.Tags,
+	})
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, nil //nolint:nilnil // no stage got ready in time
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to request stage: %w", err)
+	}
+
+	return manager.ConvertToDroneStage(stage), nil
+}
+
+// Accept assigns the stage to the runner. A stage is only assigned to a single runner,
+// runners that requested the same stage concurrently get a conflict.
+func (c *Controller) Accept(ctx context.Context, token string, stageID int64) (*drone.Stage, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.runnerSvc.CheckAvailable(ctx, runner); err != nil {
+		return nil, err
+	}
+
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Stage not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if !runnersvc.Matches(runner, stage) {
+		return nil, usererror.Forbidden("The runner doesn't have all the tags the stage runs on.")
+	}
+	if stage.Machine != "" || stage.Status != enum.CIStatusPending {
+		return nil, usererror.Conflict("The stage was accepted by another runner.")
+	}
+
+	stage, err = c.manager.Accept(ctx, stageID, runner.Machine())
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return nil, usererror.Conflict("The stage was accepted by another runner.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept stage: %w", err)
+	}
+
+	return manager.ConvertToDroneStage(stage), nil
+}
+
+// Details returns everything the runner needs to execute the stage.
+func (c *Controller) Details(ctx context.Context, token string, stageID int64) (*client.Context, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.findStage(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := c.client.Detail(ctx, manager.ConvertToDroneStage(stage))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stage details: %w", err)
+	}
+
+	return details, nil
+}
+
+// UpdateStage updates the status of the stage. A pending or running status starts the stage
+// and creates its steps, any other status completes the stage along with its steps.
+func (c *Controller) UpdateStage(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	in *drone.Stage,
+) (*drone.Stage, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.findStage(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeStage(ctx, stage, in); err != nil {
+		return nil, err
+	}
+
+	err = c.client.Update(ctx, in)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return nil, usererror.Conflict("The stage was updated in the meantime.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stage: %w", err)
+	}
+
+	return in, nil
+}
+
+// Watch waits for the cancellation of the execution of the stage.
+// It returns false if the execution wasn't cancelled in time.
+func (c *Controller) Watch(ctx context.Context, token string, stageID int64) (*WatchOutput, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.findStage(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, watchTimeout)
+	defer cancel()
+
+	cancelled, err := c.client.Watch(watchCtx, stage.ExecutionID)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return &WatchOutput{Cancelled: false}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch execution: %w", err)
+	}
+
+	return &WatchOutput{Cancelled: cancelled}, nil
+}
+
+// sanitizeStage overwrites everything the runner must not change with the stored values
+// and makes sure the steps belong to the stage.
+func (c *Controller) sanitizeStage(ctx context.Context, stage *types.Stage, in *drone.Stage) error {
+	stored := manager.ConvertToDroneStage(stage)
+	in.ID = stored.ID
+	in.BuildID = stored.BuildID
+	in.Number = stored.Number
+	in.Name = stored.Name
+	in.Kind = stored.Kind
+	in.Type = stored.Type
+	in.Machine = stored.Machine
+	in.OS = stored.OS
+	in.Arch = stored.Arch
+	in.Variant = stored.Variant
+	in.Kernel = stored.Kernel
+	in.Limit = stored.Limit
+	in.LimitRepo = stored.LimitRepo
+	in.OnSuccess = stored.OnSuccess
+	in.OnFailure = stored.OnFailure
+	in.DependsOn = stored.DependsOn
+	in.Labels = stored.Labels
+	in.Created = stored.Created
+
+	status := enum.ParseCIStatus(in.Status)
+	if status == enum.CIStatusPending || status == enum.CIStatusRunning {
+		// the steps get created once the stage starts.
+		if stage.Status != enum.CIStatusPending {
+			return usererror.Conflict("The stage was already started.")
+		}
+		for _, step := range in.Steps {
+			step.ID = 0
+			step.StageID = stage.ID
+		}
+		return nil
+	}
+
+	if stage.Status.IsDone() {
+		return usererror.Conflict("The stage is already complete.")
+	}
+
+	stages, err := c.stageStore.ListWithSteps(ctx, stage.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to list stages of execution: %w", err)
+	}
+
+	stepIDs := map[int64]struct{}{}
+	for _, sibling := range stages {
+		if sibling.ID != stage.ID {
+			continue
+		}
+		for _, step := range sibling.Steps {
+			stepIDs[step.ID] = struct{}{}
+		}
+	}
+
+	for _, step := range in.Steps {
+		if _, ok := stepIDs[step.ID]; !ok {
+			return usererror.BadRequestf("Step %d doesn't belong to the stage.", step.Number)
+		}
+		step.StageID = stage.ID
+	}
+
+	return nil
+}
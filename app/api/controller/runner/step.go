@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-go/drone"
+)
+
+// UpdateStep updates the status of the step. A pending or running status starts the step
+// and opens its log stream, any other status completes the step and closes its log stream.
+func (c *Controller) UpdateStep(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	stepNumber int,
+	in *drone.Step,
+) (*drone.Step, error) {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, step, err := c.findStep(ctx, runner, stageID, stepNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if stage.Status.IsDone() {
+		return nil, usererror.Conflict("The stage is already complete.")
+	}
+
+	in.ID = step.ID
+	in.StageID = step.StageID
+	in.Number = int(step.Number)
+	in.Name = step.Name
+	in.DependsOn = step.DependsOn
+	in.Image = step.Image
+	in.Detached = step.Detached
+	in.Schema = step.Schema
+
+	err = c.client.UpdateStep(ctx, in)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return nil, usererror.Conflict("The step was updated in the meantime.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update step: %w", err)
+	}
+
+	return in, nil
+}
+
+// WriteLogs appends the lines to the live log stream of the running step.
+func (c *Controller) WriteLogs(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	stepNumber int,
+	lines []*drone.Line,
+) error {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	_, step, err := c.findStep(ctx, runner, stageID, stepNumber)
+	if err != nil {
+		return err
+	}
+
+	if step.Status != enum.CIStatusRunning {
+		return usererror.Conflict("The step isn't running.")
+	}
+
+	if err = c.client.Batch(ctx, step.ID, lines); err != nil {
+		return fmt.Errorf("failed to write step logs: %w", err)
+	}
+
+	return nil
+}
+
+// UploadLogs stores the complete logs of the step.
+func (c *Controller) UploadLogs(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	stepNumber int,
+	lines []*drone.Line,
+) error {
+	runner, err := c.runnerSvc.Authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	_, step, err := c.findStep(ctx, runner, stageID, stepNumber)
+	if err != nil {
+		return err
+	}
+
+	if err = c.client.Upload(ctx, step.ID, lines); err != nil {
+		return fmt.Errorf("failed to upload step logs: %w", err)
+	}
+
+	return nil
+}
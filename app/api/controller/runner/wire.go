@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"github.com/harness/gitness/app/pipeline/manager"
+	runnersvc "github.com/harness/gitness/app/services/runner"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/drone/runner-go/client"
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	runnerSvc *runnersvc.Service,
+	manager manager.ExecutionManager,
+	client client.Client,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+) *Controller {
+	return NewController(runnerSvc, manager, client, stageStore, stepStore)
+}
@@ -17,6 +17,7 @@ package secret
 import (
 	"context"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +44,13 @@ type CreateInput struct {
 	UID        string `json:"uid" deprecated:"true"`
 	Identifier string `json:"identifier"`
 	Data       string `json:"data"`
+
+	// AllowedEvents restricts the secret to executions triggered by the events, all events if empty.
+	AllowedEvents []enum.TriggerEvent `json:"allowed_events"`
+	// AllowedRepos restricts the secret to the repositories matching the path patterns, all if empty.
+	AllowedRepos []string `json:"allowed_repos"`
+	// AllowForkPRs exposes the secret to executions of pull requests opened from forks, which are untrusted.
+	AllowForkPRs bool `json:"allow_fork_prs"`
 }
 
 func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.Secret, error) {
@@ -78,6 +86,10 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		Created:     now,
 		Updated:     now,
 		Version:     0,
+
+		AllowedEvents: in.AllowedEvents,
+		AllowedRepos:  in.AllowedRepos,
+		AllowForkPRs:  in.AllowForkPRs,
 	}
 	secret, err = enc(c.encrypter, secret)
 	if err != nil {
@@ -107,10 +119,38 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	if err := sanitizeRestrictions(in.AllowedEvents, in.AllowedRepos); err != nil {
+		return err
+	}
+
 	in.Description = strings.TrimSpace(in.Description)
 	return check.Description(in.Description)
 }
 
+// sanitizeRestrictions validates the events and repository path patterns the secret is restricted to.
+func sanitizeRestrictions(events []enum.TriggerEvent, repos []string) error {
+	for i, event := range events {
+		sanitized, ok := event.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unknown trigger event %q.", event)
+		}
+		events[i] = sanitized
+	}
+
+	for i, pattern := range repos {
+		pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+		if pattern == "" {
+			return usererror.BadRequest("Repository patterns can't be empty.")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return usererror.BadRequestf("Invalid repository pattern %q.", pattern)
+		}
+		repos[i] = pattern
+	}
+
+	return nil
+}
+
 // helper function returns the same secret with encrypted data.
 func enc(encrypt encrypt.Encrypter, secret *types.Secret) (*types.Secret, error) {
 	if secret == nil {
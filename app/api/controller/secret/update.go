@@ -33,6 +33,10 @@ type UpdateInput struct {
 	Identifier  *string `json:"identifier"`
 	Description *string `json:"description"`
 	Data        *string `json:"data"`
+
+	AllowedEvents *[]enum.TriggerEvent `json:"allowed_events"`
+	AllowedRepos  *[]string            `json:"allowed_repos"`
+	AllowForkPRs  *bool                `json:"allow_fork_prs"`
 }
 
 func (c *Controller) Update(
@@ -75,6 +79,15 @@ func (c *Controller) Update(
 			}
 			original.Data = string(data)
 		}
+		if in.AllowedEvents != nil {
+			original.AllowedEvents = *in.AllowedEvents
+		}
+		if in.AllowedRepos != nil {
+			original.AllowedRepos = *in.AllowedRepos
+		}
+		if in.AllowForkPRs != nil {
+			original.AllowForkPRs = *in.AllowForkPRs
+		}
 
 		return nil
 	})
@@ -99,5 +112,17 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	var events []enum.TriggerEvent
+	if in.AllowedEvents != nil {
+		events = *in.AllowedEvents
+	}
+	var repos []string
+	if in.AllowedRepos != nil {
+		repos = *in.AllowedRepos
+	}
+	if err := sanitizeRestrictions(events, repos); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -16,11 +16,16 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
@@ -30,11 +35,13 @@ type Controller struct {
 	spaceStore        store.SpaceStore
 	repoStore         store.RepoStore
 	tokenStore        store.TokenStore
+	auditService      audit.Service
+	tokenPolicy       *tokenpolicy.Service
 }
 
 func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
+	tokenStore store.TokenStore, auditService audit.Service, tokenPolicy *tokenpolicy.Service) *Controller {
 	return &Controller{
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
@@ -42,6 +49,8 @@ func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Author
 		spaceStore:        spaceStore,
 		repoStore:         repoStore,
 		tokenStore:        tokenStore,
+		auditService:      auditService,
+		tokenPolicy:       tokenPolicy,
 	}
 }
 
@@ -49,3 +58,23 @@ func findServiceAccountFromUID(ctx context.Context,
 	principalStore store.PrincipalStore, saUID string) (*types.ServiceAccount, error) {
 	return principalStore.FindServiceAccountByUID(ctx, saUID)
 }
+
+// findParentSpacePath returns the path of the space the service account belongs to.
+func (c *Controller) findParentSpacePath(ctx context.Context, sa *types.ServiceAccount) (string, error) {
+	switch sa.ParentType {
+	case enum.ParentResourceTypeSpace:
+		space, err := c.spaceStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent space: %w", err)
+		}
+		return space.Path, nil
+	case enum.ParentResourceTypeRepo:
+		repo, err := c.repoStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent repo: %w", err)
+		}
+		return paths.Parent(repo.Path), nil
+	default:
+		return "", fmt.Errorf("unsupported parent type '%s'", sa.ParentType)
+	}
+}
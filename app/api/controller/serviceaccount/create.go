@@ -40,6 +40,8 @@ type CreateInput struct {
 	DisplayName string                  `json:"display_name"`
 	ParentType  enum.ParentResourceType `json:"parent_type"`
 	ParentID    int64                   `json:"parent_id"`
+	// AllowedCIDRs restrict the networks all tokens of the service account can be used from.
+	AllowedCIDRs []string `json:"allowed_cidrs"`
 }
 
 // Create creates a new service account.
@@ -82,6 +84,8 @@ func (c *Controller) CreateNoAuth(ctx context.Context,
 		Updated:     time.Now().UnixMilli(),
 		ParentType:  in.ParentType,
 		ParentID:    in.ParentID,
+
+		AllowedCIDRs: in.AllowedCIDRs,
 	}
 
 	err := c.principalStore.CreateServiceAccount(ctx, sa)
@@ -107,11 +111,11 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput, uid string) error {
 		return err
 	}
 
-	if err := check.ServiceAccountParent(in.ParentType, in.ParentID); err != nil { //nolint:revive
+	if err := check.ServiceAccountParent(in.ParentType, in.ParentID); err != nil {
 		return err
 	}
 
-	return nil
+	return check.AllowedCIDRs(in.AllowedCIDRs)
 }
 
 // generateServiceAccountUID generates a new unique UID for a service account
@@ -21,10 +21,14 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreateTokenInput struct {
@@ -32,6 +36,9 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+	// AllowedCIDRs restrict the networks the token can be used from, in addition to the allowlist
+	// of the service account. The token can be used from anywhere if both are empty.
+	AllowedCIDRs []string `json:"allowed_cidrs"`
 }
 
 // CreateToken creates a new service account access token.
@@ -56,6 +63,15 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	maxLifetime, err := c.tokenPolicy.MaxServiceAccountLifetime(ctx, sa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maximum token lifetime: %w", err)
+	}
+
+	if err = tokenpolicy.CheckLifetime(maxLifetime, in.Lifetime); err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreateSAT(
 		ctx,
 		c.tokenStore,
@@ -63,11 +79,29 @@ func (c *Controller) CreateToken(
 		sa,
 		in.Identifier,
 		in.Lifetime,
+		in.AllowedCIDRs,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	spacePath, err := c.findParentSpacePath(ctx, sa)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find parent space path for audit log")
+	} else {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(audit.ResourceTypeServiceAccountToken, token.Identifier,
+				audit.ServiceAccountName, sa.UID),
+			audit.ActionCreated,
+			spacePath,
+			audit.WithNewObject(token),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create service account token operation: %s", err)
+		}
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -81,10 +115,9 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
-	//nolint:revive
 	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
 		return err
 	}
 
-	return nil
+	return check.AllowedCIDRs(in.AllowedCIDRs)
 }
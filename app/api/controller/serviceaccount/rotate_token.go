@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/tokenpolicy"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RotateTokenInput struct {
+	// Lifetime is the lifetime of the new secret, the lifetime the token was issued with is used if empty.
+	Lifetime *time.Duration `json:"lifetime"`
+}
+
+// RotateToken replaces the secret of a service account token.
+// The previous secret is still accepted for the rotation grace period.
+func (c *Controller) RotateToken(
+	ctx context.Context,
+	session *auth.Session,
+	saUID string,
+	identifier string,
+	in *RotateTokenInput,
+) (*types.TokenResponse, error) {
+	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
+		return nil, err
+	}
+
+	sa, err := findServiceAccountFromUID(ctx, c.principalStore, saUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent (ensures that parent exists)
+	if err = apiauth.CheckServiceAccount(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
+		sa.ParentType, sa.ParentID, sa.UID, enum.PermissionServiceAccountEdit); err != nil {
+		return nil, err
+	}
+
+	tkn, err := c.tokenStore.FindByIdentifier(ctx, sa.ID, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if tkn.Type != enum.TokenTypeSAT || tkn.PrincipalID != sa.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	lifetime := in.Lifetime
+	if lifetime == nil {
+		lifetime = token.Lifetime(tkn)
+	}
+
+	maxLifetime, err := c.tokenPolicy.MaxServiceAccountLifetime(ctx, sa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maximum token lifetime: %w", err)
+	}
+
+	if err = tokenpolicy.CheckLifetime(maxLifetime, lifetime); err != nil {
+		return nil, err
+	}
+
+	oldToken := *tkn
+
+	tkn, jwtToken, err := token.Rotate(
+		ctx,
+		c.tokenStore,
+		tkn,
+		sa.ToPrincipal(),
+		lifetime,
+		c.tokenPolicy.GracePeriod(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	spacePath, err := c.findParentSpacePath(ctx, sa)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find parent space path for audit log")
+	} else {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(audit.ResourceTypeServiceAccountToken, tkn.Identifier,
+				audit.ServiceAccountName, sa.UID),
+			audit.ActionUpdated,
+			spacePath,
+			audit.WithOldObject(oldToken),
+			audit.WithNewObject(tkn),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for rotate service account token operation: %s", err)
+		}
+	}
+
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UpdateInput struct {
+	DisplayName *string `json:"display_name"`
+	// AllowedCIDRs replace the IP allowlist of the service account, an empty list removes the restriction.
+	AllowedCIDRs *[]string `json:"allowed_cidrs"`
+}
+
+// Update updates the display name and the IP allowlist of a service account.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	saUID string,
+	in *UpdateInput,
+) (*types.ServiceAccount, error) {
+	if err := sanitizeUpdateInput(in); err != nil {
+		return nil, err
+	}
+
+	sa, err := findServiceAccountFromUID(ctx, c.principalStore, saUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent (ensures that parent exists)
+	if err = apiauth.CheckServiceAccount(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
+		sa.ParentType, sa.ParentID, sa.UID, enum.PermissionServiceAccountEdit); err != nil {
+		return nil, err
+	}
+
+	oldSA := *sa
+
+	if in.DisplayName != nil {
+		sa.DisplayName = *in.DisplayName
+	}
+	if in.AllowedCIDRs != nil {
+		sa.AllowedCIDRs = *in.AllowedCIDRs
+	}
+	sa.Updated = time.Now().UnixMilli()
+
+	if err = c.principalStore.UpdateServiceAccount(ctx, sa); err != nil {
+		return nil, fmt.Errorf("failed to update service account: %w", err)
+	}
+
+	spacePath, err := c.findParentSpacePath(ctx, sa)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find parent space path for audit log")
+	} else {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(audit.ResourceTypeServiceAccount, sa.UID),
+			audit.ActionUpdated,
+			spacePath,
+			audit.WithOldObject(oldSA),
+			audit.WithNewObject(sa),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update service account operation: %s", err)
+		}
+	}
+
+	return sa, nil
+}
+
+func sanitizeUpdateInput(in *UpdateInput) error {
+	if in.DisplayName != nil {
+		*in.DisplayName = strings.TrimSpace(*in.DisplayName)
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	if in.AllowedCIDRs != nil {
+		if err := check.AllowedCIDRs(*in.AllowedCIDRs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,7 +16,9 @@ package serviceaccount
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -29,6 +31,7 @@ var WireSet = wire.NewSet(
 
 func ProvideController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
-	return NewController(principalUIDCheck, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	tokenStore store.TokenStore, auditService audit.Service, tokenPolicy *tokenpolicy.Service) *Controller {
+	return NewController(principalUIDCheck, authorizer, principalStore, spaceStore, repoStore, tokenStore,
+		auditService, tokenPolicy)
 }
@@ -21,13 +21,22 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/auditlog"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/filetemplate"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/reviewsla"
+	"github.com/harness/gitness/app/services/role"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -89,6 +98,15 @@ type Controller struct {
 	gitspaceSvc     *gitspace.Service
 	labelSvc        *label.Service
 	instrumentation instrument.Service
+	repoTemplateSvc *repotemplate.Service
+	storagePoolSvc  *storagepool.Service
+	reviewSLASvc    *reviewsla.Service
+	settingsSvc     *settings.Service
+	quotaSvc        *quota.Service
+	auditlogSvc     *auditlog.Service
+	repoBulkSvc     *repobulk.Service
+	fileTemplateSvc *filetemplate.Service
+	roleSvc         *role.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -100,7 +118,11 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	importer *importer.Repository, exporter *exporter.Repository,
 	limiter limiter.ResourceLimiter, publicAccess publicaccess.Service, auditService audit.Service,
 	gitspaceSvc *gitspace.Service, labelSvc *label.Service,
-	instrumentation instrument.Service,
+	instrumentation instrument.Service, repoTemplateSvc *repotemplate.Service,
+	storagePoolSvc *storagepool.Service, reviewSLASvc *reviewsla.Service,
+	settingsSvc *settings.Service, quotaSvc *quota.Service, auditlogSvc *auditlog.Service,
+	repoBulkSvc *repobulk.Service, fileTemplateSvc *filetemplate.Service,
+	roleSvc *role.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -128,5 +150,14 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		gitspaceSvc:         gitspaceSvc,
 		labelSvc:            labelSvc,
 		instrumentation:     instrumentation,
+		repoTemplateSvc:     repoTemplateSvc,
+		storagePoolSvc:      storagePoolSvc,
+		reviewSLASvc:        reviewSLASvc,
+		settingsSvc:         settingsSvc,
+		quotaSvc:            quotaSvc,
+		auditlogSvc:         auditlogSvc,
+		repoBulkSvc:         repoBulkSvc,
+		fileTemplateSvc:     fileTemplateSvc,
+		roleSvc:             roleSvc,
 	}
 }
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/filetemplate"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FileTemplateList returns all templates of the type available in the space,
+// including the built-in templates and the custom templates of the system and of the parent spaces.
+func (c *Controller) FileTemplateList(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	templateType enum.FileTemplateType,
+) ([]types.FileTemplateInfo, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.fileTemplateSvc.List(ctx, space.ID, templateType)
+}
+
+// FileTemplateFind returns a custom template of the space.
+func (c *Controller) FileTemplateFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	templateType enum.FileTemplateType,
+	identifier string,
+) (*types.FileTemplate, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.fileTemplateSvc.Find(ctx, space.ID, templateType, identifier)
+}
+
+// FileTemplateCreate adds a custom template to the space.
+func (c *Controller) FileTemplateCreate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	templateType enum.FileTemplateType,
+	in *filetemplate.CreateInput,
+) (*types.FileTemplate, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.fileTemplateSvc.Create(ctx, session.Principal.ID, space.ID, templateType, in)
+}
+
+// FileTemplateDelete removes a custom template of the space.
+func (c *Controller) FileTemplateDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	templateType enum.FileTemplateType,
+	identifier string,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.fileTemplateSvc.Delete(ctx, space.ID, templateType, identifier)
+}
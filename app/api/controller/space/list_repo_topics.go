@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListRepoTopics lists the topics used by the repositories of a space, e.g. for autocompletion.
+func (c *Controller) ListRepoTopics(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.RepoTopicFilter,
+) ([]types.RepoTopic, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpaceScope(
+		ctx,
+		c.authorizer,
+		session,
+		space,
+		enum.ResourceTypeRepo,
+		enum.PermissionRepoView,
+	); err != nil {
+		return nil, err
+	}
+
+	topics, err := c.repoStore.ListTopics(ctx, space.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository topics: %w", err)
+	}
+
+	return topics, nil
+}
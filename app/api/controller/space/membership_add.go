@@ -22,11 +22,13 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipAddInput struct {
@@ -43,15 +45,6 @@ func (in *MembershipAddInput) Validate() error {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
 	return nil
 }
 
@@ -75,6 +68,12 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, err
 	}
 
+	// the role can be one of the predefined roles or a custom role.
+	in.Role, err = c.roleSvc.Sanitize(ctx, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
@@ -106,5 +105,16 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		AddedBy:    *session.Principal.ToPrincipalInfo(),
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceMembership, user.UID),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add space membership operation: %s", err)
+	}
+
 	return result, nil
 }
@@ -21,8 +21,11 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipUpdateInput struct {
@@ -34,15 +37,6 @@ func (in *MembershipUpdateInput) Validate() error {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
 	return nil
 }
 
@@ -67,6 +61,12 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, err
 	}
 
+	// the role can be one of the predefined roles or a custom role.
+	in.Role, err = c.roleSvc.Sanitize(ctx, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, userUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by uid: %w", err)
@@ -84,6 +84,8 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return membership, nil
 	}
 
+	old := membership.Membership
+
 	membership.Role = in.Role
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
@@ -91,5 +93,17 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to update membership")
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceMembership, user.UID),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(old),
+		audit.WithNewObject(membership.Membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space membership operation: %s", err)
+	}
+
 	return membership, nil
 }
@@ -80,6 +80,14 @@ func (c *Controller) PurgeNoAuth(
 	// permanently purge all repositories in the space and its subspaces after successful space purge tnx.
 	// cleanup will handle failed repository deletions.
 	for _, repo := range toBeDeletedRepos {
+		// keep the git repository in case a fork still borrows objects from it.
+		if err := c.repoCtrl.DetachForks(ctx, session, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Msg("failed to detach forks of repository, skipping git repository deletion")
+			continue
+		}
+
 		err := c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).
@@ -88,6 +96,12 @@ func (c *Controller) PurgeNoAuth(
 				Int64("repo_parent_id", repo.ParentID).
 				Msg("failed to delete repository")
 		}
+
+		if err := c.repoCtrl.RestoreForkParentPruning(ctx, session, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Msg("failed to restore pruning of the forked repository")
+		}
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// QuotaFind returns the quota of the space along with the resource usage of the space and its sub-spaces.
+func (c *Controller) QuotaFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceQuotaUsage, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	quota, err := c.quotaSvc.Find(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+// QuotaUpdate replaces the quota of the space.
+func (c *Controller) QuotaUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.SpaceQuota,
+) (*types.SpaceQuotaUsage, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	old, err := c.quotaSvc.Find(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find quota: %w", err)
+	}
+
+	quota, err := c.quotaSvc.Update(ctx, space.ID, *in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update quota: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceQuota, space.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(old.Quota),
+		audit.WithNewObject(quota.Quota),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space quota operation: %s", err)
+	}
+
+	return quota, nil
+}
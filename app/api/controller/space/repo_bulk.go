@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxRepoBulkSize = 1000
+
+type RepoBulkInput struct {
+	Operation enum.RepoBulkOperation `json:"operation"`
+	// Repos are the identifiers of the repositories of the space the operation is applied to.
+	Repos []string `json:"repos"`
+
+	// GeneralSettings and SecuritySettings are applied by the settings operation.
+	GeneralSettings  *reposettings.GeneralSettings  `json:"general_settings,omitempty"`
+	SecuritySettings *reposettings.SecuritySettings `json:"security_settings,omitempty"`
+
+	// Export is the export destination used by the export operation.
+	Export *ExportInput `json:"export,omitempty"`
+}
+
+type RepoBulkOutput struct {
+	UID      string                `json:"uid"`
+	State    job.State             `json:"state"`
+	Progress int                   `json:"progress"`
+	Result   *types.RepoBulkResult `json:"result,omitempty"`
+	Failure  string                `json:"failure,omitempty"`
+}
+
+// RepoBulk starts an operation on many repositories of the space, which is executed in the background.
+func (c *Controller) RepoBulk(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *RepoBulkInput,
+) (*RepoBulkOutput, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := c.sanitizeRepoBulkInput(in); err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]int64, 0, len(in.Repos))
+	seen := make(map[int64]struct{}, len(in.Repos))
+	for _, identifier := range in.Repos {
+		repo, err := c.repoStore.FindByRef(ctx, paths.Concatenate(space.Path, identifier))
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, usererror.BadRequestf("Repository '%s' not found in the space.", identifier)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository '%s': %w", identifier, err)
+		}
+
+		if _, ok := seen[repo.ID]; ok {
+			continue
+		}
+		seen[repo.ID] = struct{}{}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+
+	jobInput := &repobulk.Input{
+		Operation:        in.Operation,
+		SpaceID:          space.ID,
+		PrincipalID:      session.Principal.ID,
+		RepoIDs:          repoIDs,
+		GeneralSettings:  in.GeneralSettings,
+		SecuritySettings: in.SecuritySettings,
+	}
+
+	if in.Operation == enum.RepoBulkOperationExport {
+		jobInput.HarnessCodeInfo = &exporter.HarnessCodeInfo{
+			AccountID:         in.Export.AccountID,
+			ProjectIdentifier: in.Export.ProjectIdentifier,
+			OrgIdentifier:     in.Export.OrgIdentifier,
+			Token:             in.Export.Token,
+		}
+	}
+
+	jobUID, err := c.repoBulkSvc.Run(ctx, jobInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start bulk operation: %w", err)
+	}
+
+	return &RepoBulkOutput{
+		UID:   jobUID,
+		State: job.JobStateScheduled,
+	}, nil
+}
+
+// RepoBulkProgress returns the progress of a bulk operation of the space.
+func (c *Controller) RepoBulkProgress(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	jobUID string,
+) (*RepoBulkOutput, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	progress, err := c.repoBulkSvc.Progress(ctx, space.ID, jobUID)
+	if errors.Is(err, repobulk.ErrNotFound) || errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Bulk operation not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk operation progress: %w", err)
+	}
+
+	out := &RepoBulkOutput{
+		UID:      jobUID,
+		State:    progress.State,
+		Progress: progress.Progress,
+		Failure:  progress.Failure,
+	}
+
+	if progress.Result != "" {
+		out.Result = &types.RepoBulkResult{}
+		if err := json.Unmarshal([]byte(progress.Result), out.Result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bulk operation result: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+func (c *Controller) sanitizeRepoBulkInput(in *RepoBulkInput) error {
+	operation, ok := in.Operation.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported bulk operation '%s'.", in.Operation)
+	}
+	in.Operation = operation
+
+	if len(in.Repos) == 0 {
+		return usererror.BadRequest("At least one repository must be provided.")
+	}
+
+	if len(in.Repos) > maxRepoBulkSize {
+		return usererror.BadRequestf("At most %d repositories can be provided.", maxRepoBulkSize)
+	}
+
+	switch operation {
+	case enum.RepoBulkOperationSettings:
+		if in.GeneralSettings == nil && in.SecuritySettings == nil {
+			return usererror.BadRequest("Settings must be provided for the settings operation.")
+		}
+	case enum.RepoBulkOperationExport:
+		if in.Export == nil {
+			return usererror.BadRequest("Export destination must be provided for the export operation.")
+		}
+		if err := c.sanitizeExportInput(in.Export); err != nil {
+			return err
+		}
+	case enum.RepoBulkOperationArchive, enum.RepoBulkOperationUnarchive, enum.RepoBulkOperationDelete:
+	}
+
+	return nil
+}
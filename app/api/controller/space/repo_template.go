@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoTemplateFind returns the repository template of the space.
+func (c *Controller) RepoTemplateFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.RepoTemplate, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	tmpl, err := c.repoTemplateSvc.Find(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// RepoTemplateUpdate replaces the repository template of the space.
+// The template is applied to all repositories created or imported in the space afterwards.
+func (c *Controller) RepoTemplateUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.RepoTemplate,
+) (*types.RepoTemplate, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	tmpl, err := c.repoTemplateSvc.Update(ctx, space.ID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repo template: %w", err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReviewSLAFind returns the review SLA configuration of the space.
+func (c *Controller) ReviewSLAFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceReviewSLA, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	sla, err := c.reviewSLASvc.Find(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review SLA: %w", err)
+	}
+
+	return sla, nil
+}
+
+// ReviewSLAUpdate replaces the review SLA policy of the space.
+// The policy applies to pull requests opened or reopened afterwards.
+func (c *Controller) ReviewSLAUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.ReviewSLAPolicy,
+) (*types.SpaceReviewSLA, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	sla, err := c.reviewSLASvc.Update(ctx, space.ID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update review SLA: %w", err)
+	}
+
+	return sla, nil
+}
+
+// ReviewSLAReport returns the review SLA compliance report of the pull requests of the space and its sub-spaces.
+func (c *Controller) ReviewSLAReport(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter types.ReviewSLAReportFilter,
+) (*types.ReviewSLAReport, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	report, err := c.reviewSLASvc.Report(ctx, space.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review SLA report: %w", err)
+	}
+
+	return report, nil
+}
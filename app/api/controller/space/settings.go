@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	gitcheck "github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SettingsFind returns the inheritable settings defined on the space.
+// Settings the space doesn't define are omitted, they are inherited from the parent space.
+func (c *Controller) SettingsFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceSettings, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.findSettings(ctx, space.ID)
+}
+
+// SettingsUpdate replaces the inheritable settings defined on the space.
+// Omitted settings are removed from the space, which makes them inherited from the parent space again.
+func (c *Controller) SettingsUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.SpaceSettings,
+) (*types.SpaceSettings, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if in == nil {
+		in = &types.SpaceSettings{}
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.DefaultBranch != nil {
+		if err := gitcheck.BranchName(*in.DefaultBranch); err != nil {
+			return nil, errors.InvalidArgument("Invalid default branch: %s", err)
+		}
+	}
+
+	old, err := c.findSettings(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// all keys are written, the unset ones as null, so they are inherited again.
+	err = c.settingsSvc.SpaceSetMany(ctx, space.ID,
+		settings.KeyValue{Key: settings.KeyMergeMethods, Value: in.MergeMethods},
+		settings.KeyValue{Key: settings.KeyDefaultBranch, Value: in.DefaultBranch},
+		settings.KeyValue{Key: settings.KeyFileSizeLimit, Value: in.FileSizeLimit},
+		settings.KeyValue{Key: settings.KeyCodeOwnersRequestReview, Value: in.CodeOwnersRequestReview},
+		settings.KeyValue{Key: settings.KeySecretScanningEnabled, Value: in.SecretScanningEnabled},
+		settings.KeyValue{Key: settings.KeyTwoFactorRequired, Value: in.TwoFactorRequired},
+		settings.KeyValue{Key: settings.KeyTokenMaxLifetime, Value: in.TokenMaxLifetime},
+		settings.KeyValue{Key: settings.KeyPipelineClone, Value: in.PipelineClone},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store space settings: %w", err)
+	}
+
+	out, err := c.findSettings(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceSettings, space.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(old),
+		audit.WithNewObject(out),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space settings operation: %s", err)
+	}
+
+	return out, nil
+}
+
+// SettingsEffective returns the values of all inheritable settings as they apply to the space,
+// along with the scope each value is inherited from.
+func (c *Controller) SettingsEffective(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]types.EffectiveSetting, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	effective, err := c.settingsSvc.SpaceEffective(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective settings: %w", err)
+	}
+
+	return effective, nil
+}
+
+func (c *Controller) findSettings(ctx context.Context, spaceID int64) (*types.SpaceSettings, error) {
+	out := &types.SpaceSettings{}
+	err := c.settingsSvc.SpaceMap(ctx, spaceID,
+		settings.Mapping(settings.KeyMergeMethods, &out.MergeMethods),
+		settings.Mapping(settings.KeyDefaultBranch, &out.DefaultBranch),
+		settings.Mapping(settings.KeyFileSizeLimit, &out.FileSizeLimit),
+		settings.Mapping(settings.KeyCodeOwnersRequestReview, &out.CodeOwnersRequestReview),
+		settings.Mapping(settings.KeySecretScanningEnabled, &out.SecretScanningEnabled),
+		settings.Mapping(settings.KeyTwoFactorRequired, &out.TwoFactorRequired),
+		settings.Mapping(settings.KeyTokenMaxLifetime, &out.TokenMaxLifetime),
+		settings.Mapping(settings.KeyPipelineClone, &out.PipelineClone),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map space settings: %w", err)
+	}
+
+	return out, nil
+}
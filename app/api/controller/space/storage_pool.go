@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StoragePoolUpdateInput struct {
+	// Pool is the name of the storage pool, empty to inherit the storage pool of the parent space.
+	Pool string `json:"pool"`
+}
+
+// StoragePoolFind returns the storage pool configuration of the space.
+func (c *Controller) StoragePoolFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceStoragePool, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	pool, err := c.storagePoolSvc.Find(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find storage pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+// StoragePoolUpdate assigns the storage pool to the space.
+// The repositories of the space and its sub-spaces are moved to the storage pool in the background.
+func (c *Controller) StoragePoolUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *StoragePoolUpdateInput,
+) (*types.SpaceStoragePool, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	pool, err := c.storagePoolSvc.Assign(ctx, space.ID, in.Pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign storage pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+// StoragePoolRebalance starts moving all repositories of the space and its sub-spaces
+// that aren't located in the storage pool of their space.
+func (c *Controller) StoragePoolRebalance(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err = c.storagePoolSvc.Rebalance(ctx, space.ID); err != nil {
+		return fmt.Errorf("failed to start storage pool rebalancing: %w", err)
+	}
+
+	return nil
+}
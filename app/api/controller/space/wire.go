@@ -18,13 +18,22 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/auditlog"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/filetemplate"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/reviewsla"
+	"github.com/harness/gitness/app/services/role"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -52,6 +61,15 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	auditService audit.Service, gitspaceService *gitspace.Service,
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
+	repoTemplateSvc *repotemplate.Service,
+	storagePoolSvc *storagepool.Service,
+	reviewSLASvc *reviewsla.Service,
+	settingsSvc *settings.Service,
+	quotaSvc *quota.Service,
+	auditlogSvc *auditlog.Service,
+	repoBulkSvc *repobulk.Service,
+	fileTemplateSvc *filetemplate.Service,
+	roleSvc *role.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, identifierCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
@@ -62,5 +80,14 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 		auditService, gitspaceService,
 		labelSvc,
 		instrumentation,
+		repoTemplateSvc,
+		storagePoolSvc,
+		reviewSLASvc,
+		settingsSvc,
+		quotaSvc,
+		auditlogSvc,
+		repoBulkSvc,
+		fileTemplateSvc,
+		roleSvc,
 	)
 }
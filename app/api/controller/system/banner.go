@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/banner"
+	"github.com/harness/gitness/types"
+)
+
+// BannerListActive returns the banners that are currently shown to the users.
+func (c *Controller) BannerListActive(ctx context.Context) []types.BannerInfo {
+	return c.bannerSvc.ListActive(ctx)
+}
+
+// BannerList returns all banners, including the scheduled and the ended ones.
+func (c *Controller) BannerList(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.Banner, error) {
+	return c.bannerSvc.List(ctx)
+}
+
+// BannerFind returns a banner.
+func (c *Controller) BannerFind(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) (*types.Banner, error) {
+	return c.bannerSvc.Find(ctx, identifier)
+}
+
+// BannerCreate adds a banner.
+func (c *Controller) BannerCreate(
+	ctx context.Context,
+	session *auth.Session,
+	in *banner.CreateInput,
+) (*types.Banner, error) {
+	return c.bannerSvc.Create(ctx, session.Principal.ID, in)
+}
+
+// BannerUpdate updates a banner.
+func (c *Controller) BannerUpdate(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+	in *banner.UpdateInput,
+) (*types.Banner, error) {
+	return c.bannerSvc.Update(ctx, identifier, in)
+}
+
+// BannerDelete removes a banner.
+func (c *Controller) BannerDelete(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) error {
+	return c.bannerSvc.Delete(ctx, identifier)
+}
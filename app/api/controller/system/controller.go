@@ -17,19 +17,50 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/services/banner"
+	"github.com/harness/gitness/app/services/filetemplate"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/app/services/ratelimiter"
+	"github.com/harness/gitness/app/services/role"
+	"github.com/harness/gitness/app/services/saml"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore  store.PrincipalStore
+	config          *types.Config
+	fileTemplateSvc *filetemplate.Service
+	oauthSvc        *oauth.Service
+	samlSvc         *saml.Service
+	ldapSvc         *ldap.Service
+	rateLimiter     *ratelimiter.Service
+	roleSvc         *role.Service
+	bannerSvc       *banner.Service
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	fileTemplateSvc *filetemplate.Service,
+	oauthSvc *oauth.Service,
+	samlSvc *saml.Service,
+	ldapSvc *ldap.Service,
+	rateLimiter *ratelimiter.Service,
+	roleSvc *role.Service,
+	bannerSvc *banner.Service,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:  principalStore,
+		config:          config,
+		fileTemplateSvc: fileTemplateSvc,
+		oauthSvc:        oauthSvc,
+		samlSvc:         samlSvc,
+		ldapSvc:         ldapSvc,
+		rateLimiter:     rateLimiter,
+		roleSvc:         roleSvc,
+		bannerSvc:       bannerSvc,
 	}
 }
 
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/filetemplate"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FileTemplateList returns the built-in and the custom system templates of the type.
+func (c *Controller) FileTemplateList(
+	ctx context.Context,
+	templateType enum.FileTemplateType,
+) ([]types.FileTemplateInfo, error) {
+	return c.fileTemplateSvc.List(ctx, 0, templateType)
+}
+
+// FileTemplateFind returns a custom system template.
+func (c *Controller) FileTemplateFind(
+	ctx context.Context,
+	_ *auth.Session,
+	templateType enum.FileTemplateType,
+	identifier string,
+) (*types.FileTemplate, error) {
+	return c.fileTemplateSvc.Find(ctx, 0, templateType, identifier)
+}
+
+// FileTemplateCreate adds a custom system template.
+func (c *Controller) FileTemplateCreate(
+	ctx context.Context,
+	session *auth.Session,
+	templateType enum.FileTemplateType,
+	in *filetemplate.CreateInput,
+) (*types.FileTemplate, error) {
+	return c.fileTemplateSvc.Create(ctx, session.Principal.ID, 0, templateType, in)
+}
+
+// FileTemplateDelete removes a custom system template.
+func (c *Controller) FileTemplateDelete(
+	ctx context.Context,
+	_ *auth.Session,
+	templateType enum.FileTemplateType,
+	identifier string,
+) error {
+	return c.fileTemplateSvc.Delete(ctx, 0, templateType, identifier)
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/types"
+)
+
+// LDAPGroupMappingList returns the mappings of LDAP groups to space memberships.
+func (c *Controller) LDAPGroupMappingList(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.LDAPGroupMapping, error) {
+	return c.ldapSvc.ListGroupMappings(ctx)
+}
+
+// LDAPGroupMappingCreate maps an LDAP group to a membership of a space.
+func (c *Controller) LDAPGroupMappingCreate(
+	ctx context.Context,
+	session *auth.Session,
+	in *ldap.GroupMappingCreateInput,
+) (*types.LDAPGroupMapping, error) {
+	return c.ldapSvc.CreateGroupMapping(ctx, session.Principal.ID, in)
+}
+
+// LDAPGroupMappingDelete removes a mapping of an LDAP group.
+func (c *Controller) LDAPGroupMappingDelete(
+	ctx context.Context,
+	_ *auth.Session,
+	id int64,
+) error {
+	return c.ldapSvc.DeleteGroupMapping(ctx, id)
+}
+
+// LDAPSync syncs the users and their memberships with the LDAP directory.
+// With dryRun the changes a sync would make are returned without applying them.
+func (c *Controller) LDAPSync(
+	ctx context.Context,
+	_ *auth.Session,
+	dryRun bool,
+) (*types.LDAPSyncReport, error) {
+	return c.ldapSvc.Sync(ctx, dryRun)
+}
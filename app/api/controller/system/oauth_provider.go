@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/types"
+)
+
+// OAuthProviderListEnabled returns the OAuth providers users can login with.
+func (c *Controller) OAuthProviderListEnabled(ctx context.Context) ([]types.OAuthProviderInfo, error) {
+	return c.oauthSvc.ListEnabledProviders(ctx)
+}
+
+// OAuthProviderList returns all OAuth providers.
+func (c *Controller) OAuthProviderList(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.OAuthProvider, error) {
+	return c.oauthSvc.ListProviders(ctx)
+}
+
+// OAuthProviderFind returns an OAuth provider.
+func (c *Controller) OAuthProviderFind(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) (*types.OAuthProvider, error) {
+	return c.oauthSvc.FindProvider(ctx, identifier)
+}
+
+// OAuthProviderCreate adds an OAuth provider.
+func (c *Controller) OAuthProviderCreate(
+	ctx context.Context,
+	_ *auth.Session,
+	in *oauth.ProviderCreateInput,
+) (*types.OAuthProvider, error) {
+	return c.oauthSvc.CreateProvider(ctx, in)
+}
+
+// OAuthProviderUpdate updates an OAuth provider.
+func (c *Controller) OAuthProviderUpdate(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+	in *oauth.ProviderUpdateInput,
+) (*types.OAuthProvider, error) {
+	return c.oauthSvc.UpdateProvider(ctx, identifier, in)
+}
+
+// OAuthProviderDelete removes an OAuth provider and the identities linked through it.
+func (c *Controller) OAuthProviderDelete(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) error {
+	return c.oauthSvc.DeleteProvider(ctx, identifier)
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// RateLimitsFind returns the rate limits of the instance.
+func (c *Controller) RateLimitsFind(
+	ctx context.Context,
+	_ *auth.Session,
+) (*types.RateLimits, error) {
+	return c.rateLimiter.Find(ctx)
+}
+
+// RateLimitsUpdate overrides the rate limits of the instance.
+func (c *Controller) RateLimitsUpdate(
+	ctx context.Context,
+	_ *auth.Session,
+	in *types.RateLimits,
+) (*types.RateLimits, error) {
+	return c.rateLimiter.Update(ctx, in)
+}
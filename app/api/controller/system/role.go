@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/role"
+	"github.com/harness/gitness/types"
+)
+
+// RoleList returns the predefined roles and all custom roles.
+func (c *Controller) RoleList(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.Role, error) {
+	return c.roleSvc.List(ctx)
+}
+
+// RoleFind returns a predefined or custom role.
+func (c *Controller) RoleFind(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) (*types.Role, error) {
+	return c.roleSvc.Find(ctx, identifier)
+}
+
+// RoleCreate adds a custom role.
+func (c *Controller) RoleCreate(
+	ctx context.Context,
+	session *auth.Session,
+	in *role.CreateInput,
+) (*types.Role, error) {
+	return c.roleSvc.Create(ctx, session.Principal.ID, in)
+}
+
+// RoleUpdate updates a custom role.
+func (c *Controller) RoleUpdate(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+	in *role.UpdateInput,
+) (*types.Role, error) {
+	return c.roleSvc.Update(ctx, identifier, in)
+}
+
+// RoleDelete removes a custom role.
+func (c *Controller) RoleDelete(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) error {
+	return c.roleSvc.Delete(ctx, identifier)
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/saml"
+	"github.com/harness/gitness/types"
+)
+
+// SAMLProviderListEnabled returns the SAML providers users can login with.
+func (c *Controller) SAMLProviderListEnabled(ctx context.Context) ([]types.SAMLProviderInfo, error) {
+	return c.samlSvc.ListEnabledProviders(ctx)
+}
+
+// SAMLProviderList returns all SAML providers.
+func (c *Controller) SAMLProviderList(
+	ctx context.Context,
+	_ *auth.Session,
+) ([]*types.SAMLProvider, error) {
+	return c.samlSvc.ListProviders(ctx)
+}
+
+// SAMLProviderFind returns a SAML provider.
+func (c *Controller) SAMLProviderFind(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) (*types.SAMLProvider, error) {
+	return c.samlSvc.FindProvider(ctx, identifier)
+}
+
+// SAMLProviderCreate adds a SAML provider.
+func (c *Controller) SAMLProviderCreate(
+	ctx context.Context,
+	_ *auth.Session,
+	in *saml.ProviderCreateInput,
+) (*types.SAMLProvider, error) {
+	return c.samlSvc.CreateProvider(ctx, in)
+}
+
+// SAMLProviderUpdate updates a SAML provider.
+func (c *Controller) SAMLProviderUpdate(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+	in *saml.ProviderUpdateInput,
+) (*types.SAMLProvider, error) {
+	return c.samlSvc.UpdateProvider(ctx, identifier, in)
+}
+
+// SAMLProviderDelete removes a SAML provider and the identities linked through it.
+func (c *Controller) SAMLProviderDelete(
+	ctx context.Context,
+	_ *auth.Session,
+	identifier string,
+) error {
+	return c.samlSvc.DeleteProvider(ctx, identifier)
+}
+
+// SAMLProviderMetadata returns the metadata used to register the service with the SAML provider.
+func (c *Controller) SAMLProviderMetadata(ctx context.Context, identifier string) ([]byte, error) {
+	return c.samlSvc.ServiceProviderMetadata(ctx, identifier)
+}
@@ -15,6 +15,13 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/services/banner"
+	"github.com/harness/gitness/app/services/filetemplate"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/app/services/ratelimiter"
+	"github.com/harness/gitness/app/services/role"
+	"github.com/harness/gitness/app/services/saml"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -26,6 +33,26 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	fileTemplateSvc *filetemplate.Service,
+	oauthSvc *oauth.Service,
+	samlSvc *saml.Service,
+	ldapSvc *ldap.Service,
+	rateLimiter *ratelimiter.Service,
+	roleSvc *role.Service,
+	bannerSvc *banner.Service,
+) *Controller {
+	return NewController(
+		principalStore,
+		config,
+		fileTemplateSvc,
+		oauthSvc,
+		samlSvc,
+		ldapSvc,
+		rateLimiter,
+		roleSvc,
+		bannerSvc,
+	)
 }
@@ -16,22 +16,35 @@ package template
 
 import (
 	"fmt"
+	"regexp"
 
+	pipelinetemplate "github.com/harness/gitness/app/pipeline/converter/template"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/drone/spec/dist/go/parse"
 )
 
+// v1Finder matches the top level spec of v1 yaml, which drone yaml doesn't have.
+var v1Finder = regexp.MustCompilePOSIX(`^spec:`)
+
 // parseResolverType parses and validates the input yaml. It returns back the parsed
-// template type.
+// template type. Step and stage templates are written in v1 yaml, while pipeline
+// templates are drone yaml go templates.
 func parseResolverType(data string) (enum.ResolverType, error) {
+	if !v1Finder.MatchString(data) {
+		if err := pipelinetemplate.Validate(data); err != nil {
+			return "", check.NewValidationError(fmt.Sprintf("could not parse pipeline template: %s", err))
+		}
+		return enum.ResolverTypePipeline, nil
+	}
+
 	config, err := parse.ParseString(data)
 	if err != nil {
 		return "", check.NewValidationError(fmt.Sprintf("could not parse template data: %s", err))
 	}
 	resolverTypeEnum, err := enum.ParseResolverType(config.Type)
-	if err != nil {
+	if err != nil || resolverTypeEnum == enum.ResolverTypePipeline {
 		return "", check.NewValidationError(fmt.Sprintf("could not parse template type: %s", config.Type))
 	}
 	return resolverTypeEnum, nil
@@ -16,6 +16,7 @@ package trigger
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/triggerqueue"
 	"github.com/harness/gitness/app/store"
 )
 
@@ -24,6 +25,8 @@ type Controller struct {
 	triggerStore  store.TriggerStore
 	pipelineStore store.PipelineStore
 	repoStore     store.RepoStore
+	triggerQueue  *triggerqueue.Service
+	cronStore     store.CronStore
 }
 
 func NewController(
@@ -31,11 +34,15 @@ func NewController(
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
 	repoStore store.RepoStore,
+	triggerQueue *triggerqueue.Service,
+	cronStore store.CronStore,
 ) *Controller {
 	return &Controller{
 		authorizer:    authorizer,
 		triggerStore:  triggerStore,
 		pipelineStore: pipelineStore,
 		repoStore:     repoStore,
+		triggerQueue:  triggerQueue,
+		cronStore:     cronStore,
 	}
 }
@@ -0,0 +1,343 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/cron"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// cronMaxBranchLength defines the max allowed length of the branch of a cron trigger.
+const cronMaxBranchLength = 256
+
+// CronCreateInput is used for creating a cron trigger.
+type CronCreateInput struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	Branch      string `json:"branch"`
+	Expression  string `json:"expression"`
+	Timezone    string `json:"timezone"`
+	Disabled    bool   `json:"disabled"`
+}
+
+func (in *CronCreateInput) sanitize() error {
+	in.Description = strings.TrimSpace(in.Description)
+	in.Branch = strings.TrimPrefix(strings.TrimSpace(in.Branch), "refs/heads/")
+	in.Expression = strings.TrimSpace(in.Expression)
+	in.Timezone = strings.TrimSpace(in.Timezone)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+	if err := checkCronBranch(in.Branch); err != nil {
+		return err
+	}
+	if _, err := cron.ParseSchedule(in.Expression, in.Timezone); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CronUpdateInput is used for updating a cron trigger.
+type CronUpdateInput struct {
+	Identifier  *string `json:"identifier"`
+	Description *string `json:"description"`
+	Branch      *string `json:"branch"`
+	Expression  *string `json:"expression"`
+	Timezone    *string `json:"timezone"`
+	Disabled    *bool   `json:"disabled"`
+}
+
+func (in *CronUpdateInput) sanitize() error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+	if in.Branch != nil {
+		*in.Branch = strings.TrimPrefix(strings.TrimSpace(*in.Branch), "refs/heads/")
+		if err := checkCronBranch(*in.Branch); err != nil {
+			return err
+		}
+	}
+	if in.Expression != nil {
+		*in.Expression = strings.TrimSpace(*in.Expression)
+	}
+	if in.Timezone != nil {
+		*in.Timezone = strings.TrimSpace(*in.Timezone)
+	}
+
+	// the expression and the time zone are validated together once merged with the cron trigger.
+	return nil
+}
+
+func checkCronBranch(branch string) error {
+	if len(branch) > cronMaxBranchLength {
+		return check.NewValidationErrorf("The branch of a cron trigger can be at most %d characters long.",
+			cronMaxBranchLength)
+	}
+
+	return nil
+}
+
+// CronCreate creates a new cron trigger of the pipeline.
+func (c *Controller) CronCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	in *CronCreateInput,
+) (*types.Cron, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	// Cron trigger permissions are associated with pipeline permissions, same as for triggers.
+	repo, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cronTrigger := &types.Cron{
+		RepoID:      repo.ID,
+		PipelineID:  pipeline.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Branch:      in.Branch,
+		Expression:  in.Expression,
+		Timezone:    in.Timezone,
+		Disabled:    in.Disabled,
+		CreatedBy:   session.Principal.ID,
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
+	}
+
+	if err = cron.Schedule(cronTrigger, now); err != nil {
+		return nil, err
+	}
+
+	if err = c.cronStore.Create(ctx, cronTrigger); err != nil {
+		return nil, fmt.Errorf("failed to create cron trigger: %w", err)
+	}
+
+	return cronTrigger, nil
+}
+
+// CronFind finds a cron trigger of the pipeline.
+func (c *Controller) CronFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	cronIdentifier string,
+) (*types.Cron, error) {
+	_, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	cronTrigger, err := c.cronStore.FindByIdentifier(ctx, pipeline.ID, cronIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cron trigger: %w", err)
+	}
+
+	return cronTrigger, nil
+}
+
+// CronUpdate updates a cron trigger of the pipeline. Its next run is scheduled again from now.
+func (c *Controller) CronUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	cronIdentifier string,
+	in *CronUpdateInput,
+) (*types.Cron, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	_, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	cronTrigger, err := c.cronStore.FindByIdentifier(ctx, pipeline.ID, cronIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cron trigger: %w", err)
+	}
+
+	return c.cronStore.UpdateOptLock(ctx, cronTrigger, func(original *types.Cron) error {
+		if in.Identifier != nil {
+			original.Identifier = *in.Identifier
+		}
+		if in.Description != nil {
+			original.Description = *in.Description
+		}
+		if in.Branch != nil {
+			original.Branch = *in.Branch
+		}
+		if in.Expression != nil {
+			original.Expression = *in.Expression
+		}
+		if in.Timezone != nil {
+			original.Timezone = *in.Timezone
+		}
+		if in.Disabled != nil {
+			original.Disabled = *in.Disabled
+		}
+
+		return cron.Schedule(original, time.Now())
+	})
+}
+
+// CronDelete deletes a cron trigger of the pipeline.
+func (c *Controller) CronDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	cronIdentifier string,
+) error {
+	_, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineEdit)
+	if err != nil {
+		return err
+	}
+
+	// ensures a not found error for unknown cron triggers.
+	if _, err = c.cronStore.FindByIdentifier(ctx, pipeline.ID, cronIdentifier); err != nil {
+		return fmt.Errorf("failed to find cron trigger: %w", err)
+	}
+
+	if err = c.cronStore.DeleteByIdentifier(ctx, pipeline.ID, cronIdentifier); err != nil {
+		return fmt.Errorf("failed to delete cron trigger: %w", err)
+	}
+
+	return nil
+}
+
+// CronList lists the cron triggers of the pipeline.
+func (c *Controller) CronList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	filter types.ListQueryFilter,
+) ([]*types.Cron, int64, error) {
+	_, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.cronStore.Count(ctx, pipeline.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count cron triggers: %w", err)
+	}
+
+	crons, err := c.cronStore.List(ctx, pipeline.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list cron triggers: %w", err)
+	}
+
+	return crons, count, nil
+}
+
+// CronUpcoming lists the upcoming runs of all enabled cron triggers of the pipeline.
+func (c *Controller) CronUpcoming(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	limit int,
+) ([]types.CronRun, error) {
+	repo, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit < 1 || limit > cron.MaxUpcomingRuns {
+		return nil, check.NewValidationErrorf("The number of upcoming runs must be between 1 and %d.",
+			cron.MaxUpcomingRuns)
+	}
+
+	// the number of cron triggers per pipeline is small, so all of them are loaded.
+	var crons []*types.Cron
+	filter := types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 100}}
+	for {
+		page, errList := c.cronStore.List(ctx, pipeline.ID, filter)
+		if errList != nil {
+			return nil, fmt.Errorf("failed to list cron triggers: %w", errList)
+		}
+
+		crons = append(crons, page...)
+		if len(page) < filter.Size {
+			break
+		}
+		filter.Page++
+	}
+
+	return cron.Upcoming(crons, cron.DefaultBranch(pipeline, repo), time.Now(), limit), nil
+}
+
+func (c *Controller) getPipelineCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	permission enum.Permission,
+) (*types.Repository, *types.Pipeline, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, permission)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	return repo, pipeline, nil
+}
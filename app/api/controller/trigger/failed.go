@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FailedList lists the git events of the repository for which no execution could be created.
+func (c *Controller) FailedList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pagination types.Pagination,
+) ([]*types.TriggerRequest, int64, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", enum.PermissionPipelineView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	requests, count, err := c.triggerQueue.ListFailed(ctx, repo.ID, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list failed trigger requests: %w", err)
+	}
+
+	return requests, count, nil
+}
+
+// FailedRetry queues a failed git event again to create the pipeline execution.
+func (c *Controller) FailedRetry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	requestID int64,
+) error {
+	return c.failedAction(ctx, session, repoRef, requestID, enum.PermissionPipelineExecute,
+		func(request *types.TriggerRequest) error {
+			return c.triggerQueue.Retry(ctx, request)
+		})
+}
+
+// FailedDelete discards a failed git event.
+func (c *Controller) FailedDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	requestID int64,
+) error {
+	// Same as for triggers, a user that can edit the pipeline can discard its failed events.
+	return c.failedAction(ctx, session, repoRef, requestID, enum.PermissionPipelineEdit,
+		func(request *types.TriggerRequest) error {
+			return c.triggerQueue.Delete(ctx, request)
+		})
+}
+
+func (c *Controller) failedAction(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	requestID int64,
+	permission enum.Permission,
+	action func(request *types.TriggerRequest) error,
+) error {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", enum.PermissionPipelineView)
+	if err != nil {
+		return fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	request, err := c.triggerQueue.FindFailed(ctx, repo.ID, requestID)
+	if err != nil {
+		return err
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, request.PipelineIdentifier, permission)
+	if err != nil {
+		return fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	return action(request)
+}
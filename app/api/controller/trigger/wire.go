@@ -16,6 +16,7 @@ package trigger
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/triggerqueue"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -31,6 +32,8 @@ func ProvideController(
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
 	repoStore store.RepoStore,
+	triggerQueue *triggerqueue.Service,
+	cronStore store.CronStore,
 ) *Controller {
-	return NewController(authorizer, triggerStore, pipelineStore, repoStore)
+	return NewController(authorizer, triggerStore, pipelineStore, repoStore, triggerQueue, cronStore)
 }
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -35,9 +36,8 @@ import (
 )
 
 const (
-	MaxFileSize       = 10 << 20 // 10 MB file limit set in Handler
-	fileBucketPathFmt = "uploads/%d/%s"
-	peekBytes         = 512
+	MaxFileSize = 10 << 20 // 10 MB file limit set in Handler
+	peekBytes   = 512
 )
 
 var supportedFileTypes = map[string]struct{}{
@@ -45,20 +45,33 @@ var supportedFileTypes = map[string]struct{}{
 	"video": {},
 }
 
+// supportedMIMETypes contains the file (non-media) types that can be attached to comments.
+var supportedMIMETypes = []string{
+	"application/pdf",
+	"application/zip",
+	"application/gzip",
+	"application/json",
+	"text/plain",
+	"text/csv",
+}
+
 type Controller struct {
-	authorizer authz.Authorizer
-	repoStore  store.RepoStore
-	blobStore  blob.Store
+	authorizer      authz.Authorizer
+	repoStore       store.RepoStore
+	blobStore       *blob.PoolStore
+	attachmentStore store.AttachmentStore
 }
 
 func NewController(authorizer authz.Authorizer,
 	repoStore store.RepoStore,
-	blobStore blob.Store,
+	blobStore *blob.PoolStore,
+	attachmentStore store.AttachmentStore,
 ) *Controller {
 	return &Controller{
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		blobStore:  blobStore,
+		authorizer:      authorizer,
+		repoStore:       repoStore,
+		blobStore:       blobStore,
+		attachmentStore: attachmentStore,
 	}
 }
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
@@ -79,29 +92,36 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("failed to verify authorization: %w", err)
 	}
 
+	if err = controller.CheckRepoNotArchived(repo, permission); err != nil {
+		return nil, err
+	}
+
 	return repo, nil
 }
 
-func (c *Controller) getFileExtension(file *bufio.Reader) (string, error) {
+func (c *Controller) getFileType(file *bufio.Reader) (*mimetype.MIME, error) {
 	buf, err := file.Peek(peekBytes)
 	if err != nil && !errors.Is(err, io.EOF) {
-		return "", fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	// Example: mType.String() = image/png
 	// Splitting on "/" and taking the first element of the slice
 	// will give us the file type.
 	mType := mimetype.Detect(buf)
-	if _, ok := supportedFileTypes[strings.Split(mType.String(), "/")[0]]; !ok {
-		return "",
-			usererror.BadRequestf(
-				"only image and video files are supported, uploaded file is of type %s",
-				mType.String())
+	if _, ok := supportedFileTypes[strings.Split(mType.String(), "/")[0]]; ok {
+		return mType, nil
 	}
 
-	return mType.Extension(), nil
-}
+	for _, supported := range supportedMIMETypes {
+		// mType.Is ignores MIME parameters, e.g. the charset of text files.
+		if mType.Is(supported) {
+			return mType, nil
+		}
+	}
 
-func getFileBucketPath(repoID int64, fileName string) string {
-	return fmt.Sprintf(fileBucketPathFmt, repoID, fileName)
+	return nil, usererror.BadRequestf(
+		"only images, videos and %s files are supported, uploaded file is of type %s",
+		strings.Join(supportedMIMETypes, ", "),
+		mType.String())
 }
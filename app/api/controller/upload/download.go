@@ -21,6 +21,7 @@ import (
 	"io"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/attachment"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types/enum"
 )
@@ -36,9 +37,10 @@ func (c *Controller) Download(
 		return "", nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
-	fileBucketPath := getFileBucketPath(repo.ID, filePath)
+	fileBucketPath := attachment.BucketPath(repo.ID, filePath)
+	blobStore := c.blobStore.Get(repo.StoragePool)
 
-	signedURL, err := c.blobStore.GetSignedURL(ctx, fileBucketPath)
+	signedURL, err := blobStore.GetSignedURL(ctx, fileBucketPath)
 	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
 		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
 	}
@@ -47,7 +49,7 @@ func (c *Controller) Download(
 		return signedURL, nil, nil
 	}
 
-	file, err := c.blobStore.Download(ctx, fileBucketPath)
+	file, err := blobStore.Download(ctx, fileBucketPath)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to download file from blobstore: %w", err)
 	}
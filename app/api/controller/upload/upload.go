@@ -19,9 +19,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/attachment"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/google/uuid"
@@ -29,7 +33,12 @@ import (
 
 // Result contains the information about the upload.
 type Result struct {
-	FilePath string `json:"file_path"`
+	FilePath    string `json:"file_path"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Link can be used in markdown (e.g. comments) to reference the uploaded file,
+	// it's replaced with the download link of the file once the markdown is saved.
+	Link string `json:"link"`
 }
 
 const (
@@ -47,25 +56,48 @@ func (c *Controller) Upload(ctx context.Context,
 		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
+	// the files of the repository are copied to a different blob store while its storage pool changes.
+	if repo.State == enum.RepoStateStorageMove {
+		return nil, usererror.BadRequest("Repository is being moved to a different storage pool.")
+	}
+
 	if file == nil {
 		return nil, usererror.BadRequest("no file provided")
 	}
 	bufReader := bufio.NewReader(file)
-	// Check if the file is an image or video
-	extn, err := c.getFileExtension(bufReader)
+	// Check if the file is of a supported type
+	mType, err := c.getFileType(bufReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine file type: %w", err)
 	}
 
 	identifier := uuid.New().String()
-	fileName := fmt.Sprintf(fileNameFmt, identifier, extn)
+	fileName := fmt.Sprintf(fileNameFmt, identifier, mType.Extension())
 
-	fileBucketPath := getFileBucketPath(repo.ID, fileName)
-	err = c.blobStore.Upload(ctx, bufReader, fileBucketPath)
+	counter := blob.NewCountingReader(bufReader, 0)
+
+	fileBucketPath := attachment.BucketPath(repo.ID, fileName)
+	err = c.blobStore.Get(repo.StoragePool).Upload(ctx, counter, fileBucketPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
+
+	err = c.attachmentStore.Create(ctx, &types.Attachment{
+		RepoID:      repo.ID,
+		FileName:    fileName,
+		ContentType: mType.String(),
+		Size:        counter.N(),
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment details: %w", err)
+	}
+
 	return &Result{
-		FilePath: fileName,
+		FilePath:    fileName,
+		ContentType: mType.String(),
+		Size:        counter.N(),
+		Link:        attachment.SchemeLink(fileName),
 	}, nil
 }
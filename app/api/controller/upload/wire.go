@@ -30,7 +30,8 @@ var WireSet = wire.NewSet(
 func ProvideController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
-	blobStore blob.Store,
+	blobStore *blob.PoolStore,
+	attachmentStore store.AttachmentStore,
 ) *Controller {
-	return NewController(authorizer, repoStore, blobStore)
+	return NewController(authorizer, repoStore, blobStore, attachmentStore)
 }
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UploadAvatar resizes the provided image and sets it as the avatar of the user.
+func (c *Controller) UploadAvatar(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	file io.Reader,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		return nil, errors.InvalidArgument("No avatar image provided")
+	}
+
+	fileName, err := c.avatarService.Upload(ctx, user.ID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.setAvatar(ctx, user, fileName)
+}
+
+// DeleteAvatar removes the uploaded avatar of the user.
+func (c *Controller) DeleteAvatar(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if user.Avatar == "" {
+		return user, nil
+	}
+
+	return c.setAvatar(ctx, user, "")
+}
+
+// setAvatar updates the avatar of the user and removes the previous avatar file from the blob store.
+func (c *Controller) setAvatar(ctx context.Context, user *types.User, fileName string) (*types.User, error) {
+	oldFileName := user.Avatar
+
+	user.Avatar = fileName
+	user.AvatarURL = types.PrincipalAvatarPath(user.ID, enum.PrincipalTypeUser, fileName)
+	user.Updated = time.Now().UnixMilli()
+
+	if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user avatar: %w", err)
+	}
+
+	if err := c.avatarService.Delete(ctx, user.ID, oldFileName); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete previous avatar of user %d", user.ID)
+	}
+
+	return user, nil
+}
@@ -18,7 +18,16 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/saml"
+	"github.com/harness/gitness/app/services/tokenpolicy"
+	"github.com/harness/gitness/app/services/twofactor"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -28,13 +37,25 @@ import (
 )
 
 type Controller struct {
-	tx                dbtx.Transactor
-	principalUIDCheck check.PrincipalUID
-	authorizer        authz.Authorizer
-	principalStore    store.PrincipalStore
-	tokenStore        store.TokenStore
-	membershipStore   store.MembershipStore
-	publicKeyStore    store.PublicKeyStore
+	tx                   dbtx.Transactor
+	principalUIDCheck    check.PrincipalUID
+	authorizer           authz.Authorizer
+	principalStore       store.PrincipalStore
+	tokenStore           store.TokenStore
+	membershipStore      store.MembershipStore
+	publicKeyStore       store.PublicKeyStore
+	userEmailStore       store.UserEmailStore
+	userPreferencesStore store.UserPreferencesStore
+
+	notificationClient notification.Client
+	urlProvider        url.Provider
+	avatarService      *avatar.Service
+	twoFactorService   *twofactor.Service
+	passkeyService     *passkey.Service
+	oauthService       *oauth.Service
+	samlService        *saml.Service
+	ldapService        *ldap.Service
+	tokenPolicyService *tokenpolicy.Service
 }
 
 func NewController(
@@ -45,15 +66,38 @@ func NewController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	userEmailStore store.UserEmailStore,
+	userPreferencesStore store.UserPreferencesStore,
+	notificationClient notification.Client,
+	urlProvider url.Provider,
+	avatarService *avatar.Service,
+	twoFactorService *twofactor.Service,
+	passkeyService *passkey.Service,
+	oauthService *oauth.Service,
+	samlService *saml.Service,
+	ldapService *ldap.Service,
+	tokenPolicyService *tokenpolicy.Service,
 ) *Controller {
 	return &Controller{
-		tx:                tx,
-		principalUIDCheck: principalUIDCheck,
-		authorizer:        authorizer,
-		principalStore:    principalStore,
-		tokenStore:        tokenStore,
-		membershipStore:   membershipStore,
-		publicKeyStore:    publicKeyStore,
+		tx:                   tx,
+		principalUIDCheck:    principalUIDCheck,
+		authorizer:           authorizer,
+		principalStore:       principalStore,
+		tokenStore:           tokenStore,
+		membershipStore:      membershipStore,
+		publicKeyStore:       publicKeyStore,
+		userEmailStore:       userEmailStore,
+		userPreferencesStore: userPreferencesStore,
+
+		notificationClient: notificationClient,
+		urlProvider:        urlProvider,
+		avatarService:      avatarService,
+		twoFactorService:   twoFactorService,
+		passkeyService:     passkeyService,
+		oauthService:       oauthService,
+		samlService:        samlService,
+		ldapService:        ldapService,
+		tokenPolicyService: tokenPolicyService,
 	}
 }
 
@@ -17,21 +17,38 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
 
+// maxTokenResources is the maximum number of spaces and repositories a token can be restricted to.
+const maxTokenResources = 50
+
 type CreateTokenInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+	// Scopes restrict the permissions of the token, it has all permissions of the user if empty.
+	Scopes []enum.TokenScope `json:"scopes"`
+	// Resources restrict the token to spaces and repositories, it has access to all resources if empty.
+	Resources []types.TokenResource `json:"resources"`
+	// AllowedCIDRs restrict the networks the token can be used from, it can be used from anywhere if empty.
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+	// OTP is the TOTP or recovery code, required if the user has two-factor authentication enabled.
+	OTP string `json:"otp"`
+	// WebAuthn is a WebAuthn assertion that can be used as second factor instead of the OTP.
+	WebAuthn *passkey.AssertionInput `json:"webauthn"`
 }
 
 /*
@@ -57,13 +74,32 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
-	token, jwtToken, err := token.CreatePAT(
+	maxLifetime, err := c.tokenPolicyService.MaxUserLifetime(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maximum token lifetime: %w", err)
+	}
+
+	if err = tokenpolicy.CheckLifetime(maxLifetime, in.Lifetime); err != nil {
+		return nil, err
+	}
+
+	// the second factor is only checked if users create tokens for themselves.
+	if session.Principal.ID == user.ID {
+		if err = c.checkTwoFactor(ctx, user.ID, in.OTP, in.WebAuthn); err != nil {
+			return nil, err
+		}
+	}
+
+	token, jwtToken, err := token.CreateScopedPAT(
 		ctx,
 		c.tokenStore,
 		&session.Principal,
 		user,
 		in.Identifier,
 		in.Lifetime,
+		in.Scopes,
+		in.Resources,
+		in.AllowedCIDRs,
 	)
 	if err != nil {
 		return nil, err
@@ -82,10 +118,48 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
-	//nolint:revive
 	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
 		return err
 	}
 
+	if err := sanitizeTokenScopes(in.Scopes); err != nil {
+		return err
+	}
+
+	if err := check.AllowedCIDRs(in.AllowedCIDRs); err != nil {
+		return err
+	}
+
+	return sanitizeTokenResources(in.Resources)
+}
+
+func sanitizeTokenScopes(scopes []enum.TokenScope) error {
+	for i := range scopes {
+		scope, ok := scopes[i].Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unknown token scope '%s'.", scopes[i])
+		}
+		scopes[i] = scope
+	}
+
+	return nil
+}
+
+func sanitizeTokenResources(resources []types.TokenResource) error {
+	if len(resources) > maxTokenResources {
+		return usererror.BadRequestf("A token can't be restricted to more than %d resources.", maxTokenResources)
+	}
+
+	for i := range resources {
+		resources[i].Path = strings.Trim(strings.TrimSpace(resources[i].Path), "/")
+		if resources[i].Path == "" {
+			return usererror.BadRequest("The path of a token resource is required.")
+		}
+
+		if resources[i].Type != enum.ParentResourceTypeSpace && resources[i].Type != enum.ParentResourceTypeRepo {
+			return usererror.BadRequestf("Unknown token resource type '%s'.", resources[i].Type)
+		}
+	}
+
 	return nil
 }
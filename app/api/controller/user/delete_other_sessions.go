@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+/*
+ * DeleteOtherSessions deletes all session tokens of a user, except the session used by the request.
+ */
+func (c *Controller) DeleteOtherSessions(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	// the current session is only kept if the request was authenticated with a session of the user.
+	var currentID int64
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok &&
+		tokenMetadata.TokenType == enum.TokenTypeSession && session.Principal.ID == user.ID {
+		currentID = tokenMetadata.TokenID
+	}
+
+	n, err := c.tokenStore.DeleteForPrincipal(ctx, user.ID, enum.TokenTypeSession, currentID)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().Msgf("deleted %d other sessions of user %q", n, user.UID)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxUserEmails is the maximum number of additional email addresses of a user.
+	maxUserEmails = 20
+
+	// emailVerificationExpiry is the duration the email verification token is valid for.
+	emailVerificationExpiry = 24 * time.Hour
+
+	// emailVerificationResendInterval is the minimal duration between two verification emails.
+	emailVerificationResendInterval = time.Minute
+
+	emailVerificationTokenLength = 32
+)
+
+// generateEmailVerificationToken returns a random token and its hash. Only the hash is stored in the database.
+func generateEmailVerificationToken() (string, string, error) {
+	b := make([]byte, emailVerificationTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, hashEmailVerificationToken(token), nil
+}
+
+func hashEmailVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// prepareEmailVerification sets a new verification token to the email address and returns the token.
+func prepareEmailVerification(userEmail *types.UserEmail, now time.Time) (string, error) {
+	token, tokenHash, err := generateEmailVerificationToken()
+	if err != nil {
+		return "", err
+	}
+
+	sent := now.UnixMilli()
+
+	userEmail.VerificationToken = &tokenHash
+	userEmail.VerificationSent = &sent
+	userEmail.Updated = sent
+
+	return token, nil
+}
+
+// sendEmailVerification sends the verification email. Failures are only logged,
+// because the user can always request another verification email.
+func (c *Controller) sendEmailVerification(
+	ctx context.Context,
+	user *types.User,
+	userEmail *types.UserEmail,
+	token string,
+) {
+	err := c.notificationClient.SendEmailVerification(ctx, &notification.EmailVerificationPayload{
+		DisplayName:     user.DisplayName,
+		Email:           userEmail.Email,
+		VerificationURL: c.urlProvider.GenerateUIEmailVerificationURL(ctx, token),
+		ExpiresIn:       fmt.Sprintf("%d hours", int(emailVerificationExpiry.Hours())),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("principal_id", user.ID).
+			Msg("failed to send email verification mail")
+	}
+}
+
+// checkEmailAvailable returns an error if the email address already belongs to another user.
+func (c *Controller) checkEmailAvailable(ctx context.Context, user *types.User, email string) error {
+	principal, err := c.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	if principal.ID != user.ID {
+		return errors.Conflict("Email address is already in use")
+	}
+
+	return nil
+}
+
+func sanitizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if err := check.Email(email); err != nil {
+		return "", err
+	}
+
+	return email, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AddEmailInput struct {
+	Email string `json:"email"`
+}
+
+// AddEmail adds an unverified email address to the user and sends the verification email.
+func (c *Controller) AddEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *AddEmailInput,
+) (*types.UserEmail, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	email, err := sanitizeEmail(in.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(email, user.Email) {
+		return nil, errors.InvalidArgument("Email address is already the primary email address")
+	}
+
+	if err = c.checkEmailAvailable(ctx, user, email); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userEmail := &types.UserEmail{
+		PrincipalID: user.ID,
+		Email:       email,
+		Created:     now.UnixMilli(),
+	}
+
+	token, err := prepareEmailVerification(userEmail, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		userEmails, err := c.userEmailStore.List(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list user emails: %w", err)
+		}
+
+		if len(userEmails) >= maxUserEmails {
+			return errors.InvalidArgument("A user can't have more than %d additional email addresses", maxUserEmails)
+		}
+
+		err = c.userEmailStore.Create(ctx, userEmail)
+		if errors.Is(err, store.ErrDuplicate) {
+			return errors.Conflict("Email address has already been added")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create user email: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.sendEmailVerification(ctx, user, userEmail, token)
+
+	return userEmail, nil
+}
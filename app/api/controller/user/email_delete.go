@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeleteEmail removes an additional email address of the user. The primary email address can't be removed.
+func (c *Controller) DeleteEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	email string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	if strings.EqualFold(email, user.Email) {
+		return errors.InvalidArgument("The primary email address can't be removed")
+	}
+
+	userEmail, err := c.userEmailStore.Find(ctx, user.ID, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user email: %w", err)
+	}
+
+	if err = c.userEmailStore.Delete(ctx, userEmail.ID); err != nil {
+		return fmt.Errorf("failed to delete user email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListEmails lists all email addresses of the user, the primary email address first.
+func (c *Controller) ListEmails(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.UserEmail, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	userEmails, err := c.userEmailStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user emails: %w", err)
+	}
+
+	result := make([]*types.UserEmail, 0, len(userEmails)+1)
+	result = append(result, primaryUserEmail(user))
+	for _, userEmail := range userEmails {
+		if strings.EqualFold(userEmail.Email, user.Email) {
+			continue
+		}
+		result = append(result, userEmail)
+	}
+
+	return result, nil
+}
+
+// primaryUserEmail returns the primary email address of the user, which is always considered verified.
+func primaryUserEmail(user *types.User) *types.UserEmail {
+	return &types.UserEmail{
+		PrincipalID: user.ID,
+		Email:       user.Email,
+		Primary:     true,
+		Verified:    &user.Created,
+		Created:     user.Created,
+		Updated:     user.Updated,
+	}
+}
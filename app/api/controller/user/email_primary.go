@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SetPrimaryEmail makes a verified email address the primary email address of the user.
+// The primary email address is used for the notifications, the previous one is kept as a verified email address.
+func (c *Controller) SetPrimaryEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	email string,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(email, user.Email) {
+		return user, nil
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		userEmail, err := c.userEmailStore.Find(ctx, user.ID, email)
+		if err != nil {
+			return fmt.Errorf("failed to find user email: %w", err)
+		}
+
+		if userEmail.Verified == nil {
+			return errors.InvalidArgument("Only a verified email address can become the primary email address")
+		}
+
+		now := time.Now().UnixMilli()
+
+		if err = c.userEmailStore.Delete(ctx, userEmail.ID); err != nil {
+			return fmt.Errorf("failed to delete user email: %w", err)
+		}
+
+		previousEmail := &types.UserEmail{
+			PrincipalID: user.ID,
+			Email:       user.Email,
+			Verified:    &now,
+			Created:     now,
+			Updated:     now,
+		}
+
+		user.Email = userEmail.Email
+		user.Updated = now
+
+		if err = c.principalStore.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		// the previous primary email address could have been added before it became primary
+		existing, err := c.userEmailStore.Find(ctx, user.ID, previousEmail.Email)
+		if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find user email: %w", err)
+		}
+		if existing != nil {
+			if err = c.userEmailStore.Delete(ctx, existing.ID); err != nil {
+				return fmt.Errorf("failed to delete user email: %w", err)
+			}
+		}
+
+		if err = c.userEmailStore.Create(ctx, previousEmail); err != nil {
+			return fmt.Errorf("failed to keep the previous primary email address: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
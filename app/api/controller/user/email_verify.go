@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
+
+// ResendEmailVerification sends a new verification email for an unverified email address of the user.
+func (c *Controller) ResendEmailVerification(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	email string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	userEmail, err := c.userEmailStore.Find(ctx, user.ID, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user email: %w", err)
+	}
+
+	if userEmail.Verified != nil {
+		return errors.InvalidArgument("Email address is already verified")
+	}
+
+	now := time.Now()
+	if userEmail.VerificationSent != nil &&
+		now.Sub(time.UnixMilli(*userEmail.VerificationSent)) < emailVerificationResendInterval {
+		return errors.PreconditionFailed("Verification email has been sent recently, please try again later")
+	}
+
+	token, err := prepareEmailVerification(userEmail, now)
+	if err != nil {
+		return err
+	}
+
+	if err = c.userEmailStore.Update(ctx, userEmail); err != nil {
+		return fmt.Errorf("failed to update user email: %w", err)
+	}
+
+	c.sendEmailVerification(ctx, user, userEmail, token)
+
+	return nil
+}
+
+// VerifyEmail verifies an email address of the user with the token sent in the verification email.
+// Only the user the email address belongs to can verify it.
+func (c *Controller) VerifyEmail(
+	ctx context.Context,
+	session *auth.Session,
+	in *VerifyEmailInput,
+) (*types.UserEmail, error) {
+	token := strings.TrimSpace(in.Token)
+	if token == "" {
+		return nil, errors.InvalidArgument("Verification token is required")
+	}
+
+	userEmail, err := c.userEmailStore.FindByVerificationToken(ctx, hashEmailVerificationToken(token))
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, errors.InvalidArgument("Verification token is invalid or has expired")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user email by verification token: %w", err)
+	}
+
+	if userEmail.PrincipalID != session.Principal.ID {
+		return nil, errors.InvalidArgument("Verification token is invalid or has expired")
+	}
+
+	now := time.Now()
+	if userEmail.VerificationSent == nil ||
+		now.Sub(time.UnixMilli(*userEmail.VerificationSent)) > emailVerificationExpiry {
+		return nil, errors.InvalidArgument("Verification token is invalid or has expired")
+	}
+
+	user, err := c.principalStore.FindUser(ctx, userEmail.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err = c.checkEmailAvailable(ctx, user, userEmail.Email); err != nil {
+		return nil, err
+	}
+
+	verified := now.UnixMilli()
+	userEmail.Verified = &verified
+	userEmail.VerificationToken = nil
+	userEmail.VerificationSent = nil
+	userEmail.Updated = verified
+
+	err = c.userEmailStore.Update(ctx, userEmail)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, errors.Conflict("Email address is already in use")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user email: %w", err)
+	}
+
+	return userEmail, nil
+}
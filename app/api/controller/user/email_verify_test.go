@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	emailUserID  = 1
+	emailOtherID = 2
+)
+
+type fakeUserEmailStore struct {
+	appstore.UserEmailStore
+	emails  []*types.UserEmail
+	updated []*types.UserEmail
+}
+
+func (s *fakeUserEmailStore) FindByVerificationToken(_ context.Context, token string) (*types.UserEmail, error) {
+	for _, email := range s.emails {
+		if email.VerificationToken != nil && *email.VerificationToken == token {
+			return email, nil
+		}
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+func (s *fakeUserEmailStore) Update(_ context.Context, userEmail *types.UserEmail) error {
+	s.updated = append(s.updated, userEmail)
+	return nil
+}
+
+// fakeEmailPrincipalStore knows the users and their primary email addresses.
+type fakeEmailPrincipalStore struct {
+	appstore.PrincipalStore
+	users []*types.User
+}
+
+func (s *fakeEmailPrincipalStore) FindUser(_ context.Context, id int64) (*types.User, error) {
+	for _, user := range s.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+func (s *fakeEmailPrincipalStore) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	for _, user := range s.users {
+		if strings.EqualFold(user.Email, email) {
+			return &types.Principal{ID: user.ID, Email: user.Email, Type: enum.PrincipalTypeUser}, nil
+		}
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+// newUnverifiedEmail returns an email address of the user with a verification token sent at the provided time.
+func newUnverifiedEmail(t *testing.T, principalID int64, email string, sent time.Time) (*types.UserEmail, string) {
+	t.Helper()
+
+	userEmail := &types.UserEmail{PrincipalID: principalID, Email: email}
+
+	token, err := prepareEmailVerification(userEmail, sent)
+	if err != nil {
+		t.Fatalf("failed to prepare email verification: %v", err)
+	}
+
+	return userEmail, token
+}
+
+func TestController_VerifyEmail(t *testing.T) {
+	now := time.Now()
+
+	valid, validToken := newUnverifiedEmail(t, emailUserID, "john@work.com", now.Add(-time.Hour))
+	expired, expiredToken := newUnverifiedEmail(t, emailUserID, "john@old.com", now.Add(-25*time.Hour))
+	taken, takenToken := newUnverifiedEmail(t, emailUserID, "jane@example.com", now)
+	other, otherToken := newUnverifiedEmail(t, emailOtherID, "jane@work.com", now)
+
+	tests := []struct {
+		name         string
+		token        string
+		wantVerified *types.UserEmail
+		wantConflict bool
+	}{
+		{
+			name:  "missing token",
+			token: " ",
+		},
+		{
+			name:  "unknown token",
+			token: "unknown",
+		},
+		{
+			name:  "expired token",
+			token: expiredToken,
+		},
+		{
+			name:  "token of another user",
+			token: otherToken,
+		},
+		{
+			name:         "email address of another user",
+			token:        takenToken,
+			wantConflict: true,
+		},
+		{
+			name:         "verified",
+			token:        " " + validToken + " ",
+			wantVerified: valid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			emailStore := &fakeUserEmailStore{emails: []*types.UserEmail{valid, expired, taken, other}}
+			c := &Controller{
+				userEmailStore: emailStore,
+				principalStore: &fakeEmailPrincipalStore{users: []*types.User{
+					{ID: emailUserID, Email: "john@example.com"},
+					{ID: emailOtherID, Email: "jane@example.com"},
+				}},
+			}
+
+			session := &auth.Session{Principal: types.Principal{ID: emailUserID, Type: enum.PrincipalTypeUser}}
+
+			userEmail, err := c.VerifyEmail(context.Background(), session, &VerifyEmailInput{Token: test.token})
+
+			if test.wantVerified == nil {
+				if test.wantConflict && !errors.IsConflict(err) {
+					t.Errorf("expected conflict, got %v", err)
+				}
+				if !test.wantConflict && !errors.IsInvalidArgument(err) {
+					t.Errorf("expected invalid argument, got %v", err)
+				}
+				if len(emailStore.updated) > 0 {
+					t.Errorf("expected the email address not to be updated")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if userEmail != test.wantVerified || userEmail.Verified == nil {
+				t.Errorf("expected %s to be verified, got %+v", test.wantVerified.Email, userEmail)
+			}
+			if userEmail.VerificationToken != nil || userEmail.VerificationSent != nil {
+				t.Errorf("expected the verification token to be cleared")
+			}
+			if len(emailStore.updated) != 1 {
+				t.Errorf("expected a single update, got %d", len(emailStore.updated))
+			}
+		})
+	}
+}
@@ -43,5 +43,17 @@ func (c *Controller) ListTokens(ctx context.Context, session *auth.Session,
 		return nil, usererror.ErrBadRequest
 	}
 
-	return c.tokenStore.List(ctx, user.ID, tokenType)
+	tokens, err := c.tokenStore.List(ctx, user.ID, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	// mark the session used by the request, so clients can exclude it when revoking sessions.
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenType == enum.TokenTypeSession {
+		for _, token := range tokens {
+			token.Current = token.ID == tokenMetadata.TokenID
+		}
+	}
+
+	return tokens, nil
 }
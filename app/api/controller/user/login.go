@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/twofactor"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
@@ -34,6 +37,10 @@ import (
 type LoginInput struct {
 	LoginIdentifier string `json:"login_identifier"`
 	Password        string `json:"password"`
+	// OTP is the TOTP or recovery code, required if the user has two-factor authentication enabled.
+	OTP string `json:"otp"`
+	// WebAuthn is a WebAuthn assertion that can be used as second factor instead of the OTP.
+	WebAuthn *passkey.AssertionInput `json:"webauthn"`
 }
 
 /*
@@ -50,29 +57,96 @@ func (c *Controller) Login(
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
 	}
 
-	// always return not found for security reasons.
+	if c.ldapService.Enabled() {
+		user, err = c.authenticateLDAP(ctx, in.LoginIdentifier, in.Password, user, err)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// always return not found for security reasons.
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).
+				Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
+			return nil, usererror.ErrNotFound
+		}
+
+		if !checkPassword(ctx, user, in.Password) {
+			return nil, usererror.ErrNotFound
+		}
+	}
+
+	if err = checkUserNotBlocked(user); err != nil {
+		return nil, err
+	}
+
+	if err = c.verifySecondFactor(ctx, user.ID, in.OTP, in.WebAuthn); err != nil {
+		return nil, err
+	}
+
+	tokenIdentifier, err := GenerateSessionTokenIdentifier()
 	if err != nil {
-		log.Ctx(ctx).Debug().Err(err).
-			Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
-		return nil, usererror.ErrNotFound
+		return nil, err
 	}
 
-	err = bcrypt.CompareHashAndPassword(
-		[]byte(user.Password),
-		[]byte(in.Password),
-	)
+	return c.createSession(ctx, user, tokenIdentifier)
+}
+
+// createSession creates a session token for the user. Users that have to enable two-factor authentication
+// get a session that is restricted to the enrollment, they have to login again once it's enabled.
+func (c *Controller) createSession(
+	ctx context.Context,
+	user *types.User,
+	tokenIdentifier string,
+) (*types.TokenResponse, error) {
+	enrollmentRequired, err := c.twoFactorEnrollmentRequired(ctx, user.ID)
 	if err != nil {
-		log.Debug().Err(err).
-			Str("user_uid", user.UID).
-			Msg("invalid password")
+		return nil, err
+	}
+
+	var scopes []enum.TokenScope
+	if enrollmentRequired {
+		scopes = []enum.TokenScope{enum.TokenScopeTwoFactorEnrollment}
+	}
+
+	token, jwtToken, err := token.CreateScopedUserSession(ctx, c.tokenStore, user, tokenIdentifier, scopes)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil, usererror.ErrNotFound
+	return &types.TokenResponse{
+		Token:                       *token,
+		AccessToken:                 jwtToken,
+		TwoFactorEnrollmentRequired: enrollmentRequired,
+	}, nil
+}
+
+// createExternalSession creates a session token for a user authenticated by an external identity provider.
+// The second factor can't be verified as part of the login via the provider, so users that enabled two-factor
+// authentication are rejected, unless the provider is trusted to enforce multi-factor authentication.
+func (c *Controller) createExternalSession(
+	ctx context.Context,
+	user *types.User,
+	trustSecondFactor bool,
+) (*types.TokenResponse, error) {
+	if !trustSecondFactor {
+		enabled, err := c.twoFactorService.IsEnabled(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check two-factor state: %w", err)
+		}
+		if enabled {
+			return nil, twofactor.ErrExternalLoginNotAllowed
+		}
 	}
 
 	tokenIdentifier, err := GenerateSessionTokenIdentifier()
 	if err != nil {
 		return nil, err
 	}
+
+	if !trustSecondFactor {
+		return c.createSession(ctx, user, tokenIdentifier)
+	}
+
 	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
 	if err != nil {
 		return nil, err
@@ -81,6 +155,32 @@ func (c *Controller) Login(
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
+// checkPassword verifies the local password of the user.
+func checkPassword(ctx context.Context, user *types.User, password string) bool {
+	err := bcrypt.CompareHashAndPassword(
+		[]byte(user.Password),
+		[]byte(password),
+	)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("user_uid", user.UID).
+			Msg("invalid password")
+
+		return false
+	}
+
+	return true
+}
+
+// checkUserNotBlocked prevents blocked users from logging in, e.g. users removed from the LDAP directory.
+func checkUserNotBlocked(user *types.User) error {
+	if user.Blocked {
+		return usererror.Forbidden("Your account is blocked.")
+	}
+
+	return nil
+}
+
 func GenerateSessionTokenIdentifier() (string, error) {
 	r, err := rand.Int(rand.Reader, big.NewInt(10000))
 	if err != nil {
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const (
+	// externalSignupPasswordLength is the length of the random password of users signed up by an identity provider.
+	externalSignupPasswordLength = 64
+
+	// externalSignupUIDAttempts is the number of random suffixes tried if the login of the user is taken.
+	externalSignupUIDAttempts = 5
+)
+
+var invalidUIDCharacters = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
+
+// ExternalLoginRedirectURL returns the UI URL the user is redirected to after the login via an identity provider.
+// On failure the sign-in page shows the error message.
+func (c *Controller) ExternalLoginRedirectURL(ctx context.Context, errMessage string) string {
+	if errMessage != "" {
+		return c.urlProvider.GenerateUISignInURL(ctx, errMessage)
+	}
+
+	return c.urlProvider.GenerateUIHomeURL(ctx)
+}
+
+// findUserByExternalEmail finds the user with the email address as primary or as verified additional email address.
+func (c *Controller) findUserByExternalEmail(ctx context.Context, email string) (*types.User, error) {
+	user, err := findUserFromEmail(ctx, c.principalStore, email)
+	if err == nil || !errors.Is(err, store.ErrResourceNotFound) {
+		return user, err
+	}
+
+	userEmail, err := c.userEmailStore.FindVerified(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.principalStore.FindUser(ctx, userEmail.PrincipalID)
+}
+
+// generateExternalUserUID derives an available UID from the login of the user with an identity provider.
+func (c *Controller) generateExternalUserUID(ctx context.Context, login string) (string, error) {
+	base := strings.Trim(invalidUIDCharacters.ReplaceAllString(login, "-"), "-.")
+	if len(base) > check.MaxIdentifierLength-5 {
+		base = base[:check.MaxIdentifierLength-5]
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	uid := base
+	for range externalSignupUIDAttempts {
+		if c.principalUIDCheck(uid) == nil {
+			_, err := findUserFromUID(ctx, c.principalStore, uid)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return uid, nil
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to find user by uid: %w", err)
+			}
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random number: %w", err)
+		}
+		uid = fmt.Sprintf("%s-%04d", base, n.Int64())
+	}
+
+	return "", errors.Conflict("Failed to find an available user identifier for '%s'.", login)
+}
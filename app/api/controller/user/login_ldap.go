@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// authenticateLDAP authenticates the user if LDAP is enabled, given the result of the lookup of the local user.
+// Users linked to the directory are authenticated against it only. Other local users are authenticated
+// with their local password, and if it doesn't match (or there is no local user) against the directory,
+// which links the directory entry to the user with the same email address or provisions a new user.
+func (c *Controller) authenticateLDAP(
+	ctx context.Context,
+	login string,
+	password string,
+	user *types.User,
+	findErr error,
+) (*types.User, error) {
+	if findErr != nil && !errors.Is(findErr, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user: %w", findErr)
+	}
+
+	if findErr == nil {
+		identity, err := c.ldapService.FindIdentity(ctx, user.ID)
+		switch {
+		case err == nil:
+			login = identity.Login
+		case errors.Is(err, store.ErrResourceNotFound):
+			if checkPassword(ctx, user, password) {
+				return user, nil
+			}
+		default:
+			return nil, fmt.Errorf("failed to find ldap identity of user: %w", err)
+		}
+	}
+
+	user, err := c.ldapService.Login(ctx, login, password)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		// always return not found for security reasons.
+		log.Ctx(ctx).Debug().Msgf("ldap authentication of %q failed (returning ErrNotFound).", login)
+		return nil, usererror.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
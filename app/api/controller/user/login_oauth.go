@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+// BeginOAuthLogin returns the redirect of the user to the consent page of the OAuth provider.
+func (c *Controller) BeginOAuthLogin(ctx context.Context, providerIdentifier string) (*oauth.AuthRedirect, error) {
+	return c.oauthService.AuthCodeURL(ctx, providerIdentifier)
+}
+
+// LoginOAuth finishes the login via an OAuth provider and returns the session token.
+// The account with the provider is linked to the user on the first login, either to the user
+// with the same verified email address or to a new user, if the provider allows signing up.
+// The provider authenticates the user, so the password isn't required. The second factor is only skipped
+// if the provider is trusted to enforce multi-factor authentication.
+func (c *Controller) LoginOAuth(
+	ctx context.Context,
+	sysCtrl *system.Controller,
+	providerIdentifier string,
+	code string,
+	verifier string,
+) (*types.TokenResponse, error) {
+	provider, externalUser, err := c.oauthService.Exchange(ctx, providerIdentifier, code, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.findOrLinkOAuthUser(ctx, sysCtrl, provider, externalUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkUserNotBlocked(user); err != nil {
+		return nil, err
+	}
+
+	return c.createExternalSession(ctx, user, provider.TrustSecondFactor)
+}
+
+func (c *Controller) findOrLinkOAuthUser(
+	ctx context.Context,
+	sysCtrl *system.Controller,
+	provider *types.OAuthProvider,
+	externalUser *oauth.ExternalUser,
+) (*types.User, error) {
+	identity, err := c.oauthService.FindIdentity(ctx, provider, externalUser)
+	if err == nil {
+		return c.principalStore.FindUser(ctx, identity.PrincipalID)
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	// an email address that isn't verified by the provider could belong to anyone.
+	if externalUser.Email == "" || !externalUser.EmailVerified {
+		return nil, errors.Format(errors.StatusUnauthorized,
+			"Your %s account has no verified email address.", provider.DisplayName)
+	}
+
+	user, err := c.findUserByExternalEmail(ctx, externalUser.Email)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		user, err = c.signupOAuthUser(ctx, sysCtrl, provider, externalUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = c.oauthService.Link(ctx, user.ID, provider, externalUser); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("principal_id", user.ID).
+		Str("oauth_provider", provider.Identifier).
+		Str("external_login", externalUser.Login).
+		Msg("linked oauth identity to user")
+
+	return user, nil
+}
+
+func (c *Controller) signupOAuthUser(
+	ctx context.Context,
+	sysCtrl *system.Controller,
+	provider *types.OAuthProvider,
+	externalUser *oauth.ExternalUser,
+) (*types.User, error) {
+	if !provider.AllowSignup {
+		return nil, usererror.Forbidden(fmt.Sprintf(
+			"No user is linked to your %s account.", provider.DisplayName))
+	}
+
+	signUpAllowed, err := sysCtrl.IsUserSignupAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !signUpAllowed {
+		return nil, usererror.Forbidden("User sign-up is disabled")
+	}
+
+	uid, err := c.generateExternalUserUID(ctx, externalUser.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(externalUser.DisplayName)
+	if check.DisplayName(displayName) != nil {
+		displayName = uid
+	}
+
+	// the user can't login with the password, but can set one later on.
+	user, err := c.CreateNoAuth(ctx, &CreateInput{
+		UID:         uid,
+		Email:       externalUser.Email,
+		DisplayName: displayName,
+		Password:    uniuri.NewLen(externalSignupPasswordLength),
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/saml"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+// BeginSAMLLogin returns the redirect of the user to the SAML identity provider.
+func (c *Controller) BeginSAMLLogin(ctx context.Context, providerIdentifier string) (*saml.AuthRedirect, error) {
+	return c.samlService.BeginLogin(ctx, providerIdentifier)
+}
+
+// LoginSAML finishes the login via a SAML provider and returns the session token.
+// The identity with the provider is linked to the user on the first login, either to the user
+// with the same email address or to a new user, if the provider allows signing up.
+// The provider is configured by an administrator, so the email addresses it asserts are trusted
+// and users are provisioned even if the sign-up of users is disabled.
+// The display name and the space memberships of the user are updated on every login.
+func (c *Controller) LoginSAML(
+	ctx context.Context,
+	providerIdentifier string,
+	samlResponse string,
+	requestID string,
+) (*types.TokenResponse, error) {
+	provider, externalUser, err := c.samlService.ParseResponse(ctx, providerIdentifier, samlResponse, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.findOrLinkSAMLUser(ctx, provider, externalUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkUserNotBlocked(user); err != nil {
+		return nil, err
+	}
+
+	if err = c.syncSAMLUser(ctx, user, externalUser); err != nil {
+		return nil, err
+	}
+
+	if err = c.samlService.SyncMemberships(ctx, user.ID, provider, externalUser); err != nil {
+		return nil, err
+	}
+
+	return c.createExternalSession(ctx, user, provider.TrustSecondFactor)
+}
+
+func (c *Controller) findOrLinkSAMLUser(
+	ctx context.Context,
+	provider *types.SAMLProvider,
+	externalUser *saml.ExternalUser,
+) (*types.User, error) {
+	identity, err := c.samlService.FindIdentity(ctx, provider, externalUser)
+	if err == nil {
+		return c.principalStore.FindUser(ctx, identity.PrincipalID)
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	if externalUser.Email == "" {
+		return nil, errors.Format(errors.StatusUnauthorized,
+			"%s didn't provide your email address.", provider.DisplayName)
+	}
+
+	user, err := c.findUserByExternalEmail(ctx, externalUser.Email)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		user, err = c.signupSAMLUser(ctx, provider, externalUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = c.samlService.Link(ctx, user.ID, provider, externalUser); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("principal_id", user.ID).
+		Str("saml_provider", provider.Identifier).
+		Str("name_id", externalUser.NameID).
+		Msg("linked saml identity to user")
+
+	return user, nil
+}
+
+func (c *Controller) signupSAMLUser(
+	ctx context.Context,
+	provider *types.SAMLProvider,
+	externalUser *saml.ExternalUser,
+) (*types.User, error) {
+	if !provider.AllowSignup {
+		return nil, usererror.Forbidden(fmt.Sprintf(
+			"No user is linked to your %s identity.", provider.DisplayName))
+	}
+
+	login, _, _ := strings.Cut(externalUser.Email, "@")
+	uid, err := c.generateExternalUserUID(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(externalUser.DisplayName)
+	if check.DisplayName(displayName) != nil {
+		displayName = uid
+	}
+
+	// the user can't login with the password, but can set one later on.
+	user, err := c.CreateNoAuth(ctx, &CreateInput{
+		UID:         uid,
+		Email:       externalUser.Email,
+		DisplayName: displayName,
+		Password:    uniuri.NewLen(externalSignupPasswordLength),
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// syncSAMLUser updates the display name of the user to the name asserted by the provider.
+func (c *Controller) syncSAMLUser(ctx context.Context, user *types.User, externalUser *saml.ExternalUser) error {
+	displayName := strings.TrimSpace(externalUser.DisplayName)
+	if displayName == "" || displayName == user.DisplayName || check.DisplayName(displayName) != nil {
+		return nil
+	}
+
+	user.DisplayName = displayName
+	user.Updated = time.Now().UnixMilli()
+
+	if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update display name of user: %w", err)
+	}
+
+	return nil
+}
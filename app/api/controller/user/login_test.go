@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/services/twofactor"
+	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+const (
+	loginUserID           = 1
+	loginTwoFactorUserID  = 2
+	loginWebAuthnUserID   = 3
+	loginTwoFactorEnabled = 100
+)
+
+type fakeTwoFactorStore struct {
+	appstore.UserTwoFactorStore
+}
+
+func (fakeTwoFactorStore) Find(_ context.Context, principalID int64) (*types.UserTwoFactor, error) {
+	if principalID != loginTwoFactorUserID {
+		return nil, store.ErrResourceNotFound
+	}
+	return &types.UserTwoFactor{PrincipalID: principalID, Enabled: ptr.Int64(loginTwoFactorEnabled)}, nil
+}
+
+type fakeWebAuthnCredentialStore struct {
+	appstore.WebAuthnCredentialStore
+}
+
+func (fakeWebAuthnCredentialStore) Count(_ context.Context, principalID int64) (int64, error) {
+	if principalID != loginWebAuthnUserID {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// fakeMembershipStore has no memberships, so two-factor authentication isn't required by any space.
+type fakeMembershipStore struct {
+	appstore.MembershipStore
+}
+
+func (fakeMembershipStore) ListSpaces(
+	context.Context,
+	int64,
+	types.MembershipSpaceFilter,
+) ([]types.MembershipSpace, error) {
+	return nil, nil
+}
+
+type fakeTokenStore struct {
+	appstore.TokenStore
+	created []*types.Token
+}
+
+func (s *fakeTokenStore) Create(_ context.Context, token *types.Token) error {
+	token.ID = int64(len(s.created) + 1)
+	s.created = append(s.created, token)
+	return nil
+}
+
+func TestController_CreateExternalSession(t *testing.T) {
+	tests := []struct {
+		name               string
+		userID             int64
+		trustSecondFactor  bool
+		twoFactorRequired  bool
+		wantRejected       bool
+		wantEnrollmentOnly bool
+	}{
+		{
+			name:   "without two-factor authentication",
+			userID: loginUserID,
+		},
+		{
+			name:         "totp enabled",
+			userID:       loginTwoFactorUserID,
+			wantRejected: true,
+		},
+		{
+			name:         "webauthn credential registered",
+			userID:       loginWebAuthnUserID,
+			wantRejected: true,
+		},
+		{
+			name:              "totp enabled with trusted provider",
+			userID:            loginTwoFactorUserID,
+			trustSecondFactor: true,
+		},
+		{
+			name:               "enrollment required",
+			userID:             loginUserID,
+			twoFactorRequired:  true,
+			wantEnrollmentOnly: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokenStore := &fakeTokenStore{}
+			c := &Controller{
+				tokenStore: tokenStore,
+				twoFactorService: twofactor.NewService(fakeTwoFactorStore{}, fakeWebAuthnCredentialStore{},
+					fakeMembershipStore{}, nil, nil, twofactor.Config{Required: test.twoFactorRequired}),
+			}
+
+			user := &types.User{ID: test.userID, UID: "user", Salt: "salt"}
+
+			resp, err := c.createExternalSession(context.Background(), user, test.trustSecondFactor)
+			if test.wantRejected {
+				if errors.AsStatus(err) != errors.StatusUnauthorized {
+					t.Errorf("expected unauthorized, got %v", err)
+				}
+				if len(tokenStore.created) > 0 {
+					t.Errorf("expected no session to be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.Token.Type != enum.TokenTypeSession || resp.AccessToken == "" {
+				t.Errorf("expected a session token, got %+v", resp.Token)
+			}
+
+			enrollmentOnly := slices.Equal(resp.Token.Scopes, []enum.TokenScope{enum.TokenScopeTwoFactorEnrollment})
+			if enrollmentOnly != test.wantEnrollmentOnly || resp.TwoFactorEnrollmentRequired != test.wantEnrollmentOnly {
+				t.Errorf("session = %+v, want restricted to the enrollment: %t", resp, test.wantEnrollmentOnly)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/text/language"
+)
+
+type UpdatePreferencesInput struct {
+	Timezone           *string                             `json:"timezone"`
+	Locale             *string                             `json:"locale"`
+	DefaultMergeMethod *enum.MergeMethod                   `json:"default_merge_method"`
+	DiffViewStyle      *enum.DiffViewStyle                 `json:"diff_view_style"`
+	Notifications      *UpdateNotificationPreferencesInput `json:"notifications"`
+}
+
+type UpdateNotificationPreferencesInput struct {
+	ReviewerAdded       *bool `json:"reviewer_added"`
+	CommentCreated      *bool `json:"comment_created"`
+	Mentions            *bool `json:"mentions"`
+	BranchUpdated       *bool `json:"branch_updated"`
+	ReviewSubmitted     *bool `json:"review_submitted"`
+	PullReqStateChanged *bool `json:"pullreq_state_changed"`
+	ReviewSLA           *bool `json:"review_sla"`
+}
+
+// FindPreferences returns the preferences of the user.
+func (c *Controller) FindPreferences(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.UserPreferences, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.userPreferencesStore.Find(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// UpdatePreferences updates the provided preferences of the user, the others are left unchanged.
+func (c *Controller) UpdatePreferences(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *UpdatePreferencesInput,
+) (*types.UserPreferences, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.userPreferencesStore.Find(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user preferences: %w", err)
+	}
+
+	in.apply(prefs)
+	prefs.Updated = time.Now().UnixMilli()
+
+	if err = c.userPreferencesStore.Upsert(ctx, user.ID, prefs); err != nil {
+		return nil, fmt.Errorf("failed to store user preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (in *UpdatePreferencesInput) sanitize() error {
+	if in.Timezone != nil {
+		*in.Timezone = strings.TrimSpace(*in.Timezone)
+		if _, err := time.LoadLocation(*in.Timezone); err != nil || *in.Timezone == "" || *in.Timezone == "Local" {
+			return errors.InvalidArgument("Unknown timezone %q", *in.Timezone)
+		}
+	}
+
+	if in.Locale != nil {
+		tag, err := language.Parse(strings.TrimSpace(*in.Locale))
+		if err != nil {
+			return errors.InvalidArgument("Invalid locale %q", *in.Locale)
+		}
+		*in.Locale = tag.String()
+	}
+
+	if in.DefaultMergeMethod != nil && *in.DefaultMergeMethod != "" {
+		method, ok := in.DefaultMergeMethod.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("Unsupported merge method %q", *in.DefaultMergeMethod)
+		}
+		*in.DefaultMergeMethod = method
+	}
+
+	if in.DiffViewStyle != nil {
+		style, ok := in.DiffViewStyle.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("Unsupported diff view style %q", *in.DiffViewStyle)
+		}
+		*in.DiffViewStyle = style
+	}
+
+	return nil
+}
+
+func (in *UpdatePreferencesInput) apply(prefs *types.UserPreferences) {
+	setIfProvided(&prefs.Timezone, in.Timezone)
+	setIfProvided(&prefs.Locale, in.Locale)
+	setIfProvided(&prefs.DefaultMergeMethod, in.DefaultMergeMethod)
+	setIfProvided(&prefs.DiffViewStyle, in.DiffViewStyle)
+
+	if n := in.Notifications; n != nil {
+		setIfProvided(&prefs.Notifications.ReviewerAdded, n.ReviewerAdded)
+		setIfProvided(&prefs.Notifications.CommentCreated, n.CommentCreated)
+		setIfProvided(&prefs.Notifications.Mentions, n.Mentions)
+		setIfProvided(&prefs.Notifications.BranchUpdated, n.BranchUpdated)
+		setIfProvided(&prefs.Notifications.ReviewSubmitted, n.ReviewSubmitted)
+		setIfProvided(&prefs.Notifications.PullReqStateChanged, n.PullReqStateChanged)
+		setIfProvided(&prefs.Notifications.ReviewSLA, n.ReviewSLA)
+	}
+}
+
+func setIfProvided[T any](dst *T, value *T) {
+	if value != nil {
+		*dst = *value
+	}
+}
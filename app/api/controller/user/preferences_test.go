@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestUpdatePreferencesInput_Sanitize(t *testing.T) {
+	mergeMethod := func(m enum.MergeMethod) *enum.MergeMethod { return &m }
+	diffViewStyle := func(s enum.DiffViewStyle) *enum.DiffViewStyle { return &s }
+
+	tests := []struct {
+		name       string
+		in         UpdatePreferencesInput
+		wantErr    bool
+		wantLocale string
+	}{
+		{
+			name: "nothing provided",
+		},
+		{
+			name: "valid timezone",
+			in:   UpdatePreferencesInput{Timezone: ptr.String(" Europe/Berlin ")},
+		},
+		{
+			name:    "empty timezone",
+			in:      UpdatePreferencesInput{Timezone: ptr.String(" ")},
+			wantErr: true,
+		},
+		{
+			name:    "local timezone",
+			in:      UpdatePreferencesInput{Timezone: ptr.String("Local")},
+			wantErr: true,
+		},
+		{
+			name:    "unknown timezone",
+			in:      UpdatePreferencesInput{Timezone: ptr.String("Mars/Base")},
+			wantErr: true,
+		},
+		{
+			name:       "locale is normalized",
+			in:         UpdatePreferencesInput{Locale: ptr.String("en-us")},
+			wantLocale: "en-US",
+		},
+		{
+			name:    "invalid locale",
+			in:      UpdatePreferencesInput{Locale: ptr.String("not a locale")},
+			wantErr: true,
+		},
+		{
+			name: "no default merge method",
+			in:   UpdatePreferencesInput{DefaultMergeMethod: mergeMethod("")},
+		},
+		{
+			name:    "unsupported merge method",
+			in:      UpdatePreferencesInput{DefaultMergeMethod: mergeMethod("octopus")},
+			wantErr: true,
+		},
+		{
+			name: "supported diff view style",
+			in:   UpdatePreferencesInput{DiffViewStyle: diffViewStyle(enum.DiffViewStyleSplit)},
+		},
+		{
+			name:    "unsupported diff view style",
+			in:      UpdatePreferencesInput{DiffViewStyle: diffViewStyle("inline")},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.in.sanitize()
+			if test.wantErr {
+				if !errors.IsInvalidArgument(err) {
+					t.Errorf("expected invalid argument, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.in.Timezone != nil && *test.in.Timezone != "Europe/Berlin" {
+				t.Errorf("timezone = %q, want Europe/Berlin", *test.in.Timezone)
+			}
+			if test.wantLocale != "" && *test.in.Locale != test.wantLocale {
+				t.Errorf("locale = %q, want %q", *test.in.Locale, test.wantLocale)
+			}
+		})
+	}
+}
+
+func TestUpdatePreferencesInput_Apply(t *testing.T) {
+	prefs := types.DefaultUserPreferences()
+
+	in := &UpdatePreferencesInput{
+		Timezone: ptr.String("Europe/Berlin"),
+		Notifications: &UpdateNotificationPreferencesInput{
+			CommentCreated: ptr.Bool(false),
+		},
+	}
+	in.apply(&prefs)
+
+	want := types.DefaultUserPreferences()
+	want.Timezone = "Europe/Berlin"
+	want.Notifications.CommentCreated = false
+
+	if prefs != want {
+		t.Errorf("preferences = %+v, want %+v", prefs, want)
+	}
+}
@@ -30,9 +30,13 @@ import (
 )
 
 type CreatePublicKeyInput struct {
-	Identifier string              `json:"identifier"`
-	Usage      enum.PublicKeyUsage `json:"usage"`
-	Content    string              `json:"content"`
+	Identifier string               `json:"identifier"`
+	Usage      enum.PublicKeyUsage  `json:"usage"`
+	Scheme     enum.PublicKeyScheme `json:"scheme"`
+	Content    string               `json:"content"`
+
+	// ValidTo is the optional expiration time of SSH keys. Expiration of PGP keys is defined by the key itself.
+	ValidTo *int64 `json:"valid_to"`
 }
 
 func (c *Controller) CreatePublicKey(
@@ -50,27 +54,67 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
-	if err := sanitizeCreatePublicKeyInput(in); err != nil {
-		return nil, err
-	}
+	now := time.Now().UnixMilli()
 
-	key, comment, err := publickey.ParseString(in.Content)
-	if err != nil {
-		return nil, errors.InvalidArgument("could not parse public key")
+	if err := sanitizeCreatePublicKeyInput(in, now); err != nil {
+		return nil, err
 	}
 
-	now := time.Now().UnixMilli()
-
 	k := &types.PublicKey{
 		PrincipalID: user.ID,
 		Created:     now,
 		Verified:    nil, // the key is created as unverified
 		Identifier:  in.Identifier,
 		Usage:       in.Usage,
-		Fingerprint: key.Fingerprint(),
 		Content:     in.Content,
-		Comment:     comment,
-		Type:        key.Type(),
+		Scheme:      in.Scheme,
+	}
+
+	var matches func(existing *types.PublicKey) bool
+
+	switch in.Scheme {
+	case enum.PublicKeySchemeSSH:
+		key, comment, err := publickey.ParseString(in.Content)
+		if err != nil {
+			return nil, errors.InvalidArgument("could not parse public key")
+		}
+
+		k.Fingerprint = key.Fingerprint()
+		k.Comment = comment
+		k.Type = key.Type()
+		k.ValidTo = in.ValidTo
+
+		matches = func(existing *types.PublicKey) bool {
+			return existing.Scheme == enum.PublicKeySchemeSSH && key.Matches(existing.Content)
+		}
+	case enum.PublicKeySchemePGP:
+		key, comment, err := publickey.ParsePGP(in.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.Revoked {
+			return nil, errors.InvalidArgument("PGP key is revoked")
+		}
+
+		validFrom := key.ValidFrom.UnixMilli()
+
+		k.Fingerprint = key.Fingerprint()
+		k.Comment = comment
+		k.Type = key.Type()
+		k.ValidFrom = &validFrom
+
+		if key.ValidTo != nil {
+			validTo := key.ValidTo.UnixMilli()
+			if validTo < now {
+				return nil, errors.InvalidArgument("PGP key has expired")
+			}
+			k.ValidTo = &validTo
+		}
+
+		matches = func(existing *types.PublicKey) bool {
+			return existing.Scheme == enum.PublicKeySchemePGP
+		}
 	}
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
@@ -79,8 +123,8 @@ func (c *Controller) CreatePublicKey(
 			return fmt.Errorf("failed to read keys by fingerprint: %w", err)
 		}
 
-		for _, existingKey := range existingKeys {
-			if key.Matches(existingKey.Content) {
+		for i := range existingKeys {
+			if matches(&existingKeys[i]) {
 				return errors.InvalidArgument("Key is already in use")
 			}
 		}
@@ -99,7 +143,7 @@ func (c *Controller) CreatePublicKey(
 	return k, nil
 }
 
-func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
+func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput, now int64) error {
 	if err := check.Identifier(in.Identifier); err != nil {
 		return err
 	}
@@ -110,6 +154,25 @@ func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
 	}
 	in.Usage = usage
 
+	scheme, ok := in.Scheme.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("invalid value for public key scheme")
+	}
+	in.Scheme = scheme
+
+	if in.Scheme == enum.PublicKeySchemePGP {
+		if in.Usage != enum.PublicKeyUsageSign {
+			return errors.InvalidArgument("PGP keys can only be used for signing")
+		}
+		if in.ValidTo != nil {
+			return errors.InvalidArgument("expiration of PGP keys is defined by the key")
+		}
+	}
+
+	if in.ValidTo != nil && *in.ValidTo <= now {
+		return errors.InvalidArgument("expiration time of the key must be in the future")
+	}
+
 	in.Content = strings.TrimSpace(in.Content)
 	if in.Content == "" {
 		return errors.InvalidArgument("public key not provided")
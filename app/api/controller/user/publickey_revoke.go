@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RevokePublicKey marks the public key as revoked. A revoked key can't be used for authentication
+// and the commits signed with it are no longer reported as verified.
+func (c *Controller) RevokePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	identifier string,
+) (*types.PublicKey, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user by uid: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	key, err := c.publicKeyStore.FindByIdentifier(ctx, user.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public key by identifier: %w", err)
+	}
+
+	if key.Revoked != nil {
+		return key, nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	err = c.publicKeyStore.Revoke(ctx, key.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke public key: %w", err)
+	}
+
+	key.Revoked = &now
+
+	return key, nil
+}
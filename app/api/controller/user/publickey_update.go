@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxPublicKeyCommentLength = 255
+
+type UpdatePublicKeyInput struct {
+	Identifier *string `json:"identifier"`
+	Comment    *string `json:"comment"`
+}
+
+// UpdatePublicKey updates the identifier and the comment of the public key.
+// The key content and its usage can't be changed, a new key should be created instead.
+func (c *Controller) UpdatePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	identifier string,
+	in *UpdatePublicKeyInput,
+) (*types.PublicKey, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user by uid: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeUpdatePublicKeyInput(in); err != nil {
+		return nil, err
+	}
+
+	key, err := c.publicKeyStore.FindByIdentifier(ctx, user.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public key by identifier: %w", err)
+	}
+
+	if in.Identifier != nil {
+		key.Identifier = *in.Identifier
+	}
+	if in.Comment != nil {
+		key.Comment = *in.Comment
+	}
+
+	err = c.publicKeyStore.Update(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update public key: %w", err)
+	}
+
+	return key, nil
+}
+
+func sanitizeUpdatePublicKeyInput(in *UpdatePublicKeyInput) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.Comment != nil {
+		comment := strings.TrimSpace(*in.Comment)
+		if len(comment) > maxPublicKeyCommentLength {
+			return errors.InvalidArgument("public key comment can't be longer than %d characters",
+				maxPublicKeyCommentLength)
+		}
+		in.Comment = &comment
+	}
+
+	return nil
+}
@@ -20,7 +20,6 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/usererror"
-	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 )
 
@@ -55,10 +54,10 @@ func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 	}
 
 	// TODO: how should we name session tokens?
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, "register")
+	tokenResponse, err := c.createSession(ctx, user, "register")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token after successful user creation: %w", err)
 	}
 
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	return tokenResponse, nil
 }
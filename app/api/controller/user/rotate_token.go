@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/tokenpolicy"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RotateTokenInput struct {
+	// Lifetime is the lifetime of the new secret, the lifetime the token was issued with is used if empty.
+	Lifetime *time.Duration `json:"lifetime"`
+	// OTP is the TOTP or recovery code, required if the user has two-factor authentication enabled.
+	OTP string `json:"otp"`
+	// WebAuthn is a WebAuthn assertion that can be used as second factor instead of the OTP.
+	WebAuthn *passkey.AssertionInput `json:"webauthn"`
+}
+
+// RotateToken replaces the secret of a personal access token of a user.
+// The previous secret is still accepted for the rotation grace period.
+func (c *Controller) RotateToken(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	tokenIdentifier string,
+	in *RotateTokenInput,
+) (*types.TokenResponse, error) {
+	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
+		return nil, err
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	tkn, err := c.tokenStore.FindByIdentifier(ctx, user.ID, tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// only personal access tokens can be rotated, session tokens are replaced by logging in again.
+	if tkn.Type != enum.TokenTypePAT || tkn.PrincipalID != user.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	lifetime := in.Lifetime
+	if lifetime == nil {
+		lifetime = token.Lifetime(tkn)
+	}
+
+	maxLifetime, err := c.tokenPolicyService.MaxUserLifetime(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maximum token lifetime: %w", err)
+	}
+
+	if err = tokenpolicy.CheckLifetime(maxLifetime, lifetime); err != nil {
+		return nil, err
+	}
+
+	// the second factor is only checked if users rotate their own tokens.
+	if session.Principal.ID == user.ID {
+		if err = c.checkTwoFactor(ctx, user.ID, in.OTP, in.WebAuthn); err != nil {
+			return nil, err
+		}
+	}
+
+	tkn, jwtToken, err := token.Rotate(
+		ctx,
+		c.tokenStore,
+		tkn,
+		user.ToPrincipal(),
+		lifetime,
+		c.tokenPolicyService.GracePeriod(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/twofactor"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type TwoFactorCodeInput struct {
+	// Code is a TOTP code or, except for the enrollment confirmation, a recovery code.
+	Code string `json:"code"`
+}
+
+// TwoFactorStatus returns the two-factor authentication status of the user.
+func (c *Controller) TwoFactorStatus(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.TwoFactorStatus, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.twoFactorService.Status(ctx, user.ID)
+}
+
+// TwoFactorEnroll starts the TOTP enrollment of the user.
+func (c *Controller) TwoFactorEnroll(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.TwoFactorEnrollment, error) {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.twoFactorService.Enroll(ctx, user)
+}
+
+// TwoFactorConfirm finishes the TOTP enrollment of the user and returns the recovery codes.
+func (c *Controller) TwoFactorConfirm(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *TwoFactorCodeInput,
+) (*types.TwoFactorRecoveryCodes, error) {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.twoFactorService.Confirm(ctx, user.ID, in.Code)
+}
+
+// TwoFactorDisable turns off two-factor authentication of the user.
+func (c *Controller) TwoFactorDisable(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *TwoFactorCodeInput,
+) error {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return err
+	}
+
+	return c.twoFactorService.Disable(ctx, user.ID, in.Code)
+}
+
+// TwoFactorRegenerateRecoveryCodes replaces the recovery codes of the user.
+func (c *Controller) TwoFactorRegenerateRecoveryCodes(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *TwoFactorCodeInput,
+) (*types.TwoFactorRecoveryCodes, error) {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.twoFactorService.RegenerateRecoveryCodes(ctx, user.ID, in.Code)
+}
+
+// findSelfForTwoFactor returns the user after ensuring the session has edit permission on it.
+func (c *Controller) findSelfForTwoFactor(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// checkTwoFactor verifies the second factor of the user. Users for which two-factor authentication
+// is required, but who didn't enable it yet, are rejected.
+func (c *Controller) checkTwoFactor(
+	ctx context.Context,
+	principalID int64,
+	code string,
+	assertion *passkey.AssertionInput,
+) error {
+	enrollmentRequired, err := c.twoFactorEnrollmentRequired(ctx, principalID)
+	if err != nil {
+		return err
+	}
+	if enrollmentRequired {
+		return twofactor.ErrEnrollmentRequired
+	}
+
+	return c.verifySecondFactor(ctx, principalID, code, assertion)
+}
+
+// verifySecondFactor verifies the WebAuthn assertion if provided, the TOTP or recovery code otherwise.
+// It returns nil for users without two-factor authentication.
+func (c *Controller) verifySecondFactor(
+	ctx context.Context,
+	principalID int64,
+	code string,
+	assertion *passkey.AssertionInput,
+) error {
+	if assertion != nil {
+		return c.passkeyService.VerifySecondFactor(ctx, principalID, assertion)
+	}
+
+	return c.twoFactorService.Verify(ctx, principalID, code)
+}
+
+// twoFactorEnrollmentRequired returns true if two-factor authentication is required for the user,
+// but isn't enabled yet.
+func (c *Controller) twoFactorEnrollmentRequired(ctx context.Context, principalID int64) (bool, error) {
+	enabled, err := c.twoFactorService.IsEnabled(ctx, principalID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check two-factor state: %w", err)
+	}
+	if enabled {
+		return false, nil
+	}
+
+	required, err := c.twoFactorService.IsRequired(ctx, principalID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if two-factor authentication is required: %w", err)
+	}
+
+	return required, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LoginWebAuthnBeginInput struct {
+	// LoginIdentifier limits the allowed credentials to the ones of the user.
+	// Without it, the authenticator offers its discoverable credentials (passkeys).
+	LoginIdentifier string `json:"login_identifier"`
+	// SecondFactor is set if the assertion is used as second factor of a password login.
+	SecondFactor bool `json:"second_factor"`
+}
+
+// ListWebAuthnCredentials returns the WebAuthn credentials of the user.
+func (c *Controller) ListWebAuthnCredentials(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.WebAuthnCredential, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.passkeyService.List(ctx, user.ID)
+}
+
+// BeginWebAuthnRegistration starts the registration of a WebAuthn credential.
+func (c *Controller) BeginWebAuthnRegistration(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*passkey.RegistrationChallenge, error) {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.passkeyService.BeginRegistration(ctx, user)
+}
+
+// FinishWebAuthnRegistration verifies and stores a new WebAuthn credential.
+func (c *Controller) FinishWebAuthnRegistration(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *passkey.RegistrationInput,
+) (*types.WebAuthnCredential, error) {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.passkeyService.FinishRegistration(ctx, user, in)
+}
+
+// UpdateWebAuthnCredential renames a WebAuthn credential.
+func (c *Controller) UpdateWebAuthnCredential(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+	in *types.WebAuthnCredentialUpdateInput,
+) (*types.WebAuthnCredential, error) {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.passkeyService.Rename(ctx, user.ID, id, in)
+}
+
+// RevokeWebAuthnCredential deletes a WebAuthn credential.
+func (c *Controller) RevokeWebAuthnCredential(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+) error {
+	user, err := c.findSelfForTwoFactor(ctx, session, userUID)
+	if err != nil {
+		return err
+	}
+
+	return c.passkeyService.Revoke(ctx, user.ID, id)
+}
+
+// LoginWebAuthnBegin starts a WebAuthn authentication, either for a passwordless login
+// or as second factor of a password login.
+func (c *Controller) LoginWebAuthnBegin(
+	ctx context.Context,
+	in *LoginWebAuthnBeginInput,
+) (*passkey.LoginChallenge, error) {
+	var user *types.User
+
+	if in.LoginIdentifier != "" {
+		var err error
+		user, err = findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
+		}
+
+		// unknown users get a challenge for discoverable credentials, to not reveal which users exist.
+		if errors.Is(err, store.ErrResourceNotFound) {
+			user = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+	}
+
+	return c.passkeyService.BeginLogin(ctx, user, !in.SecondFactor)
+}
+
+// LoginWebAuthn logs in the user with a WebAuthn credential without a password.
+func (c *Controller) LoginWebAuthn(
+	ctx context.Context,
+	in *passkey.AssertionInput,
+) (*types.TokenResponse, error) {
+	credential, err := c.passkeyService.FinishLogin(ctx, in, true)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUser(ctx, credential.PrincipalID)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Int64("principal_id", credential.PrincipalID).
+			Msg("failed to find user of webauthn credential")
+		return nil, usererror.ErrNotFound
+	}
+
+	if err = checkUserNotBlocked(user); err != nil {
+		return nil, err
+	}
+
+	tokenIdentifier, err := GenerateSessionTokenIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
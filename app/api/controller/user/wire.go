@@ -16,7 +16,16 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/saml"
+	"github.com/harness/gitness/app/services/tokenpolicy"
+	"github.com/harness/gitness/app/services/twofactor"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
 
@@ -36,6 +45,17 @@ func ProvideController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	userEmailStore store.UserEmailStore,
+	userPreferencesStore store.UserPreferencesStore,
+	notificationClient notification.Client,
+	urlProvider url.Provider,
+	avatarService *avatar.Service,
+	twoFactorService *twofactor.Service,
+	passkeyService *passkey.Service,
+	oauthService *oauth.Service,
+	samlService *saml.Service,
+	ldapService *ldap.Service,
+	tokenPolicyService *tokenpolicy.Service,
 ) *Controller {
 	return NewController(
 		tx,
@@ -44,5 +64,16 @@ func ProvideController(
 		principalStore,
 		tokenStore,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		userEmailStore,
+		userPreferencesStore,
+		notificationClient,
+		urlProvider,
+		avatarService,
+		twoFactorService,
+		passkeyService,
+		oauthService,
+		samlService,
+		ldapService,
+		tokenPolicyService)
 }
@@ -15,16 +15,32 @@
 package usergroup
 
 import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/role"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
-	userGroupStore store.UserGroupStore
-	spaceStore     store.SpaceStore
-	authorizer     authz.Authorizer
-	searchSvc      usergroup.SearchService
+	userGroupStore  store.UserGroupStore
+	spaceStore      store.SpaceStore
+	authorizer      authz.Authorizer
+	searchSvc       usergroup.SearchService
+	userGroupSvc    *usergroup.Service
+	memberStore     store.UserGroupMemberStore
+	subgroupStore   store.UserGroupSubgroupStore
+	membershipStore store.UserGroupMembershipStore
+	principalStore  store.PrincipalStore
+	roleSvc         *role.Service
+	auditService    audit.Service
 }
 
 func NewController(
@@ -32,11 +48,64 @@ func NewController(
 	spaceStore store.SpaceStore,
 	authorizer authz.Authorizer,
 	searchSvc usergroup.SearchService,
+	userGroupSvc *usergroup.Service,
+	memberStore store.UserGroupMemberStore,
+	subgroupStore store.UserGroupSubgroupStore,
+	membershipStore store.UserGroupMembershipStore,
+	principalStore store.PrincipalStore,
+	roleSvc *role.Service,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
-		userGroupStore: userGroupStore,
-		spaceStore:     spaceStore,
-		authorizer:     authorizer,
-		searchSvc:      searchSvc,
+		userGroupStore:  userGroupStore,
+		spaceStore:      spaceStore,
+		authorizer:      authorizer,
+		searchSvc:       searchSvc,
+		userGroupSvc:    userGroupSvc,
+		memberStore:     memberStore,
+		subgroupStore:   subgroupStore,
+		membershipStore: membershipStore,
+		principalStore:  principalStore,
+		roleSvc:         roleSvc,
+		auditService:    auditService,
+	}
+}
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
 	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission); err != nil {
+		return nil, err
+	}
+
+	return space, nil
+}
+
+// getUserGroupCheckAccess returns the usergroup defined in the space after checking access to the space.
+func (c *Controller) getUserGroupCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.Space, *types.UserGroup, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, permission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userGroup, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find usergroup: %w", err)
+	}
+
+	return space, userGroup, nil
 }
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MemberAddInput struct {
+	UserUID string `json:"user_uid"`
+}
+
+// MemberList lists the direct members of a usergroup, members of nested usergroups aren't included.
+func (c *Controller) MemberList(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) ([]types.UserGroupMemberUser, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.memberStore.ListUsers(ctx, userGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroup members: %w", err)
+	}
+
+	return members, nil
+}
+
+// MemberAdd adds a user to a usergroup.
+func (c *Controller) MemberAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *MemberAddInput,
+) (*types.UserGroupMemberUser, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.UserUID == "" {
+		return nil, usererror.BadRequest("UserUID must be provided")
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	member := types.UserGroupMember{
+		UserGroupID: userGroup.ID,
+		PrincipalID: user.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.memberStore.Create(ctx, &member)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("User '%s' is already a member of the usergroup.", in.UserUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add usergroup member: %w", err)
+	}
+
+	return &types.UserGroupMemberUser{
+		UserGroupMember: member,
+		Principal:       *user.ToPrincipalInfo(),
+		AddedBy:         *session.Principal.ToPrincipalInfo(),
+	}, nil
+}
+
+// MemberDelete removes a user from a usergroup.
+func (c *Controller) MemberDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	userUID string,
+) error {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	if err = c.memberStore.Delete(ctx, userGroup.ID, user.ID); err != nil {
+		return fmt.Errorf("failed to delete usergroup member: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MembershipAddInput struct {
+	UserGroupID int64               `json:"usergroup_id"`
+	Role        enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipAddInput) Validate() error {
+	if in.UserGroupID <= 0 {
+		return usererror.BadRequest("Usergroup ID must be provided")
+	}
+
+	if in.Role == "" {
+		return usererror.BadRequest("Role must be provided")
+	}
+
+	return nil
+}
+
+type MembershipUpdateInput struct {
+	Role enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipUpdateInput) Validate() error {
+	if in.Role == "" {
+		return usererror.BadRequest("Role must be provided")
+	}
+
+	return nil
+}
+
+// MembershipList lists all usergroups that are granted a role in the space.
+func (c *Controller) MembershipList(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]types.UserGroupMembershipInfo, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := c.membershipStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroup memberships: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// MembershipAdd grants a role in the space to all members of a usergroup.
+// The usergroup must be defined in the space or in one of its parent spaces.
+func (c *Controller) MembershipAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *MembershipAddInput,
+) (*types.UserGroupMembershipInfo, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	// the role can be one of the predefined roles or a custom role.
+	in.Role, err = c.roleSvc.Sanitize(ctx, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.findVisibleUserGroup(ctx, space, in.UserGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{
+			SpaceID:     space.ID,
+			UserGroupID: userGroup.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      in.Role,
+	}
+
+	err = c.membershipStore.Create(ctx, &membership)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Usergroup '%s' is already a member of the space.", userGroup.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new usergroup membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUserGroupMembership, userGroup.Identifier),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add usergroup membership operation: %s", err)
+	}
+
+	return &types.UserGroupMembershipInfo{
+		UserGroupMembership: membership,
+		UserGroup:           *userGroup.ToUserGroupInfo(),
+		AddedBy:             *session.Principal.ToPrincipalInfo(),
+	}, nil
+}
+
+// MembershipUpdate changes the role a usergroup has in the space.
+func (c *Controller) MembershipUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupID int64,
+	in *MembershipUpdateInput,
+) (*types.UserGroupMembership, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	in.Role, err = c.roleSvc.Sanitize(ctx, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usergroup: %w", err)
+	}
+
+	membership, err := c.membershipStore.Find(ctx, types.UserGroupMembershipKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroup.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usergroup membership: %w", err)
+	}
+
+	if membership.Role == in.Role {
+		return membership, nil
+	}
+
+	oldMembership := *membership
+	membership.Role = in.Role
+
+	if err = c.membershipStore.Update(ctx, membership); err != nil {
+		return nil, fmt.Errorf("failed to update usergroup membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUserGroupMembership, userGroup.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(*membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update usergroup membership operation: %s", err)
+	}
+
+	return membership, nil
+}
+
+// MembershipDelete revokes the role a usergroup has in the space.
+func (c *Controller) MembershipDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupID int64,
+) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to find usergroup: %w", err)
+	}
+
+	key := types.UserGroupMembershipKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroup.ID,
+	}
+
+	membership, err := c.membershipStore.Find(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to find usergroup membership: %w", err)
+	}
+
+	if err = c.membershipStore.Delete(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete usergroup membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUserGroupMembership, userGroup.Identifier),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(*membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete usergroup membership operation: %s", err)
+	}
+
+	return nil
+}
+
+// findVisibleUserGroup returns the usergroup if it's defined in the space or in one of its parent spaces.
+func (c *Controller) findVisibleUserGroup(
+	ctx context.Context,
+	space *types.Space,
+	userGroupID int64,
+) (*types.UserGroup, error) {
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Usergroup %d not found", userGroupID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the usergroup: %w", err)
+	}
+
+	spaceIDs, err := c.spaceStore.GetAncestorIDs(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent space IDs: %w", err)
+	}
+
+	if !slices.Contains(spaceIDs, userGroup.SpaceID) {
+		return nil, usererror.BadRequestf("Usergroup %d isn't defined in the space or its parent spaces", userGroupID)
+	}
+
+	return userGroup, nil
+}
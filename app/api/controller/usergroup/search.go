@@ -24,7 +24,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-func (c Controller) List(
+func (c *Controller) List(
 	ctx context.Context,
 	session *auth.Session,
 	filter *types.ListQueryFilter,
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SubgroupAddInput struct {
+	Identifier string `json:"identifier"`
+}
+
+// SubgroupList lists the usergroups directly nested in a usergroup.
+func (c *Controller) SubgroupList(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) ([]*types.UserGroupInfo, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	childIDs, err := c.subgroupStore.ListChildIDs(ctx, []int64{userGroup.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subgroup IDs: %w", err)
+	}
+
+	children, err := c.userGroupStore.FindManyByIDs(ctx, childIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subgroups: %w", err)
+	}
+
+	infos := make([]*types.UserGroupInfo, len(children))
+	for i, child := range children {
+		infos[i] = child.ToUserGroupInfo()
+	}
+
+	return infos, nil
+}
+
+// SubgroupAdd nests a usergroup of the same space in a usergroup.
+// All members of the nested usergroup become members of the parent usergroup.
+func (c *Controller) SubgroupAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *SubgroupAddInput,
+) (*types.UserGroupInfo, error) {
+	space, parent, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Identifier == "" {
+		return nil, usererror.BadRequest("Identifier must be provided")
+	}
+
+	child, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, in.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Usergroup '%s' not found in the space", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the usergroup: %w", err)
+	}
+
+	if child.ID == parent.ID {
+		return nil, usererror.BadRequest("A usergroup can't be nested in itself")
+	}
+
+	// the parent must not be nested, directly or indirectly, in the child, otherwise we'd create a cycle.
+	childGroupIDs, err := c.userGroupSvc.ExpandSubgroups(ctx, []int64{child.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand subgroups of the usergroup: %w", err)
+	}
+	if slices.Contains(childGroupIDs, parent.ID) {
+		return nil, usererror.BadRequestf("Usergroup '%s' already contains usergroup '%s'",
+			child.Identifier, parent.Identifier)
+	}
+
+	subgroup := &types.UserGroupSubgroup{
+		ParentID:  parent.ID,
+		ChildID:   child.ID,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	err = c.subgroupStore.Create(ctx, subgroup)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Usergroup '%s' is already nested in the usergroup.", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to nest the usergroup: %w", err)
+	}
+
+	return child.ToUserGroupInfo(), nil
+}
+
+// SubgroupDelete removes a nested usergroup from a usergroup.
+func (c *Controller) SubgroupDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	subgroupIdentifier string,
+) error {
+	space, parent, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	child, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, subgroupIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to find the nested usergroup: %w", err)
+	}
+
+	if err = c.subgroupStore.Delete(ctx, parent.ID, child.ID); err != nil {
+		return fmt.Errorf("failed to delete the nested usergroup: %w", err)
+	}
+
+	return nil
+}
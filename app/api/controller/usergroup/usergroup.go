@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier  string `json:"identifier"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.Name = strings.TrimSpace(in.Name)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.Name == "" {
+		in.Name = in.Identifier
+	}
+	if err := check.DisplayName(in.Name); err != nil {
+		return err
+	}
+
+	return check.Description(in.Description)
+}
+
+type UpdateInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Name != nil {
+		*in.Name = strings.TrimSpace(*in.Name)
+		if err := check.DisplayName(*in.Name); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Create creates a new usergroup in the space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.UserGroup, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	userGroup := &types.UserGroup{
+		Identifier:  in.Identifier,
+		Name:        in.Name,
+		Description: in.Description,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.userGroupStore.Create(ctx, space.ID, userGroup)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Usergroup '%s' already exists in the space.", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create usergroup: %w", err)
+	}
+
+	return userGroup, nil
+}
+
+// Find returns a usergroup of the space.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) (*types.UserGroup, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return userGroup, nil
+}
+
+// Update updates the name and the description of a usergroup.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.UserGroup, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.Name != nil {
+		userGroup.Name = *in.Name
+	}
+	if in.Description != nil {
+		userGroup.Description = *in.Description
+	}
+	userGroup.Updated = time.Now().UnixMilli()
+
+	if err = c.userGroupStore.Update(ctx, userGroup); err != nil {
+		return nil, fmt.Errorf("failed to update usergroup: %w", err)
+	}
+
+	return userGroup, nil
+}
+
+// Delete deletes a usergroup. Its members lose all permissions granted to the usergroup,
+// and the usergroup is removed from all usergroups it is nested in and from pull request reviewers.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.userGroupStore.Delete(ctx, userGroup.ID); err != nil {
+		return fmt.Errorf("failed to delete usergroup: %w", err)
+	}
+
+	return nil
+}
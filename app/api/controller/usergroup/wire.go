@@ -16,8 +16,10 @@ package usergroup
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/role"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 
 	"github.com/google/wire"
 )
@@ -32,6 +34,14 @@ func ProvideController(
 	spaceStore store.SpaceStore,
 	authorizer authz.Authorizer,
 	searchSvc usergroup.SearchService,
+	userGroupSvc *usergroup.Service,
+	memberStore store.UserGroupMemberStore,
+	subgroupStore store.UserGroupSubgroupStore,
+	membershipStore store.UserGroupMembershipStore,
+	principalStore store.PrincipalStore,
+	roleSvc *role.Service,
+	auditService audit.Service,
 ) *Controller {
-	return NewController(userGroupStore, spaceStore, authorizer, searchSvc)
+	return NewController(userGroupStore, spaceStore, authorizer, searchSvc, userGroupSvc,
+		memberStore, subgroupStore, membershipStore, principalStore, roleSvc, auditService)
 }
@@ -18,11 +18,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // createRPCWriteParams creates base write parameters for git write operations.
@@ -123,10 +125,29 @@ func MapCommit(c *git.Commit) (*types.Commit, error) {
 			Author:     *author,
 			Committer:  *committer,
 			Stats:      mapStats(c),
+
+			CoAuthors:       mapIdentities(c.CoAuthors),
+			SignatureStatus: enum.CommitSignatureStatus(c.SignatureStatus),
 		},
 		nil
 }
 
+func mapIdentities(ids []git.Identity) []types.Identity {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	identities := make([]types.Identity, len(ids))
+	for i := range ids {
+		identities[i] = types.Identity{
+			Name:  ids[i].Name,
+			Email: ids[i].Email,
+		}
+	}
+
+	return identities
+}
+
 func mapStats(c *git.Commit) *types.CommitStats {
 	if len(c.FileStats) == 0 {
 		return nil
@@ -193,3 +214,34 @@ func MapSignature(s *git.Signature) (*types.Signature, error) {
 		When: s.When,
 	}, nil
 }
+
+func MapCherryPickCommits(commits []git.CherryPickCommit) []types.CherryPickCommit {
+	result := make([]types.CherryPickCommit, len(commits))
+	for i, commit := range commits {
+		result[i] = types.CherryPickCommit{
+			SHA:           commit.SHA,
+			Status:        enum.CherryPickStatus(commit.Status),
+			ConflictFiles: commit.ConflictFiles,
+		}
+		if !commit.NewSHA.IsEmpty() {
+			newSHA := commit.NewSHA
+			result[i].NewSHA = &newSHA
+		}
+	}
+	return result
+}
+
+// CheckRepoNotArchived returns an error in case the permission would allow the modification of an archived repo.
+// Archived repos can still be read and deleted.
+func CheckRepoNotArchived(repo *types.Repository, permission enum.Permission) error {
+	if !repo.Archived {
+		return nil
+	}
+
+	switch permission {
+	case enum.PermissionRepoView, enum.PermissionRepoDelete:
+		return nil
+	default:
+		return usererror.ErrRepoArchived
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 const (
@@ -28,6 +30,10 @@ const (
 	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
+	// webhookMaxBranchPatterns defines the max allowed number of branch patterns of a webhook.
+	webhookMaxBranchPatterns = 32
+	// webhookMaxBranchPatternLength defines the max allowed length of a single branch pattern of a webhook.
+	webhookMaxBranchPatternLength = 256
 )
 
 var ErrInternalWebhookOperationNotAllowed = usererror.Forbidden("changes to internal webhooks are not allowed")
@@ -115,6 +121,48 @@ func DeduplicateTriggers(in []enum.WebhookTrigger) []enum.WebhookTrigger {
 	return out
 }
 
+// CheckBranchPatterns validates the branch patterns of a webhook.
+func CheckBranchPatterns(patterns []string) error {
+	if len(patterns) > webhookMaxBranchPatterns {
+		return check.NewValidationErrorf("A webhook can have at most %d branch patterns.",
+			webhookMaxBranchPatterns)
+	}
+
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return check.NewValidationError("Branch patterns of a webhook can't be empty.")
+		}
+		if len(pattern) > webhookMaxBranchPatternLength {
+			return check.NewValidationErrorf("A branch pattern of a webhook can be at most %d characters long.",
+				webhookMaxBranchPatternLength)
+		}
+		if !doublestar.ValidatePattern(pattern) {
+			return check.NewValidationErrorf("The provided branch pattern '%s' is invalid.", pattern)
+		}
+	}
+
+	return nil
+}
+
+// DeduplicateBranchPatterns de-duplicates the branch patterns provided by the user.
+func DeduplicateBranchPatterns(in []string) []string {
+	if len(in) == 0 {
+		return []string{}
+	}
+
+	patternSet := make(map[string]bool, len(in))
+	out := make([]string, 0, len(in))
+	for _, pattern := range in {
+		if patternSet[pattern] {
+			continue
+		}
+		patternSet[pattern] = true
+		out = append(out, pattern)
+	}
+
+	return out
+}
+
 func ConvertTriggers(vals []string) []enum.WebhookTrigger {
 	res := make([]enum.WebhookTrigger, len(vals))
 	for i := range vals {
@@ -17,8 +17,10 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -30,13 +32,15 @@ import (
 )
 
 type Controller struct {
-	allowLoopback       bool
-	allowPrivateNetwork bool
+	allowLoopback             bool
+	allowPrivateNetwork       bool
+	secretRotationGracePeriod time.Duration
 
 	authorizer            authz.Authorizer
 	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
 	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
 	webhookService        *webhook.Service
 	encrypter             encrypt.Encrypter
 }
@@ -44,22 +48,26 @@ type Controller struct {
 func NewController(
 	allowLoopback bool,
 	allowPrivateNetwork bool,
+	secretRotationGracePeriod time.Duration,
 	authorizer authz.Authorizer,
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
 ) *Controller {
 	return &Controller{
-		allowLoopback:         allowLoopback,
-		allowPrivateNetwork:   allowPrivateNetwork,
-		authorizer:            authorizer,
-		webhookStore:          webhookStore,
-		webhookExecutionStore: webhookExecutionStore,
-		repoStore:             repoStore,
-		webhookService:        webhookService,
-		encrypter:             encrypter,
+		allowLoopback:             allowLoopback,
+		allowPrivateNetwork:       allowPrivateNetwork,
+		secretRotationGracePeriod: secretRotationGracePeriod,
+		authorizer:                authorizer,
+		webhookStore:              webhookStore,
+		webhookExecutionStore:     webhookExecutionStore,
+		repoStore:                 repoStore,
+		spaceStore:                spaceStore,
+		webhookService:            webhookService,
+		encrypter:                 encrypter,
 	}
 }
 
@@ -78,5 +86,27 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("failed to verify authorization: %w", err)
 	}
 
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
 	return repo, nil
 }
+
+func (c *Controller) getSpaceCheckAccess(ctx context.Context,
+	session *auth.Session, spaceRef string, reqPermission enum.Permission) (*types.Space, error) {
+	if spaceRef == "" {
+		return nil, usererror.BadRequest("A valid space reference must be provided.")
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, reqPermission); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return space, nil
+}
@@ -43,11 +43,11 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// BranchPatterns restricts the webhook to events of branches matching any of the glob patterns.
+	BranchPatterns []string `json:"branch_patterns"`
 }
 
 // Create creates a new webhook.
-//
-//nolint:gocognit
 func (c *Controller) Create(
 	ctx context.Context,
 	session *auth.Session,
@@ -61,13 +61,47 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	now := time.Now().UnixMilli()
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionWebhookEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.create(ctx, session, enum.WebhookParentRepo, repo.ID, in, internal)
+}
 
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+// CreateSpace creates a new webhook for a space.
+// The webhook is triggered for events of all repositories within the space and its subspaces.
+func (c *Controller) CreateSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.Webhook, error) {
+	// validate input
+	err := sanitizeCreateInput(in, c.allowLoopback, c.allowPrivateNetwork)
 	if err != nil {
 		return nil, err
 	}
 
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionWebhookEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.create(ctx, session, enum.WebhookParentSpace, space.ID, in, false)
+}
+
+//nolint:gocognit
+func (c *Controller) create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	in *CreateInput,
+	internal bool,
+) (*types.Webhook, error) {
+	now := time.Now().UnixMilli()
+
 	encryptedSecret, err := c.encrypter.Encrypt(in.Secret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
@@ -80,8 +114,8 @@ func (c *Controller) Create(
 		CreatedBy:  session.Principal.ID,
 		Created:    now,
 		Updated:    now,
-		ParentID:   repo.ID,
-		ParentType: enum.WebhookParentRepo,
+		ParentID:   parentID,
+		ParentType: parentType,
 		Internal:   internal,
 
 		// user input
@@ -93,6 +127,7 @@ func (c *Controller) Create(
 		Enabled:               in.Enabled,
 		Insecure:              in.Insecure,
 		Triggers:              DeduplicateTriggers(in.Triggers),
+		BranchPatterns:        DeduplicateBranchPatterns(in.BranchPatterns),
 		LatestExecutionResult: nil,
 	}
 
@@ -101,11 +136,12 @@ func (c *Controller) Create(
 	// internal hooks are hidden from non-internal read requests - properly communicate their existence on duplicate.
 	// This is best effort, any error we just ignore and fallback to original duplicate error.
 	if errors.Is(err, store.ErrDuplicate) && !internal {
-		existingHook, derr := c.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, repo.ID, hook.Identifier)
+		existingHook, derr := c.webhookStore.FindByIdentifier(ctx, parentType, parentID, hook.Identifier)
 		if derr != nil {
 			log.Ctx(ctx).Warn().Err(derr).Msgf(
-				"failed to retrieve webhook for repo %d with identifier %q on duplicate error",
-				repo.ID,
+				"failed to retrieve webhook for %s %d with identifier %q on duplicate error",
+				parentType,
+				parentID,
 				hook.Identifier,
 			)
 		}
@@ -155,7 +191,10 @@ func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwor
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := CheckTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := CheckTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := CheckBranchPatterns(in.BranchPatterns); err != nil { //nolint:revive
 		return err
 	}
 
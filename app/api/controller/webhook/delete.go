@@ -29,13 +29,38 @@ func (c *Controller) Delete(
 	webhookIdentifier string,
 	allowDeletingInternal bool,
 ) error {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionWebhookEdit)
 	if err != nil {
 		return err
 	}
 
+	return c.delete(ctx, enum.WebhookParentRepo, repo.ID, webhookIdentifier, allowDeletingInternal)
+}
+
+// DeleteSpace deletes an existing webhook of a space.
+func (c *Controller) DeleteSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionWebhookEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier, false)
+}
+
+func (c *Controller) delete(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookIdentifier string,
+	allowDeletingInternal bool,
+) error {
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parentType, parentID, webhookIdentifier)
 	if err != nil {
 		return err
 	}
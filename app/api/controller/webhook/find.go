@@ -38,12 +38,28 @@ func (c *Controller) Find(
 		return nil, err
 	}
 
-	return c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	return c.getWebhookVerifyOwnership(ctx, enum.WebhookParentRepo, repo.ID, webhookIdentifier)
+}
+
+// FindSpace finds a webhook from the provided space.
+func (c *Controller) FindSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+) (*types.Webhook, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getWebhookVerifyOwnership(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier)
 }
 
 func (c *Controller) getWebhookVerifyOwnership(
 	ctx context.Context,
-	repoID int64,
+	parentType enum.WebhookParent,
+	parentID int64,
 	webhookIdentifier string,
 ) (*types.Webhook, error) {
 	// TODO: Remove once webhook identifier migration completed
@@ -56,15 +72,16 @@ func (c *Controller) getWebhookVerifyOwnership(
 	if err == nil {
 		webhook, err = c.webhookStore.Find(ctx, webhookID)
 	} else {
-		webhook, err = c.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, repoID, webhookIdentifier)
+		webhook, err = c.webhookStore.FindByIdentifier(ctx, parentType, parentID, webhookIdentifier)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to find webhook with identifier %q: %w", webhookIdentifier, err)
 	}
 
-	// ensure the webhook actually belongs to the repo
-	if webhook.ParentType != enum.WebhookParentRepo || webhook.ParentID != repoID {
-		return nil, fmt.Errorf("webhook doesn't belong to requested repo. Returning error %w", usererror.ErrNotFound)
+	// ensure the webhook actually belongs to the parent
+	if webhook.ParentType != parentType || webhook.ParentID != parentID {
+		return nil, fmt.Errorf("webhook doesn't belong to requested %s. Returning error %w",
+			parentType, usererror.ErrNotFound)
 	}
 
 	return webhook, nil
@@ -37,8 +37,34 @@ func (c *Controller) FindExecution(
 		return nil, err
 	}
 
+	return c.findExecution(ctx, enum.WebhookParentRepo, repo.ID, webhookIdentifier, webhookExecutionID)
+}
+
+// FindExecutionSpace finds a webhook execution of a space webhook.
+func (c *Controller) FindExecutionSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+	webhookExecutionID int64,
+) (*types.WebhookExecution, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findExecution(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier, webhookExecutionID)
+}
+
+func (c *Controller) findExecution(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookIdentifier string,
+	webhookExecutionID int64,
+) (*types.WebhookExecution, error) {
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parentType, parentID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -35,14 +35,38 @@ func (c *Controller) List(
 		return nil, 0, err
 	}
 
-	count, err := c.webhookStore.Count(ctx, enum.WebhookParentRepo, repo.ID, filter)
+	return c.list(ctx, enum.WebhookParentRepo, repo.ID, filter)
+}
+
+// ListSpace returns the webhooks from the provided space.
+func (c *Controller) ListSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.WebhookFilter,
+) ([]*types.Webhook, int64, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.list(ctx, enum.WebhookParentSpace, space.ID, filter)
+}
+
+func (c *Controller) list(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	filter *types.WebhookFilter,
+) ([]*types.Webhook, int64, error) {
+	count, err := c.webhookStore.Count(ctx, parentType, parentID, filter)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count webhooks for repo with id %d: %w", repo.ID, err)
+		return nil, 0, fmt.Errorf("failed to count webhooks for %s with id %d: %w", parentType, parentID, err)
 	}
 
-	webhooks, err := c.webhookStore.List(ctx, enum.WebhookParentRepo, repo.ID, filter)
+	webhooks, err := c.webhookStore.List(ctx, parentType, parentID, filter)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list webhooks for repo with id %d: %w", repo.ID, err)
+		return nil, 0, fmt.Errorf("failed to list webhooks for %s with id %d: %w", parentType, parentID, err)
 	}
 
 	return webhooks, count, nil
@@ -36,8 +36,34 @@ func (c *Controller) ListExecutions(
 		return nil, err
 	}
 
+	return c.listExecutions(ctx, enum.WebhookParentRepo, repo.ID, webhookIdentifier, filter)
+}
+
+// ListExecutionsSpace returns the executions of the space webhook.
+func (c *Controller) ListExecutionsSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+	filter *types.WebhookExecutionFilter,
+) ([]*types.WebhookExecution, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listExecutions(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier, filter)
+}
+
+func (c *Controller) listExecutions(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookIdentifier string,
+	filter *types.WebhookExecutionFilter,
+) ([]*types.WebhookExecution, error) {
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parentType, parentID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
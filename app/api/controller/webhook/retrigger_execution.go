@@ -33,13 +33,39 @@ func (c *Controller) RetriggerExecution(
 	webhookIdentifier string,
 	webhookExecutionID int64,
 ) (*types.WebhookExecution, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionWebhookEdit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
 	}
 
+	return c.retriggerExecution(ctx, enum.WebhookParentRepo, repo.ID, webhookIdentifier, webhookExecutionID)
+}
+
+// RetriggerExecutionSpace retriggers an existing execution of a space webhook.
+func (c *Controller) RetriggerExecutionSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+	webhookExecutionID int64,
+) (*types.WebhookExecution, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionWebhookEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	return c.retriggerExecution(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier, webhookExecutionID)
+}
+
+func (c *Controller) retriggerExecution(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookIdentifier string,
+	webhookExecutionID int64,
+) (*types.WebhookExecution, error) {
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parentType, parentID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -36,6 +37,8 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// BranchPatterns restricts the webhook to events of branches matching any of the glob patterns.
+	BranchPatterns []string `json:"branch_patterns"`
 }
 
 // Update updates an existing webhook.
@@ -51,13 +54,44 @@ func (c *Controller) Update(
 		return nil, err
 	}
 
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionWebhookEdit)
 	if err != nil {
 		return nil, err
 	}
 
+	return c.update(ctx, enum.WebhookParentRepo, repo.ID, webhookIdentifier, in, allowModifyingInternal)
+}
+
+// UpdateSpace updates an existing webhook of a space.
+func (c *Controller) UpdateSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+	in *UpdateInput,
+) (*types.Webhook, error) {
+	if err := sanitizeUpdateInput(in, c.allowLoopback, c.allowPrivateNetwork); err != nil {
+		return nil, err
+	}
+
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionWebhookEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.update(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier, in, false)
+}
+
+func (c *Controller) update(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookIdentifier string,
+	in *UpdateInput,
+	allowModifyingInternal bool,
+) (*types.Webhook, error) {
 	// get the hook and ensure it belongs to us
-	hook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	hook, err := c.getWebhookVerifyOwnership(ctx, parentType, parentID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +114,9 @@ func (c *Controller) Update(
 		hook.URL = *in.URL
 	}
 	if in.Secret != nil {
-		encryptedSecret, err := c.encrypter.Encrypt(*in.Secret)
-		if err != nil {
-			return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		if err = c.rotateSecret(hook, *in.Secret); err != nil {
+			return nil, err
 		}
-		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
 		hook.Enabled = *in.Enabled
@@ -95,6 +127,9 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = DeduplicateTriggers(in.Triggers)
 	}
+	if in.BranchPatterns != nil {
+		hook.BranchPatterns = DeduplicateBranchPatterns(in.BranchPatterns)
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
@@ -103,6 +138,41 @@ func (c *Controller) Update(
 	return hook, nil
 }
 
+// rotateSecret sets the new secret of the webhook.
+// The previous secret is kept for the configured grace period to give receivers time to update their secret.
+// Only one previous secret is kept: rotating the secret again within the grace period replaces the
+// previous secret with the current one, so payloads are no longer signed with the older secret.
+func (c *Controller) rotateSecret(hook *types.Webhook, secret string) error {
+	var previousSecret string
+	if hook.Secret != "" {
+		decryptedSecret, err := c.encrypter.Decrypt([]byte(hook.Secret))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt webhook secret: %w", err)
+		}
+		previousSecret = decryptedSecret
+	}
+
+	if previousSecret == secret {
+		return nil
+	}
+
+	encryptedSecret, err := c.encrypter.Encrypt(secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	hook.PreviousSecret = ""
+	hook.PreviousSecretExpires = 0
+	if previousSecret != "" && c.secretRotationGracePeriod > 0 {
+		hook.PreviousSecret = hook.Secret
+		hook.PreviousSecretExpires = time.Now().Add(c.secretRotationGracePeriod).UnixMilli()
+	}
+
+	hook.Secret = string(encryptedSecret)
+
+	return nil
+}
+
 func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwork bool) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == nil {
@@ -139,6 +209,11 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 			return err
 		}
 	}
+	if in.BranchPatterns != nil {
+		if err := CheckBranchPatterns(in.BranchPatterns); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+)
+
+func TestRotateSecret(t *testing.T) {
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %s", err)
+	}
+
+	encrypted := func(secret string) string {
+		b, err := encrypter.Encrypt(secret)
+		if err != nil {
+			t.Fatalf("failed to encrypt secret: %s", err)
+		}
+		return string(b)
+	}
+
+	tests := []struct {
+		name            string
+		gracePeriod     time.Duration
+		secret          string
+		previousSecret  string
+		newSecret       string
+		wantSecret      string
+		wantPrevious    string
+		wantPreviousExp bool
+	}{
+		{
+			name:        "first-secret",
+			gracePeriod: time.Hour,
+			newSecret:   "new",
+			wantSecret:  "new",
+		},
+		{
+			name:            "rotation-keeps-previous",
+			gracePeriod:     time.Hour,
+			secret:          "old",
+			newSecret:       "new",
+			wantSecret:      "new",
+			wantPrevious:    "old",
+			wantPreviousExp: true,
+		},
+		{
+			name:        "rotation-without-grace-period",
+			gracePeriod: 0,
+			secret:      "old",
+			newSecret:   "new",
+			wantSecret:  "new",
+		},
+		{
+			name:            "second-rotation-drops-oldest",
+			gracePeriod:     time.Hour,
+			secret:          "middle",
+			previousSecret:  "oldest",
+			newSecret:       "new",
+			wantSecret:      "new",
+			wantPrevious:    "middle",
+			wantPreviousExp: true,
+		},
+		{
+			name:           "same-secret-is-noop",
+			gracePeriod:    time.Hour,
+			secret:         "same",
+			previousSecret: "older",
+			newSecret:      "same",
+			wantSecret:     "same",
+			wantPrevious:   "older",
+		},
+		{
+			name:            "secret-removed",
+			gracePeriod:     time.Hour,
+			secret:          "old",
+			newSecret:       "",
+			wantSecret:      "",
+			wantPrevious:    "old",
+			wantPreviousExp: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				encrypter:                 encrypter,
+				secretRotationGracePeriod: test.gracePeriod,
+			}
+
+			hook := &types.Webhook{}
+			if test.secret != "" {
+				hook.Secret = encrypted(test.secret)
+			}
+			if test.previousSecret != "" {
+				hook.PreviousSecret = encrypted(test.previousSecret)
+			}
+
+			before := time.Now()
+			if err := c.rotateSecret(hook, test.newSecret); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			secret, err := encrypter.Decrypt([]byte(hook.Secret))
+			if err != nil {
+				t.Fatalf("failed to decrypt secret: %s", err)
+			}
+			if secret != test.wantSecret {
+				t.Errorf("secret: want=%q got=%q", test.wantSecret, secret)
+			}
+
+			var previous string
+			if hook.PreviousSecret != "" {
+				previous, err = encrypter.Decrypt([]byte(hook.PreviousSecret))
+				if err != nil {
+					t.Fatalf("failed to decrypt previous secret: %s", err)
+				}
+			}
+			if previous != test.wantPrevious {
+				t.Errorf("previous secret: want=%q got=%q", test.wantPrevious, previous)
+			}
+
+			if test.wantPreviousExp {
+				minExp := before.Add(test.gracePeriod).UnixMilli()
+				if hook.PreviousSecretExpires < minExp {
+					t.Errorf("previous secret expires too early: %d < %d", hook.PreviousSecretExpires, minExp)
+				}
+			}
+		})
+	}
+}
@@ -30,10 +30,10 @@ var WireSet = wire.NewSet(
 
 func ProvideController(config webhook.Config, authorizer authz.Authorizer,
 	webhookStore store.WebhookStore, webhookExecutionStore store.WebhookExecutionStore,
-	repoStore store.RepoStore, webhookService *webhook.Service, encrypter encrypt.Encrypter,
+	repoStore store.RepoStore, spaceStore store.SpaceStore, webhookService *webhook.Service, encrypter encrypt.Encrypter,
 ) *Controller {
 	return NewController(
-		config.AllowLoopback, config.AllowPrivateNetwork, authorizer,
+		config.AllowLoopback, config.AllowPrivateNetwork, config.SecretRotationGracePeriod, authorizer,
 		webhookStore, webhookExecutionStore,
-		repoStore, webhookService, encrypter)
+		repoStore, spaceStore, webhookService, encrypter)
 }
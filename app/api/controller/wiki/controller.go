@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// pageExtension is the file extension of wiki pages, wiki pages are markdown documents.
+	pageExtension = ".md"
+
+	maxPageNameLength = 256
+	maxPageSize       = 1 << 20 // 1 MiB
+)
+
+type Controller struct {
+	authorizer  authz.Authorizer
+	repoStore   store.RepoStore
+	git         git.Interface
+	wikiService *wikisvc.Service
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	git git.Interface,
+	wikiService *wikisvc.Service,
+) *Controller {
+	return &Controller{
+		authorizer:  authorizer,
+		repoStore:   repoStore,
+		git:         git,
+		wikiService: wikiService,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if err = controller.CheckRepoNotArchived(repo, reqPermission); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// getExistingWiki checks the access to the repository and returns it, but only if its wiki has been created.
+func (c *Controller) getExistingWiki(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission,
+) (*types.Repository, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := c.wikiService.Exists(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, usererror.NotFound("The repository doesn't have a wiki yet.")
+	}
+
+	return repo, nil
+}
+
+// getPage returns the wiki page (without its content) at the provided git reference.
+func (c *Controller) getPage(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	name string,
+	includeLatestCommit bool,
+) (*types.WikiPage, error) {
+	out, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams:          wikisvc.ReadParams(repo),
+		GitREF:              gitRef,
+		Path:                pageNameToPath(name),
+		IncludeLatestCommit: includeLatestCommit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiki page: %w", err)
+	}
+
+	if out.Node.Type != git.TreeNodeTypeBlob {
+		return nil, usererror.NotFoundf("Wiki page %q not found.", name)
+	}
+
+	page := &types.WikiPage{
+		Name:  name,
+		Title: pageTitle(name),
+		Path:  out.Node.Path,
+		SHA:   out.Node.SHA,
+	}
+
+	if out.Commit != nil {
+		page.LatestCommit, err = controller.MapCommit(out.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map latest commit of wiki page: %w", err)
+		}
+	}
+
+	return page, nil
+}
+
+// commitPage commits the provided actions to the wiki, creating the wiki in case it doesn't exist yet.
+func (c *Controller) commitPage(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	message string,
+	actions ...git.CommitFileAction,
+) error {
+	if err := c.wikiService.Init(ctx, &session.Principal, repo); err != nil {
+		return err
+	}
+
+	writeParams, err := c.wikiService.WriteParams(ctx, &session.Principal, repo)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams: writeParams,
+		Title:       message,
+		Branch:      wikisvc.DefaultBranch,
+		Actions:     actions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit wiki page: %w", err)
+	}
+
+	return nil
+}
+
+func sanitizePageName(name string) (string, error) {
+	name = strings.Trim(strings.TrimSpace(name), "/")
+	name = strings.TrimSuffix(name, pageExtension)
+
+	if name == "" {
+		return "", usererror.BadRequest("Wiki page name must be provided.")
+	}
+
+	if utf8.RuneCountInString(name) > maxPageNameLength {
+		return "", usererror.BadRequestf("Wiki page name can't be longer than %d characters.", maxPageNameLength)
+	}
+
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return "", usererror.BadRequest("Wiki page name can't contain control characters.")
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "" || strings.HasPrefix(segment, ".") {
+			return "", usererror.BadRequestf("Wiki page name %q is invalid.", name)
+		}
+	}
+
+	return name, nil
+}
+
+func sanitizeMessage(message, defaultMessage string) string {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return defaultMessage
+	}
+
+	return message
+}
+
+func validateContent(content string) error {
+	if len(content) > maxPageSize {
+		return usererror.BadRequestf("Wiki page can't be larger than %d bytes.", maxPageSize)
+	}
+
+	return nil
+}
+
+func pageNameToPath(name string) string {
+	return name + pageExtension
+}
+
+func pagePathToName(filePath string) (string, bool) {
+	return strings.CutSuffix(filePath, pageExtension)
+}
+
+// pageTitle returns the title of the page, derived from the last segment of its name (e.g. "guides/Getting-Started"
+// has the title "Getting Started").
+func pageTitle(name string) string {
+	return strings.ReplaceAll(path.Base(name), "-", " ")
+}
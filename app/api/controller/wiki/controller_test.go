@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct{}
+
+func (fakeAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+func (fakeAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	if repoRef != testRepo.Path {
+		return nil, errors.NotFound("repository %q not found", repoRef)
+	}
+	return testRepo, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000"
+}
+
+// wikiGit serves the files of a wiki repository from memory and records the commits.
+type wikiGit struct {
+	git.Interface
+	exists  bool
+	files   []string
+	commits []*git.CommitFilesParams
+}
+
+func (g *wikiGit) RepositoryExists(_ context.Context, params git.ReadParams) (bool, error) {
+	if params.RepoUID != testRepo.WikiGitUID() {
+		return false, errors.InvalidArgument("unexpected repository %q", params.RepoUID)
+	}
+	return g.exists, nil
+}
+
+func (g *wikiGit) ListPaths(context.Context, *git.ListPathsParams) (*git.ListPathsOutput, error) {
+	if g.files == nil {
+		return nil, errors.NotFound("reference not found")
+	}
+	return &git.ListPathsOutput{Files: g.files}, nil
+}
+
+func (g *wikiGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	return &git.GetTreeNodeOutput{
+		Node: git.TreeNode{Type: git.TreeNodeTypeBlob, Path: params.Path, SHA: "page-sha"},
+	}, nil
+}
+
+func (g *wikiGit) CommitFiles(_ context.Context, params *git.CommitFilesParams) (git.CommitFilesResponse, error) {
+	g.commits = append(g.commits, params)
+	return git.CommitFilesResponse{}, nil
+}
+
+var testRepo = &types.Repository{
+	ID:     1,
+	Path:   "space/repo",
+	GitUID: "repo-uid",
+	State:  enum.RepoStateActive,
+}
+
+var testSession = &auth.Session{
+	Principal: types.Principal{ID: 100, UID: "caller", Type: enum.PrincipalTypeUser},
+}
+
+func newTestController(g *wikiGit) *Controller {
+	return NewController(fakeAuthorizer{}, fakeRepoStore{}, g, wikisvc.NewService(g, fakeURLProvider{}))
+}
+
+func userErrorStatus(err error) int {
+	var uErr *usererror.Error
+	if errors.As(err, &uErr) {
+		return uErr.Status
+	}
+	return 0
+}
+
+func TestSanitizePageName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", input: "Home", want: "Home"},
+		{name: "trimmed", input: " /guides/Setup/ ", want: "guides/Setup"},
+		{name: "markdown extension", input: "guides/Setup.md", want: "guides/Setup"},
+		{name: "empty", input: " / ", wantErr: true},
+		{name: "only extension", input: ".md", wantErr: true},
+		{name: "hidden file", input: "guides/.hidden", wantErr: true},
+		{name: "parent directory", input: "../Home", wantErr: true},
+		{name: "empty segment", input: "guides//Setup", wantErr: true},
+		{name: "control character", input: "Ho\nme", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", maxPageNameLength+1), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sanitizePageName(test.input)
+			if test.wantErr {
+				if userErrorStatus(err) != http.StatusBadRequest {
+					t.Errorf("expected bad request, got %q, %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("name = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPageTitle(t *testing.T) {
+	if got := pageTitle("guides/Getting-Started"); got != "Getting Started" {
+		t.Errorf("title = %q, want %q", got, "Getting Started")
+	}
+}
+
+func TestController_ListPages(t *testing.T) {
+	tests := []struct {
+		name  string
+		git   *wikiGit
+		want  []*types.WikiPage
+		check func(t *testing.T, g *wikiGit)
+	}{
+		{
+			name: "no wiki",
+			git:  &wikiGit{exists: false},
+			want: []*types.WikiPage{},
+		},
+		{
+			name: "wiki without commits",
+			git:  &wikiGit{exists: true},
+			want: []*types.WikiPage{},
+		},
+		{
+			name: "markdown files only",
+			git:  &wikiGit{exists: true, files: []string{"Home.md", "guides/Getting-Started.md", "logo.png"}},
+			want: []*types.WikiPage{
+				{Name: "Home", Title: "Home", Path: "Home.md"},
+				{Name: "guides/Getting-Started", Title: "Getting Started", Path: "guides/Getting-Started.md"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestController(test.git)
+
+			pages, err := c.ListPages(context.Background(), testSession, testRepo.Path, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(pages, test.want) {
+				t.Errorf("pages = %+v, want %+v", pages, test.want)
+			}
+		})
+	}
+}
+
+func TestController_FindPageWithoutWiki(t *testing.T) {
+	c := newTestController(&wikiGit{exists: false})
+
+	_, err := c.FindPage(context.Background(), testSession, testRepo.Path, "Home", "")
+	if userErrorStatus(err) != http.StatusNotFound {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreatePageInput struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	Message string `json:"message"`
+}
+
+func (in *CreatePageInput) sanitize() error {
+	var err error
+	in.Name, err = sanitizePageName(in.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := validateContent(in.Content); err != nil {
+		return err
+	}
+
+	in.Message = sanitizeMessage(in.Message, fmt.Sprintf("Create %s", in.Name))
+
+	return nil
+}
+
+// CreatePage creates a new page in the wiki of the repository.
+// The wiki is created together with its first page.
+func (c *Controller) CreatePage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreatePageInput,
+) (*types.WikiPage, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	err = c.commitPage(ctx, session, repo, in.Message, git.CommitFileAction{
+		Action:  git.CreateAction,
+		Path:    pageNameToPath(in.Name),
+		Payload: []byte(in.Content),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPage(ctx, repo, wikisvc.DefaultBranch, in.Name, true)
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeletePage deletes the wiki page.
+func (c *Controller) DeletePage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	name string,
+	message string,
+) error {
+	repo, err := c.getExistingWiki(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return err
+	}
+
+	name, err = sanitizePageName(name)
+	if err != nil {
+		return err
+	}
+
+	return c.commitPage(ctx, session, repo, sanitizeMessage(message, fmt.Sprintf("Delete %s", name)),
+		git.CommitFileAction{
+			Action: git.DeleteAction,
+			Path:   pageNameToPath(name),
+		})
+}
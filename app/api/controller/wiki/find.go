@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FindPage returns the wiki page with its content.
+// The gitRef (optional) allows to read previous revisions of the page.
+func (c *Controller) FindPage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	name string,
+	gitRef string,
+) (*types.WikiPage, error) {
+	repo, err := c.getExistingWiki(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = sanitizePageName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = wikisvc.DefaultBranch
+	}
+
+	page, err := c.getPage(ctx, repo, gitRef, name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: wikisvc.ReadParams(repo),
+		SHA:        page.SHA,
+		SizeLimit:  maxPageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiki page content: %w", err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiki page content: %w", err)
+	}
+
+	contentStr := string(content)
+	page.Content = &contentStr
+
+	return page, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PageHistory lists the commits that changed the wiki page, the most recent first.
+func (c *Controller) PageHistory(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	name string,
+	filter *types.PaginationFilter,
+) ([]types.Commit, error) {
+	repo, err := c.getExistingWiki(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = sanitizePageName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: wikisvc.ReadParams(repo),
+		GitREF:     wikisvc.DefaultBranch,
+		Path:       pageNameToPath(name),
+		Page:       int32(filter.Page),
+		Limit:      int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wiki page history: %w", err)
+	}
+
+	commits := make([]types.Commit, len(out.Commits))
+	for i := range out.Commits {
+		commit, err := controller.MapCommit(&out.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListPages lists all pages of the wiki of the repository.
+// An empty list is returned in case the wiki hasn't been created yet.
+func (c *Controller) ListPages(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) ([]*types.WikiPage, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := c.wikiService.Exists(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return []*types.WikiPage{}, nil
+	}
+
+	if gitRef == "" {
+		gitRef = wikisvc.DefaultBranch
+	}
+
+	out, err := c.git.ListPaths(ctx, &git.ListPathsParams{
+		ReadParams: wikisvc.ReadParams(repo),
+		GitREF:     gitRef,
+	})
+	// the wiki repository has no commits if it was only cloned so far.
+	if errors.IsNotFound(err) && gitRef == wikisvc.DefaultBranch {
+		return []*types.WikiPage{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wiki pages: %w", err)
+	}
+
+	pages := make([]*types.WikiPage, 0, len(out.Files))
+	for _, filePath := range out.Files {
+		name, ok := pagePathToName(filePath)
+		if !ok {
+			continue
+		}
+
+		pages = append(pages, &types.WikiPage{
+			Name:  name,
+			Title: pageTitle(name),
+			Path:  filePath,
+		})
+	}
+
+	return pages, nil
+}
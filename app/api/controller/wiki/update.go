@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdatePageInput struct {
+	// Name is the new name of the page, the page is renamed if provided.
+	Name *string `json:"name"`
+	// Content is the new content of the page, the content is kept if not provided.
+	Content *string `json:"content"`
+	Message string  `json:"message"`
+
+	// SHA is the sha of the page the update is based on, used to detect concurrent modifications (optional).
+	SHA sha.SHA `json:"sha"`
+}
+
+func (in *UpdatePageInput) sanitize(name string) error {
+	if in.Name != nil {
+		newName, err := sanitizePageName(*in.Name)
+		if err != nil {
+			return err
+		}
+
+		if newName == name {
+			in.Name = nil
+		} else {
+			in.Name = &newName
+		}
+	}
+
+	if in.Content != nil {
+		if err := validateContent(*in.Content); err != nil {
+			return err
+		}
+	}
+
+	if in.Name == nil && in.Content == nil {
+		return usererror.BadRequest("Either the name or the content of the wiki page must be updated.")
+	}
+
+	in.Message = sanitizeMessage(in.Message, fmt.Sprintf("Update %s", name))
+
+	return nil
+}
+
+// UpdatePage updates the content of the wiki page and/or renames it.
+func (c *Controller) UpdatePage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	name string,
+	in *UpdatePageInput,
+) (*types.WikiPage, error) {
+	repo, err := c.getExistingWiki(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = sanitizePageName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(name); err != nil {
+		return nil, err
+	}
+
+	action := git.CommitFileAction{
+		Path: pageNameToPath(name),
+		SHA:  in.SHA,
+	}
+
+	newName := name
+	if in.Name != nil {
+		newName = *in.Name
+
+		// the payload of a move is the new path, optionally followed by the new content.
+		action.Action = git.MoveAction
+		action.Payload = []byte(pageNameToPath(newName))
+		if in.Content != nil {
+			action.Payload = append(action.Payload, 0)
+			action.Payload = append(action.Payload, *in.Content...)
+		}
+	} else {
+		action.Action = git.UpdateAction
+		action.Payload = []byte(*in.Content)
+	}
+
+	if err := c.commitPage(ctx, session, repo, in.Message, action); err != nil {
+		return nil, err
+	}
+
+	return c.getPage(ctx, repo, wikisvc.DefaultBranch, newName, true)
+}
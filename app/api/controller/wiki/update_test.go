@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	wikisvc "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/git"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestController_UpdatePage(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       UpdatePageInput
+		wantAction  git.FileAction
+		wantPayload string
+		wantPage    string
+		wantMessage string
+	}{
+		{
+			name:        "content",
+			input:       UpdatePageInput{Content: ptr.String("new content")},
+			wantAction:  git.UpdateAction,
+			wantPayload: "new content",
+			wantPage:    "Home",
+			wantMessage: "Update Home",
+		},
+		{
+			name:        "rename",
+			input:       UpdatePageInput{Name: ptr.String("guides/Start.md"), Message: "Move home"},
+			wantAction:  git.MoveAction,
+			wantPayload: "guides/Start.md",
+			wantPage:    "guides/Start",
+			wantMessage: "Move home",
+		},
+		{
+			name:        "rename with content",
+			input:       UpdatePageInput{Name: ptr.String("Start"), Content: ptr.String("new content")},
+			wantAction:  git.MoveAction,
+			wantPayload: "Start.md\x00new content",
+			wantPage:    "Start",
+			wantMessage: "Update Home",
+		},
+		{
+			name:        "rename to the same name with content",
+			input:       UpdatePageInput{Name: ptr.String("Home"), Content: ptr.String("new content")},
+			wantAction:  git.UpdateAction,
+			wantPayload: "new content",
+			wantPage:    "Home",
+			wantMessage: "Update Home",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &wikiGit{exists: true}
+			c := newTestController(g)
+
+			page, err := c.UpdatePage(context.Background(), testSession, testRepo.Path, "Home", &test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if page.Name != test.wantPage {
+				t.Errorf("page = %q, want %q", page.Name, test.wantPage)
+			}
+
+			if len(g.commits) != 1 {
+				t.Fatalf("expected a single commit, got %d", len(g.commits))
+			}
+
+			commit := g.commits[0]
+			if commit.RepoUID != testRepo.WikiGitUID() || commit.Branch != wikisvc.DefaultBranch {
+				t.Errorf("committed to %s:%s, want %s:%s",
+					commit.RepoUID, commit.Branch, testRepo.WikiGitUID(), wikisvc.DefaultBranch)
+			}
+			if commit.Title != test.wantMessage {
+				t.Errorf("message = %q, want %q", commit.Title, test.wantMessage)
+			}
+
+			if len(commit.Actions) != 1 {
+				t.Fatalf("expected a single action, got %d", len(commit.Actions))
+			}
+
+			action := commit.Actions[0]
+			if action.Action != test.wantAction || action.Path != "Home.md" {
+				t.Errorf("action = %s %s, want %s Home.md", action.Action, action.Path, test.wantAction)
+			}
+			if string(action.Payload) != test.wantPayload {
+				t.Errorf("payload = %q, want %q", action.Payload, test.wantPayload)
+			}
+		})
+	}
+}
+
+func TestController_UpdatePageRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		exists     bool
+		input      UpdatePageInput
+		wantStatus int
+	}{
+		{
+			name:       "no wiki",
+			exists:     false,
+			input:      UpdatePageInput{Content: ptr.String("new content")},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "nothing to update",
+			exists:     true,
+			input:      UpdatePageInput{Name: ptr.String("Home.md")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid name",
+			exists:     true,
+			input:      UpdatePageInput{Name: ptr.String("../Home")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "content too large",
+			exists:     true,
+			input:      UpdatePageInput{Content: ptr.String(string(make([]byte, maxPageSize+1)))},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &wikiGit{exists: test.exists}
+			c := newTestController(g)
+
+			_, err := c.UpdatePage(context.Background(), testSession, testRepo.Path, "Home", &test.input)
+			if userErrorStatus(err) != test.wantStatus {
+				t.Errorf("expected status %d, got %v", test.wantStatus, err)
+			}
+			if len(g.commits) > 0 {
+				t.Errorf("expected no commit, got %d", len(g.commits))
+			}
+		})
+	}
+}
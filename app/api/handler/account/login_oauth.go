@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	oauthStateCookieName   = "gitness_oauth_state"
+	oauthStateCookieMaxAge = 10 * time.Minute
+)
+
+// HandleListOAuthProviders returns the OAuth providers users can login with.
+func HandleListOAuthProviders(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		providers, err := sysCtrl.OAuthProviderListEnabled(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, providers)
+	}
+}
+
+// HandleLoginOAuth returns an http.HandlerFunc that redirects the user
+// to the consent page of the OAuth provider.
+func HandleLoginOAuth(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		providerIdentifier, err := request.GetOAuthProviderIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		redirect, err := userCtrl.BeginOAuthLogin(ctx, providerIdentifier)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// the state and the verifier are only known to the browser that started the login.
+		cookie := newOAuthStateCookie(r)
+		cookie.Value = redirect.State + "." + redirect.Verifier
+		cookie.MaxAge = int(oauthStateCookieMaxAge.Seconds())
+		http.SetCookie(w, cookie)
+
+		http.Redirect(w, r, redirect.URL, http.StatusFound)
+	}
+}
+
+// HandleLoginOAuthCallback returns an http.HandlerFunc that finishes the login
+// after the OAuth provider redirected the user back. The session token is returned as cookie.
+func HandleLoginOAuthCallback(
+	userCtrl *user.Controller,
+	sysCtrl *system.Controller,
+	cookieName string,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		redirectWithError := func(message string) {
+			http.Redirect(w, r, userCtrl.ExternalLoginRedirectURL(ctx, message), http.StatusFound)
+		}
+
+		providerIdentifier, err := request.GetOAuthProviderIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		stateCookie, err := r.Cookie(oauthStateCookieName)
+		if err != nil {
+			redirectWithError("The login expired, please try again.")
+			return
+		}
+
+		// the state can only be used once.
+		cookie := newOAuthStateCookie(r)
+		cookie.MaxAge = -1
+		http.SetCookie(w, cookie)
+
+		state, verifier, _ := strings.Cut(stateCookie.Value, ".")
+		queryState, _ := request.QueryParam(r, request.QueryParamOAuthState)
+		if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(queryState)) != 1 {
+			redirectWithError("The login expired, please try again.")
+			return
+		}
+
+		if providerErr, ok := request.QueryParam(r, request.QueryParamOAuthError); ok {
+			log.Ctx(ctx).Debug().
+				Str("oauth_provider", providerIdentifier).
+				Str("oauth_error", providerErr).
+				Msg("oauth provider denied the login")
+			redirectWithError("The login has been denied.")
+			return
+		}
+
+		code, _ := request.QueryParam(r, request.QueryParamOAuthCode)
+		if code == "" {
+			redirectWithError("The login has been denied.")
+			return
+		}
+
+		tokenResponse, err := userCtrl.LoginOAuth(ctx, sysCtrl, providerIdentifier, code, verifier)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("oauth_provider", providerIdentifier).
+				Msg("oauth login failed")
+			redirectWithError(usererror.Translate(ctx, err).Message)
+			return
+		}
+
+		if cookieName != "" {
+			includeTokenCookie(r, w, tokenResponse, cookieName)
+		}
+
+		http.Redirect(w, r, userCtrl.ExternalLoginRedirectURL(ctx, ""), http.StatusFound)
+	}
+}
+
+// newOAuthStateCookie returns the cookie holding the state of the login. It has to be sent
+// with the redirect from the provider, so it can't be restricted to same site requests.
+func newOAuthStateCookie(r *http.Request) *http.Cookie {
+	return &http.Cookie{
+		Name:     oauthStateCookieName,
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	samlRequestCookieName   = "gitness_saml_request"
+	samlRequestCookieMaxAge = 10 * time.Minute
+
+	// samlMaxFormSize limits the size of the form posted by the identity provider.
+	samlMaxFormSize = 2 << 20
+)
+
+// HandleListSAMLProviders returns the SAML providers users can login with.
+func HandleListSAMLProviders(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		providers, err := sysCtrl.SAMLProviderListEnabled(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, providers)
+	}
+}
+
+// HandleSAMLMetadata returns the metadata used to register the service with the SAML provider.
+func HandleSAMLMetadata(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		providerIdentifier, err := request.GetSAMLProviderIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		metadata, err := sysCtrl.SAMLProviderMetadata(ctx, providerIdentifier)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(metadata)
+	}
+}
+
+// HandleLoginSAML returns an http.HandlerFunc that redirects the user
+// to the SAML identity provider.
+func HandleLoginSAML(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		providerIdentifier, err := request.GetSAMLProviderIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		redirect, err := userCtrl.BeginSAMLLogin(ctx, providerIdentifier)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// the response is only accepted from the browser that started the login.
+		cookie := newSAMLRequestCookie(r)
+		cookie.Value = redirect.RequestID
+		cookie.MaxAge = int(samlRequestCookieMaxAge.Seconds())
+		http.SetCookie(w, cookie)
+
+		http.Redirect(w, r, redirect.URL, http.StatusFound)
+	}
+}
+
+// HandleLoginSAMLACS returns an http.HandlerFunc that finishes the login
+// after the SAML identity provider posted the response. The session token is returned as cookie.
+func HandleLoginSAMLACS(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		redirectWithError := func(message string) {
+			http.Redirect(w, r, userCtrl.ExternalLoginRedirectURL(ctx, message), http.StatusSeeOther)
+		}
+
+		providerIdentifier, err := request.GetSAMLProviderIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		requestCookie, err := r.Cookie(samlRequestCookieName)
+		if err != nil {
+			redirectWithError("The login expired, please try again.")
+			return
+		}
+
+		// the request can only be answered once.
+		cookie := newSAMLRequestCookie(r)
+		cookie.MaxAge = -1
+		http.SetCookie(w, cookie)
+
+		r.Body = http.MaxBytesReader(w, r.Body, samlMaxFormSize)
+		if err = r.ParseForm(); err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		samlResponse := r.PostForm.Get(request.FormParamSAMLResponse)
+		if samlResponse == "" {
+			redirectWithError("The login has been denied.")
+			return
+		}
+
+		tokenResponse, err := userCtrl.LoginSAML(ctx, providerIdentifier, samlResponse, requestCookie.Value)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("saml_provider", providerIdentifier).
+				Msg("saml login failed")
+			redirectWithError(usererror.Translate(ctx, err).Message)
+			return
+		}
+
+		if cookieName != "" {
+			includeTokenCookie(r, w, tokenResponse, cookieName)
+		}
+
+		http.Redirect(w, r, userCtrl.ExternalLoginRedirectURL(ctx, ""), http.StatusSeeOther)
+	}
+}
+
+// newSAMLRequestCookie returns the cookie holding the ID of the authentication request.
+// The identity provider posts the response cross-site, which requires SameSite=None and thus https.
+func newSAMLRequestCookie(r *http.Request) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     samlRequestCookieName,
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+	}
+
+	if r.URL.Scheme == "https" {
+		cookie.SameSite = http.SameSiteNoneMode
+		cookie.Secure = true
+	}
+
+	return cookie
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+func HandleListArtifacts(executionCtrl *execution.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineIdentifier, err := request.GetPipelineIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		n, err := request.GetExecutionNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		artifacts, err := executionCtrl.ListArtifacts(ctx, session, repoRef, pipelineIdentifier, n)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, artifacts)
+	}
+}
+
+func HandleUploadArtifact(executionCtrl *execution.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineIdentifier, err := request.GetPipelineIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		n, err := request.GetExecutionNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		name, err := request.GetRemainderFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		artifact, err := executionCtrl.UploadArtifact(ctx, session, repoRef, pipelineIdentifier, n, name, r.Body)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, artifact)
+	}
+}
+
+func HandleDownloadArtifact(executionCtrl *execution.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineIdentifier, err := request.GetPipelineIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		n, err := request.GetExecutionNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		name, err := request.GetRemainderFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		artifact, signedURL, file, err := executionCtrl.DownloadArtifact(
+			ctx, session, repoRef, pipelineIdentifier, n, name)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if file == nil {
+			http.Redirect(w, r, signedURL, http.StatusTemporaryRedirect)
+			return
+		}
+
+		defer func() {
+			if cErr := file.Close(); cErr != nil {
+				log.Ctx(ctx).Warn().Err(cErr).Msg("failed to close pipeline artifact after rendering")
+			}
+		}()
+
+		contentType := mime.TypeByExtension(path.Ext(artifact.Name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition",
+			mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(artifact.Name)}))
+
+		render.Reader(ctx, w, http.StatusOK, file)
+	}
+}
+
+func HandleDeleteArtifact(executionCtrl *execution.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineIdentifier, err := request.GetPipelineIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		n, err := request.GetExecutionNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		name, err := request.GetRemainderFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		err = executionCtrl.DeleteArtifact(ctx, session, repoRef, pipelineIdentifier, n, name)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
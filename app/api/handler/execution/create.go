@@ -15,6 +15,9 @@
 package execution
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/execution"
@@ -39,7 +42,15 @@ func HandleCreate(executionCtrl *execution.Controller) http.HandlerFunc {
 
 		branch := request.GetBranchFromQuery(r)
 
-		execution, err := executionCtrl.Create(ctx, session, repoRef, pipelineIdentifier, branch)
+		// the request body is optional, it's only needed to provide input parameters.
+		in := new(execution.CreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil && !errors.Is(err, io.EOF) {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		execution, err := executionCtrl.Create(ctx, session, repoRef, pipelineIdentifier, branch, in)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller/logs"
@@ -67,6 +68,20 @@ func HandleTail(logCtrl *logs.Controller) http.HandlerFunc {
 			return
 		}
 
+		offset, err := request.GetLogOffsetFromRequest(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		linec, errc, err := logCtrl.Tail(
+			ctx, session, repoRef, pipelineIdentifier,
+			executionNum, int(stageNum), int(stepNum), offset)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		f, ok := w.(http.Flusher)
 		if !ok {
 			log.Error().Msg("http writer type assertion failed")
@@ -84,14 +99,6 @@ func HandleTail(logCtrl *logs.Controller) http.HandlerFunc {
 		io.WriteString(w, ": ping\n\n")
 		f.Flush()
 
-		linec, errc, err := logCtrl.Tail(
-			ctx, session, repoRef, pipelineIdentifier,
-			executionNum, int(stageNum), int(stepNum))
-		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
-			return
-		}
-
 		// could not get error channel
 		if errc == nil {
 			io.WriteString(w, "event: error\ndata: eof\n\n")
@@ -119,14 +126,24 @@ func HandleTail(logCtrl *logs.Controller) http.HandlerFunc {
 			select {
 			case <-ctx.Done():
 				break L
-			case err := <-errc:
+			case err, ok := <-errc:
+				if !ok {
+					// the stream was closed, write the remaining lines.
+					errc = nil
+					continue
+				}
 				log.Err(err).Msg("received error in the tail channel")
 				break L
 			case <-pingTimer.C:
 				// if time b/w messages takes longer, send a ping
 				io.WriteString(w, ": ping\n\n")
 				f.Flush()
-			case line := <-linec:
+			case line, ok := <-linec:
+				if !ok {
+					break L
+				}
+				// the position of the line is the event id, clients resume after it when reconnecting.
+				io.WriteString(w, "id: "+strconv.Itoa(line.Number)+"\n")
 				io.WriteString(w, "data: ")
 				enc.Encode(line)
 				io.WriteString(w, "\n\n")
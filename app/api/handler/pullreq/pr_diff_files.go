@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleDiffFiles returns a http.HandlerFunc that returns a page of the files changed by a pull request.
+func HandleDiffFiles(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		setSHAs := func(sourceSHA, mergeBaseSHA string) {
+			w.Header().Set("X-Source-Sha", sourceSHA)
+			w.Header().Set("X-Merge-Base-Sha", mergeBaseSHA)
+		}
+
+		page := request.ParsePage(r)
+		limit := request.ParseLimit(r)
+
+		files, total, err := pullreqCtrl.DiffFiles(ctx, session, repoRef, pullreqNumber, setSHAs, page, limit)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Pagination(r, w, page, limit, total)
+		render.JSON(w, http.StatusOK, files)
+	}
+}
+
+// HandleDiffFile returns a http.HandlerFunc that returns the diff of a single file changed by a pull request.
+func HandleDiffFile(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		setSHAs := func(sourceSHA, mergeBaseSHA string) {
+			w.Header().Set("X-Source-Sha", sourceSHA)
+			w.Header().Set("X-Merge-Base-Sha", mergeBaseSHA)
+		}
+
+		path := request.QueryParamOrDefault(r, request.QueryParamPath, "")
+
+		fileDiff, err := pullreqCtrl.DiffFile(ctx, session, repoRef, pullreqNumber, setSHAs, path)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, fileDiff)
+	}
+}
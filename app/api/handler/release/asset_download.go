@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/release"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleDownloadAsset returns a http.HandlerFunc that downloads a file attached to a release.
+func HandleDownloadAsset(releaseCtrl *release.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		releaseID, err := request.GetReleaseIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		assetID, err := request.GetReleaseAssetIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		asset, signedFileURL, file, err := releaseCtrl.DownloadAsset(ctx, session, repoRef, releaseID, assetID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if file == nil {
+			http.Redirect(w, r, signedFileURL, http.StatusTemporaryRedirect)
+			return
+		}
+
+		defer func() {
+			if cErr := file.Close(); cErr != nil {
+				log.Ctx(ctx).Warn().Err(cErr).Msg("failed to close release asset file after rendering")
+			}
+		}()
+
+		w.Header().Set("Content-Type", asset.ContentType)
+		w.Header().Set("Content-Disposition",
+			mime.FormatMediaType("attachment", map[string]string{"filename": asset.Name}))
+
+		render.Reader(ctx, w, http.StatusOK, file)
+	}
+}
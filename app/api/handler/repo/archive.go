@@ -57,6 +57,8 @@ func HandleArchive(repoCtrl *repo.Controller) http.HandlerFunc {
 
 		err = repoCtrl.Archive(ctx, session, repoRef, params, w)
 		if err != nil {
+			// the archive wasn't written (e.g. path not found), don't return the error as file download.
+			w.Header().Del("Content-Disposition")
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
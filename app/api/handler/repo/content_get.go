@@ -35,6 +35,12 @@ func HandleGetContent(repoCtrl *repo.Controller) http.HandlerFunc {
 
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
 
+		at, err := request.GetAtFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		includeCommit, err := request.GetIncludeCommitFromQueryOrDefault(r, false)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
@@ -43,7 +49,7 @@ func HandleGetContent(repoCtrl *repo.Controller) http.HandlerFunc {
 
 		repoPath := request.GetOptionalRemainderFromPath(r)
 
-		resp, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, repoPath, includeCommit)
+		resp, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, at, repoPath, includeCommit)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
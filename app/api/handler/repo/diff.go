@@ -40,6 +40,13 @@ func HandleDiff(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		path := request.GetOptionalRemainderFromPath(r)
+		sourceRepoRef := request.GetSourceRepoRefFromQuery(r)
+
+		at, err := request.GetAtFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		files := gittypes.FileDiffRequests{}
 		switch r.Method {
@@ -54,7 +61,7 @@ func HandleDiff(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		if strings.HasPrefix(r.Header.Get("Accept"), "text/plain") {
-			err := repoCtrl.RawDiff(ctx, w, session, repoRef, path, files...)
+			err := repoCtrl.RawDiff(ctx, w, session, repoRef, sourceRepoRef, path, at, files...)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusOK)
 			}
@@ -62,7 +69,7 @@ func HandleDiff(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		_, includePatch := request.QueryParam(r, "include_patch")
-		stream, err := repoCtrl.Diff(ctx, session, repoRef, path, includePatch, files...)
+		stream, err := repoCtrl.Diff(ctx, session, repoRef, sourceRepoRef, path, at, includePatch, files...)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -109,8 +116,15 @@ func HandleDiffStats(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		path := request.GetOptionalRemainderFromPath(r)
+		sourceRepoRef := request.GetSourceRepoRefFromQuery(r)
+
+		at, err := request.GetAtFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
-		output, err := repoCtrl.DiffStats(ctx, session, repoRef, path)
+		output, err := repoCtrl.DiffStats(ctx, session, repoRef, sourceRepoRef, path, at)
 		if uErr := gittypes.AsUnrelatedHistoriesError(err); uErr != nil {
 			render.JSON(w, http.StatusOK, &usererror.Error{
 				Message: uErr.Error(),
@@ -33,13 +33,18 @@ func HandleListPaths(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
+		at, err := request.GetAtFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 		includeDirectories, err := request.GetIncludeDirectoriesFromQueryOrDefault(r, false)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		out, err := repoCtrl.ListPaths(ctx, session, repoRef, gitRef, includeDirectories)
+		out, err := repoCtrl.ListPaths(ctx, session, repoRef, gitRef, at, includeDirectories)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
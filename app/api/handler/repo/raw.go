@@ -15,8 +15,14 @@
 package repo
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/render"
@@ -25,42 +31,214 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// HandleRaw returns the raw content of a file.
+const (
+	// rawSniffLen is the number of leading bytes used to detect the content type (same as http.DetectContentType).
+	rawSniffLen = 512
+
+	// rawContentSecurityPolicy prevents any active content of served files from being executed.
+	rawContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+)
+
+// HandleRaw returns the raw content of a file of the git reference provided as query parameter,
+// or of the default branch if no git reference is provided.
+// Conditional requests (If-None-Match) and range requests are supported.
 func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		session, _ := request.AuthSessionFrom(ctx)
+		serveRaw(w, r, repoCtrl, request.GetGitRefFromQueryOrDefault(r, ""))
+	}
+}
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+// HandleRawRef returns the raw content of a file of the git reference provided as path parameter,
+// which allows the file to be linked with relative links to other files of the same git reference.
+// Git references containing slashes have to be escaped (e.g. feature%2Fdocs).
+func HandleRawRef(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gitRef, err := request.GetGitRefFromPath(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.TranslatedUserError(r.Context(), w, err)
 			return
 		}
 
-		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
-		path := request.GetOptionalRemainderFromPath(r)
+		serveRaw(w, r, repoCtrl, gitRef)
+	}
+}
 
-		dataReader, dataLength, sha, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
-		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
-			return
+func serveRaw(w http.ResponseWriter, r *http.Request, repoCtrl *repo.Controller, gitRef string) {
+	ctx := r.Context()
+
+	session, _ := request.AuthSessionFrom(ctx)
+
+	repoRef, err := request.GetRepoRefFromPath(r)
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return
+	}
+
+	path := request.GetOptionalRemainderFromPath(r)
+
+	out, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return
+	}
+
+	content, err := newRawContent(ctx, out)
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return
+	}
+
+	defer func() {
+		if err := content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
 		}
+	}()
+
+	// support the unquoted blob SHA as well, as it was returned as ETag in the past.
+	ifNoneMatch, ok := request.GetIfNoneMatchFromHeader(r)
+	if ok && ifNoneMatch == out.BlobSHA.String() {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 
-		defer func() {
-			if err := dataReader.Close(); err != nil {
-				log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
-			}
-		}()
+	w.Header().Set(request.HeaderETag, fmt.Sprintf("%q", out.BlobSHA.String()))
+	w.Header().Set("Cache-Control", rawCacheControl(out))
+	w.Header().Set("Content-Type", rawContentType(out.Path, content.head))
+	w.Header().Set("Content-Security-Policy", rawContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 
-		ifNoneMatch, ok := request.GetIfNoneMatchFromHeader(r)
-		if ok && ifNoneMatch == sha.String() {
-			w.WriteHeader(http.StatusNotModified)
-			return
+	// ServeContent handles the conditional and range requests.
+	http.ServeContent(w, r, "", time.Time{}, content)
+}
+
+// rawCacheControl returns the Cache-Control header value of the file. Files referenced by commit SHA never change,
+// while files referenced by branch or tag need to be revalidated (which is cheap thanks to the ETag).
+func rawCacheControl(out *repo.RawOutput) string {
+	visibility := "private"
+	if out.IsPublic {
+		visibility = "public"
+	}
+
+	if out.Immutable {
+		return visibility + ", max-age=31536000, immutable"
+	}
+
+	return visibility + ", no-cache"
+}
+
+// rawContentType returns the content type of the file. Text content is always served as plain text,
+// to prevent browsers from rendering HTML files; SVG images are allowed because the CSP disables their scripts.
+func rawContentType(path string, head []byte) string {
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		return "image/svg+xml"
+	}
+
+	contentType := http.DetectContentType(head)
+	if strings.HasPrefix(contentType, "text/") {
+		return "text/plain; charset=utf-8"
+	}
+
+	return contentType
+}
+
+// rawContent is an io.ReadSeeker over the blob content. The blob is streamed from git,
+// seeking forward skips the data and seeking backward reopens the blob.
+// The position is changed lazily on the next read, so seeking to the end to get the size is free.
+type rawContent struct {
+	ctx    context.Context
+	out    *repo.RawOutput
+	head   []byte
+	reader io.ReadCloser
+	pos    int64
+	target int64
+}
+
+func newRawContent(ctx context.Context, out *repo.RawOutput) (*rawContent, error) {
+	head := make([]byte, rawSniffLen)
+	n, err := io.ReadFull(out.Content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF { //nolint:errorlint // io.ReadFull returns these as is
+		_ = out.Content.Close()
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	head = head[:n]
+
+	return &rawContent{
+		ctx:  ctx,
+		out:  out,
+		head: head,
+		reader: struct {
+			io.Reader
+			io.Closer
+		}{
+			Reader: io.MultiReader(bytes.NewReader(head), out.Content),
+			Closer: out.Content,
+		},
+	}, nil
+}
+
+func (c *rawContent) Read(p []byte) (int, error) {
+	if c.target != c.pos {
+		if err := c.reposition(); err != nil {
+			return 0, err
 		}
+	}
+
+	n, err := c.reader.Read(p)
+	c.pos += int64(n)
+	c.target = c.pos
+
+	return n, err
+}
+
+func (c *rawContent) Seek(offset int64, whence int) (int64, error) {
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = c.target + offset
+	case io.SeekEnd:
+		target = c.out.Size + offset
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
 
-		w.Header().Add("Content-Length", fmt.Sprint(dataLength))
-		w.Header().Add(request.HeaderETag, sha.String())
-		render.Reader(ctx, w, http.StatusOK, dataReader)
+	if target < 0 {
+		return 0, fmt.Errorf("negative position %d", target)
 	}
+
+	c.target = target
+
+	return target, nil
+}
+
+func (c *rawContent) reposition() error {
+	if c.target < c.pos {
+		if err := c.reader.Close(); err != nil {
+			log.Ctx(c.ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+
+		reader, err := c.out.Reopen(c.ctx)
+		if err != nil {
+			return err
+		}
+
+		c.reader = reader
+		c.pos = 0
+	}
+
+	skipped, err := io.CopyN(io.Discard, c.reader, c.target-c.pos)
+	c.pos += skipped
+	if err != nil && err != io.EOF { //nolint:errorlint // io.CopyN returns io.EOF as is
+		return fmt.Errorf("failed to skip blob content: %w", err)
+	}
+
+	c.target = c.pos
+
+	return nil
+}
+
+func (c *rawContent) Close() error {
+	return c.reader.Close()
 }
@@ -17,36 +17,41 @@ package resource
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/render"
-	"github.com/harness/gitness/resources"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
 
-func HandleGitIgnores() http.HandlerFunc {
+func HandleGitIgnores(sysCtrl *system.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		files, err := resources.GitIgnores()
+		templates, err := sysCtrl.FileTemplateList(ctx, enum.FileTemplateTypeGitIgnore)
 		if err != nil {
 			log.Ctx(ctx).Err(err).Msgf("Failed to load gitignore files")
 			render.InternalError(ctx, w)
 			return
 		}
+
+		files := make([]string, len(templates))
+		for i, template := range templates {
+			files[i] = template.Value
+		}
+
 		render.JSON(w, http.StatusOK, files)
 	}
 }
 
-func HandleLicences() http.HandlerFunc {
+func HandleLicences(sysCtrl *system.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		response, err := resources.Licenses()
+		response, err := sysCtrl.FileTemplateList(ctx, enum.FileTemplateTypeLicense)
 		if err != nil {
 			log.Ctx(ctx).Err(err).Msgf("Failed to load license files")
 			render.InternalError(ctx, w)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(response)
+		render.JSON(w, http.StatusOK, response)
 	}
 }
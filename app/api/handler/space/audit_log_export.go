@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleAuditLogExport writes the audit events of a space in the requested time range as a file download.
+func HandleAuditLogExport(spaceCtrl *space.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		filter, err := request.ParseAuditEventFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		format, err := request.ParseAuditLogExportFormat(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		contentType := "application/x-ndjson"
+		if format == enum.AuditLogExportFormatCSV {
+			contentType = "text/csv"
+		}
+
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=audit-log.%s", format))
+		w.Header().Set("Content-Type", contentType)
+
+		err = spaceCtrl.AuditLogExport(ctx, session, spaceRef, filter, format, w)
+		if err != nil {
+			// the export might have failed before anything was written (e.g. invalid time range).
+			w.Header().Del("Content-Disposition")
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+	}
+}
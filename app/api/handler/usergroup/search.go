@@ -32,6 +32,7 @@ func HandleList(usergroupCtrl *usergroup.Controller) http.HandlerFunc {
 		spaceRef, err := request.GetSpaceRefFromPath(r)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
+			return
 		}
 
 		userGroupInfos, err := usergroupCtrl.List(ctx, session, &filter, spaceRef)
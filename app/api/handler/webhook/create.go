@@ -51,3 +51,32 @@ func HandleCreate(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		render.JSON(w, http.StatusCreated, hook)
 	}
 }
+
+// HandleCreateSpace returns a http.HandlerFunc that creates a new webhook of a space.
+func HandleCreateSpace(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(webhook.CreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		hook, err := webhookCtrl.CreateSpace(ctx, session, spaceRef, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, hook)
+	}
+}
@@ -49,3 +49,31 @@ func HandleDelete(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		render.DeleteSuccessful(w)
 	}
 }
+
+// HandleDeleteSpace returns a http.HandlerFunc that deletes a webhook of a space.
+func HandleDeleteSpace(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		webhookIdentifier, err := request.GetWebhookIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		err = webhookCtrl.DeleteSpace(ctx, session, spaceRef, webhookIdentifier)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
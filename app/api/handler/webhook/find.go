@@ -49,3 +49,31 @@ func HandleFind(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		render.JSON(w, http.StatusOK, webhook)
 	}
 }
+
+// HandleFindSpace returns a http.HandlerFunc that finds a webhook of a space.
+func HandleFindSpace(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		webhookIdentifier, err := request.GetWebhookIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		webhook, err := webhookCtrl.FindSpace(ctx, session, spaceRef, webhookIdentifier)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, webhook)
+	}
+}
@@ -53,3 +53,34 @@ func HandleList(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		render.JSON(w, http.StatusOK, webhooks)
 	}
 }
+
+// HandleListSpace returns a http.HandlerFunc that lists webhooks of a space.
+func HandleListSpace(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		filter := request.ParseWebhookFilter(r)
+		if filter.Order == enum.OrderDefault {
+			filter.Order = enum.OrderAsc
+		}
+
+		// always skip internal for requests from handler
+		filter.SkipInternal = true
+
+		webhooks, totalCount, err := webhookCtrl.ListSpace(ctx, session, spaceRef, filter)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
+		render.JSON(w, http.StatusOK, webhooks)
+	}
+}
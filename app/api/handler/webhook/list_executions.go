@@ -54,3 +54,36 @@ func HandleListExecutions(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		render.JSON(w, http.StatusOK, executions)
 	}
 }
+
+// HandleListExecutionsSpace returns a http.HandlerFunc that lists webhook executions of a space.
+func HandleListExecutionsSpace(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		webhookIdentifier, err := request.GetWebhookIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		filter := request.ParseWebhookExecutionFilter(r)
+
+		executions, err := webhookCtrl.ListExecutionsSpace(ctx, session, spaceRef, webhookIdentifier, filter)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// TODO: get last page indicator explicitly - current check is wrong in case len % pageSize == 0
+		isLastPage := len(executions) < filter.Size
+		render.PaginationNoTotal(r, w, filter.Page, filter.Size, isLastPage)
+		render.JSON(w, http.StatusOK, executions)
+	}
+}
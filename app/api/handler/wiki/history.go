@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/wiki"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+// HandlePageHistory returns a http.HandlerFunc that lists the commits that changed a wiki page.
+func HandlePageHistory(wikiCtrl *wiki.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		name, err := request.GetWikiPageNameFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		filter := &types.PaginationFilter{
+			Page:  request.ParsePage(r),
+			Limit: request.ParseLimit(r),
+		}
+
+		commits, err := wikiCtrl.PageHistory(ctx, session, repoRef, name, filter)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		isLastPage := len(commits) < filter.Limit
+		render.PaginationNoTotal(r, w, filter.Page, filter.Limit, isLastPage)
+		render.JSON(w, http.StatusOK, commits)
+	}
+}
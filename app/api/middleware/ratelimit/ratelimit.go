@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/ratelimiter"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	headerLimit      = "X-RateLimit-Limit"
+	headerRemaining  = "X-RateLimit-Remaining"
+	headerReset      = "X-RateLimit-Reset"
+	headerRetryAfter = "Retry-After"
+)
+
+// Limit returns an http.HandlerFunc middleware that rate limits the requests of the class.
+// It has to run after the authentication, to limit the requests of principals instead of their IP addresses.
+func Limit(svc *ratelimiter.Service, class enum.RateLimitClass) func(http.Handler) http.Handler {
+	return limit(svc, func(*http.Request) enum.RateLimitClass { return class })
+}
+
+// LimitAPI returns an http.HandlerFunc middleware that rate limits API requests,
+// with separate limits for requests that only read and requests that change resources.
+func LimitAPI(svc *ratelimiter.Service) func(http.Handler) http.Handler {
+	return limit(svc, func(r *http.Request) enum.RateLimitClass {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return enum.RateLimitClassAPIRead
+		default:
+			return enum.RateLimitClassAPIWrite
+		}
+	})
+}
+
+func limit(
+	svc *ratelimiter.Service,
+	classFn func(*http.Request) enum.RateLimitClass,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			var principal *types.Principal
+			if session, ok := request.AuthSessionFrom(ctx); ok && !auth.IsAnonymousSession(session) {
+				principal = &session.Principal
+			}
+
+			class := classFn(r)
+
+			res, err := svc.Take(ctx, class, principal, svc.ClientIP(r))
+			if err != nil {
+				// requests aren't rejected if the rate limit backend is unavailable.
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to apply rate limit of class %q", class)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if res == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reset := strconv.Itoa(int(math.Ceil(res.Reset.Seconds())))
+
+			w.Header().Set(headerLimit, strconv.Itoa(res.Limit))
+			w.Header().Set(headerRemaining, strconv.Itoa(res.Remaining))
+			w.Header().Set(headerReset, reset)
+
+			if !res.Allowed {
+				w.Header().Set(headerRetryAfter, reset)
+				render.UserError(ctx, w, usererror.ErrTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/passkey"
 	"github.com/harness/gitness/types"
 
 	"github.com/gotidy/ptr"
@@ -65,6 +66,26 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
+	opLoginWebAuthnBegin := openapi3.Operation{}
+	opLoginWebAuthnBegin.WithTags("account")
+	opLoginWebAuthnBegin.WithMapOfAnything(map[string]interface{}{"operationId": "loginWebAuthnBegin"})
+	_ = reflector.SetRequest(&opLoginWebAuthnBegin, new(user.LoginWebAuthnBeginInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthnBegin, new(passkey.LoginChallenge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthnBegin, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthnBegin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/webauthn/begin", opLoginWebAuthnBegin)
+
+	opLoginWebAuthn := openapi3.Operation{}
+	opLoginWebAuthn.WithTags("account")
+	opLoginWebAuthn.WithMapOfAnything(map[string]interface{}{"operationId": "loginWebAuthn"})
+	opLoginWebAuthn.WithParameters(queryParameterIncludeCookie)
+	_ = reflector.SetRequest(&opLoginWebAuthn, new(passkey.AssertionInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthn, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthn, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthn, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLoginWebAuthn, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/webauthn", opLoginWebAuthn)
+
 	opLogout := openapi3.Operation{}
 	opLogout.WithTags("account")
 	opLogout.WithMapOfAnything(map[string]interface{}{"operationId": "opLogout"})
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/banner"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type bannerRequest struct {
+	Identifier string `path:"banner_identifier"`
+}
+
+func bannerOperations(reflector *openapi3.Reflector) {
+	opListActive := openapi3.Operation{}
+	opListActive.WithTags("system")
+	opListActive.WithMapOfAnything(map[string]interface{}{"operationId": "listActiveBanners"})
+	_ = reflector.SetRequest(&opListActive, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListActive, new([]types.BannerInfo), http.StatusOK)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/banners", opListActive)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListBanners"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Banner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/banners", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindBanner"})
+	_ = reflector.SetRequest(&opFind, new(bannerRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Banner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/banners/{banner_identifier}", opFind)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("admin")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateBanner"})
+	_ = reflector.SetRequest(&opCreate, new(banner.CreateInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Banner), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/banners", opCreate)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateBanner"})
+	_ = reflector.SetRequest(&opUpdate, &struct {
+		bannerRequest
+		banner.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Banner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/banners/{banner_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteBanner"})
+	_ = reflector.SetRequest(&opDelete, new(bannerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/banners/{banner_identifier}", opDelete)
+}
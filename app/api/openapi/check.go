@@ -106,4 +106,52 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/recent",
 		listStatusCheckRecent)
+
+	reportCommitStatus := openapi3.Operation{}
+	reportCommitStatus.WithTags(tag)
+	reportCommitStatus.WithMapOfAnything(map[string]interface{}{"operationId": "reportCommitStatus"})
+	_ = reflector.SetRequest(&reportCommitStatus, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		check.StatusInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&reportCommitStatus, new(types.Check), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&reportCommitStatus, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reportCommitStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reportCommitStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reportCommitStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/{commit_sha}/statuses",
+		reportCommitStatus)
+
+	listCommitStatuses := openapi3.Operation{}
+	listCommitStatuses.WithTags(tag)
+	listCommitStatuses.WithParameters(
+		QueryParameterPage, QueryParameterLimit, queryParameterStatusCheckQuery)
+	listCommitStatuses.WithMapOfAnything(map[string]interface{}{"operationId": "listCommitStatuses"})
+	_ = reflector.SetRequest(&listCommitStatuses, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listCommitStatuses, new([]types.Check), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listCommitStatuses, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listCommitStatuses, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listCommitStatuses, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listCommitStatuses, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/statuses",
+		listCommitStatuses)
+
+	getCombinedCommitStatus := openapi3.Operation{}
+	getCombinedCommitStatus.WithTags(tag)
+	getCombinedCommitStatus.WithMapOfAnything(map[string]interface{}{"operationId": "getCombinedCommitStatus"})
+	_ = reflector.SetRequest(&getCombinedCommitStatus, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(types.CombinedCheckStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/status",
+		getCombinedCommitStatus)
 }
@@ -0,0 +1,322 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/issue"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type issueRequest struct {
+	repoRequest
+	Number int64 `path:"issue_number"`
+}
+
+type createIssueRequest struct {
+	repoRequest
+	issue.CreateInput
+}
+
+type listIssueRequest struct {
+	repoRequest
+}
+
+type updateIssueRequest struct {
+	issueRequest
+	issue.UpdateInput
+}
+
+type stateIssueRequest struct {
+	issueRequest
+	issue.StateInput
+}
+
+type issueCommentRequest struct {
+	issueRequest
+	ID int64 `path:"issue_comment_id"`
+}
+
+type commentCreateIssueRequest struct {
+	issueRequest
+	issue.CommentCreateInput
+}
+
+type commentUpdateIssueRequest struct {
+	issueCommentRequest
+	issue.CommentUpdateInput
+}
+
+type assigneeAddIssueRequest struct {
+	issueRequest
+	issue.AssigneeAddInput
+}
+
+type assigneeDeleteIssueRequest struct {
+	issueRequest
+	AssigneeID int64 `path:"assignee_id"`
+}
+
+type issueAssignLabelRequest struct {
+	issueRequest
+	types.PullReqCreateInput
+}
+
+type issueUnassignLabelRequest struct {
+	issueRequest
+	LabelID int64 `path:"label_id"`
+}
+
+var queryParameterStateIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the issues to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.IssueState("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterQueryIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the issues are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCreatedByIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCreatedBy,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of principal IDs who created issues."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		// making it look like created_by=1&created_by=2
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAssigneeIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAssigneeID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The principal ID of the assignee of the issues."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func issueOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("issue")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createIssue"})
+	_ = reflector.SetRequest(&opCreate, new(createIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Issue), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issues", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("issue")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listIssues"})
+	opList.WithParameters(queryParameterStateIssue, queryParameterQueryIssue,
+		queryParameterCreatedByIssue, queryParameterAssigneeIssue, queryParameterMilestoneID, queryParameterOrder,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(listIssueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("issue")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "getIssue"})
+	_ = reflector.SetRequest(&opFind, new(issueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues/{issue_number}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("issue")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateIssue"})
+	_ = reflector.SetRequest(&opUpdate, new(updateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/issues/{issue_number}", opUpdate)
+
+	opState := openapi3.Operation{}
+	opState.WithTags("issue")
+	opState.WithMapOfAnything(map[string]interface{}{"operationId": "stateIssue"})
+	_ = reflector.SetRequest(&opState, new(stateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opState, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opState, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opState, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opState, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opState, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opState, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issues/{issue_number}/state", opState)
+
+	opCommentList := openapi3.Operation{}
+	opCommentList.WithTags("issue")
+	opCommentList.WithMapOfAnything(map[string]interface{}{"operationId": "listIssueComments"})
+	opCommentList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opCommentList, new(issueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCommentList, new([]types.IssueComment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommentList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues/{issue_number}/comments", opCommentList)
+
+	opCommentCreate := openapi3.Operation{}
+	opCommentCreate.WithTags("issue")
+	opCommentCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createIssueComment"})
+	_ = reflector.SetRequest(&opCommentCreate, new(commentCreateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCommentCreate, new(types.IssueComment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCommentCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommentCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues/{issue_number}/comments", opCommentCreate)
+
+	opCommentUpdate := openapi3.Operation{}
+	opCommentUpdate.WithTags("issue")
+	opCommentUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateIssueComment"})
+	_ = reflector.SetRequest(&opCommentUpdate, new(commentUpdateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opCommentUpdate, new(types.IssueComment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommentUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommentUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/issues/{issue_number}/comments/{issue_comment_id}", opCommentUpdate)
+
+	opCommentDelete := openapi3.Operation{}
+	opCommentDelete.WithTags("issue")
+	opCommentDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteIssueComment"})
+	_ = reflector.SetRequest(&opCommentDelete, new(issueCommentRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCommentDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opCommentDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/comments/{issue_comment_id}", opCommentDelete)
+
+	opAssigneeAdd := openapi3.Operation{}
+	opAssigneeAdd.WithTags("issue")
+	opAssigneeAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addIssueAssignee"})
+	_ = reflector.SetRequest(&opAssigneeAdd, new(assigneeAddIssueRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opAssigneeAdd, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAssigneeAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAssigneeAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAssigneeAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAssigneeAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAssigneeAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/issues/{issue_number}/assignees", opAssigneeAdd)
+
+	opAssigneeDelete := openapi3.Operation{}
+	opAssigneeDelete.WithTags("issue")
+	opAssigneeDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteIssueAssignee"})
+	_ = reflector.SetRequest(&opAssigneeDelete, new(assigneeDeleteIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAssigneeDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAssigneeDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAssigneeDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAssigneeDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAssigneeDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/assignees/{assignee_id}", opAssigneeDelete)
+
+	opAssignLabel := openapi3.Operation{}
+	opAssignLabel.WithTags("issue")
+	opAssignLabel.WithMapOfAnything(map[string]interface{}{"operationId": "assignIssueLabel"})
+	_ = reflector.SetRequest(&opAssignLabel, new(issueAssignLabelRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opAssignLabel, new(types.IssueLabel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAssignLabel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAssignLabel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAssignLabel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAssignLabel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAssignLabel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/issues/{issue_number}/labels", opAssignLabel)
+
+	opUnassignLabel := openapi3.Operation{}
+	opUnassignLabel.WithTags("issue")
+	opUnassignLabel.WithMapOfAnything(map[string]interface{}{"operationId": "unassignIssueLabel"})
+	_ = reflector.SetRequest(&opUnassignLabel, new(issueUnassignLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnassignLabel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUnassignLabel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnassignLabel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnassignLabel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnassignLabel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/labels/{label_id}", opUnassignLabel)
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type ldapGroupMappingRequest struct {
+	ID int64 `path:"ldap_group_mapping_id"`
+}
+
+func ldapOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListLDAPGroupMappings"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.LDAPGroupMapping), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/ldap/group-mappings", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("admin")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateLDAPGroupMapping"})
+	_ = reflector.SetRequest(&opCreate, new(ldap.GroupMappingCreateInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.LDAPGroupMapping), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/ldap/group-mappings", opCreate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteLDAPGroupMapping"})
+	_ = reflector.SetRequest(&opDelete, new(ldapGroupMappingRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/ldap/group-mappings/{ldap_group_mapping_id}", opDelete)
+
+	opSync := openapi3.Operation{}
+	opSync.WithTags("admin")
+	opSync.WithMapOfAnything(map[string]interface{}{"operationId": "adminLDAPSync"})
+	_ = reflector.SetRequest(&opSync, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSync, new(types.LDAPSyncReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSync, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSync, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSync, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSync, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opSync, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/ldap/sync", opSync)
+
+	opDryRun := openapi3.Operation{}
+	opDryRun.WithTags("admin")
+	opDryRun.WithMapOfAnything(map[string]interface{}{"operationId": "adminLDAPSyncDryRun"})
+	_ = reflector.SetRequest(&opDryRun, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDryRun, new(types.LDAPSyncReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDryRun, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDryRun, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDryRun, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDryRun, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opDryRun, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/ldap/sync/dry-run", opDryRun)
+}
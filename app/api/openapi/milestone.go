@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/milestone"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type milestoneRequest struct {
+	repoRequest
+	ID int64 `path:"milestone_id"`
+}
+
+type createMilestoneRequest struct {
+	repoRequest
+	milestone.CreateInput
+}
+
+type listMilestoneRequest struct {
+	repoRequest
+}
+
+type updateMilestoneRequest struct {
+	milestoneRequest
+	milestone.UpdateInput
+}
+
+type milestonePullReqRequest struct {
+	milestoneRequest
+	Number int64 `path:"pullreq_number"`
+}
+
+type milestoneIssueRequest struct {
+	milestoneRequest
+	Number int64 `path:"issue_number"`
+}
+
+var queryParameterStateMilestone = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the milestones to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.MilestoneState("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterQueryMilestone = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the milestones are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterMilestoneID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMilestoneID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the milestone the results are assigned to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func milestoneOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("milestone")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createMilestone"})
+	_ = reflector.SetRequest(&opCreate, new(createMilestoneRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Milestone), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/milestones", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("milestone")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listMilestones"})
+	opList.WithParameters(queryParameterStateMilestone, queryParameterQueryMilestone,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(listMilestoneRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/milestones", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("milestone")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "getMilestone"})
+	_ = reflector.SetRequest(&opFind, new(milestoneRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/milestones/{milestone_id}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("milestone")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateMilestone"})
+	_ = reflector.SetRequest(&opUpdate, new(updateMilestoneRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/milestones/{milestone_id}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("milestone")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteMilestone"})
+	_ = reflector.SetRequest(&opDelete, new(milestoneRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/milestones/{milestone_id}", opDelete)
+
+	opAssignPullReq := openapi3.Operation{}
+	opAssignPullReq.WithTags("milestone")
+	opAssignPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "assignMilestonePullReq"})
+	_ = reflector.SetRequest(&opAssignPullReq, new(milestonePullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opAssignPullReq, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAssignPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAssignPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAssignPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAssignPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/milestones/{milestone_id}/pullreq/{pullreq_number}", opAssignPullReq)
+
+	opUnassignPullReq := openapi3.Operation{}
+	opUnassignPullReq.WithTags("milestone")
+	opUnassignPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "unassignMilestonePullReq"})
+	_ = reflector.SetRequest(&opUnassignPullReq, new(milestonePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnassignPullReq, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUnassignPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnassignPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnassignPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnassignPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/milestones/{milestone_id}/pullreq/{pullreq_number}", opUnassignPullReq)
+
+	opAssignIssue := openapi3.Operation{}
+	opAssignIssue.WithTags("milestone")
+	opAssignIssue.WithMapOfAnything(map[string]interface{}{"operationId": "assignMilestoneIssue"})
+	_ = reflector.SetRequest(&opAssignIssue, new(milestoneIssueRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opAssignIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAssignIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAssignIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAssignIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAssignIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/milestones/{milestone_id}/issues/{issue_number}", opAssignIssue)
+
+	opUnassignIssue := openapi3.Operation{}
+	opUnassignIssue.WithTags("milestone")
+	opUnassignIssue.WithMapOfAnything(map[string]interface{}{"operationId": "unassignMilestoneIssue"})
+	_ = reflector.SetRequest(&opUnassignIssue, new(milestoneIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnassignIssue, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUnassignIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnassignIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnassignIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnassignIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/milestones/{milestone_id}/issues/{issue_number}", opUnassignIssue)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type oauthProviderRequest struct {
+	Identifier string `path:"oauth_provider_identifier"`
+}
+
+type oauthProviderCallbackRequest struct {
+	oauthProviderRequest
+	Code  string `query:"code"`
+	State string `query:"state"`
+	Error string `query:"error"`
+}
+
+type updateOAuthProviderRequest struct {
+	oauthProviderRequest
+	oauth.ProviderUpdateInput
+}
+
+func oauthProviderOperations(reflector *openapi3.Reflector) {
+	opListEnabled := openapi3.Operation{}
+	opListEnabled.WithTags("account")
+	opListEnabled.WithMapOfAnything(map[string]interface{}{"operationId": "listLoginOAuthProviders"})
+	_ = reflector.SetRequest(&opListEnabled, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListEnabled, new([]types.OAuthProviderInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListEnabled, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/oauth", opListEnabled)
+
+	opLogin := openapi3.Operation{}
+	opLogin.WithTags("account")
+	opLogin.WithMapOfAnything(map[string]interface{}{"operationId": "loginOAuth"})
+	_ = reflector.SetRequest(&opLogin, new(oauthProviderRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/oauth/{oauth_provider_identifier}", opLogin)
+
+	opCallback := openapi3.Operation{}
+	opCallback.WithTags("account")
+	opCallback.WithMapOfAnything(map[string]interface{}{"operationId": "loginOAuthCallback"})
+	_ = reflector.SetRequest(&opCallback, new(oauthProviderCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCallback, nil, http.StatusFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/login/oauth/{oauth_provider_identifier}/callback", opCallback)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListOAuthProviders"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.OAuthProvider), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/oauth-providers", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("admin")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateOAuthProvider"})
+	_ = reflector.SetRequest(&opCreate, new(oauth.ProviderCreateInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.OAuthProvider), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/oauth-providers", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindOAuthProvider"})
+	_ = reflector.SetRequest(&opFind, new(oauthProviderRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.OAuthProvider), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/oauth-providers/{oauth_provider_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateOAuthProvider"})
+	_ = reflector.SetRequest(&opUpdate, new(updateOAuthProviderRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.OAuthProvider), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/oauth-providers/{oauth_provider_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteOAuthProvider"})
+	_ = reflector.SetRequest(&opDelete, new(oauthProviderRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/oauth-providers/{oauth_provider_identifier}", opDelete)
+}
@@ -57,6 +57,16 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	buildAccount(&reflector)
 	buildUser(&reflector)
 	buildAdmin(&reflector)
+	oauthProviderOperations(&reflector)
+	samlProviderOperations(&reflector)
+	ldapOperations(&reflector)
+	rateLimitOperations(&reflector)
+	roleOperations(&reflector)
+	bannerOperations(&reflector)
+	repoMembershipOperations(&reflector)
+	userGroupOperations(&reflector)
+	buildReplication(&reflector)
+	buildRunner(&reflector)
 	buildPrincipals(&reflector)
 	spaceOperations(&reflector)
 	pluginOperations(&reflector)
@@ -67,9 +77,16 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	secretOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
+	issueOperations(&reflector)
+	milestoneOperations(&reflector)
+	releaseOperations(&reflector)
+	wikiOperations(&reflector)
+	repoInsightsOperations(&reflector)
 	webhookOperations(&reflector)
+	spaceWebhookOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
+	searchOperations(&reflector)
 	gitspaceOperations(&reflector)
 	infraProviderOperations(&reflector)
 
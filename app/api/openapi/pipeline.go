@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
@@ -38,11 +39,41 @@ type executionRequest struct {
 	Number string `path:"execution_number"`
 }
 
+type retryExecutionRequest struct {
+	executionRequest
+	execution.RetryInput
+}
+
 type triggerRequest struct {
 	pipelineRequest
 	Identifier string `path:"trigger_identifier"`
 }
 
+type cronRequest struct {
+	pipelineRequest
+	Identifier string `path:"cron_identifier"`
+}
+
+type createCronRequest struct {
+	pipelineRequest
+	trigger.CronCreateInput
+}
+
+type updateCronRequest struct {
+	cronRequest
+	trigger.CronUpdateInput
+}
+
+type failedTriggerRequest struct {
+	repoRequest
+	ID int64 `path:"trigger_request_id"`
+}
+
+type pipelineCacheRequest struct {
+	repoRequest
+	Key string `path:"cache_key"`
+}
+
 type logRequest struct {
 	executionRequest
 	StageNum string `path:"stage_number"`
@@ -51,6 +82,7 @@ type logRequest struct {
 
 type createExecutionRequest struct {
 	pipelineRequest
+	execution.CreateInput
 }
 
 type createTriggerRequest struct {
@@ -63,10 +95,20 @@ type createPipelineRequest struct {
 	pipeline.CreateInput
 }
 
+type validatePipelineRequest struct {
+	repoRequest
+	pipeline.ValidateInput
+}
+
 type getExecutionRequest struct {
 	executionRequest
 }
 
+type artifactRequest struct {
+	executionRequest
+	Name string `path:"artifact_name"`
+}
+
 type getTriggerRequest struct {
 	triggerRequest
 }
@@ -85,6 +127,21 @@ type updatePipelineRequest struct {
 	pipeline.UpdateInput
 }
 
+var queryParameterLogOffset = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLogOffset,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The position of the first log line to stream. Ignored if the Last-Event-ID header is set."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(0),
+			},
+		},
+	},
+}
+
 var queryParameterLatest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamLatest,
@@ -137,6 +194,29 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opPipelines, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipelines", opPipelines)
 
+	opPipelinesCCTray := openapi3.Operation{}
+	opPipelinesCCTray.WithTags("pipeline")
+	opPipelinesCCTray.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelinesCCTray"})
+	_ = reflector.SetRequest(&opPipelinesCCTray, new(repoRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opPipelinesCCTray, http.StatusOK, "application/xml")
+	_ = reflector.SetJSONResponse(&opPipelinesCCTray, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPipelinesCCTray, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPipelinesCCTray, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPipelinesCCTray, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipelines/cc.xml", opPipelinesCCTray)
+
+	opValidate := openapi3.Operation{}
+	opValidate.WithTags("pipeline")
+	opValidate.WithMapOfAnything(map[string]interface{}{"operationId": "validatePipeline"})
+	_ = reflector.SetRequest(&opValidate, new(validatePipelineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opValidate, new(types.PipelineValidation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipelines/validate", opValidate)
+
 	opFind := openapi3.Operation{}
 	opFind.WithTags("pipeline")
 	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPipeline"})
@@ -185,6 +265,19 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions", executionCreate)
 
+	pipelineInputs := openapi3.Operation{}
+	pipelineInputs.WithTags("pipeline")
+	pipelineInputs.WithParameters(queryParameterBranch)
+	pipelineInputs.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelineInputs"})
+	_ = reflector.SetRequest(&pipelineInputs, new(pipelineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&pipelineInputs, []types.PipelineInput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&pipelineInputs, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&pipelineInputs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&pipelineInputs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&pipelineInputs, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/inputs", pipelineInputs)
+
 	executionFind := openapi3.Operation{}
 	executionFind.WithTags("pipeline")
 	executionFind.WithMapOfAnything(map[string]interface{}{"operationId": "findExecution"})
@@ -209,6 +302,31 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/cancel", executionCancel)
 
+	executionGraph := openapi3.Operation{}
+	executionGraph.WithTags("pipeline")
+	executionGraph.WithMapOfAnything(map[string]interface{}{"operationId": "getExecutionGraph"})
+	_ = reflector.SetRequest(&executionGraph, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionGraph, new(types.ExecutionGraph), http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionGraph, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionGraph, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionGraph, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionGraph, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/graph", executionGraph)
+
+	executionRetry := openapi3.Operation{}
+	executionRetry.WithTags("pipeline")
+	executionRetry.WithMapOfAnything(map[string]interface{}{"operationId": "retryExecution"})
+	_ = reflector.SetRequest(&executionRetry, new(retryExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionRetry, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/retry", executionRetry)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExecution"})
@@ -221,6 +339,60 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodDelete,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}", executionDelete)
 
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listArtifacts"})
+	_ = reflector.SetRequest(&artifactList, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.PipelineArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&artifactDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&artifactDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactDownload)
+
+	artifactUpload := openapi3.Operation{}
+	artifactUpload.WithTags("pipeline")
+	artifactUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadArtifact"})
+	_ = reflector.SetRequest(&artifactUpload, new(artifactRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(types.PipelineArtifact), http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactUpload)
+
+	artifactDelete := openapi3.Operation{}
+	artifactDelete.WithTags("pipeline")
+	artifactDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteArtifact"})
+	_ = reflector.SetRequest(&artifactDelete, new(artifactRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&artifactDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactDelete)
+
 	executionList := openapi3.Operation{}
 	executionList.WithTags("pipeline")
 	executionList.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutions"})
@@ -296,6 +468,169 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/triggers", triggerList)
 
+	failedTriggerList := openapi3.Operation{}
+	failedTriggerList.WithTags("pipeline")
+	failedTriggerList.WithMapOfAnything(map[string]interface{}{"operationId": "listFailedTriggers"})
+	failedTriggerList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&failedTriggerList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&failedTriggerList, []types.TriggerRequest{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&failedTriggerList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&failedTriggerList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&failedTriggerList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&failedTriggerList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/failed-triggers", failedTriggerList)
+
+	failedTriggerRetry := openapi3.Operation{}
+	failedTriggerRetry.WithTags("pipeline")
+	failedTriggerRetry.WithMapOfAnything(map[string]interface{}{"operationId": "retryFailedTrigger"})
+	_ = reflector.SetRequest(&failedTriggerRetry, new(failedTriggerRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&failedTriggerRetry, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&failedTriggerRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&failedTriggerRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&failedTriggerRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&failedTriggerRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/failed-triggers/{trigger_request_id}/retry", failedTriggerRetry)
+
+	failedTriggerDelete := openapi3.Operation{}
+	failedTriggerDelete.WithTags("pipeline")
+	failedTriggerDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteFailedTrigger"})
+	_ = reflector.SetRequest(&failedTriggerDelete, new(failedTriggerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&failedTriggerDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&failedTriggerDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&failedTriggerDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&failedTriggerDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&failedTriggerDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/failed-triggers/{trigger_request_id}", failedTriggerDelete)
+
+	cacheUsage := openapi3.Operation{}
+	cacheUsage.WithTags("pipeline")
+	cacheUsage.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelineCaches"})
+	_ = reflector.SetRequest(&cacheUsage, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cacheUsage, new(types.PipelineCacheUsage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&cacheUsage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheUsage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheUsage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheUsage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/caches", cacheUsage)
+
+	cacheRestore := openapi3.Operation{}
+	cacheRestore.WithTags("pipeline")
+	cacheRestore.WithMapOfAnything(map[string]interface{}{"operationId": "restorePipelineCache"})
+	_ = reflector.SetRequest(&cacheRestore, new(pipelineCacheRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&cacheRestore, http.StatusOK, "application/gzip")
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/caches/{cache_key}", cacheRestore)
+
+	cacheSave := openapi3.Operation{}
+	cacheSave.WithTags("pipeline")
+	cacheSave.WithMapOfAnything(map[string]interface{}{"operationId": "savePipelineCache"})
+	_ = reflector.SetRequest(&cacheSave, new(pipelineCacheRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&cacheSave, new(types.PipelineCache), http.StatusOK)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/caches/{cache_key}", cacheSave)
+
+	cacheDelete := openapi3.Operation{}
+	cacheDelete.WithTags("pipeline")
+	cacheDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePipelineCache"})
+	_ = reflector.SetRequest(&cacheDelete, new(pipelineCacheRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&cacheDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/caches/{cache_key}", cacheDelete)
+
+	cronCreate := openapi3.Operation{}
+	cronCreate.WithTags("pipeline")
+	cronCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createCron"})
+	_ = reflector.SetRequest(&cronCreate, new(createCronRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&cronCreate, new(types.Cron), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&cronCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cronCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cronCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cronCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cronCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/crons", cronCreate)
+
+	cronFind := openapi3.Operation{}
+	cronFind.WithTags("pipeline")
+	cronFind.WithMapOfAnything(map[string]interface{}{"operationId": "findCron"})
+	_ = reflector.SetRequest(&cronFind, new(cronRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cronFind, new(types.Cron), http.StatusOK)
+	_ = reflector.SetJSONResponse(&cronFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cronFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cronFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cronFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/crons/{cron_identifier}", cronFind)
+
+	cronUpdate := openapi3.Operation{}
+	cronUpdate.WithTags("pipeline")
+	cronUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateCron"})
+	_ = reflector.SetRequest(&cronUpdate, new(updateCronRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&cronUpdate, new(types.Cron), http.StatusOK)
+	_ = reflector.SetJSONResponse(&cronUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cronUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cronUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cronUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cronUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/crons/{cron_identifier}", cronUpdate)
+
+	cronDelete := openapi3.Operation{}
+	cronDelete.WithTags("pipeline")
+	cronDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteCron"})
+	_ = reflector.SetRequest(&cronDelete, new(cronRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&cronDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&cronDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cronDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cronDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cronDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/crons/{cron_identifier}", cronDelete)
+
+	cronList := openapi3.Operation{}
+	cronList.WithTags("pipeline")
+	cronList.WithMapOfAnything(map[string]interface{}{"operationId": "listCrons"})
+	cronList.WithParameters(queryParameterQueryRepo, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&cronList, new(pipelineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cronList, []types.Cron{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&cronList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cronList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cronList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cronList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/crons", cronList)
+
+	cronUpcoming := openapi3.Operation{}
+	cronUpcoming.WithTags("pipeline")
+	cronUpcoming.WithMapOfAnything(map[string]interface{}{"operationId": "listCronUpcoming"})
+	cronUpcoming.WithParameters(QueryParameterLimit)
+	_ = reflector.SetRequest(&cronUpcoming, new(pipelineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cronUpcoming, []types.CronRun{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&cronUpcoming, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cronUpcoming, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cronUpcoming, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cronUpcoming, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/crons/upcoming", cronUpcoming)
+
 	logView := openapi3.Operation{}
 	logView.WithTags("pipeline")
 	logView.WithMapOfAnything(map[string]interface{}{"operationId": "viewLogs"})
@@ -311,4 +646,22 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/logs/{stage_number}/{step_number}",
 		logView,
 	)
+
+	logStream := openapi3.Operation{}
+	logStream.WithTags("pipeline")
+	logStream.WithMapOfAnything(map[string]interface{}{"operationId": "streamLogs"})
+	logStream.WithParameters(queryParameterLogOffset)
+	_ = reflector.SetRequest(&logStream, new(logRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&logStream, http.StatusOK, "text/event-stream")
+	_ = reflector.SetJSONResponse(&logStream, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&logStream, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&logStream, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&logStream, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&logStream, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}"+
+			"/logs/{stage_number}/{step_number}/stream",
+		logStream,
+	)
 }
@@ -66,6 +66,36 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type rebasePullReqRequest struct {
+	pullReqRequest
+	pullreq.RebaseInput
+}
+
+type revertPullReqRequest struct {
+	pullReqRequest
+	pullreq.RevertInput
+}
+
+type backportPullReqRequest struct {
+	pullReqRequest
+	pullreq.BackportInput
+}
+
+type dependencyAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.DependencyAddInput
+}
+
+type dependencyRemovePullReqRequest struct {
+	pullReqRequest
+	DependencyID int64 `path:"pullreq_dependency_id"`
+}
+
+type autoMergeEnablePullReqRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -104,6 +134,11 @@ type reviewerDeletePullReqRequest struct {
 	PullReqReviewerID int64 `path:"pullreq_reviewer_id"`
 }
 
+type reviewerRequestPullReqRequest struct {
+	pullReqRequest
+	PullReqReviewerID int64 `path:"pullreq_reviewer_id"`
+}
+
 type reviewerAddPullReqRequest struct {
 	pullReqRequest
 	pullreq.ReviewerAddInput
@@ -133,6 +168,11 @@ type getRawPRDiffRequest struct {
 	Path []string `query:"path" description:"provide path for diff operation"`
 }
 
+type getPRDiffFileRequest struct {
+	pullReqRequest
+	Path string `query:"path" required:"true" description:"path of the file to return the diff of"`
+}
+
 type postRawPRDiffRequest struct {
 	pullReqRequest
 	gittypes.FileDiffRequests
@@ -498,7 +538,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		QueryParameterPage, QueryParameterLimit,
 		QueryParameterLabelID, QueryParameterValueID,
 		queryParameterAuthorID, queryParameterCommenterID, queryParameterMentionedID,
-		queryParameterReviewerID, queryParameterReviewDecision)
+		queryParameterReviewerID, queryParameterReviewDecision, queryParameterMilestoneID)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listPullReq, new([]types.PullReq), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusBadRequest)
@@ -628,6 +668,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers", reviewerAdd)
 
+	reviewerRequest := openapi3.Operation{}
+	reviewerRequest.WithTags("pullreq")
+	reviewerRequest.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerRequestPullReq"})
+	_ = reflector.SetRequest(&reviewerRequest, new(reviewerRequestPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&reviewerRequest, new(types.PullReqReviewer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reviewerRequest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewerRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewerRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewerRequest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reviewerRequest, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/{pullreq_reviewer_id}", reviewerRequest)
+
 	reviewerList := openapi3.Operation{}
 	reviewerList.WithTags("pullreq")
 	reviewerList.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerListPullReq"})
@@ -679,6 +732,125 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	opRebase := openapi3.Operation{}
+	opRebase.WithTags("pullreq")
+	opRebase.WithMapOfAnything(map[string]interface{}{"operationId": "rebasePullReq"})
+	_ = reflector.SetRequest(&opRebase, new(rebasePullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRebase, new(types.RebaseResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRebase, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRebase, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRebase, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRebase, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRebase, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRebase, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/rebase", opRebase)
+
+	opRevert := openapi3.Operation{}
+	opRevert.WithTags("pullreq")
+	opRevert.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReq"})
+	_ = reflector.SetRequest(&opRevert, new(revertPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.PullReq), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", opRevert)
+
+	opBackport := openapi3.Operation{}
+	opBackport.WithTags("pullreq")
+	opBackport.WithMapOfAnything(map[string]interface{}{"operationId": "backportPullReq"})
+	_ = reflector.SetRequest(&opBackport, new(backportPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opBackport, new(types.BackportResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opBackport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opBackport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBackport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBackport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBackport, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opBackport, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/backport", opBackport)
+
+	opDependencyAdd := openapi3.Operation{}
+	opDependencyAdd.WithTags("pullreq")
+	opDependencyAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addPullReqDependency"})
+	_ = reflector.SetRequest(&opDependencyAdd, new(dependencyAddPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(types.PullReqDependency), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", opDependencyAdd)
+
+	opDependencyGraph := openapi3.Operation{}
+	opDependencyGraph.WithTags("pullreq")
+	opDependencyGraph.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqDependencyGraph"})
+	_ = reflector.SetRequest(&opDependencyGraph, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDependencyGraph, new(types.PullReqDependencyGraph), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDependencyGraph, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDependencyGraph, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencyGraph, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencyGraph, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencyGraph, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", opDependencyGraph)
+
+	opDependencyRemove := openapi3.Operation{}
+	opDependencyRemove.WithTags("pullreq")
+	opDependencyRemove.WithMapOfAnything(map[string]interface{}{"operationId": "removePullReqDependency"})
+	_ = reflector.SetRequest(&opDependencyRemove, new(dependencyRemovePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDependencyRemove, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDependencyRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencyRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencyRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencyRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies/{pullreq_dependency_id}", opDependencyRemove)
+
+	opAutoMergeEnable := openapi3.Operation{}
+	opAutoMergeEnable.WithTags("pullreq")
+	opAutoMergeEnable.WithMapOfAnything(map[string]interface{}{"operationId": "enablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeEnable, new(autoMergeEnablePullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeEnable)
+
+	opAutoMergeFind := openapi3.Operation{}
+	opAutoMergeFind.WithTags("pullreq")
+	opAutoMergeFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeFind)
+
+	opAutoMergeCancel := openapi3.Operation{}
+	opAutoMergeCancel.WithTags("pullreq")
+	opAutoMergeCancel.WithMapOfAnything(map[string]interface{}{"operationId": "cancelPullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeCancel, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutoMergeCancel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutoMergeCancel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeCancel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeCancel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeCancel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeCancel)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -806,6 +978,32 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opPostDiff, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq/{pullreq_number}/diff", opPostDiff))
 
+	opDiffFiles := openapi3.Operation{}
+	opDiffFiles.WithTags("pullreq")
+	opDiffFiles.WithMapOfAnything(map[string]interface{}{"operationId": "diffFilesPullReq"})
+	opDiffFiles.WithParameters(QueryParameterPage, QueryParameterLimit)
+	panicOnErr(reflector.SetRequest(&opDiffFiles, new(pullReqRequest), http.MethodGet))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFiles, new([]git.FileDiff), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFiles, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFiles, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFiles, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFiles, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/diff/files", opDiffFiles))
+
+	opDiffFile := openapi3.Operation{}
+	opDiffFile.WithTags("pullreq")
+	opDiffFile.WithMapOfAnything(map[string]interface{}{"operationId": "diffFilePullReq"})
+	panicOnErr(reflector.SetRequest(&opDiffFile, new(getPRDiffFileRequest), http.MethodGet))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(git.FileDiff), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusBadRequest))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/diff/file", opDiffFile))
+
 	opChecks := openapi3.Operation{}
 	opChecks.WithTags("pullreq")
 	opChecks.WithMapOfAnything(map[string]interface{}{"operationId": "checksPullReq"})
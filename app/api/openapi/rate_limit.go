@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func rateLimitOperations(reflector *openapi3.Reflector) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindRateLimits"})
+	_ = reflector.SetRequest(&opFind, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.RateLimits), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/rate-limits", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateRateLimits"})
+	_ = reflector.SetRequest(&opUpdate, new(types.RateLimits), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.RateLimits), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/rate-limits", opUpdate)
+}
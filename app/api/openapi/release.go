@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/release"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type releaseRequest struct {
+	repoRequest
+	ID int64 `path:"release_id"`
+}
+
+type createReleaseRequest struct {
+	repoRequest
+	release.CreateInput
+}
+
+type listReleasesRequest struct {
+	repoRequest
+}
+
+type updateReleaseRequest struct {
+	releaseRequest
+	release.UpdateInput
+}
+
+type uploadReleaseAssetRequest struct {
+	releaseRequest
+	Name string `query:"name" required:"true" description:"The file name under which the asset is downloaded."`
+	// Note: Below line won't produce the file upload interface in Swagger UI,
+	// ref: https://swagger.io/docs/specification/2-0/file-upload/
+	Content string `json:"-" format:"binary" description:"Binary file to upload"`
+}
+
+type releaseAssetRequest struct {
+	releaseRequest
+	AssetID int64 `path:"asset_id"`
+}
+
+var queryParameterQueryRelease = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the releases are filtered, matched against title and tag."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterIncludeDrafts = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeDrafts,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If true, draft releases are included. Requires push permission on the repository."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func releaseOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("release")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRelease"})
+	_ = reflector.SetRequest(&opCreate, new(createReleaseRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Release), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/releases", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("release")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listReleases"})
+	opList.WithParameters(queryParameterQueryRelease, queryParameterIncludeDrafts,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(listReleasesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.Release), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/releases", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("release")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "getRelease"})
+	_ = reflector.SetRequest(&opFind, new(releaseRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Release), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/releases/{release_id}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("release")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRelease"})
+	_ = reflector.SetRequest(&opUpdate, new(updateReleaseRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Release), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/releases/{release_id}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("release")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRelease"})
+	_ = reflector.SetRequest(&opDelete, new(releaseRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/releases/{release_id}", opDelete)
+
+	opUploadAsset := openapi3.Operation{}
+	opUploadAsset.WithTags("release")
+	opUploadAsset.WithMapOfAnything(map[string]interface{}{"operationId": "uploadReleaseAsset"})
+	_ = reflector.SetRequest(&opUploadAsset, new(uploadReleaseAssetRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUploadAsset, new(types.ReleaseAsset), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUploadAsset, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUploadAsset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUploadAsset, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUploadAsset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUploadAsset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/releases/{release_id}/assets", opUploadAsset)
+
+	opDownloadAsset := openapi3.Operation{}
+	opDownloadAsset.WithTags("release")
+	opDownloadAsset.WithMapOfAnything(map[string]interface{}{"operationId": "downloadReleaseAsset"})
+	_ = reflector.SetRequest(&opDownloadAsset, new(releaseAssetRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDownloadAsset, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opDownloadAsset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDownloadAsset, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDownloadAsset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDownloadAsset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/releases/{release_id}/assets/{asset_id}", opDownloadAsset)
+
+	opDeleteAsset := openapi3.Operation{}
+	opDeleteAsset.WithTags("release")
+	opDeleteAsset.WithMapOfAnything(map[string]interface{}{"operationId": "deleteReleaseAsset"})
+	_ = reflector.SetRequest(&opDeleteAsset, new(releaseAssetRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteAsset, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteAsset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteAsset, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteAsset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteAsset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/releases/{release_id}/assets/{asset_id}", opDeleteAsset)
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/replication"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// replicationRecordListRequest is the request for listing replication records.
+	replicationRecordListRequest struct {
+		After int64 `query:"after"`
+		Limit int   `query:"limit" default:"30"`
+	}
+
+	// replicationConsumerRequest is the request for replication consumer specific operations.
+	replicationConsumerRequest struct {
+		Identifier string `path:"replication_consumer_identifier"`
+	}
+
+	// replicationConsumerCreateRequest is the request for creating a replication consumer.
+	replicationConsumerCreateRequest struct {
+		replication.ConsumerCreateInput
+	}
+
+	// replicationConsumerAckRequest is the request for acknowledging replication records.
+	replicationConsumerAckRequest struct {
+		replicationConsumerRequest
+		replication.ConsumerAckInput
+	}
+)
+
+// helper function that constructs the openapi specification
+// for replication resources.
+func buildReplication(reflector *openapi3.Reflector) {
+	opListRecords := openapi3.Operation{}
+	opListRecords.WithTags("admin")
+	opListRecords.WithMapOfAnything(map[string]interface{}{"operationId": "adminListReplicationRecords"})
+	_ = reflector.SetRequest(&opListRecords, new(replicationRecordListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListRecords, new([]*types.ReplicationRecord), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListRecords, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListRecords, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListRecords, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/replication/records", opListRecords)
+
+	opListConsumers := openapi3.Operation{}
+	opListConsumers.WithTags("admin")
+	opListConsumers.WithMapOfAnything(map[string]interface{}{"operationId": "adminListReplicationConsumers"})
+	_ = reflector.SetRequest(&opListConsumers, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListConsumers, new([]*types.ReplicationConsumer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListConsumers, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListConsumers, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/replication/consumers", opListConsumers)
+
+	opCreateConsumer := openapi3.Operation{}
+	opCreateConsumer.WithTags("admin")
+	opCreateConsumer.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateReplicationConsumer"})
+	_ = reflector.SetRequest(&opCreateConsumer, new(replicationConsumerCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateConsumer, new(types.ReplicationConsumer), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateConsumer, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateConsumer, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateConsumer, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateConsumer, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/replication/consumers", opCreateConsumer)
+
+	opFindConsumer := openapi3.Operation{}
+	opFindConsumer.WithTags("admin")
+	opFindConsumer.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindReplicationConsumer"})
+	_ = reflector.SetRequest(&opFindConsumer, new(replicationConsumerRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindConsumer, new(types.ReplicationConsumer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindConsumer, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindConsumer, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindConsumer, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/admin/replication/consumers/{replication_consumer_identifier}", opFindConsumer)
+
+	opAckConsumer := openapi3.Operation{}
+	opAckConsumer.WithTags("admin")
+	opAckConsumer.WithMapOfAnything(map[string]interface{}{"operationId": "adminAckReplicationConsumer"})
+	_ = reflector.SetRequest(&opAckConsumer, new(replicationConsumerAckRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAckConsumer, new(types.ReplicationConsumer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAckConsumer, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAckConsumer, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAckConsumer, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAckConsumer, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/admin/replication/consumers/{replication_consumer_identifier}/ack", opAckConsumer)
+
+	opDeleteConsumer := openapi3.Operation{}
+	opDeleteConsumer.WithTags("admin")
+	opDeleteConsumer.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteReplicationConsumer"})
+	_ = reflector.SetRequest(&opDeleteConsumer, new(replicationConsumerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteConsumer, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteConsumer, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteConsumer, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteConsumer, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/replication/consumers/{replication_consumer_identifier}", opDeleteConsumer)
+}
@@ -65,6 +65,12 @@ type getContentRequest struct {
 	Path string `path:"path"`
 }
 
+type getRawRefRequest struct {
+	repoRequest
+	GitRef string `path:"git_ref"`
+	Path   string `path:"path"`
+}
+
 type pathsDetailsRequest struct {
 	repoRequest
 	repo.PathsDetailsInput
@@ -80,6 +86,11 @@ type commitFilesRequest struct {
 	repo.CommitFilesOptions
 }
 
+type applyPatchRequest struct {
+	repoRequest
+	repo.ApplyPatchOptions
+}
+
 // contentType is a plugin for repo.ContentType to allow using oneof.
 type contentType string
 
@@ -135,6 +146,11 @@ type createBranchRequest struct {
 	repo.CreateBranchInput
 }
 
+type renderMarkdownRequest struct {
+	repoRequest
+	repo.RenderMarkdownInput
+}
+
 type getBranchRequest struct {
 	repoRequest
 	BranchName string `path:"branch_name"`
@@ -175,6 +191,10 @@ type codeOwnersValidate struct {
 	repoRequest
 }
 
+type complianceReportRequest struct {
+	repoRequest
+}
+
 // ruleType is a plugin for types.RuleType to allow using oneof.
 type ruleType string
 
@@ -205,6 +225,16 @@ type restoreRequest struct {
 	repo.RestoreInput
 }
 
+type updateRepoArchivedRequest struct {
+	repoRequest
+	repo.UpdateArchivedInput
+}
+
+type generateRepoRequest struct {
+	repoRequest
+	repo.GenerateInput
+}
+
 type updateRepoPublicAccessRequest struct {
 	repoRequest
 	repo.UpdatePublicAccessInput
@@ -220,6 +250,11 @@ type generalSettingsRequest struct {
 	reposettings.GeneralSettings
 }
 
+type reviewerSettingsRequest struct {
+	repoRequest
+	reposettings.ReviewerSettings
+}
+
 type archiveRequest struct {
 	repoRequest
 	GitRef string `path:"git_ref" required:"true"`
@@ -238,6 +273,36 @@ type LabelValueRequest struct {
 	Color enum.LabelColor `json:"color"`
 }
 
+var queryParameterAt = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamAt,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The point in time (in Unix time millis) at which the git references are resolved. " +
+			"The state is reconstructed from the commit dates of the first-parent history."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterSourceRepoRef = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamSourceRepoRef,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The repository containing the head of the comparison. " +
+			"It has to be a fork of the repository (default: the repository itself)."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterGitRef = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamGitRef,
@@ -342,6 +407,21 @@ var QueryParamIncludeStats = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParamIncludeSignatureStatus = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeSignature,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the signatures of the commits should be verified."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterLineFrom = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamLineFrom,
@@ -562,7 +642,7 @@ var queryParamArchivePaths = openapi3.ParameterOrRef{
 		In:   openapi3.ParameterInQuery,
 		Description: ptr.String("Without an optional path parameter, all files and subdirectories of the " +
 			"current working directory are included in the archive. If one or more paths are specified," +
-			" only these are included."),
+			" only these are included. A path that doesn't exist at the requested revision results in 404."),
 		Required: ptr.Bool(false),
 		Schema: &openapi3.SchemaOrRef{
 			Schema: &openapi3.Schema{
@@ -635,6 +715,21 @@ var queryParamArchiveCompression = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterDescriptionTemplateType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the description templates. Templates of all types are returned if omitted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.DescriptionTemplateType("").Enum(),
+			},
+		},
+	},
+}
+
 var QueryParameterInherited = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamInherited,
@@ -664,6 +759,117 @@ var QueryParameterQueryLabel = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterGrepQuery = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamGrepQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The text (or regular expression if regex is set) to search for."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterGrepRegex = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRegex,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Interpret the query as extended regular expression."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterGrepIgnoreCase = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIgnoreCase,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Ignore case differences between the query and the file content."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterGrepPath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Limit the search to files under the provided paths (git pathspecs)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterGrepLimit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLimit,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The maximum number of matching lines to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(request.GrepLimitDefault),
+				Minimum: ptr.Float64(1.0),
+				Maximum: ptr.Float64(request.GrepLimitMax),
+			},
+		},
+	},
+}
+
+var queryParameterAfterTraffic = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamAfter,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String(
+			"The result should contain only days at and after this timestamp (unix millis, default: 14 days ago)."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(0),
+			},
+		},
+	},
+}
+
+var queryParameterBeforeTraffic = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBefore,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The result should contain only days at and before this timestamp (unix millis)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(0),
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func repoOperations(reflector *openapi3.Reflector) {
 	createRepository := openapi3.Operation{}
@@ -786,6 +992,57 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodPost, "/repos/{repo_ref}/public-access", opUpdatePublicAccess)
 
+	opUpdateArchived := openapi3.Operation{}
+	opUpdateArchived.WithTags("repository")
+	opUpdateArchived.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateRepositoryArchived"})
+	_ = reflector.SetRequest(
+		&opUpdateArchived, new(updateRepoArchivedRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUpdateArchived, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateArchived, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateArchived, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateArchived, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateArchived, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateArchived, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPost, "/repos/{repo_ref}/archived", opUpdateArchived)
+
+	opGenerate := openapi3.Operation{}
+	opGenerate.WithTags("repository")
+	opGenerate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "generateRepository"})
+	_ = reflector.SetRequest(&opGenerate, new(generateRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opGenerate, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opGenerate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGenerate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGenerate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGenerate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGenerate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPost, "/repos/{repo_ref}/generate", opGenerate)
+
+	opStar := openapi3.Operation{}
+	opStar.WithTags("repository")
+	opStar.WithMapOfAnything(map[string]interface{}{"operationId": "starRepository"})
+	_ = reflector.SetRequest(&opStar, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opStar, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/star", opStar)
+
+	opUnstar := openapi3.Operation{}
+	opUnstar.WithTags("repository")
+	opUnstar.WithMapOfAnything(map[string]interface{}{"operationId": "unstarRepository"})
+	_ = reflector.SetRequest(&opUnstar, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnstar, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/star", opUnstar)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -800,7 +1057,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opGetContent := openapi3.Operation{}
 	opGetContent.WithTags("repository")
 	opGetContent.WithMapOfAnything(map[string]interface{}{"operationId": "getContent"})
-	opGetContent.WithParameters(queryParameterGitRef, queryParameterIncludeCommit)
+	opGetContent.WithParameters(queryParameterGitRef, queryParameterAt, queryParameterIncludeCommit)
 	_ = reflector.SetRequest(&opGetContent, new(getContentRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opGetContent, new(getContentOutput), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opGetContent, new(usererror.Error), http.StatusInternalServerError)
@@ -812,7 +1069,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opListPaths := openapi3.Operation{}
 	opListPaths.WithTags("repository")
 	opListPaths.WithMapOfAnything(map[string]interface{}{"operationId": "listPaths"})
-	opListPaths.WithParameters(queryParameterGitRef, queryParameterIncludeDirectories)
+	opListPaths.WithParameters(queryParameterGitRef, queryParameterAt, queryParameterIncludeDirectories)
 	_ = reflector.SetRequest(&opListPaths, new(repoRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListPaths, new(repo.ListPathsOutput), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opListPaths, new(usererror.Error), http.StatusInternalServerError)
@@ -821,6 +1078,42 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opListPaths, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/paths", opListPaths)
 
+	opGrep := openapi3.Operation{}
+	opGrep.WithTags("repository")
+	opGrep.WithMapOfAnything(map[string]interface{}{"operationId": "grep"})
+	opGrep.WithParameters(queryParameterGitRef, queryParameterGrepQuery, queryParameterGrepRegex,
+		queryParameterGrepIgnoreCase, queryParameterGrepPath, queryParameterGrepLimit)
+	_ = reflector.SetRequest(&opGrep, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGrep, new(types.GrepResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGrep, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGrep, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGrep, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGrep, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGrep, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/grep", opGrep)
+
+	opTemplateDrift := openapi3.Operation{}
+	opTemplateDrift.WithTags("repository")
+	opTemplateDrift.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoTemplateDrift"})
+	_ = reflector.SetRequest(&opTemplateDrift, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTemplateDrift, new([]types.RepoTemplateDrift), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplateDrift, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTemplateDrift, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplateDrift, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTemplateDrift, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/template-drift", opTemplateDrift)
+
+	opTemplateApply := openapi3.Operation{}
+	opTemplateApply.WithTags("repository")
+	opTemplateApply.WithMapOfAnything(map[string]interface{}{"operationId": "applyRepoTemplate"})
+	_ = reflector.SetRequest(&opTemplateApply, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTemplateApply, new([]types.RepoTemplateDrift), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplateApply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTemplateApply, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplateApply, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTemplateApply, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/template-apply", opTemplateApply)
+
 	opPathDetails := openapi3.Operation{}
 	opPathDetails.WithTags("repository")
 	opPathDetails.WithMapOfAnything(map[string]interface{}{"operationId": "pathDetails"})
@@ -840,12 +1133,41 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetRequest(&opGetRaw, new(getContentRequest), http.MethodGet)
 	// TODO: Figure out how to provide proper list of all potential mime types
 	_ = reflector.SetStringResponse(&opGetRaw, http.StatusOK, "")
+	_ = reflector.SetStringResponse(&opGetRaw, http.StatusPartialContent, "")
+	_ = reflector.SetJSONResponse(&opGetRaw, nil, http.StatusNotModified)
+	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opGetRawRef := openapi3.Operation{}
+	opGetRawRef.WithTags("repository")
+	opGetRawRef.WithMapOfAnything(map[string]interface{}{"operationId": "getRawRef"})
+	_ = reflector.SetRequest(&opGetRawRef, new(getRawRefRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opGetRawRef, http.StatusOK, "")
+	_ = reflector.SetStringResponse(&opGetRawRef, http.StatusPartialContent, "")
+	_ = reflector.SetJSONResponse(&opGetRawRef, nil, http.StatusNotModified)
+	_ = reflector.SetJSONResponse(&opGetRawRef, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGetRawRef, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetRawRef, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetRawRef, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetRawRef, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw-ref/{git_ref}/{path}", opGetRawRef)
+
+	opRenderMarkdown := openapi3.Operation{}
+	opRenderMarkdown.WithTags("repository")
+	opRenderMarkdown.WithMapOfAnything(map[string]interface{}{"operationId": "renderMarkdown"})
+	_ = reflector.SetRequest(&opRenderMarkdown, new(renderMarkdownRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRenderMarkdown, new(repo.RenderMarkdownOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRenderMarkdown, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRenderMarkdown, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRenderMarkdown, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRenderMarkdown, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRenderMarkdown, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/markdown", opRenderMarkdown)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -859,12 +1181,25 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetBlame, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/blame/{path}", opGetBlame)
 
+	opGetBlameStream := openapi3.Operation{}
+	opGetBlameStream.WithTags("repository")
+	opGetBlameStream.WithMapOfAnything(map[string]interface{}{"operationId": "getBlameStream"})
+	opGetBlameStream.WithParameters(queryParameterGitRef,
+		queryParameterLineFrom, queryParameterLineTo)
+	_ = reflector.SetRequest(&opGetBlameStream, new(getBlameRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opGetBlameStream, http.StatusOK, "text/event-stream")
+	_ = reflector.SetJSONResponse(&opGetBlameStream, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetBlameStream, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetBlameStream, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetBlameStream, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/blame-stream/{path}", opGetBlameStream)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("repository")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listCommits"})
 	opListCommits.WithParameters(queryParameterGitRef, queryParameterAfterCommits, queryParameterPath,
 		queryParameterSince, queryParameterUntil, queryParameterCommitter,
-		QueryParameterPage, QueryParameterLimit, QueryParamIncludeStats)
+		QueryParameterPage, QueryParameterLimit, QueryParamIncludeStats, queryParamIncludeSignatureStatus)
 	_ = reflector.SetRequest(&opListCommits, new(listCommitsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListCommits, []types.ListCommitResponse{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusInternalServerError)
@@ -1001,8 +1336,23 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits", opCommitFiles)
 
+	opApplyPatch := openapi3.Operation{}
+	opApplyPatch.WithTags("repository")
+	opApplyPatch.WithMapOfAnything(map[string]interface{}{"operationId": "applyPatch"})
+	_ = reflector.SetRequest(&opApplyPatch, new(applyPatchRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opApplyPatch, types.CommitFilesResponse{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/apply-patch", opApplyPatch)
+
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("repository")
+	opDiff.WithParameters(queryParameterAt, queryParameterSourceRepoRef)
 	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "rawDiff"})
 	panicOnErr(reflector.SetRequest(&opDiff, new(getRawDiffRequest), http.MethodGet))
 	panicOnErr(reflector.SetStringResponse(&opDiff, http.StatusOK, "text/plain"))
@@ -1014,6 +1364,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 
 	opPostDiff := openapi3.Operation{}
 	opPostDiff.WithTags("repository")
+	opPostDiff.WithParameters(queryParameterAt, queryParameterSourceRepoRef)
 	opPostDiff.WithMapOfAnything(map[string]interface{}{"operationId": "rawDiffPost"})
 	panicOnErr(reflector.SetRequest(&opPostDiff, new(postRawDiffRequest), http.MethodPost))
 	panicOnErr(reflector.SetStringResponse(&opPostDiff, http.StatusOK, "text/plain"))
@@ -1036,6 +1387,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 
 	opDiffStats := openapi3.Operation{}
 	opDiffStats.WithTags("repository")
+	opDiffStats.WithParameters(queryParameterAt, queryParameterSourceRepoRef)
 	opDiffStats.WithMapOfAnything(map[string]interface{}{"operationId": "diffStats"})
 	_ = reflector.SetRequest(&opDiffStats, new(getRawDiffRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opDiffStats, new(types.DiffStats), http.StatusOK)
@@ -1149,6 +1501,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
 
+	opComplianceReport := openapi3.Operation{}
+	opComplianceReport.WithTags("repository")
+	opComplianceReport.WithMapOfAnything(map[string]interface{}{"operationId": "complianceReport"})
+	opComplianceReport.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opComplianceReport, new(complianceReportRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opComplianceReport, new(types.ComplianceReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opComplianceReport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opComplianceReport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opComplianceReport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opComplianceReport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opComplianceReport, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/compliance", opComplianceReport)
+
 	opSettingsSecurityUpdate := openapi3.Operation{}
 	opSettingsSecurityUpdate.WithTags("repository")
 	opSettingsSecurityUpdate.WithMapOfAnything(
@@ -1207,6 +1572,63 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodGet, "/repos/{repo_ref}/settings/general", opSettingsGeneralFind)
 
+	opSettingsEffective := openapi3.Operation{}
+	opSettingsEffective.WithTags("repository")
+	opSettingsEffective.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findEffectiveSettings"})
+	_ = reflector.SetRequest(&opSettingsEffective, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new([]types.EffectiveSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/settings/effective", opSettingsEffective)
+
+	opSettingsReviewersUpdate := openapi3.Operation{}
+	opSettingsReviewersUpdate.WithTags("repository")
+	opSettingsReviewersUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateReviewerSettings"})
+	_ = reflector.SetRequest(
+		&opSettingsReviewersUpdate, new(reviewerSettingsRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersUpdate, new(reposettings.ReviewerSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/repos/{repo_ref}/settings/reviewers", opSettingsReviewersUpdate)
+
+	opSettingsReviewersFind := openapi3.Operation{}
+	opSettingsReviewersFind.WithTags("repository")
+	opSettingsReviewersFind.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findReviewerSettings"})
+	_ = reflector.SetRequest(&opSettingsReviewersFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersFind, new(reposettings.ReviewerSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsReviewersFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/settings/reviewers", opSettingsReviewersFind)
+
+	opSettingsTemplatesList := openapi3.Operation{}
+	opSettingsTemplatesList.WithTags("repository")
+	opSettingsTemplatesList.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listDescriptionTemplates"})
+	opSettingsTemplatesList.WithParameters(queryParameterDescriptionTemplateType)
+	_ = reflector.SetRequest(&opSettingsTemplatesList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsTemplatesList, new([]types.DescriptionTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsTemplatesList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsTemplatesList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsTemplatesList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsTemplatesList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsTemplatesList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/settings/templates", opSettingsTemplatesList)
+
 	opArchive := openapi3.Operation{}
 	opArchive.WithTags("repository")
 	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archive"})
@@ -1241,6 +1663,20 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/summary", opSummary)
 
+	opTraffic := openapi3.Operation{}
+	opTraffic.WithTags("repository")
+	opTraffic.WithMapOfAnything(
+		map[string]interface{}{"operationId": "repoTraffic"})
+	opTraffic.WithParameters(queryParameterAfterTraffic, queryParameterBeforeTraffic)
+	_ = reflector.SetRequest(&opTraffic, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTraffic, new(types.RepoTraffic), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTraffic, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTraffic, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTraffic, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTraffic, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTraffic, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/traffic", opTraffic)
+
 	opDefineLabel := openapi3.Operation{}
 	opDefineLabel.WithTags("repository")
 	opDefineLabel.WithMapOfAnything(
@@ -1411,4 +1847,23 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRebaseBranch, new(types.MergeViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/rebase", opRebaseBranch)
+
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(
+		map[string]interface{}{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, &struct {
+		repoRequest
+		repo.CherryPickInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.CherryPickResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.CherryPickViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/cherry-pick", opCherryPick)
 }
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterInsightsWeeks = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamWeeks,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The number of weeks, including the current week, covered by the insights."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(request.InsightsWeeksDefault),
+				Minimum: ptr.Float64(1),
+				Maximum: ptr.Float64(260),
+			},
+		},
+	},
+}
+
+var queryParameterInsightsContributors = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamContributors,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The number of top contributors to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(request.InsightsContributorsDefault),
+				Minimum: ptr.Float64(1),
+				Maximum: ptr.Float64(100),
+			},
+		},
+	},
+}
+
+func repoInsightsOperations(reflector *openapi3.Reflector) {
+	opGet := openapi3.Operation{}
+	opGet.WithTags("repository")
+	opGet.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoInsights"})
+	opGet.WithParameters(queryParameterInsightsWeeks, queryParameterInsightsContributors)
+	_ = reflector.SetRequest(&opGet, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGet, new(types.RepoInsights), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/insights", opGet)
+}
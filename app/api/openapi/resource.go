@@ -18,10 +18,27 @@ import (
 	"net/http"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/filetemplate"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
+type fileTemplateTypeRequest struct {
+	Type enum.FileTemplateType `path:"file_template_type"`
+}
+
+type fileTemplateRequest struct {
+	fileTemplateTypeRequest
+	Identifier string `path:"file_template_identifier"`
+}
+
+type createFileTemplateRequest struct {
+	fileTemplateTypeRequest
+	filetemplate.CreateInput
+}
+
 func resourceOperations(reflector *openapi3.Reflector) {
 	opListGitignore := openapi3.Operation{}
 	opListGitignore.WithTags("resource")
@@ -37,12 +54,45 @@ func resourceOperations(reflector *openapi3.Reflector) {
 	opListLicenses.WithTags("resource")
 	opListLicenses.WithMapOfAnything(map[string]interface{}{"operationId": "listLicenses"})
 	_ = reflector.SetRequest(&opListLicenses, new(licenseRequest), http.MethodGet)
-	_ = reflector.SetJSONResponse(&opListLicenses, []struct {
-		Label string `json:"label"`
-		Value string `json:"value"`
-	}{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListLicenses, []types.FileTemplateInfo{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opListLicenses, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opListLicenses, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opListLicenses, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/resources/license", opListLicenses)
+
+	opCreateFileTemplate := openapi3.Operation{}
+	opCreateFileTemplate.WithTags("resource")
+	opCreateFileTemplate.WithMapOfAnything(map[string]interface{}{"operationId": "createFileTemplate"})
+	_ = reflector.SetRequest(&opCreateFileTemplate, new(createFileTemplateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateFileTemplate, new(types.FileTemplate), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateFileTemplate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateFileTemplate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateFileTemplate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateFileTemplate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateFileTemplate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/file-templates/{file_template_type}", opCreateFileTemplate)
+
+	opFindFileTemplate := openapi3.Operation{}
+	opFindFileTemplate.WithTags("resource")
+	opFindFileTemplate.WithMapOfAnything(map[string]interface{}{"operationId": "findFileTemplate"})
+	_ = reflector.SetRequest(&opFindFileTemplate, new(fileTemplateRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindFileTemplate, new(types.FileTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindFileTemplate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindFileTemplate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindFileTemplate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindFileTemplate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/admin/file-templates/{file_template_type}/{file_template_identifier}", opFindFileTemplate)
+
+	opDeleteFileTemplate := openapi3.Operation{}
+	opDeleteFileTemplate.WithTags("resource")
+	opDeleteFileTemplate.WithMapOfAnything(map[string]interface{}{"operationId": "deleteFileTemplate"})
+	_ = reflector.SetRequest(&opDeleteFileTemplate, new(fileTemplateRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteFileTemplate, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteFileTemplate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteFileTemplate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteFileTemplate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteFileTemplate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/file-templates/{file_template_type}/{file_template_identifier}", opDeleteFileTemplate)
 }
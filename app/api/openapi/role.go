@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/role"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type roleRequest struct {
+	Identifier string `path:"role_identifier"`
+}
+
+type repoMembershipRequest struct {
+	repoRequest
+	UserUID string `path:"user_uid"`
+}
+
+func roleOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("role")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listRoles"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Role), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/roles", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("role")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRole"})
+	_ = reflector.SetRequest(&opFind, new(roleRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Role), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/roles/{role_identifier}", opFind)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("admin")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateRole"})
+	_ = reflector.SetRequest(&opCreate, new(role.CreateInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Role), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/roles", opCreate)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateRole"})
+	_ = reflector.SetRequest(&opUpdate, &struct {
+		roleRequest
+		role.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Role), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/roles/{role_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteRole"})
+	_ = reflector.SetRequest(&opDelete, new(roleRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/roles/{role_identifier}", opDelete)
+}
+
+func repoMembershipOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("repository")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipList"})
+	_ = reflector.SetRequest(&opList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.RepoMembershipUser), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/members", opList)
+
+	opAdd := openapi3.Operation{}
+	opAdd.WithTags("repository")
+	opAdd.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipAdd"})
+	_ = reflector.SetRequest(&opAdd, struct {
+		repoRequest
+		repo.MembershipAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAdd, new(types.RepoMembershipUser), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/members", opAdd)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("repository")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipUpdate"})
+	_ = reflector.SetRequest(&opUpdate, &struct {
+		repoMembershipRequest
+		repo.MembershipUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.RepoMembershipUser), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/members/{user_uid}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("repository")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipDelete"})
+	_ = reflector.SetRequest(&opDelete, new(repoMembershipRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/members/{user_uid}", opDelete)
+}
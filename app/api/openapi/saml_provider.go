@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/saml"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type samlProviderRequest struct {
+	Identifier string `path:"saml_provider_identifier"`
+}
+
+type samlProviderACSRequest struct {
+	samlProviderRequest
+	SAMLResponse string `formData:"SAMLResponse"`
+}
+
+type updateSAMLProviderRequest struct {
+	samlProviderRequest
+	saml.ProviderUpdateInput
+}
+
+func samlProviderOperations(reflector *openapi3.Reflector) {
+	opListEnabled := openapi3.Operation{}
+	opListEnabled.WithTags("account")
+	opListEnabled.WithMapOfAnything(map[string]interface{}{"operationId": "listLoginSAMLProviders"})
+	_ = reflector.SetRequest(&opListEnabled, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListEnabled, new([]types.SAMLProviderInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListEnabled, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/saml", opListEnabled)
+
+	opLogin := openapi3.Operation{}
+	opLogin.WithTags("account")
+	opLogin.WithMapOfAnything(map[string]interface{}{"operationId": "loginSAML"})
+	_ = reflector.SetRequest(&opLogin, new(samlProviderRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/saml/{saml_provider_identifier}", opLogin)
+
+	opMetadata := openapi3.Operation{}
+	opMetadata.WithTags("account")
+	opMetadata.WithMapOfAnything(map[string]interface{}{"operationId": "getSAMLMetadata"})
+	_ = reflector.SetRequest(&opMetadata, new(samlProviderRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opMetadata, http.StatusOK, "application/samlmetadata+xml")
+	_ = reflector.SetJSONResponse(&opMetadata, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMetadata, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/login/saml/{saml_provider_identifier}/metadata", opMetadata)
+
+	opACS := openapi3.Operation{}
+	opACS.WithTags("account")
+	opACS.WithMapOfAnything(map[string]interface{}{"operationId": "loginSAMLACS"})
+	_ = reflector.SetRequest(&opACS, new(samlProviderACSRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opACS, nil, http.StatusSeeOther)
+	_ = reflector.SetJSONResponse(&opACS, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/login/saml/{saml_provider_identifier}/acs", opACS)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListSAMLProviders"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.SAMLProvider), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/saml-providers", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("admin")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateSAMLProvider"})
+	_ = reflector.SetRequest(&opCreate, new(saml.ProviderCreateInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.SAMLProvider), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/saml-providers", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindSAMLProvider"})
+	_ = reflector.SetRequest(&opFind, new(samlProviderRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.SAMLProvider), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/saml-providers/{saml_provider_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateSAMLProvider"})
+	_ = reflector.SetRequest(&opUpdate, new(updateSAMLProviderRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.SAMLProvider), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/saml-providers/{saml_provider_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteSAMLProvider"})
+	_ = reflector.SetRequest(&opDelete, new(samlProviderRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/saml-providers/{saml_provider_identifier}", opDelete)
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterCodeSearchRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRepo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Limit the search to the repository with the path, or to repositories under the space path."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCodeSearchFile = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFile,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Limit the search to files whose path contains the provided text."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCodeSearchLanguage = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLanguage,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Limit the search to files of the provided language."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCodeSearchLimit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLimit,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The maximum number of matching files to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(request.CodeSearchLimitDefault),
+				Minimum: ptr.Float64(1),
+				Maximum: ptr.Float64(request.CodeSearchLimitMax),
+			},
+		},
+	},
+}
+
+var queryParameterGlobalSearchTypes = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The types of results to search for. All types are searched if not provided."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.SearchResultType("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterGlobalSearchLimit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLimit,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The maximum number of results to return per result type."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(request.GlobalSearchLimitDefault),
+				Minimum: ptr.Float64(1),
+				Maximum: ptr.Float64(request.GlobalSearchLimitMax),
+			},
+		},
+	},
+}
+
+func searchOperations(reflector *openapi3.Reflector) {
+	opSearch := openapi3.Operation{}
+	opSearch.WithTags("search")
+	opSearch.WithMapOfAnything(map[string]interface{}{"operationId": "searchCode"})
+	opSearch.WithParameters(queryParameterGrepQuery, queryParameterGrepRegex, queryParameterGrepIgnoreCase,
+		queryParameterCodeSearchRepo, queryParameterCodeSearchFile, queryParameterCodeSearchLanguage,
+		queryParameterCodeSearchLimit)
+	_ = reflector.SetRequest(&opSearch, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSearch, new(types.SearchResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSearch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSearch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSearch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/search/code", opSearch)
+
+	opGlobalSearch := openapi3.Operation{}
+	opGlobalSearch.WithTags("search")
+	opGlobalSearch.WithMapOfAnything(map[string]interface{}{"operationId": "globalSearch"})
+	opGlobalSearch.WithParameters(queryParameterGrepQuery, queryParameterGlobalSearchTypes,
+		queryParameterGlobalSearchLimit)
+	_ = reflector.SetRequest(&opGlobalSearch, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGlobalSearch, new(types.GlobalSearchResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGlobalSearch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGlobalSearch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGlobalSearch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/search", opGlobalSearch)
+}
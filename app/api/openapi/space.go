@@ -40,6 +40,31 @@ type updateSpaceRequest struct {
 	space.UpdateInput
 }
 
+type updateRepoTemplateRequest struct {
+	spaceRequest
+	types.RepoTemplate
+}
+
+type updateStoragePoolRequest struct {
+	spaceRequest
+	space.StoragePoolUpdateInput
+}
+
+type updateReviewSLARequest struct {
+	spaceRequest
+	types.ReviewSLAPolicy
+}
+
+type updateSpaceQuotaRequest struct {
+	spaceRequest
+	types.SpaceQuota
+}
+
+type updateSpaceSettingsRequest struct {
+	spaceRequest
+	types.SpaceSettings
+}
+
 type updateSpacePublicAccessRequest struct {
 	spaceRequest
 	space.UpdatePublicAccessInput
@@ -54,6 +79,31 @@ type exportSpaceRequest struct {
 	space.ExportInput
 }
 
+type repoBulkSpaceRequest struct {
+	spaceRequest
+	space.RepoBulkInput
+}
+
+type repoBulkProgressSpaceRequest struct {
+	spaceRequest
+	UID string `path:"repo_bulk_uid"`
+}
+
+type spaceFileTemplateTypeRequest struct {
+	spaceRequest
+	fileTemplateTypeRequest
+}
+
+type spaceFileTemplateRequest struct {
+	spaceRequest
+	fileTemplateRequest
+}
+
+type createSpaceFileTemplateRequest struct {
+	spaceRequest
+	createFileTemplateRequest
+}
+
 type restoreSpaceRequest struct {
 	spaceRequest
 	space.RestoreInput
@@ -73,6 +123,7 @@ var queryParameterSortRepo = openapi3.ParameterOrRef{
 					ptr.String(enum.RepoAttrIdentifier.String()),
 					ptr.String(enum.RepoAttrCreated.String()),
 					ptr.String(enum.RepoAttrUpdated.String()),
+					ptr.String(enum.RepoAttrStars.String()),
 				},
 			},
 		},
@@ -93,6 +144,180 @@ var queryParameterQueryRepo = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTopic = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTopic,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The topic which is used to filter the repositories."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterQueryRepoTopic = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The prefix which is used to filter the repository topics."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterRecursive = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRecursive,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the subspaces should be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterIncludeArchived = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeArchived,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether archived repositories should be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterReviewSLAFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamFrom,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The start (in Unix time millis) of the time range in which the reported " +
+			"pull requests were opened. Defaults to 30 days before the end of the time range."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterReviewSLATo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamTo,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The end (in Unix time millis) of the time range in which the reported " +
+			"pull requests were opened. Defaults to now."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAuditResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the resource the audit events are filtered by."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterAuditAction = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The action the audit events are filtered by."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterAuditActorID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamActorID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the principal that performed the audited operations."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAuditFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFrom,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The start (in Unix time millis) of the time range of the audit events."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAuditTo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The end (in Unix time millis) of the time range of the audit events."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAuditExportFormat = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFormat,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The file format of the exported audit events."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.AuditLogExportFormatJSONL),
+				Enum:    enum.AuditLogExportFormat("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterSortSpace = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -214,6 +439,30 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opExportProgress, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/export-progress", opExportProgress)
 
+	opRepoBulk := openapi3.Operation{}
+	opRepoBulk.WithTags("space")
+	opRepoBulk.WithMapOfAnything(map[string]interface{}{"operationId": "repoBulkSpace"})
+	_ = reflector.SetRequest(&opRepoBulk, new(repoBulkSpaceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoBulk, new(space.RepoBulkOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opRepoBulk, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoBulk, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoBulk, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoBulk, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoBulk, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/repos-bulk", opRepoBulk)
+
+	opRepoBulkProgress := openapi3.Operation{}
+	opRepoBulkProgress.WithTags("space")
+	opRepoBulkProgress.WithMapOfAnything(map[string]interface{}{"operationId": "repoBulkProgressSpace"})
+	_ = reflector.SetRequest(&opRepoBulkProgress, new(repoBulkProgressSpaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoBulkProgress, new(space.RepoBulkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoBulkProgress, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoBulkProgress, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoBulkProgress, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoBulkProgress, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repos-bulk/{repo_bulk_uid}",
+		opRepoBulkProgress)
+
 	opGet := openapi3.Operation{}
 	opGet.WithTags("space")
 	opGet.WithMapOfAnything(map[string]interface{}{"operationId": "getSpace"})
@@ -317,7 +566,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opRepos.WithTags("space")
 	opRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listRepos"})
 	opRepos.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
-		QueryParameterPage, QueryParameterLimit)
+		QueryParameterPage, QueryParameterLimit, queryParameterIncludeArchived, queryParameterTopic)
 	_ = reflector.SetRequest(&opRepos, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opRepos, []types.Repository{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusInternalServerError)
@@ -326,6 +575,18 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repos", opRepos)
 
+	opRepoTopics := openapi3.Operation{}
+	opRepoTopics.WithTags("space")
+	opRepoTopics.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoTopics"})
+	opRepoTopics.WithParameters(queryParameterQueryRepoTopic, QueryParameterLimit, queryParameterRecursive)
+	_ = reflector.SetRequest(&opRepoTopics, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoTopics, []types.RepoTopic{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoTopics, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoTopics, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoTopics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoTopics, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repo-topics", opRepoTopics)
+
 	opTemplates := openapi3.Operation{}
 	opTemplates.WithTags("space")
 	opTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "listTemplates"})
@@ -607,4 +868,235 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{repo_ref}/pullreq", listPullReq)
+
+	opRepoTemplateFind := openapi3.Operation{}
+	opRepoTemplateFind.WithTags("space")
+	opRepoTemplateFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceRepoTemplate"})
+	_ = reflector.SetRequest(&opRepoTemplateFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoTemplateFind, new(types.RepoTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoTemplateFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoTemplateFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoTemplateFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoTemplateFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repo-template", opRepoTemplateFind)
+
+	opRepoTemplateUpdate := openapi3.Operation{}
+	opRepoTemplateUpdate.WithTags("space")
+	opRepoTemplateUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceRepoTemplate"})
+	_ = reflector.SetRequest(&opRepoTemplateUpdate, new(updateRepoTemplateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opRepoTemplateUpdate, new(types.RepoTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoTemplateUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoTemplateUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoTemplateUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoTemplateUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoTemplateUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/repo-template", opRepoTemplateUpdate)
+
+	opStoragePoolFind := openapi3.Operation{}
+	opStoragePoolFind.WithTags("space")
+	opStoragePoolFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceStoragePool"})
+	_ = reflector.SetRequest(&opStoragePoolFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStoragePoolFind, new(types.SpaceStoragePool), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStoragePoolFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStoragePoolFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStoragePoolFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStoragePoolFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/storage-pool", opStoragePoolFind)
+
+	opStoragePoolUpdate := openapi3.Operation{}
+	opStoragePoolUpdate.WithTags("space")
+	opStoragePoolUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceStoragePool"})
+	_ = reflector.SetRequest(&opStoragePoolUpdate, new(updateStoragePoolRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opStoragePoolUpdate, new(types.SpaceStoragePool), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStoragePoolUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStoragePoolUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStoragePoolUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStoragePoolUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStoragePoolUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/storage-pool", opStoragePoolUpdate)
+
+	opStoragePoolRebalance := openapi3.Operation{}
+	opStoragePoolRebalance.WithTags("space")
+	opStoragePoolRebalance.WithMapOfAnything(map[string]interface{}{"operationId": "rebalanceSpaceStoragePool"})
+	_ = reflector.SetRequest(&opStoragePoolRebalance, new(spaceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opStoragePoolRebalance, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opStoragePoolRebalance, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStoragePoolRebalance, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStoragePoolRebalance, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStoragePoolRebalance, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/storage-pool/rebalance",
+		opStoragePoolRebalance)
+
+	opReviewSLAFind := openapi3.Operation{}
+	opReviewSLAFind.WithTags("space")
+	opReviewSLAFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceReviewSLA"})
+	_ = reflector.SetRequest(&opReviewSLAFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReviewSLAFind, new(types.SpaceReviewSLA), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReviewSLAFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReviewSLAFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReviewSLAFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReviewSLAFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/review-sla", opReviewSLAFind)
+
+	opReviewSLAUpdate := openapi3.Operation{}
+	opReviewSLAUpdate.WithTags("space")
+	opReviewSLAUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceReviewSLA"})
+	_ = reflector.SetRequest(&opReviewSLAUpdate, new(updateReviewSLARequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opReviewSLAUpdate, new(types.SpaceReviewSLA), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReviewSLAUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReviewSLAUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReviewSLAUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReviewSLAUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReviewSLAUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/review-sla", opReviewSLAUpdate)
+
+	opReviewSLAReport := openapi3.Operation{}
+	opReviewSLAReport.WithTags("space")
+	opReviewSLAReport.WithMapOfAnything(map[string]interface{}{"operationId": "reportSpaceReviewSLA"})
+	opReviewSLAReport.WithParameters(queryParameterReviewSLAFrom, queryParameterReviewSLATo)
+	_ = reflector.SetRequest(&opReviewSLAReport, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReviewSLAReport, new(types.ReviewSLAReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReviewSLAReport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReviewSLAReport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReviewSLAReport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReviewSLAReport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReviewSLAReport, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/review-sla/report", opReviewSLAReport)
+
+	opQuotaFind := openapi3.Operation{}
+	opQuotaFind.WithTags("space")
+	opQuotaFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceQuota"})
+	_ = reflector.SetRequest(&opQuotaFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(types.SpaceQuotaUsage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/quota", opQuotaFind)
+
+	opQuotaUpdate := openapi3.Operation{}
+	opQuotaUpdate.WithTags("space")
+	opQuotaUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceQuota"})
+	_ = reflector.SetRequest(&opQuotaUpdate, new(updateSpaceQuotaRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(types.SpaceQuotaUsage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/quota", opQuotaUpdate)
+
+	opAuditLogList := openapi3.Operation{}
+	opAuditLogList.WithTags("space")
+	opAuditLogList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceAuditLogs"})
+	opAuditLogList.WithParameters(QueryParameterPage, QueryParameterLimit, queryParameterRecursive,
+		queryParameterAuditResourceType, queryParameterAuditAction, queryParameterAuditActorID,
+		queryParameterAuditFrom, queryParameterAuditTo)
+	_ = reflector.SetRequest(&opAuditLogList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new([]*types.AuditEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-logs", opAuditLogList)
+
+	opAuditLogExport := openapi3.Operation{}
+	opAuditLogExport.WithTags("space")
+	opAuditLogExport.WithMapOfAnything(map[string]interface{}{"operationId": "exportSpaceAuditLogs"})
+	opAuditLogExport.WithParameters(queryParameterRecursive,
+		queryParameterAuditResourceType, queryParameterAuditAction, queryParameterAuditActorID,
+		queryParameterAuditFrom, queryParameterAuditTo, queryParameterAuditExportFormat)
+	_ = reflector.SetRequest(&opAuditLogExport, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opAuditLogExport, http.StatusOK, "application/x-ndjson")
+	_ = reflector.SetJSONResponse(&opAuditLogExport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuditLogExport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuditLogExport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuditLogExport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAuditLogExport, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-logs/export", opAuditLogExport)
+
+	opFileTemplateList := openapi3.Operation{}
+	opFileTemplateList.WithTags("space")
+	opFileTemplateList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceFileTemplates"})
+	_ = reflector.SetRequest(&opFileTemplateList, new(spaceFileTemplateTypeRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFileTemplateList, []types.FileTemplateInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFileTemplateList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFileTemplateList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFileTemplateList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFileTemplateList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFileTemplateList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/file-templates/{file_template_type}", opFileTemplateList)
+
+	opFileTemplateCreate := openapi3.Operation{}
+	opFileTemplateCreate.WithTags("space")
+	opFileTemplateCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSpaceFileTemplate"})
+	_ = reflector.SetRequest(&opFileTemplateCreate, new(createSpaceFileTemplateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFileTemplateCreate, new(types.FileTemplate), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFileTemplateCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFileTemplateCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFileTemplateCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFileTemplateCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFileTemplateCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/file-templates/{file_template_type}", opFileTemplateCreate)
+
+	opFileTemplateFind := openapi3.Operation{}
+	opFileTemplateFind.WithTags("space")
+	opFileTemplateFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceFileTemplate"})
+	_ = reflector.SetRequest(&opFileTemplateFind, new(spaceFileTemplateRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFileTemplateFind, new(types.FileTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFileTemplateFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFileTemplateFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFileTemplateFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFileTemplateFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/file-templates/{file_template_type}/{file_template_identifier}", opFileTemplateFind)
+
+	opFileTemplateDelete := openapi3.Operation{}
+	opFileTemplateDelete.WithTags("space")
+	opFileTemplateDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceFileTemplate"})
+	_ = reflector.SetRequest(&opFileTemplateDelete, new(spaceFileTemplateRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opFileTemplateDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opFileTemplateDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFileTemplateDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFileTemplateDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFileTemplateDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/file-templates/{file_template_type}/{file_template_identifier}", opFileTemplateDelete)
+
+	opSettingsFind := openapi3.Operation{}
+	opSettingsFind.WithTags("space")
+	opSettingsFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceSettings"})
+	_ = reflector.SetRequest(&opSettingsFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsFind, new(types.SpaceSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/settings", opSettingsFind)
+
+	opSettingsUpdate := openapi3.Operation{}
+	opSettingsUpdate.WithTags("space")
+	opSettingsUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceSettings"})
+	_ = reflector.SetRequest(&opSettingsUpdate, new(updateSpaceSettingsRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSettingsUpdate, new(types.SpaceSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/settings", opSettingsUpdate)
+
+	opSettingsEffective := openapi3.Operation{}
+	opSettingsEffective.WithTags("space")
+	opSettingsEffective.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceEffectiveSettings"})
+	_ = reflector.SetRequest(&opSettingsEffective, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new([]types.EffectiveSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsEffective, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/settings/effective", opSettingsEffective)
 }
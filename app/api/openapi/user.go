@@ -17,9 +17,11 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/passkey"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -27,10 +29,19 @@ import (
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
+type avatarUploadRequest struct {
+	Content string `json:"-" format:"binary" description:"Image (png, jpeg or gif) to use as avatar"`
+}
+
 type tokensRequest struct {
 	Identifier string `path:"token_identifier"`
 }
 
+type rotateTokenRequest struct {
+	tokensRequest
+	user.RotateTokenInput
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -91,6 +102,28 @@ var queryParameterSortPublicKey = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterUsagePublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPublicKeyUsage,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The usage of the public keys to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.PublicKeyUsage("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 // helper function that constructs the openapi specification
 // for user account resources.
 func buildUser(reflector *openapi3.Reflector) {
@@ -122,6 +155,49 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
 
+	opStarred := openapi3.Operation{}
+	opStarred.WithTags("user")
+	opStarred.WithMapOfAnything(map[string]interface{}{"operationId": "listStarredRepositories"})
+	opStarred.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opStarred, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStarred, new([]repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStarred, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/starred", opStarred)
+
+	opAvatarUpload := openapi3.Operation{}
+	opAvatarUpload.WithTags("user")
+	opAvatarUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadAvatar"})
+	_ = reflector.SetRequest(&opAvatarUpload, new(avatarUploadRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opAvatarUpload, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAvatarUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAvatarUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/avatar", opAvatarUpload)
+
+	opAvatarDelete := openapi3.Operation{}
+	opAvatarDelete.WithTags("user")
+	opAvatarDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteAvatar"})
+	_ = reflector.SetRequest(&opAvatarDelete, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAvatarDelete, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAvatarDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/avatar", opAvatarDelete)
+
+	opPreferencesFind := openapi3.Operation{}
+	opPreferencesFind.WithTags("user")
+	opPreferencesFind.WithMapOfAnything(map[string]interface{}{"operationId": "getUserPreferences"})
+	_ = reflector.SetRequest(&opPreferencesFind, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPreferencesFind, new(types.UserPreferences), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPreferencesFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/preferences", opPreferencesFind)
+
+	opPreferencesUpdate := openapi3.Operation{}
+	opPreferencesUpdate.WithTags("user")
+	opPreferencesUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserPreferences"})
+	_ = reflector.SetRequest(&opPreferencesUpdate, new(user.UpdatePreferencesInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPreferencesUpdate, new(types.UserPreferences), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPreferencesUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPreferencesUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/preferences", opPreferencesUpdate)
+
 	opKeyCreate := openapi3.Operation{}
 	opKeyCreate.WithTags("user")
 	opKeyCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
@@ -142,17 +218,218 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opKeyDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_identifier}", opKeyDelete)
 
+	opKeyFind := openapi3.Operation{}
+	opKeyFind.WithTags("user")
+	opKeyFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPublicKey"})
+	_ = reflector.SetRequest(&opKeyFind, struct {
+		ID string `path:"public_key_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opKeyFind, new(types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opKeyFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opKeyFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys/{public_key_identifier}", opKeyFind)
+
+	opKeyUpdate := openapi3.Operation{}
+	opKeyUpdate.WithTags("user")
+	opKeyUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updatePublicKey"})
+	_ = reflector.SetRequest(&opKeyUpdate, struct {
+		user.UpdatePublicKeyInput
+		ID string `path:"public_key_identifier"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opKeyUpdate, new(types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opKeyUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opKeyUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opKeyUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/keys/{public_key_identifier}", opKeyUpdate)
+
+	opKeyRevoke := openapi3.Operation{}
+	opKeyRevoke.WithTags("user")
+	opKeyRevoke.WithMapOfAnything(map[string]interface{}{"operationId": "revokePublicKey"})
+	_ = reflector.SetRequest(&opKeyRevoke, struct {
+		ID string `path:"public_key_identifier"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opKeyRevoke, new(types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opKeyRevoke, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opKeyRevoke, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys/{public_key_identifier}/revoke", opKeyRevoke)
+
 	opKeyList := openapi3.Operation{}
 	opKeyList.WithTags("user")
 	opKeyList.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKey"})
 	opKeyList.WithParameters(QueryParameterPage, QueryParameterLimit,
-		queryParameterQueryPublicKey, queryParameterSortPublicKey, queryParameterOrder)
+		queryParameterQueryPublicKey, queryParameterSortPublicKey, queryParameterOrder, queryParameterUsagePublicKey)
 	_ = reflector.SetRequest(&opKeyList, struct{}{}, http.MethodGet)
 	_ = reflector.SetJSONResponse(&opKeyList, new([]types.PublicKey), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opKeyList)
 
+	opEmailList := openapi3.Operation{}
+	opEmailList.WithTags("user")
+	opEmailList.WithMapOfAnything(map[string]interface{}{"operationId": "listEmails"})
+	_ = reflector.SetRequest(&opEmailList, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opEmailList, new([]types.UserEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opEmailList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/emails", opEmailList)
+
+	opEmailAdd := openapi3.Operation{}
+	opEmailAdd.WithTags("user")
+	opEmailAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addEmail"})
+	_ = reflector.SetRequest(&opEmailAdd, new(user.AddEmailInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opEmailAdd, new(types.UserEmail), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opEmailAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opEmailAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opEmailAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails", opEmailAdd)
+
+	opEmailVerify := openapi3.Operation{}
+	opEmailVerify.WithTags("user")
+	opEmailVerify.WithMapOfAnything(map[string]interface{}{"operationId": "verifyEmail"})
+	_ = reflector.SetRequest(&opEmailVerify, new(user.VerifyEmailInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opEmailVerify, new(types.UserEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opEmailVerify, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opEmailVerify, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/verify", opEmailVerify)
+
+	opEmailDelete := openapi3.Operation{}
+	opEmailDelete.WithTags("user")
+	opEmailDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteEmail"})
+	_ = reflector.SetRequest(&opEmailDelete, struct {
+		Email string `path:"user_email"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opEmailDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opEmailDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opEmailDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opEmailDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/emails/{user_email}", opEmailDelete)
+
+	opEmailResend := openapi3.Operation{}
+	opEmailResend.WithTags("user")
+	opEmailResend.WithMapOfAnything(map[string]interface{}{"operationId": "resendEmailVerification"})
+	_ = reflector.SetRequest(&opEmailResend, struct {
+		Email string `path:"user_email"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opEmailResend, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opEmailResend, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opEmailResend, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opEmailResend, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{user_email}/resend-verification", opEmailResend)
+
+	opEmailPrimary := openapi3.Operation{}
+	opEmailPrimary.WithTags("user")
+	opEmailPrimary.WithMapOfAnything(map[string]interface{}{"operationId": "setPrimaryEmail"})
+	_ = reflector.SetRequest(&opEmailPrimary, struct {
+		Email string `path:"user_email"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opEmailPrimary, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opEmailPrimary, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opEmailPrimary, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opEmailPrimary, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{user_email}/primary", opEmailPrimary)
+
+	opTwoFactorStatus := openapi3.Operation{}
+	opTwoFactorStatus.WithTags("user")
+	opTwoFactorStatus.WithMapOfAnything(map[string]interface{}{"operationId": "getTwoFactorStatus"})
+	_ = reflector.SetRequest(&opTwoFactorStatus, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTwoFactorStatus, new(types.TwoFactorStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTwoFactorStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/2fa", opTwoFactorStatus)
+
+	opTwoFactorEnroll := openapi3.Operation{}
+	opTwoFactorEnroll.WithTags("user")
+	opTwoFactorEnroll.WithMapOfAnything(map[string]interface{}{"operationId": "enrollTwoFactor"})
+	_ = reflector.SetRequest(&opTwoFactorEnroll, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTwoFactorEnroll, new(types.TwoFactorEnrollment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opTwoFactorEnroll, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opTwoFactorEnroll, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/totp", opTwoFactorEnroll)
+
+	opTwoFactorConfirm := openapi3.Operation{}
+	opTwoFactorConfirm.WithTags("user")
+	opTwoFactorConfirm.WithMapOfAnything(map[string]interface{}{"operationId": "confirmTwoFactor"})
+	_ = reflector.SetRequest(&opTwoFactorConfirm, new(user.TwoFactorCodeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTwoFactorConfirm, new(types.TwoFactorRecoveryCodes), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTwoFactorConfirm, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTwoFactorConfirm, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opTwoFactorConfirm, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opTwoFactorConfirm, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/totp/confirm", opTwoFactorConfirm)
+
+	opTwoFactorDisable := openapi3.Operation{}
+	opTwoFactorDisable.WithTags("user")
+	opTwoFactorDisable.WithMapOfAnything(map[string]interface{}{"operationId": "disableTwoFactor"})
+	_ = reflector.SetRequest(&opTwoFactorDisable, new(user.TwoFactorCodeInput), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opTwoFactorDisable, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTwoFactorDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTwoFactorDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opTwoFactorDisable, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opTwoFactorDisable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/2fa/totp", opTwoFactorDisable)
+
+	opTwoFactorRecoveryCodes := openapi3.Operation{}
+	opTwoFactorRecoveryCodes.WithTags("user")
+	opTwoFactorRecoveryCodes.WithMapOfAnything(
+		map[string]interface{}{"operationId": "regenerateTwoFactorRecoveryCodes"})
+	_ = reflector.SetRequest(&opTwoFactorRecoveryCodes, new(user.TwoFactorCodeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTwoFactorRecoveryCodes, new(types.TwoFactorRecoveryCodes), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTwoFactorRecoveryCodes, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTwoFactorRecoveryCodes, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opTwoFactorRecoveryCodes, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/recovery-codes", opTwoFactorRecoveryCodes)
+
+	opWebAuthnRegisterBegin := openapi3.Operation{}
+	opWebAuthnRegisterBegin.WithTags("user")
+	opWebAuthnRegisterBegin.WithMapOfAnything(map[string]interface{}{"operationId": "beginWebAuthnRegistration"})
+	_ = reflector.SetRequest(&opWebAuthnRegisterBegin, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opWebAuthnRegisterBegin, new(passkey.RegistrationChallenge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWebAuthnRegisterBegin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/webauthn/register", opWebAuthnRegisterBegin)
+
+	opWebAuthnList := openapi3.Operation{}
+	opWebAuthnList.WithTags("user")
+	opWebAuthnList.WithMapOfAnything(map[string]interface{}{"operationId": "listWebAuthnCredentials"})
+	_ = reflector.SetRequest(&opWebAuthnList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opWebAuthnList, new([]types.WebAuthnCredential), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWebAuthnList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/webauthn/credentials", opWebAuthnList)
+
+	opWebAuthnRegisterFinish := openapi3.Operation{}
+	opWebAuthnRegisterFinish.WithTags("user")
+	opWebAuthnRegisterFinish.WithMapOfAnything(map[string]interface{}{"operationId": "finishWebAuthnRegistration"})
+	_ = reflector.SetRequest(&opWebAuthnRegisterFinish, new(passkey.RegistrationInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opWebAuthnRegisterFinish, new(types.WebAuthnCredential), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opWebAuthnRegisterFinish, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWebAuthnRegisterFinish, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opWebAuthnRegisterFinish, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/webauthn/credentials", opWebAuthnRegisterFinish)
+
+	opWebAuthnUpdate := openapi3.Operation{}
+	opWebAuthnUpdate.WithTags("user")
+	opWebAuthnUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateWebAuthnCredential"})
+	_ = reflector.SetRequest(&opWebAuthnUpdate, struct {
+		types.WebAuthnCredentialUpdateInput
+		ID int64 `path:"webauthn_credential_id"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opWebAuthnUpdate, new(types.WebAuthnCredential), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWebAuthnUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWebAuthnUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opWebAuthnUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/user/webauthn/credentials/{webauthn_credential_id}", opWebAuthnUpdate)
+
+	opWebAuthnRevoke := openapi3.Operation{}
+	opWebAuthnRevoke.WithTags("user")
+	opWebAuthnRevoke.WithMapOfAnything(map[string]interface{}{"operationId": "revokeWebAuthnCredential"})
+	_ = reflector.SetRequest(&opWebAuthnRevoke, struct {
+		ID int64 `path:"webauthn_credential_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opWebAuthnRevoke, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opWebAuthnRevoke, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opWebAuthnRevoke, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opWebAuthnRevoke, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/user/webauthn/credentials/{webauthn_credential_id}", opWebAuthnRevoke)
+
 	opListTokens := openapi3.Operation{}
 	opListTokens.WithTags("user")
 	opListTokens.WithMapOfAnything(map[string]interface{}{"operationId": "listTokens"})
@@ -183,4 +460,48 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDeleteToken, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opDeleteToken, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/tokens/{token_identifier}", opDeleteToken)
+
+	opRotateToken := openapi3.Operation{}
+	opRotateToken.WithTags("user")
+	opRotateToken.WithMapOfAnything(map[string]interface{}{"operationId": "rotateToken"})
+	_ = reflector.SetRequest(&opRotateToken, new(rotateTokenRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRotateToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/tokens/{token_identifier}/rotate", opRotateToken)
+
+	opListSessions := openapi3.Operation{}
+	opListSessions.WithTags("user")
+	opListSessions.WithMapOfAnything(map[string]interface{}{"operationId": "listSessions"})
+	_ = reflector.SetRequest(&opListSessions, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSessions, new([]types.Token), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSessions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSessions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListSessions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/sessions", opListSessions)
+
+	opDeleteOtherSessions := openapi3.Operation{}
+	opDeleteOtherSessions.WithTags("user")
+	opDeleteOtherSessions.WithMapOfAnything(map[string]interface{}{"operationId": "deleteOtherSessions"})
+	_ = reflector.SetRequest(&opDeleteOtherSessions, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteOtherSessions, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteOtherSessions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteOtherSessions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteOtherSessions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/sessions", opDeleteOtherSessions)
+
+	opDeleteSession := openapi3.Operation{}
+	opDeleteSession.WithTags("user")
+	opDeleteSession.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSession"})
+	_ = reflector.SetRequest(&opDeleteSession, new(tokensRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSession, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSession, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteSession, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSession, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteSession, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/sessions/{token_identifier}", opDeleteSession)
 }
@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/usergroup"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type userGroupRequest struct {
+	spaceRequest
+	Identifier string `path:"usergroup_identifier"`
+}
+
+type userGroupMemberRequest struct {
+	userGroupRequest
+	UserUID string `path:"user_uid"`
+}
+
+type userGroupSubgroupRequest struct {
+	userGroupRequest
+	SubgroupIdentifier string `path:"subgroup_identifier"`
+}
+
+type userGroupMembershipRequest struct {
+	spaceRequest
+	UserGroupID int64 `path:"user_group_id"`
+}
+
+func userGroupOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("usergroup")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listUsergroups"})
+	opList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.UserGroupInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("usergroup")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createUsergroup"})
+	_ = reflector.SetRequest(&opCreate, &struct {
+		spaceRequest
+		usergroup.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.UserGroup), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroups", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("usergroup")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findUsergroup"})
+	_ = reflector.SetRequest(&opFind, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.UserGroup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("usergroup")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUsergroup"})
+	_ = reflector.SetRequest(&opUpdate, &struct {
+		userGroupRequest
+		usergroup.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.UserGroup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("usergroup")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUsergroup"})
+	_ = reflector.SetRequest(&opDelete, new(userGroupRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opDelete)
+
+	opMemberList := openapi3.Operation{}
+	opMemberList.WithTags("usergroup")
+	opMemberList.WithMapOfAnything(map[string]interface{}{"operationId": "listUsergroupMembers"})
+	_ = reflector.SetRequest(&opMemberList, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMemberList, new([]types.UserGroupMemberUser), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/usergroups/{usergroup_identifier}/members", opMemberList)
+
+	opMemberAdd := openapi3.Operation{}
+	opMemberAdd.WithTags("usergroup")
+	opMemberAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addUsergroupMember"})
+	_ = reflector.SetRequest(&opMemberAdd, &struct {
+		userGroupRequest
+		usergroup.MemberAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(types.UserGroupMemberUser), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/usergroups/{usergroup_identifier}/members", opMemberAdd)
+
+	opMemberDelete := openapi3.Operation{}
+	opMemberDelete.WithTags("usergroup")
+	opMemberDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUsergroupMember"})
+	_ = reflector.SetRequest(&opMemberDelete, new(userGroupMemberRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMemberDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroups/{usergroup_identifier}/members/{user_uid}", opMemberDelete)
+
+	opSubgroupList := openapi3.Operation{}
+	opSubgroupList.WithTags("usergroup")
+	opSubgroupList.WithMapOfAnything(map[string]interface{}{"operationId": "listUsergroupSubgroups"})
+	_ = reflector.SetRequest(&opSubgroupList, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSubgroupList, new([]*types.UserGroupInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSubgroupList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSubgroupList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSubgroupList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSubgroupList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/usergroups/{usergroup_identifier}/subgroups", opSubgroupList)
+
+	opSubgroupAdd := openapi3.Operation{}
+	opSubgroupAdd.WithTags("usergroup")
+	opSubgroupAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addUsergroupSubgroup"})
+	_ = reflector.SetRequest(&opSubgroupAdd, &struct {
+		userGroupRequest
+		usergroup.SubgroupAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(types.UserGroupInfo), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSubgroupAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/usergroups/{usergroup_identifier}/subgroups", opSubgroupAdd)
+
+	opSubgroupDelete := openapi3.Operation{}
+	opSubgroupDelete.WithTags("usergroup")
+	opSubgroupDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUsergroupSubgroup"})
+	_ = reflector.SetRequest(&opSubgroupDelete, new(userGroupSubgroupRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSubgroupDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSubgroupDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSubgroupDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSubgroupDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSubgroupDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroups/{usergroup_identifier}/subgroups/{subgroup_identifier}", opSubgroupDelete)
+
+	opMembershipList := openapi3.Operation{}
+	opMembershipList.WithTags("usergroup")
+	opMembershipList.WithMapOfAnything(map[string]interface{}{"operationId": "listUsergroupMemberships"})
+	_ = reflector.SetRequest(&opMembershipList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMembershipList, new([]types.UserGroupMembershipInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroup-members", opMembershipList)
+
+	opMembershipAdd := openapi3.Operation{}
+	opMembershipAdd.WithTags("usergroup")
+	opMembershipAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addUsergroupMembership"})
+	_ = reflector.SetRequest(&opMembershipAdd, &struct {
+		spaceRequest
+		usergroup.MembershipAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(types.UserGroupMembershipInfo), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroup-members", opMembershipAdd)
+
+	opMembershipUpdate := openapi3.Operation{}
+	opMembershipUpdate.WithTags("usergroup")
+	opMembershipUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUsergroupMembership"})
+	_ = reflector.SetRequest(&opMembershipUpdate, &struct {
+		userGroupMembershipRequest
+		usergroup.MembershipUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(types.UserGroupMembership), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/usergroup-members/{user_group_id}", opMembershipUpdate)
+
+	opMembershipDelete := openapi3.Operation{}
+	opMembershipDelete.WithTags("usergroup")
+	opMembershipDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUsergroupMembership"})
+	_ = reflector.SetRequest(&opMembershipDelete, new(userGroupMembershipRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroup-members/{user_group_id}", opMembershipDelete)
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/wiki"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type wikiPageRequest struct {
+	repoRequest
+	Name string `path:"page_name"`
+}
+
+type listWikiPagesRequest struct {
+	repoRequest
+}
+
+type createWikiPageRequest struct {
+	repoRequest
+	wiki.CreatePageInput
+}
+
+type updateWikiPageRequest struct {
+	wikiPageRequest
+	wiki.UpdatePageInput
+}
+
+var queryParameterWikiMessage = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMessage,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The message of the commit that deletes the page."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func wikiOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("wiki")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listWikiPages"})
+	opList.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opList, new(listWikiPagesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.WikiPage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/wiki/pages", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("wiki")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createWikiPage"})
+	_ = reflector.SetRequest(&opCreate, new(createWikiPageRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.WikiPage), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/wiki/pages", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("wiki")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "getWikiPage"})
+	opFind.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opFind, new(wikiPageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.WikiPage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/wiki/pages/{page_name}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("wiki")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateWikiPage"})
+	_ = reflector.SetRequest(&opUpdate, new(updateWikiPageRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.WikiPage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/wiki/pages/{page_name}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("wiki")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteWikiPage"})
+	opDelete.WithParameters(queryParameterWikiMessage)
+	_ = reflector.SetRequest(&opDelete, new(wikiPageRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/wiki/pages/{page_name}", opDelete)
+
+	opHistory := openapi3.Operation{}
+	opHistory.WithTags("wiki")
+	opHistory.WithMapOfAnything(map[string]interface{}{"operationId": "listWikiPageHistory"})
+	opHistory.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opHistory, new(wikiPageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opHistory, new([]types.Commit), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/wiki/history/{page_name}", opHistory)
+}
@@ -17,6 +17,7 @@ package render
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"io"
 	"net/http"
 	"os"
@@ -53,6 +54,21 @@ func JSON(w http.ResponseWriter, code int, v interface{}) {
 	writeJSON(w, v)
 }
 
+// XML writes the xml-encoded value to the response
+// with the provides status.
+func XML(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		log.Err(err).Msgf("Failed to write xml header to response body.")
+		return
+	}
+	if err := xml.NewEncoder(w).Encode(v); err != nil {
+		log.Err(err).Msgf("Failed to write xml encoding to response body.")
+	}
+}
+
 // Reader reads the content from the provided reader and writes it as is to the response body.
 // NOTE: If no content-type header is added beforehand, the content-type will be deduced
 // automatically by `http.DetectContentType` (https://pkg.go.dev/net/http#DetectContentType).
@@ -17,6 +17,7 @@ package render
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -170,6 +171,25 @@ func TestWriteJSON(t *testing.T) {
 	}
 }
 
+func TestWriteXML(t *testing.T) {
+	type hello struct {
+		XMLName xml.Name `xml:"hello"`
+		Name    string   `xml:"name,attr"`
+	}
+
+	w := httptest.NewRecorder()
+	XML(w, http.StatusTeapot, &hello{Name: "world"})
+	if got, want := w.Body.String(), xml.Header+"<hello name=\"world\"></hello>"; got != want {
+		t.Errorf("Want XML body %q, got %q", want, got)
+	}
+	if got, want := w.Header().Get("Content-Type"), "application/xml; charset=utf-8"; got != want {
+		t.Errorf("Want Content-Type %q, got %q", want, got)
+	}
+	if got, want := w.Code, http.StatusTeapot; got != want {
+		t.Errorf("Want status code %d, got %d", want, got)
+	}
+}
+
 func TestJSONArrayDynamic(t *testing.T) {
 	noctx := context.Background()
 	type mock struct {
@@ -80,6 +80,16 @@ func ParseArchiveParams(r *http.Request) (api.ArchiveParams, string, error) {
 		return api.ArchiveParams{}, "", err
 	}
 
+	// paths are relative to the repository root, empty paths would include the whole repository.
+	var paths []string
+	for _, p := range r.URL.Query()[QueryParamArchivePaths] {
+		p = strings.Trim(p, "/")
+		if p == "" {
+			continue
+		}
+		paths = append(paths, p)
+	}
+
 	// get name from filename
 	name := strings.TrimSuffix(filename, "."+format)
 	return api.ArchiveParams{
@@ -89,6 +99,6 @@ func ParseArchiveParams(r *http.Request) (api.ArchiveParams, string, error) {
 		Time:        mtime,
 		Compression: compression,
 		Treeish:     rev + name,
-		Paths:       r.URL.Query()[QueryParamArchivePaths],
+		Paths:       paths,
 	}, filename, nil
 }
@@ -107,6 +107,22 @@ func TestParseArchiveParams(t *testing.T) {
 			},
 			wantFilename: "main.tgz",
 		},
+		{
+			name: "git archive with sub paths",
+			args: args{
+				r: func() *http.Request {
+					r := req("refs/heads/main.zip")
+					r.URL.RawQuery = "path=/docs/&path=&path=src/app"
+					return r
+				}(),
+			},
+			wantParams: api.ArchiveParams{
+				Format:  api.ArchiveFormatZip,
+				Treeish: "refs/heads/main",
+				Paths:   []string{"docs", "src/app"},
+			},
+			wantFilename: "main.zip",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
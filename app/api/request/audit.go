@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamResourceType = "resource_type"
+	QueryParamAction       = "action"
+	QueryParamActorID      = "actor_id"
+)
+
+// ParseAuditEventFilter extracts the audit event query parameters from the url.
+func ParseAuditEventFilter(r *http.Request) (*types.AuditEventFilter, error) {
+	actorID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamActorID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamFrom, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamTo, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AuditEventFilter{
+		Page:         ParsePage(r),
+		Size:         ParseLimit(r),
+		ResourceType: r.URL.Query().Get(QueryParamResourceType),
+		Action:       r.URL.Query().Get(QueryParamAction),
+		ActorID:      actorID,
+		From:         from,
+		To:           to,
+		Recursive:    recursive,
+	}, nil
+}
+
+const QueryParamFormat = "format"
+
+// ParseAuditLogExportFormat extracts the audit log export format from the url, defaults to JSONL.
+func ParseAuditLogExportFormat(r *http.Request) (enum.AuditLogExportFormat, error) {
+	format, ok := enum.AuditLogExportFormat(r.URL.Query().Get(QueryParamFormat)).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Invalid audit log export format, supported formats are %s and %s.",
+			enum.AuditLogExportFormatCSV, enum.AuditLogExportFormatJSONL)
+	}
+
+	return format, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamFileTemplateType       = "file_template_type"
+	PathParamFileTemplateIdentifier = "file_template_identifier"
+)
+
+// GetFileTemplateTypeFromPath extracts the file template type from the url.
+func GetFileTemplateTypeFromPath(r *http.Request) (enum.FileTemplateType, error) {
+	s, err := PathParamOrError(r, PathParamFileTemplateType)
+	if err != nil {
+		return "", err
+	}
+
+	templateType, ok := enum.FileTemplateType(s).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Invalid file template type '%s'.", s)
+	}
+
+	return templateType, nil
+}
+
+func GetFileTemplateIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamFileTemplateIdentifier)
+}
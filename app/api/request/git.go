@@ -30,8 +30,11 @@ const (
 	HeaderParamGitProtocol = "Git-Protocol"
 
 	PathParamCommitSHA = "commit_sha"
+	PathParamGitRef    = "git_ref"
 
 	QueryParamGitRef             = "git_ref"
+	QueryParamAt                 = "at"
+	QueryParamSourceRepoRef      = "source_repo_ref"
 	QueryParamIncludeCommit      = "include_commit"
 	QueryParamIncludeDirectories = "include_directories"
 	QueryParamLineFrom           = "line_from"
@@ -41,15 +44,38 @@ const (
 	QueryParamUntil              = "until"
 	QueryParamCommitter          = "committer"
 	QueryParamIncludeStats       = "include_stats"
+	QueryParamIncludeSignature   = "include_signature_status"
 	QueryParamInternal           = "internal"
 	QueryParamService            = "service"
 	QueryParamCommitSHA          = "commit_sha"
+	QueryParamGrepQuery          = "q"
+	QueryParamRegex              = "regex"
+	QueryParamIgnoreCase         = "ignore_case"
+
+	// GrepLimitDefault and GrepLimitMax limit the number of matching lines returned by grep.
+	GrepLimitDefault = 100
+	GrepLimitMax     = 1000
 )
 
 func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
 	return QueryParamOrDefault(r, QueryParamGitRef, deflt)
 }
 
+// GetGitRefFromPath extracts the git reference from the url path.
+func GetGitRefFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamGitRef)
+}
+
+// GetAtFromQuery extracts the optional point in time (unix millis) at which git references are resolved.
+func GetAtFromQuery(r *http.Request) (int64, error) {
+	return QueryParamAsPositiveInt64OrDefault(r, QueryParamAt, 0)
+}
+
+// GetSourceRepoRefFromQuery extracts the optional reference of the repository containing the head of a comparison.
+func GetSourceRepoRefFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamSourceRepoRef, "")
+}
+
 func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
@@ -114,6 +140,10 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	includeSignatureStatus, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeSignature, false)
+	if err != nil {
+		return nil, err
+	}
 
 	return &types.CommitFilter{
 		After: QueryParamOrDefault(r, QueryParamAfter, ""),
@@ -126,6 +156,45 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 		Until:        until,
 		Committer:    QueryParamOrDefault(r, QueryParamCommitter, ""),
 		IncludeStats: includeStats,
+
+		IncludeSignatureStatus: includeSignatureStatus,
+	}, nil
+}
+
+// ParseGrepFilter extracts the grep filter from the url.
+func ParseGrepFilter(r *http.Request) (*types.GrepFilter, error) {
+	query, err := QueryParamOrError(r, QueryParamGrepQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	regex, err := QueryParamAsBoolOrDefault(r, QueryParamRegex, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreCase, err := QueryParamAsBoolOrDefault(r, QueryParamIgnoreCase, false)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLimit, GrepLimitDefault)
+	if err != nil {
+		return nil, err
+	}
+	if limit > GrepLimitMax {
+		limit = GrepLimitMax
+	}
+
+	paths, _ := QueryParamList(r, QueryParamPath)
+
+	return &types.GrepFilter{
+		GitRef:     GetGitRefFromQueryOrDefault(r, ""),
+		Query:      query,
+		Regex:      regex,
+		IgnoreCase: ignoreCase,
+		Paths:      paths,
+		Limit:      int(limit),
 	}, nil
 }
 
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
 )
 
 func TestGetFileDiffRequestsFromQuery(t *testing.T) {
@@ -143,3 +144,74 @@ func TestGetFileDiffRequestsFromQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGrepFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *types.GrepFilter
+		wantErr bool
+	}{
+		{
+			name:    "missing query",
+			query:   "git_ref=main",
+			wantErr: true,
+		},
+		{
+			name:  "defaults",
+			query: "q=foo",
+			want: &types.GrepFilter{
+				Query: "foo",
+				Limit: GrepLimitDefault,
+			},
+		},
+		{
+			name:  "all options",
+			query: "q=fo%2Bo&git_ref=main&regex=true&ignore_case=true&limit=5&path=a.go&path=dir/",
+			want: &types.GrepFilter{
+				GitRef:     "main",
+				Query:      "fo+o",
+				Regex:      true,
+				IgnoreCase: true,
+				Paths:      []string{"a.go", "dir/"},
+				Limit:      5,
+			},
+		},
+		{
+			name:  "limit capped",
+			query: "q=foo&limit=100000",
+			want: &types.GrepFilter{
+				Query: "foo",
+				Limit: GrepLimitMax,
+			},
+		},
+		{
+			name:    "invalid regex flag",
+			query:   "q=foo&regex=maybe",
+			wantErr: true,
+		},
+		{
+			name:    "invalid limit",
+			query:   "q=foo&limit=-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{
+				URL: &url.URL{
+					Path:     "/grep",
+					RawQuery: tt.query,
+				},
+			}
+
+			got, err := ParseGrepFilter(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGrepFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGrepFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
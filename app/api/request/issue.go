@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamIssueNumber    = "issue_number"
+	PathParamIssueCommentID = "issue_comment_id"
+	PathParamAssigneeID     = "assignee_id"
+
+	QueryParamAssigneeID = "assignee_id"
+)
+
+func GetIssueNumberFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamIssueNumber)
+}
+
+func GetIssueCommentIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamIssueCommentID)
+}
+
+func GetAssigneeIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamAssigneeID)
+}
+
+// parseIssueStates extracts the issue states from the url.
+func parseIssueStates(r *http.Request) []enum.IssueState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.IssueState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.IssueState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.IssueState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return states
+}
+
+// ParseIssueFilter extracts the issue query parameters from the url.
+func ParseIssueFilter(r *http.Request) (*types.IssueFilter, error) {
+	createdBy, err := QueryParamListAsPositiveInt64(r, QueryParamCreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing createdby filter: %w", err)
+	}
+
+	assigneeID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAssigneeID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing assignee ID filter: %w", err)
+	}
+
+	milestoneID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMilestoneID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing milestone ID filter: %w", err)
+	}
+
+	return &types.IssueFilter{
+		Page:        ParsePage(r),
+		Size:        ParseLimit(r),
+		Query:       ParseQuery(r),
+		States:      parseIssueStates(r),
+		CreatedBy:   createdBy,
+		Assignee:    assigneeID,
+		MilestoneID: milestoneID,
+		Order:       ParseOrder(r),
+	}, nil
+}
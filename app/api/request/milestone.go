@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamMilestoneID = "milestone_id"
+
+	QueryParamMilestoneID = "milestone_id"
+)
+
+func GetMilestoneIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamMilestoneID)
+}
+
+// parseMilestoneStates extracts the milestone states from the url.
+func parseMilestoneStates(r *http.Request) []enum.MilestoneState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.MilestoneState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.MilestoneState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.MilestoneState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return states
+}
+
+// ParseMilestoneFilter extracts the milestone query parameters from the url.
+func ParseMilestoneFilter(r *http.Request) *types.MilestoneFilter {
+	return &types.MilestoneFilter{
+		Page:   ParsePage(r),
+		Size:   ParseLimit(r),
+		Query:  ParseQuery(r),
+		States: parseMilestoneStates(r),
+	}
+}
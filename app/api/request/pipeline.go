@@ -16,6 +16,9 @@ package request
 
 import (
 	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
 )
 
 const (
@@ -24,8 +27,15 @@ const (
 	PathParamStageNumber        = "stage_number"
 	PathParamStepNumber         = "step_number"
 	PathParamTriggerIdentifier  = "trigger_identifier"
+	PathParamTriggerRequestID   = "trigger_request_id"
+	PathParamCronIdentifier     = "cron_identifier"
+	PathParamCacheKey           = "cache_key"
 	QueryParamLatest            = "latest"
 	QueryParamBranch            = "branch"
+	QueryParamLogOffset         = "offset"
+
+	// HeaderLastEventID is sent by SSE clients reconnecting to a stream, with the id of the last event received.
+	HeaderLastEventID = "Last-Event-ID"
 )
 
 func GetPipelineIdentifierFromPath(r *http.Request) (string, error) {
@@ -48,6 +58,31 @@ func GetStepNumberFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamStepNumber)
 }
 
+// GetLogOffsetFromRequest returns the position of the first log line to stream.
+// Clients that reconnect to the stream continue after the last line they received,
+// otherwise the offset is taken from the query and defaults to the first line.
+func GetLogOffsetFromRequest(r *http.Request) (int, error) {
+	if lastEventID := r.Header.Get(HeaderLastEventID); lastEventID != "" {
+		pos, err := strconv.Atoi(lastEventID)
+		if err != nil || pos < 0 {
+			return 0, usererror.BadRequestf("Header '%s' must be a non-negative integer.", HeaderLastEventID)
+		}
+		return pos + 1, nil
+	}
+
+	value, ok := QueryParam(r, QueryParamLogOffset)
+	if !ok {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, usererror.BadRequestf("Parameter '%s' must be a non-negative integer.", QueryParamLogOffset)
+	}
+
+	return offset, nil
+}
+
 func GetLatestFromPath(r *http.Request) bool {
 	v, _ := QueryParam(r, QueryParamLatest)
 	return v == "true"
@@ -56,3 +91,15 @@ func GetLatestFromPath(r *http.Request) bool {
 func GetTriggerIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTriggerIdentifier)
 }
+
+func GetTriggerRequestIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamTriggerRequestID)
+}
+
+func GetCronIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCronIdentifier)
+}
+
+func GetCacheKeyFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCacheKey)
+}
@@ -24,12 +24,32 @@ import (
 
 const (
 	PathParamPublicKeyIdentifier = "public_key_identifier"
+
+	QueryParamPublicKeyUsage = "usage"
 )
 
 func GetPublicKeyIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamPublicKeyIdentifier)
 }
 
+// parsePublicKeyUsages extracts the public key usages from the url.
+func parsePublicKeyUsages(r *http.Request) []enum.PublicKeyUsage {
+	strUsages, _ := QueryParamList(r, QueryParamPublicKeyUsage)
+	m := make(map[enum.PublicKeyUsage]struct{}) // use map to eliminate duplicates
+	for _, s := range strUsages {
+		if usage, ok := enum.PublicKeyUsage(s).Sanitize(); ok {
+			m[usage] = struct{}{}
+		}
+	}
+
+	usages := make([]enum.PublicKeyUsage, 0, len(m))
+	for u := range m {
+		usages = append(usages, u)
+	}
+
+	return usages
+}
+
 // ParseListPublicKeyQueryFilterFromRequest parses query filter for public keys from the url.
 func ParseListPublicKeyQueryFilterFromRequest(r *http.Request) (types.PublicKeyFilter, error) {
 	sort := enum.PublicKeySort(ParseSort(r))
@@ -42,5 +62,6 @@ func ParseListPublicKeyQueryFilterFromRequest(r *http.Request) (types.PublicKeyF
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
 		Sort:            sort,
 		Order:           ParseOrder(r),
+		Usages:          parsePublicKeyUsages(r),
 	}, nil
 }
@@ -28,6 +28,7 @@ const (
 	PathParamPullReqCommentID = "pullreq_comment_id"
 	PathParamReviewerID       = "pullreq_reviewer_id"
 	PathParamUserGroupID      = "user_group_id"
+	PathParamDependencyID     = "pullreq_dependency_id"
 
 	QueryParamAuthorID           = "author_id"
 	QueryParamCommenterID        = "commenter_id"
@@ -47,6 +48,9 @@ func GetReviewerIDFromPath(r *http.Request) (int64, error) {
 func GetUserGroupIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamUserGroupID)
 }
+func GetDependencyIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamDependencyID)
+}
 
 func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
@@ -155,6 +159,11 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		return nil, fmt.Errorf("encountered error parsing mentioned ID filter: %w", err)
 	}
 
+	milestoneID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMilestoneID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing milestone ID filter: %w", err)
+	}
+
 	return &types.PullReqFilter{
 		Page:               ParsePage(r),
 		Size:               ParseLimit(r),
@@ -173,6 +182,7 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		ReviewerID:         reviewerID,
 		ReviewDecisions:    reviewDecisions,
 		MentionedID:        mentionedID,
+		MilestoneID:        milestoneID,
 		IncludeDescription: includeDescription,
 		CreatedFilter:      createdFilter,
 		UpdatedFilter:      updatedFilter,
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamReleaseID      = "release_id"
+	PathParamReleaseAssetID = "asset_id"
+
+	QueryParamIncludeDrafts = "include_drafts"
+	QueryParamAssetName     = "name"
+)
+
+func GetReleaseIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamReleaseID)
+}
+
+func GetReleaseAssetIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamReleaseAssetID)
+}
+
+// ParseReleaseFilter extracts the release query parameters from the url.
+func ParseReleaseFilter(r *http.Request) (*types.ReleaseFilter, error) {
+	includeDrafts, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeDrafts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReleaseFilter{
+		Page:          ParsePage(r),
+		Size:          ParseLimit(r),
+		Query:         ParseQuery(r),
+		IncludeDrafts: includeDrafts,
+	}, nil
+}
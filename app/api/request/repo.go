@@ -16,14 +16,18 @@ package request
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 const (
-	PathParamRepoRef = "repo_ref"
-	QueryParamRepoID = "repo_id"
+	PathParamRepoRef          = "repo_ref"
+	QueryParamRepoID          = "repo_id"
+	QueryParamIncludeArchived = "include_archived"
+	QueryParamTopic           = "topic"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -65,6 +69,16 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		deletedAt = &deletedAtVal
 	}
 
+	// archived repos are hidden unless explicitly requested.
+	var archived *bool
+	includeArchived, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeArchived, false)
+	if err != nil {
+		return nil, err
+	}
+	if !includeArchived {
+		archived = new(bool)
+	}
+
 	return &types.RepoFilter{
 		Query:             ParseQuery(r),
 		Order:             ParseOrder(r),
@@ -74,5 +88,54 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		Recursive:         recursive,
 		DeletedAt:         deletedAt,
 		DeletedBeforeOrAt: deletedBeforeOrAt,
+		Archived:          archived,
+		Topic:             strings.ToLower(r.URL.Query().Get(QueryParamTopic)),
+	}, nil
+}
+
+// ParseRepoTopicFilter extracts the repository topic filter from the url.
+func ParseRepoTopicFilter(r *http.Request) (*types.RepoTopicFilter, error) {
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RepoTopicFilter{
+		Query:     ParseQuery(r),
+		Size:      ParseLimit(r),
+		Recursive: recursive,
+	}, nil
+}
+
+// ParseRepoTrafficFilter extracts the repository traffic filter from the url.
+func ParseRepoTrafficFilter(r *http.Request) (types.RepoTrafficFilter, error) {
+	after, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAfter, 0)
+	if err != nil {
+		return types.RepoTrafficFilter{}, err
+	}
+
+	before, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamBefore, 0)
+	if err != nil {
+		return types.RepoTrafficFilter{}, err
+	}
+
+	return types.RepoTrafficFilter{
+		After:  after,
+		Before: before,
 	}, nil
 }
+
+// ParseDescriptionTemplateType extracts the optional description template type from the url.
+func ParseDescriptionTemplateType(r *http.Request) (enum.DescriptionTemplateType, error) {
+	raw := r.URL.Query().Get(QueryParamType)
+	if raw == "" {
+		return "", nil
+	}
+
+	templateType, ok := enum.DescriptionTemplateType(raw).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Invalid description template type %q.", raw)
+	}
+
+	return templateType, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamWeeks        = "weeks"
+	QueryParamContributors = "contributors"
+
+	InsightsWeeksDefault        = 52
+	InsightsContributorsDefault = 10
+)
+
+// ParseRepoInsightsFilter extracts the repository insights query parameters from the url.
+func ParseRepoInsightsFilter(r *http.Request) (types.RepoInsightsFilter, error) {
+	weeks, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamWeeks, InsightsWeeksDefault)
+	if err != nil {
+		return types.RepoInsightsFilter{}, err
+	}
+
+	contributors, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamContributors, InsightsContributorsDefault)
+	if err != nil {
+		return types.RepoInsightsFilter{}, err
+	}
+
+	return types.RepoInsightsFilter{
+		Weeks:        int(weeks),
+		Contributors: int(contributors),
+	}, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamRepo     = "repo"
+	QueryParamFile     = "file"
+	QueryParamLanguage = "lang"
+
+	// CodeSearchLimitDefault and CodeSearchLimitMax limit the number of matching files returned by code search.
+	CodeSearchLimitDefault = 50
+	CodeSearchLimitMax     = 500
+
+	// GlobalSearchLimitDefault and GlobalSearchLimitMax limit the number of results returned per result type.
+	GlobalSearchLimitDefault = 5
+	GlobalSearchLimitMax     = 20
+)
+
+// ParseCodeSearchFilter extracts the code search filter from the url.
+func ParseCodeSearchFilter(r *http.Request) (*types.CodeSearchFilter, error) {
+	query, err := QueryParamOrError(r, QueryParamGrepQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	regex, err := QueryParamAsBoolOrDefault(r, QueryParamRegex, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreCase, err := QueryParamAsBoolOrDefault(r, QueryParamIgnoreCase, false)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLimit, CodeSearchLimitDefault)
+	if err != nil {
+		return nil, err
+	}
+	if limit > CodeSearchLimitMax {
+		limit = CodeSearchLimitMax
+	}
+
+	return &types.CodeSearchFilter{
+		Query:      query,
+		Regex:      regex,
+		IgnoreCase: ignoreCase,
+		Repo:       QueryParamOrDefault(r, QueryParamRepo, ""),
+		File:       QueryParamOrDefault(r, QueryParamFile, ""),
+		Language:   QueryParamOrDefault(r, QueryParamLanguage, ""),
+		Limit:      int(limit),
+	}, nil
+}
+
+// ParseGlobalSearchFilter extracts the global search filter from the url.
+func ParseGlobalSearchFilter(r *http.Request) (*types.GlobalSearchFilter, error) {
+	query, err := QueryParamOrError(r, QueryParamGrepQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLimit, GlobalSearchLimitDefault)
+	if err != nil {
+		return nil, err
+	}
+	if limit > GlobalSearchLimitMax {
+		limit = GlobalSearchLimitMax
+	}
+
+	return &types.GlobalSearchFilter{
+		Query: query,
+		Types: parseSearchResultTypes(r),
+		Limit: int(limit),
+	}, nil
+}
+
+// parseSearchResultTypes extracts the requested search result types from the url.
+func parseSearchResultTypes(r *http.Request) []enum.SearchResultType {
+	strTypes, _ := QueryParamList(r, QueryParamType)
+	m := make(map[enum.SearchResultType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.SearchResultType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	resultTypes := make([]enum.SearchResultType, 0, len(m))
+	for t := range m {
+		resultTypes = append(resultTypes, t)
+	}
+
+	return resultTypes
+}
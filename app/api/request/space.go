@@ -23,15 +23,23 @@ import (
 )
 
 const (
-	PathParamSpaceRef = "space_ref"
+	PathParamSpaceRef    = "space_ref"
+	PathParamRepoBulkUID = "repo_bulk_uid"
 
 	QueryParamIncludeSubspaces = "include_subspaces"
+
+	QueryParamFrom = "from"
+	QueryParamTo   = "to"
 )
 
 func GetSpaceRefFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamSpaceRef)
 }
 
+func GetRepoBulkUIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamRepoBulkUID)
+}
+
 // ParseSortSpace extracts the space sort parameter from the url.
 func ParseSortSpace(r *http.Request) enum.SpaceAttr {
 	return enum.ParseSpaceAttr(
@@ -87,3 +95,21 @@ func GetIncludeSubspacesFromQuery(r *http.Request) (bool, error) {
 
 	return v, nil
 }
+
+// ParseReviewSLAReportFilter extracts the review SLA report filter from the url.
+func ParseReviewSLAReportFilter(r *http.Request) (types.ReviewSLAReportFilter, error) {
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamFrom, 0)
+	if err != nil {
+		return types.ReviewSLAReportFilter{}, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamTo, 0)
+	if err != nil {
+		return types.ReviewSLAReportFilter{}, err
+	}
+
+	return types.ReviewSLAReportFilter{
+		From: from,
+		To:   to,
+	}, nil
+}
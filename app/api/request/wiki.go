@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+)
+
+const (
+	QueryParamMessage = "message"
+)
+
+// GetWikiPageNameFromPath returns the name of the wiki page, which is the remainder of the path.
+func GetWikiPageNameFromPath(r *http.Request) (string, error) {
+	return GetRemainderFromPath(r)
+}
+
+// GetMessageFromQuery returns the commit message from the url (optional).
+func GetMessageFromQuery(r *http.Request) string {
+	return r.URL.Query().Get(QueryParamMessage)
+}
@@ -78,7 +78,9 @@ func Translate(ctx context.Context, err error) *Error {
 		return ErrCyclicHierarchy
 	case errors.Is(err, store.ErrSpaceWithChildsCantBeDeleted):
 		return ErrSpaceWithChildsCantBeDeleted
-	case errors.Is(err, limiter.ErrMaxNumReposReached):
+	case errors.Is(err, limiter.ErrMaxNumReposReached),
+		errors.Is(err, limiter.ErrMaxRepoSizeReached),
+		errors.Is(err, limiter.ErrMaxPipelineMinutesReached):
 		return Forbidden(err.Error())
 
 	//	upload errors
@@ -92,6 +92,12 @@ var (
 	// ErrEmptyRepoNeedsBranch is returned if no branch found on the githook post receieve for empty repositories.
 	ErrEmptyRepoNeedsBranch = New(http.StatusBadRequest,
 		"Pushing to an empty repository requires at least one branch with commits.")
+
+	// ErrRepoArchived is returned if an archived repository would be modified.
+	ErrRepoArchived = New(http.StatusForbidden, "The repository is archived and read-only.")
+
+	// ErrTooManyRequests is returned if the rate limit of the client was exceeded.
+	ErrTooManyRequests = New(http.StatusTooManyRequests, "Too many requests, please retry later")
 )
 
 // Error represents a json-encoded API error.
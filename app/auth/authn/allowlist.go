@@ -0,0 +1,245 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// membershipPageSize is the page size used to list the space memberships of a principal.
+const membershipPageSize = 100
+
+// checkAllowlists returns an error if the request wasn't sent from a network the token,
+// or the service account the token belongs to, is allowed to be used from.
+// Rejected attempts are recorded in the audit log.
+func (a *JWTAuthenticator) checkAllowlists(r *http.Request, principal *types.Principal, tkn *types.Token) error {
+	ctx := r.Context()
+
+	var sa *types.ServiceAccount
+	if principal.Type == enum.PrincipalTypeServiceAccount {
+		var err error
+		sa, err = a.serviceAccountCache.Get(ctx, principal.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find service account: %w", err)
+		}
+	}
+
+	tokenRestricted := tkn != nil && len(tkn.AllowedCIDRs) > 0
+	saRestricted := sa != nil && len(sa.AllowedCIDRs) > 0
+	if !tokenRestricted && !saRestricted {
+		return nil
+	}
+
+	ip, ok := a.clientIP(r)
+
+	var reason string
+	switch {
+	case !ok:
+		reason = "client address unknown"
+	case tokenRestricted && !isAllowed(tkn.AllowedCIDRs, ip):
+		reason = "client address not in allowlist of token"
+	case saRestricted && !isAllowed(sa.AllowedCIDRs, ip):
+		reason = "client address not in allowlist of service account"
+	default:
+		return nil
+	}
+
+	a.auditRejected(ctx, principal, sa, tkn, ip, reason)
+
+	return fmt.Errorf("request of principal %d rejected: %s", principal.ID, reason)
+}
+
+// clientIP returns the IP address of the client that sent the request.
+// The headers set by reverse proxies are only used if they're configured to be trusted.
+func (a *JWTAuthenticator) clientIP(r *http.Request) (netip.Addr, bool) {
+	addr := r.RemoteAddr
+	if a.trustProxyHeaders {
+		addr = audit.RealIP(r)
+	} else if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return ip.Unmap(), true
+}
+
+// isAllowed returns true if the IP address is within any of the CIDRs.
+func isAllowed(cidrs []string, ip netip.Addr) bool {
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// auditRejected records the rejected request in the audit log of the space of the service account,
+// or the spaces a personal access token is restricted to. Personal access tokens without restricted
+// spaces grant the access of the user, so their rejections are recorded in the root spaces of the user.
+func (a *JWTAuthenticator) auditRejected(
+	ctx context.Context,
+	principal *types.Principal,
+	sa *types.ServiceAccount,
+	tkn *types.Token,
+	ip netip.Addr,
+	reason string,
+) {
+	log.Ctx(ctx).Warn().
+		Int64("principal_id", principal.ID).
+		Str("client_ip", ip.String()).
+		Msgf("rejected request: %s", reason)
+
+	var resource audit.Resource
+	var spacePaths []string
+	switch {
+	case sa != nil:
+		if tkn != nil {
+			resource = audit.NewResource(audit.ResourceTypeServiceAccountToken, tkn.Identifier,
+				audit.ServiceAccountName, sa.UID)
+		} else {
+			resource = audit.NewResource(audit.ResourceTypeServiceAccount, sa.UID)
+		}
+
+		spacePath, err := a.serviceAccountSpacePath(ctx, sa)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to find space of service account for audit log")
+			return
+		}
+		spacePaths = []string{spacePath}
+	case tkn != nil && len(tkn.Resources) > 0:
+		resource = audit.NewResource(audit.ResourceTypePersonalAccessToken, tkn.Identifier)
+		spacePaths = tokenSpacePaths(tkn)
+	case tkn != nil:
+		resource = audit.NewResource(audit.ResourceTypePersonalAccessToken, tkn.Identifier)
+
+		var err error
+		spacePaths, err = a.principalSpacePaths(ctx, principal.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to find spaces of principal for audit log")
+			return
+		}
+	}
+
+	for _, spacePath := range spacePaths {
+		err := a.auditService.Log(ctx,
+			*principal,
+			resource,
+			audit.ActionRejected,
+			spacePath,
+			audit.WithClientIP(ip.String()),
+			audit.WithData(audit.RejectedReason, reason),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for rejected request")
+		}
+	}
+}
+
+func (a *JWTAuthenticator) serviceAccountSpacePath(ctx context.Context, sa *types.ServiceAccount) (string, error) {
+	if sa.ParentType == enum.ParentResourceTypeRepo {
+		repo, err := a.repoStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent repo: %w", err)
+		}
+		return paths.Parent(repo.Path), nil
+	}
+
+	space, err := a.spaceStore.Find(ctx, sa.ParentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find parent space: %w", err)
+	}
+	return space.Path, nil
+}
+
+// principalSpacePaths returns the paths of the root spaces of the spaces the principal is a member of.
+func (a *JWTAuthenticator) principalSpacePaths(ctx context.Context, principalID int64) ([]string, error) {
+	var spacePaths []string
+	seen := make(map[string]struct{})
+	for page := 1; ; page++ {
+		memberships, err := a.membershipStore.ListSpaces(ctx, principalID, types.MembershipSpaceFilter{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: membershipPageSize},
+			},
+			Sort: enum.MembershipSpaceSortIdentifier,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space memberships: %w", err)
+		}
+
+		for _, membership := range memberships {
+			rootPath := paths.Segments(membership.Space.Path)[0]
+			if _, ok := seen[rootPath]; ok {
+				continue
+			}
+			seen[rootPath] = struct{}{}
+			spacePaths = append(spacePaths, rootPath)
+		}
+
+		if len(memberships) < membershipPageSize {
+			return spacePaths, nil
+		}
+	}
+}
+
+// tokenSpacePaths returns the paths of the spaces a personal access token is restricted to.
+func tokenSpacePaths(tkn *types.Token) []string {
+	spacePaths := make([]string, 0, len(tkn.Resources))
+	seen := make(map[string]struct{}, len(tkn.Resources))
+	for _, resource := range tkn.Resources {
+		spacePath := resource.Path
+		if resource.Type == enum.ParentResourceTypeRepo {
+			spacePath = paths.Parent(resource.Path)
+		}
+
+		if _, ok := seen[spacePath]; ok {
+			continue
+		}
+		seen[spacePath] = struct{}{}
+		spacePaths = append(spacePaths, spacePath)
+	}
+
+	return spacePaths
+}
+
+// serviceAccountGetter is used to hook the principal store as source of the service account cache.
+type serviceAccountGetter struct {
+	principalStore store.PrincipalStore
+}
+
+func (g serviceAccountGetter) Find(ctx context.Context, id int64) (*types.ServiceAccount, error) {
+	return g.principalStore.FindServiceAccount(ctx, id)
+}
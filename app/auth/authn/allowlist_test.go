@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeServiceAccountStore counts the lookups of service accounts.
+type fakeServiceAccountStore struct {
+	store.PrincipalStore
+	sa    *types.ServiceAccount
+	finds int
+}
+
+func (s *fakeServiceAccountStore) FindServiceAccount(context.Context, int64) (*types.ServiceAccount, error) {
+	s.finds++
+	return s.sa, nil
+}
+
+// fakeMembershipStore has memberships of the principal in the spaces with the paths.
+type fakeMembershipStore struct {
+	store.MembershipStore
+	spacePaths []string
+}
+
+func (s *fakeMembershipStore) ListSpaces(
+	_ context.Context,
+	_ int64,
+	filter types.MembershipSpaceFilter,
+) ([]types.MembershipSpace, error) {
+	start := min((filter.Page-1)*filter.Size, len(s.spacePaths))
+	end := min(start+filter.Size, len(s.spacePaths))
+
+	memberships := make([]types.MembershipSpace, 0, end-start)
+	for _, spacePath := range s.spacePaths[start:end] {
+		memberships = append(memberships, types.MembershipSpace{Space: types.Space{Path: spacePath}})
+	}
+	return memberships, nil
+}
+
+// fakeAuditService records the space paths of the logged audit events.
+type fakeAuditService struct {
+	audit.Service
+	spacePaths []string
+}
+
+func (s *fakeAuditService) Log(
+	_ context.Context,
+	_ types.Principal,
+	_ audit.Resource,
+	_ audit.Action,
+	spacePath string,
+	_ ...audit.Option,
+) error {
+	s.spacePaths = append(s.spacePaths, spacePath)
+	return nil
+}
+
+func TestIsAllowed(t *testing.T) {
+	cidrs := []string{"10.0.0.0/8", "192.168.1.7/32", "2001:db8::/32"}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "10.1.2.3", want: true},
+		{ip: "11.1.2.3", want: false},
+		{ip: "192.168.1.7", want: true},
+		{ip: "192.168.1.8", want: false},
+		{ip: "2001:db8::1", want: true},
+		{ip: "2001:db9::1", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ip, func(t *testing.T) {
+			if got := isAllowed(cidrs, netip.MustParseAddr(test.ip)); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTokenSpacePaths(t *testing.T) {
+	tkn := &types.Token{
+		Resources: []types.TokenResource{
+			{Type: enum.ParentResourceTypeSpace, Path: "acme"},
+			{Type: enum.ParentResourceTypeRepo, Path: "acme/backend"},
+			{Type: enum.ParentResourceTypeRepo, Path: "acme/team/frontend"},
+		},
+	}
+
+	want := []string{"acme", "acme/team"}
+	if got := tokenSpacePaths(tkn); !slices.Equal(got, want) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+}
+
+func TestCheckAllowlists_ServiceAccountCached(t *testing.T) {
+	principalStore := &fakeServiceAccountStore{sa: &types.ServiceAccount{}}
+	a := NewTokenAuthenticator(principalStore, nil, nil, nil, nil, nil, "", false)
+
+	principal := &types.Principal{ID: 1, Type: enum.PrincipalTypeServiceAccount}
+	for range 3 {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if err := a.checkAllowlists(r, principal, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if principalStore.finds != 1 {
+		t.Errorf("service account was looked up %d times, want 1", principalStore.finds)
+	}
+}
+
+func TestAuditRejected_UnrestrictedToken(t *testing.T) {
+	spacePaths := []string{"acme", "acme/team"}
+	for i := range membershipPageSize {
+		spacePaths = append(spacePaths, "acme/team-"+strconv.Itoa(i))
+	}
+	spacePaths = append(spacePaths, "other/team")
+
+	auditService := &fakeAuditService{}
+	a := NewTokenAuthenticator(nil, nil, nil, nil, &fakeMembershipStore{spacePaths: spacePaths}, auditService,
+		"", false)
+
+	principal := &types.Principal{ID: 1, UID: "jane", Type: enum.PrincipalTypeUser}
+	tkn := &types.Token{Identifier: "ci", Type: enum.TokenTypePAT, AllowedCIDRs: []string{"10.0.0.0/8"}}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "192.168.1.1:1234"
+	if err := a.checkAllowlists(r, principal, tkn); err == nil {
+		t.Fatal("expected the request to be rejected")
+	}
+
+	// the rejection is recorded once in every root space of the principal.
+	want := []string{"acme", "other"}
+	if !slices.Equal(auditService.spacePaths, want) {
+		t.Errorf("want=%v got=%v", want, auditService.spacePaths)
+	}
+}
@@ -20,34 +20,67 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
 
 	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
+// lastUsedUpdateInterval is the minimum time between two updates of the last used time of a token,
+// to avoid a database write on every request.
+const lastUsedUpdateInterval = 5 * time.Minute
+
+// serviceAccountCacheDuration is the duration service accounts are cached for the allowlist checks,
+// so changes of the allowlist of a service account take effect within that duration.
+const serviceAccountCacheDuration = 30 * time.Second
+
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
-	cookieName     string
-	principalStore store.PrincipalStore
-	tokenStore     store.TokenStore
+	cookieName        string
+	trustProxyHeaders bool
+	principalStore    store.PrincipalStore
+	tokenStore        store.TokenStore
+	spaceStore        store.SpaceStore
+	repoStore         store.RepoStore
+	membershipStore   store.MembershipStore
+	auditService      audit.Service
+
+	serviceAccountCache cache.Cache[int64, *types.ServiceAccount]
 }
 
 func NewTokenAuthenticator(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	membershipStore store.MembershipStore,
+	auditService audit.Service,
 	cookieName string,
+	trustProxyHeaders bool,
 ) *JWTAuthenticator {
 	return &JWTAuthenticator{
-		cookieName:     cookieName,
-		principalStore: principalStore,
-		tokenStore:     tokenStore,
+		cookieName:        cookieName,
+		trustProxyHeaders: trustProxyHeaders,
+		principalStore:    principalStore,
+		tokenStore:        tokenStore,
+		spaceStore:        spaceStore,
+		repoStore:         repoStore,
+		membershipStore:   membershipStore,
+		auditService:      auditService,
+		serviceAccountCache: cache.New[int64, *types.ServiceAccount](
+			serviceAccountGetter{principalStore: principalStore},
+			serviceAccountCacheDuration,
+		),
 	}
 }
 
@@ -83,13 +116,20 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, errors.New("invalid HMAC signature for JWT")
 	}
 
+	// tokens of blocked principals (e.g. users removed from the LDAP directory) are rejected.
+	if principal.Blocked {
+		return nil, fmt.Errorf("principal %d is blocked", principal.ID)
+	}
+
 	var metadata auth.Metadata
+	var tkn *types.Token
 	switch {
 	case claims.Token != nil:
-		metadata, err = a.metadataFromTokenClaims(ctx, principal, claims.Token)
+		tkn, err = a.tokenFromClaims(ctx, principal, claims.Token, claims.IssuedAt)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get metadata from token claims: %w", err)
+			return nil, fmt.Errorf("failed to get token from token claims: %w", err)
 		}
+		metadata = metadataFromToken(tkn)
 	case claims.Membership != nil:
 		metadata = a.metadataFromMembershipClaims(claims.Membership)
 	case claims.AccessPermissions != nil:
@@ -98,17 +138,33 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, fmt.Errorf("jwt is missing sub-claims")
 	}
 
-	return &auth.Session{
+	if err = a.checkAllowlists(r, principal, tkn); err != nil {
+		return nil, err
+	}
+
+	if tkn != nil {
+		a.trackUsage(ctx, tkn, time.Now())
+	}
+
+	session := &auth.Session{
 		Principal: *principal,
 		Metadata:  metadata,
-	}, nil
+	}
+
+	// personal access tokens only grant admin privileges if they aren't scoped or have the admin scope.
+	if tokenMetadata, ok := metadata.(*auth.TokenMetadata); ok && !tokenMetadata.GrantsAdmin() {
+		session.Principal.Admin = false
+	}
+
+	return session, nil
 }
 
-func (a *JWTAuthenticator) metadataFromTokenClaims(
+func (a *JWTAuthenticator) tokenFromClaims(
 	ctx context.Context,
 	principal *types.Principal,
 	tknClaims *jwt.SubClaimsToken,
-) (auth.Metadata, error) {
+	issuedAt int64,
+) (*types.Token, error) {
 	// ensure tkn exists
 	tkn, err := a.tokenStore.Find(ctx, tknClaims.ID)
 	if err != nil {
@@ -123,10 +179,58 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 		)
 	}
 
+	// jwts of rotated tokens are only accepted if they were issued with the current secret,
+	// or with the previous secret during the grace period of the rotation.
+	if !isCurrentSecret(tkn, issuedAt, time.Now()) {
+		return nil, fmt.Errorf("JWT of token %d was issued with a secret that was rotated", tkn.ID)
+	}
+
+	return tkn, nil
+}
+
+// trackUsage records when and from where the token was used,
+// if it wasn't recorded recently or the token is used from a different IP address.
+// Usages without known client IP address (e.g. outside of HTTP requests) aren't recorded.
+func (a *JWTAuthenticator) trackUsage(ctx context.Context, tkn *types.Token, now time.Time) {
+	ip := audit.GetRealIP(ctx)
+	if !needsUsageUpdate(tkn, ip, now) {
+		return
+	}
+
+	if err := a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now.UnixMilli(), ip); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("token_id", tkn.ID).Msg("failed to update last used time of token")
+	}
+}
+
+func needsUsageUpdate(tkn *types.Token, ip string, now time.Time) bool {
+	if ip == "" {
+		return false
+	}
+
+	return tkn.LastUsedAt == nil ||
+		tkn.LastUsedIP != ip ||
+		now.UnixMilli()-*tkn.LastUsedAt >= lastUsedUpdateInterval.Milliseconds()
+}
+
+func metadataFromToken(tkn *types.Token) auth.Metadata {
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
-	}, nil
+		Scopes:    tkn.Scopes,
+		Resources: tkn.Resources,
+	}
+}
+
+// isCurrentSecret returns true if the jwt issue time (in seconds) belongs to the current secret of the token,
+// or to its previous secret while the grace period of the last rotation hasn't ended.
+func isCurrentSecret(tkn *types.Token, issuedAt int64, now time.Time) bool {
+	if issuedAt == tkn.IssuedAt/1000 {
+		return true
+	}
+
+	return tkn.PreviousIssuedAt != nil && tkn.PreviousValidUntil != nil &&
+		issuedAt == *tkn.PreviousIssuedAt/1000 &&
+		now.UnixMilli() < *tkn.PreviousValidUntil
 }
 
 func (a *JWTAuthenticator) metadataFromMembershipClaims(
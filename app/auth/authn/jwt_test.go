@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// fakeTokenStore records the usage updates of tokens.
+type fakeTokenStore struct {
+	store.TokenStore
+	updates int
+}
+
+func (s *fakeTokenStore) UpdateLastUsed(context.Context, int64, int64, string) error {
+	s.updates++
+	return nil
+}
+
+func TestNeedsUsageUpdate(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Minute).UnixMilli()
+	old := now.Add(-lastUsedUpdateInterval).UnixMilli()
+
+	tests := []struct {
+		name string
+		tkn  *types.Token
+		ip   string
+		want bool
+	}{
+		{
+			name: "never used",
+			tkn:  &types.Token{},
+			ip:   "10.0.0.1",
+			want: true,
+		},
+		{
+			name: "used recently from the same address",
+			tkn:  &types.Token{LastUsedAt: ptr.Int64(recent), LastUsedIP: "10.0.0.1"},
+			ip:   "10.0.0.1",
+		},
+		{
+			name: "used recently from another address",
+			tkn:  &types.Token{LastUsedAt: ptr.Int64(recent), LastUsedIP: "10.0.0.2"},
+			ip:   "10.0.0.1",
+			want: true,
+		},
+		{
+			name: "update interval elapsed",
+			tkn:  &types.Token{LastUsedAt: ptr.Int64(old), LastUsedIP: "10.0.0.1"},
+			ip:   "10.0.0.1",
+			want: true,
+		},
+		{
+			name: "never used from unknown address",
+			tkn:  &types.Token{},
+		},
+		{
+			name: "used from unknown address",
+			tkn:  &types.Token{LastUsedAt: ptr.Int64(old), LastUsedIP: "10.0.0.1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := needsUsageUpdate(test.tkn, test.ip, now); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTrackUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		remoteAddr  string
+		wantUpdates int
+	}{
+		{
+			name:        "known address",
+			remoteAddr:  "10.0.0.1:1234",
+			wantUpdates: 1,
+		},
+		{
+			name:       "unknown address",
+			remoteAddr: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokenStore := &fakeTokenStore{}
+			a := &JWTAuthenticator{tokenStore: tokenStore}
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = test.remoteAddr
+
+			// the client address is added to the context by the audit middleware.
+			audit.Middleware()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				a.trackUsage(r.Context(), &types.Token{ID: 1}, time.Now())
+			})).ServeHTTP(httptest.NewRecorder(), r)
+
+			if tokenStore.updates != test.wantUpdates {
+				t.Errorf("want=%d got=%d updates", test.wantUpdates, tokenStore.updates)
+			}
+		})
+	}
+}
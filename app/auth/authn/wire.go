@@ -16,6 +16,7 @@ package authn
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -30,6 +31,11 @@ func ProvideAuthenticator(
 	config *types.Config,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	membershipStore store.MembershipStore,
+	auditService audit.Service,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewTokenAuthenticator(principalStore, tokenStore, spaceStore, repoStore, membershipStore, auditService,
+		config.Token.CookieName, config.Token.AllowlistTrustProxyHeaders)
 }
@@ -76,11 +76,19 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// restricted personal access tokens can't exceed their scopes and resources, not even for admins.
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok &&
+		!checkTokenRestrictions(tokenMetadata, scope, resource, permission) {
+		log.Ctx(ctx).Debug().Msgf("[MembershipAuthorizer] permission %s is outside of the token restrictions",
+			permission)
+		return false, nil
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
 
-	var spacePath string
+	var spacePath, repoPath string
 
 	//nolint:exhaustive // we want to fail on anything else
 	switch resource.Type {
@@ -89,6 +97,9 @@ func (a *MembershipAuthorizer) Check(
 
 	case enum.ResourceTypeRepo:
 		spacePath = scope.SpacePath
+		if resource.Identifier != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+		}
 
 	case enum.ResourceTypeServiceAccount:
 		spacePath = scope.SpacePath
@@ -147,8 +158,9 @@ func (a *MembershipAuthorizer) Check(
 		return a.checkWithAccessPermissionMetadata(ctx, accessPermissionMetadata, spacePath, permission)
 	}
 
-	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	// ensure we aren't bypassing unknown metadata with impact on authorization (token restrictions are checked above)
+	_, isTokenMetadata := session.Metadata.(*auth.TokenMetadata)
+	if !isTokenMetadata && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
@@ -156,6 +168,7 @@ func (a *MembershipAuthorizer) Check(
 		ctx, PermissionCacheKey{
 			PrincipalID: session.Principal.ID,
 			SpaceRef:    spacePath,
+			RepoRef:     repoPath,
 			Permission:  permission,
 		},
 	)
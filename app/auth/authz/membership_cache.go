@@ -33,30 +33,64 @@ import (
 type PermissionCacheKey struct {
 	PrincipalID int64
 	SpaceRef    string
-	Permission  enum.Permission
+	// RepoRef is set if the permission is requested for a repository,
+	// repository memberships are checked before the memberships of the parent spaces.
+	RepoRef    string
+	Permission enum.Permission
 }
 type PermissionCache cache.Cache[PermissionCacheKey, bool]
 
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
+	repoMembershipStore store.RepoMembershipStore,
+	roleStore store.RoleStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	userGroupSubgroupStore store.UserGroupSubgroupStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceStore:      spaceStore,
-		membershipStore: membershipStore,
+		spaceStore:               spaceStore,
+		membershipStore:          membershipStore,
+		repoStore:                repoStore,
+		repoMembershipStore:      repoMembershipStore,
+		roleStore:                roleStore,
+		userGroupMemberStore:     userGroupMemberStore,
+		userGroupSubgroupStore:   userGroupSubgroupStore,
+		userGroupMembershipStore: userGroupMembershipStore,
 	}, cacheDuration)
 }
 
+// maxUserGroupNestingDepth limits how many levels of parent usergroups are checked for memberships.
+const maxUserGroupNestingDepth = 10
+
 type permissionCacheGetter struct {
-	spaceStore      store.SpaceStore
-	membershipStore store.MembershipStore
+	spaceStore               store.SpaceStore
+	membershipStore          store.MembershipStore
+	repoStore                store.RepoStore
+	repoMembershipStore      store.RepoMembershipStore
+	roleStore                store.RoleStore
+	userGroupMemberStore     store.UserGroupMemberStore
+	userGroupSubgroupStore   store.UserGroupSubgroupStore
+	userGroupMembershipStore store.UserGroupMembershipStore
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
 	spaceRef := key.SpaceRef
 	principalID := key.PrincipalID
 
+	if key.RepoRef != "" {
+		granted, err := g.checkRepoMembership(ctx, key.RepoRef, principalID, key.Permission)
+		if err != nil {
+			return false, err
+		}
+		if granted {
+			return true, nil
+		}
+	}
+
 	// Find the first existing space.
 	space, err := g.findFirstExistingSpace(ctx, spaceRef)
 	// authz fails if no active space is found on the path; admins can still operate on deleted top-level spaces.
@@ -70,6 +104,10 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	// limit the depth to be safe (e.g. root/space1/space2 => maxDepth of 3)
 	maxDepth := len(paths.Segments(spaceRef))
 
+	// usergroups of the principal are loaded only if the principal's own memberships don't grant the permission.
+	var userGroupIDs []int64
+	userGroupsLoaded := false
+
 	for depth := 0; depth < maxDepth; depth++ {
 		// Find the membership in the current space.
 		membership, err := g.membershipStore.Find(ctx, types.MembershipKey{
@@ -81,9 +119,40 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 		}
 
 		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
+		if membership != nil {
+			granted, err := g.roleHasPermission(ctx, membership.Role, key.Permission)
+			if err != nil {
+				return false, err
+			}
+			if granted {
+				return true, nil
+			}
+		}
+
+		// Check the memberships of the usergroups the principal belongs to.
+		if !userGroupsLoaded {
+			userGroupIDs, err = g.findUserGroupIDs(ctx, principalID)
+			if err != nil {
+				return false, err
+			}
+			userGroupsLoaded = true
+		}
+
+		if len(userGroupIDs) > 0 {
+			roles, err := g.userGroupMembershipStore.ListRoles(ctx, space.ID, userGroupIDs)
+			if err != nil {
+				return false, fmt.Errorf("failed to list usergroup membership roles: %w", err)
+			}
+
+			for _, role := range roles {
+				granted, err := g.roleHasPermission(ctx, role, key.Permission)
+				if err != nil {
+					return false, err
+				}
+				if granted {
+					return true, nil
+				}
+			}
 		}
 
 		// If membership with the requested permission has not been found in the current space,
@@ -102,6 +171,94 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	return false, nil
 }
 
+// checkRepoMembership checks whether the membership of the principal in the repository grants the permission.
+func (g permissionCacheGetter) checkRepoMembership(
+	ctx context.Context,
+	repoRef string,
+	principalID int64,
+	permission enum.Permission,
+) (bool, error) {
+	repo, err := g.repoStore.FindByRef(ctx, repoRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo '%s': %w", repoRef, err)
+	}
+
+	membership, err := g.repoMembershipStore.Find(ctx, types.RepoMembershipKey{
+		RepoID:      repo.ID,
+		PrincipalID: principalID,
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo membership: %w", err)
+	}
+
+	return g.roleHasPermission(ctx, membership.Role, permission)
+}
+
+// findUserGroupIDs returns the IDs of the usergroups the principal is a direct member of,
+// along with the IDs of all usergroups these usergroups are nested in.
+func (g permissionCacheGetter) findUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	directIDs, err := g.userGroupMemberStore.ListUserGroupIDs(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroups of the principal: %w", err)
+	}
+
+	userGroupIDs := slices.Clone(directIDs)
+	seen := make(map[int64]struct{}, len(directIDs))
+	for _, id := range directIDs {
+		seen[id] = struct{}{}
+	}
+
+	current := directIDs
+	for depth := 0; depth < maxUserGroupNestingDepth && len(current) > 0; depth++ {
+		parentIDs, err := g.userGroupSubgroupStore.ListParentIDs(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parent usergroups: %w", err)
+		}
+
+		next := make([]int64, 0, len(parentIDs))
+		for _, id := range parentIDs {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			next = append(next, id)
+		}
+
+		userGroupIDs = append(userGroupIDs, next...)
+		current = next
+	}
+
+	return userGroupIDs, nil
+}
+
+// roleHasPermission checks whether the role grants the permission, custom roles are loaded from the store.
+// A membership referencing a custom role that doesn't exist anymore doesn't grant any permission.
+func (g permissionCacheGetter) roleHasPermission(
+	ctx context.Context,
+	role enum.MembershipRole,
+	permission enum.Permission,
+) (bool, error) {
+	if role.IsBuiltIn() {
+		return roleHasPermission(role, permission), nil
+	}
+
+	customRole, err := g.roleStore.FindByIdentifier(ctx, string(role))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find role '%s': %w", role, err)
+	}
+
+	return customRole.HasPermission(permission), nil
+}
+
 func roleHasPermission(role enum.MembershipRole, permission enum.Permission) bool {
 	_, hasRole := slices.BinarySearch(role.Permissions(), permission)
 	return hasRole
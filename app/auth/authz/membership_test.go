@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakePublicAccess struct {
+	publicaccess.Service
+}
+
+func (fakePublicAccess) Get(context.Context, enum.PublicResourceType, string) (bool, error) {
+	return false, nil
+}
+
+// fakePermissionCache grants the memberships of the principals, keyed by principal and permission.
+type fakePermissionCache struct {
+	granted map[int64][]enum.Permission
+}
+
+func (fakePermissionCache) Stats() (int64, int64) {
+	return 0, 0
+}
+
+func (c fakePermissionCache) Get(_ context.Context, key PermissionCacheKey) (bool, error) {
+	for _, permission := range c.granted[key.PrincipalID] {
+		if permission == key.Permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestMembershipAuthorizer_CheckTokenRestrictions(t *testing.T) {
+	admin := types.Principal{ID: 1, UID: "admin", Type: enum.PrincipalTypeUser, Admin: true}
+	member := types.Principal{ID: 2, UID: "member", Type: enum.PrincipalTypeUser}
+
+	authorizer := NewMembershipAuthorizer(
+		fakePermissionCache{granted: map[int64][]enum.Permission{
+			member.ID: {enum.PermissionRepoView, enum.PermissionRepoPush},
+		}},
+		nil,
+		fakePublicAccess{},
+	)
+
+	tests := []struct {
+		name       string
+		principal  types.Principal
+		metadata   auth.Metadata
+		space      string
+		permission enum.Permission
+		want       bool
+	}{
+		{
+			name:       "admin with unrestricted token",
+			principal:  admin,
+			metadata:   &auth.TokenMetadata{TokenType: enum.TokenTypePAT},
+			space:      "acme",
+			permission: enum.PermissionRepoDelete,
+			want:       true,
+		},
+		{
+			name:      "admin with admin scope",
+			principal: admin,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scopes:    []enum.TokenScope{enum.TokenScopeAdmin},
+			},
+			space:      "acme",
+			permission: enum.PermissionRepoDelete,
+			want:       true,
+		},
+		{
+			name:      "admin with scope denying the permission",
+			principal: admin,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scopes:    []enum.TokenScope{enum.TokenScopeRepoRead},
+			},
+			space:      "acme",
+			permission: enum.PermissionRepoPush,
+		},
+		{
+			name:      "admin with repository outside of the allowlist",
+			principal: admin,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Resources: []types.TokenResource{{Type: enum.ParentResourceTypeSpace, Path: "other"}},
+			},
+			space:      "acme",
+			permission: enum.PermissionRepoView,
+		},
+		{
+			name:      "member with scope granting the permission",
+			principal: member,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scopes:    []enum.TokenScope{enum.TokenScopeRepoWrite},
+				Resources: []types.TokenResource{{Type: enum.ParentResourceTypeSpace, Path: "acme"}},
+			},
+			space:      "acme",
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:      "member with scope not overriding the memberships",
+			principal: member,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scopes:    []enum.TokenScope{enum.TokenScopeRepoWrite},
+			},
+			space:      "acme",
+			permission: enum.PermissionRepoEdit,
+		},
+		{
+			name:      "member enrolling two-factor authentication",
+			principal: member,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypeSession,
+				Scopes:    []enum.TokenScope{enum.TokenScopeTwoFactorEnrollment},
+			},
+			space:      "acme",
+			permission: enum.PermissionRepoView,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{Principal: test.principal, Metadata: test.metadata}
+
+			got, err := authorizer.Check(
+				context.Background(),
+				session,
+				&types.Scope{SpacePath: test.space},
+				&types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+				test.permission,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("Check() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
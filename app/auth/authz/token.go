@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// checkTokenRestrictions returns false if the permission isn't granted by the scopes of the token,
+// or if the resource is outside of the spaces and repositories the token is restricted to.
+func checkTokenRestrictions(
+	metadata *auth.TokenMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) bool {
+	if len(metadata.Scopes) > 0 && !scopesGrant(metadata.Scopes, permission) {
+		return false
+	}
+
+	if len(metadata.Resources) == 0 {
+		return true
+	}
+
+	var spacePath, repoPath string
+
+	//nolint:exhaustive // all other resources are located in a space and optionally in a repository
+	switch resource.Type {
+	case enum.ResourceTypeUser:
+		// users exist outside of spaces, so they are only restricted by the scopes.
+		return true
+	case enum.ResourceTypeService:
+		return false
+	case enum.ResourceTypeSpace:
+		spacePath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+	case enum.ResourceTypeRepo:
+		spacePath = scope.SpacePath
+		repoPath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+	default:
+		spacePath = scope.SpacePath
+		if scope.Repo != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, scope.Repo)
+		}
+	}
+
+	for _, r := range metadata.Resources {
+		switch r.Type {
+		case enum.ParentResourceTypeSpace:
+			if isPathWithin(r.Path, spacePath) {
+				return true
+			}
+		case enum.ParentResourceTypeRepo:
+			if repoPath != "" && isPathWithin(r.Path, repoPath) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func scopesGrant(scopes []enum.TokenScope, permission enum.Permission) bool {
+	for _, scope := range scopes {
+		if scope.Grants(permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPathWithin returns true if the path is equal to the parent path or one of its descendants (case insensitive).
+func isPathWithin(parent string, path string) bool {
+	parent = strings.ToLower(strings.Trim(parent, types.PathSeparator))
+	path = strings.ToLower(strings.Trim(path, types.PathSeparator))
+
+	return path == parent || strings.HasPrefix(path, parent+types.PathSeparator)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+//nolint:funlen // table driven test.
+func TestCheckTokenRestrictions(t *testing.T) {
+	spaceAllowlist := []types.TokenResource{{Type: enum.ParentResourceTypeSpace, Path: "acme"}}
+	repoAllowlist := []types.TokenResource{{Type: enum.ParentResourceTypeRepo, Path: "acme/app"}}
+
+	tests := []struct {
+		name       string
+		scopes     []enum.TokenScope
+		resources  []types.TokenResource
+		scope      types.Scope
+		resource   types.Resource
+		permission enum.Permission
+		want       bool
+	}{
+		{
+			name:       "unrestricted token",
+			scope:      types.Scope{SpacePath: "other"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:       "permission granted by the scope",
+			scopes:     []enum.TokenScope{enum.TokenScopeRepoWrite},
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:       "permission denied by the scope",
+			scopes:     []enum.TokenScope{enum.TokenScopeRepoRead},
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoPush,
+		},
+		{
+			name:       "admin scope grants every permission",
+			scopes:     []enum.TokenScope{enum.TokenScopeAdmin},
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeSpace, Identifier: "team"},
+			permission: enum.PermissionSpaceDelete,
+			want:       true,
+		},
+		{
+			name:       "repository in an allowlisted space",
+			resources:  spaceAllowlist,
+			scope:      types.Scope{SpacePath: "Acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "repository outside of the allowlist",
+			resources:  spaceAllowlist,
+			scope:      types.Scope{SpacePath: "other"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoView,
+		},
+		{
+			name:       "space with the allowlisted space as prefix",
+			resources:  spaceAllowlist,
+			scope:      types.Scope{},
+			resource:   types.Resource{Type: enum.ResourceTypeSpace, Identifier: "acme-corp"},
+			permission: enum.PermissionSpaceView,
+		},
+		{
+			name:       "pipeline of an allowlisted repository",
+			resources:  repoAllowlist,
+			scope:      types.Scope{SpacePath: "acme", Repo: "app"},
+			resource:   types.Resource{Type: enum.ResourceTypePipeline, Identifier: "build"},
+			permission: enum.PermissionPipelineView,
+			want:       true,
+		},
+		{
+			name:       "other repository of the space",
+			resources:  repoAllowlist,
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "lib"},
+			permission: enum.PermissionRepoView,
+		},
+		{
+			name:       "space of an allowlisted repository",
+			resources:  repoAllowlist,
+			scope:      types.Scope{},
+			resource:   types.Resource{Type: enum.ResourceTypeSpace, Identifier: "acme"},
+			permission: enum.PermissionSpaceView,
+		},
+		{
+			name:       "users aren't restricted by the allowlist",
+			resources:  repoAllowlist,
+			resource:   types.Resource{Type: enum.ResourceTypeUser, Identifier: "jane"},
+			permission: enum.PermissionUserView,
+			want:       true,
+		},
+		{
+			name:       "two-factor enrollment allows to edit the user",
+			scopes:     []enum.TokenScope{enum.TokenScopeTwoFactorEnrollment},
+			resource:   types.Resource{Type: enum.ResourceTypeUser, Identifier: "jane"},
+			permission: enum.PermissionUserEdit,
+			want:       true,
+		},
+		{
+			name:       "two-factor enrollment denies repository access",
+			scopes:     []enum.TokenScope{enum.TokenScopeTwoFactorEnrollment},
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoView,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scopes:    test.scopes,
+				Resources: test.resources,
+			}
+
+			got := checkTokenRestrictions(metadata, &test.scope, &test.resource, test.permission)
+			if got != test.want {
+				t.Errorf("checkTokenRestrictions() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
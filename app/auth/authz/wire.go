@@ -40,7 +40,23 @@ func ProvideAuthorizer(
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
+	repoMembershipStore store.RepoMembershipStore,
+	roleStore store.RoleStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	userGroupSubgroupStore store.UserGroupSubgroupStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(
+		spaceStore,
+		membershipStore,
+		repoStore,
+		repoMembershipStore,
+		roleStore,
+		userGroupMemberStore,
+		userGroupSubgroupStore,
+		userGroupMembershipStore,
+		permissionCacheTimeout,
+	)
 }
@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// IsInvalidCredentials returns true if the error is caused by a wrong DN or password.
+func IsInvalidCredentials(err error) bool {
+	return goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials)
+}
+
+// EscapeFilter escapes the value for use as assertion value of a search filter.
+func EscapeFilter(value string) string {
+	return goldap.EscapeFilter(value)
+}
+
+// DialConfig defines the directory server to connect to.
+type DialConfig struct {
+	// URL is the ldap:// or ldaps:// URL of the server.
+	URL string
+	// StartTLS upgrades ldap:// connections to TLS before any credentials are sent.
+	StartTLS           bool
+	InsecureSkipVerify bool
+	// Timeout limits the duration of every operation.
+	Timeout time.Duration
+}
+
+// Conn is a connection to a directory server. The protocol is implemented by go-ldap.
+type Conn struct {
+	conn *goldap.Conn
+}
+
+// Dial connects to the directory server.
+func Dial(ctx context.Context, config DialConfig) (*Conn, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap url: %w", err)
+	}
+
+	host := u.Hostname()
+	port := u.Port()
+	useTLS := false
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		if port == "" {
+			port = "389"
+		}
+	case "ldaps":
+		useTLS = true
+		if port == "" {
+			port = "636"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ldap url scheme %q", u.Scheme)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         host,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // explicitly configured by the administrator.
+	}
+
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap server: %w", err)
+	}
+
+	if useTLS {
+		netConn = tls.Client(netConn, tlsConfig)
+	}
+
+	conn := goldap.NewConn(netConn, useTLS)
+	conn.Start()
+	if config.Timeout > 0 {
+		conn.SetTimeout(config.Timeout)
+	}
+
+	if config.StartTLS && !useTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	return &Conn{conn: conn}, nil
+}
+
+// Close unbinds and closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Unbind()
+}
+
+// Bind authenticates the connection with a simple bind.
+// Empty passwords are rejected, as servers treat them as unauthenticated binds that always succeed.
+func (c *Conn) Bind(dn, password string) error {
+	if password == "" {
+		return goldap.NewError(goldap.LDAPResultInvalidCredentials, errors.New("empty password"))
+	}
+
+	return c.conn.Bind(dn, password)
+}
+
+// SearchRequest is a search of the subtree of the base DN.
+type SearchRequest struct {
+	BaseDN     string
+	Filter     string
+	Attributes []string
+	// PageSize requests the results in pages of the size, which is required by servers
+	// limiting the number of results of a single search (e.g. Active Directory).
+	PageSize int
+}
+
+// Entry is an entry returned by a search.
+type Entry struct {
+	entry *goldap.Entry
+}
+
+// DN returns the distinguished name of the entry.
+func (e *Entry) DN() string {
+	return e.entry.DN
+}
+
+// Get returns the first value of the attribute.
+func (e *Entry) Get(attribute string) string {
+	return e.entry.GetEqualFoldAttributeValue(attribute)
+}
+
+// Values returns all values of the attribute.
+func (e *Entry) Values(attribute string) []string {
+	return e.entry.GetEqualFoldAttributeValues(attribute)
+}
+
+// Search returns all entries matching the search request. Referrals to other servers are not followed.
+func (c *Conn) Search(request SearchRequest) ([]*Entry, error) {
+	searchRequest := goldap.NewSearchRequest(
+		request.BaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		request.Filter,
+		request.Attributes,
+		nil,
+	)
+
+	var result *goldap.SearchResult
+	var err error
+	if request.PageSize > 0 {
+		result, err = c.conn.SearchWithPaging(searchRequest, uint32(request.PageSize))
+	} else {
+		result, err = c.conn.Search(searchRequest)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, len(result.Entries))
+	for i, entry := range result.Entries {
+		entries[i] = &Entry{entry: entry}
+	}
+
+	return entries, nil
+}
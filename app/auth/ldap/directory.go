@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const searchPageSize = 500
+
+// ErrUserNotFound is returned if no user with the login exists in the directory.
+var ErrUserNotFound = errors.New("user not found in directory")
+
+// Config defines the directory and how users are found in it.
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+
+	// BindDN and BindPassword are the credentials of the account used to search the directory.
+	BindDN       string
+	BindPassword string
+
+	UserBaseDN string
+	// UserFilter restricts the entries considered users, e.g. (objectClass=person).
+	UserFilter string
+
+	LoginAttribute string
+	EmailAttribute string
+	NameAttribute  string
+	// GroupAttribute holds the DNs of the groups of the user, e.g. memberOf.
+	GroupAttribute string
+}
+
+// User is a user of the directory.
+type User struct {
+	DN          string
+	Login       string
+	Email       string
+	DisplayName string
+	// Groups are the DNs of the groups the user is a member of.
+	Groups []string
+}
+
+// Directory authenticates users against and reads users from a directory server.
+type Directory struct {
+	config Config
+}
+
+func NewDirectory(config Config) *Directory {
+	return &Directory{config: config}
+}
+
+// Authenticate verifies the password of the user by binding as the user.
+// It returns ErrUserNotFound if the user doesn't exist.
+func (d *Directory) Authenticate(ctx context.Context, login, password string) (*User, error) {
+	conn, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	user, err := d.findUser(conn, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.Bind(user.DN, password); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// FindUser returns the user with the login.
+// It returns ErrUserNotFound if the user doesn't exist.
+func (d *Directory) FindUser(ctx context.Context, login string) (*User, error) {
+	conn, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return d.findUser(conn, login)
+}
+
+// ListUsers returns all users matching the user filter.
+func (d *Directory) ListUsers(ctx context.Context) ([]*User, error) {
+	conn, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := conn.Search(SearchRequest{
+		BaseDN:     d.config.UserBaseDN,
+		Filter:     d.config.UserFilter,
+		Attributes: d.attributes(),
+		PageSize:   searchPageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	users := make([]*User, 0, len(entries))
+	for _, entry := range entries {
+		// entries without login can't be mapped to users.
+		if user := d.toUser(entry); user.Login != "" {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+func (d *Directory) connect(ctx context.Context) (*Conn, error) {
+	conn, err := Dial(ctx, DialConfig{
+		URL:                d.config.URL,
+		StartTLS:           d.config.StartTLS,
+		InsecureSkipVerify: d.config.InsecureSkipVerify,
+		Timeout:            d.config.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.Bind(d.config.BindDN, d.config.BindPassword); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to bind with the service account: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (d *Directory) findUser(conn *Conn, login string) (*User, error) {
+	if login == "" {
+		return nil, ErrUserNotFound
+	}
+
+	filter := fmt.Sprintf("(&%s(%s=%s))", d.config.UserFilter, d.config.LoginAttribute, EscapeFilter(login))
+	entries, err := conn.Search(SearchRequest{
+		BaseDN:     d.config.UserBaseDN,
+		Filter:     filter,
+		Attributes: d.attributes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search user: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, ErrUserNotFound
+	}
+	if len(entries) > 1 {
+		return nil, fmt.Errorf("login %q matches %d directory entries", login, len(entries))
+	}
+
+	return d.toUser(entries[0]), nil
+}
+
+func (d *Directory) attributes() []string {
+	return []string{
+		d.config.LoginAttribute,
+		d.config.EmailAttribute,
+		d.config.NameAttribute,
+		d.config.GroupAttribute,
+	}
+}
+
+func (d *Directory) toUser(entry *Entry) *User {
+	return &User{
+		DN:          entry.DN(),
+		Login:       strings.TrimSpace(entry.Get(d.config.LoginAttribute)),
+		Email:       strings.TrimSpace(entry.Get(d.config.EmailAttribute)),
+		DisplayName: strings.TrimSpace(entry.Get(d.config.NameAttribute)),
+		Groups:      entry.Values(d.config.GroupAttribute),
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+func TestEscapeFilter(t *testing.T) {
+	if got := EscapeFilter("*)(uid=*"); got != "\\2a\\29\\28uid=\\2a" {
+		t.Errorf("unexpected escaped value %q", got)
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	server := newFakeServer(t)
+
+	directory := NewDirectory(Config{
+		URL:            "ldap://" + server.addr,
+		Timeout:        5 * time.Second,
+		BindDN:         "cn=admin,dc=example,dc=com",
+		BindPassword:   "admin-secret",
+		UserBaseDN:     "ou=people,dc=example,dc=com",
+		UserFilter:     "(objectClass=person)",
+		LoginAttribute: "uid",
+		EmailAttribute: "mail",
+		NameAttribute:  "cn",
+		GroupAttribute: "memberOf",
+	})
+	ctx := context.Background()
+
+	user, err := directory.Authenticate(ctx, "jane", "jane-secret")
+	if err != nil {
+		t.Fatalf("failed to authenticate: %s", err)
+	}
+	if user.Email != "jane@example.com" || user.DisplayName != "Jane Doe" || len(user.Groups) != 2 {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if _, err = directory.Authenticate(ctx, "jane", "wrong"); !IsInvalidCredentials(err) {
+		t.Errorf("expected invalid credentials, got %v", err)
+	}
+
+	if _, err = directory.Authenticate(ctx, "jane", ""); !IsInvalidCredentials(err) {
+		t.Errorf("expected empty password to be rejected, got %v", err)
+	}
+
+	if _, err = directory.Authenticate(ctx, "*", "jane-secret"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected wildcard login to not be found, got %v", err)
+	}
+
+	users, err := directory.ListUsers(ctx)
+	if err != nil {
+		t.Fatalf("failed to list users: %s", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users, got %d", len(users))
+	}
+	if server.pages < 2 {
+		t.Errorf("expected paged search, got %d pages", server.pages)
+	}
+}
+
+type fakeEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+type fakeServer struct {
+	addr    string
+	entries []fakeEntry
+	pages   int
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	person := func(uid, name string) fakeEntry {
+		return fakeEntry{
+			dn:       "uid=" + uid + ",ou=people,dc=example,dc=com",
+			password: uid + "-secret",
+			attributes: map[string][]string{
+				"objectclass": {"person"},
+				"uid":         {uid},
+				"cn":          {name},
+				"mail":        {uid + "@example.com"},
+				"memberof":    {"cn=dev,ou=groups,dc=example,dc=com", "cn=ops,ou=groups,dc=example,dc=com"},
+			},
+		}
+	}
+
+	s := &fakeServer{
+		addr: listener.Addr().String(),
+		entries: []fakeEntry{
+			{dn: "cn=admin,dc=example,dc=com", password: "admin-secret"},
+			person("jane", "Jane Doe"),
+			person("john", "John Doe"),
+			person("max", "Max Mustermann"),
+		},
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		message, err := ber.ReadPacket(conn)
+		if err != nil || len(message.Children) < 2 {
+			return
+		}
+
+		id, ok := message.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+		op := message.Children[1]
+
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			code := int64(goldap.LDAPResultInvalidCredentials)
+			for _, e := range s.entries {
+				if e.dn == value(op.Children[1]) && e.password == value(op.Children[2]) {
+					code = goldap.LDAPResultSuccess
+				}
+			}
+			s.reply(conn, id, result(goldap.ApplicationBindResponse, code), nil)
+
+		case goldap.ApplicationSearchRequest:
+			var controls *ber.Packet
+			if len(message.Children) > 2 {
+				controls = message.Children[2]
+			}
+			s.search(conn, id, op, controls)
+
+		default:
+			return
+		}
+	}
+}
+
+func (s *fakeServer) search(conn net.Conn, id int64, op, controls *ber.Packet) {
+	var matches []fakeEntry
+	for _, e := range s.entries {
+		if e.attributes != nil && matchFilter(op.Children[6], e.attributes) {
+			matches = append(matches, e)
+		}
+	}
+
+	// return at most two entries per page, regardless of the requested page size.
+	var doneControls *ber.Packet
+	if controls != nil && len(controls.Children) > 0 {
+		control, _ := goldap.DecodeControl(controls.Children[0])
+		paging, _ := control.(*goldap.ControlPaging)
+		if paging == nil {
+			return
+		}
+		offset, _ := strconv.Atoi(string(paging.Cookie))
+
+		next := ""
+		if offset+2 < len(matches) {
+			next = strconv.Itoa(offset + 2)
+			matches = matches[offset : offset+2]
+		} else {
+			matches = matches[offset:]
+		}
+		s.pages++
+
+		donePaging := goldap.NewControlPaging(0)
+		donePaging.SetCookie([]byte(next))
+		doneControls = ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+		doneControls.AppendChild(donePaging.Encode())
+	}
+
+	for _, e := range matches {
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Entry")
+		entry.AppendChild(octetString(e.dn))
+
+		attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for name, values := range e.attributes {
+			attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attribute.AppendChild(octetString(name))
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, v := range values {
+				set.AppendChild(octetString(v))
+			}
+			attribute.AppendChild(set)
+			attributes.AppendChild(attribute)
+		}
+		entry.AppendChild(attributes)
+
+		s.reply(conn, id, entry, nil)
+	}
+
+	s.reply(conn, id, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess), doneControls)
+}
+
+func (s *fakeServer) reply(conn net.Conn, id int64, op, controls *ber.Packet) {
+	message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	message.AppendChild(op)
+	if controls != nil {
+		message.AppendChild(controls)
+	}
+	_, _ = conn.Write(message.Bytes())
+}
+
+func result(tag ber.Tag, code int64) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	op.AppendChild(octetString(""))
+	op.AppendChild(octetString(""))
+	return op
+}
+
+func octetString(s string) *ber.Packet {
+	return ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, s, "")
+}
+
+// value returns the content of a primitive packet.
+func value(p *ber.Packet) string {
+	return p.Data.String()
+}
+
+// matchFilter evaluates the subset of filters used by the directory.
+func matchFilter(filter *ber.Packet, attributes map[string][]string) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(child, attributes) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterEqualityMatch:
+		for _, v := range attributes[strings.ToLower(value(filter.Children[0]))] {
+			if strings.EqualFold(v, value(filter.Children[1])) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterPresent:
+		return len(attributes[strings.ToLower(value(filter))]) > 0
+	default:
+		return false
+	}
+}
@@ -15,7 +15,10 @@
 package auth
 
 import (
+	"slices"
+
 	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -34,10 +37,18 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+	// Scopes and Resources restrict the access granted to personal access tokens.
+	Scopes    []enum.TokenScope
+	Resources []types.TokenResource
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
-	return false
+	return len(m.Scopes) > 0 || len(m.Resources) > 0
+}
+
+// GrantsAdmin returns true if the token grants the system administration privileges of admins.
+func (m *TokenMetadata) GrantsAdmin() bool {
+	return len(m.Scopes) == 0 || slices.Contains(m.Scopes, enum.TokenScopeAdmin)
 }
 
 // MembershipMetadata contains information about an ephemeral membership grant.
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestTokenMetadata_GrantsAdmin(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []enum.TokenScope
+		want   bool
+	}{
+		{
+			name: "unrestricted token",
+			want: true,
+		},
+		{
+			name:   "admin scope",
+			scopes: []enum.TokenScope{enum.TokenScopeAdmin},
+			want:   true,
+		},
+		{
+			name:   "admin among other scopes",
+			scopes: []enum.TokenScope{enum.TokenScopeRepoRead, enum.TokenScopeAdmin},
+			want:   true,
+		},
+		{
+			name:   "repository scopes",
+			scopes: []enum.TokenScope{enum.TokenScopeRepoRead, enum.TokenScopeRepoWrite},
+		},
+		{
+			name:   "two-factor enrollment",
+			scopes: []enum.TokenScope{enum.TokenScopeTwoFactorEnrollment},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := &TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: test.scopes}
+			if got := metadata.GrantsAdmin(); got != test.want {
+				t.Errorf("GrantsAdmin() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
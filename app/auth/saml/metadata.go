@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saml
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	nsProtocol = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	bindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	nameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	nameIDFormatPersistent  = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+)
+
+// NameIDFormatEmail is the format of name IDs holding the email address of the user.
+const NameIDFormatEmail = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+// IdentityProvider is the SAML identity provider users are authenticated with.
+type IdentityProvider struct {
+	EntityID string
+	// SSOURL is the single sign-on endpoint of the HTTP-Redirect binding.
+	SSOURL string
+	// Certificates are the certificates the identity provider signs responses with.
+	Certificates []*x509.Certificate
+}
+
+type metadataEntities struct {
+	XMLName  xml.Name           `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntitiesDescriptor"`
+	Entities []metadataEntity   `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	Nested   []metadataEntities `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntitiesDescriptor"`
+}
+
+type metadataEntity struct {
+	XMLName  xml.Name             `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID string               `xml:"entityID,attr"`
+	IDPSSO   []metadataIDPSSODesc `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+type metadataIDPSSODesc struct {
+	KeyDescriptors []struct {
+		Use          string   `xml:"use,attr"`
+		Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleSignOnServices []struct {
+		Binding  string `xml:"Binding,attr"`
+		Location string `xml:"Location,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+// ParseMetadata returns the identity provider described by the SAML metadata.
+// If the metadata contains multiple entities, the first identity provider is used.
+func ParseMetadata(data []byte) (*IdentityProvider, error) {
+	if bytes.Contains(data, []byte("<!DOCTYPE")) {
+		return nil, errors.New("metadata must not contain a DTD")
+	}
+
+	var entities []metadataEntity
+
+	entity := metadataEntity{}
+	if err := xml.Unmarshal(data, &entity); err == nil {
+		entities = append(entities, entity)
+	} else {
+		group := metadataEntities{}
+		if err := xml.Unmarshal(data, &group); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata: %w", err)
+		}
+		entities = group.flatten()
+	}
+
+	for _, entity := range entities {
+		if len(entity.IDPSSO) == 0 {
+			continue
+		}
+
+		return entity.identityProvider()
+	}
+
+	return nil, errors.New("metadata doesn't describe an identity provider")
+}
+
+func (g metadataEntities) flatten() []metadataEntity {
+	entities := g.Entities
+	for _, nested := range g.Nested {
+		entities = append(entities, nested.flatten()...)
+	}
+	return entities
+}
+
+func (e metadataEntity) identityProvider() (*IdentityProvider, error) {
+	idp := &IdentityProvider{EntityID: strings.TrimSpace(e.EntityID)}
+	if idp.EntityID == "" {
+		return nil, errors.New("metadata has no entity id")
+	}
+
+	for _, desc := range e.IDPSSO {
+		for _, sso := range desc.SingleSignOnServices {
+			if sso.Binding == bindingHTTPRedirect && idp.SSOURL == "" {
+				idp.SSOURL = strings.TrimSpace(sso.Location)
+			}
+		}
+
+		for _, key := range desc.KeyDescriptors {
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+
+			for _, encoded := range key.Certificates {
+				der, err := decodeBase64(encoded)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode certificate: %w", err)
+				}
+
+				certificate, err := x509.ParseCertificate(der)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse certificate: %w", err)
+				}
+
+				idp.Certificates = append(idp.Certificates, certificate)
+			}
+		}
+	}
+
+	if idp.SSOURL == "" {
+		return nil, errors.New("identity provider doesn't support the HTTP-Redirect binding")
+	}
+
+	if len(idp.Certificates) == 0 {
+		return nil, errors.New("identity provider has no signing certificate")
+	}
+
+	return idp, nil
+}
+
+type spMetadata struct {
+	XMLName  xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID string   `xml:"entityID,attr"`
+	SPSSO    struct {
+		AuthnRequestsSigned        bool     `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool     `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string   `xml:"protocolSupportEnumeration,attr"`
+		NameIDFormats              []string `xml:"NameIDFormat"`
+		AssertionConsumerService   struct {
+			Binding   string `xml:"Binding,attr"`
+			Location  string `xml:"Location,attr"`
+			Index     int    `xml:"index,attr"`
+			IsDefault bool   `xml:"isDefault,attr"`
+		} `xml:"AssertionConsumerService"`
+	} `xml:"SPSSODescriptor"`
+}
+
+// Metadata returns the SAML metadata of the service provider, used to register it with the identity provider.
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	metadata := spMetadata{EntityID: sp.EntityID}
+	metadata.SPSSO.WantAssertionsSigned = true
+	metadata.SPSSO.ProtocolSupportEnumeration = nsProtocol
+	metadata.SPSSO.NameIDFormats = []string{NameIDFormatEmail, nameIDFormatPersistent, nameIDFormatUnspecified}
+	metadata.SPSSO.AssertionConsumerService.Binding = bindingHTTPPost
+	metadata.SPSSO.AssertionConsumerService.Location = sp.ACSURL
+	metadata.SPSSO.AssertionConsumerService.IsDefault = true
+
+	data, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	crewjamsaml "github.com/crewjam/saml"
+)
+
+// maxResponseSize limits the size of SAML responses accepted by the service provider.
+const maxResponseSize = 1 << 20
+
+// ServiceProvider is a SAML service provider authenticating users with an identity provider.
+// Only SP-initiated single sign-on is supported, with requests sent using the HTTP-Redirect binding
+// and responses received using the HTTP-POST binding. Encrypted assertions are not supported.
+// Responses are verified with crewjam/saml, which verifies the XML signatures with goxmldsig.
+type ServiceProvider struct {
+	EntityID string
+	ACSURL   string
+	IdP      *IdentityProvider
+}
+
+// Assertion is the verified identity of a user, as asserted by the identity provider.
+type Assertion struct {
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	Attributes   map[string][]string
+}
+
+// Attribute returns the first value of the attribute with the provided name.
+func (a *Assertion) Attribute(name string) string {
+	values := a.Attributes[name]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// NewRequestID generates a random ID for an authentication request.
+func NewRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate request id: %w", err)
+	}
+
+	// IDs must not start with a digit.
+	return "_" + hex.EncodeToString(b), nil
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	Destination                 string   `xml:"Destination,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      struct {
+		Value string `xml:",chardata"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy struct {
+		Format      string `xml:"Format,attr"`
+		AllowCreate bool   `xml:"AllowCreate,attr"`
+	} `xml:"NameIDPolicy"`
+}
+
+// AuthnRequestURL returns the URL the user is redirected to for authenticating with the identity provider.
+func (sp *ServiceProvider) AuthnRequestURL(requestID, relayState string, now time.Time) (string, error) {
+	request := authnRequest{
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                now.UTC().Format(time.RFC3339),
+		Destination:                 sp.IdP.SSOURL,
+		ProtocolBinding:             bindingHTTPPost,
+		AssertionConsumerServiceURL: sp.ACSURL,
+	}
+	request.Issuer.Value = sp.EntityID
+	request.NameIDPolicy.Format = nameIDFormatUnspecified
+	request.NameIDPolicy.AllowCreate = true
+
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal authentication request: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := flate.NewWriter(buf, flate.BestCompression)
+	if err != nil {
+		return "", fmt.Errorf("failed to create deflate writer: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		return "", fmt.Errorf("failed to deflate authentication request: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", fmt.Errorf("failed to deflate authentication request: %w", err)
+	}
+
+	ssoURL, err := url.Parse(sp.IdP.SSOURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse sso url: %w", err)
+	}
+
+	query := ssoURL.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	ssoURL.RawQuery = query.Encode()
+
+	return ssoURL.String(), nil
+}
+
+// ParseResponse verifies the base64 encoded SAML response received by the assertion consumer service
+// and returns the assertion it contains. The response must be a reply to the request with the provided ID.
+func (sp *ServiceProvider) ParseResponse(samlResponse, requestID string) (*Assertion, error) {
+	if len(samlResponse) > maxResponseSize {
+		return nil, errors.New("response is too large")
+	}
+
+	data, err := decodeBase64(samlResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if bytes.Contains(data, []byte("<!DOCTYPE")) {
+		return nil, errors.New("response must not contain a DTD")
+	}
+
+	if requestID == "" {
+		return nil, errors.New("response isn't a reply to an authentication request")
+	}
+
+	provider, err := sp.crewjamServiceProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, err := provider.ParseXMLResponse(data, []string{requestID}, provider.AcsURL)
+
+	// the error returned to the user is generic, the reason is only available as private error.
+	var invalidResponseErr *crewjamsaml.InvalidResponseError
+	if errors.As(err, &invalidResponseErr) {
+		return nil, fmt.Errorf("invalid response: %w", invalidResponseErr.PrivateErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	return newAssertion(assertion)
+}
+
+// crewjamServiceProvider returns the service provider with the identity provider metadata used by crewjam/saml.
+func (sp *ServiceProvider) crewjamServiceProvider() (*crewjamsaml.ServiceProvider, error) {
+	acsURL, err := url.Parse(sp.ACSURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse assertion consumer service url: %w", err)
+	}
+
+	certificates := make([]crewjamsaml.X509Certificate, len(sp.IdP.Certificates))
+	for i, certificate := range sp.IdP.Certificates {
+		certificates[i] = crewjamsaml.X509Certificate{Data: base64.StdEncoding.EncodeToString(certificate.Raw)}
+	}
+
+	idpSSODescriptor := crewjamsaml.IDPSSODescriptor{
+		SingleSignOnServices: []crewjamsaml.Endpoint{{Binding: bindingHTTPRedirect, Location: sp.IdP.SSOURL}},
+	}
+	idpSSODescriptor.KeyDescriptors = []crewjamsaml.KeyDescriptor{{
+		Use:     "signing",
+		KeyInfo: crewjamsaml.KeyInfo{X509Data: crewjamsaml.X509Data{X509Certificates: certificates}},
+	}}
+
+	return &crewjamsaml.ServiceProvider{
+		EntityID: sp.EntityID,
+		AcsURL:   *acsURL,
+		IDPMetadata: &crewjamsaml.EntityDescriptor{
+			EntityID:          sp.IdP.EntityID,
+			IDPSSODescriptors: []crewjamsaml.IDPSSODescriptor{idpSSODescriptor},
+		},
+	}, nil
+}
+
+// newAssertion returns the identity asserted by the verified assertion.
+func newAssertion(assertion *crewjamsaml.Assertion) (*Assertion, error) {
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, errors.New("assertion subject has no name id")
+	}
+
+	result := &Assertion{
+		NameID:       assertion.Subject.NameID.Value,
+		NameIDFormat: assertion.Subject.NameID.Format,
+		Attributes:   map[string][]string{},
+	}
+
+	if len(assertion.AuthnStatements) > 0 {
+		result.SessionIndex = assertion.AuthnStatements[0].SessionIndex
+	}
+
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			values := make([]string, len(attribute.Values))
+			for i, value := range attribute.Values {
+				values[i] = strings.TrimSpace(value.Value)
+			}
+
+			// attributes are available by their name and, if not conflicting, by their friendly name.
+			name := attribute.Name
+			result.Attributes[name] = append(result.Attributes[name], values...)
+			if friendlyName := attribute.FriendlyName; friendlyName != "" && friendlyName != name {
+				if _, ok := result.Attributes[friendlyName]; !ok {
+					result.Attributes[friendlyName] = values
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// decodeBase64 decodes base64 encoded data that may contain line breaks.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
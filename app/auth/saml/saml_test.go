@@ -0,0 +1,407 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	crewjamsaml "github.com/crewjam/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	testIdPEntityID = "https://idp.example.com"
+	testSPEntityID  = "https://gitness.example.com/api/v1/login/saml/corp/metadata"
+	testACSURL      = "https://gitness.example.com/api/v1/login/saml/corp/acs"
+	testRequestID   = "_4fee3b046395c4e751011e97f8900b5273d56685"
+	sigMarker       = "<!--signature-->"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParseMetadata(t *testing.T) {
+	_, certificate := newTestCertificate(t)
+
+	idp, err := ParseMetadata([]byte(testMetadata(certificate)))
+	if err != nil {
+		t.Fatalf("failed to parse metadata: %s", err)
+	}
+
+	if idp.EntityID != testIdPEntityID {
+		t.Errorf("unexpected entity id %q", idp.EntityID)
+	}
+	if idp.SSOURL != "https://idp.example.com/sso/redirect" {
+		t.Errorf("unexpected sso url %q", idp.SSOURL)
+	}
+	if len(idp.Certificates) != 1 || !idp.Certificates[0].Equal(certificate) {
+		t.Errorf("unexpected certificates %v", idp.Certificates)
+	}
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	sp := newTestServiceProvider(t, nil)
+
+	redirect, err := sp.AuthnRequestURL(testRequestID, "state", testNow)
+	if err != nil {
+		t.Fatalf("failed to create authentication request: %s", err)
+	}
+
+	u, err := url.Parse(redirect)
+	if err != nil {
+		t.Fatalf("failed to parse redirect url: %s", err)
+	}
+	if u.Query().Get("RelayState") != "state" {
+		t.Errorf("unexpected relay state %q", u.Query().Get("RelayState"))
+	}
+
+	deflated, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+	if err != nil {
+		t.Fatalf("failed to decode request: %s", err)
+	}
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		t.Fatalf("failed to inflate request: %s", err)
+	}
+
+	var request authnRequest
+	if err = xml.Unmarshal(data, &request); err != nil {
+		t.Fatalf("failed to parse request: %s", err)
+	}
+	if request.ID != testRequestID || request.AssertionConsumerServiceURL != testACSURL {
+		t.Errorf("unexpected request %+v", request)
+	}
+	if request.Issuer.Value != testSPEntityID {
+		t.Errorf("request has an unexpected issuer %q", request.Issuer.Value)
+	}
+}
+
+//nolint:gocognit // table driven test.
+func TestParseResponse(t *testing.T) {
+	key, certificate := newTestCertificate(t)
+	otherKey, otherCertificate := newTestCertificate(t)
+
+	tests := []struct {
+		name      string
+		response  func() string
+		requestID string
+		now       time.Time
+		wantErr   bool
+	}{
+		{
+			name: "signed assertion",
+			response: func() string {
+				return signElement(t, testResponse(sigMarker, ""), "_assertion", key, certificate)
+			},
+		},
+		{
+			name: "signed response",
+			response: func() string {
+				return signElement(t, testResponse("", sigMarker), "_response", key, certificate)
+			},
+		},
+		{
+			name:     "unsigned",
+			response: func() string { return testResponse("", "") },
+			wantErr:  true,
+		},
+		{
+			name: "untrusted key",
+			response: func() string {
+				return signElement(t, testResponse(sigMarker, ""), "_assertion", otherKey, otherCertificate)
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered name id",
+			response: func() string {
+				signed := signElement(t, testResponse(sigMarker, ""), "_assertion", key, certificate)
+				return strings.Replace(signed, "jane@example.com</saml:NameID>", "admin@example.com</saml:NameID>", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "signature wrapping",
+			response: func() string {
+				signed := signElement(t, testResponse(sigMarker, ""), "_assertion", key, certificate)
+				// move the signed assertion into the extensions and inject an unsigned one.
+				start := strings.Index(signed, "<saml:Assertion")
+				end := strings.Index(signed, "</saml:Assertion>") + len("</saml:Assertion>")
+				original := signed[start:end]
+				injected := strings.Replace(strings.Replace(testResponse("", ""), "_assertion", "_evil", 1),
+					"jane@example.com", "admin@example.com", 1)
+				injected = injected[strings.Index(injected, "<saml:Assertion"):strings.Index(injected, "</saml:Assertion>")] +
+					"</saml:Assertion>"
+				signed = strings.Replace(signed, original, injected, 1)
+				return strings.Replace(signed, "<samlp:Status>",
+					"<samlp:Extensions>"+original+"</samlp:Extensions><samlp:Status>", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong request id",
+			response: func() string {
+				return signElement(t, testResponse(sigMarker, ""), "_assertion", key, certificate)
+			},
+			requestID: "_other",
+			wantErr:   true,
+		},
+		{
+			name: "expired",
+			response: func() string {
+				return signElement(t, testResponse(sigMarker, ""), "_assertion", key, certificate)
+			},
+			now:     testNow.Add(time.Hour),
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			response: func() string {
+				response := strings.Replace(testResponse(sigMarker, ""),
+					"<saml:Audience>"+testSPEntityID, "<saml:Audience>https://other.example.com", 1)
+				return signElement(t, response, "_assertion", key, certificate)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := newTestServiceProvider(t, certificate)
+
+			requestID := tt.requestID
+			if requestID == "" {
+				requestID = testRequestID
+			}
+			now := tt.now
+			if now.IsZero() {
+				now = testNow
+			}
+			setTestTime(t, now)
+
+			encoded := base64.StdEncoding.EncodeToString([]byte(tt.response()))
+			assertion, err := sp.ParseResponse(encoded, requestID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected the response to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if assertion.NameID != "jane@example.com" {
+				t.Errorf("unexpected name id %q", assertion.NameID)
+			}
+			if assertion.Attribute("email") != "jane@example.com" {
+				t.Errorf("unexpected email attribute %q", assertion.Attribute("email"))
+			}
+			if assertion.Attribute("displayName") != "Jane Doe" {
+				t.Errorf("unexpected display name attribute %q", assertion.Attribute("displayName"))
+			}
+			if groups := assertion.Attributes["groups"]; len(groups) != 2 || groups[0] != "dev" || groups[1] != "ops" {
+				t.Errorf("unexpected groups %v", groups)
+			}
+		})
+	}
+}
+
+// setTestTime sets the time used by crewjam/saml and goxmldsig for the duration of the test.
+func setTestTime(t *testing.T, now time.Time) {
+	t.Helper()
+
+	timeNow, clock := crewjamsaml.TimeNow, crewjamsaml.Clock
+	t.Cleanup(func() {
+		crewjamsaml.TimeNow, crewjamsaml.Clock = timeNow, clock
+	})
+
+	crewjamsaml.TimeNow = func() time.Time { return now }
+	crewjamsaml.Clock = dsig.NewFakeClockAt(now)
+}
+
+func newTestServiceProvider(t *testing.T, certificate *x509.Certificate) *ServiceProvider {
+	t.Helper()
+
+	if certificate == nil {
+		_, certificate = newTestCertificate(t)
+	}
+
+	return &ServiceProvider{
+		EntityID: testSPEntityID,
+		ACSURL:   testACSURL,
+		IdP: &IdentityProvider{
+			EntityID:     testIdPEntityID,
+			SSOURL:       "https://idp.example.com/sso/redirect",
+			Certificates: []*x509.Certificate{certificate},
+		},
+	}
+}
+
+func newTestCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     testNow.Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %s", err)
+	}
+
+	return key, certificate
+}
+
+func testMetadata(certificate *x509.Certificate) string {
+	return `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="` + testIdPEntityID + `">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data>
+          <ds:X509Certificate>` + base64.StdEncoding.EncodeToString(certificate.Raw) + `</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+      Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+      Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+}
+
+// testResponse returns a SAML response, with the signature markers placed where the
+// signatures of the assertion and the response are inserted.
+func testResponse(assertionSig, responseSig string) string {
+	issueInstant := testNow.Add(-time.Minute).Format(time.RFC3339)
+	notOnOrAfter := testNow.Add(5 * time.Minute).Format(time.RFC3339)
+
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ` +
+		`xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_response" Version="2.0" ` +
+		`IssueInstant="` + issueInstant + `" Destination="` + testACSURL + `" InResponseTo="` + testRequestID + `">
+  <saml:Issuer>` + testIdPEntityID + `</saml:Issuer>` + responseSig + `
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  <saml:Assertion ID="_assertion" Version="2.0" IssueInstant="` + issueInstant + `">
+    <saml:Issuer>` + testIdPEntityID + `</saml:Issuer>` + assertionSig + `
+    <saml:Subject>
+      <saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress">jane@example.com</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData InResponseTo="` + testRequestID + `" ` +
+		`Recipient="` + testACSURL + `" NotOnOrAfter="` + notOnOrAfter + `"/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="` + issueInstant + `" NotOnOrAfter="` + notOnOrAfter + `">
+      <saml:AudienceRestriction><saml:Audience>` + testSPEntityID + `</saml:Audience></saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AuthnStatement AuthnInstant="` + issueInstant + `" SessionIndex="_session"/>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.3" FriendlyName="email">
+        <saml:AttributeValue>jane@example.com</saml:AttributeValue>
+      </saml:Attribute>
+      <saml:Attribute Name="displayName"><saml:AttributeValue>Jane Doe</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="groups">
+        <saml:AttributeValue>dev</saml:AttributeValue>
+        <saml:AttributeValue>ops</saml:AttributeValue>
+      </saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>
+</samlp:Response>`
+}
+
+// signElement replaces the signature marker of the document with an enveloped signature
+// of the element with the provided ID.
+func signElement(t *testing.T, doc, id string, key *rsa.PrivateKey, certificate *x509.Certificate) string {
+	t.Helper()
+
+	// the signature is computed over the element without the signature, as it's done by the verifier.
+	unsigned := etree.NewDocument()
+	if err := unsigned.ReadFromString(strings.Replace(doc, sigMarker, "", 1)); err != nil {
+		t.Fatalf("failed to parse document: %s", err)
+	}
+
+	var el *etree.Element
+	for _, candidate := range unsigned.FindElements("//*") {
+		if candidate.SelectAttrValue("ID", "") == id {
+			el = candidate
+		}
+	}
+	if el == nil {
+		t.Fatalf("element %q not found", id)
+	}
+
+	// the namespaces declared by the ancestors are copied into the signed element.
+	nsCtx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		t.Fatalf("failed to build namespace context: %s", err)
+	}
+	nsCtx, err = nsCtx.SubContext(el)
+	if err != nil {
+		t.Fatalf("failed to build namespace context: %s", err)
+	}
+	el, err = etreeutils.NSDetatch(nsCtx, el)
+	if err != nil {
+		t.Fatalf("failed to detach element: %s", err)
+	}
+
+	signingCtx, err := dsig.NewSigningContext(key, [][]byte{certificate.Raw})
+	if err != nil {
+		t.Fatalf("failed to create signing context: %s", err)
+	}
+	signingCtx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+
+	signature, err := signingCtx.ConstructSignature(el, true)
+	if err != nil {
+		t.Fatalf("failed to sign: %s", err)
+	}
+
+	signatureDoc := etree.NewDocument()
+	signatureDoc.SetRoot(signature)
+	signatureXML, err := signatureDoc.WriteToString()
+	if err != nil {
+		t.Fatalf("failed to serialize signature: %s", err)
+	}
+
+	return strings.Replace(doc, sigMarker, signatureXML, 1)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the base32 encoding of the SHA1 test key "12345678901234567890" from RFC 6238, appendix B.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// The RFC lists 8 digit codes, the expected values are their last 6 digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, test := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(test.unix, 0)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != test.want {
+			t.Errorf("time %d: want=%s got=%s", test.unix, test.want, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name     string
+		step     int64
+		wantOK   bool
+		wantStep int64
+	}{
+		{name: "current", step: current, wantOK: true, wantStep: current},
+		{name: "previous", step: current - 1, wantOK: true, wantStep: current - 1},
+		{name: "next", step: current + 1, wantOK: true, wantStep: current + 1},
+		{name: "too-old", step: current - 2, wantOK: false},
+		{name: "too-new", step: current + 2, wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			passcode, err := Code(rfcSecret, test.step)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			step, ok, err := Validate(rfcSecret, passcode, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ok != test.wantOK || step != test.wantStep {
+				t.Errorf("want=(%d, %t) got=(%d, %t)", test.wantStep, test.wantOK, step, ok)
+			}
+		})
+	}
+
+	if _, ok, _ := Validate(rfcSecret, "12345", now); ok {
+		t.Errorf("code with wrong length must not be accepted")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(secret, "=") {
+		t.Errorf("secret must not be padded: %s", secret)
+	}
+
+	if _, err = Code(secret, 1); err != nil {
+		t.Errorf("generated secret can't be used: %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("Gitness", "admin@example.com", rfcSecret)
+	want := "otpauth://totp/Gitness:admin@example.com" +
+		"?algorithm=SHA1&digits=6&issuer=Gitness&period=30&secret=" + rfcSecret
+
+	if got != want {
+		t.Errorf("want=%s got=%s", want, got)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webauthn implements the relying party side of the Web Authentication API (WebAuthn Level 2),
+// which is the ceremony verification required to register and authenticate with passkeys and security keys.
+// The ceremonies are verified with go-webauthn, this package provides the JSON serialization of the options
+// and responses that is exchanged with the UI.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+// ErrVerificationFailed is returned (wrapped) if a registration or an assertion can't be verified.
+var ErrVerificationFailed = errors.New("webauthn verification failed")
+
+const (
+	challengeSize = 32
+
+	credentialTypePublicKey = "public-key"
+)
+
+// SupportedAlgorithms are the public key algorithms accepted for credentials, in order of preference.
+var SupportedAlgorithms = []int64{
+	int64(webauthncose.AlgES256),
+	int64(webauthncose.AlgEdDSA),
+	int64(webauthncose.AlgRS256),
+}
+
+// Config defines the relying party.
+type Config struct {
+	// RPID is the relying party identifier, which is the (effective) domain of the UI.
+	RPID string
+	// RPName is the name of the relying party shown by authenticators.
+	RPName string
+	// Origins are the origins the ceremonies are accepted from.
+	Origins []string
+	// Timeout is the time users have to complete a ceremony.
+	Timeout time.Duration
+}
+
+// Base64URL is a byte slice that is represented as unpadded base64url string in JSON,
+// which is the encoding used by the WebAuthn JSON serialization of binary values.
+type Base64URL []byte
+
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return fmt.Errorf("invalid base64url value: %w", err)
+	}
+
+	*b = decoded
+	return nil
+}
+
+// The options and response types follow the JSON serialization of the WebAuthn Level 3 spec,
+// hence they can be used with PublicKeyCredential.parseCreationOptionsFromJSON
+// and PublicKeyCredential.toJSON directly.
+
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type       string    `json:"type"`
+	ID         Base64URL `json:"id"`
+	Transports []string  `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are the options of a registration ceremony (PublicKeyCredentialCreationOptions).
+type CreationOptions struct {
+	Challenge              Base64URL              `json:"challenge"`
+	RP                     RelyingParty           `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the options of an authentication ceremony (PublicKeyCredentialRequestOptions).
+// AllowCredentials is empty for discoverable credentials (passwordless login without a username).
+type RequestOptions struct {
+	Challenge        Base64URL              `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// RegistrationResponse is a newly created credential (PublicKeyCredential with an AuthenticatorAttestationResponse).
+type RegistrationResponse struct {
+	ID       string    `json:"id"`
+	RawID    Base64URL `json:"rawId"`
+	Type     string    `json:"type"`
+	Response struct {
+		ClientDataJSON    Base64URL `json:"clientDataJSON"`
+		AttestationObject Base64URL `json:"attestationObject"`
+		Transports        []string  `json:"transports"`
+	} `json:"response"`
+}
+
+// AssertionResponse is a signed challenge (PublicKeyCredential with an AuthenticatorAssertionResponse).
+type AssertionResponse struct {
+	ID       string    `json:"id"`
+	RawID    Base64URL `json:"rawId"`
+	Type     string    `json:"type"`
+	Response struct {
+		ClientDataJSON    Base64URL `json:"clientDataJSON"`
+		AuthenticatorData Base64URL `json:"authenticatorData"`
+		Signature         Base64URL `json:"signature"`
+		UserHandle        Base64URL `json:"userHandle"`
+	} `json:"response"`
+}
+
+// Credential is a verified newly registered credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey    []byte
+	SignCount    uint32
+	AAGUID       []byte
+	UserVerified bool
+}
+
+// Assertion is the result of a verified authentication ceremony.
+type Assertion struct {
+	SignCount    uint32
+	UserVerified bool
+}
+
+// NewChallenge returns a new random challenge.
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// CreationOptions returns the options of a registration ceremony.
+func (c Config) CreationOptions(
+	challenge []byte,
+	user UserEntity,
+	exclude []CredentialDescriptor,
+) *CreationOptions {
+	params := make([]CredentialParameter, len(SupportedAlgorithms))
+	for i, alg := range SupportedAlgorithms {
+		params[i] = CredentialParameter{Type: credentialTypePublicKey, Alg: alg}
+	}
+
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+
+	return &CreationOptions{
+		Challenge:          challenge,
+		RP:                 RelyingParty{ID: c.RPID, Name: c.RPName},
+		User:               user,
+		PubKeyCredParams:   params,
+		Timeout:            c.Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options of an authentication ceremony.
+// User verification is required for passwordless logins, where the credential is the only factor.
+func (c Config) RequestOptions(
+	challenge []byte,
+	allow []CredentialDescriptor,
+	requireUserVerification bool,
+) *RequestOptions {
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+
+	userVerification := "preferred"
+	if requireUserVerification {
+		userVerification = "required"
+	}
+
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          c.Timeout.Milliseconds(),
+		RPID:             c.RPID,
+		AllowCredentials: allow,
+		UserVerification: userVerification,
+	}
+}
+
+// VerifyRegistration verifies the response of a registration ceremony and returns the new credential.
+// Attestation statements are verified if the authenticator provided one, but they aren't required.
+func (c Config) VerifyRegistration(challenge []byte, resp *RegistrationResponse) (*Credential, error) {
+	if resp.Type != credentialTypePublicKey {
+		return nil, verificationErrorf("unsupported credential type %q", resp.Type)
+	}
+
+	rp, err := c.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal registration response: %w", err)
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(data))
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	// the user handle isn't part of the registration response, the user of the session is checked by the caller.
+	user := &credentialUser{}
+
+	credential, err := rp.CreateCredential(user, sessionData(challenge, user, false), parsed)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	if err = checkAlgorithm(credential.PublicKey); err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:           slices.Clone(credential.ID),
+		PublicKey:    slices.Clone(credential.PublicKey),
+		SignCount:    credential.Authenticator.SignCount,
+		AAGUID:       slices.Clone(credential.Authenticator.AAGUID),
+		UserVerified: credential.Flags.UserVerified,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of an authentication ceremony against the stored credential.
+// A signature counter that didn't increase indicates a cloned authenticator and fails the verification,
+// unless the authenticator doesn't implement the counter (it's always zero).
+func (c Config) VerifyAssertion(
+	challenge []byte,
+	resp *AssertionResponse,
+	publicKey []byte,
+	storedSignCount uint32,
+	requireUserVerification bool,
+) (*Assertion, error) {
+	if resp.Type != credentialTypePublicKey {
+		return nil, verificationErrorf("unsupported credential type %q", resp.Type)
+	}
+
+	rp, err := c.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assertion response: %w", err)
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(data))
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	// the credential is looked up by the caller, which also checks that the user handle belongs to its owner.
+	// The backup flags aren't stored, so they are taken from the assertion.
+	flags := parsed.Response.AuthenticatorData.Flags
+	user := &credentialUser{
+		id: resp.Response.UserHandle,
+		credentials: []gowebauthn.Credential{{
+			ID:        resp.RawID,
+			PublicKey: publicKey,
+			Flags: gowebauthn.CredentialFlags{
+				BackupEligible: flags.HasBackupEligible(),
+				BackupState:    flags.HasBackupState(),
+			},
+			Authenticator: gowebauthn.Authenticator{SignCount: storedSignCount},
+		}},
+	}
+
+	credential, err := rp.ValidateLogin(user, sessionData(challenge, user, requireUserVerification), parsed)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	if credential.Authenticator.CloneWarning {
+		return nil, verificationErrorf("signature counter didn't increase, the authenticator might be cloned")
+	}
+
+	return &Assertion{
+		SignCount:    credential.Authenticator.SignCount,
+		UserVerified: flags.UserVerified(),
+	}, nil
+}
+
+// relyingParty returns the go-webauthn relying party of the configuration.
+func (c Config) relyingParty() (*gowebauthn.WebAuthn, error) {
+	rp, err := gowebauthn.New(&gowebauthn.Config{
+		RPID:          c.RPID,
+		RPDisplayName: c.RPName,
+		RPOrigins:     c.Origins,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid webauthn configuration: %w", err)
+	}
+
+	return rp, nil
+}
+
+// credentialUser is the user of a single ceremony, as required by go-webauthn.
+type credentialUser struct {
+	id          []byte
+	credentials []gowebauthn.Credential
+}
+
+func (u *credentialUser) WebAuthnID() []byte                           { return u.id }
+func (u *credentialUser) WebAuthnName() string                         { return "" }
+func (u *credentialUser) WebAuthnDisplayName() string                  { return "" }
+func (u *credentialUser) WebAuthnCredentials() []gowebauthn.Credential { return u.credentials }
+
+// sessionData returns the go-webauthn session of a ceremony with the challenge.
+func sessionData(challenge []byte, user *credentialUser, requireUserVerification bool) gowebauthn.SessionData {
+	userVerification := protocol.VerificationPreferred
+	if requireUserVerification {
+		userVerification = protocol.VerificationRequired
+	}
+
+	return gowebauthn.SessionData{
+		Challenge:        base64.RawURLEncoding.EncodeToString(challenge),
+		UserID:           user.id,
+		UserVerification: userVerification,
+	}
+}
+
+// checkAlgorithm ensures the COSE encoded public key uses one of the supported algorithms.
+func checkAlgorithm(publicKey []byte) error {
+	key := webauthncose.PublicKeyData{}
+	if err := webauthncbor.Unmarshal(publicKey, &key); err != nil {
+		return verificationErrorf("invalid credential public key: %s", err)
+	}
+
+	if !slices.Contains(SupportedAlgorithms, key.Algorithm) {
+		return verificationErrorf("unsupported public key algorithm %d", key.Algorithm)
+	}
+
+	return nil
+}
+
+// verificationError wraps the error of go-webauthn, including the details of protocol errors.
+func verificationError(err error) error {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.DevInfo != "" {
+		return verificationErrorf("%s: %s", protocolErr.Details, protocolErr.DevInfo)
+	}
+
+	return verificationErrorf("%s", err)
+}
+
+func verificationErrorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrVerificationFailed, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+var testConfig = Config{
+	RPID:    "gitness.example.com",
+	RPName:  "Gitness",
+	Origins: []string{"https://gitness.example.com"},
+	Timeout: time.Minute,
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cose   []byte
+		signer crypto.Signer
+		hashed bool
+	}{
+		{name: "es256", cose: coseEC2(t, &ecKey.PublicKey), signer: ecKey, hashed: true},
+		{name: "eddsa", cose: coseOKP(t, edPublic), signer: edPrivate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			credentialID := []byte("credential-" + test.name)
+
+			challenge, err := NewChallenge()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reg := registrationResponse(t, credentialID, test.cose, challenge, testConfig.Origins[0])
+
+			credential, err := testConfig.VerifyRegistration(challenge, reg)
+			if err != nil {
+				t.Fatalf("failed to verify registration: %v", err)
+			}
+
+			if string(credential.ID) != string(credentialID) || string(credential.PublicKey) != string(test.cose) {
+				t.Fatalf("unexpected credential: %+v", credential)
+			}
+
+			challenge, _ = NewChallenge()
+			sign := func(resp *AssertionResponse) {
+				resp.Response.Signature = signAssertion(t, test.signer, test.hashed, resp)
+			}
+
+			resp := assertionResponse(credentialID, challenge, testConfig.Origins[0], 5, true)
+			sign(resp)
+
+			assertion, err := testConfig.VerifyAssertion(challenge, resp, credential.PublicKey, 4, true)
+			if err != nil {
+				t.Fatalf("failed to verify assertion: %v", err)
+			}
+
+			if assertion.SignCount != 5 || !assertion.UserVerified {
+				t.Errorf("unexpected assertion: %+v", assertion)
+			}
+
+			// replayed assertion with the same counter
+			if _, err = testConfig.VerifyAssertion(challenge, resp, credential.PublicKey, 5, true); err == nil {
+				t.Errorf("replayed assertion must be rejected")
+			}
+
+			// assertion for a different challenge
+			other, _ := NewChallenge()
+			if _, err = testConfig.VerifyAssertion(other, resp, credential.PublicKey, 4, true); err == nil {
+				t.Errorf("assertion for another challenge must be rejected")
+			}
+
+			// assertion from a foreign origin
+			resp = assertionResponse(credentialID, challenge, "https://evil.example.com", 6, true)
+			sign(resp)
+			if _, err = testConfig.VerifyAssertion(challenge, resp, credential.PublicKey, 5, true); err == nil {
+				t.Errorf("assertion from a foreign origin must be rejected")
+			}
+
+			// assertion without user verification
+			resp = assertionResponse(credentialID, challenge, testConfig.Origins[0], 6, false)
+			sign(resp)
+			if _, err = testConfig.VerifyAssertion(challenge, resp, credential.PublicKey, 5, true); err == nil {
+				t.Errorf("assertion without user verification must be rejected")
+			}
+			if _, err = testConfig.VerifyAssertion(challenge, resp, credential.PublicKey, 5, false); err != nil {
+				t.Errorf("assertion without required user verification must be accepted: %v", err)
+			}
+
+			// tampered signature
+			resp.Response.Signature[len(resp.Response.Signature)-1] ^= 0xff
+			_, err = testConfig.VerifyAssertion(challenge, resp, credential.PublicKey, 5, false)
+			if !errors.Is(err, ErrVerificationFailed) {
+				t.Errorf("tampered signature must fail the verification, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestBase64URL(t *testing.T) {
+	raw, err := json.Marshal(Base64URL{0xfb, 0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(raw) != `"-_8"` {
+		t.Errorf("unexpected encoding: %s", raw)
+	}
+
+	var b Base64URL
+	if err = json.Unmarshal([]byte(`"-_8="`), &b); err != nil || string(b) != string([]byte{0xfb, 0xff}) {
+		t.Errorf("failed to decode padded value: %v %x", err, b)
+	}
+}
+
+func registrationResponse(
+	t *testing.T,
+	credentialID []byte,
+	cose []byte,
+	challenge []byte,
+	origin string,
+) *RegistrationResponse {
+	flags := protocol.FlagUserPresent | protocol.FlagUserVerified | protocol.FlagAttestedCredentialData
+
+	authData := authenticatorData(flags, 0)
+	authData = append(authData, make([]byte, 16)...) // aaguid
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(credentialID)))
+	authData = append(authData, credentialID...)
+	authData = append(authData, cose...)
+
+	resp := &RegistrationResponse{
+		ID:    base64.RawURLEncoding.EncodeToString(credentialID),
+		RawID: credentialID,
+		Type:  credentialTypePublicKey,
+	}
+	resp.Response.ClientDataJSON = clientDataJSON(protocol.CreateCeremony, challenge, origin)
+	resp.Response.AttestationObject = marshalCBOR(t, map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+
+	return resp
+}
+
+func assertionResponse(
+	credentialID []byte,
+	challenge []byte,
+	origin string,
+	signCount uint32,
+	userVerified bool,
+) *AssertionResponse {
+	flags := protocol.FlagUserPresent
+	if userVerified {
+		flags |= protocol.FlagUserVerified
+	}
+
+	resp := &AssertionResponse{
+		ID:    base64.RawURLEncoding.EncodeToString(credentialID),
+		RawID: credentialID,
+		Type:  credentialTypePublicKey,
+	}
+	resp.Response.ClientDataJSON = clientDataJSON(protocol.AssertCeremony, challenge, origin)
+	resp.Response.AuthenticatorData = authenticatorData(flags, signCount)
+
+	return resp
+}
+
+func signAssertion(t *testing.T, signer crypto.Signer, hashed bool, resp *AssertionResponse) []byte {
+	clientDataHash := sha256.Sum256(resp.Response.ClientDataJSON)
+	message := append(append([]byte{}, resp.Response.AuthenticatorData...), clientDataHash[:]...)
+
+	opts := crypto.Hash(0)
+	if hashed {
+		digest := sha256.Sum256(message)
+		message = digest[:]
+		opts = crypto.SHA256
+	}
+
+	signature, err := signer.Sign(rand.Reader, message, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signature
+}
+
+func authenticatorData(flags protocol.AuthenticatorFlags, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(testConfig.RPID))
+	data := append(rpIDHash[:], byte(flags))
+	return binary.BigEndian.AppendUint32(data, signCount)
+}
+
+func clientDataJSON(typ protocol.CeremonyType, challenge []byte, origin string) []byte {
+	// marshaling a struct of strings can't fail.
+	raw, _ := json.Marshal(protocol.CollectedClientData{
+		Type:      typ,
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		Origin:    origin,
+	})
+
+	return raw
+}
+
+func coseEC2(t *testing.T, key *ecdsa.PublicKey) []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	return marshalCBOR(t, webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: x,
+		YCoord: y,
+	})
+}
+
+func coseOKP(t *testing.T, key ed25519.PublicKey) []byte {
+	return marshalCBOR(t, webauthncose.OKPPublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.OctetKey),
+			Algorithm: int64(webauthncose.AlgEdDSA),
+		},
+		Curve:  int64(webauthncose.Ed25519),
+		XCoord: []byte(key),
+	})
+}
+
+func marshalCBOR(t *testing.T, v any) []byte {
+	t.Helper()
+
+	data, err := webauthncbor.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
@@ -51,3 +51,32 @@ func (r *Reader) RegisterBranchUpdated(fn events.HandlerFunc[*BranchUpdatedPaylo
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, BranchUpdatedEvent, fn, opts...)
 }
+
+const TargetBranchUpdatedEvent events.EventType = "target-branch-updated"
+
+// TargetBranchUpdatedPayload is the payload of the event triggered when the target branch of a pull request moves.
+type TargetBranchUpdatedPayload struct {
+	Base
+	SourceSHA string `json:"source_sha"`
+	OldSHA    string `json:"old_sha"`
+	NewSHA    string `json:"new_sha"`
+}
+
+func (r *Reporter) TargetBranchUpdated(ctx context.Context, payload *TargetBranchUpdatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, TargetBranchUpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request target branch updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request target branch updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterTargetBranchUpdated(fn events.HandlerFunc[*TargetBranchUpdatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, TargetBranchUpdatedEvent, fn, opts...)
+}
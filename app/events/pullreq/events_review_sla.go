@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const ReviewSLAWarningEvent events.EventType = "review-sla-warning"
+
+type ReviewSLAWarningPayload struct {
+	Base
+	DueAt int64 `json:"due_at"`
+}
+
+func (r *Reporter) ReviewSLAWarning(
+	ctx context.Context,
+	payload *ReviewSLAWarningPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewSLAWarningEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request review SLA warning event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request review SLA warning event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewSLAWarning(
+	fn events.HandlerFunc[*ReviewSLAWarningPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewSLAWarningEvent, fn, opts...)
+}
+
+const ReviewSLABreachedEvent events.EventType = "review-sla-breached"
+
+type ReviewSLABreachedPayload struct {
+	Base
+	DueAt int64 `json:"due_at"`
+}
+
+func (r *Reporter) ReviewSLABreached(
+	ctx context.Context,
+	payload *ReviewSLABreachedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewSLABreachedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request review SLA breached event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request review SLA breached event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewSLABreached(
+	fn events.HandlerFunc[*ReviewSLABreachedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewSLABreachedEvent, fn, opts...)
+}
@@ -24,6 +24,7 @@ import (
 
 const (
 	ReviewerAddedEvent     events.EventType = "reviewer-added"
+	ReviewerRemovedEvent   events.EventType = "reviewer-removed"
 	UserGroupReviewerAdded events.EventType = "usergroup-reviewer-added"
 )
 
@@ -32,6 +33,11 @@ type ReviewerAddedPayload struct {
 	ReviewerID int64 `json:"reviewer_id"`
 }
 
+type ReviewerRemovedPayload struct {
+	Base
+	ReviewerID int64 `json:"reviewer_id"`
+}
+
 type UserGroupReviewerAddedPayload struct {
 	Base
 	UserGroupReviewerID int64 `json:"usergroup_reviewer_id"`
@@ -61,6 +67,30 @@ func (r *Reader) RegisterReviewerAdded(
 	return events.ReaderRegisterEvent(r.innerReader, ReviewerAddedEvent, fn, opts...)
 }
 
+func (r *Reporter) ReviewerRemoved(
+	ctx context.Context,
+	payload *ReviewerRemovedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewerRemovedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request reviewer removed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request reviewer removed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewerRemoved(
+	fn events.HandlerFunc[*ReviewerRemovedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewerRemovedEvent, fn, opts...)
+}
+
 func (r *Reporter) UserGroupReviewerAdded(
 	ctx context.Context,
 	payload *UserGroupReviewerAddedPayload,
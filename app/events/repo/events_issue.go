@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// IssueBase is the common part of the payloads of all issue events.
+type IssueBase struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+	IssueID     int64 `json:"issue_id"`
+	IssueNumber int64 `json:"issue_number"`
+}
+
+const IssueCreatedEvent events.EventType = "issue-created"
+
+type IssueCreatedPayload struct {
+	IssueBase
+}
+
+func (r *Reporter) IssueCreated(ctx context.Context, payload *IssueCreatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, IssueCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send issue created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported issue created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterIssueCreated(fn events.HandlerFunc[*IssueCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, IssueCreatedEvent, fn, opts...)
+}
+
+const IssueStateChangedEvent events.EventType = "issue-state-changed"
+
+type IssueStateChangedPayload struct {
+	IssueBase
+	OldState enum.IssueState `json:"old_state"`
+	NewState enum.IssueState `json:"new_state"`
+	// ClosedByPullReq is set in case the issue got closed by merging the pull request with this number.
+	ClosedByPullReq int64 `json:"closed_by_pullreq,omitempty"`
+}
+
+func (r *Reporter) IssueStateChanged(ctx context.Context, payload *IssueStateChangedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, IssueStateChangedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send issue state changed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported issue state changed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterIssueStateChanged(fn events.HandlerFunc[*IssueStateChangedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, IssueStateChangedEvent, fn, opts...)
+}
+
+const IssueCommentCreatedEvent events.EventType = "issue-comment-created"
+
+type IssueCommentCreatedPayload struct {
+	IssueBase
+	CommentID int64 `json:"comment_id"`
+}
+
+func (r *Reporter) IssueCommentCreated(ctx context.Context, payload *IssueCommentCreatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, IssueCommentCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send issue comment created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported issue comment created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterIssueCommentCreated(fn events.HandlerFunc[*IssueCommentCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, IssueCommentCreatedEvent, fn, opts...)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
 
 	"github.com/rs/zerolog/log"
 )
@@ -69,3 +70,36 @@ func (r *Reader) RegisterDefaultBranchUpdated(fn events.HandlerFunc[*DefaultBran
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, DefaultBranchUpdatedEvent, fn, opts...)
 }
+
+const RuleViolatedEvent events.EventType = "rule-violated"
+
+// RuleViolatedPayload describes a push or pull request merge that violated protection rules of the repo.
+type RuleViolatedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+	// PullReqNumber is set in case the violation occurred while merging a pull request.
+	PullReqNumber int64 `json:"pull_req_number,omitempty"`
+	// Branches are the branches that were subject to the violated rules.
+	Branches []string `json:"branches"`
+	// Blocked is true in case the operation got rejected, false if all violations were bypassed.
+	Blocked    bool                   `json:"blocked"`
+	Violations []types.RuleViolations `json:"violations"`
+}
+
+func (r *Reporter) RuleViolated(ctx context.Context, payload *RuleViolatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RuleViolatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send rule violated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported rule violated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRuleViolated(fn events.HandlerFunc[*RuleViolatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, RuleViolatedEvent, fn, opts...)
+}
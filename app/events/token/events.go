@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const ExpiringEvent events.EventType = "expiring"
+
+// ExpiringPayload describes an access token that is about to expire.
+type ExpiringPayload struct {
+	TokenID     int64          `json:"token_id"`
+	PrincipalID int64          `json:"principal_id"`
+	Type        enum.TokenType `json:"type"`
+	Identifier  string         `json:"identifier"`
+	CreatedBy   int64          `json:"created_by"`
+	ExpiresAt   int64          `json:"expires_at"`
+}
+
+func (r *Reporter) Expiring(ctx context.Context, payload *ExpiringPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ExpiringEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send token expiring event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported token expiring event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterExpiring(fn events.HandlerFunc[*ExpiringPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ExpiringEvent, fn, opts...)
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/pipeline/converter/yamlutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Restore is the mode of steps restoring the cache.
+	Restore = "restore"
+	// Save is the mode of steps saving the cache.
+	Save = "save"
+)
+
+const (
+	keyKind        = "kind"
+	keySteps       = "steps"
+	keyCache       = "cache"
+	keyImage       = "image"
+	keyCommands    = "commands"
+	keyEnvironment = "environment"
+
+	kindPipeline = "pipeline"
+
+	envURL   = "GITNESS_CACHE_URL"
+	envKey   = "GITNESS_CACHE_KEY"
+	envFiles = "GITNESS_CACHE_FILES"
+	envPaths = "GITNESS_CACHE_PATHS"
+)
+
+// scriptPrefix computes the final key of the cache entry, which is suffixed with the checksum of the files.
+const scriptPrefix = `set -e
+KEY="$GITNESS_CACHE_KEY"
+if [ -n "$GITNESS_CACHE_FILES" ]; then KEY="$KEY-$(cat $GITNESS_CACHE_FILES | sha256sum | cut -c1-16)"; fi
+ARCHIVE="$(mktemp)"
+`
+
+const restoreScript = scriptPrefix + `if curl -fsS -u "$DRONE_NETRC_USERNAME:$DRONE_NETRC_PASSWORD" \
+  -o "$ARCHIVE" "$GITNESS_CACHE_URL/$KEY"; then
+  tar -xzf "$ARCHIVE"
+  echo "restored cache $KEY"
+else
+  echo "cache $KEY not found"
+fi
+rm -f "$ARCHIVE"
+`
+
+const saveScript = scriptPrefix + `tar -czf "$ARCHIVE" $GITNESS_CACHE_PATHS
+curl -fsS -u "$DRONE_NETRC_USERNAME:$DRONE_NETRC_PASSWORD" \
+  -X PUT --data-binary @"$ARCHIVE" "$GITNESS_CACHE_URL/$KEY" > /dev/null
+rm -f "$ARCHIVE"
+echo "saved cache $KEY"
+`
+
+var (
+	cacheFinder = regexp.MustCompile(`(?m)^\s*cache:`)
+	keyRegex    = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+)
+
+// settings is the cache section of a step.
+type settings struct {
+	Mode  string   `yaml:"mode"`
+	Key   string   `yaml:"key"`
+	Files []string `yaml:"files"`
+	Paths []string `yaml:"paths"`
+}
+
+// Expand converts every step with a cache section into a step restoring or saving the cache
+// using the pipeline cache api of the repository at url, e.g.
+//
+//	steps:
+//	  - name: restore
+//	    cache:
+//	      mode: restore
+//	      key: go-modules
+//	      files: [go.sum]
+//	      paths: [.go/pkg/mod]
+//
+// The key of the cache entry is suffixed with the checksum of the files, so the entry
+// is replaced whenever any of the lockfiles changes. The paths are relative to the workspace.
+// Steps without an image use the provided image, which has to provide sh, tar and curl.
+func Expand(data []byte, image string, url string) ([]byte, error) {
+	if !cacheFinder.Match(data) {
+		return data, nil
+	}
+
+	docs, err := yamlutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	for _, doc := range docs {
+		root := yamlutil.Mapping(doc)
+		if root == nil || yamlutil.Scalar(yamlutil.Value(root, keyKind)) != kindPipeline {
+			continue
+		}
+
+		steps := yamlutil.Value(root, keySteps)
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for _, step := range steps.Content {
+			if step.Kind != yaml.MappingNode || yamlutil.Value(step, keyCache) == nil {
+				continue
+			}
+			if err := expandStep(step, image, url); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out, err := yamlutil.Encode(docs)
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	return out, nil
+}
+
+func expandStep(step *yaml.Node, image string, url string) error {
+	var s settings
+	if err := yamlutil.Value(step, keyCache).Decode(&s); err != nil {
+		return fmt.Errorf("cache: failed to parse cache section: %w", err)
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	script := restoreScript
+	if s.Mode == Save {
+		script = saveScript
+	}
+
+	yamlutil.Remove(step, keyCache)
+	if yamlutil.Scalar(yamlutil.Value(step, keyImage)) == "" {
+		yamlutil.Set(step, keyImage, yamlutil.String(image))
+	}
+	commands := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{yamlutil.String(script)}}
+	yamlutil.Set(step, keyCommands, commands)
+
+	env := yamlutil.Value(step, keyEnvironment)
+	if env == nil || env.Kind != yaml.MappingNode {
+		env = &yaml.Node{Kind: yaml.MappingNode}
+		yamlutil.Set(step, keyEnvironment, env)
+	}
+	yamlutil.Set(env, envURL, yamlutil.String(url))
+	yamlutil.Set(env, envKey, yamlutil.String(s.Key))
+	yamlutil.Set(env, envFiles, yamlutil.String(strings.Join(s.Files, " ")))
+	yamlutil.Set(env, envPaths, yamlutil.String(strings.Join(s.Paths, " ")))
+
+	return nil
+}
+
+func (s *settings) validate() error {
+	if s.Mode != Restore && s.Mode != Save {
+		return fmt.Errorf("cache: mode must be %q or %q", Restore, Save)
+	}
+	if !keyRegex.MatchString(s.Key) {
+		return errors.New("cache: key must consist of letters, digits, '.', '_' and '-'")
+	}
+	if s.Mode == Save && len(s.Paths) == 0 {
+		return errors.New("cache: paths are required to save the cache")
+	}
+
+	// the cache is restored into and saved from the workspace, which is shared by all steps.
+	for _, p := range append(s.Files, s.Paths...) {
+		if p == "" || strings.ContainsAny(p, " \t\n") {
+			return fmt.Errorf("cache: invalid path %q", p)
+		}
+		if path.IsAbs(p) || strings.HasPrefix(path.Clean(p), "..") {
+			return fmt.Errorf("cache: path %q must be relative to the workspace", p)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const url = "http://gitness:3000/api/v1/repos/1/pipelines/caches"
+
+func TestExpand(t *testing.T) {
+	t.Run("no cache", func(t *testing.T) {
+		data := "kind: pipeline\nname: default\n"
+		out, err := Expand([]byte(data), "alpine/curl", url)
+		require.NoError(t, err)
+		require.Equal(t, data, string(out))
+	})
+
+	t.Run("cache steps", func(t *testing.T) {
+		data := `kind: pipeline
+name: default
+steps:
+  - name: restore
+    cache:
+      mode: restore
+      key: go-modules
+      files: [go.sum]
+      paths: [.go/pkg/mod]
+  - name: build
+    image: golang
+    commands:
+      - go build ./...
+  - name: save
+    image: custom/curl
+    environment:
+      FOO: bar
+    cache:
+      mode: save
+      key: go-modules
+      files: [go.sum, tools/go.sum]
+      paths: [.go/pkg/mod, .cache]
+`
+		out, err := Expand([]byte(data), "alpine/curl", url)
+		require.NoError(t, err)
+
+		var pipeline struct {
+			Steps []struct {
+				Name        string            `yaml:"name"`
+				Image       string            `yaml:"image"`
+				Commands    []string          `yaml:"commands"`
+				Environment map[string]string `yaml:"environment"`
+				Cache       any               `yaml:"cache"`
+			} `yaml:"steps"`
+		}
+		require.NoError(t, yaml.Unmarshal(out, &pipeline))
+		require.Len(t, pipeline.Steps, 3)
+
+		restore := pipeline.Steps[0]
+		require.Nil(t, restore.Cache)
+		require.Equal(t, "alpine/curl", restore.Image)
+		require.Equal(t, map[string]string{
+			envURL:   url,
+			envKey:   "go-modules",
+			envFiles: "go.sum",
+			envPaths: ".go/pkg/mod",
+		}, restore.Environment)
+		require.Equal(t, []string{restoreScript}, restore.Commands)
+
+		build := pipeline.Steps[1]
+		require.Equal(t, []string{"go build ./..."}, build.Commands)
+		require.Nil(t, build.Environment)
+
+		save := pipeline.Steps[2]
+		require.Nil(t, save.Cache)
+		require.Equal(t, "custom/curl", save.Image)
+		require.Equal(t, "bar", save.Environment["FOO"])
+		require.Equal(t, "go.sum tools/go.sum", save.Environment[envFiles])
+		require.Equal(t, ".go/pkg/mod .cache", save.Environment[envPaths])
+		require.Equal(t, []string{saveScript}, save.Commands)
+	})
+}
+
+func TestExpandInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache string
+		err   string
+	}{
+		{name: "mode", cache: "{mode: upload, key: k, paths: [a]}", err: "mode must be"},
+		{name: "key", cache: "{mode: restore, key: 'a/b', paths: [a]}", err: "key must consist"},
+		{name: "no paths", cache: "{mode: save, key: k}", err: "paths are required"},
+		{name: "absolute", cache: "{mode: save, key: k, paths: [/go/pkg]}", err: "relative to the workspace"},
+		{name: "outside", cache: "{mode: save, key: k, paths: [../x]}", err: "relative to the workspace"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := "kind: pipeline\nsteps:\n  - name: cache\n    cache: " + test.cache + "\n"
+			_, err := Expand([]byte(data), "alpine/curl", url)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
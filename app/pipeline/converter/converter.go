@@ -16,12 +16,24 @@ package converter
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/harness/gitness/app/pipeline/converter/cache"
+	"github.com/harness/gitness/app/pipeline/converter/extension"
 	"github.com/harness/gitness/app/pipeline/converter/jsonnet"
+	"github.com/harness/gitness/app/pipeline/converter/matrix"
+	"github.com/harness/gitness/app/pipeline/converter/resources"
 	"github.com/harness/gitness/app/pipeline/converter/starlark"
+	"github.com/harness/gitness/app/pipeline/converter/template"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -32,18 +44,163 @@ const (
 )
 
 type converter struct {
-	fileService  file.Service
-	publicAccess publicaccess.Service
+	fileService   file.Service
+	publicAccess  publicaccess.Service
+	spaceStore    store.SpaceStore
+	templateStore store.TemplateStore
+	git           git.Interface
+	// extension is the remote configuration extension, it's nil if no extension is configured.
+	extension   *extension.Client
+	urlProvider url.Provider
+	// cacheImage is the image of the cache steps, it's empty if the pipeline cache is disabled.
+	cacheImage string
+	// resources is the policy for the resources of the steps.
+	resources resources.Policy
 }
 
-func newConverter(fileService file.Service, publicAccess publicaccess.Service) Service {
+func newConverter(
+	fileService file.Service,
+	publicAccess publicaccess.Service,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	git git.Interface,
+	extension *extension.Client,
+	urlProvider url.Provider,
+	cacheImage string,
+	resources resources.Policy,
+) Service {
 	return &converter{
-		fileService:  fileService,
-		publicAccess: publicAccess,
+		fileService:   fileService,
+		publicAccess:  publicAccess,
+		spaceStore:    spaceStore,
+		templateStore: templateStore,
+		git:           git,
+		extension:     extension,
+		urlProvider:   urlProvider,
+		cacheImage:    cacheImage,
+		resources:     resources,
 	}
 }
 
 func (c *converter) Convert(ctx context.Context, args *ConvertArgs) (*file.File, error) {
+	f, err := c.convert(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// expand the pipeline templates the yaml refers to.
+	data, err := template.Expand(f.Data, templateParams(args), func(identifier string) (string, error) {
+		t, err := c.findTemplate(ctx, args.Repo.ParentID, identifier)
+		if err != nil {
+			return "", err
+		}
+		return t.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.extension != nil {
+		// the remote configuration extension has the final say over the pipeline yaml.
+		final, err := c.callExtension(ctx, args, data)
+		if err != nil {
+			return nil, err
+		}
+		if final != nil {
+			data = final
+		}
+	}
+
+	// expand the pipelines with a matrix into a pipeline per combination.
+	data, err = matrix.Expand(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cacheImage != "" {
+		// convert the cache steps into steps restoring and saving the build cache of the repository.
+		cacheURL := fmt.Sprintf("%s/v1/repos/%d/pipelines/caches",
+			c.urlProvider.GetContainerAPIURL(ctx), args.Repo.ID)
+		data, err = cache.Expand(data, c.cacheImage, cacheURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// enforce the resource limits of the steps, including the instance defaults and maximums.
+	data, err = resources.Apply(data, c.resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.File{Data: data}, nil
+}
+
+// callExtension sends the build context to the remote configuration extension
+// and returns the pipeline yaml it generated, or nil if it didn't generate any.
+func (c *converter) callExtension(ctx context.Context, args *ConvertArgs, data []byte) ([]byte, error) {
+	in := &extension.Request{
+		Repo: extension.Repo{
+			ID:            args.Repo.ID,
+			Identifier:    args.Repo.Identifier,
+			Path:          args.Repo.Path,
+			DefaultBranch: args.Repo.DefaultBranch,
+		},
+		ConfigPath: args.Pipeline.ConfigPath,
+		Config:     string(data),
+	}
+
+	if e := args.Execution; e != nil {
+		in.Build = extension.Build{
+			Event:  string(e.Event),
+			Action: string(e.Action),
+			Ref:    e.Ref,
+			Before: e.Before,
+			After:  e.After,
+			Source: e.Source,
+			Target: e.Target,
+			Cron:   e.Cron,
+		}
+
+		changedFiles, err := c.changedFiles(ctx, args.Repo, e)
+		if err != nil {
+			return nil, err
+		}
+		in.ChangedFiles = changedFiles
+	}
+
+	final, err := c.extension.Find(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline yaml from configuration extension: %w", err)
+	}
+
+	return final, nil
+}
+
+// changedFiles returns the files changed by the execution, it's empty if there is no previous commit
+// to compare against (e.g. new branches and manual executions).
+func (c *converter) changedFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+) ([]string, error) {
+	if execution.Before == "" || execution.Before == types.NilSHA || execution.Before == execution.After {
+		return []string{}, nil
+	}
+
+	out, err := c.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    execution.Before,
+		HeadRef:    execution.After,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	return out.Files, nil
+}
+
+func (c *converter) convert(ctx context.Context, args *ConvertArgs) (*file.File, error) {
 	path := args.Pipeline.ConfigPath
 
 	// get public access visibility of the repo
@@ -84,6 +241,55 @@ func (c *converter) Convert(ctx context.Context, args *ConvertArgs) (*file.File,
 	return args.File, nil
 }
 
+// findTemplate searches for the pipeline template in the space and its ancestors,
+// the template of the closest space is returned.
+func (c *converter) findTemplate(ctx context.Context, spaceID int64, identifier string) (*types.Template, error) {
+	ancestors, err := c.spaceStore.GetAncestorsData(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space ancestors: %w", err)
+	}
+
+	parents := make(map[int64]int64, len(ancestors))
+	for _, ancestor := range ancestors {
+		parents[ancestor.ID] = ancestor.ParentID
+	}
+
+	for id := spaceID; id > 0; id = parents[id] {
+		t, err := c.templateStore.FindByIdentifierAndType(ctx, id, identifier, enum.ResolverTypePipeline)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find template: %w", err)
+		}
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("pipeline template %q not found", identifier)
+}
+
+// templateParams returns the parameters available to pipeline templates, next to the input.
+func templateParams(args *ConvertArgs) map[string]any {
+	params := map[string]any{
+		"repo": map[string]any{
+			"identifier":     args.Repo.Identifier,
+			"path":           args.Repo.Path,
+			"default_branch": args.Repo.DefaultBranch,
+		},
+	}
+	if e := args.Execution; e != nil {
+		params["build"] = map[string]any{
+			"event":  string(e.Event),
+			"action": string(e.Action),
+			"ref":    e.Ref,
+			"commit": e.After,
+			"source": e.Source,
+			"target": e.Target,
+		}
+	}
+	return params
+}
+
 func isJSONNet(path string) bool {
 	return strings.HasSuffix(path, ".drone.jsonnet")
 }
@@ -93,3 +299,9 @@ func isStarlark(path string) bool {
 		strings.HasSuffix(path, ".drone.star") ||
 		strings.HasSuffix(path, ".drone.starlark")
 }
+
+// IsTemplate returns true if the pipeline configuration at the path is a template
+// (jsonnet or starlark) that has to be converted to YAML.
+func IsTemplate(path string) bool {
+	return isJSONNet(path) || isStarlark(path)
+}
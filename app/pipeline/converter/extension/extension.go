@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// responseBodyBytesLimit is the maximum size of the response returned by the extension.
+const responseBodyBytesLimit = 10 << 20
+
+type (
+	// Request is the build context sent to the remote configuration extension.
+	Request struct {
+		Repo         Repo     `json:"repo"`
+		Build        Build    `json:"build"`
+		ConfigPath   string   `json:"config_path"`
+		Config       string   `json:"config"`
+		ChangedFiles []string `json:"changed_files"`
+	}
+
+	// Repo is the repository the pipeline is triggered for.
+	Repo struct {
+		ID            int64  `json:"id"`
+		Identifier    string `json:"identifier"`
+		Path          string `json:"path"`
+		DefaultBranch string `json:"default_branch"`
+	}
+
+	// Build is the execution the pipeline configuration is requested for.
+	Build struct {
+		Event  string `json:"event"`
+		Action string `json:"action"`
+		Ref    string `json:"ref"`
+		Before string `json:"before"`
+		After  string `json:"after"`
+		Source string `json:"source"`
+		Target string `json:"target"`
+		Cron   string `json:"cron,omitempty"`
+	}
+
+	// Response is returned by the remote configuration extension.
+	Response struct {
+		Data string `json:"data"`
+	}
+)
+
+// Client calls the remote configuration extension, an external HTTP endpoint
+// that returns the final pipeline yaml for the build context.
+// If the secret is set, the body is signed with HMAC SHA256 in the X-{Identity}-Signature-256 header.
+type Client struct {
+	endpoint string
+	secret   string
+	identity string
+	client   *http.Client
+}
+
+func NewClient(endpoint, secret, identity string, timeout time.Duration) *Client {
+	return &Client{
+		endpoint: endpoint,
+		secret:   secret,
+		identity: identity,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Find returns the pipeline yaml generated by the extension. It returns nil if the extension
+// responds with 204 No Content, in which case the configuration of the repository is used as is.
+func (c *Client) Find(ctx context.Context, in *Request) ([]byte, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.identity)
+	if c.secret != "" {
+		req.Header.Set("X-"+c.identity+"-Signature-256", "sha256="+sign(c.secret, body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call configuration extension: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("configuration extension responded with status %d", resp.StatusCode)
+	}
+
+	out := Response{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, responseBodyBytesLimit)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration extension response: %w", err)
+	}
+
+	return []byte(out.Data), nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
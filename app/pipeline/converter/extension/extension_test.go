@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFind(t *testing.T) {
+	in := &Request{
+		Repo:         Repo{Identifier: "monorepo"},
+		Build:        Build{Event: "push", After: "abc"},
+		ConfigPath:   ".harness/pipeline.yaml",
+		ChangedFiles: []string{"services/api/main.go"},
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := &Request{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+			require.Equal(t, in, body)
+			require.Equal(t, "Gitness", r.Header.Get("User-Agent"))
+			require.Empty(t, r.Header.Get("X-Gitness-Signature-256"))
+
+			_ = json.NewEncoder(w).Encode(&Response{Data: "kind: pipeline\n"})
+		}))
+		defer srv.Close()
+
+		out, err := NewClient(srv.URL, "", "Gitness", time.Second).Find(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, "kind: pipeline\n", string(out))
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := json.Marshal(in)
+			require.Equal(t, "sha256="+sign("secret", body), r.Header.Get("X-Gitness-Signature-256"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		out, err := NewClient(srv.URL, "secret", "Gitness", time.Second).Find(context.Background(), in)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		_, err := NewClient(srv.URL, "", "Gitness", time.Second).Find(context.Background(), in)
+		require.ErrorContains(t, err, "status 500")
+	})
+}
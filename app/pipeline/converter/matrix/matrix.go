@@ -0,0 +1,306 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/pipeline/converter/yamlutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxCombinations is the maximum number of pipelines a matrix can expand to.
+const MaxCombinations = 64
+
+const (
+	keyKind        = "kind"
+	keyName        = "name"
+	keyMatrix      = "matrix"
+	keyEnvironment = "environment"
+	keyDependsOn   = "depends_on"
+
+	kindPipeline = "pipeline"
+	defaultName  = "default"
+)
+
+var (
+	// ErrMaxCombinations indicates that the matrix has too many combinations.
+	ErrMaxCombinations = fmt.Errorf("matrix: exceeds the maximum of %d combinations", MaxCombinations)
+
+	matrixFinder = regexp.MustCompile(`(?m)^matrix:`)
+	valueFinder  = regexp.MustCompile(`\$\{\{\s*matrix\.([A-Za-z0-9_-]+)\s*}}`)
+)
+
+// axis is a variable of the matrix along with all of its values.
+type axis struct {
+	name   string
+	values []string
+}
+
+// Expand expands every pipeline with a matrix section into a pipeline per combination of the axes, e.g.
+//
+//	kind: pipeline
+//	name: test
+//	matrix:
+//	  GO_VERSION: ["1.21", "1.22"]
+//	  OS: [linux, windows]
+//
+// is expanded into four pipelines named "test (1.21, linux)", "test (1.21, windows)", ...
+// The values of the combination are added to the environment of the pipeline and replace
+// ${{ matrix.AXIS }} expressions. The matrix section of the expanded pipeline holds the values
+// of its combination. Pipelines depending on the pipeline depend on all of its combinations.
+func Expand(data []byte) ([]byte, error) {
+	if !matrixFinder.Match(data) {
+		return data, nil
+	}
+
+	docs, err := yamlutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: %w", err)
+	}
+
+	var expanded []*yaml.Node
+	names := map[string][]string{}
+	for _, doc := range docs {
+		root := yamlutil.Mapping(doc)
+		if root == nil || yamlutil.Scalar(yamlutil.Value(root, keyKind)) != kindPipeline ||
+			yamlutil.Value(root, keyMatrix) == nil {
+			expanded = append(expanded, doc)
+			continue
+		}
+
+		pipelines, err := expandPipeline(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		name := pipelineName(root)
+		for _, pipeline := range pipelines {
+			names[name] = append(names[name], pipelineName(yamlutil.Mapping(pipeline)))
+		}
+		expanded = append(expanded, pipelines...)
+	}
+
+	for _, doc := range expanded {
+		if root := yamlutil.Mapping(doc); root != nil {
+			replaceDependencies(yamlutil.Value(root, keyDependsOn), names)
+		}
+	}
+
+	out, err := yamlutil.Encode(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: %w", err)
+	}
+
+	return out, nil
+}
+
+// Parse returns the matrix values of the expanded pipelines by pipeline name.
+// Pipelines without a matrix are omitted.
+func Parse(data []byte) (map[string]map[string]string, error) {
+	matrices := map[string]map[string]string{}
+	if !matrixFinder.Match(data) {
+		return matrices, nil
+	}
+
+	docs, err := yamlutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: %w", err)
+	}
+
+	for _, doc := range docs {
+		root := yamlutil.Mapping(doc)
+		if root == nil || yamlutil.Scalar(yamlutil.Value(root, keyKind)) != kindPipeline {
+			continue
+		}
+
+		m := yamlutil.Value(root, keyMatrix)
+		if m == nil || m.Kind != yaml.MappingNode {
+			continue
+		}
+
+		values := make(map[string]string, len(m.Content)/2)
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			if m.Content[i+1].Kind == yaml.ScalarNode {
+				values[m.Content[i].Value] = m.Content[i+1].Value
+			}
+		}
+		matrices[pipelineName(root)] = values
+	}
+
+	return matrices, nil
+}
+
+func expandPipeline(doc *yaml.Node) ([]*yaml.Node, error) {
+	axes, err := parseAxes(yamlutil.Value(yamlutil.Mapping(doc), keyMatrix))
+	if err != nil {
+		return nil, err
+	}
+
+	combinations := 1
+	for _, a := range axes {
+		combinations *= len(a.values)
+		if combinations > MaxCombinations {
+			return nil, ErrMaxCombinations
+		}
+	}
+
+	raw, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: failed to encode pipeline: %w", err)
+	}
+
+	pipelines := make([]*yaml.Node, 0, combinations)
+	for i := 0; i < combinations; i++ {
+		// decode a fresh copy of the pipeline for every combination.
+		pipeline := &yaml.Node{}
+		if err := yaml.Unmarshal(raw, pipeline); err != nil {
+			return nil, fmt.Errorf("matrix: failed to decode pipeline: %w", err)
+		}
+
+		values := combination(axes, i)
+		if err := apply(yamlutil.Mapping(pipeline), axes, values); err != nil {
+			return nil, err
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
+
+func parseAxes(node *yaml.Node) ([]axis, error) {
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		return nil, errors.New("matrix: must be a map of variables to their values")
+	}
+
+	axes := make([]axis, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		a := axis{name: node.Content[i].Value}
+		switch v := node.Content[i+1]; v.Kind {
+		case yaml.ScalarNode:
+			a.values = []string{v.Value}
+		case yaml.SequenceNode:
+			for _, item := range v.Content {
+				if item.Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("matrix: values of %q must be scalars", a.name)
+				}
+				a.values = append(a.values, item.Value)
+			}
+		default:
+			return nil, fmt.Errorf("matrix: values of %q must be a list", a.name)
+		}
+		if len(a.values) == 0 {
+			return nil, fmt.Errorf("matrix: %q has no values", a.name)
+		}
+		axes = append(axes, a)
+	}
+
+	return axes, nil
+}
+
+// combination returns the values of the i-th combination of the axes.
+// The last axis changes the fastest.
+func combination(axes []axis, i int) []string {
+	values := make([]string, len(axes))
+	for j := len(axes) - 1; j >= 0; j-- {
+		n := len(axes[j].values)
+		values[j] = axes[j].values[i%n]
+		i /= n
+	}
+	return values
+}
+
+// apply sets the name, matrix values and environment of the pipeline for the combination,
+// and replaces the matrix expressions with the values.
+func apply(root *yaml.Node, axes []axis, values []string) error {
+	byName := make(map[string]string, len(axes))
+	for i, a := range axes {
+		byName[a.name] = values[i]
+	}
+
+	var err error
+	replaceExpressions(root, func(expr string) string {
+		name := valueFinder.FindStringSubmatch(expr)[1]
+		v, ok := byName[name]
+		if !ok && err == nil {
+			err = fmt.Errorf("matrix: unknown variable %q", name)
+		}
+		return v
+	})
+	if err != nil {
+		return err
+	}
+
+	m := &yaml.Node{Kind: yaml.MappingNode}
+	for i, a := range axes {
+		m.Content = append(m.Content, yamlutil.String(a.name), yamlutil.String(values[i]))
+	}
+	yamlutil.Set(root, keyMatrix, m)
+
+	env := yamlutil.Value(root, keyEnvironment)
+	if env == nil || env.Kind != yaml.MappingNode {
+		env = &yaml.Node{Kind: yaml.MappingNode}
+		yamlutil.Set(root, keyEnvironment, env)
+	}
+	for i, a := range axes {
+		yamlutil.Set(env, a.name, yamlutil.String(values[i]))
+	}
+
+	name := fmt.Sprintf("%s (%s)", pipelineName(root), strings.Join(values, ", "))
+	yamlutil.Set(root, keyName, yamlutil.String(name))
+
+	return nil
+}
+
+func replaceExpressions(node *yaml.Node, fn func(string) string) {
+	if node.Kind == yaml.ScalarNode && valueFinder.MatchString(node.Value) {
+		node.Value = valueFinder.ReplaceAllStringFunc(node.Value, fn)
+		return
+	}
+	for _, child := range node.Content {
+		replaceExpressions(child, fn)
+	}
+}
+
+func replaceDependencies(node *yaml.Node, names map[string][]string) {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, dep := range node.Content {
+		expanded, ok := names[dep.Value]
+		if dep.Kind != yaml.ScalarNode || !ok {
+			content = append(content, dep)
+			continue
+		}
+		for _, name := range expanded {
+			content = append(content, yamlutil.String(name))
+		}
+	}
+	node.Content = content
+}
+
+func pipelineName(root *yaml.Node) string {
+	if name := yamlutil.Scalar(yamlutil.Value(root, keyName)); name != "" {
+		return name
+	}
+	return defaultName
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	t.Run("no matrix", func(t *testing.T) {
+		data := "kind: pipeline\nname: default\n"
+		out, err := Expand([]byte(data))
+		require.NoError(t, err)
+		require.Equal(t, data, string(out))
+	})
+
+	t.Run("matrix", func(t *testing.T) {
+		data := `kind: pipeline
+name: test
+matrix:
+  GO_VERSION: ["1.21", "1.22"]
+  OS: [linux, windows]
+environment:
+  CGO_ENABLED: "0"
+steps:
+  - name: test
+    image: golang:${{ matrix.GO_VERSION }}
+---
+kind: pipeline
+name: notify
+depends_on:
+  - test
+`
+		out, err := Expand([]byte(data))
+		require.NoError(t, err)
+		require.Equal(t, `kind: pipeline
+name: test (1.21, linux)
+matrix:
+  GO_VERSION: "1.21"
+  OS: linux
+environment:
+  CGO_ENABLED: "0"
+  GO_VERSION: "1.21"
+  OS: linux
+steps:
+  - name: test
+    image: golang:1.21
+---
+kind: pipeline
+name: test (1.21, windows)
+matrix:
+  GO_VERSION: "1.21"
+  OS: windows
+environment:
+  CGO_ENABLED: "0"
+  GO_VERSION: "1.21"
+  OS: windows
+steps:
+  - name: test
+    image: golang:1.21
+---
+kind: pipeline
+name: test (1.22, linux)
+matrix:
+  GO_VERSION: "1.22"
+  OS: linux
+environment:
+  CGO_ENABLED: "0"
+  GO_VERSION: "1.22"
+  OS: linux
+steps:
+  - name: test
+    image: golang:1.22
+---
+kind: pipeline
+name: test (1.22, windows)
+matrix:
+  GO_VERSION: "1.22"
+  OS: windows
+environment:
+  CGO_ENABLED: "0"
+  GO_VERSION: "1.22"
+  OS: windows
+steps:
+  - name: test
+    image: golang:1.22
+---
+kind: pipeline
+name: notify
+depends_on:
+  - test (1.21, linux)
+  - test (1.21, windows)
+  - test (1.22, linux)
+  - test (1.22, windows)
+`, string(out))
+
+		matrices, err := Parse(out)
+		require.NoError(t, err)
+		require.Len(t, matrices, 4)
+		require.Equal(t, map[string]string{"GO_VERSION": "1.22", "OS": "linux"}, matrices["test (1.22, linux)"])
+	})
+
+	t.Run("unknown variable", func(t *testing.T) {
+		data := "kind: pipeline\nmatrix:\n  OS: [linux]\nsteps:\n  - image: ${{ matrix.ARCH }}\n"
+		_, err := Expand([]byte(data))
+		require.ErrorContains(t, err, `unknown variable "ARCH"`)
+	})
+
+	t.Run("no values", func(t *testing.T) {
+		_, err := Expand([]byte("kind: pipeline\nmatrix:\n  OS: []\n"))
+		require.ErrorContains(t, err, `"OS" has no values`)
+	})
+
+	t.Run("max combinations", func(t *testing.T) {
+		data := "kind: pipeline\nmatrix:\n  A: [1, 2, 3, 4, 5, 6, 7, 8]\n  B: [1, 2, 3, 4, 5, 6, 7, 8, 9]\n"
+		_, err := Expand([]byte(data))
+		require.ErrorIs(t, err, ErrMaxCombinations)
+	})
+}
@@ -0,0 +1,346 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/app/pipeline/converter/yamlutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// cpuPeriod is the cfs period (in microseconds) the cpu quota of the docker containers refers to.
+	cpuPeriod = 100000
+	// minCPU is the minimum cpu limit in millicores, docker requires a quota of at least 1ms.
+	minCPU = 10
+	// minMemory is the minimum memory limit in bytes accepted by docker.
+	minMemory = 6 << 20
+)
+
+const (
+	keyKind      = "kind"
+	keyType      = "type"
+	keyName      = "name"
+	keySteps     = "steps"
+	keyServices  = "services"
+	keyResources = "resources"
+	keyLimits    = "limits"
+	keyRequests  = "requests"
+	keyCPU       = "cpu"
+	keyMemory    = "memory"
+
+	keyMemLimit  = "mem_limit"
+	keyCPUPeriod = "cpu_period"
+	keyCPUQuota  = "cpu_quota"
+	keyCPUShares = "cpu_shares"
+
+	kindPipeline   = "pipeline"
+	typeDocker     = "docker"
+	typeKubernetes = "kubernetes"
+)
+
+var (
+	resourcesFinder = regexp.MustCompile(`(?m)^\s*resources:`)
+	cpuRegex        = regexp.MustCompile(`^(\d+(?:\.\d+)?)(m?)$`)
+	memoryRegex     = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([kKmMgGtT]?)(?:i?[bB]?)$`)
+)
+
+// Resources are the cpu (in millicores) and the memory (in bytes) of a step.
+// Zero means the resource isn't set.
+type Resources struct {
+	CPU    int64
+	Memory int64
+}
+
+// Policy defines the resources of the steps that don't set their own limits
+// and the maximum resources any step can use.
+type Policy struct {
+	Default Resources
+	Max     Resources
+}
+
+// NewPolicy parses the default and maximum resources of the steps, empty values mean no default or maximum.
+func NewPolicy(defaultCPU, defaultMemory, maxCPU, maxMemory string) (Policy, error) {
+	var (
+		p   Policy
+		err error
+	)
+	if p.Default.CPU, err = ParseCPU(defaultCPU); err != nil {
+		return Policy{}, fmt.Errorf("invalid default cpu: %w", err)
+	}
+	if p.Default.Memory, err = ParseMemory(defaultMemory); err != nil {
+		return Policy{}, fmt.Errorf("invalid default memory: %w", err)
+	}
+	if p.Max.CPU, err = ParseCPU(maxCPU); err != nil {
+		return Policy{}, fmt.Errorf("invalid maximum cpu: %w", err)
+	}
+	if p.Max.Memory, err = ParseMemory(maxMemory); err != nil {
+		return Policy{}, fmt.Errorf("invalid maximum memory: %w", err)
+	}
+
+	if p.Max.CPU > 0 && p.Default.CPU > p.Max.CPU {
+		return Policy{}, errors.New("default cpu exceeds the maximum cpu")
+	}
+	if p.Max.Memory > 0 && p.Default.Memory > p.Max.Memory {
+		return Policy{}, errors.New("default memory exceeds the maximum memory")
+	}
+
+	return p, nil
+}
+
+// ParseCPU parses cpu in cores (e.g. "2" or "0.5") or millicores (e.g. "500m") into millicores.
+// An empty string is parsed as zero.
+func ParseCPU(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	m := cpuRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("cpu %q must be in cores (e.g. 0.5) or millicores (e.g. 500m)", s)
+	}
+
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cpu %q: %w", s, err)
+	}
+	if m[2] == "" {
+		v *= 1000
+	}
+
+	return int64(v), nil
+}
+
+// ParseMemory parses memory in bytes (e.g. "536870912") or with a unit (e.g. "512MiB", "512M" or "1Gi").
+// Like docker, all units are binary. An empty string is parsed as zero.
+func ParseMemory(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	m := memoryRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("memory %q must be in bytes or with a unit (e.g. 512MiB)", s)
+	}
+
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory %q: %w", s, err)
+	}
+
+	switch m[2] {
+	case "k", "K":
+		v *= 1 << 10
+	case "m", "M":
+		v *= 1 << 20
+	case "g", "G":
+		v *= 1 << 30
+	case "t", "T":
+		v *= 1 << 40
+	}
+
+	return int64(v), nil
+}
+
+// Apply enforces the policy on the steps and services of the pipelines, e.g.
+//
+//	steps:
+//	  - name: build
+//	    resources:
+//	      limits:
+//	        cpu: 2
+//	        memory: 1GiB
+//	      requests:
+//	        cpu: 500m
+//	        memory: 256MiB
+//
+// Steps without limits use the default limits, or the maximum limits if there are no defaults.
+// Limits exceeding the maximum and requests exceeding the limits are rejected.
+// The resources section is rewritten with the cpu in millicores and the memory in bytes,
+// and for the docker runner the limits are translated into the memory limit and cpu quota
+// of the container and the cpu request into its cpu shares.
+func Apply(data []byte, policy Policy) ([]byte, error) {
+	if policy == (Policy{}) && !resourcesFinder.Match(data) {
+		return data, nil
+	}
+
+	docs, err := yamlutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("resources: %w", err)
+	}
+
+	for _, doc := range docs {
+		root := yamlutil.Mapping(doc)
+		if root == nil || yamlutil.Scalar(yamlutil.Value(root, keyKind)) != kindPipeline {
+			continue
+		}
+
+		typ := yamlutil.Scalar(yamlutil.Value(root, keyType))
+		if typ != "" && typ != typeDocker && typ != typeKubernetes {
+			continue
+		}
+
+		for _, key := range []string{keySteps, keyServices} {
+			steps := yamlutil.Value(root, key)
+			if steps == nil || steps.Kind != yaml.SequenceNode {
+				continue
+			}
+
+			for _, step := range steps.Content {
+				if step.Kind != yaml.MappingNode {
+					continue
+				}
+				if err := applyStep(step, policy, typ != typeKubernetes); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	out, err := yamlutil.Encode(docs)
+	if err != nil {
+		return nil, fmt.Errorf("resources: %w", err)
+	}
+
+	return out, nil
+}
+
+func applyStep(step *yaml.Node, policy Policy, docker bool) error {
+	name := yamlutil.Scalar(yamlutil.Value(step, keyName))
+
+	var limits, requests Resources
+	if section := yamlutil.Value(step, keyResources); section != nil {
+		if section.Kind != yaml.MappingNode {
+			return fmt.Errorf("resources: step %q: resources must be a mapping", name)
+		}
+
+		var err error
+		if limits, err = parseResources(yamlutil.Value(section, keyLimits)); err != nil {
+			return fmt.Errorf("resources: step %q: invalid limits: %w", name, err)
+		}
+		if requests, err = parseResources(yamlutil.Value(section, keyRequests)); err != nil {
+			return fmt.Errorf("resources: step %q: invalid requests: %w", name, err)
+		}
+	}
+
+	limits.CPU = firstNonZero(limits.CPU, policy.Default.CPU, policy.Max.CPU)
+	limits.Memory = firstNonZero(limits.Memory, policy.Default.Memory, policy.Max.Memory)
+
+	if err := validate(limits, requests, policy.Max); err != nil {
+		return fmt.Errorf("resources: step %q: %w", name, err)
+	}
+
+	if limits == (Resources{}) && requests == (Resources{}) {
+		yamlutil.Remove(step, keyResources)
+		return nil
+	}
+
+	section := &yaml.Node{Kind: yaml.MappingNode}
+	if limits != (Resources{}) {
+		yamlutil.Set(section, keyLimits, resourcesNode(limits))
+	}
+	if requests != (Resources{}) {
+		yamlutil.Set(section, keyRequests, resourcesNode(requests))
+	}
+	yamlutil.Set(step, keyResources, section)
+
+	if !docker {
+		return nil
+	}
+
+	// the resources override any docker specific settings, so steps can't exceed the maximums.
+	if limits.Memory > 0 {
+		yamlutil.Set(step, keyMemLimit, yamlutil.Int(limits.Memory))
+	}
+	if limits.CPU > 0 {
+		yamlutil.Set(step, keyCPUPeriod, yamlutil.Int(cpuPeriod))
+		yamlutil.Set(step, keyCPUQuota, yamlutil.Int(limits.CPU*cpuPeriod/1000))
+	}
+	if requests.CPU > 0 {
+		// docker weights the cpu shares relative to 1024, which corresponds to one core.
+		yamlutil.Set(step, keyCPUShares, yamlutil.Int(max(requests.CPU*1024/1000, 2)))
+	}
+
+	return nil
+}
+
+func validate(limits, requests, maximum Resources) error {
+	if limits.CPU > 0 && limits.CPU < minCPU {
+		return fmt.Errorf("cpu limit must be at least %dm", minCPU)
+	}
+	if limits.Memory > 0 && limits.Memory < minMemory {
+		return fmt.Errorf("memory limit must be at least %d bytes", minMemory)
+	}
+	if maximum.CPU > 0 && limits.CPU > maximum.CPU {
+		return fmt.Errorf("cpu limit of %dm exceeds the maximum of %dm", limits.CPU, maximum.CPU)
+	}
+	if maximum.Memory > 0 && limits.Memory > maximum.Memory {
+		return fmt.Errorf("memory limit of %d bytes exceeds the maximum of %d bytes", limits.Memory, maximum.Memory)
+	}
+	if limits.CPU > 0 && requests.CPU > limits.CPU {
+		return fmt.Errorf("cpu request of %dm exceeds the limit of %dm", requests.CPU, limits.CPU)
+	}
+	if limits.Memory > 0 && requests.Memory > limits.Memory {
+		return fmt.Errorf("memory request of %d bytes exceeds the limit of %d bytes", requests.Memory, limits.Memory)
+	}
+	return nil
+}
+
+func parseResources(node *yaml.Node) (Resources, error) {
+	if node == nil {
+		return Resources{}, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return Resources{}, errors.New("must be a mapping of cpu and memory")
+	}
+
+	var (
+		r   Resources
+		err error
+	)
+	if r.CPU, err = ParseCPU(yamlutil.Scalar(yamlutil.Value(node, keyCPU))); err != nil {
+		return Resources{}, err
+	}
+	if r.Memory, err = ParseMemory(yamlutil.Scalar(yamlutil.Value(node, keyMemory))); err != nil {
+		return Resources{}, err
+	}
+
+	return r, nil
+}
+
+func resourcesNode(r Resources) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	if r.CPU > 0 {
+		yamlutil.Set(node, keyCPU, yamlutil.Int(r.CPU))
+	}
+	if r.Memory > 0 {
+		yamlutil.Set(node, keyMemory, yamlutil.Int(r.Memory))
+	}
+	return node
+}
+
+func firstNonZero(values ...int64) int64 {
+	for _, v := range values {
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseCPU(t *testing.T) {
+	tests := map[string]int64{
+		"":     0,
+		"2":    2000,
+		"0.5":  500,
+		"500m": 500,
+		"1.25": 1250,
+	}
+	for in, want := range tests {
+		got, err := ParseCPU(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"-1", "1 core", "m", "1.5.2"} {
+		_, err := ParseCPU(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := map[string]int64{
+		"":       0,
+		"1024":   1024,
+		"512MiB": 512 << 20,
+		"512M":   512 << 20,
+		"1Gi":    1 << 30,
+		"1GB":    1 << 30,
+		"1.5g":   3 << 29,
+		"64kb":   64 << 10,
+	}
+	for in, want := range tests {
+		got, err := ParseMemory(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"-1", "1 PB", "GiB", "lots"} {
+		_, err := ParseMemory(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	p, err := NewPolicy("1", "1GiB", "4", "8GiB")
+	require.NoError(t, err)
+	require.Equal(t, Policy{
+		Default: Resources{CPU: 1000, Memory: 1 << 30},
+		Max:     Resources{CPU: 4000, Memory: 8 << 30},
+	}, p)
+
+	_, err = NewPolicy("8", "", "4", "")
+	require.Error(t, err)
+
+	_, err = NewPolicy("", "a lot", "", "")
+	require.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	t.Run("no resources", func(t *testing.T) {
+		data := "kind: pipeline\nname: default\n"
+		out, err := Apply([]byte(data), Policy{})
+		require.NoError(t, err)
+		require.Equal(t, data, string(out))
+	})
+
+	t.Run("step resources", func(t *testing.T) {
+		data := `kind: pipeline
+name: default
+steps:
+  - name: build
+    image: golang
+    mem_limit: 16GiB
+    resources:
+      limits:
+        cpu: 2
+        memory: 1GiB
+      requests:
+        cpu: 500m
+        memory: 256MiB
+  - name: test
+    image: golang
+services:
+  - name: db
+    image: postgres
+    resources:
+      limits:
+        memory: 512MiB
+`
+		out, err := Apply([]byte(data), Policy{})
+		require.NoError(t, err)
+
+		steps := decodeSteps(t, out, "steps")
+		require.Len(t, steps, 2)
+		require.Equal(t, map[string]any{
+			"name":      "build",
+			"image":     "golang",
+			"mem_limit": 1 << 30,
+			"resources": map[string]any{
+				"limits":   map[string]any{"cpu": 2000, "memory": 1 << 30},
+				"requests": map[string]any{"cpu": 500, "memory": 256 << 20},
+			},
+			"cpu_period": 100000,
+			"cpu_quota":  200000,
+			"cpu_shares": 512,
+		}, steps[0])
+		require.Equal(t, map[string]any{"name": "test", "image": "golang"}, steps[1])
+
+		services := decodeSteps(t, out, "services")
+		require.Equal(t, map[string]any{
+			"name":  "db",
+			"image": "postgres",
+			"resources": map[string]any{
+				"limits": map[string]any{"memory": 512 << 20},
+			},
+			"mem_limit": 512 << 20,
+		}, services[0])
+	})
+
+	t.Run("defaults and maximums", func(t *testing.T) {
+		data := `kind: pipeline
+name: default
+steps:
+  - name: build
+    image: golang
+  - name: test
+    image: golang
+    resources:
+      limits:
+        cpu: 4
+`
+		policy := Policy{Default: Resources{CPU: 1000}, Max: Resources{CPU: 4000, Memory: 2 << 30}}
+		out, err := Apply([]byte(data), policy)
+		require.NoError(t, err)
+
+		steps := decodeSteps(t, out, "steps")
+		require.Equal(t, map[string]any{"cpu": 1000, "memory": 2 << 30},
+			steps[0]["resources"].(map[string]any)["limits"])
+		require.Equal(t, 100000, steps[0]["cpu_quota"])
+		require.Equal(t, 2<<30, steps[0]["mem_limit"])
+		require.Equal(t, map[string]any{"cpu": 4000, "memory": 2 << 30},
+			steps[1]["resources"].(map[string]any)["limits"])
+		require.Equal(t, 400000, steps[1]["cpu_quota"])
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		data := `kind: pipeline
+type: kubernetes
+name: default
+steps:
+  - name: build
+    image: golang
+    resources:
+      limits:
+        memory: 1Gi
+`
+		out, err := Apply([]byte(data), Policy{})
+		require.NoError(t, err)
+
+		steps := decodeSteps(t, out, "steps")
+		require.Equal(t, map[string]any{
+			"name":      "build",
+			"image":     "golang",
+			"resources": map[string]any{"limits": map[string]any{"memory": 1 << 30}},
+		}, steps[0])
+	})
+
+	t.Run("other pipeline types", func(t *testing.T) {
+		data := `kind: pipeline
+type: exec
+name: default
+steps:
+  - name: build
+    resources:
+      limits:
+        cpu: lots
+`
+		_, err := Apply([]byte(data), Policy{Max: Resources{CPU: 1000}})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := map[string]string{
+			"exceeds maximum":       "limits:\n  cpu: 8\n",
+			"request above":         "limits:\n  memory: 1GiB\nrequests:\n  memory: 2GiB\n",
+			"request above default": "requests:\n  cpu: 2\n",
+			"too little":            "limits:\n  memory: 1MiB\n",
+			"invalid cpu":           "limits:\n  cpu: lots\n",
+			"not a mapping":         "limits: 2\n",
+		}
+		for name, resources := range tests {
+			section := "      " + strings.ReplaceAll(strings.TrimSuffix(resources, "\n"), "\n", "\n      ") + "\n"
+			data := "kind: pipeline\nsteps:\n  - name: build\n    resources:\n" + section
+			_, err := Apply([]byte(data), Policy{Default: Resources{CPU: 1000}, Max: Resources{CPU: 4000}})
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func decodeSteps(t *testing.T, data []byte, key string) []map[string]any {
+	var pipeline map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &pipeline))
+
+	var steps []map[string]any
+	for _, step := range pipeline[key].([]any) {
+		steps = append(steps, step.(map[string]any))
+	}
+	return steps
+}
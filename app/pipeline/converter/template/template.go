@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the kind of a yaml document that is replaced by a rendered pipeline template.
+// A template document loads the template by its identifier and passes the data
+// the template is parameterized with, e.g.
+//
+//	kind: template
+//	load: go-build
+//	data:
+//	  go_version: "1.22"
+const Kind = "template"
+
+// maxDepth is the maximum depth of templates including other templates.
+const maxDepth = 10
+
+var (
+	// ErrMaxDepth indicates that templates are nested too deep.
+	ErrMaxDepth = fmt.Errorf("template: maximum include depth of %d exceeded", maxDepth)
+
+	// ErrLoadMissing indicates that a template document doesn't specify the template to load.
+	ErrLoadMissing = errors.New("template: missing template identifier to load")
+
+	separator  = regexp.MustCompile(`^---\s*$`)
+	kindFinder = regexp.MustCompile(`(?m)^kind:\s*["']?` + Kind + `["']?\s*$`)
+)
+
+// LookupFunc returns the contents of the pipeline template with the provided identifier.
+type LookupFunc func(identifier string) (string, error)
+
+// directive is a yaml document that loads a pipeline template.
+type directive struct {
+	Kind string         `yaml:"kind"`
+	Load string         `yaml:"load"`
+	Data map[string]any `yaml:"data"`
+}
+
+// Expand replaces all template documents of the yaml with the rendered pipeline templates.
+// Templates are rendered using go templates, the data of the template document
+// is available as `.input`, along with the provided params. Rendered templates can
+// include other templates.
+func Expand(data []byte, params map[string]any, lookup LookupFunc) ([]byte, error) {
+	return expand(data, params, lookup, nil)
+}
+
+// Validate checks whether the provided pipeline template can be parsed.
+func Validate(data string) error {
+	if strings.TrimSpace(data) == "" {
+		return errors.New("template: empty template")
+	}
+	_, err := template.New("pipeline").Parse(data)
+	return err
+}
+
+func expand(data []byte, params map[string]any, lookup LookupFunc, loaded []string) ([]byte, error) {
+	if !kindFinder.Match(data) {
+		return data, nil
+	}
+
+	if len(loaded) >= maxDepth {
+		return nil, ErrMaxDepth
+	}
+
+	docs := split(string(data))
+	for i, doc := range docs {
+		if !kindFinder.MatchString(doc) {
+			continue
+		}
+
+		d := directive{}
+		if err := yaml.Unmarshal([]byte(doc), &d); err != nil {
+			return nil, fmt.Errorf("template: failed to parse template document: %w", err)
+		}
+		if d.Kind != Kind {
+			continue
+		}
+		if d.Load == "" {
+			return nil, ErrLoadMissing
+		}
+		for _, identifier := range loaded {
+			if identifier == d.Load {
+				return nil, fmt.Errorf("template: %q includes itself", d.Load)
+			}
+		}
+
+		rendered, err := render(d, params, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		expanded, err := expand(rendered, params, lookup, append(loaded, d.Load))
+		if err != nil {
+			return nil, err
+		}
+
+		docs[i] = string(expanded)
+	}
+
+	return []byte(strings.Join(docs, "---\n")), nil
+}
+
+func render(d directive, params map[string]any, lookup LookupFunc) ([]byte, error) {
+	text, err := lookup(d.Load)
+	if err != nil {
+		return nil, fmt.Errorf("template: failed to load %q: %w", d.Load, err)
+	}
+
+	t, err := template.New(d.Load).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template: failed to parse %q: %w", d.Load, err)
+	}
+
+	input := d.Data
+	if input == nil {
+		input = map[string]any{}
+	}
+
+	values := make(map[string]any, len(params)+1)
+	for k, v := range params {
+		values[k] = v
+	}
+	values["input"] = input
+
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, values); err != nil {
+		return nil, fmt.Errorf("template: failed to render %q: %w", d.Load, err)
+	}
+
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
+// split splits the yaml into documents. Each document retains its trailing newline,
+// joining the documents with the separator restores the original yaml.
+func split(data string) []string {
+	var docs []string
+	var doc strings.Builder
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if separator.MatchString(strings.TrimSuffix(line, "\n")) {
+			docs = append(docs, doc.String())
+			doc.Reset()
+			continue
+		}
+		doc.WriteString(line)
+	}
+	return append(docs, doc.String())
+}
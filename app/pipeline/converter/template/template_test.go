@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	templates := map[string]string{
+		"go-build": `kind: pipeline
+type: docker
+name: {{ .repo.identifier }}
+steps:
+  - name: build
+    image: golang:{{ .input.go_version }}
+    commands:
+      - go build ./...
+`,
+		"notify": `kind: template
+load: go-build
+data:
+  go_version: "{{ .input.version }}"
+---
+kind: pipeline
+type: docker
+name: notify
+depends_on:
+  - {{ .repo.identifier }}
+steps:
+  - name: notify
+    image: plugins/slack`,
+		"loop": `kind: template
+load: loop
+`,
+	}
+	lookup := func(identifier string) (string, error) {
+		data, ok := templates[identifier]
+		if !ok {
+			return "", errors.New("not found")
+		}
+		return data, nil
+	}
+	params := map[string]any{"repo": map[string]any{"identifier": "app"}}
+
+	t.Run("no templates", func(t *testing.T) {
+		data := "kind: pipeline\nname: default\n---\nkind: secret\nname: token\n"
+		out, err := Expand([]byte(data), params, lookup)
+		require.NoError(t, err)
+		require.Equal(t, data, string(out))
+	})
+
+	t.Run("template", func(t *testing.T) {
+		data := `---
+kind: template
+load: go-build
+data:
+  go_version: "1.22"
+---
+kind: secret
+name: token
+`
+		out, err := Expand([]byte(data), params, lookup)
+		require.NoError(t, err)
+		require.Equal(t, `---
+kind: pipeline
+type: docker
+name: app
+steps:
+  - name: build
+    image: golang:1.22
+    commands:
+      - go build ./...
+---
+kind: secret
+name: token
+`, string(out))
+	})
+
+	t.Run("include", func(t *testing.T) {
+		data := "kind: template\nload: notify\ndata:\n  version: \"1.21\"\n"
+		out, err := Expand([]byte(data), params, lookup)
+		require.NoError(t, err)
+		require.Contains(t, string(out), "image: golang:1.21\n")
+		require.Contains(t, string(out), "---\nkind: pipeline\ntype: docker\nname: notify\n")
+		require.Contains(t, string(out), "  - app\n")
+	})
+
+	t.Run("missing input", func(t *testing.T) {
+		_, err := Expand([]byte("kind: template\nload: go-build\n"), params, lookup)
+		require.Error(t, err)
+	})
+
+	t.Run("missing load", func(t *testing.T) {
+		_, err := Expand([]byte("kind: template\n"), params, lookup)
+		require.ErrorIs(t, err, ErrLoadMissing)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Expand([]byte("kind: template\nload: unknown\n"), params, lookup)
+		require.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := Expand([]byte("kind: template\nload: loop\n"), params, lookup)
+		require.ErrorContains(t, err, "includes itself")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate("kind: pipeline\nname: {{ .input.name }}\n"))
+	require.Error(t, Validate("kind: pipeline\nname: {{ .input.name \n"))
+	require.Error(t, Validate("  \n"))
+}
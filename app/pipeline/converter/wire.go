@@ -15,8 +15,16 @@
 package converter
 
 import (
+	"fmt"
+
+	"github.com/harness/gitness/app/pipeline/converter/extension"
+	"github.com/harness/gitness/app/pipeline/converter/resources"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -27,6 +35,37 @@ var WireSet = wire.NewSet(
 )
 
 // ProvideService provides a service which can convert templates.
-func ProvideService(fileService file.Service, publicAccess publicaccess.Service) Service {
-	return newConverter(fileService, publicAccess)
+func ProvideService(
+	config *types.Config,
+	fileService file.Service,
+	publicAccess publicaccess.Service,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	git git.Interface,
+	urlProvider url.Provider,
+) (Service, error) {
+	var ext *extension.Client
+	if endpoint := config.CI.ConfigExtension.Endpoint; endpoint != "" {
+		identity := config.Webhook.HeaderIdentity
+		if identity == "" {
+			identity = config.Webhook.UserAgentIdentity
+		}
+		ext = extension.NewClient(endpoint, config.CI.ConfigExtension.Secret, identity,
+			config.CI.ConfigExtension.Timeout)
+	}
+
+	var cacheImage string
+	if config.PipelineCache.Enabled {
+		cacheImage = config.PipelineCache.Image
+	}
+
+	stepResources := config.CI.StepResources
+	policy, err := resources.NewPolicy(stepResources.DefaultCPU, stepResources.DefaultMemory,
+		stepResources.MaxCPU, stepResources.MaxMemory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse step resources: %w", err)
+	}
+
+	return newConverter(fileService, publicAccess, spaceStore, templateStore, git, ext, urlProvider, cacheImage,
+		policy), nil
 }
@@ -0,0 +1,407 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	droneyaml "github.com/drone/drone-yaml/yaml"
+	v1yaml "github.com/drone/spec/dist/go"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kindPipeline  = "pipeline"
+	kindTemplate  = "template"
+	kindSecret    = "secret"
+	kindSignature = "signature"
+
+	defaultName = "default"
+)
+
+var (
+	separator     = regexp.MustCompile(`^---\s*$`)
+	errLineFinder = regexp.MustCompile(`line (\d+): (.*)`)
+	v1Finder      = regexp.MustCompilePOSIX(`^spec:`)
+	// v1SecretFinder finds the secret expressions of v1 YAML, e.g. <+secrets.getValue("token")>.
+	v1SecretFinder = regexp.MustCompile(`<\+\s*secrets\.getValue\(\s*["']([^"']+)["']\s*\)\s*>`)
+
+	pipelineKeys = keys(
+		"kind", "type", "name", "version", "platform", "workspace", "clone", "steps", "services",
+		"volumes", "node", "trigger", "depends_on", "concurrency", "image_pull_secrets", "environment",
+		"inputs", "matrix", "runs_on",
+		// kubernetes pipelines.
+		"node_selector", "tolerations", "service_account_name",
+	)
+	stepKeys = keys(
+		"name", "image", "commands", "command", "entrypoint", "environment", "settings", "when",
+		"depends_on", "detach", "privileged", "pull", "failure", "shell", "user", "volumes",
+		"network_mode", "resources", "working_dir", "devices", "dns", "dns_search", "extra_hosts",
+		"ports", "cache",
+	)
+	conditionKeys = keys(
+		"action", "branch", "cron", "event", "instance", "paths", "ref", "repo", "status", "target",
+	)
+	templateKeys = keys("kind", "load", "data")
+)
+
+// Reference is a reference of a pipeline YAML to a resource that has to exist, e.g. a secret.
+type Reference struct {
+	Name   string
+	Line   int
+	Column int
+}
+
+// Result is the result of linting a pipeline YAML.
+type Result struct {
+	Problems []*types.PipelineProblem
+
+	// Secrets and Templates are the references to secrets and pipeline templates.
+	// Their existence depends on the space of the repository and is checked by the caller.
+	Secrets   []Reference
+	Templates []Reference
+}
+
+// Lint checks the pipeline YAML for syntax and schema errors, unknown keys and dependencies
+// on pipelines or steps that don't exist. Problems are reported with the line they were found on.
+// Drone YAML is checked document by document, v1 YAML is only checked for parse errors.
+func Lint(data []byte) *Result {
+	r := &Result{}
+
+	if v1Finder.Match(data) {
+		r.lintV1(data)
+		return r
+	}
+
+	pipelines := map[string]*yaml.Node{}
+	var dependencies []*yaml.Node
+
+	for _, doc := range split(string(data)) {
+		root := &yaml.Node{}
+		if err := yaml.Unmarshal([]byte(doc.data), root); err != nil {
+			r.addError(enum.PipelineProblemTypeSyntax, doc.offset, err)
+			continue
+		}
+		if len(root.Content) == 0 {
+			// empty document.
+			continue
+		}
+
+		node := root.Content[0]
+		if node.Kind != yaml.MappingNode {
+			r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, node,
+				"YAML document must be a mapping")
+			continue
+		}
+
+		kind := value(node, "kind")
+		switch {
+		case kind == nil:
+			r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, node,
+				"YAML document is missing a kind")
+		case kind.Value == kindPipeline:
+			name := defaultName
+			if n := value(node, "name"); n != nil && n.Value != "" {
+				name = n.Value
+			}
+			if _, ok := pipelines[name]; ok {
+				r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, node,
+					fmt.Sprintf("duplicate pipeline name %q", name))
+			}
+			pipelines[name] = node
+
+			r.lintPipeline(doc, node, name)
+			for _, dep := range sequence(value(node, "depends_on")) {
+				dependencies = append(dependencies, shift(dep, doc.offset))
+			}
+		case kind.Value == kindTemplate:
+			r.lintTemplate(doc, node)
+		case kind.Value == kindSecret || kind.Value == kindSignature:
+			// secrets and signatures of the drone YAML aren't used.
+		default:
+			r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, kind,
+				fmt.Sprintf("unknown kind %q", kind.Value))
+		}
+	}
+
+	for _, dep := range dependencies {
+		if _, ok := pipelines[dep.Value]; !ok {
+			r.add(enum.PipelineProblemTypeMissingDependency, enum.PipelineProblemSeverityError, 0, dep,
+				fmt.Sprintf("pipeline %q doesn't exist", dep.Value))
+		}
+	}
+
+	return r
+}
+
+func (r *Result) lintV1(data []byte) {
+	if _, err := v1yaml.ParseBytes(data); err != nil {
+		r.addError(enum.PipelineProblemTypeSchema, 0, err)
+	}
+
+	for _, match := range v1SecretFinder.FindAllSubmatchIndex(data, -1) {
+		line, column := position(data, match[0])
+		r.Secrets = append(r.Secrets, Reference{
+			Name:   string(data[match[2]:match[3]]),
+			Line:   line,
+			Column: column,
+		})
+	}
+}
+
+func (r *Result) lintPipeline(doc document, node *yaml.Node, name string) {
+	// type errors are reported by the drone YAML parser.
+	if _, err := droneyaml.ParseString(doc.data); err != nil {
+		r.addError(enum.PipelineProblemTypeSchema, doc.offset, err)
+	}
+
+	r.checkKeys(doc, node, pipelineKeys, "pipeline")
+	r.checkKeys(doc, value(node, "trigger"), conditionKeys, "trigger")
+
+	pipelineType := ""
+	if t := value(node, "type"); t != nil {
+		pipelineType = t.Value
+	}
+	requiresImage := pipelineType == "" || pipelineType == "docker" || pipelineType == "kubernetes"
+
+	steps := value(node, "steps")
+	if len(sequence(steps)) == 0 {
+		r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, node,
+			fmt.Sprintf("pipeline %q has no steps", name))
+	}
+
+	stepNames := map[string]struct{}{}
+	var dependencies []*yaml.Node
+	for _, section := range []*yaml.Node{value(node, "services"), steps} {
+		for _, step := range sequence(section) {
+			if step.Kind != yaml.MappingNode {
+				r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, step,
+					"step must be a mapping")
+				continue
+			}
+
+			r.checkKeys(doc, step, stepKeys, "step")
+			r.checkKeys(doc, value(step, "when"), conditionKeys, "when")
+
+			stepName := value(step, "name")
+			switch {
+			case stepName == nil || stepName.Value == "":
+				r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, step,
+					"step is missing a name")
+			default:
+				if _, ok := stepNames[stepName.Value]; ok {
+					r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, stepName,
+						fmt.Sprintf("duplicate step name %q", stepName.Value))
+				}
+				stepNames[stepName.Value] = struct{}{}
+			}
+
+			// cache steps are converted into steps with an image.
+			if image := value(step, "image"); requiresImage && (image == nil || image.Value == "") &&
+				value(step, "cache") == nil {
+				r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, step,
+					"step is missing an image")
+			}
+
+			dependencies = append(dependencies, sequence(value(step, "depends_on"))...)
+		}
+	}
+
+	for _, dep := range dependencies {
+		if _, ok := stepNames[dep.Value]; !ok {
+			r.add(enum.PipelineProblemTypeMissingDependency, enum.PipelineProblemSeverityError, doc.offset, dep,
+				fmt.Sprintf("step %q doesn't exist in pipeline %q", dep.Value, name))
+		}
+	}
+
+	for _, secret := range sequence(value(node, "image_pull_secrets")) {
+		r.Secrets = append(r.Secrets, reference(doc, secret))
+	}
+	r.findSecrets(doc, node)
+}
+
+func (r *Result) lintTemplate(doc document, node *yaml.Node) {
+	r.checkKeys(doc, node, templateKeys, "template")
+
+	load := value(node, "load")
+	if load == nil || load.Value == "" {
+		r.add(enum.PipelineProblemTypeSchema, enum.PipelineProblemSeverityError, doc.offset, node,
+			"template is missing the identifier of the template to load")
+		return
+	}
+
+	r.Templates = append(r.Templates, reference(doc, load))
+}
+
+// findSecrets adds the secrets referenced with from_secret, e.g. in the environment or settings of steps.
+func (r *Result) findSecrets(doc document, node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if k, v := node.Content[i], node.Content[i+1]; k.Value == "from_secret" && v.Kind == yaml.ScalarNode {
+				r.Secrets = append(r.Secrets, reference(doc, v))
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		r.findSecrets(doc, child)
+	}
+}
+
+// checkKeys adds a warning for every key of the mapping that isn't one of the known keys.
+func (r *Result) checkKeys(doc document, node *yaml.Node, known map[string]struct{}, section string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if _, ok := known[key.Value]; !ok {
+			r.add(enum.PipelineProblemTypeUnknownKey, enum.PipelineProblemSeverityWarning, doc.offset, key,
+				fmt.Sprintf("unknown %s key %q", section, key.Value))
+		}
+	}
+}
+
+func (r *Result) add(
+	typ enum.PipelineProblemType,
+	severity enum.PipelineProblemSeverity,
+	offset int,
+	node *yaml.Node,
+	message string,
+) {
+	r.Problems = append(r.Problems, &types.PipelineProblem{
+		Type:     typ,
+		Severity: severity,
+		Line:     node.Line + offset,
+		Column:   node.Column,
+		Message:  message,
+	})
+}
+
+// addError adds an error for every line of the parse error. The line numbers in the error
+// messages are relative to the document, which starts after the offset.
+func (r *Result) addError(typ enum.PipelineProblemType, offset int, err error) {
+	var found bool
+	for _, match := range errLineFinder.FindAllStringSubmatch(err.Error(), -1) {
+		line, convErr := strconv.Atoi(match[1])
+		if convErr != nil {
+			continue
+		}
+		found = true
+		r.Problems = append(r.Problems, &types.PipelineProblem{
+			Type:     typ,
+			Severity: enum.PipelineProblemSeverityError,
+			Line:     line + offset,
+			Message:  match[2],
+		})
+	}
+
+	if !found {
+		r.Problems = append(r.Problems, &types.PipelineProblem{
+			Type:     typ,
+			Severity: enum.PipelineProblemSeverityError,
+			Message:  strings.TrimPrefix(err.Error(), "yaml: "),
+		})
+	}
+}
+
+// document is a YAML document of a multi-document YAML.
+type document struct {
+	data string
+	// offset is the number of lines before the document.
+	offset int
+}
+
+// split splits the YAML into its documents.
+func split(data string) []document {
+	var docs []document
+	var lines []string
+	offset := 0
+	for i, line := range strings.Split(data, "\n") {
+		if !separator.MatchString(line) {
+			lines = append(lines, line)
+			continue
+		}
+		docs = append(docs, document{data: strings.Join(lines, "\n"), offset: offset})
+		lines = nil
+		offset = i + 1
+	}
+	return append(docs, document{data: strings.Join(lines, "\n"), offset: offset})
+}
+
+// value returns the value of the key of the mapping, or nil if the key doesn't exist.
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequence returns the items of a sequence, a scalar is treated as a sequence with a single item.
+func sequence(node *yaml.Node) []*yaml.Node {
+	switch {
+	case node == nil:
+		return nil
+	case node.Kind == yaml.SequenceNode:
+		return node.Content
+	case node.Kind == yaml.ScalarNode && node.Value != "" && node.Tag != "!!null":
+		return []*yaml.Node{node}
+	default:
+		return nil
+	}
+}
+
+// shift returns a copy of the node with its line shifted by the offset.
+func shift(node *yaml.Node, offset int) *yaml.Node {
+	shifted := *node
+	shifted.Line += offset
+	return &shifted
+}
+
+func reference(doc document, node *yaml.Node) Reference {
+	return Reference{
+		Name:   node.Value,
+		Line:   node.Line + doc.offset,
+		Column: node.Column,
+	}
+}
+
+// position returns the 1-based line and column of the byte offset.
+func position(data []byte, offset int) (int, int) {
+	before := data[:offset]
+	line := strings.Count(string(before), "\n") + 1
+	column := offset - strings.LastIndex(string(before), "\n")
+	return line, column
+}
+
+func keys(values ...string) map[string]struct{} {
+	m := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		m[v] = struct{}{}
+	}
+	return m
+}
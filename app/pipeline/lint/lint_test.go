@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	const data = `kind: pipeline
+type: docker
+name: build
+
+steps:
+- name: test
+  image: golang:1.22
+  commands:
+  - go test ./...
+  environment:
+    TOKEN:
+      from_secret: token
+- name: publish
+  image: plugins/docker
+  depends_on: [test, lint]
+  settings:
+    password:
+      from_secret: docker_password
+  timeout: 10m
+- name: restore
+  cache:
+    mode: restore
+- name: publish
+  commands:
+  - echo
+
+trigger:
+  branches: [main]
+---
+kind: pipeline
+name: deploy
+depends_on: [build, release]
+image_pull_secrets: [dockerconfig]
+steps:
+- name: deploy
+  image: alpine
+---
+kind: template
+load: go-build
+`
+	result := Lint([]byte(data))
+
+	require.Equal(t, []*types.PipelineProblem{
+		{
+			Type: enum.PipelineProblemTypeUnknownKey, Severity: enum.PipelineProblemSeverityWarning,
+			Line: 28, Column: 3, Message: `unknown trigger key "branches"`,
+		},
+		{
+			Type: enum.PipelineProblemTypeUnknownKey, Severity: enum.PipelineProblemSeverityWarning,
+			Line: 19, Column: 3, Message: `unknown step key "timeout"`,
+		},
+		{
+			Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+			Line: 23, Column: 9, Message: `duplicate step name "publish"`,
+		},
+		{
+			Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+			Line: 23, Column: 3, Message: "step is missing an image",
+		},
+		{
+			Type: enum.PipelineProblemTypeMissingDependency, Severity: enum.PipelineProblemSeverityError,
+			Line: 15, Column: 22, Message: `step "lint" doesn't exist in pipeline "build"`,
+		},
+		{
+			Type: enum.PipelineProblemTypeMissingDependency, Severity: enum.PipelineProblemSeverityError,
+			Line: 32, Column: 21, Message: `pipeline "release" doesn't exist`,
+		},
+	}, result.Problems)
+
+	require.Equal(t, []Reference{
+		{Name: "token", Line: 12, Column: 20},
+		{Name: "docker_password", Line: 18, Column: 20},
+		{Name: "dockerconfig", Line: 33, Column: 22},
+	}, result.Secrets)
+
+	require.Equal(t, []Reference{
+		{Name: "go-build", Line: 39, Column: 7},
+	}, result.Templates)
+}
+
+func TestLintErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []*types.PipelineProblem
+	}{
+		{
+			name: "syntax error in second document",
+			data: "kind: pipeline\nsteps:\n- name: a\n  image: alpine\n---\nkind: pipeline\nname: b\n  steps: [\n",
+			want: []*types.PipelineProblem{{
+				Type: enum.PipelineProblemTypeSyntax, Severity: enum.PipelineProblemSeverityError,
+				Line: 8, Message: "mapping values are not allowed in this context",
+			}},
+		},
+		{
+			name: "missing kind",
+			data: "name: a\n",
+			want: []*types.PipelineProblem{{
+				Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+				Line: 1, Column: 1, Message: "YAML document is missing a kind",
+			}},
+		},
+		{
+			name: "unknown kind",
+			data: "kind: pipelines\n",
+			want: []*types.PipelineProblem{{
+				Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+				Line: 1, Column: 7, Message: `unknown kind "pipelines"`,
+			}},
+		},
+		{
+			name: "pipeline without steps",
+			data: "kind: pipeline\nname: a\n",
+			want: []*types.PipelineProblem{{
+				Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+				Line: 1, Column: 1, Message: `pipeline "a" has no steps`,
+			}},
+		},
+		{
+			name: "template without load",
+			data: "kind: template\ndata: {}\n",
+			want: []*types.PipelineProblem{{
+				Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+				Line: 1, Column: 1, Message: "template is missing the identifier of the template to load",
+			}},
+		},
+		{
+			name: "duplicate pipeline names",
+			data: "kind: pipeline\nsteps:\n- name: a\n  image: alpine\n---\n" +
+				"kind: pipeline\nsteps:\n- name: a\n  image: alpine\n",
+			want: []*types.PipelineProblem{{
+				Type: enum.PipelineProblemTypeSchema, Severity: enum.PipelineProblemSeverityError,
+				Line: 6, Column: 1, Message: `duplicate pipeline name "default"`,
+			}},
+		},
+		{
+			name: "exec pipeline without image",
+			data: "kind: pipeline\ntype: exec\nsteps:\n- name: a\n  commands: [make]\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, Lint([]byte(test.data)).Problems)
+		})
+	}
+}
+
+func TestLintV1Secrets(t *testing.T) {
+	const data = "spec:\n  stages:\n  - steps:\n    - run:\n        script: echo <+secrets.getValue(\"token\")>\n"
+
+	result := Lint([]byte(data))
+	require.Empty(t, result.Problems)
+	require.Equal(t, []Reference{{Name: "token", Line: 5, Column: 22}}, result.Secrets)
+}
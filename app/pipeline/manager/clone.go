@@ -0,0 +1,312 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultCloneImage is the image the runner uses for the implicit clone step on linux.
+	defaultCloneImage = "drone/git:latest"
+
+	cloneStepName      = "clone"
+	submodulesStepName = "submodules"
+)
+
+var v1YamlRegexp = regexp.MustCompilePOSIX(`^spec:`)
+
+// cloneOverrides is the clone section of a drone pipeline. The runner only supports disabling the clone step
+// and limiting its depth, the image and the submodules are handled by replacing the implicit clone step.
+type cloneOverrides struct {
+	Disable    *bool   `yaml:"disable"`
+	Image      *string `yaml:"image"`
+	Depth      *int    `yaml:"depth"`
+	Submodules *bool   `yaml:"submodules"`
+}
+
+// applyClone applies the clone settings of the repository to all docker pipelines of a drone YAML.
+// The clone section of a pipeline takes precedence over the settings. Pipelines using the v1 YAML
+// configure the clone step in the YAML only and are returned unchanged.
+func applyClone(data []byte, settings types.PipelineClone) ([]byte, error) {
+	if v1YamlRegexp.Match(data) {
+		return data, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	var documents []*yaml.Node
+	changed := false
+	for {
+		document := &yaml.Node{}
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode yaml document: %w", err)
+		}
+
+		documentChanged, err := applyCloneToDocument(document, settings)
+		if err != nil {
+			return nil, err
+		}
+
+		changed = changed || documentChanged
+		documents = append(documents, document)
+	}
+
+	if !changed {
+		return data, nil
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return nil, fmt.Errorf("failed to encode yaml document: %w", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close yaml encoder: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// applyCloneToDocument applies the clone settings to a single document and reports whether it was changed.
+// Documents other than docker pipelines are left unchanged.
+func applyCloneToDocument(document *yaml.Node, settings types.PipelineClone) (bool, error) {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return false, nil
+	}
+
+	pipeline := document.Content[0]
+	if pipeline.Kind != yaml.MappingNode || scalarValue(pipeline, "kind") != "pipeline" {
+		return false, nil
+	}
+	if typ := scalarValue(pipeline, "type"); typ != "" && typ != "docker" {
+		return false, nil
+	}
+
+	clone := settings
+	if node := mappingValue(pipeline, "clone"); node != nil {
+		overrides := cloneOverrides{}
+		if err := node.Decode(&overrides); err != nil {
+			return false, fmt.Errorf("failed to decode clone section of pipeline: %w", err)
+		}
+		clone = mergeClone(clone, overrides)
+	}
+
+	if err := clone.Sanitize(); err != nil {
+		return false, err
+	}
+
+	if clone == (types.PipelineClone{}) {
+		return false, nil
+	}
+
+	switch {
+	case clone.Disabled:
+		// the pipeline clones the repository itself if needed.
+	case clone.Image != "":
+		// the runner always uses its own image, so the implicit clone step is replaced by a plugin step.
+		steps := []map[string]any{cloneStep(clone)}
+		if clone.Submodules {
+			steps = append(steps, submodulesStep(clone))
+		}
+
+		if err := prependSteps(pipeline, steps); err != nil {
+			return false, err
+		}
+
+	case clone.Submodules:
+		if err := prependSteps(pipeline, []map[string]any{submodulesStep(clone)}); err != nil {
+			return false, err
+		}
+	}
+
+	// the options of the clone section the runner supports (e.g. retries) are kept as they are.
+	section := mappingValue(pipeline, "clone")
+	if section == nil || section.Kind != yaml.MappingNode {
+		section = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(pipeline, "clone", section)
+	}
+
+	deleteMappingValue(section, "image")
+	deleteMappingValue(section, "submodules")
+
+	switch {
+	case clone.Disabled || clone.Image != "":
+		setMappingValue(section, "disable", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	case clone.Depth > 0:
+		setMappingValue(section, "depth",
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(clone.Depth)})
+	}
+
+	return true, nil
+}
+
+// mergeClone returns the clone settings overridden by the clone section of a pipeline.
+func mergeClone(settings types.PipelineClone, overrides cloneOverrides) types.PipelineClone {
+	if overrides.Disable != nil {
+		settings.Disabled = *overrides.Disable
+	}
+	if overrides.Image != nil {
+		settings.Image = *overrides.Image
+	}
+	if overrides.Depth != nil {
+		settings.Depth = *overrides.Depth
+	}
+	if overrides.Submodules != nil {
+		settings.Submodules = *overrides.Submodules
+	}
+
+	return settings
+}
+
+// cloneStep returns a step cloning the repository with the configured image.
+// The image has to be compatible with drone/git, the clone parameters are passed as plugin settings.
+func cloneStep(clone types.PipelineClone) map[string]any {
+	step := map[string]any{
+		"name":  cloneStepName,
+		"image": clone.Image,
+	}
+	if clone.Depth > 0 {
+		step["settings"] = map[string]any{"depth": clone.Depth}
+	}
+
+	return step
+}
+
+// submodulesStep returns a step initializing the submodules of the cloned repository.
+func submodulesStep(clone types.PipelineClone) map[string]any {
+	image := clone.Image
+	if image == "" {
+		image = defaultCloneImage
+	}
+
+	command := "git submodule update --init --recursive"
+	if clone.Depth > 0 {
+		command += " --depth " + strconv.Itoa(clone.Depth)
+	}
+
+	return map[string]any{
+		"name":     submodulesStepName,
+		"image":    image,
+		"commands": []string{command},
+	}
+}
+
+// prependSteps adds the steps before the steps of the pipeline. The steps are executed in order.
+// If the pipeline uses depends_on, the added steps depend on each other
+// and all steps of the pipeline without dependencies depend on the last added step.
+func prependSteps(pipeline *yaml.Node, steps []map[string]any) error {
+	existing := mappingValue(pipeline, "steps")
+	if existing == nil || existing.Kind != yaml.SequenceNode {
+		return errors.New("pipeline has no steps")
+	}
+
+	graph := false
+	for _, step := range existing.Content {
+		if step.Kind == yaml.MappingNode && mappingValue(step, "depends_on") != nil {
+			graph = true
+			break
+		}
+	}
+
+	if graph {
+		for i := 1; i < len(steps); i++ {
+			steps[i]["depends_on"] = []any{steps[i-1]["name"]}
+		}
+
+		last := steps[len(steps)-1]["name"]
+		for _, step := range existing.Content {
+			if step.Kind != yaml.MappingNode || mappingValue(step, "depends_on") != nil {
+				continue
+			}
+
+			node := &yaml.Node{}
+			if err := node.Encode([]any{last}); err != nil {
+				return fmt.Errorf("failed to encode step dependencies: %w", err)
+			}
+			setMappingValue(step, "depends_on", node)
+		}
+	}
+
+	nodes := make([]*yaml.Node, len(steps))
+	for i, step := range steps {
+		nodes[i] = &yaml.Node{}
+		if err := nodes[i].Encode(step); err != nil {
+			return fmt.Errorf("failed to encode step: %w", err)
+		}
+	}
+
+	existing.Content = append(nodes, existing.Content...)
+
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	value := mappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return value.Value
+}
+
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+func deleteMappingValue(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
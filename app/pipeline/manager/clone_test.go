@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyClone(t *testing.T) {
+	const pipeline = `kind: pipeline
+type: docker
+name: default
+clone:
+  retries: 3
+steps:
+  - name: build
+    image: golang
+    commands:
+      - go build
+`
+
+	t.Run("no settings", func(t *testing.T) {
+		out, err := applyClone([]byte(pipeline), types.PipelineClone{})
+		require.NoError(t, err)
+		require.Equal(t, pipeline, string(out))
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		out := decodePipeline(t, pipeline, types.PipelineClone{Depth: 10})
+		require.Equal(t, map[string]any{"retries": 3, "depth": 10}, out["clone"])
+		require.Len(t, out["steps"], 1)
+	})
+
+	t.Run("disabled in yaml", func(t *testing.T) {
+		const in = `kind: pipeline
+name: default
+clone:
+  disable: true
+steps:
+  - name: build
+    image: golang
+---
+kind: secret
+name: token
+`
+		out := decodePipeline(t, in, types.PipelineClone{Image: "registry.local/drone/git"})
+		require.Equal(t, map[string]any{"disable": true}, out["clone"])
+		require.Len(t, out["steps"], 1)
+	})
+
+	t.Run("image and submodules", func(t *testing.T) {
+		out := decodePipeline(t, pipeline, types.PipelineClone{
+			Image:      "registry.local/drone/git",
+			Depth:      5,
+			Submodules: true,
+		})
+		require.Equal(t, map[string]any{"retries": 3, "disable": true}, out["clone"])
+
+		steps, _ := out["steps"].([]any)
+		require.Len(t, steps, 3)
+		require.Equal(t, map[string]any{
+			"name":     "clone",
+			"image":    "registry.local/drone/git",
+			"settings": map[string]any{"depth": 5},
+		}, steps[0])
+		require.Equal(t, map[string]any{
+			"name":     "submodules",
+			"image":    "registry.local/drone/git",
+			"commands": []any{"git submodule update --init --recursive --depth 5"},
+		}, steps[1])
+	})
+
+	t.Run("submodules with depends_on", func(t *testing.T) {
+		in := pipeline + "  - name: test\n    image: golang\n    depends_on: [build]\n"
+
+		out := decodePipeline(t, in, types.PipelineClone{Submodules: true})
+
+		steps, _ := out["steps"].([]any)
+		require.Len(t, steps, 3)
+		require.Equal(t, "submodules", steps[0].(map[string]any)["name"])
+		require.Equal(t, []any{"submodules"}, steps[1].(map[string]any)["depends_on"])
+		require.Equal(t, []any{"build"}, steps[2].(map[string]any)["depends_on"])
+	})
+
+	t.Run("v1 yaml", func(t *testing.T) {
+		const in = "spec:\n  stages: []\n"
+		out, err := applyClone([]byte(in), types.PipelineClone{Disabled: true})
+		require.NoError(t, err)
+		require.Equal(t, in, string(out))
+	})
+}
+
+// decodePipeline applies the clone settings and returns the first document of the result.
+func decodePipeline(t *testing.T, in string, clone types.PipelineClone) map[string]any {
+	t.Helper()
+
+	data, err := applyClone([]byte(in), clone)
+	require.NoError(t, err)
+
+	out := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(data, &out))
+
+	return out
+}
@@ -29,9 +29,11 @@ import (
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/livelog"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -63,6 +65,8 @@ type (
 		Variant string            `json:"variant"`
 		Kernel  string            `json:"kernel"`
 		Labels  map[string]string `json:"labels,omitempty"`
+		// Tags are the tags of the external runner requesting the stage.
+		Tags []string `json:"tags,omitempty"`
 	}
 
 	// Config represents a pipeline config file.
@@ -152,7 +156,10 @@ type Manager struct {
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
 
-	publicAccess publicaccess.Service
+	publicAccess    publicaccess.Service
+	settingsService *settings.Service
+	spaceStore      store.SpaceStore
+	auditService    audit.Service
 	// events reporter
 	reporter events.Reporter
 }
@@ -175,6 +182,9 @@ func New(
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
+	settingsService *settings.Service,
+	spaceStore store.SpaceStore,
+	auditService audit.Service,
 	reporter events.Reporter,
 ) *Manager {
 	return &Manager{
@@ -195,6 +205,9 @@ func New(
 		Steps:            stepStore,
 		Users:            userStore,
 		publicAccess:     publicAccess,
+		settingsService:  settingsService,
+		spaceStore:       spaceStore,
+		auditService:     auditService,
 		reporter:         reporter,
 	}
 }
@@ -219,6 +232,7 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 		Kernel:  args.Kernel,
 		Variant: args.Variant,
 		Labels:  args.Labels,
+		Tags:    args.Tags,
 	})
 	if err != nil && ctx.Err() != nil {
 		log.Debug().Err(err).Msg("manager: context canceled")
@@ -326,13 +340,13 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		Str("repo", repo.GetGitUID()).
 		Logger()
 
-	// TODO: Currently we fetch all the secrets from the same space.
-	// This logic can be updated when needed.
-	secrets, err := m.Secrets.ListAll(noContext, repo.ParentID)
+	// Secrets of the space of the repo are inherited from its ancestor spaces.
+	secrets, spacePaths, err := m.listSecrets(noContext, repo, execution)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: cannot list secrets")
 		return nil, err
 	}
+	m.auditSecretUsage(noContext, secrets, spacePaths, repo, pipeline, execution, stage)
 
 	// Fetch contents of YAML from the execution ref at the pipeline config path.
 	file, err := m.FileService.Get(noContext, repo, pipeline.ConfigPath, execution.After)
@@ -362,6 +376,20 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		return nil, err
 	}
 
+	// Apply the clone settings of the repo unless overridden by the pipeline.
+	clone, err := settings.RepoGetInherited(noContext, m.settingsService, repo,
+		settings.KeyPipelineClone, types.PipelineClone{})
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot get clone settings")
+		return nil, err
+	}
+	data, err := applyClone(file.Data, clone)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot apply clone settings")
+		return nil, err
+	}
+	file.Data = data
+
 	netrc, err := m.createNetrc(repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: failed to create netrc")
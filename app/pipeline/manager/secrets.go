@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// listSecrets returns the secrets exposed to the execution. These are the secrets of the space
+// of the repository and of all its ancestor spaces, filtered by the restrictions of the secrets.
+// Secrets of nested spaces override the secrets of their ancestors with the same identifier.
+func (m *Manager) listSecrets(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+) ([]*types.Secret, map[int64]string, error) {
+	spaces, err := m.spaceStore.GetAncestors(ctx, repo.ParentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get ancestor spaces: %w", err)
+	}
+
+	spaceIDs := make([]int64, len(spaces))
+	spacePaths := make(map[int64]string, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+		spacePaths[space.ID] = space.Path
+	}
+
+	secrets, err := m.Secrets.ListAllInSpaces(ctx, spaceIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	return inheritSecrets(secrets, spacePaths, repo.Path, execution), spacePaths, nil
+}
+
+// inheritSecrets returns the secrets exposed to the execution, where the secrets of the deepest space
+// take precedence over the secrets of their ancestors with the same identifier.
+func inheritSecrets(
+	secrets []*types.Secret,
+	spacePaths map[int64]string,
+	repoPath string,
+	execution *types.Execution,
+) []*types.Secret {
+	depth := func(s *types.Secret) int {
+		return strings.Count(spacePaths[s.SpaceID], "/")
+	}
+
+	nearest := make(map[string]*types.Secret, len(secrets))
+	for _, secret := range secrets {
+		if other, ok := nearest[secret.Identifier]; ok && depth(other) >= depth(secret) {
+			continue
+		}
+		nearest[secret.Identifier] = secret
+	}
+
+	// a restricted secret isn't replaced by the secret of an ancestor with the same identifier.
+	exposed := make([]*types.Secret, 0, len(nearest))
+	for _, secret := range secrets {
+		if nearest[secret.Identifier] == secret && secret.IsExposedTo(repoPath, execution) {
+			exposed = append(exposed, secret)
+		}
+	}
+
+	return exposed
+}
+
+// auditSecretUsage records the usage of the secrets by the stage of the execution in the audit log.
+func (m *Manager) auditSecretUsage(
+	ctx context.Context,
+	secrets []*types.Secret,
+	spacePaths map[int64]string,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+	stage *types.Stage,
+) {
+	principal := bootstrap.NewPipelineServiceSession().Principal
+	for _, secret := range secrets {
+		err := m.auditService.Log(ctx,
+			principal,
+			audit.NewResource(audit.ResourceTypeSecret, secret.Identifier),
+			audit.ActionUsed,
+			spacePaths[secret.SpaceID],
+			audit.WithData(
+				audit.RepoPath, repo.Path,
+				audit.PipelineIdentifier, pipeline.Identifier,
+				audit.ExecutionNumber, strconv.FormatInt(execution.Number, 10),
+				audit.StageName, stage.Name,
+			),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("secret", secret.Identifier).
+				Msg("manager: failed to insert audit log for secret usage")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritSecrets(t *testing.T) {
+	spacePaths := map[int64]string{
+		1: "root",
+		2: "root/team",
+	}
+	push := &types.Execution{Event: enum.TriggerEventPush}
+	pr := &types.Execution{Event: enum.TriggerEventPullRequest}
+	forkPR := &types.Execution{Event: enum.TriggerEventPullRequest, Fork: "other/repo"}
+
+	identifiers := func(secrets []*types.Secret) []string {
+		out := make([]string, len(secrets))
+		for i, s := range secrets {
+			out[i] = s.Identifier
+		}
+		return out
+	}
+
+	t.Run("nested space overrides ancestor", func(t *testing.T) {
+		root := &types.Secret{SpaceID: 1, Identifier: "token", Data: "root"}
+		team := &types.Secret{SpaceID: 2, Identifier: "token", Data: "team"}
+		other := &types.Secret{SpaceID: 1, Identifier: "other"}
+
+		secrets := inheritSecrets([]*types.Secret{team, root, other}, spacePaths, "root/team/repo", push)
+		require.Equal(t, []*types.Secret{team, other}, secrets)
+
+		secrets = inheritSecrets([]*types.Secret{root, other, team}, spacePaths, "root/team/repo", push)
+		require.Equal(t, []*types.Secret{other, team}, secrets)
+	})
+
+	t.Run("restricted override hides ancestor", func(t *testing.T) {
+		root := &types.Secret{SpaceID: 1, Identifier: "token"}
+		team := &types.Secret{SpaceID: 2, Identifier: "token", AllowedEvents: []enum.TriggerEvent{enum.TriggerEventTag}}
+
+		secrets := inheritSecrets([]*types.Secret{root, team}, spacePaths, "root/team/repo", push)
+		require.Empty(t, secrets)
+	})
+
+	t.Run("restrictions", func(t *testing.T) {
+		secrets := []*types.Secret{
+			{SpaceID: 1, Identifier: "any"},
+			{SpaceID: 1, Identifier: "push", AllowedEvents: []enum.TriggerEvent{enum.TriggerEventPush}},
+			{SpaceID: 1, Identifier: "repo", AllowedRepos: []string{"root/team/repo"}},
+			{SpaceID: 1, Identifier: "pattern", AllowedRepos: []string{"Root/Team/*"}},
+			{SpaceID: 1, Identifier: "elsewhere", AllowedRepos: []string{"root/*"}},
+			{SpaceID: 1, Identifier: "forks", AllowForkPRs: true},
+			{
+				SpaceID:       1,
+				Identifier:    "forks-push",
+				AllowedEvents: []enum.TriggerEvent{enum.TriggerEventPush},
+				AllowForkPRs:  true,
+			},
+		}
+
+		require.Equal(t, []string{"any", "push", "repo", "pattern", "forks", "forks-push"},
+			identifiers(inheritSecrets(secrets, spacePaths, "root/team/repo", push)))
+		require.Equal(t, []string{"any", "repo", "pattern", "forks"},
+			identifiers(inheritSecrets(secrets, spacePaths, "root/team/repo", pr)))
+		// secrets are withheld from pull requests of forks unless they're allowed explicitly.
+		require.Equal(t, []string{"forks"},
+			identifiers(inheritSecrets(secrets, spacePaths, "root/team/repo", forkPR)))
+	})
+}
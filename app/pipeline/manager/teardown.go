@@ -129,6 +129,16 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	// stages of other executions in the same concurrency group might
+	// be waiting for this stage to complete, signal the scheduler.
+	if stage.ConcurrencyGroup != "" {
+		err = t.Scheduler.Schedule(noContext, stage)
+		if err != nil {
+			log.Warn().Err(err).
+				Msg("manager: cannot signal scheduler for concurrency group")
+		}
+	}
+
 	if !isexecutionComplete(stages) {
 		log.Warn().Err(err).
 			Msg("manager: execution pending completion of additional stages")
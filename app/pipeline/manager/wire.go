@@ -20,9 +20,11 @@ import (
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/types"
 
@@ -55,11 +57,14 @@ func ProvideExecutionManager(
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
+	settingsService *settings.Service,
+	spaceStore store.SpaceStore,
+	auditService audit.Service,
 	reporter *events.Reporter,
 ) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
 		logStore, logStream, checkStore, repoStore, scheduler, secretStore,
-		stageStore, stepStore, userStore, publicAccess, *reporter)
+		stageStore, stepStore, userStore, publicAccess, settingsService, spaceStore, auditService, *reporter)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -43,6 +43,9 @@ func Resolve(
 		if err != nil {
 			return nil, err
 		}
+		if t == enum.ResolverTypePipeline {
+			return nil, fmt.Errorf("pipeline templates are only supported in drone yaml")
+		}
 		if k == enum.ResolverKindPlugin && t != enum.ResolverTypeStep {
 			return nil, fmt.Errorf("only step level plugins are currently supported")
 		}
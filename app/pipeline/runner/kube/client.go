@@ -0,0 +1,244 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	inClusterTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// errNotFound is returned if the kubernetes object doesn't exist.
+var errNotFound = errors.New("kubernetes object not found")
+
+// Client is a minimal client of the kubernetes API, supporting the pods and
+// persistent volume claims the kubernetes runner creates.
+type Client struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+// NewClient returns a client of the kubernetes API server.
+// If the server is empty, the in-cluster configuration of the service account is used.
+func NewClient(server, token, caFile string) (*Client, error) {
+	if server == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, errors.New("kubernetes API server isn't configured and gitness isn't running in a cluster")
+		}
+		server = "https://" + net.JoinHostPort(host, port)
+
+		if token == "" {
+			data, err := os.ReadFile(inClusterTokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read service account token: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+		}
+		if caFile == "" {
+			caFile = inClusterCAFile
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile != "" {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kubernetes certificate authority: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid kubernetes certificate authority")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &Client{
+		server: strings.TrimSuffix(server, "/"),
+		token:  token,
+		http:   &http.Client{Transport: transport},
+	}, nil
+}
+
+// CreatePVC creates the persistent volume claim.
+func (c *Client) CreatePVC(ctx context.Context, pvc *PersistentVolumeClaim) error {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/persistentvolumeclaims", pvc.Metadata.Namespace)
+	return c.do(ctx, http.MethodPost, path, "application/json", pvc, nil)
+}
+
+// DeletePVC deletes the persistent volume claim, it's a no-op if the claim doesn't exist.
+func (c *Client) DeletePVC(ctx context.Context, namespace, name string) error {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/persistentvolumeclaims/%s", namespace, name)
+	err := c.do(ctx, http.MethodDelete, path, "", nil, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// CreateSecret creates the secret.
+func (c *Client) CreateSecret(ctx context.Context, secret *SecretObject) error {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/secrets", secret.Metadata.Namespace)
+	return c.do(ctx, http.MethodPost, path, "application/json", secret, nil)
+}
+
+// DeleteSecret deletes the secret, it's a no-op if the secret doesn't exist.
+func (c *Client) DeleteSecret(ctx context.Context, namespace, name string) error {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", namespace, name)
+	err := c.do(ctx, http.MethodDelete, path, "", nil, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// CreatePod creates the pod.
+func (c *Client) CreatePod(ctx context.Context, pod *Pod) error {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods", pod.Metadata.Namespace)
+	return c.do(ctx, http.MethodPost, path, "application/json", pod, nil)
+}
+
+// GetPod returns the pod.
+func (c *Client) GetPod(ctx context.Context, namespace, name string) (*Pod, error) {
+	pod := &Pod{}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", namespace, name)
+	if err := c.do(ctx, http.MethodGet, path, "", nil, pod); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// StartContainer replaces the image of the container of the pod along with the annotations of the pod,
+// which restarts the container with the new image and the environment variables set from the annotations.
+func (c *Client) StartContainer(
+	ctx context.Context,
+	namespace, pod, container, image string,
+	annotations map[string]string,
+) error {
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"annotations": annotations,
+		},
+		"spec": map[string]any{
+			"containers": []map[string]string{{"name": container, "image": image}},
+		},
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", namespace, pod)
+	return c.do(ctx, http.MethodPatch, path, "application/strategic-merge-patch+json", patch, nil)
+}
+
+// DeletePod deletes the pod immediately, it's a no-op if the pod doesn't exist.
+func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s?gracePeriodSeconds=0", namespace, name)
+	err := c.do(ctx, http.MethodDelete, path, "", nil, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Logs streams the logs of the container of the pod until the container terminates.
+// The caller is responsible for closing the stream.
+func (c *Client) Logs(ctx context.Context, namespace, pod, container string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log?follow=true&container=%s",
+		namespace, pod, url.QueryEscape(container))
+
+	resp, err := c.send(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, contentType string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode kubernetes request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := c.send(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode kubernetes response: %w", err)
+	}
+
+	return nil
+}
+
+// send sends the request to the API server and returns the response if it was successful.
+func (c *Client) send(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes request failed: %w", err)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+
+	status := &struct {
+		Message string `json:"message"`
+	}{}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(status)
+
+	return nil, fmt.Errorf("kubernetes request %s %s failed with status %d: %s",
+		method, path, resp.StatusCode, status.Message)
+}
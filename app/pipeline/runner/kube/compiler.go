@@ -0,0 +1,404 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/drone/drone-go/drone"
+	droneyaml "github.com/drone/drone-yaml/yaml"
+	"github.com/drone/runner-go/environ"
+	"github.com/drone/runner-go/pipeline/runtime"
+	"github.com/drone/runner-go/secret"
+)
+
+const (
+	// workspace is the path the workspace volume is mounted at in the containers.
+	workspace = "/drone/src"
+
+	cloneStepName = "clone"
+)
+
+// Compiler compiles kubernetes pipelines into the spec of the pod of the stage.
+type Compiler struct {
+	Namespace        string
+	ServiceAccount   string
+	StorageClass     string
+	WorkspaceSize    string
+	PlaceholderImage string
+	CloneImage       string
+	// Secret provides the secrets in addition to the secrets of the stage.
+	Secret secret.Provider
+}
+
+var _ runtime.Compiler = (*Compiler)(nil)
+
+// Compile compiles the kubernetes pipeline of the stage.
+func (c *Compiler) Compile(ctx context.Context, args runtime.CompilerArgs) runtime.Spec {
+	pipeline, _ := args.Pipeline.(*Pipeline)
+
+	spec := &Spec{
+		Namespace: c.Namespace,
+		PodName:   fmt.Sprintf("gitness-%d-%s", args.Stage.ID, randomSuffix()),
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "gitness",
+			"gitness.io/stage":             fmt.Sprint(args.Stage.ID),
+		},
+		ServiceAccountName: c.ServiceAccount,
+		NodeSelector:       pipeline.NodeSelector,
+		Tolerations:        pipeline.Tolerations,
+		StorageClass:       c.StorageClass,
+		WorkspaceSize:      c.WorkspaceSize,
+		PlaceholderImage:   c.PlaceholderImage,
+	}
+	if pipeline.ServiceAccountName != "" {
+		spec.ServiceAccountName = pipeline.ServiceAccountName
+	}
+
+	envs := environ.Combine(
+		args.Environ,
+		environ.System(args.System),
+		environ.Repo(args.Repo),
+		environ.Build(args.Build),
+		environ.Stage(args.Stage),
+		environ.Link(args.Repo, args.Build, args.System),
+		map[string]string{
+			"CI":                   "true",
+			"DRONE":                "true",
+			"DRONE_WORKSPACE":      workspace,
+			"DRONE_WORKSPACE_BASE": workspace,
+		},
+	)
+
+	// the credentials to clone the repository, which the steps can use to call the gitness api.
+	var netrc []*Secret
+	if args.Netrc != nil && args.Netrc.Machine != "" {
+		envs["DRONE_NETRC_MACHINE"] = args.Netrc.Machine
+		netrc = []*Secret{
+			{Name: "netrc_username", Env: "DRONE_NETRC_USERNAME", Data: []byte(args.Netrc.Login)},
+			{Name: "netrc_password", Env: "DRONE_NETRC_PASSWORD", Data: []byte(args.Netrc.Password), Mask: true},
+		}
+	}
+
+	if !pipeline.Clone.Disable {
+		step := &Step{
+			Name:       cloneStepName,
+			Image:      c.CloneImage,
+			WorkingDir: workspace,
+			Envs:       environ.Combine(envs),
+			Secrets:    netrc,
+			ErrPolicy:  runtime.ErrFail,
+			RunPolicy:  runtime.RunAlways,
+		}
+		if pipeline.Clone.Depth > 0 {
+			step.Envs["PLUGIN_DEPTH"] = fmt.Sprint(pipeline.Clone.Depth)
+		}
+		spec.Steps = append(spec.Steps, step)
+	}
+
+	for _, container := range pipeline.Services {
+		step := c.compileStep(ctx, args, container, envs, netrc)
+		step.Detach = true
+		spec.Steps = append(spec.Steps, step)
+	}
+
+	for _, container := range pipeline.Steps {
+		spec.Steps = append(spec.Steps, c.compileStep(ctx, args, container, envs, netrc))
+	}
+
+	configureDependencies(spec, !pipeline.Clone.Disable)
+
+	for i, step := range spec.Steps {
+		step.Container = fmt.Sprintf("step-%d", i)
+		step.Envs = environ.Combine(step.Envs, environ.Step(&drone.Step{Name: step.Name, Number: i + 1}))
+		for _, secret := range step.Secrets {
+			delete(step.Envs, secret.Env)
+		}
+		step.EnvKeys = sortedKeys(step.Envs)
+	}
+
+	return spec
+}
+
+func (c *Compiler) compileStep(
+	ctx context.Context,
+	args runtime.CompilerArgs,
+	container *droneyaml.Container,
+	envs map[string]string,
+	netrc []*Secret,
+) *Step {
+	step := &Step{
+		Name:       container.Name,
+		Image:      container.Image,
+		WorkingDir: workspace,
+		Envs:       environ.Combine(envs),
+		Secrets:    append([]*Secret{}, netrc...),
+		DependsOn:  container.DependsOn,
+		ErrPolicy:  errPolicy(container.Failure),
+		RunPolicy:  runPolicy(container.When, args),
+		Detach:     container.Detach,
+		Resources:  resources(container.Resources),
+	}
+	if container.WorkingDir != "" {
+		step.WorkingDir = container.WorkingDir
+	}
+
+	if len(container.Entrypoint) > 0 {
+		step.Command = container.Entrypoint
+	}
+	if len(container.Commands) > 0 {
+		step.Command = []string{"/bin/sh", "-c"}
+		step.Args = []string{script(container.Commands)}
+	}
+
+	// the settings of plugins are passed as environment variables.
+	for key, param := range container.Settings {
+		if param == nil {
+			continue
+		}
+		env := "PLUGIN_" + strings.ToUpper(key)
+		if param.Secret != "" {
+			if s, ok := c.findSecret(ctx, args, param.Secret); ok {
+				step.Secrets = append(step.Secrets, &Secret{Name: param.Secret, Env: env, Data: []byte(s), Mask: true})
+			}
+			continue
+		}
+		step.Envs[env] = settingValue(param.Value)
+	}
+
+	for key, variable := range container.Environment {
+		if variable == nil {
+			continue
+		}
+		if variable.Secret != "" {
+			if s, ok := c.findSecret(ctx, args, variable.Secret); ok {
+				step.Secrets = append(step.Secrets, &Secret{Name: variable.Secret, Env: key, Data: []byte(s), Mask: true})
+			}
+			continue
+		}
+		step.Envs[key] = variable.Value
+	}
+
+	return step
+}
+
+// findSecret returns the value of the secret from the secrets of the stage or the secret provider.
+func (c *Compiler) findSecret(ctx context.Context, args runtime.CompilerArgs, name string) (string, bool) {
+	providers := []secret.Provider{args.Secret}
+	if c.Secret != nil {
+		providers = append(providers, c.Secret)
+	}
+
+	found, _ := secret.Combine(providers...).Find(ctx, &secret.Request{
+		Name:  name,
+		Build: args.Build,
+		Repo:  args.Repo,
+		Conf:  args.Manifest,
+	})
+	if found == nil {
+		return "", false
+	}
+	return found.Data, true
+}
+
+// configureDependencies makes the steps run in order if none of the steps defines dependencies,
+// otherwise the steps without dependencies depend on the clone step, like the docker runner.
+func configureDependencies(spec *Spec, clone bool) {
+	serial := true
+	for _, step := range spec.Steps {
+		if len(step.DependsOn) > 0 {
+			serial = false
+			break
+		}
+	}
+
+	for i, step := range spec.Steps {
+		switch {
+		case i == 0 || len(step.DependsOn) > 0:
+		case serial:
+			step.DependsOn = []string{spec.Steps[i-1].Name}
+		case clone:
+			step.DependsOn = []string{cloneStepName}
+		}
+	}
+}
+
+func errPolicy(failure string) runtime.ErrPolicy {
+	switch failure {
+	case "ignore":
+		return runtime.ErrIgnore
+	case "fast", "fast-fail", "fail-fast":
+		return runtime.ErrFailFast
+	default:
+		return runtime.ErrFail
+	}
+}
+
+// runPolicy returns the policy of the step based on its conditions, like the docker runner.
+func runPolicy(when droneyaml.Conditions, args runtime.CompilerArgs) runtime.RunPolicy {
+	build := args.Build
+	if !when.Event.Match(build.Event) ||
+		!when.Branch.Match(build.Target) ||
+		!when.Ref.Match(build.Ref) ||
+		!when.Action.Match(build.Action) ||
+		!when.Cron.Match(build.Cron) ||
+		!when.Target.Match(build.Deploy) ||
+		!when.Repo.Match(args.Repo.Slug) ||
+		!when.Instance.Match(args.System.Host) {
+		return runtime.RunNever
+	}
+
+	if len(when.Status.Include)+len(when.Status.Exclude) == 0 {
+		return runtime.RunOnSuccess
+	}
+
+	onSuccess := when.Status.Match(drone.StatusPassing)
+	onFailure := when.Status.Match(drone.StatusFailing)
+	switch {
+	case onSuccess && onFailure:
+		return runtime.RunAlways
+	case onFailure:
+		return runtime.RunOnFailure
+	case onSuccess:
+		return runtime.RunOnSuccess
+	default:
+		return runtime.RunNever
+	}
+}
+
+// resources converts the resources of the step, with the cpu in millicores and the memory in bytes.
+func resources(r *droneyaml.Resources) ResourceRequirements {
+	var out ResourceRequirements
+	if r == nil {
+		return out
+	}
+	if r.Limits != nil {
+		out.Limits = quantities(int64(r.Limits.CPU), int64(r.Limits.Memory))
+	}
+	if r.Requests != nil {
+		out.Requests = quantities(int64(r.Requests.CPU), int64(r.Requests.Memory))
+	}
+	return out
+}
+
+func quantities(cpu, memory int64) map[string]string {
+	out := map[string]string{}
+	if cpu > 0 {
+		out["cpu"] = fmt.Sprintf("%dm", cpu)
+	}
+	if memory > 0 {
+		out["memory"] = fmt.Sprint(memory)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// script returns the shell script running the commands, which echoes every command before running it.
+func script(commands []string) string {
+	var b strings.Builder
+	b.WriteString("set -e\n")
+	for _, command := range commands {
+		escaped := fmt.Sprintf("%q", command)
+		escaped = strings.ReplaceAll(escaped, "$", `\$`)
+		fmt.Fprintf(&b, "echo + %s\n%s\n", escaped, command)
+	}
+	return b.String()
+}
+
+// settingValue converts the value of a plugin setting into an environment variable,
+// lists are comma separated and objects are json encoded.
+func settingValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.(map[interface{}]interface{}); ok {
+				return jsonValue(v)
+			}
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ",")
+	case map[interface{}]interface{}, map[string]interface{}:
+		return jsonValue(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func jsonValue(v interface{}) string {
+	data, err := json.Marshal(stringKeys(v))
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// stringKeys converts the maps decoded from yaml, which can't be json encoded, into maps with string keys.
+func stringKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[fmt.Sprint(key)] = stringKeys(value)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[key] = stringKeys(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = stringKeys(value)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// randomSuffix returns a random suffix for the names of the kubernetes objects of the stage.
+func randomSuffix() string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 8)
+	for i := range b {
+		//nolint:gosec // the suffix only avoids name conflicts.
+		b[i] = chars[rand.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
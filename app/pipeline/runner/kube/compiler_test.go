@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureDependencies(t *testing.T) {
+	t.Run("serial", func(t *testing.T) {
+		spec := &Spec{Steps: []*Step{{Name: cloneStepName}, {Name: "build"}, {Name: "test"}}}
+		configureDependencies(spec, true)
+		require.Nil(t, spec.Steps[0].DependsOn)
+		require.Equal(t, []string{cloneStepName}, spec.Steps[1].DependsOn)
+		require.Equal(t, []string{"build"}, spec.Steps[2].DependsOn)
+	})
+
+	t.Run("graph", func(t *testing.T) {
+		spec := &Spec{Steps: []*Step{
+			{Name: cloneStepName},
+			{Name: "build"},
+			{Name: "lint"},
+			{Name: "test", DependsOn: []string{"build"}},
+		}}
+		configureDependencies(spec, true)
+		require.Equal(t, []string{cloneStepName}, spec.Steps[1].DependsOn)
+		require.Equal(t, []string{cloneStepName}, spec.Steps[2].DependsOn)
+		require.Equal(t, []string{"build"}, spec.Steps[3].DependsOn)
+	})
+
+	t.Run("graph without clone", func(t *testing.T) {
+		spec := &Spec{Steps: []*Step{{Name: "build"}, {Name: "test", DependsOn: []string{"build"}}}}
+		configureDependencies(spec, false)
+		require.Nil(t, spec.Steps[0].DependsOn)
+		require.Equal(t, []string{"build"}, spec.Steps[1].DependsOn)
+	})
+}
+
+func TestScript(t *testing.T) {
+	require.Equal(t, "set -e\necho + \"go build ./...\"\ngo build ./...\necho + \"echo \\$HOME\"\necho $HOME\n",
+		script([]string{"go build ./...", "echo $HOME"}))
+}
+
+func TestSettingValue(t *testing.T) {
+	require.Equal(t, "", settingValue(nil))
+	require.Equal(t, "foo", settingValue("foo"))
+	require.Equal(t, "3", settingValue(3))
+	require.Equal(t, "a,b", settingValue([]interface{}{"a", "b"}))
+	require.Equal(t, `{"a":{"b":1}}`, settingValue(map[interface{}]interface{}{
+		"a": map[interface{}]interface{}{"b": 1},
+	}))
+}
+
+func TestSameImage(t *testing.T) {
+	require.True(t, sameImage("docker.io/library/golang:latest", "golang"))
+	require.True(t, sameImage("docker.io/library/golang:1.22", "golang:1.22"))
+	require.True(t, sameImage("docker.io/drone/placeholder:1", "drone/placeholder:1"))
+	require.True(t, sameImage("registry:5000/app:latest", "registry:5000/app"))
+	require.False(t, sameImage("docker.io/library/golang:1.21", "golang:1.22"))
+	require.False(t, sameImage("docker.io/drone/placeholder:1", "golang"))
+}
@@ -0,0 +1,352 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/drone/runner-go/pipeline/runtime"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	workspaceVolume = "workspace"
+
+	// pollInterval is the interval the status of the pod is polled at while a step is running.
+	pollInterval = time.Second
+)
+
+// waitingErrors are the reasons of waiting containers that won't start without intervention.
+var waitingErrors = map[string]struct{}{
+	"ErrImagePull":               {},
+	"ImagePullBackOff":           {},
+	"InvalidImageName":           {},
+	"ErrImageNeverPull":          {},
+	"CreateContainerConfigError": {},
+	"CreateContainerError":       {},
+}
+
+// Engine executes the stages as kubernetes pods.
+//
+// All containers of the pod start with the placeholder image, which keeps them running until
+// their step is started by replacing the image, which makes kubernetes restart the container.
+// Environment variables can't be changed once the pod is created, so the containers read them
+// from the annotations of the pod, which are updated along with the image.
+type Engine struct {
+	client *Client
+}
+
+var _ runtime.Engine = (*Engine)(nil)
+
+func NewEngine(client *Client) *Engine {
+	return &Engine{client: client}
+}
+
+// Setup creates the persistent volume claim of the workspace, the secret and the pod of the stage.
+func (e *Engine) Setup(ctx context.Context, s runtime.Spec) error {
+	spec, ok := s.(*Spec)
+	if !ok {
+		return errors.New("invalid kubernetes spec")
+	}
+
+	pvc := &PersistentVolumeClaim{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Metadata:   e.meta(spec, nil),
+		Spec: PersistentVolumeClaimSpec{
+			AccessModes: []string{"ReadWriteOnce"},
+			Resources: ResourceRequirements{
+				Requests: map[string]string{"storage": spec.WorkspaceSize},
+			},
+		},
+	}
+	if spec.StorageClass != "" {
+		pvc.Spec.StorageClassName = &spec.StorageClass
+	}
+	if err := e.client.CreatePVC(ctx, pvc); err != nil {
+		return fmt.Errorf("failed to create workspace volume claim: %w", err)
+	}
+
+	secret := &SecretObject{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   e.meta(spec, nil),
+		Type:       "Opaque",
+		Data:       map[string][]byte{},
+	}
+	for _, step := range spec.Steps {
+		for i, s := range step.Secrets {
+			secret.Data[secretKey(step, i)] = s.Data
+		}
+	}
+	if err := e.client.CreateSecret(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	annotations := map[string]string{}
+	containers := make([]Container, len(spec.Steps))
+	for i, step := range spec.Steps {
+		for k, v := range envAnnotations(step, step.Envs) {
+			annotations[k] = v
+		}
+		containers[i] = e.container(spec, step)
+	}
+
+	pod := &Pod{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Metadata:   e.meta(spec, annotations),
+		Spec: PodSpec{
+			RestartPolicy:      "Never",
+			ServiceAccountName: spec.ServiceAccountName,
+			NodeSelector:       spec.NodeSelector,
+			Tolerations:        spec.Tolerations,
+			Volumes: []Volume{{
+				Name:                  workspaceVolume,
+				PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{ClaimName: spec.PodName},
+			}},
+			Containers: containers,
+		},
+	}
+	if err := e.client.CreatePod(ctx, pod); err != nil {
+		return fmt.Errorf("failed to create pod: %w", err)
+	}
+
+	return nil
+}
+
+// Destroy deletes the pod, the secret and the persistent volume claim of the stage.
+func (e *Engine) Destroy(ctx context.Context, s runtime.Spec) error {
+	spec, ok := s.(*Spec)
+	if !ok {
+		return errors.New("invalid kubernetes spec")
+	}
+
+	// the objects are deleted even if the stage was canceled.
+	ctx = context.WithoutCancel(ctx)
+
+	var errs []error
+	if err := e.client.DeletePod(ctx, spec.Namespace, spec.PodName); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete pod: %w", err))
+	}
+	if err := e.client.DeleteSecret(ctx, spec.Namespace, spec.PodName); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete secret: %w", err))
+	}
+	if err := e.client.DeletePVC(ctx, spec.Namespace, spec.PodName); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete workspace volume claim: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run starts the container of the step, streams its logs to the output and waits for it to terminate.
+func (e *Engine) Run(ctx context.Context, s runtime.Spec, st runtime.Step, output io.Writer) (*runtime.State, error) {
+	spec, ok := s.(*Spec)
+	if !ok {
+		return nil, errors.New("invalid kubernetes spec")
+	}
+	step, ok := st.(*Step)
+	if !ok {
+		return nil, errors.New("invalid kubernetes step")
+	}
+
+	err := e.client.StartContainer(ctx, spec.Namespace, spec.PodName, step.Container, step.Image,
+		envAnnotations(step, step.GetEnviron()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start container of step: %w", err)
+	}
+
+	status, err := e.wait(ctx, spec, step, func(state ContainerState) bool {
+		return state.Running != nil || state.Terminated != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status.State.Terminated == nil {
+		logs, err := e.client.Logs(ctx, spec.Namespace, spec.PodName, step.Container)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("step", step.Name).Msg("kubernetes: failed to stream logs of step")
+		} else {
+			_, err = io.Copy(output, logs)
+			_ = logs.Close()
+			if err != nil && ctx.Err() == nil {
+				log.Ctx(ctx).Warn().Err(err).Str("step", step.Name).Msg("kubernetes: failed to stream logs of step")
+			}
+		}
+
+		status, err = e.wait(ctx, spec, step, func(state ContainerState) bool {
+			return state.Terminated != nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if err := e.copyLogs(ctx, spec, step, output); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("step", step.Name).Msg("kubernetes: failed to get logs of step")
+	}
+
+	terminated := status.State.Terminated
+	return &runtime.State{
+		ExitCode:  terminated.ExitCode,
+		Exited:    true,
+		OOMKilled: terminated.Reason == "OOMKilled",
+	}, nil
+}
+
+// copyLogs copies the logs of a terminated container to the output.
+func (e *Engine) copyLogs(ctx context.Context, spec *Spec, step *Step, output io.Writer) error {
+	logs, err := e.client.Logs(ctx, spec.Namespace, spec.PodName, step.Container)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	_, err = io.Copy(output, logs)
+	return err
+}
+
+// wait polls the pod until the container of the step runs the image of the step and is in the expected state.
+func (e *Engine) wait(
+	ctx context.Context,
+	spec *Spec,
+	step *Step,
+	done func(ContainerState) bool,
+) (*ContainerStatus, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		pod, err := e.client.GetPod(ctx, spec.Namespace, spec.PodName)
+		if errors.Is(err, errNotFound) {
+			return nil, errors.New("the pod of the stage was deleted")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod: %w", err)
+		}
+		if pod.Status.Phase == "Failed" {
+			return nil, fmt.Errorf("the pod of the stage failed: %s", pod.Status.Message)
+		}
+
+		for i := range pod.Status.ContainerStatuses {
+			status := &pod.Status.ContainerStatuses[i]
+			if status.Name != step.Container {
+				continue
+			}
+
+			if w := status.State.Waiting; w != nil {
+				if _, ok := waitingErrors[w.Reason]; ok {
+					return nil, fmt.Errorf("failed to start container of step: %s: %s", w.Reason, w.Message)
+				}
+			}
+
+			if sameImage(status.Image, step.Image) && done(status.State) {
+				return status, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Engine) meta(spec *Spec, annotations map[string]string) ObjectMeta {
+	return ObjectMeta{
+		Name:        spec.PodName,
+		Namespace:   spec.Namespace,
+		Labels:      spec.Labels,
+		Annotations: annotations,
+	}
+}
+
+func (e *Engine) container(spec *Spec, step *Step) Container {
+	var env []EnvVar
+	for i, key := range step.EnvKeys {
+		env = append(env, EnvVar{
+			Name: key,
+			ValueFrom: &EnvVarSource{
+				FieldRef: &ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", envAnnotation(step, i)),
+				},
+			},
+		})
+	}
+	for i, s := range step.Secrets {
+		env = append(env, EnvVar{
+			Name: s.Env,
+			ValueFrom: &EnvVarSource{
+				SecretKeyRef: &SecretKeySelector{Name: spec.PodName, Key: secretKey(step, i)},
+			},
+		})
+	}
+
+	return Container{
+		Name:       step.Container,
+		Image:      spec.PlaceholderImage,
+		Command:    step.Command,
+		Args:       step.Args,
+		WorkingDir: step.WorkingDir,
+		Env:        env,
+		Resources:  step.Resources,
+		VolumeMounts: []VolumeMount{{
+			Name:      workspaceVolume,
+			MountPath: workspace,
+		}},
+	}
+}
+
+// envAnnotations returns the annotations holding the environment variables of the step.
+// Only the environment variables the step had when it was compiled are passed to the container.
+func envAnnotations(step *Step, envs map[string]string) map[string]string {
+	annotations := make(map[string]string, len(step.EnvKeys))
+	for i, key := range step.EnvKeys {
+		annotations[envAnnotation(step, i)] = envs[key]
+	}
+	return annotations
+}
+
+// envAnnotation returns the annotation of the environment variable,
+// which is identified by its index as the names of environment variables aren't valid annotation keys.
+func envAnnotation(step *Step, i int) string {
+	return fmt.Sprintf("env.gitness.io/%s.%d", step.Container, i)
+}
+
+func secretKey(step *Step, i int) string {
+	return fmt.Sprintf("%s.%d", step.Container, i)
+}
+
+// sameImage returns true if the images are the same, ignoring the default registry, repository and tag.
+func sameImage(a, b string) bool {
+	return normalizeImage(a) == normalizeImage(b)
+}
+
+func normalizeImage(image string) string {
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "library/")
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i < 0 || i < strings.LastIndex(image, "/") {
+		image += ":latest"
+	}
+	return image
+}
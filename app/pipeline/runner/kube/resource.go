@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/drone/drone-go/drone"
+	droneyaml "github.com/drone/drone-yaml/yaml"
+	"github.com/drone/runner-go/manifest"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Kind is the kind of the resources executed by the kubernetes runner.
+	Kind = "pipeline"
+	// Type is the type of the resources executed by the kubernetes runner.
+	Type = "kubernetes"
+)
+
+func init() {
+	manifest.Register(parse)
+}
+
+// Pipeline is a pipeline with `type: kubernetes`.
+type Pipeline struct {
+	*droneyaml.Pipeline
+
+	// NodeSelector and Tolerations constrain the nodes the pod of the stage is scheduled on.
+	NodeSelector       map[string]string
+	Tolerations        []Toleration
+	ServiceAccountName string
+}
+
+// extensions are the kubernetes specific settings of the pipeline, which aren't part of the drone yaml.
+type extensions struct {
+	NodeSelector       map[string]string `yaml:"node_selector"`
+	Tolerations        []Toleration      `yaml:"tolerations"`
+	ServiceAccountName string            `yaml:"service_account_name"`
+}
+
+// Toleration is a kubernetes toleration of the pod of the stage.
+type Toleration struct {
+	Key               string `yaml:"key" json:"key,omitempty"`
+	Operator          string `yaml:"operator" json:"operator,omitempty"`
+	Value             string `yaml:"value" json:"value,omitempty"`
+	Effect            string `yaml:"effect" json:"effect,omitempty"`
+	TolerationSeconds *int64 `yaml:"toleration_seconds" json:"tolerationSeconds,omitempty"`
+}
+
+var _ manifest.Resource = (*Pipeline)(nil)
+
+// GetVersion returns the resource version.
+func (p *Pipeline) GetVersion() string { return p.Version }
+
+// GetKind returns the resource kind.
+func (p *Pipeline) GetKind() string { return p.Kind }
+
+// GetType returns the resource type.
+func (p *Pipeline) GetType() string { return p.Type }
+
+// GetName returns the resource name.
+func (p *Pipeline) GetName() string { return p.Name }
+
+// Lookup returns the named pipeline from the manifest.
+func Lookup(name string, m *manifest.Manifest) (manifest.Resource, error) {
+	for _, r := range m.Resources {
+		if r.GetName() == name && r.GetKind() == Kind && r.GetType() == Type {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("kubernetes pipeline %q not found", name)
+}
+
+// Lint checks the pipeline for settings the kubernetes runner doesn't support.
+func Lint(r manifest.Resource, _ *drone.Repo) error {
+	p, ok := r.(*Pipeline)
+	if !ok {
+		return errors.New("invalid kubernetes pipeline")
+	}
+
+	names := map[string]struct{}{}
+	for _, c := range append(append([]*droneyaml.Container{}, p.Services...), p.Steps...) {
+		if c.Name == "" {
+			return errors.New("the steps of the pipeline must have a name")
+		}
+		if _, ok := names[c.Name]; ok {
+			return fmt.Errorf("duplicate step name %q", c.Name)
+		}
+		names[c.Name] = struct{}{}
+		if c.Name == cloneStepName && !p.Clone.Disable {
+			return fmt.Errorf("step name %q is reserved for the clone step", cloneStepName)
+		}
+
+		if c.Image == "" {
+			return fmt.Errorf("step %q: image is required", c.Name)
+		}
+		if c.Privileged {
+			return fmt.Errorf("step %q: privileged steps aren't supported by the kubernetes runner", c.Name)
+		}
+		if len(c.Volumes) > 0 {
+			return fmt.Errorf("step %q: volumes aren't supported by the kubernetes runner", c.Name)
+		}
+	}
+
+	return nil
+}
+
+// parse parses the raw resource if it's a kubernetes pipeline.
+func parse(r *manifest.RawResource) (manifest.Resource, bool, error) {
+	if r.Kind != Kind || r.Type != Type {
+		return nil, false, nil
+	}
+
+	m, err := droneyaml.ParseBytes(r.Data)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to parse kubernetes pipeline: %w", err)
+	}
+
+	var pipeline *droneyaml.Pipeline
+	for _, res := range m.Resources {
+		if p, ok := res.(*droneyaml.Pipeline); ok {
+			pipeline = p
+			break
+		}
+	}
+	if pipeline == nil {
+		return nil, true, errors.New("failed to parse kubernetes pipeline")
+	}
+
+	var ext extensions
+	if err := yaml.Unmarshal(r.Data, &ext); err != nil {
+		return nil, true, fmt.Errorf("failed to parse kubernetes pipeline: %w", err)
+	}
+
+	return &Pipeline{
+		Pipeline:           pipeline,
+		NodeSelector:       ext.NodeSelector,
+		Tolerations:        ext.Tolerations,
+		ServiceAccountName: ext.ServiceAccountName,
+	}, true, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"github.com/drone/runner-go/pipeline/runtime"
+)
+
+// Spec is the compiled stage, which is executed as a pod with a container per step
+// and the workspace on a persistent volume claim.
+type Spec struct {
+	Namespace string
+	// PodName is the name of the pod, the persistent volume claim and the secret of the stage.
+	PodName            string
+	Labels             map[string]string
+	ServiceAccountName string
+	NodeSelector       map[string]string
+	Tolerations        []Toleration
+	StorageClass       string
+	WorkspaceSize      string
+	// PlaceholderImage is the image the containers run until their step is started.
+	PlaceholderImage string
+	Steps            []*Step
+}
+
+// Step is a compiled step, which runs as a container of the pod of the stage.
+type Step struct {
+	Name      string
+	Container string
+	Image     string
+	// Command replaces the entrypoint of the image, Args are passed to the entrypoint.
+	Command    []string
+	Args       []string
+	WorkingDir string
+	Envs       map[string]string
+	// EnvKeys are the names of the environment variables of the container, in order.
+	EnvKeys   []string
+	Secrets   []*Secret
+	Resources ResourceRequirements
+	DependsOn []string
+	ErrPolicy runtime.ErrPolicy
+	RunPolicy runtime.RunPolicy
+	Detach    bool
+}
+
+// Secret is a secret environment variable of a step.
+type Secret struct {
+	Name string
+	Env  string
+	Data []byte
+	Mask bool
+}
+
+var (
+	_ runtime.Spec   = (*Spec)(nil)
+	_ runtime.Step   = (*Step)(nil)
+	_ runtime.Secret = (*Secret)(nil)
+)
+
+// StepLen returns the number of steps.
+func (s *Spec) StepLen() int { return len(s.Steps) }
+
+// StepAt returns the step at the index.
+func (s *Spec) StepAt(i int) runtime.Step { return s.Steps[i] }
+
+// GetName returns the name of the step.
+func (s *Step) GetName() string { return s.Name }
+
+// GetDependencies returns the names of the steps the step depends on.
+func (s *Step) GetDependencies() []string { return s.DependsOn }
+
+// GetEnviron returns the environment variables of the step.
+func (s *Step) GetEnviron() map[string]string { return s.Envs }
+
+// SetEnviron sets the environment variables of the step.
+func (s *Step) SetEnviron(env map[string]string) { s.Envs = env }
+
+// GetErrPolicy returns the error policy of the step.
+func (s *Step) GetErrPolicy() runtime.ErrPolicy { return s.ErrPolicy }
+
+// GetRunPolicy returns the run policy of the step.
+func (s *Step) GetRunPolicy() runtime.RunPolicy { return s.RunPolicy }
+
+// GetSecretAt returns the secret at the index.
+func (s *Step) GetSecretAt(i int) runtime.Secret { return s.Secrets[i] }
+
+// GetSecretLen returns the number of secrets.
+func (s *Step) GetSecretLen() int { return len(s.Secrets) }
+
+// IsDetached returns true if the step runs in the background.
+func (s *Step) IsDetached() bool { return s.Detach }
+
+// GetImage returns the image of the step.
+func (s *Step) GetImage() string { return s.Image }
+
+// Clone returns a copy of the step.
+func (s *Step) Clone() runtime.Step {
+	out := *s
+	out.Envs = make(map[string]string, len(s.Envs))
+	for k, v := range s.Envs {
+		out.Envs[k] = v
+	}
+	return &out
+}
+
+// GetName returns the name of the secret.
+func (s *Secret) GetName() string { return s.Name }
+
+// GetValue returns the value of the secret.
+func (s *Secret) GetValue() string { return string(s.Data) }
+
+// IsMasked returns true if the secret is masked in the logs.
+func (s *Secret) IsMasked() bool { return s.Mask }
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+// The kubernetes objects used by the runner are limited to the fields the runner needs,
+// so they don't depend on the kubernetes client libraries.
+
+// ObjectMeta is the metadata of a kubernetes object.
+type ObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SecretObject is the secret holding the secret environment variables of the steps of a stage.
+type SecretObject struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Type       string            `json:"type"`
+	Data       map[string][]byte `json:"data"`
+}
+
+// PersistentVolumeClaim is the claim of the workspace volume of a stage.
+type PersistentVolumeClaim struct {
+	APIVersion string                    `json:"apiVersion"`
+	Kind       string                    `json:"kind"`
+	Metadata   ObjectMeta                `json:"metadata"`
+	Spec       PersistentVolumeClaimSpec `json:"spec"`
+}
+
+// PersistentVolumeClaimSpec is the spec of a persistent volume claim.
+type PersistentVolumeClaimSpec struct {
+	AccessModes      []string             `json:"accessModes"`
+	StorageClassName *string              `json:"storageClassName,omitempty"`
+	Resources        ResourceRequirements `json:"resources"`
+}
+
+// Pod is the pod of a stage.
+type Pod struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   ObjectMeta `json:"metadata"`
+	Spec       PodSpec    `json:"spec"`
+	Status     PodStatus  `json:"status,omitempty"`
+}
+
+// PodSpec is the spec of a pod.
+type PodSpec struct {
+	RestartPolicy      string            `json:"restartPolicy"`
+	ServiceAccountName string            `json:"serviceAccountName,omitempty"`
+	NodeSelector       map[string]string `json:"nodeSelector,omitempty"`
+	Tolerations        []Toleration      `json:"tolerations,omitempty"`
+	Volumes            []Volume          `json:"volumes,omitempty"`
+	Containers         []Container       `json:"containers"`
+}
+
+// Volume is a volume of a pod.
+type Volume struct {
+	Name                  string                             `json:"name"`
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+}
+
+// PersistentVolumeClaimVolumeSource references the persistent volume claim of a volume.
+type PersistentVolumeClaimVolumeSource struct {
+	ClaimName string `json:"claimName"`
+}
+
+// Container is the container of a step.
+type Container struct {
+	Name         string               `json:"name"`
+	Image        string               `json:"image"`
+	Command      []string             `json:"command,omitempty"`
+	Args         []string             `json:"args,omitempty"`
+	WorkingDir   string               `json:"workingDir,omitempty"`
+	Env          []EnvVar             `json:"env,omitempty"`
+	Resources    ResourceRequirements `json:"resources,omitempty"`
+	VolumeMounts []VolumeMount        `json:"volumeMounts,omitempty"`
+}
+
+// EnvVar is an environment variable of a container.
+type EnvVar struct {
+	Name      string        `json:"name"`
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty"`
+}
+
+// EnvVarSource is the source of the value of an environment variable,
+// either an annotation of the pod or a key of a secret.
+type EnvVarSource struct {
+	FieldRef     *ObjectFieldSelector `json:"fieldRef,omitempty"`
+	SecretKeyRef *SecretKeySelector   `json:"secretKeyRef,omitempty"`
+}
+
+// ObjectFieldSelector selects a field of the pod.
+type ObjectFieldSelector struct {
+	FieldPath string `json:"fieldPath"`
+}
+
+// SecretKeySelector selects a key of a secret.
+type SecretKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// VolumeMount is the mount of a volume in a container.
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+}
+
+// ResourceRequirements are the resource limits and requests of a container or the size of a volume.
+type ResourceRequirements struct {
+	Limits   map[string]string `json:"limits,omitempty"`
+	Requests map[string]string `json:"requests,omitempty"`
+}
+
+// PodStatus is the status of a pod.
+type PodStatus struct {
+	Phase             string            `json:"phase,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	ContainerStatuses []ContainerStatus `json:"containerStatuses,omitempty"`
+}
+
+// ContainerStatus is the status of a container of a pod.
+type ContainerStatus struct {
+	Name  string         `json:"name"`
+	Image string         `json:"image"`
+	State ContainerState `json:"state"`
+}
+
+// ContainerState is the state of a container, only one of the states is set.
+type ContainerState struct {
+	Waiting    *ContainerStateWaiting    `json:"waiting,omitempty"`
+	Running    *struct{}                 `json:"running,omitempty"`
+	Terminated *ContainerStateTerminated `json:"terminated,omitempty"`
+}
+
+// ContainerStateWaiting is the state of a container that is waiting to start.
+type ContainerStateWaiting struct {
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerStateTerminated is the state of a container that terminated.
+type ContainerStateTerminated struct {
+	ExitCode int    `json:"exitCode"`
+	Reason   string `json:"reason,omitempty"`
+}
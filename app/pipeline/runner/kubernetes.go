@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/pipeline/runner/kube"
+	"github.com/harness/gitness/types"
+
+	runnerclient "github.com/drone/runner-go/client"
+	"github.com/drone/runner-go/pipeline/reporter/history"
+	"github.com/drone/runner-go/pipeline/reporter/remote"
+	"github.com/drone/runner-go/pipeline/runtime"
+	"github.com/drone/runner-go/pipeline/uploader"
+	"github.com/drone/runner-go/secret"
+)
+
+// NewKubernetesRunner returns a runner which executes the stages of kubernetes pipelines as pods.
+func NewKubernetesRunner(
+	config *types.Config,
+	client runnerclient.Client,
+) (*runtime.Runner, error) {
+	kubeConfig := config.CI.Kubernetes
+
+	kubeClient, err := kube.NewClient(kubeConfig.APIServer, kubeConfig.Token, kubeConfig.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	compiler := &kube.Compiler{
+		Namespace:        kubeConfig.Namespace,
+		ServiceAccount:   kubeConfig.ServiceAccount,
+		StorageClass:     kubeConfig.StorageClass,
+		WorkspaceSize:    kubeConfig.WorkspaceSize,
+		PlaceholderImage: kubeConfig.PlaceholderImage,
+		CloneImage:       kubeConfig.CloneImage,
+		Secret:           secret.Encrypted(),
+	}
+
+	remote := remote.New(client)
+	upload := uploader.New(client)
+	tracer := history.New(remote)
+	exec := runtime.NewExecer(tracer, remote, upload,
+		kube.NewEngine(kubeClient), int64(config.CI.ParallelWorkers))
+
+	return &runtime.Runner{
+		Machine:  config.InstanceID,
+		Client:   client,
+		Reporter: tracer,
+		Lookup:   kube.Lookup,
+		Lint:     kube.Lint,
+		Compiler: compiler,
+		Exec:     exec.Exec,
+	}, nil
+}
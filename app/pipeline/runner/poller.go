@@ -20,11 +20,13 @@ import (
 	"runtime/debug"
 
 	"github.com/harness/gitness/app/pipeline/logger"
+	"github.com/harness/gitness/app/pipeline/runner/kube"
 
 	"github.com/drone-runners/drone-runner-docker/engine/resource"
 	runtime2 "github.com/drone-runners/drone-runner-docker/engine2/runtime"
 	"github.com/drone/drone-go/drone"
 	runnerclient "github.com/drone/runner-go/client"
+	"github.com/drone/runner-go/pipeline/runtime"
 	"github.com/drone/runner-go/poller"
 	"github.com/rs/zerolog/log"
 )
@@ -32,6 +34,35 @@ import (
 func NewExecutionPoller(
 	runner *runtime2.Runner,
 	client runnerclient.Client,
+) *poller.Poller {
+	return newPoller(runner.Run, client, &runnerclient.Filter{
+		Kind: resource.Kind,
+		Type: resource.Type,
+		// TODO: Check if other parameters are needed.
+	})
+}
+
+// KubernetesPoller polls the manager for the stages of kubernetes pipelines.
+type KubernetesPoller struct {
+	*poller.Poller
+}
+
+func NewKubernetesPoller(
+	runner *runtime.Runner,
+	client runnerclient.Client,
+) *KubernetesPoller {
+	return &KubernetesPoller{
+		Poller: newPoller(runner.Run, client, &runnerclient.Filter{
+			Kind: kube.Kind,
+			Type: kube.Type,
+		}),
+	}
+}
+
+func newPoller(
+	run func(context.Context, *drone.Stage) error,
+	client runnerclient.Client,
+	filter *runnerclient.Filter,
 ) *poller.Poller {
 	runWithRecovery := func(ctx context.Context, stage *drone.Stage) (err error) {
 		ctx = logger.WithUnwrappedZerolog(ctx)
@@ -45,16 +76,12 @@ func NewExecutionPoller(
 				log.Ctx(ctx).Error().Err(err).Msgf("An error occurred while calling runner.Run in Poller")
 			}
 		}()
-		return runner.Run(ctx, stage)
+		return run(ctx, stage)
 	}
 
 	return &poller.Poller{
 		Client:   client,
 		Dispatch: runWithRecovery,
-		Filter: &runnerclient.Filter{
-			Kind: resource.Kind,
-			Type: resource.Type,
-			// TODO: Check if other parameters are needed.
-		},
+		Filter:   filter,
 	}
 }
@@ -28,6 +28,7 @@ import (
 var WireSet = wire.NewSet(
 	ProvideExecutionRunner,
 	ProvideExecutionPoller,
+	ProvideKubernetesPoller,
 )
 
 // ProvideExecutionRunner provides an execution runner.
@@ -47,3 +48,22 @@ func ProvideExecutionPoller(
 ) *poller.Poller {
 	return NewExecutionPoller(runner, client)
 }
+
+// ProvideKubernetesPoller provides a poller which executes the stages of kubernetes pipelines,
+// it's nil if the kubernetes runner is disabled.
+func ProvideKubernetesPoller(
+	config *types.Config,
+	client runnerclient.Client,
+) (*KubernetesPoller, error) {
+	if !config.CI.Kubernetes.Enabled {
+		//nolint:nilnil // the kubernetes runner is optional.
+		return nil, nil
+	}
+
+	runner, err := NewKubernetesRunner(config, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKubernetesPoller(runner, client), nil
+}
@@ -16,6 +16,7 @@ package scheduler
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"time"
 
@@ -87,6 +88,7 @@ func (q *queue) Request(ctx context.Context, params Filter) (*types.Stage, error
 		kernel:  params.Kernel,
 		variant: params.Variant,
 		labels:  params.Labels,
+		tags:    params.Tags,
 		channel: make(chan *types.Stage),
 		done:    ctx.Done(),
 	}
@@ -150,6 +152,13 @@ func (q *queue) signal(ctx context.Context) error {
 			continue
 		}
 
+		// if the stage belongs to a concurrency group we need
+		// to make sure the executions of the group running in
+		// parallel don't exceed the limit of the group.
+		if !withinGroupLimits(item, items) {
+			continue
+		}
+
 		// if the system defines concurrency limits
 		// per repository we need to make sure those limits
 		// are not exceeded before proceeding.
@@ -191,6 +200,12 @@ func (q *queue) signal(ctx context.Context) error {
 				}
 			}
 
+			// stages that run on tags are only delegated to
+			// external runners that have all of the tags.
+			if !checkTags(item.RunsOn, w.tags) {
+				continue
+			}
+
 			select {
 			case w.channel <- item:
 			case <-w.done:
@@ -230,6 +245,7 @@ type worker struct {
 	kernel  string
 	variant string
 	labels  map[string]string
+	tags    []string
 	channel chan *types.Stage
 	done    <-chan struct{}
 }
@@ -246,6 +262,16 @@ func checkLabels(a, b map[string]string) bool {
 	return true
 }
 
+// checkTags returns true if all the required tags are in the tags.
+func checkTags(required, tags []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
 func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 	if stage.Limit == 0 {
 		return true
@@ -269,6 +295,34 @@ func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 	return count < stage.Limit
 }
 
+// withinGroupLimits returns true if the stage can run without exceeding the number of executions
+// of its concurrency group running in parallel. Executions of a group are started in order.
+func withinGroupLimits(stage *types.Stage, siblings []*types.Stage) bool {
+	if stage.ConcurrencyGroup == "" {
+		return true
+	}
+	limit := max(stage.ConcurrencyLimit, 1)
+
+	executions := map[int64]struct{}{}
+	for _, sibling := range siblings {
+		if sibling.RepoID != stage.RepoID {
+			continue
+		}
+		if sibling.ConcurrencyGroup != stage.ConcurrencyGroup {
+			continue
+		}
+		// stages of the same execution don't count against the limit.
+		if sibling.ExecutionID == stage.ExecutionID {
+			continue
+		}
+		if sibling.ExecutionID < stage.ExecutionID ||
+			sibling.Status == enum.CIStatusRunning {
+			executions[sibling.ExecutionID] = struct{}{}
+		}
+	}
+	return len(executions) < limit
+}
+
 func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool {
 	// if no throttle limit is defined (default) then
 	// return false to indicate no throttling is needed.
@@ -32,6 +32,9 @@ type Filter struct {
 	Kernel  string
 	Variant string
 	Labels  map[string]string
+	// Tags are the tags of an external runner, stages are only
+	// assigned if the runner has all the tags the stage runs on.
+	Tags []string
 }
 
 // Scheduler schedules Build stages for execution.
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// concurrencyGroupPipelinePrefix prefixes the pipeline identifier in the default concurrency group
+	// of a pipeline, which is used if the concurrency settings don't define a group.
+	concurrencyGroupPipelinePrefix = "pipeline/"
+
+	maxConcurrencyGroupLength = 256
+)
+
+// concurrencyDocument is a pipeline YAML document with the concurrency settings of its stage.
+// The limit of parallel stages with the same name (`concurrency.limit`) is handled by the drone yaml.
+type concurrencyDocument struct {
+	Kind        string `yaml:"kind"`
+	Name        string `yaml:"name"`
+	Concurrency struct {
+		Group            string `yaml:"group"`
+		MaxParallel      int    `yaml:"max_parallel"`
+		CancelInProgress bool   `yaml:"cancel_in_progress"`
+	} `yaml:"concurrency"`
+}
+
+// concurrency is the concurrency group of a pipeline.
+type concurrency struct {
+	// Group is the name of the group, unique within the repository.
+	Group string
+	// Limit is the max number of executions of the group running in parallel,
+	// executions exceeding it are queued.
+	Limit int
+	// Cancel is true if the incomplete executions of the group are canceled
+	// once a new execution of the group is triggered.
+	Cancel bool
+}
+
+// parseConcurrency returns the concurrency groups of the pipelines by pipeline name.
+// The group is defined by `concurrency.group` and can reference the variables of the execution,
+// e.g. `deploy-${DRONE_BRANCH}`. Pipelines that only define `concurrency.max_parallel`
+// or `concurrency.cancel_in_progress` are in the provided default group.
+func parseConcurrency(data []byte, vars map[string]string, defaultGroup string) (map[string]concurrency, error) {
+	out := map[string]concurrency{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc concurrencyDocument
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse concurrency: %w", err)
+		}
+
+		settings := doc.Concurrency
+		if doc.Kind != "pipeline" ||
+			settings.Group == "" && settings.MaxParallel == 0 && !settings.CancelInProgress {
+			continue
+		}
+
+		if settings.MaxParallel < 0 {
+			return nil, errors.New("concurrency max_parallel must not be negative")
+		}
+
+		group := strings.TrimSpace(os.Expand(settings.Group, func(name string) string {
+			return vars[name]
+		}))
+		if group == "" {
+			group = defaultGroup
+		}
+		if len(group) > maxConcurrencyGroupLength {
+			return nil, fmt.Errorf("concurrency group must not be longer than %d characters",
+				maxConcurrencyGroupLength)
+		}
+
+		limit := settings.MaxParallel
+		if limit == 0 {
+			limit = 1
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = "default"
+		}
+		out[name] = concurrency{
+			Group:  group,
+			Limit:  limit,
+			Cancel: settings.CancelInProgress,
+		}
+	}
+
+	return out, nil
+}
+
+// concurrencyVars returns the variables of the execution the concurrency group can reference.
+func concurrencyVars(execution *types.Execution) map[string]string {
+	return map[string]string{
+		"DRONE_BRANCH":        execution.Target,
+		"DRONE_SOURCE_BRANCH": execution.Source,
+		"DRONE_TARGET_BRANCH": execution.Target,
+		"DRONE_COMMIT_REF":    execution.Ref,
+		"DRONE_COMMIT_SHA":    execution.After,
+		"DRONE_BUILD_EVENT":   string(execution.Event),
+	}
+}
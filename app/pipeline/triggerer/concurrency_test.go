@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConcurrency(t *testing.T) {
+	vars := map[string]string{"DRONE_BRANCH": "main"}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]concurrency
+		wantErr bool
+	}{
+		{
+			name: "groups with variables and defaults",
+			data: `kind: pipeline
+concurrency:
+  group: deploy-${DRONE_BRANCH}
+  cancel_in_progress: true
+---
+kind: pipeline
+name: test
+concurrency:
+  max_parallel: 2
+---
+kind: pipeline
+name: lint
+concurrency:
+  limit: 1
+---
+kind: pipeline
+name: unknown
+concurrency:
+  group: ${DRONE_TAG}
+`,
+			want: map[string]concurrency{
+				"default": {Group: "deploy-main", Limit: 1, Cancel: true},
+				"test":    {Group: "pipeline/build", Limit: 2},
+				"unknown": {Group: "pipeline/build", Limit: 1},
+			},
+		},
+		{
+			name: "other kinds are ignored",
+			data: `kind: secret
+name: token
+concurrency:
+  group: deploy
+`,
+			want: map[string]concurrency{},
+		},
+		{
+			name: "negative max parallel",
+			data: `kind: pipeline
+concurrency:
+  max_parallel: -1
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid max parallel",
+			data: `kind: pipeline
+concurrency:
+  max_parallel: many
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseConcurrency([]byte(test.data), vars, "pipeline/build")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
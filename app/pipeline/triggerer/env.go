@@ -16,6 +16,7 @@ package triggerer
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/types"
@@ -41,5 +42,9 @@ func Envs(
 ) map[string]string {
 	return map[string]string{
 		"DRONE_BUILD_LINK": urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier, pipeline.Seq),
+		// steps upload artifacts with a PUT request to the url followed by the name of the artifact,
+		// authenticated with the netrc credentials of the execution.
+		"GITNESS_ARTIFACTS_URL": fmt.Sprintf("%s/v1/repos/%d/pipelines/%s/executions/%d/artifacts",
+			urlProvider.GetContainerAPIURL(ctx), repo.ID, pipeline.Identifier, pipeline.Seq),
 	}
 }
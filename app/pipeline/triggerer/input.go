@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+// inputNameRegex restricts input names to valid environment variable names.
+var inputNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// inputsDocument is a YAML document with input definitions.
+// Drone YAML defines them at the top level of a pipeline, v1 YAML in the spec of the pipeline.
+type inputsDocument struct {
+	Inputs yaml.Node `yaml:"inputs"`
+	Spec   struct {
+		Inputs yaml.Node `yaml:"inputs"`
+	} `yaml:"spec"`
+}
+
+type inputDefinition struct {
+	Type        enum.PipelineInputType `yaml:"type"`
+	Description string                 `yaml:"description"`
+	Default     string                 `yaml:"default"`
+	Required    bool                   `yaml:"required"`
+	Options     []string               `yaml:"options"`
+}
+
+// ParseInputs returns the input parameters defined in the pipeline YAML, in the order of their definition.
+// Inputs with the same name in multiple YAML documents are defined by the first document.
+func ParseInputs(data []byte) ([]*types.PipelineInput, error) {
+	var inputs []*types.PipelineInput
+	names := map[string]struct{}{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc inputsDocument
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+
+		for _, node := range []*yaml.Node{&doc.Inputs, &doc.Spec.Inputs} {
+			if node.Kind == 0 {
+				continue
+			}
+			if node.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d: inputs must be a map of input names to definitions", node.Line)
+			}
+
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				input, err := parseInput(node.Content[i], node.Content[i+1])
+				if err != nil {
+					return nil, err
+				}
+
+				if _, ok := names[input.Name]; ok {
+					continue
+				}
+				names[input.Name] = struct{}{}
+				inputs = append(inputs, input)
+			}
+		}
+	}
+
+	return inputs, nil
+}
+
+func parseInput(key, value *yaml.Node) (*types.PipelineInput, error) {
+	name := key.Value
+	if !inputNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("line %d: input name %q must consist of letters, digits and underscores "+
+			"and can't start with a digit", key.Line, name)
+	}
+
+	def := inputDefinition{}
+	if err := value.Decode(&def); err != nil {
+		return nil, fmt.Errorf("line %d: invalid definition of input %q: %w", value.Line, name, err)
+	}
+
+	inputType, ok := def.Type.Sanitize()
+	if !ok {
+		return nil, fmt.Errorf("line %d: input %q has unknown type %q", value.Line, name, def.Type)
+	}
+
+	input := &types.PipelineInput{
+		Name:        name,
+		Type:        inputType,
+		Description: def.Description,
+		Default:     def.Default,
+		Required:    def.Required,
+		Options:     def.Options,
+	}
+
+	if input.Type == enum.PipelineInputTypeChoice && len(input.Options) == 0 {
+		return nil, fmt.Errorf("line %d: input %q of type choice requires options", value.Line, name)
+	}
+
+	if input.Default != "" {
+		if _, err := inputValue(input, input.Default); err != nil {
+			return nil, fmt.Errorf("line %d: invalid default of input %q: %w", value.Line, name, err)
+		}
+	}
+
+	return input, nil
+}
+
+// ResolveInputs validates the provided values of the inputs and completes them with the defaults.
+// Values for unknown inputs are rejected. If requireAll is true, values are required
+// for all required inputs without a default.
+func ResolveInputs(
+	inputs []*types.PipelineInput,
+	values map[string]string,
+	requireAll bool,
+) (map[string]string, error) {
+	known := make(map[string]struct{}, len(inputs))
+	for _, input := range inputs {
+		known[input.Name] = struct{}{}
+	}
+	for name := range values {
+		if _, ok := known[name]; !ok {
+			return nil, check.NewValidationErrorf("The pipeline doesn't define the input '%s'.", name)
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	resolved := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		value := values[input.Name]
+		if value == "" {
+			value = input.Default
+		}
+
+		if value == "" {
+			if input.Required && requireAll {
+				return nil, check.NewValidationErrorf("The input '%s' is required.", input.Name)
+			}
+			continue
+		}
+
+		value, err := inputValue(input, value)
+		if err != nil {
+			return nil, check.NewValidationErrorf("Invalid value of input '%s': %s.", input.Name, err)
+		}
+
+		resolved[input.Name] = value
+	}
+
+	return resolved, nil
+}
+
+// resolveInputs resolves the input parameters of an execution from their definitions in the pipeline YAML.
+// Values of required inputs are only enforced when the execution is created manually.
+func resolveInputs(data []byte, values map[string]string) (map[string]string, error) {
+	inputs, err := ParseInputs(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input parameters: %w", err)
+	}
+
+	return ResolveInputs(inputs, values, false)
+}
+
+// inputValues returns the values of the input parameters for the expressions of v1 YAML.
+func inputValues(inputs map[string]string) map[string]interface{} {
+	values := make(map[string]interface{}, len(inputs))
+	for name, value := range inputs {
+		values[name] = value
+	}
+	return values
+}
+
+// inputValue validates the value of an input and returns it in its normalized form.
+func inputValue(input *types.PipelineInput, value string) (string, error) {
+	switch input.Type {
+	case enum.PipelineInputTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+	case enum.PipelineInputTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a boolean", value)
+		}
+		return strconv.FormatBool(b), nil
+	case enum.PipelineInputTypeChoice:
+		if !slices.Contains(input.Options, value) {
+			return "", fmt.Errorf("%q is not one of the options", value)
+		}
+	case enum.PipelineInputTypeString:
+	}
+
+	return value, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []*types.PipelineInput
+		wantErr bool
+	}{
+		{
+			name: "drone yaml",
+			data: `kind: pipeline
+name: default
+inputs:
+  version:
+    description: the version to release
+    required: true
+  dry_run:
+    type: boolean
+    default: true
+  env:
+    type: choice
+    options: [dev, prod]
+    default: dev
+steps:
+- name: build
+  image: alpine
+---
+kind: pipeline
+name: other
+inputs:
+  version:
+    type: number
+  retries:
+    type: number
+    default: 3
+`,
+			want: []*types.PipelineInput{
+				{
+					Name:        "version",
+					Type:        enum.PipelineInputTypeString,
+					Description: "the version to release",
+					Required:    true,
+				},
+				{Name: "dry_run", Type: enum.PipelineInputTypeBoolean, Default: "true"},
+				{Name: "env", Type: enum.PipelineInputTypeChoice, Default: "dev", Options: []string{"dev", "prod"}},
+				{Name: "retries", Type: enum.PipelineInputTypeNumber, Default: "3"},
+			},
+		},
+		{
+			name: "v1 yaml",
+			data: `version: 1
+kind: pipeline
+spec:
+  inputs:
+    target:
+      type: string
+      default: staging
+  stages: []
+`,
+			want: []*types.PipelineInput{
+				{Name: "target", Type: enum.PipelineInputTypeString, Default: "staging"},
+			},
+		},
+		{
+			name: "no inputs",
+			data: "kind: pipeline\nname: default\n",
+		},
+		{
+			name:    "invalid name",
+			data:    "inputs:\n  1version: {}\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			data:    "inputs:\n  version:\n    type: date\n",
+			wantErr: true,
+		},
+		{
+			name:    "choice without options",
+			data:    "inputs:\n  env:\n    type: choice\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid default",
+			data:    "inputs:\n  retries:\n    type: number\n    default: many\n",
+			wantErr: true,
+		},
+		{
+			name:    "inputs not a map",
+			data:    "inputs: [version]\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseInputs([]byte(test.data))
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveInputs(t *testing.T) {
+	inputs := []*types.PipelineInput{
+		{Name: "version", Type: enum.PipelineInputTypeString, Required: true},
+		{Name: "dry_run", Type: enum.PipelineInputTypeBoolean, Default: "true"},
+		{Name: "env", Type: enum.PipelineInputTypeChoice, Options: []string{"dev", "prod"}},
+	}
+
+	tests := []struct {
+		name       string
+		values     map[string]string
+		requireAll bool
+		want       map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "values and defaults",
+			values:     map[string]string{"version": "1.2", "env": "prod"},
+			requireAll: true,
+			want:       map[string]string{"version": "1.2", "dry_run": "true", "env": "prod"},
+		},
+		{
+			name:       "normalized boolean",
+			values:     map[string]string{"version": "1.2", "dry_run": "0"},
+			requireAll: true,
+			want:       map[string]string{"version": "1.2", "dry_run": "false"},
+		},
+		{
+			name:       "missing required",
+			values:     map[string]string{},
+			requireAll: true,
+			wantErr:    true,
+		},
+		{
+			name:   "missing required allowed",
+			values: nil,
+			want:   map[string]string{"dry_run": "true"},
+		},
+		{
+			name:       "unknown input",
+			values:     map[string]string{"version": "1.2", "debug": "true"},
+			requireAll: true,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid choice",
+			values:     map[string]string{"version": "1.2", "env": "qa"},
+			requireAll: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ResolveInputs(inputs, test.values, test.requireAll)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
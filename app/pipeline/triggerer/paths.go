@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// pathsDocument is a pipeline YAML document with the changed paths that trigger its stage.
+type pathsDocument struct {
+	Kind    string `yaml:"kind"`
+	Name    string `yaml:"name"`
+	Trigger struct {
+		Paths yaml.Node `yaml:"paths"`
+	} `yaml:"trigger"`
+}
+
+// pathFilter is the changed paths condition of a pipeline.
+type pathFilter struct {
+	// Include are the glob patterns of which at least one has to match a changed file.
+	// If empty, all changed files match unless they are excluded.
+	Include []string
+	// Exclude are the glob patterns of the changed files that are ignored.
+	Exclude []string
+}
+
+// parsePaths returns the changed paths conditions of the pipelines by pipeline name.
+// The condition is defined by `trigger.paths`, either as a list of glob patterns to include
+// or with `include` and `exclude` lists of glob patterns, e.g. `services/api/**`.
+func parsePaths(data []byte) (map[string]pathFilter, error) {
+	out := map[string]pathFilter{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc pathsDocument
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+
+		paths := &doc.Trigger.Paths
+		if doc.Kind != "pipeline" || paths.Kind == 0 {
+			continue
+		}
+
+		var filter pathFilter
+		switch paths.Kind {
+		case yaml.ScalarNode, yaml.SequenceNode:
+			filter.Include, err = decodePatterns(paths)
+		case yaml.MappingNode:
+			var settings struct {
+				Include yaml.Node `yaml:"include"`
+				Exclude yaml.Node `yaml:"exclude"`
+			}
+			if err = paths.Decode(&settings); err != nil {
+				return nil, fmt.Errorf("line %d: trigger paths must define include and exclude patterns", paths.Line)
+			}
+			if filter.Include, err = decodePatterns(&settings.Include); err != nil {
+				break
+			}
+			filter.Exclude, err = decodePatterns(&settings.Exclude)
+		default:
+			err = fmt.Errorf("line %d: trigger paths must be a list of glob patterns", paths.Line)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(filter.Include)+len(filter.Exclude) == 0 {
+			continue
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = "default"
+		}
+		out[name] = filter
+	}
+
+	return out, nil
+}
+
+// decodePatterns decodes a single glob pattern or a list of glob patterns and validates them.
+func decodePatterns(node *yaml.Node) ([]string, error) {
+	var patterns []string
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		patterns = []string{node.Value}
+	case yaml.SequenceNode:
+		if err := node.Decode(&patterns); err != nil {
+			return nil, fmt.Errorf("line %d: trigger paths must be a list of glob patterns", node.Line)
+		}
+	default:
+		return nil, fmt.Errorf("line %d: trigger paths must be a glob pattern or a list of glob patterns", node.Line)
+	}
+
+	for _, pattern := range patterns {
+		if !doublestar.ValidatePattern(pattern) {
+			return nil, fmt.Errorf("line %d: invalid trigger paths pattern %q", node.Line, pattern)
+		}
+	}
+
+	return patterns, nil
+}
+
+// match returns true if any of the changed files is included and not excluded by the filter.
+// If the changed files are unknown (nil), the filter always matches.
+func (f pathFilter) match(files []string) bool {
+	if files == nil {
+		return true
+	}
+
+	for _, file := range files {
+		if len(f.Include) > 0 && !matchAny(f.Include, file) {
+			continue
+		}
+		if matchAny(f.Exclude, file) {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func skipPaths(paths map[string]pathFilter, name string, files []string) bool {
+	filter, ok := paths[name]
+	return ok && !filter.match(files)
+}
+
+// changedFiles returns the files changed by the hook, between the previous commit of a push
+// or the merge base of a pull request and the triggering commit.
+// It returns nil if there is nothing to compare against (e.g. new branches and manual executions).
+func (t *triggerer) changedFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	hook *Hook,
+) ([]string, error) {
+	if hook.Before == "" || hook.Before == types.NilSHA || hook.Before == hook.After {
+		return nil, nil
+	}
+
+	out, err := t.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    hook.Before,
+		HeadRef:    hook.After,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	if out.Files == nil {
+		return []string{}, nil
+	}
+
+	return out.Files, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]pathFilter
+		wantErr bool
+	}{
+		{
+			name: "list of patterns and include and exclude",
+			data: `kind: pipeline
+trigger:
+  paths: [services/api/**, go.mod]
+---
+kind: pipeline
+name: web
+trigger:
+  paths:
+    include: web/**
+    exclude: ["**/*.md"]
+---
+kind: pipeline
+name: test
+trigger:
+  branch: main
+`,
+			want: map[string]pathFilter{
+				"default": {Include: []string{"services/api/**", "go.mod"}},
+				"web":     {Include: []string{"web/**"}, Exclude: []string{"**/*.md"}},
+			},
+		},
+		{
+			name: "other kinds are ignored",
+			data: `kind: secret
+name: token
+trigger:
+  paths: web/**
+`,
+			want: map[string]pathFilter{},
+		},
+		{
+			name: "invalid pattern",
+			data: `kind: pipeline
+trigger:
+  paths: ["web/[a"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid exclude",
+			data: `kind: pipeline
+trigger:
+  paths:
+    exclude:
+      docs: true
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parsePaths([]byte(test.data))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSkipPaths(t *testing.T) {
+	paths := map[string]pathFilter{
+		"api": {Include: []string{"services/api/**", "go.mod"}},
+		"web": {Include: []string{"web/**"}, Exclude: []string{"**/*.md"}},
+		"all": {Exclude: []string{"docs/**"}},
+	}
+
+	tests := []struct {
+		name     string
+		pipeline string
+		files    []string
+		want     bool
+	}{
+		{name: "included file changed", pipeline: "api", files: []string{"web/index.ts", "services/api/main.go"}},
+		{name: "root file changed", pipeline: "api", files: []string{"go.mod"}},
+		{name: "no included file changed", pipeline: "api", files: []string{"web/index.ts"}, want: true},
+		{name: "only excluded files changed", pipeline: "web", files: []string{"web/README.md"}, want: true},
+		{name: "excluded and included files changed", pipeline: "web", files: []string{"web/README.md", "web/app.ts"}},
+		{name: "exclude only", pipeline: "all", files: []string{"docs/index.md", "main.go"}},
+		{name: "exclude only without other changes", pipeline: "all", files: []string{"docs/index.md"}, want: true},
+		{name: "no changes", pipeline: "api", files: []string{}, want: true},
+		{name: "unknown changes", pipeline: "api", files: nil},
+		{name: "pipeline without paths", pipeline: "default", files: []string{"web/index.ts"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := skipPaths(paths, test.pipeline, test.files); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
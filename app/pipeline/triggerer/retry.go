@@ -0,0 +1,278 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/pipeline/checks"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNothingToRetry is returned if only the failed stages of an execution
+// are retried, but all of its stages succeeded.
+var ErrNothingToRetry = errors.New("execution has no failed stages to retry")
+
+// Retry triggers a new execution of the commit of the previous execution with the same parameters.
+// If failedOnly is set, only the stages of the previous execution that didn't succeed are executed again,
+// the successful stages are copied to the new execution along with their steps and logs.
+func (t *triggerer) Retry(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	previous *types.Execution,
+	principal *types.Principal,
+	failedOnly bool,
+) (*types.Execution, error) {
+	base := retryHook(previous, principal)
+	if !failedOnly {
+		return t.Trigger(ctx, pipeline, base)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("pipeline.id", pipeline.ID).
+		Int64("execution.number", previous.Number).
+		Logger()
+
+	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err := t.limiter.PipelineMinutes(ctx, repo.ID); err != nil {
+		log.Warn().Err(err).Msg("retry: pipeline minutes limit exceeded")
+		return nil, fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxPipelineMinutesReached)
+	}
+
+	previousStages, err := t.stageStore.ListWithSteps(ctx, previous.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages of execution: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	stages, ok := retryStages(previousStages, now)
+	if !ok {
+		return nil, ErrNothingToRetry
+	}
+
+	execution := &types.Execution{
+		RepoID:       repo.ID,
+		PipelineID:   pipeline.ID,
+		Trigger:      base.Trigger,
+		CreatedBy:    base.TriggeredBy,
+		Parent:       base.Parent,
+		Status:       enum.CIStatusPending,
+		Event:        previous.Event,
+		Action:       previous.Action,
+		Link:         previous.Link,
+		Title:        previous.Title,
+		Message:      previous.Message,
+		Before:       previous.Before,
+		After:        previous.After,
+		Ref:          previous.Ref,
+		Fork:         previous.Fork,
+		Source:       previous.Source,
+		Target:       previous.Target,
+		Author:       previous.Author,
+		AuthorName:   previous.AuthorName,
+		AuthorEmail:  previous.AuthorEmail,
+		AuthorAvatar: previous.AuthorAvatar,
+		Inputs:       previous.Inputs,
+		Debug:        previous.Debug,
+		Sender:       base.Sender,
+		Cron:         previous.Cron,
+		Created:      now,
+		Updated:      now,
+	}
+
+	pipeline, err = t.pipelineStore.IncrementSeqNum(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment execution sequence number: %w", err)
+	}
+	execution.Number = pipeline.Seq
+	execution.Params = combine(previous.Params, Envs(ctx, repo, pipeline, t.urlProvider))
+
+	err = t.createExecutionWithStages(ctx, execution, stages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create execution: %w", err)
+	}
+
+	// the steps of the reused stages refer to the logs of the previous execution.
+	t.copyLogs(ctx, previousStages, stages)
+
+	// try to write to check store. log on failure but don't error out the execution
+	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msg("retry: could not write to check store")
+	}
+
+	for _, stage := range stages {
+		if stage.Status != enum.CIStatusPending {
+			continue
+		}
+		err = t.scheduler.Schedule(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enqueue stage: %w", err)
+		}
+	}
+
+	return execution, nil
+}
+
+// retryHook returns the hook that triggers the previous execution again on behalf of the principal.
+func retryHook(previous *types.Execution, principal *types.Principal) *Hook {
+	return &Hook{
+		Parent:       previous.Number,
+		Trigger:      principal.UID,
+		TriggeredBy:  principal.ID,
+		Action:       previous.Action,
+		Link:         previous.Link,
+		Timestamp:    previous.Timestamp,
+		Title:        previous.Title,
+		Message:      previous.Message,
+		Before:       previous.Before,
+		After:        previous.After,
+		Ref:          previous.Ref,
+		Fork:         previous.Fork,
+		Source:       previous.Source,
+		Target:       previous.Target,
+		AuthorLogin:  previous.Author,
+		AuthorName:   previous.AuthorName,
+		AuthorEmail:  previous.AuthorEmail,
+		AuthorAvatar: previous.AuthorAvatar,
+		Debug:        previous.Debug,
+		Cron:         previous.Cron,
+		Sender:       principal.UID,
+		Params:       previous.Params,
+		Inputs:       previous.Inputs,
+	}
+}
+
+// retryStages returns the stages of the retry of the failed stages of an execution, in the order of its stages.
+// Successful stages are copied along with their steps, all other stages are reset to be executed again.
+// False is returned if all stages of the execution succeeded.
+func retryStages(previous []*types.Stage, now int64) ([]*types.Stage, bool) {
+	retried := map[string]bool{}
+	for _, stage := range previous {
+		if stage.Status != enum.CIStatusSuccess {
+			retried[stage.Name] = true
+		}
+	}
+	if len(retried) == 0 {
+		return nil, false
+	}
+
+	stages := make([]*types.Stage, len(previous))
+	for i, prev := range previous {
+		stage := &types.Stage{
+			RepoID:           prev.RepoID,
+			Number:           prev.Number,
+			Name:             prev.Name,
+			Kind:             prev.Kind,
+			Type:             prev.Type,
+			OS:               prev.OS,
+			Arch:             prev.Arch,
+			Variant:          prev.Variant,
+			Kernel:           prev.Kernel,
+			Limit:            prev.Limit,
+			LimitRepo:        prev.LimitRepo,
+			Created:          now,
+			Updated:          now,
+			OnSuccess:        prev.OnSuccess,
+			OnFailure:        prev.OnFailure,
+			DependsOn:        prev.DependsOn,
+			Labels:           prev.Labels,
+			Matrix:           prev.Matrix,
+			RunsOn:           prev.RunsOn,
+			ConcurrencyGroup: prev.ConcurrencyGroup,
+			ConcurrencyLimit: prev.ConcurrencyLimit,
+		}
+
+		if !retried[prev.Name] {
+			stage.Status = prev.Status
+			stage.Error = prev.Error
+			stage.ErrIgnore = prev.ErrIgnore
+			stage.ExitCode = prev.ExitCode
+			stage.Machine = prev.Machine
+			stage.Started = prev.Started
+			stage.Stopped = prev.Stopped
+			stage.Steps = make([]*types.Step, len(prev.Steps))
+			for j, step := range prev.Steps {
+				stage.Steps[j] = &types.Step{
+					Number:    step.Number,
+					Name:      step.Name,
+					Status:    step.Status,
+					Error:     step.Error,
+					ErrIgnore: step.ErrIgnore,
+					ExitCode:  step.ExitCode,
+					Started:   step.Started,
+					Stopped:   step.Stopped,
+					DependsOn: step.DependsOn,
+					Image:     step.Image,
+					Detached:  step.Detached,
+					Schema:    step.Schema,
+				}
+			}
+			stages[i] = stage
+			continue
+		}
+
+		// the stage waits for its dependencies that are executed again.
+		stage.Status = enum.CIStatusPending
+		for _, dep := range prev.DependsOn {
+			if retried[dep] {
+				stage.Status = enum.CIStatusWaitingOnDeps
+				break
+			}
+		}
+		stages[i] = stage
+	}
+
+	return stages, true
+}
+
+// copyLogs copies the logs of the steps of the reused stages to their copies.
+// Failures are only logged, the logs aren't required to execute the retried stages.
+func (t *triggerer) copyLogs(ctx context.Context, previous []*types.Stage, stages []*types.Stage) {
+	for i, stage := range stages {
+		for j, step := range stage.Steps {
+			if err := t.copyLog(ctx, previous[i].Steps[j].ID, step.ID); err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("step.id", step.ID).
+					Msg("retry: failed to copy step log")
+			}
+		}
+	}
+}
+
+func (t *triggerer) copyLog(ctx context.Context, fromStepID, toStepID int64) error {
+	rc, err := t.logStore.Find(ctx, fromStepID)
+	if err != nil {
+		return fmt.Errorf("failed to find log: %w", err)
+	}
+	defer rc.Close()
+
+	if err := t.logStore.Create(ctx, toStepID, rc); err != nil {
+		return fmt.Errorf("failed to create log: %w", err)
+	}
+
+	return nil
+}
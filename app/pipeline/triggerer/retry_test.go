@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRetryStages(t *testing.T) {
+	previous := []*types.Stage{
+		{
+			ID:     1,
+			Name:   "build",
+			Status: enum.CIStatusSuccess,
+			Steps:  []*types.Step{{ID: 10, StageID: 1, Number: 1, Name: "compile", Status: enum.CIStatusSuccess}},
+		},
+		{ID: 2, Name: "test", Status: enum.CIStatusFailure, Machine: "runner", DependsOn: []string{"build"}},
+		{ID: 3, Name: "deploy", Status: enum.CIStatusSkipped, DependsOn: []string{"test"}},
+	}
+
+	stages, ok := retryStages(previous, 100)
+	if !ok {
+		t.Fatal("expected failed stages to retry")
+	}
+	if len(stages) != len(previous) {
+		t.Fatalf("got %d stages, want %d", len(stages), len(previous))
+	}
+
+	build := stages[0]
+	if build.ID != 0 || build.Status != enum.CIStatusSuccess || len(build.Steps) != 1 {
+		t.Errorf("successful stage must be copied with its steps, got %+v", build)
+	}
+	if step := build.Steps[0]; step.ID != 0 || step.StageID != 0 || step.Name != "compile" {
+		t.Errorf("step must be copied without ids, got %+v", step)
+	}
+
+	test := stages[1]
+	if test.Status != enum.CIStatusPending || test.Machine != "" || len(test.Steps) != 0 {
+		t.Errorf("failed stage depending on successful stages must be pending, got %+v", test)
+	}
+
+	deploy := stages[2]
+	if deploy.Status != enum.CIStatusWaitingOnDeps {
+		t.Errorf("stage depending on retried stages must wait, got %s", deploy.Status)
+	}
+}
+
+func TestRetryStagesAllSucceeded(t *testing.T) {
+	previous := []*types.Stage{{Name: "build", Status: enum.CIStatusSuccess}}
+
+	if _, ok := retryStages(previous, 100); ok {
+		t.Error("expected nothing to retry")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// runsOnDocument is a pipeline YAML document with the runner tags its stage runs on.
+type runsOnDocument struct {
+	Kind   string    `yaml:"kind"`
+	Name   string    `yaml:"name"`
+	RunsOn yaml.Node `yaml:"runs_on"`
+}
+
+// parseRunsOn returns the runner tags of the pipelines by pipeline name.
+// The tags are defined by `runs_on`, either as a single tag or as a list of tags.
+// Stages with tags are only executed by external runners that have all of them.
+func parseRunsOn(data []byte) (map[string][]string, error) {
+	out := map[string][]string{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc runsOnDocument
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+
+		if doc.Kind != "pipeline" || doc.RunsOn.Kind == 0 {
+			continue
+		}
+
+		var tags []string
+		switch doc.RunsOn.Kind {
+		case yaml.ScalarNode:
+			tags = []string{doc.RunsOn.Value}
+		case yaml.SequenceNode:
+			if err := doc.RunsOn.Decode(&tags); err != nil {
+				return nil, fmt.Errorf("line %d: runs_on must be a list of runner tags", doc.RunsOn.Line)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: runs_on must be a runner tag or a list of runner tags", doc.RunsOn.Line)
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = "default"
+		}
+		out[name] = normalizeTags(tags)
+	}
+
+	return out, nil
+}
+
+// normalizeTags trims the tags and returns them sorted, without empty and duplicate tags.
+func normalizeTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRunsOn(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "single tag and list of tags",
+			data: `kind: pipeline
+runs_on: gpu
+steps:
+- name: train
+  image: python
+---
+kind: pipeline
+name: deploy
+runs_on: [prod, " linux ", prod, ""]
+---
+kind: pipeline
+name: test
+`,
+			want: map[string][]string{
+				"default": {"gpu"},
+				"deploy":  {"linux", "prod"},
+			},
+		},
+		{
+			name: "other kinds are ignored",
+			data: `kind: secret
+name: token
+runs_on: gpu
+`,
+			want: map[string][]string{},
+		},
+		{
+			name: "invalid runs_on",
+			data: `kind: pipeline
+runs_on:
+  tag: gpu
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseRunsOn([]byte(test.data))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
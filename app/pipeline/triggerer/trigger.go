@@ -21,8 +21,11 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/converter/matrix"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
 	"github.com/harness/gitness/app/pipeline/resolver"
@@ -31,6 +34,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -71,6 +75,7 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+	Inputs       map[string]string  `json:"inputs"`
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -78,12 +83,24 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// Retry triggers the execution again on behalf of the principal.
+	// If failedOnly is set, only the stages of the execution that didn't succeed are executed again.
+	Retry(
+		ctx context.Context,
+		pipeline *types.Pipeline,
+		execution *types.Execution,
+		principal *types.Principal,
+		failedOnly bool,
+	) (*types.Execution, error)
 }
 
 type triggerer struct {
 	executionStore   store.ExecutionStore
 	checkStore       store.CheckStore
 	stageStore       store.StageStore
+	stepStore        store.StepStore
+	logStore         store.LogStore
 	tx               dbtx.Transactor
 	pipelineStore    store.PipelineStore
 	fileService      file.Service
@@ -94,12 +111,17 @@ type triggerer struct {
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
 	publicAccess     publicaccess.Service
+	limiter          limiter.ResourceLimiter
+	canceler         canceler.Canceler
+	git              git.Interface
 }
 
 func New(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
 	pipelineStore store.PipelineStore,
 	tx dbtx.Transactor,
 	repoStore store.RepoStore,
@@ -110,11 +132,16 @@ func New(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	limiter limiter.ResourceLimiter,
+	canceler canceler.Canceler,
+	git git.Interface,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
 		checkStore:       checkStore,
 		stageStore:       stageStore,
+		stepStore:        stepStore,
+		logStore:         logStore,
 		scheduler:        scheduler,
 		urlProvider:      urlProvider,
 		tx:               tx,
@@ -125,6 +152,9 @@ func New(
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
 		publicAccess:     publicAccess,
+		limiter:          limiter,
+		canceler:         canceler,
+		git:              git,
 	}
 }
 
@@ -158,6 +188,11 @@ func (t *triggerer) Trigger(
 		return nil, err
 	}
 
+	if err := t.limiter.PipelineMinutes(ctx, repo.ID); err != nil {
+		log.Warn().Err(err).Msg("trigger: pipeline minutes limit exceeded")
+		return nil, fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxPipelineMinutesReached)
+	}
+
 	repoIsPublic, err := t.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
 	if err != nil {
 		return nil, fmt.Errorf("could not check if repo is public: %w", err)
@@ -169,6 +204,17 @@ func (t *triggerer) Trigger(
 		return nil, err
 	}
 
+	// Input parameters are passed to the steps as environment variables (execution params).
+	// They are only supported by YAML pipelines, templates would have to be converted first.
+	var execInputs map[string]string
+	if !converter.IsTemplate(pipeline.ConfigPath) {
+		execInputs, err = resolveInputs(file.Data, base.Inputs)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: invalid input parameters")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+	}
+
 	now := time.Now().UnixMilli()
 	execution := &types.Execution{
 		RepoID:     repo.ID,
@@ -193,7 +239,8 @@ func (t *triggerer) Trigger(
 		AuthorName:   base.AuthorName,
 		AuthorEmail:  base.AuthorEmail,
 		AuthorAvatar: base.AuthorAvatar,
-		Params:       base.Params,
+		Params:       combine(base.Params, execInputs),
+		Inputs:       execInputs,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
 		Cron:         base.Cron,
@@ -205,6 +252,8 @@ func (t *triggerer) Trigger(
 	// and creating stages accordingly. For V1 YAML - for now we can just parse the stages
 	// and create them sequentially.
 	stages := []*types.Stage{}
+	// concurrency groups whose incomplete executions are canceled by this execution.
+	var cancelGroups []string
 	//nolint:nestif // refactor if needed
 	if !isV1Yaml(file.Data) {
 		// Convert from jsonnet/starlark to drone yaml
@@ -233,6 +282,45 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
 		}
 
+		// matrix values of the pipelines expanded from a matrix, for per-axis status reporting.
+		matrices, err := matrix.Parse(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse matrix")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		// runner tags of the pipelines, their stages are delegated to the external runners with these tags.
+		runsOn, err := parseRunsOn(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse runs_on")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		// concurrency groups of the pipelines, executions of a group are queued or cancel the previous ones.
+		concurrencies, err := parseConcurrency(file.Data, concurrencyVars(execution),
+			concurrencyGroupPipelinePrefix+pipeline.Identifier)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse concurrency")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		// changed paths conditions of the pipelines, pipelines are skipped if none of their paths changed.
+		paths, err := parsePaths(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse trigger paths")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		// the changed files are only needed to evaluate the paths conditions.
+		// If they can't be determined, the paths conditions are ignored rather than skipping the pipelines.
+		var changedFiles []string
+		if len(paths) > 0 {
+			changedFiles, err = t.changedFiles(ctx, repo, base)
+			if err != nil {
+				log.Warn().Err(err).Msg("trigger: cannot get changed files, ignoring trigger paths")
+			}
+		}
+
 		var matched []*yaml.Pipeline
 		var dag = dag.New()
 		for _, document := range manifest.Resources {
@@ -264,6 +352,8 @@ func (t *triggerer) Trigger(
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match repo")
 			case skipCron(pipeline, base.Cron):
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match cron job")
+			case skipPaths(paths, name, changedFiles):
+				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match changed paths")
 			default:
 				matched = append(matched, pipeline)
 				node.Skip = false
@@ -303,6 +393,7 @@ func (t *triggerer) Trigger(
 				OnSuccess: onSuccess,
 				OnFailure: onFailure,
 				Labels:    match.Node,
+				Matrix:    matrices[match.Name],
 				Created:   now,
 				Updated:   now,
 			}
@@ -319,6 +410,14 @@ func (t *triggerer) Trigger(
 			if stage.Name == "" {
 				stage.Name = "default"
 			}
+			stage.RunsOn = runsOn[stage.Name]
+			if group, ok := concurrencies[stage.Name]; ok {
+				stage.ConcurrencyGroup = group.Group
+				stage.ConcurrencyLimit = group.Limit
+				if group.Cancel {
+					cancelGroups = append(cancelGroups, group.Group)
+				}
+			}
 			if len(stage.DependsOn) == 0 {
 				stage.Status = enum.CIStatusPending
 			}
@@ -370,6 +469,13 @@ func (t *triggerer) Trigger(
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
 
+	// cancel the superseded executions before scheduling, so the stages
+	// of this execution don't wait for them to complete.
+	err = t.cancelSuperseded(ctx, repo, execution, cancelGroups)
+	if err != nil {
+		log.Error().Err(err).Msg("trigger: could not cancel superseded executions")
+	}
+
 	for _, stage := range stages {
 		if stage.Status != enum.CIStatusPending {
 			continue
@@ -384,6 +490,58 @@ func (t *triggerer) Trigger(
 	return execution, nil
 }
 
+// cancelSuperseded cancels the incomplete executions with stages in the concurrency groups
+// that were triggered before the execution.
+func (t *triggerer) cancelSuperseded(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	groups []string,
+) error {
+	superseded := map[int64]struct{}{}
+	for _, group := range groups {
+		stages, err := t.stageStore.ListIncompleteByConcurrencyGroup(ctx, repo.ID, group)
+		if err != nil {
+			return fmt.Errorf("failed to list incomplete stages of concurrency group: %w", err)
+		}
+		for _, stage := range stages {
+			if stage.ExecutionID < execution.ID {
+				superseded[stage.ExecutionID] = struct{}{}
+			}
+		}
+	}
+
+	for executionID := range superseded {
+		previous, err := t.executionStore.Find(ctx, executionID)
+		if err != nil {
+			return fmt.Errorf("failed to find superseded execution: %w", err)
+		}
+
+		err = t.canceler.Cancel(ctx, repo, previous)
+		if err != nil {
+			return fmt.Errorf("failed to cancel superseded execution: %w", err)
+		}
+
+		log.Ctx(ctx).Info().
+			Int64("execution.id", previous.ID).
+			Int64("superseded_by.id", execution.ID).
+			Msg("trigger: canceled superseded execution")
+
+		pipeline, err := t.pipelineStore.Find(ctx, previous.PipelineID)
+		if err != nil {
+			return fmt.Errorf("failed to find pipeline of superseded execution: %w", err)
+		}
+
+		// Write to the checks store, log and ignore on errors
+		err = checks.Write(ctx, t.checkStore, previous, pipeline)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("could not update status check of superseded execution")
+		}
+	}
+
+	return nil
+}
+
 func trunc(s string, i int) string {
 	runes := []rune(s)
 	if len(runes) > i {
@@ -433,6 +591,7 @@ func parseV1Stages(
 	inputParams := map[string]interface{}{}
 	inputParams["repo"] = inputs.Repo(manager.ConvertToDroneRepo(repo, repoIsPublic))
 	inputParams["build"] = inputs.Build(manager.ConvertToDroneBuild(execution))
+	inputParams["inputs"] = inputValues(execution.Inputs)
 
 	var prevStage string
 
@@ -525,6 +684,15 @@ func (t *triggerer) createExecutionWithStages(
 			if err != nil {
 				return err
 			}
+
+			// only the stages reused by retries come with steps.
+			for _, step := range stage.Steps {
+				step.StageID = stage.ID
+				err := t.stepStore.Create(ctx, step)
+				if err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -15,12 +15,15 @@
 package triggerer
 
 import (
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
@@ -36,6 +39,8 @@ func ProvideTriggerer(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
 	tx dbtx.Transactor,
 	pipelineStore store.PipelineStore,
 	fileService file.Service,
@@ -46,8 +51,11 @@ func ProvideTriggerer(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	limiter limiter.ResourceLimiter,
+	canceler canceler.Canceler,
+	git git.Interface,
 ) Triggerer {
-	return New(executionStore, checkStore, stageStore, pipelineStore,
+	return New(executionStore, checkStore, stageStore, stepStore, logStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
-		templateStore, pluginStore, publicAccess)
+		templateStore, pluginStore, publicAccess, limiter, canceler, git)
 }
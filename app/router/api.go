@@ -27,15 +27,21 @@ import (
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/insights"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/milestone"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/release"
+	"github.com/harness/gitness/app/api/controller/replication"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -46,6 +52,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/controller/wiki"
 	"github.com/harness/gitness/app/api/handler/account"
 	handleraiagent "github.com/harness/gitness/app/api/handler/aiagent"
 	handlercapabilities "github.com/harness/gitness/app/api/handler/capabilities"
@@ -55,16 +62,22 @@ import (
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlergitspace "github.com/harness/gitness/app/api/handler/gitspace"
 	handlerinfraProvider "github.com/harness/gitness/app/api/handler/infraprovider"
+	handlerinsights "github.com/harness/gitness/app/api/handler/insights"
+	handlerissue "github.com/harness/gitness/app/api/handler/issue"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
+	handlermilestone "github.com/harness/gitness/app/api/handler/milestone"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
 	handlerpullreq "github.com/harness/gitness/app/api/handler/pullreq"
+	handlerrelease "github.com/harness/gitness/app/api/handler/release"
+	handlerreplication "github.com/harness/gitness/app/api/handler/replication"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	handlerreposettings "github.com/harness/gitness/app/api/handler/reposettings"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -76,15 +89,18 @@ import (
 	handlerUserGroup "github.com/harness/gitness/app/api/handler/usergroup"
 	"github.com/harness/gitness/app/api/handler/users"
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
+	handlerwiki "github.com/harness/gitness/app/api/handler/wiki"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/app/api/middleware/nocache"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	middlewareratelimit "github.com/harness/gitness/app/api/middleware/ratelimit"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/ratelimiter"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -136,6 +152,14 @@ func NewAPIHandler(
 	gitspaceCtrl *gitspace.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	replicationCtrl *replication.Controller,
+	issueCtrl *issue.Controller,
+	milestoneCtrl *milestone.Controller,
+	releaseCtrl *release.Controller,
+	insightsCtrl *insights.Controller,
+	wikiCtrl *wiki.Controller,
+	runnerCtrl *runner.Controller,
+	rateLimiter *ratelimiter.Service,
 ) http.Handler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -158,17 +182,23 @@ func NewAPIHandler(
 
 	r.Route("/v1", func(r chi.Router) {
 		// special methods that don't require authentication
-		setupAccountWithoutAuth(r, userCtrl, sysCtrl, config)
+		r.Group(func(r chi.Router) {
+			r.Use(middlewareratelimit.Limit(rateLimiter, enum.RateLimitClassAuth))
+			setupAccountWithoutAuth(r, userCtrl, sysCtrl, config)
+		})
 		setupSystem(r, config, sysCtrl)
-		setupResources(r)
+		setupResources(r, sysCtrl)
+		setupRunners(r, runnerCtrl)
 
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareauthn.Attempt(authenticator))
+			r.Use(middlewareratelimit.LimitAPI(rateLimiter))
 
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl, replicationCtrl,
+				issueCtrl, milestoneCtrl, releaseCtrl, insightsCtrl, wikiCtrl, runnerCtrl, sysCtrl)
 		})
 	})
 
@@ -220,20 +250,30 @@ func setupRoutesV1WithAuth(r chi.Router,
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	replicationCtrl *replication.Controller,
+	issueCtrl *issue.Controller,
+	milestoneCtrl *milestone.Controller,
+	releaseCtrl *release.Controller,
+	insightsCtrl *insights.Controller,
+	wikiCtrl *wiki.Controller,
+	runnerCtrl *runner.Controller,
+	sysCtrl *system.Controller,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, issueCtrl, milestoneCtrl,
+		releaseCtrl, insightsCtrl, wikiCtrl)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
 	setupAiAgent(r, aiagentCtrl, capabilitiesCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, userCtrl, repoCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
+	setupRoles(r, sysCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, replicationCtrl, runnerCtrl, sysCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -247,7 +287,7 @@ func setupSpaces(
 	appCtx context.Context,
 	spaceCtrl *space.Controller,
 	userGroupCtrl *usergroup.Controller,
-
+	webhookCtrl *webhook.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -269,7 +309,38 @@ func setupSpaces(
 			r.Get("/spaces", handlerspace.HandleListSpaces(spaceCtrl))
 			r.Get("/pipelines", handlerspace.HandleListPipelines(spaceCtrl))
 			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
-			r.Get("/usergroups", handlerUserGroup.HandleList(userGroupCtrl))
+			r.Get("/repo-topics", handlerspace.HandleListRepoTopics(spaceCtrl))
+			r.Route("/usergroups", func(r chi.Router) {
+				r.Get("/", handlerUserGroup.HandleList(userGroupCtrl))
+				r.Post("/", handlerUserGroup.HandleCreate(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupIdentifier), func(r chi.Router) {
+					r.Get("/", handlerUserGroup.HandleFind(userGroupCtrl))
+					r.Patch("/", handlerUserGroup.HandleUpdate(userGroupCtrl))
+					r.Delete("/", handlerUserGroup.HandleDelete(userGroupCtrl))
+
+					r.Route("/members", func(r chi.Router) {
+						r.Get("/", handlerUserGroup.HandleMemberList(userGroupCtrl))
+						r.Post("/", handlerUserGroup.HandleMemberAdd(userGroupCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamUserUID),
+							handlerUserGroup.HandleMemberDelete(userGroupCtrl))
+					})
+
+					r.Route("/subgroups", func(r chi.Router) {
+						r.Get("/", handlerUserGroup.HandleSubgroupList(userGroupCtrl))
+						r.Post("/", handlerUserGroup.HandleSubgroupAdd(userGroupCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamSubgroupIdentifier),
+							handlerUserGroup.HandleSubgroupDelete(userGroupCtrl))
+					})
+				})
+			})
+			r.Route("/usergroup-members", func(r chi.Router) {
+				r.Get("/", handlerUserGroup.HandleMembershipList(userGroupCtrl))
+				r.Post("/", handlerUserGroup.HandleMembershipAdd(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupID), func(r chi.Router) {
+					r.Patch("/", handlerUserGroup.HandleMembershipUpdate(userGroupCtrl))
+					r.Delete("/", handlerUserGroup.HandleMembershipDelete(userGroupCtrl))
+				})
+			})
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
@@ -277,8 +348,56 @@ func setupSpaces(
 			r.Get("/gitspaces", handlerspace.HandleListGitspaces(spaceCtrl))
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
+
+			r.Route("/repos-bulk", func(r chi.Router) {
+				r.Post("/", handlerspace.HandleRepoBulk(spaceCtrl))
+				r.Get(fmt.Sprintf("/{%s}", request.PathParamRepoBulkUID), handlerspace.HandleRepoBulkProgress(spaceCtrl))
+			})
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
+			r.Get("/repo-template", handlerspace.HandleRepoTemplateFind(spaceCtrl))
+			r.Put("/repo-template", handlerspace.HandleRepoTemplateUpdate(spaceCtrl))
+
+			r.Route("/review-sla", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleReviewSLAFind(spaceCtrl))
+				r.Put("/", handlerspace.HandleReviewSLAUpdate(spaceCtrl))
+				r.Get("/report", handlerspace.HandleReviewSLAReport(spaceCtrl))
+			})
+
+			// storage pools are part of the instance setup, only admins are allowed to assign them.
+			r.Route("/storage-pool", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleStoragePoolFind(spaceCtrl))
+				r.With(middlewareprincipal.RestrictToAdmin()).
+					Put("/", handlerspace.HandleStoragePoolUpdate(spaceCtrl))
+				r.With(middlewareprincipal.RestrictToAdmin()).
+					Post("/rebalance", handlerspace.HandleStoragePoolRebalance(spaceCtrl))
+			})
+
+			// quotas limit the resources available to a space, only admins are allowed to manage them.
+			r.Route("/quota", func(r chi.Router) {
+				r.Use(middlewareprincipal.RestrictToAdmin())
+				r.Get("/", handlerspace.HandleQuotaFind(spaceCtrl))
+				r.Put("/", handlerspace.HandleQuotaUpdate(spaceCtrl))
+			})
+
+			r.Route("/settings", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleSettingsFind(spaceCtrl))
+				r.Put("/", handlerspace.HandleSettingsUpdate(spaceCtrl))
+				r.Get("/effective", handlerspace.HandleSettingsEffective(spaceCtrl))
+			})
+
+			r.Get("/audit-logs", handlerspace.HandleAuditLogList(spaceCtrl))
+			r.Get("/audit-logs/export", handlerspace.HandleAuditLogExport(spaceCtrl))
+
+			r.Route(fmt.Sprintf("/file-templates/{%s}", request.PathParamFileTemplateType), func(r chi.Router) {
+				r.Get("/", handlerspace.HandleFileTemplateList(spaceCtrl))
+				r.Post("/", handlerspace.HandleFileTemplateCreate(spaceCtrl))
+
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamFileTemplateIdentifier), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleFileTemplateFind(spaceCtrl))
+					r.Delete("/", handlerspace.HandleFileTemplateDelete(spaceCtrl))
+				})
+			})
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -290,6 +409,7 @@ func setupSpaces(
 			})
 
 			SetupSpaceLabels(r, spaceCtrl)
+			SetupSpaceWebhook(r, webhookCtrl)
 		})
 	})
 }
@@ -327,6 +447,11 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	issueCtrl *issue.Controller,
+	milestoneCtrl *milestone.Controller,
+	releaseCtrl *release.Controller,
+	insightsCtrl *insights.Controller,
+	wikiCtrl *wiki.Controller,
 ) {
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -340,15 +465,26 @@ func setupRepos(r chi.Router,
 			r.Post("/purge", handlerrepo.HandlePurge(repoCtrl))
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
 			r.Post("/public-access", handlerrepo.HandleUpdatePublicAccess(repoCtrl))
+			r.Post("/archived", handlerrepo.HandleUpdateArchived(repoCtrl))
+			r.Post("/generate", handlerrepo.HandleGenerate(repoCtrl))
+			r.Post("/star", handlerrepo.HandleStar(repoCtrl))
+			r.Delete("/star", handlerrepo.HandleUnstar(repoCtrl))
 
 			r.Route("/settings", func(r chi.Router) {
 				r.Get("/security", handlerreposettings.HandleSecurityFind(repoSettingsCtrl))
 				r.Patch("/security", handlerreposettings.HandleSecurityUpdate(repoSettingsCtrl))
 				r.Get("/general", handlerreposettings.HandleGeneralFind(repoSettingsCtrl))
 				r.Patch("/general", handlerreposettings.HandleGeneralUpdate(repoSettingsCtrl))
+				r.Get("/effective", handlerreposettings.HandleEffective(repoSettingsCtrl))
+				r.Get("/reviewers", handlerreposettings.HandleReviewersFind(repoSettingsCtrl))
+				r.Patch("/reviewers", handlerreposettings.HandleReviewersUpdate(repoSettingsCtrl))
+				r.Get("/templates", handlerreposettings.HandleTemplatesList(repoSettingsCtrl))
 			})
 
 			r.Get("/summary", handlerrepo.HandleSummary(repoCtrl))
+			r.Get("/traffic", handlerrepo.HandleTraffic(repoCtrl))
+			r.Get("/template-drift", handlerrepo.HandleTemplateDrift(repoCtrl))
+			r.Post("/template-apply", handlerrepo.HandleTemplateApply(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
@@ -357,6 +493,15 @@ func setupRepos(r chi.Router,
 
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMembershipList(repoCtrl))
+				r.Post("/", handlerrepo.HandleMembershipAdd(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+					r.Delete("/", handlerrepo.HandleMembershipDelete(repoCtrl))
+					r.Patch("/", handlerrepo.HandleMembershipUpdate(repoCtrl))
+				})
+			})
+
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
 			// We don't expect there to be any other operations in that route (as that could overlap with file names)
@@ -365,27 +510,44 @@ func setupRepos(r chi.Router,
 			})
 
 			r.Get("/paths", handlerrepo.HandleListPaths(repoCtrl))
+			r.Get("/grep", handlerrepo.HandleGrep(repoCtrl))
 			r.Post("/path-details", handlerrepo.HandlePathsDetails(repoCtrl))
 
 			r.Route("/blame", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleBlame(repoCtrl))
 			})
 
+			r.Route("/blame-stream", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleBlameStream(repoCtrl))
+			})
+
 			r.Route("/raw", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route(fmt.Sprintf("/raw-ref/{%s}", request.PathParamGitRef), func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleRawRef(repoCtrl))
+			})
+
+			r.Post("/markdown", handlerrepo.HandleRenderMarkdown(repoCtrl))
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
 
 				r.Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
 				r.Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
+				r.Post("/apply-patch", handlerrepo.HandleApplyPatch(repoCtrl))
 
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
 					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+
+					// commit statuses reported by external CI/CD systems
+					r.Post("/statuses", handlercheck.HandleStatusReport(checkCtrl))
+					r.Get("/statuses", handlercheck.HandleCheckList(checkCtrl))
+					r.Get("/status", handlercheck.HandleCombinedStatus(checkCtrl))
 				})
 			})
 
@@ -419,13 +581,25 @@ func setupRepos(r chi.Router,
 			})
 
 			r.Post("/rebase", handlerrepo.HandleRebase(repoCtrl))
+			r.Post("/cherry-pick", handlerrepo.HandleCherryPick(repoCtrl))
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
+			r.Get("/compliance", handlerrepo.HandleComplianceReport(repoCtrl))
 
 			r.Get(fmt.Sprintf("/archive/%s", request.PathParamArchiveGitRef), handlerrepo.HandleArchive(repoCtrl))
 
 			SetupPullReq(r, pullreqCtrl)
 
+			SetupIssues(r, issueCtrl)
+
+			SetupMilestones(r, milestoneCtrl)
+
+			SetupReleases(r, releaseCtrl)
+
+			SetupWiki(r, wikiCtrl)
+
+			r.Get("/insights", handlerinsights.HandleGet(insightsCtrl))
+
 			SetupWebhook(r, webhookCtrl)
 
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
@@ -482,12 +656,31 @@ func setupPipelines(
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerpipeline.HandleCreate(pipelineCtrl))
 		r.Get("/generate", handlerrepo.HandlePipelineGenerate(repoCtrl))
+		r.Get("/cc.xml", handlerrepo.HandlePipelinesCCTray(repoCtrl))
+		r.Post("/validate", handlerpipeline.HandleValidate(pipelineCtrl))
+		r.Route("/failed-triggers", func(r chi.Router) {
+			r.Get("/", handlertrigger.HandleFailedList(triggerCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamTriggerRequestID), func(r chi.Router) {
+				r.Delete("/", handlertrigger.HandleFailedDelete(triggerCtrl))
+				r.Post("/retry", handlertrigger.HandleFailedRetry(triggerCtrl))
+			})
+		})
+		r.Route("/caches", func(r chi.Router) {
+			r.Get("/", handlerpipeline.HandleCacheUsage(pipelineCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamCacheKey), func(r chi.Router) {
+				r.Get("/", handlerpipeline.HandleCacheRestore(pipelineCtrl))
+				r.Put("/", handlerpipeline.HandleCacheSave(pipelineCtrl))
+				r.Delete("/", handlerpipeline.HandleCacheDelete(pipelineCtrl))
+			})
+		})
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPipelineIdentifier), func(r chi.Router) {
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
+			r.Get("/inputs", handlerexecution.HandleListInputs(executionCtrl))
 			setupExecutions(r, executionCtrl, logCtrl)
 			setupTriggers(r, triggerCtrl)
+			setupCrons(r, triggerCtrl)
 		})
 	})
 }
@@ -563,8 +756,16 @@ func setupExecutions(
 		r.Post("/", handlerexecution.HandleCreate(executionCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
+			r.Get("/graph", handlerexecution.HandleGraph(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Post("/retry", handlerexecution.HandleRetry(executionCtrl))
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Get("/*", handlerexecution.HandleDownloadArtifact(executionCtrl))
+				r.Put("/*", handlerexecution.HandleUploadArtifact(executionCtrl))
+				r.Delete("/*", handlerexecution.HandleDeleteArtifact(executionCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
@@ -595,6 +796,22 @@ func setupTriggers(
 	})
 }
 
+func setupCrons(
+	r chi.Router,
+	triggerCtrl *trigger.Controller,
+) {
+	r.Route("/crons", func(r chi.Router) {
+		r.Get("/", handlertrigger.HandleCronList(triggerCtrl))
+		r.Post("/", handlertrigger.HandleCronCreate(triggerCtrl))
+		r.Get("/upcoming", handlertrigger.HandleCronUpcoming(triggerCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamCronIdentifier), func(r chi.Router) {
+			r.Get("/", handlertrigger.HandleCronFind(triggerCtrl))
+			r.Patch("/", handlertrigger.HandleCronUpdate(triggerCtrl))
+			r.Delete("/", handlertrigger.HandleCronDelete(triggerCtrl))
+		})
+	})
+}
+
 func setupInternal(r chi.Router, githookCtrl *controllergithook.Controller, git git.Interface) {
 	r.Route("/internal", func(r chi.Router) {
 		SetupGitHooks(r, githookCtrl, git)
@@ -632,6 +849,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
+					r.Put("/", handlerpullreq.HandleReviewerRequest(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
 				})
 				r.Route("/usergroups", func(r chi.Router) {
@@ -648,6 +866,20 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Post("/rebase", handlerpullreq.HandleRebase(pullreqCtrl))
+			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Post("/backport", handlerpullreq.HandleBackport(pullreqCtrl))
+			r.Route("/dependencies", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleDependencyAdd(pullreqCtrl))
+				r.Get("/", handlerpullreq.HandleDependencyGraph(pullreqCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamDependencyID),
+					handlerpullreq.HandleDependencyRemove(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeCancel(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 			r.Route("/branch", func(r chi.Router) {
@@ -663,6 +895,8 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/codeowners", handlerpullreq.HandleCodeOwner(pullreqCtrl))
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.Get("/diff/files", handlerpullreq.HandleDiffFiles(pullreqCtrl))
+			r.Get("/diff/file", handlerpullreq.HandleDiffFile(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
 
 			setupPullReqLabels(r, pullreqCtrl)
@@ -702,6 +936,28 @@ func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	})
 }
 
+func SetupSpaceWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Post("/", handlerwebhook.HandleCreateSpace(webhookCtrl))
+		r.Get("/", handlerwebhook.HandleListSpace(webhookCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookIdentifier), func(r chi.Router) {
+			r.Get("/", handlerwebhook.HandleFindSpace(webhookCtrl))
+			r.Patch("/", handlerwebhook.HandleUpdateSpace(webhookCtrl))
+			r.Delete("/", handlerwebhook.HandleDeleteSpace(webhookCtrl))
+
+			r.Route("/executions", func(r chi.Router) {
+				r.Get("/", handlerwebhook.HandleListExecutionsSpace(webhookCtrl))
+
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
+					r.Get("/", handlerwebhook.HandleFindExecutionSpace(webhookCtrl))
+					r.Post("/retrigger", handlerwebhook.HandleRetriggerExecutionSpace(webhookCtrl))
+				})
+			})
+		})
+	})
+}
+
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Get("/recent", handlercheck.HandleCheckListRecent(checkCtrl))
@@ -712,6 +968,93 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	})
 }
 
+func SetupIssues(r chi.Router, issueCtrl *issue.Controller) {
+	r.Route("/issues", func(r chi.Router) {
+		r.Post("/", handlerissue.HandleCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleList(issueCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueNumber), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleUpdate(issueCtrl))
+			r.Post("/state", handlerissue.HandleState(issueCtrl))
+			r.Route("/comments", func(r chi.Router) {
+				r.Get("/", handlerissue.HandleCommentList(issueCtrl))
+				r.Post("/", handlerissue.HandleCommentCreate(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueCommentID), func(r chi.Router) {
+					r.Patch("/", handlerissue.HandleCommentUpdate(issueCtrl))
+					r.Delete("/", handlerissue.HandleCommentDelete(issueCtrl))
+				})
+			})
+			r.Route("/assignees", func(r chi.Router) {
+				r.Put("/", handlerissue.HandleAssigneeAdd(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamAssigneeID), func(r chi.Router) {
+					r.Delete("/", handlerissue.HandleAssigneeDelete(issueCtrl))
+				})
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Put("/", handlerissue.HandleAssignLabel(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Delete("/", handlerissue.HandleUnassignLabel(issueCtrl))
+				})
+			})
+		})
+	})
+}
+
+func SetupMilestones(r chi.Router, milestoneCtrl *milestone.Controller) {
+	r.Route("/milestones", func(r chi.Router) {
+		r.Post("/", handlermilestone.HandleCreate(milestoneCtrl))
+		r.Get("/", handlermilestone.HandleList(milestoneCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMilestoneID), func(r chi.Router) {
+			r.Get("/", handlermilestone.HandleFind(milestoneCtrl))
+			r.Patch("/", handlermilestone.HandleUpdate(milestoneCtrl))
+			r.Delete("/", handlermilestone.HandleDelete(milestoneCtrl))
+			r.Route(fmt.Sprintf("/pullreq/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
+				r.Put("/", handlermilestone.HandleAssignPullReq(milestoneCtrl))
+				r.Delete("/", handlermilestone.HandleUnassignPullReq(milestoneCtrl))
+			})
+			r.Route(fmt.Sprintf("/issues/{%s}", request.PathParamIssueNumber), func(r chi.Router) {
+				r.Put("/", handlermilestone.HandleAssignIssue(milestoneCtrl))
+				r.Delete("/", handlermilestone.HandleUnassignIssue(milestoneCtrl))
+			})
+		})
+	})
+}
+
+func SetupReleases(r chi.Router, releaseCtrl *release.Controller) {
+	r.Route("/releases", func(r chi.Router) {
+		r.Post("/", handlerrelease.HandleCreate(releaseCtrl))
+		r.Get("/", handlerrelease.HandleList(releaseCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamReleaseID), func(r chi.Router) {
+			r.Get("/", handlerrelease.HandleFind(releaseCtrl))
+			r.Patch("/", handlerrelease.HandleUpdate(releaseCtrl))
+			r.Delete("/", handlerrelease.HandleDelete(releaseCtrl))
+			r.Route("/assets", func(r chi.Router) {
+				r.Post("/", handlerrelease.HandleUploadAsset(releaseCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamReleaseAssetID), func(r chi.Router) {
+					r.Get("/", handlerrelease.HandleDownloadAsset(releaseCtrl))
+					r.Delete("/", handlerrelease.HandleDeleteAsset(releaseCtrl))
+				})
+			})
+		})
+	})
+}
+
+func SetupWiki(r chi.Router, wikiCtrl *wiki.Controller) {
+	r.Route("/wiki", func(r chi.Router) {
+		r.Route("/pages", func(r chi.Router) {
+			r.Get("/", handlerwiki.HandleListPages(wikiCtrl))
+			r.Post("/", handlerwiki.HandleCreatePage(wikiCtrl))
+			r.Get("/*", handlerwiki.HandleFindPage(wikiCtrl))
+			r.Patch("/*", handlerwiki.HandleUpdatePage(wikiCtrl))
+			r.Delete("/*", handlerwiki.HandleDeletePage(wikiCtrl))
+		})
+		r.Get("/history/*", handlerwiki.HandlePageHistory(wikiCtrl))
+	})
+}
+
 func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	r.Route("/rules", func(r chi.Router) {
 		r.Post("/", handlerrepo.HandleRuleCreate(repoCtrl))
@@ -725,13 +1068,18 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func setupUser(r chi.Router, userCtrl *user.Controller, repoCtrl *repo.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Get("/starred", handlerrepo.HandleListStarred(repoCtrl))
+		r.Put("/avatar", handleruser.HandleUploadAvatar(userCtrl))
+		r.Delete("/avatar", handleruser.HandleDeleteAvatar(userCtrl))
+		r.Get("/preferences", handleruser.HandleFindPreferences(userCtrl))
+		r.Patch("/preferences", handleruser.HandleUpdatePreferences(userCtrl))
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -741,12 +1089,14 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			// per token operations
 			r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypePAT))
+				r.Post("/rotate", handleruser.HandleRotateToken(userCtrl))
 			})
 		})
 
 		// SESSION TOKENS
 		r.Route("/sessions", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypeSession))
+			r.Delete("/", handleruser.HandleDeleteOtherSessions(userCtrl))
 
 			// per token operations
 			r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
@@ -758,8 +1108,48 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Route("/keys", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
 			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
+				handleruser.HandleFindPublicKey(userCtrl))
+			r.Patch(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
+				handleruser.HandleUpdatePublicKey(userCtrl))
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
 				handleruser.HandleDeletePublicKey(userCtrl))
+			r.Post(fmt.Sprintf("/{%s}/revoke", request.PathParamPublicKeyIdentifier),
+				handleruser.HandleRevokePublicKey(userCtrl))
+		})
+
+		// Emails
+		r.Route("/emails", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListEmails(userCtrl))
+			r.Post("/", handleruser.HandleAddEmail(userCtrl))
+			r.Post("/verify", handleruser.HandleVerifyEmail(userCtrl))
+
+			// per email operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamUserEmail), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeleteEmail(userCtrl))
+				r.Post("/resend-verification", handleruser.HandleResendEmailVerification(userCtrl))
+				r.Post("/primary", handleruser.HandleSetPrimaryEmail(userCtrl))
+			})
+		})
+
+		// Two-factor authentication
+		r.Route("/2fa", func(r chi.Router) {
+			r.Get("/", handleruser.HandleTwoFactorStatus(userCtrl))
+			r.Post("/totp", handleruser.HandleTwoFactorEnroll(userCtrl))
+			r.Post("/totp/confirm", handleruser.HandleTwoFactorConfirm(userCtrl))
+			r.Delete("/totp", handleruser.HandleTwoFactorDisable(userCtrl))
+			r.Post("/recovery-codes", handleruser.HandleTwoFactorRegenerateRecoveryCodes(userCtrl))
+		})
+
+		// WebAuthn credentials (passkeys and security keys)
+		r.Route("/webauthn", func(r chi.Router) {
+			r.Post("/register", handleruser.HandleBeginWebAuthnRegistration(userCtrl))
+			r.Get("/credentials", handleruser.HandleListWebAuthnCredentials(userCtrl))
+			r.Post("/credentials", handleruser.HandleFinishWebAuthnRegistration(userCtrl))
+			r.Patch(fmt.Sprintf("/credentials/{%s}", request.PathParamWebAuthnCredentialID),
+				handleruser.HandleUpdateWebAuthnCredential(userCtrl))
+			r.Delete(fmt.Sprintf("/credentials/{%s}", request.PathParamWebAuthnCredentialID),
+				handleruser.HandleRevokeWebAuthnCredential(userCtrl))
 		})
 	})
 }
@@ -771,6 +1161,7 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamServiceAccountUID), func(r chi.Router) {
 			r.Get("/", handlerserviceaccount.HandleFind(saCtrl))
+			r.Patch("/", handlerserviceaccount.HandleUpdate(saCtrl))
 			r.Delete("/", handlerserviceaccount.HandleDelete(saCtrl))
 
 			// SAT
@@ -781,6 +1172,7 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 				// per token operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
 					r.Delete("/", handlerserviceaccount.HandleDeleteToken(saCtrl))
+					r.Post("/rotate", handlerserviceaccount.HandleRotateToken(saCtrl))
 				})
 			})
 		})
@@ -792,13 +1184,40 @@ func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller)
 		r.Get("/health", handlersystem.HandleHealth)
 		r.Get("/version", handlersystem.HandleVersion)
 		r.Get("/config", handlersystem.HandleGetConfig(config, sysCtrl))
+		r.Get("/banners", handlersystem.HandleBannerListActive(sysCtrl))
 	})
 }
 
-func setupResources(r chi.Router) {
+func setupResources(r chi.Router, sysCtrl *system.Controller) {
 	r.Route("/resources", func(r chi.Router) {
-		r.Get("/gitignore", resource.HandleGitIgnores())
-		r.Get("/license", resource.HandleLicences())
+		r.Get("/gitignore", resource.HandleGitIgnores(sysCtrl))
+		r.Get("/license", resource.HandleLicences(sysCtrl))
+	})
+}
+
+// setupRunners sets up the routes used by external runners.
+// They don't use principal authentication, runners authenticate with the token of their registration.
+func setupRunners(r chi.Router, runnerCtrl *runner.Controller) {
+	r.Route("/runners", func(r chi.Router) {
+		r.Post("/register", handlerrunner.HandleRegister(runnerCtrl))
+		r.Post("/heartbeat", handlerrunner.HandleHeartbeat(runnerCtrl))
+
+		r.Route("/stages", func(r chi.Router) {
+			r.Post("/request", handlerrunner.HandleRequestStage(runnerCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamStageID), func(r chi.Router) {
+				r.Put("/", handlerrunner.HandleUpdateStage(runnerCtrl))
+				r.Post("/accept", handlerrunner.HandleAcceptStage(runnerCtrl))
+				r.Get("/details", handlerrunner.HandleStageDetails(runnerCtrl))
+				r.Get("/watch", handlerrunner.HandleWatchStage(runnerCtrl))
+
+				r.Route(fmt.Sprintf("/steps/{%s}", request.PathParamStepNumber), func(r chi.Router) {
+					r.Put("/", handlerrunner.HandleUpdateStep(runnerCtrl))
+					r.Post("/logs", handlerrunner.HandleWriteStepLogs(runnerCtrl))
+					r.Put("/logs", handlerrunner.HandleUploadStepLogs(runnerCtrl))
+				})
+			})
+		})
 	})
 }
 
@@ -806,12 +1225,23 @@ func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
 	r.Route("/principals", func(r chi.Router) {
 		r.Get("/", handlerprincipal.HandleList(principalCtrl))
 		r.Get(fmt.Sprintf("/{%s}", request.PathParamPrincipalID), handlerprincipal.HandleFind(principalCtrl))
+		r.Get(fmt.Sprintf("/{%s}/avatar", request.PathParamPrincipalID), handlerprincipal.HandleAvatar(principalCtrl))
 		r.Post("/check-emails", handlerprincipal.HandleCheckExistenceByEmail(principalCtrl))
 	})
 }
 
+func setupRoles(r chi.Router, sysCtrl *system.Controller) {
+	r.Route("/roles", func(r chi.Router) {
+		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
+		r.Get("/", handlersystem.HandleRoleList(sysCtrl))
+		r.Get(fmt.Sprintf("/{%s}", request.PathParamRoleIdentifier), handlersystem.HandleRoleFind(sysCtrl))
+	})
+}
+
 func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
+	r.Get("/search", handlerkeywordsearch.HandleGlobalSearch(searchCtrl))
 	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
+	r.Get("/search/code", handlerkeywordsearch.HandleSearchCode(searchCtrl))
 }
 
 func setupGitspaces(r chi.Router, gitspacesCtrl *gitspace.Controller) {
@@ -839,7 +1269,13 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(
+	r chi.Router,
+	userCtrl *user.Controller,
+	replicationCtrl *replication.Controller,
+	runnerCtrl *runner.Controller,
+	sysCtrl *system.Controller,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -853,6 +1289,94 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+
+		r.Route("/replication", func(r chi.Router) {
+			r.Get("/records", handlerreplication.HandleListRecords(replicationCtrl))
+
+			r.Route("/consumers", func(r chi.Router) {
+				r.Get("/", handlerreplication.HandleListConsumers(replicationCtrl))
+				r.Post("/", handlerreplication.HandleCreateConsumer(replicationCtrl))
+
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamReplicationConsumerIdentifier), func(r chi.Router) {
+					r.Get("/", handlerreplication.HandleFindConsumer(replicationCtrl))
+					r.Delete("/", handlerreplication.HandleDeleteConsumer(replicationCtrl))
+					r.Post("/ack", handlerreplication.HandleAckConsumer(replicationCtrl))
+				})
+			})
+		})
+
+		r.Route("/runners", func(r chi.Router) {
+			r.Get("/", handlerrunner.HandleList(runnerCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamRunnerIdentifier), handlerrunner.HandleDelete(runnerCtrl))
+		})
+
+		r.Route(fmt.Sprintf("/file-templates/{%s}", request.PathParamFileTemplateType), func(r chi.Router) {
+			r.Post("/", resource.HandleFileTemplateCreate(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamFileTemplateIdentifier), func(r chi.Router) {
+				r.Get("/", resource.HandleFileTemplateFind(sysCtrl))
+				r.Delete("/", resource.HandleFileTemplateDelete(sysCtrl))
+			})
+		})
+
+		r.Route("/oauth-providers", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleOAuthProviderList(sysCtrl))
+			r.Post("/", handlersystem.HandleOAuthProviderCreate(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamOAuthProviderIdentifier), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleOAuthProviderFind(sysCtrl))
+				r.Patch("/", handlersystem.HandleOAuthProviderUpdate(sysCtrl))
+				r.Delete("/", handlersystem.HandleOAuthProviderDelete(sysCtrl))
+			})
+		})
+
+		r.Route("/saml-providers", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleSAMLProviderList(sysCtrl))
+			r.Post("/", handlersystem.HandleSAMLProviderCreate(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSAMLProviderIdentifier), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleSAMLProviderFind(sysCtrl))
+				r.Patch("/", handlersystem.HandleSAMLProviderUpdate(sysCtrl))
+				r.Delete("/", handlersystem.HandleSAMLProviderDelete(sysCtrl))
+			})
+		})
+
+		r.Route("/ldap", func(r chi.Router) {
+			r.Route("/group-mappings", func(r chi.Router) {
+				r.Get("/", handlersystem.HandleLDAPGroupMappingList(sysCtrl))
+				r.Post("/", handlersystem.HandleLDAPGroupMappingCreate(sysCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamLDAPGroupMappingID),
+					handlersystem.HandleLDAPGroupMappingDelete(sysCtrl))
+			})
+
+			r.Post("/sync", handlersystem.HandleLDAPSync(sysCtrl))
+			r.Get("/sync/dry-run", handlersystem.HandleLDAPSyncDryRun(sysCtrl))
+		})
+
+		r.Route("/rate-limits", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleRateLimitsFind(sysCtrl))
+			r.Patch("/", handlersystem.HandleRateLimitsUpdate(sysCtrl))
+		})
+
+		r.Route("/roles", func(r chi.Router) {
+			r.Post("/", handlersystem.HandleRoleCreate(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRoleIdentifier), func(r chi.Router) {
+				r.Patch("/", handlersystem.HandleRoleUpdate(sysCtrl))
+				r.Delete("/", handlersystem.HandleRoleDelete(sysCtrl))
+			})
+		})
+
+		r.Route("/banners", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleBannerList(sysCtrl))
+			r.Post("/", handlersystem.HandleBannerCreate(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamBannerIdentifier), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleBannerFind(sysCtrl))
+				r.Patch("/", handlersystem.HandleBannerUpdate(sysCtrl))
+				r.Delete("/", handlersystem.HandleBannerDelete(sysCtrl))
+			})
+		})
 	})
 }
 
@@ -864,6 +1388,20 @@ func setupAccountWithoutAuth(
 ) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+	r.Post("/login/webauthn/begin", account.HandleLoginWebAuthnBegin(userCtrl))
+	r.Post("/login/webauthn", account.HandleLoginWebAuthn(userCtrl, cookieName))
+	r.Get("/login/oauth", account.HandleListOAuthProviders(sysCtrl))
+	r.Get(fmt.Sprintf("/login/oauth/{%s}", request.PathParamOAuthProviderIdentifier),
+		account.HandleLoginOAuth(userCtrl))
+	r.Get(fmt.Sprintf("/login/oauth/{%s}/callback", request.PathParamOAuthProviderIdentifier),
+		account.HandleLoginOAuthCallback(userCtrl, sysCtrl, cookieName))
+	r.Get("/login/saml", account.HandleListSAMLProviders(sysCtrl))
+	r.Get(fmt.Sprintf("/login/saml/{%s}", request.PathParamSAMLProviderIdentifier),
+		account.HandleLoginSAML(userCtrl))
+	r.Get(fmt.Sprintf("/login/saml/{%s}/metadata", request.PathParamSAMLProviderIdentifier),
+		account.HandleSAMLMetadata(sysCtrl))
+	r.Post(fmt.Sprintf("/login/saml/{%s}/acs", request.PathParamSAMLProviderIdentifier),
+		account.HandleLoginSAMLACS(userCtrl, cookieName))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 }
 
@@ -24,8 +24,10 @@ import (
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewareratelimit "github.com/harness/gitness/app/api/middleware/ratelimit"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/services/ratelimiter"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/types/enum"
 
@@ -39,6 +41,7 @@ func NewGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	rateLimiter *ratelimiter.Service,
 ) http.Handler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -55,6 +58,7 @@ func NewGitHandler(
 
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
+	r.Use(middlewareratelimit.Limit(rateLimiter, enum.RateLimitClassGit))
 
 	r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 		// routes that aren't coming from git
@@ -80,9 +84,9 @@ func NewGitHandler(
 			r.Get("/objects/info/http-alternates", stubGitHandler())
 			r.Get("/objects/info/packs", stubGitHandler())
 			r.Get("/objects/info/{file:[^/]*}", stubGitHandler())
-			r.Get("/objects/{head:[0-9a-f]{2}}/{hash:[0-9a-f]{38}}", stubGitHandler())
-			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.pack", stubGitHandler())
-			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.idx", stubGitHandler())
+			r.Get("/objects/{head:[0-9a-f]{2}}/{hash:(?:[0-9a-f]{38}|[0-9a-f]{62})}", stubGitHandler())
+			r.Get("/objects/pack/pack-{file:(?:[0-9a-f]{40}|[0-9a-f]{64})}.pack", stubGitHandler())
+			r.Get("/objects/pack/pack-{file:(?:[0-9a-f]{40}|[0-9a-f]{64})}.idx", stubGitHandler())
 		})
 	})
 
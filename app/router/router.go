@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/request"
+	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/logging"
 
 	"github.com/go-logr/logr"
@@ -30,15 +31,25 @@ import (
 
 type Router struct {
 	routers []Interface
+
+	// pathPrefix is the optional path prefix under which the server is served.
+	pathPrefix string
+
+	// trustForwardedHeaders indicates whether the forwarded headers of requests can be trusted.
+	trustForwardedHeaders bool
 }
 
 // NewRouter returns a new http.Handler that routes traffic
 // to the appropriate handlers.
 func NewRouter(
 	routers []Interface,
+	pathPrefix string,
+	trustForwardedHeaders bool,
 ) *Router {
 	return &Router{
-		routers: routers,
+		routers:               routers,
+		pathPrefix:            strings.TrimRight(pathPrefix, "/"),
+		trustForwardedHeaders: trustForwardedHeaders,
 	}
 }
 
@@ -54,6 +65,25 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			Str("http.original_url", req.URL.String())
 	})
 
+	if r.trustForwardedHeaders {
+		if forwarded, ok := url.ParseForwarded(req); ok {
+			req = req.WithContext(url.WithForwarded(req.Context(), forwarded))
+		}
+	}
+
+	// remove the path prefix (only if it's there) - routers expect paths relative to the server root.
+	if r.pathPrefix != "" && (req.URL.Path == r.pathPrefix || strings.HasPrefix(req.URL.Path, r.pathPrefix+"/")) {
+		if err := request.ReplacePrefix(req, r.pathPrefix, ""); err != nil {
+			log.Err(err).Msgf("Failed striping of path prefix for request.")
+			render.InternalError(ctx, w)
+			return
+		}
+		if req.URL.Path == "" {
+			req.URL.Path = "/"
+			req.URL.RawPath = ""
+		}
+	}
+
 	for _, router := range r.routers {
 		if ok := router.IsEligibleTraffic(req); ok {
 			req = req.WithContext(logging.NewContext(req.Context(), WithLoggingRouter(router.Name())))
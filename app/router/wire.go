@@ -26,15 +26,21 @@ import (
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/insights"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/milestone"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/release"
+	"github.com/harness/gitness/app/api/controller/replication"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -45,8 +51,10 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/controller/wiki"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/services/ratelimiter"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/registry/app/api"
@@ -109,9 +117,17 @@ func ProvideRouter(
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	replicationCtrl *replication.Controller,
+	issueCtrl *issue.Controller,
+	milestoneCtrl *milestone.Controller,
+	releaseCtrl *release.Controller,
+	insightsCtrl *insights.Controller,
+	wikiCtrl *wiki.Controller,
+	runnerCtrl *runner.Controller,
 	urlProvider url.Provider,
 	openapi openapi.Service,
 	registryRouter router.AppRouter,
+	rateLimiter *ratelimiter.Service,
 ) *Router {
 	routers := make([]Interface, 4)
 
@@ -120,6 +136,7 @@ func ProvideRouter(
 		urlProvider,
 		authenticator,
 		repoCtrl,
+		rateLimiter,
 	)
 	routers[0] = NewGitRouter(gitHandler, gitRoutingHost)
 	routers[1] = router.NewRegistryRouter(registryRouter)
@@ -129,11 +146,12 @@ func ProvideRouter(
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
 		githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		infraProviderCtrl, migrateCtrl, gitspaceCtrl, aiagentCtrl, capabilitiesCtrl)
+		infraProviderCtrl, migrateCtrl, gitspaceCtrl, aiagentCtrl, capabilitiesCtrl, replicationCtrl,
+		issueCtrl, milestoneCtrl, releaseCtrl, insightsCtrl, wikiCtrl, runnerCtrl, rateLimiter)
 	routers[2] = NewAPIRouter(apiHandler)
 
 	webHandler := NewWebHandler(config, authenticator, openapi)
 	routers[3] = NewWebRouter(webHandler)
 
-	return NewRouter(routers)
+	return NewRouter(routers, config.URL.PathPrefix, config.URL.TrustForwardedHeaders)
 }
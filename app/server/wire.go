@@ -16,6 +16,7 @@ package server
 
 import (
 	"github.com/harness/gitness/app/router"
+	"github.com/harness/gitness/app/shadow"
 	"github.com/harness/gitness/http"
 	"github.com/harness/gitness/types"
 
@@ -26,7 +27,7 @@ import (
 var WireSet = wire.NewSet(ProvideServer)
 
 // ProvideServer provides a server instance.
-func ProvideServer(config *types.Config, router *router.Router) *Server {
+func ProvideServer(config *types.Config, router *router.Router, mirror *shadow.Mirror) *Server {
 	return &Server{
 		http.NewServer(
 			http.Config{
@@ -35,7 +36,7 @@ func ProvideServer(config *types.Config, router *router.Router) *Server {
 				Acme:     config.Acme.Enabled,
 				AcmeHost: config.Acme.Host,
 			},
-			router,
+			mirror.Wrap(router),
 		),
 	}
 }
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+)
+
+const (
+	// linkScheme is the scheme clients can use in markdown to reference an attachment of the repository,
+	// e.g. ![screenshot](attachment://b9bd1d9e-0e7c-4e39-9a5e-c3d4ce3c0e4b.png).
+	linkScheme = "attachment://"
+
+	bucketPathFmt = "uploads/%d/%s"
+)
+
+var (
+	// fileNamePattern matches the file names of the uploaded attachments (uuid with an optional extension).
+	fileNamePattern = `[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}(?:\.[0-9A-Za-z]+)?`
+
+	schemeLinkRE  = regexp.MustCompile(regexp.QuoteMeta(linkScheme) + `(` + fileNamePattern + `)`)
+	uploadsLinkRE = regexp.MustCompile(`/uploads/(` + fileNamePattern + `)`)
+)
+
+// Service rewrites the attachment links in markdown texts and keeps track of the referenced attachments.
+type Service struct {
+	attachmentStore store.AttachmentStore
+	urlProvider     url.Provider
+}
+
+func NewService(attachmentStore store.AttachmentStore, urlProvider url.Provider) *Service {
+	return &Service{
+		attachmentStore: attachmentStore,
+		urlProvider:     urlProvider,
+	}
+}
+
+// BucketPath returns the path of an attachment file in the blob store.
+func BucketPath(repoID int64, fileName string) string {
+	return fmt.Sprintf(bucketPathFmt, repoID, fileName)
+}
+
+// SchemeLink returns the link that can be used in markdown to reference an attachment file.
+func SchemeLink(fileName string) string {
+	return linkScheme + fileName
+}
+
+// ProcessMarkdown replaces attachment scheme links in the provided markdown text with download links
+// and marks all attachments referenced by the text as used, which protects them from the garbage collection.
+// The download links use the repository ID, which makes them stable if the repository gets renamed or moved.
+func (s *Service) ProcessMarkdown(ctx context.Context, repoID int64, text string) (string, error) {
+	text = schemeLinkRE.ReplaceAllStringFunc(text, func(link string) string {
+		return s.urlProvider.GenerateAttachmentPath(ctx, repoID, link[len(linkScheme):])
+	})
+
+	matches := uploadsLinkRE.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	fileNames := make([]string, len(matches))
+	for i, match := range matches {
+		fileNames[i] = match[1]
+	}
+
+	if err := s.attachmentStore.MarkReferenced(ctx, repoID, fileNames); err != nil {
+		return "", fmt.Errorf("failed to mark attachments as referenced: %w", err)
+	}
+
+	return text, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attachment
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+)
+
+const (
+	testFile1 = "b9bd1d9e-0e7c-4e39-9a5e-c3d4ce3c0e4b.png"
+	testFile2 = "0c8e64c4-5a6e-4b16-9d0b-2f0c1d5e7a90"
+)
+
+type referencedAttachments struct {
+	store.AttachmentStore
+	repoID    int64
+	fileNames []string
+}
+
+func (s *referencedAttachments) MarkReferenced(_ context.Context, repoID int64, fileNames []string) error {
+	s.repoID = repoID
+	s.fileNames = fileNames
+	return nil
+}
+
+func TestService_ProcessMarkdown(t *testing.T) {
+	tests := []struct {
+		name          string
+		forwarded     *url.Forwarded
+		text          string
+		expText       string
+		expReferenced []string
+	}{
+		{
+			name:    "no-attachments",
+			text:    "plain text with a [link](https://example.com/uploads/file.png)",
+			expText: "plain text with a [link](https://example.com/uploads/file.png)",
+		},
+		{
+			name:          "scheme-link",
+			text:          "![screenshot](attachment://" + testFile1 + ")",
+			expText:       "![screenshot](/api/v1/repos/42/uploads/" + testFile1 + ")",
+			expReferenced: []string{testFile1},
+		},
+		{
+			name: "scheme-and-download-links",
+			text: "![a](attachment://" + testFile1 + ") and [b](/api/v1/repos/42/uploads/" + testFile2 + ")",
+			expText: "![a](/api/v1/repos/42/uploads/" + testFile1 + ") and " +
+				"[b](/api/v1/repos/42/uploads/" + testFile2 + ")",
+			expReferenced: []string{testFile1, testFile2},
+		},
+		{
+			name:          "forwarded-prefix",
+			forwarded:     &url.Forwarded{Scheme: "https", Host: "example.com", Prefix: "/gitness"},
+			text:          "![screenshot](attachment://" + testFile1 + ")",
+			expText:       "![screenshot](/gitness/api/v1/repos/42/uploads/" + testFile1 + ")",
+			expReferenced: []string{testFile1},
+		},
+	}
+
+	urlProvider, err := url.NewProvider(
+		"http://localhost:3000",
+		"http://host.docker.internal:3000",
+		"http://localhost:3000",
+		"http://localhost:3000/api",
+		"http://localhost:3000/git",
+		"",
+		"git",
+		false,
+		"http://localhost:3000",
+		"http://localhost:3000",
+	)
+	if err != nil {
+		t.Fatalf("failed to create url provider: %s", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.forwarded != nil {
+				ctx = url.WithForwarded(ctx, *test.forwarded)
+			}
+
+			attachmentStore := &referencedAttachments{}
+			svc := NewService(attachmentStore, urlProvider)
+
+			text, err := svc.ProcessMarkdown(ctx, 42, test.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if text != test.expText {
+				t.Errorf("text: want=%q got=%q", test.expText, text)
+			}
+
+			if !reflect.DeepEqual(attachmentStore.fileNames, test.expReferenced) {
+				t.Errorf("referenced: want=%v got=%v", test.expReferenced, attachmentStore.fileNames)
+			}
+			if test.expReferenced != nil && attachmentStore.repoID != 42 {
+				t.Errorf("referenced repo: want=42 got=%d", attachmentStore.repoID)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const exportPageSize = 100
+
+var csvHeader = []string{
+	"uid",
+	"timestamp",
+	"action",
+	"actor_id",
+	"actor_uid",
+	"actor_display_name",
+	"space_path",
+	"resource_type",
+	"resource_identifier",
+	"resource_data",
+	"client_ip",
+	"request_method",
+	"request_id",
+	"old_object",
+	"new_object",
+}
+
+// Export writes the audit events of the space in the time range of the filter to the writer,
+// the most recent first. The start of the time range is required, the end defaults to now.
+func (s *Service) Export(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.AuditEventFilter,
+	format enum.AuditLogExportFormat,
+	w io.Writer,
+) error {
+	if err := s.sanitizeExportFilter(filter); err != nil {
+		return err
+	}
+
+	var csvWriter *csv.Writer
+	if format == enum.AuditLogExportFormatCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(csvHeader); err != nil {
+			return fmt.Errorf("failed to write csv header: %w", err)
+		}
+	}
+
+	filter.Page = 1
+	filter.Size = exportPageSize
+
+	for {
+		events, err := s.auditEventStore.List(ctx, spaceID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		if err = s.fillActors(ctx, events); err != nil {
+			return err
+		}
+
+		if csvWriter != nil {
+			err = writeCSV(csvWriter, events)
+		} else {
+			err = writeJSONL(w, events)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(events) < filter.Size {
+			return nil
+		}
+
+		filter.Page++
+	}
+}
+
+func (s *Service) sanitizeExportFilter(filter *types.AuditEventFilter) error {
+	if filter.From <= 0 {
+		return errors.InvalidArgument("The start of the time range is required.")
+	}
+
+	if filter.To <= 0 {
+		filter.To = time.Now().UnixMilli()
+	}
+
+	if filter.To < filter.From {
+		return errors.InvalidArgument("The end of the time range must not be before its start.")
+	}
+
+	if s.exportMaxRange > 0 && time.Duration(filter.To-filter.From)*time.Millisecond > s.exportMaxRange {
+		return errors.InvalidArgument("The time range must not be longer than %s.", s.exportMaxRange)
+	}
+
+	return nil
+}
+
+// writeJSONL writes the audit events as JSON objects, one per line.
+func writeJSONL(w io.Writer, events []*types.AuditEvent) error {
+	enc := json.NewEncoder(w)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("failed to write audit event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeCSV writes the audit events as csv records, the header must be written before.
+func writeCSV(w *csv.Writer, events []*types.AuditEvent) error {
+	for _, event := range events {
+		resourceData, err := json.Marshal(event.ResourceData)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit event resource data: %w", err)
+		}
+
+		record := []string{
+			event.UID,
+			time.UnixMilli(event.Timestamp).UTC().Format(time.RFC3339Nano),
+			event.Action,
+			strconv.FormatInt(event.Actor.ID, 10),
+			event.Actor.UID,
+			event.Actor.DisplayName,
+			event.SpacePath,
+			event.ResourceType,
+			event.ResourceIdentifier,
+			string(resourceData),
+			event.ClientIP,
+			event.RequestMethod,
+			event.RequestID,
+			string(event.OldObject),
+			string(event.NewObject),
+		}
+
+		for i := range record {
+			record[i] = escapeCSVFormula(record[i])
+		}
+
+		if err = w.Write(record); err != nil {
+			return fmt.Errorf("failed to write audit event: %w", err)
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// escapeCSVFormula prevents spreadsheet applications from interpreting user provided values as formulas.
+func escapeCSVFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
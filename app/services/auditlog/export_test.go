@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeExportFilter(t *testing.T) {
+	s := &Service{exportMaxRange: 24 * time.Hour}
+
+	tests := []struct {
+		name    string
+		filter  types.AuditEventFilter
+		wantErr bool
+	}{
+		{
+			name:    "missing start",
+			filter:  types.AuditEventFilter{To: 1000},
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			filter:  types.AuditEventFilter{From: 2000, To: 1000},
+			wantErr: true,
+		},
+		{
+			name:    "range too long",
+			filter:  types.AuditEventFilter{From: 1000, To: 1000 + (25 * time.Hour).Milliseconds()},
+			wantErr: true,
+		},
+		{
+			name:   "valid",
+			filter: types.AuditEventFilter{From: 1000, To: 1000 + time.Hour.Milliseconds()},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.sanitizeExportFilter(&test.filter)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	events := []*types.AuditEvent{
+		{
+			UID:                "uid-1",
+			Timestamp:          0,
+			Action:             "created",
+			Actor:              types.PrincipalInfo{ID: 7, UID: "jdoe", DisplayName: "=HYPERLINK()"},
+			SpacePath:          "space",
+			ResourceType:       "repository",
+			ResourceIdentifier: "repo",
+			ResourceData:       map[string]string{"repoName": "repo"},
+			NewObject:          json.RawMessage(`{"a":1}`),
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	require.NoError(t, w.Write(csvHeader))
+	require.NoError(t, writeCSV(w, events))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Len(t, records[1], len(csvHeader))
+
+	require.Equal(t, "uid-1", records[1][0])
+	require.Equal(t, "1970-01-01T00:00:00Z", records[1][1])
+	require.Equal(t, "7", records[1][3])
+	require.Equal(t, "'=HYPERLINK()", records[1][5])
+	require.Equal(t, `{"repoName":"repo"}`, records[1][9])
+	require.Equal(t, "", records[1][13])
+	require.Equal(t, `{"a":1}`, records[1][14])
+}
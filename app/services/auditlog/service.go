@@ -0,0 +1,227 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+)
+
+var _ audit.Service = (*Service)(nil)
+
+// Service implements audit.Service by recording the audit events in the database,
+// where they can be queried and exported per space. If an external sink is configured,
+// the recorded audit events are added to the outbox and streamed to the sink.
+type Service struct {
+	tx                 dbtx.Transactor
+	auditEventStore    store.AuditEventStore
+	outboxStore        store.AuditEventOutboxStore
+	spaceStore         store.SpaceStore
+	principalInfoCache store.PrincipalInfoCache
+	scheduler          *job.Scheduler
+
+	exportMaxRange time.Duration
+
+	sink            Sink
+	streamCRON      string
+	streamMaxDur    time.Duration
+	streamBatchSize int
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	auditEventStore store.AuditEventStore,
+	outboxStore store.AuditEventOutboxStore,
+	spaceStore store.SpaceStore,
+	principalInfoCache store.PrincipalInfoCache,
+	scheduler *job.Scheduler,
+	exportMaxRange time.Duration,
+	sink Sink,
+	streamCRON string,
+	streamMaxDur time.Duration,
+	streamBatchSize int,
+) *Service {
+	return &Service{
+		tx:                 tx,
+		auditEventStore:    auditEventStore,
+		outboxStore:        outboxStore,
+		spaceStore:         spaceStore,
+		principalInfoCache: principalInfoCache,
+		scheduler:          scheduler,
+		exportMaxRange:     exportMaxRange,
+		sink:               sink,
+		streamCRON:         streamCRON,
+		streamMaxDur:       streamMaxDur,
+		streamBatchSize:    streamBatchSize,
+	}
+}
+
+// Log records the audit event of an operation performed by the user on the resource of the space.
+// The client IP, request method and request ID are taken from the context if not provided as options.
+func (s *Service) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	spacePath string,
+	options ...audit.Option,
+) error {
+	event := audit.Event{
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      audit.GetRealIP(ctx),
+		RequestMethod: audit.GetRequestMethod(ctx),
+	}
+
+	for _, opt := range options {
+		opt.Apply(&event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+
+	space, err := s.spaceStore.FindByRef(ctx, event.SpacePath)
+	if err != nil {
+		return fmt.Errorf("failed to find space of audit event: %w", err)
+	}
+
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal old object of audit event: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new object of audit event: %w", err)
+	}
+
+	auditEvent := &types.AuditEvent{
+		UID:       event.ID,
+		Timestamp: event.Timestamp,
+		Action:    string(event.Action),
+		Actor: types.PrincipalInfo{
+			ID:  event.User.ID,
+			UID: event.User.UID,
+		},
+		SpaceID:            space.ID,
+		SpacePath:          space.Path,
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		ResourceData:       event.Resource.Data,
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		RequestID:          audit.GetRequestID(ctx),
+	}
+
+	// the audit event and its outbox entry are stored together, unless the caller is already in a transaction.
+	storeEvent := func(ctx context.Context) error {
+		if err := s.auditEventStore.Create(ctx, auditEvent); err != nil {
+			return fmt.Errorf("failed to store audit event: %w", err)
+		}
+
+		if s.sink == nil {
+			return nil
+		}
+
+		err := s.outboxStore.Create(ctx, &types.AuditEventOutbox{
+			AuditEventID: auditEvent.ID,
+			Created:      time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add audit event to the outbox: %w", err)
+		}
+
+		return nil
+	}
+
+	if s.sink == nil || dbtx.GetTransaction(ctx) != nil {
+		return storeEvent(ctx)
+	}
+
+	return s.tx.WithTx(ctx, storeEvent)
+}
+
+// List returns the audit events of the space, the most recent first, along with the total count.
+func (s *Service) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	events, err := s.auditEventStore.List(ctx, spaceID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	count, err := s.auditEventStore.Count(ctx, spaceID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	if err = s.fillActors(ctx, events); err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
+
+// fillActors replaces the actor IDs and UIDs stored with the audit events with the full principal info.
+// Actors that don't exist anymore are left as they are.
+func (s *Service) fillActors(ctx context.Context, events []*types.AuditEvent) error {
+	actorIDs := make([]int64, len(events))
+	for i, event := range events {
+		actorIDs[i] = event.Actor.ID
+	}
+
+	actors, err := s.principalInfoCache.Map(ctx, actorIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load audit event actors: %w", err)
+	}
+
+	for _, event := range events {
+		if actor, ok := actors[event.Actor.ID]; ok {
+			event.Actor = *actor
+		}
+	}
+
+	return nil
+}
+
+func marshalObject(obj any) (json.RawMessage, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(obj)
+}
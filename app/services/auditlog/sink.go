@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	SinkWebhook = "webhook"
+	SinkSyslog  = "syslog"
+	SinkS3      = "s3"
+)
+
+// Sink delivers audit events to an external system. A batch is either accepted as a whole or rejected,
+// a rejected batch is sent again later.
+type Sink interface {
+	Send(ctx context.Context, events []*types.AuditEvent) error
+}
+
+// newSink returns the sink defined in the config, nil if audit log streaming isn't configured.
+func newSink(config *types.Config) (Sink, error) {
+	stream := config.AuditLog.Stream
+
+	switch stream.Sink {
+	case "":
+		return nil, nil //nolint:nilnil // streaming is disabled
+	case SinkWebhook:
+		if stream.Webhook.URL == "" {
+			return nil, errors.New("audit log stream webhook URL is required")
+		}
+
+		identity := config.Webhook.HeaderIdentity
+		if identity == "" {
+			identity = config.Webhook.UserAgentIdentity
+		}
+
+		return newWebhookSink(stream.Webhook.URL, stream.Webhook.Secret, stream.Webhook.Timeout, identity), nil
+	case SinkSyslog:
+		if stream.Syslog.Address == "" {
+			return nil, errors.New("audit log stream syslog address is required")
+		}
+
+		return newSyslogSink(stream.Syslog.Network, stream.Syslog.Address, stream.Syslog.AppName)
+	case SinkS3:
+		if stream.S3.Bucket == "" {
+			return nil, errors.New("audit log stream s3 bucket is required")
+		}
+
+		return newS3Sink(stream.S3.Bucket, stream.S3.Prefix, stream.S3.Endpoint, stream.S3.PathStyle)
+	default:
+		return nil, fmt.Errorf("unknown audit log stream sink %q", stream.Sink)
+	}
+}
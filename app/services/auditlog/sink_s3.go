@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// s3Sink uploads every batch of audit events as a JSONL object.
+// Objects are grouped by the day of the first audit event and named after the IDs of the first
+// and the last audit event, so a batch delivered again overwrites the object uploaded before.
+type s3Sink struct {
+	bucket   string
+	prefix   string
+	uploader *s3manager.Uploader
+}
+
+func newS3Sink(bucket, prefix, endpoint string, pathStyle bool) (*s3Sink, error) {
+	disableSSL := false
+	if endpoint != "" {
+		disableSSL = !strings.HasPrefix(endpoint, "https://")
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		DisableSSL:       aws.Bool(disableSSL),
+		S3ForcePathStyle: aws.Bool(pathStyle),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 session: %w", err)
+	}
+
+	return &s3Sink{
+		bucket:   bucket,
+		prefix:   prefix,
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (s *s3Sink) Send(ctx context.Context, events []*types.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeJSONL(buf, events); err != nil {
+		return err
+	}
+
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:         aws.String("private"),
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.key(events)),
+		Body:        buf,
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload audit events: %w", err)
+	}
+
+	return nil
+}
+
+func (s *s3Sink) key(events []*types.AuditEvent) string {
+	first, last := events[0], events[len(events)-1]
+	day := time.UnixMilli(first.Timestamp).UTC().Format("2006/01/02")
+	return path.Join("/", s.prefix, day, fmt.Sprintf("%d-%d.jsonl", first.ID, last.ID))
+}
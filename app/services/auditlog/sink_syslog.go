@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	syslogDialTimeout = 10 * time.Second
+	syslogSendTimeout = 30 * time.Second
+
+	// syslogPriority is the priority of the audit event messages: facility local0 (16), severity info (6).
+	syslogPriority = 16*8 + 6
+)
+
+// syslogSink sends every audit event as a RFC 5424 message with the JSON encoded event as the message body.
+// Messages sent over TCP are framed with octet counting (RFC 6587).
+type syslogSink struct {
+	network  string
+	address  string
+	appName  string
+	hostname string
+}
+
+func newSyslogSink(network, address, appName string) (*syslogSink, error) {
+	if network != "udp" && network != "tcp" {
+		return nil, fmt.Errorf("unsupported audit log stream syslog network %q", network)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	if appName == "" {
+		appName = "-"
+	}
+
+	return &syslogSink{
+		network:  network,
+		address:  address,
+		appName:  appName,
+		hostname: hostname,
+	}, nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, events []*types.AuditEvent) error {
+	dialer := net.Dialer{Timeout: syslogDialTimeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog server: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err = conn.SetWriteDeadline(time.Now().Add(syslogSendTimeout)); err != nil {
+		return fmt.Errorf("failed to set syslog write deadline: %w", err)
+	}
+
+	for _, event := range events {
+		msg, err := s.format(event)
+		if err != nil {
+			return err
+		}
+
+		if s.network == "tcp" {
+			msg = fmt.Appendf(nil, "%d %s", len(msg), msg)
+		}
+
+		if _, err = conn.Write(msg); err != nil {
+			return fmt.Errorf("failed to write syslog message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// format returns the RFC 5424 message of the audit event, the UID of the event is used as the message ID.
+func (s *syslogSink) format(event *types.AuditEvent) ([]byte, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	timestamp := time.UnixMilli(event.Timestamp).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Appendf(nil, "<%d>1 %s %s %s - %s - %s",
+		syslogPriority, timestamp, s.hostname, s.appName, event.UID, body), nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// webhookSink posts every batch of audit events as a JSON array to the URL.
+// If the secret is set, the body is signed with HMAC SHA256 in the X-{Identity}-Signature-256 header.
+type webhookSink struct {
+	url      string
+	secret   string
+	identity string
+	client   *http.Client
+}
+
+func newWebhookSink(url, secret string, timeout time.Duration, identity string) *webhookSink {
+	return &webhookSink{
+		url:      url,
+		secret:   secret,
+		identity: identity,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *webhookSink) Send(ctx context.Context, events []*types.AuditEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit events: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.identity)
+	if s.secret != "" {
+		req.Header.Set("X-"+s.identity+"-Signature-256", "sha256="+sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit events: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit log webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const streamJobType = "audit-log-stream"
+
+// Register registers the recurring job that streams the audit events to the sink, if a sink is configured.
+func (s *Service) Register(ctx context.Context) error {
+	if s.sink == nil {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, streamJobType, streamJobType, s.streamCRON, s.streamMaxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for audit log streaming: %w", err)
+	}
+
+	return nil
+}
+
+// Handle delivers the audit events from the outbox to the sink in batches, the oldest first.
+// Entries are removed from the outbox only after the sink accepted them, so a batch that failed
+// is delivered again on the next run and the sink might receive an audit event more than once.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if s.sink == nil {
+		return "", nil
+	}
+
+	var count int
+	for {
+		entries, err := s.outboxStore.List(ctx, s.streamBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list audit event outbox: %w", err)
+		}
+
+		if len(entries) == 0 {
+			break
+		}
+
+		ids := make([]int64, len(entries))
+		eventIDs := make([]int64, len(entries))
+		for i, entry := range entries {
+			ids[i] = entry.ID
+			eventIDs[i] = entry.AuditEventID
+		}
+
+		events, err := s.auditEventStore.ListByIDs(ctx, eventIDs)
+		if err != nil {
+			return "", fmt.Errorf("failed to list audit events of the outbox: %w", err)
+		}
+
+		if err = s.fillActors(ctx, events); err != nil {
+			return "", err
+		}
+
+		if err = s.sink.Send(ctx, events); err != nil {
+			if errMark := s.outboxStore.MarkFailed(ctx, ids, err.Error()); errMark != nil {
+				log.Ctx(ctx).Warn().Err(errMark).Msg("failed to mark audit event outbox entries as failed")
+			}
+
+			return "", fmt.Errorf("failed to deliver %d audit events to the sink: %w", len(events), err)
+		}
+
+		if err = s.outboxStore.Delete(ctx, ids); err != nil {
+			return "", fmt.Errorf("failed to delete delivered audit events from the outbox: %w", err)
+		}
+
+		count += len(events)
+
+		if len(entries) < s.streamBatchSize {
+			break
+		}
+	}
+
+	log.Ctx(ctx).Info().Int("count", count).Msg("streamed audit events to the sink")
+
+	return "", nil
+}
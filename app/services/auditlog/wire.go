@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+	ProvideAuditService,
+)
+
+func ProvideService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	auditEventStore store.AuditEventStore,
+	outboxStore store.AuditEventOutboxStore,
+	spaceStore store.SpaceStore,
+	principalInfoCache store.PrincipalInfoCache,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	sink, err := newSink(config)
+	if err != nil {
+		return nil, err
+	}
+
+	service := NewService(
+		tx,
+		auditEventStore,
+		outboxStore,
+		spaceStore,
+		principalInfoCache,
+		scheduler,
+		config.AuditLog.ExportMaxRange,
+		sink,
+		config.AuditLog.Stream.CRON,
+		config.AuditLog.Stream.MaxDuration,
+		config.AuditLog.Stream.BatchSize,
+	)
+
+	if err = executor.Register(streamJobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// ProvideAuditService provides the audit service that records the audit events in the database.
+func ProvideAuditService(svc *Service) audit.Service {
+	return svc
+}
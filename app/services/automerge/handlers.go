@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+)
+
+// mergeOnBranchUpdated cancels the auto-merge, as it's bound to the previous source SHA.
+func (s *Service) mergeOnBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.mergePullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnTargetBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchUpdatedPayload],
+) error {
+	return s.mergePullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.mergePullReq(ctx, event.Payload.PullReqID)
+}
+
+// mergeOnClosed removes the auto-merge of the closed pull request.
+func (s *Service) mergeOnClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.mergePullReq(ctx, event.Payload.PullReqID)
+}
+
+// mergeOnMerged removes the auto-merge of a pull request that got merged manually.
+func (s *Service) mergeOnMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.mergePullReq(ctx, event.Payload.PullReqID)
+}
@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pullreq-auto-merge"
+
+	eventReaderGroupName = "gitness:pullreq:automerge"
+
+	// batchSize defines the number of auto-merges processed at once by the job.
+	batchSize = 100
+)
+
+// Service merges the pull requests with enabled auto-merge, as soon as the protection rules allow it.
+// The pull requests are merged on behalf of the principals who enabled the auto-merge,
+// so a pull request is merged only if the principal is still allowed to merge it.
+// The merge is attempted when a pull request event might have made the pull request mergeable.
+// The recurring job is a backstop for the changes that aren't reported as pull request events,
+// like status checks, merged dependencies or a draft pull request getting ready for review.
+type Service struct {
+	enabled bool
+	cron    string
+	maxDur  time.Duration
+
+	autoMergeStore store.PullReqAutoMergeStore
+	pullReqStore   store.PullReqStore
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	pullreqCtrl    *pullreq.Controller
+	scheduler      *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	autoMergeStore store.PullReqAutoMergeStore,
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	pullreqCtrl *pullreq.Controller,
+	scheduler *job.Scheduler,
+) (*Service, error) {
+	service := &Service{
+		enabled:        config.AutoMerge.Enabled,
+		cron:           config.AutoMerge.CRON,
+		maxDur:         config.AutoMerge.MaxDuration,
+		autoMergeStore: autoMergeStore,
+		pullReqStore:   pullReqStore,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		pullreqCtrl:    pullreqCtrl,
+		scheduler:      scheduler,
+	}
+
+	if !service.enabled {
+		return service, nil
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventReaderGroupName, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.mergeOnBranchUpdated)
+			_ = r.RegisterTargetBranchUpdated(service.mergeOnTargetBranchUpdated)
+			_ = r.RegisterReviewSubmitted(service.mergeOnReviewSubmitted)
+			_ = r.RegisterClosed(service.mergeOnClosed)
+			_ = r.RegisterMerged(service.mergeOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pull request auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// Handle tries to merge all pull requests with enabled auto-merge.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	var finished, waiting int
+	var afterPullReqID int64
+	for {
+		autoMerges, err := s.autoMergeStore.List(ctx, afterPullReqID, batchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list pull request auto-merges: %w", err)
+		}
+
+		for _, autoMerge := range autoMerges {
+			afterPullReqID = autoMerge.PullReqID
+
+			done, err := s.process(ctx, autoMerge)
+			if err != nil {
+				// the auto-merge stays enabled, so the merge is retried by the next event or run of the job.
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("pullreq_id", autoMerge.PullReqID).
+					Msg("failed to auto-merge pull request")
+				waiting++
+				continue
+			}
+			if !done {
+				waiting++
+				continue
+			}
+
+			finished++
+		}
+
+		if len(autoMerges) < batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return fmt.Sprintf("finished %d and kept waiting for %d pull request auto-merges", finished, waiting), nil
+}
+
+// mergePullReq tries to merge the pull request if its auto-merge is enabled.
+func (s *Service) mergePullReq(ctx context.Context, pullReqID int64) error {
+	autoMerge, err := s.autoMergeStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	if _, err = s.process(ctx, autoMerge); err != nil {
+		return fmt.Errorf("failed to auto-merge pull request: %w", err)
+	}
+
+	return nil
+}
+
+// process tries to merge the pull request and deletes the auto-merge once it's done.
+func (s *Service) process(ctx context.Context, autoMerge *types.PullReqAutoMerge) (bool, error) {
+	done, err := s.tryMerge(ctx, autoMerge)
+	if err != nil || !done {
+		return false, err
+	}
+
+	if _, err = s.autoMergeStore.Delete(ctx, autoMerge.PullReqID); err != nil {
+		return false, fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	return true, nil
+}
+
+// tryMerge merges the pull request if the protection rules allow it. It returns true if the auto-merge
+// is done, either because the pull request got merged or because the pull request can't be merged anymore.
+func (s *Service) tryMerge(ctx context.Context, autoMerge *types.PullReqAutoMerge) (bool, error) {
+	pr, err := s.pullReqStore.Find(ctx, autoMerge.PullReqID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	// the pull request got merged or closed in the meantime.
+	if pr.State != enum.PullReqStateOpen {
+		return true, nil
+	}
+
+	// new commits were pushed after the auto-merge got enabled, they need to be reviewed before merging.
+	if pr.SourceSHA != autoMerge.SourceSHA {
+		return true, nil
+	}
+
+	if pr.IsDraft {
+		return false, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	// archived repositories are read-only, the pull request is merged once the repository gets unarchived.
+	if repo.Archived {
+		return false, nil
+	}
+
+	principal, err := s.principalStore.Find(ctx, autoMerge.CreatedBy)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal who enabled the auto-merge: %w", err)
+	}
+
+	if principal.Blocked {
+		return true, nil
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	// dry run the merge first, to avoid reporting rule violations while the pull request waits for them to go away.
+	out, _, err := s.pullreqCtrl.Merge(ctx, session, repo.Path, pr.Number, &pullreq.MergeInput{
+		Method:    autoMerge.Method,
+		SourceSHA: autoMerge.SourceSHA,
+		DryRun:    true,
+	})
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to dry run the merge: %w", err)
+	}
+
+	if !out.Mergeable || protection.IsCritical(out.RuleViolations) || len(out.UnmergedDependencies) > 0 {
+		return false, nil
+	}
+
+	_, violations, err := s.pullreqCtrl.Merge(ctx, session, repo.Path, pr.Number, &pullreq.MergeInput{
+		Method:    autoMerge.Method,
+		SourceSHA: autoMerge.SourceSHA,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to merge: %w", err)
+	}
+
+	return violations == nil, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	pinnedSHA = "1111111111111111111111111111111111111111"
+	newerSHA  = "2222222222222222222222222222222222222222"
+
+	authorID  = 1
+	blockedID = 2
+)
+
+type fakeAutoMergeStore struct {
+	store.PullReqAutoMergeStore
+	autoMerges map[int64]*types.PullReqAutoMerge
+}
+
+func (s *fakeAutoMergeStore) Find(_ context.Context, pullReqID int64) (*types.PullReqAutoMerge, error) {
+	autoMerge, ok := s.autoMerges[pullReqID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return autoMerge, nil
+}
+
+func (s *fakeAutoMergeStore) Delete(_ context.Context, pullReqID int64) (bool, error) {
+	_, ok := s.autoMerges[pullReqID]
+	delete(s.autoMerges, pullReqID)
+	return ok, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s *fakePullReqStore) Find(context.Context, int64) (*types.PullReq, error) {
+	return s.pr, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	archived bool
+}
+
+func (s fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, Path: "space/repo", Archived: s.archived}, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, Type: enum.PrincipalTypeUser, Blocked: id == blockedID}, nil
+}
+
+// TestService_MergePullReq covers the cases that are decided before the merge is attempted,
+// the pull request controller isn't set, so any attempt to merge would panic.
+func TestService_MergePullReq(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       enum.PullReqState
+		isDraft     bool
+		archived    bool
+		sourceSHA   string
+		createdBy   int64
+		wantDeleted bool
+	}{
+		{
+			name:        "new commits pushed",
+			state:       enum.PullReqStateOpen,
+			sourceSHA:   newerSHA,
+			createdBy:   authorID,
+			wantDeleted: true,
+		},
+		{
+			name:        "closed",
+			state:       enum.PullReqStateClosed,
+			sourceSHA:   pinnedSHA,
+			createdBy:   authorID,
+			wantDeleted: true,
+		},
+		{
+			name:        "merged manually",
+			state:       enum.PullReqStateMerged,
+			sourceSHA:   newerSHA,
+			createdBy:   authorID,
+			wantDeleted: true,
+		},
+		{
+			name:        "enabled by a blocked principal",
+			state:       enum.PullReqStateOpen,
+			sourceSHA:   pinnedSHA,
+			createdBy:   blockedID,
+			wantDeleted: true,
+		},
+		{
+			name:      "draft",
+			state:     enum.PullReqStateOpen,
+			isDraft:   true,
+			sourceSHA: pinnedSHA,
+			createdBy: authorID,
+		},
+		{
+			name:      "archived repository",
+			state:     enum.PullReqStateOpen,
+			archived:  true,
+			sourceSHA: pinnedSHA,
+			createdBy: authorID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			autoMergeStore := &fakeAutoMergeStore{autoMerges: map[int64]*types.PullReqAutoMerge{
+				1: {PullReqID: 1, Method: enum.MergeMethodSquash, SourceSHA: pinnedSHA, CreatedBy: test.createdBy},
+			}}
+
+			s := &Service{
+				enabled:        true,
+				autoMergeStore: autoMergeStore,
+				pullReqStore: &fakePullReqStore{pr: &types.PullReq{
+					ID:           1,
+					TargetRepoID: 1,
+					State:        test.state,
+					IsDraft:      test.isDraft,
+					SourceSHA:    test.sourceSHA,
+				}},
+				repoStore:      fakeRepoStore{archived: test.archived},
+				principalStore: fakePrincipalStore{},
+			}
+
+			err := s.mergeOnReviewSubmitted(context.Background(), &events.Event[*pullreqevents.ReviewSubmittedPayload]{
+				Payload: &pullreqevents.ReviewSubmittedPayload{Base: pullreqevents.Base{PullReqID: 1}},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, exists := autoMergeStore.autoMerges[1]
+			if exists == test.wantDeleted {
+				t.Errorf("auto-merge exists = %t, want deleted = %t", exists, test.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestService_MergePullReqWithoutAutoMerge(t *testing.T) {
+	// the pull request store isn't set, the pull request must not be looked up.
+	s := &Service{enabled: true, autoMergeStore: &fakeAutoMergeStore{}}
+
+	if err := s.mergePullReq(context.Background(), 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
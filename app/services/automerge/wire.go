@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	autoMergeStore store.PullReqAutoMergeStore,
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	pullreqCtrl *pullreq.Controller,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service, err := NewService(
+		ctx,
+		config,
+		pullreqEvReaderFactory,
+		autoMergeStore,
+		pullReqStore,
+		repoStore,
+		principalStore,
+		pullreqCtrl,
+		scheduler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
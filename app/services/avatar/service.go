@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"  // register gif decoder
+	_ "image/jpeg" // register jpeg decoder
+	"image/png"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxFileSize is the maximum size of an uploaded avatar image.
+	MaxFileSize = 5 << 20 // 5 MB
+
+	bucketPathFmt = "avatars/%d/%s"
+)
+
+type Config struct {
+	Size            int
+	MaxDimension    int
+	GravatarEnabled bool
+	GravatarURL     string
+	GravatarDefault string
+}
+
+// Service stores the avatar images of principals in the blob store and serves them,
+// falling back to gravatar for principals without an uploaded avatar.
+type Service struct {
+	blobStore blob.Store
+	config    Config
+}
+
+func NewService(blobStore blob.Store, config Config) *Service {
+	return &Service{
+		blobStore: blobStore,
+		config:    config,
+	}
+}
+
+// BucketPath returns the path of an avatar file in the blob store.
+func BucketPath(principalID int64, fileName string) string {
+	return fmt.Sprintf(bucketPathFmt, principalID, fileName)
+}
+
+// Upload validates the provided image, resizes it to a square png image and stores it in the blob store.
+// It returns the file name of the stored avatar.
+func (s *Service) Upload(ctx context.Context, principalID int64, file io.Reader) (string, error) {
+	data, err := s.process(file)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := uuid.New().String() + ".png"
+
+	err = s.blobStore.Upload(ctx, bytes.NewReader(data), BucketPath(principalID, fileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload avatar: %w", err)
+	}
+
+	return fileName, nil
+}
+
+// Delete removes an uploaded avatar from the blob store.
+func (s *Service) Delete(ctx context.Context, principalID int64, fileName string) error {
+	if fileName == "" {
+		return nil
+	}
+
+	err := s.blobStore.Delete(ctx, BucketPath(principalID, fileName))
+	if err != nil {
+		return fmt.Errorf("failed to delete avatar: %w", err)
+	}
+
+	return nil
+}
+
+// Download returns the avatar of the principal. It either returns the URL to redirect to
+// (a signed blob store URL or a gravatar URL) or a reader of the avatar file.
+func (s *Service) Download(ctx context.Context, principal *types.Principal) (string, io.ReadCloser, error) {
+	if principal.Avatar == "" {
+		if !s.config.GravatarEnabled || principal.Email == "" {
+			return "", nil, errors.NotFound("Avatar not found")
+		}
+
+		return s.gravatarURL(principal.Email), nil, nil
+	}
+
+	filePath := BucketPath(principal.ID, principal.Avatar)
+
+	signedURL, err := s.blobStore.GetSignedURL(ctx, filePath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := s.blobStore.Download(ctx, filePath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return "", nil, errors.NotFound("Avatar not found")
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download avatar from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
+
+// gravatarURL returns the gravatar URL for the email. Gravatar accepts SHA256 hashes of the normalized email.
+func (s *Service) gravatarURL(email string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+
+	query := url.Values{}
+	query.Set("s", strconv.Itoa(s.config.Size))
+	if s.config.GravatarDefault != "" {
+		query.Set("d", s.config.GravatarDefault)
+	}
+
+	return strings.TrimSuffix(s.config.GravatarURL, "/") + "/" + hex.EncodeToString(hash[:]) + "?" + query.Encode()
+}
+
+// process decodes the image, crops it to a centered square and scales it to the configured size.
+func (s *Service) process(file io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.InvalidArgument("Avatar must be a png, jpeg or gif image.")
+	}
+
+	if cfg.Width > s.config.MaxDimension || cfg.Height > s.config.MaxDimension {
+		return nil, errors.InvalidArgument("Avatar dimensions can't exceed %dx%d pixels.",
+			s.config.MaxDimension, s.config.MaxDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.InvalidArgument("Avatar must be a png, jpeg or gif image.")
+	}
+
+	buf := &bytes.Buffer{}
+	if err = png.Encode(buf, resizeSquare(img, s.config.Size)); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resizeSquare crops the image to a centered square and scales it to size x size pixels.
+// Each target pixel is the average of the source pixels it covers (box filter),
+// images smaller than the target size are scaled up using the nearest source pixel.
+func resizeSquare(img image.Image, size int) *image.NRGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	src := image.NewNRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(src, src.Bounds(), img, image.Pt(x0, y0), draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		sy0 := y * side / size
+		sy1 := max((y+1)*side/size, sy0+1)
+		for x := 0; x < size; x++ {
+			sx0 := x * side / size
+			sx1 := max((x+1)*side/size, sx0+1)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := src.NRGBAAt(sx, sy)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n), //nolint:gosec // average of uint8 values
+				G: uint8(g / n), //nolint:gosec // average of uint8 values
+				B: uint8(b / n), //nolint:gosec // average of uint8 values
+				A: uint8(a / n), //nolint:gosec // average of uint8 values
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+)
+
+func TestService_process(t *testing.T) {
+	svc := NewService(nil, Config{Size: 4, MaxDimension: 16})
+
+	// left half red, right half blue, with an extra transparent row at the bottom that gets cropped
+	src := image.NewNRGBA(image.Rect(0, 0, 8, 9))
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 8; x++ {
+			switch {
+			case y == 8:
+				src.SetNRGBA(x, y, color.NRGBA{})
+			case x < 4:
+				src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+			default:
+				src.SetNRGBA(x, y, color.NRGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, src); err != nil {
+		t.Fatalf("failed to encode test image: %s", err)
+	}
+
+	data, err := svc.process(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to decode processed image: %s", err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 4 || b.Dy() != 4 {
+		t.Fatalf("expected 4x4 image, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	if got := color.NRGBAModel.Convert(img.At(0, 3)).(color.NRGBA); got != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("expected red pixel on the left, got %v", got)
+	}
+	if got := color.NRGBAModel.Convert(img.At(3, 3)).(color.NRGBA); got != (color.NRGBA{B: 255, A: 255}) {
+		t.Errorf("expected blue pixel on the right, got %v", got)
+	}
+}
+
+func TestService_processInvalid(t *testing.T) {
+	svc := NewService(nil, Config{Size: 4, MaxDimension: 16})
+
+	_, err := svc.process(strings.NewReader("not an image"))
+	if !errors.IsInvalidArgument(err) {
+		t.Errorf("expected invalid argument error for non-image, got %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = png.Encode(buf, image.NewNRGBA(image.Rect(0, 0, 32, 8))); err != nil {
+		t.Fatalf("failed to encode test image: %s", err)
+	}
+
+	_, err = svc.process(buf)
+	if !errors.IsInvalidArgument(err) {
+		t.Errorf("expected invalid argument error for oversized image, got %v", err)
+	}
+}
+
+func TestService_gravatarURL(t *testing.T) {
+	svc := NewService(nil, Config{
+		Size:            64,
+		GravatarEnabled: true,
+		GravatarURL:     "https://gravatar.example.com/avatar/",
+		GravatarDefault: "identicon",
+	})
+
+	got := svc.gravatarURL(" Jane@Example.com ")
+	want := "https://gravatar.example.com/avatar/" +
+		"8c87b489ce35cf2e2f39f80e282cb2e804932a56a213983eeeb428407d43b52d?d=identicon&s=64"
+	if got != want {
+		t.Errorf("expected gravatar url %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxMessageLength = 1024
+
+	// cacheRefreshInterval is how long the banners are served from memory. The endpoint is polled by the UI
+	// of every user, so the database is only queried once per interval.
+	cacheRefreshInterval = 15 * time.Second
+)
+
+// Service manages the announcement banners of the instance, like maintenance windows or policy notices.
+type Service struct {
+	bannerStore store.BannerStore
+
+	mutex    sync.RWMutex
+	banners  []*types.Banner
+	loadedAt time.Time
+}
+
+func NewService(bannerStore store.BannerStore) *Service {
+	return &Service{
+		bannerStore: bannerStore,
+	}
+}
+
+type CreateInput struct {
+	Identifier  string              `json:"identifier"`
+	Message     string              `json:"message"`
+	Severity    enum.BannerSeverity `json:"severity"`
+	StartsAt    int64               `json:"starts_at"`
+	EndsAt      int64               `json:"ends_at"`
+	Dismissible bool                `json:"dismissible"`
+}
+
+type UpdateInput struct {
+	Message     *string              `json:"message"`
+	Severity    *enum.BannerSeverity `json:"severity"`
+	StartsAt    *int64               `json:"starts_at"`
+	EndsAt      *int64               `json:"ends_at"`
+	Dismissible *bool                `json:"dismissible"`
+}
+
+// List returns all banners, including the scheduled and the ended ones.
+func (s *Service) List(ctx context.Context) ([]*types.Banner, error) {
+	banners, err := s.bannerStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banners: %w", err)
+	}
+
+	return banners, nil
+}
+
+// Find returns the banner with the provided identifier.
+func (s *Service) Find(ctx context.Context, identifier string) (*types.Banner, error) {
+	banner, err := s.bannerStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Banner '%s' not found.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find banner: %w", err)
+	}
+
+	return banner, nil
+}
+
+// Create creates a new banner. Banners without a start time are shown immediately.
+func (s *Service) Create(ctx context.Context, createdBy int64, in *CreateInput) (*types.Banner, error) {
+	now := time.Now().UnixMilli()
+
+	if err := in.sanitize(now); err != nil {
+		return nil, err
+	}
+
+	banner := &types.Banner{
+		Identifier:  in.Identifier,
+		Message:     in.Message,
+		Severity:    in.Severity,
+		StartsAt:    in.StartsAt,
+		EndsAt:      in.EndsAt,
+		Dismissible: in.Dismissible,
+		CreatedBy:   createdBy,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err := s.bannerStore.Create(ctx, banner)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Banner '%s' already exists.", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create banner: %w", err)
+	}
+
+	s.invalidate()
+
+	return banner, nil
+}
+
+// Update updates the message, severity, schedule or dismissibility of a banner.
+func (s *Service) Update(ctx context.Context, identifier string, in *UpdateInput) (*types.Banner, error) {
+	banner, err := s.Find(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.Message != nil {
+		banner.Message = *in.Message
+	}
+	if in.Severity != nil {
+		banner.Severity = *in.Severity
+	}
+	if in.StartsAt != nil {
+		banner.StartsAt = *in.StartsAt
+	}
+	if in.EndsAt != nil {
+		banner.EndsAt = *in.EndsAt
+	}
+	if in.Dismissible != nil {
+		banner.Dismissible = *in.Dismissible
+	}
+
+	if err = checkSchedule(banner.StartsAt, banner.EndsAt); err != nil {
+		return nil, err
+	}
+
+	banner.Updated = time.Now().UnixMilli()
+
+	if err = s.bannerStore.Update(ctx, banner); err != nil {
+		return nil, fmt.Errorf("failed to update banner: %w", err)
+	}
+
+	s.invalidate()
+
+	return banner, nil
+}
+
+// Delete deletes a banner.
+func (s *Service) Delete(ctx context.Context, identifier string) error {
+	banner, err := s.Find(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	if err = s.bannerStore.Delete(ctx, banner.ID); err != nil {
+		return fmt.Errorf("failed to delete banner: %w", err)
+	}
+
+	s.invalidate()
+
+	return nil
+}
+
+// ListActive returns the banners that are currently shown, the most severe first.
+// The banners are served from memory and reloaded at most once per refresh interval.
+func (s *Service) ListActive(ctx context.Context) []types.BannerInfo {
+	return activeBanners(s.cachedBanners(ctx), time.Now().UnixMilli())
+}
+
+// cachedBanners returns the banners that haven't ended, reloading them from the database
+// if they weren't loaded within the refresh interval. The last known banners are used if reloading fails.
+func (s *Service) cachedBanners(ctx context.Context) []*types.Banner {
+	s.mutex.RLock()
+	banners, loadedAt := s.banners, s.loadedAt
+	s.mutex.RUnlock()
+
+	if banners != nil && time.Since(loadedAt) < cacheRefreshInterval {
+		return banners
+	}
+
+	loaded, err := s.bannerStore.ListNotEnded(ctx, time.Now().UnixMilli())
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to reload banners, using last known banners")
+		return banners
+	}
+
+	s.mutex.Lock()
+	s.banners = loaded
+	s.loadedAt = time.Now()
+	s.mutex.Unlock()
+
+	return loaded
+}
+
+// invalidate forces a reload of the banners with the next request.
+// The other instances pick up the changes with their next refresh.
+func (s *Service) invalidate() {
+	s.mutex.Lock()
+	s.banners = nil
+	s.mutex.Unlock()
+}
+
+// activeBanners returns the banners that are active at the provided time,
+// sorted by severity and the most recently started first.
+func activeBanners(banners []*types.Banner, now int64) []types.BannerInfo {
+	active := make([]*types.Banner, 0, len(banners))
+	for _, banner := range banners {
+		if banner.IsActive(now) {
+			active = append(active, banner)
+		}
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		if ri, rj := active[i].Severity.Rank(), active[j].Severity.Rank(); ri != rj {
+			return ri > rj
+		}
+		return active[i].StartsAt > active[j].StartsAt
+	})
+
+	infos := make([]types.BannerInfo, len(active))
+	for i, banner := range active {
+		infos[i] = banner.ToBannerInfo()
+	}
+
+	return infos
+}
+
+func (in *CreateInput) sanitize(now int64) error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if err := checkMessage(in.Message); err != nil {
+		return err
+	}
+
+	severity, ok := in.Severity.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("Invalid banner severity '%s'.", in.Severity)
+	}
+	in.Severity = severity
+
+	if in.StartsAt == 0 {
+		in.StartsAt = now
+	}
+
+	return checkSchedule(in.StartsAt, in.EndsAt)
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Message != nil {
+		*in.Message = strings.TrimSpace(*in.Message)
+		if err := checkMessage(*in.Message); err != nil {
+			return err
+		}
+	}
+
+	if in.Severity != nil {
+		severity, ok := in.Severity.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("Invalid banner severity '%s'.", *in.Severity)
+		}
+		*in.Severity = severity
+	}
+
+	return nil
+}
+
+func checkMessage(message string) error {
+	if message == "" {
+		return errors.InvalidArgument("Banner message must be provided.")
+	}
+	if utf8.RuneCountInString(message) > maxMessageLength {
+		return errors.InvalidArgument("Banner message can't be longer than %d characters.", maxMessageLength)
+	}
+
+	return nil
+}
+
+func checkSchedule(startsAt, endsAt int64) error {
+	if startsAt < 0 || endsAt < 0 {
+		return errors.InvalidArgument("Banner start and end time can't be negative.")
+	}
+	if endsAt != 0 && endsAt <= startsAt {
+		return errors.InvalidArgument("Banner has to end after it starts.")
+	}
+
+	return nil
+}
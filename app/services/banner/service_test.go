@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banner
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveBanners(t *testing.T) {
+	const now = 10_000
+
+	banners := []*types.Banner{
+		{Identifier: "info-old", Severity: enum.BannerSeverityInfo, StartsAt: 1_000},
+		{Identifier: "scheduled", Severity: enum.BannerSeverityCritical, StartsAt: 20_000},
+		{Identifier: "ended", Severity: enum.BannerSeverityCritical, StartsAt: 1_000, EndsAt: now},
+		{Identifier: "warning", Severity: enum.BannerSeverityWarning, StartsAt: 2_000, EndsAt: 30_000},
+		{Identifier: "info-new", Severity: enum.BannerSeverityInfo, StartsAt: 5_000},
+		{Identifier: "critical", Severity: enum.BannerSeverityCritical, StartsAt: now},
+	}
+
+	got := activeBanners(banners, now)
+
+	identifiers := make([]string, len(got))
+	for i, banner := range got {
+		identifiers[i] = banner.Identifier
+	}
+
+	require.Equal(t, []string{"critical", "warning", "info-new", "info-old"}, identifiers)
+}
+
+func TestCreateInputSanitize(t *testing.T) {
+	const now = 10_000
+
+	in := &CreateInput{Identifier: "maintenance", Message: " Upgrade on Sunday "}
+	require.NoError(t, in.sanitize(now))
+	require.Equal(t, "Upgrade on Sunday", in.Message)
+	require.Equal(t, enum.BannerSeverityInfo, in.Severity)
+	require.Equal(t, int64(now), in.StartsAt)
+
+	in = &CreateInput{Identifier: "maintenance", Message: "Upgrade", StartsAt: 20_000, EndsAt: 15_000}
+	require.Error(t, in.sanitize(now), "banner can't end before it starts")
+
+	in = &CreateInput{Identifier: "maintenance", Message: "  "}
+	require.Error(t, in.sanitize(now), "message is required")
+
+	in = &CreateInput{Identifier: "maintenance", Message: "Upgrade", Severity: "fatal"}
+	require.Error(t, in.sanitize(now), "unknown severity")
+}
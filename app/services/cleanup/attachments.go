@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/attachment"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeAttachments        = "gitness:cleanup:attachments"
+	jobCronAttachments        = "41 */6 * * *" // At minute 41 past every 6th hour.
+	jobMaxDurationAttachments = 10 * time.Minute
+
+	attachmentsBatchSize = 100
+)
+
+type attachmentsCleanupJob struct {
+	retentionTime time.Duration
+
+	attachmentStore store.AttachmentStore
+	blobStore       *blob.PoolStore
+}
+
+func newAttachmentsCleanupJob(
+	retentionTime time.Duration,
+	attachmentStore store.AttachmentStore,
+	blobStore *blob.PoolStore,
+) *attachmentsCleanupJob {
+	return &attachmentsCleanupJob{
+		retentionTime: retentionTime,
+
+		attachmentStore: attachmentStore,
+		blobStore:       blobStore,
+	}
+}
+
+// Handle purges uploaded attachments that were never referenced and are past the retention time,
+// as well as the attachments of repositories that were purged.
+func (j *attachmentsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging orphaned attachments older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	deleted := 0
+	for {
+		attachments, err := j.attachmentStore.ListOrphaned(ctx, olderThan.UnixMilli(), attachmentsBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list orphaned attachments: %w", err)
+		}
+
+		for _, a := range attachments {
+			// the storage pool of purged repositories is unknown, hence the file is deleted from all pools.
+			err = j.blobStore.DeleteAll(ctx, attachment.BucketPath(a.RepoID, a.FileName))
+			if err != nil {
+				return "", fmt.Errorf("failed to delete attachment file %q: %w", a.FileName, err)
+			}
+
+			if err = j.attachmentStore.Delete(ctx, a.ID); err != nil {
+				return "", fmt.Errorf("failed to delete attachment %d: %w", a.ID, err)
+			}
+
+			deleted++
+		}
+
+		if len(attachments) < attachmentsBatchSize {
+			break
+		}
+	}
+
+	result := "no orphaned attachments found"
+	if deleted > 0 {
+		result = fmt.Sprintf("deleted %d orphaned attachments", deleted)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
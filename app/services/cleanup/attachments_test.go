@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/attachment"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+)
+
+type memAttachmentStore struct {
+	store.AttachmentStore
+	attachments map[int64]*types.Attachment
+}
+
+func (s *memAttachmentStore) ListOrphaned(
+	_ context.Context,
+	createdBefore int64,
+	limit int,
+) ([]*types.Attachment, error) {
+	ids := make([]int64, 0, len(s.attachments))
+	for id := range s.attachments {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var list []*types.Attachment
+	for _, id := range ids {
+		a := s.attachments[id]
+		if len(list) < limit && !a.Referenced && a.Created < createdBefore {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (s *memAttachmentStore) Delete(_ context.Context, id int64) error {
+	delete(s.attachments, id)
+	return nil
+}
+
+func TestAttachmentsCleanupJob_Handle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	old := now.Add(-2 * time.Hour).UnixMilli()
+
+	// more orphans than fit into a single batch, to cover the pagination.
+	const orphanCount = attachmentsBatchSize + 5
+
+	attachmentStore := &memAttachmentStore{attachments: map[int64]*types.Attachment{}}
+	for id := int64(1); id <= orphanCount; id++ {
+		attachmentStore.attachments[id] = &types.Attachment{
+			ID:       id,
+			RepoID:   1,
+			FileName: fmt.Sprintf("orphan-%d.png", id),
+			Created:  old,
+		}
+	}
+
+	referenced := &types.Attachment{
+		ID: orphanCount + 1, RepoID: 1, FileName: "referenced.png", Created: old, Referenced: true,
+	}
+	recent := &types.Attachment{
+		ID: orphanCount + 2, RepoID: 1, FileName: "recent.png", Created: now.UnixMilli(),
+	}
+	attachmentStore.attachments[referenced.ID] = referenced
+	attachmentStore.attachments[recent.ID] = recent
+
+	defaultDir := t.TempDir()
+	poolDir := t.TempDir()
+	defaultStore, _ := blob.NewFileSystemStore(blob.Config{Bucket: defaultDir})
+	poolStore, _ := blob.NewFileSystemStore(blob.Config{Bucket: poolDir})
+	blobStore := blob.NewPoolStore(defaultStore, map[string]blob.Store{"pool": poolStore})
+
+	// the orphan is stored in a storage pool, the others in the default blob store.
+	orphan := attachmentStore.attachments[1]
+	upload(ctx, t, poolStore, attachment.BucketPath(orphan.RepoID, orphan.FileName))
+	upload(ctx, t, defaultStore, attachment.BucketPath(referenced.RepoID, referenced.FileName))
+	upload(ctx, t, defaultStore, attachment.BucketPath(recent.RepoID, recent.FileName))
+
+	job := newAttachmentsCleanupJob(time.Hour, attachmentStore, blobStore)
+
+	result, err := job.Handle(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if exp := fmt.Sprintf("deleted %d orphaned attachments", orphanCount); result != exp {
+		t.Errorf("result: want=%q got=%q", exp, result)
+	}
+
+	if len(attachmentStore.attachments) != 2 ||
+		attachmentStore.attachments[referenced.ID] == nil ||
+		attachmentStore.attachments[recent.ID] == nil {
+		t.Errorf("expected only the referenced and the recent attachment to remain, got %d attachments",
+			len(attachmentStore.attachments))
+	}
+
+	assertExists(t, poolDir, attachment.BucketPath(orphan.RepoID, orphan.FileName), false)
+	assertExists(t, defaultDir, attachment.BucketPath(referenced.RepoID, referenced.FileName), true)
+	assertExists(t, defaultDir, attachment.BucketPath(recent.RepoID, recent.FileName), true)
+
+	result, err = job.Handle(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp := "no orphaned attachments found"; result != exp {
+		t.Errorf("result of second run: want=%q got=%q", exp, result)
+	}
+}
+
+func upload(ctx context.Context, t *testing.T, store blob.Store, filePath string) {
+	t.Helper()
+	if err := store.Upload(ctx, strings.NewReader("data"), filePath); err != nil {
+		t.Fatalf("failed to upload %q: %s", filePath, err)
+	}
+}
+
+func assertExists(t *testing.T, dir, filePath string, exp bool) {
+	t.Helper()
+	_, err := os.Stat(filepath.Join(dir, filePath))
+	if exists := !errors.Is(err, os.ErrNotExist); exists != exp {
+		t.Errorf("file %q: want exists=%t got exists=%t", filePath, exp, exists)
+	}
+}
@@ -22,12 +22,14 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	OrphanedAttachmentsRetentionTime time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +43,10 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.OrphanedAttachmentsRetentionTime <= 0 {
+		return errors.New("config.OrphanedAttachmentsRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +59,8 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	attachmentStore       store.AttachmentStore
+	blobStore             *blob.PoolStore
 }
 
 func NewService(
@@ -63,6 +71,8 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	attachmentStore store.AttachmentStore,
+	blobStore *blob.PoolStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +87,8 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		attachmentStore:       attachmentStore,
+		blobStore:             blobStore,
 	}, nil
 }
 
@@ -126,6 +138,17 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeAttachments,
+		jobTypeAttachments,
+		jobCronAttachments,
+		jobMaxDurationAttachments,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule orphaned attachments cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +183,16 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeAttachments,
+		newAttachmentsCleanupJob(
+			s.config.OrphanedAttachmentsRetentionTime,
+			s.attachmentStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for orphaned attachments cleanup: %w", err)
+	}
 	return nil
 }
@@ -17,6 +17,7 @@ package cleanup
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
@@ -34,6 +35,8 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	attachmentStore store.AttachmentStore,
+	blobStore *blob.PoolStore,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +46,7 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		attachmentStore,
+		blobStore,
 	)
 }
@@ -361,15 +361,29 @@ func (s *Service) getApplicableCodeOwnersForPR(
 	repo *types.Repository,
 	pr *types.PullReq,
 ) (*CodeOwners, error) {
-	codeOwners, err := s.get(ctx, repo, pr.TargetBranch)
+	return s.getApplicableCodeOwners(ctx, repo, pr.TargetBranch, DiffRange{
+		BaseRef: pr.MergeBaseSHA,
+		HeadRef: pr.SourceSHA,
+	})
+}
+
+// getApplicableCodeOwners returns the code owners entries of the target branch that match the files
+// changed in the diff range.
+func (s *Service) getApplicableCodeOwners(
+	ctx context.Context,
+	repo *types.Repository,
+	targetBranch string,
+	diffRange DiffRange,
+) (*CodeOwners, error) {
+	codeOwners, err := s.get(ctx, repo, targetBranch)
 	if err != nil {
 		return nil, err
 	}
 
 	diffFileStats, err := s.git.DiffFileNames(ctx, &git.DiffParams{
 		ReadParams: git.CreateReadParams(repo),
-		BaseRef:    pr.MergeBaseSHA,
-		HeadRef:    pr.SourceSHA,
+		BaseRef:    diffRange.BaseRef,
+		HeadRef:    diffRange.HeadRef,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get diff file stat: %w", err)
@@ -474,6 +488,76 @@ func (s *Service) Evaluate(
 	}, nil
 }
 
+// Owners holds the code owners responsible for the changes of a pull request.
+type Owners struct {
+	Users      []*types.Principal
+	UserGroups []*types.UserGroup
+}
+
+// DiffRange defines the changes for which the code owners are returned.
+type DiffRange struct {
+	BaseRef string
+	HeadRef string
+}
+
+// Owners returns the code owners of the files changed in the diff range of the pull request,
+// e.g. the whole pull request (merge base..source SHA) or just the commits of a branch update.
+// Owners that can't be resolved and the author of the pull request are skipped.
+func (s *Service) Owners(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	diffRange DiffRange,
+) (*Owners, error) {
+	codeOwners, err := s.getApplicableCodeOwners(ctx, repo, pr.TargetBranch, diffRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get codeOwners: %w", err)
+	}
+
+	out := &Owners{}
+	seen := make(map[string]struct{})
+
+	for _, entry := range codeOwners.Entries {
+		for _, owner := range entry.Owners {
+			if _, ok := seen[owner]; ok {
+				continue
+			}
+			seen[owner] = struct{}{}
+
+			if strings.HasPrefix(owner, userGroupPrefixMarker) {
+				usrgrp, err := s.userGroupResolver.Resolve(ctx, owner[1:])
+				if errors.Is(err, usergroup.ErrNotFound) {
+					log.Ctx(ctx).Debug().Msgf("usergroup %q not found hence skipping for code owner", owner)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("error resolving usergroup: %w", err)
+				}
+
+				out.UserGroups = append(out.UserGroups, usrgrp)
+				continue
+			}
+
+			principal, err := s.principalStore.FindByEmail(ctx, owner)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				log.Ctx(ctx).Debug().Msgf("user %q not found in database hence skipping for code owner", owner)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error finding user by email: %w", err)
+			}
+
+			if principal.ID == pr.CreatedBy {
+				continue
+			}
+
+			out.Users = append(out.Users, principal)
+		}
+	}
+
+	return out, nil
+}
+
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
 	owner string,
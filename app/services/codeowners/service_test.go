@@ -15,11 +15,19 @@
 package codeowners
 
 import (
+	"context"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
 )
 
 func TestService_ParseCodeOwner(t *testing.T) {
@@ -672,3 +680,145 @@ func Test_match(t *testing.T) {
 		})
 	}
 }
+
+type ownersGit struct {
+	git.Interface
+	codeOwners string
+	files      []string
+	diffParams *git.DiffParams
+}
+
+func (g *ownersGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	if params.Path != "CODEOWNERS" {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+	return &git.GetTreeNodeOutput{
+		Node: git.TreeNode{Mode: git.TreeNodeModeFile, SHA: sha.EmptyTree},
+	}, nil
+}
+
+func (g *ownersGit) GetBlob(context.Context, *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	return &git.GetBlobOutput{
+		SHA:     sha.EmptyTree,
+		Size:    int64(len(g.codeOwners)),
+		Content: io.NopCloser(strings.NewReader(g.codeOwners)),
+	}, nil
+}
+
+func (g *ownersGit) DiffFileNames(_ context.Context, params *git.DiffParams) (git.DiffFileNamesOutput, error) {
+	g.diffParams = params
+	return git.DiffFileNamesOutput{Files: g.files}, nil
+}
+
+type ownersPrincipalStore struct {
+	store.PrincipalStore
+	principals map[string]*types.Principal
+}
+
+func (s ownersPrincipalStore) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	if p, ok := s.principals[email]; ok {
+		return p, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type ownersResolver map[string]*types.UserGroup
+
+func (r ownersResolver) Resolve(_ context.Context, scopedID string) (*types.UserGroup, error) {
+	if g, ok := r[scopedID]; ok {
+		return g, nil
+	}
+	return nil, usergroup.ErrNotFound
+}
+
+func TestService_Owners(t *testing.T) {
+	const codeOwners = `* default@example.com
+/docs/ @docs author@example.com
+/app/ app@example.com default@example.com unknown@example.com
+/app/vendor/
+/web/ @missing web@example.com
+`
+
+	principals := map[string]*types.Principal{
+		"default@example.com": {ID: 1, Email: "default@example.com"},
+		"author@example.com":  {ID: 2, Email: "author@example.com"},
+		"app@example.com":     {ID: 3, Email: "app@example.com"},
+		"web@example.com":     {ID: 4, Email: "web@example.com"},
+	}
+	docs := &types.UserGroup{ID: 1, Identifier: "docs"}
+
+	tests := []struct {
+		name       string
+		files      []string
+		wantUsers  []int64
+		wantGroups []string
+	}{
+		{
+			name:      "fallback entry",
+			files:     []string{"README.md"},
+			wantUsers: []int64{1},
+		},
+		{
+			name:       "usergroup and skipped author",
+			files:      []string{"docs/index.md"},
+			wantGroups: []string{"docs"},
+		},
+		{
+			name:      "owners are deduplicated and unknown users skipped",
+			files:     []string{"README.md", "app/main.go"},
+			wantUsers: []int64{1, 3},
+		},
+		{
+			name:  "ownership reset",
+			files: []string{"app/vendor/lib.go"},
+		},
+		{
+			name:      "unknown usergroup skipped",
+			files:     []string{"web/index.html"},
+			wantUsers: []int64{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGit := &ownersGit{codeOwners: codeOwners, files: tt.files}
+			s := New(
+				nil,
+				fakeGit,
+				Config{FilePaths: []string{".harness/CODEOWNERS", "CODEOWNERS"}},
+				ownersPrincipalStore{principals: principals},
+				ownersResolver{"docs": docs},
+			)
+
+			repo := &types.Repository{GitUID: "repo", DefaultBranch: "main"}
+			pr := &types.PullReq{CreatedBy: 2, TargetBranch: "main"}
+			diffRange := DiffRange{BaseRef: "old", HeadRef: "new"}
+
+			got, err := s.Owners(context.Background(), repo, pr, diffRange)
+			if err != nil {
+				t.Fatalf("Owners() error = %v", err)
+			}
+
+			if fakeGit.diffParams.BaseRef != diffRange.BaseRef || fakeGit.diffParams.HeadRef != diffRange.HeadRef {
+				t.Errorf("Owners() diffed %s..%s, want %s..%s",
+					fakeGit.diffParams.BaseRef, fakeGit.diffParams.HeadRef, diffRange.BaseRef, diffRange.HeadRef)
+			}
+
+			var gotUsers []int64
+			for _, u := range got.Users {
+				gotUsers = append(gotUsers, u.ID)
+			}
+			if !reflect.DeepEqual(gotUsers, tt.wantUsers) {
+				t.Errorf("Owners() users = %v, want %v", gotUsers, tt.wantUsers)
+			}
+
+			var gotGroups []string
+			for _, g := range got.UserGroups {
+				gotGroups = append(gotGroups, g.Identifier)
+			}
+			if !reflect.DeepEqual(gotGroups, tt.wantGroups) {
+				t.Errorf("Owners() usergroups = %v, want %v", gotGroups, tt.wantGroups)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// scanOnPullReqCreated reports the compliance check for the source commit of a newly created pull request.
+func (s *Service) scanOnPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pull request with id '%d' doesn't exist anymore", event.Payload.PullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return s.scanPullReqCommit(ctx, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		pr.MergeBaseSHA, event.Payload.SourceSHA)
+}
+
+// scanOnPullReqBranchUpdated reports the compliance check for the new source commit of a pull request.
+func (s *Service) scanOnPullReqBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.scanPullReqCommit(ctx, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.NewMergeBaseSHA, event.Payload.NewSHA)
+}
+
+// scanPullReqCommit scans the changes of the pull request commit in the source repository,
+// which contains the commit also for fork pull requests, and reports the check in the target repository.
+func (s *Service) scanPullReqCommit(
+	ctx context.Context,
+	sourceRepoID int64,
+	targetRepoID int64,
+	mergeBaseSHA string,
+	commitSHA string,
+) error {
+	sourceRepo, err := s.findRepo(ctx, sourceRepoID)
+	if err != nil {
+		return err
+	}
+
+	targetRepo := sourceRepo
+	if targetRepoID != sourceRepoID {
+		targetRepo, err = s.findRepo(ctx, targetRepoID)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := s.scanner.ScanChanges(ctx, sourceRepo, mergeBaseSHA, commitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to scan pull request commit %s: %w", commitSHA, err)
+	}
+
+	return s.reportCheck(ctx, targetRepo.ID, report)
+}
+
+func (s *Service) findRepo(ctx context.Context, repoID int64) (*types.Repository, error) {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, events.NewDiscardEventErrorf("repo with id '%d' doesn't exist anymore", repoID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	return repo, nil
+}
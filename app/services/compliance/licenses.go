@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// licenseFingerprint identifies a license by phrases that all have to be contained in the license text.
+type licenseFingerprint struct {
+	spdx    string
+	phrases []string
+}
+
+// licenseFingerprints are checked in order - more specific licenses have to come first
+// (e.g. LGPL before GPL as the LGPL text references the GPL).
+var licenseFingerprints = []licenseFingerprint{
+	{spdx: "AGPL-3.0", phrases: []string{"gnu affero general public license"}},
+	{spdx: "SSPL-1.0", phrases: []string{"server side public license"}},
+	{spdx: "LGPL-3.0", phrases: []string{"gnu lesser general public license", "version 3"}},
+	{spdx: "LGPL-2.1", phrases: []string{"gnu lesser general public license", "version 2.1"}},
+	{spdx: "LGPL-2.0", phrases: []string{"gnu library general public license"}},
+	{spdx: "GPL-3.0", phrases: []string{"gnu general public license", "version 3"}},
+	{spdx: "GPL-2.0", phrases: []string{"gnu general public license", "version 2"}},
+	{spdx: "MPL-2.0", phrases: []string{"mozilla public license", "2.0"}},
+	{spdx: "Apache-2.0", phrases: []string{"apache license", "version 2.0"}},
+	{spdx: "BSD-3-Clause", phrases: []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{spdx: "BSD-2-Clause", phrases: []string{"redistribution and use in source and binary forms"}},
+	{spdx: "ISC", phrases: []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{spdx: "MIT", phrases: []string{"permission is hereby granted, free of charge"}},
+	{spdx: "Unlicense", phrases: []string{"this is free and unencumbered software released into the public domain"}},
+}
+
+var regexpSPDXIdentifier = regexp.MustCompile(`SPDX-License-Identifier:\s*([A-Za-z0-9.+-]+)`)
+
+// detectLicense returns the SPDX identifier of the license in the provided text.
+// An empty string is returned in case the license couldn't be detected.
+func detectLicense(text string) string {
+	if m := regexpSPDXIdentifier.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+fingerprints:
+	for _, fp := range licenseFingerprints {
+		for _, phrase := range fp.phrases {
+			if !strings.Contains(normalized, phrase) {
+				continue fingerprints
+			}
+		}
+		return fp.spdx
+	}
+
+	return ""
+}
+
+// normalizeLicense normalizes an SPDX identifier for comparison (e.g. "GPL-3.0-or-later" -> "gpl-3.0").
+func normalizeLicense(spdx string) string {
+	spdx = strings.ToLower(spdx)
+	spdx = strings.TrimSuffix(spdx, "+")
+	spdx = strings.TrimSuffix(spdx, "-only")
+	spdx = strings.TrimSuffix(spdx, "-or-later")
+	return spdx
+}
+
+// isLicenseFile returns true if the file at the provided path is a license file (e.g. LICENSE.md, COPYING).
+func isLicenseFile(filePath string) bool {
+	name := strings.ToUpper(path.Base(filePath))
+	return strings.HasPrefix(name, "LICENSE") ||
+		strings.HasPrefix(name, "LICENCE") ||
+		strings.HasPrefix(name, "COPYING")
+}
+
+// dependencyDirs are the directories that contain vendored dependencies.
+var dependencyDirs = map[string]struct{}{
+	"vendor":       {},
+	"node_modules": {},
+	"third_party":  {},
+	"third-party":  {},
+	"thirdparty":   {},
+}
+
+// isDependencyPath returns true if the file at the provided path belongs to a vendored dependency.
+func isDependencyPath(filePath string) bool {
+	dirs := strings.Split(path.Dir(filePath), "/")
+	for _, dir := range dirs {
+		if _, ok := dependencyDirs[dir]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import "testing"
+
+func TestDetectLicense(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "spdx identifier",
+			text: "// SPDX-License-Identifier: GPL-3.0-or-later\n",
+			want: "GPL-3.0-or-later",
+		},
+		{
+			name: "mit",
+			text: "MIT License\n\nPermission is hereby granted, free of charge, to any person obtaining a copy",
+			want: "MIT",
+		},
+		{
+			name: "apache",
+			text: "                                 Apache License\n                           Version 2.0, January 2004",
+			want: "Apache-2.0",
+		},
+		{
+			name: "agpl",
+			text: "GNU AFFERO GENERAL PUBLIC LICENSE\n Version 3, 19 November 2007",
+			want: "AGPL-3.0",
+		},
+		{
+			name: "lgpl before gpl",
+			text: "GNU LESSER GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007\n" +
+				"version 3 of the GNU General Public License",
+			want: "LGPL-3.0",
+		},
+		{
+			name: "unknown",
+			text: "All rights reserved.",
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := detectLicense(test.text); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLicense(t *testing.T) {
+	for in, want := range map[string]string{
+		"GPL-3.0-or-later": "gpl-3.0",
+		"GPL-2.0+":         "gpl-2.0",
+		"AGPL-3.0-only":    "agpl-3.0",
+		"MIT":              "mit",
+	} {
+		if got := normalizeLicense(in); got != want {
+			t.Errorf("normalizeLicense(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindRequiredFile(t *testing.T) {
+	files := []string{
+		"README.md",
+		"LICENSE.txt",
+		"src/SECURITY.md",
+		".github/CODEOWNERS",
+	}
+
+	tests := []struct {
+		name     string
+		wantPath string
+		wantOK   bool
+	}{
+		{name: "LICENSE", wantPath: "LICENSE.txt", wantOK: true},
+		{name: "CODEOWNERS", wantPath: ".github/CODEOWNERS", wantOK: true},
+		{name: "SECURITY.md", wantPath: "", wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotPath, gotOK := findRequiredFile(files, test.name)
+			if gotPath != test.wantPath || gotOK != test.wantOK {
+				t.Errorf("got (%q, %t), want (%q, %t)", gotPath, gotOK, test.wantPath, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsDependencyLicense(t *testing.T) {
+	for path, want := range map[string]bool{
+		"vendor/github.com/foo/bar/LICENSE": true,
+		"web/node_modules/left-pad/LICENSE": true,
+		"LICENSE":                           false,
+		"docs/vendor.md":                    false,
+	} {
+		if got := isDependencyPath(path) && isLicenseFile(path); got != want {
+			t.Errorf("isDependencyLicense(%q) = %t, want %t", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ViolationCodeRequiredFileMissing is reported for every required file that doesn't exist in the repository.
+	ViolationCodeRequiredFileMissing = "required_file_missing"
+	// ViolationCodeForbiddenLicense is reported for every vendored dependency with a forbidden license.
+	ViolationCodeForbiddenLicense = "forbidden_license"
+
+	// maxLicenseFileSize is the max number of bytes read from a license file.
+	maxLicenseFileSize = 64 * 1024
+	// maxDependencyLicenseFiles is the max number of dependency license files processed in a single scan.
+	maxDependencyLicenseFiles = 500
+)
+
+// requiredFileDirs are the directories where required files are searched for (in order).
+var requiredFileDirs = []string{"", ".harness", ".github", ".gitlab", "docs"}
+
+// Scanner checks the content of a repository against the content policy.
+type Scanner struct {
+	git               git.Interface
+	requiredFiles     []string
+	forbiddenLicenses map[string]struct{}
+}
+
+func NewScanner(git git.Interface, requiredFiles []string, forbiddenLicenses []string) *Scanner {
+	forbidden := make(map[string]struct{}, len(forbiddenLicenses))
+	for _, license := range forbiddenLicenses {
+		forbidden[normalizeLicense(license)] = struct{}{}
+	}
+
+	return &Scanner{
+		git:               git,
+		requiredFiles:     requiredFiles,
+		forbiddenLicenses: forbidden,
+	}
+}
+
+// Scan scans the repository at the provided git reference and returns the compliance report.
+// All license files of vendored dependencies in the repository are inspected.
+func (s *Scanner) Scan(ctx context.Context, repo *types.Repository, gitRef string) (*types.ComplianceReport, error) {
+	readParams := git.CreateReadParams(repo)
+
+	commitSHA, err := s.resolveCommit(ctx, readParams, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := s.git.ListPaths(ctx, &git.ListPathsParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paths: %w", err)
+	}
+
+	return s.scan(ctx, readParams, repo.ID, commitSHA, paths.Files)
+}
+
+// ScanChanges scans the repository at the provided git reference and returns the compliance report.
+// Only the license files of vendored dependencies changed since the base reference are inspected,
+// which is used for the commits of pull requests. The repository must be the source repository
+// of the pull request, as only it is guaranteed to contain the commits of fork pull requests.
+func (s *Scanner) ScanChanges(
+	ctx context.Context,
+	repo *types.Repository,
+	baseRef string,
+	gitRef string,
+) (*types.ComplianceReport, error) {
+	readParams := git.CreateReadParams(repo)
+
+	commitSHA, err := s.resolveCommit(ctx, readParams, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    baseRef,
+		HeadRef:    commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed paths: %w", err)
+	}
+
+	return s.scan(ctx, readParams, repo.ID, commitSHA, changes.Files)
+}
+
+func (s *Scanner) resolveCommit(ctx context.Context, readParams git.ReadParams, gitRef string) (string, error) {
+	commit, err := s.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		Revision:   gitRef,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit for ref %q: %w", gitRef, err)
+	}
+
+	return commit.Commit.SHA.String(), nil
+}
+
+// scan creates the compliance report of the commit. The required files and the license of the repository
+// are looked up in the commit, while the dependency licenses are taken from the provided candidate files.
+//
+//nolint:gocognit
+func (s *Scanner) scan(
+	ctx context.Context,
+	readParams git.ReadParams,
+	repoID int64,
+	commitSHA string,
+	candidateFiles []string,
+) (*types.ComplianceReport, error) {
+	wellKnownFiles, err := s.listWellKnownFiles(ctx, readParams, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.ComplianceReport{
+		CommitSHA:     commitSHA,
+		Created:       time.Now().UnixMilli(),
+		RequiredFiles: make([]types.ComplianceRequiredFile, 0, len(s.requiredFiles)),
+		Dependencies:  []types.ComplianceDependencyLicense{},
+		Violations:    []types.ComplianceViolation{},
+	}
+
+	for _, name := range s.requiredFiles {
+		filePath, ok := findRequiredFile(wellKnownFiles, name)
+		report.RequiredFiles = append(report.RequiredFiles, types.ComplianceRequiredFile{
+			Name:    name,
+			Path:    filePath,
+			Present: ok,
+		})
+
+		if !ok {
+			report.Violations = append(report.Violations, types.ComplianceViolation{
+				Code:    ViolationCodeRequiredFileMissing,
+				Message: fmt.Sprintf("Required file %q is missing.", name),
+			})
+		}
+	}
+
+	for _, filePath := range wellKnownFiles {
+		if path.Dir(filePath) != "." || !isLicenseFile(filePath) {
+			continue
+		}
+
+		content, _, err := s.readFile(ctx, readParams, commitSHA, filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		report.License = detectLicense(content)
+		break
+	}
+
+	var dependencyLicenseFiles int
+	for _, filePath := range candidateFiles {
+		if !isLicenseFile(filePath) || !isDependencyPath(filePath) {
+			continue
+		}
+
+		if dependencyLicenseFiles >= maxDependencyLicenseFiles {
+			log.Ctx(ctx).Warn().Msgf("reached the limit of %d dependency license files for repo %d",
+				maxDependencyLicenseFiles, repoID)
+			break
+		}
+		dependencyLicenseFiles++
+
+		content, ok, err := s.readFile(ctx, readParams, commitSHA, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// the file got deleted.
+			continue
+		}
+
+		license := detectLicense(content)
+
+		report.Dependencies = append(report.Dependencies, types.ComplianceDependencyLicense{
+			Path:    filePath,
+			License: license,
+		})
+
+		if _, forbidden := s.forbiddenLicenses[normalizeLicense(license)]; forbidden && license != "" {
+			report.Violations = append(report.Violations, types.ComplianceViolation{
+				Code:    ViolationCodeForbiddenLicense,
+				Message: fmt.Sprintf("Dependency is licensed under the forbidden license %q.", license),
+				Path:    filePath,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// listWellKnownFiles returns the paths of all files in the directories where required files are searched for.
+func (s *Scanner) listWellKnownFiles(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHA string,
+) ([]string, error) {
+	var files []string
+
+	for _, dir := range requiredFileDirs {
+		out, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     commitSHA,
+			Path:       dir,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of directory %q: %w", dir, err)
+		}
+
+		for _, node := range out.Nodes {
+			if node.Type == git.TreeNodeTypeBlob {
+				files = append(files, node.Path)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func (s *Scanner) readFile(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHA string,
+	filePath string,
+) (string, bool, error) {
+	node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+		Path:       filePath,
+	})
+	if errors.IsNotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get tree node for %q: %w", filePath, err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return "", false, nil
+	}
+
+	blob, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxLicenseFileSize,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get blob of %q: %w", filePath, err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read blob content of %q: %w", filePath, err)
+	}
+
+	return string(content), true, nil
+}
+
+// findRequiredFile finds the required file in the list of files.
+// The file extension is ignored - "LICENSE" matches "LICENSE.md" and "SECURITY.md" matches "SECURITY.txt".
+func findRequiredFile(files []string, name string) (string, bool) {
+	want := fileStem(name)
+
+	for _, dir := range requiredFileDirs {
+		for _, filePath := range files {
+			fileDir := path.Dir(filePath)
+			if fileDir == "." {
+				fileDir = ""
+			}
+			if fileDir != dir {
+				continue
+			}
+
+			if fileStem(filePath) == want {
+				return filePath, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func fileStem(filePath string) string {
+	name := path.Base(filePath)
+	return strings.ToUpper(strings.TrimSuffix(name, path.Ext(name)))
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"io"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+const testCommitSHA = "1111111111111111111111111111111111111111"
+
+// fakeGit serves the files of a single commit. ListPaths isn't implemented,
+// so the test panics if a pull request scan lists the whole tree.
+type fakeGit struct {
+	git.Interface
+	repoUID string
+	files   map[string]string
+	changed []string
+}
+
+func (g *fakeGit) GetCommit(_ context.Context, params *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	if params.RepoUID != g.repoUID {
+		return nil, errors.NotFound("commit %q not found", params.Revision)
+	}
+	return &git.GetCommitOutput{Commit: git.Commit{SHA: sha.Must(testCommitSHA)}}, nil
+}
+
+func (g *fakeGit) DiffFileNames(_ context.Context, params *git.DiffParams) (git.DiffFileNamesOutput, error) {
+	if params.RepoUID != g.repoUID {
+		return git.DiffFileNamesOutput{}, errors.NotFound("commit %q not found", params.HeadRef)
+	}
+	return git.DiffFileNamesOutput{Files: g.changed}, nil
+}
+
+func (g *fakeGit) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	out := &git.ListTreeNodeOutput{}
+	for filePath := range g.files {
+		dir := path.Dir(filePath)
+		if dir == "." {
+			dir = ""
+		}
+		if dir == params.Path {
+			out.Nodes = append(out.Nodes, git.TreeNode{Type: git.TreeNodeTypeBlob, Path: filePath})
+		}
+	}
+	if len(out.Nodes) == 0 {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+	return out, nil
+}
+
+func (g *fakeGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	if _, ok := g.files[params.Path]; !ok {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+	return &git.GetTreeNodeOutput{Node: git.TreeNode{Type: git.TreeNodeTypeBlob, SHA: params.Path}}, nil
+}
+
+func (g *fakeGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	return &git.GetBlobOutput{Content: io.NopCloser(strings.NewReader(g.files[params.SHA]))}, nil
+}
+
+func TestScanner_ScanChanges(t *testing.T) {
+	g := &fakeGit{
+		repoUID: "fork",
+		files: map[string]string{
+			"LICENSE":                     "SPDX-License-Identifier: Apache-2.0",
+			".github/SECURITY.md":         "security policy",
+			"vendor/changed/LICENSE":      "SPDX-License-Identifier: GPL-3.0-only",
+			"vendor/unchanged/LICENSE":    "SPDX-License-Identifier: GPL-3.0-only",
+			"node_modules/other/LICENSE":  "SPDX-License-Identifier: MIT",
+			"node_modules/other/index.js": "",
+		},
+		changed: []string{
+			"vendor/changed/LICENSE",
+			"vendor/deleted/LICENSE",
+			"node_modules/other/LICENSE",
+			"node_modules/other/index.js",
+		},
+	}
+
+	scanner := NewScanner(g, []string{"SECURITY.md", "CODEOWNERS"}, []string{"GPL-3.0-only"})
+
+	// the commit of a fork pull request exists only in the source repository.
+	report, err := scanner.ScanChanges(context.Background(), &types.Repository{GitUID: "fork"}, "base", "head")
+	if err != nil {
+		t.Fatalf("ScanChanges() error = %v", err)
+	}
+
+	if report.CommitSHA != testCommitSHA {
+		t.Errorf("report commit = %s, want %s", report.CommitSHA, testCommitSHA)
+	}
+	if report.License != "Apache-2.0" {
+		t.Errorf("report license = %q, want %q", report.License, "Apache-2.0")
+	}
+
+	wantRequired := []types.ComplianceRequiredFile{
+		{Name: "SECURITY.md", Path: ".github/SECURITY.md", Present: true},
+		{Name: "CODEOWNERS", Path: "", Present: false},
+	}
+	if !reflect.DeepEqual(report.RequiredFiles, wantRequired) {
+		t.Errorf("report required files = %+v, want %+v", report.RequiredFiles, wantRequired)
+	}
+
+	// only the changed dependency licenses are inspected, deleted ones are skipped.
+	wantDependencies := []types.ComplianceDependencyLicense{
+		{Path: "vendor/changed/LICENSE", License: "GPL-3.0-only"},
+		{Path: "node_modules/other/LICENSE", License: "MIT"},
+	}
+	if !reflect.DeepEqual(report.Dependencies, wantDependencies) {
+		t.Errorf("report dependencies = %+v, want %+v", report.Dependencies, wantDependencies)
+	}
+
+	wantViolations := []types.ComplianceViolation{
+		{Code: ViolationCodeRequiredFileMissing, Message: `Required file "CODEOWNERS" is missing.`},
+		{
+			Code:    ViolationCodeForbiddenLicense,
+			Message: `Dependency is licensed under the forbidden license "GPL-3.0-only".`,
+			Path:    "vendor/changed/LICENSE",
+		},
+	}
+	if !reflect.DeepEqual(report.Violations, wantViolations) {
+		t.Errorf("report violations = %+v, want %+v", report.Violations, wantViolations)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "compliance-scanner"
+
+	// CheckIdentifier is the identifier of the status check reported with the scan result.
+	CheckIdentifier = "compliance"
+)
+
+// Service periodically scans the default branch of all repositories
+// and reports the compliance reports as status checks.
+type Service struct {
+	enabled       bool
+	pullReqChecks bool
+	cron          string
+	maxDur        time.Duration
+	numWorkers    int
+
+	scanner      *Scanner
+	repoStore    store.RepoStore
+	pullreqStore store.PullReqStore
+	checkStore   store.CheckStore
+	scheduler    *job.Scheduler
+}
+
+// Scan scans the repository at the provided git reference and returns the compliance report.
+func (s *Service) Scan(ctx context.Context, repo *types.Repository, gitRef string) (*types.ComplianceReport, error) {
+	return s.scanner.Scan(ctx, repo, gitRef)
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for compliance scanner: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	repoInfos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("start compliance scan of %d repositories", len(repoInfos))
+
+	var wg sync.WaitGroup
+	taskCh := make(chan int64)
+	for i := 0; i < s.numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repoID := range taskCh {
+				if err := s.scanDefaultBranch(ctx, repoID); err != nil {
+					log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed compliance scan of repository")
+				}
+			}
+		}()
+	}
+
+loop:
+	for _, repoInfo := range repoInfos {
+		select {
+		case <-ctx.Done():
+			break loop
+		case taskCh <- repoInfo.ID:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return "", nil
+}
+
+func (s *Service) scanDefaultBranch(ctx context.Context, repoID int64) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if repo.IsEmpty {
+		return nil
+	}
+
+	report, err := s.scanner.Scan(ctx, repo, repo.DefaultBranch)
+	if err != nil {
+		return fmt.Errorf("failed to scan default branch: %w", err)
+	}
+
+	return s.reportCheck(ctx, repo.ID, report)
+}
+
+// reportCheck reports the compliance report as a status check of the scanned commit.
+func (s *Service) reportCheck(ctx context.Context, repoID int64, report *types.ComplianceReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal compliance report: %w", err)
+	}
+
+	status := enum.CheckStatusSuccess
+	summary := "Repository complies with the content policy."
+	if !report.Compliant() {
+		status = enum.CheckStatusFailure
+		summary = fmt.Sprintf("Found %d content policy violation(s).", len(report.Violations))
+	}
+
+	principal := bootstrap.NewSystemServiceSession().Principal
+	now := time.Now().UnixMilli()
+
+	check := &types.Check{
+		CreatedBy:  principal.ID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     repoID,
+		CommitSHA:  report.CommitSHA,
+		Identifier: CheckIdentifier,
+		Status:     status,
+		Summary:    summary,
+		Metadata:   json.RawMessage("{}"),
+		Payload: types.CheckPayload{
+			Version: "1",
+			Kind:    enum.CheckPayloadKindRaw,
+			Data:    data,
+		},
+		ReportedBy: principal.ToPrincipalInfo(),
+		Started:    report.Created,
+		Ended:      now,
+	}
+
+	if err := s.checkStore.Upsert(ctx, check); err != nil {
+		return fmt.Errorf("failed to upsert compliance check: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideScanner,
+	ProvideService,
+)
+
+func ProvideScanner(config *types.Config, git git.Interface) *Scanner {
+	return NewScanner(git, config.Compliance.RequiredFiles, config.Compliance.ForbiddenLicenses)
+}
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	scanner *Scanner,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	checkStore store.CheckStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	service := &Service{
+		enabled:       config.Compliance.Enabled,
+		pullReqChecks: config.Compliance.PullReqChecks,
+		cron:          config.Compliance.CRON,
+		maxDur:        config.Compliance.MaxDuration,
+		numWorkers:    config.Compliance.NumWorkers,
+		scanner:       scanner,
+		repoStore:     repoStore,
+		pullreqStore:  pullreqStore,
+		checkStore:    checkStore,
+		scheduler:     scheduler,
+	}
+
+	if err := executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	if !service.enabled || !service.pullReqChecks {
+		return service, nil
+	}
+
+	const groupCompliance = "gitness:compliance"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupCompliance, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(2),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.scanOnPullReqCreated)
+			_ = r.RegisterBranchUpdated(service.scanOnPullReqBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for compliance checks: %w", err)
+	}
+
+	return service, nil
+}
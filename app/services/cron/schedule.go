@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/gorhill/cronexpr"
+)
+
+const (
+	// maxExpressionLength defines the max allowed length of a cron expression.
+	maxExpressionLength = 128
+
+	// MaxUpcomingRuns defines the max number of upcoming runs that can be listed at once.
+	MaxUpcomingRuns = 100
+)
+
+// Schedule is a cron expression evaluated in a time zone.
+type Schedule struct {
+	expr *cronexpr.Expression
+	loc  *time.Location
+}
+
+// ParseSchedule parses the cron expression and the time zone of a cron trigger.
+// An empty time zone means UTC.
+func ParseSchedule(expression, timezone string) (*Schedule, error) {
+	if expression == "" {
+		return nil, check.NewValidationError("The cron expression is required.")
+	}
+	if len(expression) > maxExpressionLength {
+		return nil, check.NewValidationErrorf("The cron expression can be at most %d characters long.",
+			maxExpressionLength)
+	}
+
+	expr, err := cronexpr.Parse(expression)
+	if err != nil {
+		return nil, check.NewValidationErrorf("The cron expression '%s' is invalid: %s.", expression, err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil || timezone == "Local" {
+			return nil, check.NewValidationErrorf("The time zone '%s' is unknown.", timezone)
+		}
+	}
+
+	return &Schedule{
+		expr: expr,
+		loc:  loc,
+	}, nil
+}
+
+// Next returns the first time matching the schedule after the provided time.
+// It returns the zero time if no time in the future matches the schedule.
+func (s *Schedule) Next(after time.Time) time.Time {
+	return s.expr.Next(after.In(s.loc))
+}
+
+// nextMilli returns the first time matching the schedule after the provided time as unix milliseconds,
+// zero if no time in the future matches the schedule.
+func (s *Schedule) nextMilli(after time.Time) int64 {
+	next := s.Next(after)
+	if next.IsZero() {
+		return 0
+	}
+	return next.UnixMilli()
+}
+
+// Upcoming returns the upcoming runs of the enabled cron triggers after the provided time,
+// ordered by time. The runs of cron triggers without a branch are listed with the default branch.
+func Upcoming(crons []*types.Cron, defaultBranch string, after time.Time, limit int) []types.CronRun {
+	runs := make([]types.CronRun, 0, limit)
+	for _, c := range crons {
+		if c.Disabled {
+			continue
+		}
+
+		schedule, err := ParseSchedule(c.Expression, c.Timezone)
+		if err != nil {
+			continue
+		}
+
+		branch := c.Branch
+		if branch == "" {
+			branch = defaultBranch
+		}
+
+		// each cron contributes at most limit runs, the earliest ones are kept below.
+		next := after
+		for range limit {
+			next = schedule.Next(next)
+			if next.IsZero() {
+				break
+			}
+			runs = append(runs, types.CronRun{
+				Cron:   c.Identifier,
+				Branch: branch,
+				Time:   next.UnixMilli(),
+			})
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Time < runs[j].Time
+	})
+
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	return runs
+}
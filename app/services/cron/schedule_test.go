@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		wantErr    bool
+	}{
+		{name: "utc", expression: "0 9 * * *"},
+		{name: "timezone", expression: "0 9 * * 1-5", timezone: "Europe/Berlin"},
+		{name: "predefined", expression: "@hourly"},
+		{name: "empty", expression: "", wantErr: true},
+		{name: "invalid expression", expression: "not a cron", wantErr: true},
+		{name: "unknown timezone", expression: "0 9 * * *", timezone: "Mars/Base", wantErr: true},
+		{name: "local timezone", expression: "0 9 * * *", timezone: "Local", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseSchedule(test.expression, test.timezone)
+			if test.wantErr != (err != nil) {
+				t.Errorf("want error=%t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	schedule, err := ParseSchedule("0 9 * * *", "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to parse schedule: %s", err)
+	}
+
+	after := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+
+	if got := schedule.Next(after); !got.Equal(want) {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	crons := []*types.Cron{
+		{Identifier: "hourly", Expression: "0 * * * *"},
+		{Identifier: "dev", Branch: "dev", Expression: "30 */2 * * *"},
+		{Identifier: "disabled", Expression: "* * * * *", Disabled: true},
+	}
+
+	after := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) int64 {
+		return time.Date(2024, time.January, 1, hour, minute, 0, 0, time.UTC).UnixMilli()
+	}
+
+	want := []types.CronRun{
+		{Cron: "dev", Branch: "dev", Time: at(0, 30)},
+		{Cron: "hourly", Branch: "main", Time: at(1, 0)},
+		{Cron: "hourly", Branch: "main", Time: at(2, 0)},
+		{Cron: "dev", Branch: "dev", Time: at(2, 30)},
+	}
+
+	if got := Upcoming(crons, "main", after, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/triggerqueue"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pipeline-cron"
+
+	// dueBatchSize defines the number of due cron triggers processed at once by the job.
+	dueBatchSize = 100
+)
+
+// errCronChanged is returned when a due cron trigger was changed while it was being processed.
+var errCronChanged = errors.New("cron trigger changed")
+
+// Service creates the executions of the cron triggers of pipelines.
+//
+// A cron trigger runs at most once per job run: if several scheduled runs were missed,
+// e.g. while the server was down, a single execution catches up on all of them.
+// Missed runs older than the catch-up window are skipped.
+type Service struct {
+	enabled       bool
+	cron          string
+	maxDur        time.Duration
+	catchUpWindow time.Duration
+
+	cronStore     store.CronStore
+	pipelineStore store.PipelineStore
+	repoStore     store.RepoStore
+	commitSvc     commit.Service
+	triggerQueue  *triggerqueue.Service
+	scheduler     *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	cronStore store.CronStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	commitSvc commit.Service,
+	triggerQueue *triggerqueue.Service,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:       config.PipelineCron.Enabled,
+		cron:          config.PipelineCron.CRON,
+		maxDur:        config.PipelineCron.MaxDuration,
+		catchUpWindow: config.PipelineCron.CatchUpWindow,
+		cronStore:     cronStore,
+		pipelineStore: pipelineStore,
+		repoStore:     repoStore,
+		commitSvc:     commitSvc,
+		triggerQueue:  triggerQueue,
+		scheduler:     scheduler,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline cron triggers: %w", err)
+	}
+
+	return nil
+}
+
+// Schedule sets the time of the next run of the cron trigger, starting from now.
+func Schedule(c *types.Cron, now time.Time) error {
+	if c.Disabled {
+		c.Next = 0
+		return nil
+	}
+
+	schedule, err := ParseSchedule(c.Expression, c.Timezone)
+	if err != nil {
+		return err
+	}
+
+	c.Next = schedule.nextMilli(now)
+
+	return nil
+}
+
+// Handle creates the executions of all due cron triggers.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	var triggered, skipped, failed int
+	for {
+		now := time.Now()
+
+		crons, err := s.cronStore.ListDue(ctx, now.UnixMilli(), dueBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list due cron triggers: %w", err)
+		}
+
+		for _, c := range crons {
+			ran, err := s.run(ctx, c, now)
+			switch {
+			case err != nil:
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("cron_id", c.ID).
+					Int64("pipeline_id", c.PipelineID).
+					Msg("failed to run cron trigger")
+				failed++
+			case ran:
+				triggered++
+			default:
+				skipped++
+			}
+		}
+
+		if len(crons) < dueBatchSize {
+			break
+		}
+	}
+
+	return fmt.Sprintf("triggered=%d skipped=%d failed=%d", triggered, skipped, failed), nil
+}
+
+// run advances the cron trigger to its next scheduled run and, unless the missed run
+// is older than the catch-up window, queues the execution of the pipeline.
+// The cron is advanced first so that a failing execution isn't retried on every job run.
+func (s *Service) run(ctx context.Context, c *types.Cron, now time.Time) (bool, error) {
+	scheduled := c.Next
+
+	_, err := s.cronStore.UpdateOptLock(ctx, c, func(c *types.Cron) error {
+		if c.Disabled || c.Next != scheduled {
+			return errCronChanged
+		}
+
+		c.Prev = scheduled
+		return Schedule(c, now)
+	})
+	if errors.Is(err, errCronChanged) {
+		return false, nil
+	}
+	if err != nil {
+		// the cron would be listed as due again, stop processing it by disabling its schedule.
+		return false, s.unschedule(ctx, c, err)
+	}
+
+	if s.catchUpWindow > 0 && now.Sub(time.UnixMilli(scheduled)) > s.catchUpWindow {
+		log.Ctx(ctx).Info().
+			Int64("cron_id", c.ID).
+			Time("scheduled", time.UnixMilli(scheduled)).
+			Msg("skipping missed run of cron trigger outside of the catch-up window")
+		return false, nil
+	}
+
+	if err = s.trigger(ctx, c); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// unschedule clears the next run of a cron trigger that can't be advanced, e.g. because of an invalid
+// expression. Updating the cron trigger schedules it again.
+func (s *Service) unschedule(ctx context.Context, c *types.Cron, cause error) error {
+	_, err := s.cronStore.UpdateOptLock(ctx, c, func(c *types.Cron) error {
+		c.Next = 0
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unschedule cron trigger: %w", err)
+	}
+
+	return fmt.Errorf("failed to schedule cron trigger: %w", cause)
+}
+
+func (s *Service) trigger(ctx context.Context, c *types.Cron) error {
+	pipeline, err := s.pipelineStore.Find(ctx, c.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	// Don't fire triggers for disabled pipelines
+	if pipeline.Disabled {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, c.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	branch := c.Branch
+	if branch == "" {
+		branch = DefaultBranch(pipeline, repo)
+	}
+	ref := scm.ExpandRef(branch, "refs/heads")
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, ref)
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit of branch %q: %w", branch, err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		Action:      enum.TriggerActionCron,
+		Cron:        c.Identifier,
+		TriggeredBy: c.CreatedBy,
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Ref:         ref,
+		Message:     commit.Message,
+		Title:       commit.Title,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Source:      branch,
+		Target:      branch,
+		Params:      map[string]string{},
+		Timestamp:   commit.Author.When.UnixMilli(),
+	}
+
+	if err = s.triggerQueue.Enqueue(ctx, pipeline, hook); err != nil {
+		return fmt.Errorf("failed to queue execution: %w", err)
+	}
+
+	return nil
+}
+
+// DefaultBranch returns the branch used by cron triggers of the pipeline without a branch.
+func DefaultBranch(pipeline *types.Pipeline, repo *types.Repository) string {
+	if pipeline.DefaultBranch != "" {
+		return pipeline.DefaultBranch
+	}
+	return repo.DefaultBranch
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptiontemplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// templateDir is the directory of the repository the description templates are read from.
+	templateDir = ".gitness"
+
+	// defaultTemplateName is the name of the template stored in the single template file of a type.
+	defaultTemplateName = "default"
+
+	// templateExtension is the file extension of the templates stored in the template directory of a type.
+	templateExtension = ".md"
+
+	// maxTemplateSize is the max size of a template file, bigger files are ignored.
+	maxTemplateSize = 64 * 1024
+
+	// maxTemplatesPerType is the max number of templates of a type, additional templates are ignored.
+	maxTemplatesPerType = 32
+)
+
+// templateLocation defines where the templates of a type are stored in the repository.
+type templateLocation struct {
+	// file is the path of the default template.
+	file string
+	// dir is the path of the directory containing the named templates.
+	dir string
+}
+
+var templateLocations = map[enum.DescriptionTemplateType]templateLocation{
+	enum.DescriptionTemplateTypePullReq: {
+		file: path.Join(templateDir, "pull_request_template.md"),
+		dir:  path.Join(templateDir, "PULL_REQUEST_TEMPLATE"),
+	},
+	enum.DescriptionTemplateTypeIssue: {
+		file: path.Join(templateDir, "issue_template.md"),
+		dir:  path.Join(templateDir, "ISSUE_TEMPLATE"),
+	},
+}
+
+// Service reads the pull request and issue description templates from the default branch of repositories.
+type Service struct {
+	git git.Interface
+}
+
+func NewService(git git.Interface) *Service {
+	return &Service{
+		git: git,
+	}
+}
+
+// List returns the description templates of the provided type stored on the default branch of the repository.
+// If no type is provided, the templates of all types are returned.
+// The default template of a type (if any) is always returned first, followed by the named templates.
+func (s *Service) List(
+	ctx context.Context,
+	repo *types.Repository,
+	templateType enum.DescriptionTemplateType,
+) ([]types.DescriptionTemplate, error) {
+	templates := make([]types.DescriptionTemplate, 0)
+	if repo.IsEmpty {
+		return templates, nil
+	}
+
+	templateTypes, _ := enum.GetAllDescriptionTemplateTypes()
+	if templateType != "" {
+		templateTypes = []enum.DescriptionTemplateType{templateType}
+	}
+
+	readParams := git.CreateReadParams(repo)
+	ref := repo.DefaultBranch
+
+	for _, t := range templateTypes {
+		typeTemplates, err := s.listType(ctx, readParams, ref, t)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, typeTemplates...)
+	}
+
+	return templates, nil
+}
+
+func (s *Service) listType(
+	ctx context.Context,
+	readParams git.ReadParams,
+	ref string,
+	templateType enum.DescriptionTemplateType,
+) ([]types.DescriptionTemplate, error) {
+	location := templateLocations[templateType]
+
+	var templates []types.DescriptionTemplate
+
+	node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     ref,
+		Path:       location.file,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get %s template file: %w", templateType, err)
+	}
+	if err == nil && node.Node.Type == git.TreeNodeTypeBlob {
+		content, ok, err := s.readTemplate(ctx, readParams, node.Node.SHA)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			templates = append(templates, types.DescriptionTemplate{
+				Type:    templateType,
+				Name:    defaultTemplateName,
+				Path:    location.file,
+				Content: content,
+			})
+		}
+	}
+
+	nodes, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     ref,
+		Path:       location.dir,
+	})
+	if errors.IsNotFound(err) {
+		return templates, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s template directory: %w", templateType, err)
+	}
+
+	for _, node := range nodes.Nodes {
+		if len(templates) >= maxTemplatesPerType {
+			log.Ctx(ctx).Warn().Msgf("ignoring %s templates over the limit of %d", templateType, maxTemplatesPerType)
+			break
+		}
+
+		if node.Type != git.TreeNodeTypeBlob || !strings.HasSuffix(strings.ToLower(node.Name), templateExtension) {
+			continue
+		}
+
+		content, ok, err := s.readTemplate(ctx, readParams, node.SHA)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		templates = append(templates, types.DescriptionTemplate{
+			Type:    templateType,
+			Name:    node.Name[:len(node.Name)-len(templateExtension)],
+			Path:    node.Path,
+			Content: content,
+		})
+	}
+
+	return templates, nil
+}
+
+// readTemplate returns the content of the template blob. False is returned if the template is too big.
+func (s *Service) readTemplate(
+	ctx context.Context,
+	readParams git.ReadParams,
+	blobSHA string,
+) (string, bool, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  maxTemplateSize,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get template content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close template content reader")
+		}
+	}()
+
+	if output.Size > maxTemplateSize {
+		log.Ctx(ctx).Debug().Msgf("ignoring template %s of size %d", blobSHA, output.Size)
+		return "", false, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read template content: %w", err)
+	}
+
+	return string(content), true, nil
+}
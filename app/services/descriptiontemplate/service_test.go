@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptiontemplate
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeGit struct {
+	git.Interface
+	files map[string]git.TreeNode
+	dirs  map[string][]git.TreeNode
+	blobs map[string]string
+}
+
+func (g *fakeGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	node, ok := g.files[params.Path]
+	if !ok {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+	return &git.GetTreeNodeOutput{Node: node}, nil
+}
+
+func (g *fakeGit) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	nodes, ok := g.dirs[params.Path]
+	if !ok {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+	return &git.ListTreeNodeOutput{Nodes: nodes}, nil
+}
+
+func (g *fakeGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content := g.blobs[params.SHA]
+	return &git.GetBlobOutput{
+		Size:    int64(len(content)),
+		Content: io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func blob(path, sha string) git.TreeNode {
+	name := path[strings.LastIndex(path, "/")+1:]
+	return git.TreeNode{Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: sha, Name: name, Path: path}
+}
+
+func TestService_List(t *testing.T) {
+	fake := &fakeGit{
+		files: map[string]git.TreeNode{
+			".gitness/pull_request_template.md": blob(".gitness/pull_request_template.md", "default"),
+		},
+		dirs: map[string][]git.TreeNode{
+			".gitness/PULL_REQUEST_TEMPLATE": {
+				blob(".gitness/PULL_REQUEST_TEMPLATE/bugfix.md", "bugfix"),
+				blob(".gitness/PULL_REQUEST_TEMPLATE/notes.txt", "notes"),
+				blob(".gitness/PULL_REQUEST_TEMPLATE/huge.md", "huge"),
+				{Type: git.TreeNodeTypeTree, Name: "nested.md", Path: ".gitness/PULL_REQUEST_TEMPLATE/nested.md"},
+			},
+			".gitness/ISSUE_TEMPLATE": {
+				blob(".gitness/ISSUE_TEMPLATE/feature.md", "feature"),
+			},
+		},
+		blobs: map[string]string{
+			"default": "## Summary",
+			"bugfix":  "## Bug",
+			"notes":   "notes",
+			"huge":    strings.Repeat("a", maxTemplateSize+1),
+			"feature": "## Feature",
+		},
+	}
+
+	s := NewService(fake)
+	repo := &types.Repository{GitUID: "repo", DefaultBranch: "main"}
+
+	pullReqTemplates := []types.DescriptionTemplate{
+		{
+			Type:    enum.DescriptionTemplateTypePullReq,
+			Name:    "default",
+			Path:    ".gitness/pull_request_template.md",
+			Content: "## Summary",
+		},
+		{
+			Type:    enum.DescriptionTemplateTypePullReq,
+			Name:    "bugfix",
+			Path:    ".gitness/PULL_REQUEST_TEMPLATE/bugfix.md",
+			Content: "## Bug",
+		},
+	}
+	issueTemplates := []types.DescriptionTemplate{
+		{
+			Type:    enum.DescriptionTemplateTypeIssue,
+			Name:    "feature",
+			Path:    ".gitness/ISSUE_TEMPLATE/feature.md",
+			Content: "## Feature",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		templateType enum.DescriptionTemplateType
+		repoIsEmpty  bool
+		want         []types.DescriptionTemplate
+	}{
+		{
+			name:         "pull request templates",
+			templateType: enum.DescriptionTemplateTypePullReq,
+			want:         pullReqTemplates,
+		},
+		{
+			name:         "issue templates",
+			templateType: enum.DescriptionTemplateTypeIssue,
+			want:         issueTemplates,
+		},
+		{
+			name: "all templates",
+			want: append(append([]types.DescriptionTemplate{}, issueTemplates...), pullReqTemplates...),
+		},
+		{
+			name:        "empty repo",
+			repoIsEmpty: true,
+			want:        []types.DescriptionTemplate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.IsEmpty = tt.repoIsEmpty
+
+			got, err := s.List(context.Background(), repo, tt.templateType)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
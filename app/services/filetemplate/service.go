@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filetemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/resources"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxLabelLength   = 256
+	maxContentLength = 1 << 20 // 1 MiB
+)
+
+// CreateInput is the input for creating a custom file template.
+type CreateInput struct {
+	Identifier string `json:"identifier"`
+	Label      string `json:"label"`
+	Content    string `json:"content"`
+}
+
+// Service manages the gitignore and license templates used for repository creation.
+// The built-in templates can be extended with custom templates of the system and of spaces.
+// A custom template overrides a template with the same identifier defined on a higher level.
+type Service struct {
+	fileTemplateStore store.FileTemplateStore
+	spaceStore        store.SpaceStore
+}
+
+func NewService(
+	fileTemplateStore store.FileTemplateStore,
+	spaceStore store.SpaceStore,
+) *Service {
+	return &Service{
+		fileTemplateStore: fileTemplateStore,
+		spaceStore:        spaceStore,
+	}
+}
+
+// List returns all templates of the type available in the space, sorted by their identifier.
+// Space ID 0 returns the templates available in the system.
+func (s *Service) List(
+	ctx context.Context,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+) ([]types.FileTemplateInfo, error) {
+	builtIn, err := builtInTemplates(templateType)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := s.scopes(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	custom, err := s.fileTemplateStore.List(ctx, templateType, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom file templates: %w", err)
+	}
+
+	templates := make(map[string]types.FileTemplateInfo, len(builtIn)+len(custom))
+	for _, info := range builtIn {
+		templates[strings.ToLower(info.Value)] = info
+	}
+
+	// scopes are ordered from the system to the space, so the closest template wins.
+	for _, scopeID := range scopes {
+		for _, template := range custom {
+			if template.SpaceID != scopeID {
+				continue
+			}
+
+			templates[strings.ToLower(template.Identifier)] = types.FileTemplateInfo{
+				Label: template.Label,
+				Value: template.Identifier,
+			}
+		}
+	}
+
+	result := make([]types.FileTemplateInfo, 0, len(templates))
+	for _, info := range templates {
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Value) < strings.ToLower(result[j].Value)
+	})
+
+	return result, nil
+}
+
+// Read returns the content of the template of the type available in the space.
+func (s *Service) Read(
+	ctx context.Context,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+	identifier string,
+) ([]byte, error) {
+	scopes, err := s.scopes(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(scopes) - 1; i >= 0; i-- {
+		template, err := s.fileTemplateStore.Find(ctx, scopes[i], templateType, identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find custom file template: %w", err)
+		}
+
+		return []byte(template.Content), nil
+	}
+
+	var content []byte
+	switch templateType {
+	case enum.FileTemplateTypeGitIgnore:
+		content, err = resources.ReadGitIgnore(identifier)
+	case enum.FileTemplateTypeLicense:
+		content, err = resources.ReadLicense(identifier)
+	}
+	if err != nil || content == nil {
+		return nil, errors.InvalidArgument("Unknown %s template '%s'.", templateType, identifier)
+	}
+
+	return content, nil
+}
+
+// Find returns the custom template of the space. Space ID 0 finds a system template.
+func (s *Service) Find(
+	ctx context.Context,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+	identifier string,
+) (*types.FileTemplate, error) {
+	template, err := s.fileTemplateStore.Find(ctx, spaceID, templateType, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file template: %w", err)
+	}
+
+	return template, nil
+}
+
+// Create adds a custom template to the space. Space ID 0 adds a system template.
+func (s *Service) Create(
+	ctx context.Context,
+	principalID int64,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+	in *CreateInput,
+) (*types.FileTemplate, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	template := &types.FileTemplate{
+		SpaceID:    spaceID,
+		Type:       templateType,
+		Identifier: in.Identifier,
+		Label:      in.Label,
+		Content:    in.Content,
+		CreatedBy:  principalID,
+		Created:    now,
+		Updated:    now,
+	}
+
+	err := s.fileTemplateStore.Create(ctx, template)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("A %s template with identifier '%s' already exists.",
+			templateType, in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file template: %w", err)
+	}
+
+	return template, nil
+}
+
+// Delete removes a custom template of the space. Space ID 0 removes a system template.
+func (s *Service) Delete(
+	ctx context.Context,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+	identifier string,
+) error {
+	template, err := s.fileTemplateStore.Find(ctx, spaceID, templateType, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find file template: %w", err)
+	}
+
+	if err := s.fileTemplateStore.Delete(ctx, template.ID); err != nil {
+		return fmt.Errorf("failed to delete file template: %w", err)
+	}
+
+	return nil
+}
+
+// scopes returns the IDs of the scopes whose templates are available in the space,
+// starting with the system (ID 0), followed by the root space and ending with the space itself.
+func (s *Service) scopes(ctx context.Context, spaceID int64) ([]int64, error) {
+	if spaceID == 0 {
+		return []int64{0}, nil
+	}
+
+	ancestors, err := s.spaceStore.GetAncestors(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestors of space: %w", err)
+	}
+
+	parents := make(map[int64]int64, len(ancestors))
+	for _, space := range ancestors {
+		parents[space.ID] = space.ParentID
+	}
+
+	scopes := []int64{0}
+	for id := spaceID; id > 0; id = parents[id] {
+		scopes = append(scopes, id)
+	}
+
+	// reverse the space IDs to get the root space first.
+	for i, j := 1, len(scopes)-1; i < j; i, j = i+1, j-1 {
+		scopes[i], scopes[j] = scopes[j], scopes[i]
+	}
+
+	return scopes, nil
+}
+
+func builtInTemplates(templateType enum.FileTemplateType) ([]types.FileTemplateInfo, error) {
+	switch templateType {
+	case enum.FileTemplateTypeGitIgnore:
+		names, err := resources.GitIgnores()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gitignore files: %w", err)
+		}
+
+		result := make([]types.FileTemplateInfo, len(names))
+		for i, name := range names {
+			result[i] = types.FileTemplateInfo{Label: name, Value: name}
+		}
+
+		return result, nil
+	case enum.FileTemplateTypeLicense:
+		data, err := resources.Licenses()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load license files: %w", err)
+		}
+
+		var result []types.FileTemplateInfo
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal license index: %w", err)
+		}
+
+		return result, nil
+	default:
+		return nil, errors.InvalidArgument("Unknown file template type '%s'.", templateType)
+	}
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.Label = strings.TrimSpace(in.Label)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.Label == "" {
+		in.Label = in.Identifier
+	}
+
+	if len(in.Label) > maxLabelLength {
+		return errors.InvalidArgument("Label can be at most %d characters long.", maxLabelLength)
+	}
+
+	if in.Content == "" {
+		return errors.InvalidArgument("Content must be provided.")
+	}
+
+	if len(in.Content) > maxContentLength {
+		return errors.InvalidArgument("Content can be at most %d bytes long.", maxContentLength)
+	}
+
+	return nil
+}
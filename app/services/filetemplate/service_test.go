@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filetemplate
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	rootSpaceID  = 1
+	teamSpaceID  = 2
+	otherSpaceID = 3
+)
+
+type fakeFileTemplateStore struct {
+	store.FileTemplateStore
+	templates []*types.FileTemplate
+}
+
+func (s *fakeFileTemplateStore) Find(
+	_ context.Context,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+	identifier string,
+) (*types.FileTemplate, error) {
+	for _, template := range s.templates {
+		if template.SpaceID == spaceID && template.Type == templateType &&
+			strings.EqualFold(template.Identifier, identifier) {
+			return template, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeFileTemplateStore) Create(_ context.Context, template *types.FileTemplate) error {
+	if _, err := s.Find(context.Background(), template.SpaceID, template.Type, template.Identifier); err == nil {
+		return gitness_store.ErrDuplicate
+	}
+	s.templates = append(s.templates, template)
+	return nil
+}
+
+func (s *fakeFileTemplateStore) List(
+	_ context.Context,
+	templateType enum.FileTemplateType,
+	spaceIDs []int64,
+) ([]*types.FileTemplate, error) {
+	var result []*types.FileTemplate
+	for _, template := range s.templates {
+		if template.Type == templateType && slices.Contains(spaceIDs, template.SpaceID) {
+			result = append(result, template)
+		}
+	}
+	return result, nil
+}
+
+// fakeSpaceStore has the root space with a single team space, and another root space.
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) GetAncestors(_ context.Context, spaceID int64) ([]*types.Space, error) {
+	switch spaceID {
+	case rootSpaceID:
+		return []*types.Space{{ID: rootSpaceID}}, nil
+	case teamSpaceID:
+		return []*types.Space{{ID: teamSpaceID, ParentID: rootSpaceID}, {ID: rootSpaceID}}, nil
+	case otherSpaceID:
+		return []*types.Space{{ID: otherSpaceID}}, nil
+	default:
+		return nil, gitness_store.ErrResourceNotFound
+	}
+}
+
+func gitIgnoreTemplate(spaceID int64, identifier, label string) *types.FileTemplate {
+	return &types.FileTemplate{
+		SpaceID:    spaceID,
+		Type:       enum.FileTemplateTypeGitIgnore,
+		Identifier: identifier,
+		Label:      label,
+		Content:    label + " content",
+	}
+}
+
+func newTestService() *Service {
+	return NewService(&fakeFileTemplateStore{templates: []*types.FileTemplate{
+		gitIgnoreTemplate(0, "go", "System Go"),
+		gitIgnoreTemplate(0, "company", "System Company"),
+		gitIgnoreTemplate(rootSpaceID, "Company", "Root Company"),
+		gitIgnoreTemplate(teamSpaceID, "team", "Team"),
+		gitIgnoreTemplate(otherSpaceID, "other", "Other"),
+	}}, fakeSpaceStore{})
+}
+
+func TestService_List(t *testing.T) {
+	s := newTestService()
+
+	templates, err := s.List(context.Background(), teamSpaceID, enum.FileTemplateTypeGitIgnore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	labels := make(map[string]string, len(templates))
+	for _, template := range templates {
+		if _, ok := labels[strings.ToLower(template.Value)]; ok {
+			t.Errorf("template %q is listed more than once", template.Value)
+		}
+		labels[strings.ToLower(template.Value)] = template.Label
+	}
+
+	want := map[string]string{
+		"ada":     "Ada",          // built-in
+		"go":      "System Go",    // system overrides built-in
+		"company": "Root Company", // space overrides system
+		"team":    "Team",
+	}
+	for value, label := range want {
+		if labels[value] != label {
+			t.Errorf("label of %q = %q, want %q", value, labels[value], label)
+		}
+	}
+
+	if _, ok := labels["other"]; ok {
+		t.Errorf("template of another space is listed")
+	}
+
+	if !slices.IsSortedFunc(templates, func(a, b types.FileTemplateInfo) int {
+		return strings.Compare(strings.ToLower(a.Value), strings.ToLower(b.Value))
+	}) {
+		t.Errorf("templates aren't sorted by their identifier")
+	}
+}
+
+func TestService_Read(t *testing.T) {
+	s := newTestService()
+
+	tests := []struct {
+		name         string
+		spaceID      int64
+		templateType enum.FileTemplateType
+		identifier   string
+		want         string
+		wantBuiltIn  bool
+		wantErr      bool
+	}{
+		{
+			name:         "closest space",
+			spaceID:      teamSpaceID,
+			templateType: enum.FileTemplateTypeGitIgnore,
+			identifier:   "company",
+			want:         "Root Company content",
+		},
+		{
+			name:         "system",
+			spaceID:      0,
+			templateType: enum.FileTemplateTypeGitIgnore,
+			identifier:   "company",
+			want:         "System Company content",
+		},
+		{
+			name:         "system overrides built-in",
+			spaceID:      teamSpaceID,
+			templateType: enum.FileTemplateTypeGitIgnore,
+			identifier:   "Go",
+			want:         "System Go content",
+		},
+		{
+			name:         "built-in",
+			spaceID:      teamSpaceID,
+			templateType: enum.FileTemplateTypeLicense,
+			identifier:   "mit",
+			wantBuiltIn:  true,
+		},
+		{
+			name:         "other space",
+			spaceID:      teamSpaceID,
+			templateType: enum.FileTemplateTypeGitIgnore,
+			identifier:   "other",
+			wantErr:      true,
+		},
+		{
+			name:         "wrong type",
+			spaceID:      teamSpaceID,
+			templateType: enum.FileTemplateTypeLicense,
+			identifier:   "team",
+			wantErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content, err := s.Read(context.Background(), test.spaceID, test.templateType, test.identifier)
+			if test.wantErr {
+				if !errors.IsInvalidArgument(err) {
+					t.Errorf("expected invalid argument, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.wantBuiltIn {
+				if len(content) == 0 {
+					t.Errorf("expected the built-in template content")
+				}
+				return
+			}
+
+			if string(content) != test.want {
+				t.Errorf("content = %q, want %q", content, test.want)
+			}
+		})
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	s := newTestService()
+	ctx := context.Background()
+
+	template, err := s.Create(ctx, 1, teamSpaceID, enum.FileTemplateTypeLicense,
+		&CreateInput{Identifier: " internal ", Content: "internal license"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.Identifier != "internal" || template.Label != "internal" {
+		t.Errorf("template = %+v, want the identifier to be trimmed and used as the label", template)
+	}
+
+	_, err = s.Create(ctx, 1, teamSpaceID, enum.FileTemplateTypeLicense,
+		&CreateInput{Identifier: "Internal", Content: "internal license"})
+	if !errors.IsConflict(err) {
+		t.Errorf("expected conflict for a duplicate template, got %v", err)
+	}
+
+	invalid := []*CreateInput{
+		{Identifier: "", Content: "content"},
+		{Identifier: "no-content"},
+		{Identifier: "long-label", Label: strings.Repeat("a", maxLabelLength+1), Content: "content"},
+		{Identifier: "large", Content: strings.Repeat("a", maxContentLength+1)},
+	}
+	for _, in := range invalid {
+		if _, err = s.Create(ctx, 1, teamSpaceID, enum.FileTemplateTypeLicense, in); err == nil {
+			t.Errorf("Create() of %q succeeded, want error", in.Identifier)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -101,6 +102,7 @@ func (r *RepositoryInfo) ToRepo(
 		Updated:       now,
 		ForkID:        0,
 		DefaultBranch: r.DefaultBranch,
+		ObjectFormat:  sha.ObjectFormatSHA1,
 		State:         enum.RepoStateGitImport,
 		Path:          paths.Concatenate(spacePath, identifier),
 	}, r.IsPublic
@@ -29,12 +29,15 @@ import (
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	gitnessurl "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/job"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -55,19 +58,21 @@ var (
 )
 
 type Repository struct {
-	defaultBranch string
-	urlProvider   gitnessurl.Provider
-	git           git.Interface
-	tx            dbtx.Transactor
-	repoStore     store.RepoStore
-	pipelineStore store.PipelineStore
-	triggerStore  store.TriggerStore
-	encrypter     encrypt.Encrypter
-	scheduler     *job.Scheduler
-	sseStreamer   sse.Streamer
-	indexer       keywordsearch.Indexer
-	publicAccess  publicaccess.Service
-	auditService  audit.Service
+	defaultBranch   string
+	urlProvider     gitnessurl.Provider
+	git             git.Interface
+	tx              dbtx.Transactor
+	repoStore       store.RepoStore
+	pipelineStore   store.PipelineStore
+	triggerStore    store.TriggerStore
+	encrypter       encrypt.Encrypter
+	scheduler       *job.Scheduler
+	sseStreamer     sse.Streamer
+	indexer         keywordsearch.Indexer
+	publicAccess    publicaccess.Service
+	auditService    audit.Service
+	repoTemplateSvc *repotemplate.Service
+	storagePoolSvc  *storagepool.Service
 }
 
 var _ job.Handler = (*Repository)(nil)
@@ -293,7 +298,12 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 	log.Info().Msg("create git repository")
 
-	gitUID, err := r.createGitRepository(ctx, &systemPrincipal, repo.ID)
+	storagePool, err := r.storagePoolSvc.Resolve(ctx, repo.ParentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve storage pool: %w", err)
+	}
+
+	gitUID, err := r.createGitRepository(ctx, &systemPrincipal, repo.ID, storagePool, repo.ObjectFormat)
 	if err != nil {
 		return "", fmt.Errorf("failed to create empty git repository: %w", err)
 	}
@@ -306,6 +316,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 				return errors.New("repository has already finished importing")
 			}
 			repo.GitUID = gitUID
+			repo.StoragePool = storagePool
 			return nil
 		})
 		if err != nil {
@@ -372,6 +383,11 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		log.Warn().Err(err).Msg("failed to publish import completion SSE")
 	}
 
+	_, err = r.repoTemplateSvc.Apply(ctx, systemPrincipal.ID, repo)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to apply repo templates")
+	}
+
 	err = r.indexer.Index(ctx, repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("failed to index repository")
@@ -425,6 +441,8 @@ func (r *Repository) Cancel(ctx context.Context, repo *types.Repository) error {
 func (r *Repository) createGitRepository(ctx context.Context,
 	principal *types.Principal,
 	repoID int64,
+	storagePool string,
+	objectFormat sha.ObjectFormat,
 ) (string, error) {
 	now := time.Now()
 
@@ -440,6 +458,8 @@ func (r *Repository) createGitRepository(ctx context.Context,
 		},
 		EnvVars:       envVars,
 		DefaultBranch: r.defaultBranch,
+		StoragePool:   storagePool,
+		ObjectFormat:  objectFormat,
 		Files:         nil,
 		Author: &git.Identity{
 			Name:  principal.DisplayName,
@@ -474,6 +494,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 		Source:            sourceCloneURL,
 		CreateIfNotExists: false,
 		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		ObjectFormat:      repo.ObjectFormat,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to sync repository: %w", err)
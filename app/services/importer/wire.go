@@ -17,6 +17,8 @@ package importer
 import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/storagepool"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,21 +51,25 @@ func ProvideRepoImporter(
 	indexer keywordsearch.Indexer,
 	publicAccess publicaccess.Service,
 	auditService audit.Service,
+	repoTemplateSvc *repotemplate.Service,
+	storagePoolSvc *storagepool.Service,
 ) (*Repository, error) {
 	importer := &Repository{
-		defaultBranch: config.Git.DefaultBranch,
-		urlProvider:   urlProvider,
-		git:           git,
-		tx:            tx,
-		repoStore:     repoStore,
-		pipelineStore: pipelineStore,
-		triggerStore:  triggerStore,
-		encrypter:     encrypter,
-		scheduler:     scheduler,
-		sseStreamer:   sseStreamer,
-		indexer:       indexer,
-		publicAccess:  publicAccess,
-		auditService:  auditService,
+		defaultBranch:   config.Git.DefaultBranch,
+		urlProvider:     urlProvider,
+		git:             git,
+		tx:              tx,
+		repoStore:       repoStore,
+		pipelineStore:   pipelineStore,
+		triggerStore:    triggerStore,
+		encrypter:       encrypter,
+		scheduler:       scheduler,
+		sseStreamer:     sseStreamer,
+		indexer:         indexer,
+		publicAccess:    publicAccess,
+		auditService:    auditService,
+		repoTemplateSvc: repoTemplateSvc,
+		storagePoolSvc:  storagePoolSvc,
 	}
 
 	err := executor.Register(jobType, importer)
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// commitsPageSize defines the number of commits read at once from the git repository.
+	commitsPageSize = 100
+
+	// maxCommits limits the number of commits processed by a single computation of the repository insights.
+	// For repositories with longer history, only the most recent commits are included in the statistics.
+	maxCommits = 50000
+
+	// pullReqsPageSize defines the number of pull requests read at once when the statistics are initialized.
+	pullReqsPageSize = 100
+)
+
+// process computes the statistics of the commits pushed to the default branch since the last computation.
+// If the history of the branch got rewritten, or the default branch changed, the statistics are computed anew.
+func (s *Service) process(ctx context.Context, state *types.RepoInsightsState) error {
+	if err := s.insightsStore.ClearStale(ctx, state.RepoID); err != nil {
+		return fmt.Errorf("failed to clear stale mark: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, state.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if state.Processed == 0 {
+		if err = s.initPullReqStats(ctx, repo.ID, state.Created); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	if repo.IsEmpty {
+		return s.insightsStore.UpdateProcessed(ctx, repo.ID, repo.DefaultBranch, "", now)
+	}
+
+	branch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		return s.insightsStore.UpdateProcessed(ctx, repo.ID, repo.DefaultBranch, "", now)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	head := branch.Branch.SHA.String()
+	if state.Branch == repo.DefaultBranch && state.CommitSHA == head {
+		return s.insightsStore.UpdateProcessed(ctx, repo.ID, repo.DefaultBranch, head, now)
+	}
+
+	after, err := s.processedAncestor(ctx, repo, state, branch.Branch.SHA)
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.collectCommitStats(ctx, repo, head, after)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if after == "" {
+			if err := s.insightsStore.DeleteCommitStats(ctx, repo.ID); err != nil {
+				return fmt.Errorf("failed to delete commit statistics: %w", err)
+			}
+		}
+
+		if err := s.insightsStore.AddCommitStats(ctx, repo.ID, stats); err != nil {
+			return fmt.Errorf("failed to add commit statistics: %w", err)
+		}
+
+		return s.insightsStore.UpdateProcessed(ctx, repo.ID, repo.DefaultBranch, head, now)
+	})
+}
+
+// processedAncestor returns the last processed commit if it's still part of the history of the default branch.
+// Otherwise, it returns an empty string, meaning that the statistics must be computed from scratch.
+func (s *Service) processedAncestor(
+	ctx context.Context,
+	repo *types.Repository,
+	state *types.RepoInsightsState,
+	head sha.SHA,
+) (string, error) {
+	if state.Branch != repo.DefaultBranch || state.CommitSHA == "" {
+		return "", nil
+	}
+
+	processedSHA, err := sha.New(state.CommitSHA)
+	if err != nil {
+		return "", nil //nolint:nilerr // an invalid commit SHA is replaced by the new computation
+	}
+
+	result, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+		AncestorCommitSHA:   processedSHA,
+		DescendantCommitSHA: head,
+	})
+	if errors.IsNotFound(err) {
+		// the processed commit got garbage collected after a force push.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check if the processed commit is an ancestor of the branch: %w", err)
+	}
+
+	if !result.Ancestor {
+		return "", nil
+	}
+
+	return state.CommitSHA, nil
+}
+
+// collectCommitStats returns the weekly statistics per author of the commits reachable from head,
+// excluding the commits reachable from after.
+func (s *Service) collectCommitStats(
+	ctx context.Context,
+	repo *types.Repository,
+	head string,
+	after string,
+) ([]*types.RepoInsightsCommitStats, error) {
+	type statsKey struct {
+		week  int64
+		email string
+	}
+
+	statsMap := make(map[statsKey]*types.RepoInsightsCommitStats)
+	stats := make([]*types.RepoInsightsCommitStats, 0)
+
+	for page, count := 1, 0; count < maxCommits; page++ {
+		output, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams:   git.ReadParams{RepoUID: repo.GitUID},
+			GitREF:       head,
+			After:        after,
+			Page:         int32(page), //nolint:gosec
+			Limit:        commitsPageSize,
+			IncludeStats: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", err)
+		}
+
+		for _, commit := range output.Commits {
+			key := statsKey{
+				week:  WeekStart(commit.Author.When),
+				email: strings.ToLower(commit.Author.Identity.Email),
+			}
+
+			stat, ok := statsMap[key]
+			if !ok {
+				stat = &types.RepoInsightsCommitStats{
+					Week:        key.week,
+					AuthorEmail: key.email,
+					AuthorName:  commit.Author.Identity.Name,
+				}
+				statsMap[key] = stat
+				stats = append(stats, stat)
+			}
+
+			stat.Commits++
+			for _, fileStats := range commit.FileStats {
+				stat.Additions += fileStats.Insertions
+				stat.Deletions += fileStats.Deletions
+			}
+		}
+
+		count += len(output.Commits)
+
+		if len(output.Commits) < commitsPageSize {
+			break
+		}
+	}
+
+	return stats, nil
+}
+
+// initPullReqStats computes the statistics of the pull requests opened, merged and closed before the
+// insights of the repository have been requested. Later changes are counted by the pull request event handlers.
+func (s *Service) initPullReqStats(ctx context.Context, repoID int64, cutoff int64) error {
+	statsMap := make(map[int64]*types.RepoInsightsPullReqStats)
+	stats := make([]types.RepoInsightsPullReqStats, 0)
+
+	weekStats := func(timestamp int64) *types.RepoInsightsPullReqStats {
+		week := WeekStart(time.UnixMilli(timestamp))
+		stat, ok := statsMap[week]
+		if !ok {
+			stat = &types.RepoInsightsPullReqStats{Week: week}
+			statsMap[week] = stat
+		}
+		return stat
+	}
+
+	for page := 1; ; page++ {
+		pullReqs, err := s.pullReqStore.List(ctx, &types.PullReqFilter{
+			Page:         page,
+			Size:         pullReqsPageSize,
+			TargetRepoID: repoID,
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderAsc,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		for _, pr := range pullReqs {
+			if pr.Created < cutoff {
+				weekStats(pr.Created).Opened++
+			}
+
+			switch {
+			case pr.Merged != nil && *pr.Merged < cutoff:
+				weekStats(*pr.Merged).Merged++
+			case pr.Merged == nil && pr.Closed != nil && *pr.Closed < cutoff:
+				weekStats(*pr.Closed).Closed++
+			}
+		}
+
+		if len(pullReqs) < pullReqsPageSize {
+			break
+		}
+	}
+
+	for _, stat := range statsMap {
+		stats = append(stats, *stat)
+	}
+
+	// the statistics counted by the event handlers since the cutoff are kept.
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.insightsStore.AddPullReqStats(ctx, repoID, stats); err != nil {
+			return fmt.Errorf("failed to add pull request statistics: %w", err)
+		}
+
+		// the pull request statistics are initialized only once.
+		return s.insightsStore.UpdateProcessed(ctx, repoID, "", "", time.Now().UnixMilli())
+	})
+}
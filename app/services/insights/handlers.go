@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// markStaleOnBranchCreated requests the computation of the insights if the default branch got created.
+func (s *Service) markStaleOnBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload],
+) error {
+	return s.markStaleIfDefaultBranch(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+// markStaleOnBranchUpdated requests the computation of the insights if the default branch got updated.
+func (s *Service) markStaleOnBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	return s.markStaleIfDefaultBranch(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+// markStaleOnDefaultBranchUpdated requests the computation of the insights for the new default branch.
+func (s *Service) markStaleOnDefaultBranchUpdated(ctx context.Context,
+	event *events.Event[*repoevents.DefaultBranchUpdatedPayload],
+) error {
+	return s.markStale(ctx, event.Payload.RepoID)
+}
+
+// countOnPullReqCreated counts the opened pull request.
+func (s *Service) countOnPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	return s.addPullReqStats(ctx, event.Payload.TargetRepoID, event.Timestamp,
+		types.RepoInsightsPullReqStats{Opened: 1})
+}
+
+// countOnPullReqMerged counts the merged pull request.
+func (s *Service) countOnPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.addPullReqStats(ctx, event.Payload.TargetRepoID, event.Timestamp,
+		types.RepoInsightsPullReqStats{Merged: 1})
+}
+
+// countOnPullReqClosed counts the closed pull request.
+func (s *Service) countOnPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.addPullReqStats(ctx, event.Payload.TargetRepoID, event.Timestamp,
+		types.RepoInsightsPullReqStats{Closed: 1})
+}
+
+func (s *Service) markStaleIfDefaultBranch(ctx context.Context, repoID int64, ref string) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("repo with id '%d' doesn't exist anymore", repoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	// the insights are computed only for the default branch.
+	if strings.TrimPrefix(ref, "refs/heads/") != repo.DefaultBranch {
+		return nil
+	}
+
+	return s.markStale(ctx, repoID)
+}
+
+func (s *Service) markStale(ctx context.Context, repoID int64) error {
+	if err := s.insightsStore.MarkStale(ctx, repoID, time.Now().UnixMilli()); err != nil {
+		return fmt.Errorf("failed to mark repository insights as stale: %w", err)
+	}
+
+	return nil
+}
+
+// addPullReqStats counts the pull request event, if the insights of the repository have been requested
+// before the event. The earlier events are included when the pull request statistics are initialized.
+func (s *Service) addPullReqStats(
+	ctx context.Context,
+	repoID int64,
+	timestamp time.Time,
+	stats types.RepoInsightsPullReqStats,
+) error {
+	state, err := s.insightsStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository insights: %w", err)
+	}
+
+	if timestamp.UnixMilli() < state.Created {
+		return nil
+	}
+
+	stats.Week = WeekStart(timestamp)
+
+	err = s.insightsStore.AddPullReqStats(ctx, repoID, []types.RepoInsightsPullReqStats{stats})
+	if err != nil {
+		return fmt.Errorf("failed to add pull request statistics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "repo-insights"
+
+	// batchSize defines the number of repositories processed at once by the job.
+	batchSize = 100
+)
+
+// Service computes the insights of repositories: the commit activity and the contributors
+// of the default branch, and the pull request throughput.
+// Commit statistics are computed incrementally by a recurring job for the repositories marked as stale
+// by pushes to their default branch. Pull request statistics are updated by the pull request events.
+type Service struct {
+	enabled bool
+	cron    string
+	maxDur  time.Duration
+
+	tx            dbtx.Transactor
+	repoStore     store.RepoStore
+	pullReqStore  store.PullReqStore
+	insightsStore store.RepoInsightsStore
+	git           git.Interface
+	scheduler     *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	insightsStore store.RepoInsightsStore,
+	git git.Interface,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:       config.RepoInsights.Enabled,
+		cron:          config.RepoInsights.CRON,
+		maxDur:        config.RepoInsights.MaxDuration,
+		tx:            tx,
+		repoStore:     repoStore,
+		pullReqStore:  pullReqStore,
+		insightsStore: insightsStore,
+		git:           git,
+		scheduler:     scheduler,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repository insights: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the insights of the repository for the provided number of weeks, including the current week.
+// The computation of the insights is requested if it has never been done for the repository.
+func (s *Service) Get(
+	ctx context.Context,
+	repo *types.Repository,
+	filter types.RepoInsightsFilter,
+) (*types.RepoInsights, error) {
+	state, err := s.insightsStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		if err = s.insightsStore.MarkStale(ctx, repo.ID, time.Now().UnixMilli()); err != nil {
+			return nil, fmt.Errorf("failed to request computation of repository insights: %w", err)
+		}
+		state, err = s.insightsStore.Find(ctx, repo.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository insights: %w", err)
+	}
+
+	since := WeekStart(time.Now()) - int64(filter.Weeks-1)*weekDuration.Milliseconds()
+
+	commitActivity, err := s.insightsStore.ListCommitActivity(ctx, repo.ID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit activity: %w", err)
+	}
+
+	contributors, err := s.insightsStore.ListContributors(ctx, repo.ID, since, filter.Contributors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contributors: %w", err)
+	}
+
+	pullReqStats, err := s.insightsStore.ListPullReqStats(ctx, repo.ID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request throughput: %w", err)
+	}
+
+	return &types.RepoInsights{
+		State:             state,
+		Since:             since,
+		CommitActivity:    commitActivity,
+		TopContributors:   contributors,
+		PullReqThroughput: pullReqStats,
+	}, nil
+}
+
+// Handle computes the insights of all repositories marked as stale.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	var processed, failed int
+	var afterRepoID int64
+	for {
+		states, err := s.insightsStore.ListStale(ctx, afterRepoID, batchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list stale repository insights: %w", err)
+		}
+
+		for _, state := range states {
+			afterRepoID = state.RepoID
+
+			if err := s.process(ctx, state); err != nil {
+				// the stale mark is restored, so the computation is retried by the next run of the job.
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("repo_id", state.RepoID).
+					Msg("failed to compute repository insights")
+				if err := s.insightsStore.MarkStale(ctx, state.RepoID, time.Now().UnixMilli()); err != nil {
+					return "", fmt.Errorf("failed to mark repository insights as stale: %w", err)
+				}
+				failed++
+				continue
+			}
+
+			processed++
+		}
+
+		if len(states) < batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return fmt.Sprintf("computed insights of %d repositories, failed for %d", processed, failed), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "monday start",
+			t:    monday,
+			want: monday,
+		},
+		{
+			name: "wednesday",
+			t:    time.Date(2024, time.January, 3, 15, 4, 5, 0, time.UTC),
+			want: monday,
+		},
+		{
+			name: "sunday end",
+			t:    time.Date(2024, time.January, 7, 23, 59, 59, 0, time.UTC),
+			want: monday,
+		},
+		{
+			name: "previous year",
+			t:    time.Date(2023, time.December, 31, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			t:    time.Date(2024, time.January, 8, 1, 0, 0, 0, time.FixedZone("CET", 2*60*60)),
+			want: monday,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := WeekStart(test.t); got != test.want.UnixMilli() {
+				t.Errorf("WeekStart() = %v, want %v", time.UnixMilli(got).UTC(), test.want)
+			}
+		})
+	}
+}
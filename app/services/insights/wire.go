@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	insightsStore store.RepoInsightsStore,
+	git git.Interface,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	service := NewService(
+		config,
+		tx,
+		repoStore,
+		pullReqStore,
+		insightsStore,
+		git,
+		scheduler,
+	)
+
+	if err := executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	if !service.enabled {
+		return service, nil
+	}
+
+	const groupRepoInsights = "gitness:repoinsights"
+	const idleTimeout = 1 * time.Minute
+
+	_, err := gitReaderFactory.Launch(ctx, groupRepoInsights, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterBranchCreated(service.markStaleOnBranchCreated)
+			_ = r.RegisterBranchUpdated(service.markStaleOnBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for repository insights: %w", err)
+	}
+
+	_, err = repoReaderFactory.Launch(ctx, groupRepoInsights, config.InstanceID,
+		func(r *repoevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterDefaultBranchUpdated(service.markStaleOnDefaultBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for repository insights: %w", err)
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupRepoInsights, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterCreated(service.countOnPullReqCreated)
+			_ = r.RegisterMerged(service.countOnPullReqMerged)
+			_ = r.RegisterClosed(service.countOnPullReqClosed)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for repository insights: %w", err)
+	}
+
+	return service, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// closingKeywordRegex matches references to issues preceded by a closing keyword, like "Fixes #123".
+var closingKeywordRegex = regexp.MustCompile(
+	`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// ClosingReferences returns numbers of issues that the text closes, like "Fixes #123" or "closes #7".
+// Each number is returned only once, in the order of its first appearance.
+func ClosingReferences(text string) []int64 {
+	matches := closingKeywordRegex.FindAllStringSubmatch(text, -1)
+
+	numbers := make([]int64, 0, len(matches))
+	seen := make(map[int64]struct{}, len(matches))
+	for _, match := range matches {
+		number, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || number <= 0 {
+			continue
+		}
+
+		if _, ok := seen[number]; ok {
+			continue
+		}
+
+		seen[number] = struct{}{}
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
+
+// closeOnPullReqMerged closes the open issues of the target repository
+// referenced with a closing keyword in the title or the description of the merged pull request.
+func (s *Service) closeOnPullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	pr, err := s.pullReqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	numbers := ClosingReferences(pr.Title + "\n" + pr.Description)
+
+	for _, number := range numbers {
+		issue, err := s.issueStore.FindByNumber(ctx, pr.TargetRepoID, number)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find issue #%d: %w", number, err)
+		}
+
+		if issue.State != enum.IssueStateOpen {
+			continue
+		}
+
+		_, err = s.SetState(ctx, issue, event.Payload.PrincipalID, enum.IssueStateClosed, pr.Number)
+		if err != nil {
+			return fmt.Errorf("failed to close issue #%d: %w", number, err)
+		}
+
+		log.Ctx(ctx).Info().
+			Int64("issue_number", number).
+			Int64("pullreq_number", pr.Number).
+			Msg("closed issue referenced by merged pull request")
+	}
+
+	return nil
+}
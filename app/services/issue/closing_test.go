@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClosingReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int64
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: []int64{},
+		},
+		{
+			name: "reference without keyword",
+			text: "related to #12",
+			want: []int64{},
+		},
+		{
+			name: "all keywords",
+			text: "close #1, closes #2, closed #3, fix #4, fixes #5, fixed #6, resolve #7, resolves #8, resolved #9",
+			want: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name: "case insensitive with colon",
+			text: "FIXES: #42",
+			want: []int64{42},
+		},
+		{
+			name: "duplicates are removed",
+			text: "Fixes #3\n\nAlso closes #1 and fixes #3",
+			want: []int64{3, 1},
+		},
+		{
+			name: "keyword must be a separate word",
+			text: "prefixes #5, unresolved #6",
+			want: []int64{},
+		},
+		{
+			name: "zero is ignored",
+			text: "fixes #0",
+			want: []int64{},
+		},
+		{
+			name: "number must end at word boundary",
+			text: "fixes #12abc",
+			want: []int64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ClosingReferences(test.text); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Service creates issues and changes their state.
+// It's shared by the issue API and by the closing of issues referenced by merged pull requests.
+type Service struct {
+	repoStore     store.RepoStore
+	pullReqStore  store.PullReqStore
+	issueStore    store.IssueStore
+	eventReporter *repoevents.Reporter
+}
+
+func NewService(
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	eventReporter *repoevents.Reporter,
+) *Service {
+	return &Service{
+		repoStore:     repoStore,
+		pullReqStore:  pullReqStore,
+		issueStore:    issueStore,
+		eventReporter: eventReporter,
+	}
+}
+
+// Create creates a new open issue in the repository, numbered using the repository's issue sequence.
+func (s *Service) Create(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	title, description string,
+) (*types.Issue, error) {
+	repo, err := s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.IssueSeq++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire IssueSeq number: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	issue := &types.Issue{
+		RepoID:      repo.ID,
+		Number:      repo.IssueSeq,
+		CreatedBy:   principalID,
+		Created:     now,
+		Updated:     now,
+		State:       enum.IssueStateOpen,
+		Title:       title,
+		Description: description,
+	}
+
+	if err = s.issueStore.Create(ctx, issue); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	// reload the issue to get the author info.
+	issue, err = s.issueStore.Find(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the created issue: %w", err)
+	}
+
+	s.eventReporter.IssueCreated(ctx, &repoevents.IssueCreatedPayload{
+		IssueBase: issueEventBase(issue, principalID),
+	})
+
+	return issue, nil
+}
+
+// SetState opens or closes the issue. The closedByPullReq is the number of the merged pull request
+// which closed the issue, or zero if the issue state is changed directly.
+// Setting the state the issue is already in is a no-op.
+func (s *Service) SetState(
+	ctx context.Context,
+	issue *types.Issue,
+	principalID int64,
+	state enum.IssueState,
+	closedByPullReq int64,
+) (*types.Issue, error) {
+	oldState := issue.State
+	if oldState == state {
+		return issue, nil
+	}
+
+	issue, err := s.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		issue.State = state
+		issue.Closed = nil
+		issue.ClosedByPullReq = nil
+
+		if state == enum.IssueStateClosed {
+			now := time.Now().UnixMilli()
+			issue.Closed = &now
+			if closedByPullReq > 0 {
+				issue.ClosedByPullReq = &closedByPullReq
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue state: %w", err)
+	}
+
+	s.eventReporter.IssueStateChanged(ctx, &repoevents.IssueStateChangedPayload{
+		IssueBase:       issueEventBase(issue, principalID),
+		OldState:        oldState,
+		NewState:        state,
+		ClosedByPullReq: closedByPullReq,
+	})
+
+	return issue, nil
+}
+
+func issueEventBase(issue *types.Issue, principalID int64) repoevents.IssueBase {
+	return repoevents.IssueBase{
+		RepoID:      issue.RepoID,
+		PrincipalID: principalID,
+		IssueID:     issue.ID,
+		IssueNumber: issue.Number,
+	}
+}
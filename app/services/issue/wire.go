@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	repoReporter *repoevents.Reporter,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	service := NewService(repoStore, pullReqStore, issueStore, repoReporter)
+
+	const groupIssueClosing = "gitness:issueclosing"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupIssueClosing, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterMerged(service.closeOnPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for closing issues: %w", err)
+	}
+
+	return service, nil
+}
@@ -27,4 +27,10 @@ type Indexer interface {
 type Searcher interface {
 	Search(ctx context.Context, repoIDs []int64, query string, enableRegex bool, maxResultCount int) (
 		types.SearchResult, error)
+
+	// SearchCode searches the indexed default branches of the provided repositories.
+	SearchCode(ctx context.Context, repoIDs []int64, filter *types.CodeSearchFilter) (types.SearchResult, error)
+
+	// IndexedRepoIDs returns the IDs of all repositories that have a search index.
+	IndexedRepoIDs(ctx context.Context) ([]int64, error)
 }
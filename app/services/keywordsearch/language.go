@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"path"
+	"strings"
+)
+
+// languageByExtension maps file extensions to the language reported in search results.
+var languageByExtension = map[string]string{
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".cxx":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".css":    "CSS",
+	".dart":   "Dart",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".erl":    "Erlang",
+	".go":     "Go",
+	".groovy": "Groovy",
+	".hs":     "Haskell",
+	".html":   "HTML",
+	".htm":    "HTML",
+	".java":   "Java",
+	".js":     "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".jsx":    "JavaScript",
+	".json":   "JSON",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".lua":    "Lua",
+	".md":     "Markdown",
+	".m":      "Objective-C",
+	".php":    "PHP",
+	".pl":     "Perl",
+	".proto":  "Protocol Buffer",
+	".py":     "Python",
+	".rb":     "Ruby",
+	".rs":     "Rust",
+	".scala":  "Scala",
+	".scss":   "SCSS",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".sql":    "SQL",
+	".swift":  "Swift",
+	".tf":     "HCL",
+	".toml":   "TOML",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".vue":    "Vue",
+	".xml":    "XML",
+	".yaml":   "YAML",
+	".yml":    "YAML",
+}
+
+// languageByFileName maps well known file names without a meaningful extension to a language.
+var languageByFileName = map[string]string{
+	"dockerfile":  "Dockerfile",
+	"makefile":    "Makefile",
+	"gnumakefile": "Makefile",
+	"jenkinsfile": "Groovy",
+}
+
+// detectLanguage returns the language of the file based on its name, or an empty string if it's unknown.
+func detectLanguage(filePath string) string {
+	name := strings.ToLower(path.Base(filePath))
+	if lang, ok := languageByFileName[name]; ok {
+		return lang
+	}
+
+	return languageByExtension[path.Ext(name)]
+}
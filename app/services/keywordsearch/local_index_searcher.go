@@ -15,28 +15,414 @@
 package keywordsearch
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
 )
 
+const (
+	// shardFileExt is the file extension of the persisted repository indexes.
+	shardFileExt = ".idx"
+
+	// maxIndexedFileSize is the size limit of indexed files, larger files are skipped.
+	maxIndexedFileSize = 1 << 20 // 1 MiB
+
+	// maxIndexedFiles is the maximum number of files indexed per repository.
+	maxIndexedFiles = 50000
+
+	// binaryCheckSize is the number of leading bytes that are checked for NUL bytes to detect binary files.
+	binaryCheckSize = 8000
+
+	// defaultMaxResultCount is the number of file matches returned if the caller didn't provide a limit.
+	defaultMaxResultCount = 100
+)
+
+// LocalIndexSearcher maintains a trigram index of the default branch of every repository
+// in the local filesystem and uses it to search the code of the repositories.
+// The index of a repository is updated incrementally: the content of unchanged blobs is taken from the previous index.
 type LocalIndexSearcher struct {
+	dir string
+	git git.Interface
+
+	mx     sync.RWMutex
+	shards map[int64]*shard
+
+	repoLocks sync.Map // map[int64]*sync.Mutex
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(dir string, git git.Interface) (*LocalIndexSearcher, error) {
+	if dir == "" {
+		return nil, errors.New("index directory is required")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		dir:    dir,
+		git:    git,
+		shards: make(map[int64]*shard),
+	}, nil
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	return s.SearchCode(ctx, repoIDs, &types.CodeSearchFilter{
+		Query:      query,
+		Regex:      enableRegex,
+		IgnoreCase: true,
+		Limit:      maxResultCount,
+	})
+}
+
+func (s *LocalIndexSearcher) SearchCode(
+	ctx context.Context,
+	repoIDs []int64,
+	filter *types.CodeSearchFilter,
+) (types.SearchResult, error) {
+	m, err := newMatcher(filter)
+	if err != nil {
+		return types.SearchResult{}, errors.InvalidArgument("invalid search query: %s", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultMaxResultCount
+	}
+
+	repoIDs = append([]int64(nil), repoIDs...)
+	sort.Slice(repoIDs, func(i, j int) bool {
+		return repoIDs[i] < repoIDs[j]
+	})
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if len(result.FileMatches) >= limit {
+			break
+		}
+
+		sh, err := s.shard(repoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to load repository search index")
+			continue
+		}
+		if sh == nil {
+			continue
+		}
+
+		result.FileMatches = append(result.FileMatches, sh.search(filter, m, limit-len(result.FileMatches))...)
+	}
+
+	result.Stats.TotalFiles = len(result.FileMatches)
+	for _, fileMatch := range result.FileMatches {
+		result.Stats.TotalMatches += len(fileMatch.Matches)
+	}
+
+	return result, nil
+}
+
+func (s *LocalIndexSearcher) IndexedRepoIDs(context.Context) ([]int64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index directory: %w", err)
+	}
+
+	repoIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), shardFileExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		repoID, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	return repoIDs, nil
+}
+
+// Index updates the index of the repository's default branch.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	unlock := s.lockRepo(repo.ID)
+	defer unlock()
+
+	readParams := git.CreateReadParams(repo)
+
+	branch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the default branch doesn't exist (yet), there is nothing to search.
+		return s.removeShard(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branch.Branch.SHA.String()
+
+	prev, err := s.shard(repo.ID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).
+			Msg("failed to load repository search index, rebuilding it from scratch")
+		prev = nil
+	}
+
+	if prev != nil && prev.Branch == repo.DefaultBranch && prev.CommitSHA == commitSHA {
+		return nil
+	}
+
+	files, err := s.collectFiles(ctx, readParams, commitSHA, prev)
+	if err != nil {
+		return err
+	}
+
+	sh := newShard(repo.ID, repo.DefaultBranch, commitSHA, files)
+
+	if err := s.storeShard(sh); err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("commit_sha", commitSHA).
+		Int("files", len(files)).
+		Msg("updated repository search index")
+
+	return nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+// collectFiles reads all text files of the commit. Content of blobs that are present in the previous index is reused.
+func (s *LocalIndexSearcher) collectFiles(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHA string,
+	prev *shard,
+) ([]indexedFile, error) {
+	prevFiles := make(map[string]*indexedFile)
+	if prev != nil {
+		for idx := range prev.Files {
+			prevFiles[prev.Files[idx].BlobSHA] = &prev.Files[idx]
+		}
+	}
+
+	paths, err := s.git.ListPaths(ctx, &git.ListPathsParams{
+		ReadParams:         readParams,
+		GitREF:             commitSHA,
+		IncludeDirectories: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paths: %w", err)
+	}
+
+	// list the nodes of the root and of every directory to get the mode and the blob SHA of every file.
+	dirs := append([]string{""}, paths.Directories...)
+	files := make([]indexedFile, 0, len(paths.Files))
+
+	for _, dir := range dirs {
+		nodes, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     commitSHA,
+			Path:       dir,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tree nodes of directory %q: %w", dir, err)
+		}
+
+		for _, node := range nodes.Nodes {
+			if node.Mode != git.TreeNodeModeFile && node.Mode != git.TreeNodeModeExec {
+				continue
+			}
+
+			if len(files) >= maxIndexedFiles {
+				log.Ctx(ctx).Warn().Msgf("repository has more than %d files, the rest is not indexed", maxIndexedFiles)
+				return files, nil
+			}
+
+			var content []byte
+			if prevFile, ok := prevFiles[node.SHA]; ok {
+				content = prevFile.Content
+			} else {
+				content, err = s.readBlob(ctx, readParams, node.SHA)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if content == nil {
+				// the file is too large or binary
+				continue
+			}
+
+			files = append(files, indexedFile{
+				Path:     node.Path,
+				Language: detectLanguage(node.Path),
+				BlobSHA:  node.SHA,
+				Content:  content,
+			})
+		}
+	}
+
+	return files, nil
+}
+
+// readBlob returns the content of the blob, or nil if the blob is too large or binary.
+func (s *LocalIndexSearcher) readBlob(
+	ctx context.Context,
+	readParams git.ReadParams,
+	blobSHA string,
+) ([]byte, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  maxIndexedFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s: %w", blobSHA, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > maxIndexedFileSize {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", blobSHA, err)
+	}
+
+	if isBinary(content) {
+		return nil, nil
+	}
+
+	// content of empty files is kept non-nil to differentiate them from skipped files.
+	if content == nil {
+		content = []byte{}
+	}
+
+	return content, nil
+}
+
+// shard returns the index of the repository, loading it from the disk if needed.
+// It returns nil if the repository isn't indexed.
+func (s *LocalIndexSearcher) shard(repoID int64) (*shard, error) {
+	s.mx.RLock()
+	sh, ok := s.shards[repoID]
+	s.mx.RUnlock()
+	if ok {
+		return sh, nil
+	}
+
+	f, err := os.Open(s.shardPath(repoID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	sh = &shard{}
+	if err := gob.NewDecoder(f).Decode(sh); err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	s.mx.Lock()
+	s.shards[repoID] = sh
+	s.mx.Unlock()
+
+	return sh, nil
+}
+
+// storeShard writes the index to a temporary file which then replaces the previous index file.
+func (s *LocalIndexSearcher) storeShard(sh *shard) error {
+	f, err := os.CreateTemp(s.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath)
+
+	err = gob.NewEncoder(f).Encode(sh)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.shardPath(sh.RepoID)); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	s.mx.Lock()
+	s.shards[sh.RepoID] = sh
+	s.mx.Unlock()
+
+	return nil
+}
+
+func (s *LocalIndexSearcher) removeShard(repoID int64) error {
+	s.mx.Lock()
+	delete(s.shards, repoID)
+	s.mx.Unlock()
+
+	if err := os.Remove(s.shardPath(repoID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove index file: %w", err)
+	}
+
 	return nil
 }
+
+func (s *LocalIndexSearcher) shardPath(repoID int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(repoID, 10)+shardFileExt)
+}
+
+// lockRepo serializes index updates of the same repository.
+func (s *LocalIndexSearcher) lockRepo(repoID int64) func() {
+	l, _ := s.repoLocks.LoadOrStore(repoID, &sync.Mutex{})
+	mu, _ := l.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+func isBinary(content []byte) bool {
+	if len(content) > binaryCheckSize {
+		content = content[:binaryCheckSize]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
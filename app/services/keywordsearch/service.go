@@ -33,6 +33,9 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexDir is the directory where the search indexes of repositories are stored.
+	IndexDir string
 }
 
 func (c *Config) Prepare() error {
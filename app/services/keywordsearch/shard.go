@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// maxMatchesPerFile is the maximum number of matching lines returned for a single file.
+	maxMatchesPerFile = 100
+)
+
+// indexedFile is a single file of the indexed branch.
+type indexedFile struct {
+	Path     string
+	Language string
+	BlobSHA  string
+	Content  []byte
+}
+
+// shard is the search index of the default branch of a single repository.
+// Every file is indexed by the trigrams of its content (lower-cased ASCII),
+// which allows to find the candidate files of literal queries without scanning all the content.
+type shard struct {
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+	Files     []indexedFile
+
+	// Trigrams maps every trigram to the sorted list of indexes (in Files) of files that contain it.
+	Trigrams map[uint32][]uint32
+}
+
+func newShard(repoID int64, branch, commitSHA string, files []indexedFile) *shard {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	s := &shard{
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Files:     files,
+		Trigrams:  make(map[uint32][]uint32),
+	}
+
+	for idx := range files {
+		fileIdx := uint32(idx)
+		forEachTrigram(toLowerASCII(files[idx].Content), func(t uint32) {
+			postings := s.Trigrams[t]
+			if len(postings) > 0 && postings[len(postings)-1] == fileIdx {
+				return
+			}
+			s.Trigrams[t] = append(postings, fileIdx)
+		})
+	}
+
+	return s
+}
+
+// matcher finds all matches within a single line. The returned pairs are byte offsets within the line.
+type matcher func(line []byte) [][]int
+
+// newMatcher creates the matcher of the filter's query.
+func newMatcher(filter *types.CodeSearchFilter) (matcher, error) {
+	if filter.Regex {
+		expr := filter.Query
+		if filter.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(line []byte) [][]int {
+			return re.FindAllIndex(line, -1)
+		}, nil
+	}
+
+	query := []byte(filter.Query)
+	if filter.IgnoreCase {
+		query = toLowerASCII(query)
+	}
+
+	return func(line []byte) [][]int {
+		if filter.IgnoreCase {
+			line = toLowerASCII(line)
+		}
+
+		var result [][]int
+		for offset := 0; ; {
+			idx := bytes.Index(line[offset:], query)
+			if idx < 0 {
+				return result
+			}
+
+			start := offset + idx
+			offset = start + len(query)
+			result = append(result, []int{start, offset})
+		}
+	}, nil
+}
+
+// candidates returns the indexes of files that might contain the query.
+// Regular expressions, and queries too short to contain a trigram, need to scan all files.
+func (s *shard) candidates(filter *types.CodeSearchFilter) []uint32 {
+	query := toLowerASCII([]byte(filter.Query))
+	if filter.Regex || len(query) < 3 {
+		all := make([]uint32, len(s.Files))
+		for idx := range all {
+			all[idx] = uint32(idx)
+		}
+		return all
+	}
+
+	var result []uint32
+	first := true
+	forEachTrigram(query, func(t uint32) {
+		if !first && len(result) == 0 {
+			return
+		}
+
+		postings := s.Trigrams[t]
+		if first {
+			result = append(result, postings...)
+			first = false
+			return
+		}
+
+		result = intersect(result, postings)
+	})
+
+	return result
+}
+
+// search returns up to limit file matches of the shard's files.
+func (s *shard) search(filter *types.CodeSearchFilter, m matcher, limit int) []types.FileMatch {
+	var result []types.FileMatch
+
+	for _, idx := range s.candidates(filter) {
+		if len(result) >= limit {
+			break
+		}
+
+		file := &s.Files[idx]
+		if !matchesFileFilter(file, filter) {
+			continue
+		}
+
+		matches := matchContent(file.Content, m)
+		if len(matches) == 0 {
+			continue
+		}
+
+		result = append(result, types.FileMatch{
+			FileName:   file.Path,
+			RepoID:     s.RepoID,
+			RepoBranch: s.Branch,
+			Language:   file.Language,
+			Matches:    matches,
+		})
+	}
+
+	return result
+}
+
+func matchesFileFilter(file *indexedFile, filter *types.CodeSearchFilter) bool {
+	if filter.File != "" && !strings.Contains(strings.ToLower(file.Path), strings.ToLower(filter.File)) {
+		return false
+	}
+
+	if filter.Language != "" && !strings.EqualFold(file.Language, filter.Language) {
+		return false
+	}
+
+	return true
+}
+
+// matchContent returns the matching lines of the content, each with the line before and after it.
+func matchContent(content []byte, m matcher) []types.Match {
+	lines := bytes.Split(content, []byte{'\n'})
+
+	var matches []types.Match
+	for lineIdx, line := range lines {
+		if len(matches) >= maxMatchesPerFile {
+			break
+		}
+
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+
+		var fragments []types.Fragment
+		end := 0
+		for _, loc := range m(line) {
+			if loc[0] == loc[1] {
+				continue
+			}
+
+			fragments = append(fragments, types.Fragment{
+				Pre:   string(line[end:loc[0]]),
+				Match: string(line[loc[0]:loc[1]]),
+			})
+			end = loc[1]
+		}
+
+		if len(fragments) == 0 {
+			continue
+		}
+
+		fragments[len(fragments)-1].Post = string(line[end:])
+
+		match := types.Match{
+			LineNum:   lineIdx + 1,
+			Fragments: fragments,
+		}
+		if lineIdx > 0 {
+			match.Before = string(bytes.TrimSuffix(lines[lineIdx-1], []byte{'\r'}))
+		}
+		if lineIdx < len(lines)-1 {
+			match.After = string(bytes.TrimSuffix(lines[lineIdx+1], []byte{'\r'}))
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+func forEachTrigram(data []byte, fn func(t uint32)) {
+	for i := 0; i+3 <= len(data); i++ {
+		fn(uint32(data[i])<<16 | uint32(data[i+1])<<8 | uint32(data[i+2]))
+	}
+}
+
+// intersect returns the elements present in both of the sorted lists.
+func intersect(a, b []uint32) []uint32 {
+	result := a[:0]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// toLowerASCII lower-cases only ASCII letters, which keeps the byte offsets of the original data intact.
+func toLowerASCII(data []byte) []byte {
+	result := make([]byte, len(data))
+	for i, c := range data {
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		result[i] = c
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestShardSearch(t *testing.T) {
+	sh := newShard(1, "main", "abc", []indexedFile{
+		{Path: "main.go", Language: "Go", Content: []byte("package main\n\nfunc main() {\n\tRunServer()\n}\n")},
+		{Path: "server/server.go", Language: "Go", Content: []byte("package server\n\nfunc runServer() {}\n")},
+		{Path: "README.md", Language: "Markdown", Content: []byte("# Server\nRun the server with make run.\n")},
+	})
+
+	tests := []struct {
+		name   string
+		filter types.CodeSearchFilter
+		files  []string
+		lines  []int
+	}{
+		{
+			name:   "literal case sensitive",
+			filter: types.CodeSearchFilter{Query: "RunServer"},
+			files:  []string{"main.go"},
+			lines:  []int{4},
+		},
+		{
+			name:   "literal ignore case",
+			filter: types.CodeSearchFilter{Query: "runserver", IgnoreCase: true},
+			files:  []string{"main.go", "server/server.go"},
+			lines:  []int{4, 3},
+		},
+		{
+			name:   "short query",
+			filter: types.CodeSearchFilter{Query: "# "},
+			files:  []string{"README.md"},
+			lines:  []int{1},
+		},
+		{
+			name:   "regex",
+			filter: types.CodeSearchFilter{Query: `^package \w+$`, Regex: true},
+			files:  []string{"main.go", "server/server.go"},
+			lines:  []int{1, 1},
+		},
+		{
+			name:   "language filter",
+			filter: types.CodeSearchFilter{Query: "server", IgnoreCase: true, Language: "markdown"},
+			files:  []string{"README.md"},
+			lines:  []int{1},
+		},
+		{
+			name:   "file filter",
+			filter: types.CodeSearchFilter{Query: "package", File: "SERVER/"},
+			files:  []string{"server/server.go"},
+			lines:  []int{1},
+		},
+		{
+			name:   "no match",
+			filter: types.CodeSearchFilter{Query: "nothing"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := newMatcher(&test.filter)
+			if err != nil {
+				t.Fatalf("failed to create matcher: %v", err)
+			}
+
+			result := sh.search(&test.filter, m, 10)
+			if len(result) != len(test.files) {
+				t.Fatalf("expected %d file matches, got %d: %+v", len(test.files), len(result), result)
+			}
+
+			for idx, fileMatch := range result {
+				if fileMatch.FileName != test.files[idx] {
+					t.Errorf("expected file %q, got %q", test.files[idx], fileMatch.FileName)
+				}
+				if fileMatch.Matches[0].LineNum != test.lines[idx] {
+					t.Errorf("expected line %d, got %d", test.lines[idx], fileMatch.Matches[0].LineNum)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchContentFragments(t *testing.T) {
+	m, err := newMatcher(&types.CodeSearchFilter{Query: "ab"})
+	if err != nil {
+		t.Fatalf("failed to create matcher: %v", err)
+	}
+
+	matches := matchContent([]byte("first\nxabyab z\r\nlast"), m)
+	if len(matches) != 1 {
+		t.Fatalf("expected one match, got %d", len(matches))
+	}
+
+	match := matches[0]
+	if match.LineNum != 2 || match.Before != "first" || match.After != "last" {
+		t.Errorf("unexpected match: %+v", match)
+	}
+
+	expected := []types.Fragment{
+		{Pre: "x", Match: "ab"},
+		{Pre: "y", Match: "ab", Post: " z"},
+	}
+	if len(match.Fragments) != len(expected) {
+		t.Fatalf("expected %d fragments, got %d", len(expected), len(match.Fragments))
+	}
+	for idx := range expected {
+		if match.Fragments[idx] != expected[idx] {
+			t.Errorf("expected fragment %+v, got %+v", expected[idx], match.Fragments[idx])
+		}
+	}
+}
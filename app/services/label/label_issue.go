@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/maps"
+)
+
+// AssignToIssue assigns a label, optionally with a value, to an issue.
+// Labels of an issue are defined the same way as labels of pull requests,
+// in the repository or in any of the spaces above it.
+func (s *Service) AssignToIssue(
+	ctx context.Context,
+	principalID int64,
+	issueID int64,
+	repoID int64,
+	repoParentID int64,
+	in *types.PullReqCreateInput,
+) (*types.IssueLabel, error) {
+	label, err := s.labelStore.FindByID(ctx, in.LabelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label by id: %w", err)
+	}
+
+	if err := s.checkPullreqLabelInScope(ctx, repoParentID, repoID, label); err != nil {
+		return nil, err
+	}
+
+	valueID := in.ValueID
+	if in.ValueID != nil {
+		value, err := s.labelValueStore.FindByID(ctx, *in.ValueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find label value by id: %w", err)
+		}
+		if label.ID != value.LabelID {
+			return nil, errors.InvalidArgument("label value is not associated with label")
+		}
+	}
+
+	if in.Value != "" {
+		value, err := s.getOrDefineValue(ctx, principalID, label, in.Value)
+		if err != nil {
+			return nil, err
+		}
+		valueID = &value.ID
+	}
+
+	now := time.Now().UnixMilli()
+	issueLabel := &types.IssueLabel{
+		IssueID:   issueID,
+		LabelID:   label.ID,
+		ValueID:   valueID,
+		Created:   now,
+		Updated:   now,
+		CreatedBy: principalID,
+		UpdatedBy: principalID,
+	}
+
+	if err := s.issueLabelAssignmentStore.Assign(ctx, issueLabel); err != nil {
+		return nil, fmt.Errorf("failed to assign label to issue: %w", err)
+	}
+
+	return issueLabel, nil
+}
+
+// UnassignFromIssue removes a label from an issue.
+func (s *Service) UnassignFromIssue(
+	ctx context.Context, repoID, repoParentID, issueID, labelID int64,
+) error {
+	label, err := s.labelStore.FindByID(ctx, labelID)
+	if err != nil {
+		return fmt.Errorf("failed to find label by id: %w", err)
+	}
+
+	if err := s.checkPullreqLabelInScope(ctx, repoParentID, repoID, label); err != nil {
+		return err
+	}
+
+	return s.issueLabelAssignmentStore.Unassign(ctx, issueID, labelID)
+}
+
+// ListIssueLabels returns the labels assigned to an issue, ordered by the label key.
+func (s *Service) ListIssueLabels(ctx context.Context, issueID int64) ([]*types.LabelAssignment, error) {
+	assigned, err := s.issueLabelAssignmentStore.ListAssigned(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels assigned to issue: %w", err)
+	}
+
+	labels := maps.Values(assigned)
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Key < labels[j].Key
+	})
+
+	return labels, nil
+}
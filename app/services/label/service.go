@@ -25,6 +25,7 @@ type Service struct {
 	labelStore                  store.LabelStore
 	labelValueStore             store.LabelValueStore
 	pullReqLabelAssignmentStore store.PullReqLabelAssignmentStore
+	issueLabelAssignmentStore   store.IssueLabelAssignmentStore
 }
 
 func New(
@@ -33,6 +34,7 @@ func New(
 	labelStore store.LabelStore,
 	labelValueStore store.LabelValueStore,
 	pullReqLabelAssignmentStore store.PullReqLabelAssignmentStore,
+	issueLabelAssignmentStore store.IssueLabelAssignmentStore,
 ) *Service {
 	return &Service{
 		tx:                          tx,
@@ -40,5 +42,6 @@ func New(
 		labelStore:                  labelStore,
 		labelValueStore:             labelValueStore,
 		pullReqLabelAssignmentStore: pullReqLabelAssignmentStore,
+		issueLabelAssignmentStore:   issueLabelAssignmentStore,
 	}
 }
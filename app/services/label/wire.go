@@ -31,6 +31,7 @@ func ProvideLabel(
 	labelStore store.LabelStore,
 	labelValueStore store.LabelValueStore,
 	pullReqLabelStore store.PullReqLabelAssignmentStore,
+	issueLabelStore store.IssueLabelAssignmentStore,
 ) *Service {
-	return New(tx, spaceStore, labelStore, labelValueStore, pullReqLabelStore)
+	return New(tx, spaceStore, labelStore, labelValueStore, pullReqLabelStore, issueLabelStore)
 }
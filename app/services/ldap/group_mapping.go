@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const maxGroupDNLength = 1024
+
+// ListGroupMappings returns all group mappings.
+func (s *Service) ListGroupMappings(ctx context.Context) ([]*types.LDAPGroupMapping, error) {
+	mappings, err := s.groupMappingStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ldap group mappings: %w", err)
+	}
+
+	for _, mapping := range mappings {
+		space, err := s.spaceStore.Find(ctx, mapping.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space of ldap group mapping: %w", err)
+		}
+
+		mapping.SpacePath = space.Path
+	}
+
+	return mappings, nil
+}
+
+// CreateGroupMapping maps an LDAP group to a membership of a space.
+// The memberships are granted with the next sync or login of the members of the group.
+func (s *Service) CreateGroupMapping(
+	ctx context.Context,
+	createdBy int64,
+	in *GroupMappingCreateInput,
+) (*types.LDAPGroupMapping, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	space, err := s.spaceStore.FindByRef(ctx, in.SpacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.InvalidArgument("Space '%s' not found.", in.SpacePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	mapping := &types.LDAPGroupMapping{
+		GroupDN:   in.GroupDN,
+		SpaceID:   space.ID,
+		SpacePath: space.Path,
+		Role:      in.Role,
+		CreatedBy: createdBy,
+		Created:   now,
+		Updated:   now,
+	}
+
+	err = s.groupMappingStore.Create(ctx, mapping)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Group '%s' is already mapped to space '%s'.", in.GroupDN, space.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ldap group mapping: %w", err)
+	}
+
+	return mapping, nil
+}
+
+// DeleteGroupMapping deletes a group mapping.
+// With the next sync, the members of the group lose the membership unless another mapping grants it.
+func (s *Service) DeleteGroupMapping(ctx context.Context, id int64) error {
+	_, err := s.groupMappingStore.Find(ctx, id)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return errors.NotFound("LDAP group mapping %d not found.", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find ldap group mapping: %w", err)
+	}
+
+	if err = s.groupMappingStore.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete ldap group mapping: %w", err)
+	}
+
+	return nil
+}
+
+func (in *GroupMappingCreateInput) sanitize() error {
+	in.GroupDN = strings.TrimSpace(in.GroupDN)
+	in.SpacePath = strings.Trim(strings.TrimSpace(in.SpacePath), "/")
+
+	if in.GroupDN == "" {
+		return errors.InvalidArgument("Group DN is required.")
+	}
+	if len(in.GroupDN) > maxGroupDNLength {
+		return errors.InvalidArgument("Group DN can't be longer than %d characters.", maxGroupDNLength)
+	}
+	if in.SpacePath == "" {
+		return errors.InvalidArgument("Space is required.")
+	}
+
+	role, ok := in.Role.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("Unknown role '%s'.", in.Role)
+	}
+	in.Role = role
+
+	return nil
+}
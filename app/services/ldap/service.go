@@ -0,0 +1,740 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	ldapauth "github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	jobType = "ldap-sync"
+
+	// provisionedPasswordLength is the length of the random password of provisioned users.
+	// Users linked to the directory authenticate against it, so the password is never used.
+	provisionedPasswordLength = 64
+
+	// provisionedUIDAttempts is the number of random suffixes tried if the login of the user is taken.
+	provisionedUIDAttempts = 5
+)
+
+var (
+	// ErrDisabled is returned if LDAP authentication isn't configured.
+	ErrDisabled = errors.PreconditionFailed("LDAP authentication is disabled.")
+
+	// ErrInvalidCredentials is returned if the directory rejected the login or the password.
+	ErrInvalidCredentials = errors.Format(errors.StatusUnauthorized, "Invalid credentials.")
+
+	// ErrNotProvisioned is returned if no user is linked to the directory entry and signup is disabled.
+	ErrNotProvisioned = errors.Format(errors.StatusUnauthorized, "No user is linked to your directory account.")
+
+	invalidUIDCharacters = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
+)
+
+// GroupMappingCreateInput is the input for mapping an LDAP group to a space membership.
+type GroupMappingCreateInput struct {
+	GroupDN   string              `json:"group_dn"`
+	SpacePath string              `json:"space_path"`
+	Role      enum.MembershipRole `json:"role"`
+}
+
+// Service authenticates users against the LDAP directory and periodically syncs the users
+// and the space memberships of their groups with the directory.
+//
+// Users linked to the directory are managed by it: they are provisioned on their first login
+// (or by the sync), blocked once they are removed from the directory and their memberships
+// of the spaces any group is mapped to are granted, updated and revoked according to their groups.
+type Service struct {
+	enabled     bool
+	allowSignup bool
+	syncEnabled bool
+	deactivate  bool
+	cron        string
+	maxDur      time.Duration
+
+	// syncMx prevents concurrent runs of the scheduled and manually triggered sync.
+	syncMx sync.Mutex
+
+	directory         *ldapauth.Directory
+	principalStore    store.PrincipalStore
+	identityStore     store.LDAPIdentityStore
+	groupMappingStore store.LDAPGroupMappingStore
+	spaceStore        store.SpaceStore
+	membershipStore   store.MembershipStore
+	principalUIDCheck check.PrincipalUID
+	scheduler         *job.Scheduler
+}
+
+// Enabled returns true if users can authenticate against the LDAP directory.
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// FindIdentity finds the directory identity linked to a user.
+func (s *Service) FindIdentity(ctx context.Context, principalID int64) (*types.LDAPIdentity, error) {
+	return s.identityStore.FindByPrincipalID(ctx, principalID)
+}
+
+// Login authenticates the user against the directory and returns the linked user.
+// On the first login the directory entry is linked to the user with the same email address,
+// or a new user is provisioned, if signup is allowed. Like with every sync, the details
+// and the space memberships of the user are updated and a deactivated user is reactivated.
+func (s *Service) Login(ctx context.Context, login, password string) (*types.User, error) {
+	if !s.enabled {
+		return nil, ErrDisabled
+	}
+
+	entry, err := s.directory.Authenticate(ctx, login, password)
+	if errors.Is(err, ldapauth.ErrUserNotFound) || ldapauth.IsInvalidCredentials(err) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate against ldap directory: %w", err)
+	}
+
+	state, err := s.newSyncState(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.identityStore.FindByLogin(ctx, entry.Login)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		identity = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find ldap identity: %w", err)
+	}
+
+	user, err := s.syncUser(ctx, state, entry, identity)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotProvisioned
+	}
+
+	return user, nil
+}
+
+// Sync syncs all users with the directory and returns the changes.
+// With dryRun the changes are only computed, so administrators can review them before enabling the sync.
+func (s *Service) Sync(ctx context.Context, dryRun bool) (*types.LDAPSyncReport, error) {
+	if !s.enabled {
+		return nil, ErrDisabled
+	}
+
+	if !s.syncMx.TryLock() {
+		return nil, errors.Conflict("A sync with the LDAP directory is already running.")
+	}
+	defer s.syncMx.Unlock()
+
+	state, err := s.newSyncState(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.directory.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users of ldap directory: %w", err)
+	}
+	state.report.DirectoryUsers = len(entries)
+
+	identities, err := s.identityStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ldap identities: %w", err)
+	}
+
+	identityByLogin := make(map[string]*types.LDAPIdentity, len(identities))
+	for _, identity := range identities {
+		identityByLogin[strings.ToLower(identity.Login)] = identity
+	}
+
+	for _, entry := range entries {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		login := strings.ToLower(entry.Login)
+		identity := identityByLogin[login]
+		delete(identityByLogin, login)
+
+		if _, err = s.syncUser(ctx, state, entry, identity); err != nil {
+			return nil, fmt.Errorf("failed to sync user %q: %w", entry.Login, err)
+		}
+	}
+
+	// an empty result is most likely caused by a misconfigured filter or base DN, so nobody is deactivated.
+	if s.deactivate && len(entries) > 0 {
+		for _, identity := range identities {
+			if _, ok := identityByLogin[strings.ToLower(identity.Login)]; !ok {
+				continue
+			}
+
+			if err = s.deactivateUser(ctx, state, identity); err != nil {
+				return nil, fmt.Errorf("failed to deactivate user %q: %w", identity.Login, err)
+			}
+		}
+	}
+
+	state.report.Finished = time.Now().UnixMilli()
+
+	return state.report, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled || !s.syncEnabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for ldap sync: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled || !s.syncEnabled {
+		return "", nil
+	}
+
+	report, err := s.Sync(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	log.Ctx(ctx).Info().
+		Int("directory_users", report.DirectoryUsers).
+		Int("changes", len(report.Changes)).
+		Msg("synced users with ldap directory")
+
+	return "", nil
+}
+
+// syncState is the state shared by the users of a sync.
+type syncState struct {
+	dryRun   bool
+	now      int64
+	mappings []*types.LDAPGroupMapping
+	// spaceIDs are the spaces of the mappings in the order of the mappings.
+	spaceIDs   []int64
+	spacePaths map[int64]string
+	// linked are the principals linked to a directory entry during the sync.
+	linked map[int64]struct{}
+	report *types.LDAPSyncReport
+}
+
+func (s *Service) newSyncState(ctx context.Context, dryRun bool) (*syncState, error) {
+	mappings, err := s.groupMappingStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ldap group mappings: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	state := &syncState{
+		dryRun:     dryRun,
+		now:        now,
+		mappings:   mappings,
+		spacePaths: map[int64]string{},
+		linked:     map[int64]struct{}{},
+		report: &types.LDAPSyncReport{
+			DryRun:  dryRun,
+			Changes: []types.LDAPSyncChange{},
+			Started: now,
+		},
+	}
+
+	for _, mapping := range mappings {
+		if _, ok := state.spacePaths[mapping.SpaceID]; ok {
+			continue
+		}
+
+		space, err := s.spaceStore.Find(ctx, mapping.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space of ldap group mapping: %w", err)
+		}
+
+		state.spaceIDs = append(state.spaceIDs, space.ID)
+		state.spacePaths[space.ID] = space.Path
+	}
+
+	return state, nil
+}
+
+func (st *syncState) add(action enum.LDAPSyncAction, entry *ldapauth.User, user *types.User) {
+	change := types.LDAPSyncChange{
+		Action:      action,
+		Login:       entry.Login,
+		Email:       entry.Email,
+		DisplayName: entry.DisplayName,
+	}
+	if user != nil {
+		change.UserUID = user.UID
+	}
+
+	st.report.Changes = append(st.report.Changes, change)
+}
+
+func (st *syncState) addMembership(
+	action enum.LDAPSyncAction,
+	login string,
+	user *types.User,
+	spaceID int64,
+	role enum.MembershipRole,
+) {
+	change := types.LDAPSyncChange{
+		Action:    action,
+		Login:     login,
+		SpacePath: st.spacePaths[spaceID],
+		Role:      role,
+	}
+	if user != nil {
+		change.UserUID = user.UID
+	}
+
+	st.report.Changes = append(st.report.Changes, change)
+}
+
+// syncUser syncs the user of the directory entry and its memberships.
+// It returns nil if the entry isn't linked to a user and no user can be provisioned for it,
+// and in a dry run if the user would be provisioned.
+func (s *Service) syncUser(
+	ctx context.Context,
+	st *syncState,
+	entry *ldapauth.User,
+	identity *types.LDAPIdentity,
+) (*types.User, error) {
+	if check.Email(entry.Email) != nil {
+		entry.Email = ""
+	}
+
+	var user *types.User
+	var err error
+	if identity != nil {
+		user, err = s.principalStore.FindUser(ctx, identity.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user of ldap identity: %w", err)
+		}
+
+		if err = s.updateUser(ctx, st, entry, identity, user); err != nil {
+			return nil, err
+		}
+	} else {
+		user, err = s.linkUser(ctx, st, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil && (entry.Email == "" || !s.allowSignup) {
+			return nil, nil
+		}
+
+		if user == nil {
+			user, err = s.provisionUser(ctx, st, entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = s.syncMemberships(ctx, st, entry, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// linkUser links the directory entry to the user with the same email address.
+func (s *Service) linkUser(ctx context.Context, st *syncState, entry *ldapauth.User) (*types.User, error) {
+	if entry.Email == "" {
+		log.Ctx(ctx).Debug().Str("login", entry.Login).Msg("ldap user has no valid email address")
+		return nil, nil
+	}
+
+	user, err := s.principalStore.FindUserByEmail(ctx, entry.Email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	// the user might be linked to another directory entry with the same email address.
+	_, linked := st.linked[user.ID]
+	if !linked {
+		_, err = s.identityStore.FindByPrincipalID(ctx, user.ID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find ldap identity of user: %w", err)
+		}
+		linked = err == nil
+	}
+	if linked {
+		log.Ctx(ctx).Warn().
+			Str("login", entry.Login).
+			Str("user_uid", user.UID).
+			Msg("user with the email address of the ldap user is already linked to another ldap user")
+		return nil, nil
+	}
+
+	st.linked[user.ID] = struct{}{}
+	st.add(enum.LDAPSyncActionLinkUser, entry, user)
+
+	if st.dryRun {
+		return user, nil
+	}
+
+	if err = s.createIdentity(ctx, st, entry, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// provisionUser creates a user for the directory entry. The UID of the user is the login, if available.
+func (s *Service) provisionUser(ctx context.Context, st *syncState, entry *ldapauth.User) (*types.User, error) {
+	uid, err := s.generateUID(ctx, entry.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(entry.DisplayName)
+	if check.DisplayName(displayName) != nil {
+		displayName = uid
+	}
+
+	user := &types.User{
+		UID:         uid,
+		Email:       entry.Email,
+		DisplayName: displayName,
+		Salt:        uniuri.NewLen(uniuri.UUIDLen),
+		Created:     st.now,
+		Updated:     st.now,
+	}
+
+	st.add(enum.LDAPSyncActionCreateUser, entry, user)
+
+	if st.dryRun {
+		return nil, nil
+	}
+
+	// the user can't login with the password, as it is authenticated against the directory.
+	hash, err := bcrypt.GenerateFromPassword([]byte(uniuri.NewLen(provisionedPasswordLength)), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+	user.Password = string(hash)
+
+	if err = s.principalStore.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	st.linked[user.ID] = struct{}{}
+
+	if err = s.createIdentity(ctx, st, entry, user); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("login", entry.Login).
+		Str("user_uid", user.UID).
+		Msg("provisioned user from ldap directory")
+
+	return user, nil
+}
+
+func (s *Service) createIdentity(ctx context.Context, st *syncState, entry *ldapauth.User, user *types.User) error {
+	identity := &types.LDAPIdentity{
+		PrincipalID: user.ID,
+		Login:       entry.Login,
+		DN:          entry.DN,
+		Created:     st.now,
+		Updated:     st.now,
+	}
+
+	if err := s.identityStore.Create(ctx, identity); err != nil {
+		return fmt.Errorf("failed to create ldap identity: %w", err)
+	}
+
+	return nil
+}
+
+// updateUser reactivates a user deactivated by a previous sync and updates the details of the user.
+func (s *Service) updateUser(
+	ctx context.Context,
+	st *syncState,
+	entry *ldapauth.User,
+	identity *types.LDAPIdentity,
+	user *types.User,
+) error {
+	st.linked[user.ID] = struct{}{}
+
+	userChanged := false
+	identityChanged := identity.Login != entry.Login || identity.DN != entry.DN
+
+	if identity.Deactivated {
+		st.add(enum.LDAPSyncActionReactivateUser, entry, user)
+		identity.Deactivated = false
+		identityChanged = true
+
+		user.Blocked = false
+		userChanged = true
+	}
+
+	displayName := strings.TrimSpace(entry.DisplayName)
+	updateName := displayName != "" && displayName != user.DisplayName && check.DisplayName(displayName) == nil
+
+	updateEmail := entry.Email != "" && !strings.EqualFold(entry.Email, user.Email)
+	if updateEmail {
+		other, err := s.principalStore.FindUserByEmail(ctx, entry.Email)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find user by email: %w", err)
+		}
+		if err == nil && other.ID != user.ID {
+			log.Ctx(ctx).Warn().
+				Str("login", entry.Login).
+				Str("user_uid", user.UID).
+				Msg("email address of ldap user is used by another user")
+			updateEmail = false
+		}
+	}
+
+	if updateName || updateEmail {
+		st.add(enum.LDAPSyncActionUpdateUser, entry, user)
+
+		if updateName {
+			user.DisplayName = displayName
+		}
+		if updateEmail {
+			user.Email = entry.Email
+		}
+		userChanged = true
+	}
+
+	if st.dryRun {
+		return nil
+	}
+
+	if userChanged {
+		user.Updated = st.now
+		if err := s.principalStore.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+
+	if identityChanged {
+		identity.Login = entry.Login
+		identity.DN = entry.DN
+		identity.Updated = st.now
+		if err := s.identityStore.Update(ctx, identity); err != nil {
+			return fmt.Errorf("failed to update ldap identity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deactivateUser blocks the user of an identity that was removed from the directory.
+// Users that are already blocked are left alone, so they aren't reactivated if they reappear.
+func (s *Service) deactivateUser(ctx context.Context, st *syncState, identity *types.LDAPIdentity) error {
+	if identity.Deactivated {
+		return nil
+	}
+
+	user, err := s.principalStore.FindUser(ctx, identity.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find user of ldap identity: %w", err)
+	}
+
+	if user.Blocked {
+		return nil
+	}
+
+	st.add(enum.LDAPSyncActionDeactivateUser, &ldapauth.User{Login: identity.Login, DN: identity.DN}, user)
+
+	if st.dryRun {
+		return nil
+	}
+
+	user.Blocked = true
+	user.Updated = st.now
+	if err = s.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	identity.Deactivated = true
+	identity.Updated = st.now
+	if err = s.identityStore.Update(ctx, identity); err != nil {
+		return fmt.Errorf("failed to update ldap identity: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("login", identity.Login).
+		Str("user_uid", user.UID).
+		Msg("deactivated user removed from ldap directory")
+
+	return nil
+}
+
+// syncMemberships grants, updates and revokes the memberships of the spaces groups are mapped to.
+// For each space, the first mapping of one of the groups of the user defines the role.
+// The user is nil in a dry run if the user would be provisioned.
+func (s *Service) syncMemberships(ctx context.Context, st *syncState, entry *ldapauth.User, user *types.User) error {
+	groups := make(map[string]struct{}, len(entry.Groups))
+	for _, group := range entry.Groups {
+		groups[strings.ToLower(group)] = struct{}{}
+	}
+
+	roles := map[int64]enum.MembershipRole{}
+	for _, mapping := range st.mappings {
+		if _, ok := groups[strings.ToLower(mapping.GroupDN)]; !ok {
+			continue
+		}
+		if _, ok := roles[mapping.SpaceID]; !ok {
+			roles[mapping.SpaceID] = mapping.Role
+		}
+	}
+
+	for _, spaceID := range st.spaceIDs {
+		role, ok := roles[spaceID]
+
+		if user == nil {
+			if ok {
+				st.addMembership(enum.LDAPSyncActionAddMembership, entry.Login, nil, spaceID, role)
+			}
+			continue
+		}
+
+		if err := s.syncMembership(ctx, st, entry.Login, user, spaceID, role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncMembership syncs the membership of the user of a space. An empty role revokes the membership.
+func (s *Service) syncMembership(
+	ctx context.Context,
+	st *syncState,
+	login string,
+	user *types.User,
+	spaceID int64,
+	role enum.MembershipRole,
+) error {
+	key := types.MembershipKey{
+		SpaceID:     spaceID,
+		PrincipalID: user.ID,
+	}
+
+	membership, err := s.membershipStore.Find(ctx, key)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+	exists := err == nil
+
+	switch {
+	case !exists && role != "":
+		st.addMembership(enum.LDAPSyncActionAddMembership, login, user, spaceID, role)
+		if st.dryRun {
+			return nil
+		}
+
+		membership = &types.Membership{
+			MembershipKey: key,
+			CreatedBy:     user.ID,
+			Created:       st.now,
+			Updated:       st.now,
+			Role:          role,
+		}
+		if err = s.membershipStore.Create(ctx, membership); err != nil {
+			return fmt.Errorf("failed to create membership: %w", err)
+		}
+
+	case exists && role == "":
+		st.addMembership(enum.LDAPSyncActionRemoveMembership, login, user, spaceID, membership.Role)
+		if st.dryRun {
+			return nil
+		}
+
+		if err = s.membershipStore.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete membership: %w", err)
+		}
+
+	case exists && membership.Role != role:
+		st.addMembership(enum.LDAPSyncActionUpdateMembership, login, user, spaceID, role)
+		if st.dryRun {
+			return nil
+		}
+
+		membership.Role = role
+		membership.Updated = st.now
+		if err = s.membershipStore.Update(ctx, membership); err != nil {
+			return fmt.Errorf("failed to update membership: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// generateUID returns the login of the user as UID, or the login with a random suffix if it's taken.
+func (s *Service) generateUID(ctx context.Context, login string) (string, error) {
+	base := strings.Trim(invalidUIDCharacters.ReplaceAllString(login, "-"), "-.")
+	if len(base) > check.MaxIdentifierLength-5 {
+		base = base[:check.MaxIdentifierLength-5]
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	uid := base
+	for range provisionedUIDAttempts {
+		if s.principalUIDCheck(uid) == nil {
+			_, err := s.principalStore.FindByUID(ctx, uid)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return uid, nil
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to find principal by uid: %w", err)
+			}
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random number: %w", err)
+		}
+		uid = fmt.Sprintf("%s-%04d", base, n.Int64())
+	}
+
+	return "", errors.Conflict("Failed to find an available user identifier for '%s'.", login)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	ldapauth "github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	principalStore store.PrincipalStore,
+	identityStore store.LDAPIdentityStore,
+	groupMappingStore store.LDAPGroupMappingStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	principalUIDCheck check.PrincipalUID,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service := &Service{
+		enabled:     config.LDAP.Enabled,
+		allowSignup: config.LDAP.AllowSignup,
+		syncEnabled: config.LDAP.Sync.Enabled,
+		deactivate:  config.LDAP.Sync.Deactivate,
+		cron:        config.LDAP.Sync.CRON,
+		maxDur:      config.LDAP.Sync.MaxDuration,
+		directory: ldapauth.NewDirectory(ldapauth.Config{
+			URL:                config.LDAP.URL,
+			StartTLS:           config.LDAP.StartTLS,
+			InsecureSkipVerify: config.LDAP.InsecureSkipVerify,
+			Timeout:            config.LDAP.Timeout,
+			BindDN:             config.LDAP.BindDN,
+			BindPassword:       config.LDAP.BindPassword,
+			UserBaseDN:         config.LDAP.UserBaseDN,
+			UserFilter:         config.LDAP.UserFilter,
+			LoginAttribute:     config.LDAP.LoginAttribute,
+			EmailAttribute:     config.LDAP.EmailAttribute,
+			NameAttribute:      config.LDAP.NameAttribute,
+			GroupAttribute:     config.LDAP.GroupAttribute,
+		}),
+		principalStore:    principalStore,
+		identityStore:     identityStore,
+		groupMappingStore: groupMappingStore,
+		spaceStore:        spaceStore,
+		membershipStore:   membershipStore,
+		principalUIDCheck: principalUIDCheck,
+		scheduler:         scheduler,
+	}
+
+	if err := executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/logs"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	jobType = "pipeline-log-archival"
+
+	// batchSize defines the number of logs archived or deleted at once by the job.
+	batchSize = 100
+)
+
+// Service moves the step logs of finished executions from the database to the log archive
+// in the blob store, and deletes archived logs older than the retention period.
+//
+// The log store reads through to the archive, so archived logs are still served by the log API.
+type Service struct {
+	enabled   bool
+	delay     time.Duration
+	retention time.Duration
+	cron      string
+	maxDur    time.Duration
+
+	logStore     store.LogArchiveStore
+	archiveStore *logs.ArchiveLogStore
+	scheduler    *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	logStore store.LogArchiveStore,
+	archiveStore *logs.ArchiveLogStore,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:      config.Logs.Archive.Enabled,
+		delay:        config.Logs.Archive.Delay,
+		retention:    config.Logs.Archive.Retention,
+		cron:         config.Logs.Archive.CRON,
+		maxDur:       config.Logs.Archive.MaxDuration,
+		logStore:     logStore,
+		archiveStore: archiveStore,
+		scheduler:    scheduler,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline log archival: %w", err)
+	}
+
+	return nil
+}
+
+// Handle archives the logs of the executions that finished before the delay
+// and deletes the archived logs older than the retention period.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	now := time.Now()
+
+	archived, err := s.archive(ctx, now.Add(-s.delay).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return "", err
+	}
+
+	var deleted int
+	if s.retention > 0 {
+		deleted, err = s.deleteExpired(ctx, now.Add(-s.retention).UnixMilli())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if archived == 0 && deleted == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("archived %d and deleted %d pipeline logs", archived, deleted), nil
+}
+
+func (s *Service) archive(ctx context.Context, finishedBefore int64, now int64) (int, error) {
+	var archived int
+	for {
+		stepIDs, err := s.logStore.ListArchivable(ctx, finishedBefore, batchSize)
+		if err != nil {
+			return archived, fmt.Errorf("failed to list archivable pipeline logs: %w", err)
+		}
+
+		for _, stepID := range stepIDs {
+			if err := s.archiveLog(ctx, stepID, now); err != nil {
+				return archived, err
+			}
+			archived++
+		}
+
+		if len(stepIDs) < batchSize {
+			return archived, nil
+		}
+	}
+}
+
+// archiveLog uploads the log to the archive before removing it from the database,
+// so the log stays readable if archiving fails midway.
+func (s *Service) archiveLog(ctx context.Context, stepID int64, now int64) error {
+	rc, err := s.logStore.Find(ctx, stepID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline log of step %d: %w", stepID, err)
+	}
+	defer rc.Close()
+
+	if err := s.archiveStore.Create(ctx, stepID, rc); err != nil {
+		return fmt.Errorf("failed to archive pipeline log of step %d: %w", stepID, err)
+	}
+
+	if err := s.logStore.MarkArchived(ctx, stepID, now); err != nil {
+		return fmt.Errorf("failed to mark pipeline log of step %d as archived: %w", stepID, err)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteExpired(ctx context.Context, archivedBefore int64) (int, error) {
+	var deleted int
+	for {
+		stepIDs, err := s.logStore.ListArchivedBefore(ctx, archivedBefore, batchSize)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list expired pipeline logs: %w", err)
+		}
+
+		for _, stepID := range stepIDs {
+			if err := s.archiveStore.Delete(ctx, stepID); err != nil {
+				return deleted, fmt.Errorf("failed to delete archived pipeline log of step %d: %w", stepID, err)
+			}
+
+			if err := s.logStore.Delete(ctx, stepID); err != nil {
+				return deleted, fmt.Errorf("failed to delete pipeline log of step %d: %w", stepID, err)
+			}
+			deleted++
+		}
+
+		if len(stepIDs) < batchSize {
+			return deleted, nil
+		}
+	}
+}
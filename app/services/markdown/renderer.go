@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// maxReferences limits the number of distinct mentions and references that are resolved in a single document.
+const maxReferences = 100
+
+// referenceRegex matches user mentions (@[principal_id]) and pull request or issue references (#number).
+var referenceRegex = regexp.MustCompile(`@\[(\d+)\]|#(\d+)`)
+
+// Renderer converts markdown documents of a repository to sanitized HTML.
+type Renderer struct {
+	urlProvider        url.Provider
+	principalInfoCache store.PrincipalInfoCache
+	pullReqStore       store.PullReqStore
+	issueStore         store.IssueStore
+	markdown           goldmark.Markdown
+}
+
+func NewRenderer(
+	urlProvider url.Provider,
+	principalInfoCache store.PrincipalInfoCache,
+	pullReqStore store.PullReqStore,
+	issueStore store.IssueStore,
+) *Renderer {
+	return &Renderer{
+		urlProvider:        urlProvider,
+		principalInfoCache: principalInfoCache,
+		pullReqStore:       pullReqStore,
+		issueStore:         issueStore,
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+			// raw HTML is allowed because the output is sanitized afterwards.
+			goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+		),
+	}
+}
+
+// Render converts the markdown text to sanitized HTML.
+// Relative links and images are resolved against the directory of docPath in the provided git reference,
+// mentions are replaced with the names of the users and references are linked to pull requests and issues.
+func (r *Renderer) Render(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	docPath string,
+	text string,
+) (string, error) {
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	buf := bytes.Buffer{}
+	if err := r.markdown.Convert([]byte(text), &buf); err != nil {
+		return "", fmt.Errorf("failed to convert markdown to html: %w", err)
+	}
+
+	rc, err := r.newRenderContext(ctx, repo, gitRef, docPath, text)
+	if err != nil {
+		return "", err
+	}
+
+	return sanitize(buf.Bytes(), rc), nil
+}
+
+// newRenderContext loads the users and pull requests (or issues) referenced in the text.
+func (r *Renderer) newRenderContext(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	docPath string,
+	text string,
+) (*renderContext, error) {
+	rc := &renderContext{
+		ctx:         ctx,
+		urlProvider: r.urlProvider,
+		repo:        repo,
+		gitRef:      gitRef,
+		docPath:     docPath,
+		mentions:    map[int64]*types.PrincipalInfo{},
+		references:  map[int64]reference{},
+	}
+
+	var principalIDs []int64
+	numbers := map[int64]struct{}{}
+	seenPrincipals := map[int64]struct{}{}
+
+	for _, match := range referenceRegex.FindAllStringSubmatch(text, -1) {
+		if len(principalIDs)+len(numbers) >= maxReferences {
+			break
+		}
+
+		if match[1] != "" {
+			id, err := strconv.ParseInt(match[1], 10, 64)
+			if _, ok := seenPrincipals[id]; err == nil && !ok {
+				seenPrincipals[id] = struct{}{}
+				principalIDs = append(principalIDs, id)
+			}
+			continue
+		}
+
+		if number, err := strconv.ParseInt(match[2], 10, 64); err == nil {
+			numbers[number] = struct{}{}
+		}
+	}
+
+	if len(principalIDs) > 0 {
+		mentions, err := r.principalInfoCache.Map(ctx, principalIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch mentioned users: %w", err)
+		}
+		rc.mentions = mentions
+	}
+
+	for number := range numbers {
+		ref, ok, err := r.findReference(ctx, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rc.references[number] = ref
+		}
+	}
+
+	return rc, nil
+}
+
+// findReference finds the pull request, or the issue if there's no pull request, with the provided number.
+func (r *Renderer) findReference(
+	ctx context.Context,
+	repo *types.Repository,
+	number int64,
+) (reference, bool, error) {
+	pullReq, err := r.pullReqStore.FindByNumber(ctx, repo.ID, number)
+	if err == nil {
+		return reference{
+			title: pullReq.Title,
+			url:   r.urlProvider.GenerateUIPRURL(ctx, repo.Path, number),
+		}, true, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return reference{}, false, fmt.Errorf("failed to find referenced pull request: %w", err)
+	}
+
+	issue, err := r.issueStore.FindByNumber(ctx, repo.ID, number)
+	if err == nil {
+		return reference{
+			title: issue.Title,
+			url:   r.urlProvider.GenerateUIIssueURL(ctx, repo.Path, number),
+		}, true, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return reference{}, false, fmt.Errorf("failed to find referenced issue: %w", err)
+	}
+
+	return reference{}, false, nil
+}
@@ -0,0 +1,393 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	neturl "net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+
+	"github.com/microcosm-cc/bluemonday"
+	nethtml "golang.org/x/net/html"
+)
+
+// allowedTags lists the HTML elements that are kept, with their allowed attributes.
+var allowedTags = map[string][]string{
+	"a":          {"href", "title", "name"},
+	"abbr":       {"title"},
+	"b":          nil,
+	"blockquote": {"cite"},
+	"br":         nil,
+	"caption":    nil,
+	"code":       nil,
+	"col":        {"span"},
+	"colgroup":   {"span"},
+	"dd":         nil,
+	"del":        nil,
+	"details":    {"open"},
+	"div":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"input":      {"checked", "disabled"},
+	"ins":        nil,
+	"kbd":        nil,
+	"li":         nil,
+	"mark":       nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"picture":    nil,
+	"pre":        nil,
+	"q":          {"cite"},
+	"s":          nil,
+	"samp":       nil,
+	"small":      nil,
+	"span":       {"title"},
+	"strike":     nil,
+	"strong":     nil,
+	"sub":        nil,
+	"summary":    nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"colspan", "rowspan"},
+	"tfoot":      nil,
+	"th":         {"colspan", "rowspan"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+	"var":        nil,
+}
+
+// globalAttributes lists the attributes allowed on all kept elements.
+var globalAttributes = []string{"id", "align", "dir"}
+
+// droppedContentTags lists the elements that are removed together with their content,
+// in addition to the ones bluemonday removes by default (e.g. script and style).
+var droppedContentTags = []string{
+	"applet", "embed", "frame", "math", "select", "svg", "template", "textarea",
+}
+
+// policy is the sanitization policy applied to the rendered documents, it's safe for concurrent use.
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+
+	for tag, attrs := range allowedTags {
+		p.AllowElements(tag)
+		if len(attrs) > 0 {
+			p.AllowAttrs(attrs...).OnElements(tag)
+		}
+		// links and images without any allowed attribute are removed
+		if tag != "a" && tag != "img" {
+			p.AllowNoAttrs().OnElements(tag)
+		}
+	}
+	p.AllowAttrs(globalAttributes...).Globally()
+
+	// only the syntax highlighting classes of code blocks, the checkboxes of task lists,
+	// and the mentions and references linked by the renderer are kept.
+	p.AllowAttrs("class").Matching(codeClassRegex).OnElements("code")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`(?i)^checkbox$`)).OnElements("input")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^mention$`)).OnElements("span")
+	p.AllowAttrs("data-principal-id").Matching(bluemonday.Integer).OnElements("span")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^reference$`)).OnElements("a")
+
+	p.SkipElementsContent(droppedContentTags...)
+
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.AllowRelativeURLs(true)
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnFullyQualifiedLinks(true)
+	p.RequireNoReferrerOnFullyQualifiedLinks(true)
+
+	return p
+}
+
+// noAutolinkTags lists the elements whose text must not be auto-linked.
+var noAutolinkTags = map[string]struct{}{
+	"a":    {},
+	"code": {},
+	"pre":  {},
+}
+
+var codeClassRegex = regexp.MustCompile(`^language-[\w+#.-]+$`)
+
+// reference is a pull request or an issue referenced from the document.
+type reference struct {
+	title string
+	url   string
+}
+
+// renderContext holds the repository context of the rendered document.
+type renderContext struct {
+	ctx         context.Context
+	urlProvider url.Provider
+	repo        *types.Repository
+	gitRef      string
+	docPath     string
+	mentions    map[int64]*types.PrincipalInfo
+	references  map[int64]reference
+}
+
+// sanitize removes all elements and attributes that are not explicitly allowed from the HTML,
+// resolves relative links and images and auto-links mentions and references in the text.
+// The document is rewritten first and the result is sanitized, so the rewrite can't introduce unsafe content.
+func sanitize(data []byte, rc *renderContext) string {
+	return policy.Sanitize(rc.rewrite(data))
+}
+
+// rewrite resolves the relative links and images, prefixes the ids of the elements
+// and auto-links mentions and references in the text of the HTML.
+func (rc *renderContext) rewrite(data []byte) string {
+	out := &strings.Builder{}
+	z := nethtml.NewTokenizer(bytes.NewReader(data))
+
+	noAutolinkDepth := 0
+
+	for {
+		tokenType := z.Next()
+		if tokenType == nethtml.ErrorToken {
+			// the tokenizer reports io.EOF at the end of the input, no other error can happen with an in-memory reader.
+			return out.String()
+		}
+
+		token := z.Token()
+
+		switch tokenType {
+		case nethtml.TextToken:
+			if noAutolinkDepth > 0 {
+				out.WriteString(html.EscapeString(token.Data))
+			} else {
+				out.WriteString(rc.autolink(token.Data))
+			}
+
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			token.Attr = rc.rewriteAttributes(token.Data, token.Attr)
+			out.WriteString(token.String())
+
+			if _, ok := noAutolinkTags[token.Data]; ok && tokenType == nethtml.StartTagToken {
+				noAutolinkDepth++
+			}
+
+		case nethtml.EndTagToken:
+			out.WriteString(token.String())
+
+			if _, ok := noAutolinkTags[token.Data]; ok && noAutolinkDepth > 0 {
+				noAutolinkDepth--
+			}
+
+		case nethtml.CommentToken, nethtml.DoctypeToken, nethtml.ErrorToken:
+			// comments and doctype are removed
+		}
+	}
+}
+
+// rewriteAttributes resolves the URLs and prefixes the ids of the element.
+// Attributes with URLs that aren't allowed are removed.
+func (rc *renderContext) rewriteAttributes(tag string, attrs []nethtml.Attribute) []nethtml.Attribute {
+	result := make([]nethtml.Attribute, 0, len(attrs))
+
+	for _, attr := range attrs {
+		attr.Key = strings.ToLower(attr.Key)
+
+		switch attr.Key {
+		case "href":
+			href, ok := rc.resolveURL(attr.Val, false)
+			if !ok {
+				continue
+			}
+			attr.Val = href
+		case "src":
+			src, ok := rc.resolveURL(attr.Val, true)
+			if !ok {
+				continue
+			}
+			attr.Val = src
+		case "id", "name":
+			// prefix ids to avoid clobbering the elements of the page that displays the document
+			if !strings.HasPrefix(attr.Val, userContentPrefix) {
+				attr.Val = userContentPrefix + attr.Val
+			}
+		}
+
+		result = append(result, attr)
+	}
+
+	// the checkboxes of task lists can't be changed.
+	if tag == "input" {
+		if _, ok := attrValue(result, "disabled"); !ok {
+			result = append(result, nethtml.Attribute{Key: "disabled"})
+		}
+	}
+
+	return result
+}
+
+// userContentPrefix is the prefix of ids and anchors of the rendered document.
+const userContentPrefix = "user-content-"
+
+// resolveURL returns the URL to use in the rendered document, or false if the URL isn't allowed.
+// Relative URLs are resolved against the directory of the document within the repository:
+// images point to the raw content of the file while links point to the file in the UI.
+func (rc *renderContext) resolveURL(rawURL string, image bool) (string, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	if strings.HasPrefix(rawURL, "#") {
+		return "#" + userContentPrefix + strings.TrimPrefix(rawURL, "#"), true
+	}
+
+	if absURL, ok := safeAbsoluteURL(rawURL); ok {
+		return absURL, true
+	}
+
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Opaque != "" {
+		return "", false
+	}
+
+	filePath := u.Path
+	if strings.HasPrefix(filePath, "/") {
+		filePath = path.Clean(filePath)
+	} else {
+		filePath = path.Clean("/" + path.Join(path.Dir(rc.docPath), filePath))
+	}
+	filePath = strings.TrimPrefix(filePath, "/")
+
+	if image {
+		return rc.urlProvider.GenerateRawFilePath(rc.ctx, rc.repo.ID, rc.gitRef, filePath), true
+	}
+
+	result := rc.urlProvider.GenerateUIFileURL(rc.ctx, rc.repo.Path, rc.gitRef, filePath)
+	if u.Fragment != "" {
+		result += "#" + userContentPrefix + u.Fragment
+	}
+
+	return result, true
+}
+
+// safeAbsoluteURL returns the URL if it's an absolute URL with an allowed scheme.
+func safeAbsoluteURL(rawURL string) (string, bool) {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Scheme == "" {
+		return "", false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return u.String(), true
+	default:
+		return "", false
+	}
+}
+
+// autolink escapes the text and replaces the mentions and references in it with links.
+func (rc *renderContext) autolink(text string) string {
+	out := &strings.Builder{}
+	last := 0
+
+	for _, loc := range referenceRegex.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+
+		var replacement string
+		if loc[2] >= 0 {
+			replacement = rc.mentionHTML(text[loc[2]:loc[3]])
+		} else if start == 0 || !isWordRune(lastRune(text[:start])) {
+			replacement = rc.referenceHTML(text[loc[4]:loc[5]])
+		}
+
+		if replacement == "" {
+			continue
+		}
+
+		out.WriteString(html.EscapeString(text[last:start]))
+		out.WriteString(replacement)
+		last = end
+	}
+
+	out.WriteString(html.EscapeString(text[last:]))
+
+	return out.String()
+}
+
+func (rc *renderContext) mentionHTML(rawID string) string {
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	principal, ok := rc.mentions[id]
+	if !ok || principal == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`<span class="mention" data-principal-id="%d" title="%s">@%s</span>`,
+		principal.ID, html.EscapeString(principal.UID), html.EscapeString(principal.DisplayName))
+}
+
+func (rc *renderContext) referenceHTML(rawNumber string) string {
+	number, err := strconv.ParseInt(rawNumber, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	ref, ok := rc.references[number]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf(`<a class="reference" href="%s" title="%s">#%d</a>`,
+		html.EscapeString(ref.url), html.EscapeString(ref.title), number)
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '&' || r == '/' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func attrValue(attrs []nethtml.Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+)
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GenerateUIFileURL(_ context.Context, repoPath, gitRef, filePath string) string {
+	return "https://ui/" + repoPath + "/files/" + gitRef + "/~/" + filePath
+}
+
+func (fakeURLProvider) GenerateRawFilePath(_ context.Context, _ int64, gitRef, filePath string) string {
+	return "/api/v1/repos/1/raw-ref/" + gitRef + "/" + filePath
+}
+
+func TestSanitize(t *testing.T) {
+	rc := &renderContext{
+		ctx:         context.Background(),
+		urlProvider: fakeURLProvider{},
+		repo:        &types.Repository{ID: 1, Path: "space/repo"},
+		gitRef:      "main",
+		docPath:     "docs/README.md",
+		mentions: map[int64]*types.PrincipalInfo{
+			7: {ID: 7, UID: "jane", DisplayName: "Jane"},
+		},
+		references: map[int64]reference{
+			12: {title: "Fix <bug>", url: "https://ui/space/repo/pulls/12"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "script removed with content",
+			input:    `<p>a<script>alert(1)</script>b</p>`,
+			expected: `<p>ab</p>`,
+		},
+		{
+			name:     "event handlers and unknown tags removed",
+			input:    `<p onclick="x()"><font color="red">text</font></p>`,
+			expected: `<p>text</p>`,
+		},
+		{
+			name:     "javascript links removed",
+			input:    `<a href="javascript:alert(1)">x</a>`,
+			expected: `x`,
+		},
+		{
+			name:  "relative link resolved",
+			input: `<a href="../CONTRIBUTING.md#setup">x</a>`,
+			expected: `<a href="https://ui/space/repo/files/main/~/CONTRIBUTING.md#user-content-setup" ` +
+				`rel="nofollow noreferrer">x</a>`,
+		},
+		{
+			name:     "relative image resolved",
+			input:    `<img src="img/logo.png" alt="logo">`,
+			expected: `<img src="/api/v1/repos/1/raw-ref/main/docs/img/logo.png" alt="logo">`,
+		},
+		{
+			name:     "absolute image kept",
+			input:    `<img src="https://example.com/badge.svg">`,
+			expected: `<img src="https://example.com/badge.svg">`,
+		},
+		{
+			name:     "anchor prefixed",
+			input:    `<h2 id="usage">Usage</h2><a href="#usage">x</a>`,
+			expected: `<h2 id="user-content-usage">Usage</h2><a href="#user-content-usage">x</a>`,
+		},
+		{
+			name:  "mention and reference linked",
+			input: `<p>@[7] fixed #12 in abc#12, see #13</p>`,
+			expected: `<p><span class="mention" data-principal-id="7" title="jane">@Jane</span> fixed ` +
+				`<a class="reference" href="https://ui/space/repo/pulls/12" title="Fix &lt;bug&gt;" ` +
+				`rel="nofollow noreferrer">#12</a>` +
+				` in abc#12, see #13</p>`,
+		},
+		{
+			name:     "no autolink in code",
+			input:    `<pre><code class="language-go">// #12 @[7]</code></pre>`,
+			expected: `<pre><code class="language-go">// #12 @[7]</code></pre>`,
+		},
+		{
+			name:     "embedded content removed with content",
+			input:    `<p>a<iframe src="https://example.com">x</iframe><svg><a href="#x">y</a></svg>b</p>`,
+			expected: `<p>ab</p>`,
+		},
+		{
+			name:     "styles and foreign classes removed",
+			input:    `<span class="mention" style="color:red">@x</span><code class="evil">c</code>`,
+			expected: `<span class="mention">@x</span><code>c</code>`,
+		},
+		{
+			name:     "forged mention attributes removed",
+			input:    `<span data-principal-id="x" data-other="1">y</span>`,
+			expected: `<span>y</span>`,
+		},
+		{
+			name:     "text input disabled",
+			input:    `<input type="text" value="x">`,
+			expected: `<input disabled="">`,
+		},
+		{
+			name:     "task list checkbox kept disabled",
+			input:    `<li><input type="checkbox" checked=""> done</li>`,
+			expected: `<li><input type="checkbox" checked="" disabled=""> done</li>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := sanitize([]byte(test.input), rc)
+			if got != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, got)
+			}
+		})
+	}
+}
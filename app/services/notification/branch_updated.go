@@ -88,6 +88,12 @@ func (s *Service) processPullReqBranchUpdatedEvent(
 		}
 	}
 
+	reviewerPrincipals, err = s.filterRecipients(ctx, reviewerPrincipals,
+		func(p types.NotificationPreferences) bool { return p.BranchUpdated })
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return &PullReqBranchUpdatedPayload{
 		Base:      base,
 		NewSHA:    event.Payload.NewSHA,
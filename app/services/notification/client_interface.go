@@ -58,4 +58,18 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendReviewSLA(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *ReviewSLAPayload,
+	) error
+	SendEmailVerification(
+		ctx context.Context,
+		payload *EmailVerificationPayload,
+	) error
+	SendTokenExpiring(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *TokenExpiringPayload,
+	) error
 }
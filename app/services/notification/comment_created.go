@@ -138,6 +138,30 @@ func (s *Service) processCommentCreatedEvent(
 		author = base.Author
 	}
 
+	mentions, err = s.filterRecipients(ctx, mentions,
+		func(p types.NotificationPreferences) bool { return p.Mentions })
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	participants, err = s.filterRecipients(ctx, participants,
+		func(p types.NotificationPreferences) bool { return p.CommentCreated })
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if author != nil {
+		var authors []*types.PrincipalInfo
+		authors, err = s.filterRecipients(ctx, []*types.PrincipalInfo{author},
+			func(p types.NotificationPreferences) bool { return p.CommentCreated })
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if len(authors) == 0 {
+			author = nil
+		}
+	}
+
 	return payload, mentions, participants, author, nil
 }
 
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+)
+
+const (
+	TemplateEmailVerification = "email_verification.html"
+
+	subjectEmailVerification = "Verify your email address"
+)
+
+// EmailVerificationPayload is the data of the email sent to verify an email address of a user.
+type EmailVerificationPayload struct {
+	DisplayName     string
+	Email           string
+	VerificationURL string
+	ExpiresIn       string
+}
+
+func (m MailClient) SendEmailVerification(
+	ctx context.Context,
+	payload *EmailVerificationPayload,
+) error {
+	body, err := GetHTMLBody(TemplateEmailVerification, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate email verification mail: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{payload.Email},
+		Subject:      subjectEmailVerification,
+		Body:         string(body),
+	})
+}
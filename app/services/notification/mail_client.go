@@ -32,6 +32,7 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateReviewSLA            = "review_sla.html"
 )
 
 type MailClient struct {
@@ -169,6 +170,22 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendReviewSLA(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSLAPayload,
+) error {
+	email, err := GenerateEmailFromPayload(TemplateReviewSLA, recipients, payload.Base, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to generate mail requests after processing review SLA event: %w",
+			err,
+		)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// recipientsWithPreferences returns the recipients who didn't disable the notification in their preferences,
+// along with the preferences of all recipients.
+func (s *Service) recipientsWithPreferences(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	enabled func(types.NotificationPreferences) bool,
+) ([]*types.PrincipalInfo, map[int64]*types.UserPreferences, error) {
+	if len(recipients) == 0 {
+		return recipients, nil, nil
+	}
+
+	ids := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+
+	prefs, err := s.userPreferencesStore.FindMany(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch preferences of the recipients: %w", err)
+	}
+
+	filtered := make([]*types.PrincipalInfo, 0, len(recipients))
+	for _, recipient := range recipients {
+		if p, ok := prefs[recipient.ID]; ok && !enabled(p.Notifications) {
+			continue
+		}
+		filtered = append(filtered, recipient)
+	}
+
+	return filtered, prefs, nil
+}
+
+// filterRecipients removes the recipients who disabled the notification in their preferences.
+func (s *Service) filterRecipients(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	enabled func(types.NotificationPreferences) bool,
+) ([]*types.PrincipalInfo, error) {
+	filtered, _, err := s.recipientsWithPreferences(ctx, recipients, enabled)
+	return filtered, err
+}
+
+// groupRecipientsByTimezone groups the recipients by the timezone from their preferences,
+// recipients with an unknown timezone get UTC.
+func groupRecipientsByTimezone(
+	recipients []*types.PrincipalInfo,
+	prefs map[int64]*types.UserPreferences,
+) map[string][]*types.PrincipalInfo {
+	groups := make(map[string][]*types.PrincipalInfo)
+	for _, recipient := range recipients {
+		timezone := time.UTC.String()
+		if p, ok := prefs[recipient.ID]; ok && p.Timezone != "" {
+			timezone = p.Timezone
+		}
+		groups[timezone] = append(groups[timezone], recipient)
+	}
+
+	return groups
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeUserPreferencesStore struct {
+	store.UserPreferencesStore
+	prefs map[int64]*types.UserPreferences
+}
+
+func (s *fakeUserPreferencesStore) FindMany(
+	_ context.Context,
+	principalIDs []int64,
+) (map[int64]*types.UserPreferences, error) {
+	result := make(map[int64]*types.UserPreferences, len(principalIDs))
+	for _, id := range principalIDs {
+		if p, ok := s.prefs[id]; ok {
+			result[id] = p
+		}
+	}
+	return result, nil
+}
+
+func preferences(timezone string, commentCreated bool) *types.UserPreferences {
+	prefs := types.DefaultUserPreferences()
+	prefs.Timezone = timezone
+	prefs.Notifications.CommentCreated = commentCreated
+	return &prefs
+}
+
+func recipientIDs(recipients []*types.PrincipalInfo) []int64 {
+	ids := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+	return ids
+}
+
+func TestService_RecipientsWithPreferences(t *testing.T) {
+	s := &Service{userPreferencesStore: &fakeUserPreferencesStore{prefs: map[int64]*types.UserPreferences{
+		1: preferences("Europe/Berlin", true),
+		2: preferences("UTC", false),
+	}}}
+
+	recipients := []*types.PrincipalInfo{{ID: 1}, {ID: 2}, {ID: 3}}
+	commentCreated := func(n types.NotificationPreferences) bool { return n.CommentCreated }
+
+	filtered, prefs, err := s.recipientsWithPreferences(context.Background(), recipients, commentCreated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the recipient without stored preferences keeps receiving the notification.
+	if ids, want := recipientIDs(filtered), []int64{1, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("recipients = %v, want %v", ids, want)
+	}
+	if len(prefs) != 2 {
+		t.Errorf("got preferences of %d recipients, want 2", len(prefs))
+	}
+
+	filtered, err = s.filterRecipients(context.Background(), nil, commentCreated)
+	if err != nil || len(filtered) != 0 {
+		t.Errorf("filterRecipients() of no recipients = %v, %v, want none", filtered, err)
+	}
+}
+
+func TestGroupRecipientsByTimezone(t *testing.T) {
+	recipients := []*types.PrincipalInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	prefs := map[int64]*types.UserPreferences{
+		1: preferences("Europe/Berlin", true),
+		2: preferences("", true),
+		4: preferences("Europe/Berlin", true),
+	}
+
+	groups := groupRecipientsByTimezone(recipients, prefs)
+
+	want := map[string][]int64{
+		"Europe/Berlin": {1, 4},
+		"UTC":           {2, 3},
+	}
+	if len(groups) != len(want) {
+		t.Errorf("got %d groups, want %d", len(groups), len(want))
+	}
+	for timezone, ids := range want {
+		if got := recipientIDs(groups[timezone]); !reflect.DeepEqual(got, ids) {
+			t.Errorf("recipients in %s = %v, want %v", timezone, got, ids)
+		}
+	}
+}
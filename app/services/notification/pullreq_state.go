@@ -51,6 +51,10 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -80,6 +84,10 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -109,6 +117,10 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -169,6 +181,12 @@ func (s *Service) processPullReqStateChangedEvent(
 
 	recipients[len(reviewers)] = author
 
+	recipients, err = s.filterRecipients(ctx, recipients,
+		func(p types.NotificationPreferences) bool { return p.PullReqStateChanged })
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return &PullReqStateChangedPayload{
 		Base:      basePayload,
 		ChangedBy: stateModifierPrincipal,
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+type ReviewSLAPayload struct {
+	Base     *BasePullReqPayload
+	Breached bool
+	DueAt    time.Time
+}
+
+func (s *Service) notifyReviewSLAWarning(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSLAWarningPayload],
+) error {
+	return s.notifyReviewSLA(ctx, pullreqevents.ReviewSLAWarningEvent, event.Payload.Base, event.Payload.DueAt, false)
+}
+
+func (s *Service) notifyReviewSLABreached(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSLABreachedPayload],
+) error {
+	return s.notifyReviewSLA(ctx, pullreqevents.ReviewSLABreachedEvent, event.Payload.Base, event.Payload.DueAt, true)
+}
+
+func (s *Service) notifyReviewSLA(
+	ctx context.Context,
+	eventType events.EventType,
+	baseEvent pullreqevents.Base,
+	dueAt int64,
+	breached bool,
+) error {
+	payload, recipients, err := s.processReviewSLAEvent(ctx, baseEvent, dueAt, breached)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for pullReqID %d: %w",
+			eventType,
+			baseEvent.PullReqID,
+			err,
+		)
+	}
+
+	recipients, prefs, err := s.recipientsWithPreferences(ctx, recipients,
+		func(p types.NotificationPreferences) bool { return p.ReviewSLA })
+	if err != nil {
+		return fmt.Errorf("failed to filter recipients for pullReqID %d: %w", baseEvent.PullReqID, err)
+	}
+
+	// the due date is shown in the timezone of the recipients, hence one email is sent per timezone.
+	for timezone, group := range groupRecipientsByTimezone(recipients, prefs) {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+
+		groupPayload := *payload
+		groupPayload.DueAt = payload.DueAt.In(loc)
+
+		if err = s.notificationClient.SendReviewSLA(
+			ctx,
+			group,
+			&groupPayload,
+		); err != nil {
+			return fmt.Errorf(
+				"failed to send email for event %s for pullReqID %d: %w",
+				eventType,
+				baseEvent.PullReqID,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) processReviewSLAEvent(
+	ctx context.Context,
+	baseEvent pullreqevents.Base,
+	dueAt int64,
+	breached bool,
+) (*ReviewSLAPayload, []*types.PrincipalInfo, error) {
+	basePayload, err := s.getBasePayload(ctx, baseEvent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
+	}
+
+	author, err := s.principalInfoCache.Get(ctx, basePayload.PullReq.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to get author from principalInfoCache for pullReqID %d: %w",
+			baseEvent.PullReqID,
+			err,
+		)
+	}
+
+	reviewers, err := s.pullReqReviewersStore.List(ctx, baseEvent.PullReqID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to get reviewers from pullReqReviewersStore for pullReqID %d: %w",
+			baseEvent.PullReqID,
+			err,
+		)
+	}
+
+	recipients := make([]*types.PrincipalInfo, len(reviewers)+1)
+	for i := range reviewers {
+		recipients[i] = &reviewers[i].Reviewer
+	}
+
+	recipients[len(reviewers)] = author
+
+	return &ReviewSLAPayload{
+		Base:     basePayload,
+		Breached: breached,
+		DueAt:    time.UnixMilli(dueAt).UTC(),
+	}, recipients, nil
+}
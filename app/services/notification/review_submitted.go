@@ -45,6 +45,10 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewSubmitted(
 		ctx,
 		recipients,
@@ -91,10 +95,16 @@ func (s *Service) processReviewSubmittedEvent(
 		)
 	}
 
+	recipients, err := s.filterRecipients(ctx, []*types.PrincipalInfo{authorPrincipal},
+		func(p types.NotificationPreferences) bool { return p.ReviewSubmitted })
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return &ReviewSubmittedPayload{
 		Base:     base,
 		Author:   authorPrincipal,
 		Decision: event.Payload.Decision,
 		Reviewer: reviewerPrincipal,
-	}, []*types.PrincipalInfo{authorPrincipal}, nil
+	}, recipients, nil
 }
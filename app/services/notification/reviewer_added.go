@@ -42,6 +42,10 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
@@ -69,9 +73,12 @@ func (s *Service) processReviewerAddedEvent(
 		return nil, nil, fmt.Errorf("failed to get reviewer from principalInfoCache: %w", err)
 	}
 
-	recipients := []*types.PrincipalInfo{
+	recipients, err := s.filterRecipients(ctx, []*types.PrincipalInfo{
 		base.Author,
 		reviewerPrincipal,
+	}, func(p types.NotificationPreferences) bool { return p.ReviewerAdded })
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return &ReviewerAddedPayload{
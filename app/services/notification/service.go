@@ -23,6 +23,7 @@ import (
 	"path"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	tokenevents "github.com/harness/gitness/app/events/token"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -88,6 +89,7 @@ type Service struct {
 	config                Config
 	notificationClient    Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	tokenReaderFactory    *events.ReaderFactory[*tokenevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -95,6 +97,7 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	userPreferencesStore  store.UserPreferencesStore
 	urlProvider           url.Provider
 }
 
@@ -103,6 +106,7 @@ func NewService(
 	config Config,
 	notificationClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	tokenReaderFactory *events.ReaderFactory[*tokenevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -110,12 +114,14 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	userPreferencesStore store.UserPreferencesStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
 		prReaderFactory:       prReaderFactory,
+		tokenReaderFactory:    tokenReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -123,6 +129,7 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		userPreferencesStore:  userPreferencesStore,
 		urlProvider:           urlProvider,
 	}
 
@@ -147,12 +154,34 @@ func NewService(
 			_ = r.RegisterMerged(service.notifyPullReqStateMerged)
 			_ = r.RegisterClosed(service.notifyPullReqStateClosed)
 			_ = r.RegisterReopened(service.notifyPullReqStateReOpened)
+
+			// review SLA
+			_ = r.RegisterReviewSLAWarning(service.notifyReviewSLAWarning)
+			_ = r.RegisterReviewSLABreached(service.notifyReviewSLABreached)
 			return nil
 		})
 	if err != nil {
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.tokenReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *tokenevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterExpiring(service.notifyTokenExpiring)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch token event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
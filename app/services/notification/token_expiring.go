@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tokenevents "github.com/harness/gitness/app/events/token"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	TemplateTokenExpiring = "token_expiring.html"
+
+	subjectTokenExpiring = "Access token %q is about to expire"
+)
+
+// TokenExpiringPayload is the data of the email sent to warn about an access token that is about to expire.
+type TokenExpiringPayload struct {
+	Identifier     string
+	ServiceAccount bool
+	OwnerName      string
+	ExpiresAt      time.Time
+}
+
+// notifyTokenExpiring warns the owner of a personal access token, or the creator of a service account token,
+// about the expiry of the token.
+func (s *Service) notifyTokenExpiring(
+	ctx context.Context,
+	event *events.Event[*tokenevents.ExpiringPayload],
+) error {
+	owner, err := s.principalInfoCache.Get(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to get owner of token %d: %w", event.Payload.TokenID, err)
+	}
+
+	recipient := owner
+	if event.Payload.Type == enum.TokenTypeSAT {
+		recipient, err = s.principalInfoCache.Get(ctx, event.Payload.CreatedBy)
+		if err != nil {
+			return fmt.Errorf("failed to get creator of token %d: %w", event.Payload.TokenID, err)
+		}
+	}
+
+	if recipient.Type != enum.PrincipalTypeUser || recipient.Email == "" {
+		return nil
+	}
+
+	recipients, prefs, err := s.recipientsWithPreferences(ctx, []*types.PrincipalInfo{recipient},
+		func(p types.NotificationPreferences) bool { return p.TokenExpiring })
+	if err != nil {
+		return fmt.Errorf("failed to filter recipients for token %d: %w", event.Payload.TokenID, err)
+	}
+
+	for timezone, group := range groupRecipientsByTimezone(recipients, prefs) {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+
+		err = s.notificationClient.SendTokenExpiring(ctx, group, &TokenExpiringPayload{
+			Identifier:     event.Payload.Identifier,
+			ServiceAccount: event.Payload.Type == enum.TokenTypeSAT,
+			OwnerName:      owner.DisplayName,
+			ExpiresAt:      time.UnixMilli(event.Payload.ExpiresAt).In(loc),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send email for expiring token %d: %w", event.Payload.TokenID, err)
+		}
+	}
+
+	return nil
+}
+
+func (m MailClient) SendTokenExpiring(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *TokenExpiringPayload,
+) error {
+	body, err := GetHTMLBody(TemplateTokenExpiring, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate token expiring mail: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectTokenExpiring, payload.Identifier),
+		Body:         string(body),
+	})
+}
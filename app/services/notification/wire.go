@@ -18,6 +18,7 @@ import (
 	"context"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	tokenevents "github.com/harness/gitness/app/events/token"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -36,6 +37,7 @@ func ProvideNotificationService(
 	notificationClient Client,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	tokenReaderFactory *events.ReaderFactory[*tokenevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -43,6 +45,7 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	userPreferencesStore store.UserPreferencesStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	return NewService(
@@ -50,6 +53,7 @@ func ProvideNotificationService(
 		pullReqConfig,
 		notificationClient,
 		prReaderFactory,
+		tokenReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -57,6 +61,7 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		userPreferencesStore,
 		urlProvider,
 	)
 }
@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	gitHubURL       = "https://github.com"
+	gitHubAPIURL    = "https://api.github.com"
+	gitLabURL       = "https://gitlab.com"
+	bitbucketURL    = "https://bitbucket.org"
+	bitbucketAPIURL = "https://api.bitbucket.org"
+	googleAuthURL   = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL  = "https://oauth2.googleapis.com/token"
+	googleUserURL   = "https://openidconnect.googleapis.com/v1/userinfo"
+
+	maxResponseSize = 1 << 20 // 1 MiB
+)
+
+// ExternalUser is the account of the user with the OAuth provider.
+type ExternalUser struct {
+	// ID is the immutable identifier of the account with the provider.
+	ID          string
+	Login       string
+	DisplayName string
+	Email       string
+	// EmailVerified is true if the provider confirmed that the email address belongs to the user.
+	EmailVerified bool
+}
+
+// endpoint describes how to authorize users with a provider and how to fetch their account.
+type endpoint struct {
+	oauth    oauth2.Endpoint
+	scopes   []string
+	apiURL   string
+	userFunc func(ctx context.Context, client *http.Client, apiURL string) (*ExternalUser, error)
+}
+
+// supportsBaseURL returns true if the provider type can be self-hosted.
+func supportsBaseURL(providerType enum.OAuthProviderType) bool {
+	return providerType == enum.OAuthProviderTypeGitHub || providerType == enum.OAuthProviderTypeGitLab
+}
+
+func getEndpoint(providerType enum.OAuthProviderType, baseURL string) (endpoint, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	switch providerType {
+	case enum.OAuthProviderTypeGitHub:
+		apiURL := gitHubAPIURL
+		if baseURL == "" {
+			baseURL = gitHubURL
+		} else {
+			// GitHub Enterprise Server serves the REST API from the instance URL.
+			apiURL = baseURL + "/api/v3"
+		}
+		return endpoint{
+			oauth: oauth2.Endpoint{
+				AuthURL:  baseURL + "/login/oauth/authorize",
+				TokenURL: baseURL + "/login/oauth/access_token",
+			},
+			scopes:   []string{"read:user", "user:email"},
+			apiURL:   apiURL,
+			userFunc: fetchGitHubUser,
+		}, nil
+
+	case enum.OAuthProviderTypeGitLab:
+		if baseURL == "" {
+			baseURL = gitLabURL
+		}
+		return endpoint{
+			oauth: oauth2.Endpoint{
+				AuthURL:  baseURL + "/oauth/authorize",
+				TokenURL: baseURL + "/oauth/token",
+			},
+			scopes:   []string{"read_user"},
+			apiURL:   baseURL + "/api/v4",
+			userFunc: fetchGitLabUser,
+		}, nil
+
+	case enum.OAuthProviderTypeGoogle:
+		return endpoint{
+			oauth: oauth2.Endpoint{
+				AuthURL:  googleAuthURL,
+				TokenURL: googleTokenURL,
+			},
+			scopes:   []string{"openid", "email", "profile"},
+			apiURL:   googleUserURL,
+			userFunc: fetchGoogleUser,
+		}, nil
+
+	case enum.OAuthProviderTypeBitbucket:
+		return endpoint{
+			oauth: oauth2.Endpoint{
+				AuthURL:  bitbucketURL + "/site/oauth2/authorize",
+				TokenURL: bitbucketURL + "/site/oauth2/access_token",
+			},
+			scopes:   []string{"account", "email"},
+			apiURL:   bitbucketAPIURL + "/2.0",
+			userFunc: fetchBitbucketUser,
+		}, nil
+	}
+
+	return endpoint{}, fmt.Errorf("unsupported oauth provider type %q", providerType)
+}
+
+func fetchGitHubUser(ctx context.Context, client *http.Client, apiURL string) (*ExternalUser, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := getJSON(ctx, client, apiURL+"/user", &user); err != nil {
+		return nil, err
+	}
+
+	// the email of the profile is the public one, which isn't necessarily verified.
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, client, apiURL+"/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	out := &ExternalUser{
+		ID:          strconv.FormatInt(user.ID, 10),
+		Login:       user.Login,
+		DisplayName: user.Name,
+	}
+	for _, email := range emails {
+		if email.Primary {
+			out.Email = email.Email
+			out.EmailVerified = email.Verified
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func fetchGitLabUser(ctx context.Context, client *http.Client, apiURL string) (*ExternalUser, error) {
+	var user struct {
+		ID          int64   `json:"id"`
+		Username    string  `json:"username"`
+		Name        string  `json:"name"`
+		Email       string  `json:"email"`
+		ConfirmedAt *string `json:"confirmed_at"`
+	}
+	if err := getJSON(ctx, client, apiURL+"/user", &user); err != nil {
+		return nil, err
+	}
+
+	return &ExternalUser{
+		ID:            strconv.FormatInt(user.ID, 10),
+		Login:         user.Username,
+		DisplayName:   user.Name,
+		Email:         user.Email,
+		EmailVerified: user.ConfirmedAt != nil,
+	}, nil
+}
+
+func fetchGoogleUser(ctx context.Context, client *http.Client, apiURL string) (*ExternalUser, error) {
+	var user struct {
+		Subject       string `json:"sub"`
+		Name          string `json:"name"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := getJSON(ctx, client, apiURL, &user); err != nil {
+		return nil, err
+	}
+
+	login, _, _ := strings.Cut(user.Email, "@")
+
+	return &ExternalUser{
+		ID:            user.Subject,
+		Login:         login,
+		DisplayName:   user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+	}, nil
+}
+
+func fetchBitbucketUser(ctx context.Context, client *http.Client, apiURL string) (*ExternalUser, error) {
+	var user struct {
+		UUID        string `json:"uuid"`
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name"`
+	}
+	if err := getJSON(ctx, client, apiURL+"/user", &user); err != nil {
+		return nil, err
+	}
+
+	var emails struct {
+		Values []struct {
+			Email       string `json:"email"`
+			IsPrimary   bool   `json:"is_primary"`
+			IsConfirmed bool   `json:"is_confirmed"`
+		} `json:"values"`
+	}
+	if err := getJSON(ctx, client, apiURL+"/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	out := &ExternalUser{
+		ID:          user.UUID,
+		Login:       user.Username,
+		DisplayName: user.DisplayName,
+	}
+	for _, email := range emails.Values {
+		if email.IsPrimary {
+			out.Email = email.Email
+			out.EmailVerified = email.IsConfirmed
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed with status %d", url, resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", url, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func newUserAPIServer(t *testing.T, responses map[string]any) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestGetEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType enum.OAuthProviderType
+		baseURL      string
+		wantAuthURL  string
+		wantAPIURL   string
+	}{
+		{
+			name:         "github",
+			providerType: enum.OAuthProviderTypeGitHub,
+			wantAuthURL:  "https://github.com/login/oauth/authorize",
+			wantAPIURL:   "https://api.github.com",
+		},
+		{
+			name:         "github enterprise",
+			providerType: enum.OAuthProviderTypeGitHub,
+			baseURL:      "https://github.example.com/",
+			wantAuthURL:  "https://github.example.com/login/oauth/authorize",
+			wantAPIURL:   "https://github.example.com/api/v3",
+		},
+		{
+			name:         "self-hosted gitlab",
+			providerType: enum.OAuthProviderTypeGitLab,
+			baseURL:      "https://gitlab.example.com",
+			wantAuthURL:  "https://gitlab.example.com/oauth/authorize",
+			wantAPIURL:   "https://gitlab.example.com/api/v4",
+		},
+		{
+			name:         "bitbucket",
+			providerType: enum.OAuthProviderTypeBitbucket,
+			wantAuthURL:  "https://bitbucket.org/site/oauth2/authorize",
+			wantAPIURL:   "https://api.bitbucket.org/2.0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ep, err := getEndpoint(test.providerType, test.baseURL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ep.oauth.AuthURL != test.wantAuthURL {
+				t.Errorf("auth url: got %q, want %q", ep.oauth.AuthURL, test.wantAuthURL)
+			}
+
+			if ep.apiURL != test.wantAPIURL {
+				t.Errorf("api url: got %q, want %q", ep.apiURL, test.wantAPIURL)
+			}
+		})
+	}
+
+	if _, err := getEndpoint("unknown", ""); err == nil {
+		t.Error("expected error for unknown provider type")
+	}
+}
+
+func TestFetchUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		userFunc  func(ctx context.Context, client *http.Client, apiURL string) (*ExternalUser, error)
+		responses map[string]any
+		want      ExternalUser
+	}{
+		{
+			name:     "github uses the primary email",
+			userFunc: fetchGitHubUser,
+			responses: map[string]any{
+				"/user": map[string]any{"id": 42, "login": "octocat", "name": "The Octocat"},
+				"/user/emails": []map[string]any{
+					{"email": "other@example.com", "primary": false, "verified": true},
+					{"email": "octocat@example.com", "primary": true, "verified": true},
+				},
+			},
+			want: ExternalUser{
+				ID:            "42",
+				Login:         "octocat",
+				DisplayName:   "The Octocat",
+				Email:         "octocat@example.com",
+				EmailVerified: true,
+			},
+		},
+		{
+			name:     "gitlab without confirmation",
+			userFunc: fetchGitLabUser,
+			responses: map[string]any{
+				"/user": map[string]any{"id": 7, "username": "jdoe", "name": "J Doe", "email": "jdoe@example.com"},
+			},
+			want: ExternalUser{
+				ID:          "7",
+				Login:       "jdoe",
+				DisplayName: "J Doe",
+				Email:       "jdoe@example.com",
+			},
+		},
+		{
+			name:     "google",
+			userFunc: fetchGoogleUser,
+			responses: map[string]any{
+				"/": map[string]any{"sub": "1234", "name": "J Doe", "email": "jdoe@example.com", "email_verified": true},
+			},
+			want: ExternalUser{
+				ID:            "1234",
+				Login:         "jdoe",
+				DisplayName:   "J Doe",
+				Email:         "jdoe@example.com",
+				EmailVerified: true,
+			},
+		},
+		{
+			name:     "bitbucket with unconfirmed primary email",
+			userFunc: fetchBitbucketUser,
+			responses: map[string]any{
+				"/user": map[string]any{"uuid": "{abc}", "username": "jdoe", "display_name": "J Doe"},
+				"/user/emails": map[string]any{"values": []map[string]any{
+					{"email": "jdoe@example.com", "is_primary": true, "is_confirmed": false},
+				}},
+			},
+			want: ExternalUser{
+				ID:          "{abc}",
+				Login:       "jdoe",
+				DisplayName: "J Doe",
+				Email:       "jdoe@example.com",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newUserAPIServer(t, test.responses)
+
+			apiURL := srv.URL
+			if test.responses["/"] != nil {
+				apiURL += "/"
+			}
+
+			got, err := test.userFunc(context.Background(), srv.Client(), apiURL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if *got != test.want {
+				t.Errorf("got %+v, want %+v", *got, test.want)
+			}
+		})
+	}
+}
+
+func TestFetchUserFailure(t *testing.T) {
+	srv := newUserAPIServer(t, map[string]any{})
+
+	if _, err := fetchGitHubUser(context.Background(), srv.Client(), srv.URL); err == nil {
+		t.Error("expected error for failed request")
+	}
+}
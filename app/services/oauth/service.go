@@ -0,0 +1,497 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	maxClientIDLength     = 256
+	maxClientSecretLength = 1024
+	httpTimeout           = 30 * time.Second
+	stateLength           = 32
+)
+
+// ProviderCreateInput is the input for adding an OAuth provider.
+type ProviderCreateInput struct {
+	Identifier   string                 `json:"identifier"`
+	Type         enum.OAuthProviderType `json:"type"`
+	DisplayName  string                 `json:"display_name"`
+	ClientID     string                 `json:"client_id"`
+	ClientSecret string                 `json:"client_secret"`
+	BaseURL      string                 `json:"base_url"`
+	Enabled      bool                   `json:"enabled"`
+	AllowSignup  bool                   `json:"allow_signup"`
+
+	// TrustSecondFactor is off by default, it should only be set if the provider enforces MFA for all accounts.
+	TrustSecondFactor bool `json:"trust_second_factor"`
+}
+
+// ProviderUpdateInput is the input for updating an OAuth provider.
+type ProviderUpdateInput struct {
+	DisplayName  *string `json:"display_name"`
+	ClientID     *string `json:"client_id"`
+	ClientSecret *string `json:"client_secret"`
+	BaseURL      *string `json:"base_url"`
+	Enabled      *bool   `json:"enabled"`
+	AllowSignup  *bool   `json:"allow_signup"`
+
+	TrustSecondFactor *bool `json:"trust_second_factor"`
+}
+
+// Service manages the OAuth providers users can login with and the identities linked through them.
+type Service struct {
+	providerStore store.OAuthProviderStore
+	identityStore store.OAuthIdentityStore
+	urlProvider   urlprovider.Provider
+	encrypter     encrypt.Encrypter
+	httpClient    *http.Client
+}
+
+func NewService(
+	providerStore store.OAuthProviderStore,
+	identityStore store.OAuthIdentityStore,
+	urlProvider urlprovider.Provider,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return &Service{
+		providerStore: providerStore,
+		identityStore: identityStore,
+		urlProvider:   urlProvider,
+		encrypter:     encrypter,
+		httpClient:    &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// ListProviders returns all OAuth providers.
+func (s *Service) ListProviders(ctx context.Context) ([]*types.OAuthProvider, error) {
+	providers, err := s.providerStore.List(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth providers: %w", err)
+	}
+
+	return providers, nil
+}
+
+// ListEnabledProviders returns the public information of the providers users can login with.
+func (s *Service) ListEnabledProviders(ctx context.Context) ([]types.OAuthProviderInfo, error) {
+	providers, err := s.providerStore.List(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enabled oauth providers: %w", err)
+	}
+
+	result := make([]types.OAuthProviderInfo, len(providers))
+	for i, provider := range providers {
+		result[i] = types.OAuthProviderInfo{
+			Identifier:  provider.Identifier,
+			Type:        provider.Type,
+			DisplayName: provider.DisplayName,
+		}
+	}
+
+	return result, nil
+}
+
+// FindProvider returns an OAuth provider.
+func (s *Service) FindProvider(ctx context.Context, identifier string) (*types.OAuthProvider, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// CreateProvider adds an OAuth provider.
+func (s *Service) CreateProvider(ctx context.Context, in *ProviderCreateInput) (*types.OAuthProvider, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.encrypter.Encrypt(in.ClientSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt client secret: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	provider := &types.OAuthProvider{
+		Identifier:   in.Identifier,
+		Type:         in.Type,
+		DisplayName:  in.DisplayName,
+		ClientID:     in.ClientID,
+		ClientSecret: secret,
+		BaseURL:      in.BaseURL,
+		Enabled:      in.Enabled,
+		AllowSignup:  in.AllowSignup,
+		Created:      now,
+		Updated:      now,
+
+		TrustSecondFactor: in.TrustSecondFactor,
+	}
+
+	err = s.providerStore.Create(ctx, provider)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("An OAuth provider with identifier '%s' already exists.", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// UpdateProvider updates an OAuth provider.
+func (s *Service) UpdateProvider(
+	ctx context.Context,
+	identifier string,
+	in *ProviderUpdateInput,
+) (*types.OAuthProvider, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth provider: %w", err)
+	}
+
+	if err = in.sanitize(provider.Type); err != nil {
+		return nil, err
+	}
+
+	if in.DisplayName != nil {
+		provider.DisplayName = *in.DisplayName
+	}
+	if in.ClientID != nil {
+		provider.ClientID = *in.ClientID
+	}
+	if in.ClientSecret != nil {
+		provider.ClientSecret, err = s.encrypter.Encrypt(*in.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt client secret: %w", err)
+		}
+	}
+	if in.BaseURL != nil {
+		provider.BaseURL = *in.BaseURL
+	}
+	if in.Enabled != nil {
+		provider.Enabled = *in.Enabled
+	}
+	if in.AllowSignup != nil {
+		provider.AllowSignup = *in.AllowSignup
+	}
+	if in.TrustSecondFactor != nil {
+		provider.TrustSecondFactor = *in.TrustSecondFactor
+	}
+
+	provider.Updated = time.Now().UnixMilli()
+
+	if err = s.providerStore.Update(ctx, provider); err != nil {
+		return nil, fmt.Errorf("failed to update oauth provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// DeleteProvider removes an OAuth provider and unlinks all identities of it.
+func (s *Service) DeleteProvider(ctx context.Context, identifier string) error {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find oauth provider: %w", err)
+	}
+
+	if err = s.providerStore.Delete(ctx, provider.ID); err != nil {
+		return fmt.Errorf("failed to delete oauth provider: %w", err)
+	}
+
+	return nil
+}
+
+// AuthRedirect is the redirect of the user to the consent page of the provider.
+// The state and the PKCE verifier have to be kept by the client until the provider redirects back.
+type AuthRedirect struct {
+	URL      string
+	State    string
+	Verifier string
+}
+
+// AuthCodeURL returns the redirect of the user to the consent page of the provider.
+func (s *Service) AuthCodeURL(ctx context.Context, identifier string) (*AuthRedirect, error) {
+	provider, err := s.findEnabledProvider(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := s.oauthConfig(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	state := make([]byte, stateLength)
+	if _, err = rand.Read(state); err != nil {
+		return nil, fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+
+	redirect := &AuthRedirect{
+		State:    base64.RawURLEncoding.EncodeToString(state),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+	redirect.URL = config.AuthCodeURL(redirect.State, oauth2.S256ChallengeOption(redirect.Verifier))
+
+	return redirect, nil
+}
+
+// Exchange exchanges the authorization code for a token and returns the account of the user with the provider.
+func (s *Service) Exchange(
+	ctx context.Context,
+	identifier string,
+	code string,
+	verifier string,
+) (*types.OAuthProvider, *ExternalUser, error) {
+	provider, err := s.findEnabledProvider(ctx, identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, ep, err := s.oauthConfig(ctx, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, s.httpClient)
+
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, nil, errors.Format(errors.StatusUnauthorized,
+			"Failed to authorize with %s.", provider.DisplayName).SetErr(err)
+	}
+
+	user, err := ep.userFunc(ctx, config.Client(ctx, token), ep.apiURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch user from oauth provider %q: %w", provider.Identifier, err)
+	}
+
+	if user.ID == "" {
+		return nil, nil, fmt.Errorf("oauth provider %q returned a user without id", provider.Identifier)
+	}
+
+	return provider, user, nil
+}
+
+// FindIdentity returns the identity linked to the account of the user with the provider.
+// The login and the email of the identity are updated in case they changed.
+func (s *Service) FindIdentity(
+	ctx context.Context,
+	provider *types.OAuthProvider,
+	user *ExternalUser,
+) (*types.OAuthIdentity, error) {
+	identity, err := s.identityStore.FindByExternalID(ctx, provider.ID, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth identity: %w", err)
+	}
+
+	if identity.ExternalLogin == user.Login && identity.Email == user.Email {
+		return identity, nil
+	}
+
+	identity.ExternalLogin = user.Login
+	identity.Email = user.Email
+	identity.Updated = time.Now().UnixMilli()
+
+	if err = s.identityStore.Update(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to update oauth identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// Link links the account of the user with the provider to the principal.
+func (s *Service) Link(
+	ctx context.Context,
+	principalID int64,
+	provider *types.OAuthProvider,
+	user *ExternalUser,
+) (*types.OAuthIdentity, error) {
+	now := time.Now().UnixMilli()
+	identity := &types.OAuthIdentity{
+		PrincipalID:   principalID,
+		ProviderID:    provider.ID,
+		ExternalID:    user.ID,
+		ExternalLogin: user.Login,
+		Email:         user.Email,
+		Created:       now,
+		Updated:       now,
+	}
+
+	if err := s.identityStore.Create(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to create oauth identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (s *Service) findEnabledProvider(ctx context.Context, identifier string) (*types.OAuthProvider, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("OAuth provider '%s' not found.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth provider: %w", err)
+	}
+
+	if !provider.Enabled {
+		return nil, errors.NotFound("OAuth provider '%s' not found.", identifier)
+	}
+
+	return provider, nil
+}
+
+func (s *Service) oauthConfig(
+	ctx context.Context,
+	provider *types.OAuthProvider,
+) (*oauth2.Config, endpoint, error) {
+	ep, err := getEndpoint(provider.Type, provider.BaseURL)
+	if err != nil {
+		return nil, endpoint{}, err
+	}
+
+	secret, err := s.encrypter.Decrypt(provider.ClientSecret)
+	if err != nil {
+		return nil, endpoint{}, fmt.Errorf("failed to decrypt client secret: %w", err)
+	}
+
+	return &oauth2.Config{
+		ClientID:     provider.ClientID,
+		ClientSecret: secret,
+		Endpoint:     ep.oauth,
+		RedirectURL:  s.urlProvider.GenerateOAuthCallbackURL(ctx, provider.Identifier),
+		Scopes:       ep.scopes,
+	}, ep, nil
+}
+
+func (in *ProviderCreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+	in.ClientID = strings.TrimSpace(in.ClientID)
+	in.BaseURL = strings.TrimRight(strings.TrimSpace(in.BaseURL), "/")
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	providerType, ok := in.Type.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("Unknown OAuth provider type '%s'.", in.Type)
+	}
+	in.Type = providerType
+
+	if in.DisplayName == "" {
+		in.DisplayName = in.Identifier
+	}
+
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+
+	if err := sanitizeClientID(in.ClientID); err != nil {
+		return err
+	}
+
+	if err := sanitizeClientSecret(in.ClientSecret); err != nil {
+		return err
+	}
+
+	return sanitizeBaseURL(in.Type, in.BaseURL)
+}
+
+func (in *ProviderUpdateInput) sanitize(providerType enum.OAuthProviderType) error {
+	if in.DisplayName != nil {
+		*in.DisplayName = strings.TrimSpace(*in.DisplayName)
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	if in.ClientID != nil {
+		*in.ClientID = strings.TrimSpace(*in.ClientID)
+		if err := sanitizeClientID(*in.ClientID); err != nil {
+			return err
+		}
+	}
+
+	if in.ClientSecret != nil {
+		if err := sanitizeClientSecret(*in.ClientSecret); err != nil {
+			return err
+		}
+	}
+
+	if in.BaseURL != nil {
+		*in.BaseURL = strings.TrimRight(strings.TrimSpace(*in.BaseURL), "/")
+		if err := sanitizeBaseURL(providerType, *in.BaseURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sanitizeClientID(clientID string) error {
+	if clientID == "" || len(clientID) > maxClientIDLength {
+		return errors.InvalidArgument("Client ID must be between 1 and %d characters long.", maxClientIDLength)
+	}
+
+	return nil
+}
+
+func sanitizeClientSecret(clientSecret string) error {
+	if clientSecret == "" || len(clientSecret) > maxClientSecretLength {
+		return errors.InvalidArgument("Client secret must be between 1 and %d characters long.",
+			maxClientSecretLength)
+	}
+
+	return nil
+}
+
+func sanitizeBaseURL(providerType enum.OAuthProviderType, baseURL string) error {
+	if baseURL == "" {
+		return nil
+	}
+
+	if !supportsBaseURL(providerType) {
+		return errors.InvalidArgument("OAuth providers of type '%s' don't support a base URL.", providerType)
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.InvalidArgument("Base URL must be an absolute http or https URL.")
+	}
+
+	return nil
+}
@@ -0,0 +1,451 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/auth/webauthn"
+	"github.com/harness/gitness/app/services/twofactor"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultCredentialName   = "Passkey"
+	maxCredentialNameLength = 64
+
+	sessionIDSize = 24
+)
+
+var errAuthenticationFailed = errors.Format(errors.StatusUnauthorized, "WebAuthn authentication failed")
+
+// RegistrationChallenge is returned when a registration is started, the options are passed to
+// navigator.credentials.create and the session ID is sent back along with the created credential.
+type RegistrationChallenge struct {
+	SessionID string                    `json:"session_id"`
+	Options   *webauthn.CreationOptions `json:"options"`
+}
+
+// LoginChallenge is returned when an authentication is started, the options are passed to
+// navigator.credentials.get and the session ID is sent back along with the assertion.
+type LoginChallenge struct {
+	SessionID string                   `json:"session_id"`
+	Options   *webauthn.RequestOptions `json:"options"`
+}
+
+// RegistrationInput finishes a registration.
+type RegistrationInput struct {
+	SessionID  string                        `json:"session_id"`
+	Name       string                        `json:"name"`
+	Credential webauthn.RegistrationResponse `json:"credential"`
+}
+
+// AssertionInput finishes an authentication.
+type AssertionInput struct {
+	SessionID  string                     `json:"session_id"`
+	Credential webauthn.AssertionResponse `json:"credential"`
+}
+
+// Service manages the WebAuthn credentials (passkeys and security keys) of users
+// and runs the registration and authentication ceremonies.
+type Service struct {
+	credentialStore  store.WebAuthnCredentialStore
+	sessionStore     store.WebAuthnSessionStore
+	twoFactorService *twofactor.Service
+	config           webauthn.Config
+}
+
+func NewService(
+	credentialStore store.WebAuthnCredentialStore,
+	sessionStore store.WebAuthnSessionStore,
+	twoFactorService *twofactor.Service,
+	config webauthn.Config,
+) *Service {
+	return &Service{
+		credentialStore:  credentialStore,
+		sessionStore:     sessionStore,
+		twoFactorService: twoFactorService,
+		config:           config,
+	}
+}
+
+// List returns the WebAuthn credentials of the user.
+func (s *Service) List(ctx context.Context, principalID int64) ([]*types.WebAuthnCredential, error) {
+	credentials, err := s.credentialStore.List(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// BeginRegistration starts the registration of a new credential for the user.
+func (s *Service) BeginRegistration(ctx context.Context, user *types.User) (*RegistrationChallenge, error) {
+	credentials, err := s.credentialStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+
+	session, err := s.createSession(ctx, user.ID, enum.WebAuthnSessionTypeRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	name := user.Email
+	if name == "" {
+		name = user.UID
+	}
+
+	options := s.config.CreationOptions(
+		session.Challenge,
+		webauthn.UserEntity{
+			ID:          userHandle(user.ID),
+			Name:        name,
+			DisplayName: user.DisplayName,
+		},
+		descriptors(credentials),
+	)
+
+	return &RegistrationChallenge{SessionID: session.ID, Options: options}, nil
+}
+
+// FinishRegistration verifies the created credential and stores it.
+func (s *Service) FinishRegistration(
+	ctx context.Context,
+	user *types.User,
+	in *RegistrationInput,
+) (*types.WebAuthnCredential, error) {
+	name, err := sanitizeName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.consumeSession(ctx, in.SessionID, enum.WebAuthnSessionTypeRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.PrincipalID != user.ID {
+		return nil, errors.InvalidArgument("WebAuthn registration session not found or expired.")
+	}
+
+	verified, err := s.config.VerifyRegistration(session.Challenge, &in.Credential)
+	if errors.Is(err, webauthn.ErrVerificationFailed) {
+		return nil, errors.InvalidArgument("WebAuthn registration failed: %s", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify webauthn registration: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	credential := &types.WebAuthnCredential{
+		PrincipalID:  user.ID,
+		CredentialID: verified.ID,
+		Name:         name,
+		PublicKey:    verified.PublicKey,
+		SignCount:    verified.SignCount,
+		AAGUID:       verified.AAGUID,
+		Transports:   in.Credential.Response.Transports,
+		Created:      now,
+		Updated:      now,
+	}
+
+	err = s.credentialStore.Create(ctx, credential)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("The credential is already registered.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store webauthn credential: %w", err)
+	}
+
+	return credential, nil
+}
+
+// Rename changes the name of a credential of the user.
+func (s *Service) Rename(
+	ctx context.Context,
+	principalID int64,
+	id int64,
+	in *types.WebAuthnCredentialUpdateInput,
+) (*types.WebAuthnCredential, error) {
+	credential, err := s.find(ctx, principalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Name == nil {
+		return credential, nil
+	}
+
+	name, err := sanitizeName(*in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.Name = name
+	credential.Updated = time.Now().UnixMilli()
+
+	if err = s.credentialStore.UpdateName(ctx, credential); err != nil {
+		return nil, fmt.Errorf("failed to rename webauthn credential: %w", err)
+	}
+
+	return credential, nil
+}
+
+// Revoke deletes a credential of the user.
+// The last second factor of a user that is required to use two-factor authentication can't be removed.
+func (s *Service) Revoke(ctx context.Context, principalID int64, id int64) error {
+	credential, err := s.find(ctx, principalID, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.twoFactorService.CheckWebAuthnRemoval(ctx, principalID); err != nil {
+		return err
+	}
+
+	if err = s.credentialStore.Delete(ctx, credential.ID); err != nil {
+		return fmt.Errorf("failed to delete webauthn credential: %w", err)
+	}
+
+	return nil
+}
+
+// BeginLogin starts an authentication. If the user is known, the allowed credentials are limited
+// to the ones of the user, otherwise the authenticator offers its discoverable credentials.
+// User verification is required for passwordless logins.
+func (s *Service) BeginLogin(
+	ctx context.Context,
+	user *types.User,
+	passwordless bool,
+) (*LoginChallenge, error) {
+	var principalID int64
+	var allow []webauthn.CredentialDescriptor
+
+	if user != nil {
+		credentials, err := s.credentialStore.List(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+		}
+
+		principalID = user.ID
+		allow = descriptors(credentials)
+	}
+
+	session, err := s.createSession(ctx, principalID, enum.WebAuthnSessionTypeAuthentication)
+	if err != nil {
+		return nil, err
+	}
+
+	options := s.config.RequestOptions(session.Challenge, allow, passwordless)
+
+	return &LoginChallenge{SessionID: session.ID, Options: options}, nil
+}
+
+// FinishLogin verifies the assertion and returns the credential that was used.
+// For passwordless logins the authenticator must have verified the user (e.g. PIN or biometrics),
+// which makes the credential a multi-factor credential on its own.
+func (s *Service) FinishLogin(
+	ctx context.Context,
+	in *AssertionInput,
+	passwordless bool,
+) (*types.WebAuthnCredential, error) {
+	session, err := s.consumeSession(ctx, in.SessionID, enum.WebAuthnSessionTypeAuthentication)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errAuthenticationFailed
+	}
+
+	credential, err := s.credentialStore.FindByCredentialID(ctx, in.Credential.RawID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errAuthenticationFailed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webauthn credential: %w", err)
+	}
+
+	if session.PrincipalID != 0 && session.PrincipalID != credential.PrincipalID {
+		return nil, errAuthenticationFailed
+	}
+
+	if handle := in.Credential.Response.UserHandle; len(handle) > 0 &&
+		string(handle) != string(userHandle(credential.PrincipalID)) {
+		return nil, errAuthenticationFailed
+	}
+
+	assertion, err := s.config.VerifyAssertion(
+		session.Challenge,
+		&in.Credential,
+		credential.PublicKey,
+		credential.SignCount,
+		passwordless,
+	)
+	if errors.Is(err, webauthn.ErrVerificationFailed) {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("principal_id", credential.PrincipalID).
+			Int64("webauthn_credential_id", credential.ID).
+			Msg("webauthn assertion rejected")
+		return nil, errAuthenticationFailed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify webauthn assertion: %w", err)
+	}
+
+	err = s.credentialStore.UpdateSignCount(ctx, credential, assertion.SignCount)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		// another authentication with the credential was accepted concurrently.
+		return nil, errAuthenticationFailed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webauthn credential sign count: %w", err)
+	}
+
+	return credential, nil
+}
+
+// VerifySecondFactor verifies the assertion as second factor of the user.
+func (s *Service) VerifySecondFactor(ctx context.Context, principalID int64, in *AssertionInput) error {
+	credential, err := s.FinishLogin(ctx, in, false)
+	if err != nil {
+		return err
+	}
+
+	if credential.PrincipalID != principalID {
+		return errAuthenticationFailed
+	}
+
+	return nil
+}
+
+func (s *Service) find(ctx context.Context, principalID int64, id int64) (*types.WebAuthnCredential, error) {
+	credential, err := s.credentialStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webauthn credential: %w", err)
+	}
+
+	if credential.PrincipalID != principalID {
+		return nil, errors.NotFound("WebAuthn credential not found.")
+	}
+
+	return credential, nil
+}
+
+func (s *Service) createSession(
+	ctx context.Context,
+	principalID int64,
+	typ enum.WebAuthnSessionType,
+) (*types.WebAuthnSession, error) {
+	now := time.Now()
+
+	// sessions of abandoned ceremonies are cleaned up whenever a new one is started.
+	if err := s.sessionStore.DeleteExpired(ctx, now.UnixMilli()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete expired webauthn sessions")
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, sessionIDSize)
+	if _, err = rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate webauthn session id: %w", err)
+	}
+
+	session := &types.WebAuthnSession{
+		ID:          base64.RawURLEncoding.EncodeToString(id),
+		PrincipalID: principalID,
+		Type:        typ,
+		Challenge:   challenge,
+		Created:     now.UnixMilli(),
+		Expires:     now.Add(s.config.Timeout).UnixMilli(),
+	}
+
+	if err = s.sessionStore.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to store webauthn session: %w", err)
+	}
+
+	return session, nil
+}
+
+// consumeSession returns the session if it exists, has the expected type and isn't expired.
+func (s *Service) consumeSession(
+	ctx context.Context,
+	id string,
+	typ enum.WebAuthnSessionType,
+) (*types.WebAuthnSession, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	session, err := s.sessionStore.Consume(ctx, id)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume webauthn session: %w", err)
+	}
+
+	if session.Type != typ || session.Expires < time.Now().UnixMilli() {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+// userHandle returns the WebAuthn user handle of a user, which is the big-endian principal ID.
+func userHandle(principalID int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(principalID))
+}
+
+func descriptors(credentials []*types.WebAuthnCredential) []webauthn.CredentialDescriptor {
+	out := make([]webauthn.CredentialDescriptor, len(credentials))
+	for i, credential := range credentials {
+		out[i] = webauthn.CredentialDescriptor{
+			Type:       "public-key",
+			ID:         credential.CredentialID,
+			Transports: credential.Transports,
+		}
+	}
+
+	return out
+}
+
+func sanitizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return defaultCredentialName, nil
+	}
+
+	if utf8.RuneCountInString(name) > maxCredentialNameLength {
+		return "", errors.InvalidArgument("Credential name can be at most %d characters long.", maxCredentialNameLength)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelineartifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	jobType = "pipeline-artifact-retention"
+
+	// retentionBatchSize defines the number of expired artifacts deleted at once by the job.
+	retentionBatchSize = 100
+
+	blobPathFmt = "pipeline-artifacts/%d/%d/%s"
+
+	maxNameLength = 1024
+)
+
+// ErrDisabled is returned if pipeline artifacts are disabled.
+var ErrDisabled = usererror.BadRequest("Pipeline artifacts are disabled.")
+
+// Service manages the artifacts uploaded by the steps of pipeline executions.
+//
+// The files are stored in the blob store of the repository.
+// Artifacts older than the retention period are deleted by a recurring job.
+type Service struct {
+	enabled   bool
+	maxSize   int64
+	retention time.Duration
+	cron      string
+	maxDur    time.Duration
+
+	artifactStore store.PipelineArtifactStore
+	repoStore     store.RepoStore
+	blobStore     *blob.PoolStore
+	scheduler     *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	artifactStore store.PipelineArtifactStore,
+	repoStore store.RepoStore,
+	blobStore *blob.PoolStore,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:       config.PipelineArtifact.Enabled,
+		maxSize:       config.PipelineArtifact.MaxSize,
+		retention:     config.PipelineArtifact.Retention,
+		cron:          config.PipelineArtifact.CRON,
+		maxDur:        config.PipelineArtifact.MaxDuration,
+		artifactStore: artifactStore,
+		repoStore:     repoStore,
+		blobStore:     blobStore,
+		scheduler:     scheduler,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled || s.retention <= 0 {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline artifact retention: %w", err)
+	}
+
+	return nil
+}
+
+// SanitizeName validates the name of an artifact and returns it in its canonical form.
+// Names are relative slash separated paths, e.g. "dist/app.tar.gz".
+func SanitizeName(name string) (string, error) {
+	name = strings.TrimPrefix(name, "/")
+	cleaned := path.Clean(name)
+	if name == "" || len(name) > maxNameLength || cleaned == "." ||
+		cleaned == ".." || strings.HasPrefix(cleaned, "../") || strings.ContainsAny(name, "\\\x00") {
+		return "", usererror.BadRequestf(
+			"Artifact name must be a relative path of at most %d characters.", maxNameLength)
+	}
+	return cleaned, nil
+}
+
+// List lists all artifacts of the execution.
+func (s *Service) List(ctx context.Context, execution *types.Execution) ([]*types.PipelineArtifact, error) {
+	artifacts, err := s.artifactStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// Upload stores the file as the artifact of the execution, replacing the existing artifact with the same name.
+func (s *Service) Upload(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	name string,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	if !s.enabled {
+		return nil, ErrDisabled
+	}
+
+	now := time.Now().UnixMilli()
+	blobPath := fmt.Sprintf(blobPathFmt, repo.ID, execution.ID, name)
+
+	reader := blob.NewCountingReader(file, s.maxSize)
+	if err := s.blobStore.Get(repo.StoragePool).Upload(ctx, reader, blobPath); err != nil {
+		if reader.Exceeded() {
+			return nil, usererror.BadRequestf("Pipeline artifact exceeds the maximum size of %d bytes.", s.maxSize)
+		}
+		return nil, fmt.Errorf("failed to upload pipeline artifact: %w", err)
+	}
+
+	artifact := &types.PipelineArtifact{
+		RepoID:      repo.ID,
+		ExecutionID: execution.ID,
+		Name:        name,
+		BlobPath:    blobPath,
+		Size:        reader.N(),
+		Created:     now,
+		Updated:     now,
+	}
+	if err := s.artifactStore.Upsert(ctx, artifact); err != nil {
+		return nil, fmt.Errorf("failed to save pipeline artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// Download returns the artifact of the execution along with either a signed url of the file,
+// or the file itself if the blob store doesn't support signed urls.
+// The caller is responsible for closing the file.
+func (s *Service) Download(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	name string,
+) (*types.PipelineArtifact, string, io.ReadCloser, error) {
+	artifact, err := s.artifactStore.Find(ctx, execution.ID, name)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find pipeline artifact: %w", err)
+	}
+
+	blobStore := s.blobStore.Get(repo.StoragePool)
+
+	signedURL, err := blobStore.GetSignedURL(ctx, artifact.BlobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return artifact, signedURL, nil, nil
+	}
+
+	file, err := blobStore.Download(ctx, artifact.BlobPath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, "", nil, usererror.NotFound("Pipeline artifact file not found")
+	}
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download pipeline artifact from blobstore: %w", err)
+	}
+
+	return artifact, "", file, nil
+}
+
+// Delete deletes the artifact of the execution.
+func (s *Service) Delete(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	name string,
+) error {
+	artifact, err := s.artifactStore.Find(ctx, execution.ID, name)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline artifact: %w", err)
+	}
+
+	return s.delete(ctx, repo, artifact)
+}
+
+// DeleteAll deletes all artifacts of the execution, it's used before the execution gets deleted.
+func (s *Service) DeleteAll(ctx context.Context, repo *types.Repository, execution *types.Execution) error {
+	artifacts, err := s.artifactStore.List(ctx, execution.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pipeline artifacts: %w", err)
+	}
+
+	for _, artifact := range artifacts {
+		if err := s.delete(ctx, repo, artifact); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handle deletes the artifacts older than the retention period.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled || s.retention <= 0 {
+		return "", nil
+	}
+
+	before := time.Now().Add(-s.retention).UnixMilli()
+	repos := map[int64]*types.Repository{}
+
+	var deleted int
+	for {
+		artifacts, err := s.artifactStore.ListCreatedBefore(ctx, before, retentionBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list expired pipeline artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			repo, ok := repos[artifact.RepoID]
+			if !ok {
+				repo, err = s.repoStore.Find(ctx, artifact.RepoID)
+				if err != nil {
+					return "", fmt.Errorf("failed to find repository of pipeline artifact: %w", err)
+				}
+				repos[artifact.RepoID] = repo
+			}
+
+			if err := s.delete(ctx, repo, artifact); err != nil {
+				return "", err
+			}
+			deleted++
+		}
+
+		if len(artifacts) < retentionBatchSize {
+			break
+		}
+	}
+
+	if deleted == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("deleted %d expired pipeline artifacts", deleted), nil
+}
+
+func (s *Service) delete(ctx context.Context, repo *types.Repository, artifact *types.PipelineArtifact) error {
+	err := s.blobStore.Get(repo.StoragePool).Delete(ctx, artifact.BlobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("failed to delete pipeline artifact file: %w", err)
+	}
+
+	if err := s.artifactStore.Delete(ctx, artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete pipeline artifact: %w", err)
+	}
+
+	return nil
+}
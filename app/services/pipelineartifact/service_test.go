@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelineartifact
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "report.xml", want: "report.xml"},
+		{name: "/dist/app.tar.gz", want: "dist/app.tar.gz"},
+		{name: "dist/./bin//app", want: "dist/bin/app"},
+		{name: "dist/../app", want: "app"},
+		{name: "", wantErr: true},
+		{name: "/", wantErr: true},
+		{name: ".", wantErr: true},
+		{name: "../app", wantErr: true},
+		{name: "dist/../../app", wantErr: true},
+		{name: "dist\\app", wantErr: true},
+		{name: strings.Repeat("a", maxNameLength+1), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SanitizeName(test.name)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pipeline-cache-eviction"
+
+	// evictionBatchSize defines the number of unused cache entries evicted at once by the job.
+	evictionBatchSize = 100
+
+	blobPathFmt = "pipeline-caches/%d/%s-%d"
+
+	maxKeyLength = 256
+)
+
+var (
+	keyRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+	// ErrDisabled is returned if the build cache is disabled.
+	ErrDisabled = usererror.BadRequest("The pipeline cache is disabled.")
+)
+
+// Service manages the build cache of the pipelines of repositories.
+//
+// The archives of the cache entries are stored in the blob store of the repository.
+// If a saved entry exceeds the quota of the repository, the least recently used entries are evicted.
+// Entries that weren't restored for the TTL are evicted by a recurring job.
+type Service struct {
+	enabled   bool
+	maxSize   int64
+	repoQuota int64
+	ttl       time.Duration
+	cron      string
+	maxDur    time.Duration
+
+	cacheStore store.PipelineCacheStore
+	repoStore  store.RepoStore
+	blobStore  *blob.PoolStore
+	scheduler  *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	cacheStore store.PipelineCacheStore,
+	repoStore store.RepoStore,
+	blobStore *blob.PoolStore,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:    config.PipelineCache.Enabled,
+		maxSize:    config.PipelineCache.MaxSize,
+		repoQuota:  config.PipelineCache.RepoQuota,
+		ttl:        config.PipelineCache.TTL,
+		cron:       config.PipelineCache.CRON,
+		maxDur:     config.PipelineCache.MaxDuration,
+		cacheStore: cacheStore,
+		repoStore:  repoStore,
+		blobStore:  blobStore,
+		scheduler:  scheduler,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled || s.ttl <= 0 {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline cache eviction: %w", err)
+	}
+
+	return nil
+}
+
+// Enabled returns true if the build cache is enabled.
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// ValidateKey checks whether the key of a cache entry is valid.
+func ValidateKey(key string) error {
+	if len(key) == 0 || len(key) > maxKeyLength || !keyRegex.MatchString(key) {
+		return usererror.BadRequestf(
+			"Cache key must be between 1 and %d characters long and consist of letters, digits, '.', '_' and '-'.",
+			maxKeyLength)
+	}
+	return nil
+}
+
+// Restore returns the archive of the cache entry of the repository and marks the entry as used.
+// The caller is responsible for closing the archive.
+func (s *Service) Restore(
+	ctx context.Context,
+	repo *types.Repository,
+	key string,
+) (*types.PipelineCache, io.ReadCloser, error) {
+	if !s.enabled {
+		return nil, nil, ErrDisabled
+	}
+
+	cache, err := s.cacheStore.Find(ctx, repo.ID, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline cache: %w", err)
+	}
+
+	archive, err := s.blobStore.Get(repo.StoragePool).Download(ctx, cache.BlobPath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, nil, usererror.NotFound("Pipeline cache archive not found")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download pipeline cache archive: %w", err)
+	}
+
+	cache.LastUsed = time.Now().UnixMilli()
+	if err := s.cacheStore.UpdateLastUsed(ctx, cache.ID, cache.LastUsed); err != nil {
+		_ = archive.Close()
+		return nil, nil, fmt.Errorf("failed to mark pipeline cache as used: %w", err)
+	}
+
+	return cache, archive, nil
+}
+
+// Save stores the archive as the cache entry of the repository, replacing the existing entry with the same key.
+// If the cache of the repository exceeds the quota afterwards, the least recently used entries are evicted.
+func (s *Service) Save(
+	ctx context.Context,
+	repo *types.Repository,
+	key string,
+	archive io.Reader,
+) (*types.PipelineCache, error) {
+	if !s.enabled {
+		return nil, ErrDisabled
+	}
+
+	existing, err := s.cacheStore.Find(ctx, repo.ID, key)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find pipeline cache: %w", err)
+	}
+
+	now := time.Now()
+	blobStore := s.blobStore.Get(repo.StoragePool)
+
+	// upload the archive to a new path, so the existing entry stays intact if the upload fails.
+	blobPath := fmt.Sprintf(blobPathFmt, repo.ID, key, now.UnixNano())
+	reader := blob.NewCountingReader(archive, s.maxSize)
+	if err := blobStore.Upload(ctx, reader, blobPath); err != nil {
+		if reader.Exceeded() {
+			return nil, usererror.BadRequestf("Pipeline cache exceeds the maximum size of %d bytes.", s.maxSize)
+		}
+		return nil, fmt.Errorf("failed to upload pipeline cache archive: %w", err)
+	}
+
+	cache := &types.PipelineCache{
+		RepoID:   repo.ID,
+		Key:      key,
+		BlobPath: blobPath,
+		Size:     reader.N(),
+		Created:  now.UnixMilli(),
+		Updated:  now.UnixMilli(),
+		LastUsed: now.UnixMilli(),
+	}
+	if err := s.cacheStore.Upsert(ctx, cache); err != nil {
+		_ = blobStore.Delete(ctx, blobPath)
+		return nil, fmt.Errorf("failed to save pipeline cache: %w", err)
+	}
+
+	if existing != nil {
+		if err := blobStore.Delete(ctx, existing.BlobPath); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to delete replaced pipeline cache archive")
+		}
+	}
+
+	if err := s.enforceQuota(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// Delete deletes the cache entry of the repository.
+func (s *Service) Delete(ctx context.Context, repo *types.Repository, key string) error {
+	cache, err := s.cacheStore.Find(ctx, repo.ID, key)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline cache: %w", err)
+	}
+
+	return s.delete(ctx, repo, cache)
+}
+
+// Usage returns all cache entries of the repository along with their total size.
+func (s *Service) Usage(ctx context.Context, repo *types.Repository) (*types.PipelineCacheUsage, error) {
+	entries, err := s.cacheStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline caches: %w", err)
+	}
+
+	usage := &types.PipelineCacheUsage{
+		Entries: entries,
+		Quota:   s.repoQuota,
+	}
+	for _, entry := range entries {
+		usage.Size += entry.Size
+	}
+
+	return usage, nil
+}
+
+// Handle evicts the cache entries that weren't restored for the TTL.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled || s.ttl <= 0 {
+		return "", nil
+	}
+
+	since := time.Now().Add(-s.ttl).UnixMilli()
+	repos := map[int64]*types.Repository{}
+
+	var evicted int
+	for {
+		entries, err := s.cacheStore.ListUnusedSince(ctx, since, evictionBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list unused pipeline caches: %w", err)
+		}
+
+		for _, entry := range entries {
+			repo, ok := repos[entry.RepoID]
+			if !ok {
+				repo, err = s.repoStore.Find(ctx, entry.RepoID)
+				if err != nil {
+					return "", fmt.Errorf("failed to find repository of pipeline cache: %w", err)
+				}
+				repos[entry.RepoID] = repo
+			}
+
+			if err := s.delete(ctx, repo, entry); err != nil {
+				return "", err
+			}
+			evicted++
+		}
+
+		if len(entries) < evictionBatchSize {
+			break
+		}
+	}
+
+	if evicted == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("evicted %d unused pipeline caches", evicted), nil
+}
+
+// enforceQuota evicts the least recently used cache entries of the repository
+// until the total size of its cache is within the quota.
+func (s *Service) enforceQuota(ctx context.Context, repo *types.Repository) error {
+	if s.repoQuota <= 0 {
+		return nil
+	}
+
+	entries, err := s.cacheStore.List(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pipeline caches: %w", err)
+	}
+
+	var size int64
+	for _, entry := range entries {
+		size += entry.Size
+	}
+
+	// entries are ordered by last use, the most recently used (the saved entry) is never evicted.
+	for i := len(entries) - 1; i > 0 && size > s.repoQuota; i-- {
+		if err := s.delete(ctx, repo, entries[i]); err != nil {
+			return err
+		}
+		size -= entries[i].Size
+	}
+
+	return nil
+}
+
+func (s *Service) delete(ctx context.Context, repo *types.Repository, cache *types.PipelineCache) error {
+	if err := s.blobStore.Get(repo.StoragePool).Delete(ctx, cache.BlobPath); err != nil {
+		return fmt.Errorf("failed to delete pipeline cache archive: %w", err)
+	}
+
+	if err := s.cacheStore.Delete(ctx, cache.ID); err != nil {
+		return fmt.Errorf("failed to delete pipeline cache: %w", err)
+	}
+
+	return nil
+}
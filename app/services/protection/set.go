@@ -104,6 +104,29 @@ func (s ruleSet) RequiredChecks(
 	}, nil
 }
 
+func (s ruleSet) StaleApprovals(
+	ctx context.Context,
+	in StaleApprovalsInput,
+) (StaleApprovalsOutput, error) {
+	var out StaleApprovalsOutput
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
+		func(_ *types.RuleInfoInternal, p Protection) error {
+			rOut, err := p.StaleApprovals(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			out.Dismiss = out.Dismiss || rOut.Dismiss
+
+			return nil
+		})
+	if err != nil {
+		return StaleApprovalsOutput{}, fmt.Errorf("failed to process stale approvals: %w", err)
+	}
+
+	return out, nil
+}
+
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
@@ -462,6 +462,103 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 	}
 }
 
+func TestRuleSet_StaleApprovals(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  []types.RuleInfoInternal
+		input  StaleApprovalsInput
+		expOut StaleApprovalsOutput
+	}{
+		{
+			name:  "empty",
+			rules: []types.RuleInfoInternal{},
+			input: StaleApprovalsInput{
+				Repo:    &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq: &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+			},
+			expOut: StaleApprovalsOutput{Dismiss: false},
+		},
+		{
+			name: "one-rule-matches",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo: types.RuleInfo{
+						RepoPath:   "space/repo",
+						ID:         1,
+						Identifier: "rule1",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"pullreq":{"approvals":{"require_minimum_count":1}}}`),
+				},
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "space",
+						ID:         2,
+						Identifier: "rule2",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"pullreq":{"approvals":{"dismiss_stale_approvals":true}}}`),
+				},
+			},
+			input: StaleApprovalsInput{
+				Repo:    &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq: &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+			},
+			expOut: StaleApprovalsOutput{Dismiss: true},
+		},
+		{
+			name: "rule-does-not-match-branch",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo: types.RuleInfo{
+						RepoPath:   "space/repo",
+						ID:         1,
+						Identifier: "rule1",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"pullreq":{"approvals":{"dismiss_stale_approvals":true}}}`),
+				},
+			},
+			input: StaleApprovalsInput{
+				Repo:    &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq: &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "develop"},
+			},
+			expOut: StaleApprovalsOutput{Dismiss: false},
+		},
+	}
+
+	ctx := context.Background()
+
+	m := NewManager(nil)
+	_ = m.Register(TypeBranch, func() Definition {
+		return &Branch{}
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := ruleSet{
+				rules:   test.rules,
+				manager: m,
+			}
+
+			out, err := set.StaleApprovals(ctx, test.input)
+			if err != nil {
+				t.Errorf("got error: %s", err.Error())
+			}
+
+			if want, got := test.expOut, out; !reflect.DeepEqual(want, got) {
+				t.Errorf("output: want=%+v got=%+v", want, got)
+			}
+		})
+	}
+}
+
 func TestIntersectSorted(t *testing.T) {
 	tests := []struct {
 		name string
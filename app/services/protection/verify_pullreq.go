@@ -32,6 +32,7 @@ type (
 	MergeVerifier interface {
 		MergeVerify(ctx context.Context, in MergeVerifyInput) (MergeVerifyOutput, []types.RuleViolations, error)
 		RequiredChecks(ctx context.Context, in RequiredChecksInput) (RequiredChecksOutput, error)
+		StaleApprovals(ctx context.Context, in StaleApprovalsInput) (StaleApprovalsOutput, error)
 	}
 
 	MergeVerifyInput struct {
@@ -71,6 +72,16 @@ type (
 		RequiredIdentifiers   map[string]struct{}
 		BypassableIdentifiers map[string]struct{}
 	}
+
+	StaleApprovalsInput struct {
+		Repo    *types.Repository
+		PullReq *types.PullReq
+	}
+
+	StaleApprovalsOutput struct {
+		// Dismiss is true if approvals of older commits should be dismissed when the pull request head changes.
+		Dismiss bool
+	}
 )
 
 // ensures that the DefPullReq type implements Sanitizer and MergeVerifier interface.
@@ -94,6 +105,9 @@ const (
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
 
+	codePullReqSizeMaxChangedLines = "pullreq.size.max_changed_lines"
+	codePullReqSizeMaxChangedFiles = "pullreq.size.max_changed_files"
+
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
 )
@@ -111,8 +125,11 @@ func (v *DefPullReq) MergeVerify(
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
 
+	// approvals of older commits don't count if they are dismissed on new commits.
+	requireLatestCommit := v.Approvals.RequireLatestCommit || v.Approvals.DismissStaleApprovals
+
 	// output that depends on approval of latest commit
-	if v.Approvals.RequireLatestCommit {
+	if requireLatestCommit {
 		out.RequiresCodeOwnersApprovalLatest = v.Approvals.RequireCodeOwners
 		out.MinimumRequiredApprovalsCountLatest = v.Approvals.RequireMinimumCount
 	} else {
@@ -126,7 +143,7 @@ func (v *DefPullReq) MergeVerify(
 	for _, reviewer := range in.Reviewers {
 		switch reviewer.ReviewDecision {
 		case enum.PullReqReviewDecisionApproved:
-			if v.Approvals.RequireLatestCommit && reviewer.SHA != in.PullReq.SourceSHA {
+			if requireLatestCommit && reviewer.SHA != in.PullReq.SourceSHA {
 				continue
 			}
 			approvedBy = append(approvedBy, reviewer.Reviewer)
@@ -152,7 +169,7 @@ func (v *DefPullReq) MergeVerify(
 	}
 
 	if len(approvedBy) < v.Approvals.RequireMinimumCount {
-		if v.Approvals.RequireLatestCommit {
+		if requireLatestCommit {
 			violations.Addf(codePullReqApprovalReqMinCountLatest,
 				"Insufficient number of approvals of the latest commit. Have %d but need at least %d.",
 				len(approvedBy), v.Approvals.RequireMinimumCount)
@@ -180,7 +197,7 @@ func (v *DefPullReq) MergeVerify(
 			}
 
 			// pull req approved. check other settings
-			if !v.Approvals.RequireLatestCommit {
+			if !requireLatestCommit {
 				continue
 			}
 			latestSHAApproved := slices.ContainsFunc(approvers, func(ev codeowners.OwnerEvaluation) bool {
@@ -226,6 +243,26 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	// pullreq.size
+
+	if in.PullReq != nil {
+		stats := in.PullReq.Stats.DiffStats
+		if v.Size.MaxChangedLines > 0 && stats.Additions != nil && stats.Deletions != nil {
+			changedLines := *stats.Additions + *stats.Deletions
+			if changedLines > v.Size.MaxChangedLines {
+				violations.Addf(codePullReqSizeMaxChangedLines,
+					"The pull request changes too many lines. It changes %d but at most %d are allowed.",
+					changedLines, v.Size.MaxChangedLines)
+			}
+		}
+
+		if v.Size.MaxChangedFiles > 0 && stats.FilesChanged != nil && *stats.FilesChanged > v.Size.MaxChangedFiles {
+			violations.Addf(codePullReqSizeMaxChangedFiles,
+				"The pull request changes too many files. It changes %d but at most %d are allowed.",
+				*stats.FilesChanged, v.Size.MaxChangedFiles)
+		}
+	}
+
 	// pullreq.merge
 
 	out.AllowedMethods = enum.MergeMethods
@@ -271,11 +308,21 @@ func (v *DefPullReq) RequiredChecks(
 	}, nil
 }
 
+func (v *DefPullReq) StaleApprovals(
+	_ context.Context,
+	_ StaleApprovalsInput,
+) (StaleApprovalsOutput, error) {
+	return StaleApprovalsOutput{
+		Dismiss: v.Approvals.DismissStaleApprovals,
+	}, nil
+}
+
 type DefApprovals struct {
 	RequireCodeOwners      bool `json:"require_code_owners,omitempty"`
 	RequireMinimumCount    int  `json:"require_minimum_count,omitempty"`
 	RequireLatestCommit    bool `json:"require_latest_commit,omitempty"`
 	RequireNoChangeRequest bool `json:"require_no_change_request,omitempty"`
+	DismissStaleApprovals  bool `json:"dismiss_stale_approvals,omitempty"`
 }
 
 func (v *DefApprovals) Sanitize() error {
@@ -370,6 +417,23 @@ func (v *DefMerge) Sanitize() error {
 	return nil
 }
 
+type DefSize struct {
+	MaxChangedLines int64 `json:"max_changed_lines,omitempty"`
+	MaxChangedFiles int64 `json:"max_changed_files,omitempty"`
+}
+
+func (v *DefSize) Sanitize() error {
+	if v.MaxChangedLines < 0 {
+		return errors.New("max changed lines must be zero or a positive integer")
+	}
+
+	if v.MaxChangedFiles < 0 {
+		return errors.New("max changed files must be zero or a positive integer")
+	}
+
+	return nil
+}
+
 type DefPush struct {
 	Block bool `json:"block,omitempty"`
 }
@@ -383,6 +447,7 @@ type DefPullReq struct {
 	Comments     DefComments     `json:"comments"`
 	StatusChecks DefStatusChecks `json:"status_checks"`
 	Merge        DefMerge        `json:"merge"`
+	Size         DefSize         `json:"size"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -402,6 +467,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.Size.Sanitize(); err != nil {
+		return fmt.Errorf("size: %w", err)
+	}
+
 	return nil
 }
 
@@ -120,6 +120,39 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				MinimumRequiredApprovalsCountLatest: 2,
 			},
 		},
+		{
+			name: codePullReqApprovalReqMinCountLatest + "-dismiss-stale-fail",
+			def:  DefPullReq{Approvals: DefApprovals{RequireMinimumCount: 2, DismissStaleApprovals: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc"},
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abd"},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqMinCountLatest},
+			expParams: [][]any{{1, 2}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:                      enum.MergeMethods,
+				MinimumRequiredApprovalsCountLatest: 2,
+			},
+		},
+		{
+			name: codePullReqApprovalReqMinCountLatest + "-dismiss-stale-success",
+			def:  DefPullReq{Approvals: DefApprovals{RequireMinimumCount: 1, DismissStaleApprovals: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc"},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:                      enum.MergeMethods,
+				MinimumRequiredApprovalsCountLatest: 1,
+			},
+		},
 		{
 			name: codePullReqApprovalReqCodeOwnersNoApproval + "-fail",
 			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true}},
@@ -278,6 +311,34 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				RequiresCommentResolution: true,
 			},
 		},
+		{
+			name: codePullReqSizeMaxChangedLines + "-fail",
+			def:  DefPullReq{Size: DefSize{MaxChangedLines: 100, MaxChangedFiles: 10}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{Stats: types.PullReqStats{
+					DiffStats: types.NewDiffStats(1, 12, 80, 40),
+				}},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqSizeMaxChangedLines, codePullReqSizeMaxChangedFiles},
+			expParams: [][]any{{int64(120), int64(100)}, {int64(12), int64(10)}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqSizeMaxChangedLines + "-success",
+			def:  DefPullReq{Size: DefSize{MaxChangedLines: 100, MaxChangedFiles: 10}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{Stats: types.PullReqStats{
+					DiffStats: types.NewDiffStats(1, 10, 60, 40),
+				}},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 		{
 			name: codePullReqStatusChecksReqIdentifiers + "-fail",
 			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1"}}},
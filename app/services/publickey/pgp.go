@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+
+	"golang.org/x/crypto/openpgp"                  //nolint:staticcheck // no alternative among the dependencies
+	pgperrors "golang.org/x/crypto/openpgp/errors" //nolint:staticcheck // no alternative among the dependencies
+	"golang.org/x/crypto/openpgp/packet"           //nolint:staticcheck // no alternative among the dependencies
+)
+
+// PGPKeyInfo holds the information extracted from an armored PGP public key.
+type PGPKeyInfo struct {
+	Entity *openpgp.Entity
+
+	// ValidFrom is the creation time of the primary key.
+	ValidFrom time.Time
+	// ValidTo is the expiration time of the primary key, nil if the key doesn't expire.
+	ValidTo *time.Time
+	// Revoked is true if the key contains a revocation signature of the primary key.
+	Revoked bool
+}
+
+// ParsePGP parses an armored PGP public key. Exactly one public key must be provided.
+func ParsePGP(keyData string) (PGPKeyInfo, string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("could not parse PGP public key")
+	}
+
+	if len(entities) != 1 {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("exactly one PGP public key must be provided")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("private keys are not accepted")
+	}
+
+	key := PGPKeyInfo{
+		Entity:    entity,
+		ValidFrom: entity.PrimaryKey.CreationTime,
+		Revoked:   len(entity.Revocations) > 0,
+	}
+
+	var comment string
+	if identity := primaryIdentity(entity); identity != nil {
+		comment = identity.Name
+
+		lifetime := identity.SelfSignature.KeyLifetimeSecs
+		if lifetime != nil && *lifetime != 0 {
+			validTo := entity.PrimaryKey.CreationTime.Add(time.Duration(*lifetime) * time.Second)
+			key.ValidTo = &validTo
+		}
+	}
+
+	return key, comment, nil
+}
+
+// primaryIdentity returns the identity marked as primary, or any identity if none is marked.
+func primaryIdentity(entity *openpgp.Entity) *openpgp.Identity {
+	var identity *openpgp.Identity
+	for _, ident := range entity.Identities {
+		if ident.SelfSignature == nil {
+			continue
+		}
+		if identity == nil {
+			identity = ident
+		}
+		if ident.SelfSignature.IsPrimaryId != nil && *ident.SelfSignature.IsPrimaryId {
+			return ident
+		}
+	}
+
+	return identity
+}
+
+// Fingerprint returns the fingerprint of the primary key in upper case hex format.
+func (key PGPKeyInfo) Fingerprint() string {
+	return strings.ToUpper(hex.EncodeToString(key.Entity.PrimaryKey.Fingerprint[:]))
+}
+
+// Type returns the name of the algorithm of the primary key.
+func (key PGPKeyInfo) Type() string {
+	switch key.Entity.PrimaryKey.PubKeyAlgo {
+	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSASignOnly:
+		return "RSA"
+	case packet.PubKeyAlgoDSA:
+		return "DSA"
+	case packet.PubKeyAlgoECDSA:
+		return "ECDSA"
+	default:
+		return "PGP"
+	}
+}
+
+// checkPGPSignature checks the armored detached PGP signature of the content against the provided armored key.
+func checkPGPSignature(keyData string, signature string, content []byte) (signatureCheck, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return signatureUnknownIssuer, fmt.Errorf("failed to read PGP key: %w", err)
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(content), strings.NewReader(signature))
+
+	var sigErr pgperrors.SignatureError
+	switch {
+	case err == nil:
+		return signatureGood, nil
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		return signatureUnknownIssuer, nil
+	case errors.As(err, &sigErr):
+		return signatureBad, nil
+	default:
+		return signatureUnknownIssuer, fmt.Errorf("failed to check PGP signature: %w", err)
+	}
+}
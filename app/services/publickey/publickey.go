@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -28,33 +29,43 @@ import (
 )
 
 type Service interface {
-	ValidateKey(ctx context.Context, publicKey ssh.PublicKey, usage enum.PublicKeyUsage) (*types.PrincipalInfo, error)
+	ValidateKey(ctx context.Context, publicKey ssh.PublicKey, usage enum.PublicKeyUsage) (*types.Principal, error)
+
+	VerifyCommitSignatures(
+		ctx context.Context,
+		signatures []git.CommitSignature,
+	) (map[string]enum.CommitSignatureStatus, error)
 }
 
 func NewService(
 	publicKeyStore store.PublicKeyStore,
-	pCache store.PrincipalInfoCache,
+	principalStore store.PrincipalStore,
+	userEmailStore store.UserEmailStore,
 ) LocalService {
 	return LocalService{
 		publicKeyStore: publicKeyStore,
-		pCache:         pCache,
+		principalStore: principalStore,
+		userEmailStore: userEmailStore,
 	}
 }
 
 type LocalService struct {
 	publicKeyStore store.PublicKeyStore
-	pCache         store.PrincipalInfoCache
+	principalStore store.PrincipalStore
+	userEmailStore store.UserEmailStore
 }
 
 // ValidateKey tries to match the provided key to one of the keys in the database.
 // It updates the verified timestamp of the matched key to mark it as used.
+// Revoked and expired keys, as well as keys of blocked principals, are rejected.
 func (s LocalService) ValidateKey(
 	ctx context.Context,
 	publicKey ssh.PublicKey,
 	usage enum.PublicKeyUsage,
-) (*types.PrincipalInfo, error) {
+) (*types.Principal, error) {
 	key := From(publicKey)
 	fingerprint := key.Fingerprint()
+	now := time.Now().UnixMilli()
 
 	existingKeys, err := s.publicKeyStore.ListByFingerprint(ctx, fingerprint)
 	if err != nil {
@@ -65,10 +76,15 @@ func (s LocalService) ValidateKey(
 	var principalID int64
 
 	for _, existingKey := range existingKeys {
-		if !key.Matches(existingKey.Content) || existingKey.Usage != usage {
+		if existingKey.Scheme != enum.PublicKeySchemeSSH ||
+			!key.Matches(existingKey.Content) || existingKey.Usage != usage {
 			continue
 		}
 
+		if existingKey.Revoked != nil || (existingKey.ValidTo != nil && *existingKey.ValidTo < now) {
+			return nil, errors.PreconditionFailed("Key is revoked or expired")
+		}
+
 		keyID = existingKey.ID
 		principalID = existingKey.PrincipalID
 	}
@@ -77,15 +93,19 @@ func (s LocalService) ValidateKey(
 		return nil, errors.NotFound("Unrecognized key")
 	}
 
-	pInfo, err := s.pCache.Get(ctx, principalID)
+	principal, err := s.principalStore.Find(ctx, principalID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pull principal info by public key's principal ID: %w", err)
+		return nil, fmt.Errorf("failed to find principal by public key's principal ID: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, errors.PreconditionFailed("Principal is blocked")
 	}
 
-	err = s.publicKeyStore.MarkAsVerified(ctx, keyID, time.Now().UnixMilli())
+	err = s.publicKeyStore.MarkAsVerified(ctx, keyID, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed mark key as verified: %w", err)
 	}
 
-	return pInfo, nil
+	return principal, nil
 }
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"fmt"
+	"hash"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	armorHeaderPGPSignature = "-----BEGIN PGP SIGNATURE-----"
+	armorHeaderSSHSignature = "-----BEGIN SSH SIGNATURE-----"
+
+	pemTypeSSHSignature = "SSH SIGNATURE"
+
+	// sshSigMagic is the preamble of SSH signatures, see PROTOCOL.sshsig of OpenSSH.
+	sshSigMagic   = "SSHSIG"
+	sshSigVersion = 1
+
+	// sshSigNamespaceGit is the namespace git uses when signing commits and tags with SSH keys.
+	sshSigNamespaceGit = "git"
+)
+
+// signatureCheck is the result of a signature check against a single key.
+type signatureCheck int
+
+const (
+	// signatureUnknownIssuer means that the signature hasn't been issued by the key.
+	signatureUnknownIssuer signatureCheck = iota
+	// signatureBad means that the signature has been issued by the key, but it doesn't match the content.
+	signatureBad
+	// signatureGood means that the signature of the content has been issued by the key.
+	signatureGood
+)
+
+// sshSignature is the content of an armored SSH signature.
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      []byte
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data that is actually signed when creating an SSH signature.
+type sshSignedData struct {
+	Namespace     string
+	Reserved      []byte
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// parseSSHSignature parses an armored SSH signature.
+func parseSSHSignature(signature string) (*sshSignature, gossh.PublicKey, error) {
+	block, _ := pem.Decode([]byte(signature))
+	if block == nil || block.Type != pemTypeSSHSignature {
+		return nil, nil, fmt.Errorf("not an armored SSH signature")
+	}
+
+	blob, ok := bytes.CutPrefix(block.Bytes, []byte(sshSigMagic))
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid SSH signature preamble")
+	}
+
+	sig := &sshSignature{}
+	if err := gossh.Unmarshal(blob, sig); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal SSH signature: %w", err)
+	}
+
+	if sig.Version != sshSigVersion {
+		return nil, nil, fmt.Errorf("unsupported SSH signature version %d", sig.Version)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse public key of SSH signature: %w", err)
+	}
+
+	return sig, publicKey, nil
+}
+
+// checkSSHSignature checks the armored SSH signature of the content against the provided authorized key.
+func checkSSHSignature(keyData string, signature string, content []byte) (signatureCheck, error) {
+	key, _, err := ParseString(keyData)
+	if err != nil {
+		return signatureUnknownIssuer, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+
+	sig, sigKey, err := parseSSHSignature(signature)
+	if err != nil {
+		return signatureUnknownIssuer, err
+	}
+
+	if !key.MatchesKey(sigKey) {
+		return signatureUnknownIssuer, nil
+	}
+
+	if sig.Namespace != sshSigNamespaceGit {
+		return signatureBad, nil
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return signatureBad, nil
+	}
+
+	h.Write(content)
+
+	signedData := append([]byte(sshSigMagic), gossh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	sshSig := &gossh.Signature{}
+	if err := gossh.Unmarshal(sig.Signature, sshSig); err != nil {
+		return signatureBad, nil //nolint:nilerr // malformed signature of a known key is a bad signature
+	}
+
+	if err := key.Key.Verify(signedData, sshSig); err != nil {
+		return signatureBad, nil //nolint:nilerr // verification failure is reported as a bad signature
+	}
+
+	return signatureGood, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func signSSH(t *testing.T, signer gossh.Signer, namespace string, content []byte) string {
+	t.Helper()
+
+	h := sha512.Sum512(content)
+	signedData := append([]byte(sshSigMagic), gossh.Marshal(sshSignedData{
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Hash:          h[:],
+	})...)
+
+	sig, err := signer.Sign(rand.Reader, signedData)
+	require.NoError(t, err)
+
+	blob := append([]byte(sshSigMagic), gossh.Marshal(sshSignature{
+		Version:       sshSigVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Signature:     gossh.Marshal(sig),
+	})...)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypeSSHSignature, Bytes: blob}))
+}
+
+func newSSHSigner(t *testing.T) (gossh.Signer, string) {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	require.NoError(t, err)
+
+	return signer, string(gossh.MarshalAuthorizedKey(signer.PublicKey()))
+}
+
+func TestCheckSSHSignature(t *testing.T) {
+	signer, keyData := newSSHSigner(t)
+	_, otherKeyData := newSSHSigner(t)
+
+	content := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nInitial commit\n")
+	signature := signSSH(t, signer, sshSigNamespaceGit, content)
+
+	tests := []struct {
+		name      string
+		keyData   string
+		signature string
+		content   []byte
+		want      signatureCheck
+	}{
+		{
+			name:      "good",
+			keyData:   keyData,
+			signature: signature,
+			content:   content,
+			want:      signatureGood,
+		},
+		{
+			name:      "modified content",
+			keyData:   keyData,
+			signature: signature,
+			content:   []byte(string(content) + "!"),
+			want:      signatureBad,
+		},
+		{
+			name:      "wrong namespace",
+			keyData:   keyData,
+			signature: signSSH(t, signer, "file", content),
+			content:   content,
+			want:      signatureBad,
+		},
+		{
+			name:      "other key",
+			keyData:   otherKeyData,
+			signature: signature,
+			content:   content,
+			want:      signatureUnknownIssuer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := checkSSHSignature(test.keyData, test.signature, test.content)
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxSigningKeysPerPrincipal is the maximum number of signing keys of a principal
+// that are considered during the verification of a signature.
+const maxSigningKeysPerPrincipal = 100
+
+// VerifyCommitSignatures verifies the signatures of the commits against the signing keys registered by the committers.
+// A signature is verified only if it's been issued by a signing key of the user whose primary
+// or verified email matches the committer. Validity of the keys is checked at the time of the verification,
+// because the commit time is provided by the committer and can't be trusted.
+// It returns the signature status of each of the commits, keyed by the commit SHA.
+func (s LocalService) VerifyCommitSignatures(
+	ctx context.Context,
+	signatures []git.CommitSignature,
+) (map[string]enum.CommitSignatureStatus, error) {
+	statuses := make(map[string]enum.CommitSignatureStatus, len(signatures))
+	keysByEmail := make(map[string][]types.PublicKey)
+	now := time.Now().UnixMilli()
+
+	for _, signature := range signatures {
+		commitSHA := signature.CommitSHA.String()
+
+		if signature.Signature == "" {
+			statuses[commitSHA] = enum.CommitSignatureStatusUnsigned
+			continue
+		}
+
+		email := strings.ToLower(signature.Committer.Identity.Email)
+		keys, ok := keysByEmail[email]
+		if !ok {
+			var err error
+			keys, err = s.listSigningKeys(ctx, email)
+			if err != nil {
+				return nil, err
+			}
+			keysByEmail[email] = keys
+		}
+
+		statuses[commitSHA] = verifySignature(ctx, keys, signature, now)
+	}
+
+	return statuses, nil
+}
+
+// listSigningKeys returns the signing keys of the principal with the provided email.
+func (s LocalService) listSigningKeys(ctx context.Context, email string) ([]types.PublicKey, error) {
+	principal, err := s.findPrincipalByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.Blocked {
+		return nil, nil
+	}
+
+	keys, err := s.publicKeyStore.List(ctx, principal.ID, &types.PublicKeyFilter{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Size: maxSigningKeysPerPrincipal},
+		},
+		Sort:   enum.PublicKeySortCreated,
+		Order:  enum.OrderDesc,
+		Usages: []enum.PublicKeyUsage{enum.PublicKeyUsageSign},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys of principal: %w", err)
+	}
+
+	return keys, nil
+}
+
+// findPrincipalByEmail finds the principal with the email as primary or as verified additional email address.
+func (s LocalService) findPrincipalByEmail(ctx context.Context, email string) (*types.Principal, error) {
+	principal, err := s.principalStore.FindByEmail(ctx, email)
+	if err == nil {
+		return principal, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	userEmail, err := s.userEmailStore.FindVerified(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find verified email: %w", err)
+	}
+
+	principal, err = s.principalStore.Find(ctx, userEmail.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal of verified email: %w", err)
+	}
+
+	return principal, nil
+}
+
+// verifySignature finds the key that issued the signature and returns the signature status.
+func verifySignature(
+	ctx context.Context,
+	keys []types.PublicKey,
+	signature git.CommitSignature,
+	now int64,
+) enum.CommitSignatureStatus {
+	var scheme enum.PublicKeyScheme
+	var check func(keyData string, signature string, content []byte) (signatureCheck, error)
+
+	switch {
+	case strings.HasPrefix(signature.Signature, armorHeaderPGPSignature):
+		scheme, check = enum.PublicKeySchemePGP, checkPGPSignature
+	case strings.HasPrefix(signature.Signature, armorHeaderSSHSignature):
+		scheme, check = enum.PublicKeySchemeSSH, checkSSHSignature
+	default:
+		// other signature formats (e.g. x509) can't be verified
+		return enum.CommitSignatureStatusUnverified
+	}
+
+	for i := range keys {
+		key := &keys[i]
+		if key.Scheme != scheme {
+			continue
+		}
+
+		result, err := check(key.Content, signature.Signature, signature.SignedContent)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).
+				Str("commit_sha", signature.CommitSHA.String()).
+				Msg("failed to check commit signature")
+			return enum.CommitSignatureStatusUnverified
+		}
+
+		switch result {
+		case signatureUnknownIssuer:
+			continue
+		case signatureBad:
+			return enum.CommitSignatureStatusInvalid
+		case signatureGood:
+		}
+
+		switch {
+		case key.Revoked != nil:
+			return enum.CommitSignatureStatusRevoked
+		case key.ValidFrom != nil && now < *key.ValidFrom,
+			key.ValidTo != nil && now > *key.ValidTo:
+			return enum.CommitSignatureStatusExpired
+		default:
+			return enum.CommitSignatureStatusVerified
+		}
+	}
+
+	return enum.CommitSignatureStatusUnverified
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+const signerID = 1
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	if !strings.EqualFold(email, "john@example.com") {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Principal{ID: signerID, Email: "john@example.com"}, nil
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if id != signerID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Principal{ID: signerID, Email: "john@example.com"}, nil
+}
+
+// fakeUserEmailStore knows a single verified additional email address of the signer.
+type fakeUserEmailStore struct {
+	store.UserEmailStore
+}
+
+func (fakeUserEmailStore) FindVerified(_ context.Context, email string) (*types.UserEmail, error) {
+	if !strings.EqualFold(email, "john@work.com") {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.UserEmail{PrincipalID: signerID, Email: "john@work.com"}, nil
+}
+
+type fakePublicKeyStore struct {
+	store.PublicKeyStore
+	keys []types.PublicKey
+}
+
+func (s *fakePublicKeyStore) List(
+	_ context.Context,
+	principalID int64,
+	_ *types.PublicKeyFilter,
+) ([]types.PublicKey, error) {
+	if principalID != signerID {
+		return nil, nil
+	}
+	return s.keys, nil
+}
+
+func TestLocalService_VerifyCommitSignatures(t *testing.T) {
+	signer, keyData := newSSHSigner(t)
+
+	content := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nInitial commit\n")
+	signature := signSSH(t, signer, sshSigNamespaceGit, content)
+
+	now := time.Now()
+	past := now.Add(-time.Hour).UnixMilli()
+
+	tests := []struct {
+		name     string
+		key      types.PublicKey
+		email    string
+		signedAt time.Time
+		want     enum.CommitSignatureStatus
+	}{
+		{
+			name:     "primary email",
+			email:    "john@example.com",
+			signedAt: now,
+			want:     enum.CommitSignatureStatusVerified,
+		},
+		{
+			name:     "verified additional email",
+			email:    "John@Work.com",
+			signedAt: now,
+			want:     enum.CommitSignatureStatusVerified,
+		},
+		{
+			name:     "unknown email",
+			email:    "john@other.com",
+			signedAt: now,
+			want:     enum.CommitSignatureStatusUnverified,
+		},
+		{
+			name:     "expired key with backdated commit",
+			key:      types.PublicKey{ValidTo: &past},
+			email:    "john@example.com",
+			signedAt: now.Add(-2 * time.Hour),
+			want:     enum.CommitSignatureStatusExpired,
+		},
+		{
+			name:     "revoked key",
+			key:      types.PublicKey{Revoked: &past},
+			email:    "john@example.com",
+			signedAt: now,
+			want:     enum.CommitSignatureStatusRevoked,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key := test.key
+			key.Content = keyData
+			key.Scheme = enum.PublicKeySchemeSSH
+			key.Usage = enum.PublicKeyUsageSign
+
+			s := NewService(&fakePublicKeyStore{keys: []types.PublicKey{key}},
+				fakePrincipalStore{}, fakeUserEmailStore{})
+
+			commitSHA := sha.Must("4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+			statuses, err := s.VerifyCommitSignatures(context.Background(), []git.CommitSignature{{
+				CommitSHA: commitSHA,
+				Committer: git.Signature{
+					Identity: git.Identity{Name: "John", Email: test.email},
+					When:     test.signedAt,
+				},
+				Signature:     signature,
+				SignedContent: content,
+			}})
+			require.NoError(t, err)
+			require.Equal(t, test.want, statuses[commitSHA.String()])
+		})
+	}
+}
@@ -26,7 +26,8 @@ var WireSet = wire.NewSet(
 
 func ProvidePublicKey(
 	publicKeyStore store.PublicKeyStore,
-	pCache store.PrincipalInfoCache,
+	principalStore store.PrincipalStore,
+	userEmailStore store.UserEmailStore,
 ) Service {
-	return NewService(publicKeyStore, pCache)
+	return NewService(publicKeyStore, principalStore, userEmailStore)
 }
@@ -54,6 +54,10 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		if err != nil {
 			return err
 		}
+
+		// The mergeability of the pull requests is recomputed asynchronously,
+		// so that it's known without running a merge check when a pull request is viewed.
+		s.reportTargetBranchUpdated(ctx, event, branch)
 	}
 
 	var commitTitle string
@@ -80,10 +84,14 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to get commit info from git")
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
+		// The commits of a fork must be pulled into the target repository first.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			if _, err := s.fetchForkHeadRef(ctx, pr); err != nil {
+				return fmt.Errorf("failed to fetch head ref of PR=%d: %w", pr.Number, err)
+			}
+		}
+
 		// First check if the merge base has changed
 
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
@@ -261,23 +269,33 @@ func (s *Service) forEveryOpenPR(ctx context.Context,
 	repoID int64, ref string,
 	fn func(pr *types.PullReq) error,
 ) {
-	const largeLimit = 1000000
-
 	branch, err := getBranchFromRef(ref)
 	if len(branch) == 0 {
 		log.Ctx(ctx).Err(err).Send()
 		return
 	}
 
-	pullreqList, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
-		Page:         0,
-		Size:         largeLimit,
+	s.forEveryOpenPRWithFilter(ctx, &types.PullReqFilter{
 		SourceRepoID: repoID,
 		SourceBranch: branch,
-		States:       []enum.PullReqState{enum.PullReqStateOpen},
-		Sort:         enum.PullReqSortNumber,
-		Order:        enum.OrderAsc,
-	})
+	}, fn)
+}
+
+// forEveryOpenPRWithFilter is utility function that executes the provided function
+// for every open pull request matching the repository and branch fields of the provided filter.
+func (s *Service) forEveryOpenPRWithFilter(ctx context.Context,
+	filter *types.PullReqFilter,
+	fn func(pr *types.PullReq) error,
+) {
+	const largeLimit = 1000000
+
+	filter.Page = 0
+	filter.Size = largeLimit
+	filter.States = []enum.PullReqState{enum.PullReqStateOpen}
+	filter.Sort = enum.PullReqSortNumber
+	filter.Order = enum.OrderAsc
+
+	pullreqList, err := s.pullreqStore.List(ctx, filter)
 	if err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to get list of open pull requests")
 		return
@@ -290,6 +308,46 @@ func (s *Service) forEveryOpenPR(ctx context.Context,
 	}
 }
 
+// maxTargetBranchUpdatedPullReqs is the max number of pull requests whose mergeability is recomputed
+// when their target branch moves. Other pull requests are left unchecked and get checked on demand.
+const maxTargetBranchUpdatedPullReqs = 100
+
+// reportTargetBranchUpdated triggers the Target Branch Updated event for the most recently updated
+// open pull requests targeting the branch.
+func (s *Service) reportTargetBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+	branch string,
+) {
+	pullreqList, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Page:         0,
+		Size:         maxTargetBranchUpdatedPullReqs,
+		TargetRepoID: event.Payload.RepoID,
+		TargetBranch: branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortUpdated,
+		Order:        enum.OrderDesc,
+	})
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to get list of open pull requests of the target branch")
+		return
+	}
+
+	for _, pr := range pullreqList {
+		s.pullreqEvReporter.TargetBranchUpdated(ctx, &pullreqevents.TargetBranchUpdatedPayload{
+			Base: pullreqevents.Base{
+				PullReqID:    pr.ID,
+				SourceRepoID: pr.SourceRepoID,
+				TargetRepoID: pr.TargetRepoID,
+				PrincipalID:  event.Payload.PrincipalID,
+				Number:       pr.Number,
+			},
+			SourceSHA: pr.SourceSHA,
+			OldSHA:    event.Payload.OldSHA,
+			NewSHA:    event.Payload.NewSHA,
+		})
+	}
+}
+
 func getBranchFromRef(ref string) (string, error) {
 	const refPrefix = "refs/heads/"
 	if !strings.HasPrefix(ref, refPrefix) {
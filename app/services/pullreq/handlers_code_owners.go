@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// requestCodeOwnersOnCreated handles pull request Created events.
+// It requests reviews from the code owners of the changed files.
+func (s *Service) requestCodeOwnersOnCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	return s.requestCodeOwnerReviews(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID, nil)
+}
+
+// requestCodeOwnersOnBranchUpdate handles pull request Branch Updated events.
+// It requests reviews from the code owners of files that got changed by the new commits.
+// Owners of files that were already changed before the update aren't requested again,
+// which keeps reviewers that were removed by a user removed.
+func (s *Service) requestCodeOwnersOnBranchUpdate(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.requestCodeOwnerReviews(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID,
+		&codeowners.DiffRange{BaseRef: event.Payload.OldSHA, HeadRef: event.Payload.NewSHA})
+}
+
+// requestCodeOwnersOnReopen handles pull request Reopened events.
+// It requests reviews from the code owners of the changed files.
+func (s *Service) requestCodeOwnersOnReopen(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload],
+) error {
+	return s.requestCodeOwnerReviews(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID, nil)
+}
+
+// requestCodeOwnerReviews adds the code owners of the changed files as reviewers of the pull request.
+// The changes are the ones of the provided diff range, or of the whole pull request if no range is provided.
+// The code owners are read from the CODEOWNERS file of the target branch.
+func (s *Service) requestCodeOwnerReviews(
+	ctx context.Context,
+	repoID, pullReqID int64,
+	diffRange *codeowners.DiffRange,
+) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("repo with id '%d' doesn't exist anymore", repoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	enabled, err := settings.RepoGetInherited(ctx, s.settings, repo,
+		settings.KeyCodeOwnersRequestReview, settings.DefaultCodeOwnersRequestReview)
+	if err != nil {
+		return fmt.Errorf("failed to get code owners request review setting: %w", err)
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pull request with id '%d' doesn't exist anymore", pullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	if diffRange == nil {
+		diffRange = &codeowners.DiffRange{BaseRef: pr.MergeBaseSHA, HeadRef: pr.SourceSHA}
+	}
+
+	owners, err := s.codeOwners.Owners(ctx, repo, pr, *diffRange)
+	if errors.Is(err, codeowners.ErrNotFound) {
+		return nil
+	}
+	var tooLargeErr *codeowners.TooLargeError
+	var parseErr *codeowners.FileParseError
+	if errors.As(err, &tooLargeErr) || errors.As(err, &parseErr) {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to read code owners of pull request")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get code owners of pull request: %w", err)
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	for _, owner := range owners.Users {
+		if err := s.addCodeOwnerReviewer(ctx, &systemPrincipal, repo, pr, owner); err != nil {
+			return fmt.Errorf("failed to add code owner %q as reviewer: %w", owner.UID, err)
+		}
+	}
+
+	for _, userGroup := range owners.UserGroups {
+		if err := s.addUserGroupReviewer(ctx, &systemPrincipal, repo, pr, userGroup); err != nil {
+			return fmt.Errorf("failed to add code owner group %q as reviewer: %w", userGroup.Identifier, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) addCodeOwnerReviewer(
+	ctx context.Context,
+	addedBy *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	owner *types.Principal,
+) error {
+	// code owners without access to the repository can't review, hence aren't requested.
+	if err := apiauth.CheckRepo(ctx, s.authorizer, &auth.Session{Principal: *owner}, repo,
+		enum.PermissionRepoReview); err != nil {
+		log.Ctx(ctx).Info().Msgf("code owner %s can't review the pull request: %s", owner.UID, err)
+		return nil
+	}
+
+	_, _, err := s.AddReviewer(ctx, addedBy, repo, pr, owner.ToPrincipalInfo(), enum.PullReqReviewerTypeCodeOwner)
+
+	return err
+}
+
+func (s *Service) addUserGroupReviewer(
+	ctx context.Context,
+	addedBy *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	userGroup *types.UserGroup,
+) error {
+	_, err := s.userGroupReviewerStore.Find(ctx, pr.ID, userGroup.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find pull request usergroup reviewer: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.userGroupReviewerStore.Create(ctx, &types.UserGroupReviewer{
+		PullReqID:   pr.ID,
+		UserGroupID: userGroup.ID,
+		CreatedBy:   addedBy.ID,
+		Created:     now,
+		Updated:     now,
+		RepoID:      repo.ID,
+		UserGroup:   *userGroup.ToUserGroupInfo(),
+		AddedBy:     *addedBy.ToPrincipalInfo(),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create pull request usergroup reviewer: %w", err)
+	}
+
+	s.pullreqEvReporter.UserGroupReviewerAdded(ctx, &pullreqevents.UserGroupReviewerAddedPayload{
+		Base:                eventBase(pr, addedBy),
+		UserGroupReviewerID: userGroup.ID,
+	})
+
+	return nil
+}
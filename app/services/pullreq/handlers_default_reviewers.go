@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// requestDefaultReviewersOnCreated handles pull request Created events.
+// It requests reviews from the default reviewers of the target repository.
+// Default reviewers restricted to path patterns are requested only if the pull request changes a matching file.
+func (s *Service) requestDefaultReviewersOnCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	repoID := event.Payload.TargetRepoID
+	pullReqID := event.Payload.PullReqID
+
+	defaultReviewers, err := settings.RepoGet[[]types.DefaultReviewer](ctx, s.settings, repoID,
+		settings.KeyDefaultReviewers, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get default reviewers setting: %w", err)
+	}
+
+	if len(defaultReviewers) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("repo with id '%d' doesn't exist anymore", repoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pull request with id '%d' doesn't exist anymore", pullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	var changedFiles []string
+	for _, reviewer := range defaultReviewers {
+		if len(reviewer.Paths) == 0 {
+			continue
+		}
+
+		diffFileNames, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+			ReadParams: git.CreateReadParams(repo),
+			BaseRef:    pr.MergeBaseSHA,
+			HeadRef:    pr.SourceSHA,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get changed files of pull request: %w", err)
+		}
+
+		changedFiles = diffFileNames.Files
+		break
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	for _, reviewer := range defaultReviewers {
+		if !defaultReviewerMatches(reviewer.Paths, changedFiles) {
+			continue
+		}
+
+		if reviewer.UserGroupID > 0 {
+			if err := s.addDefaultUserGroupReviewer(ctx, &systemPrincipal, repo, pr, reviewer.UserGroupID); err != nil {
+				return fmt.Errorf("failed to add default reviewer group %d: %w", reviewer.UserGroupID, err)
+			}
+			continue
+		}
+
+		if err := s.addDefaultReviewer(ctx, &systemPrincipal, repo, pr, reviewer.PrincipalID); err != nil {
+			return fmt.Errorf("failed to add default reviewer %d: %w", reviewer.PrincipalID, err)
+		}
+	}
+
+	return nil
+}
+
+// defaultReviewerMatches returns true if any of the files matches any of the path patterns.
+// A default reviewer without path patterns matches every pull request.
+func defaultReviewerMatches(patterns []string, files []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, file := range files {
+		for _, pattern := range patterns {
+			if ok, _ := doublestar.Match(pattern, file); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *Service) addDefaultReviewer(
+	ctx context.Context,
+	addedBy *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+) error {
+	// the author of a pull request can't review it.
+	if principalID == pr.CreatedBy {
+		return nil
+	}
+
+	reviewer, err := s.principalStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		log.Ctx(ctx).Warn().Msgf("default reviewer principal %d doesn't exist anymore", principalID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if reviewer.Blocked {
+		return nil
+	}
+
+	// default reviewers without access to the repository can't review, hence aren't requested.
+	if err := apiauth.CheckRepo(ctx, s.authorizer, &auth.Session{Principal: *reviewer}, repo,
+		enum.PermissionRepoReview); err != nil {
+		log.Ctx(ctx).Info().Msgf("default reviewer %s can't review the pull request: %s", reviewer.UID, err)
+		return nil
+	}
+
+	_, _, err = s.AddReviewer(ctx, addedBy, repo, pr, reviewer.ToPrincipalInfo(), enum.PullReqReviewerTypeDefault)
+
+	return err
+}
+
+func (s *Service) addDefaultUserGroupReviewer(
+	ctx context.Context,
+	addedBy *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	userGroupID int64,
+) error {
+	userGroup, err := s.userGroupStore.Find(ctx, userGroupID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		log.Ctx(ctx).Warn().Msgf("default reviewer user group %d doesn't exist anymore", userGroupID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	return s.addUserGroupReviewer(ctx, addedBy, repo, pr, userGroup)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import "testing"
+
+func TestDefaultReviewerMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		files    []string
+		exp      bool
+	}{
+		{
+			name:     "no-patterns",
+			patterns: nil,
+			files:    nil,
+			exp:      true,
+		},
+		{
+			name:     "no-files",
+			patterns: []string{"**"},
+			files:    nil,
+			exp:      false,
+		},
+		{
+			name:     "nested-match",
+			patterns: []string{"docs/**/*.md"},
+			files:    []string{"main.go", "docs/api/v1/index.md"},
+			exp:      true,
+		},
+		{
+			name:     "single-segment-wildcard",
+			patterns: []string{"app/*.go"},
+			files:    []string{"app/api/handler.go"},
+			exp:      false,
+		},
+		{
+			name:     "any-pattern",
+			patterns: []string{"*.md", "go.mod"},
+			files:    []string{"go.mod"},
+			exp:      true,
+		},
+		{
+			name:     "no-match",
+			patterns: []string{"web/**"},
+			files:    []string{"app/main.go", "README.md"},
+			exp:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := defaultReviewerMatches(test.patterns, test.files); got != test.exp {
+				t.Errorf("want=%t got=%t", test.exp, got)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
 )
 
 // createHeadRefOnCreated handles pull request Created events.
@@ -31,6 +32,11 @@ import (
 func (s *Service) createHeadRefOnCreated(ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
 ) error {
+	// the head ref of a pull request from a fork is fetched already during the pull request creation.
+	if event.Payload.SourceRepoID != event.Payload.TargetRepoID {
+		return nil
+	}
+
 	repoGit, err := s.repoGitInfoCache.Get(ctx, event.Payload.TargetRepoID)
 	if err != nil {
 		return fmt.Errorf("failed to get repo git info: %w", err)
@@ -41,8 +47,6 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -62,6 +66,11 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 	event *events.Event[*pullreqevents.BranchUpdatedPayload],
 ) error {
+	// the head ref of a pull request from a fork is fetched before the Branch Updated event is reported.
+	if event.Payload.SourceRepoID != event.Payload.TargetRepoID {
+		return nil
+	}
+
 	repoGit, err := s.repoGitInfoCache.Get(ctx, event.Payload.TargetRepoID)
 	if err != nil {
 		return fmt.Errorf("failed to get repo git info: %w", err)
@@ -72,8 +81,6 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -93,6 +100,11 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload],
 ) error {
+	// the head ref of a pull request from a fork is fetched already when the pull request is reopened.
+	if event.Payload.SourceRepoID != event.Payload.TargetRepoID {
+		return nil
+	}
+
 	repoGit, err := s.repoGitInfoCache.Get(ctx, event.Payload.TargetRepoID)
 	if err != nil {
 		return fmt.Errorf("failed to get repo git info: %w", err)
@@ -103,8 +115,6 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -118,3 +128,35 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 
 	return nil
 }
+
+// fetchForkHeadRef fetches the source branch of a pull request from a fork into the PR head git ref
+// of the target repository. This makes the commits of the fork available for diffs and merging.
+func (s *Service) fetchForkHeadRef(ctx context.Context, pr *types.PullReq) (sha.SHA, error) {
+	targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to get target repo git info: %w", err)
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, pr.SourceRepoID)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	out, err := s.git.FetchRef(ctx, git.FetchRefParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		SourceBranch:  pr.SourceBranch,
+		Type:          gitenum.RefTypePullReqHead,
+		Name:          strconv.Itoa(int(pr.Number)),
+	})
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to fetch source branch of fork: %w", err)
+	}
+
+	return out.SHA, nil
+}
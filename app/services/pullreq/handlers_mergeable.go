@@ -27,6 +27,7 @@ import (
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/pubsub"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -43,12 +44,19 @@ const (
 func (s *Service) mergeCheckOnCreated(ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
 ) error {
+	sourceSHA, err := sha.New(event.Payload.SourceSHA)
+	if err != nil {
+		// the event reader logs the discarded event.
+		return events.NewDiscardEventErrorf("invalid source SHA %q: %s", event.Payload.SourceSHA, err)
+	}
+
 	return s.updateMergeData(
 		ctx,
 		event.Payload.TargetRepoID,
 		event.Payload.Number,
-		sha.Nil.String(),
+		sourceSHA.ObjectFormat().Nil().String(),
 		event.Payload.SourceSHA,
+		"",
 	)
 }
 
@@ -66,6 +74,65 @@ func (s *Service) mergeCheckOnBranchUpdate(ctx context.Context,
 	)
 }
 
+// mergeCheckOnTargetBranchUpdate handles pull request Target Branch Updated events.
+// It recomputes the mergeability of the pull request against the new target branch commit.
+func (s *Service) mergeCheckOnTargetBranchUpdate(ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchUpdatedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pull request with id '%d' doesn't exist anymore",
+			event.Payload.PullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	// the pull request got closed or its source branch moved in the meantime,
+	// for the latter the mergeability is recomputed by the branch updated event.
+	if pr.State != enum.PullReqStateOpen || pr.SourceSHA != event.Payload.SourceSHA {
+		return nil
+	}
+
+	// the mergeability has already been checked against the new target branch commit.
+	if pr.MergeCheckStatus != enum.MergeCheckStatusUnchecked &&
+		pr.MergeTargetSHA != nil && *pr.MergeTargetSHA == event.Payload.NewSHA {
+		return nil
+	}
+
+	// Debounce quickly following pushes to the target branch: only the event of the latest commit
+	// of the target branch recomputes the mergeability, the others would be outdated immediately.
+	targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo git info: %w", err)
+	}
+
+	targetBranch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		BranchName: pr.TargetBranch,
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	if targetBranch.Branch.SHA.String() != event.Payload.NewSHA {
+		return nil
+	}
+
+	// The source SHA didn't change, hence there's no previous check to cancel (empty old SHA).
+	return s.updateMergeData(
+		ctx,
+		event.Payload.TargetRepoID,
+		event.Payload.Number,
+		"",
+		pr.SourceSHA,
+		event.Payload.NewSHA,
+	)
+}
+
 // mergeCheckOnReopen handles pull request StateChanged events.
 // It updates the PR head git ref to point to the source branch commit SHA.
 func (s *Service) mergeCheckOnReopen(ctx context.Context,
@@ -77,6 +144,7 @@ func (s *Service) mergeCheckOnReopen(ctx context.Context,
 		event.Payload.Number,
 		sha.None.String(),
 		event.Payload.SourceSHA,
+		"",
 	)
 }
 
@@ -120,6 +188,59 @@ func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64)
 	return nil
 }
 
+// mergeCheckKey identifies a running mergeability check by the source and the target commit.
+// The target SHA is empty if the check runs against the current commit of the target branch.
+type mergeCheckKey struct {
+	sourceSHA string
+	targetSHA string
+}
+
+// mergeCheck is a running mergeability check.
+type mergeCheck struct {
+	cancel context.CancelFunc
+}
+
+// startMergeCheck registers a mergeability check. It returns nil if the same check is already running.
+// Checks of the same source commit against other target commits are canceled, as they are outdated.
+func (s *Service) startMergeCheck(key mergeCheckKey, cancel context.CancelFunc) *mergeCheck {
+	s.cancelMutex.Lock()
+	defer s.cancelMutex.Unlock()
+
+	// NOTE: Temporary workaround to avoid overwriting existing cancel method on same machine.
+	// This doesn't avoid same SHA running on multiple machines
+	if _, ok := s.cancelMergeability[key]; ok {
+		return nil
+	}
+
+	s.cancelMergeChecksLocked(key.sourceSHA)
+
+	check := &mergeCheck{cancel: cancel}
+	s.cancelMergeability[key] = check
+
+	return check
+}
+
+// finishMergeCheck unregisters the mergeability check, unless it has been canceled and replaced in the meantime.
+func (s *Service) finishMergeCheck(key mergeCheckKey, check *mergeCheck) {
+	s.cancelMutex.Lock()
+	defer s.cancelMutex.Unlock()
+
+	if s.cancelMergeability[key] == check {
+		delete(s.cancelMergeability, key)
+	}
+}
+
+// cancelMergeChecksLocked cancels all running mergeability checks of the source commit.
+// The caller must hold the cancel mutex.
+func (s *Service) cancelMergeChecksLocked(sourceSHA string) {
+	for key, check := range s.cancelMergeability {
+		if key.sourceSHA == sourceSHA {
+			check.cancel()
+			delete(s.cancelMergeability, key)
+		}
+	}
+}
+
 //nolint:funlen // refactor if required.
 func (s *Service) updateMergeData(
 	ctx context.Context,
@@ -127,6 +248,7 @@ func (s *Service) updateMergeData(
 	prNum int64,
 	oldSHA string,
 	newSHA string,
+	targetSHA string,
 ) error {
 	pr, err := s.pullreqStore.FindByNumber(ctx, repoID, prNum)
 	if err != nil {
@@ -150,22 +272,16 @@ func (s *Service) updateMergeData(
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 
-	s.cancelMutex.Lock()
-	// NOTE: Temporary workaround to avoid overwriting existing cancel method on same machine.
-	// This doesn't avoid same SHA running on multiple machines
-	if _, ok := s.cancelMergeability[newSHA]; ok {
-		s.cancelMutex.Unlock()
+	key := mergeCheckKey{sourceSHA: newSHA, targetSHA: targetSHA}
+	check := s.startMergeCheck(key, cancel)
+	if check == nil {
 		cancel()
 		return nil
 	}
-	s.cancelMergeability[newSHA] = cancel
-	s.cancelMutex.Unlock()
 
 	defer func() {
 		cancel()
-		s.cancelMutex.Lock()
-		delete(s.cancelMergeability, newSHA)
-		s.cancelMutex.Unlock()
+		s.finishMergeCheck(key, check)
 	}()
 
 	// load repository objects
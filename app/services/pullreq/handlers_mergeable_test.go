@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+const (
+	mergeSourceSHA    = "1111111111111111111111111111111111111111"
+	mergeTargetSHA    = "2222222222222222222222222222222222222222"
+	mergeNewTargetSHA = "3333333333333333333333333333333333333333"
+)
+
+func TestService_MergeChecks(t *testing.T) {
+	s := &Service{cancelMergeability: make(map[mergeCheckKey]*mergeCheck)}
+
+	var canceled []string
+	cancelFn := func(name string) context.CancelFunc {
+		return func() { canceled = append(canceled, name) }
+	}
+
+	oldTarget := mergeCheckKey{sourceSHA: mergeSourceSHA, targetSHA: mergeTargetSHA}
+	newTarget := mergeCheckKey{sourceSHA: mergeSourceSHA, targetSHA: mergeNewTargetSHA}
+
+	oldCheck := s.startMergeCheck(oldTarget, cancelFn("old"))
+	if oldCheck == nil {
+		t.Fatalf("expected the first check to start")
+	}
+
+	if s.startMergeCheck(oldTarget, cancelFn("duplicate")) != nil {
+		t.Errorf("expected the same check not to start twice")
+	}
+
+	// the check against the new target commit replaces the outdated one.
+	newCheck := s.startMergeCheck(newTarget, cancelFn("new"))
+	if newCheck == nil {
+		t.Fatalf("expected the check against the new target commit to start")
+	}
+	if len(canceled) != 1 || canceled[0] != "old" {
+		t.Errorf("canceled = %v, want the check against the old target commit", canceled)
+	}
+
+	// the canceled check finishing doesn't remove the one that replaced it.
+	s.finishMergeCheck(oldTarget, oldCheck)
+	if s.cancelMergeability[newTarget] != newCheck {
+		t.Errorf("expected the check against the new target commit to be running")
+	}
+
+	s.finishMergeCheck(newTarget, newCheck)
+	if len(s.cancelMergeability) != 0 {
+		t.Errorf("got %d running checks, want none", len(s.cancelMergeability))
+	}
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s *fakePullReqStore) Find(context.Context, int64) (*types.PullReq, error) {
+	return s.pr, nil
+}
+
+type fakeRepoGitInfoCache struct {
+	store.RepoGitInfoCache
+}
+
+func (fakeRepoGitInfoCache) Get(_ context.Context, id int64) (*types.RepositoryGitInfo, error) {
+	return &types.RepositoryGitInfo{ID: id, GitUID: "repo-uid"}, nil
+}
+
+type branchGit struct {
+	git.Interface
+	sha string
+}
+
+func (g *branchGit) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{Name: params.BranchName, SHA: sha.Must(g.sha)}}, nil
+}
+
+// TestService_MergeCheckOnTargetBranchUpdateSkipped covers the events that don't recompute the mergeability,
+// the merge check dependencies aren't set, so any attempt to recompute it would panic.
+func TestService_MergeCheckOnTargetBranchUpdateSkipped(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          enum.PullReqState
+		sourceSHA      string
+		mergeTargetSHA string
+		branchSHA      string
+	}{
+		{
+			name:      "closed",
+			state:     enum.PullReqStateClosed,
+			sourceSHA: mergeSourceSHA,
+			branchSHA: mergeNewTargetSHA,
+		},
+		{
+			name:      "source branch moved",
+			state:     enum.PullReqStateOpen,
+			sourceSHA: mergeTargetSHA,
+			branchSHA: mergeNewTargetSHA,
+		},
+		{
+			name:           "already checked",
+			state:          enum.PullReqStateOpen,
+			sourceSHA:      mergeSourceSHA,
+			mergeTargetSHA: mergeNewTargetSHA,
+			branchSHA:      mergeNewTargetSHA,
+		},
+		{
+			name:      "target branch moved again",
+			state:     enum.PullReqStateOpen,
+			sourceSHA: mergeSourceSHA,
+			branchSHA: mergeTargetSHA,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{
+				ID:               1,
+				Number:           1,
+				TargetRepoID:     1,
+				TargetBranch:     "main",
+				State:            test.state,
+				SourceSHA:        test.sourceSHA,
+				MergeCheckStatus: enum.MergeCheckStatusMergeable,
+			}
+			if test.mergeTargetSHA != "" {
+				pr.MergeTargetSHA = ptr.String(test.mergeTargetSHA)
+			}
+
+			s := &Service{
+				pullreqStore:     &fakePullReqStore{pr: pr},
+				repoGitInfoCache: fakeRepoGitInfoCache{},
+				git:              &branchGit{sha: test.branchSHA},
+			}
+
+			err := s.mergeCheckOnTargetBranchUpdate(context.Background(),
+				&events.Event[*pullreqevents.TargetBranchUpdatedPayload]{
+					Payload: &pullreqevents.TargetBranchUpdatedPayload{
+						Base:      pullreqevents.Base{PullReqID: 1, TargetRepoID: 1, Number: 1},
+						SourceSHA: mergeSourceSHA,
+						OldSHA:    mergeTargetSHA,
+						NewSHA:    mergeNewTargetSHA,
+					},
+				})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// dismissStaleApprovalsOnBranchUpdate handles pull request Branch Updated events.
+// If a branch rule of the target branch requires it, approvals given for older commits are dismissed
+// by resetting the review decision of the reviewers back to pending, so they need to approve again.
+func (s *Service) dismissStaleApprovalsOnBranchUpdate(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.TargetRepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("repo with id '%d' doesn't exist anymore", event.Payload.TargetRepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pull request with id '%d' doesn't exist anymore",
+			event.Payload.PullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	out, err := protectionRules.StaleApprovals(ctx, protection.StaleApprovalsInput{
+		Repo:    repo,
+		PullReq: pr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check stale approvals protection rules: %w", err)
+	}
+
+	if !out.Dismiss {
+		return nil
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	dismissedIDs := make([]int64, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision != enum.PullReqReviewDecisionApproved || reviewer.SHA == pr.SourceSHA {
+			continue
+		}
+
+		reviewer.ReviewDecision = enum.PullReqReviewDecisionPending
+
+		if err = s.reviewerStore.Update(ctx, reviewer); err != nil {
+			return fmt.Errorf("failed to dismiss approval of reviewer %d: %w", reviewer.PrincipalID, err)
+		}
+
+		dismissedIDs = append(dismissedIDs, reviewer.PrincipalID)
+	}
+
+	if len(dismissedIDs) == 0 {
+		return nil
+	}
+
+	err = func() error {
+		payload := &types.PullRequestActivityPayloadReviewDismiss{
+			CommitSHA:    pr.SourceSHA,
+			PrincipalIDs: dismissedIDs,
+		}
+
+		metadata := &types.PullReqActivityMetadata{
+			Mentions: &types.PullReqActivityMentionsMetadata{IDs: dismissedIDs},
+		}
+
+		if pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		systemPrincipalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+		_, err = s.activityStore.CreateWithPayload(ctx, pr, systemPrincipalID, payload, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request activity: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after dismissing stale approvals")
+	}
+
+	return nil
+}
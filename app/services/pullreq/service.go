@@ -20,11 +20,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -36,21 +40,29 @@ import (
 )
 
 type Service struct {
-	pullreqEvReporter   *pullreqevents.Reporter
-	git                 git.Interface
-	repoGitInfoCache    store.RepoGitInfoCache
-	repoStore           store.RepoStore
-	pullreqStore        store.PullReqStore
-	activityStore       store.PullReqActivityStore
-	codeCommentView     store.CodeCommentView
-	principalInfoCache  store.PrincipalInfoCache
-	codeCommentMigrator *codecomments.Migrator
-	fileViewStore       store.PullReqFileViewStore
-	sseStreamer         sse.Streamer
-	urlProvider         url.Provider
+	pullreqEvReporter      *pullreqevents.Reporter
+	git                    git.Interface
+	repoGitInfoCache       store.RepoGitInfoCache
+	repoStore              store.RepoStore
+	pullreqStore           store.PullReqStore
+	activityStore          store.PullReqActivityStore
+	codeCommentView        store.CodeCommentView
+	principalInfoCache     store.PrincipalInfoCache
+	principalStore         store.PrincipalStore
+	userGroupStore         store.UserGroupStore
+	codeCommentMigrator    *codecomments.Migrator
+	fileViewStore          store.PullReqFileViewStore
+	reviewerStore          store.PullReqReviewerStore
+	userGroupReviewerStore store.UserGroupReviewersStore
+	codeOwners             *codeowners.Service
+	protectionManager      *protection.Manager
+	settings               *settings.Service
+	authorizer             authz.Authorizer
+	sseStreamer            sse.Streamer
+	urlProvider            url.Provider
 
 	cancelMutex        sync.Mutex
-	cancelMergeability map[string]context.CancelFunc
+	cancelMergeability map[mergeCheckKey]*mergeCheck
 
 	pubsub pubsub.PubSub
 }
@@ -69,26 +81,42 @@ func New(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	reviewerStore store.PullReqReviewerStore,
+	userGroupReviewerStore store.UserGroupReviewersStore,
+	codeOwners *codeowners.Service,
+	protectionManager *protection.Manager,
+	settings *settings.Service,
+	authorizer authz.Authorizer,
 	principalInfoCache store.PrincipalInfoCache,
+	principalStore store.PrincipalStore,
+	userGroupStore store.UserGroupStore,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
 ) (*Service, error) {
 	service := &Service{
-		pullreqEvReporter:   pullreqEvReporter,
-		git:                 git,
-		repoGitInfoCache:    repoGitInfoCache,
-		repoStore:           repoStore,
-		pullreqStore:        pullreqStore,
-		activityStore:       activityStore,
-		principalInfoCache:  principalInfoCache,
-		codeCommentView:     codeCommentView,
-		urlProvider:         urlProvider,
-		codeCommentMigrator: codeCommentMigrator,
-		fileViewStore:       fileViewStore,
-		cancelMergeability:  make(map[string]context.CancelFunc),
-		pubsub:              bus,
-		sseStreamer:         sseStreamer,
+		pullreqEvReporter:      pullreqEvReporter,
+		git:                    git,
+		repoGitInfoCache:       repoGitInfoCache,
+		repoStore:              repoStore,
+		pullreqStore:           pullreqStore,
+		activityStore:          activityStore,
+		principalInfoCache:     principalInfoCache,
+		principalStore:         principalStore,
+		userGroupStore:         userGroupStore,
+		codeCommentView:        codeCommentView,
+		urlProvider:            urlProvider,
+		codeCommentMigrator:    codeCommentMigrator,
+		fileViewStore:          fileViewStore,
+		reviewerStore:          reviewerStore,
+		userGroupReviewerStore: userGroupReviewerStore,
+		codeOwners:             codeOwners,
+		protectionManager:      protectionManager,
+		settings:               settings,
+		authorizer:             authorizer,
+		cancelMergeability:     make(map[mergeCheckKey]*mergeCheck),
+		pubsub:                 bus,
+		sseStreamer:            sseStreamer,
 	}
 
 	var err error
@@ -195,6 +223,7 @@ func New(ctx context.Context,
 
 			_ = r.RegisterCreated(service.mergeCheckOnCreated)
 			_ = r.RegisterBranchUpdated(service.mergeCheckOnBranchUpdate)
+			_ = r.RegisterTargetBranchUpdated(service.mergeCheckOnTargetBranchUpdate)
 			_ = r.RegisterReopened(service.mergeCheckOnReopen)
 			_ = r.RegisterClosed(service.mergeCheckOnClosed)
 			_ = r.RegisterMerged(service.mergeCheckOnMerged)
@@ -216,12 +245,7 @@ func New(ctx context.Context,
 		service.cancelMutex.Lock()
 		defer service.cancelMutex.Unlock()
 
-		cancel := service.cancelMergeability[oldSHA]
-		if cancel != nil {
-			cancel()
-		}
-
-		delete(service.cancelMergeability, oldSHA)
+		service.cancelMergeChecksLocked(oldSHA)
 
 		return nil
 	}, pubsub.WithChannelNamespace("pullreq"))
@@ -247,6 +271,68 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// code owner reviews
+	const groupPullReqCodeOwners = "gitness:pullreq:codeowners"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqCodeOwners, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.requestCodeOwnersOnCreated)
+			_ = r.RegisterBranchUpdated(service.requestCodeOwnersOnBranchUpdate)
+			_ = r.RegisterReopened(service.requestCodeOwnersOnReopen)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// stale approvals
+	const groupPullReqStaleApprovals = "gitness:pullreq:staleapprovals"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqStaleApprovals, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.dismissStaleApprovalsOnBranchUpdate)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// default reviewers
+	const groupPullReqDefaultReviewers = "gitness:pullreq:defaultreviewers"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqDefaultReviewers, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.requestDefaultReviewersOnCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	return service, nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitness_store "github.com/harness/gitness/store"
@@ -42,6 +43,7 @@ type ListService struct {
 	repoGitInfoCache store.RepoGitInfoCache
 	pullreqStore     store.PullReqStore
 	labelSvc         *label.Service
+	riskCache        cache.Cache[riskKey, *types.PullReqRisk]
 }
 
 func NewListService(
@@ -63,6 +65,10 @@ func NewListService(
 		repoGitInfoCache: repoGitInfoCache,
 		pullreqStore:     pullreqStore,
 		labelSvc:         labelSvc,
+		riskCache: cache.New[riskKey, *types.PullReqRisk](
+			riskGetter{git: git, repoGitInfoCache: repoGitInfoCache},
+			riskCacheDuration,
+		),
 	}
 }
 
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AddReviewer adds the principal as a reviewer of the pull request, unless it's a reviewer already.
+// For a newly added reviewer it writes the reviewer-add activity and reports the reviewer added event.
+// It returns the reviewer and whether the reviewer got added by the call.
+func (s *Service) AddReviewer(
+	ctx context.Context,
+	addedBy *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	reviewerInfo *types.PrincipalInfo,
+	reviewerType enum.PullReqReviewerType,
+) (*types.PullReqReviewer, bool, error) {
+	reviewer, err := s.reviewerStore.Find(ctx, pr.ID, reviewerInfo.ID)
+	if err == nil {
+		return reviewer, false, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, false, fmt.Errorf("failed to find pull request reviewer: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	reviewer = &types.PullReqReviewer{
+		PullReqID:      pr.ID,
+		PrincipalID:    reviewerInfo.ID,
+		CreatedBy:      addedBy.ID,
+		Created:        now,
+		Updated:        now,
+		RepoID:         repo.ID,
+		Type:           reviewerType,
+		ReviewDecision: enum.PullReqReviewDecisionPending,
+		Reviewer:       *reviewerInfo,
+		AddedBy:        *addedBy.ToPrincipalInfo(),
+	}
+
+	err = s.reviewerStore.Create(ctx, reviewer)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the reviewer got added concurrently.
+		reviewer, err = s.reviewerStore.Find(ctx, pr.ID, reviewerInfo.ID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find pull request reviewer: %w", err)
+		}
+		return reviewer, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create pull request reviewer: %w", err)
+	}
+
+	err = func() error {
+		payload := &types.PullRequestActivityPayloadReviewerAdd{
+			PrincipalID:  reviewer.PrincipalID,
+			ReviewerType: reviewerType,
+		}
+
+		metadata := &types.PullReqActivityMetadata{
+			Mentions: &types.PullReqActivityMentionsMetadata{IDs: []int64{reviewer.PrincipalID}},
+		}
+
+		if pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, pr, addedBy.ID, payload, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request activity: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after adding a reviewer")
+	}
+
+	s.pullreqEvReporter.ReviewerAdded(ctx, &pullreqevents.ReviewerAddedPayload{
+		Base:       eventBase(pr, addedBy),
+		ReviewerID: reviewer.PrincipalID,
+	})
+
+	return reviewer, true, nil
+}
+
+func eventBase(pr *types.PullReq, principal *types.Principal) pullreqevents.Base {
+	return pullreqevents.Base{
+		PullReqID:    pr.ID,
+		SourceRepoID: pr.SourceRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		PrincipalID:  principal.ID,
+		Number:       pr.Number,
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// riskMaxModules is the maximum number of touched modules for which the commit history is inspected.
+	riskMaxModules = 10
+	// riskHistoryCommits is the number of recent commits per module used to calculate the defect density.
+	riskHistoryCommits = 50
+	// riskCacheDuration is how long the calculated risk of a pull request version is cached.
+	riskCacheDuration = time.Hour
+)
+
+// riskFixCommitRegex matches titles of commits that are most likely bug fixes.
+var riskFixCommitRegex = regexp.MustCompile(`(?i)\b(fix(es|ed)?|bug|hotfix|revert|regression|patch)\b`)
+
+// riskKey identifies the risk of a pull request.
+// The risk only depends on the diff of the pull request and on the history of the target branch,
+// so it stays valid as long as neither the source SHA nor the merge base SHA changes.
+type riskKey struct {
+	repoID       int64
+	mergeBaseSHA string
+	sourceSHA    string
+}
+
+// riskGetter calculates the risk of pull requests, it's used as the getter of the risk cache.
+type riskGetter struct {
+	git              git.Interface
+	repoGitInfoCache store.RepoGitInfoCache
+}
+
+// BackfillRisk populates the size and risk annotation of the pull request.
+// The calculation inspects the diff and the commit history, so it's done only for a single pull request
+// and never for pull request lists. The risk is cached per source and merge base SHA,
+// so it's calculated only once per pull request version.
+func (c *ListService) BackfillRisk(ctx context.Context, pr *types.PullReq) error {
+	if pr.Risk != nil {
+		return nil
+	}
+
+	risk, err := c.riskCache.Get(ctx, riskKey{
+		repoID:       pr.TargetRepoID,
+		mergeBaseSHA: pr.MergeBaseSHA,
+		sourceSHA:    pr.SourceSHA,
+	})
+	if err != nil {
+		return err
+	}
+
+	pr.Risk = risk
+
+	return nil
+}
+
+// Find calculates the size and risk annotation of the pull request identified by the key.
+func (g riskGetter) Find(ctx context.Context, key riskKey) (*types.PullReqRisk, error) {
+	repoGitInfo, err := g.repoGitInfoCache.Get(ctx, key.repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get repo git info to calculate risk: %w", err)
+	}
+
+	readParams := git.CreateReadParams(repoGitInfo)
+	diffParams := &git.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    key.mergeBaseSHA,
+		HeadRef:    key.sourceSHA,
+	}
+
+	stats, err := g.git.DiffStats(ctx, diffParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+
+	fileNames, err := g.git.DiffFileNames(ctx, diffParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed file names: %w", err)
+	}
+
+	moduleFiles := riskModuleFileCounts(fileNames.Files)
+
+	// inspect the history of the most touched modules on the target branch
+	modules := make([]string, 0, len(moduleFiles))
+	for module := range moduleFiles {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if moduleFiles[modules[i]] != moduleFiles[modules[j]] {
+			return moduleFiles[modules[i]] > moduleFiles[modules[j]]
+		}
+		return modules[i] < modules[j]
+	})
+	if len(modules) > riskMaxModules {
+		modules = modules[:riskMaxModules]
+	}
+
+	var commitCount, fixCount int
+	for _, module := range modules {
+		path := module
+		if path == "." {
+			path = ""
+		}
+
+		output, err := g.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: readParams,
+			GitREF:     key.mergeBaseSHA,
+			Page:       1,
+			Limit:      riskHistoryCommits,
+			Path:       path,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of module %q: %w", module, err)
+		}
+
+		for i := range output.Commits {
+			commitCount++
+			if riskFixCommitRegex.MatchString(output.Commits[i].Title) {
+				fixCount++
+			}
+		}
+	}
+
+	metrics := types.PullReqRiskMetrics{
+		LinesChanged:  int64(stats.Additions + stats.Deletions),
+		FilesChanged:  int64(stats.FilesChanged),
+		Modules:       len(moduleFiles),
+		ModuleEntropy: riskEntropy(moduleFiles),
+	}
+	if commitCount > 0 {
+		metrics.DefectDensity = float64(fixCount) / float64(commitCount)
+	}
+
+	return calculateRisk(metrics), nil
+}
+
+// riskModuleFileCounts groups the changed files by their top level directory.
+// Files in the repository root are grouped under ".".
+func riskModuleFileCounts(files []string) map[string]int {
+	modules := make(map[string]int)
+	for _, file := range files {
+		module, _, found := strings.Cut(strings.TrimPrefix(file, "/"), "/")
+		if !found {
+			module = "."
+		}
+		modules[module]++
+	}
+	return modules
+}
+
+// riskEntropy returns the Shannon entropy (in bits) of the distribution of files across modules.
+func riskEntropy(moduleFiles map[string]int) float64 {
+	total := 0
+	for _, n := range moduleFiles {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	entropy := 0.0
+	for _, n := range moduleFiles {
+		p := float64(n) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+
+	return math.Round(entropy*100) / 100
+}
+
+// calculateRisk combines the metrics into a risk score in the range [0, 100].
+// Size accounts for up to 40 points, the number of files, the module entropy
+// and the defect density account for up to 20 points each.
+func calculateRisk(metrics types.PullReqRiskMetrics) *types.PullReqRisk {
+	score := 40*math.Min(float64(metrics.LinesChanged)/1000, 1) +
+		20*math.Min(float64(metrics.FilesChanged)/50, 1) +
+		20*math.Min(metrics.ModuleEntropy/3, 1) +
+		20*math.Min(metrics.DefectDensity, 1)
+
+	risk := &types.PullReqRisk{
+		Score:   int(math.Round(score)),
+		Metrics: metrics,
+	}
+
+	switch {
+	case metrics.LinesChanged < 10:
+		risk.Size = enum.PullReqSizeXS
+	case metrics.LinesChanged < 100:
+		risk.Size = enum.PullReqSizeS
+	case metrics.LinesChanged < 400:
+		risk.Size = enum.PullReqSizeM
+	case metrics.LinesChanged < 1000:
+		risk.Size = enum.PullReqSizeL
+	default:
+		risk.Size = enum.PullReqSizeXL
+	}
+
+	switch {
+	case risk.Score < 30:
+		risk.Level = enum.PullReqRiskLevelLow
+	case risk.Score < 60:
+		risk.Level = enum.PullReqRiskLevelMedium
+	default:
+		risk.Level = enum.PullReqRiskLevelHigh
+	}
+
+	return risk
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRiskModuleFileCounts(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		exp   map[string]int
+	}{
+		{
+			name:  "no-files",
+			files: nil,
+			exp:   map[string]int{},
+		},
+		{
+			name:  "root-files",
+			files: []string{"README.md", "/go.mod"},
+			exp:   map[string]int{".": 2},
+		},
+		{
+			name:  "modules",
+			files: []string{"app/a.go", "app/store/b.go", "/git/c.go", "main.go"},
+			exp:   map[string]int{"app": 2, "git": 1, ".": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := riskModuleFileCounts(test.files)
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("want=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestRiskEntropy(t *testing.T) {
+	tests := []struct {
+		name        string
+		moduleFiles map[string]int
+		exp         float64
+	}{
+		{name: "empty", moduleFiles: map[string]int{}, exp: 0},
+		{name: "single-module", moduleFiles: map[string]int{"app": 7}, exp: 0},
+		{name: "two-even-modules", moduleFiles: map[string]int{"app": 3, "git": 3}, exp: 1},
+		{name: "four-even-modules", moduleFiles: map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}, exp: 2},
+		{name: "uneven-modules", moduleFiles: map[string]int{"app": 3, "git": 1}, exp: 0.81},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := riskEntropy(test.moduleFiles); got != test.exp {
+				t.Errorf("want=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestCalculateRisk(t *testing.T) {
+	tests := []struct {
+		name     string
+		metrics  types.PullReqRiskMetrics
+		expSize  enum.PullReqSize
+		expLevel enum.PullReqRiskLevel
+		expScore int
+	}{
+		{
+			name:     "empty",
+			metrics:  types.PullReqRiskMetrics{},
+			expSize:  enum.PullReqSizeXS,
+			expLevel: enum.PullReqRiskLevelLow,
+			expScore: 0,
+		},
+		{
+			name:     "size-s-lower-bound",
+			metrics:  types.PullReqRiskMetrics{LinesChanged: 10, FilesChanged: 1},
+			expSize:  enum.PullReqSizeS,
+			expLevel: enum.PullReqRiskLevelLow,
+			expScore: 1,
+		},
+		{
+			name:     "size-m-lower-bound",
+			metrics:  types.PullReqRiskMetrics{LinesChanged: 100, FilesChanged: 5},
+			expSize:  enum.PullReqSizeM,
+			expLevel: enum.PullReqRiskLevelLow,
+			expScore: 6,
+		},
+		{
+			name:     "size-l-lower-bound",
+			metrics:  types.PullReqRiskMetrics{LinesChanged: 400, FilesChanged: 10, ModuleEntropy: 1.5},
+			expSize:  enum.PullReqSizeL,
+			expLevel: enum.PullReqRiskLevelMedium,
+			expScore: 30,
+		},
+		{
+			name:     "size-xl-lower-bound",
+			metrics:  types.PullReqRiskMetrics{LinesChanged: 1000},
+			expSize:  enum.PullReqSizeXL,
+			expLevel: enum.PullReqRiskLevelMedium,
+			expScore: 40,
+		},
+		{
+			name: "high-risk-lower-bound",
+			metrics: types.PullReqRiskMetrics{
+				LinesChanged:  1000,
+				FilesChanged:  50,
+				ModuleEntropy: 0,
+				DefectDensity: 0,
+			},
+			expSize:  enum.PullReqSizeXL,
+			expLevel: enum.PullReqRiskLevelHigh,
+			expScore: 60,
+		},
+		{
+			name: "max-score-is-capped",
+			metrics: types.PullReqRiskMetrics{
+				LinesChanged:  5000,
+				FilesChanged:  200,
+				ModuleEntropy: 4,
+				DefectDensity: 1,
+			},
+			expSize:  enum.PullReqSizeXL,
+			expLevel: enum.PullReqRiskLevelHigh,
+			expScore: 100,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			risk := calculateRisk(test.metrics)
+			if risk.Size != test.expSize {
+				t.Errorf("size: want=%s got=%s", test.expSize, risk.Size)
+			}
+			if risk.Level != test.expLevel {
+				t.Errorf("level: want=%s got=%s", test.expLevel, risk.Level)
+			}
+			if risk.Score != test.expScore {
+				t.Errorf("score: want=%d got=%d", test.expScore, risk.Score)
+			}
+			if risk.Metrics != test.metrics {
+				t.Errorf("metrics: want=%+v got=%+v", test.metrics, risk.Metrics)
+			}
+		})
+	}
+}
@@ -21,7 +21,10 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -50,9 +53,17 @@ func ProvideService(ctx context.Context,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
 	principalInfoCache store.PrincipalInfoCache,
+	principalStore store.PrincipalStore,
+	userGroupStore store.UserGroupStore,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	reviewerStore store.PullReqReviewerStore,
+	userGroupReviewerStore store.UserGroupReviewersStore,
+	codeOwners *codeowners.Service,
+	protectionManager *protection.Manager,
+	settings *settings.Service,
+	authorizer authz.Authorizer,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
@@ -70,7 +81,15 @@ func ProvideService(ctx context.Context,
 		codeCommentView,
 		codeCommentMigrator,
 		fileViewStore,
+		reviewerStore,
+		userGroupReviewerStore,
+		codeOwners,
+		protectionManager,
+		settings,
+		authorizer,
 		principalInfoCache,
+		principalStore,
+		userGroupStore,
 		pubsub,
 		urlProvider,
 		sseStreamer,
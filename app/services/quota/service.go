@@ -0,0 +1,258 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Service manages the resource quotas of spaces and enforces them.
+// A quota assigned to a space limits the resources of the space and all its sub-spaces,
+// hence every quota along the path to the root space has to be satisfied.
+type Service struct {
+	settings       *settings.Service
+	spaceStore     store.SpaceStore
+	repoStore      store.RepoStore
+	executionStore store.ExecutionStore
+}
+
+func NewService(
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	executionStore store.ExecutionStore,
+) *Service {
+	return &Service{
+		settings:       settings,
+		spaceStore:     spaceStore,
+		repoStore:      repoStore,
+		executionStore: executionStore,
+	}
+}
+
+// Find returns the quota of the space along with the current resource usage of the space and its sub-spaces.
+func (s *Service) Find(ctx context.Context, spaceID int64) (*types.SpaceQuotaUsage, error) {
+	quota, err := s.find(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.Usage(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SpaceQuotaUsage{
+		Quota: quota,
+		Usage: usage,
+	}, nil
+}
+
+// Update replaces the quota of the space. The new quota is enforced for new resources only,
+// existing resources that exceed it are left untouched.
+func (s *Service) Update(ctx context.Context, spaceID int64, quota types.SpaceQuota) (*types.SpaceQuotaUsage, error) {
+	if err := quota.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err := s.settings.SpaceSet(ctx, spaceID, settings.KeyQuota, quota); err != nil {
+		return nil, fmt.Errorf("failed to store quota of space: %w", err)
+	}
+
+	return s.Find(ctx, spaceID)
+}
+
+// Usage returns the current resource usage of the space and all its sub-spaces.
+func (s *Service) Usage(ctx context.Context, spaceID int64) (types.SpaceUsage, error) {
+	repos, err := s.repoStore.Count(ctx, spaceID, &types.RepoFilter{Recursive: true})
+	if err != nil {
+		return types.SpaceUsage{}, fmt.Errorf("failed to count repositories: %w", err)
+	}
+
+	storage, err := s.storage(ctx, spaceID)
+	if err != nil {
+		return types.SpaceUsage{}, err
+	}
+
+	periodStart, minutes, err := s.pipelineMinutes(ctx, spaceID)
+	if err != nil {
+		return types.SpaceUsage{}, err
+	}
+
+	return types.SpaceUsage{
+		Repos:               repos,
+		Storage:             storage,
+		PipelineMinutes:     minutes,
+		PipelinePeriodStart: periodStart,
+	}, nil
+}
+
+func (s *Service) find(ctx context.Context, spaceID int64) (types.SpaceQuota, error) {
+	quota, err := settings.SpaceGet(ctx, s.settings, spaceID, settings.KeyQuota, types.SpaceQuota{})
+	if err != nil {
+		return types.SpaceQuota{}, fmt.Errorf("failed to get quota of space %d: %w", spaceID, err)
+	}
+
+	return quota, nil
+}
+
+// storage returns the total size of the repositories of the space and its sub-spaces in bytes.
+func (s *Service) storage(ctx context.Context, spaceID int64) (int64, error) {
+	sizeInKiB, err := s.repoStore.SumSize(ctx, spaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum repository sizes: %w", err)
+	}
+
+	return sizeInKiB * 1024, nil
+}
+
+// pipelineMinutes returns the start of the current calendar month and the number of pipeline execution minutes
+// used since then by the space and its sub-spaces.
+func (s *Service) pipelineMinutes(ctx context.Context, spaceID int64) (int64, int64, error) {
+	now := time.Now().UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	duration, err := s.executionStore.SumDuration(ctx, spaceID, periodStart, now.UnixMilli())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to sum pipeline execution durations: %w", err)
+	}
+
+	return periodStart, duration / time.Minute.Milliseconds(), nil
+}
+
+// spaceQuota is the quota assigned to a space.
+type spaceQuota struct {
+	spaceID int64
+	path    string
+	quota   types.SpaceQuota
+}
+
+// quotas returns the quotas assigned to the space and its ancestors.
+func (s *Service) quotas(ctx context.Context, spaceID int64) ([]spaceQuota, error) {
+	ancestors, err := s.spaceStore.GetAncestors(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestors of space: %w", err)
+	}
+
+	quotas := make([]spaceQuota, 0, len(ancestors))
+	for _, space := range ancestors {
+		quota, err := s.find(ctx, space.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if quota.IsEmpty() {
+			continue
+		}
+
+		quotas = append(quotas, spaceQuota{spaceID: space.ID, path: space.Path, quota: quota})
+	}
+
+	return quotas, nil
+}
+
+// RepoCount verifies that count new repositories can be added to the space without exceeding any quota.
+func (s *Service) RepoCount(ctx context.Context, spaceID int64, count int) error {
+	quotas, err := s.quotas(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range quotas {
+		if q.quota.MaxRepos == nil {
+			continue
+		}
+
+		repos, err := s.repoStore.Count(ctx, q.spaceID, &types.RepoFilter{Recursive: true})
+		if err != nil {
+			return fmt.Errorf("failed to count repositories of space %d: %w", q.spaceID, err)
+		}
+
+		if repos+int64(count) > *q.quota.MaxRepos {
+			return fmt.Errorf("repository quota of space %q (%d) exceeded",
+				q.path, *q.quota.MaxRepos)
+		}
+	}
+
+	return nil
+}
+
+// RepoSize verifies that the storage quotas of the spaces of the repository aren't exceeded.
+func (s *Service) RepoSize(ctx context.Context, repoID int64) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	quotas, err := s.quotas(ctx, repo.ParentID)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range quotas {
+		if q.quota.MaxStorage == nil {
+			continue
+		}
+
+		storage, err := s.storage(ctx, q.spaceID)
+		if err != nil {
+			return err
+		}
+
+		if storage >= *q.quota.MaxStorage {
+			return fmt.Errorf("storage quota of space %q (%d bytes) exceeded",
+				q.path, *q.quota.MaxStorage)
+		}
+	}
+
+	return nil
+}
+
+// PipelineMinutes verifies that the pipeline minutes quotas of the spaces of the repository aren't exceeded.
+func (s *Service) PipelineMinutes(ctx context.Context, repoID int64) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	quotas, err := s.quotas(ctx, repo.ParentID)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range quotas {
+		if q.quota.MaxPipelineMinutes == nil {
+			continue
+		}
+
+		_, minutes, err := s.pipelineMinutes(ctx, q.spaceID)
+		if err != nil {
+			return err
+		}
+
+		if minutes >= *q.quota.MaxPipelineMinutes {
+			return fmt.Errorf("pipeline minutes quota of space %q (%d minutes) exceeded",
+				q.path, *q.quota.MaxPipelineMinutes)
+		}
+	}
+
+	return nil
+}
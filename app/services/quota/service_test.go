@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+const (
+	rootSpaceID = 1
+	teamSpaceID = 2
+	teamRepoID  = 1
+)
+
+type fakeSettingsStore struct {
+	store.SettingsStore
+	values map[string]json.RawMessage
+}
+
+func settingsKey(scopeID int64, key string) string {
+	return fmt.Sprintf("%d/%s", scopeID, key)
+}
+
+func (s *fakeSettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	key string,
+) (json.RawMessage, error) {
+	value, ok := s.values[settingsKey(scopeID, key)]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+func (s *fakeSettingsStore) Upsert(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	key string,
+	value json.RawMessage,
+) error {
+	s.values[settingsKey(scopeID, key)] = value
+	return nil
+}
+
+// fakeSpaceStore has the root space with a single team space.
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) GetAncestors(_ context.Context, spaceID int64) ([]*types.Space, error) {
+	root := &types.Space{ID: rootSpaceID, Path: "root"}
+	switch spaceID {
+	case rootSpaceID:
+		return []*types.Space{root}, nil
+	case teamSpaceID:
+		return []*types.Space{{ID: teamSpaceID, ParentID: rootSpaceID, Path: "root/team"}, root}, nil
+	default:
+		return nil, gitness_store.ErrResourceNotFound
+	}
+}
+
+// fakeRepoStore holds the recursive number of repositories and their total size per space.
+type fakeRepoStore struct {
+	store.RepoStore
+	counts map[int64]int64
+	sizes  map[int64]int64
+}
+
+func (s *fakeRepoStore) Count(_ context.Context, spaceID int64, _ *types.RepoFilter) (int64, error) {
+	return s.counts[spaceID], nil
+}
+
+func (s *fakeRepoStore) SumSize(_ context.Context, spaceID int64) (int64, error) {
+	return s.sizes[spaceID], nil
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if id != teamRepoID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Repository{ID: teamRepoID, ParentID: teamSpaceID}, nil
+}
+
+// fakeExecutionStore holds the recursive execution duration of the current month per space.
+type fakeExecutionStore struct {
+	store.ExecutionStore
+	durations map[int64]int64
+}
+
+func (s *fakeExecutionStore) SumDuration(_ context.Context, spaceID int64, _, _ int64) (int64, error) {
+	return s.durations[spaceID], nil
+}
+
+type testQuotas map[int64]types.SpaceQuota
+
+func newTestService(t *testing.T, quotas testQuotas, repos *fakeRepoStore, executions *fakeExecutionStore) *Service {
+	t.Helper()
+
+	settingsStore := &fakeSettingsStore{values: make(map[string]json.RawMessage)}
+	settingsSvc := settings.NewService(settingsStore, fakeSpaceStore{}, "main")
+
+	for spaceID, quota := range quotas {
+		if err := settingsSvc.SpaceSet(context.Background(), spaceID, settings.KeyQuota, quota); err != nil {
+			t.Fatalf("failed to set quota: %v", err)
+		}
+	}
+
+	return NewService(settingsSvc, fakeSpaceStore{}, repos, executions)
+}
+
+func TestService_RepoCount(t *testing.T) {
+	repos := &fakeRepoStore{counts: map[int64]int64{rootSpaceID: 9, teamSpaceID: 2}}
+
+	tests := []struct {
+		name    string
+		quotas  testQuotas
+		count   int
+		wantErr bool
+	}{
+		{
+			name:  "no quotas",
+			count: 100,
+		},
+		{
+			name:   "within the quota of the root space",
+			quotas: testQuotas{rootSpaceID: {MaxRepos: ptr.Int64(10)}},
+			count:  1,
+		},
+		{
+			name:    "exceeds the quota of the root space",
+			quotas:  testQuotas{rootSpaceID: {MaxRepos: ptr.Int64(10)}},
+			count:   2,
+			wantErr: true,
+		},
+		{
+			name:    "exceeds the quota of the team space",
+			quotas:  testQuotas{rootSpaceID: {MaxRepos: ptr.Int64(100)}, teamSpaceID: {MaxRepos: ptr.Int64(2)}},
+			count:   1,
+			wantErr: true,
+		},
+		{
+			name:   "other resources limited only",
+			quotas: testQuotas{rootSpaceID: {MaxStorage: ptr.Int64(0)}},
+			count:  100,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestService(t, test.quotas, repos, nil)
+
+			err := s.RepoCount(context.Background(), teamSpaceID, test.count)
+			if (err != nil) != test.wantErr {
+				t.Errorf("RepoCount() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_RepoSize(t *testing.T) {
+	// sizes are in KiB, quotas in bytes.
+	repos := &fakeRepoStore{sizes: map[int64]int64{rootSpaceID: 20, teamSpaceID: 10}}
+
+	tests := []struct {
+		name    string
+		quotas  testQuotas
+		wantErr bool
+	}{
+		{
+			name:   "within the quota",
+			quotas: testQuotas{rootSpaceID: {MaxStorage: ptr.Int64(21 * 1024)}},
+		},
+		{
+			name:    "quota of the root space reached",
+			quotas:  testQuotas{rootSpaceID: {MaxStorage: ptr.Int64(20 * 1024)}},
+			wantErr: true,
+		},
+		{
+			name:    "quota of the team space exceeded",
+			quotas:  testQuotas{teamSpaceID: {MaxStorage: ptr.Int64(5 * 1024)}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestService(t, test.quotas, repos, nil)
+
+			err := s.RepoSize(context.Background(), teamRepoID)
+			if (err != nil) != test.wantErr {
+				t.Errorf("RepoSize() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_PipelineMinutes(t *testing.T) {
+	executions := &fakeExecutionStore{durations: map[int64]int64{
+		rootSpaceID: (30 * time.Minute).Milliseconds(),
+		teamSpaceID: (10*time.Minute + 59*time.Second).Milliseconds(),
+	}}
+
+	tests := []struct {
+		name    string
+		quotas  testQuotas
+		wantErr bool
+	}{
+		{
+			name:   "partial minutes aren't counted",
+			quotas: testQuotas{teamSpaceID: {MaxPipelineMinutes: ptr.Int64(11)}},
+		},
+		{
+			name:    "quota of the root space reached",
+			quotas:  testQuotas{rootSpaceID: {MaxPipelineMinutes: ptr.Int64(30)}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestService(t, test.quotas, &fakeRepoStore{}, executions)
+
+			err := s.PipelineMinutes(context.Background(), teamRepoID)
+			if (err != nil) != test.wantErr {
+				t.Errorf("PipelineMinutes() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	repos := &fakeRepoStore{
+		counts: map[int64]int64{teamSpaceID: 3},
+		sizes:  map[int64]int64{teamSpaceID: 2},
+	}
+	executions := &fakeExecutionStore{durations: map[int64]int64{teamSpaceID: (5 * time.Minute).Milliseconds()}}
+
+	s := newTestService(t, nil, repos, executions)
+
+	_, err := s.Update(context.Background(), teamSpaceID, types.SpaceQuota{MaxRepos: ptr.Int64(-1)})
+	if err == nil {
+		t.Errorf("Update() with a negative quota succeeded, want error")
+	}
+
+	quota := types.SpaceQuota{MaxRepos: ptr.Int64(5)}
+
+	usage, err := s.Update(context.Background(), teamSpaceID, quota)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if usage.Quota.MaxRepos == nil || *usage.Quota.MaxRepos != 5 ||
+		usage.Quota.MaxStorage != nil || usage.Quota.MaxPipelineMinutes != nil {
+		t.Errorf("quota = %+v, want %+v", usage.Quota, quota)
+	}
+
+	if usage.Usage.Repos != 3 || usage.Usage.Storage != 2*1024 || usage.Usage.PipelineMinutes != 5 {
+		t.Errorf("usage = %+v, want 3 repos, 2 KiB storage and 5 pipeline minutes", usage.Usage)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/ratelimit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Service applies the rate limits of the instance to the requests of principals and anonymous clients.
+// The limits default to the server config and can be overridden by admins, which is stored in the system settings.
+type Service struct {
+	settings          *settings.Service
+	limiter           ratelimit.Limiter
+	defaults          types.RateLimits
+	trustProxyHeaders bool
+	refreshInterval   time.Duration
+
+	mutex    sync.RWMutex
+	limits   *types.RateLimits
+	loadedAt time.Time
+}
+
+func NewService(
+	config *types.Config,
+	settings *settings.Service,
+	limiter ratelimit.Limiter,
+) *Service {
+	return &Service{
+		settings:          settings,
+		limiter:           limiter,
+		defaults:          defaultLimits(config),
+		trustProxyHeaders: config.RateLimit.TrustProxyHeaders,
+		refreshInterval:   config.RateLimit.SettingsRefreshInterval,
+	}
+}
+
+func defaultLimits(config *types.Config) types.RateLimits {
+	return types.RateLimits{
+		Enabled:       config.RateLimit.Enabled,
+		WindowSeconds: int64(config.RateLimit.Window / time.Second),
+		Classes: map[enum.RateLimitClass]types.RateLimit{
+			enum.RateLimitClassAPIRead: {
+				PerPrincipal: config.RateLimit.APIRead.PerPrincipal,
+				PerIP:        config.RateLimit.APIRead.PerIP,
+			},
+			enum.RateLimitClassAPIWrite: {
+				PerPrincipal: config.RateLimit.APIWrite.PerPrincipal,
+				PerIP:        config.RateLimit.APIWrite.PerIP,
+			},
+			enum.RateLimitClassAuth: {
+				PerPrincipal: config.RateLimit.Auth.PerPrincipal,
+				PerIP:        config.RateLimit.Auth.PerIP,
+			},
+			enum.RateLimitClassGit: {
+				PerPrincipal: config.RateLimit.Git.PerPrincipal,
+				PerIP:        config.RateLimit.Git.PerIP,
+			},
+		},
+	}
+}
+
+// Find returns the rate limits of the instance.
+func (s *Service) Find(ctx context.Context) (*types.RateLimits, error) {
+	overrides := &types.RateLimits{}
+	found, err := s.settings.SystemGet(ctx, settings.KeyRateLimits, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limits from settings: %w", err)
+	}
+
+	limits := mergeLimits(s.defaults, overrides, found)
+
+	return &limits, nil
+}
+
+// Update overrides the rate limits of the instance. Classes that aren't provided keep their limits.
+func (s *Service) Update(ctx context.Context, in *types.RateLimits) (*types.RateLimits, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	*limits = mergeLimits(*limits, in, true)
+
+	if err = s.settings.SystemSet(ctx, settings.KeyRateLimits, limits); err != nil {
+		return nil, fmt.Errorf("failed to store rate limits in settings: %w", err)
+	}
+
+	// the other instances pick up the new limits with their next refresh.
+	s.mutex.Lock()
+	s.limits = limits
+	s.loadedAt = time.Now()
+	s.mutex.Unlock()
+
+	return limits, nil
+}
+
+// mergeLimits returns the base limits overridden by the provided limits.
+func mergeLimits(base types.RateLimits, overrides *types.RateLimits, found bool) types.RateLimits {
+	out := types.RateLimits{
+		Enabled:       base.Enabled,
+		WindowSeconds: base.WindowSeconds,
+		Classes:       make(map[enum.RateLimitClass]types.RateLimit, len(base.Classes)),
+	}
+	for class, limit := range base.Classes {
+		out.Classes[class] = limit
+	}
+
+	if !found {
+		return out
+	}
+
+	out.Enabled = overrides.Enabled
+	if overrides.WindowSeconds > 0 {
+		out.WindowSeconds = overrides.WindowSeconds
+	}
+	for class, limit := range overrides.Classes {
+		out.Classes[class] = limit
+	}
+
+	return out
+}
+
+// Take counts the request against the limit of the class. Requests of principals are limited per principal,
+// anonymous requests per IP address. It returns nil if rate limiting is disabled or the request isn't limited.
+func (s *Service) Take(
+	ctx context.Context,
+	class enum.RateLimitClass,
+	principal *types.Principal,
+	ip string,
+) (*ratelimit.Result, error) {
+	limits := s.cachedLimits(ctx)
+	if !limits.Enabled {
+		return nil, nil //nolint:nilnil // nil means that the request isn't limited.
+	}
+
+	var key string
+	var limit int
+	switch {
+	case principal != nil:
+		key = fmt.Sprintf("%s:principal:%d", class, principal.ID)
+		limit = limits.Classes[class].PerPrincipal
+	case ip != "":
+		key = fmt.Sprintf("%s:ip:%s", class, ip)
+		limit = limits.Classes[class].PerIP
+	}
+
+	if key == "" || limit <= 0 {
+		return nil, nil //nolint:nilnil // nil means that the request isn't limited.
+	}
+
+	res, err := s.limiter.Take(ctx, key, limit, limits.Window())
+	if err != nil {
+		return nil, fmt.Errorf("failed to take rate limit: %w", err)
+	}
+
+	return &res, nil
+}
+
+// cachedLimits returns the rate limits of the instance, reloading them from the settings
+// if they weren't loaded within the refresh interval. The last known limits are used if reloading fails.
+func (s *Service) cachedLimits(ctx context.Context) *types.RateLimits {
+	s.mutex.RLock()
+	limits, loadedAt := s.limits, s.loadedAt
+	s.mutex.RUnlock()
+
+	if limits != nil && time.Since(loadedAt) < s.refreshInterval {
+		return limits
+	}
+
+	loaded, err := s.Find(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to reload rate limits, using last known limits")
+		if limits == nil {
+			limits = &s.defaults
+		}
+		loaded = limits
+	}
+
+	s.mutex.Lock()
+	s.limits = loaded
+	s.loadedAt = time.Now()
+	s.mutex.Unlock()
+
+	return loaded
+}
+
+// ClientIP returns the IP address of the client that sent the request.
+// The headers set by reverse proxies are only used if they're configured to be trusted.
+func (s *Service) ClientIP(r *http.Request) string {
+	if s.trustProxyHeaders {
+		return audit.RealIP(r)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
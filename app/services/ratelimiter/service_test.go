@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiter
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeLimits(t *testing.T) {
+	base := types.RateLimits{
+		Enabled:       false,
+		WindowSeconds: 60,
+		Classes: map[enum.RateLimitClass]types.RateLimit{
+			enum.RateLimitClassAPIRead: {PerPrincipal: 100, PerIP: 10},
+			enum.RateLimitClassGit:     {PerPrincipal: 50, PerIP: 5},
+		},
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		out := mergeLimits(base, &types.RateLimits{Enabled: true}, false)
+		require.Equal(t, base, out)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		out := mergeLimits(base, &types.RateLimits{
+			Enabled: true,
+			Classes: map[enum.RateLimitClass]types.RateLimit{
+				enum.RateLimitClassGit: {PerPrincipal: 0, PerIP: 1},
+			},
+		}, true)
+
+		require.True(t, out.Enabled)
+		require.Equal(t, int64(60), out.WindowSeconds)
+		require.Equal(t, base.Classes[enum.RateLimitClassAPIRead], out.Classes[enum.RateLimitClassAPIRead])
+		require.Equal(t, types.RateLimit{PerPrincipal: 0, PerIP: 1}, out.Classes[enum.RateLimitClassGit])
+
+		// the base limits aren't modified.
+		require.Equal(t, types.RateLimit{PerPrincipal: 50, PerIP: 5}, base.Classes[enum.RateLimitClassGit])
+	})
+}
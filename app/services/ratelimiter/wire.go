@@ -12,24 +12,24 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package usergroup
+package ratelimiter
 
 import (
-	"context"
-
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/types"
-)
 
-var _ Resolver = (*GitnessResolver)(nil)
-
-type GitnessResolver struct {
-}
+	"github.com/google/wire"
+)
 
-func NewGitnessResolver() *GitnessResolver {
-	return &GitnessResolver{}
-}
+var WireSet = wire.NewSet(
+	ProvideService,
+)
 
-func (s *GitnessResolver) Resolve(context.Context, string) (*types.UserGroup, error) {
-	// todo: implement once usergroup is supported
-	return nil, ErrNotFound
+func ProvideService(
+	config *types.Config,
+	settings *settings.Service,
+	limiter ratelimit.Limiter,
+) *Service {
+	return NewService(config, settings, limiter)
 }
@@ -12,26 +12,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package usergroup
+package release
 
 import (
-	"context"
 	"fmt"
-
-	"github.com/harness/gitness/types"
 )
 
-type searchService struct {
-}
-
-func NewSearchService() SearchService {
-	return &searchService{}
-}
+const assetBucketPathFmt = "releases/%d/%s"
 
-func (s *searchService) Search(
-	_ context.Context,
-	_ *types.ListQueryFilter,
-	_ string,
-) ([]*types.UserGroupInfo, error) {
-	return nil, fmt.Errorf("not implemented")
+// AssetBucketPath returns the path of a release asset file in the blob store.
+func AssetBucketPath(repoID int64, fileName string) string {
+	return fmt.Sprintf(assetBucketPathFmt, repoID, fileName)
 }
@@ -0,0 +1,468 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const (
+	jobType = "replication-records-cleanup"
+
+	// sequencerLockExpiry defines the expiry of the lock held while replication records get sequenced.
+	sequencerLockExpiry = 30 * time.Second
+
+	// sequenceBatchSize defines the max number of replication records sequenced at once.
+	sequenceBatchSize = 1000
+
+	// staleRecordTimeout defines how long a replication record waits for its push to complete,
+	// after that the record is reconciled with the repository by the cleanup job.
+	staleRecordTimeout = 10 * time.Minute
+
+	// staleRecordBatchSize defines the max number of stale replication records reconciled by a single cleanup.
+	staleRecordBatchSize = 1000
+)
+
+// Service records all reference updates of all repositories in a durable, ordered stream.
+// External disaster recovery tools (consumers) read the stream to keep replicas of the repositories
+// and acknowledge the records they applied, which is used to report their lag
+// and to decide which records are safe to delete.
+//
+// Records are written by the pre-receive hook without any coordination between pushes (outbox),
+// so a push is rejected if its reference updates can't be recorded. The post-receive hook marks
+// the records as having their pack available. Records get their sequence number only once they are
+// committed and their pack is available, when the stream is read, so consumers never miss a record
+// that got committed after a record with a greater ID and never get a record they can't fetch.
+// Records of pushes that never completed are reconciled with the repository by the cleanup job.
+type Service struct {
+	enabled   bool
+	retention time.Duration
+	cron      string
+	maxDur    time.Duration
+
+	tx               dbtx.Transactor
+	git              git.Interface
+	replicationStore store.ReplicationStore
+	locker           *locker.Locker
+	scheduler        *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	git git.Interface,
+	replicationStore store.ReplicationStore,
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:          config.Replication.Enabled,
+		retention:        config.Replication.RetentionTime,
+		cron:             config.Replication.CRON,
+		maxDur:           config.Replication.MaxDuration,
+		tx:               tx,
+		git:              git,
+		replicationStore: replicationStore,
+		locker:           locker,
+		scheduler:        scheduler,
+	}
+}
+
+// Record stores a replication record for each of the reference updates of the repository.
+// The records are stored in the order of the reference updates, before the references get updated,
+// and aren't available to consumers until MarkPackAvailable is called for them.
+func (s *Service) Record(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates []hook.ReferenceUpdate,
+) error {
+	if !s.enabled || len(refUpdates) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, refUpdate := range refUpdates {
+			record := &types.ReplicationRecord{
+				RepoID:     repo.ID,
+				RepoGitUID: repo.GitUID,
+				Ref:        refUpdate.Ref,
+				OldSHA:     refUpdate.Old.String(),
+				NewSHA:     refUpdate.New.String(),
+				Created:    now,
+			}
+
+			if err := s.replicationStore.CreateRecord(ctx, record); err != nil {
+				return fmt.Errorf("failed to create replication record for ref %q: %w", refUpdate.Ref, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record reference updates: %w", err)
+	}
+
+	return nil
+}
+
+// MarkPackAvailable makes the replication records of the completed reference updates available to consumers.
+func (s *Service) MarkPackAvailable(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates []hook.ReferenceUpdate,
+) error {
+	if !s.enabled || len(refUpdates) == 0 {
+		return nil
+	}
+
+	records := make([]*types.ReplicationRecord, len(refUpdates))
+	for i, refUpdate := range refUpdates {
+		records[i] = &types.ReplicationRecord{
+			Ref:    refUpdate.Ref,
+			OldSHA: refUpdate.Old.String(),
+			NewSHA: refUpdate.New.String(),
+		}
+	}
+
+	if _, err := s.replicationStore.MarkPackAvailable(ctx, repo.ID, records); err != nil {
+		return fmt.Errorf("failed to mark replication records as available: %w", err)
+	}
+
+	return nil
+}
+
+// reconcile resolves the replication records whose push didn't report completion in time.
+// A record is made available if the repository reflects its reference update,
+// either directly or through a later update of the reference. Otherwise, the push failed
+// after the pre-receive hook and the record is deleted.
+func (s *Service) reconcile(ctx context.Context) (int, error) {
+	createdBefore := time.Now().Add(-staleRecordTimeout).UnixMilli()
+
+	records, err := s.replicationStore.ListStaleRecords(ctx, createdBefore, staleRecordBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stale replication records: %w", err)
+	}
+
+	for _, record := range records {
+		applied, errApplied := s.isApplied(ctx, record)
+		if errApplied != nil {
+			return 0, errApplied
+		}
+
+		if !applied {
+			if err = s.replicationStore.DeleteRecord(ctx, record.ID); err != nil {
+				return 0, fmt.Errorf("failed to delete stale replication record: %w", err)
+			}
+			continue
+		}
+
+		_, err = s.replicationStore.MarkPackAvailable(ctx, record.RepoID, []*types.ReplicationRecord{record})
+		if err != nil {
+			return 0, fmt.Errorf("failed to mark stale replication record as available: %w", err)
+		}
+	}
+
+	return len(records), nil
+}
+
+func (s *Service) isApplied(ctx context.Context, record *types.ReplicationRecord) (bool, error) {
+	ref, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: record.RepoGitUID},
+		Name:       record.Ref,
+		Type:       gitenum.RefTypeRaw,
+	})
+	switch {
+	case errors.IsNotFound(err):
+		// the deletion of a reference is recorded with the nil SHA.
+		if newSHA, errSHA := sha.New(record.NewSHA); errSHA == nil && newSHA.IsNil() {
+			return true, nil
+		}
+	case err != nil:
+		return false, fmt.Errorf("failed to get reference %q of replication record: %w", record.Ref, err)
+	case ref.SHA.String() == record.NewSHA:
+		return true, nil
+	}
+
+	successor, err := s.replicationStore.HasSuccessor(ctx, record)
+	if err != nil {
+		return false, fmt.Errorf("failed to check successor of replication record: %w", err)
+	}
+
+	return successor, nil
+}
+
+// sequence assigns sequence numbers to all committed replication records that don't have one yet.
+// Only the sequencing is serialized, the creation of the records isn't affected by it.
+func (s *Service) sequence(ctx context.Context) error {
+	unlock, err := s.locker.LockReplicationSequencer(ctx, sequencerLockExpiry)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for {
+		var n int
+		err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+			var errSeq error
+			n, errSeq = s.replicationStore.SequenceRecords(ctx, sequenceBatchSize)
+			return errSeq
+		})
+		if err != nil {
+			return fmt.Errorf("failed to sequence replication records: %w", err)
+		}
+
+		if n < sequenceBatchSize {
+			return nil
+		}
+	}
+}
+
+// ListRecords lists the replication records after the provided sequence number.
+func (s *Service) ListRecords(
+	ctx context.Context,
+	filter types.ReplicationRecordFilter,
+) ([]*types.ReplicationRecord, error) {
+	if !s.enabled {
+		return nil, usererror.BadRequest("Replication isn't enabled.")
+	}
+
+	if err := s.sequence(ctx); err != nil {
+		return nil, err
+	}
+
+	records, err := s.replicationStore.ListRecords(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replication records: %w", err)
+	}
+
+	return records, nil
+}
+
+// CreateConsumer creates a new replication consumer.
+// The consumer starts at the latest sequenced replication record, all older records are considered acknowledged.
+func (s *Service) CreateConsumer(
+	ctx context.Context,
+	principalID int64,
+	identifier string,
+	description string,
+) (*types.ReplicationConsumer, error) {
+	if !s.enabled {
+		return nil, usererror.BadRequest("Replication isn't enabled.")
+	}
+
+	description = strings.TrimSpace(description)
+
+	if err := check.Identifier(identifier); err != nil {
+		return nil, err
+	}
+	if err := check.Description(description); err != nil {
+		return nil, err
+	}
+
+	latestSeq, err := s.replicationStore.LatestSeq(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest replication record: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	consumer := &types.ReplicationConsumer{
+		Identifier:  identifier,
+		Description: description,
+		AckedSeq:    latestSeq,
+		Acked:       now,
+		CreatedBy:   principalID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = s.replicationStore.CreateConsumer(ctx, consumer); err != nil {
+		return nil, fmt.Errorf("failed to create replication consumer: %w", err)
+	}
+
+	return consumer, nil
+}
+
+// FindConsumer returns the replication consumer with its lag.
+func (s *Service) FindConsumer(ctx context.Context, identifier string) (*types.ReplicationConsumer, error) {
+	consumer, err := s.replicationStore.FindConsumerByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find replication consumer: %w", err)
+	}
+
+	if err = s.populateLag(ctx, consumer, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+// ListConsumers returns all replication consumers with their lag.
+func (s *Service) ListConsumers(ctx context.Context) ([]*types.ReplicationConsumer, error) {
+	consumers, err := s.replicationStore.ListConsumers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replication consumers: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	for _, consumer := range consumers {
+		if err = s.populateLag(ctx, consumer, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return consumers, nil
+}
+
+// Ack marks all replication records up to the provided sequence number as applied by the consumer.
+// Acknowledging a record older than the last acknowledged one has no effect.
+func (s *Service) Ack(
+	ctx context.Context,
+	identifier string,
+	seq int64,
+) (*types.ReplicationConsumer, error) {
+	if seq <= 0 {
+		return nil, usererror.BadRequest("A valid replication record sequence number must be provided.")
+	}
+
+	consumer, err := s.replicationStore.FindConsumerByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find replication consumer: %w", err)
+	}
+
+	latestSeq, err := s.replicationStore.LatestSeq(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest replication record: %w", err)
+	}
+
+	if seq > latestSeq {
+		return nil, usererror.BadRequestf("Replication record %d doesn't exist yet.", seq)
+	}
+
+	now := time.Now().UnixMilli()
+
+	updated, err := s.replicationStore.AckConsumer(ctx, consumer.ID, seq, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge replication record: %w", err)
+	}
+
+	if updated {
+		consumer.AckedSeq = seq
+		consumer.Acked = now
+		consumer.Updated = now
+	}
+
+	if err = s.populateLag(ctx, consumer, now); err != nil {
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+// DeleteConsumer deletes the replication consumer.
+// Records that were only retained for the consumer get deleted by the next cleanup.
+func (s *Service) DeleteConsumer(ctx context.Context, identifier string) error {
+	consumer, err := s.replicationStore.FindConsumerByIdentifier(ctx, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find replication consumer: %w", err)
+	}
+
+	if err = s.replicationStore.DeleteConsumer(ctx, consumer.ID); err != nil {
+		return fmt.Errorf("failed to delete replication consumer: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) populateLag(ctx context.Context, consumer *types.ReplicationConsumer, now int64) error {
+	lag, err := s.replicationStore.Lag(ctx, consumer.AckedSeq, now)
+	if err != nil {
+		return fmt.Errorf("failed to get lag of replication consumer %q: %w", consumer.Identifier, err)
+	}
+
+	consumer.Lag = lag
+
+	return nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for replication records cleanup: %w", err)
+	}
+
+	return nil
+}
+
+// Handle reconciles stale replication records and deletes replication records older than the retention time
+// that were acknowledged by all consumers.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	reconciled, err := s.reconcile(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.sequence(ctx); err != nil {
+		return "", err
+	}
+
+	maxSeq, err := s.replicationStore.LatestSeq(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest replication record: %w", err)
+	}
+
+	consumers, err := s.replicationStore.ListConsumers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list replication consumers: %w", err)
+	}
+
+	for _, consumer := range consumers {
+		maxSeq = min(maxSeq, consumer.AckedSeq)
+	}
+
+	createdBefore := time.Now().Add(-s.retention).UnixMilli()
+
+	n, err := s.replicationStore.DeleteRecords(ctx, createdBefore, maxSeq)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete replication records: %w", err)
+	}
+
+	return fmt.Sprintf("reconciled %d and deleted %d replication records", reconciled, n), nil
+}
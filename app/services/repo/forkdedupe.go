@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobTypeForkDeduplicator = "repo-fork-deduplicator"
+
+// ForkDeduplicator periodically repacks all forks to remove objects that are available in the forked repository.
+// Objects pushed to a fork that got merged into the forked repository afterwards are stored twice otherwise.
+type ForkDeduplicator struct {
+	enabled   bool
+	cron      string
+	maxDur    time.Duration
+	git       git.Interface
+	repoStore store.RepoStore
+	scheduler *job.Scheduler
+}
+
+func (d *ForkDeduplicator) Register(ctx context.Context) error {
+	if !d.enabled {
+		return nil
+	}
+
+	err := d.scheduler.AddRecurring(ctx, jobTypeForkDeduplicator, jobTypeForkDeduplicator, d.cron, d.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for fork deduplicator: %w", err)
+	}
+
+	return nil
+}
+
+func (d *ForkDeduplicator) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !d.enabled {
+		return "", nil
+	}
+
+	forks, err := d.repoStore.ListForkGitInfos(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("start deduplication of %d forks", len(forks))
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	actor := git.Identity{
+		Name:  systemPrincipal.DisplayName,
+		Email: systemPrincipal.Email,
+	}
+
+	// repacking is expensive, forks are processed one after the other.
+	for _, fork := range forks {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		err = d.git.DeduplicateRepository(ctx, &git.DeduplicateRepositoryParams{
+			WriteParams: git.WriteParams{
+				RepoUID: fork.GitUID,
+				Actor:   actor,
+			},
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", fork.ID).
+				Str("repo_git_uid", fork.GitUID).
+				Msg("failed to deduplicate fork")
+		}
+	}
+
+	return "", nil
+}
@@ -31,6 +31,7 @@ import (
 
 var WireSet = wire.NewSet(
 	ProvideCalculator,
+	ProvideForkDeduplicator,
 	ProvideService,
 )
 
@@ -59,6 +60,30 @@ func ProvideCalculator(
 	return job, nil
 }
 
+func ProvideForkDeduplicator(
+	config *types.Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*ForkDeduplicator, error) {
+	job := &ForkDeduplicator{
+		enabled:   config.RepoForkDeduplication.Enabled,
+		cron:      config.RepoForkDeduplication.CRON,
+		maxDur:    config.RepoForkDeduplication.MaxDuration,
+		git:       git,
+		repoStore: repoStore,
+		scheduler: scheduler,
+	}
+
+	err := executor.Register(jobTypeForkDeduplicator, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
 func ProvideService(ctx context.Context,
 	config *types.Config,
 	repoEvReporter *repoevents.Reporter,
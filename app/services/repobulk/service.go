@@ -0,0 +1,275 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "repo-bulk-operation"
+	jobMaxRetries  = 0
+	jobMaxDuration = 2 * time.Hour
+	jobUIDPrefix   = "repo-bulk-%d-"
+)
+
+// ErrNotFound is returned if no bulk operation was found for the space.
+var ErrNotFound = errors.New("bulk operation not found")
+
+// Input is the input of a bulk repository operation job.
+type Input struct {
+	Operation        enum.RepoBulkOperation         `json:"operation"`
+	SpaceID          int64                          `json:"space_id"`
+	PrincipalID      int64                          `json:"principal_id"`
+	RepoIDs          []int64                        `json:"repo_ids"`
+	GeneralSettings  *reposettings.GeneralSettings  `json:"general_settings,omitempty"`
+	SecuritySettings *reposettings.SecuritySettings `json:"security_settings,omitempty"`
+	HarnessCodeInfo  *exporter.HarnessCodeInfo      `json:"harness_code_info,omitempty"`
+}
+
+// Service executes operations on many repositories of a space in a background job.
+// Every repository is processed on behalf of the principal that started the operation,
+// so the access checks of the single repository operations apply.
+type Service struct {
+	repoCtrl         *repo.Controller
+	repoSettingsCtrl *reposettings.Controller
+	exporter         *exporter.Repository
+	principalStore   store.PrincipalStore
+	repoStore        store.RepoStore
+	scheduler        *job.Scheduler
+	encrypter        encrypt.Encrypter
+}
+
+func NewService(
+	repoCtrl *repo.Controller,
+	repoSettingsCtrl *reposettings.Controller,
+	exporter *exporter.Repository,
+	principalStore store.PrincipalStore,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return &Service{
+		repoCtrl:         repoCtrl,
+		repoSettingsCtrl: repoSettingsCtrl,
+		exporter:         exporter,
+		principalStore:   principalStore,
+		repoStore:        repoStore,
+		scheduler:        scheduler,
+		encrypter:        encrypter,
+	}
+}
+
+// Run starts a background job that executes the bulk operation and returns the job UID.
+func (s *Service) Run(ctx context.Context, in *Input) (string, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	// the input is encrypted because it might contain the harness code token of the export operation.
+	encryptedData, err := s.encrypter.Encrypt(string(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt job input: %w", err)
+	}
+
+	uid, err := job.UID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job uid: %w", err)
+	}
+
+	jobUID := fmt.Sprintf(jobUIDPrefix, in.SpaceID) + uid
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       base64.StdEncoding.EncodeToString(encryptedData),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run bulk operation job: %w", err)
+	}
+
+	return jobUID, nil
+}
+
+// Progress returns the progress of a bulk operation job of the space.
+func (s *Service) Progress(ctx context.Context, spaceID int64, jobUID string) (job.Progress, error) {
+	if !strings.HasPrefix(jobUID, fmt.Sprintf(jobUIDPrefix, spaceID)) {
+		return job.Progress{}, ErrNotFound
+	}
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil {
+		return job.Progress{}, fmt.Errorf("failed to get job progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Handle is the bulk repository operation background job handler.
+func (s *Service) Handle(ctx context.Context, data string, fn job.ProgressReporter) (string, error) {
+	in, err := s.getJobInput(data)
+	if err != nil {
+		return "", err
+	}
+
+	principal, err := s.principalStore.Find(ctx, in.PrincipalID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal that started the bulk operation: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	result := types.RepoBulkResult{
+		Operation: in.Operation,
+		Total:     len(in.RepoIDs),
+		Succeeded: []string{},
+		Failed:    []types.RepoBulkFailure{},
+	}
+
+	exported := make([]*types.Repository, 0)
+
+	for i, repoID := range in.RepoIDs {
+		repoPath, err := s.process(ctx, session, repoID, &in, &exported)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo.id", repoID).
+				Msgf("failed to apply bulk operation '%s' to repository", in.Operation)
+
+			result.Failed = append(result.Failed, types.RepoBulkFailure{
+				Repo:  repoPath,
+				Error: err.Error(),
+			})
+		} else {
+			result.Succeeded = append(result.Succeeded, repoPath)
+		}
+
+		if err := fn(100*(i+1)/len(in.RepoIDs), ""); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to report bulk operation progress")
+		}
+	}
+
+	if len(exported) > 0 {
+		err = s.exporter.RunManyForSpace(ctx, in.SpaceID, exported, in.HarnessCodeInfo)
+		if err != nil {
+			return "", fmt.Errorf("failed to start export of repositories: %w", err)
+		}
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bulk operation result: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// process applies the bulk operation to a single repository and returns the repository path.
+// Repositories that should be exported are only collected, they are exported together afterward.
+func (s *Service) process(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+	in *Input,
+	exported *[]*types.Repository,
+) (string, error) {
+	repository, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return strconv.FormatInt(repoID, 10), fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if in.Operation == enum.RepoBulkOperationExport {
+		*exported = append(*exported, repository)
+		return repository.Path, nil
+	}
+
+	return repository.Path, s.apply(ctx, session, repository, in)
+}
+
+func (s *Service) apply(
+	ctx context.Context,
+	session *auth.Session,
+	repository *types.Repository,
+	in *Input,
+) error {
+	var err error
+
+	switch in.Operation {
+	case enum.RepoBulkOperationArchive, enum.RepoBulkOperationUnarchive:
+		_, err = s.repoCtrl.UpdateArchived(ctx, session, repository.Path, &repo.UpdateArchivedInput{
+			Archived: in.Operation == enum.RepoBulkOperationArchive,
+		})
+	case enum.RepoBulkOperationDelete:
+		_, err = s.repoCtrl.SoftDelete(ctx, session, repository.Path)
+	case enum.RepoBulkOperationSettings:
+		if in.GeneralSettings != nil {
+			_, err = s.repoSettingsCtrl.GeneralUpdate(ctx, session, repository.Path, in.GeneralSettings)
+			if err != nil {
+				return err
+			}
+		}
+		if in.SecuritySettings != nil {
+			_, err = s.repoSettingsCtrl.SecurityUpdate(ctx, session, repository.Path, in.SecuritySettings)
+		}
+	case enum.RepoBulkOperationExport:
+		return fmt.Errorf("export operation must not be applied to a single repository")
+	default:
+		return fmt.Errorf("unsupported bulk operation '%s'", in.Operation)
+	}
+
+	return err
+}
+
+func (s *Service) getJobInput(data string) (Input, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to base64 decode job input: %w", err)
+	}
+
+	decrypted, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to decrypt job input: %w", err)
+	}
+
+	var input Input
+
+	err = json.NewDecoder(strings.NewReader(decrypted)).Decode(&input)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	return input, nil
+}
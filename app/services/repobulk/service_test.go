@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, Type: enum.PrincipalTypeUser}, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	return &Service{
+		principalStore: fakePrincipalStore{},
+		repoStore: &fakeRepoStore{repos: map[int64]*types.Repository{
+			1: {ID: 1, ParentID: 1, Path: "space/repo-1"},
+		}},
+		encrypter: encrypter,
+	}
+}
+
+// encodeJobInput encodes the job input the same way Run does it.
+func encodeJobInput(t *testing.T, s *Service, in *Input) string {
+	t.Helper()
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("failed to marshal job input: %v", err)
+	}
+
+	encrypted, err := s.encrypter.Encrypt(string(data))
+	if err != nil {
+		t.Fatalf("failed to encrypt job input: %v", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(encrypted)
+}
+
+func TestService_ProgressOfAnotherSpace(t *testing.T) {
+	s := newTestService(t)
+
+	// the scheduler isn't set, the job of another space must be rejected before it's looked up.
+	_, err := s.Progress(context.Background(), 1, "repo-bulk-2-abc")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	_, err = s.Progress(context.Background(), 1, "repo-bulk-12-abc")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found for a space with the same prefix, got %v", err)
+	}
+}
+
+func TestService_HandleFailures(t *testing.T) {
+	s := newTestService(t)
+
+	// the first repository exists but the operation isn't supported, the second repository doesn't exist.
+	data := encodeJobInput(t, s, &Input{
+		Operation:   enum.RepoBulkOperation("rename"),
+		SpaceID:     1,
+		PrincipalID: 100,
+		RepoIDs:     []int64{1, 2},
+	})
+
+	var progress []int
+	out, err := s.Handle(context.Background(), data, func(p int, _ string) error {
+		progress = append(progress, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result types.RepoBulkResult
+	if err = json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+
+	if result.Total != 2 || len(result.Succeeded) != 0 || len(result.Failed) != 2 {
+		t.Fatalf("result = %+v, want 2 failures", result)
+	}
+
+	if result.Failed[0].Repo != "space/repo-1" || !strings.Contains(result.Failed[0].Error, "unsupported") {
+		t.Errorf("first failure = %+v, want unsupported operation of space/repo-1", result.Failed[0])
+	}
+	if result.Failed[1].Repo != "2" || !strings.Contains(result.Failed[1].Error, "failed to find repository") {
+		t.Errorf("second failure = %+v, want repository 2 not found", result.Failed[1])
+	}
+
+	if want := []int{50, 100}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestService_HandleInvalidInput(t *testing.T) {
+	s := newTestService(t)
+
+	// input that isn't encrypted with the key of the service is rejected.
+	data := base64.StdEncoding.EncodeToString([]byte(`{"operation":"delete","repo_ids":[1]}`))
+
+	_, err := s.Handle(context.Background(), data, func(int, string) error { return nil })
+	if err == nil {
+		t.Errorf("expected an error for input that isn't encrypted")
+	}
+}
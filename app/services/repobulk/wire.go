@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	repoCtrl *repo.Controller,
+	repoSettingsCtrl *reposettings.Controller,
+	exporter *exporter.Repository,
+	principalStore store.PrincipalStore,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	encrypter encrypt.Encrypter,
+) (*Service, error) {
+	svc := NewService(repoCtrl, repoSettingsCtrl, exporter, principalStore, repoStore, scheduler, encrypter)
+
+	if err := executor.Register(jobType, svc); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
@@ -0,0 +1,612 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxTemplateRules defines the max number of protection rules a single repo template can contain.
+	maxTemplateRules = 32
+	// maxTemplateWebhooks defines the max number of webhooks a single repo template can contain.
+	maxTemplateWebhooks = 32
+	// maxWebhookSecretLength defines the max allowed length of a webhook secret.
+	maxWebhookSecretLength = 4096
+)
+
+// Service manages repository templates of spaces.
+// A repository template is stored as a space setting and is applied to all repositories
+// created or imported in the space or in any of its sub-spaces.
+type Service struct {
+	tx                  dbtx.Transactor
+	settings            *settings.Service
+	spaceStore          store.SpaceStore
+	ruleStore           store.RuleStore
+	webhookStore        store.WebhookStore
+	protectionManager   *protection.Manager
+	encrypter           encrypt.Encrypter
+	allowLoopback       bool
+	allowPrivateNetwork bool
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+	ruleStore store.RuleStore,
+	webhookStore store.WebhookStore,
+	protectionManager *protection.Manager,
+	encrypter encrypt.Encrypter,
+	allowLoopback bool,
+	allowPrivateNetwork bool,
+) *Service {
+	return &Service{
+		tx:                  tx,
+		settings:            settings,
+		spaceStore:          spaceStore,
+		ruleStore:           ruleStore,
+		webhookStore:        webhookStore,
+		protectionManager:   protectionManager,
+		encrypter:           encrypter,
+		allowLoopback:       allowLoopback,
+		allowPrivateNetwork: allowPrivateNetwork,
+	}
+}
+
+// Find returns the repository template of a space. Webhook secrets are never returned.
+func (s *Service) Find(ctx context.Context, spaceID int64) (*types.RepoTemplate, error) {
+	tmpl, err := s.find(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sanitizeOutput(tmpl), nil
+}
+
+// Update validates and replaces the repository template of a space.
+// Webhooks without a secret keep the secret previously stored for the webhook with the same identifier.
+func (s *Service) Update(
+	ctx context.Context,
+	spaceID int64,
+	in *types.RepoTemplate,
+) (*types.RepoTemplate, error) {
+	if err := s.sanitize(in); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.find(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingSecrets := make(map[string]string, len(existing.Webhooks))
+	for _, hook := range existing.Webhooks {
+		existingSecrets[hook.Identifier] = hook.Secret
+	}
+
+	for i := range in.Webhooks {
+		hook := &in.Webhooks[i]
+		hook.HasSecret = false
+
+		if hook.Secret == "" {
+			hook.Secret = existingSecrets[hook.Identifier]
+			continue
+		}
+
+		encryptedSecret, err := s.encrypter.Encrypt(hook.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+
+		hook.Secret = base64.StdEncoding.EncodeToString(encryptedSecret)
+	}
+
+	err = s.settings.SpaceSet(ctx, spaceID, settings.KeyRepoTemplate, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store repo template: %w", err)
+	}
+
+	return sanitizeOutput(in), nil
+}
+
+// Drift compares the repository with all repository templates that apply to it.
+func (s *Service) Drift(ctx context.Context, repo *types.Repository) ([]types.RepoTemplateDrift, error) {
+	items, err := s.effectiveTemplate(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := make([]types.RepoTemplateDrift, 0, len(items.rules)+len(items.webhooks))
+
+	for _, item := range items.rules {
+		status, _, err := s.ruleDrift(ctx, repo.ID, &item.RepoTemplateRule)
+		if err != nil {
+			return nil, err
+		}
+
+		drift = append(drift, types.RepoTemplateDrift{
+			Kind:       enum.RepoTemplateItemKindRule,
+			Identifier: item.Identifier,
+			SpaceID:    item.spaceID,
+			Status:     status,
+		})
+	}
+
+	for _, item := range items.webhooks {
+		status, _, err := s.webhookDrift(ctx, repo.ID, &item.RepoTemplateWebhook)
+		if err != nil {
+			return nil, err
+		}
+
+		drift = append(drift, types.RepoTemplateDrift{
+			Kind:       enum.RepoTemplateItemKindWebhook,
+			Identifier: item.Identifier,
+			SpaceID:    item.spaceID,
+			Status:     status,
+		})
+	}
+
+	return drift, nil
+}
+
+// Apply creates all template items missing in the repository and overwrites all modified ones.
+// It returns the drift of the repository as it was before the template got applied.
+// All items are applied in a single transaction, so a failure leaves the repository unchanged.
+func (s *Service) Apply(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+) ([]types.RepoTemplateDrift, error) {
+	items, err := s.effectiveTemplate(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []types.RepoTemplateDrift
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		drift, err = s.apply(ctx, principalID, repo, items)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return drift, nil
+}
+
+//nolint:gocognit
+func (s *Service) apply(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	items templateItems,
+) ([]types.RepoTemplateDrift, error) {
+
+	now := time.Now().UnixMilli()
+	drift := make([]types.RepoTemplateDrift, 0, len(items.rules)+len(items.webhooks))
+
+	for _, item := range items.rules {
+		status, rule, err := s.ruleDrift(ctx, repo.ID, &item.RepoTemplateRule)
+		if err != nil {
+			return nil, err
+		}
+
+		drift = append(drift, types.RepoTemplateDrift{
+			Kind:       enum.RepoTemplateItemKindRule,
+			Identifier: item.Identifier,
+			SpaceID:    item.spaceID,
+			Status:     status,
+		})
+
+		switch status {
+		case enum.RepoTemplateDriftStatusMissing:
+			rule = &types.Rule{
+				CreatedBy:  principalID,
+				Created:    now,
+				RepoID:     &repo.ID,
+				SpaceID:    nil,
+				Identifier: item.Identifier,
+			}
+			copyRule(rule, &item.RepoTemplateRule, now)
+
+			if err = s.ruleStore.Create(ctx, rule); err != nil {
+				return nil, fmt.Errorf("failed to create protection rule %q: %w", item.Identifier, err)
+			}
+		case enum.RepoTemplateDriftStatusModified:
+			copyRule(rule, &item.RepoTemplateRule, now)
+
+			if err = s.ruleStore.Update(ctx, rule); err != nil {
+				return nil, fmt.Errorf("failed to update protection rule %q: %w", item.Identifier, err)
+			}
+		case enum.RepoTemplateDriftStatusInSync:
+		}
+	}
+
+	for _, item := range items.webhooks {
+		status, hook, err := s.webhookDrift(ctx, repo.ID, &item.RepoTemplateWebhook)
+		if err != nil {
+			return nil, err
+		}
+
+		drift = append(drift, types.RepoTemplateDrift{
+			Kind:       enum.RepoTemplateItemKindWebhook,
+			Identifier: item.Identifier,
+			SpaceID:    item.spaceID,
+			Status:     status,
+		})
+
+		switch status {
+		case enum.RepoTemplateDriftStatusMissing:
+			secret, err := s.webhookSecret(&item.RepoTemplateWebhook)
+			if err != nil {
+				return nil, err
+			}
+
+			hook = &types.Webhook{
+				CreatedBy:  principalID,
+				Created:    now,
+				ParentID:   repo.ID,
+				ParentType: enum.WebhookParentRepo,
+				Identifier: item.Identifier,
+				Secret:     secret,
+			}
+			copyWebhook(hook, &item.RepoTemplateWebhook, now)
+
+			if err = s.webhookStore.Create(ctx, hook); err != nil {
+				return nil, fmt.Errorf("failed to create webhook %q: %w", item.Identifier, err)
+			}
+		case enum.RepoTemplateDriftStatusModified:
+			_, err = s.webhookStore.UpdateOptLock(ctx, hook, func(hook *types.Webhook) error {
+				copyWebhook(hook, &item.RepoTemplateWebhook, now)
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to update webhook %q: %w", item.Identifier, err)
+			}
+		case enum.RepoTemplateDriftStatusInSync:
+		}
+	}
+
+	return drift, nil
+}
+
+// webhookSecret returns the secret of the template webhook in the form it's stored for webhooks.
+// A template webhook without a secret gets the encrypted empty secret, same as a webhook created via the API.
+func (s *Service) webhookSecret(item *types.RepoTemplateWebhook) (string, error) {
+	if item.Secret == "" {
+		encryptedSecret, err := s.encrypter.Encrypt("")
+		if err != nil {
+			return "", fmt.Errorf("failed to encrypt secret of webhook %q: %w", item.Identifier, err)
+		}
+
+		return string(encryptedSecret), nil
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(item.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret of webhook %q: %w", item.Identifier, err)
+	}
+
+	return string(secret), nil
+}
+
+func (s *Service) find(ctx context.Context, spaceID int64) (*types.RepoTemplate, error) {
+	tmpl := &types.RepoTemplate{}
+
+	_, err := s.settings.SpaceGet(ctx, spaceID, settings.KeyRepoTemplate, tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo template: %w", err)
+	}
+
+	if tmpl.Rules == nil {
+		tmpl.Rules = []types.RepoTemplateRule{}
+	}
+	if tmpl.Webhooks == nil {
+		tmpl.Webhooks = []types.RepoTemplateWebhook{}
+	}
+
+	return tmpl, nil
+}
+
+type templateRule struct {
+	types.RepoTemplateRule
+	spaceID int64
+}
+
+type templateWebhook struct {
+	types.RepoTemplateWebhook
+	spaceID int64
+}
+
+type templateItems struct {
+	rules    []templateRule
+	webhooks []templateWebhook
+}
+
+// effectiveTemplate merges the repo templates of the space and all of its ancestors.
+// If several templates define an item with the same identifier, the one of the closest space is used.
+func (s *Service) effectiveTemplate(ctx context.Context, spaceID int64) (templateItems, error) {
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, spaceID)
+	if err != nil {
+		return templateItems{}, fmt.Errorf("failed to get space ancestors: %w", err)
+	}
+
+	var items templateItems
+	rulesSeen := map[string]struct{}{}
+	webhooksSeen := map[string]struct{}{}
+
+	for _, id := range spaceIDs {
+		tmpl, err := s.find(ctx, id)
+		if err != nil {
+			return templateItems{}, err
+		}
+
+		for _, rule := range tmpl.Rules {
+			if _, ok := rulesSeen[rule.Identifier]; ok {
+				continue
+			}
+			rulesSeen[rule.Identifier] = struct{}{}
+			items.rules = append(items.rules, templateRule{RepoTemplateRule: rule, spaceID: id})
+		}
+
+		for _, hook := range tmpl.Webhooks {
+			if _, ok := webhooksSeen[hook.Identifier]; ok {
+				continue
+			}
+			webhooksSeen[hook.Identifier] = struct{}{}
+			items.webhooks = append(items.webhooks, templateWebhook{RepoTemplateWebhook: hook, spaceID: id})
+		}
+	}
+
+	return items, nil
+}
+
+func (s *Service) ruleDrift(
+	ctx context.Context,
+	repoID int64,
+	item *types.RepoTemplateRule,
+) (enum.RepoTemplateDriftStatus, *types.Rule, error) {
+	rule, err := s.ruleStore.FindByIdentifier(ctx, nil, &repoID, item.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return enum.RepoTemplateDriftStatusMissing, nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find protection rule %q: %w", item.Identifier, err)
+	}
+
+	if rule.Type != item.Type ||
+		rule.State != item.State ||
+		rule.Description != item.Description ||
+		!equalJSON(rule.Pattern, item.Pattern) ||
+		!equalJSON(rule.Definition, item.Definition) {
+		return enum.RepoTemplateDriftStatusModified, rule, nil
+	}
+
+	return enum.RepoTemplateDriftStatusInSync, rule, nil
+}
+
+func (s *Service) webhookDrift(
+	ctx context.Context,
+	repoID int64,
+	item *types.RepoTemplateWebhook,
+) (enum.RepoTemplateDriftStatus, *types.Webhook, error) {
+	hook, err := s.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, repoID, item.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return enum.RepoTemplateDriftStatusMissing, nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find webhook %q: %w", item.Identifier, err)
+	}
+
+	// secrets are ignored as they are stored encrypted (and can be rotated by repo admins).
+	if hook.DisplayName != item.DisplayName ||
+		hook.Description != item.Description ||
+		hook.URL != item.URL ||
+		hook.Enabled != item.Enabled ||
+		hook.Insecure != item.Insecure ||
+		!equalTriggers(hook.Triggers, item.Triggers) ||
+		!equalBranchPatterns(hook.BranchPatterns, item.BranchPatterns) {
+		return enum.RepoTemplateDriftStatusModified, hook, nil
+	}
+
+	return enum.RepoTemplateDriftStatusInSync, hook, nil
+}
+
+func (s *Service) sanitize(in *types.RepoTemplate) error {
+	if len(in.Rules) > maxTemplateRules {
+		return usererror.BadRequestf("A repo template can contain at most %d rules.", maxTemplateRules)
+	}
+	if len(in.Webhooks) > maxTemplateWebhooks {
+		return usererror.BadRequestf("A repo template can contain at most %d webhooks.", maxTemplateWebhooks)
+	}
+
+	if in.Rules == nil {
+		in.Rules = []types.RepoTemplateRule{}
+	}
+	if in.Webhooks == nil {
+		in.Webhooks = []types.RepoTemplateWebhook{}
+	}
+
+	identifiers := map[string]struct{}{}
+	for i := range in.Rules {
+		rule := &in.Rules[i]
+
+		if err := check.Identifier(rule.Identifier); err != nil {
+			return err
+		}
+		if _, ok := identifiers[rule.Identifier]; ok {
+			return usererror.BadRequestf("Duplicate rule identifier %q.", rule.Identifier)
+		}
+		identifiers[rule.Identifier] = struct{}{}
+
+		var ok bool
+		rule.State, ok = rule.State.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("State of rule %q is invalid.", rule.Identifier)
+		}
+
+		if rule.Type == "" {
+			rule.Type = protection.TypeBranch
+		}
+
+		pattern := protection.Pattern{}
+		if len(rule.Pattern) > 0 {
+			if err := json.Unmarshal(rule.Pattern, &pattern); err != nil {
+				return usererror.BadRequestf("Invalid pattern of rule %q: %s", rule.Identifier, err)
+			}
+		}
+		if err := pattern.Validate(); err != nil {
+			return usererror.BadRequestf("Invalid pattern of rule %q: %s", rule.Identifier, err)
+		}
+		rule.Pattern = pattern.JSON()
+
+		if len(rule.Definition) == 0 {
+			return usererror.BadRequestf("Definition of rule %q is missing.", rule.Identifier)
+		}
+
+		var err error
+		rule.Definition, err = s.protectionManager.SanitizeJSON(rule.Type, rule.Definition)
+		if err != nil {
+			return usererror.BadRequestf("Invalid definition of rule %q: %s", rule.Identifier, err)
+		}
+	}
+
+	identifiers = map[string]struct{}{}
+	for i := range in.Webhooks {
+		hook := &in.Webhooks[i]
+
+		if err := check.Identifier(hook.Identifier); err != nil {
+			return err
+		}
+		if _, ok := identifiers[hook.Identifier]; ok {
+			return usererror.BadRequestf("Duplicate webhook identifier %q.", hook.Identifier)
+		}
+		identifiers[hook.Identifier] = struct{}{}
+
+		if hook.DisplayName == "" {
+			hook.DisplayName = hook.Identifier
+		}
+		if err := check.DisplayName(hook.DisplayName); err != nil {
+			return err
+		}
+		if err := check.Description(hook.Description); err != nil {
+			return err
+		}
+		if err := webhook.CheckURL(hook.URL, s.allowLoopback, s.allowPrivateNetwork); err != nil {
+			return err
+		}
+		if len(hook.Secret) > maxWebhookSecretLength {
+			return check.NewValidationErrorf("The secret of a webhook can be at most %d characters long.",
+				maxWebhookSecretLength)
+		}
+		if err := webhook.CheckTriggers(hook.Triggers); err != nil {
+			return err
+		}
+		hook.Triggers = webhook.DeduplicateTriggers(hook.Triggers)
+		if err := webhook.CheckBranchPatterns(hook.BranchPatterns); err != nil {
+			return err
+		}
+		hook.BranchPatterns = webhook.DeduplicateBranchPatterns(hook.BranchPatterns)
+	}
+
+	return nil
+}
+
+// sanitizeOutput removes the webhook secrets from the template.
+func sanitizeOutput(tmpl *types.RepoTemplate) *types.RepoTemplate {
+	out := &types.RepoTemplate{
+		Rules:    tmpl.Rules,
+		Webhooks: make([]types.RepoTemplateWebhook, len(tmpl.Webhooks)),
+	}
+
+	for i, hook := range tmpl.Webhooks {
+		hook.HasSecret = hook.Secret != ""
+		hook.Secret = ""
+		out.Webhooks[i] = hook
+	}
+
+	return out
+}
+
+func copyRule(rule *types.Rule, item *types.RepoTemplateRule, now int64) {
+	rule.Updated = now
+	rule.Type = item.Type
+	rule.State = item.State
+	rule.Description = item.Description
+	rule.Pattern = item.Pattern
+	rule.Definition = item.Definition
+}
+
+func copyWebhook(hook *types.Webhook, item *types.RepoTemplateWebhook, now int64) {
+	hook.Updated = now
+	hook.DisplayName = item.DisplayName
+	hook.Description = item.Description
+	hook.URL = item.URL
+	hook.Enabled = item.Enabled
+	hook.Insecure = item.Insecure
+	hook.Triggers = item.Triggers
+	hook.BranchPatterns = item.BranchPatterns
+}
+
+func equalJSON(a, b json.RawMessage) bool {
+	var valA, valB any
+
+	if err := json.Unmarshal(a, &valA); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &valB); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(valA, valB)
+}
+
+func equalTriggers(a, b []enum.WebhookTrigger) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
+
+func equalBranchPatterns(a, b []string) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
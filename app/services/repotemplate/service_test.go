@@ -0,0 +1,369 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"maps"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeSettingsStore struct {
+	store.SettingsStore
+	values map[int64]json.RawMessage
+}
+
+func (s *fakeSettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	_ string,
+) (json.RawMessage, error) {
+	value, ok := s.values[scopeID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+func (s *fakeSettingsStore) Upsert(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	_ string,
+	value json.RawMessage,
+) error {
+	s.values[scopeID] = value
+	return nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	ancestors map[int64][]int64
+}
+
+func (s *fakeSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	return s.ancestors[spaceID], nil
+}
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules map[string]*types.Rule
+}
+
+func (s *fakeRuleStore) FindByIdentifier(
+	_ context.Context,
+	_, _ *int64,
+	identifier string,
+) (*types.Rule, error) {
+	rule, ok := s.rules[identifier]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return rule, nil
+}
+
+func (s *fakeRuleStore) Create(_ context.Context, rule *types.Rule) error {
+	s.rules[rule.Identifier] = rule
+	return nil
+}
+
+func (s *fakeRuleStore) Update(_ context.Context, rule *types.Rule) error {
+	s.rules[rule.Identifier] = rule
+	return nil
+}
+
+type fakeWebhookStore struct {
+	store.WebhookStore
+	hooks     map[string]*types.Webhook
+	createErr error
+}
+
+func (s *fakeWebhookStore) FindByIdentifier(
+	_ context.Context,
+	_ enum.WebhookParent,
+	_ int64,
+	identifier string,
+) (*types.Webhook, error) {
+	hook, ok := s.hooks[identifier]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return hook, nil
+}
+
+func (s *fakeWebhookStore) Create(_ context.Context, hook *types.Webhook) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+	s.hooks[hook.Identifier] = hook
+	return nil
+}
+
+func (s *fakeWebhookStore) UpdateOptLock(
+	_ context.Context,
+	hook *types.Webhook,
+	mutateFn func(hook *types.Webhook) error,
+) (*types.Webhook, error) {
+	updated := *hook
+	if err := mutateFn(&updated); err != nil {
+		return nil, err
+	}
+	s.hooks[updated.Identifier] = &updated
+	return &updated, nil
+}
+
+// fakeTransactor restores the content of the fake stores if the transaction fails.
+type fakeTransactor struct {
+	ruleStore    *fakeRuleStore
+	webhookStore *fakeWebhookStore
+}
+
+func (t *fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...any) error {
+	rules := maps.Clone(t.ruleStore.rules)
+	hooks := maps.Clone(t.webhookStore.hooks)
+
+	if err := txFn(ctx); err != nil {
+		t.ruleStore.rules = rules
+		t.webhookStore.hooks = hooks
+		return err
+	}
+
+	return nil
+}
+
+type fixture struct {
+	service      *Service
+	encrypter    encrypt.Encrypter
+	ruleStore    *fakeRuleStore
+	webhookStore *fakeWebhookStore
+}
+
+func newFixture(t *testing.T, templates map[int64]types.RepoTemplate, ancestors map[int64][]int64) fixture {
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %s", err)
+	}
+
+	settingsStore := &fakeSettingsStore{values: map[int64]json.RawMessage{}}
+	for spaceID, tmpl := range templates {
+		raw, err := json.Marshal(tmpl)
+		if err != nil {
+			t.Fatalf("failed to marshal template: %s", err)
+		}
+		settingsStore.values[spaceID] = raw
+	}
+
+	ruleStore := &fakeRuleStore{rules: map[string]*types.Rule{}}
+	webhookStore := &fakeWebhookStore{hooks: map[string]*types.Webhook{}}
+
+	return fixture{
+		service: NewService(
+			&fakeTransactor{ruleStore: ruleStore, webhookStore: webhookStore},
+			settings.NewService(settingsStore, &fakeSpaceStore{ancestors: ancestors}, "main"),
+			&fakeSpaceStore{ancestors: ancestors},
+			ruleStore,
+			webhookStore,
+			nil,
+			encrypter,
+			false,
+			false,
+		),
+		encrypter:    encrypter,
+		ruleStore:    ruleStore,
+		webhookStore: webhookStore,
+	}
+}
+
+func templateRule(identifier, description string) types.RepoTemplateRule {
+	return types.RepoTemplateRule{
+		Identifier:  identifier,
+		Description: description,
+		Type:        protection.TypeBranch,
+		State:       enum.RuleStateActive,
+		Pattern:     json.RawMessage(`{"default":true}`),
+		Definition:  json.RawMessage(`{"lifecycle":{"delete_forbidden":true}}`),
+	}
+}
+
+func TestService_EffectiveTemplate(t *testing.T) {
+	templates := map[int64]types.RepoTemplate{
+		1: {
+			Rules:    []types.RepoTemplateRule{templateRule("shared", "root"), templateRule("root", "root")},
+			Webhooks: []types.RepoTemplateWebhook{{Identifier: "hook", URL: "https://root.example.com"}},
+		},
+		3: {
+			Rules: []types.RepoTemplateRule{templateRule("shared", "child")},
+		},
+	}
+
+	// space 3 is a child of space 2, which is a child of the root space 1 (that has no template).
+	f := newFixture(t, templates, map[int64][]int64{3: {3, 2, 1}})
+
+	items, err := f.service.effectiveTemplate(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("effectiveTemplate() error = %v", err)
+	}
+
+	type ruleItem struct {
+		Identifier  string
+		Description string
+		SpaceID     int64
+	}
+	var gotRules []ruleItem
+	for _, rule := range items.rules {
+		gotRules = append(gotRules, ruleItem{rule.Identifier, rule.Description, rule.spaceID})
+	}
+	wantRules := []ruleItem{{"shared", "child", 3}, {"root", "root", 1}}
+	if !reflect.DeepEqual(gotRules, wantRules) {
+		t.Errorf("effectiveTemplate() rules = %v, want %v", gotRules, wantRules)
+	}
+
+	if len(items.webhooks) != 1 || items.webhooks[0].Identifier != "hook" || items.webhooks[0].spaceID != 1 {
+		t.Errorf("effectiveTemplate() webhooks = %v, want webhook \"hook\" of space 1", items.webhooks)
+	}
+}
+
+func TestService_Apply(t *testing.T) {
+	f := newFixture(t, nil, map[int64][]int64{1: {1}})
+
+	encryptedSecret, err := f.encrypter.Encrypt("s3cr3t")
+	if err != nil {
+		t.Fatalf("failed to encrypt secret: %s", err)
+	}
+
+	err = f.service.settings.SpaceSet(context.Background(), 1, settings.KeyRepoTemplate, types.RepoTemplate{
+		Rules: []types.RepoTemplateRule{templateRule("missing", "new"), templateRule("modified", "new")},
+		Webhooks: []types.RepoTemplateWebhook{
+			{
+				Identifier: "with-secret",
+				URL:        "https://example.com/a",
+				Enabled:    true,
+				Secret:     base64.StdEncoding.EncodeToString(encryptedSecret),
+			},
+			{Identifier: "without-secret", URL: "https://example.com/b", Enabled: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to store template: %s", err)
+	}
+
+	repoID := int64(42)
+	existing := templateRule("modified", "old")
+	f.ruleStore.rules["modified"] = &types.Rule{
+		RepoID:      &repoID,
+		Identifier:  existing.Identifier,
+		Description: existing.Description,
+		Type:        existing.Type,
+		State:       existing.State,
+		Pattern:     existing.Pattern,
+		Definition:  existing.Definition,
+	}
+
+	repo := &types.Repository{ID: repoID, ParentID: 1}
+
+	drift, err := f.service.Apply(context.Background(), 7, repo)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	wantStatus := map[string]enum.RepoTemplateDriftStatus{
+		"missing":        enum.RepoTemplateDriftStatusMissing,
+		"modified":       enum.RepoTemplateDriftStatusModified,
+		"with-secret":    enum.RepoTemplateDriftStatusMissing,
+		"without-secret": enum.RepoTemplateDriftStatusMissing,
+	}
+	for _, d := range drift {
+		if d.Status != wantStatus[d.Identifier] {
+			t.Errorf("Apply() drift of %q = %s, want %s", d.Identifier, d.Status, wantStatus[d.Identifier])
+		}
+	}
+	if len(drift) != len(wantStatus) {
+		t.Errorf("Apply() returned %d drift entries, want %d", len(drift), len(wantStatus))
+	}
+
+	if rule := f.ruleStore.rules["missing"]; rule == nil || rule.RepoID == nil || *rule.RepoID != repoID {
+		t.Errorf("Apply() didn't create rule \"missing\" for the repo")
+	}
+	if rule := f.ruleStore.rules["modified"]; rule.Description != "new" {
+		t.Errorf("Apply() rule \"modified\" description = %q, want %q", rule.Description, "new")
+	}
+
+	for identifier, wantSecret := range map[string]string{"with-secret": "s3cr3t", "without-secret": ""} {
+		hook := f.webhookStore.hooks[identifier]
+		if hook == nil {
+			t.Errorf("Apply() didn't create webhook %q", identifier)
+			continue
+		}
+		if hook.CreatedBy != 7 || hook.ParentID != repoID || hook.ParentType != enum.WebhookParentRepo {
+			t.Errorf("Apply() created webhook %q with wrong owner: %+v", identifier, hook)
+		}
+
+		// the secret must be stored encrypted, the same way as for webhooks created via the API.
+		secret, err := f.encrypter.Decrypt([]byte(hook.Secret))
+		if err != nil {
+			t.Errorf("Apply() stored undecryptable secret for webhook %q: %s", identifier, err)
+			continue
+		}
+		if secret != wantSecret {
+			t.Errorf("Apply() webhook %q secret = %q, want %q", identifier, secret, wantSecret)
+		}
+	}
+
+	drift, err = f.service.Apply(context.Background(), 7, repo)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	for _, d := range drift {
+		if d.Status != enum.RepoTemplateDriftStatusInSync {
+			t.Errorf("Apply() second drift of %q = %s, want %s", d.Identifier, d.Status,
+				enum.RepoTemplateDriftStatusInSync)
+		}
+	}
+}
+
+func TestService_ApplyRollback(t *testing.T) {
+	f := newFixture(t, map[int64]types.RepoTemplate{
+		1: {
+			Rules:    []types.RepoTemplateRule{templateRule("rule", "new")},
+			Webhooks: []types.RepoTemplateWebhook{{Identifier: "hook", URL: "https://example.com", Enabled: true}},
+		},
+	}, map[int64][]int64{1: {1}})
+
+	f.webhookStore.createErr = errors.New("failed")
+
+	_, err := f.service.Apply(context.Background(), 7, &types.Repository{ID: 42, ParentID: 1})
+	if err == nil {
+		t.Fatalf("Apply() expected an error")
+	}
+
+	// the rule created before the failing webhook must not remain in the repository.
+	if len(f.ruleStore.rules) != 0 || len(f.webhookStore.hooks) != 0 {
+		t.Errorf("Apply() left partially applied items: rules=%v, webhooks=%v",
+			f.ruleStore.rules, f.webhookStore.hooks)
+	}
+}
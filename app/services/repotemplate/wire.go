@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplate
+
+import (
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+	ruleStore store.RuleStore,
+	webhookStore store.WebhookStore,
+	protectionManager *protection.Manager,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return NewService(
+		tx,
+		settings,
+		spaceStore,
+		ruleStore,
+		webhookStore,
+		protectionManager,
+		encrypter,
+		config.Webhook.AllowLoopback,
+		config.Webhook.AllowPrivateNetwork,
+	)
+}
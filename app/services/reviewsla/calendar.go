@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewsla
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// maxCalendarDays limits the number of days the calendar walks through,
+	// to protect against calendars without any business hours.
+	maxCalendarDays = 5 * 366
+)
+
+// calendar measures time in business hours.
+type calendar struct {
+	loc       *time.Location
+	workDays  [7]bool
+	startHour int
+	endHour   int
+	holidays  map[string]struct{}
+}
+
+func newCalendar(in types.BusinessCalendar) (*calendar, error) {
+	loc := time.UTC
+	if in.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(in.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", in.TimeZone)
+		}
+	}
+
+	if len(in.WorkDays) == 0 {
+		return nil, fmt.Errorf("at least one work day is required")
+	}
+
+	c := &calendar{
+		loc:       loc,
+		startHour: in.StartHour,
+		endHour:   in.EndHour,
+		holidays:  make(map[string]struct{}, len(in.Holidays)),
+	}
+
+	for _, day := range in.WorkDays {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("invalid work day %d", day)
+		}
+		c.workDays[day] = true
+	}
+
+	if in.StartHour < 0 || in.EndHour > 24 || in.StartHour >= in.EndHour {
+		return nil, fmt.Errorf("invalid business hours %d-%d", in.StartHour, in.EndHour)
+	}
+
+	for _, holiday := range in.Holidays {
+		if _, err := time.Parse(dateLayout, holiday); err != nil {
+			return nil, fmt.Errorf("invalid holiday %q, expected format YYYY-MM-DD", holiday)
+		}
+		c.holidays[holiday] = struct{}{}
+	}
+
+	return c, nil
+}
+
+// businessHours returns the business hours of the day of the provided time.
+// It returns false if the day isn't a business day.
+func (c *calendar) businessHours(t time.Time) (time.Time, time.Time, bool) {
+	if !c.workDays[t.Weekday()] {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if _, ok := c.holidays[t.Format(dateLayout)]; ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	y, m, d := t.Date()
+	return time.Date(y, m, d, c.startHour, 0, 0, 0, c.loc), time.Date(y, m, d, c.endHour, 0, 0, 0, c.loc), true
+}
+
+// nextDay returns the start of the day following the day of the provided time.
+func (c *calendar) nextDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, c.loc)
+}
+
+// Add returns the time at which the provided amount of business time has passed since start.
+func (c *calendar) Add(start time.Time, d time.Duration) time.Time {
+	t := start.In(c.loc)
+	for range maxCalendarDays {
+		from, to, ok := c.businessHours(t)
+		if ok && t.Before(to) {
+			if t.Before(from) {
+				t = from
+			}
+
+			available := to.Sub(t)
+			if d <= available {
+				return t.Add(d)
+			}
+
+			d -= available
+		}
+
+		t = c.nextDay(t)
+	}
+
+	return t
+}
+
+// Elapsed returns the amount of business time between start and end.
+func (c *calendar) Elapsed(start, end time.Time) time.Duration {
+	var elapsed time.Duration
+
+	t := start.In(c.loc)
+	for i := 0; i < maxCalendarDays && t.Before(end); i++ {
+		from, to, ok := c.businessHours(t)
+		if ok {
+			if t.After(from) {
+				from = t
+			}
+			if end.Before(to) {
+				to = end
+			}
+			if from.Before(to) {
+				elapsed += to.Sub(from)
+			}
+		}
+
+		t = c.nextDay(t)
+	}
+
+	return elapsed
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewsla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestCalendar(t *testing.T) {
+	cal, err := newCalendar(types.BusinessCalendar{
+		WorkDays:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		StartHour: 9,
+		EndHour:   17,
+		Holidays:  []string{"2024-01-03"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create calendar: %s", err)
+	}
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.January, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		d     time.Duration
+		want  time.Time
+	}{
+		{
+			name:  "within business hours",
+			start: at(1, 10),
+			d:     2 * time.Hour,
+			want:  at(1, 12),
+		},
+		{
+			name:  "before business hours",
+			start: at(1, 7),
+			d:     time.Hour,
+			want:  at(1, 10),
+		},
+		{
+			name:  "overnight",
+			start: at(1, 16),
+			d:     2 * time.Hour,
+			want:  at(2, 10),
+		},
+		{
+			name:  "over holiday",
+			start: at(2, 16),
+			d:     2 * time.Hour,
+			want:  at(4, 10),
+		},
+		{
+			name:  "over weekend",
+			start: at(5, 16),
+			d:     2 * time.Hour,
+			want:  at(8, 10),
+		},
+		{
+			name:  "from weekend",
+			start: at(6, 12),
+			d:     time.Hour,
+			want:  at(8, 10),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := cal.Add(test.start, test.d); !got.Equal(test.want) {
+				t.Errorf("Add: want %s, got %s", test.want, got)
+			}
+
+			if got := cal.Elapsed(test.start, test.want); got != test.d {
+				t.Errorf("Elapsed: want %s, got %s", test.d, got)
+			}
+		})
+	}
+}
+
+func TestNewCalendarInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   types.BusinessCalendar
+	}{
+		{
+			name: "no work days",
+			in:   types.BusinessCalendar{StartHour: 9, EndHour: 17},
+		},
+		{
+			name: "invalid hours",
+			in:   types.BusinessCalendar{WorkDays: []time.Weekday{time.Monday}, StartHour: 17, EndHour: 9},
+		},
+		{
+			name: "invalid time zone",
+			in:   types.BusinessCalendar{TimeZone: "Nowhere/Land", WorkDays: []time.Weekday{time.Monday}, EndHour: 24},
+		},
+		{
+			name: "invalid holiday",
+			in:   types.BusinessCalendar{WorkDays: []time.Weekday{time.Monday}, EndHour: 24, Holidays: []string{"01/01"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := newCalendar(test.in); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewsla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// startOnPullReqCreated starts the review SLA of a newly created pull request.
+func (s *Service) startOnPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	return s.start(ctx, event.Payload.PullReqID, event.Payload.TargetRepoID, event.Timestamp)
+}
+
+// startOnPullReqReopened restarts the review SLA of a reopened pull request.
+func (s *Service) startOnPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload],
+) error {
+	return s.start(ctx, event.Payload.PullReqID, event.Payload.TargetRepoID, event.Timestamp)
+}
+
+// respondOnReviewSubmitted records the first response to a pull request when a review gets submitted.
+func (s *Service) respondOnReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.respond(ctx, event.Payload.PullReqID, event.Payload.ReviewerID, event.Timestamp)
+}
+
+// respondOnCommentCreated records the first response to a pull request when a comment gets created.
+func (s *Service) respondOnCommentCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload],
+) error {
+	return s.respond(ctx, event.Payload.PullReqID, event.Payload.PrincipalID, event.Timestamp)
+}
+
+// closeOnPullReqClosed stops the review SLA of a closed pull request.
+func (s *Service) closeOnPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.close(ctx, event.Payload.PullReqID, event.Timestamp)
+}
+
+// closeOnPullReqMerged stops the review SLA of a merged pull request.
+func (s *Service) closeOnPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.close(ctx, event.Payload.PullReqID, event.Timestamp)
+}
+
+func (s *Service) start(ctx context.Context, pullReqID, repoID int64, started time.Time) error {
+	policy, cal, err := s.policyForRepo(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("repo with id '%d' doesn't exist anymore", repoID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if policy == nil {
+		return nil
+	}
+
+	sla := &types.PullReqReviewSLA{
+		PullReqID: pullReqID,
+		RepoID:    repoID,
+		Started:   started.UnixMilli(),
+		DueAt:     cal.Add(started, time.Duration(policy.FirstResponseHours)*time.Hour).UnixMilli(),
+	}
+
+	if policy.WarningPercent > 0 {
+		warnAfter := time.Duration(policy.FirstResponseHours) * time.Hour * time.Duration(policy.WarningPercent) / 100
+		sla.WarnAt = cal.Add(started, warnAfter).UnixMilli()
+	}
+
+	if err := s.slaStore.Upsert(ctx, sla); err != nil {
+		return fmt.Errorf("failed to store review SLA of pull request: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) respond(ctx context.Context, pullReqID, principalID int64, responded time.Time) error {
+	sla, err := s.slaStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil // the pull request isn't subject to a review SLA
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find review SLA of pull request: %w", err)
+	}
+
+	if sla.Responded != nil || sla.Closed != nil {
+		return nil
+	}
+
+	pr, err := s.pullReqStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pull request with id '%d' doesn't exist anymore", pullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.CreatedBy == principalID {
+		return nil // the author's own activity doesn't count as a response
+	}
+
+	// the response time is measured in business time if the pull request is still subject to a review SLA.
+	started := time.UnixMilli(sla.Started)
+	responseTime := responded.Sub(started)
+
+	_, cal, err := s.policyForRepo(ctx, sla.RepoID)
+	if err != nil {
+		return err
+	}
+	if cal != nil {
+		responseTime = cal.Elapsed(started, responded)
+	}
+
+	_, err = s.slaStore.MarkResponded(ctx, pullReqID, responded.UnixMilli(), responseTime.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("failed to mark review SLA of pull request as responded: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) close(ctx context.Context, pullReqID int64, closed time.Time) error {
+	err := s.slaStore.MarkClosed(ctx, pullReqID, closed.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to mark review SLA of pull request as closed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,362 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewsla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pullreq-review-sla-check"
+
+	// maxFirstResponseHours defines the max allowed review SLA.
+	maxFirstResponseHours = 30 * 24
+
+	// alertBatchSize defines the number of review SLAs processed at once by the check job.
+	alertBatchSize = 100
+
+	// defaultReportRange defines the time range of a review SLA report if none is provided.
+	defaultReportRange = 30 * 24 * time.Hour
+)
+
+// Service tracks the time pull requests wait for their first review and reports SLA warnings and breaches.
+// The review SLA policy is stored as a space setting and applies to all pull requests of the space and
+// of its sub-spaces, unless a sub-space has a review SLA policy configured itself.
+type Service struct {
+	enabled bool
+	cron    string
+	maxDur  time.Duration
+
+	settings        *settings.Service
+	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
+	pullReqStore    store.PullReqStore
+	slaStore        store.PullReqReviewSLAStore
+	pullreqReporter *pullreqevents.Reporter
+	scheduler       *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	slaStore store.PullReqReviewSLAStore,
+	pullreqReporter *pullreqevents.Reporter,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		enabled:         config.ReviewSLA.Enabled,
+		cron:            config.ReviewSLA.CRON,
+		maxDur:          config.ReviewSLA.MaxDuration,
+		settings:        settings,
+		spaceStore:      spaceStore,
+		repoStore:       repoStore,
+		pullReqStore:    pullReqStore,
+		slaStore:        slaStore,
+		pullreqReporter: pullreqReporter,
+		scheduler:       scheduler,
+	}
+}
+
+// Find returns the review SLA configuration of a space.
+func (s *Service) Find(ctx context.Context, spaceID int64) (*types.SpaceReviewSLA, error) {
+	policy, err := settings.SpaceGet[*types.ReviewSLAPolicy](ctx, s.settings, spaceID, settings.KeyReviewSLA, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review SLA policy of space: %w", err)
+	}
+
+	effectivePolicy, err := s.Resolve(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SpaceReviewSLA{
+		Policy:          policy,
+		EffectivePolicy: effectivePolicy,
+	}, nil
+}
+
+// Update replaces the review SLA policy of the space.
+// Providing no policy makes the space inherit the review SLA policy again.
+// The policy applies to pull requests opened or reopened afterwards.
+func (s *Service) Update(
+	ctx context.Context,
+	spaceID int64,
+	policy *types.ReviewSLAPolicy,
+) (*types.SpaceReviewSLA, error) {
+	if policy != nil {
+		if err := sanitizePolicy(policy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.settings.SpaceSet(ctx, spaceID, settings.KeyReviewSLA, policy); err != nil {
+		return nil, fmt.Errorf("failed to store review SLA policy of space: %w", err)
+	}
+
+	return s.Find(ctx, spaceID)
+}
+
+func sanitizePolicy(policy *types.ReviewSLAPolicy) error {
+	if policy.FirstResponseHours < 1 || policy.FirstResponseHours > maxFirstResponseHours {
+		return usererror.BadRequestf("First response hours must be between 1 and %d.", maxFirstResponseHours)
+	}
+
+	if policy.WarningPercent < 0 || policy.WarningPercent > 99 {
+		return usererror.BadRequest("Warning percent must be between 0 and 99.")
+	}
+
+	if len(policy.Calendar.WorkDays) == 0 {
+		policy.Calendar.WorkDays = []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}
+	}
+
+	if policy.Calendar.StartHour == 0 && policy.Calendar.EndHour == 0 {
+		policy.Calendar.EndHour = 24
+	}
+
+	if policy.Calendar.Holidays == nil {
+		policy.Calendar.Holidays = []string{}
+	}
+
+	if _, err := newCalendar(policy.Calendar); err != nil {
+		return usererror.BadRequestf("Invalid business calendar: %s.", err)
+	}
+
+	return nil
+}
+
+// Resolve returns the review SLA policy applied to the pull requests of the space.
+// It's the policy configured for the space or for its closest ancestor, nil if there's none.
+func (s *Service) Resolve(ctx context.Context, spaceID int64) (*types.ReviewSLAPolicy, error) {
+	ancestors, err := s.spaceStore.GetAncestors(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestors of space: %w", err)
+	}
+
+	parents := make(map[int64]int64, len(ancestors))
+	for _, space := range ancestors {
+		parents[space.ID] = space.ParentID
+	}
+
+	for id := spaceID; id > 0; id = parents[id] {
+		policy, err := settings.SpaceGet[*types.ReviewSLAPolicy](ctx, s.settings, id, settings.KeyReviewSLA, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get review SLA policy of space %d: %w", id, err)
+		}
+
+		if policy != nil {
+			return policy, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// Report returns the review SLA compliance report of the pull requests of the space and its sub-spaces.
+func (s *Service) Report(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ReviewSLAReportFilter,
+) (*types.ReviewSLAReport, error) {
+	now := time.Now()
+
+	if filter.To <= 0 {
+		filter.To = now.UnixMilli()
+	}
+	if filter.From <= 0 {
+		filter.From = filter.To - defaultReportRange.Milliseconds()
+	}
+	if filter.From >= filter.To {
+		return nil, usererror.BadRequest("The report start time must be before its end time.")
+	}
+
+	spaces, err := s.spaceStore.GetDescendantsData(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space descendant data: %w", err)
+	}
+
+	spaceIDs := make([]int64, len(spaces))
+	for i := range spaces {
+		spaceIDs[i] = spaces[i].ID
+	}
+
+	report, err := s.slaStore.Report(ctx, spaceIDs, filter, now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review SLA report: %w", err)
+	}
+
+	return report, nil
+}
+
+// policyForRepo returns the review SLA policy of the repository and its business calendar.
+// It returns nil if the pull requests of the repository aren't subject to a review SLA.
+func (s *Service) policyForRepo(
+	ctx context.Context,
+	repoID int64,
+) (*types.ReviewSLAPolicy, *calendar, error) {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	policy, err := s.Resolve(ctx, repo.ParentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if policy == nil || !policy.Enabled {
+		return nil, nil, nil
+	}
+
+	cal, err := newCalendar(policy.Calendar)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid business calendar of review SLA policy: %w", err)
+	}
+
+	return policy, cal, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for review SLA check: %w", err)
+	}
+
+	return nil
+}
+
+// Handle reports warnings and breaches of review SLAs of pull requests still waiting for their first response.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	var warned, breached, failed int
+	for {
+		now := time.Now().UnixMilli()
+
+		slas, err := s.slaStore.ListAlertable(ctx, now, alertBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list alertable review SLAs: %w", err)
+		}
+
+		for _, sla := range slas {
+			isBreach := sla.DueAt <= now
+
+			reported, err := s.markAndReport(ctx, sla, isBreach, now)
+			if err != nil {
+				// the review SLA stays unmarked, so the alert is retried by the next run of the job.
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("pullreq_id", sla.PullReqID).
+					Msg("failed to report review SLA alert")
+				failed++
+				continue
+			}
+			if !reported {
+				continue
+			}
+
+			if isBreach {
+				breached++
+			} else {
+				warned++
+			}
+		}
+
+		// stop on failures, otherwise the same review SLAs would be listed over and over again.
+		if len(slas) < alertBatchSize || failed > 0 || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return fmt.Sprintf("reported %d review SLA warnings and %d breaches", warned, breached), nil
+}
+
+// markAndReport marks the review SLA as warned or breached and reports the alert.
+// The event is sent only after the mark is stored, so it's never sent for a mark that didn't persist.
+// It returns false if the alert was already reported or the pull request doesn't exist anymore.
+func (s *Service) markAndReport(
+	ctx context.Context,
+	sla *types.PullReqReviewSLA,
+	isBreach bool,
+	now int64,
+) (bool, error) {
+	// the pull request is loaded before the mark, so the event can't be lost after the review SLA is marked.
+	pr, err := s.pullReqStore.Find(ctx, sla.PullReqID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	var updated bool
+	if isBreach {
+		updated, err = s.slaStore.MarkBreached(ctx, sla.PullReqID, now)
+	} else {
+		updated, err = s.slaStore.MarkWarned(ctx, sla.PullReqID, now)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to mark review SLA: %w", err)
+	}
+	if !updated || pr == nil {
+		return false, nil
+	}
+
+	s.reportAlert(ctx, pr, sla, isBreach)
+
+	return true, nil
+}
+
+func (s *Service) reportAlert(ctx context.Context, pr *types.PullReq, sla *types.PullReqReviewSLA, isBreach bool) {
+	base := pullreqevents.Base{
+		PullReqID:    pr.ID,
+		SourceRepoID: pr.SourceRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		PrincipalID:  bootstrap.NewSystemServiceSession().Principal.ID,
+		Number:       pr.Number,
+	}
+
+	if isBreach {
+		s.pullreqReporter.ReviewSLABreached(ctx, &pullreqevents.ReviewSLABreachedPayload{
+			Base:  base,
+			DueAt: sla.DueAt,
+		})
+	} else {
+		s.pullreqReporter.ReviewSLAWarning(ctx, &pullreqevents.ReviewSLAWarningPayload{
+			Base:  base,
+			DueAt: sla.DueAt,
+		})
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+var builtInDisplayNames = map[enum.MembershipRole]string{
+	enum.MembershipRoleReader:      "Reader",
+	enum.MembershipRoleExecutor:    "Executor",
+	enum.MembershipRoleContributor: "Contributor",
+	enum.MembershipRoleSpaceOwner:  "Space Owner",
+}
+
+// Service manages the membership roles. The predefined roles are always available,
+// custom roles are defined by admins and can be assigned to space and repository members.
+type Service struct {
+	roleStore                store.RoleStore
+	membershipStore          store.MembershipStore
+	repoMembershipStore      store.RepoMembershipStore
+	userGroupMembershipStore store.UserGroupMembershipStore
+}
+
+func NewService(
+	roleStore store.RoleStore,
+	membershipStore store.MembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
+) *Service {
+	return &Service{
+		roleStore:                roleStore,
+		membershipStore:          membershipStore,
+		repoMembershipStore:      repoMembershipStore,
+		userGroupMembershipStore: userGroupMembershipStore,
+	}
+}
+
+type CreateInput struct {
+	Identifier  string            `json:"identifier"`
+	DisplayName string            `json:"display_name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+type UpdateInput struct {
+	DisplayName *string           `json:"display_name"`
+	Description *string           `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+// List returns the predefined roles followed by all custom roles.
+func (s *Service) List(ctx context.Context) ([]*types.Role, error) {
+	customRoles, err := s.roleStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+
+	roles := make([]*types.Role, 0, len(enum.MembershipRoles)+len(customRoles))
+	for _, role := range enum.MembershipRoles {
+		roles = append(roles, builtInRole(role))
+	}
+
+	return append(roles, customRoles...), nil
+}
+
+// Find returns the predefined or custom role with the provided identifier.
+func (s *Service) Find(ctx context.Context, identifier string) (*types.Role, error) {
+	if role, ok := enum.MembershipRole(identifier).Sanitize(); ok {
+		return builtInRole(role), nil
+	}
+
+	role, err := s.roleStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Role '%s' not found.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+
+	return role, nil
+}
+
+// Sanitize returns the canonical form of a role that is about to be assigned to a member.
+func (s *Service) Sanitize(ctx context.Context, role enum.MembershipRole) (enum.MembershipRole, error) {
+	if role == "" {
+		return "", errors.InvalidArgument("Role must be provided.")
+	}
+
+	if builtIn, ok := role.Sanitize(); ok {
+		return builtIn, nil
+	}
+
+	customRole, err := s.roleStore.FindByIdentifier(ctx, string(role))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", errors.InvalidArgument("Role '%s' doesn't exist.", role)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find role: %w", err)
+	}
+
+	return enum.MembershipRole(customRole.Identifier), nil
+}
+
+// Create creates a new custom role.
+func (s *Service) Create(ctx context.Context, createdBy int64, in *CreateInput) (*types.Role, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	role := &types.Role{
+		Identifier:  in.Identifier,
+		DisplayName: in.DisplayName,
+		Description: in.Description,
+		Permissions: in.Permissions,
+		CreatedBy:   createdBy,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err := s.roleStore.Create(ctx, role)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Role '%s' already exists.", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return role, nil
+}
+
+// Update updates a custom role. Changed permissions apply to all members with the role.
+func (s *Service) Update(ctx context.Context, identifier string, in *UpdateInput) (*types.Role, error) {
+	role, err := s.findCustom(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.DisplayName != nil {
+		role.DisplayName = *in.DisplayName
+	}
+	if in.Description != nil {
+		role.Description = *in.Description
+	}
+	if in.Permissions != nil {
+		role.Permissions = in.Permissions
+	}
+	role.Updated = time.Now().UnixMilli()
+
+	if err = s.roleStore.Update(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return role, nil
+}
+
+// Delete deletes a custom role. Roles that are still assigned to space, repository or usergroup members
+// can't be deleted.
+func (s *Service) Delete(ctx context.Context, identifier string) error {
+	role, err := s.findCustom(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	spaceCount, err := s.membershipStore.CountByRole(ctx, enum.MembershipRole(role.Identifier))
+	if err != nil {
+		return fmt.Errorf("failed to count space memberships with role: %w", err)
+	}
+
+	repoCount, err := s.repoMembershipStore.CountByRole(ctx, enum.MembershipRole(role.Identifier))
+	if err != nil {
+		return fmt.Errorf("failed to count repository memberships with role: %w", err)
+	}
+
+	userGroupCount, err := s.userGroupMembershipStore.CountByRole(ctx, enum.MembershipRole(role.Identifier))
+	if err != nil {
+		return fmt.Errorf("failed to count usergroup memberships with role: %w", err)
+	}
+
+	if spaceCount+repoCount+userGroupCount > 0 {
+		return errors.Conflict("Role '%s' is assigned to %d space, %d repository and %d usergroup members.",
+			role.Identifier, spaceCount, repoCount, userGroupCount)
+	}
+
+	if err = s.roleStore.Delete(ctx, role.ID); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) findCustom(ctx context.Context, identifier string) (*types.Role, error) {
+	if enum.MembershipRole(identifier).IsBuiltIn() {
+		return nil, errors.InvalidArgument("Predefined role '%s' can't be modified.", identifier)
+	}
+
+	role, err := s.roleStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Role '%s' not found.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+
+	return role, nil
+}
+
+func builtInRole(role enum.MembershipRole) *types.Role {
+	return &types.Role{
+		Identifier:  string(role),
+		DisplayName: builtInDisplayNames[role],
+		Permissions: role.Permissions(),
+		BuiltIn:     true,
+	}
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if enum.MembershipRole(strings.ToLower(in.Identifier)).IsBuiltIn() {
+		return errors.InvalidArgument("Identifier '%s' is reserved for a predefined role.", in.Identifier)
+	}
+
+	if in.DisplayName == "" {
+		in.DisplayName = in.Identifier
+	}
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	permissions, err := sanitizePermissions(in.Permissions)
+	if err != nil {
+		return err
+	}
+	in.Permissions = permissions
+
+	return nil
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.DisplayName != nil {
+		*in.DisplayName = strings.TrimSpace(*in.DisplayName)
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Permissions != nil {
+		permissions, err := sanitizePermissions(in.Permissions)
+		if err != nil {
+			return err
+		}
+		in.Permissions = permissions
+	}
+
+	return nil
+}
+
+func sanitizePermissions(permissions []enum.Permission) ([]enum.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, errors.InvalidArgument("Role must grant at least one permission.")
+	}
+
+	for _, permission := range permissions {
+		if !enum.IsMembershipPermission(permission) {
+			return nil, errors.InvalidArgument("Permission '%s' can't be granted by a role.", permission)
+		}
+	}
+
+	permissions = slices.Clone(permissions)
+	slices.Sort(permissions)
+
+	return slices.Compact(permissions), nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizePermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []enum.Permission
+		want    []enum.Permission
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			input:   nil,
+			wantErr: true,
+		},
+		{
+			name: "sorted and deduplicated",
+			input: []enum.Permission{
+				enum.PermissionRepoPush,
+				enum.PermissionRepoView,
+				enum.PermissionRepoPush,
+			},
+			want: []enum.Permission{
+				enum.PermissionRepoPush,
+				enum.PermissionRepoView,
+			},
+		},
+		{
+			name:    "not a membership permission",
+			input:   []enum.Permission{enum.PermissionRepoView, enum.PermissionUserEdit},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sanitizePermissions(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCreateInputSanitize(t *testing.T) {
+	in := &CreateInput{
+		Identifier:  " Space_Owner ",
+		Permissions: []enum.Permission{enum.PermissionRepoView},
+	}
+	require.Error(t, in.sanitize(), "identifiers of predefined roles are reserved")
+
+	in = &CreateInput{
+		Identifier:  "maintainer",
+		Permissions: []enum.Permission{enum.PermissionRepoMerge, enum.PermissionRepoView},
+	}
+	require.NoError(t, in.sanitize())
+	require.Equal(t, "maintainer", in.DisplayName)
+}
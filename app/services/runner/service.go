@@ -0,0 +1,443 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pipeline-runner-health"
+
+	maxTags      = 32
+	maxTagLength = 64
+	maxCapacity  = 256
+
+	tokenLength = 32
+)
+
+var (
+	tagRegex = regexp.MustCompile(`^[a-zA-Z0-9._:/-]+$`)
+
+	// ErrDisabled is returned if the registration of external runners is disabled.
+	ErrDisabled = usererror.Forbidden("The registration of external runners is disabled.")
+
+	// ErrUnhealthy is returned if an external runner requests a stage without a recent heartbeat.
+	ErrUnhealthy = usererror.Forbidden("The runner has to send a heartbeat before requesting stages.")
+
+	// ErrAtCapacity is returned if an external runner requests a stage while running as many stages as it can.
+	ErrAtCapacity = usererror.Conflict("The runner is at capacity.")
+)
+
+// Service manages the external runners that execute the pipeline stages delegated to them.
+//
+// Runners register with a shared secret and authenticate with the token they receive, only its hash is stored.
+// Runners that didn't send a heartbeat within the timeout are considered dead: they don't get any stages,
+// and a recurring job reschedules their accepted stages and fails the stages they were running.
+type Service struct {
+	instanceID string
+	secret     string
+	timeout    time.Duration
+	cron       string
+	maxDur     time.Duration
+
+	runnerStore    store.RunnerStore
+	stageStore     store.StageStore
+	manager        manager.ExecutionManager
+	stageScheduler scheduler.Scheduler
+	scheduler      *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	manager manager.ExecutionManager,
+	stageScheduler scheduler.Scheduler,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		instanceID:     config.InstanceID,
+		secret:         config.CI.Runners.RegistrationSecret,
+		timeout:        config.CI.Runners.HeartbeatTimeout,
+		cron:           config.CI.Runners.CRON,
+		maxDur:         config.CI.Runners.MaxDuration,
+		runnerStore:    runnerStore,
+		stageStore:     stageStore,
+		manager:        manager,
+		stageScheduler: stageScheduler,
+		scheduler:      scheduler,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if s.secret == "" {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline runner health: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterRunner registers the runner with the registration secret. The identifier has to be unique,
+// a runner has to be deleted before another runner can register with its identifier.
+// The returned token is the only credential of the runner.
+func (s *Service) RegisterRunner(
+	ctx context.Context,
+	secret string,
+	runner *types.Runner,
+) (*types.RunnerRegistration, error) {
+	if s.secret == "" {
+		return nil, ErrDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(s.secret)) != 1 {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if err := check.Identifier(runner.Identifier); err != nil {
+		return nil, err
+	}
+	// the embedded runner uses the id of the instance as its machine.
+	if strings.EqualFold(runner.Identifier, s.instanceID) {
+		return nil, usererror.BadRequestf("Runner identifier %q is reserved.", runner.Identifier)
+	}
+
+	tags, err := sanitizeTags(runner.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if runner.Capacity == 0 {
+		runner.Capacity = 1
+	}
+	if runner.Capacity < 0 || runner.Capacity > maxCapacity {
+		return nil, usererror.BadRequestf("Runner capacity must be between 1 and %d.", maxCapacity)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	runner.Tags = tags
+	runner.TokenHash = hashToken(token)
+	runner.LastSeen = now
+	runner.Created = now
+	runner.Updated = now
+
+	err = s.runnerStore.Create(ctx, runner)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("A runner with identifier %q is already registered.",
+			runner.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to register runner: %w", err)
+	}
+
+	runner.Healthy = true
+
+	return &types.RunnerRegistration{
+		Runner: runner,
+		Token:  token,
+	}, nil
+}
+
+// Authenticate returns the runner the token belongs to.
+func (s *Service) Authenticate(ctx context.Context, token string) (*types.Runner, error) {
+	if token == "" {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	runner, err := s.runnerStore.FindByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	runner.Healthy = s.healthy(runner, time.Now())
+
+	return runner, nil
+}
+
+// Heartbeat marks the runner as alive.
+func (s *Service) Heartbeat(ctx context.Context, runner *types.Runner) error {
+	runner.LastSeen = time.Now().UnixMilli()
+	runner.Healthy = true
+
+	if err := s.runnerStore.UpdateLastSeen(ctx, runner.ID, runner.LastSeen); err != nil {
+		return fmt.Errorf("failed to update runner heartbeat: %w", err)
+	}
+
+	return nil
+}
+
+// CheckAvailable returns an error if the runner is dead or at capacity.
+func (s *Service) CheckAvailable(ctx context.Context, runner *types.Runner) error {
+	if !runner.Healthy {
+		return ErrUnhealthy
+	}
+
+	stages, err := s.Stages(ctx, runner)
+	if err != nil {
+		return err
+	}
+
+	if len(stages) >= runner.Capacity {
+		return ErrAtCapacity
+	}
+
+	return nil
+}
+
+// Matches returns true if the runner has all the tags the stage runs on.
+func Matches(runner *types.Runner, stage *types.Stage) bool {
+	for _, tag := range stage.RunsOn {
+		if !slices.Contains(runner.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Stages returns the incomplete stages assigned to the runner.
+func (s *Service) Stages(ctx context.Context, runner *types.Runner) ([]*types.Stage, error) {
+	stages, err := s.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	return slices.DeleteFunc(stages, func(stage *types.Stage) bool {
+		return stage.Machine != runner.Machine()
+	}), nil
+}
+
+// List returns all runners with their health and the number of stages assigned to them.
+func (s *Service) List(ctx context.Context) ([]*types.Runner, error) {
+	runners, err := s.runnerStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	stages, err := s.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	counts := map[string]int{}
+	for _, stage := range stages {
+		counts[stage.Machine]++
+	}
+
+	now := time.Now()
+	for _, runner := range runners {
+		runner.Healthy = s.healthy(runner, now)
+		runner.Stages = counts[runner.Machine()]
+	}
+
+	return runners, nil
+}
+
+// Delete deletes the runner and releases its stages, as if the runner died.
+func (s *Service) Delete(ctx context.Context, identifier string) error {
+	runner, err := s.runnerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	if err = s.runnerStore.Delete(ctx, runner.ID); err != nil {
+		return fmt.Errorf("failed to delete runner: %w", err)
+	}
+
+	if _, err = s.release(ctx, runner); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Handle releases the stages of all runners without heartbeat within the timeout.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if s.secret == "" {
+		return "", nil
+	}
+
+	runners, err := s.runnerStore.ListLastSeenBefore(ctx, time.Now().Add(-s.timeout).UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list dead runners: %w", err)
+	}
+
+	var released int
+	for _, runner := range runners {
+		n, err := s.release(ctx, runner)
+		if err != nil {
+			return "", err
+		}
+		released += n
+	}
+
+	if released == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("released %d stages of dead runners", released), nil
+}
+
+// release reschedules the stages the runner accepted but didn't start yet
+// and fails the stages the runner was running.
+func (s *Service) release(ctx context.Context, runner *types.Runner) (int, error) {
+	stages, err := s.Stages(ctx, runner)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusRunning {
+			err = s.fail(ctx, runner, stage)
+		} else {
+			err = s.reschedule(ctx, stage)
+		}
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			// the runner updated the stage in the meantime, it's handled by the next run if it's still dead.
+			log.Ctx(ctx).Warn().Int64("stage.id", stage.ID).Msg("stage of dead runner was updated concurrently")
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(stages), nil
+}
+
+func (s *Service) reschedule(ctx context.Context, stage *types.Stage) error {
+	stage.Machine = ""
+	if err := s.stageStore.Update(ctx, stage); err != nil {
+		return fmt.Errorf("failed to unassign stage of dead runner: %w", err)
+	}
+
+	if err := s.stageScheduler.Schedule(ctx, stage); err != nil {
+		return fmt.Errorf("failed to reschedule stage of dead runner: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) fail(ctx context.Context, runner *types.Runner, stage *types.Stage) error {
+	stages, err := s.stageStore.ListWithSteps(ctx, stage.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to list stages of execution: %w", err)
+	}
+
+	for _, sibling := range stages {
+		if sibling.ID == stage.ID {
+			stage.Steps = sibling.Steps
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	for _, step := range stage.Steps {
+		switch step.Status {
+		case enum.CIStatusPending:
+			step.Status = enum.CIStatusSkipped
+			step.Started = now
+			step.Stopped = now
+		case enum.CIStatusRunning:
+			step.Status = enum.CIStatusError
+			step.Error = "The runner stopped responding."
+			step.ExitCode = 255
+			step.Stopped = now
+		default:
+		}
+	}
+
+	stage.Status = enum.CIStatusError
+	stage.Error = fmt.Sprintf("Runner %q stopped responding.", runner.Identifier)
+	stage.ExitCode = 255
+	stage.Stopped = now
+
+	if err := s.manager.AfterStage(ctx, stage); err != nil {
+		return fmt.Errorf("failed to fail stage of dead runner: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) healthy(runner *types.Runner, now time.Time) bool {
+	return runner.LastSeen >= now.Add(-s.timeout).UnixMilli()
+}
+
+// sanitizeTags validates the tags and returns them sorted and without duplicates.
+func sanitizeTags(tags []string) ([]string, error) {
+	if len(tags) > maxTags {
+		return nil, usererror.BadRequestf("A runner can't have more than %d tags.", maxTags)
+	}
+
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if len(tag) == 0 || len(tag) > maxTagLength || !tagRegex.MatchString(tag) {
+			return nil, usererror.BadRequestf(
+				"Runner tags must be between 1 and %d characters long and consist of letters, digits, '.', "+
+					"'_', ':', '/' and '-'.", maxTagLength)
+		}
+		out = append(out, tag)
+	}
+
+	slices.Sort(out)
+
+	return slices.Compact(out), nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate runner token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
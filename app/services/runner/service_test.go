@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "none", tags: nil, want: []string{}},
+		{name: "sorted and unique", tags: []string{"linux", " gpu ", "linux"}, want: []string{"gpu", "linux"}},
+		{name: "special characters", tags: []string{"region:eu-west/1", "v1.2_3"},
+			want: []string{"region:eu-west/1", "v1.2_3"}},
+		{name: "empty", tags: []string{" "}, wantErr: true},
+		{name: "invalid character", tags: []string{"gpu large"}, wantErr: true},
+		{name: "too long", tags: []string{strings.Repeat("a", maxTagLength+1)}, wantErr: true},
+		{name: "too many", tags: make([]string, maxTags+1), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sanitizeTags(test.tags)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	runner := &types.Runner{Tags: []string{"gpu", "linux"}}
+
+	require.True(t, Matches(runner, &types.Stage{}))
+	require.True(t, Matches(runner, &types.Stage{RunsOn: []string{"gpu"}}))
+	require.True(t, Matches(runner, &types.Stage{RunsOn: []string{"gpu", "linux"}}))
+	require.False(t, Matches(runner, &types.Stage{RunsOn: []string{"gpu", "windows"}}))
+	require.False(t, Matches(&types.Runner{}, &types.Stage{RunsOn: []string{"gpu"}}))
+}
+
+type fakeRunnerStore struct {
+	store.RunnerStore
+	runners []*types.Runner
+}
+
+func (s *fakeRunnerStore) Create(_ context.Context, runner *types.Runner) error {
+	for _, r := range s.runners {
+		if r.Identifier == runner.Identifier {
+			return gitness_store.ErrDuplicate
+		}
+	}
+	runner.ID = int64(len(s.runners) + 1)
+	s.runners = append(s.runners, runner)
+	return nil
+}
+
+type fakeStageStore struct {
+	store.StageStore
+	stages []*types.Stage
+}
+
+func (s *fakeStageStore) ListIncomplete(context.Context) ([]*types.Stage, error) {
+	return append([]*types.Stage(nil), s.stages...), nil
+}
+
+func TestService_RegisterRunner(t *testing.T) {
+	config := &types.Config{InstanceID: "gitness-1"}
+	config.CI.Runners.RegistrationSecret = "secret"
+
+	s := NewService(config, &fakeRunnerStore{}, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	registration, err := s.RegisterRunner(ctx, "secret", &types.Runner{Identifier: "linux-1"})
+	require.NoError(t, err)
+	require.NotEmpty(t, registration.Token)
+	require.Equal(t, 1, registration.Runner.Capacity)
+
+	_, err = s.RegisterRunner(ctx, "secret", &types.Runner{Identifier: "linux-1"})
+	require.ErrorContains(t, err, "already registered")
+
+	_, err = s.RegisterRunner(ctx, "secret", &types.Runner{Identifier: "Gitness-1"})
+	require.ErrorContains(t, err, "reserved")
+
+	_, err = s.RegisterRunner(ctx, "wrong", &types.Runner{Identifier: "linux-2"})
+	require.Error(t, err)
+}
+
+func TestService_Stages(t *testing.T) {
+	deleted := &types.Runner{ID: 1, Identifier: "linux"}
+	runner := &types.Runner{ID: 2, Identifier: "linux"}
+
+	stageStore := &fakeStageStore{stages: []*types.Stage{
+		{ID: 1, Machine: deleted.Machine()},
+		{ID: 2, Machine: runner.Machine()},
+		{ID: 3, Machine: "linux"},
+	}}
+	s := NewService(&types.Config{}, nil, stageStore, nil, nil, nil)
+
+	// the stages are bound to the runner that accepted them, not to its identifier.
+	stages, err := s.Stages(context.Background(), runner)
+	require.NoError(t, err)
+	require.Len(t, stages, 1)
+	require.Equal(t, int64(2), stages[0].ID)
+}
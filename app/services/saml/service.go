@@ -0,0 +1,662 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saml
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	samlauth "github.com/harness/gitness/app/auth/saml"
+	"github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const (
+	maxMetadataSize        = 1 << 20
+	maxAttributeNameLength = 256
+	maxGroupMappings       = 100
+
+	defaultNameAttribute   = "name"
+	defaultEmailAttribute  = "email"
+	defaultGroupsAttribute = "groups"
+)
+
+// ProviderCreateInput is the input for adding a SAML provider.
+type ProviderCreateInput struct {
+	Identifier  string `json:"identifier"`
+	DisplayName string `json:"display_name"`
+	// IdPMetadata is the metadata document of the identity provider.
+	IdPMetadata      string                      `json:"idp_metadata"`
+	AttributeMapping *types.SAMLAttributeMapping `json:"attribute_mapping"`
+	GroupMappings    []types.SAMLGroupMapping    `json:"group_mappings"`
+	Enabled          bool                        `json:"enabled"`
+	AllowSignup      bool                        `json:"allow_signup"`
+
+	// TrustSecondFactor is off by default, it should only be set if the provider enforces MFA for all accounts.
+	TrustSecondFactor bool `json:"trust_second_factor"`
+}
+
+// ProviderUpdateInput is the input for updating a SAML provider.
+type ProviderUpdateInput struct {
+	DisplayName      *string                     `json:"display_name"`
+	IdPMetadata      *string                     `json:"idp_metadata"`
+	AttributeMapping *types.SAMLAttributeMapping `json:"attribute_mapping"`
+	GroupMappings    *[]types.SAMLGroupMapping   `json:"group_mappings"`
+	Enabled          *bool                       `json:"enabled"`
+	AllowSignup      *bool                       `json:"allow_signup"`
+
+	TrustSecondFactor *bool `json:"trust_second_factor"`
+}
+
+// ExternalUser is the user as asserted by the identity provider, with the attributes mapped.
+type ExternalUser struct {
+	NameID      string
+	Email       string
+	DisplayName string
+	Groups      []string
+}
+
+// AuthRedirect is the redirect of the user to the identity provider.
+// The request ID has to be kept by the client until the identity provider posts the response.
+type AuthRedirect struct {
+	URL       string
+	RequestID string
+}
+
+// Service manages the SAML providers users can login with and the identities linked through them.
+type Service struct {
+	providerStore   store.SAMLProviderStore
+	identityStore   store.SAMLIdentityStore
+	spaceStore      store.SpaceStore
+	membershipStore store.MembershipStore
+	urlProvider     urlprovider.Provider
+}
+
+func NewService(
+	providerStore store.SAMLProviderStore,
+	identityStore store.SAMLIdentityStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	urlProvider urlprovider.Provider,
+) *Service {
+	return &Service{
+		providerStore:   providerStore,
+		identityStore:   identityStore,
+		spaceStore:      spaceStore,
+		membershipStore: membershipStore,
+		urlProvider:     urlProvider,
+	}
+}
+
+// ListProviders returns all SAML providers.
+func (s *Service) ListProviders(ctx context.Context) ([]*types.SAMLProvider, error) {
+	providers, err := s.providerStore.List(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saml providers: %w", err)
+	}
+
+	for _, provider := range providers {
+		if err = s.fillSpacePaths(ctx, provider); err != nil {
+			return nil, err
+		}
+	}
+
+	return providers, nil
+}
+
+// ListEnabledProviders returns the public information of the providers users can login with.
+func (s *Service) ListEnabledProviders(ctx context.Context) ([]types.SAMLProviderInfo, error) {
+	providers, err := s.providerStore.List(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enabled saml providers: %w", err)
+	}
+
+	result := make([]types.SAMLProviderInfo, len(providers))
+	for i, provider := range providers {
+		result[i] = types.SAMLProviderInfo{
+			Identifier:  provider.Identifier,
+			DisplayName: provider.DisplayName,
+		}
+	}
+
+	return result, nil
+}
+
+// FindProvider returns a SAML provider.
+func (s *Service) FindProvider(ctx context.Context, identifier string) (*types.SAMLProvider, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saml provider: %w", err)
+	}
+
+	if err = s.fillSpacePaths(ctx, provider); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// CreateProvider adds a SAML provider.
+func (s *Service) CreateProvider(ctx context.Context, in *ProviderCreateInput) (*types.SAMLProvider, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	idp, err := parseMetadata(in.IdPMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.resolveGroupMappings(ctx, in.GroupMappings); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	provider := &types.SAMLProvider{
+		Identifier:       in.Identifier,
+		DisplayName:      in.DisplayName,
+		IdPMetadata:      in.IdPMetadata,
+		IdPEntityID:      idp.EntityID,
+		IdPSSOURL:        idp.SSOURL,
+		AttributeMapping: *in.AttributeMapping,
+		GroupMappings:    in.GroupMappings,
+		Enabled:          in.Enabled,
+		AllowSignup:      in.AllowSignup,
+		Created:          now,
+		Updated:          now,
+
+		TrustSecondFactor: in.TrustSecondFactor,
+	}
+
+	err = s.providerStore.Create(ctx, provider)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("A SAML provider with identifier '%s' already exists.", in.Identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saml provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// UpdateProvider updates a SAML provider.
+func (s *Service) UpdateProvider(
+	ctx context.Context,
+	identifier string,
+	in *ProviderUpdateInput,
+) (*types.SAMLProvider, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saml provider: %w", err)
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.DisplayName != nil {
+		provider.DisplayName = *in.DisplayName
+	}
+	if in.IdPMetadata != nil {
+		var idp *samlauth.IdentityProvider
+		if idp, err = parseMetadata(*in.IdPMetadata); err != nil {
+			return nil, err
+		}
+
+		provider.IdPMetadata = *in.IdPMetadata
+		provider.IdPEntityID = idp.EntityID
+		provider.IdPSSOURL = idp.SSOURL
+	}
+	if in.AttributeMapping != nil {
+		provider.AttributeMapping = *in.AttributeMapping
+	}
+	if in.GroupMappings != nil {
+		if err = s.resolveGroupMappings(ctx, *in.GroupMappings); err != nil {
+			return nil, err
+		}
+		provider.GroupMappings = *in.GroupMappings
+	}
+	if in.Enabled != nil {
+		provider.Enabled = *in.Enabled
+	}
+	if in.AllowSignup != nil {
+		provider.AllowSignup = *in.AllowSignup
+	}
+	if in.TrustSecondFactor != nil {
+		provider.TrustSecondFactor = *in.TrustSecondFactor
+	}
+
+	provider.Updated = time.Now().UnixMilli()
+
+	if err = s.providerStore.Update(ctx, provider); err != nil {
+		return nil, fmt.Errorf("failed to update saml provider: %w", err)
+	}
+
+	if err = s.fillSpacePaths(ctx, provider); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// DeleteProvider removes a SAML provider and unlinks all identities of it.
+func (s *Service) DeleteProvider(ctx context.Context, identifier string) error {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find saml provider: %w", err)
+	}
+
+	if err = s.providerStore.Delete(ctx, provider.ID); err != nil {
+		return fmt.Errorf("failed to delete saml provider: %w", err)
+	}
+
+	return nil
+}
+
+// ServiceProviderMetadata returns the metadata used to register the service with the identity provider.
+// It is available for disabled providers as well, as the registration precedes enabling the provider.
+func (s *Service) ServiceProviderMetadata(ctx context.Context, identifier string) ([]byte, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("SAML provider '%s' not found.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saml provider: %w", err)
+	}
+
+	sp := &samlauth.ServiceProvider{
+		EntityID: s.urlProvider.GenerateSAMLMetadataURL(provider.Identifier),
+		ACSURL:   s.urlProvider.GenerateSAMLACSURL(provider.Identifier),
+	}
+
+	return sp.Metadata()
+}
+
+// BeginLogin returns the redirect of the user to the identity provider.
+func (s *Service) BeginLogin(ctx context.Context, identifier string) (*AuthRedirect, error) {
+	provider, err := s.findEnabledProvider(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err := s.serviceProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, err := samlauth.NewRequestID()
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURL, err := sp.AuthnRequestURL(requestID, "", time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saml authentication request: %w", err)
+	}
+
+	return &AuthRedirect{
+		URL:       redirectURL,
+		RequestID: requestID,
+	}, nil
+}
+
+// ParseResponse verifies the response the identity provider posted for the authentication request
+// and returns the user it asserts.
+func (s *Service) ParseResponse(
+	ctx context.Context,
+	identifier string,
+	samlResponse string,
+	requestID string,
+) (*types.SAMLProvider, *ExternalUser, error) {
+	provider, err := s.findEnabledProvider(ctx, identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sp, err := s.serviceProvider(provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assertion, err := sp.ParseResponse(samlResponse, requestID)
+	if err != nil {
+		return nil, nil, errors.Format(errors.StatusUnauthorized,
+			"Failed to authenticate with %s.", provider.DisplayName).SetErr(err)
+	}
+
+	mapping := provider.AttributeMapping
+	user := &ExternalUser{
+		NameID:      assertion.NameID,
+		Email:       assertion.Attribute(mapping.Email),
+		DisplayName: assertion.Attribute(mapping.Name),
+		Groups:      assertion.Attributes[mapping.Groups],
+	}
+
+	if user.Email == "" && assertion.NameIDFormat == samlauth.NameIDFormatEmail {
+		user.Email = assertion.NameID
+	}
+
+	return provider, user, nil
+}
+
+// FindIdentity returns the identity linked to the name ID asserted by the provider.
+// The email of the identity is updated in case it changed.
+func (s *Service) FindIdentity(
+	ctx context.Context,
+	provider *types.SAMLProvider,
+	user *ExternalUser,
+) (*types.SAMLIdentity, error) {
+	identity, err := s.identityStore.FindByNameID(ctx, provider.ID, user.NameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saml identity: %w", err)
+	}
+
+	if identity.Email == user.Email {
+		return identity, nil
+	}
+
+	identity.Email = user.Email
+	identity.Updated = time.Now().UnixMilli()
+
+	if err = s.identityStore.Update(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to update saml identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// Link links the name ID asserted by the provider to the principal.
+func (s *Service) Link(
+	ctx context.Context,
+	principalID int64,
+	provider *types.SAMLProvider,
+	user *ExternalUser,
+) (*types.SAMLIdentity, error) {
+	now := time.Now().UnixMilli()
+	identity := &types.SAMLIdentity{
+		PrincipalID: principalID,
+		ProviderID:  provider.ID,
+		NameID:      user.NameID,
+		Email:       user.Email,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := s.identityStore.Create(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to create saml identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// SyncMemberships grants the principal the space memberships mapped to its groups.
+// The first mapping of a space matching one of the groups defines the role. Memberships are never revoked,
+// as memberships granted through the provider can't be told apart from memberships granted by space owners.
+func (s *Service) SyncMemberships(
+	ctx context.Context,
+	principalID int64,
+	provider *types.SAMLProvider,
+	user *ExternalUser,
+) error {
+	groups := make(map[string]struct{}, len(user.Groups))
+	for _, group := range user.Groups {
+		groups[group] = struct{}{}
+	}
+
+	synced := map[int64]struct{}{}
+	for _, mapping := range provider.GroupMappings {
+		if _, ok := groups[mapping.Group]; !ok {
+			continue
+		}
+		if _, ok := synced[mapping.SpaceID]; ok {
+			continue
+		}
+		synced[mapping.SpaceID] = struct{}{}
+
+		if err := s.syncMembership(ctx, principalID, mapping); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) syncMembership(ctx context.Context, principalID int64, mapping types.SAMLGroupMapping) error {
+	key := types.MembershipKey{
+		SpaceID:     mapping.SpaceID,
+		PrincipalID: principalID,
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership, err := s.membershipStore.Find(ctx, key)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		membership = &types.Membership{
+			MembershipKey: key,
+			CreatedBy:     principalID,
+			Created:       now,
+			Updated:       now,
+			Role:          mapping.Role,
+		}
+
+		if err = s.membershipStore.Create(ctx, membership); err != nil {
+			return fmt.Errorf("failed to create membership from saml group %q: %w", mapping.Group, err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	if membership.Role == mapping.Role {
+		return nil
+	}
+
+	membership.Role = mapping.Role
+	membership.Updated = now
+
+	if err = s.membershipStore.Update(ctx, membership); err != nil {
+		return fmt.Errorf("failed to update membership from saml group %q: %w", mapping.Group, err)
+	}
+
+	return nil
+}
+
+func (s *Service) findEnabledProvider(ctx context.Context, identifier string) (*types.SAMLProvider, error) {
+	provider, err := s.providerStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("SAML provider '%s' not found.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saml provider: %w", err)
+	}
+
+	if !provider.Enabled {
+		return nil, errors.NotFound("SAML provider '%s' not found.", identifier)
+	}
+
+	return provider, nil
+}
+
+func (s *Service) serviceProvider(provider *types.SAMLProvider) (*samlauth.ServiceProvider, error) {
+	idp, err := samlauth.ParseMetadata([]byte(provider.IdPMetadata))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of saml provider %q: %w", provider.Identifier, err)
+	}
+
+	return &samlauth.ServiceProvider{
+		EntityID: s.urlProvider.GenerateSAMLMetadataURL(provider.Identifier),
+		ACSURL:   s.urlProvider.GenerateSAMLACSURL(provider.Identifier),
+		IdP:      idp,
+	}, nil
+}
+
+// resolveGroupMappings resolves the spaces of the group mappings.
+func (s *Service) resolveGroupMappings(ctx context.Context, mappings []types.SAMLGroupMapping) error {
+	for i := range mappings {
+		space, err := s.spaceStore.FindByRef(ctx, mappings[i].SpacePath)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return errors.InvalidArgument("Space '%s' of group '%s' not found.",
+				mappings[i].SpacePath, mappings[i].Group)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space of group mapping: %w", err)
+		}
+
+		mappings[i].SpaceID = space.ID
+		mappings[i].SpacePath = space.Path
+	}
+
+	return nil
+}
+
+// fillSpacePaths sets the current paths of the spaces of the group mappings.
+func (s *Service) fillSpacePaths(ctx context.Context, provider *types.SAMLProvider) error {
+	for i := range provider.GroupMappings {
+		space, err := s.spaceStore.Find(ctx, provider.GroupMappings[i].SpaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space of group mapping: %w", err)
+		}
+
+		provider.GroupMappings[i].SpacePath = space.Path
+	}
+
+	return nil
+}
+
+func parseMetadata(metadata string) (*samlauth.IdentityProvider, error) {
+	if len(metadata) > maxMetadataSize {
+		return nil, errors.InvalidArgument("IdP metadata can't be larger than %d bytes.", maxMetadataSize)
+	}
+
+	idp, err := samlauth.ParseMetadata([]byte(metadata))
+	if err != nil {
+		return nil, errors.InvalidArgument("Invalid IdP metadata: %s.", err)
+	}
+
+	return idp, nil
+}
+
+func (in *ProviderCreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.DisplayName == "" {
+		in.DisplayName = in.Identifier
+	}
+
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+
+	if in.AttributeMapping == nil {
+		in.AttributeMapping = &types.SAMLAttributeMapping{}
+	}
+
+	if err := sanitizeAttributeMapping(in.AttributeMapping); err != nil {
+		return err
+	}
+
+	return sanitizeGroupMappings(in.GroupMappings)
+}
+
+func (in *ProviderUpdateInput) sanitize() error {
+	if in.DisplayName != nil {
+		displayName := strings.TrimSpace(*in.DisplayName)
+		if err := check.DisplayName(displayName); err != nil {
+			return err
+		}
+		in.DisplayName = &displayName
+	}
+
+	if in.AttributeMapping != nil {
+		if err := sanitizeAttributeMapping(in.AttributeMapping); err != nil {
+			return err
+		}
+	}
+
+	if in.GroupMappings != nil {
+		if err := sanitizeGroupMappings(*in.GroupMappings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sanitizeAttributeMapping(mapping *types.SAMLAttributeMapping) error {
+	mapping.Name = strings.TrimSpace(mapping.Name)
+	mapping.Email = strings.TrimSpace(mapping.Email)
+	mapping.Groups = strings.TrimSpace(mapping.Groups)
+
+	if mapping.Name == "" {
+		mapping.Name = defaultNameAttribute
+	}
+	if mapping.Email == "" {
+		mapping.Email = defaultEmailAttribute
+	}
+	if mapping.Groups == "" {
+		mapping.Groups = defaultGroupsAttribute
+	}
+
+	for _, name := range []string{mapping.Name, mapping.Email, mapping.Groups} {
+		if len(name) > maxAttributeNameLength {
+			return errors.InvalidArgument("Attribute names can't be longer than %d characters.",
+				maxAttributeNameLength)
+		}
+	}
+
+	return nil
+}
+
+func sanitizeGroupMappings(mappings []types.SAMLGroupMapping) error {
+	if len(mappings) > maxGroupMappings {
+		return errors.InvalidArgument("A SAML provider can't have more than %d group mappings.", maxGroupMappings)
+	}
+
+	for i := range mappings {
+		mappings[i].Group = strings.TrimSpace(mappings[i].Group)
+		mappings[i].SpacePath = strings.Trim(strings.TrimSpace(mappings[i].SpacePath), "/")
+
+		if mappings[i].Group == "" {
+			return errors.InvalidArgument("Group of a group mapping is required.")
+		}
+		if mappings[i].SpacePath == "" {
+			return errors.InvalidArgument("Space of group '%s' is required.", mappings[i].Group)
+		}
+
+		role, ok := mappings[i].Role.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("Unknown role '%s' of group '%s'.", mappings[i].Role, mappings[i].Group)
+		}
+		mappings[i].Role = role
+	}
+
+	return nil
+}
@@ -17,6 +17,8 @@ package settings
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/types"
 )
 
 // RepoGet is a helper method for getting a setting of a specific type for a repo.
@@ -60,3 +62,58 @@ func RepoGetRequired[T any](
 
 	return out, nil
 }
+
+// SpaceGet is a helper method for getting a setting of a specific type for a space.
+func SpaceGet[T any](
+	ctx context.Context,
+	s *Service,
+	spaceID int64,
+	key Key,
+	dflt T,
+) (T, error) {
+	var out T
+	ok, err := s.SpaceGet(ctx, spaceID, key, &out)
+	if err != nil {
+		return out, err
+	}
+
+	if !ok {
+		return dflt, nil
+	}
+
+	return out, nil
+}
+
+// RepoGetInherited is a helper method for getting a setting of a specific type for a repo.
+// If the repo doesn't define the setting, it's inherited from the closest ancestor space defining it.
+func RepoGetInherited[T any](
+	ctx context.Context,
+	s *Service,
+	repo *types.Repository,
+	key Key,
+	dflt T,
+) (T, error) {
+	out := dflt
+	if err := s.RepoMapInherited(ctx, repo, Mapping(key, &out)); err != nil {
+		return dflt, err
+	}
+
+	return out, nil
+}
+
+// SpaceGetInherited is a helper method for getting a setting of a specific type for a space.
+// If the space doesn't define the setting, it's inherited from the closest ancestor space defining it.
+func SpaceGetInherited[T any](
+	ctx context.Context,
+	s *Service,
+	spaceID int64,
+	key Key,
+	dflt T,
+) (T, error) {
+	out := dflt
+	if err := s.SpaceMapInherited(ctx, spaceID, Mapping(key, &out)); err != nil {
+		return dflt, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// InheritableKeys are the keys of the settings that can be defined on a space (or the system)
+// and are inherited by its sub-spaces and repositories unless they define the setting themselves.
+var InheritableKeys = []Key{
+	KeyMergeMethods,
+	KeyDefaultBranch,
+	KeyFileSizeLimit,
+	KeyCodeOwnersRequestReview,
+	KeySecretScanningEnabled,
+	KeyTwoFactorRequired,
+	KeyTokenMaxLifetime,
+	KeyPipelineClone,
+}
+
+// IsInheritable returns true if the setting with the provided key is inherited from the parent scopes.
+func IsInheritable(key Key) bool {
+	return slices.Contains(InheritableKeys, key)
+}
+
+// scopeLevel is a single level of the settings inheritance chain.
+type scopeLevel struct {
+	scope enum.SettingsScope
+	id    int64
+	path  string
+}
+
+// resolvedValue is the raw value of a setting along with the level it's defined on.
+type resolvedValue struct {
+	raw   json.RawMessage
+	level scopeLevel
+}
+
+// defaults returns the values used for inheritable settings that aren't defined on any level.
+func (s *Service) defaults() map[Key]any {
+	return map[Key]any{
+		KeyMergeMethods:            enum.MergeMethods,
+		KeyDefaultBranch:           s.defaultBranch,
+		KeyFileSizeLimit:           DefaultFileSizeLimit,
+		KeyCodeOwnersRequestReview: DefaultCodeOwnersRequestReview,
+		KeySecretScanningEnabled:   DefaultSecretScanningEnabled,
+		KeyTwoFactorRequired:       DefaultTwoFactorRequired,
+		KeyTokenMaxLifetime:        DefaultTokenMaxLifetime,
+		KeyPipelineClone:           types.PipelineClone{},
+	}
+}
+
+// spaceLevels returns the inheritance chain of the space, starting with the space itself,
+// followed by all its ancestors and ending with the system.
+func (s *Service) spaceLevels(ctx context.Context, spaceID int64) ([]scopeLevel, error) {
+	ancestors, err := s.spaceStore.GetAncestors(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestors of space: %w", err)
+	}
+
+	spaces := make(map[int64]*types.Space, len(ancestors))
+	for _, space := range ancestors {
+		spaces[space.ID] = space
+	}
+
+	levels := make([]scopeLevel, 0, len(ancestors)+1)
+	for id := spaceID; id > 0; {
+		space, ok := spaces[id]
+		if !ok {
+			break
+		}
+
+		levels = append(levels, scopeLevel{scope: enum.SettingsScopeSpace, id: space.ID, path: space.Path})
+		id = space.ParentID
+	}
+
+	return append(levels, scopeLevel{scope: enum.SettingsScopeSystem}), nil
+}
+
+// repoLevels returns the inheritance chain of the repository,
+// starting with the repository itself followed by the chain of its space.
+func (s *Service) repoLevels(ctx context.Context, repo *types.Repository) ([]scopeLevel, error) {
+	levels, err := s.spaceLevels(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]scopeLevel{{scope: enum.SettingsScopeRepo, id: repo.ID, path: repo.Path}}, levels...), nil
+}
+
+// resolve returns the raw values of the settings with the given keys as defined on the most specific level.
+// Settings that aren't inheritable are only looked up on the first level.
+// A setting explicitly set to null is treated as not defined, which makes it inherited again.
+func (s *Service) resolve(
+	ctx context.Context,
+	levels []scopeLevel,
+	keys ...Key,
+) (map[Key]resolvedValue, error) {
+	values := make(map[Key]resolvedValue, len(keys))
+
+	for i, level := range levels {
+		pending := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if _, ok := values[key]; ok || (i > 0 && !IsInheritable(key)) {
+				continue
+			}
+			pending = append(pending, string(key))
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+
+		rawValues, err := s.settingsStore.FindMany(ctx, level.scope, level.id, pending...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find settings of %s %d in store: %w", level.scope, level.id, err)
+		}
+
+		for key, raw := range rawValues {
+			if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+				continue
+			}
+			values[Key(key)] = resolvedValue{raw: raw, level: level}
+		}
+	}
+
+	return values, nil
+}
+
+// mapResolved maps the resolved settings values using the provided handlers.
+func mapResolved(ctx context.Context, values map[Key]resolvedValue, handlers []SettingHandler) error {
+	for _, m := range handlers {
+		value, found := values[m.Key()]
+		if !found && m.Required() {
+			return fmt.Errorf("required setting %q not found", m.Key())
+		}
+		if !found {
+			continue
+		}
+
+		if err := m.Handle(ctx, value.raw); err != nil {
+			return fmt.Errorf("failed to handle value for setting %q: %w", m.Key(), err)
+		}
+	}
+
+	return nil
+}
+
+// RepoMapInherited maps the settings of the repository using the provided handlers.
+// Inheritable settings not defined on the repository are taken from its closest ancestor space defining them.
+func (s *Service) RepoMapInherited(
+	ctx context.Context,
+	repo *types.Repository,
+	handlers ...SettingHandler,
+) error {
+	levels, err := s.repoLevels(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	return s.mapInherited(ctx, levels, handlers)
+}
+
+// SpaceMapInherited maps the settings of the space using the provided handlers.
+// Inheritable settings not defined on the space are taken from its closest ancestor defining them.
+func (s *Service) SpaceMapInherited(
+	ctx context.Context,
+	spaceID int64,
+	handlers ...SettingHandler,
+) error {
+	levels, err := s.spaceLevels(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	return s.mapInherited(ctx, levels, handlers)
+}
+
+func (s *Service) mapInherited(ctx context.Context, levels []scopeLevel, handlers []SettingHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	keys := make([]Key, len(handlers))
+	for i, m := range handlers {
+		keys[i] = m.Key()
+	}
+
+	values, err := s.resolve(ctx, levels, keys...)
+	if err != nil {
+		return err
+	}
+
+	return mapResolved(ctx, values, handlers)
+}
+
+// RepoEffective returns the effective values of all inheritable settings of the repository.
+func (s *Service) RepoEffective(ctx context.Context, repo *types.Repository) ([]types.EffectiveSetting, error) {
+	levels, err := s.repoLevels(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.effective(ctx, levels)
+}
+
+// SpaceEffective returns the effective values of all inheritable settings of the space.
+func (s *Service) SpaceEffective(ctx context.Context, spaceID int64) ([]types.EffectiveSetting, error) {
+	levels, err := s.spaceLevels(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.effective(ctx, levels)
+}
+
+func (s *Service) effective(ctx context.Context, levels []scopeLevel) ([]types.EffectiveSetting, error) {
+	values, err := s.resolve(ctx, levels, InheritableKeys...)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := s.defaults()
+	out := make([]types.EffectiveSetting, len(InheritableKeys))
+	for i, key := range InheritableKeys {
+		value, found := values[key]
+		if !found {
+			raw, err := json.Marshal(defaults[key])
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal default value of setting %q: %w", key, err)
+			}
+
+			out[i] = types.EffectiveSetting{Key: string(key), Value: raw, Inherited: true}
+			continue
+		}
+
+		out[i] = types.EffectiveSetting{
+			Key:       string(key),
+			Value:     value.raw,
+			Inherited: value.level != levels[0],
+			Source: &types.SettingSource{
+				Scope: value.level.scope,
+				ID:    value.level.id,
+				Path:  value.level.path,
+			},
+		}
+	}
+
+	return out, nil
+}
@@ -42,13 +42,19 @@ type SettingHandler interface {
 // Service is used to enhance interaction with the settings store.
 type Service struct {
 	settingsStore appstore.SettingsStore
+	spaceStore    appstore.SpaceStore
+	defaultBranch string
 }
 
 func NewService(
 	settingsStore appstore.SettingsStore,
+	spaceStore appstore.SpaceStore,
+	defaultBranch string,
 ) *Service {
 	return &Service{
 		settingsStore: settingsStore,
+		spaceStore:    spaceStore,
+		defaultBranch: defaultBranch,
 	}
 }
 
@@ -14,6 +14,8 @@
 
 package settings
 
+import "time"
+
 type Key string
 
 var (
@@ -24,4 +26,32 @@ var (
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
 	KeyInstallID                 Key = "install_id"
 	DefaultInstallID                 = string("")
+	// KeyCodeOwnersRequestReview [bool] enables requesting reviews from the code owners of the changed files.
+	KeyCodeOwnersRequestReview     Key = "code_owners_request_review"
+	DefaultCodeOwnersRequestReview     = true
+	// KeyDefaultReviewers [[]types.DefaultReviewer] defines the reviewers requested on every new pull request of a repo.
+	KeyDefaultReviewers Key = "default_reviewers"
+	// KeyRepoTemplate [types.RepoTemplate] defines the rules and webhooks applied to new repositories of a space.
+	KeyRepoTemplate Key = "repo_template"
+	// KeyStoragePool [string] defines the storage pool of the repositories of a space.
+	KeyStoragePool Key = "storage_pool"
+	// KeyReviewSLA [types.ReviewSLAPolicy] defines the review SLA of the pull requests of a space.
+	KeyReviewSLA Key = "review_sla"
+	// KeyQuota [types.SpaceQuota] defines the resource limits of a space and its sub-spaces.
+	KeyQuota Key = "quota"
+	// KeyMergeMethods [[]enum.MergeMethod] defines the merge methods allowed for pull requests.
+	KeyMergeMethods Key = "merge_methods"
+	// KeyDefaultBranch [string] defines the default branch of new repositories of a space.
+	KeyDefaultBranch Key = "default_branch"
+	// KeyTwoFactorRequired [bool] requires members of a space to use two-factor authentication.
+	KeyTwoFactorRequired     Key = "two_factor_required"
+	DefaultTwoFactorRequired     = false
+	// KeyTokenMaxLifetime [time.Duration] limits the lifetime of the access tokens of the members
+	// and service accounts of a space. Zero means that the lifetime isn't limited.
+	KeyTokenMaxLifetime     Key = "token_max_lifetime"
+	DefaultTokenMaxLifetime     = time.Duration(0)
+	// KeyRateLimits [types.RateLimits] overrides the rate limits of the server config for the system.
+	KeyRateLimits Key = "rate_limits"
+	// KeyPipelineClone [types.PipelineClone] configures the implicit clone step of pipelines.
+	KeyPipelineClone Key = "pipeline_clone"
 )
@@ -16,6 +16,7 @@ package settings
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -25,7 +26,9 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideService(
+	config *types.Config,
 	settingsStore store.SettingsStore,
+	spaceStore store.SpaceStore,
 ) *Service {
-	return NewService(settingsStore)
+	return NewService(settingsStore, spaceStore, config.Git.DefaultBranch)
 }
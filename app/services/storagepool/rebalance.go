@@ -0,0 +1,245 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagepool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/attachment"
+	releasesvc "github.com/harness/gitness/app/services/release"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const rebalanceBatchSize = 100
+
+var _ job.Handler = (*Service)(nil)
+
+// Handle moves all repositories of a space and its sub-spaces into the storage pool of their space.
+// Repositories are moved one after the other, a failed move doesn't stop the rebalancing of the other ones.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input rebalanceInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	log := log.Ctx(ctx).With().Int64("space.id", input.SpaceID).Logger()
+
+	// storage pools are resolved once per space.
+	spacePools := map[int64]string{}
+
+	moved, failed := 0, 0
+	for page := 1; ; page++ {
+		repos, err := s.repoStore.List(ctx, input.SpaceID, &types.RepoFilter{
+			Page:      page,
+			Size:      rebalanceBatchSize,
+			Sort:      enum.RepoAttrCreated,
+			Order:     enum.OrderAsc,
+			Recursive: true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+
+			pool, ok := spacePools[repo.ParentID]
+			if !ok {
+				pool, err = s.Resolve(ctx, repo.ParentID)
+				if err != nil {
+					return "", err
+				}
+				spacePools[repo.ParentID] = pool
+			}
+
+			if repo.StoragePool == pool && repo.State != enum.RepoStateStorageMove {
+				continue
+			}
+
+			err = s.moveRepo(ctx, repo, pool)
+			if err != nil {
+				log.Warn().Err(err).
+					Int64("repo.id", repo.ID).
+					Str("storage_pool", pool).
+					Msg("failed to move repository to storage pool")
+				failed++
+				continue
+			}
+
+			moved++
+		}
+
+		if len(repos) < rebalanceBatchSize {
+			break
+		}
+	}
+
+	result := fmt.Sprintf("moved %d repositories", moved)
+	if failed > 0 {
+		return "", fmt.Errorf("%s, failed to move %d repositories", result, failed)
+	}
+
+	log.Info().Msg(result)
+
+	return result, nil
+}
+
+// moveRepo moves the repository into the storage pool while keeping it online.
+// The repository is copied first, only the final sync and switch block write access to the repository.
+// A move that was interrupted while the repository was blocked is resumed.
+func (s *Service) moveRepo(ctx context.Context, repo *types.Repository, pool string) error {
+	if repo.State != enum.RepoStateActive && repo.State != enum.RepoStateStorageMove {
+		return fmt.Errorf("repository in state %s can't be moved", repo.State)
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	writeParams := func(gitUID string) git.WriteParams {
+		return git.WriteParams{
+			RepoUID: gitUID,
+			Actor: git.Identity{
+				Name:  systemPrincipal.DisplayName,
+				Email: systemPrincipal.Email,
+			},
+		}
+	}
+
+	// forks borrow objects from the repository via its path, hence they have to become independent first.
+	forks, err := s.repoStore.ListForkGitInfos(ctx, &repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		err = s.git.DetachAlternates(ctx, &git.DetachAlternatesParams{WriteParams: writeParams(fork.GitUID)})
+		if err != nil {
+			return fmt.Errorf("failed to detach fork %d from repository: %w", fork.ID, err)
+		}
+	}
+
+	params := &git.MoveRepositoryParams{
+		WriteParams: writeParams(repo.GitUID),
+		StoragePool: pool,
+	}
+
+	if err = s.git.StageRepositoryMove(ctx, params); err != nil {
+		return fmt.Errorf("failed to stage repository move: %w", err)
+	}
+
+	fromPool := repo.StoragePool
+
+	repo, err = s.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		if r.State != enum.RepoStateActive && r.State != enum.RepoStateStorageMove {
+			return fmt.Errorf("repository in state %s can't be moved", r.State)
+		}
+		r.State = enum.RepoStateStorageMove
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to block repository for the move: %w", err)
+	}
+
+	filePaths, err := s.listBlobPaths(ctx, repo.ID)
+	if err != nil {
+		return errors.Join(err, s.unblockRepo(ctx, repo, nil))
+	}
+
+	errMove := func() error {
+		// files are copied first, so they are available no matter which storage pool the repository is in.
+		for _, filePath := range filePaths {
+			err := s.blobStore.Copy(ctx, filePath, fromPool, pool)
+			if err != nil {
+				return fmt.Errorf("failed to copy file %q: %w", filePath, err)
+			}
+		}
+
+		if err := s.git.CompleteRepositoryMove(ctx, params); err != nil {
+			return fmt.Errorf("failed to complete repository move: %w", err)
+		}
+
+		return nil
+	}()
+
+	// the repository is unblocked even if the move failed, it stays in its original storage pool in that case.
+	newPool := &pool
+	if errMove != nil {
+		newPool = nil
+	}
+
+	if err = s.unblockRepo(ctx, repo, newPool); err != nil || errMove != nil {
+		return errors.Join(errMove, err)
+	}
+
+	// the copies of the files left in the original storage pool aren't needed anymore.
+	if fromStore := s.blobStore.Get(fromPool); fromStore != s.blobStore.Get(pool) {
+		for _, filePath := range filePaths {
+			if err = fromStore.Delete(ctx, filePath); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete moved file %q", filePath)
+			}
+		}
+	}
+
+	return nil
+}
+
+// listBlobPaths returns the blob store paths of all files of the repository,
+// that is of its attachments and of its release assets.
+func (s *Service) listBlobPaths(ctx context.Context, repoID int64) ([]string, error) {
+	attachments, err := s.attachmentStore.ListByRepo(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	assets, err := s.releaseAssetStore.ListByRepo(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list release assets: %w", err)
+	}
+
+	filePaths := make([]string, 0, len(attachments)+len(assets))
+	for _, a := range attachments {
+		filePaths = append(filePaths, attachment.BucketPath(repoID, a.FileName))
+	}
+	for _, a := range assets {
+		filePaths = append(filePaths, releasesvc.AssetBucketPath(repoID, a.FileName))
+	}
+
+	return filePaths, nil
+}
+
+// unblockRepo makes the repository active again and updates its storage pool if provided.
+func (s *Service) unblockRepo(ctx context.Context, repo *types.Repository, pool *string) error {
+	_, err := s.repoStore.UpdateOptLock(context.WithoutCancel(ctx), repo, func(r *types.Repository) error {
+		r.State = enum.RepoStateActive
+		if pool != nil {
+			r.StoragePool = *pool
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unblock repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagepool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	jobTypeRebalance        = "storage-pool-rebalance"
+	jobMaxRetriesRebalance  = 3
+	jobMaxDurationRebalance = 12 * time.Hour
+)
+
+// Service manages the storage pools of spaces.
+// The storage pool of a space is stored as a space setting and applies to all repositories
+// of the space and of its sub-spaces, unless a sub-space has a storage pool assigned itself.
+type Service struct {
+	pools             []string
+	settings          *settings.Service
+	spaceStore        store.SpaceStore
+	repoStore         store.RepoStore
+	attachmentStore   store.AttachmentStore
+	releaseAssetStore store.ReleaseAssetStore
+	git               git.Interface
+	blobStore         *blob.PoolStore
+	scheduler         *job.Scheduler
+}
+
+func NewService(
+	config *types.Config,
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	attachmentStore store.AttachmentStore,
+	releaseAssetStore store.ReleaseAssetStore,
+	git git.Interface,
+	blobStore *blob.PoolStore,
+	scheduler *job.Scheduler,
+) *Service {
+	pools := make([]string, 0, len(config.Git.StoragePools))
+	for name := range config.Git.StoragePools {
+		pools = append(pools, name)
+	}
+	sort.Strings(pools)
+
+	return &Service{
+		pools:             pools,
+		settings:          settings,
+		spaceStore:        spaceStore,
+		repoStore:         repoStore,
+		attachmentStore:   attachmentStore,
+		releaseAssetStore: releaseAssetStore,
+		git:               git,
+		blobStore:         blobStore,
+		scheduler:         scheduler,
+	}
+}
+
+type rebalanceInput struct {
+	SpaceID int64 `json:"space_id"`
+}
+
+// Find returns the storage pool configuration of a space.
+func (s *Service) Find(ctx context.Context, spaceID int64) (*types.SpaceStoragePool, error) {
+	pool, err := settings.SpaceGet(ctx, s.settings, spaceID, settings.KeyStoragePool, git.DefaultStoragePool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage pool of space: %w", err)
+	}
+
+	effectivePool, err := s.Resolve(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SpaceStoragePool{
+		Pool:          pool,
+		EffectivePool: effectivePool,
+		Available:     s.pools,
+	}, nil
+}
+
+// Assign assigns the storage pool to the space and starts a background job
+// that moves the repositories of the space and its sub-spaces into their storage pool.
+// Assigning the default storage pool (empty name) makes the space inherit the storage pool again.
+func (s *Service) Assign(ctx context.Context, spaceID int64, pool string) (*types.SpaceStoragePool, error) {
+	if pool != git.DefaultStoragePool && !slices.Contains(s.pools, pool) {
+		return nil, usererror.BadRequestf("Unknown storage pool %q.", pool)
+	}
+
+	if err := s.settings.SpaceSet(ctx, spaceID, settings.KeyStoragePool, pool); err != nil {
+		return nil, fmt.Errorf("failed to store storage pool of space: %w", err)
+	}
+
+	if err := s.Rebalance(ctx, spaceID); err != nil {
+		return nil, err
+	}
+
+	return s.Find(ctx, spaceID)
+}
+
+// Resolve returns the storage pool used for the repositories of the space.
+// It's the storage pool assigned to the space or to its closest ancestor.
+func (s *Service) Resolve(ctx context.Context, spaceID int64) (string, error) {
+	if len(s.pools) == 0 {
+		return git.DefaultStoragePool, nil
+	}
+
+	ancestors, err := s.spaceStore.GetAncestors(ctx, spaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ancestors of space: %w", err)
+	}
+
+	parents := make(map[int64]int64, len(ancestors))
+	for _, space := range ancestors {
+		parents[space.ID] = space.ParentID
+	}
+
+	for id := spaceID; id > 0; id = parents[id] {
+		pool, err := settings.SpaceGet(ctx, s.settings, id, settings.KeyStoragePool, git.DefaultStoragePool)
+		if err != nil {
+			return "", fmt.Errorf("failed to get storage pool of space %d: %w", id, err)
+		}
+
+		if pool != git.DefaultStoragePool {
+			return pool, nil
+		}
+	}
+
+	return git.DefaultStoragePool, nil
+}
+
+// Rebalance starts a background job that moves all repositories of the space and its sub-spaces
+// that aren't located in the storage pool of their space.
+func (s *Service) Rebalance(ctx context.Context, spaceID int64) error {
+	data, err := json.Marshal(rebalanceInput{SpaceID: spaceID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	uid, err := job.UID()
+	if err != nil {
+		return fmt.Errorf("failed to generate job uid: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobTypeRebalance + "-" + uid,
+		Type:       jobTypeRebalance,
+		MaxRetries: jobMaxRetriesRebalance,
+		Timeout:    jobMaxDurationRebalance,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run storage pool rebalance job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenpolicy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tokenevents "github.com/harness/gitness/app/events/token"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "token-expiry-warning"
+
+	membershipPageSize = 100
+	expiringPageSize   = 100
+)
+
+// warnedTokenTypes are the types of the tokens whose owners are warned before they expire.
+var warnedTokenTypes = []enum.TokenType{enum.TokenTypePAT, enum.TokenTypeSAT}
+
+// Service enforces the maximum lifetime of access tokens and periodically warns
+// the owners of access tokens that are about to expire.
+type Service struct {
+	maxLifetime    time.Duration
+	gracePeriod    time.Duration
+	warningEnabled bool
+	warningBefore  time.Duration
+	cron           string
+	maxDur         time.Duration
+
+	settingsService *settings.Service
+	membershipStore store.MembershipStore
+	repoStore       store.RepoStore
+	tokenStore      store.TokenStore
+	reporter        *tokenevents.Reporter
+	scheduler       *job.Scheduler
+}
+
+// GracePeriod returns the duration the previous secret of a rotated token is still accepted.
+func (s *Service) GracePeriod() time.Duration {
+	return s.gracePeriod
+}
+
+// MaxUserLifetime returns the maximum lifetime of the personal access tokens of the user.
+// It's the lowest limit of the instance and all spaces the user is a member of, zero if there's no limit.
+func (s *Service) MaxUserLifetime(ctx context.Context, userID int64) (time.Duration, error) {
+	maxLifetime := s.maxLifetime
+
+	filter := types.MembershipSpaceFilter{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Page: 1, Size: membershipPageSize},
+		},
+	}
+
+	for {
+		memberships, err := s.membershipStore.ListSpaces(ctx, userID, filter)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list membership spaces: %w", err)
+		}
+
+		for _, membership := range memberships {
+			spaceLifetime, err := s.spaceMaxLifetime(ctx, membership.Space.ID)
+			if err != nil {
+				return 0, err
+			}
+
+			maxLifetime = minLifetime(maxLifetime, spaceLifetime)
+		}
+
+		if len(memberships) < filter.Size {
+			return maxLifetime, nil
+		}
+
+		filter.Page++
+	}
+}
+
+// MaxServiceAccountLifetime returns the maximum lifetime of the tokens of the service account.
+// It's the lower limit of the instance and the space of the service account, zero if there's no limit.
+func (s *Service) MaxServiceAccountLifetime(ctx context.Context, sa *types.ServiceAccount) (time.Duration, error) {
+	spaceID := sa.ParentID
+	if sa.ParentType == enum.ParentResourceTypeRepo {
+		repo, err := s.repoStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find parent repo of service account: %w", err)
+		}
+
+		spaceID = repo.ParentID
+	}
+
+	spaceLifetime, err := s.spaceMaxLifetime(ctx, spaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	return minLifetime(s.maxLifetime, spaceLifetime), nil
+}
+
+// CheckLifetime returns an error if the token lifetime exceeds the maximum lifetime.
+// Tokens without expiry aren't allowed if the lifetime is limited.
+func CheckLifetime(maxLifetime time.Duration, lifetime *time.Duration) error {
+	if maxLifetime <= 0 {
+		return nil
+	}
+
+	if lifetime == nil {
+		return errors.InvalidArgument("The token lifetime is required, tokens must expire within %s.", maxLifetime)
+	}
+
+	if *lifetime > maxLifetime {
+		return errors.InvalidArgument("The token lifetime exceeds the maximum lifetime of %s.", maxLifetime)
+	}
+
+	return nil
+}
+
+func (s *Service) spaceMaxLifetime(ctx context.Context, spaceID int64) (time.Duration, error) {
+	maxLifetime, err := settings.SpaceGetInherited(ctx, s.settingsService, spaceID,
+		settings.KeyTokenMaxLifetime, settings.DefaultTokenMaxLifetime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token lifetime setting of space %d: %w", spaceID, err)
+	}
+
+	return maxLifetime, nil
+}
+
+// minLifetime returns the lower of the two lifetime limits, where zero means that there's no limit.
+func minLifetime(a, b time.Duration) time.Duration {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 {
+		return a
+	}
+
+	return min(a, b)
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.warningEnabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for token expiry warnings: %w", err)
+	}
+
+	return nil
+}
+
+// Handle reports an expiring event for every access token that expires soon.
+// Every token is reported once, tokens are reported again only after they're rotated.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.warningEnabled {
+		return "", nil
+	}
+
+	var count int
+	for {
+		tokens, err := s.tokenStore.ListExpiringBefore(ctx, time.Now().Add(s.warningBefore),
+			warnedTokenTypes, expiringPageSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list expiring tokens: %w", err)
+		}
+
+		for _, token := range tokens {
+			token.ExpiryWarnedAt = ptr.Int64(time.Now().UnixMilli())
+			if err = s.tokenStore.Update(ctx, token); err != nil {
+				return "", fmt.Errorf("failed to mark token %d as warned: %w", token.ID, err)
+			}
+
+			s.reporter.Expiring(ctx, &tokenevents.ExpiringPayload{
+				TokenID:     token.ID,
+				PrincipalID: token.PrincipalID,
+				Type:        token.Type,
+				Identifier:  token.Identifier,
+				CreatedBy:   token.CreatedBy,
+				ExpiresAt:   *token.ExpiresAt,
+			})
+		}
+
+		count += len(tokens)
+
+		if len(tokens) < expiringPageSize {
+			break
+		}
+	}
+
+	log.Ctx(ctx).Info().Int("count", count).Msg("reported expiring access tokens")
+
+	return "", nil
+}
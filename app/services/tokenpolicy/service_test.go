@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/errors"
+)
+
+func TestMinLifetime(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b time.Duration
+		want time.Duration
+	}{
+		{name: "both unlimited", a: 0, b: 0, want: 0},
+		{name: "first unlimited", a: 0, b: time.Hour, want: time.Hour},
+		{name: "second unlimited", a: time.Hour, b: 0, want: time.Hour},
+		{name: "lower of both", a: 2 * time.Hour, b: time.Hour, want: time.Hour},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := minLifetime(test.a, test.b); got != test.want {
+				t.Errorf("want=%s got=%s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckLifetime(t *testing.T) {
+	hour := time.Hour
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		maxLifetime time.Duration
+		lifetime    *time.Duration
+		wantErr     bool
+	}{
+		{name: "unlimited without expiry", maxLifetime: 0, lifetime: nil},
+		{name: "unlimited with expiry", maxLifetime: 0, lifetime: &day},
+		{name: "limited without expiry", maxLifetime: day, lifetime: nil, wantErr: true},
+		{name: "limited within", maxLifetime: day, lifetime: &hour},
+		{name: "limited exact", maxLifetime: day, lifetime: &day},
+		{name: "limited exceeded", maxLifetime: hour, lifetime: &day, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckLifetime(test.maxLifetime, test.lifetime)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("want error=%t got=%v", test.wantErr, err)
+			}
+			if err != nil && errors.AsStatus(err) != errors.StatusInvalidArgument {
+				t.Errorf("want invalid argument error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenpolicy
+
+import (
+	tokenevents "github.com/harness/gitness/app/events/token"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	settingsService *settings.Service,
+	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
+	tokenStore store.TokenStore,
+	reporter *tokenevents.Reporter,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service := &Service{
+		maxLifetime:     config.Token.MaxLifetime,
+		gracePeriod:     config.Token.RotationGracePeriod,
+		warningEnabled:  config.Token.ExpiryWarning.Enabled,
+		warningBefore:   config.Token.ExpiryWarning.Before,
+		cron:            config.Token.ExpiryWarning.CRON,
+		maxDur:          config.Token.ExpiryWarning.MaxDuration,
+		settingsService: settingsService,
+		membershipStore: membershipStore,
+		repoStore:       repoStore,
+		tokenStore:      tokenStore,
+		reporter:        reporter,
+		scheduler:       scheduler,
+	}
+
+	if err := executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -124,5 +124,15 @@ func (s *Service) augmentPullReqInfo(
 	hook.Source = pullreq.SourceBranch
 	// expand the branch to a git reference.
 	hook.Ref = fmt.Sprintf("refs/pullreq/%d/head", pullreq.Number)
+
+	// pull requests from forks are marked, so restricted secrets aren't exposed to them.
+	if pullreq.SourceRepoID != pullreq.TargetRepoID {
+		sourceRepo, err := s.repoStore.Find(ctx, pullreq.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("could not find source repository: %w", err)
+		}
+		hook.Fork = sourceRepo.Path
+	}
+
 	return nil
 }
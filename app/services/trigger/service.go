@@ -24,6 +24,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/triggerqueue"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/stream"
@@ -65,7 +66,7 @@ type Service struct {
 	pullReqStore  store.PullReqStore
 	repoStore     store.RepoStore
 	pipelineStore store.PipelineStore
-	triggerSvc    triggerer.Triggerer
+	triggerQueue  *triggerqueue.Service
 	commitSvc     commit.Service
 }
 
@@ -76,7 +77,7 @@ func New(
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
-	triggerSvc triggerer.Triggerer,
+	triggerQueue *triggerqueue.Service,
 	commitSvc commit.Service,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
@@ -91,7 +92,7 @@ func New(
 		repoStore:     repoStore,
 		commitSvc:     commitSvc,
 		pipelineStore: pipelineStore,
-		triggerSvc:    triggerSvc,
+		triggerQueue:  triggerQueue,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -179,7 +180,8 @@ func (s *Service) trigger(ctx context.Context, repoID int64,
 			continue
 		}
 
-		_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
+		// The executions are created asynchronously, with retries on failures.
+		err = s.triggerQueue.Enqueue(ctx, pipeline, hook)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 		}
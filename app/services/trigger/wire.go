@@ -20,7 +20,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
-	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/triggerqueue"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
 
@@ -39,10 +39,10 @@ func ProvideService(
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
-	triggerSvc triggerer.Triggerer,
+	triggerQueue *triggerqueue.Service,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqEvFactory *events.ReaderFactory[*pullreqevents.Reader],
 ) (*Service, error) {
-	return New(ctx, config, triggerStore, pullReqStore, repoStore, pipelineStore, triggerSvc,
+	return New(ctx, config, triggerStore, pullReqStore, repoStore, pipelineStore, triggerQueue,
 		commitSvc, gitReaderFactory, pullReqEvFactory)
 }
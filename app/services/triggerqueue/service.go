@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "pipeline-trigger"
+	jobUIDPrefix   = "pipeline-trigger-%d-"
+	jobMaxDuration = 5 * time.Minute
+)
+
+// Service queues the creation of pipeline executions for git events. The events are handled as soon
+// as they are queued; the creation is retried on failures, and the events for which all attempts failed
+// are kept as failed trigger requests of the repository until they are retried or deleted.
+type Service struct {
+	requestStore  store.TriggerRequestStore
+	pipelineStore store.PipelineStore
+	triggerer     triggerer.Triggerer
+	scheduler     *job.Scheduler
+	maxRetries    int
+}
+
+func NewService(
+	requestStore store.TriggerRequestStore,
+	pipelineStore store.PipelineStore,
+	triggerer triggerer.Triggerer,
+	scheduler *job.Scheduler,
+	maxRetries int,
+) *Service {
+	return &Service{
+		requestStore:  requestStore,
+		pipelineStore: pipelineStore,
+		triggerer:     triggerer,
+		scheduler:     scheduler,
+		maxRetries:    maxRetries,
+	}
+}
+
+// Enqueue queues the creation of an execution of the pipeline for the hook.
+func (s *Service) Enqueue(ctx context.Context, pipeline *types.Pipeline, hook *triggerer.Hook) error {
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	request := &types.TriggerRequest{
+		RepoID:     pipeline.RepoID,
+		PipelineID: pipeline.ID,
+		Action:     hook.Action,
+		Ref:        hook.Ref,
+		SHA:        hook.After,
+		Hook:       data,
+		State:      enum.TriggerRequestStateQueued,
+		Created:    now,
+		Updated:    now,
+	}
+
+	if err = s.requestStore.Create(ctx, request); err != nil {
+		return fmt.Errorf("failed to create trigger request: %w", err)
+	}
+
+	return s.runJob(ctx, request)
+}
+
+// ListFailed returns the trigger requests of the repository that failed all attempts.
+func (s *Service) ListFailed(
+	ctx context.Context,
+	repoID int64,
+	pagination types.Pagination,
+) ([]*types.TriggerRequest, int64, error) {
+	count, err := s.requestStore.CountFailed(ctx, repoID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count failed trigger requests: %w", err)
+	}
+
+	requests, err := s.requestStore.ListFailed(ctx, repoID, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list failed trigger requests: %w", err)
+	}
+
+	return requests, count, nil
+}
+
+// FindFailed returns a trigger request of the repository that failed all attempts.
+func (s *Service) FindFailed(ctx context.Context, repoID, id int64) (*types.TriggerRequest, error) {
+	request, err := s.requestStore.Find(ctx, id)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) ||
+		err == nil && (request.RepoID != repoID || request.State != enum.TriggerRequestStateFailed) {
+		return nil, errors.NotFound("Failed trigger request %d not found.", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find trigger request: %w", err)
+	}
+
+	return request, nil
+}
+
+// Retry queues a failed trigger request again, with all its attempts.
+func (s *Service) Retry(ctx context.Context, request *types.TriggerRequest) error {
+	request.State = enum.TriggerRequestStateQueued
+	request.Attempts = 0
+	request.LastError = ""
+	request.Updated = time.Now().UnixMilli()
+
+	if err := s.requestStore.Update(ctx, request); err != nil {
+		return fmt.Errorf("failed to update trigger request: %w", err)
+	}
+
+	return s.runJob(ctx, request)
+}
+
+// Delete deletes a trigger request.
+func (s *Service) Delete(ctx context.Context, request *types.TriggerRequest) error {
+	if err := s.requestStore.Delete(ctx, request.ID); err != nil {
+		return fmt.Errorf("failed to delete trigger request: %w", err)
+	}
+
+	return nil
+}
+
+// runJob starts the job creating the execution of a trigger request.
+// If the job can't be started the request is marked as failed, so it can be retried later.
+func (s *Service) runJob(ctx context.Context, request *types.TriggerRequest) error {
+	uid, err := job.UID()
+	if err == nil {
+		err = s.scheduler.RunJob(ctx, job.Definition{
+			UID:        fmt.Sprintf(jobUIDPrefix, request.ID) + uid,
+			Type:       jobType,
+			MaxRetries: s.maxRetries,
+			Timeout:    jobMaxDuration,
+			Data:       strconv.FormatInt(request.ID, 10),
+		})
+	}
+	if err == nil {
+		return nil
+	}
+
+	request.State = enum.TriggerRequestStateFailed
+	request.LastError = fmt.Sprintf("failed to queue the trigger request: %s", err)
+	request.Updated = time.Now().UnixMilli()
+
+	if errUpdate := s.requestStore.Update(ctx, request); errUpdate != nil {
+		log.Ctx(ctx).Warn().Err(errUpdate).Int64("trigger_request_id", request.ID).
+			Msg("failed to mark trigger request as failed")
+	}
+
+	return fmt.Errorf("failed to run pipeline trigger job: %w", err)
+}
+
+// Handle creates the execution of a trigger request. Failures are returned to the job scheduler
+// which retries the job, after the last attempt the request is marked as failed.
+// The request is claimed before the execution is created, so a request handled by more than one job
+// (e.g. a retried request whose previous job is still running) creates a single execution.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	id, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid trigger request id %q: %w", data, err)
+	}
+
+	request, err := s.requestStore.Find(ctx, id)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "trigger request was deleted", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find trigger request: %w", err)
+	}
+
+	// a running request is claimed again only once the job that claimed it has timed out.
+	staleBefore := time.Now().Add(-jobMaxDuration).UnixMilli()
+
+	claimed, err := s.requestStore.Claim(ctx, request, staleBefore)
+	if err != nil {
+		return "", fmt.Errorf("failed to claim trigger request: %w", err)
+	}
+	if !claimed {
+		return "trigger request isn't queued", nil
+	}
+
+	result, err := s.trigger(ctx, request)
+	if err != nil {
+		request.State = enum.TriggerRequestStateQueued
+		request.Attempts++
+		request.LastError = err.Error()
+		request.Updated = time.Now().UnixMilli()
+		if request.Attempts > s.maxRetries {
+			request.State = enum.TriggerRequestStateFailed
+		}
+
+		if errUpdate := s.requestStore.Update(ctx, request); errUpdate != nil {
+			return "", fmt.Errorf("failed to update trigger request after failed attempt: %w", errUpdate)
+		}
+
+		return "", err
+	}
+
+	// the execution is created, the job mustn't be retried even if the request can't be deleted.
+	if err = s.requestStore.Delete(ctx, request.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("trigger_request_id", request.ID).
+			Msg("failed to delete processed trigger request")
+	}
+
+	return result, nil
+}
+
+func (s *Service) trigger(ctx context.Context, request *types.TriggerRequest) (string, error) {
+	pipeline, err := s.pipelineStore.Find(ctx, request.PipelineID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	// the pipeline might have been disabled after the request was queued.
+	if pipeline.Disabled {
+		return "pipeline is disabled", nil
+	}
+
+	hook := &triggerer.Hook{}
+	if err = json.Unmarshal(request.Hook, hook); err != nil {
+		return "", fmt.Errorf("failed to unmarshal hook: %w", err)
+	}
+
+	execution, err := s.triggerer.Trigger(ctx, pipeline, hook)
+	if err != nil {
+		return "", fmt.Errorf("failed to trigger pipeline: %w", err)
+	}
+
+	if execution == nil {
+		return "no matching pipelines", nil
+	}
+
+	return fmt.Sprintf("created execution %d", execution.Number), nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const testRequestID = 1
+
+type fakeRequestStore struct {
+	store.TriggerRequestStore
+	requests  map[int64]types.TriggerRequest
+	deleteErr error
+}
+
+func (s *fakeRequestStore) Find(_ context.Context, id int64) (*types.TriggerRequest, error) {
+	request, ok := s.requests[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &request, nil
+}
+
+func (s *fakeRequestStore) Update(_ context.Context, request *types.TriggerRequest) error {
+	if _, ok := s.requests[request.ID]; !ok {
+		return gitness_store.ErrResourceNotFound
+	}
+	s.requests[request.ID] = *request
+	return nil
+}
+
+func (s *fakeRequestStore) Claim(_ context.Context, request *types.TriggerRequest, staleBefore int64) (bool, error) {
+	stored, ok := s.requests[request.ID]
+	if !ok || stored.State != enum.TriggerRequestStateQueued &&
+		(stored.State != enum.TriggerRequestStateRunning || stored.Updated >= staleBefore) {
+		return false, nil
+	}
+
+	request.State = enum.TriggerRequestStateRunning
+	request.Updated = time.Now().UnixMilli()
+	s.requests[request.ID] = *request
+
+	return true, nil
+}
+
+func (s *fakeRequestStore) Delete(_ context.Context, id int64) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	delete(s.requests, id)
+	return nil
+}
+
+type fakePipelineStore struct {
+	store.PipelineStore
+}
+
+func (fakePipelineStore) Find(_ context.Context, id int64) (*types.Pipeline, error) {
+	return &types.Pipeline{ID: id}, nil
+}
+
+// fakeTriggerer creates an execution per call, unless it fails. The hook is called before the execution is created.
+type fakeTriggerer struct {
+	triggerer.Triggerer
+	calls int
+	err   error
+	hook  func()
+}
+
+func (t *fakeTriggerer) Trigger(context.Context, *types.Pipeline, *triggerer.Hook) (*types.Execution, error) {
+	t.calls++
+	if t.hook != nil {
+		t.hook()
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &types.Execution{Number: int64(t.calls)}, nil
+}
+
+func newTestService(request types.TriggerRequest, trig *fakeTriggerer) (*Service, *fakeRequestStore) {
+	request.ID = testRequestID
+	request.Hook = []byte(`{}`)
+
+	requestStore := &fakeRequestStore{requests: map[int64]types.TriggerRequest{testRequestID: request}}
+
+	return NewService(requestStore, fakePipelineStore{}, trig, nil, 2), requestStore
+}
+
+func TestService_Handle(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		request   types.TriggerRequest
+		err       error
+		wantCalls int
+		wantState enum.TriggerRequestState // empty if the request is deleted
+	}{
+		{
+			name:      "queued",
+			request:   types.TriggerRequest{State: enum.TriggerRequestStateQueued},
+			wantCalls: 1,
+		},
+		{
+			name:      "failed",
+			request:   types.TriggerRequest{State: enum.TriggerRequestStateFailed},
+			wantState: enum.TriggerRequestStateFailed,
+		},
+		{
+			name:      "running",
+			request:   types.TriggerRequest{State: enum.TriggerRequestStateRunning, Updated: now.UnixMilli()},
+			wantState: enum.TriggerRequestStateRunning,
+		},
+		{
+			name: "running with a timed out job",
+			request: types.TriggerRequest{
+				State:   enum.TriggerRequestStateRunning,
+				Updated: now.Add(-2 * jobMaxDuration).UnixMilli(),
+			},
+			wantCalls: 1,
+		},
+		{
+			name:      "failed attempt",
+			request:   types.TriggerRequest{State: enum.TriggerRequestStateQueued},
+			err:       errors.New("failure"),
+			wantCalls: 1,
+			wantState: enum.TriggerRequestStateQueued,
+		},
+		{
+			name:      "failed last attempt",
+			request:   types.TriggerRequest{State: enum.TriggerRequestStateQueued, Attempts: 2},
+			err:       errors.New("failure"),
+			wantCalls: 1,
+			wantState: enum.TriggerRequestStateFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trig := &fakeTriggerer{err: test.err}
+			s, requestStore := newTestService(test.request, trig)
+
+			_, err := s.Handle(context.Background(), "1", nil)
+			if (err != nil) != (test.err != nil) {
+				t.Errorf("Handle() error = %v, want %v", err, test.err)
+			}
+
+			if trig.calls != test.wantCalls {
+				t.Errorf("triggered %d times, want %d", trig.calls, test.wantCalls)
+			}
+
+			request, ok := requestStore.requests[testRequestID]
+			if test.wantState == "" {
+				if ok {
+					t.Errorf("expected the request to be deleted, got %+v", request)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected the request to be kept")
+			}
+			if request.State != test.wantState {
+				t.Errorf("state = %s, want %s", request.State, test.wantState)
+			}
+			if test.err != nil && (request.Attempts != test.request.Attempts+1 || request.LastError == "") {
+				t.Errorf("request = %+v, want the failed attempt to be recorded", request)
+			}
+		})
+	}
+}
+
+func TestService_HandleConcurrently(t *testing.T) {
+	trig := &fakeTriggerer{}
+	s, requestStore := newTestService(types.TriggerRequest{State: enum.TriggerRequestStateQueued}, trig)
+
+	// another job handles the same request while the execution is being created.
+	var concurrentResult string
+	var concurrentErr error
+	trig.hook = func() {
+		trig.hook = nil
+		concurrentResult, concurrentErr = s.Handle(context.Background(), "1", nil)
+	}
+
+	if _, err := s.Handle(context.Background(), "1", nil); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if concurrentErr != nil || concurrentResult != "trigger request isn't queued" {
+		t.Errorf("concurrent Handle() = %q, %v, want the request to be skipped", concurrentResult, concurrentErr)
+	}
+	if trig.calls != 1 {
+		t.Errorf("triggered %d times, want once", trig.calls)
+	}
+	if len(requestStore.requests) != 0 {
+		t.Errorf("expected the request to be deleted")
+	}
+}
+
+func TestService_HandleDeleteFailure(t *testing.T) {
+	trig := &fakeTriggerer{}
+	s, requestStore := newTestService(types.TriggerRequest{State: enum.TriggerRequestStateQueued}, trig)
+	requestStore.deleteErr = errors.New("failure")
+
+	// the job isn't retried once the execution is created, and the running request isn't handled again.
+	for range 2 {
+		if _, err := s.Handle(context.Background(), "1", nil); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+
+	if trig.calls != 1 {
+		t.Errorf("triggered %d times, want once", trig.calls)
+	}
+}
@@ -0,0 +1,551 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth/totp"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// recoveryCodeCount is the number of recovery codes generated for a user.
+	recoveryCodeCount = 10
+	// recoveryCodeLength is the number of characters of a recovery code, without the separator.
+	recoveryCodeLength = 10
+	// recoveryCodeAlphabet omits characters that are easily confused (0/o, 1/l/i).
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	membershipPageSize = 100
+)
+
+var (
+	// ErrCodeInvalid is returned if the provided code is neither a valid TOTP code nor an unused recovery code.
+	ErrCodeInvalid = errors.Format(errors.StatusUnauthorized, "Invalid two-factor authentication code").
+			SetDetails(map[string]any{"two_factor_required": true})
+
+	// ErrEnrollmentRequired is returned if two-factor authentication is required for the user
+	// but the user didn't enable it yet.
+	ErrEnrollmentRequired = errors.PreconditionFailed(
+		"Two-factor authentication is required, enable it before creating access tokens.")
+
+	// ErrExternalLoginNotAllowed is returned if a user with two-factor authentication logs in via an identity provider
+	// that isn't trusted to enforce multi-factor authentication.
+	ErrExternalLoginNotAllowed = errors.Format(errors.StatusUnauthorized,
+		"Your account uses two-factor authentication, login with your password and second factor instead.")
+)
+
+type Config struct {
+	// Required enforces two-factor authentication for all users.
+	Required bool
+	// Issuer is the name shown by authenticator apps.
+	Issuer string
+}
+
+// Service manages the two-factor authentication of users.
+// The second factor is either a TOTP code (managed by the service) or a WebAuthn credential.
+type Service struct {
+	twoFactorStore          store.UserTwoFactorStore
+	webAuthnCredentialStore store.WebAuthnCredentialStore
+	membershipStore         store.MembershipStore
+	settingsService         *settings.Service
+	encrypter               encrypt.Encrypter
+	config                  Config
+}
+
+func NewService(
+	twoFactorStore store.UserTwoFactorStore,
+	webAuthnCredentialStore store.WebAuthnCredentialStore,
+	membershipStore store.MembershipStore,
+	settingsService *settings.Service,
+	encrypter encrypt.Encrypter,
+	config Config,
+) *Service {
+	return &Service{
+		twoFactorStore:          twoFactorStore,
+		webAuthnCredentialStore: webAuthnCredentialStore,
+		membershipStore:         membershipStore,
+		settingsService:         settingsService,
+		encrypter:               encrypter,
+		config:                  config,
+	}
+}
+
+// Status returns the two-factor authentication status of the user.
+func (s *Service) Status(ctx context.Context, principalID int64) (*types.TwoFactorStatus, error) {
+	required, err := s.IsRequired(ctx, principalID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.TwoFactorStatus{Required: required}
+
+	twoFactor, err := s.findEnabled(ctx, principalID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor != nil {
+		out.TOTPEnabled = true
+		out.RecoveryCodesRemaining = len(twoFactor.RecoveryCodes)
+	}
+
+	out.WebAuthnCredentials, err = s.countWebAuthnCredentials(ctx, principalID)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Enabled = out.TOTPEnabled || out.WebAuthnCredentials > 0
+
+	return out, nil
+}
+
+// IsEnabled returns true if the user has confirmed the TOTP enrollment or registered a WebAuthn credential.
+func (s *Service) IsEnabled(ctx context.Context, principalID int64) (bool, error) {
+	twoFactor, err := s.findEnabled(ctx, principalID)
+	if err != nil {
+		return false, err
+	}
+	if twoFactor != nil {
+		return true, nil
+	}
+
+	count, err := s.countWebAuthnCredentials(ctx, principalID)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// IsRequired returns true if two-factor authentication is required for the whole instance
+// or by any space the user is a member of (including settings inherited from parent spaces).
+func (s *Service) IsRequired(ctx context.Context, principalID int64) (bool, error) {
+	if s.config.Required {
+		return true, nil
+	}
+
+	filter := types.MembershipSpaceFilter{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Page: 1, Size: membershipPageSize},
+		},
+	}
+
+	for {
+		memberships, err := s.membershipStore.ListSpaces(ctx, principalID, filter)
+		if err != nil {
+			return false, fmt.Errorf("failed to list membership spaces: %w", err)
+		}
+
+		for _, membership := range memberships {
+			required, err := settings.SpaceGetInherited(ctx, s.settingsService, membership.Space.ID,
+				settings.KeyTwoFactorRequired, settings.DefaultTwoFactorRequired)
+			if err != nil {
+				return false, fmt.Errorf("failed to get two-factor setting of space %d: %w", membership.Space.ID, err)
+			}
+
+			if required {
+				return true, nil
+			}
+		}
+
+		if len(memberships) < filter.Size {
+			return false, nil
+		}
+
+		filter.Page++
+	}
+}
+
+// Enroll generates a new TOTP secret for the user. The enrollment has to be confirmed
+// with a code generated from the secret before it is used for authentication.
+// A pending enrollment is replaced.
+func (s *Service) Enroll(ctx context.Context, user *types.User) (*types.TwoFactorEnrollment, error) {
+	twoFactor, err := s.findEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor != nil {
+		return nil, errors.Conflict("Two-factor authentication is already enabled.")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedSecret, err := s.encrypter.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.twoFactorStore.Upsert(ctx, &types.UserTwoFactor{
+		PrincipalID:   user.ID,
+		Secret:        encryptedSecret,
+		RecoveryCodes: []string{},
+		Created:       now,
+		Updated:       now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store two-factor enrollment: %w", err)
+	}
+
+	account := user.Email
+	if account == "" {
+		account = user.UID
+	}
+
+	return &types.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(s.config.Issuer, account, secret),
+	}, nil
+}
+
+// Confirm enables two-factor authentication of the user if the code matches the pending enrollment.
+// It returns the recovery codes of the user, which are only available in plain text once.
+func (s *Service) Confirm(
+	ctx context.Context,
+	principalID int64,
+	code string,
+) (*types.TwoFactorRecoveryCodes, error) {
+	twoFactor, err := s.twoFactorStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.PreconditionFailed("Two-factor authentication enrollment not started.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find two-factor state: %w", err)
+	}
+
+	if twoFactor.Enabled != nil {
+		return nil, errors.Conflict("Two-factor authentication is already enabled.")
+	}
+
+	step, ok, err := s.validateTOTP(twoFactor, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.InvalidArgument("Invalid two-factor authentication code.")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	twoFactor.Enabled = &now
+	twoFactor.LastUsedStep = step
+	twoFactor.RecoveryCodes = hashes
+	twoFactor.Updated = now
+
+	if err = s.twoFactorStore.Upsert(ctx, twoFactor); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	return &types.TwoFactorRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off TOTP based two-factor authentication of the user, after verifying the provided code.
+// It's not possible to disable it if two-factor authentication is required for the user
+// and the user doesn't have a WebAuthn credential as alternative second factor.
+func (s *Service) Disable(ctx context.Context, principalID int64, code string) error {
+	required, err := s.IsRequired(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.countWebAuthnCredentials(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	if required && count == 0 {
+		return errors.PreconditionFailed("Two-factor authentication is required and can't be disabled.")
+	}
+
+	if err = s.verifyEnabled(ctx, principalID, code); err != nil {
+		return err
+	}
+
+	if err = s.twoFactorStore.Delete(ctx, principalID); err != nil {
+		return fmt.Errorf("failed to delete two-factor state: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user, after verifying the provided code.
+func (s *Service) RegenerateRecoveryCodes(
+	ctx context.Context,
+	principalID int64,
+	code string,
+) (*types.TwoFactorRecoveryCodes, error) {
+	if err := s.verifyEnabled(ctx, principalID, code); err != nil {
+		return nil, err
+	}
+
+	// re-read the state to have the latest version after a possibly consumed recovery code.
+	twoFactor, err := s.twoFactorStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find two-factor state: %w", err)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor.RecoveryCodes = hashes
+	if err = s.twoFactorStore.UpdateRecoveryCodes(ctx, twoFactor); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return &types.TwoFactorRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// CheckWebAuthnRemoval returns an error if removing a WebAuthn credential of the user would leave
+// the user without a second factor, while two-factor authentication is required for the user.
+func (s *Service) CheckWebAuthnRemoval(ctx context.Context, principalID int64) error {
+	twoFactor, err := s.findEnabled(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.countWebAuthnCredentials(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	if twoFactor != nil || count > 1 {
+		return nil
+	}
+
+	required, err := s.IsRequired(ctx, principalID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.PreconditionFailed(
+			"Two-factor authentication is required, the last second factor can't be removed.")
+	}
+
+	return nil
+}
+
+// Verify checks the TOTP second factor of the user. It returns nil if the user doesn't have
+// two-factor authentication enabled, otherwise the code must be a valid TOTP code or an unused recovery code.
+// Accepted TOTP codes can't be reused and used recovery codes are removed.
+// Users that only have WebAuthn credentials must authenticate with one of them instead.
+func (s *Service) Verify(ctx context.Context, principalID int64, code string) error {
+	twoFactor, err := s.findEnabled(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.countWebAuthnCredentials(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	if twoFactor == nil && count == 0 {
+		return nil
+	}
+
+	if strings.TrimSpace(code) == "" {
+		return errCodeRequired(twoFactor != nil, count > 0)
+	}
+
+	if twoFactor == nil {
+		return ErrCodeInvalid
+	}
+
+	return s.verify(ctx, twoFactor, code)
+}
+
+func (s *Service) verifyEnabled(ctx context.Context, principalID int64, code string) error {
+	twoFactor, err := s.findEnabled(ctx, principalID)
+	if err != nil {
+		return err
+	}
+	if twoFactor == nil {
+		return errors.NotFound("Two-factor authentication is not enabled.")
+	}
+
+	return s.verify(ctx, twoFactor, code)
+}
+
+func (s *Service) verify(ctx context.Context, twoFactor *types.UserTwoFactor, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return errCodeRequired(true, false)
+	}
+
+	step, ok, err := s.validateTOTP(twoFactor, code)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		err = s.twoFactorStore.UpdateLastUsedStep(ctx, twoFactor.PrincipalID, step)
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			log.Ctx(ctx).Warn().Int64("principal_id", twoFactor.PrincipalID).Msg("replayed totp code rejected")
+			return ErrCodeInvalid
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update last used totp step: %w", err)
+		}
+
+		return nil
+	}
+
+	hash := hashRecoveryCode(code)
+	idx := slices.Index(twoFactor.RecoveryCodes, hash)
+	if idx < 0 {
+		return ErrCodeInvalid
+	}
+
+	twoFactor.RecoveryCodes = slices.Delete(twoFactor.RecoveryCodes, idx, idx+1)
+
+	err = s.twoFactorStore.UpdateRecoveryCodes(ctx, twoFactor)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		// the state changed concurrently, the recovery code might have been used already.
+		return ErrCodeInvalid
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("principal_id", twoFactor.PrincipalID).
+		Int("recovery_codes_remaining", len(twoFactor.RecoveryCodes)).
+		Msg("recovery code used for two-factor authentication")
+
+	return nil
+}
+
+func (s *Service) findEnabled(ctx context.Context, principalID int64) (*types.UserTwoFactor, error) {
+	twoFactor, err := s.twoFactorStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find two-factor state: %w", err)
+	}
+
+	if twoFactor.Enabled == nil {
+		return nil, nil
+	}
+
+	return twoFactor, nil
+}
+
+func (s *Service) countWebAuthnCredentials(ctx context.Context, principalID int64) (int, error) {
+	count, err := s.webAuthnCredentialStore.Count(ctx, principalID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count webauthn credentials: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (s *Service) validateTOTP(twoFactor *types.UserTwoFactor, code string) (int64, bool, error) {
+	secret, err := s.encrypter.Decrypt(twoFactor.Secret)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	step, ok, err := totp.Validate(secret, code, time.Now())
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to validate totp code: %w", err)
+	}
+
+	// codes of already accepted steps are rejected to prevent replay attacks.
+	if ok && step <= twoFactor.LastUsedStep {
+		return 0, false, nil
+	}
+
+	return step, ok, nil
+}
+
+// errCodeRequired returns the error of a missing second factor,
+// the details tell clients which second factors the user can use.
+func errCodeRequired(totp bool, webAuthn bool) error {
+	methods := make([]string, 0, 2)
+	if totp {
+		methods = append(methods, "totp")
+	}
+	if webAuthn {
+		methods = append(methods, "webauthn")
+	}
+
+	return errors.Format(errors.StatusUnauthorized, "Two-factor authentication required").
+		SetDetails(map[string]any{
+			"two_factor_required": true,
+			"two_factor_methods":  methods,
+		})
+}
+
+// generateRecoveryCodes returns new recovery codes in plain text along with their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := range codes {
+		var sb strings.Builder
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+			}
+
+			sb.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+
+		codes[i] = sb.String()
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes the recovery code ignoring case and separators.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
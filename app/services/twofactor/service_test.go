@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("want %d codes, got %d codes and %d hashes", recoveryCodeCount, len(codes), len(hashes))
+	}
+
+	seen := map[string]struct{}{}
+	for i, code := range codes {
+		if len(code) != recoveryCodeLength+1 || code[recoveryCodeLength/2] != '-' {
+			t.Errorf("unexpected recovery code format: %s", code)
+		}
+
+		if hashes[i] != hashRecoveryCode(code) {
+			t.Errorf("hash of recovery code %s doesn't match", code)
+		}
+
+		if _, ok := seen[code]; ok {
+			t.Errorf("duplicate recovery code: %s", code)
+		}
+		seen[code] = struct{}{}
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := hashRecoveryCode("abcde-fghjk")
+
+	for _, code := range []string{"abcdefghjk", "ABCDE-FGHJK", "abcde fghjk"} {
+		if got := hashRecoveryCode(code); got != want {
+			t.Errorf("code %q: want=%s got=%s", code, want, got)
+		}
+	}
+
+	if hashRecoveryCode("abcde-fghjm") == want {
+		t.Errorf("different codes must not have the same hash")
+	}
+
+	if strings.Contains(want, "abcde") {
+		t.Errorf("hash must not contain the code")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/maps"
+)
+
+// maxNestingDepth limits how deep nested usergroups are expanded.
+const maxNestingDepth = 10
+
+var (
+	_ SearchService = (*Service)(nil)
+	_ Resolver      = (*Service)(nil)
+)
+
+// Service provides the usergroups defined in spaces. Usergroups can contain users and other usergroups,
+// the members of nested usergroups are members of all usergroups they are nested in.
+type Service struct {
+	userGroupStore     store.UserGroupStore
+	memberStore        store.UserGroupMemberStore
+	subgroupStore      store.UserGroupSubgroupStore
+	spaceStore         store.SpaceStore
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func NewService(
+	userGroupStore store.UserGroupStore,
+	memberStore store.UserGroupMemberStore,
+	subgroupStore store.UserGroupSubgroupStore,
+	spaceStore store.SpaceStore,
+	principalInfoCache store.PrincipalInfoCache,
+) *Service {
+	return &Service{
+		userGroupStore:     userGroupStore,
+		memberStore:        memberStore,
+		subgroupStore:      subgroupStore,
+		spaceStore:         spaceStore,
+		principalInfoCache: principalInfoCache,
+	}
+}
+
+// Search returns the usergroups that can be used in the space,
+// these are the usergroups defined in the space and in any of its ancestor spaces.
+func (s *Service) Search(
+	ctx context.Context,
+	filter *types.ListQueryFilter,
+	spacePath string,
+) ([]*types.UserGroupInfo, error) {
+	space, err := s.spaceStore.FindByRef(ctx, spacePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces: %w", err)
+	}
+
+	userGroups, err := s.userGroupStore.List(ctx, spaceIDs, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroups: %w", err)
+	}
+
+	infos := make([]*types.UserGroupInfo, len(userGroups))
+	for i, userGroup := range userGroups {
+		infos[i] = userGroup.ToUserGroupInfo()
+	}
+
+	return infos, nil
+}
+
+// ListUsers returns the UIDs of all users of the usergroup, including the users of nested usergroups.
+func (s *Service) ListUsers(
+	ctx context.Context,
+	_ *auth.Session,
+	userGroup *types.UserGroup,
+) ([]string, error) {
+	principalIDs, err := s.ListUserIDsByGroupIDs(ctx, []int64{userGroup.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(principalIDs) == 0 {
+		return nil, nil
+	}
+
+	infoMap, err := s.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load principal infos of usergroup users: %w", err)
+	}
+
+	uids := make([]string, 0, len(infoMap))
+	for _, principalID := range principalIDs {
+		if info, ok := infoMap[principalID]; ok {
+			uids = append(uids, info.UID)
+		}
+	}
+
+	return uids, nil
+}
+
+// ListUserIDsByGroupIDs returns the IDs of all users of the usergroups, including the users of nested usergroups.
+func (s *Service) ListUserIDsByGroupIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	if len(userGroupIDs) == 0 {
+		return nil, nil
+	}
+
+	expandedIDs, err := s.ExpandSubgroups(ctx, userGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	principalIDs, err := s.memberStore.ListPrincipalIDs(ctx, expandedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroup members: %w", err)
+	}
+
+	return principalIDs, nil
+}
+
+// ExpandSubgroups returns the IDs of the usergroups along with the IDs of all usergroups nested in them.
+func (s *Service) ExpandSubgroups(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	seen := make(map[int64]struct{}, len(userGroupIDs))
+	for _, id := range userGroupIDs {
+		seen[id] = struct{}{}
+	}
+
+	current := userGroupIDs
+	for depth := 0; depth < maxNestingDepth && len(current) > 0; depth++ {
+		childIDs, err := s.subgroupStore.ListChildIDs(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nested usergroups: %w", err)
+		}
+
+		next := make([]int64, 0, len(childIDs))
+		for _, id := range childIDs {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			next = append(next, id)
+		}
+
+		current = next
+	}
+
+	return maps.Keys(seen), nil
+}
+
+// Resolve returns the usergroup with its users for a usergroup reference
+// in the form <space_path>/<usergroup_identifier>, as used in CODEOWNERS files.
+func (s *Service) Resolve(ctx context.Context, scopedID string) (*types.UserGroup, error) {
+	spacePath, identifier, err := paths.DisectLeaf(scopedID)
+	if err != nil || spacePath == "" {
+		return nil, ErrNotFound
+	}
+
+	space, err := s.spaceStore.FindByRef(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of usergroup: %w", err)
+	}
+
+	userGroup, err := s.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usergroup: %w", err)
+	}
+
+	userGroup.Users, err = s.ListUsers(ctx, nil, userGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return userGroup, nil
+}
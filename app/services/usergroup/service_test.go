@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// subgroupStoreMock keeps the nested usergroups as a map of parent ID to child IDs.
+type subgroupStoreMock map[int64][]int64
+
+func (m subgroupStoreMock) Create(context.Context, *types.UserGroupSubgroup) error { return nil }
+func (m subgroupStoreMock) Delete(context.Context, int64, int64) error             { return nil }
+
+func (m subgroupStoreMock) ListChildIDs(_ context.Context, parentIDs []int64) ([]int64, error) {
+	var ids []int64
+	for _, id := range parentIDs {
+		ids = append(ids, m[id]...)
+	}
+	return ids, nil
+}
+
+func (m subgroupStoreMock) ListParentIDs(context.Context, []int64) ([]int64, error) { return nil, nil }
+
+func TestExpandSubgroups(t *testing.T) {
+	tests := []struct {
+		name      string
+		subgroups subgroupStoreMock
+		input     []int64
+		want      []int64
+	}{
+		{
+			name:      "no subgroups",
+			subgroups: subgroupStoreMock{},
+			input:     []int64{1, 2},
+			want:      []int64{1, 2},
+		},
+		{
+			name:      "nested",
+			subgroups: subgroupStoreMock{1: {2, 3}, 3: {4}},
+			input:     []int64{1},
+			want:      []int64{1, 2, 3, 4},
+		},
+		{
+			name:      "shared subgroup",
+			subgroups: subgroupStoreMock{1: {3}, 2: {3}},
+			input:     []int64{1, 2},
+			want:      []int64{1, 2, 3},
+		},
+		{
+			name:      "cycle",
+			subgroups: subgroupStoreMock{1: {2}, 2: {1}},
+			input:     []int64{1},
+			want:      []int64{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := NewService(nil, nil, test.subgroups, nil, nil)
+
+			got, err := svc.ExpandSubgroups(context.Background(), test.input)
+			require.NoError(t, err)
+
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			require.Equal(t, test.want, got)
+		})
+	}
+}
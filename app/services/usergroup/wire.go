@@ -15,19 +15,32 @@
 package usergroup
 
 import (
+	"github.com/harness/gitness/app/store"
+
 	"github.com/google/wire"
 )
 
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
+	ProvideService,
 	ProvideUserGroupResolver,
 	ProvideSearchService,
 )
 
-func ProvideUserGroupResolver() Resolver {
-	return NewGitnessResolver()
+func ProvideService(
+	userGroupStore store.UserGroupStore,
+	memberStore store.UserGroupMemberStore,
+	subgroupStore store.UserGroupSubgroupStore,
+	spaceStore store.SpaceStore,
+	principalInfoCache store.PrincipalInfoCache,
+) *Service {
+	return NewService(userGroupStore, memberStore, subgroupStore, spaceStore, principalInfoCache)
+}
+
+func ProvideUserGroupResolver(svc *Service) Resolver {
+	return svc
 }
 
-func ProvideSearchService() SearchService {
-	return NewSearchService()
+func ProvideSearchService(svc *Service) SearchService {
+	return svc
 }
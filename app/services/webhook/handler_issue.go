@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// IssuePayload describes the body of the issue created, closed and reopened triggers.
+type IssuePayload struct {
+	BaseSegment
+	IssueSegment
+}
+
+// IssueCommentPayload describes the body of the issue comment created trigger.
+type IssueCommentPayload struct {
+	BaseSegment
+	IssueSegment
+	IssueCommentSegment
+}
+
+// handleEventIssueCreated handles issue created events
+// and triggers issue created webhooks for the repo.
+func (s *Service) handleEventIssueCreated(ctx context.Context,
+	event *events.Event[*repoevents.IssueCreatedPayload]) error {
+	return s.triggerForIssueEvent(ctx, enum.WebhookTriggerIssueCreated, event.ID, &event.Payload.IssueBase)
+}
+
+// handleEventIssueStateChanged handles issue state changed events
+// and triggers issue closed or issue reopened webhooks for the repo.
+func (s *Service) handleEventIssueStateChanged(ctx context.Context,
+	event *events.Event[*repoevents.IssueStateChangedPayload]) error {
+	trigger := enum.WebhookTriggerIssueReopened
+	if event.Payload.NewState == enum.IssueStateClosed {
+		trigger = enum.WebhookTriggerIssueClosed
+	}
+
+	return s.triggerForIssueEvent(ctx, trigger, event.ID, &event.Payload.IssueBase)
+}
+
+// handleEventIssueCommentCreated handles issue comment created events
+// and triggers issue comment created webhooks for the repo.
+func (s *Service) handleEventIssueCommentCreated(ctx context.Context,
+	event *events.Event[*repoevents.IssueCommentCreatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerIssueCommentCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			issue, err := s.issueStore.Find(ctx, event.Payload.IssueID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find issue: %w", err)
+			}
+
+			comment, err := s.issueCommentStore.Find(ctx, event.Payload.CommentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find issue comment: %w", err)
+			}
+
+			return &IssueCommentPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueCommentCreated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(ctx, issue, repo, s.urlProvider),
+				},
+				IssueCommentSegment: IssueCommentSegment{
+					CommentInfo: CommentInfo{
+						ID:   comment.ID,
+						Text: comment.Text,
+					},
+				},
+			}, nil
+		})
+}
+
+func (s *Service) triggerForIssueEvent(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	base *repoevents.IssueBase,
+) error {
+	return s.triggerForEventWithRepo(ctx, trigger, eventID, base.PrincipalID, base.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			issue, err := s.issueStore.Find(ctx, base.IssueID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find issue: %w", err)
+			}
+
+			return &IssuePayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(ctx, issue, repo, s.urlProvider),
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PullReqReviewSLAPayload describes the body of the pullreq review SLA warning and breached triggers.
+type PullReqReviewSLAPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	ReviewSLASegment
+}
+
+// ReviewSLASegment contains the review SLA details of a pull request.
+type ReviewSLASegment struct {
+	// DueAt is the time the pull request has to receive its first review by.
+	DueAt int64 `json:"due_at"`
+}
+
+// handleEventPullReqReviewSLAWarning handles review SLA warning events for pull requests
+// and triggers pullreq review SLA warning webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewSLAWarning(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSLAWarningPayload],
+) error {
+	return s.triggerForReviewSLAEvent(ctx, enum.WebhookTriggerPullReqReviewSLAWarning,
+		event.ID, event.Payload.Base, event.Payload.DueAt)
+}
+
+// handleEventPullReqReviewSLABreached handles review SLA breached events for pull requests
+// and triggers pullreq review SLA breached webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewSLABreached(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSLABreachedPayload],
+) error {
+	return s.triggerForReviewSLAEvent(ctx, enum.WebhookTriggerPullReqReviewSLABreached,
+		event.ID, event.Payload.Base, event.Payload.DueAt)
+}
+
+func (s *Service) triggerForReviewSLAEvent(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	base pullreqevents.Base,
+	dueAt int64,
+) error {
+	return s.triggerForEventWithPullReq(ctx, trigger,
+		eventID, base.PrincipalID, base.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(ctx, targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(ctx, sourceRepo, s.urlProvider)
+
+			return &PullReqReviewSLAPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(ctx, pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				ReviewSLASegment: ReviewSLASegment{
+					DueAt: dueAt,
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// BranchProtectionViolatedPayload describes the payload of branch protection violated triggers.
+type BranchProtectionViolatedPayload struct {
+	BaseSegment
+	Branches      []string            `json:"branches"`
+	PullReqNumber int64               `json:"pull_req_number,omitempty"`
+	Blocked       bool                `json:"blocked"`
+	Violations    []RuleViolationInfo `json:"violations"`
+}
+
+// handleEventRuleViolated handles rule violated events
+// and triggers branch protection violated webhooks for the repo.
+func (s *Service) handleEventRuleViolated(ctx context.Context,
+	event *events.Event[*repoevents.RuleViolatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerBranchProtectionViolated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &BranchProtectionViolatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerBranchProtectionViolated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				Branches:      event.Payload.Branches,
+				PullReqNumber: event.Payload.PullReqNumber,
+				Blocked:       event.Payload.Blocked,
+				Violations:    ruleViolationsInfoFrom(event.Payload.Violations),
+			}, nil
+		})
+}
+
+func (p *BranchProtectionViolatedPayload) branchNames() []string {
+	return p.Branches
+}
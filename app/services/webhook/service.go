@@ -23,6 +23,7 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -41,12 +42,15 @@ type Config struct {
 	UserAgentIdentity string
 	// HeaderIdentity specifies the identity used for headers in webhook calls (e.g. X-Gitness-Trigger, ...).
 	// NOTE: If no value is provided, the UserAgentIdentity will be used.
-	HeaderIdentity      string
-	EventReaderName     string
-	Concurrency         int
-	MaxRetries          int
-	AllowPrivateNetwork bool
-	AllowLoopback       bool
+	HeaderIdentity  string
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	MaxRetryBackoff time.Duration
+	// SecretRotationGracePeriod is the duration the previous secret is still used after a secret change.
+	SecretRotationGracePeriod time.Duration
+	AllowPrivateNetwork       bool
+	AllowLoopback             bool
 }
 
 func (c *Config) Prepare() error {
@@ -65,6 +69,12 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.MaxRetryBackoff < 0 {
+		return errors.New("config.MaxRetryBackoff can't be negative")
+	}
+	if c.SecretRotationGracePeriod < 0 {
+		return errors.New("config.SecretRotationGracePeriod can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
@@ -80,7 +90,10 @@ type Service struct {
 	webhookExecutionStore store.WebhookExecutionStore
 	urlProvider           url.Provider
 	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
 	pullreqStore          store.PullReqStore
+	issueStore            store.IssueStore
+	issueCommentStore     store.IssueCommentStore
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
@@ -100,11 +113,15 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	issueStore store.IssueStore,
+	issueCommentStore store.IssueCommentStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
@@ -117,8 +134,11 @@ func NewService(
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
+		spaceStore:            spaceStore,
 		pullreqStore:          pullreqStore,
 		activityStore:         activityStore,
+		issueStore:            issueStore,
+		issueCommentStore:     issueCommentStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
@@ -141,6 +161,7 @@ func NewService(
 				stream.WithHandlerOptions(
 					stream.WithIdleTimeout(idleTimeout),
 					stream.WithMaxRetries(config.MaxRetries),
+					stream.WithRetryBackoff(config.MaxRetryBackoff),
 				))
 
 			// register events
@@ -166,6 +187,7 @@ func NewService(
 				stream.WithHandlerOptions(
 					stream.WithIdleTimeout(idleTimeout),
 					stream.WithMaxRetries(config.MaxRetries),
+					stream.WithRetryBackoff(config.MaxRetryBackoff),
 				))
 
 			// register events
@@ -176,6 +198,8 @@ func NewService(
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
 			_ = r.RegisterUpdated(service.handleEventPullReqUpdated)
+			_ = r.RegisterReviewSLAWarning(service.handleEventPullReqReviewSLAWarning)
+			_ = r.RegisterReviewSLABreached(service.handleEventPullReqReviewSLABreached)
 
 			return nil
 		})
@@ -183,5 +207,28 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+					stream.WithRetryBackoff(config.MaxRetryBackoff),
+				))
+
+			// register events
+			_ = r.RegisterRuleViolated(service.handleEventRuleViolated)
+			_ = r.RegisterIssueCreated(service.handleEventIssueCreated)
+			_ = r.RegisterIssueStateChanged(service.handleEventIssueStateChanged)
+			_ = r.RegisterIssueCommentCreated(service.handleEventIssueCommentCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
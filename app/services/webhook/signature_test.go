@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+)
+
+func TestGenerateSignatures(t *testing.T) {
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %s", err)
+	}
+
+	encrypted := func(secret string) string {
+		b, err := encrypter.Encrypt(secret)
+		if err != nil {
+			t.Fatalf("failed to encrypt secret: %s", err)
+		}
+		return string(b)
+	}
+
+	payload := []byte(`{"trigger":"branch_created"}`)
+
+	signature := func(secret string) string {
+		s, err := generateHMACSHA256(payload, []byte(secret))
+		if err != nil {
+			t.Fatalf("failed to generate signature: %s", err)
+		}
+		return s
+	}
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		webhook *types.Webhook
+		want    []string
+	}{
+		{
+			name:    "no-secret",
+			webhook: &types.Webhook{},
+			want:    []string{},
+		},
+		{
+			name:    "current-secret",
+			webhook: &types.Webhook{Secret: encrypted("current")},
+			want:    []string{signature("current")},
+		},
+		{
+			name: "dual-signing-during-grace-period",
+			webhook: &types.Webhook{
+				Secret:                encrypted("current"),
+				PreviousSecret:        encrypted("previous"),
+				PreviousSecretExpires: now.Add(time.Hour).UnixMilli(),
+			},
+			want: []string{signature("current"), signature("previous")},
+		},
+		{
+			name: "previous-secret-expired",
+			webhook: &types.Webhook{
+				Secret:                encrypted("current"),
+				PreviousSecret:        encrypted("previous"),
+				PreviousSecretExpires: now.Add(-time.Minute).UnixMilli(),
+			},
+			want: []string{signature("current")},
+		},
+		{
+			name: "secret-removed-during-grace-period",
+			webhook: &types.Webhook{
+				PreviousSecret:        encrypted("previous"),
+				PreviousSecretExpires: now.Add(time.Hour).UnixMilli(),
+			},
+			want: []string{signature("previous")},
+		},
+	}
+
+	s := &Service{encrypter: encrypter}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.generateSignatures(test.webhook, payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("signatures: want=%v got=%v", test.want, got)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("signature %d: want=%q got=%q", i, test.want[i], got[i])
+				}
+			}
+		})
+	}
+}
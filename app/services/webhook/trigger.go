@@ -26,6 +26,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/store"
@@ -33,6 +34,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 	"github.com/harness/gitness/version"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/rs/zerolog/log"
 )
 
@@ -46,6 +48,10 @@ const (
 
 	// responseBodyBytesLimit defines the maximum number of bytes processed from the webhook response body.
 	responseBodyBytesLimit = 1024
+
+	// SignatureScheme is the scheme used to sign webhook payloads (HMAC with SHA256, hex encoded).
+	// Signatures are sent in the X-{Identity}-Signature-256 header as comma separated "sha256=<hmac>" values.
+	SignatureScheme = "sha256"
 )
 
 var (
@@ -68,7 +74,63 @@ func (r *TriggerResult) Skipped() bool {
 
 func (s *Service) triggerWebhooksFor(ctx context.Context, parentType enum.WebhookParent, parentID int64,
 	triggerID string, triggerType enum.WebhookTrigger, body any) ([]TriggerResult, error) {
-	// get all webhooks for the given parent
+	webhooks, err := s.listWebhooksWithAncestors(ctx, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.triggerWebhooks(ctx, webhooks, triggerID, triggerType, body)
+}
+
+// listWebhooksWithAncestors returns all webhooks of the given parent and of all its ancestor spaces.
+func (s *Service) listWebhooksWithAncestors(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+) ([]*types.Webhook, error) {
+	var spaceID int64
+	var webhooks []*types.Webhook
+
+	switch parentType {
+	case enum.WebhookParentRepo:
+		repoWebhooks, err := s.listWebhooks(ctx, enum.WebhookParentRepo, parentID)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = repoWebhooks
+
+		repo, err := s.repoStore.Find(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo %d: %w", parentID, err)
+		}
+		spaceID = repo.ParentID
+	case enum.WebhookParentSpace:
+		spaceID = parentID
+	default:
+		return nil, fmt.Errorf("unsupported webhook parent type %q", parentType)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestors of space %d: %w", spaceID, err)
+	}
+
+	for _, id := range spaceIDs {
+		spaceWebhooks, err := s.listWebhooks(ctx, enum.WebhookParentSpace, id)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, spaceWebhooks...)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Service) listWebhooks(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+) ([]*types.Webhook, error) {
 	// NOTE: there never should be even close to 1000 webhooks for a repo (that should be blocked in the future).
 	// We just use 1000 as a safe number to get all hooks
 	webhooks, err := s.webhookStore.List(ctx, parentType, parentID, &types.WebhookFilter{Size: 1000, Order: enum.OrderAsc})
@@ -76,7 +138,7 @@ func (s *Service) triggerWebhooksFor(ctx context.Context, parentType enum.Webhoo
 		return nil, fmt.Errorf("failed to list webhooks for %s %d: %w", parentType, parentID, err)
 	}
 
-	return s.triggerWebhooks(ctx, webhooks, triggerID, triggerType, body)
+	return webhooks, nil
 }
 
 //nolint:gocognit // refactor if needed
@@ -133,6 +195,11 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 			continue
 		}
 
+		// check if the payload matches the branch patterns of the webhook
+		if !matchesBranchPatterns(webhook.BranchPatterns, body) {
+			continue
+		}
+
 		// execute trigger and store output in result
 		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
 	}
@@ -140,6 +207,37 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 	return results, nil
 }
 
+// matchesBranchPatterns returns true in case the branches of the payload match any of the provided patterns
+// (empty list => all branches are matched).
+// Payloads that aren't related to a branch (e.g. tag triggers) always match.
+// For pull request payloads the target branch is used, as that's the branch that receives the changes.
+func matchesBranchPatterns(patterns []string, body any) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	var branches []string
+	switch b := body.(type) {
+	case interface{ targetBranchNames() []string }:
+		branches = b.targetBranchNames()
+	case interface{ branchNames() []string }:
+		branches = b.branchNames()
+	}
+	if len(branches) == 0 {
+		return true
+	}
+
+	for _, branch := range branches {
+		for _, pattern := range patterns {
+			if ok, _ := doublestar.Match(pattern, branch); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutionID int64) (*TriggerResult, error) {
 	// find execution
 	webhookExecution, err := s.webhookExecutionStore.Find(ctx, webhookExecutionID)
@@ -343,17 +441,19 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 	req.Header.Add(s.toXHeader("Webhook-Identifier"), fmt.Sprint(webhook.Identifier))
 
 	// add HMAC only if a secret was provided
-	if webhook.Secret != "" {
-		decryptedSecret, err := s.encrypter.Decrypt([]byte(webhook.Secret))
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
-		}
-		var hmac string
-		hmac, err = generateHMACSHA256(bBuff.Bytes(), []byte(decryptedSecret))
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate SHA256 based HMAC: %w", err)
+	signatures, err := s.generateSignatures(webhook, bBuff.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(signatures) > 0 {
+		// NOTE: the plain signature header is kept for backwards compatibility.
+		req.Header.Add(s.toXHeader("Signature"), signatures[0])
+
+		schemeSignatures := make([]string, len(signatures))
+		for i, signature := range signatures {
+			schemeSignatures[i] = SignatureScheme + "=" + signature
 		}
-		req.Header.Add(s.toXHeader("Signature"), hmac)
+		req.Header.Add(s.toXHeader("Signature-256"), strings.Join(schemeSignatures, ","))
 	}
 
 	hBuffer := &bytes.Buffer{}
@@ -471,6 +571,33 @@ func handleWebhookResponse(execution *types.WebhookExecution, resp *http.Respons
 	}
 }
 
+// generateSignatures generates the HMAC signatures of the payload for all valid secrets of the webhook.
+// While a secret rotation is in progress, the payload is signed with both the current and the previous secret.
+func (s *Service) generateSignatures(webhook *types.Webhook, payload []byte) ([]string, error) {
+	secrets := make([]string, 0, 2)
+	if webhook.Secret != "" {
+		secrets = append(secrets, webhook.Secret)
+	}
+	if webhook.PreviousSecret != "" && time.Now().UnixMilli() < webhook.PreviousSecretExpires {
+		secrets = append(secrets, webhook.PreviousSecret)
+	}
+
+	signatures := make([]string, len(secrets))
+	for i, secret := range secrets {
+		decryptedSecret, err := s.encrypter.Decrypt([]byte(secret))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+		}
+
+		signatures[i], err = generateHMACSHA256(payload, []byte(decryptedSecret))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate SHA256 based HMAC: %w", err)
+		}
+	}
+
+	return signatures, nil
+}
+
 // generateHMACSHA256 generates a new HMAC using SHA256 as hash function.
 func generateHMACSHA256(data []byte, key []byte) (string, error) {
 	h := hmac.New(sha256.New, key)
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+)
+
+func TestMatchesBranchPatterns(t *testing.T) {
+	branchPayload := func(ref string) any {
+		return &ReferencePayload{ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: ref}}}
+	}
+	pullReqPayload := func(source, target string) any {
+		return &PullReqCreatedPayload{
+			ReferenceSegment: ReferenceSegment{
+				Ref: ReferenceInfo{Name: gitReferenceNamePrefixBranch + source},
+			},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+				TargetRef: ReferenceInfo{Name: gitReferenceNamePrefixBranch + target},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		body     any
+		want     bool
+	}{
+		{
+			name:     "no-patterns-matches-all",
+			patterns: nil,
+			body:     branchPayload("refs/heads/feature/x"),
+			want:     true,
+		},
+		{
+			name:     "branch-matches",
+			patterns: []string{"main", "release/**"},
+			body:     branchPayload("refs/heads/release/1.0/hotfix"),
+			want:     true,
+		},
+		{
+			name:     "branch-mismatches",
+			patterns: []string{"main", "release/*"},
+			body:     branchPayload("refs/heads/feature/x"),
+			want:     false,
+		},
+		{
+			name:     "tag-ignores-patterns",
+			patterns: []string{"main"},
+			body:     branchPayload("refs/tags/v1.0"),
+			want:     true,
+		},
+		{
+			name:     "pullreq-uses-target-branch",
+			patterns: []string{"main"},
+			body:     pullReqPayload("feature/x", "main"),
+			want:     true,
+		},
+		{
+			name:     "pullreq-ignores-source-branch",
+			patterns: []string{"feature/*"},
+			body:     pullReqPayload("feature/x", "main"),
+			want:     false,
+		},
+		{
+			name:     "pullreq-reopened-uses-target-branch",
+			patterns: []string{"main"},
+			body:     (*PullReqReopenedPayload)(pullReqPayload("feature/x", "main").(*PullReqCreatedPayload)),
+			want:     true,
+		},
+		{
+			name:     "violation-matches-any-branch",
+			patterns: []string{"main"},
+			body:     &BranchProtectionViolatedPayload{Branches: []string{"dev", "main"}},
+			want:     true,
+		},
+		{
+			name:     "violation-mismatches",
+			patterns: []string{"main"},
+			body:     &BranchProtectionViolatedPayload{Branches: []string{"dev"}},
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := matchesBranchPatterns(test.patterns, test.body); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/url"
@@ -46,6 +47,14 @@ type ReferenceSegment struct {
 	Ref ReferenceInfo `json:"ref"`
 }
 
+// branchNames returns the branch the reference refers to (empty in case it's not a branch).
+func (s ReferenceSegment) branchNames() []string {
+	if !strings.HasPrefix(s.Ref.Name, gitReferenceNamePrefixBranch) {
+		return nil
+	}
+	return []string{strings.TrimPrefix(s.Ref.Name, gitReferenceNamePrefixBranch)}
+}
+
 // ReferenceDetailsSegment contains extra details for reference related payloads for webhooks.
 type ReferenceDetailsSegment struct {
 	SHA string `json:"sha"`
@@ -70,11 +79,26 @@ type PullReqTargetReferenceSegment struct {
 	TargetRef ReferenceInfo `json:"target_ref"`
 }
 
+// targetBranchNames returns the target branch of the pull request.
+func (s PullReqTargetReferenceSegment) targetBranchNames() []string {
+	return []string{strings.TrimPrefix(s.TargetRef.Name, gitReferenceNamePrefixBranch)}
+}
+
 // PullReqSegment contains details for all pull req related payloads for webhooks.
 type PullReqSegment struct {
 	PullReq PullReqInfo `json:"pull_req"`
 }
 
+// IssueSegment contains details for all issue related payloads for webhooks.
+type IssueSegment struct {
+	Issue IssueInfo `json:"issue"`
+}
+
+// IssueCommentSegment contains details for all issue comment related payloads for webhooks.
+type IssueCommentSegment struct {
+	CommentInfo CommentInfo `json:"comment"`
+}
+
 // PullReqCommentSegment contains details for all pull req comment related payloads for webhooks.
 type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
@@ -170,6 +194,36 @@ func pullReqInfoFrom(
 	}
 }
 
+// IssueInfo describes the issue related info for a webhook payload.
+// NOTE: don't use types package as we want issue payload to be independent from API calls.
+type IssueInfo struct {
+	Number          int64           `json:"number"`
+	State           enum.IssueState `json:"state"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	ClosedByPullReq *int64          `json:"closed_by_pullreq,omitempty"`
+	Author          PrincipalInfo   `json:"author"`
+	IssueURL        string          `json:"issue_url"`
+}
+
+// issueInfoFrom gets the IssueInfo from a types.Issue.
+func issueInfoFrom(
+	ctx context.Context,
+	issue *types.Issue,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) IssueInfo {
+	return IssueInfo{
+		Number:          issue.Number,
+		State:           issue.State,
+		Title:           issue.Title,
+		Description:     issue.Description,
+		ClosedByPullReq: issue.ClosedByPullReq,
+		Author:          principalInfoFrom(&issue.Author),
+		IssueURL:        urlProvider.GenerateUIIssueURL(ctx, repo.Path, issue.Number),
+	}
+}
+
 // PrincipalInfo describes the principal related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type PrincipalInfo struct {
@@ -293,3 +347,38 @@ type CommentInfo struct {
 	ParentID *int64 `json:"parent_id,omitempty"`
 	Text     string `json:"text"`
 }
+
+// RuleViolationInfo describes the violations of a single protection rule for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type RuleViolationInfo struct {
+	Rule       string          `json:"rule"`
+	Bypassed   bool            `json:"bypassed"`
+	Violations []ViolationInfo `json:"violations"`
+}
+
+// ViolationInfo describes a single violation of a protection rule for a webhook payload.
+type ViolationInfo struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ruleViolationsInfoFrom gets the RuleViolationInfos from a []types.RuleViolations.
+func ruleViolationsInfoFrom(ruleViolations []types.RuleViolations) []RuleViolationInfo {
+	res := make([]RuleViolationInfo, len(ruleViolations))
+	for i, ruleViolation := range ruleViolations {
+		violations := make([]ViolationInfo, len(ruleViolation.Violations))
+		for j, violation := range ruleViolation.Violations {
+			violations[j] = ViolationInfo{
+				Code:    violation.Code,
+				Message: violation.Message,
+			}
+		}
+
+		res[i] = RuleViolationInfo{
+			Rule:       ruleViolation.Rule.Identifier,
+			Bypassed:   ruleViolation.Bypassed,
+			Violations: violations,
+		}
+	}
+	return res
+}
@@ -19,6 +19,7 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -37,17 +38,21 @@ func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	issueStore store.IssueStore,
+	issueCommentStore store.IssueCommentStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
 ) (*Service, error) {
-	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
-		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
-		urlProvider, principalStore, git, encrypter)
+	return NewService(ctx, config, gitReaderFactory, prReaderFactory, repoReaderFactory,
+		webhookStore, webhookExecutionStore, repoStore, spaceStore, pullreqStore, activityStore,
+		issueStore, issueCommentStore, urlProvider, principalStore, git, encrypter)
 }
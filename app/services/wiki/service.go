@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// RepoRefSuffix is appended to the path of a repository to address its wiki over git (e.g. "space/repo.wiki.git").
+	RepoRefSuffix = ".wiki"
+
+	// DefaultBranch is the branch that holds the wiki pages.
+	DefaultBranch = "main"
+)
+
+// Service manages the companion git repositories that hold the wiki pages of repositories.
+// A wiki repository is created lazily, on the first write to it.
+type Service struct {
+	git         git.Interface
+	urlProvider url.Provider
+}
+
+func NewService(git git.Interface, urlProvider url.Provider) *Service {
+	return &Service{
+		git:         git,
+		urlProvider: urlProvider,
+	}
+}
+
+// ReadParams returns the git read params of the wiki of the repository.
+func ReadParams(repo *types.Repository) git.ReadParams {
+	return git.ReadParams{
+		RepoUID: repo.WikiGitUID(),
+	}
+}
+
+// Exists returns whether the wiki git repository of the repository has been created.
+func (s *Service) Exists(ctx context.Context, repo *types.Repository) (bool, error) {
+	exists, err := s.git.RepositoryExists(ctx, ReadParams(repo))
+	if err != nil {
+		return false, fmt.Errorf("failed to check if wiki repository exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+// WriteParams returns the git write params of the wiki of the repository.
+// Githooks are disabled for wikis, as branch rules, events and webhooks apply only to the repository itself.
+func (s *Service) WriteParams(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) (git.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(ctx),
+		repo.ID,
+		principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.WikiGitUID(),
+		EnvVars: envVars,
+	}, nil
+}
+
+// Init creates the wiki git repository of the repository, if it doesn't exist yet.
+func (s *Service) Init(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) error {
+	exists, err := s.Exists(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	writeParams, err := s.WriteParams(ctx, principal, repo)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.git.CreateRepository(ctx, &git.CreateRepositoryParams{
+		RepoUID:       writeParams.RepoUID,
+		Actor:         writeParams.Actor,
+		EnvVars:       writeParams.EnvVars,
+		DefaultBranch: DefaultBranch,
+		StoragePool:   repo.StoragePool,
+		ObjectFormat:  repo.ObjectFormat,
+	})
+	// the wiki could have been created concurrently.
+	if errors.IsConflict(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create wiki repository: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Msg("created wiki repository")
+
+	return nil
+}
+
+// Delete removes the wiki git repository of the repository, it's a no-op if the wiki doesn't exist.
+func (s *Service) Delete(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) error {
+	writeParams, err := s.WriteParams(ctx, principal, repo)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.DeleteRepository(ctx, &git.DeleteRepositoryParams{
+		WriteParams: writeParams,
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete wiki repository: %w", err)
+	}
+
+	return nil
+}
@@ -15,17 +15,30 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/auditlog"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/cron"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceinfraevent"
 	"github.com/harness/gitness/app/services/infraprovider"
+	"github.com/harness/gitness/app/services/insights"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/ldap"
+	"github.com/harness/gitness/app/services/logarchive"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/replication"
 	"github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/reviewsla"
+	"github.com/harness/gitness/app/services/runner"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
@@ -41,11 +54,25 @@ type Services struct {
 	Webhook               *webhook.Service
 	PullReq               *pullreq.Service
 	Trigger               *trigger.Service
+	Cron                  *cron.Service
+	PipelineCache         *pipelinecache.Service
+	PipelineArtifact      *pipelineartifact.Service
+	LogArchive            *logarchive.Service
 	JobScheduler          *job.Scheduler
 	MetricCollector       *metric.Collector
 	RepoSizeCalculator    *repo.SizeCalculator
+	RepoForkDeduplicator  *repo.ForkDeduplicator
+	Compliance            *compliance.Service
+	LDAP                  *ldap.Service
+	TokenPolicy           *tokenpolicy.Service
+	ReviewSLA             *reviewsla.Service
+	AutoMerge             *automerge.Service
+	RepoInsights          *insights.Service
+	Replication           *replication.Service
+	Runner                *runner.Service
 	Repo                  *repo.Service
 	Cleanup               *cleanup.Service
+	AuditLog              *auditlog.Service
 	Notification          *notification.Service
 	Keywordsearch         *keywordsearch.Service
 	GitspaceService       *GitspaceServices
@@ -79,11 +106,25 @@ func ProvideServices(
 	webhooksSvc *webhook.Service,
 	pullReqSvc *pullreq.Service,
 	triggerSvc *trigger.Service,
+	cronSvc *cron.Service,
+	pipelineCacheSvc *pipelinecache.Service,
+	pipelineArtifactSvc *pipelineartifact.Service,
+	logArchiveSvc *logarchive.Service,
 	jobScheduler *job.Scheduler,
 	metricCollector *metric.Collector,
 	repoSizeCalculator *repo.SizeCalculator,
+	repoForkDeduplicator *repo.ForkDeduplicator,
+	complianceSvc *compliance.Service,
+	ldapSvc *ldap.Service,
+	tokenPolicySvc *tokenpolicy.Service,
+	reviewSLASvc *reviewsla.Service,
+	autoMergeSvc *automerge.Service,
+	repoInsightsSvc *insights.Service,
+	replicationSvc *replication.Service,
+	runnerSvc *runner.Service,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
+	auditLogSvc *auditlog.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
 	gitspaceSvc *GitspaceServices,
@@ -95,11 +136,25 @@ func ProvideServices(
 		Webhook:               webhooksSvc,
 		PullReq:               pullReqSvc,
 		Trigger:               triggerSvc,
+		Cron:                  cronSvc,
+		PipelineCache:         pipelineCacheSvc,
+		PipelineArtifact:      pipelineArtifactSvc,
+		LogArchive:            logArchiveSvc,
 		JobScheduler:          jobScheduler,
 		MetricCollector:       metricCollector,
 		RepoSizeCalculator:    repoSizeCalculator,
+		RepoForkDeduplicator:  repoForkDeduplicator,
+		Compliance:            complianceSvc,
+		LDAP:                  ldapSvc,
+		TokenPolicy:           tokenPolicySvc,
+		ReviewSLA:             reviewSLASvc,
+		AutoMerge:             autoMergeSvc,
+		RepoInsights:          repoInsightsSvc,
+		Replication:           replicationSvc,
+		Runner:                runnerSvc,
 		Repo:                  repo,
 		Cleanup:               cleanupSvc,
+		AuditLog:              auditLogSvc,
 		Notification:          notificationSvc,
 		Keywordsearch:         keywordsearchSvc,
 		GitspaceService:       gitspaceSvc,
@@ -0,0 +1,353 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderShadow is set on all mirrored requests to allow the shadow backend to identify them.
+const HeaderShadow = "X-Gitness-Shadow"
+
+// hopHeaders are headers that are only valid for a single connection and aren't forwarded.
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// credentialHeaders carry the credentials of the caller and are never sent to the shadow backend.
+var credentialHeaders = []string{
+	"Authorization",
+	"Cookie",
+}
+
+// Result contains the outcome of a single mirrored request.
+type Result struct {
+	Method string
+	URL    string
+
+	PrimaryStatus  int
+	PrimarySize    int64
+	PrimaryLatency time.Duration
+
+	ShadowStatus  int
+	ShadowSize    int64
+	ShadowLatency time.Duration
+	ShadowErr     error
+}
+
+// Match returns true if the shadow backend returned the same status code as the primary backend.
+// Bodies aren't compared, as they contain request specific data (e.g. timestamps or generated IDs).
+func (r Result) Match() bool {
+	return r.ShadowErr == nil && r.PrimaryStatus == r.ShadowStatus
+}
+
+// Mirror mirrors a sample of the read-only traffic to a shadow backend and compares the responses.
+// The responses of the shadow backend are discarded - only the status code, the body size
+// and the latency are compared and reported.
+// The credentials of the caller aren't forwarded, so the shadow backend only serves anonymous requests.
+type Mirror struct {
+	target             *url.URL
+	client             *http.Client
+	timeout            time.Duration
+	sampleRate         float64
+	maxRequestBodySize int64
+	sem                chan struct{}
+
+	// report is called with the result of every mirrored request.
+	report func(Result)
+}
+
+func NewMirror(
+	targetURL string,
+	sampleRate float64,
+	timeout time.Duration,
+	maxRequestBodySize int64,
+	maxConcurrency int,
+) (*Mirror, error) {
+	target, err := url.Parse(strings.TrimRight(targetURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shadow target url '%s': %w", targetURL, err)
+	}
+	if target.Scheme != "http" && target.Scheme != "https" || target.Host == "" {
+		return nil, fmt.Errorf("shadow target url '%s' has to be an absolute http(s) url", targetURL)
+	}
+
+	if sampleRate < 0 || sampleRate > 1 {
+		return nil, fmt.Errorf("shadow sample rate %f has to be between 0 and 1", sampleRate)
+	}
+
+	if maxConcurrency <= 0 {
+		return nil, fmt.Errorf("shadow max concurrency has to be a positive integer")
+	}
+
+	return &Mirror{
+		target: target,
+		client: &http.Client{
+			// never follow redirects - the redirect response itself is what's compared.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		timeout:            timeout,
+		sampleRate:         sampleRate,
+		maxRequestBodySize: maxRequestBodySize,
+		sem:                make(chan struct{}, maxConcurrency),
+		report:             logResult,
+	}, nil
+}
+
+// Wrap returns a handler that serves all requests via next and mirrors a sample of the eligible ones.
+// If the mirror is nil, next is returned as is.
+func (m *Mirror) Wrap(next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !isEligible(req) || !m.sample() {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		// the shadow request has to be created before serving, as routers modify the original request.
+		shadowReq, ok := m.newShadowRequest(req)
+		if !ok {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		select {
+		case m.sem <- struct{}{}:
+		default:
+			// too many mirrored requests in flight - skip this one to protect the primary.
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		chPrimary := make(chan Result, 1)
+		go func() {
+			defer func() { <-m.sem }()
+			m.mirror(shadowReq, chPrimary)
+		}()
+
+		rec := &recorder{ResponseWriter: w}
+		start := time.Now()
+
+		// the result is sent in a defer, so the mirror goroutine isn't blocked forever if the handler panics.
+		defer func() {
+			chPrimary <- Result{
+				Method:         req.Method,
+				URL:            shadowReq.URL.RequestURI(),
+				PrimaryStatus:  rec.statusCode(),
+				PrimarySize:    rec.size,
+				PrimaryLatency: time.Since(start),
+			}
+		}()
+
+		next.ServeHTTP(rec, req)
+	})
+}
+
+// mirror executes the shadow request and reports the result once the primary request is completed.
+func (m *Mirror) mirror(shadowReq *http.Request, chPrimary <-chan Result) {
+	ctx, cancel := context.WithTimeout(shadowReq.Context(), m.timeout)
+	defer cancel()
+
+	var (
+		status int
+		size   int64
+		err    error
+	)
+
+	start := time.Now()
+
+	resp, err := m.client.Do(shadowReq.WithContext(ctx))
+	if err == nil {
+		size, err = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		status = resp.StatusCode
+	}
+
+	latency := time.Since(start)
+
+	result := <-chPrimary
+	result.ShadowStatus = status
+	result.ShadowSize = size
+	result.ShadowLatency = latency
+	result.ShadowErr = err
+
+	m.report(result)
+}
+
+func (m *Mirror) sample() bool {
+	return m.sampleRate > 0 && rand.Float64() < m.sampleRate //nolint:gosec // no need for crypto rand
+}
+
+// newShadowRequest creates a copy of the request targeting the shadow backend.
+// The request body is buffered and restored for the primary request.
+// Returns false if the request can't be mirrored (e.g. body exceeds the size limit).
+func (m *Mirror) newShadowRequest(req *http.Request) (*http.Request, bool) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		buf, err := io.ReadAll(io.LimitReader(req.Body, m.maxRequestBodySize+1))
+		if err != nil {
+			// restore whatever was read - the primary request will fail the same way.
+			req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(buf), req.Body), Closer: req.Body}
+			return nil, false
+		}
+
+		req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(buf), req.Body), Closer: req.Body}
+		if int64(len(buf)) > m.maxRequestBodySize {
+			return nil, false
+		}
+
+		body = buf
+	}
+
+	targetURL, err := url.Parse(m.target.String() + req.URL.RequestURI())
+	if err != nil {
+		return nil, false
+	}
+
+	// the shadow request must not be canceled with the primary request.
+	ctx := context.WithoutCancel(req.Context())
+
+	shadowReq, err := http.NewRequestWithContext(ctx, req.Method, targetURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, false
+	}
+
+	shadowReq.Header = req.Header.Clone()
+	for _, h := range hopHeaders {
+		shadowReq.Header.Del(h)
+	}
+	for _, h := range credentialHeaders {
+		shadowReq.Header.Del(h)
+	}
+	shadowReq.Header.Set(HeaderShadow, "true")
+	shadowReq.Host = req.Host
+	shadowReq.ContentLength = int64(len(body))
+
+	return shadowReq, true
+}
+
+// isEligible returns true for read-only requests that can be mirrored.
+func isEligible(req *http.Request) bool {
+	// never mirror long-lived streams or connection upgrades.
+	if req.Header.Get("Upgrade") != "" ||
+		strings.Contains(req.Header.Get("Accept"), "text/event-stream") ||
+		strings.HasSuffix(req.URL.Path, "/stream") {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		// git fetch/clone negotiation is read-only.
+		return strings.HasSuffix(req.URL.Path, "/git-upload-pack")
+	default:
+		return false
+	}
+}
+
+func logResult(result Result) {
+	event := log.Debug()
+	msg := "shadow response matches"
+
+	switch {
+	case result.ShadowErr != nil:
+		event = log.Warn().Err(result.ShadowErr)
+		msg = "shadow request failed"
+	case !result.Match():
+		event = log.Warn()
+		msg = "shadow response mismatch"
+	}
+
+	event.
+		Str("shadow.method", result.Method).
+		Str("shadow.url", result.URL).
+		Int("shadow.primary_status", result.PrimaryStatus).
+		Int("shadow.shadow_status", result.ShadowStatus).
+		Int64("shadow.primary_size", result.PrimarySize).
+		Int64("shadow.shadow_size", result.ShadowSize).
+		Dur("shadow.primary_latency", result.PrimaryLatency).
+		Dur("shadow.shadow_latency", result.ShadowLatency).
+		Msg(msg)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recorder passes the response through to the client while recording the status code and body size.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (r *recorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap allows http.ResponseController to access the original response writer.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *recorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
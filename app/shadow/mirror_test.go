@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMirror_Wrap(t *testing.T) {
+	shadowBodies := make(chan string, 1)
+	shadowSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderShadow) != "true" {
+			t.Errorf("missing shadow header")
+		}
+		if r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != "" {
+			t.Errorf("credentials must not be sent to the shadow backend")
+		}
+		b, _ := io.ReadAll(r.Body)
+		shadowBodies <- r.Method + " " + r.URL.RequestURI() + " " + string(b)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("different"))
+	}))
+	defer shadowSrv.Close()
+
+	m, err := NewMirror(shadowSrv.URL, 1, time.Minute, 16, 1)
+	if err != nil {
+		t.Fatalf("failed to create mirror: %s", err)
+	}
+
+	results := make(chan Result, 1)
+	m.report = func(r Result) { results <- r }
+
+	primary := m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("primary:" + string(b)))
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/git/space/repo.git/git-upload-pack?x=1", strings.NewReader("want"))
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Cookie", "token=secret")
+	primary.ServeHTTP(rec, req)
+
+	if got := rec.Body.String(); got != "primary:want" {
+		t.Errorf("primary got body %q", got)
+	}
+
+	if got := <-shadowBodies; got != "POST /git/space/repo.git/git-upload-pack?x=1 want" {
+		t.Errorf("shadow got request %q", got)
+	}
+
+	result := <-results
+	if result.ShadowErr != nil {
+		t.Fatalf("unexpected shadow error: %s", result.ShadowErr)
+	}
+	if result.PrimaryStatus != http.StatusOK || result.ShadowStatus != http.StatusNotFound {
+		t.Errorf("unexpected status codes: primary=%d shadow=%d", result.PrimaryStatus, result.ShadowStatus)
+	}
+	if result.PrimarySize != int64(len("primary:want")) || result.ShadowSize != int64(len("different")) {
+		t.Errorf("unexpected sizes: primary=%d shadow=%d", result.PrimarySize, result.ShadowSize)
+	}
+	if result.Match() {
+		t.Errorf("expected status mismatch")
+	}
+}
+
+func TestMirror_Wrap_Match(t *testing.T) {
+	shadowSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// the bodies differ, but only the status code is compared.
+		_, _ = w.Write([]byte("shadow"))
+	}))
+	defer shadowSrv.Close()
+
+	m, err := NewMirror(shadowSrv.URL, 1, time.Minute, 16, 1)
+	if err != nil {
+		t.Fatalf("failed to create mirror: %s", err)
+	}
+
+	results := make(chan Result, 1)
+	m.report = func(r Result) { results <- r }
+
+	primary := m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("primary"))
+	}))
+
+	primary.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/repos/space/repo", nil))
+
+	if result := <-results; !result.Match() {
+		t.Errorf("expected match, got %+v", result)
+	}
+}
+
+func TestMirror_Wrap_PrimaryPanic(t *testing.T) {
+	shadowSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer shadowSrv.Close()
+
+	m, err := NewMirror(shadowSrv.URL, 1, time.Minute, 16, 1)
+	if err != nil {
+		t.Fatalf("failed to create mirror: %s", err)
+	}
+
+	results := make(chan Result, 1)
+	m.report = func(r Result) { results <- r }
+
+	primary := m.Wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("primary failed")
+	}))
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected the panic of the primary handler to be propagated")
+			}
+		}()
+		primary.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/repos/space/repo", nil))
+	}()
+
+	select {
+	case <-results:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("mirror goroutine is blocked after the primary handler panicked")
+	}
+
+	// the concurrency slot must be released for the next request.
+	select {
+	case m.sem <- struct{}{}:
+	case <-time.After(10 * time.Second):
+		t.Errorf("concurrency slot wasn't released")
+	}
+}
+
+func TestMirror_Wrap_NotMirrored(t *testing.T) {
+	var called bool
+	shadowSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+	defer shadowSrv.Close()
+
+	m, err := NewMirror(shadowSrv.URL, 1, time.Minute, 4, 1)
+	if err != nil {
+		t.Fatalf("failed to create mirror: %s", err)
+	}
+	m.report = func(Result) { t.Errorf("no request should be mirrored") }
+
+	primary := m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}))
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		body string
+	}{
+		{
+			name: "write request",
+			req:  httptest.NewRequest(http.MethodPost, "/git/space/repo.git/git-receive-pack", strings.NewReader("push")),
+			body: "push",
+		},
+		{
+			name: "body too large",
+			req:  httptest.NewRequest(http.MethodPost, "/git/space/repo.git/git-upload-pack", strings.NewReader("too large")),
+			body: "too large",
+		},
+		{
+			name: "event stream",
+			req:  httptest.NewRequest(http.MethodGet, "/api/v1/spaces/space/+/stream", nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			primary.ServeHTTP(rec, test.req)
+			if got := rec.Body.String(); got != test.body {
+				t.Errorf("primary got body %q, want %q", got, test.body)
+			}
+		})
+	}
+
+	if called {
+		t.Errorf("shadow backend shouldn't be called")
+	}
+}
+
+func TestMirror_Nil(t *testing.T) {
+	var m *Mirror
+	h := http.NotFoundHandler()
+	if got := m.Wrap(h); got == nil {
+		t.Errorf("nil mirror should return the handler")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideMirror,
+)
+
+// ProvideMirror provides the traffic mirror. Returns nil if traffic mirroring is disabled.
+func ProvideMirror(config *types.Config) (*Mirror, error) {
+	if !config.Shadow.Enabled {
+		return nil, nil //nolint:nilnil // a nil mirror disables traffic mirroring
+	}
+
+	return NewMirror(
+		config.Shadow.TargetURL,
+		config.Shadow.SampleRate,
+		config.Shadow.Timeout,
+		config.Shadow.MaxRequestBodySize,
+		config.Shadow.MaxConcurrency,
+	)
+}
@@ -40,7 +40,7 @@ type (
 		// If a UID isn't found, it's not returned in the list.
 		FindManyByUID(ctx context.Context, uids []string) ([]*types.Principal, error)
 
-		// FindByEmail finds the principal by email.
+		// FindByEmail finds the principal by its primary email or by a verified additional email of a user.
 		FindByEmail(ctx context.Context, email string) (*types.Principal, error)
 
 		/*
@@ -224,6 +224,9 @@ type (
 
 		// List returns a list of child spaces in a space.
 		List(ctx context.Context, id int64, opts *types.SpaceFilter) ([]*types.Space, error)
+
+		// Search returns a list of spaces matching the filter regardless of their parent space.
+		Search(ctx context.Context, opts *types.SpaceFilter) ([]*types.Space, error)
 	}
 
 	// RepoStore defines the repository data storage.
@@ -249,6 +252,9 @@ type (
 		// Get the repo size.
 		GetSize(ctx context.Context, id int64) (int64, error)
 
+		// SumSize returns the total size (in KiB) of the active repos of the space and all its sub-spaces.
+		SumSize(ctx context.Context, spaceID int64) (int64, error)
+
 		// UpdateOptLock the repo details using the optimistic locking mechanism.
 		UpdateOptLock(
 			ctx context.Context, repo *types.Repository,
@@ -275,8 +281,18 @@ type (
 		// List returns a list of repos in a space. With "DeletedBeforeOrAt" filter, lists deleted repos.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
 
+		// Search returns a list of repos matching the filter regardless of their parent space.
+		Search(ctx context.Context, opts *types.RepoFilter) ([]*types.Repository, error)
+
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// ListTopics returns the topics used by the repositories of the space, the most used topics first.
+		ListTopics(ctx context.Context, parentID int64, filter *types.RepoTopicFilter) ([]types.RepoTopic, error)
+
+		// ListForkGitInfos returns the git infos of all forks (including deleted ones) of the repo.
+		// If no repo ID is provided, the git infos of all forks are returned.
+		ListForkGitInfos(ctx context.Context, repoID *int64) ([]*types.RepositoryGitInfo, error)
 	}
 
 	// SettingsStore defines the settings storage.
@@ -313,6 +329,64 @@ type (
 		Find(ctx context.Context, id int64) (*types.RepositoryGitInfo, error)
 	}
 
+	// RepoRedirectStore defines the storage of the former paths of moved repositories.
+	RepoRedirectStore interface {
+		// Upsert creates the redirect or points an existing redirect of the same path to another repository.
+		Upsert(ctx context.Context, redirect *types.RepositoryRedirect) error
+
+		// FindRepoID returns the ID of the repository the path consisting of the space and identifier redirects to.
+		FindRepoID(ctx context.Context, spaceID int64, identifier string) (int64, error)
+
+		// Delete removes the redirect of the path consisting of the space and identifier, if it exists.
+		Delete(ctx context.Context, spaceID int64, identifier string) error
+	}
+
+	// RepoStarStore defines the storage of the repositories starred by principals.
+	RepoStarStore interface {
+		// Create stars the repository for the principal. It returns false if the repository was already starred.
+		Create(ctx context.Context, principalID, repoID, created int64) (bool, error)
+
+		// Delete unstars the repository for the principal. It returns false if the repository wasn't starred.
+		Delete(ctx context.Context, principalID, repoID int64) (bool, error)
+
+		// ListRepoIDs returns the IDs of the repositories starred by the principal, the most recently starred first.
+		ListRepoIDs(ctx context.Context, principalID int64, pagination types.Pagination) ([]int64, error)
+
+		// Count returns the number of repositories starred by the principal.
+		Count(ctx context.Context, principalID int64) (int64, error)
+	}
+
+	// RepoTrafficStore defines the repository clone and fetch traffic storage.
+	RepoTrafficStore interface {
+		// Record increments the traffic counter matching the provided event.
+		Record(ctx context.Context, event *types.RepoTrafficEvent) error
+
+		// ListDays returns the daily traffic statistics of a repository.
+		ListDays(ctx context.Context, repoID int64, filter types.RepoTrafficFilter) ([]types.RepoTrafficDay, error)
+
+		// CountUniqueCallers returns the number of distinct principals that cloned or fetched the repository.
+		CountUniqueCallers(ctx context.Context, repoID int64, filter types.RepoTrafficFilter) (int64, error)
+	}
+
+	// AttachmentStore defines the storage of the details of files uploaded to repositories.
+	AttachmentStore interface {
+		// Create saves the attachment details.
+		Create(ctx context.Context, attachment *types.Attachment) error
+
+		// MarkReferenced marks the attachments of a repository with the provided file names as referenced.
+		MarkReferenced(ctx context.Context, repoID int64, fileNames []string) error
+
+		// ListOrphaned returns attachments that were created before the provided time and were never referenced,
+		// and attachments of repositories that don't exist anymore.
+		ListOrphaned(ctx context.Context, createdBefore int64, limit int) ([]*types.Attachment, error)
+
+		// ListByRepo returns all attachments of a repository.
+		ListByRepo(ctx context.Context, repoID int64) ([]*types.Attachment, error)
+
+		// Delete deletes the attachment details.
+		Delete(ctx context.Context, id int64) error
+	}
+
 	// MembershipStore defines the membership data storage.
 	MembershipStore interface {
 		Find(ctx context.Context, key types.MembershipKey) (*types.Membership, error)
@@ -320,6 +394,8 @@ type (
 		Create(ctx context.Context, membership *types.Membership) error
 		Update(ctx context.Context, membership *types.Membership) error
 		Delete(ctx context.Context, key types.MembershipKey) error
+		// CountByRole returns the number of space memberships with the role.
+		CountByRole(ctx context.Context, role enum.MembershipRole) (int64, error)
 		CountUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) (int64, error)
 		ListUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) ([]types.MembershipUser, error)
 		CountSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) (int64, error)
@@ -330,6 +406,63 @@ type (
 		) ([]types.MembershipSpace, error)
 	}
 
+	// RepoMembershipStore defines the repository membership data storage.
+	RepoMembershipStore interface {
+		Find(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembership, error)
+		FindUser(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembershipUser, error)
+		Create(ctx context.Context, membership *types.RepoMembership) error
+		Update(ctx context.Context, membership *types.RepoMembership) error
+		Delete(ctx context.Context, key types.RepoMembershipKey) error
+
+		// ListUsers returns all memberships of a repository.
+		ListUsers(ctx context.Context, repoID int64) ([]types.RepoMembershipUser, error)
+
+		// CountByRole returns the number of repository memberships with the role.
+		CountByRole(ctx context.Context, role enum.MembershipRole) (int64, error)
+	}
+
+	// RoleStore defines the custom role data storage.
+	RoleStore interface {
+		// Find finds the role by id.
+		Find(ctx context.Context, id int64) (*types.Role, error)
+
+		// FindByIdentifier finds the role by its identifier (case insensitive).
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Role, error)
+
+		// List returns all custom roles.
+		List(ctx context.Context) ([]*types.Role, error)
+
+		// Create stores a new custom role.
+		Create(ctx context.Context, role *types.Role) error
+
+		// Update updates the display name, description and permissions of a custom role.
+		Update(ctx context.Context, role *types.Role) error
+
+		// Delete deletes a custom role.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// BannerStore stores the instance wide announcement banners.
+	BannerStore interface {
+		// FindByIdentifier finds the banner by its identifier (case insensitive).
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Banner, error)
+
+		// List returns all banners, the most recently starting first.
+		List(ctx context.Context) ([]*types.Banner, error)
+
+		// ListNotEnded returns the banners without an end or with an end after the provided time (unix millis).
+		ListNotEnded(ctx context.Context, now int64) ([]*types.Banner, error)
+
+		// Create stores a new banner.
+		Create(ctx context.Context, banner *types.Banner) error
+
+		// Update updates the message, severity, schedule and dismissibility of a banner.
+		Update(ctx context.Context, banner *types.Banner) error
+
+		// Delete deletes a banner.
+		Delete(ctx context.Context, id int64) error
+	}
+
 	// PublicAccessStore defines the publicly accessible resources data storage.
 	PublicAccessStore interface {
 		Find(ctx context.Context, typ enum.PublicResourceType, id int64) (bool, error)
@@ -348,9 +481,20 @@ type (
 		// Create saves the token details.
 		Create(ctx context.Context, token *types.Token) error
 
+		// Update updates the issue and expiry times of the token, which change when the token is rotated,
+		// and the time its owner was warned about the expiry.
+		Update(ctx context.Context, token *types.Token) error
+
+		// UpdateLastUsed updates the time at which the token was last used and the IP address it was used from.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsedAt int64, lastUsedIP string) error
+
 		// Delete deletes the token with the given id.
 		Delete(ctx context.Context, id int64) error
 
+		// DeleteForPrincipal deletes all tokens of a specific type for a specific principal,
+		// except the token with the provided id (if it isn't zero).
+		DeleteForPrincipal(ctx context.Context, principalID int64, tokenType enum.TokenType, exceptID int64) (int64, error)
+
 		// DeleteExpiredBefore deletes all tokens that expired before the provided time.
 		// If tokenTypes are provided, then only tokens of that type are deleted.
 		DeleteExpiredBefore(ctx context.Context, before time.Time, tknTypes []enum.TokenType) (int64, error)
@@ -358,6 +502,15 @@ type (
 		// List returns a list of tokens of a specific type for a specific principal.
 		List(ctx context.Context, principalID int64, tokenType enum.TokenType) ([]*types.Token, error)
 
+		// ListExpiringBefore returns the tokens of the provided types that expire between now and the provided time
+		// and whose owners weren't warned about the expiry yet.
+		ListExpiringBefore(
+			ctx context.Context,
+			before time.Time,
+			tknTypes []enum.TokenType,
+			limit int,
+		) ([]*types.Token, error)
+
 		// Count returns a count of tokens of a specifc type for a specific principal.
 		Count(ctx context.Context, principalID int64, tokenType enum.TokenType) (int64, error)
 	}
@@ -501,6 +654,560 @@ type (
 		) (*types.UserGroupReviewer, error)
 	}
 
+	// MilestoneStore stores the milestones of the repositories.
+	MilestoneStore interface {
+		// Find the milestone by id.
+		Find(ctx context.Context, id int64) (*types.Milestone, error)
+
+		// FindInRepo finds the milestone by id and verifies that it belongs to the repository.
+		FindInRepo(ctx context.Context, repoID, id int64) (*types.Milestone, error)
+
+		// Create a new milestone.
+		Create(ctx context.Context, milestone *types.Milestone) error
+
+		// Update the milestone. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, milestone *types.Milestone) error
+
+		// UpdateOptLock the milestone details using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, milestone *types.Milestone,
+			mutateFn func(milestone *types.Milestone) error) (*types.Milestone, error)
+
+		// Delete the milestone. Pull requests and issues assigned to it are left without a milestone.
+		Delete(ctx context.Context, id int64) error
+
+		// Count of milestones in a repository.
+		Count(ctx context.Context, repoID int64, opts *types.MilestoneFilter) (int64, error)
+
+		// List returns a list of milestones in a repository.
+		List(ctx context.Context, repoID int64, opts *types.MilestoneFilter) ([]*types.Milestone, error)
+	}
+
+	// ReleaseStore stores the releases of the repositories.
+	ReleaseStore interface {
+		// Find the release by id.
+		Find(ctx context.Context, id int64) (*types.Release, error)
+
+		// FindInRepo finds the release by id and verifies that it belongs to the repository.
+		FindInRepo(ctx context.Context, repoID, id int64) (*types.Release, error)
+
+		// FindByTag finds the release of the tag.
+		FindByTag(ctx context.Context, repoID int64, tagName string) (*types.Release, error)
+
+		// Create a new release.
+		Create(ctx context.Context, release *types.Release) error
+
+		// Update the release. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, release *types.Release) error
+
+		// UpdateOptLock the release details using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, release *types.Release,
+			mutateFn func(release *types.Release) error) (*types.Release, error)
+
+		// Delete the release together with its assets.
+		Delete(ctx context.Context, id int64) error
+
+		// Count of releases in a repository.
+		Count(ctx context.Context, repoID int64, opts *types.ReleaseFilter) (int64, error)
+
+		// List returns a list of releases in a repository, the most recent first.
+		List(ctx context.Context, repoID int64, opts *types.ReleaseFilter) ([]*types.Release, error)
+	}
+
+	// ReleaseAssetStore stores the details of the files attached to releases.
+	// The content of the files is kept in the blob store.
+	ReleaseAssetStore interface {
+		// FindInRelease finds the asset by id and verifies that it belongs to the release.
+		FindInRelease(ctx context.Context, releaseID, id int64) (*types.ReleaseAsset, error)
+
+		// Create a new release asset.
+		Create(ctx context.Context, asset *types.ReleaseAsset) error
+
+		// Delete the release asset.
+		Delete(ctx context.Context, id int64) error
+
+		// ListByReleases returns the assets of the releases.
+		ListByReleases(ctx context.Context, releaseIDs []int64) ([]*types.ReleaseAsset, error)
+
+		// ListByRepo returns the assets of all releases of the repository.
+		ListByRepo(ctx context.Context, repoID int64) ([]*types.ReleaseAsset, error)
+	}
+
+	// AuditEventStore stores the audit log of the spaces.
+	AuditEventStore interface {
+		// Create stores a new audit event.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// Count returns the number of audit events of a space.
+		Count(ctx context.Context, spaceID int64, opts *types.AuditEventFilter) (int64, error)
+
+		// List returns a list of audit events of a space, the most recent first.
+		List(ctx context.Context, spaceID int64, opts *types.AuditEventFilter) ([]*types.AuditEvent, error)
+
+		// ListByIDs returns the audit events with the provided IDs, ordered by ID.
+		ListByIDs(ctx context.Context, ids []int64) ([]*types.AuditEvent, error)
+	}
+
+	// AuditEventOutboxStore stores the audit events waiting to be delivered to the external audit log sink.
+	AuditEventOutboxStore interface {
+		// Create adds an audit event to the outbox.
+		Create(ctx context.Context, entry *types.AuditEventOutbox) error
+
+		// List returns the oldest entries of the outbox.
+		List(ctx context.Context, limit int) ([]*types.AuditEventOutbox, error)
+
+		// Delete removes the delivered entries from the outbox.
+		Delete(ctx context.Context, ids []int64) error
+
+		// MarkFailed increments the number of delivery attempts of the entries and records the error.
+		MarkFailed(ctx context.Context, ids []int64, lastError string) error
+	}
+
+	// FileTemplateStore stores the custom gitignore and license templates.
+	FileTemplateStore interface {
+		// Find finds the template of a space by its type and identifier. Space ID 0 finds a system template.
+		Find(
+			ctx context.Context,
+			spaceID int64,
+			templateType enum.FileTemplateType,
+			identifier string,
+		) (*types.FileTemplate, error)
+
+		// Create stores a new template.
+		Create(ctx context.Context, template *types.FileTemplate) error
+
+		// Delete deletes a template.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the templates of the type defined in any of the spaces, without their content.
+		// Space ID 0 stands for the system templates.
+		List(ctx context.Context, templateType enum.FileTemplateType, spaceIDs []int64) ([]*types.FileTemplate, error)
+	}
+
+	// UserEmailStore stores the additional email addresses of users.
+	UserEmailStore interface {
+		// Find finds an email address of a user.
+		Find(ctx context.Context, principalID int64, email string) (*types.UserEmail, error)
+
+		// FindByVerificationToken finds an email address by the hash of its verification token.
+		FindByVerificationToken(ctx context.Context, token string) (*types.UserEmail, error)
+
+		// FindVerified finds the verified email address, regardless of the user it belongs to.
+		FindVerified(ctx context.Context, email string) (*types.UserEmail, error)
+
+		// Create stores a new email address of a user.
+		Create(ctx context.Context, userEmail *types.UserEmail) error
+
+		// Update updates the verification state of the email address.
+		Update(ctx context.Context, userEmail *types.UserEmail) error
+
+		// Delete deletes an email address.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all email addresses of a user.
+		List(ctx context.Context, principalID int64) ([]*types.UserEmail, error)
+	}
+
+	// UserPreferencesStore stores the personal preferences of users.
+	UserPreferencesStore interface {
+		// Find returns the preferences of a user, defaults are returned for users without stored preferences.
+		Find(ctx context.Context, principalID int64) (*types.UserPreferences, error)
+
+		// FindMany returns the preferences of the users, defaults are returned for users without stored preferences.
+		FindMany(ctx context.Context, principalIDs []int64) (map[int64]*types.UserPreferences, error)
+
+		// Upsert stores the preferences of a user.
+		Upsert(ctx context.Context, principalID int64, preferences *types.UserPreferences) error
+	}
+
+	// UserTwoFactorStore stores the two-factor authentication state of users.
+	UserTwoFactorStore interface {
+		// Find returns the two-factor authentication state of a user.
+		Find(ctx context.Context, principalID int64) (*types.UserTwoFactor, error)
+
+		// Upsert stores the two-factor authentication state of a user.
+		Upsert(ctx context.Context, twoFactor *types.UserTwoFactor) error
+
+		// UpdateLastUsedStep stores the time step of an accepted TOTP code.
+		// It fails with ErrVersionConflict if a code of the same or a later step was already accepted.
+		UpdateLastUsedStep(ctx context.Context, principalID int64, step int64) error
+
+		// UpdateRecoveryCodes stores the recovery codes of the provided state and bumps its Updated time.
+		// It fails with ErrVersionConflict if the state was changed since it was read.
+		UpdateRecoveryCodes(ctx context.Context, twoFactor *types.UserTwoFactor) error
+
+		// Delete removes the two-factor authentication state of a user.
+		Delete(ctx context.Context, principalID int64) error
+	}
+
+	// WebAuthnCredentialStore stores the WebAuthn credentials (passkeys and security keys) of users.
+	WebAuthnCredentialStore interface {
+		// Find finds a WebAuthn credential by its ID.
+		Find(ctx context.Context, id int64) (*types.WebAuthnCredential, error)
+
+		// FindByCredentialID finds a WebAuthn credential by the identifier assigned by the authenticator.
+		FindByCredentialID(ctx context.Context, credentialID []byte) (*types.WebAuthnCredential, error)
+
+		// List returns all WebAuthn credentials of a user.
+		List(ctx context.Context, principalID int64) ([]*types.WebAuthnCredential, error)
+
+		// Count returns the number of WebAuthn credentials of a user.
+		Count(ctx context.Context, principalID int64) (int64, error)
+
+		// Create stores a new WebAuthn credential.
+		Create(ctx context.Context, credential *types.WebAuthnCredential) error
+
+		// UpdateName renames a WebAuthn credential.
+		UpdateName(ctx context.Context, credential *types.WebAuthnCredential) error
+
+		// UpdateSignCount stores the signature counter and the last usage time of an authentication.
+		// It fails with ErrVersionConflict if the stored counter isn't the one the authentication was verified with.
+		UpdateSignCount(ctx context.Context, credential *types.WebAuthnCredential, signCount uint32) error
+
+		// Delete deletes a WebAuthn credential.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// WebAuthnSessionStore stores the challenges of started WebAuthn ceremonies.
+	WebAuthnSessionStore interface {
+		// Create stores a new WebAuthn session.
+		Create(ctx context.Context, session *types.WebAuthnSession) error
+
+		// Consume deletes the WebAuthn session and returns it, so the challenge can only be used once.
+		Consume(ctx context.Context, id string) (*types.WebAuthnSession, error)
+
+		// DeleteExpired deletes all WebAuthn sessions that expired before the provided time.
+		DeleteExpired(ctx context.Context, before int64) error
+	}
+
+	// OAuthProviderStore stores the external identity providers users can login with.
+	OAuthProviderStore interface {
+		// Find finds an OAuth provider by its ID.
+		Find(ctx context.Context, id int64) (*types.OAuthProvider, error)
+
+		// FindByIdentifier finds an OAuth provider by its identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.OAuthProvider, error)
+
+		// List returns all OAuth providers, or only the enabled ones.
+		List(ctx context.Context, enabledOnly bool) ([]*types.OAuthProvider, error)
+
+		// Create stores a new OAuth provider.
+		Create(ctx context.Context, provider *types.OAuthProvider) error
+
+		// Update updates an OAuth provider.
+		Update(ctx context.Context, provider *types.OAuthProvider) error
+
+		// Delete deletes an OAuth provider together with the identities linked through it.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// OAuthIdentityStore stores the links between principals and their accounts with OAuth providers.
+	OAuthIdentityStore interface {
+		// FindByExternalID finds the identity of the account with the provider.
+		FindByExternalID(ctx context.Context, providerID int64, externalID string) (*types.OAuthIdentity, error)
+
+		// List returns all identities linked to a principal.
+		List(ctx context.Context, principalID int64) ([]*types.OAuthIdentity, error)
+
+		// Create stores a new identity.
+		Create(ctx context.Context, identity *types.OAuthIdentity) error
+
+		// Update updates the login and the email of an identity.
+		Update(ctx context.Context, identity *types.OAuthIdentity) error
+
+		// Delete deletes an identity.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// SAMLProviderStore stores the SAML identity providers users can login with.
+	SAMLProviderStore interface {
+		// Find finds a SAML provider by its ID.
+		Find(ctx context.Context, id int64) (*types.SAMLProvider, error)
+
+		// FindByIdentifier finds a SAML provider by its identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.SAMLProvider, error)
+
+		// List returns all SAML providers, or only the enabled ones.
+		List(ctx context.Context, enabledOnly bool) ([]*types.SAMLProvider, error)
+
+		// Create stores a new SAML provider.
+		Create(ctx context.Context, provider *types.SAMLProvider) error
+
+		// Update updates a SAML provider.
+		Update(ctx context.Context, provider *types.SAMLProvider) error
+
+		// Delete deletes a SAML provider together with the identities linked through it.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// SAMLIdentityStore stores the links between principals and their identities with SAML providers.
+	SAMLIdentityStore interface {
+		// FindByNameID finds the identity with the name ID asserted by the provider.
+		FindByNameID(ctx context.Context, providerID int64, nameID string) (*types.SAMLIdentity, error)
+
+		// List returns all identities linked to a principal.
+		List(ctx context.Context, principalID int64) ([]*types.SAMLIdentity, error)
+
+		// Create stores a new identity.
+		Create(ctx context.Context, identity *types.SAMLIdentity) error
+
+		// Update updates the email of an identity.
+		Update(ctx context.Context, identity *types.SAMLIdentity) error
+
+		// Delete deletes an identity.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// LDAPIdentityStore defines the data storage of the links of principals to LDAP directory entries.
+	LDAPIdentityStore interface {
+		// FindByPrincipalID finds the identity linked to a principal.
+		FindByPrincipalID(ctx context.Context, principalID int64) (*types.LDAPIdentity, error)
+
+		// FindByLogin finds the identity with the login (case insensitive).
+		FindByLogin(ctx context.Context, login string) (*types.LDAPIdentity, error)
+
+		// List returns all identities.
+		List(ctx context.Context) ([]*types.LDAPIdentity, error)
+
+		// Create stores a new identity.
+		Create(ctx context.Context, identity *types.LDAPIdentity) error
+
+		// Update updates the login, DN and the deactivation flag of an identity.
+		Update(ctx context.Context, identity *types.LDAPIdentity) error
+	}
+
+	// LDAPGroupMappingStore defines the data storage of the mappings of LDAP groups to space memberships.
+	LDAPGroupMappingStore interface {
+		// Find finds the group mapping by id.
+		Find(ctx context.Context, id int64) (*types.LDAPGroupMapping, error)
+
+		// List returns all group mappings.
+		List(ctx context.Context) ([]*types.LDAPGroupMapping, error)
+
+		// Create stores a new group mapping.
+		Create(ctx context.Context, mapping *types.LDAPGroupMapping) error
+
+		// Delete deletes a group mapping.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// RepoInsightsStore stores the incrementally computed statistics of repositories.
+	RepoInsightsStore interface {
+		// Find finds the insights state of a repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoInsightsState, error)
+
+		// MarkStale marks the insights of a repository for recomputation, creating the state if it doesn't exist.
+		MarkStale(ctx context.Context, repoID int64, now int64) error
+
+		// ClearStale removes the stale mark of the insights of a repository.
+		ClearStale(ctx context.Context, repoID int64) error
+
+		// ListStale lists the insights states of repositories marked for recomputation
+		// with repository ID greater than the provided one, ordered by repository ID.
+		ListStale(ctx context.Context, afterRepoID int64, limit int) ([]*types.RepoInsightsState, error)
+
+		// UpdateProcessed stores the branch and the commit up to which the commit statistics are computed.
+		UpdateProcessed(ctx context.Context, repoID int64, branch, commitSHA string, processed int64) error
+
+		// DeleteCommitStats deletes all commit statistics of a repository.
+		DeleteCommitStats(ctx context.Context, repoID int64) error
+
+		// AddCommitStats adds the provided values to the weekly commit statistics of the authors.
+		AddCommitStats(ctx context.Context, repoID int64, stats []*types.RepoInsightsCommitStats) error
+
+		// AddPullReqStats adds the provided values to the weekly pull request statistics.
+		AddPullReqStats(ctx context.Context, repoID int64, stats []types.RepoInsightsPullReqStats) error
+
+		// ListCommitActivity returns the weekly commit activity of a repository, starting with the provided week.
+		ListCommitActivity(ctx context.Context, repoID int64, since int64) ([]types.RepoInsightsWeek, error)
+
+		// ListContributors returns the authors with the most commits, starting with the provided week.
+		ListContributors(ctx context.Context, repoID int64, since int64, limit int) ([]types.RepoContributor, error)
+
+		// ListPullReqStats returns the weekly pull request statistics of a repository,
+		// starting with the provided week.
+		ListPullReqStats(ctx context.Context, repoID int64, since int64) ([]types.RepoInsightsPullReqStats, error)
+	}
+
+	// IssueStore stores the issues of the repositories.
+	IssueStore interface {
+		// Find the issue by id.
+		Find(ctx context.Context, id int64) (*types.Issue, error)
+
+		// FindByNumber finds the issue by repo ID and the issue number.
+		FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error)
+
+		// Create a new issue.
+		Create(ctx context.Context, issue *types.Issue) error
+
+		// Update the issue. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, issue *types.Issue) error
+
+		// UpdateOptLock the issue details using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, issue *types.Issue,
+			mutateFn func(issue *types.Issue) error) (*types.Issue, error)
+
+		// Count of issues in a repository.
+		Count(ctx context.Context, repoID int64, opts *types.IssueFilter) (int64, error)
+
+		// List returns a list of issues in a repository.
+		List(ctx context.Context, repoID int64, opts *types.IssueFilter) ([]*types.Issue, error)
+	}
+
+	// IssueCommentStore stores the comments of the issues.
+	IssueCommentStore interface {
+		// Find the issue comment by id.
+		Find(ctx context.Context, id int64) (*types.IssueComment, error)
+
+		// Create a new issue comment.
+		Create(ctx context.Context, comment *types.IssueComment) error
+
+		// Update the issue comment. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, comment *types.IssueComment) error
+
+		// List returns the comments of an issue, in the order they were created.
+		List(ctx context.Context, issueID int64, page, size int) ([]*types.IssueComment, error)
+	}
+
+	// IssueAssigneeStore stores the principals assigned to the issues.
+	IssueAssigneeStore interface {
+		// Assign assigns a principal to an issue. Assigning an already assigned principal is a no-op.
+		Assign(ctx context.Context, issueID, principalID, createdBy, created int64) error
+
+		// Unassign removes a principal from the assignees of an issue.
+		Unassign(ctx context.Context, issueID, principalID int64) error
+
+		// ListIDs returns IDs of the principals assigned to an issue.
+		ListIDs(ctx context.Context, issueID int64) ([]int64, error)
+	}
+
+	// IssueLabelAssignmentStore stores the labels assigned to the issues.
+	IssueLabelAssignmentStore interface {
+		// Assign assigns a label to an issue.
+		Assign(ctx context.Context, label *types.IssueLabel) error
+
+		// Unassign removes a label from an issue.
+		Unassign(ctx context.Context, issueID int64, labelID int64) error
+
+		// FindByLabelID finds a label assigned to an issue.
+		FindByLabelID(ctx context.Context, issueID int64, labelID int64) (*types.IssueLabel, error)
+
+		// ListAssigned list labels assigned to an issue.
+		ListAssigned(ctx context.Context, issueID int64) (map[int64]*types.LabelAssignment, error)
+	}
+
+	// ReplicationStore stores the replication records and the consumers acknowledging them.
+	ReplicationStore interface {
+		// CreateRecord creates a new replication record.
+		CreateRecord(ctx context.Context, record *types.ReplicationRecord) error
+
+		// MarkPackAvailable marks the replication records of the repository matching the provided reference updates
+		// as having their pack available.
+		MarkPackAvailable(ctx context.Context, repoID int64, records []*types.ReplicationRecord) (int64, error)
+
+		// ListStaleRecords lists the replication records without available pack created before the provided time.
+		ListStaleRecords(ctx context.Context, createdBefore int64, limit int) ([]*types.ReplicationRecord, error)
+
+		// HasSuccessor returns true if a later replication record with available pack
+		// continues from the new SHA of the provided record.
+		HasSuccessor(ctx context.Context, record *types.ReplicationRecord) (bool, error)
+
+		// DeleteRecord deletes the replication record.
+		DeleteRecord(ctx context.Context, id int64) error
+
+		// SequenceRecords assigns sequence numbers to the replication records with available pack
+		// that don't have one yet, keeping the order of the updates of each reference.
+		SequenceRecords(ctx context.Context, limit int) (int, error)
+
+		// ListRecords lists the sequenced replication records in the order of their sequence numbers.
+		ListRecords(ctx context.Context, filter types.ReplicationRecordFilter) ([]*types.ReplicationRecord, error)
+
+		// LatestSeq returns the sequence number of the latest sequenced replication record, 0 if there are none.
+		LatestSeq(ctx context.Context) (int64, error)
+
+		// Lag returns the lag of a consumer that acknowledged all records up to the provided sequence number.
+		Lag(ctx context.Context, ackedSeq int64, now int64) (types.ReplicationLag, error)
+
+		// DeleteRecords deletes replication records created before the provided time,
+		// up to the provided sequence number.
+		DeleteRecords(ctx context.Context, createdBefore int64, maxSeq int64) (int64, error)
+
+		// CreateConsumer creates a new replication consumer.
+		CreateConsumer(ctx context.Context, consumer *types.ReplicationConsumer) error
+
+		// FindConsumerByIdentifier finds the replication consumer by its identifier.
+		FindConsumerByIdentifier(ctx context.Context, identifier string) (*types.ReplicationConsumer, error)
+
+		// ListConsumers lists all replication consumers.
+		ListConsumers(ctx context.Context) ([]*types.ReplicationConsumer, error)
+
+		// AckConsumer moves the acknowledged sequence number of the replication consumer forward.
+		AckConsumer(ctx context.Context, id int64, seq int64, acked int64) (bool, error)
+
+		// DeleteConsumer deletes the replication consumer.
+		DeleteConsumer(ctx context.Context, id int64) error
+	}
+
+	// PullReqAutoMergeStore stores the pull requests waiting to get merged automatically.
+	PullReqAutoMergeStore interface {
+		// Upsert enables the auto-merge of a pull request, or replaces it if it's already enabled.
+		Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error
+
+		// Find finds the auto-merge of a pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, error)
+
+		// Delete disables the auto-merge of a pull request. It returns false if the auto-merge wasn't enabled.
+		Delete(ctx context.Context, pullReqID int64) (bool, error)
+
+		// List lists the auto-merges with pull request ID greater than the provided one, ordered by pull request ID.
+		List(ctx context.Context, afterPullReqID int64, limit int) ([]*types.PullReqAutoMerge, error)
+	}
+
+	// PullReqDependencyStore stores the dependencies between pull requests.
+	PullReqDependencyStore interface {
+		// Create declares that a pull request depends on another pull request.
+		Create(ctx context.Context, dependency *types.PullReqDependency) error
+
+		// Delete removes the dependency of a pull request on another pull request.
+		// It returns false if the dependency doesn't exist.
+		Delete(ctx context.Context, pullReqID, dependsOnID int64) (bool, error)
+
+		// List lists the dependencies of the provided pull requests.
+		List(ctx context.Context, pullReqIDs []int64) ([]*types.PullReqDependency, error)
+	}
+
+	// PullReqReviewSLAStore stores the review SLAs of pull requests.
+	PullReqReviewSLAStore interface {
+		// Upsert inserts the review SLA of a pull request, or restarts it if it already exists.
+		Upsert(ctx context.Context, sla *types.PullReqReviewSLA) error
+
+		// Find finds the review SLA of a pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.PullReqReviewSLA, error)
+
+		// MarkResponded sets the time of the first response to the pull request, unless it's already set.
+		MarkResponded(ctx context.Context, pullReqID int64, responded int64, responseTime int64) (bool, error)
+
+		// MarkClosed sets the time the pull request got closed, unless it's already set.
+		MarkClosed(ctx context.Context, pullReqID int64, closed int64) error
+
+		// MarkWarned sets the time the review SLA warning got reported, unless it's already set.
+		MarkWarned(ctx context.Context, pullReqID int64, warned int64) (bool, error)
+
+		// MarkBreached sets the time the review SLA breach got reported, unless it's already set.
+		MarkBreached(ctx context.Context, pullReqID int64, breached int64) (bool, error)
+
+		// ListAlertable lists review SLAs of pull requests waiting for their first response,
+		// which reached their warning or due time without it being reported.
+		ListAlertable(ctx context.Context, now int64, limit int) ([]*types.PullReqReviewSLA, error)
+
+		// Report returns the review SLA compliance of the pull requests of the repositories in the spaces.
+		Report(
+			ctx context.Context,
+			spaceIDs []int64,
+			filter types.ReviewSLAReportFilter,
+			now int64,
+		) (*types.ReviewSLAReport, error)
+	}
+
 	// PullReqFileViewStore stores information about what file a user viewed.
 	PullReqFileViewStore interface {
 		// Upsert inserts or updates the latest viewed sha for a file in a PR.
@@ -820,6 +1527,9 @@ type (
 
 		// ListAll lists all the secrets in a given space.
 		ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error)
+
+		// ListAllInSpaces lists all the secrets in any of the given spaces.
+		ListAllInSpaces(ctx context.Context, spaceIDs []int64) ([]*types.Secret, error)
 	}
 
 	ExecutionStore interface {
@@ -843,6 +1553,10 @@ type (
 
 		// Count the number of executions in a space
 		Count(ctx context.Context, parentID int64) (int64, error)
+
+		// SumDuration returns the total duration (in milliseconds) of the executions started since the provided
+		// time in the repos of the space and all its sub-spaces. Running executions are counted up to now.
+		SumDuration(ctx context.Context, spaceID int64, since, now int64) (int64, error)
 	}
 
 	StageStore interface {
@@ -850,6 +1564,10 @@ type (
 		// where the stage is incomplete (pending or running).
 		ListIncomplete(ctx context.Context) ([]*types.Stage, error)
 
+		// ListIncompleteByConcurrencyGroup returns the incomplete stages
+		// (waiting on dependencies, pending or running) of the concurrency group of the repo.
+		ListIncompleteByConcurrencyGroup(ctx context.Context, repoID int64, group string) ([]*types.Stage, error)
+
 		// List returns a list of stages corresponding to an execution ID.
 		List(ctx context.Context, executionID int64) ([]*types.Stage, error)
 
@@ -985,6 +1703,128 @@ type (
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
 	}
 
+	// TriggerRequestStore stores the requests to create pipeline executions for git events.
+	TriggerRequestStore interface {
+		// Find finds the trigger request by id.
+		Find(ctx context.Context, id int64) (*types.TriggerRequest, error)
+
+		// Create creates a new trigger request.
+		Create(ctx context.Context, request *types.TriggerRequest) error
+
+		// Update updates the state, the attempts and the last error of a trigger request.
+		Update(ctx context.Context, request *types.TriggerRequest) error
+
+		// Claim marks a queued trigger request as running. A running request that wasn't updated since
+		// staleBefore is claimed as well. It returns false if the request was claimed by someone else.
+		Claim(ctx context.Context, request *types.TriggerRequest, staleBefore int64) (bool, error)
+
+		// Delete deletes a trigger request.
+		Delete(ctx context.Context, id int64) error
+
+		// ListFailed lists the trigger requests of a repo that failed all attempts, the most recent first.
+		ListFailed(ctx context.Context, repoID int64, pagination types.Pagination) ([]*types.TriggerRequest, error)
+
+		// CountFailed returns the number of trigger requests of a repo that failed all attempts.
+		CountFailed(ctx context.Context, repoID int64) (int64, error)
+	}
+
+	// CronStore stores the cron triggers of pipelines.
+	CronStore interface {
+		// FindByIdentifier finds the cron trigger of a pipeline by its identifier.
+		FindByIdentifier(ctx context.Context, pipelineID int64, identifier string) (*types.Cron, error)
+
+		// Create creates a new cron trigger.
+		Create(ctx context.Context, cron *types.Cron) error
+
+		// UpdateOptLock updates the cron trigger using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			cron *types.Cron,
+			mutateFn func(cron *types.Cron) error,
+		) (*types.Cron, error)
+
+		// DeleteByIdentifier deletes the cron trigger of a pipeline by its identifier.
+		DeleteByIdentifier(ctx context.Context, pipelineID int64, identifier string) error
+
+		// List lists the cron triggers of a pipeline.
+		List(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) ([]*types.Cron, error)
+
+		// Count returns the number of cron triggers of a pipeline.
+		Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error)
+
+		// ListDue lists the enabled cron triggers with a scheduled run at or before the provided time,
+		// the most overdue first.
+		ListDue(ctx context.Context, before int64, limit int) ([]*types.Cron, error)
+	}
+
+	// PipelineCacheStore stores the build cache entries of the pipelines of repositories.
+	PipelineCacheStore interface {
+		// Find finds the cache entry of the repository by its key.
+		Find(ctx context.Context, repoID int64, key string) (*types.PipelineCache, error)
+
+		// Upsert creates the cache entry or replaces the existing entry of the repository with the same key.
+		Upsert(ctx context.Context, cache *types.PipelineCache) error
+
+		// UpdateLastUsed sets the time the cache entry was last restored.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error
+
+		// Delete deletes the cache entry.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists all cache entries of the repository, the most recently used first.
+		List(ctx context.Context, repoID int64) ([]*types.PipelineCache, error)
+
+		// ListUnusedSince lists the cache entries of all repositories that weren't used since the provided time,
+		// the least recently used first.
+		ListUnusedSince(ctx context.Context, since int64, limit int) ([]*types.PipelineCache, error)
+	}
+
+	// PipelineArtifactStore stores the artifacts uploaded by the steps of pipeline executions.
+	PipelineArtifactStore interface {
+		// Find finds the artifact of the execution by its name.
+		Find(ctx context.Context, executionID int64, name string) (*types.PipelineArtifact, error)
+
+		// Upsert creates the artifact or replaces the existing artifact of the execution with the same name.
+		Upsert(ctx context.Context, artifact *types.PipelineArtifact) error
+
+		// Delete deletes the artifact.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists all artifacts of the execution ordered by name.
+		List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error)
+
+		// ListCreatedBefore lists the artifacts of all executions created before the provided time,
+		// the oldest first.
+		ListCreatedBefore(ctx context.Context, before int64, limit int) ([]*types.PipelineArtifact, error)
+	}
+
+	// RunnerStore stores the external runners that registered to execute pipeline stages.
+	RunnerStore interface {
+		// Find finds the runner by its id.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByIdentifier finds the runner by its identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error)
+
+		// FindByTokenHash finds the runner by the hash of its token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// UpdateLastSeen sets the time of the last heartbeat of the runner.
+		UpdateLastSeen(ctx context.Context, id int64, lastSeen int64) error
+
+		// Delete deletes the runner.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists all runners ordered by identifier.
+		List(ctx context.Context) ([]*types.Runner, error)
+
+		// ListLastSeenBefore lists the runners without heartbeat since the provided time.
+		ListLastSeenBefore(ctx context.Context, before int64) ([]*types.Runner, error)
+	}
+
 	PluginStore interface {
 		// List returns back the list of plugins matching the given filter
 		// along with their associated schemas.
@@ -1034,6 +1874,62 @@ type (
 			spaceID int64,
 			userGroup *types.UserGroup,
 		) error
+
+		// Update updates the name and the description of a usergroup.
+		Update(ctx context.Context, userGroup *types.UserGroup) error
+
+		// Delete deletes a usergroup along with its members, subgroups and memberships.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the usergroups defined in any of the provided spaces.
+		List(ctx context.Context, spaceIDs []int64, filter *types.ListQueryFilter) ([]*types.UserGroup, error)
+
+		// Count returns the number of usergroups defined in any of the provided spaces.
+		Count(ctx context.Context, spaceIDs []int64, filter *types.ListQueryFilter) (int64, error)
+	}
+
+	// UserGroupMemberStore defines the storage of the direct members of usergroups.
+	UserGroupMemberStore interface {
+		Create(ctx context.Context, member *types.UserGroupMember) error
+		Delete(ctx context.Context, userGroupID, principalID int64) error
+
+		// ListUsers returns the direct members of a usergroup.
+		ListUsers(ctx context.Context, userGroupID int64) ([]types.UserGroupMemberUser, error)
+
+		// ListPrincipalIDs returns the IDs of the direct members of any of the usergroups.
+		ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error)
+
+		// ListUserGroupIDs returns the IDs of the usergroups the principal is a direct member of.
+		ListUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
+	// UserGroupSubgroupStore defines the storage of nested usergroups.
+	UserGroupSubgroupStore interface {
+		Create(ctx context.Context, subgroup *types.UserGroupSubgroup) error
+		Delete(ctx context.Context, parentID, childID int64) error
+
+		// ListChildIDs returns the IDs of the usergroups nested directly in any of the parent usergroups.
+		ListChildIDs(ctx context.Context, parentIDs []int64) ([]int64, error)
+
+		// ListParentIDs returns the IDs of the usergroups any of the child usergroups are directly nested in.
+		ListParentIDs(ctx context.Context, childIDs []int64) ([]int64, error)
+	}
+
+	// UserGroupMembershipStore defines the storage of space memberships granted to usergroups.
+	UserGroupMembershipStore interface {
+		Find(ctx context.Context, key types.UserGroupMembershipKey) (*types.UserGroupMembership, error)
+		Create(ctx context.Context, membership *types.UserGroupMembership) error
+		Update(ctx context.Context, membership *types.UserGroupMembership) error
+		Delete(ctx context.Context, key types.UserGroupMembershipKey) error
+
+		// List returns all usergroup memberships of a space.
+		List(ctx context.Context, spaceID int64) ([]types.UserGroupMembershipInfo, error)
+
+		// ListRoles returns the roles granted in the space to any of the usergroups.
+		ListRoles(ctx context.Context, spaceID int64, userGroupIDs []int64) ([]enum.MembershipRole, error)
+
+		// CountByRole returns the number of usergroup memberships with the role.
+		CountByRole(ctx context.Context, role enum.MembershipRole) (int64, error)
 	}
 
 	PublicKeyStore interface {
@@ -1046,6 +1942,12 @@ type (
 		// Create creates a new public key.
 		Create(ctx context.Context, publicKey *types.PublicKey) error
 
+		// Update updates the identifier and the comment of the public key.
+		Update(ctx context.Context, publicKey *types.PublicKey) error
+
+		// Revoke marks the public key as revoked.
+		Revoke(ctx context.Context, id int64, revoked int64) error
+
 		// DeleteByIdentifier deletes a public key.
 		DeleteByIdentifier(ctx context.Context, principalID int64, identifier string) error
 
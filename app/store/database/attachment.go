@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.AttachmentStore = (*AttachmentStore)(nil)
+
+// NewAttachmentStore returns a new AttachmentStore.
+func NewAttachmentStore(db *sqlx.DB) *AttachmentStore {
+	return &AttachmentStore{
+		db: db,
+	}
+}
+
+// AttachmentStore implements store.AttachmentStore backed by a relational database.
+type AttachmentStore struct {
+	db *sqlx.DB
+}
+
+type attachment struct {
+	ID          int64  `db:"attachment_id"`
+	RepoID      int64  `db:"attachment_repo_id"`
+	FileName    string `db:"attachment_file_name"`
+	ContentType string `db:"attachment_content_type"`
+	Size        int64  `db:"attachment_size"`
+	CreatedBy   int64  `db:"attachment_created_by"`
+	Created     int64  `db:"attachment_created"`
+	Referenced  bool   `db:"attachment_referenced"`
+}
+
+const (
+	attachmentColumns = `
+		 attachment_id
+		,attachment_repo_id
+		,attachment_file_name
+		,attachment_content_type
+		,attachment_size
+		,attachment_created_by
+		,attachment_created
+		,attachment_referenced`
+)
+
+// Create saves the attachment details.
+func (s *AttachmentStore) Create(ctx context.Context, a *types.Attachment) error {
+	const sqlQuery = `
+		INSERT INTO attachments (
+			 attachment_repo_id
+			,attachment_file_name
+			,attachment_content_type
+			,attachment_size
+			,attachment_created_by
+			,attachment_created
+			,attachment_referenced
+		) VALUES (
+			 :attachment_repo_id
+			,:attachment_file_name
+			,:attachment_content_type
+			,:attachment_size
+			,:attachment_created_by
+			,:attachment_created
+			,:attachment_referenced
+		)
+		RETURNING attachment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAttachment(a))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind attachment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// MarkReferenced marks the attachments of a repository with the provided file names as referenced.
+func (s *AttachmentStore) MarkReferenced(ctx context.Context, repoID int64, fileNames []string) error {
+	if len(fileNames) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Update("attachments").
+		Set("attachment_referenced", true).
+		Where("attachment_repo_id = ?", repoID).
+		Where(squirrel.Eq{"attachment_file_name": fileNames}).
+		Where("attachment_referenced = ?", false)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark attachments as referenced")
+	}
+
+	return nil
+}
+
+// ListOrphaned returns attachments that were created before the provided time and were never referenced,
+// and attachments of repositories that don't exist anymore.
+func (s *AttachmentStore) ListOrphaned(
+	ctx context.Context,
+	createdBefore int64,
+	limit int,
+) ([]*types.Attachment, error) {
+	stmt := database.Builder.
+		Select(attachmentColumns).
+		From("attachments").
+		Where(squirrel.Or{
+			squirrel.And{
+				squirrel.Eq{"attachment_referenced": false},
+				squirrel.Lt{"attachment_created": createdBefore},
+			},
+			squirrel.Expr("NOT EXISTS (SELECT 1 FROM repositories WHERE repo_id = attachment_repo_id)"),
+		}).
+		OrderBy("attachment_id").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*attachment
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list orphaned attachments query")
+	}
+
+	result := make([]*types.Attachment, len(dst))
+	for i, a := range dst {
+		result[i] = mapToAttachment(a)
+	}
+
+	return result, nil
+}
+
+// ListByRepo returns all attachments of a repository.
+func (s *AttachmentStore) ListByRepo(ctx context.Context, repoID int64) ([]*types.Attachment, error) {
+	stmt := database.Builder.
+		Select(attachmentColumns).
+		From("attachments").
+		Where("attachment_repo_id = ?", repoID).
+		OrderBy("attachment_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*attachment
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list attachments query")
+	}
+
+	result := make([]*types.Attachment, len(dst))
+	for i, a := range dst {
+		result[i] = mapToAttachment(a)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the attachment details.
+func (s *AttachmentStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM attachments
+		WHERE attachment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalAttachment(a *types.Attachment) *attachment {
+	return &attachment{
+		ID:          a.ID,
+		RepoID:      a.RepoID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+		Referenced:  a.Referenced,
+	}
+}
+
+func mapToAttachment(a *attachment) *types.Attachment {
+	return &types.Attachment{
+		ID:          a.ID,
+		RepoID:      a.RepoID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+		Referenced:  a.Referenced,
+	}
+}
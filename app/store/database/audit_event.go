@@ -0,0 +1,355 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.AuditEventStore = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(db *sqlx.DB) *AuditEventStore {
+	return &AuditEventStore{
+		db: db,
+	}
+}
+
+// AuditEventStore implements store.AuditEventStore backed by a relational database.
+type AuditEventStore struct {
+	db *sqlx.DB
+}
+
+type auditEvent struct {
+	ID        int64  `db:"audit_event_id"`
+	UID       string `db:"audit_event_uid"`
+	Timestamp int64  `db:"audit_event_timestamp"`
+	Action    string `db:"audit_event_action"`
+
+	ActorID  int64  `db:"audit_event_actor_id"`
+	ActorUID string `db:"audit_event_actor_uid"`
+
+	SpaceID   int64  `db:"audit_event_space_id"`
+	SpacePath string `db:"audit_event_space_path"`
+
+	ResourceType       string `db:"audit_event_resource_type"`
+	ResourceIdentifier string `db:"audit_event_resource_identifier"`
+	ResourceData       string `db:"audit_event_resource_data"`
+
+	OldObject null.String `db:"audit_event_old_object"`
+	NewObject null.String `db:"audit_event_new_object"`
+
+	ClientIP      string `db:"audit_event_client_ip"`
+	RequestMethod string `db:"audit_event_request_method"`
+	RequestID     string `db:"audit_event_request_id"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_uid
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_actor_id
+		,audit_event_actor_uid
+		,audit_event_space_id
+		,audit_event_space_path
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_request_id`
+)
+
+// Create stores a new audit event.
+func (s *AuditEventStore) Create(ctx context.Context, in *types.AuditEvent) error {
+	const sqlQuery = `
+	INSERT INTO audit_events (
+		 audit_event_uid
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_actor_id
+		,audit_event_actor_uid
+		,audit_event_space_id
+		,audit_event_space_path
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_request_id
+	) values (
+		 :audit_event_uid
+		,:audit_event_timestamp
+		,:audit_event_action
+		,:audit_event_actor_id
+		,:audit_event_actor_uid
+		,:audit_event_space_id
+		,:audit_event_space_path
+		,:audit_event_resource_type
+		,:audit_event_resource_identifier
+		,:audit_event_resource_data
+		,:audit_event_old_object
+		,:audit_event_new_object
+		,:audit_event_client_ip
+		,:audit_event_request_method
+		,:audit_event_request_id
+	) RETURNING audit_event_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEvent, err := mapInternalAuditEvent(in)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEvent)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of audit events of a space.
+func (s *AuditEventStore) Count(ctx context.Context, spaceID int64, opts *types.AuditEventFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("audit_events")
+
+	stmt, err := s.applyAuditEventFilter(ctx, stmt, spaceID, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of audit events of a space, the most recent first.
+func (s *AuditEventStore) List(
+	ctx context.Context,
+	spaceID int64,
+	opts *types.AuditEventFilter,
+) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events")
+
+	stmt, err := s.applyAuditEventFilter(ctx, stmt, spaceID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy("audit_event_timestamp DESC", "audit_event_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*auditEvent, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	result := make([]*types.AuditEvent, len(dst))
+	for i, e := range dst {
+		result[i], err = mapAuditEvent(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ListByIDs returns the audit events with the provided IDs, ordered by ID.
+func (s *AuditEventStore) ListByIDs(ctx context.Context, ids []int64) ([]*types.AuditEvent, error) {
+	if len(ids) == 0 {
+		return []*types.AuditEvent{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events").
+		Where(squirrel.Eq{"audit_event_id": ids}).
+		OrderBy("audit_event_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*auditEvent, 0, len(ids))
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list by IDs query")
+	}
+
+	result := make([]*types.AuditEvent, len(dst))
+	for i, e := range dst {
+		result[i], err = mapAuditEvent(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *AuditEventStore) applyAuditEventFilter(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	spaceID int64,
+	opts *types.AuditEventFilter,
+) (squirrel.SelectBuilder, error) {
+	if opts.Recursive {
+		query := spaceDescendantsQuery + `
+		SELECT space_descendant_id
+		FROM space_descendants`
+
+		db := dbtx.GetAccessor(ctx, s.db)
+
+		var spaceIDs []int64
+		if err := db.SelectContext(ctx, &spaceIDs, query, spaceID); err != nil {
+			return stmt, database.ProcessSQLErrorf(ctx, err, "failed to retrieve spaces")
+		}
+
+		stmt = stmt.Where(squirrel.Eq{"audit_event_space_id": spaceIDs})
+	} else {
+		stmt = stmt.Where("audit_event_space_id = ?", spaceID)
+	}
+
+	if opts.ResourceType != "" {
+		stmt = stmt.Where("audit_event_resource_type = ?", opts.ResourceType)
+	}
+
+	if opts.Action != "" {
+		stmt = stmt.Where("audit_event_action = ?", opts.Action)
+	}
+
+	if opts.ActorID > 0 {
+		stmt = stmt.Where("audit_event_actor_id = ?", opts.ActorID)
+	}
+
+	if opts.From > 0 {
+		stmt = stmt.Where("audit_event_timestamp >= ?", opts.From)
+	}
+
+	if opts.To > 0 {
+		stmt = stmt.Where("audit_event_timestamp <= ?", opts.To)
+	}
+
+	return stmt, nil
+}
+
+func mapInternalAuditEvent(in *types.AuditEvent) (*auditEvent, error) {
+	data, err := json.Marshal(in.ResourceData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event resource data: %w", err)
+	}
+
+	return &auditEvent{
+		ID:                 in.ID,
+		UID:                in.UID,
+		Timestamp:          in.Timestamp,
+		Action:             in.Action,
+		ActorID:            in.Actor.ID,
+		ActorUID:           in.Actor.UID,
+		SpaceID:            in.SpaceID,
+		SpacePath:          in.SpacePath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourceData:       string(data),
+		OldObject:          null.NewString(string(in.OldObject), len(in.OldObject) > 0),
+		NewObject:          null.NewString(string(in.NewObject), len(in.NewObject) > 0),
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+		RequestID:          in.RequestID,
+	}, nil
+}
+
+func mapAuditEvent(in *auditEvent) (*types.AuditEvent, error) {
+	var data map[string]string
+	if err := json.Unmarshal([]byte(in.ResourceData), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event resource data: %w", err)
+	}
+
+	out := &types.AuditEvent{
+		ID:        in.ID,
+		UID:       in.UID,
+		Timestamp: in.Timestamp,
+		Action:    in.Action,
+		Actor: types.PrincipalInfo{
+			ID:  in.ActorID,
+			UID: in.ActorUID,
+		},
+		SpaceID:            in.SpaceID,
+		SpacePath:          in.SpacePath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourceData:       data,
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+		RequestID:          in.RequestID,
+	}
+
+	if in.OldObject.Valid {
+		out.OldObject = json.RawMessage(in.OldObject.String)
+	}
+	if in.NewObject.Valid {
+		out.NewObject = json.RawMessage(in.NewObject.String)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.AuditEventOutboxStore = (*AuditEventOutboxStore)(nil)
+
+// NewAuditEventOutboxStore returns a new AuditEventOutboxStore.
+func NewAuditEventOutboxStore(db *sqlx.DB) *AuditEventOutboxStore {
+	return &AuditEventOutboxStore{
+		db: db,
+	}
+}
+
+// AuditEventOutboxStore implements store.AuditEventOutboxStore backed by a relational database.
+type AuditEventOutboxStore struct {
+	db *sqlx.DB
+}
+
+type auditEventOutbox struct {
+	ID           int64  `db:"audit_event_outbox_id"`
+	AuditEventID int64  `db:"audit_event_outbox_audit_event_id"`
+	Created      int64  `db:"audit_event_outbox_created"`
+	Attempts     int    `db:"audit_event_outbox_attempts"`
+	LastError    string `db:"audit_event_outbox_last_error"`
+}
+
+const (
+	auditEventOutboxColumns = `
+		 audit_event_outbox_id
+		,audit_event_outbox_audit_event_id
+		,audit_event_outbox_created
+		,audit_event_outbox_attempts
+		,audit_event_outbox_last_error`
+)
+
+// Create adds an audit event to the outbox.
+func (s *AuditEventOutboxStore) Create(ctx context.Context, entry *types.AuditEventOutbox) error {
+	const sqlQuery = `
+	INSERT INTO audit_event_outbox (
+		 audit_event_outbox_audit_event_id
+		,audit_event_outbox_created
+		,audit_event_outbox_attempts
+		,audit_event_outbox_last_error
+	) values (
+		 :audit_event_outbox_audit_event_id
+		,:audit_event_outbox_created
+		,:audit_event_outbox_attempts
+		,:audit_event_outbox_last_error
+	) RETURNING audit_event_outbox_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalAuditEventOutbox(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event outbox object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns the oldest entries of the outbox.
+func (s *AuditEventOutboxStore) List(ctx context.Context, limit int) ([]*types.AuditEventOutbox, error) {
+	stmt := database.Builder.
+		Select(auditEventOutboxColumns).
+		From("audit_event_outbox").
+		OrderBy("audit_event_outbox_id").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*auditEventOutbox, 0, limit)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list query")
+	}
+
+	result := make([]*types.AuditEventOutbox, len(dst))
+	for i, e := range dst {
+		result[i] = mapAuditEventOutbox(e)
+	}
+
+	return result, nil
+}
+
+// Delete removes the delivered entries from the outbox.
+func (s *AuditEventOutboxStore) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Delete("audit_event_outbox").
+		Where(squirrel.Eq{"audit_event_outbox_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing delete query")
+	}
+
+	return nil
+}
+
+// MarkFailed increments the number of delivery attempts of the entries and records the error.
+func (s *AuditEventOutboxStore) MarkFailed(ctx context.Context, ids []int64, lastError string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Update("audit_event_outbox").
+		Set("audit_event_outbox_attempts", squirrel.Expr("audit_event_outbox_attempts + 1")).
+		Set("audit_event_outbox_last_error", lastError).
+		Where(squirrel.Eq{"audit_event_outbox_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing update query")
+	}
+
+	return nil
+}
+
+func mapInternalAuditEventOutbox(in *types.AuditEventOutbox) *auditEventOutbox {
+	return &auditEventOutbox{
+		ID:           in.ID,
+		AuditEventID: in.AuditEventID,
+		Created:      in.Created,
+		Attempts:     in.Attempts,
+		LastError:    in.LastError,
+	}
+}
+
+func mapAuditEventOutbox(in *auditEventOutbox) *types.AuditEventOutbox {
+	return &types.AuditEventOutbox{
+		ID:           in.ID,
+		AuditEventID: in.AuditEventID,
+		Created:      in.Created,
+		Attempts:     in.Attempts,
+		LastError:    in.LastError,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestAuditEventStore_List(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	auditEventStore := database.NewAuditEventStore(db)
+
+	events := []*types.AuditEvent{
+		{SpaceID: 1, Timestamp: 100, Action: "created", ResourceType: "repository", Actor: types.PrincipalInfo{ID: 1}},
+		{SpaceID: 1, Timestamp: 200, Action: "updated", ResourceType: "space", Actor: types.PrincipalInfo{ID: 2}},
+		{SpaceID: 2, Timestamp: 300, Action: "created", ResourceType: "repository", Actor: types.PrincipalInfo{ID: 1}},
+		{
+			SpaceID:      1,
+			Timestamp:    400,
+			Action:       "deleted",
+			ResourceType: "repository",
+			Actor:        types.PrincipalInfo{ID: 1, UID: "user_1"},
+			ResourceData: map[string]string{"repo": "repo_1"},
+			OldObject:    json.RawMessage(`{"identifier":"repo_1"}`),
+		},
+	}
+	for i, event := range events {
+		event.UID = "event_" + strconv.Itoa(i+1)
+		if err := auditEventStore.Create(ctx, event); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filter  types.AuditEventFilter
+		wantIDs []int64
+	}{
+		{
+			name:    "space only, most recent first",
+			filter:  types.AuditEventFilter{},
+			wantIDs: []int64{4, 2, 1},
+		},
+		{
+			name:    "recursive",
+			filter:  types.AuditEventFilter{Recursive: true},
+			wantIDs: []int64{4, 3, 2, 1},
+		},
+		{
+			name:    "resource type and action",
+			filter:  types.AuditEventFilter{Recursive: true, ResourceType: "repository", Action: "created"},
+			wantIDs: []int64{3, 1},
+		},
+		{
+			name:    "actor",
+			filter:  types.AuditEventFilter{ActorID: 2},
+			wantIDs: []int64{2},
+		},
+		{
+			name:    "time range",
+			filter:  types.AuditEventFilter{Recursive: true, From: 200, To: 300},
+			wantIDs: []int64{3, 2},
+		},
+		{
+			name:    "paginated",
+			filter:  types.AuditEventFilter{Recursive: true, Page: 2, Size: 3},
+			wantIDs: []int64{1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := auditEventStore.List(ctx, 1, &test.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			ids := make([]int64, len(list))
+			for i, event := range list {
+				ids[i] = event.ID
+			}
+			if !reflect.DeepEqual(ids, test.wantIDs) {
+				t.Errorf("List() = %v, want %v", ids, test.wantIDs)
+			}
+
+			// the count ignores the pagination.
+			count, err := auditEventStore.Count(ctx, 1, &test.filter)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+			if test.filter.Size == 0 && count != int64(len(test.wantIDs)) {
+				t.Errorf("Count() = %d, want %d", count, len(test.wantIDs))
+			}
+		})
+	}
+
+	found, err := auditEventStore.ListByIDs(ctx, []int64{4})
+	if err != nil {
+		t.Fatalf("ListByIDs() error = %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("ListByIDs() returned %d events, want 1", len(found))
+	}
+
+	event := found[0]
+	if event.Actor.UID != "user_1" || !reflect.DeepEqual(event.ResourceData, map[string]string{"repo": "repo_1"}) {
+		t.Errorf("event = %+v, want actor user_1 and the resource data", event)
+	}
+	if string(event.OldObject) != `{"identifier":"repo_1"}` || event.NewObject != nil {
+		t.Errorf("objects = %s, %s, want the old object only", event.OldObject, event.NewObject)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.BannerStore = (*BannerStore)(nil)
+
+// NewBannerStore returns a new BannerStore.
+func NewBannerStore(db *sqlx.DB) *BannerStore {
+	return &BannerStore{
+		db: db,
+	}
+}
+
+// BannerStore implements store.BannerStore backed by a relational database.
+type BannerStore struct {
+	db *sqlx.DB
+}
+
+type banner struct {
+	ID          int64               `db:"banner_id"`
+	Identifier  string              `db:"banner_uid"`
+	Message     string              `db:"banner_message"`
+	Severity    enum.BannerSeverity `db:"banner_severity"`
+	StartsAt    int64               `db:"banner_starts_at"`
+	EndsAt      int64               `db:"banner_ends_at"`
+	Dismissible bool                `db:"banner_dismissible"`
+	CreatedBy   int64               `db:"banner_created_by"`
+	Created     int64               `db:"banner_created"`
+	Updated     int64               `db:"banner_updated"`
+}
+
+const (
+	bannerColumns = `
+		 banner_id
+		,banner_uid
+		,banner_message
+		,banner_severity
+		,banner_starts_at
+		,banner_ends_at
+		,banner_dismissible
+		,banner_created_by
+		,banner_created
+		,banner_updated`
+
+	bannerSelectBase = `
+		SELECT` + bannerColumns + `
+		FROM banners`
+)
+
+// FindByIdentifier finds the banner by its identifier (case insensitive).
+func (s *BannerStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Banner, error) {
+	const sqlQuery = bannerSelectBase + `
+		WHERE LOWER(banner_uid) = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &banner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find banner by identifier")
+	}
+
+	return mapBanner(dst), nil
+}
+
+// List returns all banners, the most recently starting first.
+func (s *BannerStore) List(ctx context.Context) ([]*types.Banner, error) {
+	const sqlQuery = bannerSelectBase + `
+		ORDER BY banner_starts_at DESC, banner_id DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*banner, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list banners")
+	}
+
+	return mapBanners(dst), nil
+}
+
+// ListNotEnded returns the banners without an end or with an end after the provided time (unix millis).
+func (s *BannerStore) ListNotEnded(ctx context.Context, now int64) ([]*types.Banner, error) {
+	const sqlQuery = bannerSelectBase + `
+		WHERE banner_ends_at = 0 OR banner_ends_at > $1
+		ORDER BY banner_starts_at DESC, banner_id DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*banner, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, now); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list banners that haven't ended")
+	}
+
+	return mapBanners(dst), nil
+}
+
+// Create stores a new banner.
+func (s *BannerStore) Create(ctx context.Context, b *types.Banner) error {
+	const sqlQuery = `
+		INSERT INTO banners (
+			 banner_uid
+			,banner_message
+			,banner_severity
+			,banner_starts_at
+			,banner_ends_at
+			,banner_dismissible
+			,banner_created_by
+			,banner_created
+			,banner_updated
+		) values (
+			 :banner_uid
+			,:banner_message
+			,:banner_severity
+			,:banner_starts_at
+			,:banner_ends_at
+			,:banner_dismissible
+			,:banner_created_by
+			,:banner_created
+			,:banner_updated
+		) RETURNING banner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalBanner(b))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind banner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&b.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert banner query failed")
+	}
+
+	return nil
+}
+
+// Update updates the message, severity, schedule and dismissibility of a banner.
+func (s *BannerStore) Update(ctx context.Context, b *types.Banner) error {
+	const sqlQuery = `
+		UPDATE banners
+		SET
+			 banner_message = :banner_message
+			,banner_severity = :banner_severity
+			,banner_starts_at = :banner_starts_at
+			,banner_ends_at = :banner_ends_at
+			,banner_dismissible = :banner_dismissible
+			,banner_updated = :banner_updated
+		WHERE banner_id = :banner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalBanner(b))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind banner object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update banner")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a banner.
+func (s *BannerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM banners
+		WHERE banner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete banner")
+	}
+
+	return nil
+}
+
+func mapBanner(in *banner) *types.Banner {
+	return &types.Banner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Message:     in.Message,
+		Severity:    in.Severity,
+		StartsAt:    in.StartsAt,
+		EndsAt:      in.EndsAt,
+		Dismissible: in.Dismissible,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapBanners(in []*banner) []*types.Banner {
+	out := make([]*types.Banner, len(in))
+	for i, b := range in {
+		out[i] = mapBanner(b)
+	}
+	return out
+}
+
+func mapInternalBanner(in *types.Banner) *banner {
+	return &banner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Message:     in.Message,
+		Severity:    in.Severity,
+		StartsAt:    in.StartsAt,
+		EndsAt:      in.EndsAt,
+		Dismissible: in.Dismissible,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
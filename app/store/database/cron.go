@@ -0,0 +1,394 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CronStore = (*CronStore)(nil)
+
+// NewCronStore returns a new CronStore.
+func NewCronStore(db *sqlx.DB) *CronStore {
+	return &CronStore{
+		db: db,
+	}
+}
+
+// CronStore implements store.CronStore backed by a relational database.
+type CronStore struct {
+	db *sqlx.DB
+}
+
+type cron struct {
+	ID          int64  `db:"cron_id"`
+	RepoID      int64  `db:"cron_repo_id"`
+	PipelineID  int64  `db:"cron_pipeline_id"`
+	Identifier  string `db:"cron_uid"`
+	Description string `db:"cron_description"`
+	Branch      string `db:"cron_branch"`
+	Expression  string `db:"cron_expression"`
+	Timezone    string `db:"cron_timezone"`
+	Disabled    bool   `db:"cron_disabled"`
+	Next        int64  `db:"cron_next"`
+	Prev        int64  `db:"cron_prev"`
+	CreatedBy   int64  `db:"cron_created_by"`
+	Created     int64  `db:"cron_created"`
+	Updated     int64  `db:"cron_updated"`
+	Version     int64  `db:"cron_version"`
+}
+
+const (
+	cronColumns = `
+		 cron_id
+		,cron_repo_id
+		,cron_pipeline_id
+		,cron_uid
+		,cron_description
+		,cron_branch
+		,cron_expression
+		,cron_timezone
+		,cron_disabled
+		,cron_next
+		,cron_prev
+		,cron_created_by
+		,cron_created
+		,cron_updated
+		,cron_version`
+)
+
+// FindByIdentifier finds the cron trigger of a pipeline by its identifier.
+func (s *CronStore) FindByIdentifier(
+	ctx context.Context,
+	pipelineID int64,
+	identifier string,
+) (*types.Cron, error) {
+	const sqlQuery = `
+		SELECT` + cronColumns + `
+		FROM crons
+		WHERE cron_pipeline_id = $1 AND LOWER(cron_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &cron{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pipelineID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find cron")
+	}
+
+	return mapCron(dst), nil
+}
+
+// Create creates a new cron trigger.
+func (s *CronStore) Create(ctx context.Context, c *types.Cron) error {
+	const sqlQuery = `
+		INSERT INTO crons (
+			 cron_repo_id
+			,cron_pipeline_id
+			,cron_uid
+			,cron_description
+			,cron_branch
+			,cron_expression
+			,cron_timezone
+			,cron_disabled
+			,cron_next
+			,cron_prev
+			,cron_created_by
+			,cron_created
+			,cron_updated
+			,cron_version
+		) values (
+			 :cron_repo_id
+			,:cron_pipeline_id
+			,:cron_uid
+			,:cron_description
+			,:cron_branch
+			,:cron_expression
+			,:cron_timezone
+			,:cron_disabled
+			,:cron_next
+			,:cron_prev
+			,:cron_created_by
+			,:cron_created
+			,:cron_updated
+			,:cron_version
+		) RETURNING cron_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCron(c))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind cron object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&c.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert cron query failed")
+	}
+
+	return nil
+}
+
+// update updates the cron trigger if its version didn't change.
+func (s *CronStore) update(ctx context.Context, c *types.Cron) error {
+	const sqlQuery = `
+		UPDATE crons
+		SET
+			 cron_uid = :cron_uid
+			,cron_description = :cron_description
+			,cron_branch = :cron_branch
+			,cron_expression = :cron_expression
+			,cron_timezone = :cron_timezone
+			,cron_disabled = :cron_disabled
+			,cron_next = :cron_next
+			,cron_prev = :cron_prev
+			,cron_updated = :cron_updated
+			,cron_version = :cron_version
+		WHERE cron_id = :cron_id AND cron_version = :cron_version - 1`
+
+	dbCron := mapInternalCron(c)
+	dbCron.Version++
+	dbCron.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbCron)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind cron object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update cron")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	c.Version = dbCron.Version
+	c.Updated = dbCron.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the cron trigger using the optimistic locking mechanism.
+func (s *CronStore) UpdateOptLock(
+	ctx context.Context,
+	c *types.Cron,
+	mutateFn func(c *types.Cron) error,
+) (*types.Cron, error) {
+	for {
+		dup := *c
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		c, err = s.find(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *CronStore) find(ctx context.Context, id int64) (*types.Cron, error) {
+	const sqlQuery = `
+		SELECT` + cronColumns + `
+		FROM crons
+		WHERE cron_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &cron{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find cron")
+	}
+
+	return mapCron(dst), nil
+}
+
+// DeleteByIdentifier deletes the cron trigger of a pipeline by its identifier.
+func (s *CronStore) DeleteByIdentifier(ctx context.Context, pipelineID int64, identifier string) error {
+	const sqlQuery = `
+		DELETE FROM crons
+		WHERE cron_pipeline_id = $1 AND LOWER(cron_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pipelineID, strings.ToLower(identifier)); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete cron")
+	}
+
+	return nil
+}
+
+// List lists the cron triggers of a pipeline.
+func (s *CronStore) List(
+	ctx context.Context,
+	pipelineID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Cron, error) {
+	stmt := database.Builder.
+		Select(cronColumns).
+		From("crons").
+		Where("cron_pipeline_id = ?", pipelineID).
+		OrderBy("cron_uid").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(cron_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*cron, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list crons")
+	}
+
+	return mapCrons(dst), nil
+}
+
+// Count returns the number of cron triggers of a pipeline.
+func (s *CronStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("crons").
+		Where("cron_pipeline_id = ?", pipelineID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(cron_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count crons")
+	}
+
+	return count, nil
+}
+
+// ListDue lists the enabled cron triggers with a scheduled run at or before the provided time,
+// the most overdue first.
+func (s *CronStore) ListDue(ctx context.Context, before int64, limit int) ([]*types.Cron, error) {
+	stmt := database.Builder.
+		Select(cronColumns).
+		From("crons").
+		Where("cron_disabled = FALSE").
+		Where("cron_next > 0").
+		Where("cron_next <= ?", before).
+		OrderBy("cron_next", "cron_id").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*cron, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list due crons")
+	}
+
+	return mapCrons(dst), nil
+}
+
+func mapCron(in *cron) *types.Cron {
+	return &types.Cron{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		PipelineID:  in.PipelineID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Branch:      in.Branch,
+		Expression:  in.Expression,
+		Timezone:    in.Timezone,
+		Disabled:    in.Disabled,
+		Next:        in.Next,
+		Prev:        in.Prev,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}
+}
+
+func mapCrons(in []*cron) []*types.Cron {
+	out := make([]*types.Cron, len(in))
+	for i, c := range in {
+		out[i] = mapCron(c)
+	}
+	return out
+}
+
+func mapInternalCron(in *types.Cron) *cron {
+	return &cron{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		PipelineID:  in.PipelineID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Branch:      in.Branch,
+		Expression:  in.Expression,
+		Timezone:    in.Timezone,
+		Disabled:    in.Disabled,
+		Next:        in.Next,
+		Prev:        in.Prev,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}
+}
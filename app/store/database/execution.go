@@ -73,6 +73,7 @@ type execution struct {
 	AuthorAvatar string             `db:"execution_author_avatar"`
 	Sender       string             `db:"execution_sender"`
 	Params       sqlxtypes.JSONText `db:"execution_params"`
+	Inputs       sqlxtypes.JSONText `db:"execution_inputs"`
 	Cron         string             `db:"execution_cron"`
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
@@ -113,6 +114,7 @@ const (
 		,execution_author_avatar
 		,execution_sender
 		,execution_params
+		,execution_inputs
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
@@ -190,6 +192,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_author_avatar
 		,execution_sender
 		,execution_params
+		,execution_inputs
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
@@ -226,6 +229,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_author_avatar
 		,:execution_sender
 		,:execution_params
+		,:execution_inputs
 		,:execution_cron
 		,:execution_deploy
 		,:execution_deploy_id
@@ -359,6 +363,28 @@ func (s *executionStore) Count(ctx context.Context, pipelineID int64) (int64, er
 	return count, nil
 }
 
+// SumDuration returns the total duration (in milliseconds) of the executions started since the provided
+// time in the repos of the space and all its sub-spaces. Running executions are counted up to now.
+func (s *executionStore) SumDuration(ctx context.Context, spaceID int64, since, now int64) (int64, error) {
+	query := spaceDescendantsQuery + `
+		SELECT COALESCE(SUM(
+			CASE WHEN execution_finished > 0 THEN execution_finished ELSE $3 END - execution_started
+		), 0)
+		FROM executions
+		JOIN repositories ON repo_id = execution_repo_id
+		WHERE repo_parent_id IN (SELECT space_descendant_id FROM space_descendants)
+			AND execution_started >= $2
+			AND execution_started > 0`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var duration int64
+	if err := db.GetContext(ctx, &duration, query, spaceID, since, now); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to sum execution durations")
+	}
+	return duration, nil
+}
+
 // Delete deletes an execution given a pipeline ID and an execution number.
 func (s *executionStore) Delete(ctx context.Context, pipelineID int64, executionNum int64) error {
 	const executionDeleteStmt = `
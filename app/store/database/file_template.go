@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.FileTemplateStore = (*FileTemplateStore)(nil)
+
+// NewFileTemplateStore returns a new FileTemplateStore.
+func NewFileTemplateStore(db *sqlx.DB) *FileTemplateStore {
+	return &FileTemplateStore{
+		db: db,
+	}
+}
+
+// FileTemplateStore implements store.FileTemplateStore backed by a relational database.
+type FileTemplateStore struct {
+	db *sqlx.DB
+}
+
+type fileTemplate struct {
+	ID         int64                 `db:"file_template_id"`
+	SpaceID    null.Int              `db:"file_template_space_id"`
+	Type       enum.FileTemplateType `db:"file_template_type"`
+	Identifier string                `db:"file_template_identifier"`
+	Label      string                `db:"file_template_label"`
+	Content    string                `db:"file_template_content"`
+	CreatedBy  int64                 `db:"file_template_created_by"`
+	Created    int64                 `db:"file_template_created"`
+	Updated    int64                 `db:"file_template_updated"`
+}
+
+const (
+	fileTemplateColumnsNoContent = `
+		 file_template_id
+		,file_template_space_id
+		,file_template_type
+		,file_template_identifier
+		,file_template_label
+		,file_template_created_by
+		,file_template_created
+		,file_template_updated`
+
+	fileTemplateColumns = fileTemplateColumnsNoContent + `
+		,file_template_content`
+)
+
+// Find finds the template of a space by its type and identifier. Space ID 0 finds a system template.
+func (s *FileTemplateStore) Find(
+	ctx context.Context,
+	spaceID int64,
+	templateType enum.FileTemplateType,
+	identifier string,
+) (*types.FileTemplate, error) {
+	stmt := database.Builder.
+		Select(fileTemplateColumns).
+		From("file_templates").
+		Where("COALESCE(file_template_space_id, 0) = ?", spaceID).
+		Where("file_template_type = ?", templateType).
+		Where("LOWER(file_template_identifier) = LOWER(?)", identifier)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &fileTemplate{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find file template")
+	}
+
+	return mapFileTemplate(dst), nil
+}
+
+// Create stores a new template.
+func (s *FileTemplateStore) Create(ctx context.Context, template *types.FileTemplate) error {
+	const sqlQuery = `
+	INSERT INTO file_templates (
+		 file_template_space_id
+		,file_template_type
+		,file_template_identifier
+		,file_template_label
+		,file_template_content
+		,file_template_created_by
+		,file_template_created
+		,file_template_updated
+	) values (
+		 :file_template_space_id
+		,:file_template_type
+		,:file_template_identifier
+		,:file_template_label
+		,:file_template_content
+		,:file_template_created_by
+		,:file_template_created
+		,:file_template_updated
+	) RETURNING file_template_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalFileTemplate(template))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind file template object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&template.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes a template.
+func (s *FileTemplateStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM file_templates
+	WHERE file_template_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete file template")
+	}
+
+	return nil
+}
+
+// List returns the templates of the type defined in any of the spaces, without their content.
+// Space ID 0 stands for the system templates.
+func (s *FileTemplateStore) List(
+	ctx context.Context,
+	templateType enum.FileTemplateType,
+	spaceIDs []int64,
+) ([]*types.FileTemplate, error) {
+	if len(spaceIDs) == 0 {
+		return []*types.FileTemplate{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(fileTemplateColumnsNoContent).
+		From("file_templates").
+		Where("file_template_type = ?", templateType).
+		Where(squirrel.Eq{"COALESCE(file_template_space_id, 0)": spaceIDs}).
+		OrderBy("file_template_identifier")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*fileTemplate, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list file templates query")
+	}
+
+	result := make([]*types.FileTemplate, len(dst))
+	for i, t := range dst {
+		result[i] = mapFileTemplate(t)
+	}
+
+	return result, nil
+}
+
+func mapInternalFileTemplate(in *types.FileTemplate) *fileTemplate {
+	return &fileTemplate{
+		ID:         in.ID,
+		SpaceID:    null.NewInt(in.SpaceID, in.SpaceID != 0),
+		Type:       in.Type,
+		Identifier: in.Identifier,
+		Label:      in.Label,
+		Content:    in.Content,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
+
+func mapFileTemplate(in *fileTemplate) *types.FileTemplate {
+	return &types.FileTemplate{
+		ID:         in.ID,
+		SpaceID:    in.SpaceID.Int64,
+		Type:       in.Type,
+		Identifier: in.Identifier,
+		Label:      in.Label,
+		Content:    in.Content,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
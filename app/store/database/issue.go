@@ -0,0 +1,401 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.IssueStore = (*IssueStore)(nil)
+
+// NewIssueStore returns a new IssueStore.
+func NewIssueStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *IssueStore {
+	return &IssueStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueStore implements store.IssueStore backed by a relational database.
+type IssueStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type issue struct {
+	ID      int64 `db:"issue_id"`
+	Version int64 `db:"issue_version"`
+	RepoID  int64 `db:"issue_repo_id"`
+	Number  int64 `db:"issue_number"`
+
+	CreatedBy int64    `db:"issue_created_by"`
+	Created   int64    `db:"issue_created"`
+	Updated   int64    `db:"issue_updated"`
+	Closed    null.Int `db:"issue_closed"`
+
+	ClosedByPullReq null.Int `db:"issue_closed_by_pullreq"`
+
+	State enum.IssueState `db:"issue_state"`
+
+	Title       string `db:"issue_title"`
+	Description string `db:"issue_description"`
+
+	CommentCount int `db:"issue_comment_count"`
+
+	MilestoneID null.Int `db:"issue_milestone_id"`
+}
+
+const (
+	issueColumns = `
+		 issue_id
+		,issue_version
+		,issue_repo_id
+		,issue_number
+		,issue_created_by
+		,issue_created
+		,issue_updated
+		,issue_closed
+		,issue_closed_by_pullreq
+		,issue_state
+		,issue_title
+		,issue_description
+		,issue_comment_count
+		,issue_milestone_id`
+
+	issueSelectBase = `
+	SELECT` + issueColumns + `
+	FROM issues`
+)
+
+// Find finds the issue by id.
+func (s *IssueStore) Find(ctx context.Context, id int64) (*types.Issue, error) {
+	const sqlQuery = issueSelectBase + `
+	WHERE issue_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issue{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue")
+	}
+
+	return s.mapIssue(ctx, dst), nil
+}
+
+// FindByNumber finds the issue by repo ID and the issue number.
+func (s *IssueStore) FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error) {
+	const sqlQuery = issueSelectBase + `
+	WHERE issue_repo_id = $1 AND issue_number = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issue{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, number); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue by number")
+	}
+
+	return s.mapIssue(ctx, dst), nil
+}
+
+// Create creates a new issue.
+func (s *IssueStore) Create(ctx context.Context, in *types.Issue) error {
+	const sqlQuery = `
+	INSERT INTO issues (
+		 issue_version
+		,issue_repo_id
+		,issue_number
+		,issue_created_by
+		,issue_created
+		,issue_updated
+		,issue_closed
+		,issue_closed_by_pullreq
+		,issue_state
+		,issue_title
+		,issue_description
+		,issue_comment_count
+		,issue_milestone_id
+	) values (
+		 :issue_version
+		,:issue_repo_id
+		,:issue_number
+		,:issue_created_by
+		,:issue_created
+		,:issue_updated
+		,:issue_closed
+		,:issue_closed_by_pullreq
+		,:issue_state
+		,:issue_title
+		,:issue_description
+		,:issue_comment_count
+		,:issue_milestone_id
+	) RETURNING issue_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalIssue(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the issue.
+func (s *IssueStore) Update(ctx context.Context, in *types.Issue) error {
+	const sqlQuery = `
+	UPDATE issues
+	SET
+		 issue_version = :issue_version
+		,issue_updated = :issue_updated
+		,issue_closed = :issue_closed
+		,issue_closed_by_pullreq = :issue_closed_by_pullreq
+		,issue_state = :issue_state
+		,issue_title = :issue_title
+		,issue_description = :issue_description
+		,issue_comment_count = :issue_comment_count
+		,issue_milestone_id = :issue_milestone_id
+	WHERE issue_id = :issue_id AND issue_version = :issue_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIssue := mapInternalIssue(in)
+	dbIssue.Version++
+	dbIssue.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbIssue)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update issue")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	*in = *s.mapIssue(ctx, dbIssue)
+
+	return nil
+}
+
+// UpdateOptLock the issue details using the optimistic locking mechanism.
+func (s *IssueStore) UpdateOptLock(ctx context.Context, in *types.Issue,
+	mutateFn func(issue *types.Issue) error,
+) (*types.Issue, error) {
+	for {
+		dup := *in
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		in, err = s.Find(ctx, in.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Count of issues in a repository.
+func (s *IssueStore) Count(ctx context.Context, repoID int64, opts *types.IssueFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("issues")
+
+	stmt = applyIssueFilter(stmt, repoID, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of issues in a repository.
+func (s *IssueStore) List(ctx context.Context, repoID int64, opts *types.IssueFilter) ([]*types.Issue, error) {
+	stmt := database.Builder.
+		Select(issueColumns).
+		From("issues")
+
+	stmt = applyIssueFilter(stmt, repoID, opts)
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy("issue_number " + opts.Order.String())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*issue, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return s.mapSliceIssue(ctx, dst)
+}
+
+func applyIssueFilter(
+	stmt squirrel.SelectBuilder,
+	repoID int64,
+	opts *types.IssueFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.Where("issue_repo_id = ?", repoID)
+
+	if len(opts.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"issue_state": opts.States})
+	}
+
+	if len(opts.CreatedBy) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"issue_created_by": opts.CreatedBy})
+	}
+
+	if opts.Query != "" {
+		stmt = stmt.Where("LOWER(issue_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+	}
+
+	if opts.Assignee > 0 {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM issue_assignees
+			WHERE issue_assignee_issue_id = issue_id AND issue_assignee_principal_id = ?)`, opts.Assignee)
+	}
+
+	if opts.MilestoneID > 0 {
+		stmt = stmt.Where("issue_milestone_id = ?", opts.MilestoneID)
+	}
+
+	return stmt
+}
+
+func mapIssue(in *issue) *types.Issue {
+	return &types.Issue{
+		ID:              in.ID,
+		Version:         in.Version,
+		RepoID:          in.RepoID,
+		Number:          in.Number,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Updated:         in.Updated,
+		Closed:          in.Closed.Ptr(),
+		ClosedByPullReq: in.ClosedByPullReq.Ptr(),
+		State:           in.State,
+		Title:           in.Title,
+		Description:     in.Description,
+		CommentCount:    in.CommentCount,
+		MilestoneID:     in.MilestoneID.Ptr(),
+	}
+}
+
+func mapInternalIssue(in *types.Issue) *issue {
+	return &issue{
+		ID:              in.ID,
+		Version:         in.Version,
+		RepoID:          in.RepoID,
+		Number:          in.Number,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Updated:         in.Updated,
+		Closed:          null.IntFromPtr(in.Closed),
+		ClosedByPullReq: null.IntFromPtr(in.ClosedByPullReq),
+		State:           in.State,
+		Title:           in.Title,
+		Description:     in.Description,
+		CommentCount:    in.CommentCount,
+		MilestoneID:     null.IntFromPtr(in.MilestoneID),
+	}
+}
+
+func (s *IssueStore) mapIssue(ctx context.Context, in *issue) *types.Issue {
+	m := mapIssue(in)
+
+	author, err := s.pCache.Get(ctx, in.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load issue author")
+	}
+	if author != nil {
+		m.Author = *author
+	}
+
+	return m
+}
+
+func (s *IssueStore) mapSliceIssue(ctx context.Context, issues []*issue) ([]*types.Issue, error) {
+	ids := make([]int64, len(issues))
+	for i, in := range issues {
+		ids[i] = in.CreatedBy
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue principal infos: %w", err)
+	}
+
+	m := make([]*types.Issue, len(issues))
+	for i, in := range issues {
+		m[i] = mapIssue(in)
+		if author, ok := infoMap[in.CreatedBy]; ok {
+			m[i].Author = *author
+		}
+	}
+
+	return m, nil
+}
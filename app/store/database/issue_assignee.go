@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IssueAssigneeStore = (*IssueAssigneeStore)(nil)
+
+// NewIssueAssigneeStore returns a new IssueAssigneeStore.
+func NewIssueAssigneeStore(db *sqlx.DB) *IssueAssigneeStore {
+	return &IssueAssigneeStore{
+		db: db,
+	}
+}
+
+// IssueAssigneeStore implements store.IssueAssigneeStore backed by a relational database.
+type IssueAssigneeStore struct {
+	db *sqlx.DB
+}
+
+// Assign assigns a principal to an issue. Assigning an already assigned principal is a no-op.
+func (s *IssueAssigneeStore) Assign(ctx context.Context, issueID, principalID, createdBy, created int64) error {
+	const sqlQuery = `
+	INSERT INTO issue_assignees (
+		 issue_assignee_issue_id
+		,issue_assignee_principal_id
+		,issue_assignee_created_by
+		,issue_assignee_created
+	) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (issue_assignee_issue_id, issue_assignee_principal_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, issueID, principalID, createdBy, created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to assign principal to issue")
+	}
+
+	return nil
+}
+
+// Unassign removes a principal from the assignees of an issue.
+func (s *IssueAssigneeStore) Unassign(ctx context.Context, issueID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM issue_assignees
+	WHERE issue_assignee_issue_id = $1 AND issue_assignee_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, issueID, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to unassign principal from issue")
+	}
+
+	return nil
+}
+
+// ListIDs returns IDs of the principals assigned to an issue.
+func (s *IssueAssigneeStore) ListIDs(ctx context.Context, issueID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT issue_assignee_principal_id
+	FROM issue_assignees
+	WHERE issue_assignee_issue_id = $1
+	ORDER BY issue_assignee_created, issue_assignee_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	ids := make([]int64, 0)
+	if err := db.SelectContext(ctx, &ids, sqlQuery, issueID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list issue assignees")
+	}
+
+	return ids, nil
+}
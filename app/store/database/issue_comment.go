@@ -0,0 +1,248 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IssueCommentStore = (*IssueCommentStore)(nil)
+
+// NewIssueCommentStore returns a new IssueCommentStore.
+func NewIssueCommentStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *IssueCommentStore {
+	return &IssueCommentStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueCommentStore implements store.IssueCommentStore backed by a relational database.
+type IssueCommentStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type issueComment struct {
+	ID      int64 `db:"issue_comment_id"`
+	Version int64 `db:"issue_comment_version"`
+	IssueID int64 `db:"issue_comment_issue_id"`
+
+	CreatedBy int64    `db:"issue_comment_created_by"`
+	Created   int64    `db:"issue_comment_created"`
+	Updated   int64    `db:"issue_comment_updated"`
+	Deleted   null.Int `db:"issue_comment_deleted"`
+
+	Text string `db:"issue_comment_text"`
+}
+
+const (
+	issueCommentColumns = `
+		 issue_comment_id
+		,issue_comment_version
+		,issue_comment_issue_id
+		,issue_comment_created_by
+		,issue_comment_created
+		,issue_comment_updated
+		,issue_comment_deleted
+		,issue_comment_text`
+
+	issueCommentSelectBase = `
+	SELECT` + issueCommentColumns + `
+	FROM issue_comments`
+)
+
+// Find finds the issue comment by id.
+func (s *IssueCommentStore) Find(ctx context.Context, id int64) (*types.IssueComment, error) {
+	const sqlQuery = issueCommentSelectBase + `
+	WHERE issue_comment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issueComment{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue comment")
+	}
+
+	m := mapIssueComment(dst)
+
+	author, err := s.pCache.Get(ctx, dst.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue comment author: %w", err)
+	}
+	m.Author = *author
+
+	return m, nil
+}
+
+// Create creates a new issue comment.
+func (s *IssueCommentStore) Create(ctx context.Context, comment *types.IssueComment) error {
+	const sqlQuery = `
+	INSERT INTO issue_comments (
+		 issue_comment_version
+		,issue_comment_issue_id
+		,issue_comment_created_by
+		,issue_comment_created
+		,issue_comment_updated
+		,issue_comment_deleted
+		,issue_comment_text
+	) values (
+		 :issue_comment_version
+		,:issue_comment_issue_id
+		,:issue_comment_created_by
+		,:issue_comment_created
+		,:issue_comment_updated
+		,:issue_comment_deleted
+		,:issue_comment_text
+	) RETURNING issue_comment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalIssueComment(comment))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue comment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&comment.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the issue comment.
+func (s *IssueCommentStore) Update(ctx context.Context, comment *types.IssueComment) error {
+	const sqlQuery = `
+	UPDATE issue_comments
+	SET
+		 issue_comment_version = :issue_comment_version
+		,issue_comment_updated = :issue_comment_updated
+		,issue_comment_deleted = :issue_comment_deleted
+		,issue_comment_text = :issue_comment_text
+	WHERE issue_comment_id = :issue_comment_id AND issue_comment_version = :issue_comment_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbComment := mapInternalIssueComment(comment)
+	dbComment.Version++
+	dbComment.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbComment)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue comment object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update issue comment")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	comment.Version = dbComment.Version
+	comment.Updated = dbComment.Updated
+
+	return nil
+}
+
+// List returns the comments of an issue, in the order they were created.
+func (s *IssueCommentStore) List(
+	ctx context.Context,
+	issueID int64,
+	page, size int,
+) ([]*types.IssueComment, error) {
+	stmt := database.Builder.
+		Select(issueCommentColumns).
+		From("issue_comments").
+		Where("issue_comment_issue_id = ?", issueID).
+		OrderBy("issue_comment_created ASC", "issue_comment_id ASC").
+		Limit(database.Limit(size)).
+		Offset(database.Offset(page, size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*issueComment, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing issue comment list query")
+	}
+
+	ids := make([]int64, len(dst))
+	for i, c := range dst {
+		ids[i] = c.CreatedBy
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue comment principal infos: %w", err)
+	}
+
+	comments := make([]*types.IssueComment, len(dst))
+	for i, c := range dst {
+		comments[i] = mapIssueComment(c)
+		if author, ok := infoMap[c.CreatedBy]; ok {
+			comments[i].Author = *author
+		}
+	}
+
+	return comments, nil
+}
+
+func mapIssueComment(c *issueComment) *types.IssueComment {
+	return &types.IssueComment{
+		ID:        c.ID,
+		Version:   c.Version,
+		IssueID:   c.IssueID,
+		CreatedBy: c.CreatedBy,
+		Created:   c.Created,
+		Updated:   c.Updated,
+		Deleted:   c.Deleted.Ptr(),
+		Text:      c.Text,
+	}
+}
+
+func mapInternalIssueComment(c *types.IssueComment) *issueComment {
+	return &issueComment{
+		ID:        c.ID,
+		Version:   c.Version,
+		IssueID:   c.IssueID,
+		CreatedBy: c.CreatedBy,
+		Created:   c.Created,
+		Updated:   c.Updated,
+		Deleted:   null.IntFromPtr(c.Deleted),
+		Text:      c.Text,
+	}
+}
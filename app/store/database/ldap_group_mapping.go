@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LDAPGroupMappingStore = (*LDAPGroupMappingStore)(nil)
+
+// NewLDAPGroupMappingStore returns a new LDAPGroupMappingStore.
+func NewLDAPGroupMappingStore(db *sqlx.DB) *LDAPGroupMappingStore {
+	return &LDAPGroupMappingStore{
+		db: db,
+	}
+}
+
+// LDAPGroupMappingStore implements store.LDAPGroupMappingStore backed by a relational database.
+type LDAPGroupMappingStore struct {
+	db *sqlx.DB
+}
+
+type ldapGroupMapping struct {
+	ID        int64               `db:"ldap_group_mapping_id"`
+	GroupDN   string              `db:"ldap_group_mapping_group_dn"`
+	SpaceID   int64               `db:"ldap_group_mapping_space_id"`
+	Role      enum.MembershipRole `db:"ldap_group_mapping_role"`
+	CreatedBy int64               `db:"ldap_group_mapping_created_by"`
+	Created   int64               `db:"ldap_group_mapping_created"`
+	Updated   int64               `db:"ldap_group_mapping_updated"`
+}
+
+const (
+	ldapGroupMappingColumns = `
+		 ldap_group_mapping_id
+		,ldap_group_mapping_group_dn
+		,ldap_group_mapping_space_id
+		,ldap_group_mapping_role
+		,ldap_group_mapping_created_by
+		,ldap_group_mapping_created
+		,ldap_group_mapping_updated`
+
+	ldapGroupMappingSelectBase = `
+		SELECT` + ldapGroupMappingColumns + `
+		FROM ldap_group_mappings`
+)
+
+// Find finds the group mapping by id.
+func (s *LDAPGroupMappingStore) Find(ctx context.Context, id int64) (*types.LDAPGroupMapping, error) {
+	const sqlQuery = ldapGroupMappingSelectBase + `
+		WHERE ldap_group_mapping_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &ldapGroupMapping{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find ldap group mapping")
+	}
+
+	return mapLDAPGroupMapping(dst), nil
+}
+
+// List returns all group mappings.
+func (s *LDAPGroupMappingStore) List(ctx context.Context) ([]*types.LDAPGroupMapping, error) {
+	const sqlQuery = ldapGroupMappingSelectBase + `
+		ORDER BY ldap_group_mapping_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*ldapGroupMapping, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list ldap group mappings")
+	}
+
+	out := make([]*types.LDAPGroupMapping, len(dst))
+	for i, d := range dst {
+		out[i] = mapLDAPGroupMapping(d)
+	}
+
+	return out, nil
+}
+
+// Create stores a new group mapping.
+func (s *LDAPGroupMappingStore) Create(ctx context.Context, mapping *types.LDAPGroupMapping) error {
+	const sqlQuery = `
+		INSERT INTO ldap_group_mappings (
+			 ldap_group_mapping_group_dn
+			,ldap_group_mapping_space_id
+			,ldap_group_mapping_role
+			,ldap_group_mapping_created_by
+			,ldap_group_mapping_created
+			,ldap_group_mapping_updated
+		) values (
+			 :ldap_group_mapping_group_dn
+			,:ldap_group_mapping_space_id
+			,:ldap_group_mapping_role
+			,:ldap_group_mapping_created_by
+			,:ldap_group_mapping_created
+			,:ldap_group_mapping_updated
+		) RETURNING ldap_group_mapping_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalLDAPGroupMapping(mapping))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind ldap group mapping object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mapping.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert ldap group mapping query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes a group mapping.
+func (s *LDAPGroupMappingStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM ldap_group_mappings
+		WHERE ldap_group_mapping_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete ldap group mapping")
+	}
+
+	return nil
+}
+
+func mapLDAPGroupMapping(in *ldapGroupMapping) *types.LDAPGroupMapping {
+	return &types.LDAPGroupMapping{
+		ID:        in.ID,
+		GroupDN:   in.GroupDN,
+		SpaceID:   in.SpaceID,
+		Role:      in.Role,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}
+}
+
+func mapInternalLDAPGroupMapping(in *types.LDAPGroupMapping) *ldapGroupMapping {
+	return &ldapGroupMapping{
+		ID:        in.ID,
+		GroupDN:   in.GroupDN,
+		SpaceID:   in.SpaceID,
+		Role:      in.Role,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}
+}
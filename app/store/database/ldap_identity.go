@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LDAPIdentityStore = (*LDAPIdentityStore)(nil)
+
+// NewLDAPIdentityStore returns a new LDAPIdentityStore.
+func NewLDAPIdentityStore(db *sqlx.DB) *LDAPIdentityStore {
+	return &LDAPIdentityStore{
+		db: db,
+	}
+}
+
+// LDAPIdentityStore implements store.LDAPIdentityStore backed by a relational database.
+type LDAPIdentityStore struct {
+	db *sqlx.DB
+}
+
+type ldapIdentity struct {
+	ID          int64  `db:"ldap_identity_id"`
+	PrincipalID int64  `db:"ldap_identity_principal_id"`
+	Login       string `db:"ldap_identity_login"`
+	DN          string `db:"ldap_identity_dn"`
+	Deactivated bool   `db:"ldap_identity_deactivated"`
+	Created     int64  `db:"ldap_identity_created"`
+	Updated     int64  `db:"ldap_identity_updated"`
+}
+
+const (
+	ldapIdentityColumns = `
+		 ldap_identity_id
+		,ldap_identity_principal_id
+		,ldap_identity_login
+		,ldap_identity_dn
+		,ldap_identity_deactivated
+		,ldap_identity_created
+		,ldap_identity_updated`
+
+	ldapIdentitySelectBase = `
+		SELECT` + ldapIdentityColumns + `
+		FROM ldap_identities`
+)
+
+// FindByPrincipalID finds the identity linked to a principal.
+func (s *LDAPIdentityStore) FindByPrincipalID(ctx context.Context, principalID int64) (*types.LDAPIdentity, error) {
+	const sqlQuery = ldapIdentitySelectBase + `
+		WHERE ldap_identity_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &ldapIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find ldap identity by principal id")
+	}
+
+	return mapLDAPIdentity(dst), nil
+}
+
+// FindByLogin finds the identity with the login (case insensitive).
+func (s *LDAPIdentityStore) FindByLogin(ctx context.Context, login string) (*types.LDAPIdentity, error) {
+	const sqlQuery = ldapIdentitySelectBase + `
+		WHERE LOWER(ldap_identity_login) = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &ldapIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(login)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find ldap identity by login")
+	}
+
+	return mapLDAPIdentity(dst), nil
+}
+
+// List returns all identities.
+func (s *LDAPIdentityStore) List(ctx context.Context) ([]*types.LDAPIdentity, error) {
+	const sqlQuery = ldapIdentitySelectBase + `
+		ORDER BY ldap_identity_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*ldapIdentity, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list ldap identities")
+	}
+
+	out := make([]*types.LDAPIdentity, len(dst))
+	for i, d := range dst {
+		out[i] = mapLDAPIdentity(d)
+	}
+
+	return out, nil
+}
+
+// Create stores a new identity.
+func (s *LDAPIdentityStore) Create(ctx context.Context, identity *types.LDAPIdentity) error {
+	const sqlQuery = `
+		INSERT INTO ldap_identities (
+			 ldap_identity_principal_id
+			,ldap_identity_login
+			,ldap_identity_dn
+			,ldap_identity_deactivated
+			,ldap_identity_created
+			,ldap_identity_updated
+		) values (
+			 :ldap_identity_principal_id
+			,:ldap_identity_login
+			,:ldap_identity_dn
+			,:ldap_identity_deactivated
+			,:ldap_identity_created
+			,:ldap_identity_updated
+		) RETURNING ldap_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalLDAPIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind ldap identity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert ldap identity query failed")
+	}
+
+	return nil
+}
+
+// Update updates the login, DN and the deactivation flag of an identity.
+func (s *LDAPIdentityStore) Update(ctx context.Context, identity *types.LDAPIdentity) error {
+	const sqlQuery = `
+		UPDATE ldap_identities
+		SET
+			 ldap_identity_login = :ldap_identity_login
+			,ldap_identity_dn = :ldap_identity_dn
+			,ldap_identity_deactivated = :ldap_identity_deactivated
+			,ldap_identity_updated = :ldap_identity_updated
+		WHERE ldap_identity_id = :ldap_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalLDAPIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind ldap identity object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update ldap identity")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapLDAPIdentity(in *ldapIdentity) *types.LDAPIdentity {
+	return &types.LDAPIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Login:       in.Login,
+		DN:          in.DN,
+		Deactivated: in.Deactivated,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapInternalLDAPIdentity(in *types.LDAPIdentity) *ldapIdentity {
+	return &ldapIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Login:       in.Login,
+		DN:          in.DN,
+		Deactivated: in.Deactivated,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
@@ -199,6 +199,23 @@ func (s *MembershipStore) Delete(ctx context.Context, key types.MembershipKey) e
 	return nil
 }
 
+// CountByRole returns the number of space memberships with the role.
+func (s *MembershipStore) CountByRole(ctx context.Context, role enum.MembershipRole) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM memberships
+	WHERE membership_role = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, role).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing membership count query")
+	}
+
+	return count, nil
+}
+
 // CountUsers returns a number of users memberships that matches the provided filter.
 func (s *MembershipStore) CountUsers(ctx context.Context,
 	spaceID int64,
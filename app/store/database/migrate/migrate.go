@@ -60,6 +60,36 @@ func To(ctx context.Context, db *sqlx.DB, version string) error {
 	return migrate.New(opts).MigrateTo(ctx, version)
 }
 
+// Force overwrites the current version of the database with the provided version without running any migrations.
+// It's meant to recover a database that got stuck on a failed migration, after it was fixed manually.
+func Force(ctx context.Context, db *sqlx.DB, version string) error {
+	opts, err := getMigrator(db)
+	if err != nil {
+		return fmt.Errorf("failed to get migrator: %w", err)
+	}
+
+	if _, err = fs.Stat(opts.FS, version+".up.sql"); err != nil {
+		return fmt.Errorf("unknown migration version '%s': %w", version, err)
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return fmt.Errorf("migration table doesn't exist yet")
+	}
+
+	query := "update " + tableName + " set version = $1"
+	if _, err = db.ExecContext(ctx, query, version); err != nil {
+		return fmt.Errorf("failed to update version in migration table: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("forced database version from '%s' to '%s'", current, version)
+
+	return nil
+}
+
 // Current returns the current version ID (the latest migration applied) of the database.
 func Current(ctx context.Context, db *sqlx.DB) (string, error) {
 	var (
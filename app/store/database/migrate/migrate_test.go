@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForce(t *testing.T) {
+	ctx := context.Background()
+
+	dsn := fmt.Sprintf("file:%s.db?mode=memory&cache=shared", xid.New().String())
+	db, err := sqlx.Connect(sqliteDriverName, dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the version can't be forced before the database got migrated for the first time.
+	err = Force(ctx, db, "0001_create_table_e_tokens")
+	assert.Error(t, err, "force on database without migration table")
+
+	require.NoError(t, To(ctx, db, "0001_create_table_e_tokens"))
+
+	const version = "0002_create_index_principals_lower_email"
+	require.NoError(t, Force(ctx, db, version))
+
+	current, err := Current(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, version, current)
+
+	// no migrations must have been executed, only the version got overwritten.
+	var indexCount int
+	err = db.QueryRowContext(ctx,
+		`SELECT count(*) FROM sqlite_master WHERE type = 'index' AND name = 'principals_lower_email'`,
+	).Scan(&indexCount)
+	require.NoError(t, err)
+	assert.Equal(t, 0, indexCount)
+
+	err = Force(ctx, db, "9999_unknown_migration")
+	assert.Error(t, err, "force to unknown version")
+
+	current, err = Current(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, version, current)
+}
@@ -0,0 +1,18 @@
+CREATE TABLE repo_traffic (
+ repo_traffic_repo_id INTEGER NOT NULL
+,repo_traffic_day BIGINT NOT NULL
+,repo_traffic_principal_id INTEGER NOT NULL
+,repo_traffic_transport TEXT NOT NULL
+,repo_traffic_operation TEXT NOT NULL
+,repo_traffic_shallow BOOLEAN NOT NULL
+,repo_traffic_count INTEGER NOT NULL
+
+-- one counter per repo, day, caller and kind of operation (existing counters are incremented)
+,CONSTRAINT pk_repo_traffic PRIMARY KEY (repo_traffic_repo_id, repo_traffic_day, repo_traffic_principal_id,
+    repo_traffic_transport, repo_traffic_operation, repo_traffic_shallow)
+
+,CONSTRAINT fk_repo_traffic_repo_id FOREIGN KEY (repo_traffic_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE webhooks
+    DROP COLUMN webhook_previous_secret_expires_at,
+    DROP COLUMN webhook_previous_secret;
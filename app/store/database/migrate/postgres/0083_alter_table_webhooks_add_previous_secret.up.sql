@@ -0,0 +1,3 @@
+ALTER TABLE webhooks
+    ADD COLUMN webhook_previous_secret TEXT NOT NULL DEFAULT '',
+    ADD COLUMN webhook_previous_secret_expires_at BIGINT NOT NULL DEFAULT 0;
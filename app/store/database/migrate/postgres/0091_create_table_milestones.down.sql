@@ -0,0 +1,11 @@
+DROP INDEX issues_milestone_id;
+ALTER TABLE issues
+    DROP CONSTRAINT fk_issue_milestone_id,
+    DROP COLUMN issue_milestone_id;
+
+DROP INDEX pullreqs_milestone_id;
+ALTER TABLE pullreqs
+    DROP CONSTRAINT fk_pullreq_milestone_id,
+    DROP COLUMN pullreq_milestone_id;
+
+DROP TABLE milestones;
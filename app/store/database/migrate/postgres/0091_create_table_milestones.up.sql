@@ -0,0 +1,48 @@
+CREATE TABLE milestones (
+    milestone_id SERIAL PRIMARY KEY,
+    milestone_version INTEGER NOT NULL,
+    milestone_repo_id INTEGER NOT NULL,
+    milestone_title TEXT NOT NULL,
+    milestone_description TEXT NOT NULL,
+    milestone_due_date BIGINT,
+    milestone_state TEXT NOT NULL,
+    milestone_closed BIGINT,
+    milestone_created_by INTEGER NOT NULL,
+    milestone_created BIGINT NOT NULL,
+    milestone_updated BIGINT NOT NULL,
+    CONSTRAINT fk_milestone_repo_id FOREIGN KEY (milestone_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_milestone_created_by FOREIGN KEY (milestone_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX milestones_repo_id_lower_title
+    ON milestones(milestone_repo_id, LOWER(milestone_title));
+
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_milestone_id INTEGER,
+    ADD CONSTRAINT fk_pullreq_milestone_id
+        FOREIGN KEY (pullreq_milestone_id)
+        REFERENCES milestones(milestone_id)
+        ON DELETE SET NULL
+        ON UPDATE NO ACTION;
+
+CREATE INDEX pullreqs_milestone_id
+    ON pullreqs(pullreq_milestone_id)
+    WHERE pullreq_milestone_id IS NOT NULL;
+
+ALTER TABLE issues
+    ADD COLUMN issue_milestone_id INTEGER,
+    ADD CONSTRAINT fk_issue_milestone_id
+        FOREIGN KEY (issue_milestone_id)
+        REFERENCES milestones(milestone_id)
+        ON DELETE SET NULL
+        ON UPDATE NO ACTION;
+
+CREATE INDEX issues_milestone_id
+    ON issues(issue_milestone_id)
+    WHERE issue_milestone_id IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE pullreq_auto_merges;
@@ -0,0 +1,3 @@
+DROP TABLE release_assets;
+
+DROP TABLE releases;
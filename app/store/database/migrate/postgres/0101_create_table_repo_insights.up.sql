@@ -0,0 +1,47 @@
+CREATE TABLE repo_insights (
+    repo_insight_repo_id INTEGER PRIMARY KEY,
+    repo_insight_stale BOOLEAN NOT NULL,
+    repo_insight_branch TEXT NOT NULL,
+    repo_insight_commit_sha TEXT NOT NULL,
+    repo_insight_created BIGINT NOT NULL,
+    repo_insight_updated BIGINT NOT NULL,
+    repo_insight_processed BIGINT NOT NULL,
+    CONSTRAINT fk_repo_insight_repo_id FOREIGN KEY (repo_insight_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX repo_insights_stale
+    ON repo_insights(repo_insight_repo_id)
+    WHERE repo_insight_stale;
+
+CREATE TABLE repo_insight_commits (
+    repo_insight_commit_repo_id INTEGER NOT NULL,
+    repo_insight_commit_week BIGINT NOT NULL,
+    repo_insight_commit_author_email TEXT NOT NULL,
+    repo_insight_commit_author_name TEXT NOT NULL,
+    repo_insight_commit_commits INTEGER NOT NULL,
+    repo_insight_commit_additions BIGINT NOT NULL,
+    repo_insight_commit_deletions BIGINT NOT NULL,
+    CONSTRAINT pk_repo_insight_commits
+        PRIMARY KEY (repo_insight_commit_repo_id, repo_insight_commit_week, repo_insight_commit_author_email),
+    CONSTRAINT fk_repo_insight_commit_repo_id FOREIGN KEY (repo_insight_commit_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE TABLE repo_insight_pullreqs (
+    repo_insight_pullreq_repo_id INTEGER NOT NULL,
+    repo_insight_pullreq_week BIGINT NOT NULL,
+    repo_insight_pullreq_opened INTEGER NOT NULL,
+    repo_insight_pullreq_merged INTEGER NOT NULL,
+    repo_insight_pullreq_closed INTEGER NOT NULL,
+    CONSTRAINT pk_repo_insight_pullreqs
+        PRIMARY KEY (repo_insight_pullreq_repo_id, repo_insight_pullreq_week),
+    CONSTRAINT fk_repo_insight_pullreq_repo_id FOREIGN KEY (repo_insight_pullreq_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
@@ -0,0 +1,22 @@
+CREATE TABLE file_templates (
+    file_template_id SERIAL PRIMARY KEY,
+    file_template_space_id INTEGER,
+    file_template_type TEXT NOT NULL,
+    file_template_identifier TEXT NOT NULL,
+    file_template_label TEXT NOT NULL,
+    file_template_content TEXT NOT NULL,
+    file_template_created_by INTEGER NOT NULL,
+    file_template_created BIGINT NOT NULL,
+    file_template_updated BIGINT NOT NULL,
+    CONSTRAINT fk_file_template_space_id FOREIGN KEY (file_template_space_id)
+        REFERENCES spaces (space_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_file_template_created_by FOREIGN KEY (file_template_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX file_templates_space_id_type_identifier
+    ON file_templates(COALESCE(file_template_space_id, 0), file_template_type, LOWER(file_template_identifier));
@@ -0,0 +1,24 @@
+CREATE TABLE user_emails (
+    user_email_id SERIAL PRIMARY KEY,
+    user_email_principal_id INTEGER NOT NULL,
+    user_email_address TEXT NOT NULL,
+    user_email_verified BIGINT,
+    user_email_verification_token TEXT,
+    user_email_verification_sent BIGINT,
+    user_email_created BIGINT NOT NULL,
+    user_email_updated BIGINT NOT NULL,
+    CONSTRAINT fk_user_email_principal_id FOREIGN KEY (user_email_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_emails_principal_id_address
+    ON user_emails(user_email_principal_id, LOWER(user_email_address));
+
+CREATE UNIQUE INDEX user_emails_verified_address
+    ON user_emails(LOWER(user_email_address))
+    WHERE user_email_verified IS NOT NULL;
+
+CREATE UNIQUE INDEX user_emails_verification_token
+    ON user_emails(user_email_verification_token);
@@ -0,0 +1 @@
+ALTER TABLE principals ADD COLUMN principal_avatar TEXT NOT NULL DEFAULT '';
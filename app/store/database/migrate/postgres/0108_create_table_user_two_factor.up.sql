@@ -0,0 +1,13 @@
+CREATE TABLE user_two_factor (
+    user_two_factor_principal_id INTEGER PRIMARY KEY,
+    user_two_factor_secret BYTEA NOT NULL,
+    user_two_factor_enabled BIGINT,
+    user_two_factor_recovery_codes TEXT NOT NULL DEFAULT '[]',
+    user_two_factor_last_used_step BIGINT NOT NULL DEFAULT 0,
+    user_two_factor_created BIGINT NOT NULL,
+    user_two_factor_updated BIGINT NOT NULL,
+    CONSTRAINT fk_user_two_factor_principal_id FOREIGN KEY (user_two_factor_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
@@ -0,0 +1,39 @@
+CREATE TABLE webauthn_credentials (
+    webauthn_credential_id SERIAL PRIMARY KEY,
+    webauthn_credential_principal_id INTEGER NOT NULL,
+    webauthn_credential_credential_id BYTEA NOT NULL,
+    webauthn_credential_name TEXT NOT NULL,
+    webauthn_credential_public_key BYTEA NOT NULL,
+    webauthn_credential_sign_count BIGINT NOT NULL DEFAULT 0,
+    webauthn_credential_aaguid BYTEA,
+    webauthn_credential_transports TEXT NOT NULL DEFAULT '[]',
+    webauthn_credential_created BIGINT NOT NULL,
+    webauthn_credential_updated BIGINT NOT NULL,
+    webauthn_credential_last_used BIGINT,
+    CONSTRAINT fk_webauthn_credential_principal_id FOREIGN KEY (webauthn_credential_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX webauthn_credentials_credential_id
+    ON webauthn_credentials(webauthn_credential_credential_id);
+
+CREATE INDEX webauthn_credentials_principal_id
+    ON webauthn_credentials(webauthn_credential_principal_id);
+
+CREATE TABLE webauthn_sessions (
+    webauthn_session_id TEXT PRIMARY KEY,
+    webauthn_session_principal_id INTEGER,
+    webauthn_session_type TEXT NOT NULL,
+    webauthn_session_challenge BYTEA NOT NULL,
+    webauthn_session_created BIGINT NOT NULL,
+    webauthn_session_expires BIGINT NOT NULL,
+    CONSTRAINT fk_webauthn_session_principal_id FOREIGN KEY (webauthn_session_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX webauthn_sessions_expires
+    ON webauthn_sessions(webauthn_session_expires);
@@ -0,0 +1,2 @@
+DROP TABLE ldap_group_mappings;
+DROP TABLE ldap_identities;
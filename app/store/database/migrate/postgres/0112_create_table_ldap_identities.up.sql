@@ -0,0 +1,36 @@
+CREATE TABLE ldap_identities (
+    ldap_identity_id SERIAL PRIMARY KEY,
+    ldap_identity_principal_id INTEGER NOT NULL,
+    ldap_identity_login TEXT NOT NULL,
+    ldap_identity_dn TEXT NOT NULL,
+    ldap_identity_deactivated BOOLEAN NOT NULL DEFAULT FALSE,
+    ldap_identity_created BIGINT NOT NULL,
+    ldap_identity_updated BIGINT NOT NULL,
+    CONSTRAINT fk_ldap_identity_principal_id FOREIGN KEY (ldap_identity_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ldap_identities_principal_id
+    ON ldap_identities(ldap_identity_principal_id);
+
+CREATE UNIQUE INDEX ldap_identities_lower_login
+    ON ldap_identities(LOWER(ldap_identity_login));
+
+CREATE TABLE ldap_group_mappings (
+    ldap_group_mapping_id SERIAL PRIMARY KEY,
+    ldap_group_mapping_group_dn TEXT NOT NULL,
+    ldap_group_mapping_space_id INTEGER NOT NULL,
+    ldap_group_mapping_role TEXT NOT NULL,
+    ldap_group_mapping_created_by INTEGER NOT NULL,
+    ldap_group_mapping_created BIGINT NOT NULL,
+    ldap_group_mapping_updated BIGINT NOT NULL,
+    CONSTRAINT fk_ldap_group_mapping_space_id FOREIGN KEY (ldap_group_mapping_space_id)
+        REFERENCES spaces (space_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ldap_group_mappings_lower_group_dn_space_id
+    ON ldap_group_mappings(LOWER(ldap_group_mapping_group_dn), ldap_group_mapping_space_id);
@@ -0,0 +1,2 @@
+ALTER TABLE tokens ADD COLUMN token_scopes TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tokens ADD COLUMN token_resources TEXT NOT NULL DEFAULT '[]';
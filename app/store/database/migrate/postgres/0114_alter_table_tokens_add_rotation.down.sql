@@ -0,0 +1,3 @@
+ALTER TABLE tokens DROP COLUMN token_previous_issued_at;
+ALTER TABLE tokens DROP COLUMN token_previous_valid_until;
+ALTER TABLE tokens DROP COLUMN token_expiry_warned_at;
@@ -0,0 +1,4 @@
+ALTER TABLE tokens DROP COLUMN token_user_agent;
+ALTER TABLE tokens DROP COLUMN token_ip;
+ALTER TABLE tokens DROP COLUMN token_last_used_at;
+ALTER TABLE tokens DROP COLUMN token_last_used_ip;
@@ -0,0 +1,2 @@
+DROP TABLE repo_memberships;
+DROP TABLE roles;
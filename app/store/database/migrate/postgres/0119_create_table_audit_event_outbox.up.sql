@@ -0,0 +1,11 @@
+CREATE TABLE audit_event_outbox (
+    audit_event_outbox_id SERIAL PRIMARY KEY,
+    audit_event_outbox_audit_event_id INTEGER NOT NULL,
+    audit_event_outbox_created BIGINT NOT NULL,
+    audit_event_outbox_attempts INTEGER NOT NULL DEFAULT 0,
+    audit_event_outbox_last_error TEXT NOT NULL DEFAULT '',
+    CONSTRAINT fk_audit_event_outbox_audit_event_id FOREIGN KEY (audit_event_outbox_audit_event_id)
+        REFERENCES audit_events (audit_event_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
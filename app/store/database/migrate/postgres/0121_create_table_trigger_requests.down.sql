@@ -0,0 +1 @@
+DROP TABLE trigger_requests;
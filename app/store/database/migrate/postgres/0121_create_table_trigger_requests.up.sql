@@ -0,0 +1,25 @@
+CREATE TABLE trigger_requests (
+    trigger_request_id SERIAL PRIMARY KEY,
+    trigger_request_repo_id INTEGER NOT NULL,
+    trigger_request_pipeline_id INTEGER NOT NULL,
+    trigger_request_action TEXT NOT NULL,
+    trigger_request_ref TEXT NOT NULL,
+    trigger_request_sha TEXT NOT NULL,
+    trigger_request_hook TEXT NOT NULL,
+    trigger_request_state TEXT NOT NULL,
+    trigger_request_attempts INTEGER NOT NULL DEFAULT 0,
+    trigger_request_last_error TEXT NOT NULL DEFAULT '',
+    trigger_request_created BIGINT NOT NULL,
+    trigger_request_updated BIGINT NOT NULL,
+    CONSTRAINT fk_trigger_request_repo_id FOREIGN KEY (trigger_request_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_trigger_request_pipeline_id FOREIGN KEY (trigger_request_pipeline_id)
+        REFERENCES pipelines (pipeline_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX trigger_requests_repo_id_state
+    ON trigger_requests(trigger_request_repo_id, trigger_request_state);
@@ -0,0 +1,32 @@
+CREATE TABLE crons (
+    cron_id SERIAL PRIMARY KEY,
+    cron_repo_id INTEGER NOT NULL,
+    cron_pipeline_id INTEGER NOT NULL,
+    cron_uid TEXT NOT NULL,
+    cron_description TEXT NOT NULL DEFAULT '',
+    cron_branch TEXT NOT NULL DEFAULT '',
+    cron_expression TEXT NOT NULL,
+    cron_timezone TEXT NOT NULL DEFAULT '',
+    cron_disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    cron_next BIGINT NOT NULL DEFAULT 0,
+    cron_prev BIGINT NOT NULL DEFAULT 0,
+    cron_created_by INTEGER NOT NULL,
+    cron_created BIGINT NOT NULL,
+    cron_updated BIGINT NOT NULL,
+    cron_version INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT fk_cron_repo_id FOREIGN KEY (cron_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_cron_pipeline_id FOREIGN KEY (cron_pipeline_id)
+        REFERENCES pipelines (pipeline_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX crons_pipeline_id_uid
+    ON crons(cron_pipeline_id, LOWER(cron_uid));
+
+CREATE INDEX crons_next
+    ON crons(cron_next)
+    WHERE cron_disabled = FALSE AND cron_next > 0;
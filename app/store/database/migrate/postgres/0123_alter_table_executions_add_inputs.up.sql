@@ -0,0 +1 @@
+ALTER TABLE executions ADD COLUMN execution_inputs TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,20 @@
+CREATE TABLE pipeline_caches (
+    pipeline_cache_id SERIAL PRIMARY KEY,
+    pipeline_cache_repo_id INTEGER NOT NULL,
+    pipeline_cache_key TEXT NOT NULL,
+    pipeline_cache_blob_path TEXT NOT NULL,
+    pipeline_cache_size BIGINT NOT NULL,
+    pipeline_cache_created BIGINT NOT NULL,
+    pipeline_cache_updated BIGINT NOT NULL,
+    pipeline_cache_last_used BIGINT NOT NULL,
+    CONSTRAINT fk_pipeline_cache_repo_id FOREIGN KEY (pipeline_cache_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX pipeline_caches_repo_id_key
+    ON pipeline_caches(pipeline_cache_repo_id, pipeline_cache_key);
+
+CREATE INDEX pipeline_caches_last_used
+    ON pipeline_caches(pipeline_cache_last_used);
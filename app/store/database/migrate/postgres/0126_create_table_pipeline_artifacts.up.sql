@@ -0,0 +1,20 @@
+CREATE TABLE pipeline_artifacts (
+    pipeline_artifact_id SERIAL PRIMARY KEY,
+    pipeline_artifact_repo_id INTEGER NOT NULL,
+    pipeline_artifact_execution_id INTEGER NOT NULL,
+    pipeline_artifact_name TEXT NOT NULL,
+    pipeline_artifact_blob_path TEXT NOT NULL,
+    pipeline_artifact_size BIGINT NOT NULL,
+    pipeline_artifact_created BIGINT NOT NULL,
+    pipeline_artifact_updated BIGINT NOT NULL,
+    CONSTRAINT fk_pipeline_artifact_execution_id FOREIGN KEY (pipeline_artifact_execution_id)
+        REFERENCES executions (execution_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX pipeline_artifacts_execution_id_name
+    ON pipeline_artifacts(pipeline_artifact_execution_id, pipeline_artifact_name);
+
+CREATE INDEX pipeline_artifacts_created
+    ON pipeline_artifacts(pipeline_artifact_created);
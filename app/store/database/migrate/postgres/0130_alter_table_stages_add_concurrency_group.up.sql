@@ -0,0 +1,6 @@
+ALTER TABLE stages ADD COLUMN stage_concurrency_group TEXT NOT NULL DEFAULT '';
+ALTER TABLE stages ADD COLUMN stage_concurrency_limit INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX stages_repo_id_concurrency_group
+    ON stages(stage_repo_id, stage_concurrency_group)
+    WHERE stage_concurrency_group <> '';
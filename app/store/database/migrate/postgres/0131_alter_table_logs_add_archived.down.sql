@@ -0,0 +1,3 @@
+DROP INDEX logs_archived;
+
+ALTER TABLE logs DROP COLUMN log_archived;
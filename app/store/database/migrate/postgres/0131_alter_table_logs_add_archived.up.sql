@@ -0,0 +1,5 @@
+ALTER TABLE logs ADD COLUMN log_archived BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX logs_archived
+    ON logs(log_archived)
+    WHERE log_archived > 0;
@@ -0,0 +1,5 @@
+ALTER TABLE replication_records ADD COLUMN replication_record_pack_available BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX replication_records_pack_unavailable
+    ON replication_records(replication_record_repo_id, replication_record_ref)
+    WHERE replication_record_pack_available = FALSE;
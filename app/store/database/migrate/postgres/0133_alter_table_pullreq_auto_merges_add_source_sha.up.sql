@@ -0,0 +1,8 @@
+ALTER TABLE pullreq_auto_merges ADD COLUMN pullreq_auto_merge_source_sha TEXT NOT NULL DEFAULT '';
+
+UPDATE pullreq_auto_merges
+SET pullreq_auto_merge_source_sha = (
+    SELECT pullreq_source_sha
+    FROM pullreqs
+    WHERE pullreq_id = pullreq_auto_merge_pullreq_id
+);
@@ -0,0 +1 @@
+ALTER TABLE oauth_providers ADD COLUMN oauth_provider_trust_second_factor BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE saml_providers DROP COLUMN saml_provider_trust_second_factor;
@@ -0,0 +1 @@
+ALTER TABLE saml_providers ADD COLUMN saml_provider_trust_second_factor BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+-- merging pull requests required repo_push and editing webhooks required repo_edit or space_edit,
+-- so custom roles keep their access with the dedicated repo_merge and webhook_edit permissions.
+UPDATE roles
+SET role_permissions = REPLACE(role_permissions, '"repo_push"', '"repo_push","repo_merge"')
+WHERE role_permissions LIKE '%"repo_push"%' AND role_permissions NOT LIKE '%"repo_merge"%';
+
+UPDATE roles
+SET role_permissions = REPLACE(role_permissions, '"repo_edit"', '"webhook_edit","repo_edit"')
+WHERE role_permissions LIKE '%"repo_edit"%' AND role_permissions NOT LIKE '%"webhook_edit"%';
+
+UPDATE roles
+SET role_permissions = REPLACE(role_permissions, '"space_edit"', '"webhook_edit","space_edit"')
+WHERE role_permissions LIKE '%"space_edit"%' AND role_permissions NOT LIKE '%"webhook_edit"%';
@@ -0,0 +1,20 @@
+CREATE TABLE attachments (
+ attachment_id INTEGER PRIMARY KEY AUTOINCREMENT
+,attachment_repo_id INTEGER NOT NULL
+,attachment_file_name TEXT NOT NULL
+,attachment_content_type TEXT NOT NULL
+,attachment_size BIGINT NOT NULL
+,attachment_created_by INTEGER NOT NULL
+,attachment_created BIGINT NOT NULL
+,attachment_referenced BOOLEAN NOT NULL DEFAULT FALSE
+
+-- no foreign key to repositories: attachments of purged repositories are removed from the blob store
+-- by the orphaned attachments cleanup job.
+);
+
+CREATE UNIQUE INDEX attachments_repo_id_file_name
+    ON attachments(attachment_repo_id, attachment_file_name);
+
+CREATE INDEX attachments_created
+    ON attachments(attachment_created)
+    WHERE attachment_referenced = FALSE;
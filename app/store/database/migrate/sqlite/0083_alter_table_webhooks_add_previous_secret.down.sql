@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN webhook_previous_secret_expires_at;
+ALTER TABLE webhooks DROP COLUMN webhook_previous_secret;
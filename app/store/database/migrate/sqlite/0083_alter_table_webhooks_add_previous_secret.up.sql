@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN webhook_previous_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN webhook_previous_secret_expires_at INTEGER NOT NULL DEFAULT 0;
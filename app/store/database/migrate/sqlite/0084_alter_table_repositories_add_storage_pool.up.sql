@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_storage_pool TEXT NOT NULL DEFAULT '';
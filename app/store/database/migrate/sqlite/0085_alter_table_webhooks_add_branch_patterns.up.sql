@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_branch_patterns TEXT NOT NULL DEFAULT '[]';
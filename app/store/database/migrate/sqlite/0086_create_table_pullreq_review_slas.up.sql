@@ -0,0 +1,29 @@
+CREATE TABLE pullreq_review_slas (
+    pullreq_review_sla_pullreq_id INTEGER PRIMARY KEY,
+    pullreq_review_sla_repo_id INTEGER NOT NULL,
+    pullreq_review_sla_started BIGINT NOT NULL,
+    pullreq_review_sla_warn_at BIGINT NOT NULL,
+    pullreq_review_sla_due_at BIGINT NOT NULL,
+    pullreq_review_sla_responded BIGINT,
+    pullreq_review_sla_response_time BIGINT,
+    pullreq_review_sla_closed BIGINT,
+    pullreq_review_sla_warned BIGINT,
+    pullreq_review_sla_breached BIGINT,
+    CONSTRAINT fk_pullreq_review_sla_pullreq_id FOREIGN KEY (pullreq_review_sla_pullreq_id)
+        REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_pullreq_review_sla_repo_id FOREIGN KEY (pullreq_review_sla_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX pullreq_review_slas_repo_id_started
+    ON pullreq_review_slas(pullreq_review_sla_repo_id, pullreq_review_sla_started);
+
+CREATE INDEX pullreq_review_slas_due_at
+    ON pullreq_review_slas(pullreq_review_sla_due_at)
+    WHERE pullreq_review_sla_responded IS NULL
+      AND pullreq_review_sla_closed IS NULL
+      AND pullreq_review_sla_breached IS NULL;
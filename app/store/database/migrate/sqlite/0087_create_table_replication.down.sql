@@ -0,0 +1,2 @@
+DROP TABLE replication_consumers;
+DROP TABLE replication_records;
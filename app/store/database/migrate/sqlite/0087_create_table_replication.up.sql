@@ -0,0 +1,34 @@
+CREATE TABLE replication_records (
+    replication_record_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    replication_record_seq BIGINT,
+    replication_record_repo_id INTEGER NOT NULL,
+    replication_record_repo_git_uid TEXT NOT NULL,
+    replication_record_ref TEXT NOT NULL,
+    replication_record_old_sha TEXT NOT NULL,
+    replication_record_new_sha TEXT NOT NULL,
+    replication_record_created BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX replication_records_seq
+    ON replication_records(replication_record_seq);
+
+CREATE INDEX replication_records_created
+    ON replication_records(replication_record_created);
+
+CREATE TABLE replication_consumers (
+    replication_consumer_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    replication_consumer_identifier TEXT NOT NULL,
+    replication_consumer_description TEXT NOT NULL,
+    replication_consumer_acked_seq BIGINT NOT NULL,
+    replication_consumer_acked BIGINT NOT NULL,
+    replication_consumer_created_by INTEGER NOT NULL,
+    replication_consumer_created BIGINT NOT NULL,
+    replication_consumer_updated BIGINT NOT NULL,
+    CONSTRAINT fk_replication_consumer_created_by FOREIGN KEY (replication_consumer_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX replication_consumers_identifier
+    ON replication_consumers(LOWER(replication_consumer_identifier));
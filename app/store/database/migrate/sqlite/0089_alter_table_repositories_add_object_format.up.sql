@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_object_format TEXT NOT NULL DEFAULT 'sha1';
@@ -0,0 +1,94 @@
+ALTER TABLE repositories ADD COLUMN repo_issue_seq INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE issues (
+    issue_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_version INTEGER NOT NULL,
+    issue_repo_id INTEGER NOT NULL,
+    issue_number INTEGER NOT NULL,
+    issue_created_by INTEGER NOT NULL,
+    issue_created BIGINT NOT NULL,
+    issue_updated BIGINT NOT NULL,
+    issue_closed BIGINT,
+    issue_closed_by_pullreq INTEGER,
+    issue_state TEXT NOT NULL,
+    issue_title TEXT NOT NULL,
+    issue_description TEXT NOT NULL,
+    issue_comment_count INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT fk_issue_repo_id FOREIGN KEY (issue_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_created_by FOREIGN KEY (issue_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX issues_repo_id_number
+    ON issues(issue_repo_id, issue_number);
+
+CREATE INDEX issues_repo_id_state
+    ON issues(issue_repo_id, issue_state);
+
+CREATE TABLE issue_comments (
+    issue_comment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_comment_version INTEGER NOT NULL,
+    issue_comment_issue_id INTEGER NOT NULL,
+    issue_comment_created_by INTEGER NOT NULL,
+    issue_comment_created BIGINT NOT NULL,
+    issue_comment_updated BIGINT NOT NULL,
+    issue_comment_deleted BIGINT,
+    issue_comment_text TEXT NOT NULL,
+    CONSTRAINT fk_issue_comment_issue_id FOREIGN KEY (issue_comment_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_comment_created_by FOREIGN KEY (issue_comment_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE INDEX issue_comments_issue_id_created
+    ON issue_comments(issue_comment_issue_id, issue_comment_created);
+
+CREATE TABLE issue_assignees (
+    issue_assignee_issue_id INTEGER NOT NULL,
+    issue_assignee_principal_id INTEGER NOT NULL,
+    issue_assignee_created_by INTEGER NOT NULL,
+    issue_assignee_created BIGINT NOT NULL,
+    CONSTRAINT fk_issue_assignee_issue_id FOREIGN KEY (issue_assignee_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_assignee_principal_id FOREIGN KEY (issue_assignee_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_assignee_created_by FOREIGN KEY (issue_assignee_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    PRIMARY KEY (issue_assignee_issue_id, issue_assignee_principal_id)
+);
+
+CREATE TABLE issue_labels (
+    issue_label_issue_id INTEGER NOT NULL,
+    issue_label_label_id INTEGER NOT NULL,
+    issue_label_label_value_id INTEGER DEFAULT NULL,
+    issue_label_created BIGINT NOT NULL,
+    issue_label_updated BIGINT NOT NULL,
+    issue_label_created_by INTEGER NOT NULL,
+    issue_label_updated_by INTEGER NOT NULL,
+    CONSTRAINT fk_issue_labels_issue_id FOREIGN KEY (issue_label_issue_id)
+        REFERENCES issues (issue_id) ON DELETE CASCADE,
+    CONSTRAINT fk_issue_labels_label_id FOREIGN KEY (issue_label_label_id)
+        REFERENCES labels (label_id) ON DELETE CASCADE,
+    CONSTRAINT fk_issue_labels_label_value_id FOREIGN KEY (issue_label_label_value_id)
+        REFERENCES label_values (label_value_id) ON DELETE SET NULL,
+    CONSTRAINT fk_issue_labels_created_by FOREIGN KEY (issue_label_created_by)
+        REFERENCES principals (principal_id),
+    CONSTRAINT fk_issue_labels_updated_by FOREIGN KEY (issue_label_updated_by)
+        REFERENCES principals (principal_id),
+    PRIMARY KEY (issue_label_issue_id, issue_label_label_id)
+);
@@ -0,0 +1,7 @@
+DROP INDEX issues_milestone_id;
+ALTER TABLE issues DROP COLUMN issue_milestone_id;
+
+DROP INDEX pullreqs_milestone_id;
+ALTER TABLE pullreqs DROP COLUMN pullreq_milestone_id;
+
+DROP TABLE milestones;
@@ -0,0 +1 @@
+ALTER TABLE pullreq_file_views DROP COLUMN pullreq_file_view_commit_sha;
@@ -0,0 +1 @@
+ALTER TABLE pullreq_file_views ADD COLUMN pullreq_file_view_commit_sha TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_archived BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+ALTER TABLE repositories DROP COLUMN repo_num_stars;
+
+DROP TABLE repository_stars;
@@ -0,0 +1,19 @@
+CREATE TABLE repository_stars (
+    star_principal_id INTEGER NOT NULL,
+    star_repo_id INTEGER NOT NULL,
+    star_created BIGINT NOT NULL,
+    CONSTRAINT pk_repository_stars PRIMARY KEY (star_principal_id, star_repo_id),
+    CONSTRAINT fk_star_principal_id FOREIGN KEY (star_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_star_repo_id FOREIGN KEY (star_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX repository_stars_repo_id
+    ON repository_stars(star_repo_id);
+
+ALTER TABLE repositories ADD COLUMN repo_num_stars INTEGER NOT NULL DEFAULT 0;
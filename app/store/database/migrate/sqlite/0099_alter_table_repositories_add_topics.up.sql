@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_topics TEXT NOT NULL DEFAULT '';
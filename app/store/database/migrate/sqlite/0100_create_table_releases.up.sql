@@ -0,0 +1,55 @@
+CREATE TABLE releases (
+    release_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    release_version INTEGER NOT NULL,
+    release_repo_id INTEGER NOT NULL,
+    release_tag_name TEXT NOT NULL,
+    release_title TEXT NOT NULL,
+    release_notes TEXT NOT NULL,
+    release_is_draft BOOLEAN NOT NULL,
+    release_is_prerelease BOOLEAN NOT NULL,
+    release_published BIGINT,
+    release_created_by INTEGER NOT NULL,
+    release_created BIGINT NOT NULL,
+    release_updated BIGINT NOT NULL,
+    CONSTRAINT fk_release_repo_id FOREIGN KEY (release_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_release_created_by FOREIGN KEY (release_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX releases_repo_id_tag_name
+    ON releases(release_repo_id, release_tag_name);
+
+CREATE TABLE release_assets (
+    asset_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    asset_release_id INTEGER NOT NULL,
+    asset_repo_id INTEGER NOT NULL,
+    asset_name TEXT NOT NULL,
+    asset_file_name TEXT NOT NULL,
+    asset_content_type TEXT NOT NULL,
+    asset_size BIGINT NOT NULL,
+    asset_created_by INTEGER NOT NULL,
+    asset_created BIGINT NOT NULL,
+    CONSTRAINT fk_asset_release_id FOREIGN KEY (asset_release_id)
+        REFERENCES releases (release_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_asset_repo_id FOREIGN KEY (asset_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_asset_created_by FOREIGN KEY (asset_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX release_assets_release_id_lower_name
+    ON release_assets(asset_release_id, LOWER(asset_name));
+
+CREATE INDEX release_assets_repo_id
+    ON release_assets(asset_repo_id);
@@ -0,0 +1,3 @@
+DROP TABLE repo_insight_pullreqs;
+DROP TABLE repo_insight_commits;
+DROP TABLE repo_insights;
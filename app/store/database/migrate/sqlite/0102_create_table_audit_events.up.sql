@@ -0,0 +1,25 @@
+CREATE TABLE audit_events (
+    audit_event_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    audit_event_uid TEXT NOT NULL,
+    audit_event_timestamp BIGINT NOT NULL,
+    audit_event_action TEXT NOT NULL,
+    audit_event_actor_id INTEGER NOT NULL,
+    audit_event_actor_uid TEXT NOT NULL,
+    audit_event_space_id INTEGER NOT NULL,
+    audit_event_space_path TEXT NOT NULL,
+    audit_event_resource_type TEXT NOT NULL,
+    audit_event_resource_identifier TEXT NOT NULL,
+    audit_event_resource_data TEXT NOT NULL,
+    audit_event_old_object TEXT,
+    audit_event_new_object TEXT,
+    audit_event_client_ip TEXT NOT NULL,
+    audit_event_request_method TEXT NOT NULL,
+    audit_event_request_id TEXT NOT NULL,
+    CONSTRAINT fk_audit_event_space_id FOREIGN KEY (audit_event_space_id)
+        REFERENCES spaces (space_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX audit_events_space_id_timestamp
+    ON audit_events(audit_event_space_id, audit_event_timestamp);
@@ -0,0 +1,4 @@
+ALTER TABLE public_keys DROP COLUMN public_key_revoked;
+ALTER TABLE public_keys DROP COLUMN public_key_valid_to;
+ALTER TABLE public_keys DROP COLUMN public_key_valid_from;
+ALTER TABLE public_keys DROP COLUMN public_key_scheme;
@@ -0,0 +1,4 @@
+ALTER TABLE public_keys ADD COLUMN public_key_scheme TEXT NOT NULL DEFAULT 'ssh';
+ALTER TABLE public_keys ADD COLUMN public_key_valid_from BIGINT;
+ALTER TABLE public_keys ADD COLUMN public_key_valid_to BIGINT;
+ALTER TABLE public_keys ADD COLUMN public_key_revoked BIGINT;
@@ -0,0 +1 @@
+ALTER TABLE principals DROP COLUMN principal_avatar;
@@ -0,0 +1,9 @@
+CREATE TABLE user_preferences (
+    user_preference_principal_id INTEGER PRIMARY KEY,
+    user_preference_value TEXT NOT NULL,
+    user_preference_updated BIGINT NOT NULL,
+    CONSTRAINT fk_user_preference_principal_id FOREIGN KEY (user_preference_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
@@ -0,0 +1,41 @@
+CREATE TABLE oauth_providers (
+    oauth_provider_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    oauth_provider_identifier TEXT NOT NULL,
+    oauth_provider_type TEXT NOT NULL,
+    oauth_provider_display_name TEXT NOT NULL,
+    oauth_provider_client_id TEXT NOT NULL,
+    oauth_provider_client_secret BLOB NOT NULL,
+    oauth_provider_base_url TEXT NOT NULL DEFAULT '',
+    oauth_provider_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    oauth_provider_allow_signup BOOLEAN NOT NULL DEFAULT FALSE,
+    oauth_provider_created BIGINT NOT NULL,
+    oauth_provider_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX oauth_providers_lower_identifier
+    ON oauth_providers(LOWER(oauth_provider_identifier));
+
+CREATE TABLE oauth_identities (
+    oauth_identity_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    oauth_identity_principal_id INTEGER NOT NULL,
+    oauth_identity_provider_id INTEGER NOT NULL,
+    oauth_identity_external_id TEXT NOT NULL,
+    oauth_identity_external_login TEXT NOT NULL DEFAULT '',
+    oauth_identity_email TEXT NOT NULL DEFAULT '',
+    oauth_identity_created BIGINT NOT NULL,
+    oauth_identity_updated BIGINT NOT NULL,
+    CONSTRAINT fk_oauth_identity_principal_id FOREIGN KEY (oauth_identity_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_identity_provider_id FOREIGN KEY (oauth_identity_provider_id)
+        REFERENCES oauth_providers (oauth_provider_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX oauth_identities_provider_id_external_id
+    ON oauth_identities(oauth_identity_provider_id, oauth_identity_external_id);
+
+CREATE INDEX oauth_identities_principal_id
+    ON oauth_identities(oauth_identity_principal_id);
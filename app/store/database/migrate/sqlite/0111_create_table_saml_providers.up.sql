@@ -0,0 +1,41 @@
+CREATE TABLE saml_providers (
+    saml_provider_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    saml_provider_identifier TEXT NOT NULL,
+    saml_provider_display_name TEXT NOT NULL,
+    saml_provider_idp_metadata TEXT NOT NULL,
+    saml_provider_idp_entity_id TEXT NOT NULL,
+    saml_provider_idp_sso_url TEXT NOT NULL,
+    saml_provider_attribute_mapping TEXT NOT NULL,
+    saml_provider_group_mappings TEXT NOT NULL,
+    saml_provider_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    saml_provider_allow_signup BOOLEAN NOT NULL DEFAULT FALSE,
+    saml_provider_created BIGINT NOT NULL,
+    saml_provider_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX saml_providers_lower_identifier
+    ON saml_providers(LOWER(saml_provider_identifier));
+
+CREATE TABLE saml_identities (
+    saml_identity_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    saml_identity_principal_id INTEGER NOT NULL,
+    saml_identity_provider_id INTEGER NOT NULL,
+    saml_identity_name_id TEXT NOT NULL,
+    saml_identity_email TEXT NOT NULL DEFAULT '',
+    saml_identity_created BIGINT NOT NULL,
+    saml_identity_updated BIGINT NOT NULL,
+    CONSTRAINT fk_saml_identity_principal_id FOREIGN KEY (saml_identity_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_saml_identity_provider_id FOREIGN KEY (saml_identity_provider_id)
+        REFERENCES saml_providers (saml_provider_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX saml_identities_provider_id_name_id
+    ON saml_identities(saml_identity_provider_id, saml_identity_name_id);
+
+CREATE INDEX saml_identities_principal_id
+    ON saml_identities(saml_identity_principal_id);
@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN token_scopes;
+ALTER TABLE tokens DROP COLUMN token_resources;
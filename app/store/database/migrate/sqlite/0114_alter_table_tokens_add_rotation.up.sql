@@ -0,0 +1,3 @@
+ALTER TABLE tokens ADD COLUMN token_previous_issued_at BIGINT;
+ALTER TABLE tokens ADD COLUMN token_previous_valid_until BIGINT;
+ALTER TABLE tokens ADD COLUMN token_expiry_warned_at BIGINT;
@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN token_allowed_cidrs;
+ALTER TABLE principals DROP COLUMN principal_sa_allowed_cidrs;
@@ -0,0 +1,2 @@
+ALTER TABLE tokens ADD COLUMN token_allowed_cidrs TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE principals ADD COLUMN principal_sa_allowed_cidrs TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+ALTER TABLE tokens ADD COLUMN token_user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE tokens ADD COLUMN token_ip TEXT NOT NULL DEFAULT '';
+ALTER TABLE tokens ADD COLUMN token_last_used_at BIGINT;
+ALTER TABLE tokens ADD COLUMN token_last_used_ip TEXT NOT NULL DEFAULT '';
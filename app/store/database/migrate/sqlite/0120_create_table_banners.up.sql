@@ -0,0 +1,15 @@
+CREATE TABLE banners (
+    banner_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    banner_uid TEXT NOT NULL,
+    banner_message TEXT NOT NULL,
+    banner_severity TEXT NOT NULL,
+    banner_starts_at BIGINT NOT NULL,
+    banner_ends_at BIGINT NOT NULL DEFAULT 0,
+    banner_dismissible BOOLEAN NOT NULL DEFAULT FALSE,
+    banner_created_by INTEGER NOT NULL,
+    banner_created BIGINT NOT NULL,
+    banner_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX banners_lower_uid
+    ON banners(LOWER(banner_uid));
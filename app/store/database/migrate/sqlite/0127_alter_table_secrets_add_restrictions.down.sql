@@ -0,0 +1,3 @@
+ALTER TABLE secrets DROP COLUMN secret_allowed_events;
+ALTER TABLE secrets DROP COLUMN secret_allowed_repos;
+ALTER TABLE secrets DROP COLUMN secret_disallow_fork_prs;
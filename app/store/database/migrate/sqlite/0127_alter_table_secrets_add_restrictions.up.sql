@@ -0,0 +1,3 @@
+ALTER TABLE secrets ADD COLUMN secret_allowed_events TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE secrets ADD COLUMN secret_allowed_repos TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE secrets ADD COLUMN secret_disallow_fork_prs BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,19 @@
+CREATE TABLE runners (
+    runner_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    runner_identifier TEXT NOT NULL,
+    runner_tags TEXT NOT NULL DEFAULT '[]',
+    runner_capacity INTEGER NOT NULL,
+    runner_version TEXT NOT NULL,
+    runner_os TEXT NOT NULL,
+    runner_arch TEXT NOT NULL,
+    runner_token_hash TEXT NOT NULL,
+    runner_last_seen BIGINT NOT NULL,
+    runner_created BIGINT NOT NULL,
+    runner_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX runners_identifier
+    ON runners(runner_identifier);
+
+CREATE UNIQUE INDEX runners_token_hash
+    ON runners(runner_token_hash);
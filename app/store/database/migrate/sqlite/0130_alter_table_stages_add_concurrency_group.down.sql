@@ -0,0 +1,4 @@
+DROP INDEX stages_repo_id_concurrency_group;
+
+ALTER TABLE stages DROP COLUMN stage_concurrency_limit;
+ALTER TABLE stages DROP COLUMN stage_concurrency_group;
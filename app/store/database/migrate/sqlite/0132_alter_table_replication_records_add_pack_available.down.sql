@@ -0,0 +1,3 @@
+DROP INDEX replication_records_pack_unavailable;
+
+ALTER TABLE replication_records DROP COLUMN replication_record_pack_available;
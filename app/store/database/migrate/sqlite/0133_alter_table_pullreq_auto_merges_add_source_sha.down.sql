@@ -0,0 +1 @@
+ALTER TABLE pullreq_auto_merges DROP COLUMN pullreq_auto_merge_source_sha;
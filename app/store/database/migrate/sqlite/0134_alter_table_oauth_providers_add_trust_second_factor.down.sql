@@ -0,0 +1 @@
+ALTER TABLE oauth_providers DROP COLUMN oauth_provider_trust_second_factor;
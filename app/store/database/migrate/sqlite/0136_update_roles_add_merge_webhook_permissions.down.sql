@@ -0,0 +1,4 @@
+-- the permissions are inserted in an order that sanitized permission lists never have.
+UPDATE roles SET role_permissions = REPLACE(role_permissions, '"repo_push","repo_merge"', '"repo_push"');
+UPDATE roles SET role_permissions = REPLACE(role_permissions, '"webhook_edit","repo_edit"', '"repo_edit"');
+UPDATE roles SET role_permissions = REPLACE(role_permissions, '"webhook_edit","space_edit"', '"space_edit"');
@@ -0,0 +1,2 @@
+ALTER TABLE secrets ADD COLUMN secret_disallow_fork_prs BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE secrets DROP COLUMN secret_allow_fork_prs;
@@ -0,0 +1,406 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.MilestoneStore = (*MilestoneStore)(nil)
+
+// NewMilestoneStore returns a new MilestoneStore.
+func NewMilestoneStore(db *sqlx.DB) *MilestoneStore {
+	return &MilestoneStore{
+		db: db,
+	}
+}
+
+// MilestoneStore implements store.MilestoneStore backed by a relational database.
+type MilestoneStore struct {
+	db *sqlx.DB
+}
+
+type milestone struct {
+	ID      int64 `db:"milestone_id"`
+	Version int64 `db:"milestone_version"`
+	RepoID  int64 `db:"milestone_repo_id"`
+
+	Title       string   `db:"milestone_title"`
+	Description string   `db:"milestone_description"`
+	DueDate     null.Int `db:"milestone_due_date"`
+
+	State  enum.MilestoneState `db:"milestone_state"`
+	Closed null.Int            `db:"milestone_closed"`
+
+	CreatedBy int64 `db:"milestone_created_by"`
+	Created   int64 `db:"milestone_created"`
+	Updated   int64 `db:"milestone_updated"`
+
+	OpenIssues     int64 `db:"milestone_open_issues"`
+	ClosedIssues   int64 `db:"milestone_closed_issues"`
+	OpenPullReqs   int64 `db:"milestone_open_pullreqs"`
+	ClosedPullReqs int64 `db:"milestone_closed_pullreqs"`
+}
+
+const (
+	milestoneColumns = `
+		 milestone_id
+		,milestone_version
+		,milestone_repo_id
+		,milestone_title
+		,milestone_description
+		,milestone_due_date
+		,milestone_state
+		,milestone_closed
+		,milestone_created_by
+		,milestone_created
+		,milestone_updated`
+
+	// milestoneProgressColumns counts the pull requests and issues assigned to the milestone.
+	// Merged pull requests are counted as closed.
+	milestoneProgressColumns = `
+		,(SELECT COUNT(*) FROM issues
+			WHERE issue_milestone_id = milestone_id AND issue_state = 'open') AS milestone_open_issues
+		,(SELECT COUNT(*) FROM issues
+			WHERE issue_milestone_id = milestone_id AND issue_state <> 'open') AS milestone_closed_issues
+		,(SELECT COUNT(*) FROM pullreqs
+			WHERE pullreq_milestone_id = milestone_id AND pullreq_state = 'open') AS milestone_open_pullreqs
+		,(SELECT COUNT(*) FROM pullreqs
+			WHERE pullreq_milestone_id = milestone_id AND pullreq_state <> 'open') AS milestone_closed_pullreqs`
+
+	milestoneSelectBase = `
+	SELECT` + milestoneColumns + milestoneProgressColumns + `
+	FROM milestones`
+)
+
+// Find finds the milestone by id.
+func (s *MilestoneStore) Find(ctx context.Context, id int64) (*types.Milestone, error) {
+	const sqlQuery = milestoneSelectBase + `
+	WHERE milestone_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &milestone{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find milestone")
+	}
+
+	return mapMilestone(dst), nil
+}
+
+// FindInRepo finds the milestone by id and verifies that it belongs to the repository.
+func (s *MilestoneStore) FindInRepo(ctx context.Context, repoID, id int64) (*types.Milestone, error) {
+	const sqlQuery = milestoneSelectBase + `
+	WHERE milestone_repo_id = $1 AND milestone_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &milestone{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find milestone in repo")
+	}
+
+	return mapMilestone(dst), nil
+}
+
+// Create creates a new milestone.
+func (s *MilestoneStore) Create(ctx context.Context, in *types.Milestone) error {
+	const sqlQuery = `
+	INSERT INTO milestones (
+		 milestone_version
+		,milestone_repo_id
+		,milestone_title
+		,milestone_description
+		,milestone_due_date
+		,milestone_state
+		,milestone_closed
+		,milestone_created_by
+		,milestone_created
+		,milestone_updated
+	) values (
+		 :milestone_version
+		,:milestone_repo_id
+		,:milestone_title
+		,:milestone_description
+		,:milestone_due_date
+		,:milestone_state
+		,:milestone_closed
+		,:milestone_created_by
+		,:milestone_created
+		,:milestone_updated
+	) RETURNING milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalMilestone(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind milestone object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the milestone.
+func (s *MilestoneStore) Update(ctx context.Context, in *types.Milestone) error {
+	const sqlQuery = `
+	UPDATE milestones
+	SET
+		 milestone_version = :milestone_version
+		,milestone_updated = :milestone_updated
+		,milestone_title = :milestone_title
+		,milestone_description = :milestone_description
+		,milestone_due_date = :milestone_due_date
+		,milestone_state = :milestone_state
+		,milestone_closed = :milestone_closed
+	WHERE milestone_id = :milestone_id AND milestone_version = :milestone_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMilestone := mapInternalMilestone(in)
+	dbMilestone.Version++
+	dbMilestone.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMilestone)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind milestone object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update milestone")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	in.Version = dbMilestone.Version
+	in.Updated = dbMilestone.Updated
+
+	return nil
+}
+
+// UpdateOptLock the milestone details using the optimistic locking mechanism.
+func (s *MilestoneStore) UpdateOptLock(ctx context.Context, in *types.Milestone,
+	mutateFn func(milestone *types.Milestone) error,
+) (*types.Milestone, error) {
+	for {
+		dup := *in
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		in, err = s.Find(ctx, in.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the milestone. Pull requests and issues assigned to the milestone are left without one.
+func (s *MilestoneStore) Delete(ctx context.Context, id int64) error {
+	const (
+		sqlQueryPullReqs = `
+		UPDATE pullreqs
+		SET pullreq_milestone_id = NULL
+		WHERE pullreq_milestone_id = $1`
+
+		sqlQueryIssues = `
+		UPDATE issues
+		SET issue_milestone_id = NULL
+		WHERE issue_milestone_id = $1`
+
+		sqlQuery = `
+		DELETE FROM milestones
+		WHERE milestone_id = $1`
+	)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	// The foreign keys would take care of this in postgres, but they don't exist in sqlite.
+	if _, err := db.ExecContext(ctx, sqlQueryPullReqs, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to unassign pull requests from milestone")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQueryIssues, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to unassign issues from milestone")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete milestone")
+	}
+
+	return nil
+}
+
+// Count of milestones in a repository.
+func (s *MilestoneStore) Count(ctx context.Context, repoID int64, opts *types.MilestoneFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("milestones")
+
+	stmt = applyMilestoneFilter(stmt, repoID, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of milestones in a repository.
+// Milestones are ordered by the due date, milestones without the due date are listed last.
+func (s *MilestoneStore) List(
+	ctx context.Context,
+	repoID int64,
+	opts *types.MilestoneFilter,
+) ([]*types.Milestone, error) {
+	stmt := database.Builder.
+		Select(milestoneColumns + milestoneProgressColumns).
+		From("milestones")
+
+	stmt = applyMilestoneFilter(stmt, repoID, opts)
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy("CASE WHEN milestone_due_date IS NULL THEN 1 ELSE 0 END",
+		"milestone_due_date", "milestone_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*milestone, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	result := make([]*types.Milestone, len(dst))
+	for i, m := range dst {
+		result[i] = mapMilestone(m)
+	}
+
+	return result, nil
+}
+
+func applyMilestoneFilter(
+	stmt squirrel.SelectBuilder,
+	repoID int64,
+	opts *types.MilestoneFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.Where("milestone_repo_id = ?", repoID)
+
+	if len(opts.States) == 1 {
+		stmt = stmt.Where("milestone_state = ?", opts.States[0])
+	} else if len(opts.States) > 1 {
+		stmt = stmt.Where(squirrel.Eq{"milestone_state": opts.States})
+	}
+
+	if opts.Query != "" {
+		stmt = stmt.Where("LOWER(milestone_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+	}
+
+	return stmt
+}
+
+func mapMilestone(in *milestone) *types.Milestone {
+	progress := types.MilestoneProgress{
+		OpenIssues:     in.OpenIssues,
+		ClosedIssues:   in.ClosedIssues,
+		OpenPullReqs:   in.OpenPullReqs,
+		ClosedPullReqs: in.ClosedPullReqs,
+	}
+
+	closed := in.ClosedIssues + in.ClosedPullReqs
+	if total := closed + in.OpenIssues + in.OpenPullReqs; total > 0 {
+		progress.Percentage = int(closed * 100 / total)
+	}
+
+	return &types.Milestone{
+		ID:          in.ID,
+		Version:     in.Version,
+		RepoID:      in.RepoID,
+		Title:       in.Title,
+		Description: in.Description,
+		DueDate:     in.DueDate.Ptr(),
+		State:       in.State,
+		Closed:      in.Closed.Ptr(),
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Progress:    progress,
+	}
+}
+
+func mapInternalMilestone(in *types.Milestone) *milestone {
+	return &milestone{
+		ID:          in.ID,
+		Version:     in.Version,
+		RepoID:      in.RepoID,
+		Title:       in.Title,
+		Description: in.Description,
+		DueDate:     null.IntFromPtr(in.DueDate),
+		State:       in.State,
+		Closed:      null.IntFromPtr(in.Closed),
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
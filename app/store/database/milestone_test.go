@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMilestoneStore_Progress(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	milestoneStore := database.NewMilestoneStore(db)
+	issueStore := database.NewIssueStore(db, nil)
+
+	milestone := &types.Milestone{
+		RepoID:    1,
+		Title:     "v1.0",
+		State:     enum.MilestoneStateOpen,
+		CreatedBy: userID,
+	}
+	if err := milestoneStore.Create(ctx, milestone); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// an empty milestone has no progress.
+	found, err := milestoneStore.FindInRepo(ctx, 1, milestone.ID)
+	if err != nil {
+		t.Fatalf("FindInRepo() error = %v", err)
+	}
+	if found.Progress != (types.MilestoneProgress{}) {
+		t.Errorf("empty milestone progress = %+v, want none", found.Progress)
+	}
+
+	issues := []struct {
+		state     enum.IssueState
+		milestone *int64
+	}{
+		{state: enum.IssueStateOpen, milestone: &milestone.ID},
+		{state: enum.IssueStateClosed, milestone: &milestone.ID},
+		{state: enum.IssueStateClosed, milestone: &milestone.ID},
+		{state: enum.IssueStateClosed, milestone: nil},
+	}
+	for i, issue := range issues {
+		err = issueStore.Create(ctx, &types.Issue{
+			RepoID:      1,
+			Number:      int64(i + 1),
+			CreatedBy:   userID,
+			State:       issue.state,
+			Title:       "issue",
+			MilestoneID: issue.milestone,
+		})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+
+	found, err = milestoneStore.FindInRepo(ctx, 1, milestone.ID)
+	if err != nil {
+		t.Fatalf("FindInRepo() error = %v", err)
+	}
+
+	wantProgress := types.MilestoneProgress{OpenIssues: 1, ClosedIssues: 2, Percentage: 66}
+	if found.Progress != wantProgress {
+		t.Errorf("milestone progress = %+v, want %+v", found.Progress, wantProgress)
+	}
+
+	// the milestone of another repository isn't found.
+	if _, err = milestoneStore.FindInRepo(ctx, 2, milestone.ID); err == nil {
+		t.Errorf("FindInRepo() of another repository succeeded, want error")
+	}
+
+	if err = milestoneStore.Delete(ctx, milestone.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	var assigned int
+	err = db.QueryRowContext(ctx, "SELECT count(*) FROM issues WHERE issue_milestone_id IS NOT NULL").Scan(&assigned)
+	if err != nil {
+		t.Fatalf("failed to count assigned issues: %v", err)
+	}
+	if assigned != 0 {
+		t.Errorf("%d issues are still assigned to the deleted milestone", assigned)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OAuthIdentityStore = (*OAuthIdentityStore)(nil)
+
+// NewOAuthIdentityStore returns a new OAuthIdentityStore.
+func NewOAuthIdentityStore(db *sqlx.DB) *OAuthIdentityStore {
+	return &OAuthIdentityStore{
+		db: db,
+	}
+}
+
+// OAuthIdentityStore implements store.OAuthIdentityStore backed by a relational database.
+type OAuthIdentityStore struct {
+	db *sqlx.DB
+}
+
+type oauthIdentity struct {
+	ID            int64  `db:"oauth_identity_id"`
+	PrincipalID   int64  `db:"oauth_identity_principal_id"`
+	ProviderID    int64  `db:"oauth_identity_provider_id"`
+	ExternalID    string `db:"oauth_identity_external_id"`
+	ExternalLogin string `db:"oauth_identity_external_login"`
+	Email         string `db:"oauth_identity_email"`
+	Created       int64  `db:"oauth_identity_created"`
+	Updated       int64  `db:"oauth_identity_updated"`
+}
+
+const (
+	oauthIdentityColumns = `
+		 oauth_identity_id
+		,oauth_identity_principal_id
+		,oauth_identity_provider_id
+		,oauth_identity_external_id
+		,oauth_identity_external_login
+		,oauth_identity_email
+		,oauth_identity_created
+		,oauth_identity_updated`
+
+	oauthIdentitySelectBase = `
+		SELECT` + oauthIdentityColumns + `
+		FROM oauth_identities`
+)
+
+// FindByExternalID finds the identity of the account with the provider.
+func (s *OAuthIdentityStore) FindByExternalID(
+	ctx context.Context,
+	providerID int64,
+	externalID string,
+) (*types.OAuthIdentity, error) {
+	const sqlQuery = oauthIdentitySelectBase + `
+		WHERE oauth_identity_provider_id = $1 AND oauth_identity_external_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &oauthIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, providerID, externalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find oauth identity")
+	}
+
+	return mapOAuthIdentity(dst), nil
+}
+
+// List returns all identities linked to a principal.
+func (s *OAuthIdentityStore) List(ctx context.Context, principalID int64) ([]*types.OAuthIdentity, error) {
+	const sqlQuery = oauthIdentitySelectBase + `
+		WHERE oauth_identity_principal_id = $1
+		ORDER BY oauth_identity_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*oauthIdentity, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list oauth identities")
+	}
+
+	out := make([]*types.OAuthIdentity, len(dst))
+	for i, d := range dst {
+		out[i] = mapOAuthIdentity(d)
+	}
+
+	return out, nil
+}
+
+// Create stores a new identity.
+func (s *OAuthIdentityStore) Create(ctx context.Context, identity *types.OAuthIdentity) error {
+	const sqlQuery = `
+		INSERT INTO oauth_identities (
+			 oauth_identity_principal_id
+			,oauth_identity_provider_id
+			,oauth_identity_external_id
+			,oauth_identity_external_login
+			,oauth_identity_email
+			,oauth_identity_created
+			,oauth_identity_updated
+		) values (
+			 :oauth_identity_principal_id
+			,:oauth_identity_provider_id
+			,:oauth_identity_external_id
+			,:oauth_identity_external_login
+			,:oauth_identity_email
+			,:oauth_identity_created
+			,:oauth_identity_updated
+		) RETURNING oauth_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalOAuthIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind oauth identity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert oauth identity query failed")
+	}
+
+	return nil
+}
+
+// Update updates the login and the email of an identity.
+func (s *OAuthIdentityStore) Update(ctx context.Context, identity *types.OAuthIdentity) error {
+	const sqlQuery = `
+		UPDATE oauth_identities
+		SET
+			 oauth_identity_external_login = $1
+			,oauth_identity_email = $2
+			,oauth_identity_updated = $3
+		WHERE oauth_identity_id = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery,
+		identity.ExternalLogin, identity.Email, identity.Updated, identity.ID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update oauth identity")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an identity.
+func (s *OAuthIdentityStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM oauth_identities
+		WHERE oauth_identity_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete oauth identity")
+	}
+
+	return nil
+}
+
+func mapOAuthIdentity(in *oauthIdentity) *types.OAuthIdentity {
+	return &types.OAuthIdentity{
+		ID:            in.ID,
+		PrincipalID:   in.PrincipalID,
+		ProviderID:    in.ProviderID,
+		ExternalID:    in.ExternalID,
+		ExternalLogin: in.ExternalLogin,
+		Email:         in.Email,
+		Created:       in.Created,
+		Updated:       in.Updated,
+	}
+}
+
+func mapInternalOAuthIdentity(in *types.OAuthIdentity) *oauthIdentity {
+	return &oauthIdentity{
+		ID:            in.ID,
+		PrincipalID:   in.PrincipalID,
+		ProviderID:    in.ProviderID,
+		ExternalID:    in.ExternalID,
+		ExternalLogin: in.ExternalLogin,
+		Email:         in.Email,
+		Created:       in.Created,
+		Updated:       in.Updated,
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OAuthProviderStore = (*OAuthProviderStore)(nil)
+
+// NewOAuthProviderStore returns a new OAuthProviderStore.
+func NewOAuthProviderStore(db *sqlx.DB) *OAuthProviderStore {
+	return &OAuthProviderStore{
+		db: db,
+	}
+}
+
+// OAuthProviderStore implements store.OAuthProviderStore backed by a relational database.
+type OAuthProviderStore struct {
+	db *sqlx.DB
+}
+
+type oauthProvider struct {
+	ID           int64                  `db:"oauth_provider_id"`
+	Identifier   string                 `db:"oauth_provider_identifier"`
+	Type         enum.OAuthProviderType `db:"oauth_provider_type"`
+	DisplayName  string                 `db:"oauth_provider_display_name"`
+	ClientID     string                 `db:"oauth_provider_client_id"`
+	ClientSecret []byte                 `db:"oauth_provider_client_secret"`
+	BaseURL      string                 `db:"oauth_provider_base_url"`
+	Enabled      bool                   `db:"oauth_provider_enabled"`
+	AllowSignup  bool                   `db:"oauth_provider_allow_signup"`
+	Created      int64                  `db:"oauth_provider_created"`
+	Updated      int64                  `db:"oauth_provider_updated"`
+
+	TrustSecondFactor bool `db:"oauth_provider_trust_second_factor"`
+}
+
+const (
+	oauthProviderColumns = `
+		 oauth_provider_id
+		,oauth_provider_identifier
+		,oauth_provider_type
+		,oauth_provider_display_name
+		,oauth_provider_client_id
+		,oauth_provider_client_secret
+		,oauth_provider_base_url
+		,oauth_provider_enabled
+		,oauth_provider_allow_signup
+		,oauth_provider_trust_second_factor
+		,oauth_provider_created
+		,oauth_provider_updated`
+
+	oauthProviderSelectBase = `
+		SELECT` + oauthProviderColumns + `
+		FROM oauth_providers`
+)
+
+// Find finds an OAuth provider by its ID.
+func (s *OAuthProviderStore) Find(ctx context.Context, id int64) (*types.OAuthProvider, error) {
+	const sqlQuery = oauthProviderSelectBase + `
+		WHERE oauth_provider_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &oauthProvider{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find oauth provider")
+	}
+
+	return mapOAuthProvider(dst), nil
+}
+
+// FindByIdentifier finds an OAuth provider by its identifier.
+func (s *OAuthProviderStore) FindByIdentifier(ctx context.Context, identifier string) (*types.OAuthProvider, error) {
+	const sqlQuery = oauthProviderSelectBase + `
+		WHERE LOWER(oauth_provider_identifier) = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &oauthProvider{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find oauth provider by identifier")
+	}
+
+	return mapOAuthProvider(dst), nil
+}
+
+// List returns all OAuth providers, or only the enabled ones.
+func (s *OAuthProviderStore) List(ctx context.Context, enabledOnly bool) ([]*types.OAuthProvider, error) {
+	stmt := database.Builder.
+		Select(oauthProviderColumns).
+		From("oauth_providers").
+		OrderBy("oauth_provider_identifier ASC")
+
+	if enabledOnly {
+		stmt = stmt.Where("oauth_provider_enabled = ?", true)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*oauthProvider, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list oauth providers")
+	}
+
+	out := make([]*types.OAuthProvider, len(dst))
+	for i, d := range dst {
+		out[i] = mapOAuthProvider(d)
+	}
+
+	return out, nil
+}
+
+// Create stores a new OAuth provider.
+func (s *OAuthProviderStore) Create(ctx context.Context, provider *types.OAuthProvider) error {
+	const sqlQuery = `
+		INSERT INTO oauth_providers (
+			 oauth_provider_identifier
+			,oauth_provider_type
+			,oauth_provider_display_name
+			,oauth_provider_client_id
+			,oauth_provider_client_secret
+			,oauth_provider_base_url
+			,oauth_provider_enabled
+			,oauth_provider_allow_signup
+			,oauth_provider_trust_second_factor
+			,oauth_provider_created
+			,oauth_provider_updated
+		) values (
+			 :oauth_provider_identifier
+			,:oauth_provider_type
+			,:oauth_provider_display_name
+			,:oauth_provider_client_id
+			,:oauth_provider_client_secret
+			,:oauth_provider_base_url
+			,:oauth_provider_enabled
+			,:oauth_provider_allow_signup
+			,:oauth_provider_trust_second_factor
+			,:oauth_provider_created
+			,:oauth_provider_updated
+		) RETURNING oauth_provider_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalOAuthProvider(provider))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind oauth provider object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&provider.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert oauth provider query failed")
+	}
+
+	return nil
+}
+
+// Update updates an OAuth provider.
+func (s *OAuthProviderStore) Update(ctx context.Context, provider *types.OAuthProvider) error {
+	const sqlQuery = `
+		UPDATE oauth_providers
+		SET
+			 oauth_provider_display_name = :oauth_provider_display_name
+			,oauth_provider_client_id = :oauth_provider_client_id
+			,oauth_provider_client_secret = :oauth_provider_client_secret
+			,oauth_provider_base_url = :oauth_provider_base_url
+			,oauth_provider_enabled = :oauth_provider_enabled
+			,oauth_provider_allow_signup = :oauth_provider_allow_signup
+			,oauth_provider_trust_second_factor = :oauth_provider_trust_second_factor
+			,oauth_provider_updated = :oauth_provider_updated
+		WHERE oauth_provider_id = :oauth_provider_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalOAuthProvider(provider))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind oauth provider object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update oauth provider")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an OAuth provider together with the identities linked through it.
+func (s *OAuthProviderStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM oauth_providers
+		WHERE oauth_provider_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete oauth provider")
+	}
+
+	return nil
+}
+
+func mapOAuthProvider(in *oauthProvider) *types.OAuthProvider {
+	return &types.OAuthProvider{
+		ID:           in.ID,
+		Identifier:   in.Identifier,
+		Type:         in.Type,
+		DisplayName:  in.DisplayName,
+		ClientID:     in.ClientID,
+		ClientSecret: in.ClientSecret,
+		BaseURL:      in.BaseURL,
+		Enabled:      in.Enabled,
+		AllowSignup:  in.AllowSignup,
+		Created:      in.Created,
+		Updated:      in.Updated,
+
+		TrustSecondFactor: in.TrustSecondFactor,
+	}
+}
+
+func mapInternalOAuthProvider(in *types.OAuthProvider) *oauthProvider {
+	return &oauthProvider{
+		ID:           in.ID,
+		Identifier:   in.Identifier,
+		Type:         in.Type,
+		DisplayName:  in.DisplayName,
+		ClientID:     in.ClientID,
+		ClientSecret: in.ClientSecret,
+		BaseURL:      in.BaseURL,
+		Enabled:      in.Enabled,
+		AllowSignup:  in.AllowSignup,
+		Created:      in.Created,
+		Updated:      in.Updated,
+
+		TrustSecondFactor: in.TrustSecondFactor,
+	}
+}
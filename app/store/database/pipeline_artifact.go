@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineArtifactStore = (*PipelineArtifactStore)(nil)
+
+// NewPipelineArtifactStore returns a new PipelineArtifactStore.
+func NewPipelineArtifactStore(db *sqlx.DB) *PipelineArtifactStore {
+	return &PipelineArtifactStore{
+		db: db,
+	}
+}
+
+// PipelineArtifactStore implements store.PipelineArtifactStore backed by a relational database.
+type PipelineArtifactStore struct {
+	db *sqlx.DB
+}
+
+type pipelineArtifact struct {
+	ID          int64  `db:"pipeline_artifact_id"`
+	RepoID      int64  `db:"pipeline_artifact_repo_id"`
+	ExecutionID int64  `db:"pipeline_artifact_execution_id"`
+	Name        string `db:"pipeline_artifact_name"`
+	BlobPath    string `db:"pipeline_artifact_blob_path"`
+	Size        int64  `db:"pipeline_artifact_size"`
+	Created     int64  `db:"pipeline_artifact_created"`
+	Updated     int64  `db:"pipeline_artifact_updated"`
+}
+
+const (
+	pipelineArtifactColumns = `
+		 pipeline_artifact_id
+		,pipeline_artifact_repo_id
+		,pipeline_artifact_execution_id
+		,pipeline_artifact_name
+		,pipeline_artifact_blob_path
+		,pipeline_artifact_size
+		,pipeline_artifact_created
+		,pipeline_artifact_updated`
+)
+
+// Find finds the artifact of the execution by its name.
+func (s *PipelineArtifactStore) Find(
+	ctx context.Context,
+	executionID int64,
+	name string,
+) (*types.PipelineArtifact, error) {
+	const sqlQuery = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts
+		WHERE pipeline_artifact_execution_id = $1 AND pipeline_artifact_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineArtifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, executionID, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline artifact")
+	}
+
+	return mapPipelineArtifact(dst), nil
+}
+
+// Upsert creates the artifact or replaces the existing artifact of the execution with the same name.
+func (s *PipelineArtifactStore) Upsert(ctx context.Context, artifact *types.PipelineArtifact) error {
+	const sqlQuery = `
+		INSERT INTO pipeline_artifacts (
+			 pipeline_artifact_repo_id
+			,pipeline_artifact_execution_id
+			,pipeline_artifact_name
+			,pipeline_artifact_blob_path
+			,pipeline_artifact_size
+			,pipeline_artifact_created
+			,pipeline_artifact_updated
+		) values (
+			 :pipeline_artifact_repo_id
+			,:pipeline_artifact_execution_id
+			,:pipeline_artifact_name
+			,:pipeline_artifact_blob_path
+			,:pipeline_artifact_size
+			,:pipeline_artifact_created
+			,:pipeline_artifact_updated
+		)
+		ON CONFLICT (pipeline_artifact_execution_id, pipeline_artifact_name) DO
+		UPDATE SET
+			 pipeline_artifact_blob_path = :pipeline_artifact_blob_path
+			,pipeline_artifact_size = :pipeline_artifact_size
+			,pipeline_artifact_updated = :pipeline_artifact_updated
+		RETURNING pipeline_artifact_id, pipeline_artifact_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPipelineArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID, &artifact.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert pipeline artifact query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the artifact.
+func (s *PipelineArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_artifacts
+		WHERE pipeline_artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pipeline artifact")
+	}
+
+	return nil
+}
+
+// List lists all artifacts of the execution ordered by name.
+func (s *PipelineArtifactStore) List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error) {
+	const sqlQuery = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts
+		WHERE pipeline_artifact_execution_id = $1
+		ORDER BY pipeline_artifact_name`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pipelineArtifact, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pipeline artifacts")
+	}
+
+	return mapPipelineArtifacts(dst), nil
+}
+
+// ListCreatedBefore lists the artifacts of all executions created before the provided time, the oldest first.
+func (s *PipelineArtifactStore) ListCreatedBefore(
+	ctx context.Context,
+	before int64,
+	limit int,
+) ([]*types.PipelineArtifact, error) {
+	const sqlQuery = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts
+		WHERE pipeline_artifact_created < $1
+		ORDER BY pipeline_artifact_created, pipeline_artifact_id
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pipelineArtifact, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired pipeline artifacts")
+	}
+
+	return mapPipelineArtifacts(dst), nil
+}
+
+func mapPipelineArtifact(in *pipelineArtifact) *types.PipelineArtifact {
+	return &types.PipelineArtifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		ExecutionID: in.ExecutionID,
+		Name:        in.Name,
+		BlobPath:    in.BlobPath,
+		Size:        in.Size,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapPipelineArtifacts(in []*pipelineArtifact) []*types.PipelineArtifact {
+	out := make([]*types.PipelineArtifact, len(in))
+	for i, a := range in {
+		out[i] = mapPipelineArtifact(a)
+	}
+	return out
+}
+
+func mapInternalPipelineArtifact(in *types.PipelineArtifact) *pipelineArtifact {
+	return &pipelineArtifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		ExecutionID: in.ExecutionID,
+		Name:        in.Name,
+		BlobPath:    in.BlobPath,
+		Size:        in.Size,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineCacheStore = (*PipelineCacheStore)(nil)
+
+// NewPipelineCacheStore returns a new PipelineCacheStore.
+func NewPipelineCacheStore(db *sqlx.DB) *PipelineCacheStore {
+	return &PipelineCacheStore{
+		db: db,
+	}
+}
+
+// PipelineCacheStore implements store.PipelineCacheStore backed by a relational database.
+type PipelineCacheStore struct {
+	db *sqlx.DB
+}
+
+type pipelineCache struct {
+	ID       int64  `db:"pipeline_cache_id"`
+	RepoID   int64  `db:"pipeline_cache_repo_id"`
+	Key      string `db:"pipeline_cache_key"`
+	BlobPath string `db:"pipeline_cache_blob_path"`
+	Size     int64  `db:"pipeline_cache_size"`
+	Created  int64  `db:"pipeline_cache_created"`
+	Updated  int64  `db:"pipeline_cache_updated"`
+	LastUsed int64  `db:"pipeline_cache_last_used"`
+}
+
+const (
+	pipelineCacheColumns = `
+		 pipeline_cache_id
+		,pipeline_cache_repo_id
+		,pipeline_cache_key
+		,pipeline_cache_blob_path
+		,pipeline_cache_size
+		,pipeline_cache_created
+		,pipeline_cache_updated
+		,pipeline_cache_last_used`
+)
+
+// Find finds the cache entry of the repository by its key.
+func (s *PipelineCacheStore) Find(ctx context.Context, repoID int64, key string) (*types.PipelineCache, error) {
+	const sqlQuery = `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches
+		WHERE pipeline_cache_repo_id = $1 AND pipeline_cache_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline cache")
+	}
+
+	return mapPipelineCache(dst), nil
+}
+
+// Upsert creates the cache entry or replaces the existing entry of the repository with the same key.
+func (s *PipelineCacheStore) Upsert(ctx context.Context, cache *types.PipelineCache) error {
+	const sqlQuery = `
+		INSERT INTO pipeline_caches (
+			 pipeline_cache_repo_id
+			,pipeline_cache_key
+			,pipeline_cache_blob_path
+			,pipeline_cache_size
+			,pipeline_cache_created
+			,pipeline_cache_updated
+			,pipeline_cache_last_used
+		) values (
+			 :pipeline_cache_repo_id
+			,:pipeline_cache_key
+			,:pipeline_cache_blob_path
+			,:pipeline_cache_size
+			,:pipeline_cache_created
+			,:pipeline_cache_updated
+			,:pipeline_cache_last_used
+		)
+		ON CONFLICT (pipeline_cache_repo_id, pipeline_cache_key) DO
+		UPDATE SET
+			 pipeline_cache_blob_path = :pipeline_cache_blob_path
+			,pipeline_cache_size = :pipeline_cache_size
+			,pipeline_cache_updated = :pipeline_cache_updated
+			,pipeline_cache_last_used = :pipeline_cache_last_used
+		RETURNING pipeline_cache_id, pipeline_cache_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPipelineCache(cache))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline cache object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&cache.ID, &cache.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert pipeline cache query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastUsed sets the time the cache entry was last restored.
+func (s *PipelineCacheStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error {
+	const sqlQuery = `
+		UPDATE pipeline_caches
+		SET pipeline_cache_last_used = $1
+		WHERE pipeline_cache_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update pipeline cache last used time")
+	}
+
+	return nil
+}
+
+// Delete deletes the cache entry.
+func (s *PipelineCacheStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_caches
+		WHERE pipeline_cache_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pipeline cache")
+	}
+
+	return nil
+}
+
+// List lists all cache entries of the repository, the most recently used first.
+func (s *PipelineCacheStore) List(ctx context.Context, repoID int64) ([]*types.PipelineCache, error) {
+	const sqlQuery = `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches
+		WHERE pipeline_cache_repo_id = $1
+		ORDER BY pipeline_cache_last_used DESC, pipeline_cache_id DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pipelineCache, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pipeline caches")
+	}
+
+	return mapPipelineCaches(dst), nil
+}
+
+// ListUnusedSince lists the cache entries of all repositories that weren't used since the provided time,
+// the least recently used first.
+func (s *PipelineCacheStore) ListUnusedSince(
+	ctx context.Context,
+	since int64,
+	limit int,
+) ([]*types.PipelineCache, error) {
+	const sqlQuery = `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches
+		WHERE pipeline_cache_last_used < $1
+		ORDER BY pipeline_cache_last_used, pipeline_cache_id
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pipelineCache, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, since, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list unused pipeline caches")
+	}
+
+	return mapPipelineCaches(dst), nil
+}
+
+func mapPipelineCache(in *pipelineCache) *types.PipelineCache {
+	return &types.PipelineCache{
+		ID:       in.ID,
+		RepoID:   in.RepoID,
+		Key:      in.Key,
+		BlobPath: in.BlobPath,
+		Size:     in.Size,
+		Created:  in.Created,
+		Updated:  in.Updated,
+		LastUsed: in.LastUsed,
+	}
+}
+
+func mapPipelineCaches(in []*pipelineCache) []*types.PipelineCache {
+	out := make([]*types.PipelineCache, len(in))
+	for i, c := range in {
+		out[i] = mapPipelineCache(c)
+	}
+	return out
+}
+
+func mapInternalPipelineCache(in *types.PipelineCache) *pipelineCache {
+	return &pipelineCache{
+		ID:       in.ID,
+		RepoID:   in.RepoID,
+		Key:      in.Key,
+		BlobPath: in.BlobPath,
+		Size:     in.Size,
+		Created:  in.Created,
+		Updated:  in.Updated,
+		LastUsed: in.LastUsed,
+	}
+}
@@ -70,6 +70,7 @@ const principalCommonColumns = `
 // principalColumns defines the column that are used only in a principal itself
 // (for explicit principals the type is implicit, only the generic principal struct stores it explicitly).
 const principalColumns = principalCommonColumns + `
+	,principal_avatar
 	,principal_type`
 
 //nolint:goconst
@@ -148,10 +149,17 @@ func (s *PrincipalStore) FindManyByUID(ctx context.Context, uids []string) ([]*t
 	return s.mapDBPrincipals(dst), nil
 }
 
-// FindByEmail finds the principal by email.
+// FindByEmail finds the principal by its primary email or by a verified additional email of a user.
 func (s *PrincipalStore) FindByEmail(ctx context.Context, email string) (*types.Principal, error) {
 	const sqlQuery = principalSelectBase + `
-		WHERE LOWER(principal_email) = $1`
+		WHERE LOWER(principal_email) = $1
+		OR principal_id IN (
+			SELECT user_email_principal_id
+			FROM user_emails
+			WHERE LOWER(user_email_address) = $1 AND user_email_verified IS NOT NULL
+		)
+		ORDER BY CASE WHEN LOWER(principal_email) = $1 THEN 0 ELSE 1 END
+		LIMIT 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
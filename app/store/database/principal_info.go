@@ -48,6 +48,7 @@ const (
 		,principal_email
 		,principal_display_name
 		,principal_type
+		,principal_avatar
 		,principal_created
 		,principal_updated`
 )
@@ -58,6 +59,7 @@ type principalInfo struct {
 	DisplayName string             `db:"principal_display_name"`
 	Email       string             `db:"principal_email"`
 	Type        enum.PrincipalType `db:"principal_type"`
+	Avatar      string             `db:"principal_avatar"`
 	Created     int64              `db:"principal_created"`
 	Updated     int64              `db:"principal_updated"`
 }
@@ -136,6 +138,7 @@ func mapToPrincipalInfo(p *principalInfo) types.PrincipalInfo {
 		DisplayName: p.DisplayName,
 		Email:       p.Email,
 		Type:        p.Type,
+		AvatarURL:   types.PrincipalAvatarPath(p.ID, p.Type, p.Avatar),
 		Created:     p.Created,
 		Updated:     p.Updated,
 	}
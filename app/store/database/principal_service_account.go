@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	gitness_store "github.com/harness/gitness/store"
@@ -32,11 +33,13 @@ import (
 type serviceAccount struct {
 	types.ServiceAccount
 	UIDUnique string `db:"principal_uid_unique"`
+	CIDRs     string `db:"principal_sa_allowed_cidrs"`
 }
 
 const serviceAccountColumns = principalCommonColumns + `
 	,principal_sa_parent_type
-	,principal_sa_parent_id`
+	,principal_sa_parent_id
+	,principal_sa_allowed_cidrs`
 
 const serviceAccountSelectBase = `
 	SELECT` + serviceAccountColumns + `
@@ -53,7 +56,7 @@ func (s *PrincipalStore) FindServiceAccount(ctx context.Context, id int64) (*typ
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select by id query failed")
 	}
-	return s.mapDBServiceAccount(dst), nil
+	return s.mapDBServiceAccount(dst)
 }
 
 // FindServiceAccountByUID finds the service account by uid.
@@ -76,7 +79,7 @@ func (s *PrincipalStore) FindServiceAccountByUID(ctx context.Context, uid string
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select by uid query failed")
 	}
 
-	return s.mapDBServiceAccount(dst), nil
+	return s.mapDBServiceAccount(dst)
 }
 
 // CreateServiceAccount saves the service account.
@@ -95,6 +98,7 @@ func (s *PrincipalStore) CreateServiceAccount(ctx context.Context, sa *types.Ser
 			,principal_updated
 			,principal_sa_parent_type
 			,principal_sa_parent_id
+			,principal_sa_allowed_cidrs
 		) values (
 			'serviceaccount'
 			,:principal_uid
@@ -108,6 +112,7 @@ func (s *PrincipalStore) CreateServiceAccount(ctx context.Context, sa *types.Ser
 			,:principal_updated
 			,:principal_sa_parent_type
 			,:principal_sa_parent_id
+			,:principal_sa_allowed_cidrs
 		) RETURNING principal_id`
 
 	dbSA, err := s.mapToDBserviceAccount(sa)
@@ -134,13 +139,14 @@ func (s *PrincipalStore) UpdateServiceAccount(ctx context.Context, sa *types.Ser
 	const sqlQuery = `
 		UPDATE principals
 		SET
-			 principal_uid	          = :principal_uid
-			,principal_uid_unique     = :principal_uid_unique
-			,principal_email          = :principal_email
-			,principal_display_name   = :principal_display_name
-			,principal_blocked        = :principal_blocked
-			,principal_salt           = :principal_salt
-			,principal_updated        = :principal_updated
+			 principal_uid	            = :principal_uid
+			,principal_uid_unique       = :principal_uid_unique
+			,principal_email            = :principal_email
+			,principal_display_name     = :principal_display_name
+			,principal_blocked          = :principal_blocked
+			,principal_salt             = :principal_salt
+			,principal_updated          = :principal_updated
+			,principal_sa_allowed_cidrs = :principal_sa_allowed_cidrs
 		WHERE principal_type = 'serviceaccount' AND principal_id = :principal_id`
 
 	dbSA, err := s.mapToDBserviceAccount(sa)
@@ -192,7 +198,7 @@ func (s *PrincipalStore) ListServiceAccounts(ctx context.Context, parentType enu
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing default list query")
 	}
 
-	return s.mapDBServiceAccounts(dst), nil
+	return s.mapDBServiceAccounts(dst)
 }
 
 // CountServiceAccounts returns a count of service accounts for a specific parent.
@@ -214,16 +220,24 @@ func (s *PrincipalStore) CountServiceAccounts(ctx context.Context,
 	return count, nil
 }
 
-func (s *PrincipalStore) mapDBServiceAccount(dbSA *serviceAccount) *types.ServiceAccount {
-	return &dbSA.ServiceAccount
+func (s *PrincipalStore) mapDBServiceAccount(dbSA *serviceAccount) (*types.ServiceAccount, error) {
+	if err := json.Unmarshal([]byte(dbSA.CIDRs), &dbSA.AllowedCIDRs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal service account allowed cidrs: %w", err)
+	}
+
+	return &dbSA.ServiceAccount, nil
 }
 
-func (s *PrincipalStore) mapDBServiceAccounts(dbSAs []*serviceAccount) []*types.ServiceAccount {
+func (s *PrincipalStore) mapDBServiceAccounts(dbSAs []*serviceAccount) ([]*types.ServiceAccount, error) {
 	res := make([]*types.ServiceAccount, len(dbSAs))
 	for i := range dbSAs {
-		res[i] = s.mapDBServiceAccount(dbSAs[i])
+		sa, err := s.mapDBServiceAccount(dbSAs[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = sa
 	}
-	return res
+	return res, nil
 }
 
 func (s *PrincipalStore) mapToDBserviceAccount(sa *types.ServiceAccount) (*serviceAccount, error) {
@@ -241,5 +255,15 @@ func (s *PrincipalStore) mapToDBserviceAccount(sa *types.ServiceAccount) (*servi
 		UIDUnique:      uidUnique,
 	}
 
+	cidrs := sa.AllowedCIDRs
+	if cidrs == nil {
+		cidrs = []string{}
+	}
+	data, err := json.Marshal(cidrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal service account allowed cidrs: %w", err)
+	}
+	dbSA.CIDRs = string(data)
+
 	return dbSA, nil
 }
@@ -37,6 +37,7 @@ type user struct {
 }
 
 const userColumns = principalCommonColumns + `
+	,principal_avatar
 	,principal_user_password`
 
 const userSelectBase = `
@@ -156,6 +157,7 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 			,principal_admin          = :principal_admin
 			,principal_blocked        = :principal_blocked
 			,principal_salt           = :principal_salt
+			,principal_avatar         = :principal_avatar
 			,principal_updated        = :principal_updated
 			,principal_user_password  = :principal_user_password
 		WHERE principal_type = 'user' AND principal_id = :principal_id`
@@ -269,6 +271,7 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 }
 
 func (s *PrincipalStore) mapDBUser(dbUser *user) *types.User {
+	dbUser.AvatarURL = types.PrincipalAvatarPath(dbUser.ID, enum.PrincipalTypeUser, dbUser.Avatar)
 	return &dbUser.User
 }
 
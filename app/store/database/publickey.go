@@ -60,6 +60,11 @@ type publicKey struct {
 	Content     string `db:"public_key_content"`
 	Comment     string `db:"public_key_comment"`
 	Type        string `db:"public_key_type"`
+
+	Scheme    string   `db:"public_key_scheme"`
+	ValidFrom null.Int `db:"public_key_valid_from"`
+	ValidTo   null.Int `db:"public_key_valid_to"`
+	Revoked   null.Int `db:"public_key_revoked"`
 }
 
 const (
@@ -73,7 +78,11 @@ const (
 		,public_key_fingerprint
 		,public_key_content
 		,public_key_comment
-		,public_key_type`
+		,public_key_type
+		,public_key_scheme
+		,public_key_valid_from
+		,public_key_valid_to
+		,public_key_revoked`
 
 	publicKeySelectBase = `
 		SELECT` + publicKeyColumns + `
@@ -131,6 +140,10 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			,public_key_content
 			,public_key_comment
 			,public_key_type
+			,public_key_scheme
+			,public_key_valid_from
+			,public_key_valid_to
+			,public_key_revoked
 		) values (
 			 :public_key_principal_id
 			,:public_key_created
@@ -141,6 +154,10 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			,:public_key_content
 			,:public_key_comment
 			,:public_key_type
+			,:public_key_scheme
+			,:public_key_valid_from
+			,:public_key_valid_to
+			,:public_key_revoked
 		) RETURNING public_key_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -161,6 +178,55 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 	return nil
 }
 
+// Update updates the identifier and the comment of the public key.
+func (s PublicKeyStore) Update(ctx context.Context, key *types.PublicKey) error {
+	const sqlQuery = `
+		UPDATE public_keys
+		SET
+			 public_key_identifier = :public_key_identifier
+			,public_key_comment = :public_key_comment
+		WHERE public_key_id = :public_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbKey := mapToInternalPublicKey(key)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind public key object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update public key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after update of public key failed")
+	}
+
+	if count == 0 {
+		return errors.NotFound("Key not found")
+	}
+
+	return nil
+}
+
+// Revoke marks the public key as revoked.
+func (s PublicKeyStore) Revoke(ctx context.Context, id int64, revoked int64) error {
+	const sqlQuery = `
+		UPDATE public_keys
+		SET public_key_revoked = $1
+		WHERE public_key_id = $2 AND public_key_revoked IS NULL`
+
+	if _, err := dbtx.GetAccessor(ctx, s.db).ExecContext(ctx, sqlQuery, revoked, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to revoke public key")
+	}
+
+	return nil
+}
+
 // DeleteByIdentifier deletes a public key.
 func (s PublicKeyStore) DeleteByIdentifier(ctx context.Context, principalID int64, identifier string) error {
 	const sqlQuery = `DELETE FROM public_keys WHERE public_key_principal_id = $1 and LOWER(public_key_identifier) = $2`
@@ -290,6 +356,10 @@ func (PublicKeyStore) applyQueryFilter(
 			fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
 	}
 
+	if len(filter.Usages) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"public_key_usage": filter.Usages})
+	}
+
 	return stmt
 }
 
@@ -327,6 +397,10 @@ func mapToInternalPublicKey(in *types.PublicKey) publicKey {
 		Content:     in.Content,
 		Comment:     in.Comment,
 		Type:        in.Type,
+		Scheme:      string(in.Scheme),
+		ValidFrom:   null.IntFromPtr(in.ValidFrom),
+		ValidTo:     null.IntFromPtr(in.ValidTo),
+		Revoked:     null.IntFromPtr(in.Revoked),
 	}
 }
 
@@ -342,6 +416,10 @@ func mapToPublicKey(in *publicKey) types.PublicKey {
 		Content:     in.Content,
 		Comment:     in.Comment,
 		Type:        in.Type,
+		Scheme:      enum.PublicKeyScheme(in.Scheme),
+		ValidFrom:   in.ValidFrom.Ptr(),
+		ValidTo:     in.ValidTo.Ptr(),
+		Revoked:     in.Revoked.Ptr(),
 	}
 }
 
@@ -98,6 +98,8 @@ type pullReq struct {
 	FileCount   null.Int `db:"pullreq_file_count"`
 	Additions   null.Int `db:"pullreq_additions"`
 	Deletions   null.Int `db:"pullreq_deletions"`
+
+	MilestoneID null.Int `db:"pullreq_milestone_id"`
 }
 
 const (
@@ -134,7 +136,8 @@ const (
 		,pullreq_commit_count
 		,pullreq_file_count
 		,pullreq_additions
-		,pullreq_deletions`
+		,pullreq_deletions
+		,pullreq_milestone_id`
 
 	pullReqColumns = pullReqColumnsNoDescription + `
 		,pullreq_description`
@@ -234,6 +237,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_file_count
 		,pullreq_additions
 		,pullreq_deletions
+		,pullreq_milestone_id
 	) values (
 		 :pullreq_version
 		,:pullreq_number
@@ -268,6 +272,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_file_count
 		,:pullreq_additions
 		,:pullreq_deletions
+		,:pullreq_milestone_id
 	) RETURNING pullreq_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -315,6 +320,7 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_file_count = :pullreq_file_count
 		,pullreq_additions = :pullreq_additions
 		,pullreq_deletions = :pullreq_deletions
+		,pullreq_milestone_id = :pullreq_milestone_id
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -648,6 +654,10 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 		*stmt = stmt.Where("pullreq_created_by = ?", opts.AuthorID)
 	}
 
+	if opts.MilestoneID > 0 {
+		*stmt = stmt.Where("pullreq_milestone_id = ?", opts.MilestoneID)
+	}
+
 	if opts.CommenterID > 0 {
 		*stmt = stmt.InnerJoin("pullreq_activities act_com ON act_com.pullreq_activity_pullreq_id = pullreq_id")
 		*stmt = stmt.Where("act_com.pullreq_activity_deleted IS NULL")
@@ -755,6 +765,7 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		MergeConflicts:    mergeConflicts,
 		RebaseCheckStatus: pr.RebaseCheckStatus,
 		RebaseConflicts:   rebaseConflicts,
+		MilestoneID:       pr.MilestoneID.Ptr(),
 		Author:            types.PrincipalInfo{},
 		Merger:            nil,
 		Stats: types.PullReqStats{
@@ -808,6 +819,7 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		FileCount:         null.IntFromPtr(pr.Stats.FilesChanged),
 		Additions:         null.IntFromPtr(pr.Stats.Additions),
 		Deletions:         null.IntFromPtr(pr.Stats.Deletions),
+		MilestoneID:       null.IntFromPtr(pr.MilestoneID),
 	}
 
 	return m
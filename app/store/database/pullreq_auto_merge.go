@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqAutoMergeStore = (*PullReqAutoMergeStore)(nil)
+
+// NewPullReqAutoMergeStore returns a new PullReqAutoMergeStore.
+func NewPullReqAutoMergeStore(db *sqlx.DB) *PullReqAutoMergeStore {
+	return &PullReqAutoMergeStore{
+		db: db,
+	}
+}
+
+// PullReqAutoMergeStore implements store.PullReqAutoMergeStore backed by a relational database.
+type PullReqAutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type pullReqAutoMerge struct {
+	PullReqID int64            `db:"pullreq_auto_merge_pullreq_id"`
+	RepoID    int64            `db:"pullreq_auto_merge_repo_id"`
+	Method    enum.MergeMethod `db:"pullreq_auto_merge_method"`
+	SourceSHA string           `db:"pullreq_auto_merge_source_sha"`
+	Title     string           `db:"pullreq_auto_merge_title"`
+	Message   string           `db:"pullreq_auto_merge_message"`
+	CreatedBy int64            `db:"pullreq_auto_merge_created_by"`
+	Created   int64            `db:"pullreq_auto_merge_created"`
+}
+
+const (
+	pullReqAutoMergeColumns = `
+		 pullreq_auto_merge_pullreq_id
+		,pullreq_auto_merge_repo_id
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_source_sha
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message
+		,pullreq_auto_merge_created_by
+		,pullreq_auto_merge_created`
+
+	pullReqAutoMergeSelectBase = `
+	SELECT` + pullReqAutoMergeColumns + `
+	FROM pullreq_auto_merges`
+)
+
+// Upsert enables the auto-merge of a pull request, or replaces it if it's already enabled.
+func (s *PullReqAutoMergeStore) Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_auto_merges (` + pullReqAutoMergeColumns + `
+	) VALUES (
+		 :pullreq_auto_merge_pullreq_id
+		,:pullreq_auto_merge_repo_id
+		,:pullreq_auto_merge_method
+		,:pullreq_auto_merge_source_sha
+		,:pullreq_auto_merge_title
+		,:pullreq_auto_merge_message
+		,:pullreq_auto_merge_created_by
+		,:pullreq_auto_merge_created
+	)
+	ON CONFLICT (pullreq_auto_merge_pullreq_id) DO
+	UPDATE SET
+		 pullreq_auto_merge_method = :pullreq_auto_merge_method
+		,pullreq_auto_merge_source_sha = :pullreq_auto_merge_source_sha
+		,pullreq_auto_merge_title = :pullreq_auto_merge_title
+		,pullreq_auto_merge_message = :pullreq_auto_merge_message
+		,pullreq_auto_merge_created_by = :pullreq_auto_merge_created_by
+		,pullreq_auto_merge_created = :pullreq_auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqAutoMerge(autoMerge))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request auto-merge object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Find finds the auto-merge of a pull request.
+func (s *PullReqAutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, error) {
+	const sqlQuery = pullReqAutoMergeSelectBase + `
+	WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqAutoMerge{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request auto-merge")
+	}
+
+	return mapPullReqAutoMerge(dst), nil
+}
+
+// Delete disables the auto-merge of a pull request. It returns false if the auto-merge wasn't enabled.
+func (s *PullReqAutoMergeStore) Delete(ctx context.Context, pullReqID int64) (bool, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_auto_merges
+	WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request auto-merge")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
+
+// List lists the auto-merges with pull request ID greater than the provided one, ordered by pull request ID.
+func (s *PullReqAutoMergeStore) List(
+	ctx context.Context,
+	afterPullReqID int64,
+	limit int,
+) ([]*types.PullReqAutoMerge, error) {
+	stmt := database.Builder.
+		Select(pullReqAutoMergeColumns).
+		From("pullreq_auto_merges").
+		Where("pullreq_auto_merge_pullreq_id > ?", afterPullReqID).
+		OrderBy("pullreq_auto_merge_pullreq_id").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReqAutoMerge, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request auto-merges")
+	}
+
+	result := make([]*types.PullReqAutoMerge, len(dst))
+	for i, autoMerge := range dst {
+		result[i] = mapPullReqAutoMerge(autoMerge)
+	}
+
+	return result, nil
+}
+
+func mapToInternalPullReqAutoMerge(autoMerge *types.PullReqAutoMerge) *pullReqAutoMerge {
+	return &pullReqAutoMerge{
+		PullReqID: autoMerge.PullReqID,
+		RepoID:    autoMerge.RepoID,
+		Method:    autoMerge.Method,
+		SourceSHA: autoMerge.SourceSHA,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+		CreatedBy: autoMerge.CreatedBy,
+		Created:   autoMerge.Created,
+	}
+}
+
+func mapPullReqAutoMerge(autoMerge *pullReqAutoMerge) *types.PullReqAutoMerge {
+	return &types.PullReqAutoMerge{
+		PullReqID: autoMerge.PullReqID,
+		RepoID:    autoMerge.RepoID,
+		Method:    autoMerge.Method,
+		SourceSHA: autoMerge.SourceSHA,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+		CreatedBy: autoMerge.CreatedBy,
+		Created:   autoMerge.Created,
+	}
+}
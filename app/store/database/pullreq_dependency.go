@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqDependencyStore = (*PullReqDependencyStore)(nil)
+
+// NewPullReqDependencyStore returns a new PullReqDependencyStore.
+func NewPullReqDependencyStore(db *sqlx.DB) *PullReqDependencyStore {
+	return &PullReqDependencyStore{
+		db: db,
+	}
+}
+
+// PullReqDependencyStore implements store.PullReqDependencyStore backed by a relational database.
+type PullReqDependencyStore struct {
+	db *sqlx.DB
+}
+
+type pullReqDependency struct {
+	PullReqID   int64 `db:"pullreq_dependency_pullreq_id"`
+	DependsOnID int64 `db:"pullreq_dependency_depends_on_id"`
+	CreatedBy   int64 `db:"pullreq_dependency_created_by"`
+	Created     int64 `db:"pullreq_dependency_created"`
+}
+
+const (
+	pullReqDependencyColumns = `
+		 pullreq_dependency_pullreq_id
+		,pullreq_dependency_depends_on_id
+		,pullreq_dependency_created_by
+		,pullreq_dependency_created`
+)
+
+// Create declares that a pull request depends on another pull request.
+func (s *PullReqDependencyStore) Create(ctx context.Context, dependency *types.PullReqDependency) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_dependencies (` + pullReqDependencyColumns + `
+	) VALUES (
+		 :pullreq_dependency_pullreq_id
+		,:pullreq_dependency_depends_on_id
+		,:pullreq_dependency_created_by
+		,:pullreq_dependency_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqDependency(dependency))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request dependency object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the dependency of a pull request on another pull request.
+// It returns false if the dependency doesn't exist.
+func (s *PullReqDependencyStore) Delete(ctx context.Context, pullReqID, dependsOnID int64) (bool, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_dependencies
+	WHERE pullreq_dependency_pullreq_id = $1 AND pullreq_dependency_depends_on_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID, dependsOnID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request dependency")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
+
+// List lists the dependencies of the provided pull requests.
+func (s *PullReqDependencyStore) List(ctx context.Context, pullReqIDs []int64) ([]*types.PullReqDependency, error) {
+	stmt := database.Builder.
+		Select(pullReqDependencyColumns).
+		From("pullreq_dependencies").
+		Where(squirrel.Eq{"pullreq_dependency_pullreq_id": pullReqIDs}).
+		OrderBy("pullreq_dependency_pullreq_id", "pullreq_dependency_depends_on_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReqDependency, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request dependencies")
+	}
+
+	result := make([]*types.PullReqDependency, len(dst))
+	for i, dependency := range dst {
+		result[i] = mapPullReqDependency(dependency)
+	}
+
+	return result, nil
+}
+
+func mapToInternalPullReqDependency(dependency *types.PullReqDependency) *pullReqDependency {
+	return &pullReqDependency{
+		PullReqID:   dependency.PullReqID,
+		DependsOnID: dependency.DependsOnID,
+		CreatedBy:   dependency.CreatedBy,
+		Created:     dependency.Created,
+	}
+}
+
+func mapPullReqDependency(dependency *pullReqDependency) *types.PullReqDependency {
+	return &types.PullReqDependency{
+		PullReqID:   dependency.PullReqID,
+		DependsOnID: dependency.DependsOnID,
+		CreatedBy:   dependency.CreatedBy,
+		Created:     dependency.Created,
+	}
+}
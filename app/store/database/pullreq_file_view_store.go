@@ -48,9 +48,10 @@ type pullReqFileView struct {
 	PullReqID   int64 `db:"pullreq_file_view_pullreq_id"`
 	PrincipalID int64 `db:"pullreq_file_view_principal_id"`
 
-	Path     string `db:"pullreq_file_view_path"`
-	SHA      string `db:"pullreq_file_view_sha"`
-	Obsolete bool   `db:"pullreq_file_view_obsolete"`
+	Path      string `db:"pullreq_file_view_path"`
+	SHA       string `db:"pullreq_file_view_sha"`
+	CommitSHA string `db:"pullreq_file_view_commit_sha"`
+	Obsolete  bool   `db:"pullreq_file_view_obsolete"`
 
 	Created int64 `db:"pullreq_file_view_created"`
 	Updated int64 `db:"pullreq_file_view_updated"`
@@ -62,6 +63,7 @@ const (
 		,pullreq_file_view_principal_id
 		,pullreq_file_view_path
 		,pullreq_file_view_sha
+		,pullreq_file_view_commit_sha
 		,pullreq_file_view_obsolete
 		,pullreq_file_view_created
 		,pullreq_file_view_updated`
@@ -75,6 +77,7 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 		,pullreq_file_view_principal_id
 		,pullreq_file_view_path
 		,pullreq_file_view_sha
+		,pullreq_file_view_commit_sha
 		,pullreq_file_view_obsolete
 		,pullreq_file_view_created
 		,pullreq_file_view_updated
@@ -83,6 +86,7 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 		,:pullreq_file_view_principal_id
 		,:pullreq_file_view_path
 		,:pullreq_file_view_sha
+		,:pullreq_file_view_commit_sha
 		,:pullreq_file_view_obsolete
 		,:pullreq_file_view_created
 		,:pullreq_file_view_updated
@@ -91,6 +95,7 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 	UPDATE SET
 		 pullreq_file_view_updated = :pullreq_file_view_updated
 		,pullreq_file_view_sha = :pullreq_file_view_sha
+		,pullreq_file_view_commit_sha = :pullreq_file_view_commit_sha
 		,pullreq_file_view_obsolete = :pullreq_file_view_obsolete
 	RETURNING pullreq_file_view_created`
 
@@ -187,6 +192,7 @@ func mapToInternalPullreqFileView(view *types.PullReqFileView) *pullReqFileView
 		PrincipalID: view.PrincipalID,
 		Path:        view.Path,
 		SHA:         view.SHA,
+		CommitSHA:   view.CommitSHA,
 		Obsolete:    view.Obsolete,
 		Created:     view.Created,
 		Updated:     view.Updated,
@@ -199,6 +205,7 @@ func mapToPullreqFileView(view *pullReqFileView) *types.PullReqFileView {
 		PrincipalID: view.PrincipalID,
 		Path:        view.Path,
 		SHA:         view.SHA,
+		CommitSHA:   view.CommitSHA,
 		Obsolete:    view.Obsolete,
 		Created:     view.Created,
 		Updated:     view.Updated,
@@ -0,0 +1,411 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqReviewSLAStore = (*PullReqReviewSLAStore)(nil)
+
+// NewPullReqReviewSLAStore returns a new PullReqReviewSLAStore.
+func NewPullReqReviewSLAStore(db *sqlx.DB) *PullReqReviewSLAStore {
+	return &PullReqReviewSLAStore{
+		db: db,
+	}
+}
+
+// PullReqReviewSLAStore implements store.PullReqReviewSLAStore backed by a relational database.
+type PullReqReviewSLAStore struct {
+	db *sqlx.DB
+}
+
+type pullReqReviewSLA struct {
+	PullReqID    int64    `db:"pullreq_review_sla_pullreq_id"`
+	RepoID       int64    `db:"pullreq_review_sla_repo_id"`
+	Started      int64    `db:"pullreq_review_sla_started"`
+	WarnAt       int64    `db:"pullreq_review_sla_warn_at"`
+	DueAt        int64    `db:"pullreq_review_sla_due_at"`
+	Responded    null.Int `db:"pullreq_review_sla_responded"`
+	ResponseTime null.Int `db:"pullreq_review_sla_response_time"`
+	Closed       null.Int `db:"pullreq_review_sla_closed"`
+	Warned       null.Int `db:"pullreq_review_sla_warned"`
+	Breached     null.Int `db:"pullreq_review_sla_breached"`
+}
+
+type reviewSLAStats struct {
+	Met             int64   `db:"met"`
+	Breached        int64   `db:"breached"`
+	Pending         int64   `db:"pending"`
+	AvgResponseTime float64 `db:"avg_response_time"`
+}
+
+type reviewSLATeamStats struct {
+	UserGroupIdentifier  null.String `db:"usergroup_identifier"`
+	UserGroupName        null.String `db:"usergroup_name"`
+	UserGroupDescription null.String `db:"usergroup_description"`
+	reviewSLAStats
+}
+
+const (
+	pullReqReviewSLAColumns = `
+		 pullreq_review_sla_pullreq_id
+		,pullreq_review_sla_repo_id
+		,pullreq_review_sla_started
+		,pullreq_review_sla_warn_at
+		,pullreq_review_sla_due_at
+		,pullreq_review_sla_responded
+		,pullreq_review_sla_response_time
+		,pullreq_review_sla_closed
+		,pullreq_review_sla_warned
+		,pullreq_review_sla_breached`
+
+	pullReqReviewSLASelectBase = `
+	SELECT` + pullReqReviewSLAColumns + `
+	FROM pullreq_review_slas`
+)
+
+// Upsert inserts the review SLA of a pull request, or restarts it if it already exists.
+func (s *PullReqReviewSLAStore) Upsert(ctx context.Context, sla *types.PullReqReviewSLA) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_review_slas (` + pullReqReviewSLAColumns + `
+	) VALUES (
+		 :pullreq_review_sla_pullreq_id
+		,:pullreq_review_sla_repo_id
+		,:pullreq_review_sla_started
+		,:pullreq_review_sla_warn_at
+		,:pullreq_review_sla_due_at
+		,:pullreq_review_sla_responded
+		,:pullreq_review_sla_response_time
+		,:pullreq_review_sla_closed
+		,:pullreq_review_sla_warned
+		,:pullreq_review_sla_breached
+	)
+	ON CONFLICT (pullreq_review_sla_pullreq_id) DO
+	UPDATE SET
+		 pullreq_review_sla_repo_id = :pullreq_review_sla_repo_id
+		,pullreq_review_sla_started = :pullreq_review_sla_started
+		,pullreq_review_sla_warn_at = :pullreq_review_sla_warn_at
+		,pullreq_review_sla_due_at = :pullreq_review_sla_due_at
+		,pullreq_review_sla_responded = :pullreq_review_sla_responded
+		,pullreq_review_sla_response_time = :pullreq_review_sla_response_time
+		,pullreq_review_sla_closed = :pullreq_review_sla_closed
+		,pullreq_review_sla_warned = :pullreq_review_sla_warned
+		,pullreq_review_sla_breached = :pullreq_review_sla_breached`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqReviewSLA(sla))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request review SLA object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Find finds the review SLA of a pull request.
+func (s *PullReqReviewSLAStore) Find(ctx context.Context, pullReqID int64) (*types.PullReqReviewSLA, error) {
+	const sqlQuery = pullReqReviewSLASelectBase + `
+	WHERE pullreq_review_sla_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqReviewSLA{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request review SLA")
+	}
+
+	return mapPullReqReviewSLA(dst), nil
+}
+
+// MarkResponded sets the time of the first response to the pull request, unless it's already set.
+// It returns true if the review SLA got updated.
+func (s *PullReqReviewSLAStore) MarkResponded(
+	ctx context.Context,
+	pullReqID int64,
+	responded int64,
+	responseTime int64,
+) (bool, error) {
+	stmt := database.Builder.
+		Update("pullreq_review_slas").
+		Set("pullreq_review_sla_responded", responded).
+		Set("pullreq_review_sla_response_time", responseTime).
+		Where("pullreq_review_sla_pullreq_id = ?", pullReqID).
+		Where("pullreq_review_sla_responded IS NULL").
+		Where("pullreq_review_sla_closed IS NULL")
+
+	return s.update(ctx, stmt)
+}
+
+// MarkClosed sets the time the pull request got closed, unless it's already set.
+func (s *PullReqReviewSLAStore) MarkClosed(ctx context.Context, pullReqID int64, closed int64) error {
+	stmt := database.Builder.
+		Update("pullreq_review_slas").
+		Set("pullreq_review_sla_closed", closed).
+		Where("pullreq_review_sla_pullreq_id = ?", pullReqID).
+		Where("pullreq_review_sla_closed IS NULL")
+
+	_, err := s.update(ctx, stmt)
+	return err
+}
+
+// MarkWarned sets the time the review SLA warning got reported, unless it's already set.
+// It returns true if the review SLA got updated.
+func (s *PullReqReviewSLAStore) MarkWarned(ctx context.Context, pullReqID int64, warned int64) (bool, error) {
+	stmt := database.Builder.
+		Update("pullreq_review_slas").
+		Set("pullreq_review_sla_warned", warned).
+		Where("pullreq_review_sla_pullreq_id = ?", pullReqID).
+		Where("pullreq_review_sla_warned IS NULL").
+		Where("pullreq_review_sla_responded IS NULL").
+		Where("pullreq_review_sla_closed IS NULL")
+
+	return s.update(ctx, stmt)
+}
+
+// MarkBreached sets the time the review SLA breach got reported, unless it's already set.
+// It returns true if the review SLA got updated.
+func (s *PullReqReviewSLAStore) MarkBreached(ctx context.Context, pullReqID int64, breached int64) (bool, error) {
+	stmt := database.Builder.
+		Update("pullreq_review_slas").
+		Set("pullreq_review_sla_breached", breached).
+		Where("pullreq_review_sla_pullreq_id = ?", pullReqID).
+		Where("pullreq_review_sla_breached IS NULL").
+		Where("pullreq_review_sla_responded IS NULL").
+		Where("pullreq_review_sla_closed IS NULL")
+
+	return s.update(ctx, stmt)
+}
+
+func (s *PullReqReviewSLAStore) update(ctx context.Context, stmt squirrel.UpdateBuilder) (bool, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to update pull request review SLA")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	return count > 0, nil
+}
+
+// ListAlertable lists review SLAs of open pull requests, still waiting for their first response,
+// which reached their warning or due time without it being reported.
+func (s *PullReqReviewSLAStore) ListAlertable(
+	ctx context.Context,
+	now int64,
+	limit int,
+) ([]*types.PullReqReviewSLA, error) {
+	stmt := database.Builder.
+		Select(pullReqReviewSLAColumns).
+		From("pullreq_review_slas").
+		Where("pullreq_review_sla_responded IS NULL").
+		Where("pullreq_review_sla_closed IS NULL").
+		Where("pullreq_review_sla_breached IS NULL").
+		Where(squirrel.Or{
+			squirrel.LtOrEq{"pullreq_review_sla_due_at": now},
+			squirrel.And{
+				squirrel.Expr("pullreq_review_sla_warned IS NULL"),
+				squirrel.Gt{"pullreq_review_sla_warn_at": 0},
+				squirrel.LtOrEq{"pullreq_review_sla_warn_at": now},
+			},
+		}).
+		OrderBy("pullreq_review_sla_due_at").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReqReviewSLA, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list alertable pull request review SLAs")
+	}
+
+	result := make([]*types.PullReqReviewSLA, len(dst))
+	for i, sla := range dst {
+		result[i] = mapPullReqReviewSLA(sla)
+	}
+
+	return result, nil
+}
+
+// Report returns the review SLA compliance of the pull requests of the repositories in the provided spaces,
+// in total and grouped by the user groups requested as reviewers.
+func (s *PullReqReviewSLAStore) Report(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter types.ReviewSLAReportFilter,
+	now int64,
+) (*types.ReviewSLAReport, error) {
+	statsColumns := squirrel.Expr(reviewSLAStatsColumns, now, now)
+
+	totalStmt := database.Builder.
+		Select().
+		Column(statsColumns).
+		From("pullreq_review_slas")
+	totalStmt = applyReviewSLAReportFilter(totalStmt, spaceIDs, filter)
+
+	teamStmt := database.Builder.
+		Select("usergroup_identifier", "usergroup_name", "usergroup_description").
+		Column(statsColumns).
+		From("pullreq_review_slas").
+		LeftJoin("usergroup_reviewers ON usergroup_reviewer_pullreq_id = pullreq_review_sla_pullreq_id").
+		LeftJoin("usergroups ON usergroup_id = usergroup_reviewer_usergroup_id").
+		GroupBy("usergroup_id", "usergroup_identifier", "usergroup_name", "usergroup_description").
+		OrderBy("usergroup_identifier")
+	teamStmt = applyReviewSLAReportFilter(teamStmt, spaceIDs, filter)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	sql, args, err := totalStmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	total := &reviewSLAStats{}
+	if err = db.GetContext(ctx, total, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to get total review SLA stats")
+	}
+
+	sql, args, err = teamStmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	teams := make([]*reviewSLATeamStats, 0)
+	if err = db.SelectContext(ctx, &teams, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to get review SLA stats per team")
+	}
+
+	report := &types.ReviewSLAReport{
+		From:  filter.From,
+		To:    filter.To,
+		Total: mapReviewSLAStats(total),
+		Teams: make([]types.ReviewSLATeamStats, len(teams)),
+	}
+
+	for i, team := range teams {
+		report.Teams[i].ReviewSLAStats = mapReviewSLAStats(&team.reviewSLAStats)
+		if team.UserGroupIdentifier.Valid {
+			report.Teams[i].Team = &types.UserGroupInfo{
+				Identifier:  team.UserGroupIdentifier.String,
+				Name:        team.UserGroupName.String,
+				Description: team.UserGroupDescription.String,
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// reviewSLAStatsColumns are the aggregate columns of the review SLA stats, parametrized with the current time.
+// A review SLA is met if the first response came in time, and breached if it came late or
+// if it didn't come before the due time while the pull request was open.
+const reviewSLAStatsColumns = `
+	 COALESCE(SUM(CASE WHEN pullreq_review_sla_responded IS NOT NULL
+		AND pullreq_review_sla_responded <= pullreq_review_sla_due_at THEN 1 ELSE 0 END), 0) AS met
+	,COALESCE(SUM(CASE WHEN (pullreq_review_sla_responded IS NOT NULL
+		AND pullreq_review_sla_responded > pullreq_review_sla_due_at)
+		OR (pullreq_review_sla_responded IS NULL
+		AND pullreq_review_sla_due_at <= COALESCE(pullreq_review_sla_closed, ?)) THEN 1 ELSE 0 END), 0) AS breached
+	,COALESCE(SUM(CASE WHEN pullreq_review_sla_responded IS NULL
+		AND pullreq_review_sla_closed IS NULL
+		AND pullreq_review_sla_due_at > ? THEN 1 ELSE 0 END), 0) AS pending
+	,COALESCE(AVG(pullreq_review_sla_response_time), 0) AS avg_response_time`
+
+func applyReviewSLAReportFilter(
+	stmt squirrel.SelectBuilder,
+	spaceIDs []int64,
+	filter types.ReviewSLAReportFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.
+		InnerJoin("repositories ON repo_id = pullreq_review_sla_repo_id").
+		Where("repo_deleted IS NULL").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs})
+
+	if filter.From > 0 {
+		stmt = stmt.Where("pullreq_review_sla_started >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		stmt = stmt.Where("pullreq_review_sla_started < ?", filter.To)
+	}
+
+	return stmt
+}
+
+func mapToInternalPullReqReviewSLA(sla *types.PullReqReviewSLA) *pullReqReviewSLA {
+	return &pullReqReviewSLA{
+		PullReqID:    sla.PullReqID,
+		RepoID:       sla.RepoID,
+		Started:      sla.Started,
+		WarnAt:       sla.WarnAt,
+		DueAt:        sla.DueAt,
+		Responded:    null.IntFromPtr(sla.Responded),
+		ResponseTime: null.IntFromPtr(sla.ResponseTime),
+		Closed:       null.IntFromPtr(sla.Closed),
+		Warned:       null.IntFromPtr(sla.Warned),
+		Breached:     null.IntFromPtr(sla.Breached),
+	}
+}
+
+func mapPullReqReviewSLA(sla *pullReqReviewSLA) *types.PullReqReviewSLA {
+	return &types.PullReqReviewSLA{
+		PullReqID:    sla.PullReqID,
+		RepoID:       sla.RepoID,
+		Started:      sla.Started,
+		WarnAt:       sla.WarnAt,
+		DueAt:        sla.DueAt,
+		Responded:    sla.Responded.Ptr(),
+		ResponseTime: sla.ResponseTime.Ptr(),
+		Closed:       sla.Closed.Ptr(),
+		Warned:       sla.Warned.Ptr(),
+		Breached:     sla.Breached.Ptr(),
+	}
+}
+
+func mapReviewSLAStats(stats *reviewSLAStats) types.ReviewSLAStats {
+	return types.ReviewSLAStats{
+		Met:             stats.Met,
+		Breached:        stats.Breached,
+		Pending:         stats.Pending,
+		AvgResponseTime: int64(stats.AvgResponseTime),
+	}
+}
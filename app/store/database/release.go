@@ -0,0 +1,387 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ReleaseStore = (*ReleaseStore)(nil)
+
+// NewReleaseStore returns a new ReleaseStore.
+func NewReleaseStore(db *sqlx.DB) *ReleaseStore {
+	return &ReleaseStore{
+		db: db,
+	}
+}
+
+// ReleaseStore implements store.ReleaseStore backed by a relational database.
+type ReleaseStore struct {
+	db *sqlx.DB
+}
+
+type release struct {
+	ID      int64 `db:"release_id"`
+	Version int64 `db:"release_version"`
+	RepoID  int64 `db:"release_repo_id"`
+
+	TagName string `db:"release_tag_name"`
+	Title   string `db:"release_title"`
+	Notes   string `db:"release_notes"`
+
+	IsDraft      bool     `db:"release_is_draft"`
+	IsPrerelease bool     `db:"release_is_prerelease"`
+	Published    null.Int `db:"release_published"`
+
+	CreatedBy int64 `db:"release_created_by"`
+	Created   int64 `db:"release_created"`
+	Updated   int64 `db:"release_updated"`
+}
+
+const (
+	releaseColumns = `
+		 release_id
+		,release_version
+		,release_repo_id
+		,release_tag_name
+		,release_title
+		,release_notes
+		,release_is_draft
+		,release_is_prerelease
+		,release_published
+		,release_created_by
+		,release_created
+		,release_updated`
+
+	releaseSelectBase = `
+	SELECT` + releaseColumns + `
+	FROM releases`
+)
+
+// Find finds the release by id.
+func (s *ReleaseStore) Find(ctx context.Context, id int64) (*types.Release, error) {
+	const sqlQuery = releaseSelectBase + `
+	WHERE release_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &release{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find release")
+	}
+
+	return mapRelease(dst), nil
+}
+
+// FindInRepo finds the release by id and verifies that it belongs to the repository.
+func (s *ReleaseStore) FindInRepo(ctx context.Context, repoID, id int64) (*types.Release, error) {
+	const sqlQuery = releaseSelectBase + `
+	WHERE release_repo_id = $1 AND release_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &release{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find release in repo")
+	}
+
+	return mapRelease(dst), nil
+}
+
+// FindByTag finds the release of the tag.
+func (s *ReleaseStore) FindByTag(ctx context.Context, repoID int64, tagName string) (*types.Release, error) {
+	const sqlQuery = releaseSelectBase + `
+	WHERE release_repo_id = $1 AND release_tag_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &release{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, tagName); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find release by tag")
+	}
+
+	return mapRelease(dst), nil
+}
+
+// Create creates a new release.
+func (s *ReleaseStore) Create(ctx context.Context, in *types.Release) error {
+	const sqlQuery = `
+	INSERT INTO releases (
+		 release_version
+		,release_repo_id
+		,release_tag_name
+		,release_title
+		,release_notes
+		,release_is_draft
+		,release_is_prerelease
+		,release_published
+		,release_created_by
+		,release_created
+		,release_updated
+	) values (
+		 :release_version
+		,:release_repo_id
+		,:release_tag_name
+		,:release_title
+		,:release_notes
+		,:release_is_draft
+		,:release_is_prerelease
+		,:release_published
+		,:release_created_by
+		,:release_created
+		,:release_updated
+	) RETURNING release_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRelease(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind release object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the release.
+func (s *ReleaseStore) Update(ctx context.Context, in *types.Release) error {
+	const sqlQuery = `
+	UPDATE releases
+	SET
+		 release_version = :release_version
+		,release_updated = :release_updated
+		,release_tag_name = :release_tag_name
+		,release_title = :release_title
+		,release_notes = :release_notes
+		,release_is_draft = :release_is_draft
+		,release_is_prerelease = :release_is_prerelease
+		,release_published = :release_published
+	WHERE release_id = :release_id AND release_version = :release_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRelease := mapInternalRelease(in)
+	dbRelease.Version++
+	dbRelease.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRelease)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind release object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update release")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	in.Version = dbRelease.Version
+	in.Updated = dbRelease.Updated
+
+	return nil
+}
+
+// UpdateOptLock the release details using the optimistic locking mechanism.
+func (s *ReleaseStore) UpdateOptLock(ctx context.Context, in *types.Release,
+	mutateFn func(release *types.Release) error,
+) (*types.Release, error) {
+	for {
+		dup := *in
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		in, err = s.Find(ctx, in.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the release together with its assets.
+func (s *ReleaseStore) Delete(ctx context.Context, id int64) error {
+	const (
+		sqlQueryAssets = `
+		DELETE FROM release_assets
+		WHERE asset_release_id = $1`
+
+		sqlQuery = `
+		DELETE FROM releases
+		WHERE release_id = $1`
+	)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	// The foreign keys would take care of this in postgres, but they aren't enforced in sqlite.
+	if _, err := db.ExecContext(ctx, sqlQueryAssets, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete release assets")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete release")
+	}
+
+	return nil
+}
+
+// Count of releases in a repository.
+func (s *ReleaseStore) Count(ctx context.Context, repoID int64, opts *types.ReleaseFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("releases")
+
+	stmt = applyReleaseFilter(stmt, repoID, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of releases in a repository.
+// Releases are ordered by the publish time, the most recent first. Drafts are listed before all published releases.
+func (s *ReleaseStore) List(
+	ctx context.Context,
+	repoID int64,
+	opts *types.ReleaseFilter,
+) ([]*types.Release, error) {
+	stmt := database.Builder.
+		Select(releaseColumns).
+		From("releases")
+
+	stmt = applyReleaseFilter(stmt, repoID, opts)
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy("CASE WHEN release_published IS NULL THEN 0 ELSE 1 END",
+		"release_published DESC", "release_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*release, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	result := make([]*types.Release, len(dst))
+	for i, r := range dst {
+		result[i] = mapRelease(r)
+	}
+
+	return result, nil
+}
+
+func applyReleaseFilter(
+	stmt squirrel.SelectBuilder,
+	repoID int64,
+	opts *types.ReleaseFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.Where("release_repo_id = ?", repoID)
+
+	if !opts.IncludeDrafts {
+		stmt = stmt.Where("release_is_draft = ?", false)
+	}
+
+	if opts.Query != "" {
+		query := fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query))
+		stmt = stmt.Where("(LOWER(release_title) LIKE ? OR LOWER(release_tag_name) LIKE ?)", query, query)
+	}
+
+	return stmt
+}
+
+func mapRelease(in *release) *types.Release {
+	return &types.Release{
+		ID:           in.ID,
+		Version:      in.Version,
+		RepoID:       in.RepoID,
+		TagName:      in.TagName,
+		Title:        in.Title,
+		Notes:        in.Notes,
+		IsDraft:      in.IsDraft,
+		IsPrerelease: in.IsPrerelease,
+		Published:    in.Published.Ptr(),
+		CreatedBy:    in.CreatedBy,
+		Created:      in.Created,
+		Updated:      in.Updated,
+		Assets:       []*types.ReleaseAsset{},
+	}
+}
+
+func mapInternalRelease(in *types.Release) *release {
+	return &release{
+		ID:           in.ID,
+		Version:      in.Version,
+		RepoID:       in.RepoID,
+		TagName:      in.TagName,
+		Title:        in.Title,
+		Notes:        in.Notes,
+		IsDraft:      in.IsDraft,
+		IsPrerelease: in.IsPrerelease,
+		Published:    null.IntFromPtr(in.Published),
+		CreatedBy:    in.CreatedBy,
+		Created:      in.Created,
+		Updated:      in.Updated,
+	}
+}
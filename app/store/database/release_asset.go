@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ReleaseAssetStore = (*ReleaseAssetStore)(nil)
+
+// NewReleaseAssetStore returns a new ReleaseAssetStore.
+func NewReleaseAssetStore(db *sqlx.DB) *ReleaseAssetStore {
+	return &ReleaseAssetStore{
+		db: db,
+	}
+}
+
+// ReleaseAssetStore implements store.ReleaseAssetStore backed by a relational database.
+type ReleaseAssetStore struct {
+	db *sqlx.DB
+}
+
+type releaseAsset struct {
+	ID          int64  `db:"asset_id"`
+	ReleaseID   int64  `db:"asset_release_id"`
+	RepoID      int64  `db:"asset_repo_id"`
+	Name        string `db:"asset_name"`
+	FileName    string `db:"asset_file_name"`
+	ContentType string `db:"asset_content_type"`
+	Size        int64  `db:"asset_size"`
+	CreatedBy   int64  `db:"asset_created_by"`
+	Created     int64  `db:"asset_created"`
+}
+
+const (
+	releaseAssetColumns = `
+		 asset_id
+		,asset_release_id
+		,asset_repo_id
+		,asset_name
+		,asset_file_name
+		,asset_content_type
+		,asset_size
+		,asset_created_by
+		,asset_created`
+)
+
+// FindInRelease finds the asset by id and verifies that it belongs to the release.
+func (s *ReleaseAssetStore) FindInRelease(ctx context.Context, releaseID, id int64) (*types.ReleaseAsset, error) {
+	const sqlQuery = `
+	SELECT` + releaseAssetColumns + `
+	FROM release_assets
+	WHERE asset_release_id = $1 AND asset_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &releaseAsset{}
+	if err := db.GetContext(ctx, dst, sqlQuery, releaseID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find release asset")
+	}
+
+	return mapReleaseAsset(dst), nil
+}
+
+// Create saves the release asset details.
+func (s *ReleaseAssetStore) Create(ctx context.Context, a *types.ReleaseAsset) error {
+	const sqlQuery = `
+	INSERT INTO release_assets (
+		 asset_release_id
+		,asset_repo_id
+		,asset_name
+		,asset_file_name
+		,asset_content_type
+		,asset_size
+		,asset_created_by
+		,asset_created
+	) values (
+		 :asset_release_id
+		,:asset_repo_id
+		,:asset_name
+		,:asset_file_name
+		,:asset_content_type
+		,:asset_size
+		,:asset_created_by
+		,:asset_created
+	) RETURNING asset_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalReleaseAsset(a))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind release asset object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the release asset details.
+func (s *ReleaseAssetStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM release_assets
+	WHERE asset_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete release asset")
+	}
+
+	return nil
+}
+
+// ListByReleases returns the assets of the releases.
+func (s *ReleaseAssetStore) ListByReleases(ctx context.Context, releaseIDs []int64) ([]*types.ReleaseAsset, error) {
+	if len(releaseIDs) == 0 {
+		return []*types.ReleaseAsset{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(releaseAssetColumns).
+		From("release_assets").
+		Where(squirrel.Eq{"asset_release_id": releaseIDs}).
+		OrderBy("asset_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ListByRepo returns the assets of all releases of the repository.
+func (s *ReleaseAssetStore) ListByRepo(ctx context.Context, repoID int64) ([]*types.ReleaseAsset, error) {
+	stmt := database.Builder.
+		Select(releaseAssetColumns).
+		From("release_assets").
+		Where("asset_repo_id = ?", repoID).
+		OrderBy("asset_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *ReleaseAssetStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.ReleaseAsset, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*releaseAsset
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list release assets query")
+	}
+
+	result := make([]*types.ReleaseAsset, len(dst))
+	for i, a := range dst {
+		result[i] = mapReleaseAsset(a)
+	}
+
+	return result, nil
+}
+
+func mapReleaseAsset(in *releaseAsset) *types.ReleaseAsset {
+	return &types.ReleaseAsset{
+		ID:          in.ID,
+		ReleaseID:   in.ReleaseID,
+		RepoID:      in.RepoID,
+		Name:        in.Name,
+		FileName:    in.FileName,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+	}
+}
+
+func mapInternalReleaseAsset(in *types.ReleaseAsset) *releaseAsset {
+	return &releaseAsset{
+		ID:          in.ID,
+		ReleaseID:   in.ReleaseID,
+		RepoID:      in.RepoID,
+		Name:        in.Name,
+		FileName:    in.FileName,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+	}
+}
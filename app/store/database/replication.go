@@ -0,0 +1,582 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ReplicationStore = (*ReplicationStore)(nil)
+
+// NewReplicationStore returns a new ReplicationStore.
+func NewReplicationStore(db *sqlx.DB) *ReplicationStore {
+	return &ReplicationStore{
+		db: db,
+	}
+}
+
+// ReplicationStore implements store.ReplicationStore backed by a relational database.
+type ReplicationStore struct {
+	db *sqlx.DB
+}
+
+type replicationRecord struct {
+	ID         int64    `db:"replication_record_id"`
+	Seq        null.Int `db:"replication_record_seq"`
+	RepoID     int64    `db:"replication_record_repo_id"`
+	RepoGitUID string   `db:"replication_record_repo_git_uid"`
+	Ref        string   `db:"replication_record_ref"`
+	OldSHA     string   `db:"replication_record_old_sha"`
+	NewSHA     string   `db:"replication_record_new_sha"`
+	Created    int64    `db:"replication_record_created"`
+
+	PackAvailable bool `db:"replication_record_pack_available"`
+}
+
+type replicationConsumer struct {
+	ID          int64  `db:"replication_consumer_id"`
+	Identifier  string `db:"replication_consumer_identifier"`
+	Description string `db:"replication_consumer_description"`
+	AckedSeq    int64  `db:"replication_consumer_acked_seq"`
+	Acked       int64  `db:"replication_consumer_acked"`
+	CreatedBy   int64  `db:"replication_consumer_created_by"`
+	Created     int64  `db:"replication_consumer_created"`
+	Updated     int64  `db:"replication_consumer_updated"`
+}
+
+const (
+	replicationRecordColumns = `
+		 replication_record_id
+		,replication_record_seq
+		,replication_record_repo_id
+		,replication_record_repo_git_uid
+		,replication_record_ref
+		,replication_record_old_sha
+		,replication_record_new_sha
+		,replication_record_created
+		,replication_record_pack_available`
+
+	replicationConsumerColumns = `
+		 replication_consumer_id
+		,replication_consumer_identifier
+		,replication_consumer_description
+		,replication_consumer_acked_seq
+		,replication_consumer_acked
+		,replication_consumer_created_by
+		,replication_consumer_created
+		,replication_consumer_updated`
+
+	replicationConsumerSelectBase = `
+	SELECT` + replicationConsumerColumns + `
+	FROM replication_consumers`
+)
+
+// CreateRecord creates a new replication record.
+func (s *ReplicationStore) CreateRecord(ctx context.Context, record *types.ReplicationRecord) error {
+	const sqlQuery = `
+	INSERT INTO replication_records (
+		 replication_record_repo_id
+		,replication_record_repo_git_uid
+		,replication_record_ref
+		,replication_record_old_sha
+		,replication_record_new_sha
+		,replication_record_created
+		,replication_record_pack_available
+	) VALUES (
+		 :replication_record_repo_id
+		,:replication_record_repo_git_uid
+		,:replication_record_ref
+		,:replication_record_old_sha
+		,:replication_record_new_sha
+		,:replication_record_created
+		,:replication_record_pack_available
+	) RETURNING replication_record_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalReplicationRecord(record))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind replication record object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&record.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// MarkPackAvailable marks the replication records of the repository matching the provided reference updates
+// as having their pack available. All records are updated with a single query.
+// It returns the number of updated records.
+func (s *ReplicationStore) MarkPackAvailable(
+	ctx context.Context,
+	repoID int64,
+	records []*types.ReplicationRecord,
+) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	refUpdates := make(squirrel.Or, len(records))
+	for i, record := range records {
+		refUpdates[i] = squirrel.Eq{
+			"replication_record_ref":     record.Ref,
+			"replication_record_old_sha": record.OldSHA,
+			"replication_record_new_sha": record.NewSHA,
+		}
+	}
+
+	stmt := database.Builder.
+		Update("replication_records").
+		Set("replication_record_pack_available", true).
+		Where("replication_record_repo_id = ?", repoID).
+		Where("replication_record_pack_available = ?", false).
+		Where(refUpdates)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to mark replication records as available")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated replication records")
+	}
+
+	return n, nil
+}
+
+// ListStaleRecords lists the replication records without available pack created before the provided time.
+func (s *ReplicationStore) ListStaleRecords(
+	ctx context.Context,
+	createdBefore int64,
+	limit int,
+) ([]*types.ReplicationRecord, error) {
+	stmt := database.Builder.
+		Select(replicationRecordColumns).
+		From("replication_records").
+		Where("replication_record_pack_available = ?", false).
+		Where("replication_record_created < ?", createdBefore).
+		OrderBy("replication_record_id").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*replicationRecord, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list stale replication records")
+	}
+
+	result := make([]*types.ReplicationRecord, len(dst))
+	for i, record := range dst {
+		result[i] = mapReplicationRecord(record)
+	}
+
+	return result, nil
+}
+
+// HasSuccessor returns true if a later replication record with available pack
+// continues from the new SHA of the provided record.
+func (s *ReplicationStore) HasSuccessor(ctx context.Context, record *types.ReplicationRecord) (bool, error) {
+	const sqlQuery = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM replication_records
+		WHERE replication_record_repo_id = $1
+			AND replication_record_ref = $2
+			AND replication_record_old_sha = $3
+			AND replication_record_id > $4
+			AND replication_record_pack_available = TRUE
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var exists bool
+	err := db.QueryRowContext(ctx, sqlQuery, record.RepoID, record.Ref, record.NewSHA, record.ID).Scan(&exists)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to find successor of replication record")
+	}
+
+	return exists, nil
+}
+
+// DeleteRecord deletes the replication record.
+func (s *ReplicationStore) DeleteRecord(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM replication_records
+	WHERE replication_record_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete replication record")
+	}
+
+	return nil
+}
+
+// SequenceRecords assigns sequence numbers to the replication records with available pack
+// that don't have one yet, in the order of their IDs. A record is skipped while an earlier record
+// of the same reference is still waiting for its pack, so the updates of a reference are always
+// sequenced in order. All records are updated with a single query.
+// It returns the number of sequenced records.
+// The caller must make sure it's never executed concurrently.
+func (s *ReplicationStore) SequenceRecords(ctx context.Context, limit int) (int, error) {
+	const sqlQueryLatest = `
+	SELECT COALESCE(MAX(replication_record_seq), 0)
+	FROM replication_records`
+
+	const sqlQueryPending = `
+	SELECT r.replication_record_id
+	FROM replication_records r
+	WHERE r.replication_record_seq IS NULL
+		AND r.replication_record_pack_available = TRUE
+		AND NOT EXISTS (
+			SELECT 1
+			FROM replication_records p
+			WHERE p.replication_record_repo_id = r.replication_record_repo_id
+				AND p.replication_record_ref = r.replication_record_ref
+				AND p.replication_record_pack_available = FALSE
+				AND p.replication_record_id < r.replication_record_id
+		)
+	ORDER BY r.replication_record_id
+	LIMIT $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var seq int64
+	if err := db.QueryRowContext(ctx, sqlQueryLatest).Scan(&seq); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get latest replication record sequence number")
+	}
+
+	ids := make([]int64, 0, limit)
+	if err := db.SelectContext(ctx, &ids, sqlQueryPending, limit); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to list unsequenced replication records")
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	seqCase := squirrel.Case("replication_record_id")
+	for _, id := range ids {
+		seq++
+		seqCase = seqCase.When(squirrel.Expr("?", id), squirrel.Expr("CAST(? AS BIGINT)", seq))
+	}
+
+	stmt := database.Builder.
+		Update("replication_records").
+		Set("replication_record_seq", seqCase).
+		Where(squirrel.Eq{"replication_record_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to sequence replication records")
+	}
+
+	return len(ids), nil
+}
+
+// ListRecords lists the sequenced replication records in the order of their sequence numbers.
+func (s *ReplicationStore) ListRecords(
+	ctx context.Context,
+	filter types.ReplicationRecordFilter,
+) ([]*types.ReplicationRecord, error) {
+	stmt := database.Builder.
+		Select(replicationRecordColumns).
+		From("replication_records").
+		Where("replication_record_seq > ?", filter.After).
+		OrderBy("replication_record_seq").
+		Limit(uint64(filter.Limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*replicationRecord, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list replication records")
+	}
+
+	result := make([]*types.ReplicationRecord, len(dst))
+	for i, record := range dst {
+		result[i] = mapReplicationRecord(record)
+	}
+
+	return result, nil
+}
+
+// LatestSeq returns the sequence number of the latest sequenced replication record, 0 if there are none.
+func (s *ReplicationStore) LatestSeq(ctx context.Context) (int64, error) {
+	const sqlQuery = `
+	SELECT COALESCE(MAX(replication_record_seq), 0)
+	FROM replication_records`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var seq int64
+	if err := db.QueryRowContext(ctx, sqlQuery).Scan(&seq); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get latest replication record sequence number")
+	}
+
+	return seq, nil
+}
+
+// Lag returns the lag of a consumer that acknowledged all records up to the provided sequence number.
+// Records that aren't sequenced yet count as not acknowledged.
+func (s *ReplicationStore) Lag(ctx context.Context, ackedSeq int64, now int64) (types.ReplicationLag, error) {
+	const sqlQuery = `
+	SELECT
+		 COUNT(*)
+		,COALESCE(MIN(replication_record_created), 0)
+	FROM replication_records
+	WHERE replication_record_seq > $1 OR replication_record_seq IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count, oldest int64
+	if err := db.QueryRowContext(ctx, sqlQuery, ackedSeq).Scan(&count, &oldest); err != nil {
+		return types.ReplicationLag{}, database.ProcessSQLErrorf(ctx, err, "Failed to get replication lag")
+	}
+
+	lag := types.ReplicationLag{Records: count}
+	if count > 0 {
+		lag.Time = now - oldest
+	}
+
+	return lag, nil
+}
+
+// DeleteRecords deletes replication records created before the provided time,
+// up to the provided sequence number. Unsequenced records are never deleted.
+func (s *ReplicationStore) DeleteRecords(ctx context.Context, createdBefore int64, maxSeq int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM replication_records
+	WHERE replication_record_created < $1 AND replication_record_seq <= $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, createdBefore, maxSeq)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete replication records")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted replication records")
+	}
+
+	return n, nil
+}
+
+// CreateConsumer creates a new replication consumer.
+func (s *ReplicationStore) CreateConsumer(ctx context.Context, consumer *types.ReplicationConsumer) error {
+	const sqlQuery = `
+	INSERT INTO replication_consumers (
+		 replication_consumer_identifier
+		,replication_consumer_description
+		,replication_consumer_acked_seq
+		,replication_consumer_acked
+		,replication_consumer_created_by
+		,replication_consumer_created
+		,replication_consumer_updated
+	) VALUES (
+		 :replication_consumer_identifier
+		,:replication_consumer_description
+		,:replication_consumer_acked_seq
+		,:replication_consumer_acked
+		,:replication_consumer_created_by
+		,:replication_consumer_created
+		,:replication_consumer_updated
+	) RETURNING replication_consumer_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalReplicationConsumer(consumer))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind replication consumer object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&consumer.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// FindConsumerByIdentifier finds the replication consumer by its identifier.
+func (s *ReplicationStore) FindConsumerByIdentifier(
+	ctx context.Context,
+	identifier string,
+) (*types.ReplicationConsumer, error) {
+	const sqlQuery = replicationConsumerSelectBase + `
+	WHERE LOWER(replication_consumer_identifier) = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &replicationConsumer{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find replication consumer")
+	}
+
+	return mapReplicationConsumer(dst), nil
+}
+
+// ListConsumers lists all replication consumers.
+func (s *ReplicationStore) ListConsumers(ctx context.Context) ([]*types.ReplicationConsumer, error) {
+	const sqlQuery = replicationConsumerSelectBase + `
+	ORDER BY replication_consumer_identifier`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*replicationConsumer, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list replication consumers")
+	}
+
+	result := make([]*types.ReplicationConsumer, len(dst))
+	for i, consumer := range dst {
+		result[i] = mapReplicationConsumer(consumer)
+	}
+
+	return result, nil
+}
+
+// AckConsumer moves the acknowledged sequence number of the replication consumer forward.
+// It returns false if the consumer already acknowledged the provided sequence number.
+func (s *ReplicationStore) AckConsumer(ctx context.Context, id int64, seq int64, acked int64) (bool, error) {
+	const sqlQuery = `
+	UPDATE replication_consumers
+	SET
+		 replication_consumer_acked_seq = $1
+		,replication_consumer_acked = $2
+		,replication_consumer_updated = $2
+	WHERE replication_consumer_id = $3 AND replication_consumer_acked_seq < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, seq, acked, id)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to acknowledge replication record")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	return n > 0, nil
+}
+
+// DeleteConsumer deletes the replication consumer.
+func (s *ReplicationStore) DeleteConsumer(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM replication_consumers
+	WHERE replication_consumer_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete replication consumer")
+	}
+
+	return nil
+}
+
+func mapToInternalReplicationRecord(record *types.ReplicationRecord) *replicationRecord {
+	return &replicationRecord{
+		ID:         record.ID,
+		Seq:        null.NewInt(record.Seq, record.Seq > 0),
+		RepoID:     record.RepoID,
+		RepoGitUID: record.RepoGitUID,
+		Ref:        record.Ref,
+		OldSHA:     record.OldSHA,
+		NewSHA:     record.NewSHA,
+		Created:    record.Created,
+
+		PackAvailable: record.PackAvailable,
+	}
+}
+
+func mapReplicationRecord(record *replicationRecord) *types.ReplicationRecord {
+	return &types.ReplicationRecord{
+		ID:         record.ID,
+		Seq:        record.Seq.Int64,
+		RepoID:     record.RepoID,
+		RepoGitUID: record.RepoGitUID,
+		Ref:        record.Ref,
+		OldSHA:     record.OldSHA,
+		NewSHA:     record.NewSHA,
+		Created:    record.Created,
+
+		PackAvailable: record.PackAvailable,
+	}
+}
+
+func mapToInternalReplicationConsumer(consumer *types.ReplicationConsumer) *replicationConsumer {
+	return &replicationConsumer{
+		ID:          consumer.ID,
+		Identifier:  consumer.Identifier,
+		Description: consumer.Description,
+		AckedSeq:    consumer.AckedSeq,
+		Acked:       consumer.Acked,
+		CreatedBy:   consumer.CreatedBy,
+		Created:     consumer.Created,
+		Updated:     consumer.Updated,
+	}
+}
+
+func mapReplicationConsumer(consumer *replicationConsumer) *types.ReplicationConsumer {
+	return &types.ReplicationConsumer{
+		ID:          consumer.ID,
+		Identifier:  consumer.Identifier,
+		Description: consumer.Description,
+		AckedSeq:    consumer.AckedSeq,
+		Acked:       consumer.Acked,
+		CreatedBy:   consumer.CreatedBy,
+		Created:     consumer.Created,
+		Updated:     consumer.Updated,
+	}
+}
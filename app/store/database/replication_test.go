@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestReplicationStore_SequenceRecords(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	replicationStore := database.NewReplicationStore(db)
+
+	create := func(ref, oldSHA, newSHA string, packAvailable bool) *types.ReplicationRecord {
+		record := &types.ReplicationRecord{
+			RepoID:        1,
+			RepoGitUID:    "repo",
+			Ref:           ref,
+			OldSHA:        oldSHA,
+			NewSHA:        newSHA,
+			Created:       1,
+			PackAvailable: packAvailable,
+		}
+		if err := replicationStore.CreateRecord(ctx, record); err != nil {
+			t.Fatalf("CreateRecord() error = %v", err)
+		}
+		return record
+	}
+
+	sequence := func(want int) {
+		n, err := replicationStore.SequenceRecords(ctx, 10)
+		if err != nil {
+			t.Fatalf("SequenceRecords() error = %v", err)
+		}
+		if n != want {
+			t.Errorf("SequenceRecords() = %d, want %d", n, want)
+		}
+	}
+
+	listRefs := func() []string {
+		records, err := replicationStore.ListRecords(ctx, types.ReplicationRecordFilter{Limit: 10})
+		if err != nil {
+			t.Fatalf("ListRecords() error = %v", err)
+		}
+		refs := make([]string, len(records))
+		for i, record := range records {
+			if record.Seq != int64(i+1) {
+				t.Errorf("record %d has seq %d, want %d", i, record.Seq, i+1)
+			}
+			if !record.PackAvailable {
+				t.Errorf("record %d is listed without available pack", i)
+			}
+			refs[i] = record.Ref + ":" + record.NewSHA
+		}
+		return refs
+	}
+
+	// the push of main is still in progress, the later update of main has to wait for it.
+	mainPending := create("refs/heads/main", "a", "b", false)
+	create("refs/heads/main", "b", "c", true)
+	create("refs/heads/dev", "a", "d", true)
+
+	sequence(1)
+	if refs, want := listRefs(), []string{"refs/heads/dev:d"}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("listed records = %v, want %v", refs, want)
+	}
+
+	tagPending := create("refs/tags/v1", "0", "e", false)
+
+	n, err := replicationStore.MarkPackAvailable(ctx, 1, []*types.ReplicationRecord{
+		{Ref: mainPending.Ref, OldSHA: mainPending.OldSHA, NewSHA: mainPending.NewSHA},
+		{Ref: tagPending.Ref, OldSHA: tagPending.OldSHA, NewSHA: tagPending.NewSHA},
+		{Ref: "refs/heads/unknown", OldSHA: "a", NewSHA: "b"},
+	})
+	if err != nil {
+		t.Fatalf("MarkPackAvailable() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("MarkPackAvailable() = %d, want 2", n)
+	}
+
+	sequence(3)
+	want := []string{"refs/heads/dev:d", "refs/heads/main:b", "refs/heads/main:c", "refs/tags/v1:e"}
+	if refs := listRefs(); !reflect.DeepEqual(refs, want) {
+		t.Errorf("listed records = %v, want %v", refs, want)
+	}
+
+	sequence(0)
+}
+
+func TestReplicationStore_StaleRecords(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	replicationStore := database.NewReplicationStore(db)
+
+	records := []*types.ReplicationRecord{
+		{Ref: "refs/heads/main", OldSHA: "a", NewSHA: "b", Created: 1},
+		{Ref: "refs/heads/main", OldSHA: "b", NewSHA: "c", Created: 2, PackAvailable: true},
+		{Ref: "refs/heads/dev", OldSHA: "a", NewSHA: "d", Created: 3},
+		{Ref: "refs/heads/feature", OldSHA: "a", NewSHA: "f", Created: 10},
+	}
+	for _, record := range records {
+		record.RepoID = 1
+		record.RepoGitUID = "repo"
+		if err := replicationStore.CreateRecord(ctx, record); err != nil {
+			t.Fatalf("CreateRecord() error = %v", err)
+		}
+	}
+
+	stale, err := replicationStore.ListStaleRecords(ctx, 5, 10)
+	if err != nil {
+		t.Fatalf("ListStaleRecords() error = %v", err)
+	}
+	if len(stale) != 2 || stale[0].ID != records[0].ID || stale[1].ID != records[2].ID {
+		t.Fatalf("ListStaleRecords() = %+v, want records %d and %d", stale, records[0].ID, records[2].ID)
+	}
+
+	for _, test := range []struct {
+		record *types.ReplicationRecord
+		want   bool
+	}{
+		{record: records[0], want: true},
+		{record: records[2], want: false},
+	} {
+		successor, err := replicationStore.HasSuccessor(ctx, test.record)
+		if err != nil {
+			t.Fatalf("HasSuccessor() error = %v", err)
+		}
+		if successor != test.want {
+			t.Errorf("HasSuccessor(%s) = %t, want %t", test.record.Ref, successor, test.want)
+		}
+	}
+
+	if err = replicationStore.DeleteRecord(ctx, records[2].ID); err != nil {
+		t.Fatalf("DeleteRecord() error = %v", err)
+	}
+
+	stale, err = replicationStore.ListStaleRecords(ctx, 5, 10)
+	if err != nil {
+		t.Fatalf("ListStaleRecords() error = %v", err)
+	}
+	if len(stale) != 1 || stale[0].ID != records[0].ID {
+		t.Errorf("ListStaleRecords() = %+v, want record %d", stale, records[0].ID)
+	}
+}
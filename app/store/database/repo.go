@@ -17,12 +17,14 @@ package database
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git/sha"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -43,21 +45,24 @@ func NewRepoStore(
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
 	spaceStore store.SpaceStore,
+	repoRedirectStore store.RepoRedirectStore,
 ) *RepoStore {
 	return &RepoStore{
-		db:             db,
-		spacePathCache: spacePathCache,
-		spacePathStore: spacePathStore,
-		spaceStore:     spaceStore,
+		db:                db,
+		spacePathCache:    spacePathCache,
+		spacePathStore:    spacePathStore,
+		spaceStore:        spaceStore,
+		repoRedirectStore: repoRedirectStore,
 	}
 }
 
 // RepoStore implements a store.RepoStore backed by a relational database.
 type RepoStore struct {
-	db             *sqlx.DB
-	spacePathCache store.SpacePathCache
-	spacePathStore store.SpacePathStore
-	spaceStore     store.SpaceStore
+	db                *sqlx.DB
+	spacePathCache    store.SpacePathCache
+	spacePathStore    store.SpacePathStore
+	spaceStore        store.SpaceStore
+	repoRedirectStore store.RepoRedirectStore
 }
 
 type repository struct {
@@ -76,11 +81,15 @@ type repository struct {
 	SizeUpdated int64 `db:"repo_size_updated"`
 
 	GitUID        string `db:"repo_git_uid"`
+	StoragePool   string `db:"repo_storage_pool"`
+	ObjectFormat  string `db:"repo_object_format"`
 	DefaultBranch string `db:"repo_default_branch"`
 	ForkID        int64  `db:"repo_fork_id"`
 	PullReqSeq    int64  `db:"repo_pullreq_seq"`
+	IssueSeq      int64  `db:"repo_issue_seq"`
 
 	NumForks       int `db:"repo_num_forks"`
+	NumStars       int `db:"repo_num_stars"`
 	NumPulls       int `db:"repo_num_pulls"`
 	NumClosedPulls int `db:"repo_num_closed_pulls"`
 	NumOpenPulls   int `db:"repo_num_open_pulls"`
@@ -88,6 +97,10 @@ type repository struct {
 
 	State   enum.RepoState `db:"repo_state"`
 	IsEmpty bool           `db:"repo_is_empty"`
+
+	Archived   bool   `db:"repo_archived"`
+	IsTemplate bool   `db:"repo_is_template"`
+	Topics     string `db:"repo_topics"`
 }
 
 const (
@@ -104,16 +117,23 @@ const (
 		,repo_size
 		,repo_size_updated
 		,repo_git_uid
+		,repo_storage_pool
+		,repo_object_format
 		,repo_default_branch
 		,repo_pullreq_seq
+		,repo_issue_seq
 		,repo_fork_id
 		,repo_num_forks
+		,repo_num_stars
 		,repo_num_pulls
 		,repo_num_closed_pulls
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
 		,repo_state
-		,repo_is_empty`
+		,repo_is_empty
+		,repo_archived
+		,repo_is_template
+		,repo_topics`
 )
 
 // Find finds the repo by id.
@@ -194,7 +214,21 @@ func (s *RepoStore) findByRef(ctx context.Context, repoRef string, deletedAt *in
 			return nil, fmt.Errorf("failed to get space path: %w", err)
 		}
 
-		return s.findByIdentifier(ctx, pathObject.SpaceID, repoIdentifier, deletedAt)
+		repo, err := s.findByIdentifier(ctx, pathObject.SpaceID, repoIdentifier, deletedAt)
+		if deletedAt == nil && errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the path might be the former path of a moved repository.
+			redirectRepoID, rErr := s.repoRedirectStore.FindRepoID(ctx, pathObject.SpaceID, repoIdentifier)
+			if errors.Is(rErr, gitness_store.ErrResourceNotFound) {
+				return nil, err
+			}
+			if rErr != nil {
+				return nil, fmt.Errorf("failed to find repo redirect: %w", rErr)
+			}
+
+			return s.find(ctx, redirectRepoID, nil)
+		}
+
+		return repo, err
 	}
 	return s.find(ctx, id, deletedAt)
 }
@@ -228,16 +262,23 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_size
 			,repo_size_updated	
 			,repo_git_uid
+			,repo_storage_pool
+			,repo_object_format
 			,repo_default_branch
 			,repo_fork_id
 			,repo_pullreq_seq
+			,repo_issue_seq
 			,repo_num_forks
+			,repo_num_stars
 			,repo_num_pulls
 			,repo_num_closed_pulls
 			,repo_num_open_pulls
 			,repo_num_merged_pulls
 			,repo_state
 			,repo_is_empty
+			,repo_archived
+			,repo_is_template
+			,repo_topics
 		) values (
 			:repo_version
 			,:repo_parent_id
@@ -250,16 +291,23 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_size
 			,:repo_size_updated
 			,:repo_git_uid
+			,:repo_storage_pool
+			,:repo_object_format
 			,:repo_default_branch
 			,:repo_fork_id
 			,:repo_pullreq_seq
+			,:repo_issue_seq
 			,:repo_num_forks
+			,:repo_num_stars
 			,:repo_num_pulls
 			,:repo_num_closed_pulls
 			,:repo_num_open_pulls
 			,:repo_num_merged_pulls
 			,:repo_state
 			,:repo_is_empty
+			,:repo_archived
+			,:repo_is_template
+			,:repo_topics
 		) RETURNING repo_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -293,16 +341,22 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_parent_id = :repo_parent_id
 			,repo_uid = :repo_uid
 			,repo_git_uid = :repo_git_uid
+			,repo_storage_pool = :repo_storage_pool
 			,repo_description = :repo_description
 			,repo_default_branch = :repo_default_branch
 			,repo_pullreq_seq = :repo_pullreq_seq
+			,repo_issue_seq = :repo_issue_seq
 			,repo_num_forks = :repo_num_forks
+			,repo_num_stars = :repo_num_stars
 			,repo_num_pulls = :repo_num_pulls
 			,repo_num_closed_pulls = :repo_num_closed_pulls
 			,repo_num_open_pulls = :repo_num_open_pulls
 			,repo_num_merged_pulls = :repo_num_merged_pulls
 			,repo_state = :repo_state
 			,repo_is_empty = :repo_is_empty
+			,repo_archived = :repo_archived
+			,repo_is_template = :repo_is_template
+			,repo_topics = :repo_topics
 		WHERE repo_id = :repo_id AND repo_version = :repo_version - 1`
 
 	dbRepo := mapToInternalRepo(repo)
@@ -388,6 +442,23 @@ func (s *RepoStore) GetSize(ctx context.Context, id int64) (int64, error) {
 	return size, nil
 }
 
+// SumSize returns the total size (in KiB) of the active repos of the space and all its sub-spaces.
+func (s *RepoStore) SumSize(ctx context.Context, spaceID int64) (int64, error) {
+	query := spaceDescendantsQuery + `
+		SELECT COALESCE(SUM(repo_size), 0)
+		FROM repositories
+		WHERE repo_parent_id IN (SELECT space_descendant_id FROM space_descendants)
+			AND repo_deleted IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var size int64
+	if err := db.GetContext(ctx, &size, query, spaceID); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to sum repo sizes")
+	}
+	return size, nil
+}
+
 // UpdateOptLock updates the active repository using the optimistic locking mechanism.
 func (s *RepoStore) UpdateOptLock(
 	ctx context.Context,
@@ -722,6 +793,76 @@ func (s *RepoStore) listAll(
 	return s.mapToRepos(ctx, repos)
 }
 
+// Search returns a list of repos matching the filter regardless of their parent space.
+func (s *RepoStore) Search(
+	ctx context.Context,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories")
+
+	stmt = applyQueryFilter(stmt, filter)
+	stmt = applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom search query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListForkGitInfos returns the git infos of all forks (including deleted ones) of the repo.
+// If no repo ID is provided, the git infos of all forks are returned.
+func (s *RepoStore) ListForkGitInfos(ctx context.Context, repoID *int64) ([]*types.RepositoryGitInfo, error) {
+	stmt := database.Builder.
+		Select("repo_id", "repo_parent_id", "repo_git_uid").
+		From("repositories")
+
+	if repoID != nil {
+		stmt = stmt.Where("repo_fork_id = ?", *repoID)
+	} else {
+		stmt = stmt.Where("repo_fork_id > 0")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoGitInfo{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list fork git infos query")
+	}
+
+	res := make([]*types.RepositoryGitInfo, len(dst))
+	for i := range dst {
+		res[i] = &types.RepositoryGitInfo{
+			ID:       dst[i].ID,
+			ParentID: dst[i].ParentID,
+			GitUID:   dst[i].GitUID,
+		}
+	}
+
+	return res, nil
+}
+
+type repoGitInfo struct {
+	ID       int64  `db:"repo_id"`
+	ParentID int64  `db:"repo_parent_id"`
+	GitUID   string `db:"repo_git_uid"`
+}
+
 type repoSize struct {
 	ID          int64  `db:"repo_id"`
 	GitUID      string `db:"repo_git_uid"`
@@ -750,6 +891,75 @@ func (s *RepoStore) ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeI
 	return s.mapToRepoSizes(dst), nil
 }
 
+// ListTopics returns the topics used by the repositories of the space, the most used topics first.
+func (s *RepoStore) ListTopics(
+	ctx context.Context,
+	parentID int64,
+	filter *types.RepoTopicFilter,
+) ([]types.RepoTopic, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	spaceIDs := []int64{parentID}
+	if filter.Recursive {
+		query := spaceDescendantsQuery + `
+		SELECT space_descendant_id
+		FROM space_descendants`
+
+		if err := db.SelectContext(ctx, &spaceIDs, query, parentID); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "failed to retrieve spaces")
+		}
+	}
+
+	stmt := database.Builder.
+		Select("repo_topics").
+		From("repositories").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs}).
+		Where("repo_deleted IS NULL").
+		Where("repo_topics <> ''")
+
+	query := strings.ToLower(filter.Query)
+	if query != "" {
+		stmt = stmt.Where("(',' || repo_topics) LIKE ?", "%"+topicsSeparator+query+"%")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	var dst []string
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list topics query")
+	}
+
+	counts := make(map[string]int)
+	for _, topics := range dst {
+		for _, topic := range topicsFromString(topics) {
+			if strings.HasPrefix(topic, query) {
+				counts[topic]++
+			}
+		}
+	}
+
+	res := make([]types.RepoTopic, 0, len(counts))
+	for name, count := range counts {
+		res = append(res, types.RepoTopic{Name: name, Count: count})
+	}
+
+	slices.SortFunc(res, func(a, b types.RepoTopic) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if filter.Size > 0 && len(res) > filter.Size {
+		res = res[:filter.Size]
+	}
+
+	return res, nil
+}
+
 func (s *RepoStore) mapToRepo(
 	ctx context.Context,
 	in *repository,
@@ -768,16 +978,23 @@ func (s *RepoStore) mapToRepo(
 		Size:           in.Size,
 		SizeUpdated:    in.SizeUpdated,
 		GitUID:         in.GitUID,
+		StoragePool:    in.StoragePool,
+		ObjectFormat:   sha.ObjectFormat(in.ObjectFormat),
 		DefaultBranch:  in.DefaultBranch,
 		ForkID:         in.ForkID,
 		PullReqSeq:     in.PullReqSeq,
+		IssueSeq:       in.IssueSeq,
 		NumForks:       in.NumForks,
+		NumStars:       in.NumStars,
 		NumPulls:       in.NumPulls,
 		NumClosedPulls: in.NumClosedPulls,
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
 		State:          in.State,
 		IsEmpty:        in.IsEmpty,
+		Archived:       in.Archived,
+		IsTemplate:     in.IsTemplate,
+		Topics:         topicsFromString(in.Topics),
 		// Path: is set below
 	}
 
@@ -851,16 +1068,23 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		Size:           in.Size,
 		SizeUpdated:    in.SizeUpdated,
 		GitUID:         in.GitUID,
+		StoragePool:    in.StoragePool,
+		ObjectFormat:   string(in.ObjectFormat),
 		DefaultBranch:  in.DefaultBranch,
 		ForkID:         in.ForkID,
 		PullReqSeq:     in.PullReqSeq,
+		IssueSeq:       in.IssueSeq,
 		NumForks:       in.NumForks,
+		NumStars:       in.NumStars,
 		NumPulls:       in.NumPulls,
 		NumClosedPulls: in.NumClosedPulls,
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
 		State:          in.State,
 		IsEmpty:        in.IsEmpty,
+		Archived:       in.Archived,
+		IsTemplate:     in.IsTemplate,
+		Topics:         topicsToString(in.Topics),
 	}
 }
 
@@ -869,6 +1093,15 @@ func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squ
 		stmt = stmt.Where("LOWER(repo_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
 	}
 	//nolint:gocritic
+	if filter.Archived != nil {
+		stmt = stmt.Where("repo_archived = ?", *filter.Archived)
+	}
+	if filter.Topic != "" {
+		// topics are stored comma separated, surround them with the separator to match complete topics only.
+		stmt = stmt.Where("(',' || repo_topics || ',') LIKE ?",
+			"%"+topicsSeparator+strings.ToLower(filter.Topic)+topicsSeparator+"%")
+	}
+
 	if filter.DeletedAt != nil {
 		stmt = stmt.Where("repo_deleted = ?", filter.DeletedAt)
 	} else if filter.DeletedBeforeOrAt != nil {
@@ -896,7 +1129,25 @@ func applySortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squi
 		stmt = stmt.OrderBy("repo_updated " + filter.Order.String())
 	case enum.RepoAttrDeleted:
 		stmt = stmt.OrderBy("repo_deleted " + filter.Order.String())
+	case enum.RepoAttrStars:
+		stmt = stmt.OrderBy("repo_num_stars "+filter.Order.String(), "repo_uid")
 	}
 
 	return stmt
 }
+
+// topicsSeparator defines the character that's used to join topics for storing them in the DB.
+// ASSUMPTION: topics are validated and don't contain ",".
+const topicsSeparator = ","
+
+func topicsFromString(topicsString string) []string {
+	if topicsString == "" {
+		return []string{}
+	}
+
+	return strings.Split(topicsString, topicsSeparator)
+}
+
+func topicsToString(topics []string) string {
+	return strings.Join(topics, topicsSeparator)
+}
@@ -0,0 +1,391 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoInsightsStore = (*RepoInsightsStore)(nil)
+
+// insightsBatchSize defines the max number of rows inserted by a single query.
+const insightsBatchSize = 100
+
+// NewRepoInsightsStore returns a new RepoInsightsStore.
+func NewRepoInsightsStore(db *sqlx.DB) *RepoInsightsStore {
+	return &RepoInsightsStore{
+		db: db,
+	}
+}
+
+// RepoInsightsStore implements store.RepoInsightsStore backed by a relational database.
+type RepoInsightsStore struct {
+	db *sqlx.DB
+}
+
+type repoInsightsState struct {
+	RepoID    int64  `db:"repo_insight_repo_id"`
+	Stale     bool   `db:"repo_insight_stale"`
+	Branch    string `db:"repo_insight_branch"`
+	CommitSHA string `db:"repo_insight_commit_sha"`
+	Created   int64  `db:"repo_insight_created"`
+	Updated   int64  `db:"repo_insight_updated"`
+	Processed int64  `db:"repo_insight_processed"`
+}
+
+const (
+	repoInsightsColumns = `
+		 repo_insight_repo_id
+		,repo_insight_stale
+		,repo_insight_branch
+		,repo_insight_commit_sha
+		,repo_insight_created
+		,repo_insight_updated
+		,repo_insight_processed`
+
+	repoInsightsSelectBase = `
+	SELECT` + repoInsightsColumns + `
+	FROM repo_insights`
+)
+
+// Find finds the insights state of a repository.
+func (s *RepoInsightsStore) Find(ctx context.Context, repoID int64) (*types.RepoInsightsState, error) {
+	const sqlQuery = repoInsightsSelectBase + `
+	WHERE repo_insight_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoInsightsState{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repository insights")
+	}
+
+	return mapRepoInsightsState(dst), nil
+}
+
+// MarkStale marks the insights of a repository for recomputation, creating the state if it doesn't exist.
+func (s *RepoInsightsStore) MarkStale(ctx context.Context, repoID int64, now int64) error {
+	const sqlQuery = `
+	INSERT INTO repo_insights (` + repoInsightsColumns + `
+	) VALUES ($1, TRUE, '', '', $2, $2, 0)
+	ON CONFLICT (repo_insight_repo_id) DO
+	UPDATE SET
+		 repo_insight_stale = TRUE
+		,repo_insight_updated = EXCLUDED.repo_insight_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, now); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark repository insights as stale")
+	}
+
+	return nil
+}
+
+// ClearStale removes the stale mark of the insights of a repository.
+func (s *RepoInsightsStore) ClearStale(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	UPDATE repo_insights
+	SET repo_insight_stale = FALSE
+	WHERE repo_insight_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to clear stale mark of repository insights")
+	}
+
+	return nil
+}
+
+// ListStale lists the insights states of repositories marked for recomputation
+// with repository ID greater than the provided one, ordered by repository ID.
+func (s *RepoInsightsStore) ListStale(
+	ctx context.Context,
+	afterRepoID int64,
+	limit int,
+) ([]*types.RepoInsightsState, error) {
+	stmt := database.Builder.
+		Select(repoInsightsColumns).
+		From("repo_insights").
+		Where("repo_insight_stale").
+		Where("repo_insight_repo_id > ?", afterRepoID).
+		OrderBy("repo_insight_repo_id").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*repoInsightsState, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list stale repository insights")
+	}
+
+	result := make([]*types.RepoInsightsState, len(dst))
+	for i, state := range dst {
+		result[i] = mapRepoInsightsState(state)
+	}
+
+	return result, nil
+}
+
+// UpdateProcessed stores the branch and the commit up to which the commit statistics are computed.
+func (s *RepoInsightsStore) UpdateProcessed(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+	commitSHA string,
+	processed int64,
+) error {
+	const sqlQuery = `
+	UPDATE repo_insights
+	SET
+		 repo_insight_branch = $2
+		,repo_insight_commit_sha = $3
+		,repo_insight_updated = $4
+		,repo_insight_processed = $4
+	WHERE repo_insight_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, branch, commitSHA, processed); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update processed repository insights")
+	}
+
+	return nil
+}
+
+// DeleteCommitStats deletes all commit statistics of a repository.
+func (s *RepoInsightsStore) DeleteCommitStats(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_insight_commits
+	WHERE repo_insight_commit_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repository commit statistics")
+	}
+
+	return nil
+}
+
+// AddCommitStats adds the provided values to the weekly commit statistics of the authors.
+// The provided statistics must not contain the same week and author more than once.
+func (s *RepoInsightsStore) AddCommitStats(
+	ctx context.Context,
+	repoID int64,
+	stats []*types.RepoInsightsCommitStats,
+) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	for start := 0; start < len(stats); start += insightsBatchSize {
+		end := min(start+insightsBatchSize, len(stats))
+
+		stmt := database.Builder.
+			Insert("repo_insight_commits").
+			Columns(
+				"repo_insight_commit_repo_id",
+				"repo_insight_commit_week",
+				"repo_insight_commit_author_email",
+				"repo_insight_commit_author_name",
+				"repo_insight_commit_commits",
+				"repo_insight_commit_additions",
+				"repo_insight_commit_deletions",
+			)
+
+		for _, stat := range stats[start:end] {
+			stmt = stmt.Values(repoID, stat.Week, stat.AuthorEmail, stat.AuthorName,
+				stat.Commits, stat.Additions, stat.Deletions)
+		}
+
+		stmt = stmt.Suffix(`
+		ON CONFLICT (repo_insight_commit_repo_id, repo_insight_commit_week, repo_insight_commit_author_email) DO
+		UPDATE SET
+			 repo_insight_commit_author_name = EXCLUDED.repo_insight_commit_author_name
+			,repo_insight_commit_commits =
+				repo_insight_commits.repo_insight_commit_commits + EXCLUDED.repo_insight_commit_commits
+			,repo_insight_commit_additions =
+				repo_insight_commits.repo_insight_commit_additions + EXCLUDED.repo_insight_commit_additions
+			,repo_insight_commit_deletions =
+				repo_insight_commits.repo_insight_commit_deletions + EXCLUDED.repo_insight_commit_deletions`)
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+		}
+
+		if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to add repository commit statistics")
+		}
+	}
+
+	return nil
+}
+
+// AddPullReqStats adds the provided values to the weekly pull request statistics.
+// The provided statistics must not contain the same week more than once.
+func (s *RepoInsightsStore) AddPullReqStats(
+	ctx context.Context,
+	repoID int64,
+	stats []types.RepoInsightsPullReqStats,
+) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	for start := 0; start < len(stats); start += insightsBatchSize {
+		end := min(start+insightsBatchSize, len(stats))
+
+		stmt := database.Builder.
+			Insert("repo_insight_pullreqs").
+			Columns(
+				"repo_insight_pullreq_repo_id",
+				"repo_insight_pullreq_week",
+				"repo_insight_pullreq_opened",
+				"repo_insight_pullreq_merged",
+				"repo_insight_pullreq_closed",
+			)
+
+		for _, stat := range stats[start:end] {
+			stmt = stmt.Values(repoID, stat.Week, stat.Opened, stat.Merged, stat.Closed)
+		}
+
+		stmt = stmt.Suffix(`
+		ON CONFLICT (repo_insight_pullreq_repo_id, repo_insight_pullreq_week) DO
+		UPDATE SET
+			 repo_insight_pullreq_opened =
+				repo_insight_pullreqs.repo_insight_pullreq_opened + EXCLUDED.repo_insight_pullreq_opened
+			,repo_insight_pullreq_merged =
+				repo_insight_pullreqs.repo_insight_pullreq_merged + EXCLUDED.repo_insight_pullreq_merged
+			,repo_insight_pullreq_closed =
+				repo_insight_pullreqs.repo_insight_pullreq_closed + EXCLUDED.repo_insight_pullreq_closed`)
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+		}
+
+		if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to add repository pull request statistics")
+		}
+	}
+
+	return nil
+}
+
+// ListCommitActivity returns the weekly commit activity of a repository, starting with the provided week.
+func (s *RepoInsightsStore) ListCommitActivity(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+) ([]types.RepoInsightsWeek, error) {
+	const sqlQuery = `
+	SELECT
+		 repo_insight_commit_week AS "week"
+		,SUM(repo_insight_commit_commits) AS "commits"
+		,SUM(repo_insight_commit_additions) AS "additions"
+		,SUM(repo_insight_commit_deletions) AS "deletions"
+	FROM repo_insight_commits
+	WHERE repo_insight_commit_repo_id = $1 AND repo_insight_commit_week >= $2
+	GROUP BY repo_insight_commit_week
+	ORDER BY repo_insight_commit_week`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := make([]types.RepoInsightsWeek, 0)
+	if err := db.SelectContext(ctx, &result, sqlQuery, repoID, since); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repository commit activity")
+	}
+
+	return result, nil
+}
+
+// ListContributors returns the authors with the most commits, starting with the provided week.
+func (s *RepoInsightsStore) ListContributors(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+	limit int,
+) ([]types.RepoContributor, error) {
+	const sqlQuery = `
+	SELECT
+		 MAX(repo_insight_commit_author_name) AS "name"
+		,repo_insight_commit_author_email AS "email"
+		,SUM(repo_insight_commit_commits) AS "commits"
+		,SUM(repo_insight_commit_additions) AS "additions"
+		,SUM(repo_insight_commit_deletions) AS "deletions"
+	FROM repo_insight_commits
+	WHERE repo_insight_commit_repo_id = $1 AND repo_insight_commit_week >= $2
+	GROUP BY repo_insight_commit_author_email
+	ORDER BY SUM(repo_insight_commit_commits) DESC, repo_insight_commit_author_email
+	LIMIT $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := make([]types.RepoContributor, 0)
+	if err := db.SelectContext(ctx, &result, sqlQuery, repoID, since, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repository contributors")
+	}
+
+	return result, nil
+}
+
+// ListPullReqStats returns the weekly pull request statistics of a repository, starting with the provided week.
+func (s *RepoInsightsStore) ListPullReqStats(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+) ([]types.RepoInsightsPullReqStats, error) {
+	const sqlQuery = `
+	SELECT
+		 repo_insight_pullreq_week AS "week"
+		,repo_insight_pullreq_opened AS "opened"
+		,repo_insight_pullreq_merged AS "merged"
+		,repo_insight_pullreq_closed AS "closed"
+	FROM repo_insight_pullreqs
+	WHERE repo_insight_pullreq_repo_id = $1 AND repo_insight_pullreq_week >= $2
+	ORDER BY repo_insight_pullreq_week`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := make([]types.RepoInsightsPullReqStats, 0)
+	if err := db.SelectContext(ctx, &result, sqlQuery, repoID, since); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repository pull request statistics")
+	}
+
+	return result, nil
+}
+
+func mapRepoInsightsState(state *repoInsightsState) *types.RepoInsightsState {
+	return &types.RepoInsightsState{
+		RepoID:    state.RepoID,
+		Stale:     state.Stale,
+		Branch:    state.Branch,
+		CommitSHA: state.CommitSHA,
+		Created:   state.Created,
+		Updated:   state.Updated,
+		Processed: state.Processed,
+	}
+}
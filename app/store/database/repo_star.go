@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoStarStore = (*RepoStarStore)(nil)
+
+// NewRepoStarStore returns a new RepoStarStore.
+func NewRepoStarStore(db *sqlx.DB) *RepoStarStore {
+	return &RepoStarStore{
+		db: db,
+	}
+}
+
+// RepoStarStore implements store.RepoStarStore backed by a relational database.
+type RepoStarStore struct {
+	db *sqlx.DB
+}
+
+// Create stars the repository for the principal. It returns false if the repository was already starred.
+func (s *RepoStarStore) Create(ctx context.Context, principalID, repoID, created int64) (bool, error) {
+	const sqlQuery = `
+	INSERT INTO repository_stars (
+		 star_principal_id
+		,star_repo_id
+		,star_created
+	) VALUES ($1, $2, $3)
+	ON CONFLICT (star_principal_id, star_repo_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, repoID, created)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to insert repository star")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted repository stars")
+	}
+
+	return count > 0, nil
+}
+
+// Delete unstars the repository for the principal. It returns false if the repository wasn't starred.
+func (s *RepoStarStore) Delete(ctx context.Context, principalID, repoID int64) (bool, error) {
+	const sqlQuery = `
+	DELETE FROM repository_stars
+	WHERE star_principal_id = $1 AND star_repo_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, repoID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to delete repository star")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted repository stars")
+	}
+
+	return count > 0, nil
+}
+
+// ListRepoIDs returns the IDs of the repositories starred by the principal, the most recently starred first.
+func (s *RepoStarStore) ListRepoIDs(
+	ctx context.Context,
+	principalID int64,
+	pagination types.Pagination,
+) ([]int64, error) {
+	stmt := database.Builder.
+		Select("star_repo_id").
+		From("repository_stars").
+		InnerJoin("repositories ON repo_id = star_repo_id").
+		Where("star_principal_id = ?", principalID).
+		Where("repo_deleted IS NULL").
+		OrderBy("star_created DESC", "star_repo_id DESC").
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var repoIDs []int64
+	if err = db.SelectContext(ctx, &repoIDs, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list starred repositories")
+	}
+
+	return repoIDs, nil
+}
+
+// Count returns the number of repositories starred by the principal.
+func (s *RepoStarStore) Count(ctx context.Context, principalID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("repository_stars").
+		InnerJoin("repositories ON repo_id = star_repo_id").
+		Where("star_principal_id = ?", principalID).
+		Where("repo_deleted IS NULL")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count starred repositories")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestRepoStarStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepos(ctx, t, repoStore, 1, 3, 1)
+
+	starStore := database.NewRepoStarStore(db)
+
+	// the repositories are starred in order, the most recently starred is listed first.
+	for i, repoID := range []int64{1, 2, 3} {
+		created, err := starStore.Create(ctx, userID, repoID, int64(i+1))
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if !created {
+			t.Errorf("Create() of repository %d = false, want true", repoID)
+		}
+	}
+
+	created, err := starStore.Create(ctx, userID, 1, 10)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created {
+		t.Errorf("Create() of an already starred repository = true, want false")
+	}
+
+	repoIDs, err := starStore.ListRepoIDs(ctx, userID, types.Pagination{Page: 1, Size: 2})
+	if err != nil {
+		t.Fatalf("ListRepoIDs() error = %v", err)
+	}
+	if want := []int64{3, 2}; !reflect.DeepEqual(repoIDs, want) {
+		t.Errorf("ListRepoIDs() = %v, want %v", repoIDs, want)
+	}
+
+	// deleted repositories are neither listed nor counted.
+	repo, err := repoStore.Find(ctx, 3)
+	if err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+	if err = repoStore.SoftDelete(ctx, repo, time.Now().UnixMilli()); err != nil {
+		t.Fatalf("failed to soft delete repo: %v", err)
+	}
+
+	repoIDs, err = starStore.ListRepoIDs(ctx, userID, types.Pagination{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("ListRepoIDs() error = %v", err)
+	}
+	if want := []int64{2, 1}; !reflect.DeepEqual(repoIDs, want) {
+		t.Errorf("ListRepoIDs() = %v, want %v", repoIDs, want)
+	}
+
+	count, err := starStore.Count(ctx, userID)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Count() = %d, want 2", count)
+	}
+
+	deleted, err := starStore.Delete(ctx, userID, 1)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !deleted {
+		t.Errorf("Delete() of a starred repository = false, want true")
+	}
+
+	deleted, err = starStore.Delete(ctx, userID, 1)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if deleted {
+		t.Errorf("Delete() of a repository that isn't starred = true, want false")
+	}
+
+	count, err = starStore.Count(ctx, userID)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+}
@@ -16,6 +16,7 @@ package database_test
 
 import (
 	"context"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -177,6 +178,92 @@ func TestDatabase_ListAll(t *testing.T) {
 	}
 }
 
+func TestDatabase_Topics(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	repoTopics := [][]string{
+		{"golang", "git"},
+		{"go", "golang"},
+		{"golang-tools"},
+		{},
+	}
+	for i, topics := range repoTopics {
+		identifier := "repo_" + strconv.Itoa(i+1)
+		repo := types.Repository{Identifier: identifier, ParentID: 1, GitUID: identifier, Topics: topics}
+		if err := repoStore.Create(ctx, &repo); err != nil {
+			t.Fatalf("failed to create repo %v", err)
+		}
+	}
+
+	repos, err := repoStore.List(ctx, 1, &types.RepoFilter{Topic: "golang", Size: numTestRepos})
+	if err != nil {
+		t.Fatalf("failed to list repos %v", err)
+	}
+
+	identifiers := make([]string, len(repos))
+	for i, repo := range repos {
+		identifiers[i] = repo.Identifier
+	}
+	// only complete topics are matched, "golang-tools" isn't "golang".
+	if want := []string{"repo_1", "repo_2"}; !reflect.DeepEqual(identifiers, want) {
+		t.Errorf("repos = %v, want %v", identifiers, want)
+	}
+
+	tests := []struct {
+		name   string
+		filter types.RepoTopicFilter
+		want   []types.RepoTopic
+	}{
+		{
+			name:   "all topics",
+			filter: types.RepoTopicFilter{},
+			want: []types.RepoTopic{
+				{Name: "golang", Count: 2},
+				{Name: "git", Count: 1},
+				{Name: "go", Count: 1},
+				{Name: "golang-tools", Count: 1},
+			},
+		},
+		{
+			name:   "prefix",
+			filter: types.RepoTopicFilter{Query: "GOL"},
+			want: []types.RepoTopic{
+				{Name: "golang", Count: 2},
+				{Name: "golang-tools", Count: 1},
+			},
+		},
+		{
+			name:   "limited",
+			filter: types.RepoTopicFilter{Query: "go", Size: 2},
+			want: []types.RepoTopic{
+				{Name: "golang", Count: 2},
+				{Name: "go", Count: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			topics, err := repoStore.ListTopics(ctx, 1, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list topics %v", err)
+			}
+
+			if !reflect.DeepEqual(topics, test.want) {
+				t.Errorf("topics = %v, want %v", topics, test.want)
+			}
+		})
+	}
+}
+
 func createRepo(
 	ctx context.Context,
 	t *testing.T,
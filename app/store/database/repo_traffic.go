@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.RepoTrafficStore = (*RepoTrafficStore)(nil)
+
+// NewRepoTrafficStore returns a new RepoTrafficStore.
+func NewRepoTrafficStore(db *sqlx.DB) *RepoTrafficStore {
+	return &RepoTrafficStore{
+		db: db,
+	}
+}
+
+// RepoTrafficStore implements store.RepoTrafficStore backed by a relational database.
+type RepoTrafficStore struct {
+	db *sqlx.DB
+}
+
+type repoTraffic struct {
+	RepoID      int64                    `db:"repo_traffic_repo_id"`
+	Day         int64                    `db:"repo_traffic_day"`
+	PrincipalID int64                    `db:"repo_traffic_principal_id"`
+	Transport   enum.GitTransport        `db:"repo_traffic_transport"`
+	Operation   enum.GitTrafficOperation `db:"repo_traffic_operation"`
+	Shallow     bool                     `db:"repo_traffic_shallow"`
+	Count       int64                    `db:"repo_traffic_count"`
+}
+
+type repoTrafficDay struct {
+	Day           int64 `db:"repo_traffic_day"`
+	Clones        int64 `db:"clones"`
+	Fetches       int64 `db:"fetches"`
+	UniqueCallers int64 `db:"unique_callers"`
+	Shallow       int64 `db:"shallow_count"`
+	Full          int64 `db:"full_count"`
+	HTTP          int64 `db:"http_count"`
+	SSH           int64 `db:"ssh_count"`
+}
+
+// Record increments the traffic counter matching the provided event.
+func (s *RepoTrafficStore) Record(ctx context.Context, event *types.RepoTrafficEvent) error {
+	const sqlQuery = `
+	INSERT INTO repo_traffic (
+		 repo_traffic_repo_id
+		,repo_traffic_day
+		,repo_traffic_principal_id
+		,repo_traffic_transport
+		,repo_traffic_operation
+		,repo_traffic_shallow
+		,repo_traffic_count
+	) VALUES (
+		 :repo_traffic_repo_id
+		,:repo_traffic_day
+		,:repo_traffic_principal_id
+		,:repo_traffic_transport
+		,:repo_traffic_operation
+		,:repo_traffic_shallow
+		,:repo_traffic_count
+	)
+	ON CONFLICT (repo_traffic_repo_id, repo_traffic_day, repo_traffic_principal_id,
+		repo_traffic_transport, repo_traffic_operation, repo_traffic_shallow) DO
+	UPDATE SET
+		repo_traffic_count = repo_traffic.repo_traffic_count + 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &repoTraffic{
+		RepoID:      event.RepoID,
+		Day:         event.Day,
+		PrincipalID: event.PrincipalID,
+		Transport:   event.Transport,
+		Operation:   event.Operation,
+		Shallow:     event.Shallow,
+		Count:       1,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo traffic object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Record query failed")
+	}
+
+	return nil
+}
+
+// ListDays returns the daily traffic statistics of a repository.
+func (s *RepoTrafficStore) ListDays(
+	ctx context.Context,
+	repoID int64,
+	filter types.RepoTrafficFilter,
+) ([]types.RepoTrafficDay, error) {
+	stmt := database.Builder.
+		Select(`repo_traffic_day
+		,SUM(CASE WHEN repo_traffic_operation = 'clone' THEN repo_traffic_count ELSE 0 END) AS clones
+		,SUM(CASE WHEN repo_traffic_operation = 'fetch' THEN repo_traffic_count ELSE 0 END) AS fetches
+		,COUNT(DISTINCT repo_traffic_principal_id) AS unique_callers
+		,SUM(CASE WHEN repo_traffic_shallow THEN repo_traffic_count ELSE 0 END) AS shallow_count
+		,SUM(CASE WHEN repo_traffic_shallow THEN 0 ELSE repo_traffic_count END) AS full_count
+		,SUM(CASE WHEN repo_traffic_transport = 'http' THEN repo_traffic_count ELSE 0 END) AS http_count
+		,SUM(CASE WHEN repo_traffic_transport = 'ssh' THEN repo_traffic_count ELSE 0 END) AS ssh_count`).
+		From("repo_traffic").
+		Where("repo_traffic_repo_id = ?", repoID).
+		GroupBy("repo_traffic_day").
+		OrderBy("repo_traffic_day ASC")
+
+	stmt = applyRepoTrafficFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []repoTrafficDay
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list query")
+	}
+
+	days := make([]types.RepoTrafficDay, len(dst))
+	for i, d := range dst {
+		days[i] = types.RepoTrafficDay{
+			Day:           d.Day,
+			Clones:        d.Clones,
+			Fetches:       d.Fetches,
+			UniqueCallers: d.UniqueCallers,
+			Shallow:       d.Shallow,
+			Full:          d.Full,
+			HTTP:          d.HTTP,
+			SSH:           d.SSH,
+		}
+	}
+
+	return days, nil
+}
+
+// CountUniqueCallers returns the number of distinct principals that cloned or fetched the repository.
+func (s *RepoTrafficStore) CountUniqueCallers(
+	ctx context.Context,
+	repoID int64,
+	filter types.RepoTrafficFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(DISTINCT repo_traffic_principal_id)").
+		From("repo_traffic").
+		Where("repo_traffic_repo_id = ?", repoID)
+
+	stmt = applyRepoTrafficFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+func applyRepoTrafficFilter(stmt squirrel.SelectBuilder, filter types.RepoTrafficFilter) squirrel.SelectBuilder {
+	if filter.After > 0 {
+		stmt = stmt.Where("repo_traffic_day >= ?", filter.After)
+	}
+	if filter.Before > 0 {
+		stmt = stmt.Where("repo_traffic_day <= ?", filter.Before)
+	}
+	return stmt
+}
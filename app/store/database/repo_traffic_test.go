@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRepoTrafficStore_Record(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	trafficStore := database.NewRepoTrafficStore(db)
+
+	const (
+		day1 = int64(1_700_000_000_000)
+		day2 = day1 + 24*60*60*1000
+	)
+
+	events := []types.RepoTrafficEvent{
+		// the same event is counted in one row.
+		{Day: day1, PrincipalID: 1, Transport: enum.GitTransportHTTP, Operation: enum.GitTrafficOperationClone},
+		{Day: day1, PrincipalID: 1, Transport: enum.GitTransportHTTP, Operation: enum.GitTrafficOperationClone},
+		{Day: day1, PrincipalID: 2, Transport: enum.GitTransportSSH, Operation: enum.GitTrafficOperationFetch},
+		{Day: day1, PrincipalID: 2, Transport: enum.GitTransportSSH, Operation: enum.GitTrafficOperationClone,
+			Shallow: true},
+		{Day: day2, PrincipalID: 3, Transport: enum.GitTransportHTTP, Operation: enum.GitTrafficOperationFetch},
+	}
+	for i := range events {
+		events[i].RepoID = 1
+		if err := trafficStore.Record(ctx, &events[i]); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	var rows int
+	if err := db.QueryRowContext(ctx, "SELECT count(*) FROM repo_traffic").Scan(&rows); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if rows != 4 {
+		t.Errorf("Record() stored %d rows, want 4", rows)
+	}
+
+	days, err := trafficStore.ListDays(ctx, 1, types.RepoTrafficFilter{})
+	if err != nil {
+		t.Fatalf("ListDays() error = %v", err)
+	}
+
+	wantDays := []types.RepoTrafficDay{
+		{Day: day1, Clones: 3, Fetches: 1, UniqueCallers: 2, Shallow: 1, Full: 3, HTTP: 2, SSH: 2},
+		{Day: day2, Clones: 0, Fetches: 1, UniqueCallers: 1, Shallow: 0, Full: 1, HTTP: 1, SSH: 0},
+	}
+	if !reflect.DeepEqual(days, wantDays) {
+		t.Errorf("ListDays() = %+v, want %+v", days, wantDays)
+	}
+
+	tests := []struct {
+		name   string
+		filter types.RepoTrafficFilter
+		want   int64
+	}{
+		{name: "all days", want: 3},
+		{name: "first day", filter: types.RepoTrafficFilter{Before: day1}, want: 2},
+		{name: "second day", filter: types.RepoTrafficFilter{After: day2}, want: 1},
+		{name: "no days", filter: types.RepoTrafficFilter{After: day2 + 1}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := trafficStore.CountUniqueCallers(ctx, 1, tt.filter)
+			if err != nil {
+				t.Fatalf("CountUniqueCallers() error = %v", err)
+			}
+			if count != tt.want {
+				t.Errorf("CountUniqueCallers() = %d, want %d", count, tt.want)
+			}
+		})
+	}
+}
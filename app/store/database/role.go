@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RoleStore = (*RoleStore)(nil)
+
+// NewRoleStore returns a new RoleStore.
+func NewRoleStore(db *sqlx.DB) *RoleStore {
+	return &RoleStore{
+		db: db,
+	}
+}
+
+// RoleStore implements store.RoleStore backed by a relational database.
+type RoleStore struct {
+	db *sqlx.DB
+}
+
+type role struct {
+	ID          int64  `db:"role_id"`
+	Identifier  string `db:"role_uid"`
+	DisplayName string `db:"role_display_name"`
+	Description string `db:"role_description"`
+	Permissions string `db:"role_permissions"`
+	CreatedBy   int64  `db:"role_created_by"`
+	Created     int64  `db:"role_created"`
+	Updated     int64  `db:"role_updated"`
+}
+
+const (
+	roleColumns = `
+		 role_id
+		,role_uid
+		,role_display_name
+		,role_description
+		,role_permissions
+		,role_created_by
+		,role_created
+		,role_updated`
+
+	roleSelectBase = `
+		SELECT` + roleColumns + `
+		FROM roles`
+)
+
+// Find finds the role by id.
+func (s *RoleStore) Find(ctx context.Context, id int64) (*types.Role, error) {
+	const sqlQuery = roleSelectBase + `
+		WHERE role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &role{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find role")
+	}
+
+	return mapRole(dst)
+}
+
+// FindByIdentifier finds the role by its identifier (case insensitive).
+func (s *RoleStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Role, error) {
+	const sqlQuery = roleSelectBase + `
+		WHERE LOWER(role_uid) = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &role{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find role by identifier")
+	}
+
+	return mapRole(dst)
+}
+
+// List returns all custom roles.
+func (s *RoleStore) List(ctx context.Context) ([]*types.Role, error) {
+	const sqlQuery = roleSelectBase + `
+		ORDER BY role_uid ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*role, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list roles")
+	}
+
+	out := make([]*types.Role, len(dst))
+	for i, d := range dst {
+		var err error
+		if out[i], err = mapRole(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Create stores a new custom role.
+func (s *RoleStore) Create(ctx context.Context, r *types.Role) error {
+	const sqlQuery = `
+		INSERT INTO roles (
+			 role_uid
+			,role_display_name
+			,role_description
+			,role_permissions
+			,role_created_by
+			,role_created
+			,role_updated
+		) values (
+			 :role_uid
+			,:role_display_name
+			,:role_description
+			,:role_permissions
+			,:role_created_by
+			,:role_created
+			,:role_updated
+		) RETURNING role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRole, err := mapInternalRole(r)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind role object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert role query failed")
+	}
+
+	return nil
+}
+
+// Update updates the display name, description and permissions of a custom role.
+func (s *RoleStore) Update(ctx context.Context, r *types.Role) error {
+	const sqlQuery = `
+		UPDATE roles
+		SET
+			 role_display_name = :role_display_name
+			,role_description = :role_description
+			,role_permissions = :role_permissions
+			,role_updated = :role_updated
+		WHERE role_id = :role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRole, err := mapInternalRole(r)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind role object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update role")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a custom role.
+func (s *RoleStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM roles
+		WHERE role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete role")
+	}
+
+	return nil
+}
+
+func mapRole(in *role) (*types.Role, error) {
+	out := &types.Role{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		DisplayName: in.DisplayName,
+		Description: in.Description,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	if err := json.Unmarshal([]byte(in.Permissions), &out.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal role permissions: %w", err)
+	}
+
+	return out, nil
+}
+
+func mapInternalRole(in *types.Role) (*role, error) {
+	permissions := in.Permissions
+	if permissions == nil {
+		permissions = []enum.Permission{}
+	}
+
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal role permissions: %w", err)
+	}
+
+	return &role{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		DisplayName: in.DisplayName,
+		Description: in.Description,
+		Permissions: string(data),
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+type runner struct {
+	ID         int64              `db:"runner_id"`
+	Identifier string             `db:"runner_identifier"`
+	Tags       sqlxtypes.JSONText `db:"runner_tags"`
+	Capacity   int                `db:"runner_capacity"`
+	Version    string             `db:"runner_version"`
+	OS         string             `db:"runner_os"`
+	Arch       string             `db:"runner_arch"`
+	TokenHash  string             `db:"runner_token_hash"`
+	LastSeen   int64              `db:"runner_last_seen"`
+	Created    int64              `db:"runner_created"`
+	Updated    int64              `db:"runner_updated"`
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_identifier
+		,runner_tags
+		,runner_capacity
+		,runner_version
+		,runner_os
+		,runner_arch
+		,runner_token_hash
+		,runner_last_seen
+		,runner_created
+		,runner_updated`
+)
+
+// Find finds the runner by its id.
+func (s *RunnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const sqlQuery = `
+		SELECT` + runnerColumns + `
+		FROM runners
+		WHERE runner_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByIdentifier finds the runner by its identifier.
+func (s *RunnerStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error) {
+	const sqlQuery = `
+		SELECT` + runnerColumns + `
+		FROM runners
+		WHERE runner_identifier = $1`
+
+	return s.find(ctx, sqlQuery, identifier)
+}
+
+// FindByTokenHash finds the runner by the hash of its token.
+func (s *RunnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const sqlQuery = `
+		SELECT` + runnerColumns + `
+		FROM runners
+		WHERE runner_token_hash = $1`
+
+	return s.find(ctx, sqlQuery, tokenHash)
+}
+
+func (s *RunnerStore) find(ctx context.Context, sqlQuery string, arg any) (*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner")
+	}
+
+	return mapRunner(dst)
+}
+
+// Create creates a new runner.
+func (s *RunnerStore) Create(ctx context.Context, runner *types.Runner) error {
+	const sqlQuery = `
+		INSERT INTO runners (
+			 runner_identifier
+			,runner_tags
+			,runner_capacity
+			,runner_version
+			,runner_os
+			,runner_arch
+			,runner_token_hash
+			,runner_last_seen
+			,runner_created
+			,runner_updated
+		) values (
+			 :runner_identifier
+			,:runner_tags
+			,:runner_capacity
+			,:runner_version
+			,:runner_os
+			,:runner_arch
+			,:runner_token_hash
+			,:runner_last_seen
+			,:runner_created
+			,:runner_updated
+		) RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRunner(runner))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&runner.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert runner query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastSeen sets the time of the last heartbeat of the runner.
+func (s *RunnerStore) UpdateLastSeen(ctx context.Context, id int64, lastSeen int64) error {
+	const sqlQuery = `
+		UPDATE runners
+		SET runner_last_seen = $1
+		WHERE runner_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastSeen, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner last seen time")
+	}
+
+	return nil
+}
+
+// Delete deletes the runner.
+func (s *RunnerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM runners
+		WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete runner")
+	}
+
+	return nil
+}
+
+// List lists all runners ordered by identifier.
+func (s *RunnerStore) List(ctx context.Context) ([]*types.Runner, error) {
+	const sqlQuery = `
+		SELECT` + runnerColumns + `
+		FROM runners
+		ORDER BY runner_identifier`
+
+	return s.list(ctx, sqlQuery)
+}
+
+// ListLastSeenBefore lists the runners without heartbeat since the provided time.
+func (s *RunnerStore) ListLastSeenBefore(ctx context.Context, before int64) ([]*types.Runner, error) {
+	const sqlQuery = `
+		SELECT` + runnerColumns + `
+		FROM runners
+		WHERE runner_last_seen < $1
+		ORDER BY runner_identifier`
+
+	return s.list(ctx, sqlQuery, before)
+}
+
+func (s *RunnerStore) list(ctx context.Context, sqlQuery string, args ...any) ([]*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*runner, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list runners")
+	}
+
+	out := make([]*types.Runner, len(dst))
+	for i, r := range dst {
+		var err error
+		if out[i], err = mapRunner(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func mapRunner(in *runner) (*types.Runner, error) {
+	var tags []string
+	if err := json.Unmarshal(in.Tags, &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal runner tags: %w", err)
+	}
+
+	return &types.Runner{
+		ID:         in.ID,
+		Identifier: in.Identifier,
+		Tags:       tags,
+		Capacity:   in.Capacity,
+		Version:    in.Version,
+		OS:         in.OS,
+		Arch:       in.Arch,
+		TokenHash:  in.TokenHash,
+		LastSeen:   in.LastSeen,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}, nil
+}
+
+func mapInternalRunner(in *types.Runner) *runner {
+	tags := in.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &runner{
+		ID:         in.ID,
+		Identifier: in.Identifier,
+		Tags:       EncodeToSQLXJSON(tags),
+		Capacity:   in.Capacity,
+		Version:    in.Version,
+		OS:         in.OS,
+		Arch:       in.Arch,
+		TokenHash:  in.TokenHash,
+		LastSeen:   in.LastSeen,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SAMLIdentityStore = (*SAMLIdentityStore)(nil)
+
+// NewSAMLIdentityStore returns a new SAMLIdentityStore.
+func NewSAMLIdentityStore(db *sqlx.DB) *SAMLIdentityStore {
+	return &SAMLIdentityStore{
+		db: db,
+	}
+}
+
+// SAMLIdentityStore implements store.SAMLIdentityStore backed by a relational database.
+type SAMLIdentityStore struct {
+	db *sqlx.DB
+}
+
+type samlIdentity struct {
+	ID          int64  `db:"saml_identity_id"`
+	PrincipalID int64  `db:"saml_identity_principal_id"`
+	ProviderID  int64  `db:"saml_identity_provider_id"`
+	NameID      string `db:"saml_identity_name_id"`
+	Email       string `db:"saml_identity_email"`
+	Created     int64  `db:"saml_identity_created"`
+	Updated     int64  `db:"saml_identity_updated"`
+}
+
+const (
+	samlIdentityColumns = `
+		 saml_identity_id
+		,saml_identity_principal_id
+		,saml_identity_provider_id
+		,saml_identity_name_id
+		,saml_identity_email
+		,saml_identity_created
+		,saml_identity_updated`
+
+	samlIdentitySelectBase = `
+		SELECT` + samlIdentityColumns + `
+		FROM saml_identities`
+)
+
+// FindByNameID finds the identity with the name ID asserted by the provider.
+func (s *SAMLIdentityStore) FindByNameID(
+	ctx context.Context,
+	providerID int64,
+	nameID string,
+) (*types.SAMLIdentity, error) {
+	const sqlQuery = samlIdentitySelectBase + `
+		WHERE saml_identity_provider_id = $1 AND saml_identity_name_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &samlIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, providerID, nameID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find saml identity")
+	}
+
+	return mapSAMLIdentity(dst), nil
+}
+
+// List returns all identities linked to a principal.
+func (s *SAMLIdentityStore) List(ctx context.Context, principalID int64) ([]*types.SAMLIdentity, error) {
+	const sqlQuery = samlIdentitySelectBase + `
+		WHERE saml_identity_principal_id = $1
+		ORDER BY saml_identity_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*samlIdentity, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list saml identities")
+	}
+
+	out := make([]*types.SAMLIdentity, len(dst))
+	for i, d := range dst {
+		out[i] = mapSAMLIdentity(d)
+	}
+
+	return out, nil
+}
+
+// Create stores a new identity.
+func (s *SAMLIdentityStore) Create(ctx context.Context, identity *types.SAMLIdentity) error {
+	const sqlQuery = `
+		INSERT INTO saml_identities (
+			 saml_identity_principal_id
+			,saml_identity_provider_id
+			,saml_identity_name_id
+			,saml_identity_email
+			,saml_identity_created
+			,saml_identity_updated
+		) values (
+			 :saml_identity_principal_id
+			,:saml_identity_provider_id
+			,:saml_identity_name_id
+			,:saml_identity_email
+			,:saml_identity_created
+			,:saml_identity_updated
+		) RETURNING saml_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalSAMLIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind saml identity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert saml identity query failed")
+	}
+
+	return nil
+}
+
+// Update updates the email of an identity.
+func (s *SAMLIdentityStore) Update(ctx context.Context, identity *types.SAMLIdentity) error {
+	const sqlQuery = `
+		UPDATE saml_identities
+		SET
+			 saml_identity_email = $1
+			,saml_identity_updated = $2
+		WHERE saml_identity_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, identity.Email, identity.Updated, identity.ID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update saml identity")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an identity.
+func (s *SAMLIdentityStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM saml_identities
+		WHERE saml_identity_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete saml identity")
+	}
+
+	return nil
+}
+
+func mapSAMLIdentity(in *samlIdentity) *types.SAMLIdentity {
+	return &types.SAMLIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		ProviderID:  in.ProviderID,
+		NameID:      in.NameID,
+		Email:       in.Email,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapInternalSAMLIdentity(in *types.SAMLIdentity) *samlIdentity {
+	return &samlIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		ProviderID:  in.ProviderID,
+		NameID:      in.NameID,
+		Email:       in.Email,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
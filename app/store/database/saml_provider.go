@@ -0,0 +1,341 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SAMLProviderStore = (*SAMLProviderStore)(nil)
+
+// NewSAMLProviderStore returns a new SAMLProviderStore.
+func NewSAMLProviderStore(db *sqlx.DB) *SAMLProviderStore {
+	return &SAMLProviderStore{
+		db: db,
+	}
+}
+
+// SAMLProviderStore implements store.SAMLProviderStore backed by a relational database.
+type SAMLProviderStore struct {
+	db *sqlx.DB
+}
+
+type samlProvider struct {
+	ID               int64  `db:"saml_provider_id"`
+	Identifier       string `db:"saml_provider_identifier"`
+	DisplayName      string `db:"saml_provider_display_name"`
+	IdPMetadata      string `db:"saml_provider_idp_metadata"`
+	IdPEntityID      string `db:"saml_provider_idp_entity_id"`
+	IdPSSOURL        string `db:"saml_provider_idp_sso_url"`
+	AttributeMapping string `db:"saml_provider_attribute_mapping"`
+	GroupMappings    string `db:"saml_provider_group_mappings"`
+	Enabled          bool   `db:"saml_provider_enabled"`
+	AllowSignup      bool   `db:"saml_provider_allow_signup"`
+	Created          int64  `db:"saml_provider_created"`
+	Updated          int64  `db:"saml_provider_updated"`
+
+	TrustSecondFactor bool `db:"saml_provider_trust_second_factor"`
+}
+
+// samlGroupMapping is the stored form of a group mapping, which references the space by its ID.
+type samlGroupMapping struct {
+	Group   string              `json:"group"`
+	SpaceID int64               `json:"space_id"`
+	Role    enum.MembershipRole `json:"role"`
+}
+
+const (
+	samlProviderColumns = `
+		 saml_provider_id
+		,saml_provider_identifier
+		,saml_provider_display_name
+		,saml_provider_idp_metadata
+		,saml_provider_idp_entity_id
+		,saml_provider_idp_sso_url
+		,saml_provider_attribute_mapping
+		,saml_provider_group_mappings
+		,saml_provider_enabled
+		,saml_provider_allow_signup
+		,saml_provider_trust_second_factor
+		,saml_provider_created
+		,saml_provider_updated`
+
+	samlProviderSelectBase = `
+		SELECT` + samlProviderColumns + `
+		FROM saml_providers`
+)
+
+// Find finds a SAML provider by its ID.
+func (s *SAMLProviderStore) Find(ctx context.Context, id int64) (*types.SAMLProvider, error) {
+	const sqlQuery = samlProviderSelectBase + `
+		WHERE saml_provider_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &samlProvider{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find saml provider")
+	}
+
+	return mapSAMLProvider(dst)
+}
+
+// FindByIdentifier finds a SAML provider by its identifier.
+func (s *SAMLProviderStore) FindByIdentifier(ctx context.Context, identifier string) (*types.SAMLProvider, error) {
+	const sqlQuery = samlProviderSelectBase + `
+		WHERE LOWER(saml_provider_identifier) = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &samlProvider{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find saml provider by identifier")
+	}
+
+	return mapSAMLProvider(dst)
+}
+
+// List returns all SAML providers, or only the enabled ones.
+func (s *SAMLProviderStore) List(ctx context.Context, enabledOnly bool) ([]*types.SAMLProvider, error) {
+	stmt := database.Builder.
+		Select(samlProviderColumns).
+		From("saml_providers").
+		OrderBy("saml_provider_identifier ASC")
+
+	if enabledOnly {
+		stmt = stmt.Where("saml_provider_enabled = ?", true)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*samlProvider, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list saml providers")
+	}
+
+	out := make([]*types.SAMLProvider, len(dst))
+	for i, d := range dst {
+		if out[i], err = mapSAMLProvider(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Create stores a new SAML provider.
+func (s *SAMLProviderStore) Create(ctx context.Context, provider *types.SAMLProvider) error {
+	const sqlQuery = `
+		INSERT INTO saml_providers (
+			 saml_provider_identifier
+			,saml_provider_display_name
+			,saml_provider_idp_metadata
+			,saml_provider_idp_entity_id
+			,saml_provider_idp_sso_url
+			,saml_provider_attribute_mapping
+			,saml_provider_group_mappings
+			,saml_provider_enabled
+			,saml_provider_allow_signup
+			,saml_provider_trust_second_factor
+			,saml_provider_created
+			,saml_provider_updated
+		) values (
+			 :saml_provider_identifier
+			,:saml_provider_display_name
+			,:saml_provider_idp_metadata
+			,:saml_provider_idp_entity_id
+			,:saml_provider_idp_sso_url
+			,:saml_provider_attribute_mapping
+			,:saml_provider_group_mappings
+			,:saml_provider_enabled
+			,:saml_provider_allow_signup
+			,:saml_provider_trust_second_factor
+			,:saml_provider_created
+			,:saml_provider_updated
+		) RETURNING saml_provider_id`
+
+	dbProvider, err := mapInternalSAMLProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbProvider)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind saml provider object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&provider.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert saml provider query failed")
+	}
+
+	return nil
+}
+
+// Update updates a SAML provider.
+func (s *SAMLProviderStore) Update(ctx context.Context, provider *types.SAMLProvider) error {
+	const sqlQuery = `
+		UPDATE saml_providers
+		SET
+			 saml_provider_display_name = :saml_provider_display_name
+			,saml_provider_idp_metadata = :saml_provider_idp_metadata
+			,saml_provider_idp_entity_id = :saml_provider_idp_entity_id
+			,saml_provider_idp_sso_url = :saml_provider_idp_sso_url
+			,saml_provider_attribute_mapping = :saml_provider_attribute_mapping
+			,saml_provider_group_mappings = :saml_provider_group_mappings
+			,saml_provider_enabled = :saml_provider_enabled
+			,saml_provider_allow_signup = :saml_provider_allow_signup
+			,saml_provider_trust_second_factor = :saml_provider_trust_second_factor
+			,saml_provider_updated = :saml_provider_updated
+		WHERE saml_provider_id = :saml_provider_id`
+
+	dbProvider, err := mapInternalSAMLProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbProvider)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind saml provider object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update saml provider")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a SAML provider together with the identities linked through it.
+func (s *SAMLProviderStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM saml_providers
+		WHERE saml_provider_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete saml provider")
+	}
+
+	return nil
+}
+
+func mapSAMLProvider(in *samlProvider) (*types.SAMLProvider, error) {
+	attributeMapping := types.SAMLAttributeMapping{}
+	if err := json.Unmarshal([]byte(in.AttributeMapping), &attributeMapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal saml provider attribute mapping: %w", err)
+	}
+
+	groupMappings := []samlGroupMapping{}
+	if err := json.Unmarshal([]byte(in.GroupMappings), &groupMappings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal saml provider group mappings: %w", err)
+	}
+
+	out := &types.SAMLProvider{
+		ID:               in.ID,
+		Identifier:       in.Identifier,
+		DisplayName:      in.DisplayName,
+		IdPMetadata:      in.IdPMetadata,
+		IdPEntityID:      in.IdPEntityID,
+		IdPSSOURL:        in.IdPSSOURL,
+		AttributeMapping: attributeMapping,
+		GroupMappings:    make([]types.SAMLGroupMapping, len(groupMappings)),
+		Enabled:          in.Enabled,
+		AllowSignup:      in.AllowSignup,
+		Created:          in.Created,
+		Updated:          in.Updated,
+
+		TrustSecondFactor: in.TrustSecondFactor,
+	}
+
+	for i, mapping := range groupMappings {
+		out.GroupMappings[i] = types.SAMLGroupMapping{
+			Group:   mapping.Group,
+			SpaceID: mapping.SpaceID,
+			Role:    mapping.Role,
+		}
+	}
+
+	return out, nil
+}
+
+func mapInternalSAMLProvider(in *types.SAMLProvider) (*samlProvider, error) {
+	attributeMapping, err := json.Marshal(in.AttributeMapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saml provider attribute mapping: %w", err)
+	}
+
+	groupMappings := make([]samlGroupMapping, len(in.GroupMappings))
+	for i, mapping := range in.GroupMappings {
+		groupMappings[i] = samlGroupMapping{
+			Group:   mapping.Group,
+			SpaceID: mapping.SpaceID,
+			Role:    mapping.Role,
+		}
+	}
+
+	groupMappingsJSON, err := json.Marshal(groupMappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saml provider group mappings: %w", err)
+	}
+
+	return &samlProvider{
+		ID:               in.ID,
+		Identifier:       in.Identifier,
+		DisplayName:      in.DisplayName,
+		IdPMetadata:      in.IdPMetadata,
+		IdPEntityID:      in.IdPEntityID,
+		IdPSSOURL:        in.IdPSSOURL,
+		AttributeMapping: string(attributeMapping),
+		GroupMappings:    string(groupMappingsJSON),
+		Enabled:          in.Enabled,
+		AllowSignup:      in.AllowSignup,
+		Created:          in.Created,
+		Updated:          in.Updated,
+
+		TrustSecondFactor: in.TrustSecondFactor,
+	}, nil
+}
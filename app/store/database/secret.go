@@ -26,7 +26,9 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 	"github.com/pkg/errors"
 )
 
@@ -47,10 +49,28 @@ const (
 	secret_data,
 	secret_created,
 	secret_updated,
-	secret_version
+	secret_version,
+	secret_allowed_events,
+	secret_allowed_repos,
+	secret_allow_fork_prs
 	`
 )
 
+type secret struct {
+	ID            int64              `db:"secret_id"`
+	Description   string             `db:"secret_description"`
+	SpaceID       int64              `db:"secret_space_id"`
+	CreatedBy     int64              `db:"secret_created_by"`
+	Identifier    string             `db:"secret_uid"`
+	Data          string             `db:"secret_data"`
+	Created       int64              `db:"secret_created"`
+	Updated       int64              `db:"secret_updated"`
+	Version       int64              `db:"secret_version"`
+	AllowedEvents sqlxtypes.JSONText `db:"secret_allowed_events"`
+	AllowedRepos  sqlxtypes.JSONText `db:"secret_allowed_repos"`
+	AllowForkPRs  bool               `db:"secret_allow_fork_prs"`
+}
+
 // NewSecretStore returns a new SecretStore.
 func NewSecretStore(db *sqlx.DB) store.SecretStore {
 	return &secretStore{
@@ -68,11 +88,11 @@ func (s *secretStore) Find(ctx context.Context, id int64) (*types.Secret, error)
 		WHERE secret_id = $1`
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Secret)
+	dst := new(secret)
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find secret")
 	}
-	return dst, nil
+	return mapInternalToSecret(dst)
 }
 
 // FindByIdentifier returns a secret in a given space with a given identifier.
@@ -81,11 +101,11 @@ func (s *secretStore) FindByIdentifier(ctx context.Context, spaceID int64, ident
 		WHERE secret_space_id = $1 AND secret_uid = $2`
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Secret)
+	dst := new(secret)
 	if err := db.GetContext(ctx, dst, findQueryStmt, spaceID, identifier); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find secret")
 	}
-	return dst, nil
+	return mapInternalToSecret(dst)
 }
 
 // Create creates a secret.
@@ -100,7 +120,10 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		secret_data,
 		secret_created,
 		secret_updated,
-		secret_version
+		secret_version,
+		secret_allowed_events,
+		secret_allowed_repos,
+		secret_allow_fork_prs
 	) VALUES (
 		:secret_description,
 		:secret_space_id,
@@ -109,11 +132,14 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		:secret_data,
 		:secret_created,
 		:secret_updated,
-		:secret_version
+		:secret_version,
+		:secret_allowed_events,
+		:secret_allowed_repos,
+		:secret_allow_fork_prs
 	) RETURNING secret_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(secretInsertStmt, secret)
+	query, arg, err := db.BindNamed(secretInsertStmt, mapSecretToInternal(secret))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind secret object")
 	}
@@ -133,7 +159,10 @@ func (s *secretStore) Update(ctx context.Context, p *types.Secret) error {
 		secret_uid = :secret_uid,
 		secret_data = :secret_data,
 		secret_updated = :secret_updated,
-		secret_version = :secret_version
+		secret_version = :secret_version,
+		secret_allowed_events = :secret_allowed_events,
+		secret_allowed_repos = :secret_allowed_repos,
+		secret_allow_fork_prs = :secret_allow_fork_prs
 	WHERE secret_id = :secret_id AND secret_version = :secret_version - 1`
 	updatedAt := time.Now()
 	secret := *p
@@ -143,7 +172,7 @@ func (s *secretStore) Update(ctx context.Context, p *types.Secret) error {
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(secretUpdateStmt, secret)
+	query, arg, err := db.BindNamed(secretUpdateStmt, mapSecretToInternal(&secret))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind secret object")
 	}
@@ -216,12 +245,12 @@ func (s *secretStore) List(ctx context.Context, parentID int64, filter types.Lis
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Secret{}
+	dst := []*secret{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
 
-	return dst, nil
+	return mapInternalToSecrets(dst)
 }
 
 // ListAll lists all the secrets present in a space.
@@ -238,12 +267,34 @@ func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Sec
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Secret{}
+	dst := []*secret{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return mapInternalToSecrets(dst)
+}
+
+// ListAllInSpaces lists all the secrets present in any of the spaces.
+func (s *secretStore) ListAllInSpaces(ctx context.Context, spaceIDs []int64) ([]*types.Secret, error) {
+	stmt := database.Builder.
+		Select(secretColumns).
+		From("secrets").
+		Where(squirrel.Eq{"secret_space_id": spaceIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*secret{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
 
-	return dst, nil
+	return mapInternalToSecrets(dst)
 }
 
 // Delete deletes a secret given a secret ID.
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func mapInternalToSecret(in *secret) (*types.Secret, error) {
+	var allowedEvents []enum.TriggerEvent
+	if err := in.AllowedEvents.Unmarshal(&allowedEvents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret allowed events: %w", err)
+	}
+	var allowedRepos []string
+	if err := in.AllowedRepos.Unmarshal(&allowedRepos); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret allowed repos: %w", err)
+	}
+
+	return &types.Secret{
+		ID:            in.ID,
+		Description:   in.Description,
+		SpaceID:       in.SpaceID,
+		CreatedBy:     in.CreatedBy,
+		Identifier:    in.Identifier,
+		Data:          in.Data,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		Version:       in.Version,
+		AllowedEvents: allowedEvents,
+		AllowedRepos:  allowedRepos,
+		AllowForkPRs:  in.AllowForkPRs,
+	}, nil
+}
+
+func mapInternalToSecrets(in []*secret) ([]*types.Secret, error) {
+	secrets := make([]*types.Secret, len(in))
+	for i, s := range in {
+		var err error
+		secrets[i], err = mapInternalToSecret(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secrets, nil
+}
+
+func mapSecretToInternal(in *types.Secret) *secret {
+	allowedEvents := in.AllowedEvents
+	if allowedEvents == nil {
+		allowedEvents = []enum.TriggerEvent{}
+	}
+	allowedRepos := in.AllowedRepos
+	if allowedRepos == nil {
+		allowedRepos = []string{}
+	}
+
+	return &secret{
+		ID:            in.ID,
+		Description:   in.Description,
+		SpaceID:       in.SpaceID,
+		CreatedBy:     in.CreatedBy,
+		Identifier:    in.Identifier,
+		Data:          in.Data,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		Version:       in.Version,
+		AllowedEvents: EncodeToSQLXJSON(allowedEvents),
+		AllowedRepos:  EncodeToSQLXJSON(allowedRepos),
+		AllowForkPRs:  in.AllowForkPRs,
+	}
+}
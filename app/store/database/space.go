@@ -776,6 +776,33 @@ func (s *SpaceStore) listAll(
 	return s.mapToSpaces(ctx, s.db, dst)
 }
 
+// Search returns a list of spaces matching the filter regardless of their parent space.
+func (s *SpaceStore) Search(
+	ctx context.Context,
+	opts *types.SpaceFilter,
+) ([]*types.Space, error) {
+	stmt := database.Builder.
+		Select(spaceColumns).
+		From("spaces")
+
+	stmt = s.applyQueryFilter(stmt, opts)
+	stmt = s.applySortFilter(stmt, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*space
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom search query")
+	}
+
+	return s.mapToSpaces(ctx, s.db, dst)
+}
+
 func (s *SpaceStore) applyQueryFilter(
 	stmt squirrel.SelectBuilder,
 	opts *types.SpaceFilter,
@@ -61,38 +61,46 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_matrix
+	,stage_runs_on
+	,stage_concurrency_group
+	,stage_concurrency_limit
 	`
 )
 
 type stage struct {
-	ID            int64              `db:"stage_id"`
-	ExecutionID   int64              `db:"stage_execution_id"`
-	RepoID        int64              `db:"stage_repo_id"`
-	Number        int64              `db:"stage_number"`
-	Name          string             `db:"stage_name"`
-	Kind          string             `db:"stage_kind"`
-	Type          string             `db:"stage_type"`
-	Status        enum.CIStatus      `db:"stage_status"`
-	Error         string             `db:"stage_error"`
-	ParentGroupID int64              `db:"stage_parent_group_id"`
-	ErrIgnore     bool               `db:"stage_errignore"`
-	ExitCode      int                `db:"stage_exit_code"`
-	Machine       string             `db:"stage_machine"`
-	OS            string             `db:"stage_os"`
-	Arch          string             `db:"stage_arch"`
-	Variant       string             `db:"stage_variant"`
-	Kernel        string             `db:"stage_kernel"`
-	Limit         int                `db:"stage_limit"`
-	LimitRepo     int                `db:"stage_limit_repo"`
-	Started       int64              `db:"stage_started"`
-	Stopped       int64              `db:"stage_stopped"`
-	Created       int64              `db:"stage_created"`
-	Updated       int64              `db:"stage_updated"`
-	Version       int64              `db:"stage_version"`
-	OnSuccess     bool               `db:"stage_on_success"`
-	OnFailure     bool               `db:"stage_on_failure"`
-	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
-	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+	ID               int64              `db:"stage_id"`
+	ExecutionID      int64              `db:"stage_execution_id"`
+	RepoID           int64              `db:"stage_repo_id"`
+	Number           int64              `db:"stage_number"`
+	Name             string             `db:"stage_name"`
+	Kind             string             `db:"stage_kind"`
+	Type             string             `db:"stage_type"`
+	Status           enum.CIStatus      `db:"stage_status"`
+	Error            string             `db:"stage_error"`
+	ParentGroupID    int64              `db:"stage_parent_group_id"`
+	ErrIgnore        bool               `db:"stage_errignore"`
+	ExitCode         int                `db:"stage_exit_code"`
+	Machine          string             `db:"stage_machine"`
+	OS               string             `db:"stage_os"`
+	Arch             string             `db:"stage_arch"`
+	Variant          string             `db:"stage_variant"`
+	Kernel           string             `db:"stage_kernel"`
+	Limit            int                `db:"stage_limit"`
+	LimitRepo        int                `db:"stage_limit_repo"`
+	Started          int64              `db:"stage_started"`
+	Stopped          int64              `db:"stage_stopped"`
+	Created          int64              `db:"stage_created"`
+	Updated          int64              `db:"stage_updated"`
+	Version          int64              `db:"stage_version"`
+	OnSuccess        bool               `db:"stage_on_success"`
+	OnFailure        bool               `db:"stage_on_failure"`
+	DependsOn        sqlxtypes.JSONText `db:"stage_depends_on"`
+	Labels           sqlxtypes.JSONText `db:"stage_labels"`
+	Matrix           sqlxtypes.JSONText `db:"stage_matrix"`
+	RunsOn           sqlxtypes.JSONText `db:"stage_runs_on"`
+	ConcurrencyGroup string             `db:"stage_concurrency_group"`
+	ConcurrencyLimit int                `db:"stage_concurrency_limit"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +160,10 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_matrix
+			,stage_runs_on
+			,stage_concurrency_group
+			,stage_concurrency_limit
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,7 +192,10 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
-
+			,:stage_matrix
+			,:stage_runs_on
+			,:stage_concurrency_group
+			,:stage_concurrency_limit
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -192,6 +207,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 	if err = db.QueryRowContext(ctx, query, arg...).Scan(&stage.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Stage query failed")
 	}
+	st.ID = stage.ID
 	return nil
 }
 
@@ -250,6 +266,30 @@ func (s *stageStore) ListIncomplete(ctx context.Context) ([]*types.Stage, error)
 	return mapInternalToStageList(dst)
 }
 
+// ListIncompleteByConcurrencyGroup returns the incomplete stages of the concurrency group of the repo.
+func (s *stageStore) ListIncompleteByConcurrencyGroup(
+	ctx context.Context,
+	repoID int64,
+	group string,
+) ([]*types.Stage, error) {
+	const queryListIncompleteByGroup = `
+	SELECT` + stageColumns + `
+	FROM stages
+	WHERE stage_repo_id = $1
+		AND stage_concurrency_group = $2
+		AND stage_status IN ('waiting_on_dependencies','pending','running')
+	ORDER BY stage_id ASC
+	`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stage{}
+	if err := db.SelectContext(ctx, &dst, queryListIncompleteByGroup, repoID, group); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find incomplete stages of concurrency group")
+	}
+	// map stages list
+	return mapInternalToStageList(dst)
+}
+
 // List returns a list of stages corresponding to an execution ID.
 func (s *stageStore) List(ctx context.Context, executionID int64) ([]*types.Stage, error) {
 	const queryList = `
@@ -82,66 +82,84 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not unmarshal stage.labels")
 	}
+	var matrix map[string]string
+	err = json.Unmarshal(in.Matrix, &matrix)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.matrix")
+	}
+	var runsOn []string
+	err = json.Unmarshal(in.RunsOn, &runsOn)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.runs_on")
+	}
 	return &types.Stage{
-		ID:          in.ID,
-		ExecutionID: in.ExecutionID,
-		RepoID:      in.RepoID,
-		Number:      in.Number,
-		Name:        in.Name,
-		Kind:        in.Kind,
-		Type:        in.Type,
-		Status:      in.Status,
-		Error:       in.Error,
-		ErrIgnore:   in.ErrIgnore,
-		ExitCode:    in.ExitCode,
-		Machine:     in.Machine,
-		OS:          in.OS,
-		Arch:        in.Arch,
-		Variant:     in.Variant,
-		Kernel:      in.Kernel,
-		Limit:       in.Limit,
-		LimitRepo:   in.LimitRepo,
-		Started:     in.Started,
-		Stopped:     in.Stopped,
-		Created:     in.Created,
-		Updated:     in.Updated,
-		Version:     in.Version,
-		OnSuccess:   in.OnSuccess,
-		OnFailure:   in.OnFailure,
-		DependsOn:   dependsOn,
-		Labels:      labels,
+		ID:               in.ID,
+		ExecutionID:      in.ExecutionID,
+		RepoID:           in.RepoID,
+		Number:           in.Number,
+		Name:             in.Name,
+		Kind:             in.Kind,
+		Type:             in.Type,
+		Status:           in.Status,
+		Error:            in.Error,
+		ErrIgnore:        in.ErrIgnore,
+		ExitCode:         in.ExitCode,
+		Machine:          in.Machine,
+		OS:               in.OS,
+		Arch:             in.Arch,
+		Variant:          in.Variant,
+		Kernel:           in.Kernel,
+		Limit:            in.Limit,
+		LimitRepo:        in.LimitRepo,
+		Started:          in.Started,
+		Stopped:          in.Stopped,
+		Created:          in.Created,
+		Updated:          in.Updated,
+		Version:          in.Version,
+		OnSuccess:        in.OnSuccess,
+		OnFailure:        in.OnFailure,
+		DependsOn:        dependsOn,
+		Labels:           labels,
+		Matrix:           matrix,
+		RunsOn:           runsOn,
+		ConcurrencyGroup: in.ConcurrencyGroup,
+		ConcurrencyLimit: in.ConcurrencyLimit,
 	}, nil
 }
 
 func mapStageToInternal(in *types.Stage) *stage {
 	return &stage{
-		ID:          in.ID,
-		ExecutionID: in.ExecutionID,
-		RepoID:      in.RepoID,
-		Number:      in.Number,
-		Name:        in.Name,
-		Kind:        in.Kind,
-		Type:        in.Type,
-		Status:      in.Status,
-		Error:       in.Error,
-		ErrIgnore:   in.ErrIgnore,
-		ExitCode:    in.ExitCode,
-		Machine:     in.Machine,
-		OS:          in.OS,
-		Arch:        in.Arch,
-		Variant:     in.Variant,
-		Kernel:      in.Kernel,
-		Limit:       in.Limit,
-		LimitRepo:   in.LimitRepo,
-		Started:     in.Started,
-		Stopped:     in.Stopped,
-		Created:     in.Created,
-		Updated:     in.Updated,
-		Version:     in.Version,
-		OnSuccess:   in.OnSuccess,
-		OnFailure:   in.OnFailure,
-		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
-		Labels:      EncodeToSQLXJSON(in.Labels),
+		ID:               in.ID,
+		ExecutionID:      in.ExecutionID,
+		RepoID:           in.RepoID,
+		Number:           in.Number,
+		Name:             in.Name,
+		Kind:             in.Kind,
+		Type:             in.Type,
+		Status:           in.Status,
+		Error:            in.Error,
+		ErrIgnore:        in.ErrIgnore,
+		ExitCode:         in.ExitCode,
+		Machine:          in.Machine,
+		OS:               in.OS,
+		Arch:             in.Arch,
+		Variant:          in.Variant,
+		Kernel:           in.Kernel,
+		Limit:            in.Limit,
+		LimitRepo:        in.LimitRepo,
+		Started:          in.Started,
+		Stopped:          in.Stopped,
+		Created:          in.Created,
+		Updated:          in.Updated,
+		Version:          in.Version,
+		OnSuccess:        in.OnSuccess,
+		OnFailure:        in.OnFailure,
+		DependsOn:        EncodeToSQLXJSON(in.DependsOn),
+		Labels:           EncodeToSQLXJSON(in.Labels),
+		Matrix:           EncodeToSQLXJSON(in.Matrix),
+		RunsOn:           EncodeToSQLXJSON(in.RunsOn),
+		ConcurrencyGroup: in.ConcurrencyGroup,
+		ConcurrencyLimit: in.ConcurrencyLimit,
 	}
 }
 
@@ -191,6 +209,8 @@ func scanRowsWithSteps(rows *sql.Rows) ([]*types.Stage, error) {
 func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	depJSON := sqlxtypes.JSONText{}
 	labJSON := sqlxtypes.JSONText{}
+	matrixJSON := sqlxtypes.JSONText{}
+	runsOnJSON := sqlxtypes.JSONText{}
 	stepDepJSON := sqlxtypes.JSONText{}
 	err := rows.Scan(
 		&stage.ID,
@@ -220,6 +240,10 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&matrixJSON,
+		&runsOnJSON,
+		&stage.ConcurrencyGroup,
+		&stage.ConcurrencyLimit,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
@@ -247,6 +271,14 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal labJSON: %w", err)
 	}
+	err = json.Unmarshal(matrixJSON, &stage.Matrix)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal matrixJSON: %w", err)
+	}
+	err = json.Unmarshal(runsOnJSON, &stage.RunsOn)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal runsOnJSON: %w", err)
+	}
 	if step.ID.Valid {
 		// try to unmarshal step dependencies if step exists
 		err = json.Unmarshal(stepDepJSON, &step.DependsOn)
@@ -16,11 +16,13 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -42,23 +44,45 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+type token struct {
+	ID          int64          `db:"token_id"`
+	PrincipalID int64          `db:"token_principal_id"`
+	Type        enum.TokenType `db:"token_type"`
+	Identifier  string         `db:"token_uid"`
+	ExpiresAt   *int64         `db:"token_expires_at"`
+	IssuedAt    int64          `db:"token_issued_at"`
+	CreatedBy   int64          `db:"token_created_by"`
+	Scopes      string         `db:"token_scopes"`
+	Resources   string         `db:"token_resources"`
+	CIDRs       string         `db:"token_allowed_cidrs"`
+
+	PreviousIssuedAt   *int64 `db:"token_previous_issued_at"`
+	PreviousValidUntil *int64 `db:"token_previous_valid_until"`
+	ExpiryWarnedAt     *int64 `db:"token_expiry_warned_at"`
+
+	UserAgent  string `db:"token_user_agent"`
+	IP         string `db:"token_ip"`
+	LastUsedAt *int64 `db:"token_last_used_at"`
+	LastUsedIP string `db:"token_last_used_ip"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +93,19 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	dbToken, err := mapInternalToken(token)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(tokenInsert, dbToken)
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
@@ -88,6 +117,59 @@ func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	return nil
 }
 
+// Update updates the issue and expiry times of the token, which change when the token is rotated,
+// and the time its owner was warned about the expiry.
+func (s *TokenStore) Update(ctx context.Context, token *types.Token) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbToken, err := mapInternalToken(token)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(tokenUpdate, dbToken)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update token")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsed updates the time at which the token was last used and the IP address it was used from.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsedAt int64, lastUsedIP string) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, tokenUpdateLastUsed, lastUsedAt, lastUsedIP, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update token last used")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
 // Delete deletes the token with the given id.
 func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -99,6 +181,43 @@ func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// DeleteForPrincipal deletes all tokens of a specific type for a specific principal,
+// except the token with the provided id (if it isn't zero).
+func (s *TokenStore) DeleteForPrincipal(
+	ctx context.Context,
+	principalID int64,
+	tokenType enum.TokenType,
+	exceptID int64,
+) (int64, error) {
+	stmt := database.Builder.
+		Delete("tokens").
+		Where("token_principal_id = ?", principalID).
+		Where("token_type = ?", tokenType)
+
+	if exceptID != 0 {
+		stmt = stmt.Where("token_id <> ?", exceptID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete token query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete token query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted tokens")
+	}
+
+	return n, nil
+}
+
 // DeleteExpiredBefore deletes all tokens that expired before the provided time.
 // If tokenTypes are provided, then only tokens of that type are deleted.
 func (s *TokenStore) DeleteExpiredBefore(
@@ -134,6 +253,49 @@ func (s *TokenStore) DeleteExpiredBefore(
 	return n, nil
 }
 
+// ListExpiringBefore returns the tokens of the provided types that expire between now and the provided time
+// and whose owners weren't warned about the expiry yet.
+func (s *TokenStore) ListExpiringBefore(
+	ctx context.Context,
+	before time.Time,
+	tknTypes []enum.TokenType,
+	limit int,
+) ([]*types.Token, error) {
+	stmt := database.Builder.
+		Select(tokenColumns).
+		From("tokens").
+		Where("token_expires_at >= ?", time.Now().UnixMilli()).
+		Where("token_expires_at < ?", before.UnixMilli()).
+		Where("token_expiry_warned_at IS NULL").
+		OrderBy("token_expires_at ASC").
+		Limit(uint64(limit))
+
+	if len(tknTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"token_type": tknTypes})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list expiring tokens query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*token{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list expiring tokens query")
+	}
+
+	out := make([]*types.Token, len(dst))
+	for i, d := range dst {
+		if out[i], err = mapToken(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
 // Count returns a count of tokens of a specifc type for a specific principal.
 func (s *TokenStore) Count(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) (int64, error) {
@@ -153,7 +315,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,11 +323,18 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	out := make([]*types.Token, len(dst))
+	for i, d := range dst {
+		if out[i], err = mapToken(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
 }
 
-const tokenSelectBase = `
-SELECT
+const tokenColumns = `
 token_id
 ,token_type
 ,token_uid
@@ -173,6 +342,19 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_scopes
+,token_resources
+,token_allowed_cidrs
+,token_previous_issued_at
+,token_previous_valid_until
+,token_expiry_warned_at
+,token_user_agent
+,token_ip
+,token_last_used_at
+,token_last_used_ip` //#nosec G101
+
+const tokenSelectBase = `
+SELECT` + tokenColumns + `
 FROM tokens
 ` //#nosec G101
 
@@ -200,6 +382,25 @@ DELETE FROM tokens
 WHERE token_id = $1
 `
 
+const tokenUpdate = `
+UPDATE tokens
+SET
+	token_issued_at = :token_issued_at
+	,token_expires_at = :token_expires_at
+	,token_previous_issued_at = :token_previous_issued_at
+	,token_previous_valid_until = :token_previous_valid_until
+	,token_expiry_warned_at = :token_expiry_warned_at
+WHERE token_id = :token_id
+`
+
+const tokenUpdateLastUsed = `
+UPDATE tokens
+SET
+	token_last_used_at = $1
+	,token_last_used_ip = $2
+WHERE token_id = $3
+`
+
 const tokenInsert = `
 INSERT INTO tokens (
 	token_type
@@ -208,6 +409,11 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_scopes
+	,token_resources
+	,token_allowed_cidrs
+	,token_user_agent
+	,token_ip
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +421,96 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_scopes
+	,:token_resources
+	,:token_allowed_cidrs
+	,:token_user_agent
+	,:token_ip
 ) RETURNING token_id
 `
+
+func mapToken(in *token) (*types.Token, error) {
+	out := &types.Token{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Type:        in.Type,
+		Identifier:  in.Identifier,
+		ExpiresAt:   in.ExpiresAt,
+		IssuedAt:    in.IssuedAt,
+		CreatedBy:   in.CreatedBy,
+
+		PreviousIssuedAt:   in.PreviousIssuedAt,
+		PreviousValidUntil: in.PreviousValidUntil,
+		ExpiryWarnedAt:     in.ExpiryWarnedAt,
+
+		UserAgent:  in.UserAgent,
+		IP:         in.IP,
+		LastUsedAt: in.LastUsedAt,
+		LastUsedIP: in.LastUsedIP,
+	}
+
+	if err := json.Unmarshal([]byte(in.Scopes), &out.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token scopes: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(in.Resources), &out.Resources); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token resources: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(in.CIDRs), &out.AllowedCIDRs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token allowed cidrs: %w", err)
+	}
+
+	return out, nil
+}
+
+func mapInternalToken(in *types.Token) (*token, error) {
+	out := &token{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Type:        in.Type,
+		Identifier:  in.Identifier,
+		ExpiresAt:   in.ExpiresAt,
+		IssuedAt:    in.IssuedAt,
+		CreatedBy:   in.CreatedBy,
+
+		PreviousIssuedAt:   in.PreviousIssuedAt,
+		PreviousValidUntil: in.PreviousValidUntil,
+		ExpiryWarnedAt:     in.ExpiryWarnedAt,
+
+		UserAgent:  in.UserAgent,
+		IP:         in.IP,
+		LastUsedAt: in.LastUsedAt,
+		LastUsedIP: in.LastUsedIP,
+	}
+
+	scopes := in.Scopes
+	if scopes == nil {
+		scopes = []enum.TokenScope{}
+	}
+	data, err := json.Marshal(scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token scopes: %w", err)
+	}
+	out.Scopes = string(data)
+
+	resources := in.Resources
+	if resources == nil {
+		resources = []types.TokenResource{}
+	}
+	if data, err = json.Marshal(resources); err != nil {
+		return nil, fmt.Errorf("failed to marshal token resources: %w", err)
+	}
+	out.Resources = string(data)
+
+	cidrs := in.AllowedCIDRs
+	if cidrs == nil {
+		cidrs = []string{}
+	}
+	if data, err = json.Marshal(cidrs); err != nil {
+		return nil, fmt.Errorf("failed to marshal token allowed cidrs: %w", err)
+	}
+	out.CIDRs = string(data)
+
+	return out, nil
+}
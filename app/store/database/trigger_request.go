@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.TriggerRequestStore = (*TriggerRequestStore)(nil)
+
+// NewTriggerRequestStore returns a new TriggerRequestStore.
+func NewTriggerRequestStore(db *sqlx.DB) *TriggerRequestStore {
+	return &TriggerRequestStore{
+		db: db,
+	}
+}
+
+// TriggerRequestStore implements store.TriggerRequestStore backed by a relational database.
+type TriggerRequestStore struct {
+	db *sqlx.DB
+}
+
+type triggerRequest struct {
+	ID         int64                    `db:"trigger_request_id"`
+	RepoID     int64                    `db:"trigger_request_repo_id"`
+	PipelineID int64                    `db:"trigger_request_pipeline_id"`
+	Action     enum.TriggerAction       `db:"trigger_request_action"`
+	Ref        string                   `db:"trigger_request_ref"`
+	SHA        string                   `db:"trigger_request_sha"`
+	Hook       sqlxtypes.JSONText       `db:"trigger_request_hook"`
+	State      enum.TriggerRequestState `db:"trigger_request_state"`
+	Attempts   int                      `db:"trigger_request_attempts"`
+	LastError  string                   `db:"trigger_request_last_error"`
+	Created    int64                    `db:"trigger_request_created"`
+	Updated    int64                    `db:"trigger_request_updated"`
+}
+
+type triggerRequestWithPipeline struct {
+	triggerRequest
+	PipelineIdentifier string `db:"pipeline_uid"`
+}
+
+const (
+	triggerRequestColumns = `
+		 trigger_request_id
+		,trigger_request_repo_id
+		,trigger_request_pipeline_id
+		,trigger_request_action
+		,trigger_request_ref
+		,trigger_request_sha
+		,trigger_request_hook
+		,trigger_request_state
+		,trigger_request_attempts
+		,trigger_request_last_error
+		,trigger_request_created
+		,trigger_request_updated`
+)
+
+// Find finds the trigger request by id.
+func (s *TriggerRequestStore) Find(ctx context.Context, id int64) (*types.TriggerRequest, error) {
+	const sqlQuery = `
+		SELECT` + triggerRequestColumns + `
+			,pipeline_uid
+		FROM trigger_requests
+		INNER JOIN pipelines ON pipeline_id = trigger_request_pipeline_id
+		WHERE trigger_request_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &triggerRequestWithPipeline{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find trigger request")
+	}
+
+	return mapTriggerRequest(dst), nil
+}
+
+// Create creates a new trigger request.
+func (s *TriggerRequestStore) Create(ctx context.Context, request *types.TriggerRequest) error {
+	const sqlQuery = `
+		INSERT INTO trigger_requests (
+			 trigger_request_repo_id
+			,trigger_request_pipeline_id
+			,trigger_request_action
+			,trigger_request_ref
+			,trigger_request_sha
+			,trigger_request_hook
+			,trigger_request_state
+			,trigger_request_attempts
+			,trigger_request_last_error
+			,trigger_request_created
+			,trigger_request_updated
+		) values (
+			 :trigger_request_repo_id
+			,:trigger_request_pipeline_id
+			,:trigger_request_action
+			,:trigger_request_ref
+			,:trigger_request_sha
+			,:trigger_request_hook
+			,:trigger_request_state
+			,:trigger_request_attempts
+			,:trigger_request_last_error
+			,:trigger_request_created
+			,:trigger_request_updated
+		) RETURNING trigger_request_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalTriggerRequest(request))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind trigger request object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&request.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert trigger request query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state, the attempts and the last error of a trigger request.
+func (s *TriggerRequestStore) Update(ctx context.Context, request *types.TriggerRequest) error {
+	const sqlQuery = `
+		UPDATE trigger_requests
+		SET
+			 trigger_request_state = :trigger_request_state
+			,trigger_request_attempts = :trigger_request_attempts
+			,trigger_request_last_error = :trigger_request_last_error
+			,trigger_request_updated = :trigger_request_updated
+		WHERE trigger_request_id = :trigger_request_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalTriggerRequest(request))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind trigger request object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update trigger request")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Claim marks a queued trigger request as running. A running request that wasn't updated since
+// staleBefore is claimed as well. It returns false if the request was claimed by someone else.
+func (s *TriggerRequestStore) Claim(
+	ctx context.Context,
+	request *types.TriggerRequest,
+	staleBefore int64,
+) (bool, error) {
+	const sqlQuery = `
+		UPDATE trigger_requests
+		SET
+			 trigger_request_state = $1
+			,trigger_request_updated = $2
+		WHERE trigger_request_id = $3 AND (trigger_request_state = $4 OR
+			(trigger_request_state = $5 AND trigger_request_updated < $6))`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	updated := time.Now().UnixMilli()
+
+	result, err := db.ExecContext(ctx, sqlQuery,
+		enum.TriggerRequestStateRunning,
+		updated,
+		request.ID,
+		enum.TriggerRequestStateQueued,
+		enum.TriggerRequestStateRunning,
+		staleBefore,
+	)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to claim trigger request")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return false, nil
+	}
+
+	request.State = enum.TriggerRequestStateRunning
+	request.Updated = updated
+
+	return true, nil
+}
+
+// Delete deletes a trigger request.
+func (s *TriggerRequestStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM trigger_requests
+		WHERE trigger_request_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete trigger request")
+	}
+
+	return nil
+}
+
+// ListFailed lists the trigger requests of a repo that failed all attempts, the most recent first.
+func (s *TriggerRequestStore) ListFailed(
+	ctx context.Context,
+	repoID int64,
+	pagination types.Pagination,
+) ([]*types.TriggerRequest, error) {
+	stmt := database.Builder.
+		Select(triggerRequestColumns+", pipeline_uid").
+		From("trigger_requests").
+		InnerJoin("pipelines ON pipeline_id = trigger_request_pipeline_id").
+		Where("trigger_request_repo_id = ?", repoID).
+		Where("trigger_request_state = ?", enum.TriggerRequestStateFailed).
+		OrderBy("trigger_request_updated DESC", "trigger_request_id DESC").
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*triggerRequestWithPipeline, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list failed trigger requests")
+	}
+
+	out := make([]*types.TriggerRequest, len(dst))
+	for i, request := range dst {
+		out[i] = mapTriggerRequest(request)
+	}
+
+	return out, nil
+}
+
+// CountFailed returns the number of trigger requests of a repo that failed all attempts.
+func (s *TriggerRequestStore) CountFailed(ctx context.Context, repoID int64) (int64, error) {
+	const sqlQuery = `
+		SELECT COUNT(*)
+		FROM trigger_requests
+		WHERE trigger_request_repo_id = $1 AND trigger_request_state = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID, enum.TriggerRequestStateFailed).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count failed trigger requests")
+	}
+
+	return count, nil
+}
+
+func mapTriggerRequest(in *triggerRequestWithPipeline) *types.TriggerRequest {
+	return &types.TriggerRequest{
+		ID:                 in.ID,
+		RepoID:             in.RepoID,
+		PipelineID:         in.PipelineID,
+		PipelineIdentifier: in.PipelineIdentifier,
+		Action:             in.Action,
+		Ref:                in.Ref,
+		SHA:                in.SHA,
+		Hook:               json.RawMessage(in.Hook),
+		State:              in.State,
+		Attempts:           in.Attempts,
+		LastError:          in.LastError,
+		Created:            in.Created,
+		Updated:            in.Updated,
+	}
+}
+
+func mapInternalTriggerRequest(in *types.TriggerRequest) *triggerRequest {
+	return &triggerRequest{
+		ID:         in.ID,
+		RepoID:     in.RepoID,
+		PipelineID: in.PipelineID,
+		Action:     in.Action,
+		Ref:        in.Ref,
+		SHA:        in.SHA,
+		Hook:       sqlxtypes.JSONText(in.Hook),
+		State:      in.State,
+		Attempts:   in.Attempts,
+		LastError:  in.LastError,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserEmailStore = (*UserEmailStore)(nil)
+
+// NewUserEmailStore returns a new UserEmailStore.
+func NewUserEmailStore(db *sqlx.DB) *UserEmailStore {
+	return &UserEmailStore{
+		db: db,
+	}
+}
+
+// UserEmailStore implements store.UserEmailStore backed by a relational database.
+type UserEmailStore struct {
+	db *sqlx.DB
+}
+
+type userEmail struct {
+	ID                int64       `db:"user_email_id"`
+	PrincipalID       int64       `db:"user_email_principal_id"`
+	Address           string      `db:"user_email_address"`
+	Verified          null.Int    `db:"user_email_verified"`
+	VerificationToken null.String `db:"user_email_verification_token"`
+	VerificationSent  null.Int    `db:"user_email_verification_sent"`
+	Created           int64       `db:"user_email_created"`
+	Updated           int64       `db:"user_email_updated"`
+}
+
+const (
+	userEmailColumns = `
+		 user_email_id
+		,user_email_principal_id
+		,user_email_address
+		,user_email_verified
+		,user_email_verification_token
+		,user_email_verification_sent
+		,user_email_created
+		,user_email_updated`
+
+	userEmailSelectBase = `
+		SELECT` + userEmailColumns + `
+		FROM user_emails`
+)
+
+// Find finds an email address of a user.
+func (s *UserEmailStore) Find(ctx context.Context, principalID int64, email string) (*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE user_email_principal_id = $1 AND LOWER(user_email_address) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userEmail{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, strings.ToLower(email)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user email")
+	}
+
+	return mapUserEmail(dst), nil
+}
+
+// FindByVerificationToken finds an email address by the hash of its verification token.
+func (s *UserEmailStore) FindByVerificationToken(ctx context.Context, token string) (*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE user_email_verification_token = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userEmail{}
+	if err := db.GetContext(ctx, dst, sqlQuery, token); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user email by verification token")
+	}
+
+	return mapUserEmail(dst), nil
+}
+
+// FindVerified finds the verified email address, regardless of the user it belongs to.
+func (s *UserEmailStore) FindVerified(ctx context.Context, email string) (*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE LOWER(user_email_address) = $1 AND user_email_verified IS NOT NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userEmail{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(email)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find verified user email")
+	}
+
+	return mapUserEmail(dst), nil
+}
+
+// Create stores a new email address of a user.
+func (s *UserEmailStore) Create(ctx context.Context, userEmail *types.UserEmail) error {
+	const sqlQuery = `
+		INSERT INTO user_emails (
+			 user_email_principal_id
+			,user_email_address
+			,user_email_verified
+			,user_email_verification_token
+			,user_email_verification_sent
+			,user_email_created
+			,user_email_updated
+		) values (
+			 :user_email_principal_id
+			,:user_email_address
+			,:user_email_verified
+			,:user_email_verification_token
+			,:user_email_verification_sent
+			,:user_email_created
+			,:user_email_updated
+		) RETURNING user_email_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUserEmail(userEmail))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user email object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&userEmail.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert user email query failed")
+	}
+
+	return nil
+}
+
+// Update updates the verification state of the email address.
+func (s *UserEmailStore) Update(ctx context.Context, userEmail *types.UserEmail) error {
+	const sqlQuery = `
+		UPDATE user_emails
+		SET
+			 user_email_verified = :user_email_verified
+			,user_email_verification_token = :user_email_verification_token
+			,user_email_verification_sent = :user_email_verification_sent
+			,user_email_updated = :user_email_updated
+		WHERE user_email_id = :user_email_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUserEmail(userEmail))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user email object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update user email query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes an email address.
+func (s *UserEmailStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM user_emails
+		WHERE user_email_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete user email")
+	}
+
+	return nil
+}
+
+// List returns all email addresses of a user.
+func (s *UserEmailStore) List(ctx context.Context, principalID int64) ([]*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE user_email_principal_id = $1
+		ORDER BY user_email_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userEmail, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list user emails query")
+	}
+
+	result := make([]*types.UserEmail, len(dst))
+	for i, e := range dst {
+		result[i] = mapUserEmail(e)
+	}
+
+	return result, nil
+}
+
+func mapInternalUserEmail(in *types.UserEmail) *userEmail {
+	return &userEmail{
+		ID:                in.ID,
+		PrincipalID:       in.PrincipalID,
+		Address:           in.Email,
+		Verified:          null.IntFromPtr(in.Verified),
+		VerificationToken: null.StringFromPtr(in.VerificationToken),
+		VerificationSent:  null.IntFromPtr(in.VerificationSent),
+		Created:           in.Created,
+		Updated:           in.Updated,
+	}
+}
+
+func mapUserEmail(in *userEmail) *types.UserEmail {
+	return &types.UserEmail{
+		ID:                in.ID,
+		PrincipalID:       in.PrincipalID,
+		Email:             in.Address,
+		Verified:          in.Verified.Ptr(),
+		VerificationToken: in.VerificationToken.Ptr(),
+		VerificationSent:  in.VerificationSent.Ptr(),
+		Created:           in.Created,
+		Updated:           in.Updated,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserPreferencesStore = (*UserPreferencesStore)(nil)
+
+// NewUserPreferencesStore returns a new UserPreferencesStore.
+func NewUserPreferencesStore(db *sqlx.DB) *UserPreferencesStore {
+	return &UserPreferencesStore{
+		db: db,
+	}
+}
+
+// UserPreferencesStore implements store.UserPreferencesStore backed by a relational database.
+type UserPreferencesStore struct {
+	db *sqlx.DB
+}
+
+type userPreferences struct {
+	PrincipalID int64           `db:"user_preference_principal_id"`
+	Value       json.RawMessage `db:"user_preference_value"`
+	Updated     int64           `db:"user_preference_updated"`
+}
+
+const (
+	userPreferencesColumns = `
+		 user_preference_principal_id
+		,user_preference_value
+		,user_preference_updated`
+)
+
+// Find returns the preferences of a user, defaults are returned for users without stored preferences.
+func (s *UserPreferencesStore) Find(ctx context.Context, principalID int64) (*types.UserPreferences, error) {
+	prefs, err := s.FindMany(ctx, []int64{principalID})
+	if err != nil {
+		return nil, err
+	}
+
+	return prefs[principalID], nil
+}
+
+// FindMany returns the preferences of the users, defaults are returned for users without stored preferences.
+func (s *UserPreferencesStore) FindMany(
+	ctx context.Context,
+	principalIDs []int64,
+) (map[int64]*types.UserPreferences, error) {
+	out := make(map[int64]*types.UserPreferences, len(principalIDs))
+	if len(principalIDs) == 0 {
+		return out, nil
+	}
+
+	stmt := database.Builder.
+		Select(userPreferencesColumns).
+		From("user_preferences").
+		Where(squirrel.Eq{"user_preference_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*userPreferences{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user preferences")
+	}
+
+	for _, d := range dst {
+		// unmarshal on top of the defaults to cover preferences added after the user stored theirs.
+		prefs := types.DefaultUserPreferences()
+		if err = json.Unmarshal(d.Value, &prefs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal preferences of user %d: %w", d.PrincipalID, err)
+		}
+
+		prefs.Updated = d.Updated
+		out[d.PrincipalID] = &prefs
+	}
+
+	for _, id := range principalIDs {
+		if _, ok := out[id]; !ok {
+			prefs := types.DefaultUserPreferences()
+			out[id] = &prefs
+		}
+	}
+
+	return out, nil
+}
+
+// Upsert stores the preferences of a user.
+func (s *UserPreferencesStore) Upsert(
+	ctx context.Context,
+	principalID int64,
+	preferences *types.UserPreferences,
+) error {
+	const sqlQuery = `
+		INSERT INTO user_preferences (
+			 user_preference_principal_id
+			,user_preference_value
+			,user_preference_updated
+		) VALUES ($1, $2, $3)
+		ON CONFLICT (user_preference_principal_id) DO UPDATE SET
+			 user_preference_value = EXCLUDED.user_preference_value
+			,user_preference_updated = EXCLUDED.user_preference_updated`
+
+	value, err := json.Marshal(preferences)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user preferences: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, principalID, value, preferences.Updated); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert user preferences")
+	}
+
+	return nil
+}
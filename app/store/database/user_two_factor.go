@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserTwoFactorStore = (*UserTwoFactorStore)(nil)
+
+// NewUserTwoFactorStore returns a new UserTwoFactorStore.
+func NewUserTwoFactorStore(db *sqlx.DB) *UserTwoFactorStore {
+	return &UserTwoFactorStore{
+		db: db,
+	}
+}
+
+// UserTwoFactorStore implements store.UserTwoFactorStore backed by a relational database.
+type UserTwoFactorStore struct {
+	db *sqlx.DB
+}
+
+type userTwoFactor struct {
+	PrincipalID   int64           `db:"user_two_factor_principal_id"`
+	Secret        []byte          `db:"user_two_factor_secret"`
+	Enabled       *int64          `db:"user_two_factor_enabled"`
+	RecoveryCodes json.RawMessage `db:"user_two_factor_recovery_codes"`
+	LastUsedStep  int64           `db:"user_two_factor_last_used_step"`
+	Created       int64           `db:"user_two_factor_created"`
+	Updated       int64           `db:"user_two_factor_updated"`
+}
+
+const (
+	userTwoFactorColumns = `
+		 user_two_factor_principal_id
+		,user_two_factor_secret
+		,user_two_factor_enabled
+		,user_two_factor_recovery_codes
+		,user_two_factor_last_used_step
+		,user_two_factor_created
+		,user_two_factor_updated`
+)
+
+// Find returns the two-factor authentication state of a user.
+func (s *UserTwoFactorStore) Find(ctx context.Context, principalID int64) (*types.UserTwoFactor, error) {
+	const sqlQuery = `
+		SELECT` + userTwoFactorColumns + `
+		FROM user_two_factor
+		WHERE user_two_factor_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userTwoFactor{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user two-factor state")
+	}
+
+	return mapUserTwoFactor(dst)
+}
+
+// Upsert stores the two-factor authentication state of a user.
+func (s *UserTwoFactorStore) Upsert(ctx context.Context, twoFactor *types.UserTwoFactor) error {
+	const sqlQuery = `
+		INSERT INTO user_two_factor (
+			 user_two_factor_principal_id
+			,user_two_factor_secret
+			,user_two_factor_enabled
+			,user_two_factor_recovery_codes
+			,user_two_factor_last_used_step
+			,user_two_factor_created
+			,user_two_factor_updated
+		) VALUES (
+			 :user_two_factor_principal_id
+			,:user_two_factor_secret
+			,:user_two_factor_enabled
+			,:user_two_factor_recovery_codes
+			,:user_two_factor_last_used_step
+			,:user_two_factor_created
+			,:user_two_factor_updated
+		)
+		ON CONFLICT (user_two_factor_principal_id) DO UPDATE SET
+			 user_two_factor_secret = EXCLUDED.user_two_factor_secret
+			,user_two_factor_enabled = EXCLUDED.user_two_factor_enabled
+			,user_two_factor_recovery_codes = EXCLUDED.user_two_factor_recovery_codes
+			,user_two_factor_last_used_step = EXCLUDED.user_two_factor_last_used_step
+			,user_two_factor_created = EXCLUDED.user_two_factor_created
+			,user_two_factor_updated = EXCLUDED.user_two_factor_updated`
+
+	dbTwoFactor, err := mapInternalUserTwoFactor(twoFactor)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbTwoFactor)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user two-factor object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert user two-factor query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastUsedStep stores the time step of an accepted TOTP code.
+func (s *UserTwoFactorStore) UpdateLastUsedStep(ctx context.Context, principalID int64, step int64) error {
+	const sqlQuery = `
+		UPDATE user_two_factor
+		SET
+			 user_two_factor_last_used_step = $1
+			,user_two_factor_updated = $2
+		WHERE user_two_factor_principal_id = $3 AND user_two_factor_last_used_step < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, step, time.Now().UnixMilli(), principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update last used step of user two-factor")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// UpdateRecoveryCodes stores the recovery codes of the provided state and bumps its Updated time.
+func (s *UserTwoFactorStore) UpdateRecoveryCodes(ctx context.Context, twoFactor *types.UserTwoFactor) error {
+	const sqlQuery = `
+		UPDATE user_two_factor
+		SET
+			 user_two_factor_recovery_codes = $1
+			,user_two_factor_updated = $2
+		WHERE user_two_factor_principal_id = $3 AND user_two_factor_updated = $4`
+
+	recoveryCodes, err := json.Marshal(twoFactor.RecoveryCodes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recovery codes: %w", err)
+	}
+
+	updated := time.Now().UnixMilli()
+	if updated <= twoFactor.Updated {
+		updated = twoFactor.Updated + 1
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, recoveryCodes, updated, twoFactor.PrincipalID, twoFactor.Updated)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update recovery codes of user two-factor")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	twoFactor.Updated = updated
+
+	return nil
+}
+
+// Delete removes the two-factor authentication state of a user.
+func (s *UserTwoFactorStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM user_two_factor
+		WHERE user_two_factor_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete user two-factor state")
+	}
+
+	return nil
+}
+
+func mapUserTwoFactor(in *userTwoFactor) (*types.UserTwoFactor, error) {
+	recoveryCodes := []string{}
+	if len(in.RecoveryCodes) > 0 {
+		if err := json.Unmarshal(in.RecoveryCodes, &recoveryCodes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal recovery codes: %w", err)
+		}
+	}
+
+	return &types.UserTwoFactor{
+		PrincipalID:   in.PrincipalID,
+		Secret:        in.Secret,
+		Enabled:       in.Enabled,
+		RecoveryCodes: recoveryCodes,
+		LastUsedStep:  in.LastUsedStep,
+		Created:       in.Created,
+		Updated:       in.Updated,
+	}, nil
+}
+
+func mapInternalUserTwoFactor(in *types.UserTwoFactor) (*userTwoFactor, error) {
+	recoveryCodes := in.RecoveryCodes
+	if recoveryCodes == nil {
+		recoveryCodes = []string{}
+	}
+
+	recoveryCodesJSON, err := json.Marshal(recoveryCodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recovery codes: %w", err)
+	}
+
+	return &userTwoFactor{
+		PrincipalID:   in.PrincipalID,
+		Secret:        in.Secret,
+		Enabled:       in.Enabled,
+		RecoveryCodes: recoveryCodesJSON,
+		LastUsedStep:  in.LastUsedStep,
+		Created:       in.Created,
+		Updated:       in.Updated,
+	}, nil
+}
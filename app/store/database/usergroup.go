@@ -16,6 +16,8 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	gitnessAppStore "github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store"
@@ -198,13 +200,140 @@ func (s *UserGroupStore) Create(
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup object")
 	}
 
-	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&userGroup.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to insert usergroup")
 	}
 
+	userGroup.SpaceID = spaceID
+
 	return nil
 }
 
+// Update updates the name and the description of a usergroup.
+func (s *UserGroupStore) Update(ctx context.Context, userGroup *types.UserGroup) error {
+	const sqlQuery = `
+	UPDATE usergroups
+	SET
+		 usergroup_name = :usergroup_name
+		,usergroup_description = :usergroup_description
+		,usergroup_updated = :usergroup_updated
+	WHERE usergroup_id = :usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUserGroup(userGroup, userGroup.SpaceID))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update usergroup")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a usergroup along with its members, subgroups and memberships.
+func (s *UserGroupStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroups
+	WHERE usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete usergroup")
+	}
+
+	return nil
+}
+
+// List returns the usergroups defined in any of the provided spaces.
+func (s *UserGroupStore) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("usergroups").
+		Where(squirrel.Eq{"usergroup_space_id": spaceIDs}).
+		OrderBy("usergroup_identifier ASC")
+
+	stmt = applyUserGroupFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to generate list usergroups query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*UserGroup{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "list usergroups query failed")
+	}
+
+	result := make([]*types.UserGroup, len(dst))
+	for i, u := range dst {
+		result[i] = mapUserGroup(u)
+	}
+
+	return result, nil
+}
+
+// Count returns the number of usergroups defined in any of the provided spaces.
+func (s *UserGroupStore) Count(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroups").
+		Where(squirrel.Eq{"usergroup_space_id": spaceIDs})
+
+	stmt = applyUserGroupFilter(stmt, filter)
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to generate count usergroups query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sqlQuery, params...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "count usergroups query failed")
+	}
+
+	return count, nil
+}
+
+func applyUserGroupFilter(stmt squirrel.SelectBuilder, filter *types.ListQueryFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		searchTerm := fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query))
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Expr("LOWER(usergroup_identifier) LIKE ?", searchTerm),
+			squirrel.Expr("LOWER(usergroup_name) LIKE ?", searchTerm),
+		})
+	}
+
+	return stmt
+}
+
 func (s *UserGroupStore) CreateOrUpdate(
 	ctx context.Context,
 	spaceID int64,
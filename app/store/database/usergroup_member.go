@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMemberStore = (*UserGroupMemberStore)(nil)
+
+// NewUserGroupMemberStore returns a new UserGroupMemberStore.
+func NewUserGroupMemberStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *UserGroupMemberStore {
+	return &UserGroupMemberStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMemberStore implements store.UserGroupMemberStore backed by a relational database.
+type UserGroupMemberStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMember struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	PrincipalID int64 `db:"usergroup_member_principal_id"`
+	CreatedBy   int64 `db:"usergroup_member_created_by"`
+	Created     int64 `db:"usergroup_member_created"`
+}
+
+type userGroupMemberPrincipal struct {
+	userGroupMember
+	principalInfo
+}
+
+const (
+	userGroupMemberColumns = `
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created`
+)
+
+// Create adds a principal to a usergroup.
+func (s *UserGroupMemberStore) Create(ctx context.Context, member *types.UserGroupMember) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_members (
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created
+	) values (
+		 :usergroup_member_usergroup_id
+		,:usergroup_member_principal_id
+		,:usergroup_member_created_by
+		,:usergroup_member_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, userGroupMember(*member))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup member object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert usergroup member")
+	}
+
+	return nil
+}
+
+// Delete removes a principal from a usergroup.
+func (s *UserGroupMemberStore) Delete(ctx context.Context, userGroupID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_members
+	WHERE usergroup_member_usergroup_id = $1 AND
+	      usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete usergroup member")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListUsers returns the direct members of a usergroup.
+func (s *UserGroupMemberStore) ListUsers(
+	ctx context.Context,
+	userGroupID int64,
+) ([]types.UserGroupMemberUser, error) {
+	const columns = userGroupMemberColumns + "," + principalInfoCommonColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID).
+		OrderBy("principal_display_name ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup members list query to sql: %w", err)
+	}
+
+	dst := make([]*userGroupMemberPrincipal, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup members list query")
+	}
+
+	ids := make([]int64, 0, len(dst))
+	for _, m := range dst {
+		ids = append(ids, m.userGroupMember.CreatedBy)
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usergroup member principal infos: %w", err)
+	}
+
+	res := make([]types.UserGroupMemberUser, len(dst))
+	for i, m := range dst {
+		res[i].UserGroupMember = types.UserGroupMember(m.userGroupMember)
+		res[i].Principal = mapToPrincipalInfo(&m.principalInfo)
+		if addedBy, ok := infoMap[m.userGroupMember.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
+
+// ListPrincipalIDs returns the IDs of the direct members of any of the usergroups.
+func (s *UserGroupMemberStore) ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	stmt := database.Builder.
+		Select("DISTINCT usergroup_member_principal_id").
+		From("usergroup_members").
+		Where(squirrel.Eq{"usergroup_member_usergroup_id": userGroupIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup member ids query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var ids []int64
+	if err = db.SelectContext(ctx, &ids, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup member ids query")
+	}
+
+	return ids, nil
+}
+
+// ListUserGroupIDs returns the IDs of the usergroups the principal is a direct member of.
+func (s *UserGroupMemberStore) ListUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT usergroup_member_usergroup_id
+	FROM usergroup_members
+	WHERE usergroup_member_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup ids of principal query")
+	}
+
+	return ids, nil
+}
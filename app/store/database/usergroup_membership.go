@@ -0,0 +1,293 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMembershipStore = (*UserGroupMembershipStore)(nil)
+
+// NewUserGroupMembershipStore returns a new UserGroupMembershipStore.
+func NewUserGroupMembershipStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *UserGroupMembershipStore {
+	return &UserGroupMembershipStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMembershipStore implements store.UserGroupMembershipStore backed by a relational database.
+type UserGroupMembershipStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMembership struct {
+	SpaceID     int64 `db:"usergroup_membership_space_id"`
+	UserGroupID int64 `db:"usergroup_membership_usergroup_id"`
+
+	CreatedBy int64 `db:"usergroup_membership_created_by"`
+	Created   int64 `db:"usergroup_membership_created"`
+	Updated   int64 `db:"usergroup_membership_updated"`
+
+	Role enum.MembershipRole `db:"usergroup_membership_role"`
+}
+
+type userGroupMembershipUserGroup struct {
+	userGroupMembership
+	UserGroup
+}
+
+const (
+	userGroupMembershipColumns = `
+		 usergroup_membership_space_id
+		,usergroup_membership_usergroup_id
+		,usergroup_membership_created_by
+		,usergroup_membership_created
+		,usergroup_membership_updated
+		,usergroup_membership_role`
+
+	userGroupMembershipSelectBase = `
+	SELECT` + userGroupMembershipColumns + `
+	FROM usergroup_memberships`
+)
+
+// Find finds the membership of a usergroup in a space.
+func (s *UserGroupMembershipStore) Find(
+	ctx context.Context,
+	key types.UserGroupMembershipKey,
+) (*types.UserGroupMembership, error) {
+	const sqlQuery = userGroupMembershipSelectBase + `
+	WHERE usergroup_membership_space_id = $1 AND usergroup_membership_usergroup_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroupMembership{}
+	if err := db.GetContext(ctx, dst, sqlQuery, key.SpaceID, key.UserGroupID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find usergroup membership")
+	}
+
+	result := mapToUserGroupMembership(dst)
+
+	return &result, nil
+}
+
+// Create creates a new usergroup membership.
+func (s *UserGroupMembershipStore) Create(ctx context.Context, membership *types.UserGroupMembership) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_memberships (
+		 usergroup_membership_space_id
+		,usergroup_membership_usergroup_id
+		,usergroup_membership_created_by
+		,usergroup_membership_created
+		,usergroup_membership_updated
+		,usergroup_membership_role
+	) values (
+		 :usergroup_membership_space_id
+		,:usergroup_membership_usergroup_id
+		,:usergroup_membership_created_by
+		,:usergroup_membership_created
+		,:usergroup_membership_updated
+		,:usergroup_membership_role
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroupMembership(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert usergroup membership")
+	}
+
+	return nil
+}
+
+// Update updates the role of a usergroup membership.
+func (s *UserGroupMembershipStore) Update(ctx context.Context, membership *types.UserGroupMembership) error {
+	const sqlQuery = `
+	UPDATE usergroup_memberships
+	SET
+		 usergroup_membership_updated = :usergroup_membership_updated
+		,usergroup_membership_role = :usergroup_membership_role
+	WHERE usergroup_membership_space_id = :usergroup_membership_space_id AND
+	      usergroup_membership_usergroup_id = :usergroup_membership_usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMembership := mapToInternalUserGroupMembership(membership)
+	dbMembership.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMembership)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update usergroup membership role")
+	}
+
+	membership.Updated = dbMembership.Updated
+
+	return nil
+}
+
+// Delete deletes the usergroup membership.
+func (s *UserGroupMembershipStore) Delete(ctx context.Context, key types.UserGroupMembershipKey) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_memberships
+	WHERE usergroup_membership_space_id = $1 AND
+	      usergroup_membership_usergroup_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key.SpaceID, key.UserGroupID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "delete usergroup membership query failed")
+	}
+
+	return nil
+}
+
+// List returns all usergroup memberships of a space.
+func (s *UserGroupMembershipStore) List(
+	ctx context.Context,
+	spaceID int64,
+) ([]types.UserGroupMembershipInfo, error) {
+	const columns = userGroupMembershipColumns + "," + userGroupColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("usergroup_memberships").
+		InnerJoin("usergroups ON usergroup_membership_usergroup_id = usergroup_id").
+		Where("usergroup_membership_space_id = ?", spaceID).
+		OrderBy("usergroup_identifier ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup membership list query to sql: %w", err)
+	}
+
+	dst := make([]*userGroupMembershipUserGroup, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup membership list query")
+	}
+
+	ids := make([]int64, 0, len(dst))
+	for _, m := range dst {
+		ids = append(ids, m.userGroupMembership.CreatedBy)
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usergroup membership principal infos: %w", err)
+	}
+
+	res := make([]types.UserGroupMembershipInfo, len(dst))
+	for i, m := range dst {
+		res[i].UserGroupMembership = mapToUserGroupMembership(&m.userGroupMembership)
+		res[i].UserGroup = *mapUserGroup(&m.UserGroup).ToUserGroupInfo()
+		if addedBy, ok := infoMap[m.userGroupMembership.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
+
+// ListRoles returns the roles granted in the space to any of the usergroups.
+func (s *UserGroupMembershipStore) ListRoles(
+	ctx context.Context,
+	spaceID int64,
+	userGroupIDs []int64,
+) ([]enum.MembershipRole, error) {
+	stmt := database.Builder.
+		Select("DISTINCT usergroup_membership_role").
+		From("usergroup_memberships").
+		Where("usergroup_membership_space_id = ?", spaceID).
+		Where(squirrel.Eq{"usergroup_membership_usergroup_id": userGroupIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup membership roles query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var roles []enum.MembershipRole
+	if err = db.SelectContext(ctx, &roles, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup membership roles query")
+	}
+
+	return roles, nil
+}
+
+// CountByRole returns the number of usergroup memberships with the role.
+func (s *UserGroupMembershipStore) CountByRole(ctx context.Context, role enum.MembershipRole) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM usergroup_memberships
+	WHERE usergroup_membership_role = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, role).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup membership count query")
+	}
+
+	return count, nil
+}
+
+func mapToUserGroupMembership(m *userGroupMembership) types.UserGroupMembership {
+	return types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{
+			SpaceID:     m.SpaceID,
+			UserGroupID: m.UserGroupID,
+		},
+		CreatedBy: m.CreatedBy,
+		Created:   m.Created,
+		Updated:   m.Updated,
+		Role:      m.Role,
+	}
+}
+
+func mapToInternalUserGroupMembership(m *types.UserGroupMembership) userGroupMembership {
+	return userGroupMembership{
+		SpaceID:     m.SpaceID,
+		UserGroupID: m.UserGroupID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Role:        m.Role,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupSubgroupStore = (*UserGroupSubgroupStore)(nil)
+
+// NewUserGroupSubgroupStore returns a new UserGroupSubgroupStore.
+func NewUserGroupSubgroupStore(db *sqlx.DB) *UserGroupSubgroupStore {
+	return &UserGroupSubgroupStore{
+		db: db,
+	}
+}
+
+// UserGroupSubgroupStore implements store.UserGroupSubgroupStore backed by a relational database.
+type UserGroupSubgroupStore struct {
+	db *sqlx.DB
+}
+
+type userGroupSubgroup struct {
+	ParentID  int64 `db:"usergroup_subgroup_parent_id"`
+	ChildID   int64 `db:"usergroup_subgroup_child_id"`
+	CreatedBy int64 `db:"usergroup_subgroup_created_by"`
+	Created   int64 `db:"usergroup_subgroup_created"`
+}
+
+// Create nests the child usergroup in the parent usergroup.
+func (s *UserGroupSubgroupStore) Create(ctx context.Context, subgroup *types.UserGroupSubgroup) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_subgroups (
+		 usergroup_subgroup_parent_id
+		,usergroup_subgroup_child_id
+		,usergroup_subgroup_created_by
+		,usergroup_subgroup_created
+	) values (
+		 :usergroup_subgroup_parent_id
+		,:usergroup_subgroup_child_id
+		,:usergroup_subgroup_created_by
+		,:usergroup_subgroup_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, userGroupSubgroup(*subgroup))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup subgroup object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert usergroup subgroup")
+	}
+
+	return nil
+}
+
+// Delete removes the child usergroup from the parent usergroup.
+func (s *UserGroupSubgroupStore) Delete(ctx context.Context, parentID, childID int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_subgroups
+	WHERE usergroup_subgroup_parent_id = $1 AND
+	      usergroup_subgroup_child_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, parentID, childID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete usergroup subgroup")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListChildIDs returns the IDs of the usergroups nested directly in any of the parent usergroups.
+func (s *UserGroupSubgroupStore) ListChildIDs(ctx context.Context, parentIDs []int64) ([]int64, error) {
+	return s.listIDs(ctx, "usergroup_subgroup_child_id", "usergroup_subgroup_parent_id", parentIDs)
+}
+
+// ListParentIDs returns the IDs of the usergroups any of the child usergroups are directly nested in.
+func (s *UserGroupSubgroupStore) ListParentIDs(ctx context.Context, childIDs []int64) ([]int64, error) {
+	return s.listIDs(ctx, "usergroup_subgroup_parent_id", "usergroup_subgroup_child_id", childIDs)
+}
+
+func (s *UserGroupSubgroupStore) listIDs(
+	ctx context.Context,
+	selectColumn string,
+	filterColumn string,
+	ids []int64,
+) ([]int64, error) {
+	stmt := database.Builder.
+		Select("DISTINCT " + selectColumn).
+		From("usergroup_subgroups").
+		Where(squirrel.Eq{filterColumn: ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup subgroup ids query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var result []int64
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup subgroup ids query")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.WebAuthnCredentialStore = (*WebAuthnCredentialStore)(nil)
+
+// NewWebAuthnCredentialStore returns a new WebAuthnCredentialStore.
+func NewWebAuthnCredentialStore(db *sqlx.DB) *WebAuthnCredentialStore {
+	return &WebAuthnCredentialStore{
+		db: db,
+	}
+}
+
+// WebAuthnCredentialStore implements store.WebAuthnCredentialStore backed by a relational database.
+type WebAuthnCredentialStore struct {
+	db *sqlx.DB
+}
+
+type webAuthnCredential struct {
+	ID           int64           `db:"webauthn_credential_id"`
+	PrincipalID  int64           `db:"webauthn_credential_principal_id"`
+	CredentialID []byte          `db:"webauthn_credential_credential_id"`
+	Name         string          `db:"webauthn_credential_name"`
+	PublicKey    []byte          `db:"webauthn_credential_public_key"`
+	SignCount    int64           `db:"webauthn_credential_sign_count"`
+	AAGUID       []byte          `db:"webauthn_credential_aaguid"`
+	Transports   json.RawMessage `db:"webauthn_credential_transports"`
+	Created      int64           `db:"webauthn_credential_created"`
+	Updated      int64           `db:"webauthn_credential_updated"`
+	LastUsed     *int64          `db:"webauthn_credential_last_used"`
+}
+
+const (
+	webAuthnCredentialColumns = `
+		 webauthn_credential_id
+		,webauthn_credential_principal_id
+		,webauthn_credential_credential_id
+		,webauthn_credential_name
+		,webauthn_credential_public_key
+		,webauthn_credential_sign_count
+		,webauthn_credential_aaguid
+		,webauthn_credential_transports
+		,webauthn_credential_created
+		,webauthn_credential_updated
+		,webauthn_credential_last_used`
+
+	webAuthnCredentialSelectBase = `
+		SELECT` + webAuthnCredentialColumns + `
+		FROM webauthn_credentials`
+)
+
+// Find finds a WebAuthn credential by its ID.
+func (s *WebAuthnCredentialStore) Find(ctx context.Context, id int64) (*types.WebAuthnCredential, error) {
+	const sqlQuery = webAuthnCredentialSelectBase + `
+		WHERE webauthn_credential_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &webAuthnCredential{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find webauthn credential")
+	}
+
+	return mapWebAuthnCredential(dst)
+}
+
+// FindByCredentialID finds a WebAuthn credential by the identifier assigned by the authenticator.
+func (s *WebAuthnCredentialStore) FindByCredentialID(
+	ctx context.Context,
+	credentialID []byte,
+) (*types.WebAuthnCredential, error) {
+	const sqlQuery = webAuthnCredentialSelectBase + `
+		WHERE webauthn_credential_credential_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &webAuthnCredential{}
+	if err := db.GetContext(ctx, dst, sqlQuery, credentialID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find webauthn credential by credential id")
+	}
+
+	return mapWebAuthnCredential(dst)
+}
+
+// List returns all WebAuthn credentials of a user.
+func (s *WebAuthnCredentialStore) List(ctx context.Context, principalID int64) ([]*types.WebAuthnCredential, error) {
+	const sqlQuery = webAuthnCredentialSelectBase + `
+		WHERE webauthn_credential_principal_id = $1
+		ORDER BY webauthn_credential_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*webAuthnCredential, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list webauthn credentials")
+	}
+
+	out := make([]*types.WebAuthnCredential, len(dst))
+	for i, d := range dst {
+		credential, err := mapWebAuthnCredential(d)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = credential
+	}
+
+	return out, nil
+}
+
+// Count returns the number of WebAuthn credentials of a user.
+func (s *WebAuthnCredentialStore) Count(ctx context.Context, principalID int64) (int64, error) {
+	const sqlQuery = `
+		SELECT COUNT(*)
+		FROM webauthn_credentials
+		WHERE webauthn_credential_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, principalID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count webauthn credentials")
+	}
+
+	return count, nil
+}
+
+// Create stores a new WebAuthn credential.
+func (s *WebAuthnCredentialStore) Create(ctx context.Context, credential *types.WebAuthnCredential) error {
+	const sqlQuery = `
+		INSERT INTO webauthn_credentials (
+			 webauthn_credential_principal_id
+			,webauthn_credential_credential_id
+			,webauthn_credential_name
+			,webauthn_credential_public_key
+			,webauthn_credential_sign_count
+			,webauthn_credential_aaguid
+			,webauthn_credential_transports
+			,webauthn_credential_created
+			,webauthn_credential_updated
+			,webauthn_credential_last_used
+		) values (
+			 :webauthn_credential_principal_id
+			,:webauthn_credential_credential_id
+			,:webauthn_credential_name
+			,:webauthn_credential_public_key
+			,:webauthn_credential_sign_count
+			,:webauthn_credential_aaguid
+			,:webauthn_credential_transports
+			,:webauthn_credential_created
+			,:webauthn_credential_updated
+			,:webauthn_credential_last_used
+		) RETURNING webauthn_credential_id`
+
+	dbCredential, err := mapInternalWebAuthnCredential(credential)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbCredential)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind webauthn credential object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&credential.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert webauthn credential query failed")
+	}
+
+	return nil
+}
+
+// UpdateName renames a WebAuthn credential.
+func (s *WebAuthnCredentialStore) UpdateName(ctx context.Context, credential *types.WebAuthnCredential) error {
+	const sqlQuery = `
+		UPDATE webauthn_credentials
+		SET
+			 webauthn_credential_name = $1
+			,webauthn_credential_updated = $2
+		WHERE webauthn_credential_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	_, err := db.ExecContext(ctx, sqlQuery, credential.Name, credential.Updated, credential.ID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update webauthn credential name")
+	}
+
+	return nil
+}
+
+// UpdateSignCount stores the signature counter and the last usage time of an authentication.
+func (s *WebAuthnCredentialStore) UpdateSignCount(
+	ctx context.Context,
+	credential *types.WebAuthnCredential,
+	signCount uint32,
+) error {
+	const sqlQuery = `
+		UPDATE webauthn_credentials
+		SET
+			 webauthn_credential_sign_count = $1
+			,webauthn_credential_last_used = $2
+		WHERE webauthn_credential_id = $3 AND webauthn_credential_sign_count = $4`
+
+	now := time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, int64(signCount), now, credential.ID, int64(credential.SignCount))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update webauthn credential sign count")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	credential.SignCount = signCount
+	credential.LastUsed = &now
+
+	return nil
+}
+
+// Delete deletes a WebAuthn credential.
+func (s *WebAuthnCredentialStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM webauthn_credentials
+		WHERE webauthn_credential_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete webauthn credential")
+	}
+
+	return nil
+}
+
+func mapWebAuthnCredential(in *webAuthnCredential) (*types.WebAuthnCredential, error) {
+	transports := []string{}
+	if len(in.Transports) > 0 {
+		if err := json.Unmarshal(in.Transports, &transports); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webauthn credential transports: %w", err)
+		}
+	}
+
+	return &types.WebAuthnCredential{
+		ID:           in.ID,
+		PrincipalID:  in.PrincipalID,
+		CredentialID: in.CredentialID,
+		Name:         in.Name,
+		PublicKey:    in.PublicKey,
+		SignCount:    uint32(in.SignCount),
+		AAGUID:       in.AAGUID,
+		Transports:   transports,
+		Created:      in.Created,
+		Updated:      in.Updated,
+		LastUsed:     in.LastUsed,
+	}, nil
+}
+
+func mapInternalWebAuthnCredential(in *types.WebAuthnCredential) (*webAuthnCredential, error) {
+	transports := in.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	transportsJSON, err := json.Marshal(transports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webauthn credential transports: %w", err)
+	}
+
+	return &webAuthnCredential{
+		ID:           in.ID,
+		PrincipalID:  in.PrincipalID,
+		CredentialID: in.CredentialID,
+		Name:         in.Name,
+		PublicKey:    in.PublicKey,
+		SignCount:    int64(in.SignCount),
+		AAGUID:       in.AAGUID,
+		Transports:   transportsJSON,
+		Created:      in.Created,
+		Updated:      in.Updated,
+		LastUsed:     in.LastUsed,
+	}, nil
+}
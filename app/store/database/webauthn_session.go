@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.WebAuthnSessionStore = (*WebAuthnSessionStore)(nil)
+
+// NewWebAuthnSessionStore returns a new WebAuthnSessionStore.
+func NewWebAuthnSessionStore(db *sqlx.DB) *WebAuthnSessionStore {
+	return &WebAuthnSessionStore{
+		db: db,
+	}
+}
+
+// WebAuthnSessionStore implements store.WebAuthnSessionStore backed by a relational database.
+type WebAuthnSessionStore struct {
+	db *sqlx.DB
+}
+
+type webAuthnSession struct {
+	ID          string                   `db:"webauthn_session_id"`
+	PrincipalID null.Int                 `db:"webauthn_session_principal_id"`
+	Type        enum.WebAuthnSessionType `db:"webauthn_session_type"`
+	Challenge   []byte                   `db:"webauthn_session_challenge"`
+	Created     int64                    `db:"webauthn_session_created"`
+	Expires     int64                    `db:"webauthn_session_expires"`
+}
+
+const (
+	webAuthnSessionColumns = `
+		 webauthn_session_id
+		,webauthn_session_principal_id
+		,webauthn_session_type
+		,webauthn_session_challenge
+		,webauthn_session_created
+		,webauthn_session_expires`
+)
+
+// Create stores a new WebAuthn session.
+func (s *WebAuthnSessionStore) Create(ctx context.Context, session *types.WebAuthnSession) error {
+	const sqlQuery = `
+		INSERT INTO webauthn_sessions (` + webAuthnSessionColumns + `
+		) values (
+			 :webauthn_session_id
+			,:webauthn_session_principal_id
+			,:webauthn_session_type
+			,:webauthn_session_challenge
+			,:webauthn_session_created
+			,:webauthn_session_expires
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalWebAuthnSession(session))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind webauthn session object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert webauthn session query failed")
+	}
+
+	return nil
+}
+
+// Consume deletes the WebAuthn session and returns it.
+func (s *WebAuthnSessionStore) Consume(ctx context.Context, id string) (*types.WebAuthnSession, error) {
+	const sqlQuery = `
+		DELETE FROM webauthn_sessions
+		WHERE webauthn_session_id = $1
+		RETURNING` + webAuthnSessionColumns
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &webAuthnSession{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to consume webauthn session")
+	}
+
+	return mapWebAuthnSession(dst), nil
+}
+
+// DeleteExpired deletes all WebAuthn sessions that expired before the provided time.
+func (s *WebAuthnSessionStore) DeleteExpired(ctx context.Context, before int64) error {
+	const sqlQuery = `
+		DELETE FROM webauthn_sessions
+		WHERE webauthn_session_expires < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, before); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete expired webauthn sessions")
+	}
+
+	return nil
+}
+
+func mapWebAuthnSession(in *webAuthnSession) *types.WebAuthnSession {
+	return &types.WebAuthnSession{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID.Int64,
+		Type:        in.Type,
+		Challenge:   in.Challenge,
+		Created:     in.Created,
+		Expires:     in.Expires,
+	}
+}
+
+func mapInternalWebAuthnSession(in *types.WebAuthnSession) *webAuthnSession {
+	return &webAuthnSession{
+		ID:          in.ID,
+		PrincipalID: null.NewInt(in.PrincipalID, in.PrincipalID != 0),
+		Type:        in.Type,
+		Challenge:   in.Challenge,
+		Created:     in.Created,
+		Expires:     in.Expires,
+	}
+}
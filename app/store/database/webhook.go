@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -59,14 +60,17 @@ type webhook struct {
 
 	Identifier string `db:"webhook_uid"`
 	// TODO [CODE-1364]: Remove once UID/Identifier migration is completed.
-	DisplayName           string      `db:"webhook_display_name"`
-	Description           string      `db:"webhook_description"`
-	URL                   string      `db:"webhook_url"`
-	Secret                string      `db:"webhook_secret"`
-	Enabled               bool        `db:"webhook_enabled"`
-	Insecure              bool        `db:"webhook_insecure"`
-	Triggers              string      `db:"webhook_triggers"`
-	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+	DisplayName           string          `db:"webhook_display_name"`
+	Description           string          `db:"webhook_description"`
+	URL                   string          `db:"webhook_url"`
+	Secret                string          `db:"webhook_secret"`
+	PreviousSecret        string          `db:"webhook_previous_secret"`
+	PreviousSecretExpires int64           `db:"webhook_previous_secret_expires_at"`
+	Enabled               bool            `db:"webhook_enabled"`
+	Insecure              bool            `db:"webhook_insecure"`
+	Triggers              string          `db:"webhook_triggers"`
+	BranchPatterns        json.RawMessage `db:"webhook_branch_patterns"`
+	LatestExecutionResult null.String     `db:"webhook_latest_execution_result"`
 }
 
 const (
@@ -83,9 +87,12 @@ const (
 		,webhook_description
 		,webhook_url
 		,webhook_secret
+		,webhook_previous_secret
+		,webhook_previous_secret_expires_at
 		,webhook_enabled
 		,webhook_insecure
 		,webhook_triggers
+		,webhook_branch_patterns
 		,webhook_latest_execution_result
 		,webhook_internal`
 
@@ -169,9 +176,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_description
 			,webhook_url
 			,webhook_secret
+			,webhook_previous_secret
+			,webhook_previous_secret_expires_at
 			,webhook_enabled
 			,webhook_insecure
 			,webhook_triggers
+			,webhook_branch_patterns
 			,webhook_latest_execution_result
 			,webhook_internal
 		) values (
@@ -185,9 +195,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_description
 			,:webhook_url
 			,:webhook_secret
+			,:webhook_previous_secret
+			,:webhook_previous_secret_expires_at
 			,:webhook_enabled
 			,:webhook_insecure
 			,:webhook_triggers
+			,:webhook_branch_patterns
 			,:webhook_latest_execution_result
 			,:webhook_internal
 		) RETURNING webhook_id`
@@ -223,9 +236,12 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_description = :webhook_description
 			,webhook_url = :webhook_url
 			,webhook_secret = :webhook_secret
+			,webhook_previous_secret = :webhook_previous_secret
+			,webhook_previous_secret_expires_at = :webhook_previous_secret_expires_at
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
+			,webhook_branch_patterns = :webhook_branch_patterns
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
@@ -458,6 +474,8 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Description:           hook.Description,
 		URL:                   hook.URL,
 		Secret:                hook.Secret,
+		PreviousSecret:        hook.PreviousSecret,
+		PreviousSecretExpires: hook.PreviousSecretExpires,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersFromString(hook.Triggers),
@@ -465,6 +483,12 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Internal:              hook.Internal,
 	}
 
+	if len(hook.BranchPatterns) > 0 {
+		if err := json.Unmarshal(hook.BranchPatterns, &res.BranchPatterns); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal branch patterns of hook %d: %w", hook.ID, err)
+		}
+	}
+
 	switch {
 	case hook.RepoID.Valid && hook.SpaceID.Valid:
 		return nil, fmt.Errorf("both repoID and spaceID are set for hook %d", hook.ID)
@@ -494,6 +518,8 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Description:           hook.Description,
 		URL:                   hook.URL,
 		Secret:                hook.Secret,
+		PreviousSecret:        hook.PreviousSecret,
+		PreviousSecretExpires: hook.PreviousSecretExpires,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersToString(hook.Triggers),
@@ -501,6 +527,16 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Internal:              hook.Internal,
 	}
 
+	branchPatterns := hook.BranchPatterns
+	if branchPatterns == nil {
+		branchPatterns = []string{}
+	}
+	var err error
+	res.BranchPatterns, err = json.Marshal(branchPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal branch patterns: %w", err)
+	}
+
 	switch hook.ParentType {
 	case enum.WebhookParentRepo:
 		res.RepoID = null.IntFrom(hook.ParentID)
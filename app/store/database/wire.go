@@ -37,6 +37,8 @@ var WireSet = wire.NewSet(
 	ProvideSpacePathStore,
 	ProvideSpaceStore,
 	ProvideRepoStore,
+	ProvideRepoRedirectStore,
+	ProvideRepoStarStore,
 	ProvideRuleStore,
 	ProvideJobStore,
 	ProvideExecutionStore,
@@ -45,6 +47,8 @@ var WireSet = wire.NewSet(
 	ProvideStepStore,
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
+	ProvideRepoTrafficStore,
+	ProvideAttachmentStore,
 	ProvideMembershipStore,
 	ProvideTokenStore,
 	ProvidePullReqStore,
@@ -53,6 +57,38 @@ var WireSet = wire.NewSet(
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
+	ProvidePullReqReviewSLAStore,
+	ProvidePullReqAutoMergeStore,
+	ProvidePullReqDependencyStore,
+	ProvideReplicationStore,
+	ProvideMilestoneStore,
+	ProvideReleaseStore,
+	ProvideReleaseAssetStore,
+	ProvideRepoInsightsStore,
+	ProvideAuditEventStore,
+	ProvideAuditEventOutboxStore,
+	ProvideFileTemplateStore,
+	ProvideUserEmailStore,
+	ProvideUserPreferencesStore,
+	ProvideUserTwoFactorStore,
+	ProvideWebAuthnCredentialStore,
+	ProvideWebAuthnSessionStore,
+	ProvideOAuthProviderStore,
+	ProvideOAuthIdentityStore,
+	ProvideSAMLProviderStore,
+	ProvideSAMLIdentityStore,
+	ProvideLDAPIdentityStore,
+	ProvideLDAPGroupMappingStore,
+	ProvideRoleStore,
+	ProvideBannerStore,
+	ProvideRepoMembershipStore,
+	ProvideUserGroupMemberStore,
+	ProvideUserGroupSubgroupStore,
+	ProvideUserGroupMembershipStore,
+	ProvideIssueStore,
+	ProvideIssueCommentStore,
+	ProvideIssueAssigneeStore,
+	ProvideIssueLabelAssignmentStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
 	ProvideSettingsStore,
@@ -61,6 +97,11 @@ var WireSet = wire.NewSet(
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
+	ProvideTriggerRequestStore,
+	ProvideCronStore,
+	ProvidePipelineCacheStore,
+	ProvidePipelineArtifactStore,
+	ProvideRunnerStore,
 	ProvidePluginStore,
 	ProvidePublicKeyStore,
 	ProvideInfraProviderConfigStore,
@@ -143,8 +184,19 @@ func ProvideRepoStore(
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
 	spaceStore store.SpaceStore,
+	repoRedirectStore store.RepoRedirectStore,
 ) store.RepoStore {
-	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, repoRedirectStore)
+}
+
+// ProvideRepoRedirectStore provides a repo redirect store.
+func ProvideRepoRedirectStore(db *sqlx.DB) store.RepoRedirectStore {
+	return NewRepoRedirectStore(db)
+}
+
+// ProvideRepoStarStore provides a repo star store.
+func ProvideRepoStarStore(db *sqlx.DB) store.RepoStarStore {
+	return NewRepoStarStore(db)
 }
 
 // ProvideRuleStore provides a rule store.
@@ -219,6 +271,31 @@ func ProvideTriggerStore(db *sqlx.DB) store.TriggerStore {
 	return NewTriggerStore(db)
 }
 
+// ProvideCronStore provides a store for the cron triggers of pipelines.
+func ProvideCronStore(db *sqlx.DB) store.CronStore {
+	return NewCronStore(db)
+}
+
+// ProvidePipelineCacheStore provides a store for the build cache entries of pipelines.
+func ProvidePipelineCacheStore(db *sqlx.DB) store.PipelineCacheStore {
+	return NewPipelineCacheStore(db)
+}
+
+// ProvidePipelineArtifactStore provides a store for the artifacts of pipeline executions.
+func ProvidePipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return NewPipelineArtifactStore(db)
+}
+
+// ProvideRunnerStore provides a store for the external pipeline runners.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvideTriggerRequestStore provides a store for the requests to create pipeline executions.
+func ProvideTriggerRequestStore(db *sqlx.DB) store.TriggerRequestStore {
+	return NewTriggerRequestStore(db)
+}
+
 // ProvideExecutionStore provides an execution store.
 func ProvideExecutionStore(db *sqlx.DB) store.ExecutionStore {
 	return NewExecutionStore(db)
@@ -234,6 +311,16 @@ func ProvideRepoGitInfoView(db *sqlx.DB) store.RepoGitInfoView {
 	return NewRepoGitInfoView(db)
 }
 
+// ProvideAttachmentStore provides an attachment store.
+func ProvideAttachmentStore(db *sqlx.DB) store.AttachmentStore {
+	return NewAttachmentStore(db)
+}
+
+// ProvideRepoTrafficStore provides a repo traffic store.
+func ProvideRepoTrafficStore(db *sqlx.DB) store.RepoTrafficStore {
+	return NewRepoTrafficStore(db)
+}
+
 func ProvideMembershipStore(
 	db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
@@ -243,6 +330,24 @@ func ProvideMembershipStore(
 	return NewMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
 }
 
+// ProvideRepoMembershipStore provides a repo membership store.
+func ProvideRepoMembershipStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RepoMembershipStore {
+	return NewRepoMembershipStore(db, principalInfoCache)
+}
+
+// ProvideRoleStore provides a custom role store.
+func ProvideRoleStore(db *sqlx.DB) store.RoleStore {
+	return NewRoleStore(db)
+}
+
+// ProvideBannerStore provides an announcement banner store.
+func ProvideBannerStore(db *sqlx.DB) store.BannerStore {
+	return NewBannerStore(db)
+}
+
 // ProvideTokenStore provides a token store.
 func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
@@ -282,6 +387,142 @@ func ProvidePullReqReviewerStore(
 	return NewPullReqReviewerStore(db, principalInfoCache)
 }
 
+// ProvidePullReqReviewSLAStore provides a pull request review SLA store.
+func ProvidePullReqReviewSLAStore(db *sqlx.DB) store.PullReqReviewSLAStore {
+	return NewPullReqReviewSLAStore(db)
+}
+
+// ProvidePullReqAutoMergeStore provides a pull request auto-merge store.
+func ProvidePullReqAutoMergeStore(db *sqlx.DB) store.PullReqAutoMergeStore {
+	return NewPullReqAutoMergeStore(db)
+}
+
+// ProvidePullReqDependencyStore provides a pull request dependency store.
+func ProvidePullReqDependencyStore(db *sqlx.DB) store.PullReqDependencyStore {
+	return NewPullReqDependencyStore(db)
+}
+
+// ProvideMilestoneStore provides a milestone store.
+func ProvideMilestoneStore(db *sqlx.DB) store.MilestoneStore {
+	return NewMilestoneStore(db)
+}
+
+// ProvideReleaseStore provides a release store.
+func ProvideReleaseStore(db *sqlx.DB) store.ReleaseStore {
+	return NewReleaseStore(db)
+}
+
+// ProvideReleaseAssetStore provides a release asset store.
+func ProvideReleaseAssetStore(db *sqlx.DB) store.ReleaseAssetStore {
+	return NewReleaseAssetStore(db)
+}
+
+// ProvideRepoInsightsStore provides a repository insights store.
+func ProvideRepoInsightsStore(db *sqlx.DB) store.RepoInsightsStore {
+	return NewRepoInsightsStore(db)
+}
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(db *sqlx.DB) store.AuditEventStore {
+	return NewAuditEventStore(db)
+}
+
+// ProvideAuditEventOutboxStore provides an audit event outbox store.
+func ProvideAuditEventOutboxStore(db *sqlx.DB) store.AuditEventOutboxStore {
+	return NewAuditEventOutboxStore(db)
+}
+
+// ProvideFileTemplateStore provides a file template store.
+func ProvideFileTemplateStore(db *sqlx.DB) store.FileTemplateStore {
+	return NewFileTemplateStore(db)
+}
+
+// ProvideUserEmailStore provides a user email store.
+func ProvideUserEmailStore(db *sqlx.DB) store.UserEmailStore {
+	return NewUserEmailStore(db)
+}
+
+// ProvideUserPreferencesStore provides a user preferences store.
+func ProvideUserPreferencesStore(db *sqlx.DB) store.UserPreferencesStore {
+	return NewUserPreferencesStore(db)
+}
+
+// ProvideUserTwoFactorStore provides a user two-factor store.
+func ProvideUserTwoFactorStore(db *sqlx.DB) store.UserTwoFactorStore {
+	return NewUserTwoFactorStore(db)
+}
+
+// ProvideWebAuthnCredentialStore provides a webauthn credential store.
+func ProvideWebAuthnCredentialStore(db *sqlx.DB) store.WebAuthnCredentialStore {
+	return NewWebAuthnCredentialStore(db)
+}
+
+// ProvideWebAuthnSessionStore provides a webauthn session store.
+func ProvideWebAuthnSessionStore(db *sqlx.DB) store.WebAuthnSessionStore {
+	return NewWebAuthnSessionStore(db)
+}
+
+// ProvideOAuthProviderStore provides an oauth provider store.
+func ProvideOAuthProviderStore(db *sqlx.DB) store.OAuthProviderStore {
+	return NewOAuthProviderStore(db)
+}
+
+// ProvideOAuthIdentityStore provides an oauth identity store.
+func ProvideOAuthIdentityStore(db *sqlx.DB) store.OAuthIdentityStore {
+	return NewOAuthIdentityStore(db)
+}
+
+// ProvideSAMLProviderStore provides a saml provider store.
+func ProvideSAMLProviderStore(db *sqlx.DB) store.SAMLProviderStore {
+	return NewSAMLProviderStore(db)
+}
+
+// ProvideSAMLIdentityStore provides a saml identity store.
+func ProvideSAMLIdentityStore(db *sqlx.DB) store.SAMLIdentityStore {
+	return NewSAMLIdentityStore(db)
+}
+
+// ProvideLDAPIdentityStore provides an ldap identity store.
+func ProvideLDAPIdentityStore(db *sqlx.DB) store.LDAPIdentityStore {
+	return NewLDAPIdentityStore(db)
+}
+
+// ProvideLDAPGroupMappingStore provides an ldap group mapping store.
+func ProvideLDAPGroupMappingStore(db *sqlx.DB) store.LDAPGroupMappingStore {
+	return NewLDAPGroupMappingStore(db)
+}
+
+// ProvideIssueStore provides an issue store.
+func ProvideIssueStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueStore {
+	return NewIssueStore(db, principalInfoCache)
+}
+
+// ProvideIssueCommentStore provides an issue comment store.
+func ProvideIssueCommentStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueCommentStore {
+	return NewIssueCommentStore(db, principalInfoCache)
+}
+
+// ProvideIssueAssigneeStore provides an issue assignee store.
+func ProvideIssueAssigneeStore(db *sqlx.DB) store.IssueAssigneeStore {
+	return NewIssueAssigneeStore(db)
+}
+
+// ProvideIssueLabelAssignmentStore provides an issue label assignment store.
+func ProvideIssueLabelAssignmentStore(db *sqlx.DB) store.IssueLabelAssignmentStore {
+	return NewIssueLabelStore(db)
+}
+
+// ProvideReplicationStore provides a replication store.
+func ProvideReplicationStore(db *sqlx.DB) store.ReplicationStore {
+	return NewReplicationStore(db)
+}
+
 // ProvidePullReqFileViewStore provides a pull request file view store.
 func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
@@ -349,3 +590,24 @@ func ProvideInfraProviderTemplateStore(db *sqlx.DB) store.InfraProviderTemplateS
 func ProvideInfraProvisionedStore(db *sqlx.DB) store.InfraProvisionedStore {
 	return NewInfraProvisionedStore(db)
 }
+
+// ProvideUserGroupMemberStore provides a usergroup member store.
+func ProvideUserGroupMemberStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db, principalInfoCache)
+}
+
+// ProvideUserGroupSubgroupStore provides a usergroup subgroup store.
+func ProvideUserGroupSubgroupStore(db *sqlx.DB) store.UserGroupSubgroupStore {
+	return NewUserGroupSubgroupStore(db)
+}
+
+// ProvideUserGroupMembershipStore provides a usergroup membership store.
+func ProvideUserGroupMembershipStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.UserGroupMembershipStore {
+	return NewUserGroupMembershipStore(db, principalInfoCache)
+}
@@ -33,3 +33,18 @@ type LogStore interface {
 	// Delete purges the log stream from the datastore.
 	Delete(ctx context.Context, stepID int64) error
 }
+
+// LogArchiveStore provides an interface for the database log store that keeps track
+// of the logs moved to the log archive.
+type LogArchiveStore interface {
+	LogStore
+
+	// ListArchivable returns the step IDs of the logs in the database of executions finished before the time.
+	ListArchivable(ctx context.Context, finishedBefore int64, limit int) ([]int64, error)
+
+	// MarkArchived removes the log data from the database and marks the log as archived.
+	MarkArchived(ctx context.Context, stepID int64, archived int64) error
+
+	// ListArchivedBefore returns the step IDs of the logs archived before the time.
+	ListArchivedBefore(ctx context.Context, archivedBefore int64, limit int) ([]int64, error)
+}
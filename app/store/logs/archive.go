@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+var (
+	_ store.LogStore = (*ArchiveLogStore)(nil)
+	_ store.LogStore = (*archived)(nil)
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// NewArchiveLogStore returns a new log store that keeps the logs in the blob store,
+// which can be backed by the file system or by S3 or GCS compatible object storage.
+func NewArchiveLogStore(blobStore blob.Store, prefix string, compress bool) *ArchiveLogStore {
+	return &ArchiveLogStore{
+		blobStore: blobStore,
+		prefix:    prefix,
+		compress:  compress,
+	}
+}
+
+// ArchiveLogStore is the log store of the logs archived after their execution finished.
+type ArchiveLogStore struct {
+	blobStore blob.Store
+	prefix    string
+	compress  bool
+}
+
+// Find returns the archived log of the step.
+// Compressed logs are decompressed regardless of the current compression setting.
+func (s *ArchiveLogStore) Find(ctx context.Context, step int64) (io.ReadCloser, error) {
+	rc, err := s.blobStore.Download(ctx, s.key(step))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archived log: %w", err)
+	}
+
+	r, err := decompress(rc)
+	if err != nil {
+		_ = rc.Close()
+		return nil, fmt.Errorf("failed to decompress archived log: %w", err)
+	}
+
+	return readCloser{Reader: r, Closer: rc}, nil
+}
+
+// Create uploads the log of the step to the blob store.
+func (s *ArchiveLogStore) Create(ctx context.Context, step int64, r io.Reader) error {
+	if s.compress {
+		buf := new(bytes.Buffer)
+		zw := gzip.NewWriter(buf)
+		if _, err := io.Copy(zw, r); err != nil {
+			return fmt.Errorf("failed to compress log: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress log: %w", err)
+		}
+		r = buf
+	}
+
+	if err := s.blobStore.Upload(ctx, r, s.key(step)); err != nil {
+		return fmt.Errorf("failed to upload archived log: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces the archived log of the step.
+func (s *ArchiveLogStore) Update(ctx context.Context, step int64, r io.Reader) error {
+	return s.Create(ctx, step, r)
+}
+
+// Delete deletes the archived log of the step.
+func (s *ArchiveLogStore) Delete(ctx context.Context, step int64) error {
+	if err := s.blobStore.Delete(ctx, s.key(step)); err != nil {
+		return fmt.Errorf("failed to delete archived log: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ArchiveLogStore) key(step int64) string {
+	return path.Join(s.prefix, fmt.Sprint(step))
+}
+
+// decompress returns a reader of the decompressed data if the data is gzip compressed.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if !bytes.Equal(magic, gzipMagic) {
+		return br, nil
+	}
+
+	return gzip.NewReader(br)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// NewArchived returns a new log store that reads through to the log archive
+// for the logs that were moved from the primary log store.
+func NewArchived(primary store.LogStore, archive store.LogStore) store.LogStore {
+	return &archived{
+		primary: primary,
+		archive: archive,
+	}
+}
+
+type archived struct {
+	primary, archive store.LogStore
+}
+
+func (s *archived) Find(ctx context.Context, step int64) (io.ReadCloser, error) {
+	rc, err := s.primary.Find(ctx, step)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return s.archive.Find(ctx, step)
+	}
+	return rc, err
+}
+
+func (s *archived) Create(ctx context.Context, step int64, r io.Reader) error {
+	return s.primary.Create(ctx, step, r)
+}
+
+func (s *archived) Update(ctx context.Context, step int64, r io.Reader) error {
+	return s.primary.Update(ctx, step, r)
+}
+
+func (s *archived) Delete(ctx context.Context, step int64) error {
+	if err := s.primary.Delete(ctx, step); err != nil {
+		return err
+	}
+	return s.archive.Delete(ctx, step)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryBlobStore struct {
+	files map[string][]byte
+}
+
+func (s *memoryBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	s.files[filePath] = data
+	return nil
+}
+
+func (s *memoryBlobStore) GetSignedURL(context.Context, string) (string, error) {
+	return "", blob.ErrNotSupported
+}
+
+func (s *memoryBlobStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryBlobStore) Delete(_ context.Context, filePath string) error {
+	delete(s.files, filePath)
+	return nil
+}
+
+func readLog(t *testing.T, s store.LogStore, step int64) string {
+	t.Helper()
+	rc, err := s.Find(context.Background(), step)
+	require.NoError(t, err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestArchiveLogStore(t *testing.T) {
+	ctx := context.Background()
+	blobStore := &memoryBlobStore{files: map[string][]byte{}}
+	compressed := NewArchiveLogStore(blobStore, "logs", true)
+	uncompressed := NewArchiveLogStore(blobStore, "logs", false)
+
+	const data = `[{"pos":0,"out":"hello"}]`
+
+	require.NoError(t, compressed.Create(ctx, 1, bytes.NewBufferString(data)))
+	require.NotEqual(t, data, string(blobStore.files["logs/1"]))
+	require.Equal(t, data, readLog(t, compressed, 1))
+	// archives are decompressed regardless of the compression setting.
+	require.Equal(t, data, readLog(t, uncompressed, 1))
+
+	require.NoError(t, uncompressed.Create(ctx, 2, bytes.NewBufferString(data)))
+	require.Equal(t, data, string(blobStore.files["logs/2"]))
+	require.Equal(t, data, readLog(t, compressed, 2))
+
+	require.NoError(t, uncompressed.Create(ctx, 3, &bytes.Buffer{}))
+	require.Equal(t, "", readLog(t, compressed, 3))
+
+	require.NoError(t, compressed.Delete(ctx, 1))
+	_, err := compressed.Find(ctx, 1)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
+
+func TestArchived(t *testing.T) {
+	ctx := context.Background()
+	archive := NewArchiveLogStore(&memoryBlobStore{files: map[string][]byte{}}, "logs", true)
+	primary := NewArchiveLogStore(&memoryBlobStore{files: map[string][]byte{}}, "", false)
+	s := NewArchived(primary, archive)
+
+	require.NoError(t, s.Create(ctx, 1, bytes.NewBufferString("live")))
+	require.NoError(t, archive.Create(ctx, 2, bytes.NewBufferString("archived")))
+
+	require.Equal(t, "live", readLog(t, s, 1))
+	require.Equal(t, "archived", readLog(t, s, 2))
+
+	_, err := s.Find(ctx, 3)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	require.NoError(t, s.Delete(ctx, 2))
+	_, err = archive.Find(ctx, 2)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
@@ -27,7 +27,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-var _ store.LogStore = (*logStore)(nil)
+var _ store.LogArchiveStore = (*logStore)(nil)
 
 // not used out of this package.
 type logs struct {
@@ -42,17 +42,24 @@ func NewDatabaseLogStore(db *sqlx.DB) store.LogStore {
 	}
 }
 
+// NewDatabaseLogArchiveStore returns a new LogArchiveStore.
+func NewDatabaseLogArchiveStore(db *sqlx.DB) store.LogArchiveStore {
+	return &logStore{
+		db: db,
+	}
+}
+
 type logStore struct {
 	db *sqlx.DB
 }
 
-// Find returns a log given a log ID.
+// Find returns a log given a log ID. Logs moved to the log archive aren't found.
 func (s *logStore) Find(ctx context.Context, stepID int64) (io.ReadCloser, error) {
 	const findQueryStmt = `
 			SELECT
 			log_id, log_data
 			FROM logs
-			WHERE log_id = $1`
+			WHERE log_id = $1 AND log_archived = 0`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	var err error
@@ -139,3 +146,65 @@ func (s *logStore) Delete(ctx context.Context, stepID int64) error {
 
 	return nil
 }
+
+// ListArchivable returns the log IDs of the logs in the database of executions finished before the time.
+func (s *logStore) ListArchivable(ctx context.Context, finishedBefore int64, limit int) ([]int64, error) {
+	const listArchivableStmt = `
+		SELECT log_id
+		FROM logs
+		INNER JOIN steps ON step_id = log_id
+		INNER JOIN stages ON stage_id = step_stage_id
+		INNER JOIN executions ON execution_id = stage_execution_id
+		WHERE log_archived = 0
+			AND execution_finished > 0
+			AND execution_finished < $1
+		ORDER BY log_id
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, listArchivableStmt, finishedBefore, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list archivable logs")
+	}
+
+	return dst, nil
+}
+
+// MarkArchived removes the log data from the database and marks the log as archived.
+func (s *logStore) MarkArchived(ctx context.Context, stepID int64, archived int64) error {
+	const logMarkArchivedStmt = `
+		UPDATE logs
+		SET
+			log_data = $1
+			,log_archived = $2
+		WHERE log_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, logMarkArchivedStmt, []byte{}, archived, stepID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark log as archived")
+	}
+
+	return nil
+}
+
+// ListArchivedBefore returns the log IDs of the logs archived before the time.
+func (s *logStore) ListArchivedBefore(ctx context.Context, archivedBefore int64, limit int) ([]int64, error) {
+	const listArchivedStmt = `
+		SELECT log_id
+		FROM logs
+		WHERE log_archived > 0
+			AND log_archived < $1
+		ORDER BY log_id
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, listArchivedStmt, archivedBefore, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list archived logs")
+	}
+
+	return dst, nil
+}
@@ -16,6 +16,7 @@ package logs
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -25,10 +26,15 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideLogStore,
+	ProvideLogArchiveStore,
+	ProvideArchiveLogStore,
 )
 
-func ProvideLogStore(db *sqlx.DB, config *types.Config) store.LogStore {
+func ProvideLogStore(db *sqlx.DB, config *types.Config, archive *ArchiveLogStore) store.LogStore {
 	s := NewDatabaseLogStore(db)
+	if config.Logs.Archive.Enabled {
+		s = NewArchived(s, archive)
+	}
 	if config.Logs.S3.Bucket != "" {
 		p := NewS3LogStore(
 			config.Logs.S3.Bucket,
@@ -40,3 +46,13 @@ func ProvideLogStore(db *sqlx.DB, config *types.Config) store.LogStore {
 	}
 	return s
 }
+
+// ProvideLogArchiveStore provides the database log store that keeps track of the archived logs.
+func ProvideLogArchiveStore(db *sqlx.DB) store.LogArchiveStore {
+	return NewDatabaseLogArchiveStore(db)
+}
+
+// ProvideArchiveLogStore provides the log store of the logs archived in the blob store.
+func ProvideArchiveLogStore(blobStore blob.Store, config *types.Config) *ArchiveLogStore {
+	return NewArchiveLogStore(blobStore, config.Logs.Archive.Prefix, config.Logs.Archive.Compress)
+}
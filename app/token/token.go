@@ -17,10 +17,13 @@ package token
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -32,6 +35,9 @@ const (
 	// NOTE: Users can list / delete session tokens via rest API if they want to cleanup earlier.
 	userSessionTokenLifeTime                  time.Duration = 30 * 24 * time.Hour // 30 days.
 	sessionTokenWithAccessPermissionsLifeTime time.Duration = 24 * time.Hour      // 24 hours.
+
+	// maxUserAgentLength limits the length of the user agent stored with a session token.
+	maxUserAgentLength = 512
 )
 
 func CreateUserWithAccessPermissions(
@@ -46,20 +52,38 @@ func CreateUserWithAccessPermissions(
 	)
 }
 
+// CreateUserSession creates a session token for the user,
+// recording the user agent and IP address of the client the request came from.
 func CreateUserSession(
 	ctx context.Context,
 	tokenStore store.TokenStore,
 	user *types.User,
 	identifier string,
+) (*types.Token, string, error) {
+	return CreateScopedUserSession(ctx, tokenStore, user, identifier, nil)
+}
+
+// CreateScopedUserSession creates a session token for the user that is restricted to the scopes.
+func CreateScopedUserSession(
+	ctx context.Context,
+	tokenStore store.TokenStore,
+	user *types.User,
+	identifier string,
+	scopes []enum.TokenScope,
 ) (*types.Token, string, error) {
 	principal := user.ToPrincipal()
 	return create(
 		ctx,
 		tokenStore,
-		enum.TokenTypeSession,
+		&types.Token{
+			Type:       enum.TokenTypeSession,
+			Identifier: identifier,
+			UserAgent:  truncate(audit.GetUserAgent(ctx), maxUserAgentLength),
+			IP:         audit.GetRealIP(ctx),
+			Scopes:     scopes,
+		},
 		principal,
 		principal,
-		identifier,
 		ptr.Duration(userSessionTokenLifeTime),
 	)
 }
@@ -71,14 +95,34 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+) (*types.Token, string, error) {
+	return CreateScopedPAT(ctx, tokenStore, createdBy, createdFor, identifier, lifetime, nil, nil, nil)
+}
+
+// CreateScopedPAT creates a personal access token restricted to the scopes, resources and networks.
+func CreateScopedPAT(
+	ctx context.Context,
+	tokenStore store.TokenStore,
+	createdBy *types.Principal,
+	createdFor *types.User,
+	identifier string,
+	lifetime *time.Duration,
+	scopes []enum.TokenScope,
+	resources []types.TokenResource,
+	allowedCIDRs []string,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
 		tokenStore,
-		enum.TokenTypePAT,
+		&types.Token{
+			Type:         enum.TokenTypePAT,
+			Identifier:   identifier,
+			Scopes:       scopes,
+			Resources:    resources,
+			AllowedCIDRs: allowedCIDRs,
+		},
 		createdBy,
 		createdFor.ToPrincipal(),
-		identifier,
 		lifetime,
 	)
 }
@@ -90,25 +134,29 @@ func CreateSAT(
 	createdFor *types.ServiceAccount,
 	identifier string,
 	lifetime *time.Duration,
+	allowedCIDRs []string,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
 		tokenStore,
-		enum.TokenTypeSAT,
+		&types.Token{
+			Type:         enum.TokenTypeSAT,
+			Identifier:   identifier,
+			AllowedCIDRs: allowedCIDRs,
+		},
 		createdBy,
 		createdFor.ToPrincipal(),
-		identifier,
 		lifetime,
 	)
 }
 
+// create stores the token (with the type, identifier and restrictions set) and returns it with the jwt.
 func create(
 	ctx context.Context,
 	tokenStore store.TokenStore,
-	tokenType enum.TokenType,
+	token *types.Token,
 	createdBy *types.Principal,
 	createdFor *types.Principal,
-	identifier string,
 	lifetime *time.Duration,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
@@ -119,27 +167,76 @@ func create(
 	}
 
 	// create db entry first so we get the id.
-	token := types.Token{
-		Type:        tokenType,
-		Identifier:  identifier,
-		PrincipalID: createdFor.ID,
-		IssuedAt:    issuedAt.UnixMilli(),
-		ExpiresAt:   expiresAt,
-		CreatedBy:   createdBy.ID,
-	}
+	token.PrincipalID = createdFor.ID
+	token.IssuedAt = issuedAt.UnixMilli()
+	token.ExpiresAt = expiresAt
+	token.CreatedBy = createdBy.ID
 
-	err := tokenStore.Create(ctx, &token)
+	err := tokenStore.Create(ctx, token)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to store token in db: %w", err)
 	}
 
 	// create jwt token.
-	jwtToken, err := jwt.GenerateForToken(&token, createdFor.Salt)
+	jwtToken, err := jwt.GenerateForToken(token, createdFor.Salt)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create jwt token: %w", err)
 	}
 
-	return &token, jwtToken, nil
+	return token, jwtToken, nil
+}
+
+// Rotate replaces the secret of the token and returns the token with the new jwt.
+// The new secret is valid for the provided lifetime, the previous secret is accepted until the grace period ends.
+// The secret replaced by an earlier rotation isn't accepted anymore, even if its grace period didn't end yet.
+func Rotate(
+	ctx context.Context,
+	tokenStore store.TokenStore,
+	token *types.Token,
+	createdFor *types.Principal,
+	lifetime *time.Duration,
+	gracePeriod time.Duration,
+) (*types.Token, string, error) {
+	issuedAt := time.Now()
+
+	// jwts only contain the issue time in seconds, which is used to tell the previous and the new secret apart.
+	if issuedAt.Unix() == token.IssuedAt/1000 {
+		return nil, "", errors.Conflict("The token was issued less than a second ago, please retry.")
+	}
+
+	var expiresAt *int64
+	if lifetime != nil {
+		expiresAt = ptr.Int64(issuedAt.Add(*lifetime).UnixMilli())
+	}
+
+	previousIssuedAt := token.IssuedAt
+
+	token.PreviousIssuedAt = &previousIssuedAt
+	token.PreviousValidUntil = ptr.Int64(issuedAt.Add(gracePeriod).UnixMilli())
+	token.IssuedAt = issuedAt.UnixMilli()
+	token.ExpiresAt = expiresAt
+	token.ExpiryWarnedAt = nil
+
+	err := tokenStore.Update(ctx, token)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update token in db: %w", err)
+	}
+
+	jwtToken, err := jwt.GenerateForToken(token, createdFor.Salt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create jwt token: %w", err)
+	}
+
+	return token, jwtToken, nil
+}
+
+// Lifetime returns the lifetime the token was issued with, nil if the token doesn't expire.
+func Lifetime(token *types.Token) *time.Duration {
+	if token.ExpiresAt == nil {
+		return nil
+	}
+
+	return ptr.Duration(time.Duration(*token.ExpiresAt-token.IssuedAt) * time.Millisecond)
 }
 
 func createWithAccessPermissions(
@@ -156,3 +253,11 @@ func createWithAccessPermissions(
 
 	return jwtToken, nil
 }
+
+// truncate cuts the string to at most n bytes, dropping a partial rune at the end.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package url
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Forwarded contains the external facing URL details of a request as reported by a reverse proxy.
+type Forwarded struct {
+	// Scheme is the protocol used by the client (http or https).
+	Scheme string
+	// Host is the host (and optional port) requested by the client.
+	Host string
+	// Prefix is the path prefix that was removed by the proxy (optional).
+	Prefix string
+}
+
+type forwardedKey struct{}
+
+// WithForwarded returns a copy of the context with the forwarded information attached.
+func WithForwarded(ctx context.Context, forwarded Forwarded) context.Context {
+	return context.WithValue(ctx, forwardedKey{}, forwarded)
+}
+
+// ForwardedFrom returns the forwarded information of the context (if any).
+func ForwardedFrom(ctx context.Context) (Forwarded, bool) {
+	forwarded, ok := ctx.Value(forwardedKey{}).(Forwarded)
+	return forwarded, ok
+}
+
+// ParseForwarded extracts the external facing URL details from the standard Forwarded header (RFC 7239),
+// falling back to the X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers.
+// Only the first (client facing) proxy entry is taken into account.
+// Returns false if the request doesn't contain a valid forwarded host.
+//
+// IMPORTANT: The headers can be set by any client - only use with requests coming from a trusted proxy.
+func ParseForwarded(req *http.Request) (Forwarded, bool) {
+	var forwarded Forwarded
+
+	if header := req.Header.Get("Forwarded"); header != "" {
+		first, _, _ := strings.Cut(header, ",")
+		for _, pair := range strings.Split(first, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				continue
+			}
+			value = strings.Trim(value, `"`)
+			switch strings.ToLower(key) {
+			case "proto":
+				forwarded.Scheme = value
+			case "host":
+				forwarded.Host = value
+			}
+		}
+	}
+
+	if forwarded.Host == "" {
+		forwarded.Host = firstHeaderValue(req, "X-Forwarded-Host")
+	}
+	if forwarded.Scheme == "" {
+		forwarded.Scheme = firstHeaderValue(req, "X-Forwarded-Proto")
+	}
+	forwarded.Prefix = firstHeaderValue(req, "X-Forwarded-Prefix")
+
+	if forwarded.Host == "" || strings.ContainsAny(forwarded.Host, "/\\@ ") {
+		return Forwarded{}, false
+	}
+
+	forwarded.Scheme = strings.ToLower(forwarded.Scheme)
+	if forwarded.Scheme != "http" && forwarded.Scheme != "https" {
+		forwarded.Scheme = "http"
+		if req.TLS != nil {
+			forwarded.Scheme = "https"
+		}
+	}
+
+	if forwarded.Prefix != "" {
+		forwarded.Prefix = path.Clean("/" + forwarded.Prefix)
+		if forwarded.Prefix == "/" {
+			forwarded.Prefix = ""
+		}
+	}
+
+	return forwarded, true
+}
+
+func firstHeaderValue(req *http.Request, name string) string {
+	value, _, _ := strings.Cut(req.Header.Get(name), ",")
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package url
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseForwarded(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    Forwarded
+		wantOK  bool
+	}{
+		{
+			name:   "no headers",
+			wantOK: false,
+		},
+		{
+			name: "forwarded header",
+			headers: map[string]string{
+				"Forwarded": `for=1.2.3.4;proto=https;host="example.com", for=5.6.7.8;proto=http;host=proxy`,
+			},
+			want:   Forwarded{Scheme: "https", Host: "example.com"},
+			wantOK: true,
+		},
+		{
+			name: "x-forwarded headers",
+			headers: map[string]string{
+				"X-Forwarded-Proto":  "https",
+				"X-Forwarded-Host":   "example.com:8443, proxy",
+				"X-Forwarded-Prefix": "/gitness/",
+			},
+			want:   Forwarded{Scheme: "https", Host: "example.com:8443", Prefix: "/gitness"},
+			wantOK: true,
+		},
+		{
+			name: "invalid host",
+			headers: map[string]string{
+				"X-Forwarded-Host": "example.com/evil",
+			},
+			wantOK: false,
+		},
+		{
+			name: "unknown proto",
+			headers: map[string]string{
+				"X-Forwarded-Proto": "gopher",
+				"X-Forwarded-Host":  "example.com",
+			},
+			want:   Forwarded{Scheme: "http", Host: "example.com"},
+			wantOK: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/repos", nil)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+
+			got, ok := ParseForwarded(req)
+			require.Equal(t, test.wantOK, ok)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestProviderExternal(t *testing.T) {
+	p, err := NewProvider(
+		"http://localhost:3000",
+		"http://host.docker.internal:3000",
+		"http://localhost:3000/gitness",
+		"http://localhost:3000/gitness/api",
+		"http://git.localhost:3000/gitness/git",
+		"ssh://localhost",
+		"git",
+		false,
+		"http://localhost:3000/gitness",
+		"http://host.docker.internal:3000",
+	)
+	require.NoError(t, err)
+
+	ctx := WithForwarded(context.Background(), Forwarded{Scheme: "https", Host: "example.com", Prefix: "/code"})
+
+	// ui url is served via the base host and is rewritten
+	require.Equal(t, "https://example.com/code/space/repo", p.GenerateUIRepoURL(ctx, "space/repo"))
+	// git url is served via a different host and stays as configured
+	require.Equal(t, "http://git.localhost:3000/gitness/git/space/repo.git", p.GenerateGITCloneURL(ctx, "space/repo"))
+}
@@ -42,6 +42,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GetInternalAPIURL(ctx context.Context) string
 
+	// GetContainerAPIURL returns the base url of the api that can be used by CI container builds.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GetContainerAPIURL(ctx context.Context) string
+
 	// GenerateContainerGITCloneURL generates a URL that can be used by CI container builds to
 	// interact with Harness and clone a repo.
 	GenerateContainerGITCloneURL(ctx context.Context, repoPath string) string
@@ -60,9 +64,41 @@ type Provider interface {
 	// GenerateUIPRURL returns the url for the UI screen of an existing pr.
 	GenerateUIPRURL(ctx context.Context, repoPath string, prID int64) string
 
+	// GenerateUIIssueURL returns the url for the UI screen of an existing issue.
+	GenerateUIIssueURL(ctx context.Context, repoPath string, issueNumber int64) string
+
 	// GenerateUICompareURL returns the url for the UI screen comparing two references.
 	GenerateUICompareURL(ctx context.Context, repoPath string, ref1 string, ref2 string) string
 
+	// GenerateUIFileURL returns the url for the UI screen of a file or a directory of a repository.
+	GenerateUIFileURL(ctx context.Context, repoPath string, gitRef string, filePath string) string
+
+	// GenerateUIEmailVerificationURL returns the url of the page that verifies an email address with the token.
+	GenerateUIEmailVerificationURL(ctx context.Context, token string) string
+
+	// GenerateUIHomeURL returns the url of the landing page of the UI.
+	GenerateUIHomeURL(ctx context.Context) string
+
+	// GenerateUISignInURL returns the url of the sign-in page, optionally showing the provided error.
+	GenerateUISignInURL(ctx context.Context, errMessage string) string
+
+	// GenerateOAuthCallbackURL returns the url the OAuth provider redirects to after the user authorized the login.
+	GenerateOAuthCallbackURL(ctx context.Context, providerIdentifier string) string
+
+	// GenerateSAMLMetadataURL returns the url of the service provider metadata, which is also used as entity ID.
+	GenerateSAMLMetadataURL(providerIdentifier string) string
+
+	// GenerateSAMLACSURL returns the url of the assertion consumer service the SAML provider posts responses to.
+	GenerateSAMLACSURL(providerIdentifier string) string
+
+	// GenerateAttachmentPath returns the path (without scheme and host) from which an attachment
+	// of a repository can be downloaded.
+	GenerateAttachmentPath(ctx context.Context, repoID int64, fileName string) string
+
+	// GenerateRawFilePath returns the path (without scheme and host) from which the raw content
+	// of a file of a repository can be downloaded.
+	GenerateRawFilePath(ctx context.Context, repoID int64, gitRef string, filePath string) string
+
 	// GetAPIHostname returns the host for the api endpoint.
 	GetAPIHostname(ctx context.Context) string
 
@@ -89,6 +125,10 @@ type provider struct {
 	// build container.
 	containerURL *url.URL
 
+	// baseURL stores the URL via which the service is reachable at publicly.
+	// It's used to identify which URLs can be overwritten by forwarded headers of a trusted proxy.
+	baseURL *url.URL
+
 	// apiURL stores the raw URL the api endpoints are reachable at publicly.
 	apiURL *url.URL
 
@@ -110,6 +150,7 @@ type provider struct {
 func NewProvider(
 	internalURLRaw,
 	containerURLRaw string,
+	baseURLRaw string,
 	apiURLRaw string,
 	gitURLRaw,
 	gitSSHURLRaw string,
@@ -121,6 +162,7 @@ func NewProvider(
 	// remove trailing '/' to make usage easier
 	internalURLRaw = strings.TrimRight(internalURLRaw, "/")
 	containerURLRaw = strings.TrimRight(containerURLRaw, "/")
+	baseURLRaw = strings.TrimRight(baseURLRaw, "/")
 	apiURLRaw = strings.TrimRight(apiURLRaw, "/")
 	gitURLRaw = strings.TrimRight(gitURLRaw, "/")
 	gitSSHURLRaw = strings.TrimRight(gitSSHURLRaw, "/")
@@ -137,6 +179,11 @@ func NewProvider(
 		return nil, fmt.Errorf("provided containerURLRaw '%s' is invalid: %w", containerURLRaw, err)
 	}
 
+	baseURL, err := url.Parse(baseURLRaw)
+	if err != nil {
+		return nil, fmt.Errorf("provided baseURLRaw '%s' is invalid: %w", baseURLRaw, err)
+	}
+
 	apiURL, err := url.Parse(apiURLRaw)
 	if err != nil {
 		return nil, fmt.Errorf("provided apiURLRaw '%s' is invalid: %w", apiURLRaw, err)
@@ -165,6 +212,7 @@ func NewProvider(
 	return &provider{
 		internalURL:    internalURL,
 		containerURL:   containerURL,
+		baseURL:        baseURL,
 		apiURL:         apiURL,
 		gitURL:         gitURL,
 		gitSSHURL:      gitSSHURL,
@@ -179,6 +227,10 @@ func (p *provider) GetInternalAPIURL(context.Context) string {
 	return p.internalURL.JoinPath(APIMount).String()
 }
 
+func (p *provider) GetContainerAPIURL(context.Context) string {
+	return p.containerURL.JoinPath(APIMount).String()
+}
+
 func (p *provider) GenerateContainerGITCloneURL(_ context.Context, repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
@@ -188,13 +240,13 @@ func (p *provider) GenerateContainerGITCloneURL(_ context.Context, repoPath stri
 	return p.containerURL.JoinPath(GITMount, repoPath).String()
 }
 
-func (p *provider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
+func (p *provider) GenerateGITCloneURL(ctx context.Context, repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
 		repoPath += GITSuffix
 	}
 
-	return p.gitURL.JoinPath(repoPath).String()
+	return p.external(ctx, p.gitURL).JoinPath(repoPath).String()
 }
 
 func (p *provider) GenerateGITCloneSSHURL(_ context.Context, repoPath string) string {
@@ -204,23 +256,80 @@ func (p *provider) GenerateGITCloneSSHURL(_ context.Context, repoPath string) st
 	return BuildGITCloneSSHURL(p.SSHDefaultUser, p.gitSSHURL, repoPath)
 }
 
-func (p *provider) GenerateUIBuildURL(_ context.Context, repoPath, pipelineIdentifier string, seqNumber int64) string {
-	return p.uiURL.JoinPath(
+func (p *provider) GenerateUIBuildURL(ctx context.Context, repoPath, pipelineIdentifier string, seqNumber int64) string {
+	return p.external(ctx, p.uiURL).JoinPath(
 		repoPath, "pipelines",
 		pipelineIdentifier, "execution", strconv.Itoa(int(seqNumber)),
 	).String()
 }
 
-func (p *provider) GenerateUIRepoURL(_ context.Context, repoPath string) string {
-	return p.uiURL.JoinPath(repoPath).String()
+func (p *provider) GenerateUIRepoURL(ctx context.Context, repoPath string) string {
+	return p.external(ctx, p.uiURL).JoinPath(repoPath).String()
+}
+
+func (p *provider) GenerateUIPRURL(ctx context.Context, repoPath string, prID int64) string {
+	return p.external(ctx, p.uiURL).JoinPath(repoPath, "pulls", fmt.Sprint(prID)).String()
+}
+
+func (p *provider) GenerateUIIssueURL(ctx context.Context, repoPath string, issueNumber int64) string {
+	return p.external(ctx, p.uiURL).JoinPath(repoPath, "issues", fmt.Sprint(issueNumber)).String()
+}
+
+func (p *provider) GenerateUICompareURL(ctx context.Context, repoPath string, ref1 string, ref2 string) string {
+	return p.external(ctx, p.uiURL).JoinPath(repoPath, "pulls/compare", ref1+"..."+ref2).String()
 }
 
-func (p *provider) GenerateUIPRURL(_ context.Context, repoPath string, prID int64) string {
-	return p.uiURL.JoinPath(repoPath, "pulls", fmt.Sprint(prID)).String()
+func (p *provider) GenerateUIFileURL(ctx context.Context, repoPath string, gitRef string, filePath string) string {
+	return p.external(ctx, p.uiURL).JoinPath(repoPath, "files", gitRef, "~", filePath).String()
 }
 
-func (p *provider) GenerateUICompareURL(_ context.Context, repoPath string, ref1 string, ref2 string) string {
-	return p.uiURL.JoinPath(repoPath, "pulls/compare", ref1+"..."+ref2).String()
+func (p *provider) GenerateUIEmailVerificationURL(ctx context.Context, token string) string {
+	u := p.external(ctx, p.uiURL).JoinPath("verify-email")
+	u.RawQuery = url.Values{"token": []string{token}}.Encode()
+	return u.String()
+}
+
+func (p *provider) GenerateUIHomeURL(ctx context.Context) string {
+	return p.external(ctx, p.uiURL).String()
+}
+
+func (p *provider) GenerateUISignInURL(ctx context.Context, errMessage string) string {
+	u := p.external(ctx, p.uiURL).JoinPath("signin")
+	if errMessage != "" {
+		u.RawQuery = url.Values{"error": []string{errMessage}}.Encode()
+	}
+	return u.String()
+}
+
+func (p *provider) GenerateOAuthCallbackURL(ctx context.Context, providerIdentifier string) string {
+	return p.external(ctx, p.apiURL).JoinPath("v1/login/oauth", providerIdentifier, "callback").String()
+}
+
+// GenerateSAMLMetadataURL doesn't depend on the request, as the url is registered with the identity provider.
+func (p *provider) GenerateSAMLMetadataURL(providerIdentifier string) string {
+	return p.apiURL.JoinPath("v1/login/saml", providerIdentifier, "metadata").String()
+}
+
+// GenerateSAMLACSURL doesn't depend on the request, as the url is registered with the identity provider.
+func (p *provider) GenerateSAMLACSURL(providerIdentifier string) string {
+	return p.apiURL.JoinPath("v1/login/saml", providerIdentifier, "acs").String()
+}
+
+func (p *provider) GenerateAttachmentPath(ctx context.Context, repoID int64, fileName string) string {
+	return p.external(ctx, p.apiURL).JoinPath("v1/repos", strconv.FormatInt(repoID, 10), "uploads", fileName).Path
+}
+
+func (p *provider) GenerateRawFilePath(ctx context.Context, repoID int64, gitRef string, filePath string) string {
+	// the git reference is a single path segment, so the relative links of the file stay within the git reference.
+	// JoinPath expects escaped elements, so all segments are escaped to keep the escaped slashes of the reference.
+	segments := strings.Split(filePath, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+
+	return p.external(ctx, p.apiURL).JoinPath(
+		"v1/repos", strconv.FormatInt(repoID, 10), "raw-ref", url.PathEscape(gitRef), strings.Join(segments, "/"),
+	).EscapedPath()
 }
 
 func (p *provider) GetAPIHostname(context.Context) string {
@@ -239,6 +348,26 @@ func (p *provider) RegistryURL() string {
 	return p.registryURL.String()
 }
 
+// external returns the URL as seen by the client of the current request.
+// If the request came in via a trusted proxy (see WithForwarded), URLs that are served via the base host
+// are rewritten to the scheme, host and path prefix reported by the proxy.
+func (p *provider) external(ctx context.Context, u *url.URL) *url.URL {
+	forwarded, ok := ForwardedFrom(ctx)
+	if !ok || !strings.EqualFold(u.Host, p.baseURL.Host) {
+		return u
+	}
+
+	res := *u
+	res.Scheme = forwarded.Scheme
+	res.Host = forwarded.Host
+	if forwarded.Prefix != "" {
+		res.Path = forwarded.Prefix + strings.TrimPrefix(u.Path, p.baseURL.Path)
+		res.RawPath = ""
+	}
+
+	return &res
+}
+
 func BuildGITCloneSSHURL(user string, sshURL *url.URL, repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
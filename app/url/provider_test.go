@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package url
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderGenerateRawFilePath(t *testing.T) {
+	p, err := NewProvider(
+		"http://localhost:3000",
+		"http://host.docker.internal:3000",
+		"http://localhost:3000/gitness",
+		"http://localhost:3000/gitness/api",
+		"http://git.localhost:3000/gitness/git",
+		"ssh://localhost",
+		"git",
+		false,
+		"http://localhost:3000/gitness",
+		"http://host.docker.internal:3000",
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.Equal(t, "/gitness/api/v1/repos/1/raw-ref/main/docs/README.md",
+		p.GenerateRawFilePath(ctx, 1, "main", "docs/README.md"))
+
+	// git references with slashes are kept in a single path segment.
+	require.Equal(t, "/gitness/api/v1/repos/1/raw-ref/feature%2Fdocs/docs/README.md",
+		p.GenerateRawFilePath(ctx, 1, "feature/docs", "docs/README.md"))
+
+	require.Equal(t, "/gitness/api/v1/repos/1/raw-ref/main/docs/a%20b%25.md",
+		p.GenerateRawFilePath(ctx, 1, "main", "docs/a b%.md"))
+
+	ctx = WithForwarded(ctx, Forwarded{Scheme: "https", Host: "example.com", Prefix: "/code"})
+	require.Equal(t, "/code/api/v1/repos/1/raw-ref/feature%2Fdocs/logo.png",
+		p.GenerateRawFilePath(ctx, 1, "feature/docs", "logo.png"))
+}
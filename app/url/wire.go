@@ -27,6 +27,7 @@ func ProvideURLProvider(config *types.Config) (Provider, error) {
 	return NewProvider(
 		config.URL.Internal,
 		config.URL.Container,
+		config.URL.Base,
 		config.URL.API,
 		config.URL.Git,
 		config.URL.GitSSH,
@@ -35,6 +35,11 @@ const (
 	BypassedResourceType            = "bypassedResourceType"
 	BypassedResourceName            = "bypassedResourceName"
 	RepoPath                        = "repoPath"
+	ServiceAccountName              = "serviceAccountName"
+	RejectedReason                  = "rejectedReason"
+	PipelineIdentifier              = "pipelineIdentifier"
+	ExecutionNumber                 = "executionNumber"
+	StageName                       = "stageName"
 	BypassedResourceTypePullRequest = "pull_request"
 	BypassedResourceTypeBranch      = "branch"
 	BypassedResourceTypeCommit      = "commit"
@@ -52,11 +57,13 @@ const (
 	ActionUpdated  Action = "updated" // update default branch, switching default branch, updating description
 	ActionDeleted  Action = "deleted"
 	ActionBypassed Action = "bypassed"
+	ActionRejected Action = "rejected" // token used from a network outside of its IP allowlist
+	ActionUsed     Action = "used"     // secret exposed to a pipeline execution
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed:
+	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed, ActionRejected, ActionUsed:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -73,6 +80,15 @@ const (
 	ResourceTypeRepositorySettings    ResourceType = "repository_settings"
 	ResourceTypeRegistry              ResourceType = "registry"
 	ResourceTypeRegistryUpstreamProxy ResourceType = "registry_upstream_proxy"
+	ResourceTypeSpaceSettings         ResourceType = "space_settings"
+	ResourceTypeSpaceQuota            ResourceType = "space_quota"
+	ResourceTypeSpaceMembership       ResourceType = "space_membership"
+	ResourceTypeRepoMembership        ResourceType = "repository_membership"
+	ResourceTypeUserGroupMembership   ResourceType = "usergroup_membership"
+	ResourceTypeServiceAccountToken   ResourceType = "service_account_token"
+	ResourceTypeServiceAccount        ResourceType = "service_account"
+	ResourceTypePersonalAccessToken   ResourceType = "personal_access_token"
+	ResourceTypeSecret                ResourceType = "secret"
 )
 
 func (a ResourceType) Validate() error {
@@ -83,7 +99,16 @@ func (a ResourceType) Validate() error {
 		ResourceTypePullRequest,
 		ResourceTypeRepositorySettings,
 		ResourceTypeRegistry,
-		ResourceTypeRegistryUpstreamProxy:
+		ResourceTypeRegistryUpstreamProxy,
+		ResourceTypeSpaceSettings,
+		ResourceTypeSpaceQuota,
+		ResourceTypeSpaceMembership,
+		ResourceTypeRepoMembership,
+		ResourceTypeUserGroupMembership,
+		ResourceTypeServiceAccountToken,
+		ResourceTypeServiceAccount,
+		ResourceTypePersonalAccessToken,
+		ResourceTypeSecret:
 		return nil
 
 	default:
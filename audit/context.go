@@ -22,6 +22,7 @@ const (
 	realIPKey key = iota
 	requestID
 	requestMethod
+	userAgent
 )
 
 // GetRealIP returns IP address from context.
@@ -53,3 +54,13 @@ func GetRequestMethod(ctx context.Context) string {
 
 	return method
 }
+
+// GetUserAgent returns the user agent of the client from context.
+func GetUserAgent(ctx context.Context) string {
+	agent, ok := ctx.Value(userAgent).(string)
+	if !ok {
+		return ""
+	}
+
+	return agent
+}
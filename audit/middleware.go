@@ -32,12 +32,13 @@ func Middleware() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
-			if rip := realIP(r); rip != "" {
+			if rip := RealIP(r); rip != "" {
 				ctx = context.WithValue(ctx, realIPKey, rip)
 			}
 
 			ctx = context.WithValue(ctx, requestMethod, r.Method)
 			ctx = context.WithValue(ctx, requestID, w.Header().Get("X-Request-Id"))
+			ctx = context.WithValue(ctx, userAgent, r.UserAgent())
 
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
@@ -45,7 +46,8 @@ func Middleware() func(next http.Handler) http.Handler {
 	}
 }
 
-func realIP(r *http.Request) string {
+// RealIP returns the IP address of the client, taking the headers set by reverse proxies into account.
+func RealIP(r *http.Request) string {
 	var ip string
 
 	if tcip := r.Header.Get(trueClientIP); tcip != "" {
@@ -21,6 +21,7 @@ type Provider string
 const (
 	ProviderGCS        Provider = "gcs"
 	ProviderFileSystem Provider = "filesystem"
+	ProviderS3         Provider = "s3"
 )
 
 type Config struct {
@@ -29,4 +30,9 @@ type Config struct {
 	KeyPath               string
 	TargetPrincipal       string
 	ImpersonationLifetime time.Duration
+	// PoolBuckets maps storage pools to the buckets used instead of the default bucket.
+	PoolBuckets map[string]string
+	// Endpoint is the URL of an S3 compatible storage, the AWS endpoint is used if empty.
+	Endpoint  string
+	PathStyle bool
 }
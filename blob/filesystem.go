@@ -92,3 +92,13 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+
+	if err := os.Remove(fileDiskPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	return nil
+}
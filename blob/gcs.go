@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -108,8 +109,35 @@ func (c *GCSStore) GetSignedURL(ctx context.Context, filePath string) (string, e
 	return signedURL, nil
 }
 
-func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error) {
-	return nil, fmt.Errorf("not implemented")
+func (c *GCSStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	reader, err := gcsClient.Bucket(c.config.Bucket).Object(filePath).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return reader, nil
+}
+
+func (c *GCSStore) Delete(ctx context.Context, filePath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
 }
 
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
@@ -34,4 +34,7 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete removes a file from the blob store. It doesn't fail if the file doesn't exist.
+	Delete(ctx context.Context, filePath string) error
 }
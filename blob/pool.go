@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PoolStore holds the blob stores of the storage pools.
+// Storage pools without a dedicated blob store use the default store.
+type PoolStore struct {
+	defaultStore Store
+	stores       map[string]Store
+}
+
+func NewPoolStore(defaultStore Store, stores map[string]Store) *PoolStore {
+	return &PoolStore{
+		defaultStore: defaultStore,
+		stores:       stores,
+	}
+}
+
+// Get returns the blob store of the storage pool.
+func (p *PoolStore) Get(pool string) Store {
+	if store, ok := p.stores[pool]; ok {
+		return store
+	}
+
+	return p.defaultStore
+}
+
+// DeleteAll removes a file from the blob stores of all storage pools.
+// It's meant for files whose storage pool isn't known anymore.
+func (p *PoolStore) DeleteAll(ctx context.Context, filePath string) error {
+	if err := p.defaultStore.Delete(ctx, filePath); err != nil {
+		return err
+	}
+
+	for pool, store := range p.stores {
+		if err := store.Delete(ctx, filePath); err != nil {
+			return fmt.Errorf("failed to delete file from blob store of storage pool %q: %w", pool, err)
+		}
+	}
+
+	return nil
+}
+
+// Copy copies a file from the blob store of one storage pool to the blob store of another one.
+// It's a no-op in case both storage pools use the same blob store or the file doesn't exist.
+func (p *PoolStore) Copy(ctx context.Context, filePath, fromPool, toPool string) error {
+	from := p.Get(fromPool)
+	to := p.Get(toPool)
+	if from == to {
+		return nil
+	}
+
+	file, err := from.Download(ctx, filePath)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer file.Close()
+
+	if err = to.Upload(ctx, file, filePath); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrMaxSizeExceeded = errors.New("maximum size exceeded")
+
+// CountingReader counts the bytes read from the underlying reader, e.g. the size of an uploaded file.
+// If a maximum size is set, reading fails with ErrMaxSizeExceeded once more bytes were read.
+type CountingReader struct {
+	reader  io.Reader
+	maxSize int64
+	n       int64
+}
+
+// NewCountingReader returns a new CountingReader. A maxSize of zero or less disables the size limit.
+func NewCountingReader(reader io.Reader, maxSize int64) *CountingReader {
+	return &CountingReader{reader: reader, maxSize: maxSize}
+}
+
+func (r *CountingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	if r.Exceeded() {
+		return n, ErrMaxSizeExceeded
+	}
+	return n, err
+}
+
+// N returns the number of bytes read so far.
+func (r *CountingReader) N() int64 {
+	return r.n
+}
+
+// Exceeded returns true if more than the maximum size was read.
+func (r *CountingReader) Exceeded() bool {
+	return r.maxSize > 0 && r.n > r.maxSize
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCountingReader(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxSize      int64
+		wantErr      error
+		wantExceeded bool
+	}{
+		{name: "no limit"},
+		{name: "within the limit", maxSize: 10},
+		{name: "at the limit", maxSize: 5},
+		{name: "exceeds the limit", maxSize: 4, wantErr: ErrMaxSizeExceeded, wantExceeded: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader := NewCountingReader(strings.NewReader("12345"), test.maxSize)
+
+			_, err := io.Copy(io.Discard, reader)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("error = %v, want %v", err, test.wantErr)
+			}
+			if reader.Exceeded() != test.wantExceeded {
+				t.Errorf("exceeded = %t, want %t", reader.Exceeded(), test.wantExceeded)
+			}
+			if err == nil && reader.N() != 5 {
+				t.Errorf("read %d bytes, want 5", reader.N())
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Store stores the files in an S3 bucket. Any S3 compatible storage (e.g. minio)
+// can be used by configuring its endpoint.
+type S3Store struct {
+	bucket   string
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	disableSSL := false
+	if cfg.Endpoint != "" {
+		disableSSL = !strings.HasPrefix(cfg.Endpoint, "https://")
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(cfg.Endpoint),
+		DisableSSL:       aws.Bool(disableSSL),
+		S3ForcePathStyle: aws.Bool(cfg.PathStyle),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 session: %w", err)
+	}
+
+	return &S3Store{
+		bucket:   cfg.Bucket,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:    aws.String("private"),
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write file to s3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+	})
+
+	signedURL, err := req.Presign(1 * time.Hour)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
+	}
+
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %s from bucket: %s %w", filePath, c.bucket, err)
+	}
+
+	return out.Body, nil
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	// deleting a key that doesn't exist succeeds.
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.bucket, err)
+	}
+
+	return nil
+}
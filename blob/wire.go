@@ -23,6 +23,7 @@ import (
 
 var WireSet = wire.NewSet(
 	ProvideStore,
+	ProvidePoolStore,
 )
 
 func ProvideStore(ctx context.Context, config Config) (Store, error) {
@@ -31,7 +32,27 @@ func ProvideStore(ctx context.Context, config Config) (Store, error) {
 		return NewFileSystemStore(config)
 	case ProviderGCS:
 		return NewGCSStore(ctx, config)
+	case ProviderS3:
+		return NewS3Store(config)
 	default:
 		return nil, fmt.Errorf("invalid blob store provider: %s", config.Provider)
 	}
 }
+
+func ProvidePoolStore(ctx context.Context, config Config, defaultStore Store) (*PoolStore, error) {
+	stores := make(map[string]Store, len(config.PoolBuckets))
+	for pool, bucket := range config.PoolBuckets {
+		poolConfig := config
+		poolConfig.Bucket = bucket
+		poolConfig.PoolBuckets = nil
+
+		store, err := ProvideStore(ctx, poolConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob store for storage pool %q: %w", pool, err)
+		}
+
+		stores[pool] = store
+	}
+
+	return NewPoolStore(defaultStore, stores), nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/cli/operations/server"
+	gitnessdatabase "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Register the admin command.
+// The admin commands operate directly on the database and the git root,
+// and are meant to be executed while the server is stopped.
+func Register(app *kingpin.Application) {
+	cmd := app.Command("admin", "offline administration and recovery tool (server must be stopped)")
+	registerResetPassword(cmd)
+	registerPromote(cmd)
+	registerUnlockMigration(cmd)
+	registerRefreshRepo(cmd)
+}
+
+func loadConfig(envfile string) (*types.Config, error) {
+	_ = godotenv.Load(envfile)
+
+	config, err := server.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+func getDB(ctx context.Context, config *types.Config) (*sqlx.DB, error) {
+	db, err := gitnessdatabase.Connect(ctx, config.Database.Driver, config.Database.Datasource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database handle: %w", err)
+	}
+
+	return db, nil
+}
+
+func getPrincipalStore(db *sqlx.DB) store.PrincipalStore {
+	return database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+}
+
+func getRepoStore(db *sqlx.DB) store.RepoStore {
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+
+	return database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, database.NewRepoRedirectStore(db))
+}
+
+func getPullReqStore(db *sqlx.DB) store.PullReqStore {
+	principalInfoCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	return database.NewPullReqStore(db, principalInfoCache)
+}
+
+func setupLoggingContext(ctx context.Context) context.Context {
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log := log.Logger.With().Logger()
+	return log.WithContext(ctx)
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	gittypes "github.com/harness/gitness/git/types"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	users   map[string]*types.User
+	updates int
+}
+
+func (s *fakePrincipalStore) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	user, ok := s.users[uid]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return user, nil
+}
+
+func (s *fakePrincipalStore) UpdateUser(_ context.Context, user *types.User) error {
+	s.users[user.UID] = user
+	s.updates++
+	return nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Update(_ context.Context, repo *types.Repository) error {
+	s.repo = repo
+	return nil
+}
+
+func (s *fakeRepoStore) UpdateSize(_ context.Context, _ int64, sizeInKiB int64) error {
+	s.repo.Size = sizeInKiB
+	return nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs     []*types.PullReq
+	updates int
+}
+
+func (s *fakePullReqStore) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	if opts.Page > 1 {
+		return nil, nil
+	}
+	return s.prs, nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	if err := mutateFn(pr); err != nil {
+		return nil, err
+	}
+	s.updates++
+	return pr, nil
+}
+
+func TestSetUserAdmin(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &fakePrincipalStore{users: map[string]*types.User{"jane": {UID: "jane"}}}
+
+	updated, err := setUserAdmin(ctx, principalStore, "jane", true)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.True(t, principalStore.users["jane"].Admin)
+
+	// promoting an admin again doesn't touch the user.
+	updated, err = setUserAdmin(ctx, principalStore, "jane", true)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.Equal(t, 1, principalStore.updates)
+
+	updated, err = setUserAdmin(ctx, principalStore, "jane", false)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, principalStore.users["jane"].Admin)
+
+	_, err = setUserAdmin(ctx, principalStore, "unknown", true)
+	assert.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
+
+func TestResetUserPassword(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &fakePrincipalStore{users: map[string]*types.User{"jane": {UID: "jane"}}}
+
+	require.NoError(t, resetUserPassword(ctx, principalStore, "jane", "new-password"))
+
+	hash := principalStore.users["jane"].Password
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")))
+	assert.NotZero(t, principalStore.users["jane"].Updated)
+
+	err := resetUserPassword(ctx, principalStore, "unknown", "new-password")
+	assert.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
+
+func TestRefreshRepoMetadata(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	ctx := context.Background()
+	repoPath := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	runGit("init", "--bare", "--initial-branch=develop")
+
+	gitAPI, err := api.New(gittypes.Config{}, nil, nil)
+	require.NoError(t, err)
+
+	metadata, err := readRepoMetadata(ctx, gitAPI, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "develop", metadata.DefaultBranch)
+	assert.True(t, metadata.IsEmpty)
+
+	commitSHA := runGit("-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit-tree", sha.EmptyTree.String(), "-m", "initial")
+	runGit("update-ref", "refs/heads/develop", commitSHA)
+
+	metadata, err = readRepoMetadata(ctx, gitAPI, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "develop", metadata.DefaultBranch)
+	assert.False(t, metadata.IsEmpty)
+
+	repoStore := &fakeRepoStore{}
+	repo := &types.Repository{ID: 1, DefaultBranch: "main", IsEmpty: true}
+
+	require.NoError(t, updateRepoMetadata(ctx, repoStore, repo, metadata))
+	assert.Equal(t, "develop", repoStore.repo.DefaultBranch)
+	assert.False(t, repoStore.repo.IsEmpty)
+	assert.Equal(t, metadata.Size, repoStore.repo.Size)
+}
+
+func TestRefreshPullReqRefs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	ctx := context.Background()
+	repoPath := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	runGit("init", "--bare", "--initial-branch=main")
+	commit := func(message string) string {
+		return runGit("-c", "user.name=test", "-c", "user.email=test@example.com",
+			"commit-tree", sha.EmptyTree.String(), "-m", message)
+	}
+	first := commit("first")
+	second := commit("second")
+	runGit("update-ref", "refs/heads/feature", second)
+	runGit("update-ref", "refs/pullreq/2/head", first)
+
+	gitAPI, err := api.New(gittypes.Config{}, nil, nil)
+	require.NoError(t, err)
+
+	repo := &types.Repository{ID: 1}
+	pullReqStore := &fakePullReqStore{prs: []*types.PullReq{
+		// open pull request behind its source branch, without head ref.
+		{Number: 1, State: enum.PullReqStateOpen, SourceRepoID: 1, TargetRepoID: 1,
+			SourceBranch: "feature", SourceSHA: first, MergeCheckStatus: enum.MergeCheckStatusMergeable},
+		// merged pull request, the head ref is up to date.
+		{Number: 2, State: enum.PullReqStateMerged, SourceRepoID: 1, TargetRepoID: 1,
+			SourceBranch: "deleted", SourceSHA: first},
+		// open pull request with a deleted source branch keeps its source commit.
+		{Number: 3, State: enum.PullReqStateOpen, SourceRepoID: 1, TargetRepoID: 1,
+			SourceBranch: "deleted", SourceSHA: first},
+	}}
+
+	updated, err := refreshPullReqRefs(ctx, gitAPI, pullReqStore, repo, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	assert.Equal(t, 1, pullReqStore.updates)
+
+	pr := pullReqStore.prs[0]
+	assert.Equal(t, second, pr.SourceSHA)
+	assert.Equal(t, enum.MergeCheckStatusUnchecked, pr.MergeCheckStatus)
+	assert.Equal(t, second, runGit("rev-parse", "refs/pullreq/1/head"))
+	assert.Equal(t, first, runGit("rev-parse", "refs/pullreq/2/head"))
+	assert.Equal(t, first, runGit("rev-parse", "refs/pullreq/3/head"))
+
+	// a second run finds nothing to refresh.
+	updated, err = refreshPullReqRefs(ctx, gitAPI, pullReqStore, repo, repoPath)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
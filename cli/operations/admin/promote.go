@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type promoteCommand struct {
+	envfile string
+	uid     string
+	demote  bool
+}
+
+func (c *promoteCommand) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	config, err := loadConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	db, err := getDB(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	admin := !c.demote
+
+	updated, err := setUserAdmin(ctx, getPrincipalStore(db), c.uid, admin)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		fmt.Printf("user '%s' already has admin=%t\n", c.uid, admin)
+		return nil
+	}
+
+	fmt.Printf("user '%s' updated to admin=%t\n", c.uid, admin)
+
+	return nil
+}
+
+// setUserAdmin grants or revokes the system admin privileges of the user.
+// It returns false if the user already had the requested privileges.
+func setUserAdmin(ctx context.Context, principalStore store.PrincipalStore, uid string, admin bool) (bool, error) {
+	user, err := principalStore.FindUserByUID(ctx, uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to find user '%s': %w", uid, err)
+	}
+
+	if user.Admin == admin {
+		return false, nil
+	}
+
+	user.Admin = admin
+	user.Updated = time.Now().UnixMilli()
+
+	if err = principalStore.UpdateUser(ctx, user); err != nil {
+		return false, fmt.Errorf("failed to update user '%s': %w", uid, err)
+	}
+
+	return true, nil
+}
+
+func registerPromote(app *kingpin.CmdClause) {
+	c := &promoteCommand{}
+
+	cmd := app.Command("promote", "grant system admin privileges to a user").
+		Action(c.run)
+
+	cmd.Arg("uid", "uid of the user").
+		Required().
+		StringVar(&c.uid)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("demote", "revoke system admin privileges instead").
+		BoolVar(&c.demote)
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/command"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type refreshRepoCommand struct {
+	envfile string
+	repoRef string
+}
+
+// repoMetadata is the repository metadata stored in the database that can be read from the git repository.
+type repoMetadata struct {
+	DefaultBranch string
+	IsEmpty       bool
+	Size          int64
+}
+
+// run re-synchronizes the repository metadata stored in the database (default branch, emptiness and size)
+// and the ref metadata of its pull requests (source commits and head refs) with the actual state
+// of the git repository on disk.
+// NOTE: Branches and tags aren't stored in the database, they are always read from the git repository.
+func (c *refreshRepoCommand) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	config, err := loadConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	db, err := getDB(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repoStore := getRepoStore(db)
+
+	repo, err := repoStore.FindByRef(ctx, c.repoRef)
+	if err != nil {
+		return fmt.Errorf("failed to find repository '%s': %w", c.repoRef, err)
+	}
+
+	repoPath, err := getRepoPath(config, repo)
+	if err != nil {
+		return err
+	}
+
+	gitAPI, err := api.New(gittypes.Config{Root: config.Git.Root}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create git api: %w", err)
+	}
+
+	metadata, err := readRepoMetadata(ctx, gitAPI, repoPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("default branch: %q -> %q\n", repo.DefaultBranch, metadata.DefaultBranch)
+	fmt.Printf("is empty:       %t -> %t\n", repo.IsEmpty, metadata.IsEmpty)
+	fmt.Printf("size (KiB):     %d -> %d\n", repo.Size, metadata.Size)
+
+	if err = updateRepoMetadata(ctx, repoStore, repo, metadata); err != nil {
+		return err
+	}
+
+	pullReqStore := getPullReqStore(db)
+
+	updated, err := refreshPullReqRefs(ctx, gitAPI, pullReqStore, repo, repoPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("refs of %d pull requests refreshed\n", updated)
+	fmt.Printf("metadata of repository '%s' refreshed\n", repo.Path)
+
+	return nil
+}
+
+// getRepoPath returns the path of the git repository of the repository in its storage pool.
+func getRepoPath(config *types.Config, repo *types.Repository) (string, error) {
+	gitRoot := config.Git.Root
+	if repo.StoragePool != git.DefaultStoragePool {
+		gitRoot = config.Git.StoragePools[repo.StoragePool]
+	}
+
+	repoPath := git.RepoPath(gitRoot, repo.GitUID)
+	if _, err := os.Stat(repoPath); err != nil {
+		return "", fmt.Errorf("failed to access git repository of '%s': %w", repo.Path, err)
+	}
+
+	return repoPath, nil
+}
+
+// readRepoMetadata reads the repository metadata from the git repository.
+func readRepoMetadata(ctx context.Context, gitAPI *api.Git, repoPath string) (repoMetadata, error) {
+	defaultBranchRef, err := gitAPI.GetDefaultBranch(ctx, repoPath)
+	if err != nil {
+		return repoMetadata{}, fmt.Errorf("failed to read default branch: %w", err)
+	}
+
+	// NOTE: HasBranches returns true iff the repo has no branches (same as it's used in git service).
+	isEmpty, err := gitAPI.HasBranches(ctx, repoPath)
+	if err != nil {
+		return repoMetadata{}, fmt.Errorf("failed to check whether the repository is empty: %w", err)
+	}
+
+	objectCount, err := gitAPI.CountObjects(ctx, repoPath)
+	if err != nil {
+		return repoMetadata{}, fmt.Errorf("failed to count repository objects: %w", err)
+	}
+
+	return repoMetadata{
+		DefaultBranch: strings.TrimPrefix(strings.TrimSpace(defaultBranchRef), api.BranchPrefix),
+		IsEmpty:       isEmpty,
+		Size:          objectCount.Size + objectCount.SizePack,
+	}, nil
+}
+
+// updateRepoMetadata stores the repository metadata in the database.
+func updateRepoMetadata(
+	ctx context.Context,
+	repoStore store.RepoStore,
+	repo *types.Repository,
+	metadata repoMetadata,
+) error {
+	repo.DefaultBranch = metadata.DefaultBranch
+	repo.IsEmpty = metadata.IsEmpty
+
+	if err := repoStore.Update(ctx, repo); err != nil {
+		return fmt.Errorf("failed to update repository: %w", err)
+	}
+
+	if err := repoStore.UpdateSize(ctx, repo.ID, metadata.Size); err != nil {
+		return fmt.Errorf("failed to update repository size: %w", err)
+	}
+
+	return nil
+}
+
+// refreshPullReqRefs re-synchronizes the ref metadata of the pull requests targeting the repository:
+// The source commit of open pull requests is moved to the current commit of their source branch
+// (their mergeability gets rechecked), and the head refs of all pull requests are recreated
+// if they're missing or don't point to the source commit of the pull request.
+// Pull requests from forks keep their source commit, as the commits of the fork might not be
+// available in the repository. It returns the number of updated pull requests.
+func refreshPullReqRefs(
+	ctx context.Context,
+	gitAPI *api.Git,
+	pullReqStore store.PullReqStore,
+	repo *types.Repository,
+	repoPath string,
+) (int, error) {
+	const pageSize = 100
+
+	var updated int
+	for page := 1; ; page++ {
+		prs, err := pullReqStore.List(ctx, &types.PullReqFilter{
+			Page:         page,
+			Size:         pageSize,
+			TargetRepoID: repo.ID,
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderAsc,
+		})
+		if err != nil {
+			return updated, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		for _, pr := range prs {
+			changed, err := refreshPullReqRef(ctx, gitAPI, pullReqStore, pr, repoPath)
+			if err != nil {
+				return updated, fmt.Errorf("failed to refresh refs of pull request #%d: %w", pr.Number, err)
+			}
+			if changed {
+				updated++
+			}
+		}
+
+		if len(prs) < pageSize {
+			return updated, nil
+		}
+	}
+}
+
+func refreshPullReqRef(
+	ctx context.Context,
+	gitAPI *api.Git,
+	pullReqStore store.PullReqStore,
+	pr *types.PullReq,
+	repoPath string,
+) (bool, error) {
+	var changed bool
+
+	if pr.State == enum.PullReqStateOpen && pr.SourceRepoID == pr.TargetRepoID {
+		branchSHA, err := gitAPI.GetRef(ctx, repoPath, api.BranchPrefix+pr.SourceBranch)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to read source branch %q: %w", pr.SourceBranch, err)
+		}
+
+		if err == nil && branchSHA.String() != pr.SourceSHA {
+			fmt.Printf("pull request #%d: source commit %s -> %s\n", pr.Number, pr.SourceSHA, branchSHA)
+
+			pr, err = pullReqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+				pr.SourceSHA = branchSHA.String()
+				pr.MergeSHA = nil
+				pr.MergeTargetSHA = nil
+				pr.MarkAsMergeUnchecked()
+				return nil
+			})
+			if err != nil {
+				return false, fmt.Errorf("failed to update source commit: %w", err)
+			}
+			changed = true
+		}
+	}
+
+	sourceSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return changed, fmt.Errorf("invalid source commit %q: %w", pr.SourceSHA, err)
+	}
+
+	headRef, err := git.GetRefPath(strconv.FormatInt(pr.Number, 10), gitenum.RefTypePullReqHead)
+	if err != nil {
+		return changed, err
+	}
+
+	headSHA, err := gitAPI.GetRef(ctx, repoPath, headRef)
+	if err != nil && !errors.IsNotFound(err) {
+		return changed, fmt.Errorf("failed to read head ref: %w", err)
+	}
+	if err == nil && headSHA.Equal(sourceSHA) {
+		return changed, nil
+	}
+
+	fmt.Printf("pull request #%d: head ref %s -> %s\n", pr.Number, headSHA, sourceSHA)
+
+	cmd := command.New("update-ref",
+		command.WithArg(headRef),
+		command.WithArg(sourceSHA.String()),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return changed, fmt.Errorf("failed to update head ref: %w", err)
+	}
+
+	return true, nil
+}
+
+func registerRefreshRepo(app *kingpin.CmdClause) {
+	c := &refreshRepoCommand{}
+
+	cmd := app.Command("refresh-repo",
+		"refresh the default branch, emptiness, size and pull request refs of a repository from its git repository").
+		Action(c.run)
+
+	cmd.Arg("repo", "path or id of the repository").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
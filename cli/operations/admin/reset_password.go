@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cli/textui"
+
+	"github.com/dchest/uniuri"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type resetPasswordCommand struct {
+	envfile string
+	uid     string
+	pass    string
+	passgen bool
+}
+
+func (c *resetPasswordCommand) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	password := c.pass
+	switch {
+	case c.passgen:
+		const maxRandomChars = 12
+		password = uniuri.NewLen(maxRandomChars)
+		fmt.Printf("generated temporary password: %s\n", password)
+	case password == "":
+		password = textui.Password()
+	}
+	if password == "" {
+		return fmt.Errorf("password can't be empty")
+	}
+
+	config, err := loadConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	db, err := getDB(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err = resetUserPassword(ctx, getPrincipalStore(db), c.uid, password); err != nil {
+		return err
+	}
+
+	fmt.Printf("password of user '%s' has been reset\n", c.uid)
+
+	return nil
+}
+
+// resetUserPassword replaces the password of the user with the provided one.
+func resetUserPassword(ctx context.Context, principalStore store.PrincipalStore, uid string, password string) error {
+	user, err := principalStore.FindUserByUID(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to find user '%s': %w", uid, err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user.Password = string(hash)
+	user.Updated = time.Now().UnixMilli()
+
+	if err = principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user '%s': %w", uid, err)
+	}
+
+	return nil
+}
+
+func registerResetPassword(app *kingpin.CmdClause) {
+	c := &resetPasswordCommand{}
+
+	cmd := app.Command("reset-password", "reset the password of a user").
+		Action(c.run)
+
+	cmd.Arg("uid", "uid of the user").
+		Required().
+		StringVar(&c.uid)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("password", "new password of the user (prompted if not provided)").
+		StringVar(&c.pass)
+
+	cmd.Flag("passgen", "generate a random password").
+		BoolVar(&c.passgen)
+}
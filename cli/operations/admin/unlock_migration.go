@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type unlockMigrationCommand struct {
+	envfile string
+	version string
+}
+
+func (c *unlockMigrationCommand) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	config, err := loadConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	db, err := getDB(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err = migrate.Force(ctx, db, c.version); err != nil {
+		return err
+	}
+
+	fmt.Printf("database version set to '%s'\n", c.version)
+
+	return nil
+}
+
+func registerUnlockMigration(app *kingpin.CmdClause) {
+	c := &unlockMigrationCommand{}
+
+	cmd := app.Command("unlock-migration",
+		"mark the database as migrated to the provided version without running any migrations").
+		Action(c.run)
+
+	cmd.Arg("version", "database version the database is at (e.g. after fixing a failed migration manually)").
+		Required().
+		StringVar(&c.version)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
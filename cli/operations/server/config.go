@@ -22,15 +22,18 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/harness/gitness/app/auth/webauthn"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
 	"github.com/harness/gitness/app/gitspace/orchestrator/ide"
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/twofactor"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
@@ -39,6 +42,7 @@ import (
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/types"
 
@@ -49,11 +53,12 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	schemeSSH      = "ssh"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP       = "http"
+	schemeHTTPS      = "https"
+	schemeSSH        = "ssh"
+	gitnessHomeDir   = ".gitness"
+	blobDir          = "blob"
+	keywordSearchDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -75,6 +80,11 @@ func LoadConfig() (*types.Config, error) {
 		return nil, fmt.Errorf("failed to backfil urls: %w", err)
 	}
 
+	err = validateURLs(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url configuration: %w", err)
+	}
+
 	if config.Git.HookPath == "" {
 		executablePath, err := os.Executable()
 		if err != nil {
@@ -108,7 +118,7 @@ func LoadConfig() (*types.Config, error) {
 func backfillURLs(config *types.Config) error {
 	// default values for HTTP
 	// TODO: once we actually use the config.HTTP.Proto, we have to update that here.
-	scheme, host, port, path := schemeHTTP, "localhost", "", ""
+	scheme, host, port, path := schemeHTTP, "localhost", "", config.URL.PathPrefix
 	if config.HTTP.Host != "" {
 		host = config.HTTP.Host
 	}
@@ -201,6 +211,52 @@ func backfillURLs(config *types.Config) error {
 	return nil
 }
 
+// validateURLs ensures the external facing URLs are absolute http(s) URLs
+// and that the path prefix (if configured) matches the base URL.
+func validateURLs(config *types.Config) error {
+	externalURLs := []struct {
+		name string
+		raw  string
+	}{
+		{name: "base", raw: config.URL.Base},
+		{name: "api", raw: config.URL.API},
+		{name: "git", raw: config.URL.Git},
+		{name: "ui", raw: config.URL.UI},
+	}
+
+	for _, externalURL := range externalURLs {
+		u, err := url.Parse(externalURL.raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s url '%s': %w", externalURL.name, externalURL.raw, err)
+		}
+		if u.Scheme != schemeHTTP && u.Scheme != schemeHTTPS {
+			return fmt.Errorf("%s url scheme '%s' is not supported (valid values: %v)",
+				externalURL.name, u.Scheme, []string{schemeHTTP, schemeHTTPS})
+		}
+		if u.Hostname() == "" {
+			return fmt.Errorf("%s url '%s' has to have a non-empty host", externalURL.name, externalURL.raw)
+		}
+	}
+
+	if config.URL.PathPrefix == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(config.URL.PathPrefix, "/") {
+		return fmt.Errorf("path prefix '%s' has to start with '/'", config.URL.PathPrefix)
+	}
+
+	baseURL, _ := url.Parse(config.URL.Base) // already validated above
+	prefix := strings.TrimRight(config.URL.PathPrefix, "/")
+	basePath := strings.TrimRight(baseURL.Path, "/")
+	if basePath != prefix && !strings.HasPrefix(basePath, prefix+"/") {
+		return fmt.Errorf("path prefix '%s' doesn't match the path of the base url '%s'",
+			config.URL.PathPrefix, config.URL.Base)
+	}
+
+	return nil
+}
+
 func combineToRawURL(scheme, host, port, path string) string {
 	urlRAW := scheme + "://" + host
 
@@ -294,16 +350,20 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		PoolBuckets:           config.BlobStore.PoolBuckets,
+		Endpoint:              config.BlobStore.Endpoint,
+		PathStyle:             config.BlobStore.PathStyle,
 	}, nil
 }
 
 // ProvideGitConfig loads the git config from the main config.
 func ProvideGitConfig(config *types.Config) gittypes.Config {
 	return gittypes.Config{
-		Trace:    config.Git.Trace,
-		Root:     config.Git.Root,
-		TmpDir:   config.Git.TmpDir,
-		HookPath: config.Git.HookPath,
+		Trace:        config.Git.Trace,
+		Root:         config.Git.Root,
+		TmpDir:       config.Git.TmpDir,
+		HookPath:     config.Git.HookPath,
+		StoragePools: config.Git.StoragePools,
 		LastCommitCache: gittypes.LastCommitCacheConfig{
 			Mode:     config.Git.LastCommitCache.Mode,
 			Duration: config.Git.LastCommitCache.Duration,
@@ -324,13 +384,15 @@ func ProvideEventsConfig(config *types.Config) events.Config {
 // ProvideWebhookConfig loads the webhook service config from the main config.
 func ProvideWebhookConfig(config *types.Config) webhook.Config {
 	return webhook.Config{
-		UserAgentIdentity:   config.Webhook.UserAgentIdentity,
-		HeaderIdentity:      config.Webhook.HeaderIdentity,
-		EventReaderName:     config.InstanceID,
-		Concurrency:         config.Webhook.Concurrency,
-		MaxRetries:          config.Webhook.MaxRetries,
-		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
-		AllowLoopback:       config.Webhook.AllowLoopback,
+		UserAgentIdentity:         config.Webhook.UserAgentIdentity,
+		HeaderIdentity:            config.Webhook.HeaderIdentity,
+		EventReaderName:           config.InstanceID,
+		Concurrency:               config.Webhook.Concurrency,
+		MaxRetries:                config.Webhook.MaxRetries,
+		MaxRetryBackoff:           config.Webhook.MaxRetryBackoff,
+		SecretRotationGracePeriod: config.Webhook.SecretRotationGracePeriod,
+		AllowPrivateNetwork:       config.Webhook.AllowPrivateNetwork,
+		AllowLoopback:             config.Webhook.AllowLoopback,
 	}
 }
 
@@ -377,11 +439,20 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	}
 }
 
+// ProvideRateLimitConfig loads the ratelimit config from the main config.
+func ProvideRateLimitConfig(config *types.Config) ratelimit.Config {
+	return ratelimit.Config{
+		App:      config.RateLimit.AppNamespace,
+		Provider: config.RateLimit.Provider,
+	}
+}
+
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		OrphanedAttachmentsRetentionTime: config.Repos.OrphanedAttachmentsRetentionTime,
 	}
 }
 
@@ -392,13 +463,68 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 	}
 }
 
+// ProvideAvatarConfig loads the avatar config from the main config.
+func ProvideAvatarConfig(config *types.Config) avatar.Config {
+	return avatar.Config{
+		Size:            config.Principal.Avatar.Size,
+		MaxDimension:    config.Principal.Avatar.MaxDimension,
+		GravatarEnabled: config.Principal.Avatar.Gravatar.Enabled,
+		GravatarURL:     config.Principal.Avatar.Gravatar.URL,
+		GravatarDefault: config.Principal.Avatar.Gravatar.Default,
+	}
+}
+
+// ProvideTwoFactorConfig loads the two-factor authentication config from the main config.
+func ProvideTwoFactorConfig(config *types.Config) twofactor.Config {
+	return twofactor.Config{
+		Required: config.TwoFactor.Required,
+		Issuer:   config.TwoFactor.Issuer,
+	}
+}
+
+// ProvideWebAuthnConfig loads the WebAuthn relying party config from the main config.
+func ProvideWebAuthnConfig(config *types.Config) (webauthn.Config, error) {
+	uiURL, err := url.Parse(config.URL.UI)
+	if err != nil {
+		return webauthn.Config{}, fmt.Errorf("failed to parse ui url '%s': %w", config.URL.UI, err)
+	}
+
+	rpID := config.WebAuthn.RPID
+	if rpID == "" {
+		rpID = uiURL.Hostname()
+	}
+
+	origins := config.WebAuthn.Origins
+	if len(origins) == 0 {
+		origins = []string{uiURL.Scheme + "://" + uiURL.Host}
+	}
+
+	return webauthn.Config{
+		RPID:    rpID,
+		RPName:  config.WebAuthn.RPName,
+		Origins: origins,
+		Timeout: config.WebAuthn.Timeout,
+	}, nil
+}
+
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
-func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+func ProvideKeywordSearchConfig(config *types.Config) (keywordsearch.Config, error) {
+	indexDir := config.KeywordSearch.IndexDir
+	if indexDir == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return keywordsearch.Config{}, err
+		}
+
+		indexDir = filepath.Join(homedir, gitnessHomeDir, keywordSearchDir)
+	}
+
 	return keywordsearch.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
-	}
+		IndexDir:        indexDir,
+	}, nil
 }
 
 func ProvideJobsConfig(config *types.Config) job.Config {
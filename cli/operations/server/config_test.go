@@ -283,3 +283,42 @@ func TestBackfillURLsCustom(t *testing.T) {
 
 	require.Equal(t, "ssh://GITSSH:21/GITSSH/p", config.URL.GitSSH)
 }
+
+func TestBackfillURLsPathPrefix(t *testing.T) {
+	config := &types.Config{}
+	config.HTTP.Port = 1234
+	config.URL.PathPrefix = "/gitness"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "http://localhost:1234", config.URL.Internal)
+
+	require.Equal(t, "http://localhost:1234/gitness", config.URL.Base)
+	require.Equal(t, "http://localhost:1234/gitness/api", config.URL.API)
+	require.Equal(t, "http://localhost:1234/gitness/git", config.URL.Git)
+	require.Equal(t, "http://localhost:1234/gitness", config.URL.UI)
+
+	require.NoError(t, validateURLs(config))
+}
+
+func TestValidateURLsPathPrefixMismatch(t *testing.T) {
+	config := &types.Config{}
+	config.URL.Base = "https://xyz/test"
+	config.URL.PathPrefix = "/gitness"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, validateURLs(config), "doesn't match the path of the base url")
+}
+
+func TestValidateURLsInvalidScheme(t *testing.T) {
+	config := &types.Config{}
+	config.URL.API = "ftp://xyz/api"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, validateURLs(config), "api url scheme 'ftp' is not supported")
+}
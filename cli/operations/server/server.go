@@ -100,11 +100,83 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if system.services.RepoForkDeduplicator != nil {
+			if err := system.services.RepoForkDeduplicator.Register(gCtx); err != nil {
+				log.Error().Err(err).Msg("failed to register repo fork deduplicator")
+				return err
+			}
+		}
+
+		if err := system.services.Compliance.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register compliance scanner")
+			return err
+		}
+
+		if err := system.services.LDAP.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register ldap sync")
+			return err
+		}
+
+		if err := system.services.TokenPolicy.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register token expiry warnings")
+			return err
+		}
+
+		if err := system.services.ReviewSLA.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register review SLA check")
+			return err
+		}
+
+		if err := system.services.Cron.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline cron triggers")
+			return err
+		}
+
+		if err := system.services.PipelineCache.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline cache eviction")
+			return err
+		}
+
+		if err := system.services.PipelineArtifact.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline artifact retention")
+			return err
+		}
+
+		if err := system.services.LogArchive.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline log archival")
+			return err
+		}
+
+		if err := system.services.AutoMerge.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pull request auto-merge")
+			return err
+		}
+
+		if err := system.services.RepoInsights.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repository insights computation")
+			return err
+		}
+
+		if err := system.services.Replication.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register replication records cleanup")
+			return err
+		}
+
+		if err := system.services.Runner.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline runner health check")
+			return err
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
 		}
 
+		if err := system.services.AuditLog.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register audit log streaming")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -128,6 +200,16 @@ func (c *command) run(*kingpin.ParseContext) error {
 			)
 			return nil
 		})
+		if system.kubernetesPoller != nil {
+			// start poller for CI build executions of kubernetes pipelines.
+			g.Go(func() error {
+				system.kubernetesPoller.Poll(
+					logger.WithWrappedZerolog(ctx),
+					config.CI.ParallelWorkers,
+				)
+				return nil
+			})
+		}
 	}
 
 	if config.SSH.Enable {
@@ -17,6 +17,7 @@ package server
 import (
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/pipeline/resolver"
+	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	"github.com/harness/gitness/ssh"
@@ -31,7 +32,9 @@ type System struct {
 	sshServer       *ssh.Server
 	resolverManager *resolver.Manager
 	poller          *poller.Poller
-	services        services.Services
+	// kubernetesPoller is nil if the kubernetes runner is disabled.
+	kubernetesPoller *runner.KubernetesPoller
+	services         services.Services
 }
 
 // NewSystem returns a new system structure.
@@ -40,15 +43,17 @@ func NewSystem(
 	server *server.Server,
 	sshServer *ssh.Server,
 	poller *poller.Poller,
+	kubernetesPoller *runner.KubernetesPoller,
 	resolverManager *resolver.Manager,
 	services services.Services,
 ) *System {
 	return &System{
-		bootstrap:       bootstrap,
-		server:          server,
-		sshServer:       sshServer,
-		poller:          poller,
-		resolverManager: resolverManager,
-		services:        services,
+		bootstrap:        bootstrap,
+		server:           server,
+		sshServer:        sshServer,
+		poller:           poller,
+		kubernetesPoller: kubernetesPoller,
+		resolverManager:  resolverManager,
+		services:         services,
 	}
 }
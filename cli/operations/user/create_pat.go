@@ -23,6 +23,8 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/drone/funcmap"
 	"github.com/gotidy/ptr"
@@ -39,6 +41,10 @@ token:       {{ .AccessToken }}
 type createPATCommand struct {
 	identifier  string
 	lifetimeInS int64
+	scopes      []string
+	spaces      []string
+	repos       []string
+	cidrs       []string
 
 	json bool
 	tmpl string
@@ -54,8 +60,18 @@ func (c *createPATCommand) run(*kingpin.ParseContext) error {
 	}
 
 	in := user.CreateTokenInput{
-		Identifier: c.identifier,
-		Lifetime:   lifeTime,
+		Identifier:   c.identifier,
+		Lifetime:     lifeTime,
+		AllowedCIDRs: c.cidrs,
+	}
+	for _, scope := range c.scopes {
+		in.Scopes = append(in.Scopes, enum.TokenScope(scope))
+	}
+	for _, space := range c.spaces {
+		in.Resources = append(in.Resources, types.TokenResource{Type: enum.ParentResourceTypeSpace, Path: space})
+	}
+	for _, repo := range c.repos {
+		in.Resources = append(in.Resources, types.TokenResource{Type: enum.ParentResourceTypeRepo, Path: repo})
 	}
 
 	tokenResp, err := provide.Client().UserCreatePAT(ctx, in)
@@ -87,6 +103,18 @@ func registerCreatePAT(app *kingpin.CmdClause) {
 	cmd.Arg("lifetime", "the lifetime of the token in seconds").
 		Int64Var(&c.lifetimeInS)
 
+	cmd.Flag("scope", "restrict the token to a scope (repo:read, repo:write, pipeline:execute, admin)").
+		StringsVar(&c.scopes)
+
+	cmd.Flag("space", "restrict the token to a space (including its content)").
+		StringsVar(&c.spaces)
+
+	cmd.Flag("repo", "restrict the token to a repository").
+		StringsVar(&c.repos)
+
+	cmd.Flag("allow-cidr", "restrict the token to a network (CIDR or IP address)").
+		StringsVar(&c.cidrs)
+
 	cmd.Flag("json", "json encode the output").
 		BoolVar(&c.json)
 
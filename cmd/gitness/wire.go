@@ -28,16 +28,22 @@ import (
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
+	controllerinsights "github.com/harness/gitness/app/api/controller/insights"
+	controllerissue "github.com/harness/gitness/app/api/controller/issue"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/milestone"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/release"
+	"github.com/harness/gitness/app/api/controller/replication"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -49,6 +55,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/usergroup"
 	controllerwebhook "github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/controller/wiki"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
@@ -60,6 +67,7 @@ import (
 	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	tokenevents "github.com/harness/gitness/app/events/token"
 	infrastructure "github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
@@ -82,41 +90,73 @@ import (
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	aiagentservice "github.com/harness/gitness/app/services/aiagent"
+	"github.com/harness/gitness/app/services/attachment"
+	"github.com/harness/gitness/app/services/auditlog"
+	"github.com/harness/gitness/app/services/automerge"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/banner"
 	capabilitiesservice "github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/cron"
+	"github.com/harness/gitness/app/services/descriptiontemplate"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/filetemplate"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceservice"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
 	"github.com/harness/gitness/app/services/instrument"
+	issueservice "github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/ldap"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/logarchive"
+	"github.com/harness/gitness/app/services/markdown"
 	messagingservice "github.com/harness/gitness/app/services/messaging"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/ratelimiter"
+	replicationservice "github.com/harness/gitness/app/services/replication"
 	reposervice "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/reviewsla"
+	"github.com/harness/gitness/app/services/role"
+	runnerservice "github.com/harness/gitness/app/services/runner"
+	"github.com/harness/gitness/app/services/saml"
 	secretservice "github.com/harness/gitness/app/services/secret"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/storagepool"
 	systemsvc "github.com/harness/gitness/app/services/system"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/triggerqueue"
+	"github.com/harness/gitness/app/services/twofactor"
 	usergroupservice "github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
+	wikiservice "github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/app/shadow"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -129,6 +169,7 @@ import (
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -153,9 +194,13 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cache.WireSet,
 		router.WireSet,
 		pullreqservice.WireSet,
+		repotemplate.WireSet,
+		descriptiontemplate.WireSet,
+		storagepool.WireSet,
 		services.WireSet,
 		services.ProvideGitspaceServices,
 		server.WireSet,
+		shadow.WireSet,
 		url.WireSet,
 		space.WireSet,
 		limiter.WireSet,
@@ -163,8 +208,17 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		repo.WireSet,
 		reposettings.WireSet,
 		pullreq.WireSet,
+		replication.WireSet,
+		controllerrunner.WireSet,
+		controllerissue.WireSet,
+		controllerinsights.WireSet,
+		milestone.WireSet,
+		release.WireSet,
+		wiki.WireSet,
 		controllerwebhook.WireSet,
 		svclabel.WireSet,
+		markdown.WireSet,
+		wikiservice.WireSet,
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
@@ -183,6 +237,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		tokenevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -197,17 +252,37 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		webhook.WireSet,
 		cliserver.ProvideTriggerConfig,
 		trigger.WireSet,
+		triggerqueue.WireSet,
+		cron.WireSet,
+		pipelinecache.WireSet,
+		pipelineartifact.WireSet,
+		logarchive.WireSet,
 		githookCtrl.ExtenderWireSet,
 		githookCtrl.WireSet,
 		cliserver.ProvideLockConfig,
 		lock.WireSet,
 		locker.WireSet,
+		cliserver.ProvideRateLimitConfig,
+		ratelimit.WireSet,
+		ratelimiter.WireSet,
 		cliserver.ProvidePubsubConfig,
 		pubsub.WireSet,
 		cliserver.ProvideJobsConfig,
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
+		compliance.WireSet,
+		reviewsla.WireSet,
+		role.WireSet,
+		banner.WireSet,
+		quota.WireSet,
+		repobulk.WireSet,
+		automerge.WireSet,
+		insights.WireSet,
+		replicationservice.WireSet,
+		runnerservice.WireSet,
+		issueservice.WireSet,
+		attachment.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -235,8 +310,19 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		migrateservice.WireSet,
 		canceler.WireSet,
 		exporter.WireSet,
+		filetemplate.WireSet,
 		metric.WireSet,
 		reposervice.WireSet,
+		cliserver.ProvideAvatarConfig,
+		avatar.WireSet,
+		cliserver.ProvideTwoFactorConfig,
+		twofactor.WireSet,
+		cliserver.ProvideWebAuthnConfig,
+		passkey.WireSet,
+		oauth.WireSet,
+		saml.WireSet,
+		ldap.WireSet,
+		tokenpolicy.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
 		gitspaceevent.WireSet,
@@ -248,7 +334,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		usergroup.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
-		audit.WireSet,
+		auditlog.WireSet,
 		ssh.WireSet,
 		publickey.WireSet,
 		migrate.WireSet,
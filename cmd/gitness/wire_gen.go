@@ -17,16 +17,22 @@ import (
 	"github.com/harness/gitness/app/api/controller/githook"
 	gitspace2 "github.com/harness/gitness/app/api/controller/gitspace"
 	infraprovider3 "github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/insights"
+	"github.com/harness/gitness/app/api/controller/issue"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
 	migrate2 "github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/milestone"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/release"
+	"github.com/harness/gitness/app/api/controller/replication"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	runner2 "github.com/harness/gitness/app/api/controller/runner"
 	secret2 "github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -38,6 +44,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	usergroup2 "github.com/harness/gitness/app/api/controller/usergroup"
 	webhook2 "github.com/harness/gitness/app/api/controller/webhook"
+	wiki2 "github.com/harness/gitness/app/api/controller/wiki"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
@@ -49,6 +56,7 @@ import (
 	events5 "github.com/harness/gitness/app/events/pipeline"
 	events6 "github.com/harness/gitness/app/events/pullreq"
 	events2 "github.com/harness/gitness/app/events/repo"
+	events8 "github.com/harness/gitness/app/events/token"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
@@ -71,43 +79,75 @@ import (
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	"github.com/harness/gitness/app/services/aiagent"
+	"github.com/harness/gitness/app/services/attachment"
+	"github.com/harness/gitness/app/services/auditlog"
+	"github.com/harness/gitness/app/services/automerge"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/banner"
 	"github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/cron"
+	"github.com/harness/gitness/app/services/descriptiontemplate"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/filetemplate"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceinfraevent"
 	"github.com/harness/gitness/app/services/importer"
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
+	insights2 "github.com/harness/gitness/app/services/insights"
 	"github.com/harness/gitness/app/services/instrument"
+	issue2 "github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/ldap"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/logarchive"
+	"github.com/harness/gitness/app/services/markdown"
 	"github.com/harness/gitness/app/services/messaging"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/oauth"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/ratelimiter"
+	replication2 "github.com/harness/gitness/app/services/replication"
 	repo2 "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/repotemplate"
+	"github.com/harness/gitness/app/services/reviewsla"
+	"github.com/harness/gitness/app/services/role"
+	runner3 "github.com/harness/gitness/app/services/runner"
+	"github.com/harness/gitness/app/services/saml"
 	secret3 "github.com/harness/gitness/app/services/secret"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/storagepool"
 	system2 "github.com/harness/gitness/app/services/system"
+	"github.com/harness/gitness/app/services/tokenpolicy"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/triggerqueue"
+	"github.com/harness/gitness/app/services/twofactor"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/services/wiki"
+	"github.com/harness/gitness/app/shadow"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -120,6 +160,7 @@ import (
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 	api2 "github.com/harness/gitness/registry/app/api"
 	"github.com/harness/gitness/registry/app/api/router"
 	"github.com/harness/gitness/registry/app/pkg"
@@ -154,36 +195,116 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
+	repoRedirectStore := database.ProvideRepoRedirectStore(db)
+	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore, repoRedirectStore)
+	repoMembershipStore := database.ProvideRepoMembershipStore(db, principalInfoCache)
+	roleStore := database.ProvideRoleStore(db)
+	userGroupMemberStore := database.ProvideUserGroupMemberStore(db, principalInfoCache)
+	userGroupSubgroupStore := database.ProvideUserGroupSubgroupStore(db)
+	userGroupMembershipStore := database.ProvideUserGroupMembershipStore(db, principalInfoCache)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore, repoStore, repoMembershipStore, roleStore, userGroupMemberStore, userGroupSubgroupStore, userGroupMembershipStore)
 	publicAccessStore := database.ProvidePublicAccessStore(db)
-	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	publicaccessService := publicaccess.ProvidePublicAccess(config, publicAccessStore, repoStore, spaceStore)
 	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, publicaccessService)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
 	publicKeyStore := database.ProvidePublicKeyStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore)
-	serviceController := service.NewController(principalUID, authorizer, principalStore)
-	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
+	userEmailStore := database.ProvideUserEmailStore(db)
+	userPreferencesStore := database.ProvideUserPreferencesStore(db)
+	mailerMailer := mailer.ProvideMailClient(config)
+	notificationClient := notification.ProvideMailClient(mailerMailer)
 	provider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
-	pipelineStore := database.ProvidePipelineStore(db)
-	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	avatarConfig := server.ProvideAvatarConfig(config)
+	avatarService := avatar.ProvideService(blobStore, avatarConfig)
+	userTwoFactorStore := database.ProvideUserTwoFactorStore(db)
 	settingsStore := database.ProvideSettingsStore(db)
-	settingsService := settings.ProvideService(settingsStore)
-	protectionManager, err := protection.ProvideManager(ruleStore)
+	settingsService := settings.ProvideService(config, settingsStore, spaceStore)
+	encrypter, err := encrypt.ProvideEncrypter(config)
 	if err != nil {
 		return nil, err
 	}
-	typesConfig := server.ProvideGitConfig(config)
+	twofactorConfig := server.ProvideTwoFactorConfig(config)
+	webAuthnCredentialStore := database.ProvideWebAuthnCredentialStore(db)
+	twofactorService := twofactor.ProvideService(userTwoFactorStore, webAuthnCredentialStore, membershipStore, settingsService, encrypter, twofactorConfig)
+	webAuthnSessionStore := database.ProvideWebAuthnSessionStore(db)
+	webauthnConfig, err := server.ProvideWebAuthnConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	passkeyService := passkey.ProvideService(webAuthnCredentialStore, webAuthnSessionStore, twofactorService, webauthnConfig)
+	oAuthProviderStore := database.ProvideOAuthProviderStore(db)
+	oAuthIdentityStore := database.ProvideOAuthIdentityStore(db)
+	oauthService := oauth.ProvideService(oAuthProviderStore, oAuthIdentityStore, provider, encrypter)
+	samlProviderStore := database.ProvideSAMLProviderStore(db)
+	samlIdentityStore := database.ProvideSAMLIdentityStore(db)
+	samlService := saml.ProvideService(samlProviderStore, samlIdentityStore, spaceStore, membershipStore, provider)
+	ldapIdentityStore := database.ProvideLDAPIdentityStore(db)
+	ldapGroupMappingStore := database.ProvideLDAPGroupMappingStore(db)
 	universalClient, err := server.ProvideRedis(config)
 	if err != nil {
 		return nil, err
 	}
+	jobStore := database.ProvideJobStore(db)
+	pubsubConfig := server.ProvidePubsubConfig(config)
+	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
+	executor := job.ProvideExecutor(jobStore, pubSub)
+	lockConfig := server.ProvideLockConfig(config)
+	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
+	rateLimitConfig := server.ProvideRateLimitConfig(config)
+	ratelimitLimiter := ratelimit.ProvideLimiter(rateLimitConfig, universalClient)
+	ratelimiterService := ratelimiter.ProvideService(config, settingsService, ratelimitLimiter)
+	jobConfig := server.ProvideJobsConfig(config)
+	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
+	if err != nil {
+		return nil, err
+	}
+	ldapService, err := ldap.ProvideService(config, principalStore, ldapIdentityStore, ldapGroupMappingStore, spaceStore, membershipStore, principalUID, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	eventsConfig := server.ProvideEventsConfig(config)
+	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	if err != nil {
+		return nil, err
+	}
+	reporter6, err := events8.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	tokenpolicyService, err := tokenpolicy.ProvideService(config, settingsService, membershipStore, repoStore, tokenStore, reporter6, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, userEmailStore, userPreferencesStore, notificationClient, provider, avatarService, twofactorService, passkeyService, oauthService, samlService, ldapService, tokenpolicyService)
+	serviceController := service.NewController(principalUID, authorizer, principalStore)
+	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
+	auditEventStore := database.ProvideAuditEventStore(db)
+	auditEventOutboxStore := database.ProvideAuditEventOutboxStore(db)
+	auditlogService, err := auditlog.ProvideService(config, transactor, auditEventStore, auditEventOutboxStore, spaceStore, principalInfoCache, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	auditService := auditlog.ProvideAuditService(auditlogService)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore, spaceStore, repoStore, membershipStore, auditService)
+	pipelineStore := database.ProvidePipelineStore(db)
+	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
+	protectionManager, err := protection.ProvideManager(ruleStore)
+	if err != nil {
+		return nil, err
+	}
+	typesConfig := server.ProvideGitConfig(config)
 	cacheCache, err := api.ProvideLastCommitCache(typesConfig, universalClient)
 	if err != nil {
 		return nil, err
@@ -199,42 +320,44 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
-	encrypter, err := encrypt.ProvideEncrypter(config)
+	streamer := sse.ProvideEventsStreaming(pubSub)
+	keywordsearchConfig, err := server.ProvideKeywordSearchConfig(config)
 	if err != nil {
 		return nil, err
 	}
-	jobStore := database.ProvideJobStore(db)
-	pubsubConfig := server.ProvidePubsubConfig(config)
-	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
-	executor := job.ProvideExecutor(jobStore, pubSub)
-	lockConfig := server.ProvideLockConfig(config)
-	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
-	jobConfig := server.ProvideJobsConfig(config)
-	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
+	localIndexSearcher, err := keywordsearch.ProvideLocalIndexSearcher(keywordsearchConfig, gitInterface)
 	if err != nil {
 		return nil, err
 	}
-	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	auditService := audit.ProvideAuditService()
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, auditService)
+	webhookStore := database.ProvideWebhookStore(db)
+	repotemplateService := repotemplate.ProvideService(config, transactor, settingsService, spaceStore, ruleStore, webhookStore, protectionManager, encrypter)
+	attachmentStore := database.ProvideAttachmentStore(db)
+	poolStore, err := blob.ProvidePoolStore(ctx, blobConfig, blobStore)
 	if err != nil {
 		return nil, err
 	}
-	codeownersConfig := server.ProvideCodeOwnerConfig(config)
-	usergroupResolver := usergroup.ProvideUserGroupResolver()
-	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
-	eventsConfig := server.ProvideEventsConfig(config)
-	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	releaseAssetStore := database.ProvideReleaseAssetStore(db)
+	storagepoolService, err := storagepool.ProvideService(config, settingsService, spaceStore, repoStore, attachmentStore, releaseAssetStore, gitInterface, poolStore, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
+	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, auditService, repotemplateService, storagepoolService)
+	if err != nil {
+		return nil, err
+	}
+	codeownersConfig := server.ProvideCodeOwnerConfig(config)
+	userGroupStore := database.ProvideUserGroupStore(db)
+	usergroupService := usergroup.ProvideService(userGroupStore, userGroupMemberStore, userGroupSubgroupStore, spaceStore, principalInfoCache)
+	usergroupResolver := usergroup.ProvideUserGroupResolver(usergroupService)
+	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
 	reporter, err := events2.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	resourceLimiter, err := limiter.ProvideLimiter()
+	executionStore := database.ProvideExecutionStore(db)
+	quotaService := quota.ProvideService(settingsService, spaceStore, repoStore, executionStore)
+	resourceLimiter, err := limiter.ProvideLimiter(quotaService)
 	if err != nil {
 		return nil, err
 	}
@@ -244,13 +367,24 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	labelStore := database.ProvideLabelStore(db)
 	labelValueStore := database.ProvideLabelValueStore(db)
 	pullReqLabelAssignmentStore := database.ProvidePullReqLabelStore(db)
-	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore)
+	issueLabelAssignmentStore := database.ProvideIssueLabelAssignmentStore(db)
+	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, issueLabelAssignmentStore)
 	instrumentService := instrument.ProvideService()
-	userGroupStore := database.ProvideUserGroupStore(db)
-	searchService := usergroup.ProvideSearchService()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, searchService)
-	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, auditService)
-	executionStore := database.ProvideExecutionStore(db)
+	searchService := usergroup.ProvideSearchService(usergroupService)
+	repoTrafficStore := database.ProvideRepoTrafficStore(db)
+	scanner := compliance.ProvideScanner(config, gitInterface)
+	repoStarStore := database.ProvideRepoStarStore(db)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	issueStore := database.ProvideIssueStore(db, principalInfoCache)
+	renderer := markdown.ProvideRenderer(provider, principalInfoCache, pullReqStore, issueStore)
+	wikiService := wiki.ProvideService(gitInterface, provider)
+	fileTemplateStore := database.ProvideFileTemplateStore(db)
+	filetemplateService := filetemplate.ProvideService(fileTemplateStore, spaceStore)
+	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalStore, userEmailStore)
+	roleService := role.ProvideService(roleStore, membershipStore, repoMembershipStore, userGroupMembershipStore)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, searchService, repoTrafficStore, repotemplateService, scanner, storagepoolService, repoRedirectStore, repoStarStore, renderer, wikiService, filetemplateService, publickeyService, repoMembershipStore, roleService)
+	descriptiontemplateService := descriptiontemplate.ProvideService(gitInterface)
+	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, auditService, descriptiontemplateService)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
@@ -261,12 +395,41 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore)
 	commitService := commit.ProvideService(gitInterface)
 	fileService := file.ProvideService(gitInterface)
-	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
+	converterService, err := converter.ProvideService(config, fileService, publicaccessService, spaceStore, templateStore, gitInterface, provider)
+	if err != nil {
+		return nil, err
+	}
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
-	logStore := logs.ProvideLogStore(db, config)
+	archiveLogStore := logs.ProvideArchiveLogStore(blobStore, config)
+	logStore := logs.ProvideLogStore(db, config, archiveLogStore)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, stepStore, logStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService, resourceLimiter, cancelerCanceler, gitInterface)
+	triggerRequestStore := database.ProvideTriggerRequestStore(db)
+	triggerqueueService, err := triggerqueue.ProvideService(config, triggerRequestStore, pipelineStore, triggererTriggerer, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	cronStore := database.ProvideCronStore(db)
+	cronService, err := cron.ProvideService(config, cronStore, pipelineStore, repoStore, commitService, triggerqueueService, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	pipelineCacheStore := database.ProvidePipelineCacheStore(db)
+	pipelinecacheService, err := pipelinecache.ProvideService(config, pipelineCacheStore, repoStore, poolStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	pipelineArtifactStore := database.ProvidePipelineArtifactStore(db)
+	pipelineartifactService, err := pipelineartifact.ProvideService(config, pipelineArtifactStore, repoStore, poolStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	logArchiveStore := logs.ProvideLogArchiveStore(db)
+	logarchiveService, err := logarchive.ProvideService(config, logArchiveStore, archiveLogStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore, fileService, pipelineartifactService, converterService, publicaccessService)
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
@@ -274,12 +437,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	connectorStore := database.ProvideConnectorStore(db, secretStore)
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
 	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, repoStore, repoGitInfoCache, pullReqStore, labelService)
 	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
+	repobulkService, err := repobulk.ProvideService(repoController, reposettingsController, exporterRepository, principalStore, repoStore, jobScheduler, executor, encrypter)
+	if err != nil {
+		return nil, err
+	}
 	infraProviderResourceView := database.ProvideInfraProviderResourceView(db)
 	infraProviderResourceCache := cache.ProvideInfraProviderResourceCache(infraProviderResourceView)
 	gitspaceConfigStore := database.ProvideGitspaceConfigStore(db, principalInfoCache, infraProviderResourceCache)
@@ -324,14 +490,27 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	resolverFactory := secret.ProvideResolverFactory(passwordResolver)
 	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, infraProviderResourceStore, infraProvisioner, containerOrchestrator, eventsReporter, orchestratorConfig, vsCode, vsCodeWeb, resolverFactory)
 	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, eventsReporter, gitspaceEventStore, spaceStore, infraproviderService, orchestratorOrchestrator)
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService)
+	pullReqReviewSLAStore := database.ProvidePullReqReviewSLAStore(db)
+	reporter4, err := events6.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	eventsReaderFactory, err := events6.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	reviewslaService, err := reviewsla.ProvideService(ctx, config, settingsService, spaceStore, repoStore, pullReqStore, pullReqReviewSLAStore, reporter4, jobScheduler, executor, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, repotemplateService, storagepoolService, reviewslaService, settingsService, quotaService, auditlogService, repobulkService, filetemplateService, roleService)
 	reporter3, err := events5.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, reporter3)
+	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, reporter3, pipelinecacheService, spaceStore, secretStore, templateStore)
 	secretController := secret2.ProvideController(encrypter, secretStore, authorizer, spaceStore)
-	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
+	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore, triggerqueueService, cronStore)
 	scmService := connector.ProvideSCMConnectorHandler(secretStore)
 	connectorService := connector.ProvideConnectorHandler(secretStore, scmService)
 	connectorController := connector2.ProvideController(connectorStore, connectorService, authorizer, spaceStore)
@@ -343,33 +522,32 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	userGroupReviewersStore := database.ProvideUserGroupReviewerStore(db, principalInfoCache, userGroupStore)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	reporter4, err := events6.ProvideReporter(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
+	pullReqAutoMergeStore := database.ProvidePullReqAutoMergeStore(db)
+	pullReqDependencyStore := database.ProvidePullReqDependencyStore(db)
 	migrator := codecomments.ProvideMigrator(gitInterface)
 	readerFactory, err := events7.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events6.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter4, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter4, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, principalStore, userGroupStore, codeCommentView, migrator, pullReqFileViewStore, pullReqReviewerStore, userGroupReviewersStore, codeownersService, protectionManager, settingsService, authorizer, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
 	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, repoStore, pullReqStore, pullReqActivityStore, transactor)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter4, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService)
+	attachmentService := attachment.ProvideService(attachmentStore, provider)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, pullReqAutoMergeStore, pullReqDependencyStore, membershipStore, checkStore, gitInterface, reporter4, reporter, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService, attachmentService, settingsService)
 	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
+	issueCommentStore := database.ProvideIssueCommentStore(db, principalInfoCache)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory2, webhookStore, webhookExecutionStore, repoStore, spaceStore, pullReqStore, pullReqActivityStore, issueStore, issueCommentStore, provider, principalStore, gitInterface, encrypter)
+	if err != nil {
+		return nil, err
+	}
+	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, spaceStore, webhookService, encrypter)
 	reporter5, err := events7.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -386,30 +564,29 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter5, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
-	principalController := principal.ProvideController(principalStore, authorizer)
-	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, authorizer, searchService)
-	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	replicationStore := database.ProvideReplicationStore(db)
+	replicationService, err := replication2.ProvideService(config, transactor, gitInterface, replicationStore, lockerLocker, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
-	if err != nil {
-		return nil, err
-	}
-	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter5, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, replicationService, preReceiveExtender, updateExtender, postReceiveExtender)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, auditService, tokenpolicyService)
+	principalController := principal.ProvideController(principalStore, authorizer, avatarService)
+	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, authorizer, searchService, usergroupService, userGroupMemberStore, userGroupSubgroupStore, userGroupMembershipStore, principalStore, roleService, auditService)
+	v := check2.ProvideCheckSanitizers()
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
+	bannerStore := database.ProvideBannerStore(db)
+	bannerService := banner.ProvideService(bannerStore)
+	systemController := system.NewController(principalStore, config, filetemplateService, oauthService, samlService, ldapService, ratelimiterService, roleService, bannerService)
+	uploadController := upload.ProvideController(authorizer, repoStore, poolStore, attachmentStore)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoStore, spaceStore, pullReqStore, principalStore, repoController, spaceController)
 	infraproviderController := infraprovider3.ProvideController(authorizer, spaceStore, infraproviderService)
 	limiterGitspace := limiter.ProvideGitspaceLimiter()
 	gitspaceController := gitspace2.ProvideController(transactor, authorizer, infraproviderService, gitspaceConfigStore, gitspaceInstanceStore, spaceStore, gitspaceEventStore, statefulLogger, scmSCM, repoStore, gitspaceService, limiterGitspace)
 	rule := migrate.ProvideRuleImporter(ruleStore, transactor, principalStore)
 	migrateWebhook := migrate.ProvideWebhookImporter(webhookConfig, transactor, webhookStore)
-	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore)
+	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, storagepoolService)
 	registry, err := capabilities.ProvideCapabilities(repoStore, gitInterface)
 	if err != nil {
 		return nil, err
@@ -460,20 +637,51 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	cleanupPolicyRepository := database2.ProvideCleanupPolicyDao(db, transactor)
 	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceStore, transactor, authenticator, provider, authorizer, auditService, spacePathStore)
 	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler)
-	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, aiagentController, capabilitiesController, provider, openapiService, appRouter)
-	serverServer := server2.ProvideServer(config, routerRouter)
-	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalInfoCache)
-	sshServer := ssh.ProvideServer(config, publickeyService, repoController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter3)
+	replicationController := replication.ProvideController(replicationService)
+	issueAssigneeStore := database.ProvideIssueAssigneeStore(db)
+	issueService, err := issue2.ProvideService(ctx, config, repoStore, pullReqStore, issueStore, reporter, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	issueController := issue.ProvideController(transactor, authorizer, repoStore, principalStore, principalInfoCache, issueStore, issueCommentStore, issueAssigneeStore, issueService, labelService, reporter, attachmentService)
+	milestoneStore := database.ProvideMilestoneStore(db)
+	milestoneController := milestone.ProvideController(authorizer, repoStore, milestoneStore, pullReqStore, issueStore)
+	releaseStore := database.ProvideReleaseStore(db)
+	releaseController := release.ProvideController(authorizer, repoStore, releaseStore, releaseAssetStore, gitInterface, poolStore)
+	wikiController := wiki2.ProvideController(authorizer, repoStore, gitInterface, wikiService)
+	repoInsightsStore := database.ProvideRepoInsightsStore(db)
+	insightsService, err := insights2.ProvideService(ctx, config, transactor, repoStore, pullReqStore, repoInsightsStore, gitInterface, jobScheduler, executor, readerFactory, readerFactory2, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	insightsController := insights.ProvideController(authorizer, repoStore, insightsService)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, settingsService, spaceStore, auditService, reporter3)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	runnerStore := database.ProvideRunnerStore(db)
+	runnerService, err := runner3.ProvideService(config, runnerStore, stageStore, executionManager, schedulerScheduler, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	runnerController := runner2.ProvideController(runnerService, executionManager, client, stageStore, stepStore)
+	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, aiagentController, capabilitiesController, replicationController, issueController, milestoneController, releaseController, insightsController, wikiController, runnerController, provider, openapiService, appRouter, ratelimiterService)
+	mirror, err := shadow.ProvideMirror(config)
+	if err != nil {
+		return nil, err
+	}
+	serverServer := server2.ProvideServer(config, routerRouter, mirror)
+	sshServer := ssh.ProvideServer(config, publickeyService, repoController)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
 	if err != nil {
 		return nil, err
 	}
 	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
+	kubernetesPoller, err := runner.ProvideKubernetesPoller(config, client)
+	if err != nil {
+		return nil, err
+	}
 	triggerConfig := server.ProvideTriggerConfig(config)
-	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
+	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggerqueueService, readerFactory, eventsReaderFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -486,7 +694,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	forkDeduplicator, err := repo2.ProvideForkDeduplicator(config, gitInterface, repoStore, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
@@ -495,18 +703,23 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, attachmentStore, poolStore)
+	if err != nil {
+		return nil, err
+	}
+	automergeService, err := automerge.ProvideService(ctx, config, eventsReaderFactory, pullReqAutoMergeStore, pullReqStore, repoStore, principalStore, pullreqController, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	readerFactory5, err := events8.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, readerFactory5, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, userPreferencesStore, provider)
 	if err != nil {
 		return nil, err
 	}
-	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
 	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory2, repoStore, indexer)
 	if err != nil {
 		return nil, err
@@ -537,7 +750,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
+	complianceService, err := compliance.ProvideService(ctx, config, scanner, repoStore, pullReqStore, checkStore, jobScheduler, executor, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, cronService, pipelinecacheService, pipelineartifactService, logarchiveService, jobScheduler, collector, sizeCalculator, forkDeduplicator, complianceService, ldapService, tokenpolicyService, reviewslaService, automergeService, insightsService, replicationService, runnerService, repoService, cleanupService, auditlogService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, kubernetesPoller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
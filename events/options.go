@@ -66,3 +66,8 @@ func WithMaxRetries(maxRetries int) HandlerOption {
 func WithIdleTimeout(timeout time.Duration) HandlerOption {
 	return stream.WithIdleTimeout(timeout)
 }
+
+// WithRetryBackoff can be used to enable exponential retry backoff for a specific event handler.
+func WithRetryBackoff(maxBackoff time.Duration) HandlerOption {
+	return stream.WithRetryBackoff(maxBackoff)
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// alternatesFile is the path of the file listing the alternate object directories, relative to the repo path.
+const alternatesFile = "objects/info/alternates"
+
+// GetAlternates returns the alternate object directories of the repository.
+func (g *Git) GetAlternates(repoPath string) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	content, err := os.ReadFile(path.Join(repoPath, alternatesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alternates file: %w", err)
+	}
+
+	var alternates []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		alternates = append(alternates, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse alternates file: %w", err)
+	}
+
+	return alternates, nil
+}
+
+// SetAlternates configures the object directories of the provided repositories as alternates of the repository.
+// Objects available in any of the alternate repositories don't have to be stored in the repository itself.
+// If no alternate repository is provided, the alternates of the repository are removed.
+func (g *Git) SetAlternates(repoPath string, alternateRepoPaths ...string) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	filePath := path.Join(repoPath, alternatesFile)
+
+	if len(alternateRepoPaths) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove alternates file: %w", err)
+		}
+		return nil
+	}
+
+	content := &strings.Builder{}
+	for _, alternateRepoPath := range alternateRepoPaths {
+		// relative paths would be resolved relative to the objects directory of the repository.
+		objectsDir, err := filepath.Abs(path.Join(alternateRepoPath, "objects"))
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path of alternate %q: %w", alternateRepoPath, err)
+		}
+		content.WriteString(objectsDir)
+		content.WriteByte('\n')
+	}
+
+	if err := os.WriteFile(filePath, []byte(content.String()), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write alternates file: %w", err)
+	}
+
+	return nil
+}
+
+// RepackAll repacks all objects of the repository into a single pack and removes redundant packs and objects.
+// If local is true, objects available in alternate object directories are not packed (deduplication),
+// otherwise, all objects borrowed from the alternates are copied into the repository.
+func (g *Git) RepackAll(ctx context.Context, repoPath string, local bool) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a"),
+		command.WithFlag("-d"),
+		command.WithFlag("-q"),
+	)
+	if local {
+		cmd.Add(command.WithFlag("-l"))
+	}
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return processGitErrorf(err, "failed to repack repository")
+	}
+
+	return nil
+}
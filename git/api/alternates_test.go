@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGit_SetAlternates(t *testing.T) {
+	g := &Git{}
+
+	repoPath := t.TempDir()
+	parentPath := t.TempDir()
+	if err := os.MkdirAll(path.Join(repoPath, "objects", "info"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	alternates, err := g.GetAlternates(repoPath)
+	if err != nil {
+		t.Fatalf("failed to get alternates of a repo without alternates: %s", err)
+	}
+	if len(alternates) != 0 {
+		t.Fatalf("expected no alternates, got: %v", alternates)
+	}
+
+	if err = g.SetAlternates(repoPath, parentPath); err != nil {
+		t.Fatalf("failed to set alternates: %s", err)
+	}
+
+	alternates, err = g.GetAlternates(repoPath)
+	if err != nil {
+		t.Fatalf("failed to get alternates: %s", err)
+	}
+
+	want := []string{filepath.Join(parentPath, "objects")}
+	if diff := cmp.Diff(want, alternates); diff != "" {
+		t.Error(diff)
+	}
+
+	if err = g.SetAlternates(repoPath); err != nil {
+		t.Fatalf("failed to remove alternates: %s", err)
+	}
+
+	if _, err = os.Stat(path.Join(repoPath, alternatesFile)); !os.IsNotExist(err) {
+		t.Errorf("expected alternates file to be removed, got: %v", err)
+	}
+}
@@ -136,6 +136,18 @@ func (g *Git) Archive(ctx context.Context, repoPath string, params ArchiveParams
 	if err := params.Validate(); err != nil {
 		return err
 	}
+
+	// git archive fails with a generic error if a path doesn't exist, verify them upfront.
+	for _, path := range params.Paths {
+		_, err := GetTreeNode(ctx, repoPath, params.Treeish, path, false)
+		if errors.IsNotFound(err) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to verify archive path %q: %w", path, err)
+		}
+	}
+
 	cmd := command.New("archive",
 		command.WithArg(params.Treeish),
 	)
@@ -186,7 +198,9 @@ func (g *Git) Archive(ctx context.Context, repoPath string, params ArchiveParams
 		}
 	}
 
-	cmd.Add(command.WithArg(params.Paths...))
+	if len(params.Paths) > 0 {
+		cmd.Add(command.WithPostSepArg(params.Paths...))
+	}
 
 	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(w)); err != nil {
 		return fmt.Errorf("failed to archive repository: %w", err)
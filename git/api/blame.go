@@ -53,10 +53,21 @@ func (g *Git) Blame(
 	lineFrom int,
 	lineTo int,
 ) BlameNextReader {
-	// prepare the git command line arguments
+	cmd := blameCommand("--porcelain", rev, file, lineFrom, lineTo)
+	pipeRead, stderr := runBlameCommand(ctx, cmd, repoPath)
+
+	return &BlameReader{
+		scanner:     bufio.NewScanner(pipeRead),
+		commitCache: make(map[string]*Commit),
+		errReader:   stderr, // Any stderr output will cause the BlameReader to fail.
+	}
+}
+
+// blameCommand prepares the git blame command line arguments for the provided output format flag.
+func blameCommand(format string, rev string, file string, lineFrom int, lineTo int) *command.Command {
 	cmd := command.New(
 		"blame",
-		command.WithFlag("--porcelain"),
+		command.WithFlag(format),
 		command.WithFlag("--encoding", "UTF-8"),
 	)
 	if lineFrom > 0 || lineTo > 0 {
@@ -74,6 +85,12 @@ func (g *Git) Blame(
 	cmd.Add(command.WithArg(rev))
 	cmd.Add(command.WithPostSepArg(file))
 
+	return cmd
+}
+
+// runBlameCommand starts the git blame command in the background
+// and returns the reader of its standard output and the buffer with its error output.
+func runBlameCommand(ctx context.Context, cmd *command.Command, repoPath string) (io.Reader, *bytes.Buffer) {
 	pipeRead, pipeWrite := io.Pipe()
 	stderr := &bytes.Buffer{}
 	go func() {
@@ -91,11 +108,7 @@ func (g *Git) Blame(
 		)
 	}()
 
-	return &BlameReader{
-		scanner:     bufio.NewScanner(pipeRead),
-		commitCache: make(map[string]*Commit),
-		errReader:   stderr, // Any stderr output will cause the BlameReader to fail.
-	}
+	return pipeRead, stderr
 }
 
 type BlameReader struct {
@@ -190,9 +203,28 @@ func (r *BlameReader) NextPart() (*BlamePart, error) {
 		parseBlameHeaders(line, commit)
 	}
 
+	if err = processBlameError(r.errReader, err); !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var part *BlamePart
+
+	if commit != nil && len(lines) > 0 {
+		part = &BlamePart{
+			Commit: commit,
+			Lines:  lines,
+		}
+	}
+
+	return part, err
+}
+
+// processBlameError converts the error output of the git blame command to an error.
+// It returns the provided read error in case git didn't report any error (normally io.EOF).
+func processBlameError(errReader io.Reader, err error) error {
 	// Check if there's something in the error buffer... If yes, that's the error!
 	// It should contain error string from the git.
-	errRaw, _ := io.ReadAll(r.errReader)
+	errRaw, _ := io.ReadAll(errReader)
 	if len(errRaw) > 0 {
 		line := string(errRaw)
 
@@ -204,31 +236,22 @@ func (r *BlameReader) NextPart() (*BlamePart, error) {
 
 		switch {
 		case strings.Contains(line, "no such path"):
-			return nil, errors.NotFound(line)
+			return errors.NotFound(line)
 		case strings.Contains(line, "bad revision"):
-			return nil, errors.NotFound(line)
+			return errors.NotFound(line)
 		case blamePorcelainOutOfRangeErrorRE.MatchString(line):
-			return nil, errors.InvalidArgument(line)
+			return errors.InvalidArgument(line)
 		default:
-			return nil, errors.Internal(nil, "failed to get next part: %s", line)
+			return errors.Internal(nil, "failed to get next part: %s", line)
 		}
 	}
 
 	// This error can happen if the command git failed to start. Triggered by pipe writer's CloseWithError call.
 	if !errors.Is(err, io.EOF) {
-		return nil, errors.Internal(err, "failed to start git blame command")
+		return errors.Internal(err, "failed to start git blame command")
 	}
 
-	var part *BlamePart
-
-	if commit != nil && len(lines) > 0 {
-		part = &BlamePart{
-			Commit: commit,
-			Lines:  lines,
-		}
-	}
-
-	return part, err
+	return err
 }
 
 func parseBlameHeaders(line string, commit *Commit) {
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/sha"
+)
+
+// blameIncrementalHeadRE is used to detect hunk header start in git blame incremental output.
+// It is explained here: https://www.git-scm.com/docs/git-blame#_incremental_output
+var blameIncrementalHeadRE = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64}) (\d+) (\d+) (\d+)$`)
+
+// BlameHunk is a range of consecutive lines of a file that were last modified by the same commit.
+// Unlike BlamePart it doesn't contain the content of the lines.
+type BlameHunk struct {
+	Commit *Commit
+	// OriginalLine is the line number of the first line of the hunk in the file of the commit.
+	OriginalLine int
+	// OriginalPath is the path of the file in the commit.
+	OriginalPath string
+	// Line is the line number of the first line of the hunk in the blamed file.
+	Line int
+	// LineCount is the number of lines in the hunk.
+	LineCount int
+}
+
+type BlameHunkReader interface {
+	NextHunk() (*BlameHunk, error)
+}
+
+// BlameIncremental runs git blame with incremental output.
+// Hunks are returned as soon as git finds them (not necessarily in file order),
+// which allows to process the output of large files before git finishes.
+func (g *Git) BlameIncremental(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	file string,
+	lineFrom int,
+	lineTo int,
+) BlameHunkReader {
+	cmd := blameCommand("--incremental", rev, file, lineFrom, lineTo)
+	pipeRead, stderr := runBlameCommand(ctx, cmd, repoPath)
+
+	return &BlameIncrementalReader{
+		scanner:     bufio.NewScanner(pipeRead),
+		commitCache: make(map[string]*Commit),
+		errReader:   stderr, // Any stderr output will cause the BlameIncrementalReader to fail.
+	}
+}
+
+type BlameIncrementalReader struct {
+	scanner     *bufio.Scanner
+	commitCache map[string]*Commit
+	errReader   io.Reader
+}
+
+// NextHunk returns the next hunk of the blame output. It returns io.EOF after the last hunk.
+func (r *BlameIncrementalReader) NextHunk() (*BlameHunk, error) {
+	var hunk *BlameHunk
+
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if matches := blameIncrementalHeadRE.FindStringSubmatch(line); matches != nil {
+			commitSHA := sha.Must(matches[1])
+
+			commit := r.commitCache[commitSHA.String()]
+			if commit == nil {
+				// git outputs the commit headers only for the first hunk of each commit.
+				commit = &Commit{SHA: commitSHA}
+				r.commitCache[commitSHA.String()] = commit
+			}
+
+			originalLine, _ := strconv.Atoi(matches[2])
+			finalLine, _ := strconv.Atoi(matches[3])
+			lineCount, _ := strconv.Atoi(matches[4])
+
+			hunk = &BlameHunk{
+				Commit:       commit,
+				OriginalLine: originalLine,
+				Line:         finalLine,
+				LineCount:    lineCount,
+			}
+
+			continue
+		}
+
+		if hunk == nil {
+			// This should not happen. Normal output always starts with a hunk header (with a commit SHA).
+			continue
+		}
+
+		// the filename header is always the last line of a hunk.
+		if filename, ok := strings.CutPrefix(line, "filename "); ok {
+			hunk.OriginalPath = filename
+			return hunk, nil
+		}
+
+		parseBlameHeaders(line, hunk.Commit)
+	}
+
+	err := r.scanner.Err()
+	if err == nil {
+		err = io.EOF
+	}
+
+	return nil, processBlameError(r.errReader, err)
+}
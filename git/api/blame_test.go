@@ -167,3 +167,111 @@ func TestBlameReader_NextPart_CmdError(t *testing.T) {
 		t.Errorf("expected %v, but got: %v", s.Message, err)
 	}
 }
+
+func TestBlameIncrementalReader_NextHunk(t *testing.T) {
+	// a sample of git blame incremental output
+	const blameOut = `dcb4b6b63e86f06ed4e4c52fbc825545dc0b6200 12 12 1
+author Marko
+author-mail <marko.gacesa@harness.io>
+author-time 1673952128
+author-tz +0100
+committer Committer
+committer-mail <noreply@harness.io>
+committer-time 1673952128
+committer-tz +0100
+summary Pull request 2
+previous 6561a7b86e1a5e74ea0e4e73ccdfc18b486a2826 file_name.go
+filename file_name.go
+16f267ad4f731af1b2e36f42e170ed8921377398 9 10 2
+author Marko
+author-mail <marko.gacesa@harness.io>
+author-time 1669812989
+author-tz +0100
+committer Committer
+committer-mail <noreply@harness.io>
+committer-time 1669812989
+committer-tz +0100
+summary Pull request 1
+boundary
+filename file_name_before_rename.go
+16f267ad4f731af1b2e36f42e170ed8921377398 13 13 2
+filename file_name.go
+`
+
+	author := Identity{
+		Name:  "Marko",
+		Email: "marko.gacesa@harness.io",
+	}
+	committer := Identity{
+		Name:  "Committer",
+		Email: "noreply@harness.io",
+	}
+
+	commit1 := &Commit{
+		SHA:   sha.Must("16f267ad4f731af1b2e36f42e170ed8921377398"),
+		Title: "Pull request 1",
+		Author: Signature{
+			Identity: author,
+			When:     time.Unix(1669812989, 0),
+		},
+		Committer: Signature{
+			Identity: committer,
+			When:     time.Unix(1669812989, 0),
+		},
+	}
+
+	commit2 := &Commit{
+		SHA:   sha.Must("dcb4b6b63e86f06ed4e4c52fbc825545dc0b6200"),
+		Title: "Pull request 2",
+		Author: Signature{
+			Identity: author,
+			When:     time.Unix(1673952128, 0),
+		},
+		Committer: Signature{
+			Identity: committer,
+			When:     time.Unix(1673952128, 0),
+		},
+	}
+
+	want := []*BlameHunk{
+		{Commit: commit2, OriginalLine: 12, OriginalPath: "file_name.go", Line: 12, LineCount: 1},
+		{Commit: commit1, OriginalLine: 9, OriginalPath: "file_name_before_rename.go", Line: 10, LineCount: 2},
+		{Commit: commit1, OriginalLine: 13, OriginalPath: "file_name.go", Line: 13, LineCount: 2},
+	}
+
+	reader := BlameIncrementalReader{
+		scanner:     bufio.NewScanner(strings.NewReader(blameOut)),
+		commitCache: make(map[string]*Commit),
+		errReader:   strings.NewReader(""),
+	}
+
+	var got []*BlameHunk
+
+	for {
+		hunk, err := reader.NextHunk()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Errorf("failed with the error: %v", err)
+			}
+			break
+		}
+		got = append(got, hunk)
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestBlameIncrementalReader_NextHunk_UserError(t *testing.T) {
+	reader := BlameIncrementalReader{
+		scanner:     bufio.NewScanner(strings.NewReader("")),
+		commitCache: make(map[string]*Commit),
+		errReader:   strings.NewReader("fatal: bad revision 'x'\n"),
+	}
+
+	_, err := reader.NextHunk()
+	if s := errors.AsStatus(err); s != errors.StatusNotFound {
+		t.Errorf("expected NotFound error but got: %v", err)
+	}
+}
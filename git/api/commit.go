@@ -63,6 +63,9 @@ type Commit struct {
 	Signature  *CommitGPGSignature
 	ParentSHAs []sha.SHA
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+
+	// CoAuthors are the identities listed in the Co-authored-by trailers of the commit message.
+	CoAuthors []Identity `json:"co_authors,omitempty"`
 }
 
 type CommitFilter struct {
@@ -696,7 +699,8 @@ func getCommit(
 		fmtCommitterEmail + fmtZero + // 6
 		fmtCommitterTime + fmtZero + // 7
 		fmtSubject + fmtZero + // 8
-		fmtBody // 9
+		fmtCoAuthors + fmtZero + // 9
+		fmtBody // 10
 
 	cmd := command.New("log",
 		command.WithFlag("--max-count", "1"),
@@ -721,7 +725,7 @@ func getCommit(
 		return nil, errors.InvalidArgument("path %q not found in %s", path, rev)
 	}
 
-	const columnCount = 10
+	const columnCount = 11
 
 	commitData := strings.Split(strings.TrimSpace(commitLine), separatorZero)
 	if len(commitData) != columnCount {
@@ -743,7 +747,8 @@ func getCommit(
 	committerEmail := commitData[6]
 	committerTimestamp := commitData[7]
 	subject := commitData[8]
-	body := commitData[9]
+	coAuthors := commitData[9]
+	body := commitData[10]
 
 	authorTime, _ := time.Parse(time.RFC3339Nano, authorTimestamp)
 	committerTime, _ := time.Parse(time.RFC3339Nano, committerTimestamp)
@@ -767,6 +772,7 @@ func getCommit(
 			},
 			When: committerTime,
 		},
+		CoAuthors: parseCoAuthors(coAuthors),
 	}, nil
 }
 
@@ -858,6 +864,7 @@ func CommitFromReader(commitSHA sha.SHA, reader io.Reader) (*Commit, error) {
 	messageSB := new(strings.Builder)
 	message := false
 	pgpsig := false
+	pgpsigSHA256 := false
 
 	bufReader, ok := reader.(*bufio.Reader)
 	if !ok {
@@ -884,8 +891,20 @@ readLoop:
 			}
 			pgpsig = false
 		}
+		if pgpsigSHA256 {
+			if len(line) > 0 && line[0] == ' ' {
+				continue
+			}
+			pgpsigSHA256 = false
+		}
 
 		if !message {
+			if len(line) > 0 && line[0] == ' ' {
+				// continuation of a multi-line header (e.g. mergetag) is part of the signed payload
+				_, _ = payloadSB.Write(line)
+				continue
+			}
+
 			// This is probably not correct but is copied from go-gits interpretation...
 			trimmed := bytes.TrimSpace(line)
 			if len(trimmed) == 0 {
@@ -922,6 +941,11 @@ readLoop:
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			case "gpgsig-sha256":
+				// the signature of the SHA-256 representation of the commit isn't part of the payload
+				pgpsigSHA256 = true
+			default:
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+)
+
+const (
+	// fmtSignatureStatus is the status of the commit signature as reported by git.
+	fmtSignatureStatus = "%G?"
+
+	// fmtCoAuthors lists the values of all Co-authored-by trailers of the commit message.
+	fmtCoAuthors = "%(trailers:key=Co-authored-by,valueonly,separator=%x01)"
+
+	separatorCoAuthors = "\x01"
+)
+
+// GetCommitSignatureStatuses verifies the signatures of the provided commits using a single git command.
+// It returns the signature status of each of the commits, keyed by the commit SHA.
+func (g *Git) GetCommitSignatureStatuses(
+	ctx context.Context,
+	repoPath string,
+	commitSHAs []sha.SHA,
+) (map[string]enum.CommitSignatureStatus, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(commitSHAs) == 0 {
+		return map[string]enum.CommitSignatureStatus{}, nil
+	}
+
+	cmd := command.New("log",
+		command.WithFlag("--no-walk=unsorted"),
+		command.WithFlag("--format="+fmtCommitHash+fmtZero+fmtSignatureStatus),
+	)
+	for _, commitSHA := range commitSHAs {
+		cmd.Add(command.WithArg(commitSHA.String()))
+	}
+
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return nil, processGitErrorf(err, "failed to get commit signature statuses")
+	}
+
+	return parseSignatureStatuses(output.String())
+}
+
+// GetCommitSignatures reads the raw commit objects of the provided commits using a single git command.
+// The returned commits contain the signature and the signed payload, the signature is nil for unsigned commits.
+func (g *Git) GetCommitSignatures(
+	ctx context.Context,
+	repoPath string,
+	commitSHAs []sha.SHA,
+) ([]*Commit, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(commitSHAs) == 0 {
+		return nil, nil
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, nil)
+	defer func() {
+		cancel()
+		_ = writer.Close()
+	}()
+
+	commits := make([]*Commit, len(commitSHAs))
+	for i, commitSHA := range commitSHAs {
+		if _, err := writer.Write([]byte(commitSHA.String() + "\n")); err != nil {
+			return nil, fmt.Errorf("failed to write to cat-file batch: %w", err)
+		}
+
+		commit, err := getCommitFromBatchReader(ctx, repoPath, reader, commitSHA.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", commitSHA, err)
+		}
+
+		commits[i] = commit
+	}
+
+	return commits, nil
+}
+
+// parseSignatureStatuses parses the output of git log with the format "<sha>\0<signature status>".
+func parseSignatureStatuses(output string) (map[string]enum.CommitSignatureStatus, error) {
+	statuses := map[string]enum.CommitSignatureStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		commitSHA, status, ok := strings.Cut(line, separatorZero)
+		if !ok {
+			return nil, fmt.Errorf("unexpected git log signature status output line: %q", line)
+		}
+
+		statuses[commitSHA] = parseSignatureStatus(status)
+	}
+
+	return statuses, nil
+}
+
+// parseSignatureStatus converts the output of the %G? git format placeholder into a signature status.
+func parseSignatureStatus(s string) enum.CommitSignatureStatus {
+	switch strings.TrimSpace(s) {
+	case "G":
+		return enum.CommitSignatureStatusVerified
+	case "U", "E":
+		return enum.CommitSignatureStatusUnverified
+	case "B":
+		return enum.CommitSignatureStatusInvalid
+	case "X", "Y":
+		return enum.CommitSignatureStatusExpired
+	case "R":
+		return enum.CommitSignatureStatusRevoked
+	default:
+		return enum.CommitSignatureStatusUnsigned
+	}
+}
+
+// parseCoAuthors parses the values of Co-authored-by trailers (e.g. "Jane Doe <jane@example.com>").
+// Invalid entries and duplicates are ignored.
+func parseCoAuthors(s string) []Identity {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	var coAuthors []Identity
+	seen := map[string]struct{}{}
+
+	for _, value := range strings.Split(s, separatorCoAuthors) {
+		address, err := mail.ParseAddress(strings.TrimSpace(value))
+		if err != nil || address.Name == "" {
+			continue
+		}
+
+		key := strings.ToLower(address.Address)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		coAuthors = append(coAuthors, Identity{
+			Name:  address.Name,
+			Email: address.Address,
+		})
+	}
+
+	return coAuthors
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCoAuthors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Identity
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "single",
+			input: "Jane Doe <jane@example.com>",
+			want:  []Identity{{Name: "Jane Doe", Email: "jane@example.com"}},
+		},
+		{
+			name: "multiple with duplicates and invalid entries",
+			input: "Jane Doe <jane@example.com>\x01" +
+				"not an identity\x01" +
+				"<anonymous@example.com>\x01" +
+				"John Doe <john@example.com>\x01" +
+				"Jane D. <JANE@example.com>",
+			want: []Identity{
+				{Name: "Jane Doe", Email: "jane@example.com"},
+				{Name: "John Doe", Email: "john@example.com"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, parseCoAuthors(test.input))
+		})
+	}
+}
+
+func TestParseSignatureStatus(t *testing.T) {
+	require.Equal(t, enum.CommitSignatureStatusVerified, parseSignatureStatus("G"))
+	require.Equal(t, enum.CommitSignatureStatusUnverified, parseSignatureStatus("E"))
+	require.Equal(t, enum.CommitSignatureStatusInvalid, parseSignatureStatus("B"))
+	require.Equal(t, enum.CommitSignatureStatusExpired, parseSignatureStatus("Y"))
+	require.Equal(t, enum.CommitSignatureStatusRevoked, parseSignatureStatus("R"))
+	require.Equal(t, enum.CommitSignatureStatusUnsigned, parseSignatureStatus("N"))
+}
+
+func TestParseSignatureStatuses(t *testing.T) {
+	statuses, err := parseSignatureStatuses("aaa\x00G\nbbb\x00N\n\nccc\x00\n")
+	require.NoError(t, err)
+	require.Equal(t, map[string]enum.CommitSignatureStatus{
+		"aaa": enum.CommitSignatureStatusVerified,
+		"bbb": enum.CommitSignatureStatusUnsigned,
+		"ccc": enum.CommitSignatureStatusUnsigned,
+	}, statuses)
+
+	_, err = parseSignatureStatuses("aaa G\n")
+	require.Error(t, err)
+}
+
+func TestCommitFromReaderSignedPayload(t *testing.T) {
+	raw := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Jane Doe <jane@example.com> 1700000000 +0000\n" +
+		"committer Jane Doe <jane@example.com> 1700000000 +0000\n" +
+		"encoding ISO-8859-1\n" +
+		"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+		" U1NIU0lH\n" +
+		" -----END SSH SIGNATURE-----\n" +
+		"\n" +
+		"Initial commit\n"
+
+	commit, err := CommitFromReader(sha.Nil, strings.NewReader(raw))
+	require.NoError(t, err)
+	require.NotNil(t, commit.Signature)
+	require.Equal(t, "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n",
+		commit.Signature.Signature)
+	require.Equal(t, "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"+
+		"author Jane Doe <jane@example.com> 1700000000 +0000\n"+
+		"committer Jane Doe <jane@example.com> 1700000000 +0000\n"+
+		"encoding ISO-8859-1\n"+
+		"\n"+
+		"Initial commit\n",
+		commit.Signature.Payload)
+}
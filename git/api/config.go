@@ -51,3 +51,36 @@ func (g *Git) Config(
 	}
 	return nil
 }
+
+// UnsetConfig removes the local git configuration of the key. It's a no-op in case the key isn't set.
+func (g *Git) UnsetConfig(
+	ctx context.Context,
+	repoPath string,
+	key string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if key == "" {
+		return errors.InvalidArgument("key cannot be empty")
+	}
+	var outbuf, errbuf strings.Builder
+	cmd := command.New("config",
+		command.WithFlag("--local"),
+		command.WithFlag("--unset-all"),
+		command.WithArg(key),
+	)
+	err := cmd.Run(ctx, command.WithDir(repoPath),
+		command.WithStdout(&outbuf),
+		command.WithStderr(&errbuf),
+	)
+	if err != nil {
+		// exit code 5 means the key isn't set.
+		if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.IsExitCode(5) {
+			return nil
+		}
+		return fmt.Errorf("git config --unset-all [%s]: %w\n%s\n%s",
+			key, err, outbuf.String(), errbuf.String())
+	}
+	return nil
+}
@@ -302,8 +302,12 @@ func (g *Git) DiffShortStat(
 	}
 
 	shortstatArgs := []string{baseRef + separator + headRef}
-	if len(baseRef) == 0 || baseRef == types.NilSHA {
-		shortstatArgs = []string{sha.EmptyTree.String(), headRef}
+	if len(baseRef) == 0 || baseRef == types.NilSHA || baseRef == sha.Nil256.String() {
+		objectFormat, err := g.GetObjectFormat(ctx, repoPath)
+		if err != nil {
+			return DiffShortStat{}, fmt.Errorf("failed to get object format of repository: %w", err)
+		}
+		shortstatArgs = []string{objectFormat.EmptyTree().String(), headRef}
 	}
 	stat, err := GetDiffShortStat(ctx, repoPath, shortstatArgs...)
 	if err != nil {
@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+)
+
+const (
+	// grepMaxLineLength is the max number of bytes of a matching line that are returned.
+	grepMaxLineLength = 1024
+
+	// grepTimeoutDefault is the max time a search is allowed to run if no timeout is provided.
+	// The pattern is provided by the user, so a search must never run unbounded.
+	grepTimeoutDefault = 30 * time.Second
+)
+
+type GrepOptions struct {
+	Pattern string
+	// Regex interprets the pattern as extended regular expression, otherwise it's matched as fixed string.
+	Regex      bool
+	IgnoreCase bool
+	// Paths limits the search to files matching any of the provided paths (git pathspecs).
+	Paths []string
+	// MaxResults is the max number of matching lines returned.
+	MaxResults int
+	// Timeout is the max time the search is allowed to run (defaults to grepTimeoutDefault).
+	Timeout time.Duration
+}
+
+// GrepMatch is a single line of a file matching the grep pattern.
+type GrepMatch struct {
+	Path       string
+	LineNumber int
+	Column     int
+	Line       string
+}
+
+// Grep searches the files of the provided revision for lines matching the pattern.
+// It returns true in case there were more matches than the requested max number of results.
+func (g *Git) Grep(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	opts GrepOptions,
+) ([]GrepMatch, bool, error) {
+	if repoPath == "" {
+		return nil, false, ErrRepositoryPathEmpty
+	}
+	if opts.Pattern == "" {
+		return nil, false, errors.InvalidArgument("grep pattern cannot be empty")
+	}
+
+	commitSHA, err := g.ResolveRev(ctx, repoPath, rev)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve revision %q: %w", rev, err)
+	}
+
+	cmd := command.New("grep",
+		command.WithFlag("--null"),
+		command.WithFlag("--line-number"),
+		command.WithFlag("--column"),
+		command.WithFlag("-I"), // skip binary files
+	)
+	if opts.IgnoreCase {
+		cmd.Add(command.WithFlag("--ignore-case"))
+	}
+	if opts.Regex {
+		cmd.Add(command.WithFlag("--extended-regexp"))
+	} else {
+		cmd.Add(command.WithFlag("--fixed-strings"))
+	}
+	cmd.Add(
+		command.WithFlag("-e", opts.Pattern),
+		command.WithArg(commitSHA.String()),
+		command.WithPostSepArg(opts.Paths...),
+	)
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = grepTimeoutDefault
+	}
+
+	// stop the git command as soon as we have collected enough results or the search takes too long.
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pipeRead, pipeWrite := io.Pipe()
+	defer func() { _ = pipeRead.Close() }()
+
+	stderr := &bytes.Buffer{}
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+
+		defer func() {
+			_ = pipeWrite.CloseWithError(err)
+			errCh <- err
+		}()
+
+		err = cmd.Run(ctx,
+			command.WithDir(repoPath),
+			command.WithStdout(pipeWrite),
+			command.WithStderr(stderr),
+		)
+	}()
+
+	prefix := commitSHA.String() + ":"
+	reader := bufio.NewReader(pipeRead)
+
+	var matches []GrepMatch
+	truncated := false
+	for {
+		line, errRead := reader.ReadString('\n')
+		if line != "" {
+			if opts.MaxResults > 0 && len(matches) >= opts.MaxResults {
+				truncated = true
+				break
+			}
+
+			match, errParse := parseGrepLine(strings.TrimSuffix(line, "\n"), prefix)
+			if errParse != nil {
+				return nil, false, errParse
+			}
+
+			matches = append(matches, match)
+		}
+		if errRead != nil {
+			break
+		}
+	}
+
+	cancel()
+	_ = pipeRead.Close()
+	err = <-errCh
+
+	switch {
+	case truncated:
+		// the command got canceled on purpose.
+	case err == nil:
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, false, errors.InvalidArgument(
+			"search timed out after %s, use a more specific pattern or limit the paths", timeout)
+	case command.AsError(err) != nil && command.AsError(err).IsExitCode(1):
+		// exit code 1 means that no lines were selected.
+	case strings.Contains(stderr.String(), "Invalid regular expression"),
+		strings.Contains(stderr.String(), "Unmatched"):
+		return nil, false, errors.InvalidArgument("invalid regular expression: %s", strings.TrimSpace(stderr.String()))
+	default:
+		return nil, false, processGitErrorf(err, "failed to grep repository: %s", stderr.String())
+	}
+
+	return matches, truncated, nil
+}
+
+// parseGrepLine parses a single line of the output of git grep --null --line-number --column.
+// The expected format is "<rev>:<path>\0<line-number>\0<column>\0<line>".
+func parseGrepLine(line string, prefix string) (GrepMatch, error) {
+	parts := strings.SplitN(strings.TrimPrefix(line, prefix), "\x00", 4)
+	if len(parts) != 4 {
+		return GrepMatch{}, fmt.Errorf("unexpected grep output line: %q", line)
+	}
+
+	lineNumber, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return GrepMatch{}, fmt.Errorf("failed to parse grep line number %q: %w", parts[1], err)
+	}
+
+	column, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return GrepMatch{}, fmt.Errorf("failed to parse grep column %q: %w", parts[2], err)
+	}
+
+	text := parts[3]
+	if len(text) > grepMaxLineLength {
+		text = strings.ToValidUTF8(text[:grepMaxLineLength], "")
+	}
+
+	return GrepMatch{
+		Path:       parts[0],
+		LineNumber: lineNumber,
+		Column:     column,
+		Line:       text,
+	}, nil
+}
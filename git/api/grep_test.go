@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/errors"
+)
+
+func TestParseGrepLine(t *testing.T) {
+	const prefix = "abc123:"
+	longLine := strings.Repeat("x", grepMaxLineLength+10)
+
+	tests := []struct {
+		name    string
+		line    string
+		want    GrepMatch
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			line: prefix + "dir/file.go\x0012\x005\x00\tfoo := bar",
+			want: GrepMatch{Path: "dir/file.go", LineNumber: 12, Column: 5, Line: "\tfoo := bar"},
+		},
+		{
+			name: "line containing separator",
+			line: prefix + "file.txt\x001\x001\x00a\x00b",
+			want: GrepMatch{Path: "file.txt", LineNumber: 1, Column: 1, Line: "a\x00b"},
+		},
+		{
+			name: "long line truncated",
+			line: prefix + "file.txt\x001\x001\x00" + longLine,
+			want: GrepMatch{Path: "file.txt", LineNumber: 1, Column: 1, Line: longLine[:grepMaxLineLength]},
+		},
+		{
+			name: "long line truncated to valid utf8",
+			line: prefix + "file.txt\x001\x001\x00" + strings.Repeat("x", grepMaxLineLength-1) + "é",
+			want: GrepMatch{Path: "file.txt", LineNumber: 1, Column: 1, Line: strings.Repeat("x", grepMaxLineLength-1)},
+		},
+		{
+			name:    "missing fields",
+			line:    prefix + "file.txt\x001",
+			wantErr: true,
+		},
+		{
+			name:    "invalid line number",
+			line:    prefix + "file.txt\x00a\x001\x00foo",
+			wantErr: true,
+		},
+		{
+			name:    "invalid column",
+			line:    prefix + "file.txt\x001\x00b\x00foo",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseGrepLine(test.line, prefix)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseGrepLine() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("parseGrepLine() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGit_Grep(t *testing.T) {
+	ctx := context.Background()
+	g := &Git{}
+	repoPath := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %s", args, err, out)
+		}
+	}
+
+	run("init", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("foo\nbar\nFoo bar\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+	run("add", "a.txt")
+	run("commit", "-m", "first")
+
+	t.Run("matches", func(t *testing.T) {
+		matches, truncated, err := g.Grep(ctx, repoPath, "main", GrepOptions{Pattern: "foo", IgnoreCase: true})
+		if err != nil {
+			t.Fatalf("failed to grep: %s", err)
+		}
+		if truncated || len(matches) != 2 {
+			t.Fatalf("want 2 matches, got %d (truncated=%t)", len(matches), truncated)
+		}
+		if matches[1] != (GrepMatch{Path: "a.txt", LineNumber: 3, Column: 1, Line: "Foo bar"}) {
+			t.Errorf("unexpected match: %+v", matches[1])
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		matches, _, err := g.Grep(ctx, repoPath, "main", GrepOptions{Pattern: "baz"})
+		if err != nil {
+			t.Fatalf("failed to grep: %s", err)
+		}
+		if len(matches) != 0 {
+			t.Errorf("want no matches, got %d", len(matches))
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		matches, truncated, err := g.Grep(ctx, repoPath, "main", GrepOptions{Pattern: "o", MaxResults: 1})
+		if err != nil {
+			t.Fatalf("failed to grep: %s", err)
+		}
+		if !truncated || len(matches) != 1 {
+			t.Errorf("want 1 truncated match, got %d (truncated=%t)", len(matches), truncated)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, _, err := g.Grep(ctx, repoPath, "main", GrepOptions{Pattern: "foo", Timeout: time.Nanosecond})
+		if !errors.IsInvalidArgument(err) {
+			t.Errorf("want invalid argument error, got: %v", err)
+		}
+	})
+}
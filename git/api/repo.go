@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -77,6 +78,7 @@ func (g *Git) InitRepository(
 	ctx context.Context,
 	repoPath string,
 	bare bool,
+	objectFormat sha.ObjectFormat,
 ) error {
 	if repoPath == "" {
 		return ErrRepositoryPathEmpty
@@ -90,9 +92,35 @@ func (g *Git) InitRepository(
 	if bare {
 		cmd.Add(command.WithFlag("--bare"))
 	}
+	if objectFormat != "" {
+		cmd.Add(command.WithFlag("--object-format=" + string(objectFormat)))
+	}
 	return cmd.Run(ctx, command.WithDir(repoPath))
 }
 
+// GetObjectFormat returns the object format (hash algorithm) used by the repository.
+func (g *Git) GetObjectFormat(
+	ctx context.Context,
+	repoPath string,
+) (sha.ObjectFormat, error) {
+	if repoPath == "" {
+		return "", ErrRepositoryPathEmpty
+	}
+
+	return GetObjectFormat(ctx, repoPath)
+}
+
+// GetObjectFormat returns the object format (hash algorithm) used by the repository at the provided path.
+func GetObjectFormat(ctx context.Context, repoPath string) (sha.ObjectFormat, error) {
+	cmd := command.New("rev-parse", command.WithFlag("--show-object-format"))
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return "", processGitErrorf(err, "failed to get object format of repository")
+	}
+
+	return sha.ParseObjectFormat(output.String())
+}
+
 // SetDefaultBranch sets the default branch of a repo.
 func (g *Git) SetDefaultBranch(
 	ctx context.Context,
@@ -263,6 +291,25 @@ func (g *Git) Sync(
 	return nil
 }
 
+// CheckConnectivity verifies that all objects reachable from the references of the repository are available.
+func (g *Git) CheckConnectivity(ctx context.Context, repoPath string) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("fsck",
+		command.WithFlag("--connectivity-only"),
+		command.WithFlag("--no-dangling"),
+		command.WithFlag("--no-progress"),
+	)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return processGitErrorf(err, "failed to check connectivity of repository")
+	}
+
+	return nil
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -18,7 +18,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
@@ -40,3 +42,33 @@ func (g *Git) ResolveRev(ctx context.Context,
 	}
 	return sha.New(output.String())
 }
+
+// ResolveRevAt resolves the revision to the commit it pointed to at the provided time.
+// The state is reconstructed from commit dates, using the latest commit in the first-parent history
+// of the revision that got committed at or before the provided time.
+func (g *Git) ResolveRevAt(ctx context.Context,
+	repoPath string,
+	rev string,
+	at time.Time,
+) (sha.SHA, error) {
+	cmd := command.New("rev-list",
+		command.WithFlag("--max-count", "1"),
+		command.WithFlag("--first-parent"),
+		command.WithFlag("--before", strconv.FormatInt(at.Unix(), 10)),
+		command.WithArg(rev),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		if strings.Contains(err.Error(), "ambiguous argument") || strings.Contains(err.Error(), "bad revision") {
+			return sha.None, errors.InvalidArgument("could not resolve git revision: %s", rev)
+		}
+		return sha.None, fmt.Errorf("failed to resolve git revision at %s: %w", at.Format(time.RFC3339), err)
+	}
+
+	if strings.TrimSpace(output.String()) == "" {
+		return sha.None, errors.NotFound("git revision %q has no commits before %s", rev, at.Format(time.RFC3339))
+	}
+
+	return sha.New(output.String())
+}
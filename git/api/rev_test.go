@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/errors"
+)
+
+func TestGit_ResolveRevAt(t *testing.T) {
+	ctx := context.Background()
+	g := &Git{}
+	repoPath := t.TempDir()
+
+	base := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return base.Add(time.Duration(n) * 24 * time.Hour) }
+
+	run := func(when time.Time, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		date := when.Format(time.RFC3339)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com", "GIT_COMMITTER_DATE="+date)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %s: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	run(day(0), "init", "--initial-branch=main")
+	run(day(0), "commit", "--allow-empty", "-m", "first")
+	first := run(day(0), "rev-parse", "HEAD")
+
+	run(day(1), "checkout", "-b", "feature")
+	run(day(1), "commit", "--allow-empty", "-m", "feature")
+
+	run(day(2), "checkout", "main")
+	run(day(2), "merge", "--no-ff", "-m", "merge", "feature")
+	merge := run(day(2), "rev-parse", "HEAD")
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{name: "at-commit-time", at: day(0), want: first},
+		{name: "ignores-merged-branch-commits", at: day(1).Add(time.Hour), want: first},
+		{name: "after-merge", at: day(3), want: merge},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := g.ResolveRevAt(ctx, repoPath, "main", test.at)
+			if err != nil {
+				t.Fatalf("failed to resolve revision: %s", err)
+			}
+			if got.String() != test.want {
+				t.Errorf("want=%s got=%s", test.want, got)
+			}
+		})
+	}
+
+	_, err := g.ResolveRevAt(ctx, repoPath, "main", day(-1))
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected not found error for a time before the first commit, got: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// MaxPatchSize is the maximum size of a patch that can be applied.
+const MaxPatchSize = 10 << 20 // 10 MiB
+
+// ApplyPatchParams holds the data for the apply patch operation.
+type ApplyPatchParams struct {
+	WriteParams
+	Title     string
+	Message   string
+	Branch    string
+	NewBranch string
+
+	// Patch is the unified diff (output of git diff or git format-patch) that is applied to the branch.
+	Patch []byte
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the files
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the files
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *ApplyPatchParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Title == "" {
+		return errors.InvalidArgument("commit title is mandatory")
+	}
+
+	if len(bytes.TrimSpace(p.Patch)) == 0 {
+		return errors.InvalidArgument("patch is mandatory")
+	}
+
+	if len(p.Patch) > MaxPatchSize {
+		return errors.InvalidArgument("patch exceeds the maximum size of %d bytes", MaxPatchSize)
+	}
+
+	return nil
+}
+
+type ApplyPatchOutput struct {
+	CommitID sha.SHA
+}
+
+// ApplyPatch applies the patch on top of the latest commit of the branch and commits the result.
+// If NewBranch is provided, the commit is pushed to the new branch instead.
+func (s *Service) ApplyPatch(ctx context.Context, params *ApplyPatchParams) (ApplyPatchOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ApplyPatchOutput{}, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	if params.Branch == "" {
+		defaultBranch, err := s.git.GetDefaultBranch(ctx, repoPath)
+		if err != nil {
+			return ApplyPatchOutput{}, fmt.Errorf("failed to get default branch: %w", err)
+		}
+		params.Branch = defaultBranch
+	}
+
+	params.Branch = strings.TrimPrefix(strings.TrimSpace(params.Branch), gitReferenceNamePrefixBranch)
+	params.NewBranch = strings.TrimPrefix(strings.TrimSpace(params.NewBranch), gitReferenceNamePrefixBranch)
+	if params.NewBranch == "" {
+		params.NewBranch = params.Branch
+	}
+
+	branch, err := s.git.GetBranch(ctx, repoPath, params.Branch)
+	if err != nil {
+		return ApplyPatchOutput{}, fmt.Errorf("failed to get branch '%s': %w", params.Branch, err)
+	}
+
+	refOldSHA := branch.Commit.SHA
+	if params.Branch != params.NewBranch {
+		existingBranch, err := s.git.GetBranch(ctx, repoPath, params.NewBranch)
+		if existingBranch != nil {
+			return ApplyPatchOutput{}, errors.Conflict("branch %s already exists", existingBranch.Name)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return ApplyPatchOutput{}, fmt.Errorf("failed to create new branch '%s': %w", params.NewBranch, err)
+		}
+		refOldSHA = branch.Commit.SHA.ObjectFormat().Nil()
+	}
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+	if params.Author != nil {
+		author.Identity = api.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	message := strings.TrimSpace(params.Title)
+	if len(params.Message) > 0 {
+		message += "\n\n" + strings.TrimSpace(params.Message)
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath,
+		api.GetReferenceFromBranchName(params.NewBranch))
+	if err != nil {
+		return ApplyPatchOutput{}, fmt.Errorf("failed to create ref updater: %w", err)
+	}
+
+	var commitSHA sha.SHA
+
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		oldTreeSHA, err := r.GetTreeSHA(ctx, branch.Commit.SHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha of branch: %w", err)
+		}
+
+		if err := r.SetIndex(ctx, branch.Commit.SHA); err != nil {
+			return fmt.Errorf("failed to set index in shared repository: %w", err)
+		}
+
+		if err := r.ApplyPatch(ctx, bytes.NewReader(params.Patch)); err != nil {
+			return err
+		}
+
+		treeSHA, err := r.WriteTree(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to write tree object: %w", err)
+		}
+
+		if oldTreeSHA.Equal(treeSHA) {
+			return errors.InvalidArgument("No effective changes.")
+		}
+
+		commitSHA, err = r.CommitTree(ctx, &author, &committer, treeSHA, message, false, branch.Commit.SHA)
+		if err != nil {
+			return fmt.Errorf("failed to commit the tree: %w", err)
+		}
+
+		if err := refUpdater.Init(ctx, refOldSHA, commitSHA); err != nil {
+			return fmt.Errorf("failed to init ref updater old=%s new=%s: %w", refOldSHA, commitSHA, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ApplyPatchOutput{}, fmt.Errorf("ApplyPatch: failed to create commit in shared repository: %w", err)
+	}
+
+	return ApplyPatchOutput{
+		CommitID: commitSHA,
+	}, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/types"
+)
+
+type noopHookClientFactory struct{}
+
+func (noopHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+func TestService_ApplyPatch(t *testing.T) {
+	ctx := context.Background()
+
+	config := types.Config{
+		Root:   t.TempDir(),
+		TmpDir: t.TempDir(),
+	}
+
+	gitAPI, err := api.New(config, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git api: %s", err)
+	}
+
+	s, err := New(config, gitAPI, noopHookClientFactory{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %s", err)
+	}
+
+	const repoUID = "applypatchrepo"
+	repoPath := s.repoPath(repoUID)
+	runGit(t, "", "init", "--bare", "--initial-branch=main", repoPath)
+
+	workDir := t.TempDir()
+	runGit(t, workDir, "init", "--initial-branch=main")
+
+	writeFile := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+	}
+
+	writeFile("a.txt", "base\n")
+	runGit(t, workDir, "add", "a.txt")
+	runGit(t, workDir, "commit", "-m", "base")
+	runGit(t, workDir, "push", repoPath, "main")
+
+	// the patches are generated against the pushed main branch and the work tree is reset afterwards.
+	diff := func(name, content string) []byte {
+		writeFile(name, content)
+		runGit(t, workDir, "add", "-N", name)
+		patch := runGit(t, workDir, "diff") + "\n"
+		runGit(t, workDir, "reset", "--hard")
+		runGit(t, workDir, "clean", "-fd")
+		return []byte(patch)
+	}
+
+	addB := diff("b.txt", "b\n")
+	changeA := diff("a.txt", "changed\n")
+
+	params := func(patch []byte, newBranch string) *ApplyPatchParams {
+		return &ApplyPatchParams{
+			WriteParams: WriteParams{
+				RepoUID: repoUID,
+				Actor:   Identity{Name: "test", Email: "test@example.com"},
+			},
+			Title:     "apply patch",
+			Branch:    "main",
+			NewBranch: newBranch,
+			Patch:     patch,
+		}
+	}
+
+	t.Run("clean", func(t *testing.T) {
+		out, err := s.ApplyPatch(ctx, params(addB, "clean"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := runGit(t, "", "--git-dir", repoPath, "rev-parse", "refs/heads/clean"); got != out.CommitID.String() {
+			t.Errorf("expected branch clean to point to %s, got %s", out.CommitID, got)
+		}
+
+		content := runGit(t, "", "--git-dir", repoPath, "show", out.CommitID.String()+":b.txt")
+		if content != "b" {
+			t.Errorf("expected b.txt to contain %q, got %q", "b", content)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		writeFile("a.txt", "main\n")
+		runGit(t, workDir, "commit", "-am", "change a on main")
+		runGit(t, workDir, "push", repoPath, "main")
+
+		_, err := s.ApplyPatch(ctx, params(changeA, "conflict"))
+		if !errors.IsInvalidArgument(err) {
+			t.Errorf("expected invalid argument error, got %v", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := s.ApplyPatch(ctx, params([]byte("this is not a patch\n"), "malformed"))
+		if !errors.IsInvalidArgument(err) {
+			t.Errorf("expected invalid argument error, got %v", err)
+		}
+	})
+
+	t.Run("too-large", func(t *testing.T) {
+		_, err := s.ApplyPatch(ctx, params(bytes.Repeat([]byte("x"), MaxPatchSize+1), "too-large"))
+		if !errors.IsInvalidArgument(err) {
+			t.Errorf("expected invalid argument error, got %v", err)
+		}
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
 )
 
 type BlameParams struct {
@@ -86,7 +87,7 @@ func (s *Service) Blame(ctx context.Context, params *BlameParams) (<-chan *Blame
 			return
 		}
 
-		repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+		repoPath := s.repoPath(params.RepoUID)
 
 		reader := s.git.Blame(ctx,
 			repoPath, params.GitRef, params.Path,
@@ -118,3 +119,85 @@ func (s *Service) Blame(ctx context.Context, params *BlameParams) (<-chan *Blame
 
 	return ch, chErr
 }
+
+type BlameHunk struct {
+	Commit       *Commit `json:"commit"`
+	OriginalLine int     `json:"original_line"`
+	OriginalPath string  `json:"original_path"`
+	Line         int     `json:"line"`
+	LineCount    int     `json:"line_count"`
+}
+
+// BlameIncremental streams the git blame hunks as soon as git produces them.
+// The hunks don't contain the content of the lines and are not necessarily sorted by line number.
+// The parameters are validated and the git ref is resolved before the streaming starts,
+// so invalid input and unknown refs or paths are returned as an error rather than through the error channel.
+// The function returns two channels: The data channel and the error channel.
+// If any error happens during the operation it will be put to the error channel
+// and the streaming will stop. Maximum of one error can be put on the channel.
+func (s *Service) BlameIncremental(
+	ctx context.Context,
+	params *BlameParams,
+) (<-chan *BlameHunk, <-chan error, error) {
+	if err := params.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	refCommit, err := s.git.GetCommit(ctx, repoPath, params.GitRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve git ref %q: %w", params.GitRef, err)
+	}
+
+	node, err := s.git.GetTreeNode(ctx, repoPath, refCommit.SHA.String(), params.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find path %q in %q: %w", params.Path, params.GitRef, err)
+	}
+	if node.NodeType != api.TreeNodeTypeBlob {
+		return nil, nil, errors.InvalidArgument("path %q is not a file", params.Path)
+	}
+
+	ch := make(chan *BlameHunk)
+	chErr := make(chan error, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(chErr)
+
+		reader := s.git.BlameIncremental(ctx,
+			repoPath, refCommit.SHA.String(), params.Path,
+			params.LineFrom, params.LineTo)
+
+		for {
+			hunk, err := reader.NextHunk()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				chErr <- err
+				return
+			}
+
+			commit, err := mapCommit(hunk.Commit)
+			if err != nil {
+				chErr <- fmt.Errorf("failed to map rpc commit: %w", err)
+				return
+			}
+
+			select {
+			case ch <- &BlameHunk{
+				Commit:       commit,
+				OriginalLine: hunk.OriginalLine,
+				OriginalPath: hunk.OriginalPath,
+				Line:         hunk.Line,
+				LineCount:    hunk.LineCount,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, chErr
+}
@@ -43,7 +43,7 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 		return nil, ErrNoParamsProvided
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	// TODO: do we need to validate request for nil?
 	reader, err := api.GetBlob(
@@ -98,7 +98,7 @@ func (s *Service) CreateBranch(ctx context.Context, params *CreateBranchParams)
 		return nil, errors.InvalidArgument(err.Error())
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	targetCommit, err := s.git.GetCommit(ctx, repoPath, strings.TrimSpace(params.Target))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get target commit: %w", err)
@@ -111,7 +111,7 @@ func (s *Service) CreateBranch(ctx context.Context, params *CreateBranchParams)
 		return nil, fmt.Errorf("failed to create ref updater to create the branch: %w", err)
 	}
 
-	err = refUpdater.Do(ctx, sha.Nil, targetCommit.SHA)
+	err = refUpdater.Do(ctx, targetCommit.SHA.ObjectFormat().Nil(), targetCommit.SHA)
 	if errors.IsConflict(err) {
 		return nil, errors.Conflict("branch %q already exists", params.BranchName)
 	}
@@ -138,7 +138,7 @@ func (s *Service) GetBranch(ctx context.Context, params *GetBranchParams) (*GetB
 		return nil, ErrNoParamsProvided
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	sanitizedBranchName := strings.TrimPrefix(params.BranchName, gitReferenceNamePrefixBranch)
 
 	gitBranch, err := s.git.GetBranch(ctx, repoPath, sanitizedBranchName)
@@ -161,7 +161,7 @@ func (s *Service) DeleteBranch(ctx context.Context, params *DeleteBranchParams)
 		return ErrNoParamsProvided
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	branchRef := api.GetReferenceFromBranchName(params.BranchName)
 	commitSha, _ := sha.NewOrEmpty(params.SHA)
 
@@ -170,7 +170,7 @@ func (s *Service) DeleteBranch(ctx context.Context, params *DeleteBranchParams)
 		return fmt.Errorf("failed to create ref updater to create the branch: %w", err)
 	}
 
-	err = refUpdater.Do(ctx, commitSha, sha.Nil)
+	err = refUpdater.Do(ctx, commitSha, sha.None) // empty new value deletes the branch
 	if errors.IsNotFound(err) {
 		return errors.NotFound("branch %q does not exist", params.BranchName)
 	}
@@ -186,7 +186,7 @@ func (s *Service) ListBranches(ctx context.Context, params *ListBranchesParams)
 		return nil, ErrNoParamsProvided
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	gitBranches, err := s.listBranchesLoadReferenceData(ctx, repoPath, api.BranchFilter{
 		IncludeCommit: params.IncludeCommit,
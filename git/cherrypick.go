@@ -0,0 +1,320 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/rs/zerolog/log"
+)
+
+// errCherryPickConflict is used to error out of sharedrepo Run method without failing the cherry-pick.
+var errCherryPickConflict = errors.New("cherry-pick conflict")
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// Revisions is either a single commit or a commit range in the form "A..B".
+	// For a range, all non-merge commits reachable from B but not from A are applied, oldest first.
+	Revisions string
+
+	// Branch is the target branch onto which the commits are applied.
+	Branch string
+
+	// BranchExpectedSHA is the expected commit sha of the target branch (optional).
+	// If provided and it doesn't match the latest sha of the branch the cherry-pick fails.
+	BranchExpectedSHA sha.SHA
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+
+	// DryRun only checks whether the commits can be applied, the branch isn't updated.
+	DryRun bool
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	if p.Revisions == "" {
+		return errors.InvalidArgument("revisions are mandatory")
+	}
+
+	return nil
+}
+
+// CherryPickCommit holds the result of cherry-picking a single commit.
+type CherryPickCommit struct {
+	SHA           sha.SHA
+	NewSHA        sha.SHA
+	Status        enum.CherryPickStatus
+	ConflictFiles []string
+}
+
+// CherryPickOutput is the result of the cherry-pick operation.
+type CherryPickOutput struct {
+	// BaseSHA is the sha of the target branch before the cherry-pick.
+	BaseSHA sha.SHA
+	// NewSHA is the sha of the target branch after the cherry-pick.
+	// It's sha.None if the cherry-pick stopped because of a conflict.
+	NewSHA  sha.SHA
+	Commits []CherryPickCommit
+}
+
+// HasConflicts returns true if any of the commits couldn't be applied.
+func (o CherryPickOutput) HasConflicts() bool {
+	for i := range o.Commits {
+		if o.Commits[i].Status == enum.CherryPickStatusConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// CherryPick applies the commits specified by params.Revisions onto the target branch one by one.
+// Each commit keeps its original author and message, the committer is replaced.
+// Commits whose changes already exist on the branch are skipped.
+// The operation is atomic: if any of the commits conflicts, the processing stops, the branch is left
+// unchanged and the output contains the conflicting files of the failed commit.
+//
+//nolint:gocognit
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	fromSHA, toSHA, err := s.resolveCherryPickRevisions(ctx, repoPath, params.Revisions)
+	if err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	branchRef := api.GetReferenceFromBranchName(params.Branch)
+
+	baseSHA, err := s.git.GetFullCommitID(ctx, repoPath, branchRef)
+	if errors.IsNotFound(err) {
+		return CherryPickOutput{}, errors.NotFound("branch %q not found", params.Branch)
+	}
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to resolve branch %q: %w", params.Branch, err)
+	}
+
+	if !params.BranchExpectedSHA.IsEmpty() && !params.BranchExpectedSHA.Equal(baseSHA) {
+		return CherryPickOutput{}, errors.PreconditionFailed(
+			"branch %q is on SHA %q which doesn't match expected SHA %q.",
+			params.Branch, baseSHA, params.BranchExpectedSHA)
+	}
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	var refUpdater *hook.RefUpdater
+	if !params.DryRun {
+		refUpdater, err = hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, branchRef)
+		if err != nil {
+			return CherryPickOutput{}, errors.Internal(err, "failed to create ref updater object")
+		}
+
+		if err := refUpdater.InitOld(ctx, baseSHA); err != nil {
+			return CherryPickOutput{}, errors.Internal(err, "failed to set old reference value for ref updater")
+		}
+	}
+
+	output := CherryPickOutput{
+		BaseSHA: baseSHA,
+		NewSHA:  sha.None,
+	}
+
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		commitSHAs, err := r.CommitSHAsForCherryPick(ctx, fromSHA, toSHA)
+		if err != nil {
+			return fmt.Errorf("failed to find commit list for cherry-pick: %w", err)
+		}
+
+		if len(commitSHAs) == 0 {
+			return errors.InvalidArgument("no non-merge commits to cherry-pick in %q", params.Revisions)
+		}
+
+		output.Commits = make([]CherryPickCommit, len(commitSHAs))
+		for i, commitSHA := range commitSHAs {
+			output.Commits[i] = CherryPickCommit{
+				SHA:    commitSHA,
+				NewSHA: sha.None,
+				Status: enum.CherryPickStatusPending,
+			}
+		}
+
+		lastCommitSHA := baseSHA
+		lastTreeSHA, err := r.GetTreeSHA(ctx, baseSHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha for target: %w", err)
+		}
+
+		for i, commitSHA := range commitSHAs {
+			commitInfo, err := api.GetCommit(ctx, r.Directory(), commitSHA.String())
+			if err != nil {
+				return fmt.Errorf("failed to get commit data for cherry-pick: %w", err)
+			}
+
+			var mergeBaseSHA sha.SHA
+			if len(commitInfo.ParentSHAs) > 0 {
+				// use the parent of the commit as merge base to only apply the changes introduced by the commit.
+				mergeBaseSHA = commitInfo.ParentSHAs[0]
+			}
+
+			treeSHA, conflicts, err := r.MergeTree(ctx, mergeBaseSHA, lastCommitSHA, commitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to merge tree for cherry-pick of %s: %w", commitSHA, err)
+			}
+			if len(conflicts) > 0 {
+				output.Commits[i].Status = enum.CherryPickStatusConflict
+				output.Commits[i].ConflictFiles = conflicts
+				return errCherryPickConflict
+			}
+
+			if treeSHA.Equal(lastTreeSHA) {
+				log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after cherry-pick", commitSHA)
+				output.Commits[i].Status = enum.CherryPickStatusSkipped
+				continue
+			}
+
+			message := commitInfo.Title
+			if commitInfo.Message != "" {
+				message += "\n\n" + commitInfo.Message
+			}
+
+			lastCommitSHA, err = r.CommitTree(ctx, &commitInfo.Author, &committer, treeSHA, message, false,
+				lastCommitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to commit tree for cherry-pick of %s: %w", commitSHA, err)
+			}
+			lastTreeSHA = treeSHA
+
+			output.Commits[i].Status = enum.CherryPickStatusApplied
+			output.Commits[i].NewSHA = lastCommitSHA
+		}
+
+		if lastCommitSHA.Equal(baseSHA) {
+			return errors.InvalidArgument("all commits already exist on branch %q", params.Branch)
+		}
+
+		output.NewSHA = lastCommitSHA
+
+		if refUpdater == nil {
+			return nil
+		}
+
+		if err := refUpdater.InitNew(ctx, lastCommitSHA); err != nil {
+			return fmt.Errorf("refUpdater.InitNew failed: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, errCherryPickConflict) {
+		return output, nil
+	}
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to cherry-pick %q onto %q: %w",
+			params.Revisions, params.Branch, err)
+	}
+
+	return output, nil
+}
+
+// resolveCherryPickRevisions parses the revisions ("A..B" or a single commit) and resolves them to commit SHAs.
+// For a single commit the returned fromSHA is empty.
+func (s *Service) resolveCherryPickRevisions(
+	ctx context.Context,
+	repoPath string,
+	revisions string,
+) (sha.SHA, sha.SHA, error) {
+	if strings.Contains(revisions, "...") {
+		return sha.None, sha.None, errors.InvalidArgument("symmetric difference ranges are not supported")
+	}
+
+	from, to, isRange := strings.Cut(revisions, "..")
+	if isRange && (from == "" || to == "") {
+		return sha.None, sha.None, errors.InvalidArgument("commit range %q must be in the form A..B", revisions)
+	}
+
+	resolve := func(rev string) (sha.SHA, error) {
+		if strings.HasPrefix(rev, "-") {
+			return sha.None, errors.InvalidArgument("invalid revision %q", rev)
+		}
+		commitSHA, err := s.git.GetFullCommitID(ctx, repoPath, rev+"^{commit}")
+		if errors.IsNotFound(err) {
+			return sha.None, errors.NotFound("revision %q not found", rev)
+		}
+		if err != nil {
+			return sha.None, fmt.Errorf("failed to resolve revision %q: %w", rev, err)
+		}
+		return commitSHA, nil
+	}
+
+	if !isRange {
+		to = from
+	}
+
+	toSHA, err := resolve(to)
+	if err != nil {
+		return sha.None, sha.None, err
+	}
+
+	if !isRange {
+		// a single merge commit would be filtered out of the commit list, so report it explicitly.
+		commit, err := api.GetCommit(ctx, repoPath, toSHA.String())
+		if err != nil {
+			return sha.None, sha.None, fmt.Errorf("failed to get commit %q: %w", to, err)
+		}
+		if len(commit.ParentSHAs) > 1 {
+			return sha.None, sha.None, errors.InvalidArgument("cherry-picking merge commits is not supported")
+		}
+
+		return sha.None, toSHA, nil
+	}
+
+	fromSHA, err := resolve(from)
+	if err != nil {
+		return sha.None, sha.None, err
+	}
+
+	return fromSHA, toSHA, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestService_CherryPick(t *testing.T) {
+	ctx := context.Background()
+
+	config := types.Config{
+		Root:   t.TempDir(),
+		TmpDir: t.TempDir(),
+	}
+
+	gitAPI, err := api.New(config, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git api: %s", err)
+	}
+
+	s, err := New(config, gitAPI, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %s", err)
+	}
+
+	const repoUID = "cherrypickrepo"
+	repoPath := s.repoPath(repoUID)
+	runGit(t, "", "init", "--bare", "--initial-branch=main", repoPath)
+
+	workDir := t.TempDir()
+	runGit(t, workDir, "init", "--initial-branch=main")
+
+	commitFile := func(name, content, msg string) string {
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+		runGit(t, workDir, "add", name)
+		runGit(t, workDir, "commit", "-m", msg)
+		return runGit(t, workDir, "rev-parse", "HEAD")
+	}
+
+	base := commitFile("a.txt", "base\n", "base")
+
+	runGit(t, workDir, "checkout", "-b", "feature")
+	conflicting := commitFile("a.txt", "feature\n", "change a on feature")
+	independent := commitFile("b.txt", "b\n", "add b on feature")
+
+	runGit(t, workDir, "checkout", "main")
+	commitFile("a.txt", "main\n", "change a on main")
+
+	runGit(t, workDir, "checkout", "-b", "side", base)
+	commitFile("c.txt", "c\n", "add c on side")
+	runGit(t, workDir, "checkout", "main")
+	runGit(t, workDir, "merge", "--no-ff", "-m", "merge side", "side")
+	merge := runGit(t, workDir, "rev-parse", "HEAD")
+
+	runGit(t, workDir, "push", repoPath, "main", "feature", "side")
+
+	params := func(revisions string) *CherryPickParams {
+		return &CherryPickParams{
+			WriteParams: WriteParams{
+				RepoUID: repoUID,
+				Actor:   Identity{Name: "test", Email: "test@example.com"},
+			},
+			Revisions: revisions,
+			Branch:    "main",
+			DryRun:    true,
+		}
+	}
+
+	t.Run("conflict", func(t *testing.T) {
+		out, err := s.CherryPick(ctx, params(base+".."+independent))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !out.HasConflicts() {
+			t.Fatalf("expected conflicts, got %+v", out)
+		}
+		if len(out.Commits) != 2 {
+			t.Fatalf("expected 2 commits, got %d", len(out.Commits))
+		}
+		if got := out.Commits[0]; got.SHA.String() != conflicting || got.Status != enum.CherryPickStatusConflict {
+			t.Errorf("expected first commit %s to conflict, got %s with status %s", conflicting, got.SHA, got.Status)
+		}
+		if got := out.Commits[0].ConflictFiles; len(got) != 1 || got[0] != "a.txt" {
+			t.Errorf("expected conflict in a.txt, got %v", got)
+		}
+		if got := out.Commits[1].Status; got != enum.CherryPickStatusPending {
+			t.Errorf("expected second commit to stay pending, got %s", got)
+		}
+		if !out.NewSHA.IsEmpty() {
+			t.Errorf("expected no new sha on conflict, got %s", out.NewSHA)
+		}
+	})
+
+	t.Run("single-commit", func(t *testing.T) {
+		out, err := s.CherryPick(ctx, params(independent))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if out.HasConflicts() || len(out.Commits) != 1 {
+			t.Fatalf("expected a single applied commit, got %+v", out)
+		}
+		if got := out.Commits[0].Status; got != enum.CherryPickStatusApplied {
+			t.Errorf("expected commit to be applied, got %s", got)
+		}
+	})
+
+	t.Run("empty-range", func(t *testing.T) {
+		_, err := s.CherryPick(ctx, params(independent+".."+independent))
+		if !errors.IsInvalidArgument(err) {
+			t.Errorf("expected invalid argument error, got %v", err)
+		}
+	})
+
+	t.Run("merge-commit", func(t *testing.T) {
+		_, err := s.CherryPick(ctx, params(merge))
+		if !errors.IsInvalidArgument(err) {
+			t.Fatalf("expected invalid argument error, got %v", err)
+		}
+		if got := errors.Message(err); got != "cherry-picking merge commits is not supported" {
+			t.Errorf("unexpected error message: %q", got)
+		}
+	})
+}
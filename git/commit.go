@@ -42,6 +42,9 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+
+	CoAuthors       []Identity                 `json:"co_authors,omitempty"`
+	SignatureStatus enum.CommitSignatureStatus `json:"signature_status,omitempty"`
 }
 
 type GetCommitOutput struct {
@@ -74,7 +77,7 @@ func (s *Service) GetCommit(ctx context.Context, params *GetCommitParams) (*GetC
 	if params == nil {
 		return nil, ErrNoParamsProvided
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	result, err := s.git.GetCommit(ctx, repoPath, params.Revision)
 	if err != nil {
 		return nil, err
@@ -90,6 +93,99 @@ func (s *Service) GetCommit(ctx context.Context, params *GetCommitParams) (*GetC
 	}, nil
 }
 
+type ResolveRevisionAtParams struct {
+	ReadParams
+	// Revision is a git reference (branch / tag / commit SHA).
+	Revision string
+	// At is the point in time the revision is resolved at (UNIX timestamp in milliseconds).
+	At int64
+}
+
+type ResolveRevisionAtOutput struct {
+	SHA sha.SHA
+}
+
+// ResolveRevisionAt resolves the revision to the commit it pointed to at the provided point in time.
+func (s *Service) ResolveRevisionAt(
+	ctx context.Context,
+	params *ResolveRevisionAtParams,
+) (*ResolveRevisionAtOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if params.At <= 0 {
+		return nil, errors.InvalidArgument("point in time has to be a positive timestamp")
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+	commitSHA, err := s.git.ResolveRevAt(ctx, repoPath, params.Revision, time.UnixMilli(params.At))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResolveRevisionAtOutput{
+		SHA: commitSHA,
+	}, nil
+}
+
+type GetCommitSignaturesParams struct {
+	ReadParams
+	CommitSHAs []sha.SHA
+}
+
+// CommitSignature contains the raw signature of a commit and the content that has been signed.
+type CommitSignature struct {
+	CommitSHA sha.SHA
+	Committer Signature
+	// Signature is the armored signature of the commit, empty if the commit isn't signed.
+	Signature string
+	// SignedContent is the commit object without the signature header.
+	SignedContent []byte
+}
+
+type GetCommitSignaturesOutput struct {
+	Signatures []CommitSignature
+}
+
+// GetCommitSignatures returns the raw signatures of the provided commits.
+// The signatures aren't verified, the caller is expected to verify them against the known keys.
+func (s *Service) GetCommitSignatures(
+	ctx context.Context,
+	params *GetCommitSignaturesParams,
+) (*GetCommitSignaturesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+	gitCommits, err := s.git.GetCommitSignatures(ctx, repoPath, params.CommitSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]CommitSignature, len(gitCommits))
+	for i, gitCommit := range gitCommits {
+		committer, err := mapSignature(&gitCommit.Committer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc committer: %w", err)
+		}
+
+		signatures[i] = CommitSignature{
+			CommitSHA: gitCommit.SHA,
+			Committer: *committer,
+		}
+
+		if gitCommit.Signature != nil {
+			signatures[i].Signature = gitCommit.Signature.Signature
+			signatures[i].SignedContent = []byte(gitCommit.Signature.Payload)
+		}
+	}
+
+	return &GetCommitSignaturesOutput{
+		Signatures: signatures,
+	}, nil
+}
+
 type ListCommitsParams struct {
 	ReadParams
 	// GitREF is a git reference (branch / tag / commit SHA)
@@ -112,6 +208,9 @@ type ListCommitsParams struct {
 
 	// IncludeStats allows to include information about inserted, deletions and status for changed files.
 	IncludeStats bool
+
+	// IncludeSignatureStatus allows to include the verification status of the commit signatures.
+	IncludeSignatureStatus bool
 }
 
 type RenameDetails struct {
@@ -140,7 +239,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 		return nil, ErrNoParamsProvided
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	gitCommits, renameDetails, err := s.git.ListCommits(
 		ctx,
@@ -187,6 +286,22 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 		commits[i] = *commit
 	}
 
+	if params.IncludeSignatureStatus {
+		commitSHAs := make([]sha.SHA, len(gitCommits))
+		for i := range gitCommits {
+			commitSHAs[i] = gitCommits[i].SHA
+		}
+
+		statuses, err := s.git.GetCommitSignatureStatuses(ctx, repoPath, commitSHAs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit signature statuses: %w", err)
+		}
+
+		for i := range commits {
+			commits[i].SignatureStatus = statuses[commits[i].SHA.String()]
+		}
+	}
+
 	return &ListCommitsOutput{
 		Commits:       commits,
 		RenameDetails: mapRenameDetails(renameDetails),
@@ -228,7 +343,7 @@ func (s *Service) GetCommitDivergences(
 		return nil, ErrNoParamsProvided
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	requests := make([]api.CommitDivergenceRequest, len(params.Requests))
 	for i, req := range params.Requests {
@@ -276,7 +391,7 @@ func (s *Service) FindOversizeFiles(
 	if params.RepoUID == "" {
 		return nil, api.ErrRepositoryPathEmpty
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	var fileInfos []FileInfo
 	for _, gitObjDir := range params.GitObjectDirs {
@@ -64,7 +64,7 @@ func (s *Service) rawDiff(ctx context.Context, w io.Writer, params *DiffParams,
 		return err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	err := s.git.RawDiff(ctx,
 		w,
@@ -82,7 +82,7 @@ func (s *Service) rawDiff(ctx context.Context, w io.Writer, params *DiffParams,
 }
 
 func (s *Service) CommitDiff(ctx context.Context, params *GetCommitParams, out io.Writer) error {
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	err := s.git.CommitDiff(ctx, repoPath, params.Revision, out)
 	if err != nil {
 		return err
@@ -101,7 +101,7 @@ func (s *Service) DiffShortStat(ctx context.Context, params *DiffParams) (DiffSh
 	if err := params.Validate(); err != nil {
 		return DiffShortStatOutput{}, err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	stat, err := s.git.DiffShortStat(ctx,
 		repoPath,
 		params.BaseRef,
@@ -220,7 +220,7 @@ func (s *Service) GetDiffHunkHeaders(
 		return GetDiffHunkHeadersOutput{}, nil
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	hunkHeaders, err := s.git.GetDiffHunkHeaders(ctx, repoPath, params.TargetCommitSHA, params.SourceCommitSHA)
 	if err != nil {
@@ -270,7 +270,7 @@ func (s *Service) DiffCut(ctx context.Context, params *DiffCutParams) (DiffCutOu
 		return DiffCutOutput{}, errors.InvalidArgument("source and target SHA cannot be the same")
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	mergeBaseSHA, _, err := s.git.GetMergeBase(ctx, repoPath, "", params.TargetCommitSHA, params.SourceCommitSHA)
 	if err != nil {
@@ -418,7 +418,7 @@ func (s *Service) DiffFileNames(ctx context.Context, params *DiffParams) (DiffFi
 	if err := params.Validate(); err != nil {
 		return DiffFileNamesOutput{}, err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	fileNames, err := s.git.DiffFileName(
 		ctx,
 		repoPath,
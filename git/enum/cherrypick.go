@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// CherryPickStatus represents the outcome of cherry-picking a single commit.
+type CherryPickStatus string
+
+const (
+	// CherryPickStatusApplied the commit was applied on top of the target.
+	CherryPickStatusApplied CherryPickStatus = "applied"
+	// CherryPickStatusSkipped the commit was dropped as its changes already exist on the target.
+	CherryPickStatusSkipped CherryPickStatus = "skipped"
+	// CherryPickStatusConflict the commit couldn't be applied due to merge conflicts.
+	CherryPickStatusConflict CherryPickStatus = "conflict"
+	// CherryPickStatusPending the commit wasn't processed because an earlier commit failed.
+	CherryPickStatusPending CherryPickStatus = "pending"
+)
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// CommitSignatureStatus represents the result of the verification of a commit signature.
+type CommitSignatureStatus string
+
+const (
+	// CommitSignatureStatusUnsigned the commit isn't signed.
+	CommitSignatureStatusUnsigned CommitSignatureStatus = "unsigned"
+	// CommitSignatureStatusVerified the commit has a good signature of a trusted key.
+	CommitSignatureStatusVerified CommitSignatureStatus = "verified"
+	// CommitSignatureStatusUnverified the commit is signed, but the signature couldn't be verified
+	// (e.g. the key is unknown to the server or the validity of the key is unknown).
+	CommitSignatureStatusUnverified CommitSignatureStatus = "unverified"
+	// CommitSignatureStatusInvalid the commit has a bad signature.
+	CommitSignatureStatusInvalid CommitSignatureStatus = "invalid"
+	// CommitSignatureStatusExpired the commit is signed with an expired key or the signature has expired.
+	CommitSignatureStatusExpired CommitSignatureStatus = "expired"
+	// CommitSignatureStatusRevoked the commit is signed with a revoked key.
+	CommitSignatureStatusRevoked CommitSignatureStatus = "revoked"
+)
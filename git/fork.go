@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+// configKeyPruneExpire is the git config key that defines when unreachable objects get pruned.
+const configKeyPruneExpire = "gc.pruneExpire"
+
+// forkRefSpecs are the references copied from the parent repository when a repository is forked.
+var forkRefSpecs = []string{
+	"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+	"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+}
+
+type ForkRepositoryParams struct {
+	// Fork operation is similar to create, the UID of the fork is optional and generated if not provided.
+	RepoUID string
+	Actor   Identity
+	EnvVars map[string]string
+
+	// ParentRepoUID is the UID of the repository that's being forked.
+	ParentRepoUID string
+	// DefaultBranch is the default branch of the fork (should match the default branch of the parent).
+	DefaultBranch string
+	// StoragePool is the name of the storage pool the fork is created in (optional, default: default pool).
+	StoragePool string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.ParentRepoUID == "" {
+		return errors.InvalidArgument("parent repository id cannot be empty")
+	}
+
+	return p.Actor.Validate()
+}
+
+type DetachAlternatesParams struct {
+	WriteParams
+}
+
+type DeduplicateRepositoryParams struct {
+	WriteParams
+}
+
+type RestorePruningParams struct {
+	WriteParams
+}
+
+// ForkRepository creates a new repository containing all branches and tags of the parent repository.
+// The objects of the fork are not copied, instead the object directory of the parent is used as alternate.
+// IMPORTANT: Before the parent repository is deleted, DetachAlternates has to be called for all of its forks.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*CreateRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.RepoUID == "" {
+		uid, err := NewRepositoryUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+		params.RepoUID = uid
+	}
+
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("parent_repo_uid", params.ParentRepoUID).
+		Logger()
+
+	parentPath := s.repoPath(params.ParentRepoUID)
+
+	objectFormat, err := s.git.GetObjectFormat(ctx, parentPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object format of parent repository: %w", err)
+	}
+
+	// Unreachable objects of the parent could still be referenced by the fork - never prune them.
+	// The pruning is re-enabled with RestorePruning once the parent doesn't have any forks anymore.
+	err = s.git.Config(ctx, parentPath, configKeyPruneExpire, "never")
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable pruning of parent repository: %w", err)
+	}
+
+	writeParams := WriteParams{
+		RepoUID: params.RepoUID,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err = s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		params.StoragePool,
+		params.DefaultBranch,
+		objectFormat,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	err = func() error {
+		if err := s.git.SetAlternates(repoPath, parentPath); err != nil {
+			return fmt.Errorf("failed to set alternates: %w", err)
+		}
+
+		// all objects are available via the alternates, hence the fetch only copies the references.
+		if err := s.git.Sync(ctx, repoPath, parentPath, forkRefSpecs); err != nil {
+			return fmt.Errorf("failed to fetch references of parent repository: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		if errDel := s.DeleteRepositoryBestEffort(ctx, params.RepoUID); errDel != nil {
+			log.Warn().Err(errDel).Msg("failed to delete fork after failed fork operation")
+		}
+		return nil, err
+	}
+
+	log.Info().Msg("repository forked")
+
+	return &CreateRepositoryOutput{
+		UID: params.RepoUID,
+	}, nil
+}
+
+// DetachAlternates copies all objects borrowed from the alternates into the repository and removes the alternates.
+// It's a no-op in case the repository doesn't have any alternates.
+func (s *Service) DetachAlternates(ctx context.Context, params *DetachAlternatesParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	alternates, err := s.git.GetAlternates(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get alternates: %w", err)
+	}
+	if len(alternates) == 0 {
+		return nil
+	}
+
+	// repack without the local flag includes all objects borrowed from the alternates.
+	if err := s.git.RepackAll(ctx, repoPath, false); err != nil {
+		return fmt.Errorf("failed to repack objects of alternates: %w", err)
+	}
+
+	if err := s.git.SetAlternates(repoPath); err != nil {
+		return fmt.Errorf("failed to remove alternates: %w", err)
+	}
+
+	return nil
+}
+
+// DeduplicateRepository removes all objects from the repository that are available in its alternates.
+// It's a no-op in case the repository doesn't have any alternates.
+func (s *Service) DeduplicateRepository(ctx context.Context, params *DeduplicateRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	alternates, err := s.git.GetAlternates(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get alternates: %w", err)
+	}
+	if len(alternates) == 0 {
+		return nil
+	}
+
+	if err := s.git.RepackAll(ctx, repoPath, true); err != nil {
+		return fmt.Errorf("failed to repack repository: %w", err)
+	}
+
+	return nil
+}
+
+// RestorePruning re-enables the pruning of unreachable objects of a repository that was disabled when it got forked.
+// IMPORTANT: It must only be called once no other repository borrows objects from the repository anymore.
+func (s *Service) RestorePruning(ctx context.Context, params *RestorePruningParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.git.UnsetConfig(ctx, s.repoPath(params.RepoUID), configKeyPruneExpire); err != nil {
+		return fmt.Errorf("failed to restore pruning of repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+)
+
+type GrepParams struct {
+	ReadParams
+	// Ref is the git revision that is searched.
+	Ref     string
+	Pattern string
+	// Regex interprets the pattern as extended regular expression, otherwise it's matched as fixed string.
+	Regex      bool
+	IgnoreCase bool
+	// Paths optionally limits the search to the provided paths.
+	Paths []string
+	// MaxResults is the max number of matching lines returned.
+	MaxResults int
+}
+
+func (p *GrepParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Ref == "" {
+		return errors.InvalidArgument("git ref is mandatory")
+	}
+
+	if p.Pattern == "" {
+		return errors.InvalidArgument("search pattern is mandatory")
+	}
+
+	return nil
+}
+
+type GrepOutput struct {
+	Matches []api.GrepMatch
+	// Truncated is true in case there are more matching lines than MaxResults.
+	Truncated bool
+}
+
+// Grep searches the files of a git revision for lines matching the provided pattern.
+func (s *Service) Grep(ctx context.Context, params *GrepParams) (*GrepOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	matches, truncated, err := s.git.Grep(ctx, repoPath, params.Ref, api.GrepOptions{
+		Pattern:    params.Pattern,
+		Regex:      params.Regex,
+		IgnoreCase: params.IgnoreCase,
+		Paths:      params.Paths,
+		MaxResults: params.MaxResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to grep repository: %w", err)
+	}
+
+	return &GrepOutput{
+		Matches:   matches,
+		Truncated: truncated,
+	}, nil
+}
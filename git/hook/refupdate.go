@@ -157,7 +157,7 @@ func (u *RefUpdater) InitOld(ctx context.Context, oldValue sha.SHA) error {
 	return nil
 }
 
-func (u *RefUpdater) InitNew(_ context.Context, newValue sha.SHA) error {
+func (u *RefUpdater) InitNew(ctx context.Context, newValue sha.SHA) error {
 	if u == nil {
 		return nil
 	}
@@ -175,9 +175,48 @@ func (u *RefUpdater) InitNew(_ context.Context, newValue sha.SHA) error {
 	u.state = statePre
 	u.newValue = newValue
 
+	// callers (and the not found case above) might provide the sha1 nil value,
+	// the hooks have to receive the nil value in the object format of the repository.
+	if u.oldValue.IsNil() || u.newValue.IsNil() {
+		nilValue, err := u.nilValue(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get nil value of repository: %w", err)
+		}
+
+		if u.oldValue.IsNil() {
+			u.oldValue = nilValue
+		}
+		if u.newValue.IsNil() {
+			u.newValue = nilValue
+		}
+	}
+
 	return nil
 }
 
+// nilValue returns the nil value in the object format of the repository.
+// The object format is taken from the provided values if possible, to avoid an additional git call.
+func (u *RefUpdater) nilValue(ctx context.Context) (sha.SHA, error) {
+	for _, value := range []sha.SHA{u.oldValue, u.newValue} {
+		if value.IsFull() && !value.IsNil() {
+			return value.ObjectFormat().Nil(), nil
+		}
+	}
+
+	cmd := command.New("rev-parse", command.WithFlag("--show-object-format"))
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(u.repoPath), command.WithStdout(output)); err != nil {
+		return sha.None, fmt.Errorf("failed to get object format of repository: %w", err)
+	}
+
+	objectFormat, err := sha.ParseObjectFormat(strings.TrimSpace(output.String()))
+	if err != nil {
+		return sha.None, err
+	}
+
+	return objectFormat.Nil(), nil
+}
+
 // Pre runs the pre-receive git hook.
 func (u *RefUpdater) Pre(ctx context.Context, alternateDirs ...string) error {
 	if u.state != statePre {
@@ -235,7 +274,12 @@ func (u *RefUpdater) UpdateRef(ctx context.Context) error {
 		cmd.Add(command.WithArg(u.ref, u.newValue.String()))
 	}
 
-	cmd.Add(command.WithArg(u.oldValue.String()))
+	// an empty old value ensures the reference doesn't exist yet, independent of the repo's object format.
+	oldValue := u.oldValue.String()
+	if u.oldValue.IsNil() {
+		oldValue = ""
+	}
+	cmd.Add(command.WithArg(oldValue))
 
 	if err := cmd.Run(ctx, command.WithDir(u.repoPath)); err != nil {
 		msg := err.Error()
@@ -24,6 +24,8 @@ import (
 type Interface interface {
 	CreateRepository(ctx context.Context, params *CreateRepositoryParams) (*CreateRepositoryOutput, error)
 	DeleteRepository(ctx context.Context, params *DeleteRepositoryParams) error
+	// RepositoryExists returns whether the git repository exists in any of the storage pools.
+	RepositoryExists(ctx context.Context, params ReadParams) (bool, error)
 	GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*GetTreeNodeOutput, error)
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
 	ListPaths(ctx context.Context, params *ListPathsParams) (*ListPathsOutput, error)
@@ -46,19 +48,42 @@ type Interface interface {
 	// prior to the call. To remove a ref use the zero ref as the NewValue. To require the creation of a new one and
 	// not update of an exiting one, set the zero ref as the OldValue.
 	UpdateRef(ctx context.Context, params UpdateRefParams) error
+	// FetchRef fetches a branch of another repository into a reference of the repository.
+	FetchRef(ctx context.Context, params FetchRefParams) (FetchRefOutput, error)
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	// ForkRepository creates a new repository with all branches and tags of the parent, sharing its objects.
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*CreateRepositoryOutput, error)
+	// DetachAlternates makes the repository independent of the repositories it borrows objects from.
+	DetachAlternates(ctx context.Context, params *DetachAlternatesParams) error
+	// DeduplicateRepository removes all objects from the repository that are available in its alternates.
+	DeduplicateRepository(ctx context.Context, params *DeduplicateRepositoryParams) error
+	// RestorePruning re-enables the pruning of unreachable objects once the repository doesn't have forks anymore.
+	RestorePruning(ctx context.Context, params *RestorePruningParams) error
+
+	// StageRepositoryMove copies the repository into the staging area of another storage pool.
+	StageRepositoryMove(ctx context.Context, params *MoveRepositoryParams) error
+	// CompleteRepositoryMove replaces the repository with its staged copy in the other storage pool.
+	CompleteRepositoryMove(ctx context.Context, params *MoveRepositoryParams) error
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
+	Grep(ctx context.Context, params *GrepParams) (*GrepOutput, error)
+
 	/*
 	 * Commits service
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
+	// ResolveRevisionAt resolves the revision to the commit it pointed to at the provided point in time.
+	ResolveRevisionAt(ctx context.Context, params *ResolveRevisionAtParams) (*ResolveRevisionAtOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	// GetCommitSignatures returns the raw signatures of the commits along with the signed content.
+	GetCommitSignatures(ctx context.Context, params *GetCommitSignaturesParams) (*GetCommitSignaturesOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
+	ApplyPatch(ctx context.Context, params *ApplyPatchParams) (ApplyPatchOutput, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
 	IsAncestor(ctx context.Context, params IsAncestorParams) (IsAncestorOutput, error)
 	FindOversizeFiles(
@@ -89,11 +114,14 @@ type Interface interface {
 	 * Merge services
 	 */
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
+	CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error)
+	Revert(ctx context.Context, params *RevertParams) (RevertOutput, error)
 
 	/*
 	 * Blame services
 	 */
 	Blame(ctx context.Context, params *BlameParams) (<-chan *BlamePart, <-chan error)
+	BlameIncremental(ctx context.Context, params *BlameParams) (<-chan *BlameHunk, <-chan error, error)
 	PushRemote(ctx context.Context, params *PushRemoteParams) error
 
 	GeneratePipeline(ctx context.Context, params *GeneratePipelineParams) (GeneratePipelinesOutput, error)
@@ -65,9 +65,27 @@ func mapCommit(c *api.Commit) (*Commit, error) {
 		Author:     *author,
 		Committer:  *comitter,
 		FileStats:  mapFileStats(c.FileStats),
+
+		CoAuthors: mapIdentities(c.CoAuthors),
 	}, nil
 }
 
+func mapIdentities(ids []api.Identity) []Identity {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	identities := make([]Identity, len(ids))
+	for i := range ids {
+		identities[i] = Identity{
+			Name:  ids[i].Name,
+			Email: ids[i].Email,
+		}
+	}
+
+	return identities
+}
+
 func mapFileStats(typeStats []api.CommitFileStats) []CommitFileStats {
 	var stats = make([]CommitFileStats, len(typeStats))
 
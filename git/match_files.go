@@ -36,7 +36,7 @@ type MatchFilesOutput struct {
 func (s *Service) MatchFiles(ctx context.Context,
 	params *MatchFilesParams,
 ) (*MatchFilesOutput, error) {
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	matchedFiles, err := s.git.MatchFiles(ctx, repoPath,
 		params.Ref, params.DirPath, params.Pattern, params.MaxSize)
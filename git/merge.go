@@ -33,7 +33,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// The head commit has to be available in the repository as well, e.g. fetched with FetchRef.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -124,7 +124,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("params not valid: %w", err)
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	// prepare the merge method function
 
@@ -164,7 +164,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 
 		refOldValue, err = s.git.GetFullCommitID(ctx, repoPath, refPath)
 		if errors.IsNotFound(err) {
-			refOldValue = sha.Nil
+			var objectFormat sha.ObjectFormat
+			objectFormat, err = s.git.GetObjectFormat(ctx, repoPath)
+			if err != nil {
+				return MergeOutput{}, fmt.Errorf("failed to get object format of repository: %w", err)
+			}
+			refOldValue = objectFormat.Nil()
 		} else if err != nil {
 			return MergeOutput{}, fmt.Errorf("failed to resolve %q: %w", refPath, err)
 		}
@@ -177,7 +182,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get base branch commit SHA: %w", err)
 	}
 
-	headCommitSHA, err := s.git.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = s.repoPath(params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.git.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get head branch commit SHA: %w", err)
 	}
@@ -335,7 +345,7 @@ func (s *Service) MergeBase(
 		return MergeBaseOutput{}, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	result, _, err := s.git.GetMergeBase(ctx, repoPath, "", params.Ref1, params.Ref2)
 	if err != nil {
@@ -361,7 +371,7 @@ func (s *Service) IsAncestor(
 	ctx context.Context,
 	params IsAncestorParams,
 ) (IsAncestorOutput, error) {
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	result, err := s.git.IsAncestor(
 		ctx,
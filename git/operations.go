@@ -108,7 +108,7 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 		authorDate = *params.AuthorDate
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	// check if repo is empty
 
@@ -137,7 +137,12 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 	branchRef := api.GetReferenceFromBranchName(params.Branch)
 	if params.Branch != params.NewBranch {
 		// we are creating a new branch, rather than updating the existing one
-		refOldSHA = sha.Nil
+		if commit != nil {
+			refOldSHA = commit.SHA.ObjectFormat().Nil()
+		} else {
+			// the ref updater resolves the nil value of the empty repository's object format.
+			refOldSHA = sha.Nil
+		}
 		branchRef = api.GetReferenceFromBranchName(params.NewBranch)
 	} else if commit != nil {
 		refOldSHA = commit.SHA
@@ -155,7 +160,12 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 		var oldTreeSHA sha.SHA
 
 		if isEmpty {
-			oldTreeSHA = sha.EmptyTree
+			objectFormat, err := s.git.GetObjectFormat(ctx, repoPath)
+			if err != nil {
+				return fmt.Errorf("failed to get object format of repository: %w", err)
+			}
+
+			oldTreeSHA = objectFormat.EmptyTree()
 			err = s.prepareTreeEmptyRepo(ctx, r, params.Actions)
 			if err != nil {
 				return fmt.Errorf("failed to prepare empty tree: %w", err)
@@ -37,7 +37,7 @@ func (s *Service) GeneratePipeline(ctx context.Context,
 	if err := params.Validate(); err != nil {
 		return GeneratePipelinesOutput{}, err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	sha, err := s.git.ResolveRev(ctx, repoPath, "HEAD")
 	if err != nil {
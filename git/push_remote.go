@@ -47,7 +47,7 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 		return err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	if ok, err := s.git.HasBranches(ctx, repoPath); ok {
 		if err != nil {
 			return errors.Internal(err, "push to repo failed")
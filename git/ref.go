@@ -54,7 +54,7 @@ func (s *Service) GetRef(ctx context.Context, params GetRefParams) (GetRefRespon
 	if err := params.Validate(); err != nil {
 		return GetRefResponse{}, err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	reference, err := GetRefPath(params.Name, params.Type)
 	if err != nil {
@@ -85,7 +85,7 @@ func (s *Service) UpdateRef(ctx context.Context, params UpdateRefParams) error {
 	if err := params.Validate(); err != nil {
 		return err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	reference, err := GetRefPath(params.Name, params.Type)
 	if err != nil {
@@ -104,6 +104,69 @@ func (s *Service) UpdateRef(ctx context.Context, params UpdateRefParams) error {
 	return nil
 }
 
+type FetchRefParams struct {
+	WriteParams
+	// SourceRepoUID is the UID of the repository the branch is fetched from.
+	SourceRepoUID string
+	// SourceBranch is the name of the branch in the source repository.
+	SourceBranch string
+	// Type and Name define the reference in the target repository the branch is fetched into.
+	Type enum.RefType
+	Name string
+}
+
+func (p *FetchRefParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository id cannot be empty")
+	}
+	if p.SourceBranch == "" {
+		return errors.InvalidArgument("source branch cannot be empty")
+	}
+	if p.Name == "" {
+		return errors.InvalidArgument("ref name cannot be empty")
+	}
+	return nil
+}
+
+type FetchRefOutput struct {
+	SHA sha.SHA
+}
+
+// FetchRef fetches a branch of another repository (e.g. a fork) into a reference of the repository.
+// The reference is force updated, and all missing objects are copied into the repository.
+func (s *Service) FetchRef(ctx context.Context, params FetchRefParams) (FetchRefOutput, error) {
+	if err := params.Validate(); err != nil {
+		return FetchRefOutput{}, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	reference, err := GetRefPath(params.Name, params.Type)
+	if err != nil {
+		return FetchRefOutput{}, fmt.Errorf("FetchRef: failed to get reference '%s': %w", params.Name, err)
+	}
+
+	refSpec := "+" + api.BranchPrefix + params.SourceBranch + ":" + reference
+
+	err = s.git.Sync(ctx, repoPath, s.repoPath(params.SourceRepoUID), []string{refSpec})
+	if err != nil {
+		return FetchRefOutput{}, fmt.Errorf("failed to fetch branch '%s': %w", params.SourceBranch, err)
+	}
+
+	refSHA, err := s.git.GetRef(ctx, repoPath, reference)
+	if err != nil {
+		return FetchRefOutput{}, fmt.Errorf("failed to get fetched reference: %w", err)
+	}
+
+	return FetchRefOutput{SHA: refSHA}, nil
+}
+
 func GetRefPath(refName string, refType enum.RefType) (string, error) {
 	const (
 		refPullReqPrefix      = "refs/pullreq/"
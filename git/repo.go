@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/sha"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rs/zerolog/log"
@@ -66,6 +67,12 @@ type CreateRepositoryParams struct {
 	DefaultBranch string
 	Files         []File
 
+	// StoragePool is the name of the storage pool the repository is created in (optional, default: default pool).
+	StoragePool string
+
+	// ObjectFormat is the hash algorithm used by the new repository (optional, default: sha1).
+	ObjectFormat sha.ObjectFormat
+
 	// Committer overwrites the git committer used for committing the files
 	// (optional, default: actor)
 	Committer *Identity
@@ -81,6 +88,12 @@ type CreateRepositoryParams struct {
 }
 
 func (p *CreateRepositoryParams) Validate() error {
+	if p.ObjectFormat != "" {
+		if _, err := sha.ParseObjectFormat(string(p.ObjectFormat)); err != nil {
+			return err
+		}
+	}
+
 	return p.Actor.Validate()
 }
 
@@ -109,6 +122,13 @@ type SyncRepositoryParams struct {
 	// RefSpecs [OPTIONAL] allows to override the refspecs that are being synced from the remote repository.
 	// By default all references present on the remote repository will be fetched (including scm internal ones).
 	RefSpecs []string
+
+	// StoragePool [OPTIONAL] is the name of the storage pool used in case the repository has to be created.
+	StoragePool string
+
+	// ObjectFormat [OPTIONAL] is the hash algorithm used in case the repository has to be created.
+	// It has to match the object format of the remote repository (default: sha1).
+	ObjectFormat sha.ObjectFormat
 }
 
 type SyncRepositoryOutput struct {
@@ -181,7 +201,9 @@ func (s *Service) CreateRepository(
 	err := s.createRepositoryInternal(
 		ctx,
 		&writeParams,
+		params.StoragePool,
 		params.DefaultBranch,
+		params.ObjectFormat,
 		params.Files,
 		&committer,
 		committerDate,
@@ -205,7 +227,7 @@ func (s *Service) DeleteRepository(ctx context.Context, params *DeleteRepository
 	if err := params.Validate(); err != nil {
 		return err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	if _, err := os.Stat(repoPath); err != nil && os.IsNotExist(err) {
 		return errors.NotFound("repository path not found")
@@ -216,14 +238,25 @@ func (s *Service) DeleteRepository(ctx context.Context, params *DeleteRepository
 	return s.DeleteRepositoryBestEffort(ctx, params.RepoUID)
 }
 
+// RepositoryExists returns whether the git repository exists in any of the storage pools.
+func (s *Service) RepositoryExists(_ context.Context, params ReadParams) (bool, error) {
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+
+	return exists(s.repoPath(params.RepoUID)), nil
+}
+
 func (s *Service) DeleteRepositoryBestEffort(ctx context.Context, repoUID string) error {
-	repoPath := getFullPathForRepo(s.reposRoot, repoUID)
-	tempPath := path.Join(s.reposGraveyard, repoUID)
+	// the graveyard of the repo's storage pool is used, as repos can't be moved across file systems.
+	pool, _ := s.storagePool(s.repoStoragePool(repoUID))
+	repoPath := getFullPathForRepo(pool.reposRoot, repoUID)
+	tempPath := path.Join(pool.reposGraveyard, repoUID)
 
 	// delete should not fail if repoGraveyard dir does not exist.
-	if _, err := os.Stat(s.reposGraveyard); os.IsNotExist(err) {
-		if errdir := os.MkdirAll(s.reposGraveyard, fileMode700); errdir != nil {
-			return fmt.Errorf("clean up dir '%s' doesn't exist and can't be created: %w", s.reposGraveyard, errdir)
+	if _, err := os.Stat(pool.reposGraveyard); os.IsNotExist(err) {
+		if errdir := os.MkdirAll(pool.reposGraveyard, fileMode700); errdir != nil {
+			return fmt.Errorf("clean up dir '%s' doesn't exist and can't be created: %w", pool.reposGraveyard, errdir)
 		}
 	}
 	// move current dir to a temp dir (prevent partial deletion)
@@ -231,6 +264,8 @@ func (s *Service) DeleteRepositoryBestEffort(ctx context.Context, repoUID string
 		return fmt.Errorf("couldn't move dir %s to %s : %w", repoPath, tempPath, err)
 	}
 
+	s.repoPools.Delete(repoUID)
+
 	if err := os.RemoveAll(tempPath); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete dir %s from graveyard", tempPath)
 	}
@@ -245,7 +280,7 @@ func (s *Service) SyncRepository(
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	// create repo if requested
 	_, err := os.Stat(repoPath)
@@ -264,7 +299,9 @@ func (s *Service) SyncRepository(
 		if err = s.createRepositoryInternal(
 			ctx,
 			&params.WriteParams,
+			params.StoragePool,
 			syncDefaultBranch,
+			params.ObjectFormat,
 			nil,
 			nil,
 			time.Time{},
@@ -308,7 +345,7 @@ func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryPara
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	// add all references of the repo to the channel in a separate go routine, to allow streamed processing.
 	// Ensure we cancel the go routine in case we exit the func early.
@@ -384,7 +421,9 @@ func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryPara
 func (s *Service) createRepositoryInternal(
 	ctx context.Context,
 	base *WriteParams,
+	storagePool string,
 	defaultBranch string,
+	objectFormat sha.ObjectFormat,
 	files []File,
 	committer *Identity,
 	committerDate time.Time,
@@ -392,16 +431,29 @@ func (s *Service) createRepositoryInternal(
 	authorDate time.Time,
 ) error {
 	log := log.Ctx(ctx)
-	repoPath := getFullPathForRepo(s.reposRoot, base.RepoUID)
+	if existingPath := s.repoPath(base.RepoUID); exists(existingPath) {
+		return errors.Conflict("repository already exists at path %q", existingPath)
+	}
+
+	pool, err := s.storagePool(storagePool)
+	if err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(pool.reposRoot, base.RepoUID)
 	if _, err := os.Stat(repoPath); !os.IsNotExist(err) {
 		return errors.Conflict("repository already exists at path %q", repoPath)
 	}
 
+	if storagePool != DefaultStoragePool {
+		s.repoPools.Store(base.RepoUID, storagePool)
+	}
+
 	// create repository in repos folder
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	err := s.git.InitRepository(ctx, repoPath, true)
+	err = s.git.InitRepository(ctx, repoPath, true, objectFormat)
 	// delete repo dir on error
 	defer func() {
 		if err != nil {
@@ -504,7 +556,7 @@ func (s *Service) GetRepositorySize(
 	ctx context.Context,
 	params *GetRepositorySizeParams,
 ) (*GetRepositorySizeOutput, error) {
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	count, err := s.git.CountObjects(ctx, repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count objects for repo: %w", err)
@@ -527,7 +579,7 @@ func (s *Service) UpdateDefaultBranch(
 		return errors.InvalidArgument(err.Error())
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	err := s.git.SetDefaultBranch(ctx, repoPath, params.BranchName, false)
 	if err != nil {
@@ -557,7 +609,7 @@ func (s *Service) Archive(ctx context.Context, params ArchiveParams, w io.Writer
 	if err := params.Validate(); err != nil {
 		return err
 	}
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	err := s.git.Archive(ctx, repoPath, params.ArchiveParams, w)
 	if err != nil {
 		return fmt.Errorf("failed to run git archive: %w", err)
@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// errRevertConflict is used to error out of sharedrepo Run method without failing the revert.
+var errRevertConflict = errors.New("revert conflict")
+
+// RevertParams is input structure object for the revert operation.
+type RevertParams struct {
+	WriteParams
+
+	// ParentCommitSHA is the commit on top of which the revert commit is created.
+	ParentCommitSHA sha.SHA
+
+	// FromCommitSHA and ToCommitSHA define the reverted changes:
+	// all changes introduced between the two commits are undone.
+	FromCommitSHA sha.SHA
+	ToCommitSHA   sha.SHA
+
+	// RevertBranch is the name of the new branch that points to the revert commit. It must not exist.
+	RevertBranch string
+
+	Message string
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the files
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the files
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+
+	// DryRun only checks whether the changes can be reverted, the revert branch isn't created.
+	DryRun bool
+}
+
+func (p *RevertParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.ParentCommitSHA.IsEmpty() {
+		return errors.InvalidArgument("parent commit SHA is mandatory")
+	}
+
+	if p.FromCommitSHA.IsEmpty() || p.ToCommitSHA.IsEmpty() {
+		return errors.InvalidArgument("from and to commit SHAs are mandatory")
+	}
+
+	if p.RevertBranch == "" {
+		return errors.InvalidArgument("revert branch is mandatory")
+	}
+
+	if strings.TrimSpace(p.Message) == "" {
+		return errors.InvalidArgument("revert commit message is mandatory")
+	}
+
+	return nil
+}
+
+// RevertOutput is the result of the revert operation.
+type RevertOutput struct {
+	// CommitSHA is the sha of the revert commit. It's sha.None if the revert is blocked by a conflict.
+	CommitSHA     sha.SHA
+	ConflictFiles []string
+}
+
+// Revert creates a commit on top of the parent commit that undoes the changes between the from and to commits,
+// and creates a new branch pointing to it. If the changes can't be reverted because of conflicts,
+// no branch is created and the output contains the conflicting files.
+func (s *Service) Revert(ctx context.Context, params *RevertParams) (RevertOutput, error) {
+	if err := params.Validate(); err != nil {
+		return RevertOutput{}, err
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+
+	branchRef := api.GetReferenceFromBranchName(params.RevertBranch)
+
+	_, err := s.git.GetFullCommitID(ctx, repoPath, branchRef)
+	if err == nil {
+		return RevertOutput{}, errors.Conflict("branch %q already exists", params.RevertBranch)
+	}
+	if !errors.IsNotFound(err) {
+		return RevertOutput{}, fmt.Errorf("failed to resolve branch %q: %w", params.RevertBranch, err)
+	}
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+	if params.Author != nil {
+		author.Identity = api.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	var refUpdater *hook.RefUpdater
+	if !params.DryRun {
+		var objectFormat sha.ObjectFormat
+		objectFormat, err = s.git.GetObjectFormat(ctx, repoPath)
+		if err != nil {
+			return RevertOutput{}, fmt.Errorf("failed to get object format of repository: %w", err)
+		}
+
+		refUpdater, err = hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, branchRef)
+		if err != nil {
+			return RevertOutput{}, errors.Internal(err, "failed to create ref updater object")
+		}
+
+		if err := refUpdater.InitOld(ctx, objectFormat.Nil()); err != nil {
+			return RevertOutput{}, errors.Internal(err, "failed to set old reference value for ref updater")
+		}
+	}
+
+	output := RevertOutput{
+		CommitSHA: sha.None,
+	}
+
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		// merging with the "to" commit as merge base and the "from" commit as source
+		// applies the inverse of the changes between the two commits.
+		treeSHA, conflicts, err := r.MergeTree(ctx, params.ToCommitSHA, params.ParentCommitSHA, params.FromCommitSHA)
+		if err != nil {
+			return fmt.Errorf("failed to merge tree for revert: %w", err)
+		}
+		if len(conflicts) > 0 {
+			output.ConflictFiles = conflicts
+			return errRevertConflict
+		}
+
+		parentTreeSHA, err := r.GetTreeSHA(ctx, params.ParentCommitSHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha of parent commit: %w", err)
+		}
+
+		if treeSHA.Equal(parentTreeSHA) {
+			return errors.InvalidArgument("the changes are already reverted")
+		}
+
+		commitSHA, err := r.CommitTree(ctx, &author, &committer, treeSHA, params.Message, false,
+			params.ParentCommitSHA)
+		if err != nil {
+			return fmt.Errorf("failed to commit tree for revert: %w", err)
+		}
+
+		output.CommitSHA = commitSHA
+
+		if refUpdater == nil {
+			return nil
+		}
+
+		if err := refUpdater.InitNew(ctx, commitSHA); err != nil {
+			return fmt.Errorf("refUpdater.InitNew failed: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, errRevertConflict) {
+		return output, nil
+	}
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to revert changes onto branch %q: %w", params.RevertBranch, err)
+	}
+
+	return output, nil
+}
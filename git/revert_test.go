@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestService_Revert(t *testing.T) {
+	ctx := context.Background()
+
+	config := types.Config{
+		Root:   t.TempDir(),
+		TmpDir: t.TempDir(),
+	}
+
+	gitAPI, err := api.New(config, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git api: %s", err)
+	}
+
+	s, err := New(config, gitAPI, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %s", err)
+	}
+
+	const repoUID = "revertrepo"
+	repoPath := s.repoPath(repoUID)
+	runGit(t, "", "init", "--bare", "--initial-branch=main", repoPath)
+
+	workDir := t.TempDir()
+	runGit(t, workDir, "init", "--initial-branch=main")
+
+	commitFile := func(name, content, msg string) string {
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+		runGit(t, workDir, "add", name)
+		runGit(t, workDir, "commit", "-m", msg)
+		return runGit(t, workDir, "rev-parse", "HEAD")
+	}
+
+	base := commitFile("a.txt", "base\n", "base")
+	addB := commitFile("b.txt", "b\n", "add b")
+	changeA := commitFile("a.txt", "changed\n", "change a")
+	head := commitFile("a.txt", "changed again\n", "change a again")
+
+	runGit(t, workDir, "push", repoPath, "main")
+
+	params := func(from, to string) *RevertParams {
+		return &RevertParams{
+			WriteParams: WriteParams{
+				RepoUID: repoUID,
+				Actor:   Identity{Name: "test", Email: "test@example.com"},
+			},
+			ParentCommitSHA: sha.Must(head),
+			FromCommitSHA:   sha.Must(from),
+			ToCommitSHA:     sha.Must(to),
+			RevertBranch:    "revert",
+			Message:         "revert",
+			DryRun:          true,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		out, err := s.Revert(ctx, params(base, addB))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(out.ConflictFiles) > 0 {
+			t.Fatalf("expected no conflicts, got %v", out.ConflictFiles)
+		}
+		if out.CommitSHA.IsEmpty() {
+			t.Fatalf("expected revert commit")
+		}
+
+		files := runGit(t, "", "--git-dir", repoPath, "ls-tree", "--name-only", out.CommitSHA.String())
+		if files != "a.txt" {
+			t.Errorf("expected only a.txt in the reverted tree, got %q", files)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		out, err := s.Revert(ctx, params(addB, changeA))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(out.ConflictFiles) != 1 || out.ConflictFiles[0] != "a.txt" {
+			t.Errorf("expected conflict in a.txt, got %v", out.ConflictFiles)
+		}
+		if !out.CommitSHA.IsEmpty() {
+			t.Errorf("expected no revert commit on conflict, got %s", out.CommitSHA)
+		}
+	})
+
+	t.Run("branch-exists", func(t *testing.T) {
+		p := params(base, addB)
+		p.RevertBranch = "main"
+
+		_, err := s.Revert(ctx, p)
+		if !errors.IsConflict(err) {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+	})
+}
@@ -75,7 +75,7 @@ func (s *Service) ScanSecrets(ctx context.Context, params *ScanSecretsParams) (*
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	var findings []ScanSecretsFinding
 	err := sharedrepo.Run(ctx, nil, s.tmpDir, repoPath, func(sharedRepo *sharedrepo.SharedRepo) error {
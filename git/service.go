@@ -15,8 +15,10 @@
 package git
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
@@ -38,6 +40,11 @@ type Service struct {
 	store             storage.Store
 	gitHookPath       string
 	reposGraveyard    string
+
+	// storagePools holds the additional storage pools, the default pool is represented by reposRoot.
+	storagePools map[string]storagePool
+	// repoPools caches the storage pool of repositories, in case storage pools are configured.
+	repoPools sync.Map
 }
 
 func New(
@@ -62,6 +69,21 @@ func New(
 			return nil, errdir
 		}
 	}
+
+	storagePools := make(map[string]storagePool, len(config.StoragePools))
+	for name, root := range config.StoragePools {
+		if name == DefaultStoragePool || root == "" {
+			return nil, fmt.Errorf("invalid storage pool %q with root %q", name, root)
+		}
+
+		pool, err := newStoragePool(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up storage pool %q: %w", name, err)
+		}
+
+		storagePools[name] = pool
+	}
+
 	return &Service{
 		reposRoot:         reposRoot,
 		tmpDir:            config.TmpDir,
@@ -70,5 +92,6 @@ func New(
 		hookClientFactory: hookClientFactory,
 		store:             storage,
 		gitHookPath:       config.HookPath,
+		storagePools:      storagePools,
 	}, nil
 }
@@ -41,7 +41,7 @@ func (s *Service) GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefs
 		environ = append(environ, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	err := s.git.InfoRefs(ctx, repoPath, params.Service, w, environ...)
 	if err != nil {
 		return fmt.Errorf("failed to fetch info references: %w", err)
@@ -72,13 +72,13 @@ func (s *Service) ServicePack(ctx context.Context, params *ServicePackParams) er
 		if err := params.ReadParams.Validate(); err != nil {
 			return errors.InvalidArgument("upload-pack requires ReadParams")
 		}
-		repoPath = getFullPathForRepo(s.reposRoot, params.ReadParams.RepoUID)
+		repoPath = s.repoPath(params.ReadParams.RepoUID)
 	case enum.GitServiceTypeReceivePack:
 		if err := params.WriteParams.Validate(); err != nil {
 			return errors.InvalidArgument("receive-pack requires WriteParams")
 		}
 		params.Env = append(params.Env, CreateEnvironmentForPush(ctx, *params.WriteParams)...)
-		repoPath = getFullPathForRepo(s.reposRoot, params.WriteParams.RepoUID)
+		repoPath = s.repoPath(params.WriteParams.RepoUID)
 	default:
 		return errors.InvalidArgument("unsupported service provided: %s", params.Service)
 	}
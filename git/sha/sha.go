@@ -27,19 +27,68 @@ import (
 	"github.com/swaggest/jsonschema-go"
 )
 
+// ObjectFormat is the hash algorithm used by a git repository to address its objects.
+type ObjectFormat string
+
+const (
+	ObjectFormatSHA1   ObjectFormat = "sha1"
+	ObjectFormatSHA256 ObjectFormat = "sha256"
+)
+
 var (
-	Nil  = Must("0000000000000000000000000000000000000000")
-	None = SHA{}
+	// Nil is the all-zeroes SHA of a SHA-1 repository.
+	Nil = Must("0000000000000000000000000000000000000000")
+	// Nil256 is the all-zeroes SHA of a SHA-256 repository.
+	Nil256 = Must("0000000000000000000000000000000000000000000000000000000000000000")
+	None   = SHA{}
 	// regex defines the valid SHA format accepted by GIT (full form and short forms).
-	// Note: as of now SHA is at most 40 characters long, but in the future it's moving to sha256
-	// which is 64 chars - keep this forward-compatible.
+	// Full SHAs are 40 characters long for sha1 and 64 characters long for sha256 repositories.
 	regex    = regexp.MustCompile("^[0-9a-f]{4,64}$")
 	nilRegex = regexp.MustCompile("^0{4,64}$")
 
-	// EmptyTree is the SHA of an empty tree.
+	// EmptyTree is the SHA of an empty tree in a SHA-1 repository.
 	EmptyTree = Must("4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+	// EmptyTree256 is the SHA of an empty tree in a SHA-256 repository.
+	EmptyTree256 = Must("6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321")
 )
 
+// ParseObjectFormat parses the provided object format, an empty value defaults to sha1.
+func ParseObjectFormat(value string) (ObjectFormat, error) {
+	switch ObjectFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ObjectFormatSHA1:
+		return ObjectFormatSHA1, nil
+	case ObjectFormatSHA256:
+		return ObjectFormatSHA256, nil
+	default:
+		return "", errors.InvalidArgument("object format '%s' is not supported, use '%s' or '%s'.",
+			value, ObjectFormatSHA1, ObjectFormatSHA256)
+	}
+}
+
+// HexLength returns the length of a full hex encoded SHA of the object format.
+func (f ObjectFormat) HexLength() int {
+	if f == ObjectFormatSHA256 {
+		return 64
+	}
+	return 40
+}
+
+// Nil returns the all-zeroes SHA of the object format.
+func (f ObjectFormat) Nil() SHA {
+	if f == ObjectFormatSHA256 {
+		return Nil256
+	}
+	return Nil
+}
+
+// EmptyTree returns the SHA of an empty tree of the object format.
+func (f ObjectFormat) EmptyTree() SHA {
+	if f == ObjectFormatSHA256 {
+		return EmptyTree256
+	}
+	return EmptyTree
+}
+
 // SHA represents a git sha.
 type SHA struct {
 	str string
@@ -114,6 +163,20 @@ func (s SHA) IsNil() bool {
 	return nilRegex.MatchString(s.str)
 }
 
+// IsFull returns whether this SHA is a full (non-abbreviated) SHA of any supported object format.
+func (s SHA) IsFull() bool {
+	return len(s.str) == ObjectFormatSHA1.HexLength() || len(s.str) == ObjectFormatSHA256.HexLength()
+}
+
+// ObjectFormat returns the object format of a full SHA, based on its length.
+// Abbreviated SHAs are reported as sha1.
+func (s SHA) ObjectFormat() ObjectFormat {
+	if len(s.str) == ObjectFormatSHA256.HexLength() {
+		return ObjectFormatSHA256
+	}
+	return ObjectFormatSHA1
+}
+
 // IsEmpty returns whether this SHA is empty string.
 func (s SHA) IsEmpty() bool {
 	return s.str == ""
@@ -90,3 +90,87 @@ func TestSHA_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestParseObjectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ObjectFormat
+		wantErr bool
+	}{
+		{
+			name:  "empty defaults to sha1",
+			input: "",
+			want:  ObjectFormatSHA1,
+		},
+		{
+			name:  "sha1",
+			input: "sha1",
+			want:  ObjectFormatSHA1,
+		},
+		{
+			name:  "sha256 with whitespace and upper case",
+			input: " SHA256\n",
+			want:  ObjectFormatSHA256,
+		},
+		{
+			name:    "unknown format",
+			input:   "md5",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseObjectFormat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseObjectFormat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseObjectFormat() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSHA_ObjectFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  SHA
+		want   ObjectFormat
+		isFull bool
+	}{
+		{
+			name:   "sha1 empty tree",
+			input:  EmptyTree,
+			want:   ObjectFormatSHA1,
+			isFull: true,
+		},
+		{
+			name:   "sha256 empty tree",
+			input:  EmptyTree256,
+			want:   ObjectFormatSHA256,
+			isFull: true,
+		},
+		{
+			name:   "abbreviated",
+			input:  Must("4b825dc"),
+			want:   ObjectFormatSHA1,
+			isFull: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.input.ObjectFormat(); got != tt.want {
+				t.Errorf("ObjectFormat() got = %s, want %s", got, tt.want)
+			}
+			if got := tt.input.IsFull(); got != tt.isFull {
+				t.Errorf("IsFull() got = %t, want %t", got, tt.isFull)
+			}
+		})
+	}
+
+	if !ObjectFormatSHA256.Nil().IsNil() || len(ObjectFormatSHA256.Nil().String()) != 64 {
+		t.Errorf("unexpected sha256 nil value %s", ObjectFormatSHA256.Nil())
+	}
+}
@@ -81,7 +81,16 @@ func (r *SharedRepo) Close(ctx context.Context) {
 }
 
 func (r *SharedRepo) Init(ctx context.Context, alternates ...string) error {
-	cmd := command.New("init", command.WithFlag("--bare"))
+	// the shared repository must use the same object format as the source repository,
+	// otherwise objects from the alternates can't be read.
+	objectFormat, err := api.GetObjectFormat(ctx, r.sourceRepoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get object format of the source repository: %w", err)
+	}
+
+	cmd := command.New("init",
+		command.WithFlag("--bare"),
+		command.WithFlag("--object-format="+string(objectFormat)))
 
 	if err := cmd.Run(ctx, command.WithDir(r.repoPath)); err != nil {
 		return fmt.Errorf("failed to initialize bare git repository directory: %w", err)
@@ -290,6 +299,33 @@ func (r *SharedRepo) AddObjectToIndex(
 	return nil
 }
 
+// ApplyPatch applies the provided patch (output of git diff or git format-patch) to the git index.
+func (r *SharedRepo) ApplyPatch(
+	ctx context.Context,
+	patch io.Reader,
+) error {
+	cmd := command.New("apply",
+		command.WithFlag("--cached"),
+		command.WithFlag("--whitespace=nowarn"),
+		command.WithArg("-"))
+
+	stderr := bytes.NewBuffer(nil)
+
+	err := cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdin(patch),
+		command.WithStderr(stderr))
+	if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.ExitCode() > 0 {
+		// git apply exits with a non-zero exit code if the patch is malformed or doesn't apply cleanly.
+		return errors.InvalidArgument("failed to apply patch: %s", strings.TrimSpace(stderr.String()))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply patch in shared repo: %w", err)
+	}
+
+	return nil
+}
+
 // WriteTree writes the current index as a tree to the object db and returns its hash.
 func (r *SharedRepo) WriteTree(ctx context.Context) (sha.SHA, error) {
 	cmd := command.New("write-tree")
@@ -450,6 +486,43 @@ func (r *SharedRepo) CommitSHAsForRebase(
 	return commitSHAs, nil
 }
 
+// CommitSHAsForCherryPick returns the list of non-merge commits in the range (from, to], oldest first.
+// If from is empty, only the commit to is returned.
+func (r *SharedRepo) CommitSHAsForCherryPick(
+	ctx context.Context,
+	from, to sha.SHA,
+) ([]sha.SHA, error) {
+	revRange := to.String() + "^!"
+	if !from.IsEmpty() {
+		revRange = from.String() + ".." + to.String()
+	}
+
+	cmd := command.New("rev-list",
+		command.WithFlag("--max-parents=1"), // exclude merge commits
+		command.WithFlag("--reverse"),
+		command.WithFlag("--topo-order"),
+		command.WithArg(revRange))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(r.repoPath), command.WithStdout(stdout)); err != nil {
+		return nil, fmt.Errorf("failed to rev-list in shared repo: %w", err)
+	}
+
+	var commitSHAs []sha.SHA
+
+	scan := bufio.NewScanner(stdout)
+	for scan.Scan() {
+		commitSHA := sha.Must(scan.Text())
+		commitSHAs = append(commitSHAs, commitSHA)
+	}
+	if err := scan.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rev-list output in shared repo: %w", err)
+	}
+
+	return commitSHAs, nil
+}
+
 // MergeBase returns number of commits between the two git revisions.
 func (r *SharedRepo) MergeBase(
 	ctx context.Context,
@@ -0,0 +1,335 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultStoragePool is the name of the storage pool located in the git root directory.
+	DefaultStoragePool = ""
+
+	storagePoolStagingSubdirName = "staging"
+)
+
+// storagePool is a root directory in which repositories are stored.
+type storagePool struct {
+	reposRoot      string
+	reposGraveyard string
+	reposStaging   string
+}
+
+func newStoragePool(root string) (storagePool, error) {
+	pool := storagePool{
+		reposRoot:      filepath.Join(root, repoSubdirName),
+		reposGraveyard: filepath.Join(root, ReposGraveyardSubdirName),
+		reposStaging:   filepath.Join(root, storagePoolStagingSubdirName),
+	}
+
+	for _, dir := range []string{pool.reposRoot, pool.reposGraveyard, pool.reposStaging} {
+		if err := os.MkdirAll(dir, fileMode700); err != nil {
+			return storagePool{}, fmt.Errorf("failed to create directory %q: %w", dir, err)
+		}
+	}
+
+	return pool, nil
+}
+
+type MoveRepositoryParams struct {
+	WriteParams
+	// StoragePool is the name of the storage pool the repository is moved to.
+	StoragePool string
+}
+
+// storagePool returns the storage pool with the provided name.
+func (s *Service) storagePool(name string) (storagePool, error) {
+	if name == DefaultStoragePool {
+		return storagePool{
+			reposRoot:      s.reposRoot,
+			reposGraveyard: s.reposGraveyard,
+			reposStaging:   filepath.Join(filepath.Dir(s.reposRoot), storagePoolStagingSubdirName),
+		}, nil
+	}
+
+	pool, ok := s.storagePools[name]
+	if !ok {
+		return storagePool{}, errors.InvalidArgument("unknown storage pool %q", name)
+	}
+
+	return pool, nil
+}
+
+// repoStoragePool returns the name of the storage pool containing the repository.
+// Repositories that don't exist are reported to be in the default storage pool.
+func (s *Service) repoStoragePool(repoUID string) string {
+	if len(s.storagePools) == 0 {
+		return DefaultStoragePool
+	}
+
+	// the cached pool is verified as the repository could have been moved by a different instance.
+	if name, ok := s.repoPools.Load(repoUID); ok {
+		if pool, err := s.storagePool(name.(string)); err == nil && exists(getFullPathForRepo(pool.reposRoot, repoUID)) {
+			return name.(string)
+		}
+		s.repoPools.Delete(repoUID)
+	}
+
+	names := make([]string, 0, len(s.storagePools)+1)
+	names = append(names, DefaultStoragePool)
+	for name := range s.storagePools {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+
+	for _, name := range names {
+		pool, _ := s.storagePool(name)
+		if exists(getFullPathForRepo(pool.reposRoot, repoUID)) {
+			s.repoPools.Store(repoUID, name)
+			return name
+		}
+	}
+
+	return DefaultStoragePool
+}
+
+// repoPath returns the full path of the repository within its storage pool.
+func (s *Service) repoPath(repoUID string) string {
+	if len(s.storagePools) == 0 {
+		return getFullPathForRepo(s.reposRoot, repoUID)
+	}
+
+	pool, _ := s.storagePool(s.repoStoragePool(repoUID))
+
+	return getFullPathForRepo(pool.reposRoot, repoUID)
+}
+
+// StageRepositoryMove copies the repository into the staging area of the target storage pool.
+// The repository stays fully usable while it's being copied. Calling it again for an already staged
+// repository only copies the changes done since the last call.
+// It's a no-op in case the repository is already located in the target storage pool.
+func (s *Service) StageRepositoryMove(ctx context.Context, params *MoveRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	target, err := s.storagePool(params.StoragePool)
+	if err != nil {
+		return err
+	}
+
+	if s.repoStoragePool(params.RepoUID) == params.StoragePool {
+		return nil
+	}
+
+	repoPath := s.repoPath(params.RepoUID)
+	if !exists(repoPath) {
+		return errors.NotFound("repository path not found")
+	}
+
+	stagingPath := filepath.Join(target.reposStaging, params.RepoUID)
+
+	if !exists(stagingPath) {
+		err = func() error {
+			// references are copied first, so all objects they point to are copied afterwards.
+			if err := copyDir(ctx, repoPath, stagingPath, func(rel string) bool { return rel != "objects" }); err != nil {
+				return err
+			}
+			return copyDir(ctx, filepath.Join(repoPath, "objects"), filepath.Join(stagingPath, "objects"), nil)
+		}()
+		if err != nil {
+			removeBestEffort(ctx, stagingPath)
+			return fmt.Errorf("failed to copy repository to staging area: %w", err)
+		}
+	}
+
+	// catch up with changes done to the repository while it was being copied.
+	if err = s.git.Sync(ctx, stagingPath, repoPath, nil); err != nil {
+		return fmt.Errorf("failed to sync staged repository: %w", err)
+	}
+
+	// objects could have been repacked while being copied - start over in case anything is missing.
+	if err = s.git.CheckConnectivity(ctx, stagingPath); err != nil {
+		removeBestEffort(ctx, stagingPath)
+		return fmt.Errorf("staged repository is incomplete: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteRepositoryMove syncs the staged repository one last time and replaces the original repository with it.
+// IMPORTANT: The caller has to ensure that the repository isn't modified while the move is completed.
+// It's a no-op in case the repository is already located in the target storage pool.
+func (s *Service) CompleteRepositoryMove(ctx context.Context, params *MoveRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	target, err := s.storagePool(params.StoragePool)
+	if err != nil {
+		return err
+	}
+
+	sourceName := s.repoStoragePool(params.RepoUID)
+	if sourceName == params.StoragePool {
+		return nil
+	}
+
+	source, _ := s.storagePool(sourceName)
+
+	repoPath := getFullPathForRepo(source.reposRoot, params.RepoUID)
+	stagingPath := filepath.Join(target.reposStaging, params.RepoUID)
+	targetPath := getFullPathForRepo(target.reposRoot, params.RepoUID)
+
+	if !exists(stagingPath) {
+		return errors.PreconditionFailed("move of repository to storage pool %q wasn't staged", params.StoragePool)
+	}
+	if exists(targetPath) {
+		return errors.Conflict("repository already exists at path %q", targetPath)
+	}
+
+	if err = s.git.Sync(ctx, stagingPath, repoPath, nil); err != nil {
+		return fmt.Errorf("failed to sync staged repository: %w", err)
+	}
+
+	// the default branch and the config aren't covered by the sync.
+	for _, file := range []string{"HEAD", "config"} {
+		if err = copyFile(filepath.Join(repoPath, file), filepath.Join(stagingPath, file)); err != nil {
+			return fmt.Errorf("failed to copy %s file of repository: %w", file, err)
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(targetPath), fileMode700); err != nil {
+		return fmt.Errorf("failed to create directory of repository in storage pool: %w", err)
+	}
+
+	// move the original repository out of the way first to avoid having it in two pools at the same time.
+	graveyardPath := filepath.Join(source.reposGraveyard, params.RepoUID)
+	if err = os.Rename(repoPath, graveyardPath); err != nil {
+		return fmt.Errorf("failed to move repository %s to %s: %w", repoPath, graveyardPath, err)
+	}
+
+	if err = os.Rename(stagingPath, targetPath); err != nil {
+		if errRestore := os.Rename(graveyardPath, repoPath); errRestore != nil {
+			log.Ctx(ctx).Error().Err(errRestore).Msgf("failed to restore repository %s", repoPath)
+		}
+		return fmt.Errorf("failed to move staged repository %s to %s: %w", stagingPath, targetPath, err)
+	}
+
+	s.repoPools.Store(params.RepoUID, params.StoragePool)
+
+	removeBestEffort(ctx, graveyardPath)
+
+	log.Ctx(ctx).Info().Msgf("repository %s moved to storage pool %q", params.RepoUID, params.StoragePool)
+
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func removeBestEffort(ctx context.Context, path string) {
+	if err := os.RemoveAll(path); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete dir %s", path)
+	}
+}
+
+// copyDir recursively copies the content of the src directory to the dst directory.
+// Files that disappear while the directory is being copied are skipped.
+// If provided, only entries of the src directory for which the include function returns true are copied.
+func copyDir(ctx context.Context, src, dst string, include func(rel string) bool) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		if include != nil && rel != "." && !include(rel) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(target, fileMode700)
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			err = copyFile(path, target)
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
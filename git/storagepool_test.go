@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestService_MoveRepositoryToStoragePool(t *testing.T) {
+	ctx := context.Background()
+
+	poolRoot := t.TempDir()
+	config := types.Config{
+		Root:         t.TempDir(),
+		StoragePools: map[string]string{"eu": poolRoot},
+	}
+
+	gitAPI, err := api.New(config, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git api: %s", err)
+	}
+
+	s, err := New(config, gitAPI, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %s", err)
+	}
+
+	const repoUID = "abcdefghijkl"
+	repoPath := s.repoPath(repoUID)
+	runGit(t, "", "init", "--bare", "--initial-branch=main", repoPath)
+
+	workDir := t.TempDir()
+	runGit(t, workDir, "init", "--initial-branch=main")
+	commitAndPush := func(msg string) string {
+		runGit(t, workDir, "commit", "--allow-empty", "-m", msg)
+		runGit(t, workDir, "push", repoPath, "main")
+		return runGit(t, workDir, "rev-parse", "HEAD")
+	}
+
+	commitAndPush("first")
+
+	params := &MoveRepositoryParams{
+		WriteParams: WriteParams{
+			RepoUID: repoUID,
+			Actor:   Identity{Name: "test", Email: "test@example.com"},
+		},
+		StoragePool: "eu",
+	}
+
+	if err = s.StageRepositoryMove(ctx, params); err != nil {
+		t.Fatalf("failed to stage repository move: %s", err)
+	}
+
+	// changes done after the repository got staged must not get lost.
+	head := commitAndPush("second")
+
+	if err = s.CompleteRepositoryMove(ctx, params); err != nil {
+		t.Fatalf("failed to complete repository move: %s", err)
+	}
+
+	if _, err = os.Stat(repoPath); !os.IsNotExist(err) {
+		t.Errorf("expected original repository to be removed, got: %v", err)
+	}
+
+	wantPath := getFullPathForRepo(filepath.Join(poolRoot, repoSubdirName), repoUID)
+	if got := s.repoPath(repoUID); got != wantPath {
+		t.Errorf("expected repository path %q, got %q", wantPath, got)
+	}
+
+	if got := runGit(t, wantPath, "rev-parse", "main"); got != head {
+		t.Errorf("expected main to point to %s, got %s", head, got)
+	}
+
+	// a service without cached storage pools has to find the repository as well.
+	s, err = New(config, gitAPI, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %s", err)
+	}
+
+	if got := s.repoStoragePool(repoUID); got != "eu" {
+		t.Errorf("expected repository in storage pool %q, got %q", "eu", got)
+	}
+
+	if err = s.StageRepositoryMove(ctx, &MoveRepositoryParams{
+		WriteParams: params.WriteParams,
+		StoragePool: "us",
+	}); err == nil {
+		t.Error("expected move to unknown storage pool to fail")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %s: %s", strings.Join(args, " "), err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
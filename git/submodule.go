@@ -39,7 +39,7 @@ func (s *Service) GetSubmodule(ctx context.Context, params *GetSubmoduleParams)
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	// TODO: do we need to validate request for nil?
 	gitSubmodule, err := s.git.GetSubmodule(ctx, repoPath, params.GitREF, params.Path)
 	if err != nil {
@@ -38,7 +38,7 @@ func (s *Service) Summary(
 	ctx context.Context,
 	params SummaryParams,
 ) (SummaryOutput, error) {
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	defaultBranch, err := s.git.GetDefaultBranch(ctx, repoPath)
 	if err != nil {
@@ -131,7 +131,7 @@ func (s *Service) ListCommitTags(
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	// get all required information from git references
 	tags, err := s.listCommitTagsLoadReferenceData(ctx, repoPath, params)
@@ -231,7 +231,7 @@ func (s *Service) CreateCommitTag(ctx context.Context, params *CreateCommitTagPa
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	targetCommit, err := s.git.GetCommit(ctx, repoPath, params.Target)
 	if errors.IsNotFound(err) {
@@ -295,7 +295,7 @@ func (s *Service) CreateCommitTag(ctx context.Context, params *CreateCommitTagPa
 			return fmt.Errorf("failed to read annotated tag after creation: %w", err)
 		}
 
-		if err := refUpdater.Init(ctx, sha.Nil, tag.Sha); err != nil {
+		if err := refUpdater.Init(ctx, tag.Sha.ObjectFormat().Nil(), tag.Sha); err != nil {
 			return fmt.Errorf("failed to init ref updater: %w", err)
 		}
 
@@ -336,7 +336,7 @@ func (s *Service) DeleteTag(ctx context.Context, params *DeleteTagParams) error
 		return err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 	tagRef := api.GetReferenceFromTagName(params.Name)
 
 	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, tagRef)
@@ -344,7 +344,7 @@ func (s *Service) DeleteTag(ctx context.Context, params *DeleteTagParams) error
 		return fmt.Errorf("failed to create ref updater to delete the tag: %w", err)
 	}
 
-	err = refUpdater.Do(ctx, sha.None, sha.Nil) // delete whatever is there
+	err = refUpdater.Do(ctx, sha.None, sha.None) // delete whatever is there
 	if errors.IsNotFound(err) {
 		return errors.NotFound("tag %q does not exist", params.Name)
 	}
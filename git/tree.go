@@ -79,7 +79,7 @@ func (s *Service) GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	gitNode, err := s.git.GetTreeNode(ctx, repoPath, params.GitREF, params.Path)
 	if err != nil {
@@ -124,7 +124,7 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	res, err := s.git.ListTreeNodes(
 		ctx,
@@ -167,7 +167,7 @@ func (s *Service) ListPaths(ctx context.Context, params *ListPathsParams) (*List
 		return nil, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	files, dirs, err := s.git.ListPaths(
 		ctx,
@@ -206,7 +206,7 @@ func (s *Service) PathsDetails(ctx context.Context, params PathsDetailsParams) (
 		return PathsDetailsOutput{}, err
 	}
 
-	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repoPath := s.repoPath(params.RepoUID)
 
 	pathsDetails, err := s.git.PathsDetails(
 		ctx,
@@ -31,6 +31,8 @@ type Config struct {
 	TmpDir string
 	// HookPath points to the binary used as git server hook.
 	HookPath string
+	// StoragePools (optional) maps the names of additional storage pools to their root directories.
+	StoragePools map[string]string
 
 	// LastCommitCache holds configuration options for the last commit cache.
 	LastCommitCache LastCommitCacheConfig
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -35,6 +36,8 @@ const (
 type File struct {
 	Path    string
 	Content []byte
+	// Executable indicates that the file is committed with the executable file mode.
+	Executable bool
 }
 
 // TODO: this should be taken as a struct input defined in proto.
@@ -109,5 +112,11 @@ func (s *Service) handleFileUploadIfAvailable(
 		return "", errors.Internal(err, "cannot save file to the store: %s", fullPath)
 	}
 
+	if file.Executable {
+		if err = os.Chmod(fullPath, 0o755); err != nil { //nolint:gosec // executable files have to be executable
+			return "", errors.Internal(err, "cannot make file executable: %s", fullPath)
+		}
+	}
+
 	return fullPath, nil
 }
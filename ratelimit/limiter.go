@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter counts the requests made for a key within fixed time windows.
+type Limiter interface {
+	// Take counts a request for the key and returns whether it's within the limit of the current window.
+	Take(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+}
+
+// Result is the state of the window of a key after a request was counted.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the current window ends and the count of the key is reset.
+	Reset time.Duration
+}
+
+func newResult(count int, limit int, reset time.Duration) Result {
+	return Result{
+		Allowed:   count <= limit,
+		Limit:     limit,
+		Remaining: max(limit-count, 0),
+		Reset:     reset,
+	}
+}
+
+func formatKey(app, key string) string {
+	return app + ":ratelimit:" + key
+}
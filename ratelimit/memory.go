@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the windows that ended are removed from memory.
+const sweepInterval = time.Minute
+
+// InMemory is a local implementation of a Limiter, it's only correct when running a single instance.
+type InMemory struct {
+	config    Config
+	mutex     sync.Mutex
+	windows   map[string]*inMemWindow
+	lastSweep time.Time
+}
+
+type inMemWindow struct {
+	count int
+	end   time.Time
+}
+
+// NewInMemory creates a new InMemory instance.
+func NewInMemory(config Config) *InMemory {
+	return &InMemory{
+		config:    config,
+		windows:   make(map[string]*inMemWindow),
+		lastSweep: time.Now(),
+	}
+}
+
+// Take counts a request for the key and returns whether it's within the limit of the current window.
+func (m *InMemory) Take(_ context.Context, key string, limit int, window time.Duration) (Result, error) {
+	now := time.Now()
+	key = formatKey(m.config.App, key)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.sweep(now)
+
+	w, ok := m.windows[key]
+	if !ok || !now.Before(w.end) {
+		w = &inMemWindow{end: now.Add(window)}
+		m.windows[key] = w
+	}
+
+	w.count++
+
+	return newResult(w.count, limit, w.end.Sub(now)), nil
+}
+
+// sweep removes the windows that ended, the caller must hold the mutex.
+func (m *InMemory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, w := range m.windows {
+		if !now.Before(w.end) {
+			delete(m.windows, key)
+		}
+	}
+
+	m.lastSweep = now
+}
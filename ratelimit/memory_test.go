@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemory_Take(t *testing.T) {
+	limiter := NewInMemory(Config{App: "gitness"})
+	ctx := context.Background()
+
+	for i := range 3 {
+		res, err := limiter.Take(ctx, "key1", 3, time.Minute)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, err := limiter.Take(ctx, "key1", 3, time.Minute)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+	require.Greater(t, res.Reset, time.Duration(0))
+
+	// other keys are counted separately.
+	res, err = limiter.Take(ctx, "key2", 3, time.Minute)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+}
+
+func TestInMemory_TakeWindowReset(t *testing.T) {
+	limiter := NewInMemory(Config{App: "gitness"})
+	ctx := context.Background()
+
+	res, err := limiter.Take(ctx, "key", 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	res, err = limiter.Take(ctx, "key", 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+
+	time.Sleep(60 * time.Millisecond)
+
+	res, err = limiter.Take(ctx, "key", 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// takeScript increments the count of the key, starts the window with the first request
+// and returns the count and the remaining time of the window in milliseconds.
+// The script runs atomically. The expiry is also set if the key has none (e.g. it was written
+// by another client or the expiry was lost during a failover), so a key can't block forever.
+var takeScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+local ttl = redis.call("PTTL", KEYS[1])
+if count == 1 or ttl == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// Redis is a Limiter that shares the counts between all instances.
+type Redis struct {
+	config Config
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis instance.
+func NewRedis(config Config, client redis.UniversalClient) *Redis {
+	return &Redis{
+		config: config,
+		client: client,
+	}
+}
+
+// Take counts a request for the key and returns whether it's within the limit of the current window.
+func (r *Redis) Take(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	key = formatKey(r.config.App, key)
+
+	res, err := takeScript.Run(ctx, r.client, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to count request in redis: %w", err)
+	}
+
+	if len(res) != 2 {
+		return Result{}, fmt.Errorf("unexpected result of rate limit script: %v", res)
+	}
+
+	reset := time.Duration(res[1]) * time.Millisecond
+	if reset < 0 {
+		reset = window
+	}
+
+	return newResult(int(res[0]), limit, reset), nil
+}
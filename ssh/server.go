@@ -199,10 +199,27 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// newSession returns the session of the principal authenticated by its public key.
+// Only the identity of the principal is copied, so flags like Admin don't grant any
+// additional permissions to git operations over SSH.
+func newSession(principal *types.Principal) *auth.Session {
+	return &auth.Session{
+		Principal: types.Principal{
+			ID:          principal.ID,
+			UID:         principal.UID,
+			Email:       principal.Email,
+			Type:        principal.Type,
+			DisplayName: principal.DisplayName,
+			Created:     principal.Created,
+			Updated:     principal.Updated,
+		},
+	}
+}
+
 func (s *Server) sessionHandler(session ssh.Session) {
 	command := session.RawCommand()
 
-	principal, ok := session.Context().Value(principalKey).(*types.PrincipalInfo)
+	principal, ok := session.Context().Value(principalKey).(*types.Principal)
 	if !ok {
 		_, _ = fmt.Fprintf(session.Stderr(), "principal not found or empty")
 		return
@@ -254,17 +271,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 
 	err = s.RepoCtrl.GitServicePack(
 		ctx,
-		&auth.Session{
-			Principal: types.Principal{
-				ID:          principal.ID,
-				UID:         principal.UID,
-				Email:       principal.Email,
-				Type:        principal.Type,
-				DisplayName: principal.DisplayName,
-				Created:     principal.Created,
-				Updated:     principal.Updated,
-			},
-		},
+		newSession(principal),
 		repoRef,
 		api.ServicePackOptions{
 			Service:  service,
@@ -320,6 +327,10 @@ func (s *Server) publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 		log.Debug().Err(err).Msg("public key is unknown")
 		return false
 	}
+	if errors.IsPreconditionFailed(err) {
+		log.Warn().Err(err).Msgf("rejected public key from %s", ctx.RemoteAddr())
+		return false
+	}
 	if err != nil {
 		log.Warn().Err(err).Msg("failed to validate public key")
 		return false
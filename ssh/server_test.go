@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestNewSession(t *testing.T) {
+	principal := &types.Principal{
+		ID:          1,
+		UID:         "admin",
+		Email:       "admin@example.com",
+		Type:        enum.PrincipalTypeUser,
+		DisplayName: "Admin",
+		Admin:       true,
+		Salt:        "salt",
+		Created:     100,
+		Updated:     200,
+	}
+
+	session := newSession(principal)
+
+	want := types.Principal{
+		ID:          1,
+		UID:         "admin",
+		Email:       "admin@example.com",
+		Type:        enum.PrincipalTypeUser,
+		DisplayName: "Admin",
+		Created:     100,
+		Updated:     200,
+	}
+	if session.Principal != want {
+		t.Errorf("principal = %+v, want %+v", session.Principal, want)
+	}
+	if session.Metadata != nil {
+		t.Errorf("expected no session metadata, got %+v", session.Metadata)
+	}
+}
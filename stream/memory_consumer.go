@@ -215,9 +215,9 @@ func (c *MemoryConsumer) consume(ctx context.Context) {
 
 				// requeue message for a retry (needs to be in a separate go func to avoid deadlock)
 				// IMPORTANT: this won't requeue to broker, only in this consumer's queue!
+				delay := handler.config.retryDelay(m.retries)
 				go func() {
-					// TODO: linear/exponential backoff relative to retry count might be good
-					time.Sleep(handler.config.idleTimeout)
+					time.Sleep(delay)
 					c.messageQueue <- m
 				}()
 			}
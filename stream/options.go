@@ -98,3 +98,15 @@ func WithIdleTimeout(timeout time.Duration) HandlerOption {
 		c.idleTimeout = timeout
 	})
 }
+
+// WithRetryBackoff can be used to enable exponential backoff for retries of a specific handler.
+// The retry delay starts at the idle timeout and doubles with every retry up to the provided max.
+func WithRetryBackoff(maxBackoff time.Duration) HandlerOption {
+	if maxBackoff < 0 {
+		// missconfiguration - panic to keep options clean
+		panic(fmt.Sprintf("provided max backoff %s is invalid - can't be negative", maxBackoff))
+	}
+	return handlerOptionFunc(func(c *HandlerConfig) {
+		c.maxRetryBackoff = maxBackoff
+	})
+}
@@ -401,6 +401,12 @@ func (c *RedisConsumer) reclaimer(ctx context.Context, reclaimInterval time.Dura
 						continue
 					}
 
+					// Skip messages that haven't been idle for long enough (retry backoff).
+					// NOTE: redis counts the first delivery, so the retry count is the number of the next retry.
+					if resMessage.Idle < handler.config.retryDelay(resMessage.RetryCount) {
+						continue
+					}
+
 					// Otherwise, claim the message so we can retry it.
 					claimedMessages, errClaim := c.rdb.XClaim(ctx, &redis.XClaimArgs{
 						Stream:   streamID,
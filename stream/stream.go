@@ -52,6 +52,25 @@ type HandlerConfig struct {
 
 	// maxRetries specifies the max number a stream message is retried.
 	maxRetries int
+
+	// maxRetryBackoff specifies the max delay before a failed message is retried.
+	// If set, the retry delay grows exponentially from the idleTimeout (doubling with every retry).
+	// If not set, failed messages are retried after the idleTimeout.
+	maxRetryBackoff time.Duration
+}
+
+// retryDelay returns the delay before the n-th retry of a message (starting with 1).
+func (c HandlerConfig) retryDelay(retry int64) time.Duration {
+	if c.maxRetryBackoff <= c.idleTimeout || retry <= 1 {
+		return c.idleTimeout
+	}
+
+	delay := c.idleTimeout
+	for i := int64(1); i < retry && delay < c.maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, c.maxRetryBackoff)
 }
 
 // HandlerFunc defines the signature of a function handling stream messages.
@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Attachment represents a file uploaded to a repository in order to be referenced from markdown,
+// e.g. an image in a pull request comment.
+type Attachment struct {
+	ID          int64  `json:"-"`
+	RepoID      int64  `json:"-"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedBy   int64  `json:"created_by"`
+	Created     int64  `json:"created"`
+	// Referenced is set once the attachment gets referenced from any markdown text.
+	Referenced bool `json:"referenced"`
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "encoding/json"
+
+// AuditEvent is a recorded mutating operation performed in a space.
+type AuditEvent struct {
+	ID        int64  `json:"-"`
+	UID       string `json:"uid"`
+	Timestamp int64  `json:"timestamp"`
+	Action    string `json:"action"`
+
+	Actor PrincipalInfo `json:"actor"`
+
+	SpaceID   int64  `json:"space_id"`
+	SpacePath string `json:"space_path"`
+
+	ResourceType       string            `json:"resource_type"`
+	ResourceIdentifier string            `json:"resource_identifier"`
+	ResourceData       map[string]string `json:"resource_data,omitempty"`
+
+	// OldObject and NewObject hold the state of the resource before and after the operation.
+	OldObject json.RawMessage `json:"old_object,omitempty"`
+	NewObject json.RawMessage `json:"new_object,omitempty"`
+
+	ClientIP      string `json:"client_ip,omitempty"`
+	RequestMethod string `json:"request_method,omitempty"`
+	RequestID     string `json:"request_id,omitempty"`
+}
+
+// AuditEventFilter stores audit event query parameters.
+type AuditEventFilter struct {
+	Page         int    `json:"page"`
+	Size         int    `json:"size"`
+	ResourceType string `json:"resource_type"`
+	Action       string `json:"action"`
+	ActorID      int64  `json:"actor_id"`
+	// From and To limit the events to the provided time range (unix millis, inclusive).
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// Recursive includes the events of all sub-spaces.
+	Recursive bool `json:"recursive"`
+}
+
+// AuditEventOutbox is an audit event waiting to be delivered to the external audit log sink.
+type AuditEventOutbox struct {
+	ID           int64  `json:"id"`
+	AuditEventID int64  `json:"audit_event_id"`
+	Created      int64  `json:"created"`
+	Attempts     int    `json:"attempts"`
+	LastError    string `json:"last_error"`
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Banner is an instance wide announcement shown to all users, e.g. a maintenance window or a policy notice.
+// The banner is shown from StartsAt until EndsAt, or indefinitely if EndsAt is zero.
+type Banner struct {
+	ID          int64               `json:"-"`
+	Identifier  string              `json:"identifier"`
+	Message     string              `json:"message"`
+	Severity    enum.BannerSeverity `json:"severity"`
+	StartsAt    int64               `json:"starts_at"`
+	EndsAt      int64               `json:"ends_at,omitempty"`
+	Dismissible bool                `json:"dismissible"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// BannerInfo is the public representation of a banner, returned to unauthenticated clients.
+type BannerInfo struct {
+	Identifier  string              `json:"identifier"`
+	Message     string              `json:"message"`
+	Severity    enum.BannerSeverity `json:"severity"`
+	StartsAt    int64               `json:"starts_at"`
+	EndsAt      int64               `json:"ends_at,omitempty"`
+	Dismissible bool                `json:"dismissible"`
+}
+
+// IsActive returns true if the banner is shown at the provided time (unix millis).
+func (b *Banner) IsActive(now int64) bool {
+	return b.StartsAt <= now && (b.EndsAt == 0 || b.EndsAt > now)
+}
+
+func (b *Banner) ToBannerInfo() BannerInfo {
+	return BannerInfo{
+		Identifier:  b.Identifier,
+		Message:     b.Message,
+		Severity:    b.Severity,
+		StartsAt:    b.StartsAt,
+		EndsAt:      b.EndsAt,
+		Dismissible: b.Dismissible,
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "encoding/xml"
+
+// CCProjects is a CCTray XML feed with the build status of projects,
+// as consumed by CCMenu, BuildNotify and radiator dashboards.
+type CCProjects struct {
+	XMLName xml.Name     `xml:"Projects"`
+	Project []*CCProject `xml:"Project"`
+}
+
+// CCProject is the build status of a project in a CCTray XML feed.
+type CCProject struct {
+	XMLName         xml.Name `xml:"Project"`
+	Name            string   `xml:"name,attr"`
+	Activity        string   `xml:"activity,attr"`
+	LastBuildStatus string   `xml:"lastBuildStatus,attr"`
+	LastBuildLabel  string   `xml:"lastBuildLabel,attr"`
+	LastBuildTime   string   `xml:"lastBuildTime,attr"`
+	WebURL          string   `xml:"webUrl,attr"`
+}
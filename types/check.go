@@ -76,6 +76,14 @@ type CheckPayload struct {
 	Data    json.RawMessage       `json:"data"`
 }
 
+// CombinedCheckStatus is the aggregated state of all status checks reported for a commit.
+type CombinedCheckStatus struct {
+	CommitSHA  string           `json:"commit_sha"`
+	Status     enum.CheckStatus `json:"status"`
+	TotalCount int              `json:"total_count"`
+	Checks     []Check          `json:"checks"`
+}
+
 // CheckListOptions holds list status checks query parameters.
 type CheckListOptions struct {
 	ListQueryFilter
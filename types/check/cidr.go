@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"net/netip"
+	"strings"
+)
+
+const maxAllowedCIDRs = 100
+
+var ErrTooManyAllowedCIDRs = &ValidationError{
+	"An IP allowlist can't contain more than 100 entries.",
+}
+
+// AllowedCIDRs validates the entries of an IP allowlist and normalizes them in place.
+// Entries can be CIDRs or single IP addresses, which are converted to single host CIDRs.
+func AllowedCIDRs(cidrs []string) error {
+	if len(cidrs) > maxAllowedCIDRs {
+		return ErrTooManyAllowedCIDRs
+	}
+
+	for i, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return NewValidationErrorf("Invalid IP address or CIDR %q.", cidr)
+			}
+
+			cidrs[i] = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()).String()
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return NewValidationErrorf("Invalid IP address or CIDR %q.", cidr)
+		}
+
+		cidrs[i] = prefix.Masked().String()
+	}
+
+	return nil
+}
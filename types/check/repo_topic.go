@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	maxRepoTopics       = 20
+	maxRepoTopicLength  = 50
+	repoTopicRegexValue = "^[a-z0-9][a-z0-9-]*$"
+)
+
+var repoTopicRegex = regexp.MustCompile(repoTopicRegexValue)
+
+var (
+	ErrRepoTopicsTooMany = &ValidationError{
+		fmt.Sprintf("A repository can have at most %d topics.", maxRepoTopics),
+	}
+	ErrRepoTopicLength = &ValidationError{
+		fmt.Sprintf("Topics have to be between 1 and %d in length.", maxRepoTopicLength),
+	}
+	ErrRepoTopicRegex = &ValidationError{
+		"Topics have to start with a lower case letter or a number " +
+			"and can only contain lower case letters, numbers and hyphens.",
+	}
+)
+
+// RepoTopics checks the provided repository topics and returns an error if they aren't valid.
+func RepoTopics(topics []string) error {
+	if len(topics) > maxRepoTopics {
+		return ErrRepoTopicsTooMany
+	}
+
+	for _, topic := range topics {
+		if len(topic) == 0 || len(topic) > maxRepoTopicLength {
+			return ErrRepoTopicLength
+		}
+
+		if !repoTopicRegex.MatchString(topic) {
+			return ErrRepoTopicRegex
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CherryPickCommit holds the result of cherry-picking a single commit.
+type CherryPickCommit struct {
+	SHA           sha.SHA               `json:"sha"`
+	NewSHA        *sha.SHA              `json:"new_sha,omitempty"`
+	Status        enum.CherryPickStatus `json:"status"`
+	ConflictFiles []string              `json:"conflict_files,omitempty"`
+}
+
+type CherryPickResponse struct {
+	NewBranchSHA   *sha.SHA           `json:"new_branch_sha,omitempty"`
+	Commits        []CherryPickCommit `json:"commits"`
+	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+
+	DryRunRules bool `json:"dry_run_rules,omitempty"`
+	DryRun      bool `json:"dry_run,omitempty"`
+}
+
+// CherryPickViolations is returned when the cherry-pick is blocked by protection rules or by conflicts.
+type CherryPickViolations struct {
+	Message        string             `json:"message,omitempty"`
+	Commits        []CherryPickCommit `json:"commits,omitempty"`
+	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+}
+
+// BackportResult holds the outcome of backporting a merged pull request to a single target branch.
+type BackportResult struct {
+	TargetBranch string `json:"target_branch"`
+	// Branch is the name of the branch created for the backport pull request.
+	Branch string `json:"branch"`
+	// PullReq is the created backport pull request, nil if the backport failed.
+	PullReq *PullReq `json:"pull_request,omitempty"`
+
+	// Message describes why the backport failed.
+	Message        string             `json:"message,omitempty"`
+	Commits        []CherryPickCommit `json:"commits,omitempty"`
+	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+}
+
+type BackportResponse struct {
+	Results []BackportResult `json:"results"`
+}
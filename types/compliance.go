@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ComplianceReport is the result of the content policy scan of a repository at a specific commit.
+type ComplianceReport struct {
+	CommitSHA string `json:"commit_sha"`
+	Created   int64  `json:"created"`
+
+	// License is the license of the repository itself (empty if no license could be detected).
+	License string `json:"license,omitempty"`
+
+	RequiredFiles []ComplianceRequiredFile      `json:"required_files"`
+	Dependencies  []ComplianceDependencyLicense `json:"dependencies"`
+	Violations    []ComplianceViolation         `json:"violations"`
+}
+
+// Compliant returns true in case the scan didn't find any policy violations.
+func (r *ComplianceReport) Compliant() bool {
+	return len(r.Violations) == 0
+}
+
+// ComplianceRequiredFile contains the scan result of a single required file.
+type ComplianceRequiredFile struct {
+	Name    string `json:"name"`
+	Path    string `json:"path,omitempty"`
+	Present bool   `json:"present"`
+}
+
+// ComplianceDependencyLicense contains the license detected in a license file of a vendored dependency.
+type ComplianceDependencyLicense struct {
+	Path    string `json:"path"`
+	License string `json:"license,omitempty"`
+}
+
+// ComplianceViolation describes a single violation of the content policy.
+type ComplianceViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+}
@@ -22,6 +22,7 @@ import (
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -93,6 +94,39 @@ type Config struct {
 		// Registry is used as a base to generate external facing URLs.
 		// Value is derived from HTTP.Server unless explicitly specified (e.g. http://host.docker.internal:3000).
 		Registry string `envconfig:"GITNESS_URL_REGISTRY"`
+
+		// PathPrefix is the path under which the server is reachable if it's served under a URL path prefix
+		// and the reverse proxy doesn't strip the prefix (e.g. /gitness).
+		// If set, the prefix is removed from all incoming requests before routing.
+		PathPrefix string `envconfig:"GITNESS_URL_PATH_PREFIX"`
+
+		// TrustForwardedHeaders enables the per-request override of the external URLs via the standard
+		// Forwarded and X-Forwarded-Proto / X-Forwarded-Host / X-Forwarded-Prefix headers.
+		// IMPORTANT: Only enable if the server is exclusively reachable via a trusted reverse proxy.
+		TrustForwardedHeaders bool `envconfig:"GITNESS_URL_TRUST_FORWARDED_HEADERS"`
+	}
+
+	// Shadow defines the configuration of the traffic mirroring used to validate a second backend
+	// (e.g. different storage or newer version) with a sample of the production read-only traffic.
+	Shadow struct {
+		Enabled bool `envconfig:"GITNESS_SHADOW_ENABLED" default:"false"`
+
+		// TargetURL is the base URL of the shadow backend (e.g. http://gitness-canary:3000).
+		TargetURL string `envconfig:"GITNESS_SHADOW_TARGET_URL"`
+
+		// SampleRate is the fraction of eligible requests that are mirrored (0.0 - 1.0).
+		SampleRate float64 `envconfig:"GITNESS_SHADOW_SAMPLE_RATE" default:"0.01"`
+
+		// Timeout is the maximum duration of a mirrored request.
+		Timeout time.Duration `envconfig:"GITNESS_SHADOW_TIMEOUT" default:"60s"`
+
+		// MaxRequestBodySize is the maximum size of a request body that is mirrored.
+		// Requests with bigger bodies aren't mirrored.
+		MaxRequestBodySize int64 `envconfig:"GITNESS_SHADOW_MAX_REQUEST_BODY_SIZE" default:"1048576"`
+
+		// MaxConcurrency is the maximum number of mirrored requests in flight.
+		// Sampled requests are skipped if the limit is reached.
+		MaxConcurrency int `envconfig:"GITNESS_SHADOW_MAX_CONCURRENCY" default:"16"`
 	}
 
 	// Git defines the git configuration parameters
@@ -108,6 +142,10 @@ type Config struct {
 		TmpDir string `envconfig:"GITNESS_GIT_TMP_DIR"`
 		// HookPath points to the binary used as git server hook.
 		HookPath string `envconfig:"GITNESS_GIT_HOOK_PATH"`
+		// StoragePools (optional) maps the names of additional storage pools to their root directories
+		// (e.g. "eu:/mnt/eu/git,us:/mnt/us/git"). Spaces can be assigned to a pool to keep their repositories
+		// on a specific disk or region, repositories of unassigned spaces are stored in Root.
+		StoragePools map[string]string `envconfig:"GITNESS_GIT_STORAGE_POOLS"`
 
 		// LastCommitCache holds configuration options for the last commit cache.
 		LastCommitCache struct {
@@ -173,6 +211,62 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// TriggerMaxRetries is the number of times the creation of an execution for a git event is retried
+		// before the event is listed as a failed trigger of the repository.
+		TriggerMaxRetries int `envconfig:"GITNESS_CI_TRIGGER_MAX_RETRIES" default:"3"`
+
+		// ConfigExtension is an external HTTP endpoint that's called with the build context
+		// (repo, ref, changed files) and returns the final pipeline YAML. It's disabled if the endpoint is empty.
+		// If the secret is set, requests are signed with HMAC SHA256 in the X-{Identity}-Signature-256 header.
+		ConfigExtension struct {
+			Endpoint string        `envconfig:"GITNESS_CI_CONFIG_EXTENSION_ENDPOINT"`
+			Secret   string        `envconfig:"GITNESS_CI_CONFIG_EXTENSION_SECRET"`
+			Timeout  time.Duration `envconfig:"GITNESS_CI_CONFIG_EXTENSION_TIMEOUT" default:"30s"`
+		}
+
+		// StepResources defines the resources of the pipeline steps, with the cpu in cores or millicores
+		// (eg "0.5" or "500m") and the memory in bytes or with a unit (eg "512MiB").
+		// Steps without limits in the pipeline yaml use the defaults, and no step can exceed the maximums.
+		// Empty values mean there is no default or maximum.
+		StepResources struct {
+			DefaultCPU    string `envconfig:"GITNESS_CI_STEP_DEFAULT_CPU"`
+			DefaultMemory string `envconfig:"GITNESS_CI_STEP_DEFAULT_MEMORY"`
+			MaxCPU        string `envconfig:"GITNESS_CI_STEP_MAX_CPU"`
+			MaxMemory     string `envconfig:"GITNESS_CI_STEP_MAX_MEMORY"`
+		}
+
+		// Kubernetes defines the configuration of the kubernetes runner, which executes the stages of pipelines
+		// with `type: kubernetes` as pods, with a container per step and the workspace on a persistent volume claim.
+		// If no API server is configured, the in-cluster configuration of the service account is used.
+		Kubernetes struct {
+			Enabled   bool   `envconfig:"GITNESS_CI_KUBERNETES_ENABLED"`
+			APIServer string `envconfig:"GITNESS_CI_KUBERNETES_API_SERVER"`
+			Token     string `envconfig:"GITNESS_CI_KUBERNETES_TOKEN"`
+			// CAFile is the path to the certificate authority of the API server.
+			CAFile    string `envconfig:"GITNESS_CI_KUBERNETES_CA_FILE"`
+			Namespace string `envconfig:"GITNESS_CI_KUBERNETES_NAMESPACE" default:"default"`
+			// ServiceAccount is the service account of the pods, unless the pipeline yaml sets one.
+			ServiceAccount string `envconfig:"GITNESS_CI_KUBERNETES_SERVICE_ACCOUNT"`
+			// StorageClass is the storage class of the workspace volumes, the cluster default is used if empty.
+			StorageClass  string `envconfig:"GITNESS_CI_KUBERNETES_STORAGE_CLASS"`
+			WorkspaceSize string `envconfig:"GITNESS_CI_KUBERNETES_WORKSPACE_SIZE" default:"1Gi"`
+			// PlaceholderImage is the image the step containers run until the step is started.
+			PlaceholderImage string `envconfig:"GITNESS_CI_KUBERNETES_PLACEHOLDER_IMAGE" default:"drone/placeholder:1"`
+			CloneImage       string `envconfig:"GITNESS_CI_KUBERNETES_CLONE_IMAGE" default:"drone/git:latest"`
+		}
+
+		// Runners defines the external runners, which register with the server and execute the stages
+		// of pipelines delegated to them based on their tags (`runs_on`).
+		Runners struct {
+			// RegistrationSecret is the shared secret runners register with, registration is disabled if empty.
+			RegistrationSecret string `envconfig:"GITNESS_CI_RUNNERS_REGISTRATION_SECRET"`
+			// HeartbeatTimeout is the time after which a runner without heartbeat is considered dead,
+			// its stages are rescheduled or failed.
+			HeartbeatTimeout time.Duration `envconfig:"GITNESS_CI_RUNNERS_HEARTBEAT_TIMEOUT" default:"2m"`
+			CRON             string        `envconfig:"GITNESS_CI_RUNNERS_HEALTH_CRON" default:"* * * * *"`
+			MaxDuration      time.Duration `envconfig:"GITNESS_CI_RUNNERS_HEALTH_MAX_DURATION" default:"50s"`
+		}
 	}
 
 	// Database defines the database configuration parameters.
@@ -183,11 +277,14 @@ type Config struct {
 
 	// BlobStore defines the blob storage configuration parameters.
 	BlobStore struct {
-		// Provider is a name of blob storage service like filesystem or gcs
+		// Provider is a name of blob storage service like filesystem, gcs or s3
 		Provider blob.Provider `envconfig:"GITNESS_BLOBSTORE_PROVIDER" default:"filesystem"`
 		// Bucket is a path to the directory where the files will be stored when using filesystem blob storage,
 		// in case of gcs provider this will be the actual bucket where the images are stored.
 		Bucket string `envconfig:"GITNESS_BLOBSTORE_BUCKET"`
+		// PoolBuckets (optional) maps git storage pools to the buckets used for the files of their repositories.
+		// Pools without a bucket use the default bucket.
+		PoolBuckets map[string]string `envconfig:"GITNESS_BLOBSTORE_POOL_BUCKETS"`
 
 		// In case of GCS provider, this is expected to be the path to the service account key file.
 		KeyPath string `envconfig:"GITNESS_BLOBSTORE_KEY_PATH" default:""`
@@ -196,12 +293,90 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// In case of S3 provider, Endpoint (optional) is the URL of an S3 compatible storage like minio,
+		// which usually also requires path style addressing. Credentials and region are taken from the environment.
+		Endpoint  string `envconfig:"GITNESS_BLOBSTORE_ENDPOINT"`
+		PathStyle bool   `envconfig:"GITNESS_BLOBSTORE_PATH_STYLE"`
 	}
 
 	// Token defines token configuration parameters.
 	Token struct {
 		CookieName string        `envconfig:"GITNESS_TOKEN_COOKIE_NAME" default:"token"`
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
+
+		// MaxLifetime is the maximum lifetime of access tokens, tokens without expiry aren't allowed if set.
+		// Zero means that the lifetime of access tokens isn't limited.
+		MaxLifetime time.Duration `envconfig:"GITNESS_TOKEN_MAX_LIFETIME" default:"0"`
+
+		// RotationGracePeriod is the duration the previous secret of a rotated token is still accepted.
+		RotationGracePeriod time.Duration `envconfig:"GITNESS_TOKEN_ROTATION_GRACE_PERIOD" default:"1h"`
+
+		// AllowlistTrustProxyHeaders makes IP allowlists use the client IP from the headers set by reverse proxies
+		// (True-Client-IP, X-Real-IP and X-Forwarded-For) instead of the address of the connection.
+		// Only enable it if Gitness is reachable exclusively through a reverse proxy that sets the headers.
+		AllowlistTrustProxyHeaders bool `envconfig:"GITNESS_TOKEN_ALLOWLIST_TRUST_PROXY_HEADERS" default:"false"`
+
+		// ExpiryWarning defines the periodic job that warns the owners of access tokens about to expire.
+		ExpiryWarning struct {
+			Enabled     bool          `envconfig:"GITNESS_TOKEN_EXPIRY_WARNING_ENABLED" default:"true"`
+			CRON        string        `envconfig:"GITNESS_TOKEN_EXPIRY_WARNING_CRON" default:"0 * * * *"`
+			MaxDuration time.Duration `envconfig:"GITNESS_TOKEN_EXPIRY_WARNING_MAX_DURATION" default:"10m"`
+			// Before is how long before the expiry of a token its owner is warned.
+			Before time.Duration `envconfig:"GITNESS_TOKEN_EXPIRY_WARNING_BEFORE" default:"168h"`
+		}
+	}
+
+	// TwoFactor defines the two-factor authentication (TOTP) configuration.
+	TwoFactor struct {
+		// Required enforces two-factor authentication for all users of the instance.
+		Required bool `envconfig:"GITNESS_TWO_FACTOR_REQUIRED" default:"false"`
+		// Issuer is the name authenticator apps display next to the account.
+		Issuer string `envconfig:"GITNESS_TWO_FACTOR_ISSUER" default:"Gitness"`
+	}
+
+	// WebAuthn defines the relying party used for WebAuthn credentials (passkeys and security keys).
+	WebAuthn struct {
+		// RPID is the relying party ID, derived from the UI URL unless explicitly specified (e.g. example.com).
+		RPID   string `envconfig:"GITNESS_WEBAUTHN_RP_ID"`
+		RPName string `envconfig:"GITNESS_WEBAUTHN_RP_NAME" default:"Gitness"`
+		// Origins are the origins WebAuthn ceremonies are accepted from, derived from the UI URL by default.
+		Origins []string      `envconfig:"GITNESS_WEBAUTHN_ORIGINS"`
+		Timeout time.Duration `envconfig:"GITNESS_WEBAUTHN_TIMEOUT" default:"5m"`
+	}
+
+	// LDAP defines the LDAP / Active Directory server users can authenticate against and which is
+	// synced periodically to provision and deactivate users and their space memberships.
+	LDAP struct {
+		Enabled bool `envconfig:"GITNESS_LDAP_ENABLED" default:"false"`
+		// URL of the directory server (ldap://host:389 or ldaps://host:636).
+		URL                string        `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool          `envconfig:"GITNESS_LDAP_START_TLS" default:"false"`
+		InsecureSkipVerify bool          `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY" default:"false"`
+		Timeout            time.Duration `envconfig:"GITNESS_LDAP_TIMEOUT" default:"10s"`
+
+		// BindDN and BindPassword are the credentials of the service account used to search the directory.
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		UserBaseDN     string `envconfig:"GITNESS_LDAP_USER_BASE_DN"`
+		UserFilter     string `envconfig:"GITNESS_LDAP_USER_FILTER" default:"(objectClass=person)"`
+		LoginAttribute string `envconfig:"GITNESS_LDAP_LOGIN_ATTRIBUTE" default:"uid"`
+		EmailAttribute string `envconfig:"GITNESS_LDAP_EMAIL_ATTRIBUTE" default:"mail"`
+		NameAttribute  string `envconfig:"GITNESS_LDAP_NAME_ATTRIBUTE" default:"cn"`
+		GroupAttribute string `envconfig:"GITNESS_LDAP_GROUP_ATTRIBUTE" default:"memberOf"`
+
+		// AllowSignup provisions users on their first successful LDAP login.
+		AllowSignup bool `envconfig:"GITNESS_LDAP_ALLOW_SIGNUP" default:"true"`
+
+		// Sync defines the recurring job that reconciles users and group memberships with the directory.
+		Sync struct {
+			Enabled     bool          `envconfig:"GITNESS_LDAP_SYNC_ENABLED" default:"false"`
+			CRON        string        `envconfig:"GITNESS_LDAP_SYNC_CRON" default:"0 * * * *"`
+			MaxDuration time.Duration `envconfig:"GITNESS_LDAP_SYNC_MAX_DURATION" default:"30m"`
+			// Deactivate blocks users that were removed from the directory (or no longer match the user filter).
+			Deactivate bool `envconfig:"GITNESS_LDAP_SYNC_DEACTIVATE" default:"true"`
+		}
 	}
 
 	Logs struct {
@@ -212,6 +387,22 @@ type Config struct {
 			Endpoint  string `envconfig:"GITNESS_LOGS_S3_ENDPOINT"`
 			PathStyle bool   `envconfig:"GITNESS_LOGS_S3_PATH_STYLE"`
 		}
+
+		// Archive moves the logs of finished executions from the database to the blob store,
+		// the log API reads through to the archive transparently.
+		Archive struct {
+			Enabled bool `envconfig:"GITNESS_LOGS_ARCHIVE_ENABLED" default:"false"`
+			// Prefix is the path prefix of the archived logs in the blob store.
+			Prefix string `envconfig:"GITNESS_LOGS_ARCHIVE_PREFIX" default:"logs"`
+			// Compress enables gzip compression of the archived logs.
+			Compress bool `envconfig:"GITNESS_LOGS_ARCHIVE_COMPRESS" default:"true"`
+			// Delay is the duration after an execution finished before its logs are archived.
+			Delay time.Duration `envconfig:"GITNESS_LOGS_ARCHIVE_DELAY" default:"1h"`
+			// Retention is the duration after which archived logs are deleted. Zero means logs are kept forever.
+			Retention   time.Duration `envconfig:"GITNESS_LOGS_ARCHIVE_RETENTION" default:"0"`
+			CRON        string        `envconfig:"GITNESS_LOGS_ARCHIVE_CRON" default:"30 * * * *"`
+			MaxDuration time.Duration `envconfig:"GITNESS_LOGS_ARCHIVE_MAX_DURATION" default:"30m"`
+		}
 	}
 
 	// Cors defines http cors parameters
@@ -272,6 +463,22 @@ type Config struct {
 			Email       string `envconfig:"GITNESS_PRINCIPAL_ADMIN_EMAIL"`    // No default email
 			Password    string `envconfig:"GITNESS_PRINCIPAL_ADMIN_PASSWORD"` // No default password
 		}
+
+		// Avatar defines how the avatar images of users are processed and served.
+		Avatar struct {
+			// Size is the width and height (in pixels) uploaded avatars are resized to.
+			Size int `envconfig:"GITNESS_PRINCIPAL_AVATAR_SIZE" default:"256"`
+			// MaxDimension is the maximum width and height (in pixels) of uploaded images.
+			MaxDimension int `envconfig:"GITNESS_PRINCIPAL_AVATAR_MAX_DIMENSION" default:"4096"`
+
+			// Gravatar is used as fallback for users without an uploaded avatar.
+			Gravatar struct {
+				Enabled bool   `envconfig:"GITNESS_PRINCIPAL_AVATAR_GRAVATAR_ENABLED" default:"false"`
+				URL     string `envconfig:"GITNESS_PRINCIPAL_AVATAR_GRAVATAR_URL"     default:"https://www.gravatar.com/avatar"`
+				// Default is the gravatar image used for emails without a gravatar (e.g. identicon, retro, 404).
+				Default string `envconfig:"GITNESS_PRINCIPAL_AVATAR_GRAVATAR_DEFAULT" default:"identicon"`
+			}
+		}
 	}
 
 	Redis struct {
@@ -317,6 +524,38 @@ type Config struct {
 		ChannelSize      int           `envconfig:"GITNESS_PUBSUB_CHANNEL_SIZE"      default:"100"`
 	}
 
+	// RateLimit defines the default rate limits of the API and git HTTP requests, admins can tune them at runtime.
+	RateLimit struct {
+		Enabled bool `envconfig:"GITNESS_RATE_LIMIT_ENABLED" default:"false"`
+		// Provider is the backend counting the requests, redis is required when running multiple instances.
+		Provider ratelimit.Provider `envconfig:"GITNESS_RATE_LIMIT_PROVIDER" default:"inmemory"`
+		// AppNamespace is just service app prefix to avoid conflicts on key definition
+		AppNamespace string        `envconfig:"GITNESS_RATE_LIMIT_APP_NAMESPACE" default:"gitness"`
+		Window       time.Duration `envconfig:"GITNESS_RATE_LIMIT_WINDOW"        default:"1m"`
+		// TrustProxyHeaders uses the client IP set by reverse proxies to limit anonymous requests,
+		// it should only be enabled if all requests go through a proxy that sets the headers.
+		TrustProxyHeaders bool `envconfig:"GITNESS_RATE_LIMIT_TRUST_PROXY_HEADERS" default:"false"`
+		// SettingsRefreshInterval is how often the limits set by admins are reloaded from the database.
+		SettingsRefreshInterval time.Duration `envconfig:"GITNESS_RATE_LIMIT_SETTINGS_REFRESH_INTERVAL" default:"30s"`
+
+		APIRead struct {
+			PerPrincipal int `envconfig:"GITNESS_RATE_LIMIT_API_READ_PER_PRINCIPAL" default:"1200"`
+			PerIP        int `envconfig:"GITNESS_RATE_LIMIT_API_READ_PER_IP"        default:"300"`
+		}
+		APIWrite struct {
+			PerPrincipal int `envconfig:"GITNESS_RATE_LIMIT_API_WRITE_PER_PRINCIPAL" default:"300"`
+			PerIP        int `envconfig:"GITNESS_RATE_LIMIT_API_WRITE_PER_IP"        default:"60"`
+		}
+		Auth struct {
+			PerPrincipal int `envconfig:"GITNESS_RATE_LIMIT_AUTH_PER_PRINCIPAL" default:"30"`
+			PerIP        int `envconfig:"GITNESS_RATE_LIMIT_AUTH_PER_IP"        default:"30"`
+		}
+		Git struct {
+			PerPrincipal int `envconfig:"GITNESS_RATE_LIMIT_GIT_PER_PRINCIPAL" default:"600"`
+			PerIP        int `envconfig:"GITNESS_RATE_LIMIT_GIT_PER_IP"        default:"120"`
+		}
+	}
+
 	BackgroundJobs struct {
 		// MaxRunning is maximum number of jobs that can be running at once.
 		MaxRunning int `envconfig:"GITNESS_JOBS_MAX_RUNNING" default:"10"`
@@ -337,6 +576,12 @@ type Config struct {
 		MaxRetries          int    `envconfig:"GITNESS_WEBHOOK_MAX_RETRIES" default:"3"`
 		AllowPrivateNetwork bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_PRIVATE_NETWORK" default:"false"`
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
+		// MaxRetryBackoff is the max delay between retries of a failed webhook execution.
+		// The delay starts at one minute and doubles with every retry.
+		MaxRetryBackoff time.Duration `envconfig:"GITNESS_WEBHOOK_MAX_RETRY_BACKOFF" default:"10m"`
+		// SecretRotationGracePeriod is the duration for which the previous secret of a webhook
+		// is still used to sign executions after the secret got changed.
+		SecretRotationGracePeriod time.Duration `envconfig:"GITNESS_WEBHOOK_SECRET_ROTATION_GRACE_PERIOD" default:"1h"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 	}
@@ -359,6 +604,144 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	// RepoForkDeduplication defines the periodic repack of forks that drops objects available in the forked repo.
+	RepoForkDeduplication struct {
+		Enabled     bool          `envconfig:"GITNESS_REPO_FORK_DEDUPLICATION_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_REPO_FORK_DEDUPLICATION_CRON" default:"0 3 * * 0"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_FORK_DEDUPLICATION_MAX_DURATION" default:"2h"`
+	}
+
+	// Compliance defines the content policy scanner that checks repositories for required files
+	// and forbidden licenses of vendored dependencies.
+	Compliance struct {
+		Enabled     bool          `envconfig:"GITNESS_COMPLIANCE_ENABLED" default:"false"`
+		CRON        string        `envconfig:"GITNESS_COMPLIANCE_CRON" default:"0 2 * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_COMPLIANCE_MAX_DURATION" default:"1h"`
+		NumWorkers  int           `envconfig:"GITNESS_COMPLIANCE_NUM_WORKERS" default:"2"`
+
+		// RequiredFiles are the files every repository has to contain (in the root or a docs/config folder).
+		RequiredFiles []string `envconfig:"GITNESS_COMPLIANCE_REQUIRED_FILES" default:"LICENSE,SECURITY.md,CODEOWNERS"`
+		// ForbiddenLicenses are the SPDX identifiers of licenses that aren't allowed for vendored dependencies.
+		ForbiddenLicenses []string `envconfig:"GITNESS_COMPLIANCE_FORBIDDEN_LICENSES" default:"AGPL-3.0,SSPL-1.0"`
+
+		// PullReqChecks enables reporting of the scan result as status check for pull request commits.
+		// The check can be made blocking by adding it to the required status checks of a branch rule.
+		PullReqChecks bool `envconfig:"GITNESS_COMPLIANCE_PULLREQ_CHECKS" default:"false"`
+	}
+
+	// ReviewSLA defines the recurring check that emits warnings and breaches of pull request review SLAs.
+	// The SLAs themselves are configured per space.
+	ReviewSLA struct {
+		Enabled     bool          `envconfig:"GITNESS_REVIEW_SLA_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_REVIEW_SLA_CRON" default:"*/5 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REVIEW_SLA_MAX_DURATION" default:"4m"`
+	}
+
+	// RepoInsights defines the recurring job that computes the insights of the repositories
+	// with new commits on their default branch.
+	RepoInsights struct {
+		Enabled     bool          `envconfig:"GITNESS_REPO_INSIGHTS_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_REPO_INSIGHTS_CRON" default:"*/10 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_INSIGHTS_MAX_DURATION" default:"9m"`
+	}
+
+	// AutoMerge defines the auto-merge of pull requests. Pull requests are merged on pull request events,
+	// the recurring job catches up with changes that aren't reported as events, like status checks.
+	AutoMerge struct {
+		Enabled     bool          `envconfig:"GITNESS_AUTO_MERGE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_AUTO_MERGE_CRON" default:"*/5 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_AUTO_MERGE_MAX_DURATION" default:"4m"`
+	}
+
+	// PipelineCron defines the recurring job that creates the executions of the cron triggers of pipelines.
+	PipelineCron struct {
+		Enabled     bool          `envconfig:"GITNESS_PIPELINE_CRON_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_PIPELINE_CRON_CRON" default:"* * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_PIPELINE_CRON_MAX_DURATION" default:"50s"`
+		// CatchUpWindow is the max age of a missed run of a cron trigger, e.g. while the server was down.
+		// All missed runs within the window are caught up with a single execution, older ones are skipped.
+		// Zero means missed runs are always caught up.
+		CatchUpWindow time.Duration `envconfig:"GITNESS_PIPELINE_CRON_CATCH_UP_WINDOW" default:"24h"`
+	}
+
+	// PipelineCache defines the build cache of pipelines, stored in the blob store of the repository.
+	// Cache steps restore and save the cache entries, unused entries are evicted by a recurring job.
+	PipelineCache struct {
+		Enabled bool `envconfig:"GITNESS_PIPELINE_CACHE_ENABLED" default:"true"`
+		// Image is the container image of cache steps, it requires sh, tar, sha256sum and curl.
+		Image string `envconfig:"GITNESS_PIPELINE_CACHE_IMAGE" default:"alpine/curl:8.9.1"`
+		// MaxSize is the max size of a single cache entry in bytes.
+		MaxSize int64 `envconfig:"GITNESS_PIPELINE_CACHE_MAX_SIZE" default:"1073741824"` // 1 GiB
+		// RepoQuota is the max total size of the cache entries of a repository in bytes.
+		// The least recently used entries are evicted if a saved entry exceeds the quota. Zero means unlimited.
+		RepoQuota int64 `envconfig:"GITNESS_PIPELINE_CACHE_REPO_QUOTA" default:"5368709120"` // 5 GiB
+		// TTL is the duration after which cache entries that weren't restored are evicted.
+		TTL         time.Duration `envconfig:"GITNESS_PIPELINE_CACHE_TTL" default:"168h"` // 7 days
+		CRON        string        `envconfig:"GITNESS_PIPELINE_CACHE_CRON" default:"15 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_PIPELINE_CACHE_MAX_DURATION" default:"10m"`
+	}
+
+	// PipelineArtifact defines the artifacts uploaded by the steps of pipeline executions,
+	// stored in the blob store of the repository. Steps upload artifacts to the url in GITNESS_ARTIFACTS_URL,
+	// artifacts older than the retention period are deleted by a recurring job.
+	PipelineArtifact struct {
+		Enabled bool `envconfig:"GITNESS_PIPELINE_ARTIFACT_ENABLED" default:"true"`
+		// MaxSize is the max size of a single artifact in bytes.
+		MaxSize int64 `envconfig:"GITNESS_PIPELINE_ARTIFACT_MAX_SIZE" default:"536870912"` // 512 MiB
+		// Retention is the duration after which artifacts are deleted. Zero means artifacts are kept forever.
+		Retention   time.Duration `envconfig:"GITNESS_PIPELINE_ARTIFACT_RETENTION" default:"720h"` // 30 days
+		CRON        string        `envconfig:"GITNESS_PIPELINE_ARTIFACT_CRON" default:"45 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_PIPELINE_ARTIFACT_MAX_DURATION" default:"10m"`
+	}
+
+	// Replication defines the recording of reference updates consumed by external disaster recovery tools.
+	Replication struct {
+		// Enabled enables recording of all reference updates of all repositories.
+		Enabled bool `envconfig:"GITNESS_REPLICATION_ENABLED" default:"false"`
+		// RetentionTime is the time after which records acknowledged by all consumers are deleted.
+		RetentionTime time.Duration `envconfig:"GITNESS_REPLICATION_RETENTION_TIME" default:"168h"` // 7 days
+		CRON          string        `envconfig:"GITNESS_REPLICATION_CLEANUP_CRON" default:"41 * * * *"`
+		MaxDuration   time.Duration `envconfig:"GITNESS_REPLICATION_CLEANUP_MAX_DURATION" default:"5m"`
+	}
+
+	// AuditLog defines the export of the audit events and their streaming to an external sink.
+	AuditLog struct {
+		// ExportMaxRange limits the time range of the audit events exported at once.
+		ExportMaxRange time.Duration `envconfig:"GITNESS_AUDIT_LOG_EXPORT_MAX_RANGE" default:"2232h"` // 93 days
+
+		// Stream defines the recurring job that delivers the audit events from the outbox to the external sink.
+		// Every audit event is delivered at least once, failed deliveries are retried on the next run.
+		Stream struct {
+			// Sink is the type of the external sink (webhook, syslog or s3), streaming is disabled if empty.
+			Sink        string        `envconfig:"GITNESS_AUDIT_LOG_STREAM_SINK"`
+			CRON        string        `envconfig:"GITNESS_AUDIT_LOG_STREAM_CRON" default:"* * * * *"`
+			MaxDuration time.Duration `envconfig:"GITNESS_AUDIT_LOG_STREAM_MAX_DURATION" default:"50s"`
+			BatchSize   int           `envconfig:"GITNESS_AUDIT_LOG_STREAM_BATCH_SIZE" default:"100"`
+
+			// Webhook posts the audit events as a JSON array, signed with the secret if provided.
+			Webhook struct {
+				URL     string        `envconfig:"GITNESS_AUDIT_LOG_STREAM_WEBHOOK_URL"`
+				Secret  string        `envconfig:"GITNESS_AUDIT_LOG_STREAM_WEBHOOK_SECRET"`
+				Timeout time.Duration `envconfig:"GITNESS_AUDIT_LOG_STREAM_WEBHOOK_TIMEOUT" default:"10s"`
+			}
+
+			// Syslog sends every audit event as a RFC 5424 message.
+			Syslog struct {
+				Network string `envconfig:"GITNESS_AUDIT_LOG_STREAM_SYSLOG_NETWORK" default:"udp"`
+				Address string `envconfig:"GITNESS_AUDIT_LOG_STREAM_SYSLOG_ADDRESS"`
+				AppName string `envconfig:"GITNESS_AUDIT_LOG_STREAM_SYSLOG_APP_NAME" default:"gitness"`
+			}
+
+			// S3 uploads every batch of audit events as a JSONL object.
+			S3 struct {
+				Bucket    string `envconfig:"GITNESS_AUDIT_LOG_STREAM_S3_BUCKET"`
+				Prefix    string `envconfig:"GITNESS_AUDIT_LOG_STREAM_S3_PREFIX"`
+				Endpoint  string `envconfig:"GITNESS_AUDIT_LOG_STREAM_S3_ENDPOINT"`
+				PathStyle bool   `envconfig:"GITNESS_AUDIT_LOG_STREAM_S3_PATH_STYLE"`
+			}
+		}
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
@@ -380,11 +763,19 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+
+		// IndexDir is the directory where the code search indexes are stored.
+		// Defaults to a directory in the gitness home directory.
+		IndexDir string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_DIR"`
 	}
 
 	Repos struct {
 		// DeletedRetentionTime is the duration after which deleted repositories will be purged.
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
+
+		// OrphanedAttachmentsRetentionTime is the duration after which uploaded attachments
+		// that aren't referenced anywhere will be purged.
+		OrphanedAttachmentsRetentionTime time.Duration `envconfig:"GITNESS_REPOS_ORPHANED_ATTACHMENTS_RETENTION_TIME" default:"24h"` //nolint:lll
 	}
 
 	Docker struct {
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Cron is a scheduled trigger of a pipeline. It creates executions of the pipeline
+// for the head of a branch at the times matching the cron expression.
+type Cron struct {
+	ID          int64  `json:"-"`
+	RepoID      int64  `json:"repo_id"`
+	PipelineID  int64  `json:"pipeline_id"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	// Branch is the branch the executions are created for.
+	// If empty, the default branch of the pipeline or of the repository is used.
+	Branch     string `json:"branch"`
+	Expression string `json:"expression"`
+	Timezone   string `json:"timezone"`
+	Disabled   bool   `json:"disabled"`
+	// Next is the time of the next scheduled run, zero if the cron is disabled
+	// or the expression doesn't match any time in the future.
+	Next int64 `json:"next"`
+	// Prev is the scheduled time of the last run, zero if the cron never ran.
+	Prev      int64 `json:"prev"`
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	Version   int64 `json:"-"`
+}
+
+// CronRun is an upcoming run of a cron trigger of a pipeline.
+type CronRun struct {
+	Cron   string `json:"cron"`
+	Branch string `json:"branch"`
+	Time   int64  `json:"time"`
+}
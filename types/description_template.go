@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// DescriptionTemplate is a markdown template stored in the repository
+// that is used to prefill the description of new pull requests or issues.
+type DescriptionTemplate struct {
+	Type enum.DescriptionTemplateType `json:"type"`
+	// Name identifies the template, the default template of a type is named "default".
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
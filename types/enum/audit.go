@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AuditLogExportFormat defines the file format of exported audit events.
+type AuditLogExportFormat string
+
+func (AuditLogExportFormat) Enum() []interface{} { return toInterfaceSlice(auditLogExportFormats) }
+func (f AuditLogExportFormat) Sanitize() (AuditLogExportFormat, bool) {
+	return Sanitize(f, GetAllAuditLogExportFormats)
+}
+func GetAllAuditLogExportFormats() ([]AuditLogExportFormat, AuditLogExportFormat) {
+	return auditLogExportFormats, AuditLogExportFormatJSONL
+}
+
+const (
+	// AuditLogExportFormatCSV exports the audit events as comma separated values with a header row.
+	AuditLogExportFormatCSV AuditLogExportFormat = "csv"
+	// AuditLogExportFormatJSONL exports the audit events as JSON objects, one per line.
+	AuditLogExportFormatJSONL AuditLogExportFormat = "jsonl"
+)
+
+var auditLogExportFormats = sortEnum([]AuditLogExportFormat{
+	AuditLogExportFormatCSV,
+	AuditLogExportFormatJSONL,
+})
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// BannerSeverity defines how prominently a banner is shown.
+type BannerSeverity string
+
+func (BannerSeverity) Enum() []interface{} { return toInterfaceSlice(bannerSeverities) }
+func (s BannerSeverity) Sanitize() (BannerSeverity, bool) {
+	return Sanitize(s, GetAllBannerSeverities)
+}
+func GetAllBannerSeverities() ([]BannerSeverity, BannerSeverity) {
+	return bannerSeverities, BannerSeverityInfo
+}
+
+// Rank returns a higher value for the more severe banners.
+func (s BannerSeverity) Rank() int {
+	switch s {
+	case BannerSeverityCritical:
+		return 2
+	case BannerSeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+const (
+	BannerSeverityInfo     BannerSeverity = "info"
+	BannerSeverityWarning  BannerSeverity = "warning"
+	BannerSeverityCritical BannerSeverity = "critical"
+)
+
+var bannerSeverities = sortEnum([]BannerSeverity{
+	BannerSeverityInfo,
+	BannerSeverityWarning,
+	BannerSeverityCritical,
+})
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import gitenum "github.com/harness/gitness/git/enum"
+
+// CherryPickStatus represents the outcome of cherry-picking a single commit.
+type CherryPickStatus gitenum.CherryPickStatus
+
+// CherryPickStatus enumeration.
+const (
+	CherryPickStatusApplied  = CherryPickStatus(gitenum.CherryPickStatusApplied)
+	CherryPickStatusSkipped  = CherryPickStatus(gitenum.CherryPickStatusSkipped)
+	CherryPickStatusConflict = CherryPickStatus(gitenum.CherryPickStatusConflict)
+	CherryPickStatusPending  = CherryPickStatus(gitenum.CherryPickStatusPending)
+)
+
+var cherryPickStatuses = sortEnum([]CherryPickStatus{
+	CherryPickStatusApplied,
+	CherryPickStatusSkipped,
+	CherryPickStatusConflict,
+	CherryPickStatusPending,
+})
+
+func (CherryPickStatus) Enum() []interface{} { return toInterfaceSlice(cherryPickStatuses) }
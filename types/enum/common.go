@@ -62,6 +62,7 @@ const (
 	desc          = "desc"
 	descending    = "descending"
 	value         = "value"
+	stars         = "stars"
 )
 
 func toInterfaceSlice[T interface{}](vals []T) []interface{} {
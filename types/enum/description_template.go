@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// DescriptionTemplateType defines what a description template stored in a repository is used for.
+type DescriptionTemplateType string
+
+func (DescriptionTemplateType) Enum() []interface{} {
+	return toInterfaceSlice(descriptionTemplateTypes)
+}
+
+func (t DescriptionTemplateType) Sanitize() (DescriptionTemplateType, bool) {
+	return Sanitize(t, GetAllDescriptionTemplateTypes)
+}
+
+func GetAllDescriptionTemplateTypes() ([]DescriptionTemplateType, DescriptionTemplateType) {
+	return descriptionTemplateTypes, ""
+}
+
+const (
+	// DescriptionTemplateTypePullReq is a template of pull request descriptions.
+	DescriptionTemplateTypePullReq DescriptionTemplateType = "pullreq"
+	// DescriptionTemplateTypeIssue is a template of issue descriptions.
+	DescriptionTemplateTypeIssue DescriptionTemplateType = "issue"
+)
+
+var descriptionTemplateTypes = sortEnum([]DescriptionTemplateType{
+	DescriptionTemplateTypePullReq,
+	DescriptionTemplateTypeIssue,
+})
@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// FileTemplateType defines the type of file template that can be added to a new repository.
+type FileTemplateType string
+
+func (FileTemplateType) Enum() []interface{} { return toInterfaceSlice(fileTemplateTypes) }
+func (t FileTemplateType) Sanitize() (FileTemplateType, bool) {
+	return Sanitize(t, GetAllFileTemplateTypes)
+}
+func GetAllFileTemplateTypes() ([]FileTemplateType, FileTemplateType) {
+	return fileTemplateTypes, ""
+}
+
+const (
+	FileTemplateTypeGitIgnore FileTemplateType = "gitignore"
+	FileTemplateTypeLicense   FileTemplateType = "license"
+)
+
+var fileTemplateTypes = sortEnum([]FileTemplateType{
+	FileTemplateTypeGitIgnore,
+	FileTemplateTypeLicense,
+})
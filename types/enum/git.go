@@ -17,6 +17,8 @@ package enum
 import (
 	"fmt"
 	"strings"
+
+	gitenum "github.com/harness/gitness/git/enum"
 )
 
 // BranchSortOption specifies the available sort options for branches.
@@ -114,3 +116,27 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return "", fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// CommitSignatureStatus represents the verification status of a commit signature.
+type CommitSignatureStatus gitenum.CommitSignatureStatus
+
+// CommitSignatureStatus enumeration.
+const (
+	CommitSignatureStatusUnsigned   = CommitSignatureStatus(gitenum.CommitSignatureStatusUnsigned)
+	CommitSignatureStatusVerified   = CommitSignatureStatus(gitenum.CommitSignatureStatusVerified)
+	CommitSignatureStatusUnverified = CommitSignatureStatus(gitenum.CommitSignatureStatusUnverified)
+	CommitSignatureStatusInvalid    = CommitSignatureStatus(gitenum.CommitSignatureStatusInvalid)
+	CommitSignatureStatusExpired    = CommitSignatureStatus(gitenum.CommitSignatureStatusExpired)
+	CommitSignatureStatusRevoked    = CommitSignatureStatus(gitenum.CommitSignatureStatusRevoked)
+)
+
+var commitSignatureStatuses = sortEnum([]CommitSignatureStatus{
+	CommitSignatureStatusUnsigned,
+	CommitSignatureStatusVerified,
+	CommitSignatureStatusUnverified,
+	CommitSignatureStatusInvalid,
+	CommitSignatureStatusExpired,
+	CommitSignatureStatusRevoked,
+})
+
+func (CommitSignatureStatus) Enum() []interface{} { return toInterfaceSlice(commitSignatureStatuses) }
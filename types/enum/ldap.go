@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// LDAPSyncAction defines a change applied (or planned) by a sync with the LDAP directory.
+type LDAPSyncAction string
+
+func (LDAPSyncAction) Enum() []interface{} { return toInterfaceSlice(ldapSyncActions) }
+
+// LDAPSyncAction enumeration.
+const (
+	LDAPSyncActionCreateUser       LDAPSyncAction = "create_user"
+	LDAPSyncActionLinkUser         LDAPSyncAction = "link_user"
+	LDAPSyncActionUpdateUser       LDAPSyncAction = "update_user"
+	LDAPSyncActionDeactivateUser   LDAPSyncAction = "deactivate_user"
+	LDAPSyncActionReactivateUser   LDAPSyncAction = "reactivate_user"
+	LDAPSyncActionAddMembership    LDAPSyncAction = "add_membership"
+	LDAPSyncActionUpdateMembership LDAPSyncAction = "update_membership"
+	LDAPSyncActionRemoveMembership LDAPSyncAction = "remove_membership"
+)
+
+var ldapSyncActions = sortEnum([]LDAPSyncAction{
+	LDAPSyncActionCreateUser,
+	LDAPSyncActionLinkUser,
+	LDAPSyncActionUpdateUser,
+	LDAPSyncActionDeactivateUser,
+	LDAPSyncActionReactivateUser,
+	LDAPSyncActionAddMembership,
+	LDAPSyncActionUpdateMembership,
+	LDAPSyncActionRemoveMembership,
+})
@@ -58,6 +58,7 @@ var membershipRoleExecutorPermissions = slices.Clip(slices.Insert(membershipRole
 var membershipRoleContributorPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
 	PermissionRepoPush,
 	PermissionRepoReview,
+	PermissionRepoMerge,
 
 	PermissionArtifactsUpload,
 	PermissionArtifactsDelete,
@@ -69,6 +70,9 @@ var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRo
 	PermissionRepoPush,
 	PermissionRepoReportCommitCheck,
 	PermissionRepoReview,
+	PermissionRepoMerge,
+
+	PermissionWebhookEdit,
 
 	PermissionSpaceEdit,
 	PermissionSpaceDelete,
@@ -130,6 +134,19 @@ func (m MembershipRole) Permissions() []Permission {
 	}
 }
 
+// IsBuiltIn returns true if the role is one of the predefined roles, any other role is a custom role.
+func (m MembershipRole) IsBuiltIn() bool {
+	_, ok := m.Sanitize()
+	return ok
+}
+
+// IsMembershipPermission returns true if the permission can be granted by a membership role.
+// Custom roles can be composed of any of these permissions, all of them are granted to space owners.
+func IsMembershipPermission(permission Permission) bool {
+	_, ok := slices.BinarySearch(membershipRoleSpaceOwnerPermissions, permission)
+	return ok
+}
+
 const (
 	MembershipRoleReader      MembershipRole = "reader"
 	MembershipRoleExecutor    MembershipRole = "executor"
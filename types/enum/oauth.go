@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// OAuthProviderType defines the identity provider used for OAuth login.
+type OAuthProviderType string
+
+func (OAuthProviderType) Enum() []interface{} { return toInterfaceSlice(oauthProviderTypes) }
+func (t OAuthProviderType) Sanitize() (OAuthProviderType, bool) {
+	return Sanitize(t, GetAllOAuthProviderTypes)
+}
+func GetAllOAuthProviderTypes() ([]OAuthProviderType, OAuthProviderType) {
+	return oauthProviderTypes, ""
+}
+
+const (
+	OAuthProviderTypeGitHub    OAuthProviderType = "github"
+	OAuthProviderTypeGitLab    OAuthProviderType = "gitlab"
+	OAuthProviderTypeGoogle    OAuthProviderType = "google"
+	OAuthProviderTypeBitbucket OAuthProviderType = "bitbucket"
+)
+
+var oauthProviderTypes = sortEnum([]OAuthProviderType{
+	OAuthProviderTypeGitHub,
+	OAuthProviderTypeGitLab,
+	OAuthProviderTypeGoogle,
+	OAuthProviderTypeBitbucket,
+})
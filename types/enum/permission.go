@@ -54,6 +54,18 @@ const (
 	PermissionRepoPush              Permission = "repo_push"
 	PermissionRepoReview            Permission = "repo_review"
 	PermissionRepoReportCommitCheck Permission = "repo_reportCommitCheck"
+	// PermissionRepoMerge allows merging pull requests, which required PermissionRepoPush before.
+	// Custom roles with PermissionRepoPush were granted it by migration 0136.
+	PermissionRepoMerge Permission = "repo_merge"
+)
+
+const (
+	/*
+		----- WEBHOOK -----
+	*/
+	// PermissionWebhookEdit allows managing webhooks, which required PermissionRepoEdit or PermissionSpaceEdit before.
+	// Custom roles with either of them were granted it by migration 0136.
+	PermissionWebhookEdit Permission = "webhook_edit"
 )
 
 const (
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PipelineInputType defines the type of an input parameter of a pipeline.
+type PipelineInputType string
+
+func (PipelineInputType) Enum() []interface{} { return toInterfaceSlice(pipelineInputTypes) }
+func (t PipelineInputType) Sanitize() (PipelineInputType, bool) {
+	return Sanitize(t, GetAllPipelineInputTypes)
+}
+func GetAllPipelineInputTypes() ([]PipelineInputType, PipelineInputType) {
+	return pipelineInputTypes, PipelineInputTypeString
+}
+
+const (
+	// PipelineInputTypeString accepts any value.
+	PipelineInputTypeString PipelineInputType = "string"
+	// PipelineInputTypeNumber accepts integer and decimal numbers.
+	PipelineInputTypeNumber PipelineInputType = "number"
+	// PipelineInputTypeBoolean accepts "true" and "false".
+	PipelineInputTypeBoolean PipelineInputType = "boolean"
+	// PipelineInputTypeChoice accepts one of the options of the input.
+	PipelineInputTypeChoice PipelineInputType = "choice"
+)
+
+var pipelineInputTypes = sortEnum([]PipelineInputType{
+	PipelineInputTypeString,
+	PipelineInputTypeNumber,
+	PipelineInputTypeBoolean,
+	PipelineInputTypeChoice,
+})
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PipelineProblemType defines the type of a problem found by the validation of a pipeline YAML.
+type PipelineProblemType string
+
+func (PipelineProblemType) Enum() []interface{} { return toInterfaceSlice(pipelineProblemTypes) }
+
+const (
+	// PipelineProblemTypeSyntax is a YAML syntax error.
+	PipelineProblemTypeSyntax PipelineProblemType = "syntax"
+	// PipelineProblemTypeSchema is a value that is missing or doesn't match the pipeline schema.
+	PipelineProblemTypeSchema PipelineProblemType = "schema"
+	// PipelineProblemTypeUnknownKey is a key that isn't part of the pipeline schema and is ignored.
+	PipelineProblemTypeUnknownKey PipelineProblemType = "unknown_key"
+	// PipelineProblemTypeMissingSecret is a reference to a secret that doesn't exist.
+	PipelineProblemTypeMissingSecret PipelineProblemType = "missing_secret"
+	// PipelineProblemTypeMissingTemplate is a reference to a pipeline template that doesn't exist.
+	PipelineProblemTypeMissingTemplate PipelineProblemType = "missing_template"
+	// PipelineProblemTypeMissingDependency is a dependency on a pipeline or step that doesn't exist.
+	PipelineProblemTypeMissingDependency PipelineProblemType = "missing_dependency"
+)
+
+var pipelineProblemTypes = sortEnum([]PipelineProblemType{
+	PipelineProblemTypeSyntax,
+	PipelineProblemTypeSchema,
+	PipelineProblemTypeUnknownKey,
+	PipelineProblemTypeMissingSecret,
+	PipelineProblemTypeMissingTemplate,
+	PipelineProblemTypeMissingDependency,
+})
+
+// PipelineProblemSeverity defines the severity of a problem found by the validation of a pipeline YAML.
+type PipelineProblemSeverity string
+
+func (PipelineProblemSeverity) Enum() []interface{} {
+	return toInterfaceSlice(pipelineProblemSeverities)
+}
+
+const (
+	// PipelineProblemSeverityError is a problem that prevents the pipeline from running as intended.
+	PipelineProblemSeverityError PipelineProblemSeverity = "error"
+	// PipelineProblemSeverityWarning is a problem that doesn't prevent the pipeline from running.
+	PipelineProblemSeverityWarning PipelineProblemSeverity = "warning"
+)
+
+var pipelineProblemSeverities = sortEnum([]PipelineProblemSeverity{
+	PipelineProblemSeverityError,
+	PipelineProblemSeverityWarning,
+})
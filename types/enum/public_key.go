@@ -25,6 +25,7 @@ const (
 
 var publicKeyTypes = sortEnum([]PublicKeyUsage{
 	PublicKeyUsageAuth,
+	PublicKeyUsageSign,
 })
 
 func (PublicKeyUsage) Enum() []interface{} { return toInterfaceSlice(publicKeyTypes) }
@@ -35,6 +36,28 @@ func GetAllPublicKeyUsages() ([]PublicKeyUsage, PublicKeyUsage) {
 	return publicKeyTypes, PublicKeyUsageAuth
 }
 
+// PublicKeyScheme represents the format of public key.
+type PublicKeyScheme string
+
+// PublicKeyScheme enumeration.
+const (
+	PublicKeySchemeSSH PublicKeyScheme = "ssh"
+	PublicKeySchemePGP PublicKeyScheme = "pgp"
+)
+
+var publicKeySchemes = sortEnum([]PublicKeyScheme{
+	PublicKeySchemeSSH,
+	PublicKeySchemePGP,
+})
+
+func (PublicKeyScheme) Enum() []interface{} { return toInterfaceSlice(publicKeySchemes) }
+func (s PublicKeyScheme) Sanitize() (PublicKeyScheme, bool) {
+	return Sanitize(s, GetAllPublicKeySchemes)
+}
+func GetAllPublicKeySchemes() ([]PublicKeyScheme, PublicKeyScheme) {
+	return publicKeySchemes, PublicKeySchemeSSH
+}
+
 // PublicKeySort is used to specify sorting of public keys.
 type PublicKeySort string
 
@@ -85,11 +85,13 @@ const (
 	PullReqActivityTypeReviewSubmit   PullReqActivityType = "review-submit"
 	PullReqActivityTypeReviewerAdd    PullReqActivityType = "reviewer-add"
 	PullReqActivityTypeReviewerDelete PullReqActivityType = "reviewer-delete"
+	PullReqActivityTypeReviewDismiss  PullReqActivityType = "review-dismiss"
 	PullReqActivityTypeBranchUpdate   PullReqActivityType = "branch-update"
 	PullReqActivityTypeBranchDelete   PullReqActivityType = "branch-delete"
 	PullReqActivityTypeBranchRestore  PullReqActivityType = "branch-restore"
 	PullReqActivityTypeMerge          PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify    PullReqActivityType = "label-modify"
+	PullReqActivityTypeCommentStatus  PullReqActivityType = "comment-status"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -100,11 +102,13 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeReviewSubmit,
 	PullReqActivityTypeReviewerAdd,
 	PullReqActivityTypeReviewerDelete,
+	PullReqActivityTypeReviewDismiss,
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
 	PullReqActivityTypeBranchRestore,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeCommentStatus,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -207,12 +211,16 @@ const (
 	PullReqReviewerTypeRequested    PullReqReviewerType = "requested"
 	PullReqReviewerTypeAssigned     PullReqReviewerType = "assigned"
 	PullReqReviewerTypeSelfAssigned PullReqReviewerType = "self_assigned"
+	PullReqReviewerTypeCodeOwner    PullReqReviewerType = "code_owner"
+	PullReqReviewerTypeDefault      PullReqReviewerType = "default"
 )
 
 var pullReqReviewerTypes = sortEnum([]PullReqReviewerType{
 	PullReqReviewerTypeRequested,
 	PullReqReviewerTypeAssigned,
 	PullReqReviewerTypeSelfAssigned,
+	PullReqReviewerTypeCodeOwner,
+	PullReqReviewerTypeDefault,
 })
 
 type MergeMethod gitenum.MergeMethod
@@ -272,3 +280,43 @@ var LabelActivityTypes = sortEnum([]PullReqLabelActivityType{
 	LabelActivityReassign,
 	LabelActivityNoop,
 })
+
+// PullReqSize is the size label of a pull request based on the number of changed lines.
+type PullReqSize string
+
+func (PullReqSize) Enum() []interface{} { return toInterfaceSlice(pullReqSizes) }
+
+// PullReqSize enumeration.
+const (
+	PullReqSizeXS PullReqSize = "xs"
+	PullReqSizeS  PullReqSize = "s"
+	PullReqSizeM  PullReqSize = "m"
+	PullReqSizeL  PullReqSize = "l"
+	PullReqSizeXL PullReqSize = "xl"
+)
+
+var pullReqSizes = sortEnum([]PullReqSize{
+	PullReqSizeXS,
+	PullReqSizeS,
+	PullReqSizeM,
+	PullReqSizeL,
+	PullReqSizeXL,
+})
+
+// PullReqRiskLevel is the estimated risk of merging a pull request.
+type PullReqRiskLevel string
+
+func (PullReqRiskLevel) Enum() []interface{} { return toInterfaceSlice(pullReqRiskLevels) }
+
+// PullReqRiskLevel enumeration.
+const (
+	PullReqRiskLevelLow    PullReqRiskLevel = "low"
+	PullReqRiskLevelMedium PullReqRiskLevel = "medium"
+	PullReqRiskLevelHigh   PullReqRiskLevel = "high"
+)
+
+var pullReqRiskLevels = sortEnum([]PullReqRiskLevel{
+	PullReqRiskLevelLow,
+	PullReqRiskLevelMedium,
+	PullReqRiskLevelHigh,
+})
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RateLimitClass groups the routes that share a rate limit.
+type RateLimitClass string
+
+func (RateLimitClass) Enum() []interface{}                       { return toInterfaceSlice(rateLimitClasses) }
+func (c RateLimitClass) Sanitize() (RateLimitClass, bool)        { return Sanitize(c, GetAllRateLimitClasses) }
+func GetAllRateLimitClasses() ([]RateLimitClass, RateLimitClass) { return rateLimitClasses, "" }
+
+// RateLimitClass enumeration.
+const (
+	// RateLimitClassAPIRead are the API requests that don't change any resources.
+	RateLimitClassAPIRead RateLimitClass = "api_read"
+
+	// RateLimitClassAPIWrite are the API requests that create, update or delete resources.
+	RateLimitClassAPIWrite RateLimitClass = "api_write"
+
+	// RateLimitClassAuth are the login and registration requests.
+	RateLimitClassAuth RateLimitClass = "auth"
+
+	// RateLimitClassGit are the git HTTP requests (clone, fetch and push).
+	RateLimitClassGit RateLimitClass = "git"
+)
+
+var rateLimitClasses = sortEnum([]RateLimitClass{
+	RateLimitClassAPIRead,
+	RateLimitClassAPIWrite,
+	RateLimitClassAuth,
+	RateLimitClassGit,
+})
@@ -30,6 +30,7 @@ const (
 	RepoAttrCreated
 	RepoAttrUpdated
 	RepoAttrDeleted
+	RepoAttrStars
 )
 
 // ParseRepoAttr parses the repo attribute string
@@ -47,6 +48,8 @@ func ParseRepoAttr(s string) RepoAttr {
 		return RepoAttrUpdated
 	case deleted, deletedAt:
 		return RepoAttrDeleted
+	case stars:
+		return RepoAttrStars
 	default:
 		return RepoAttrNone
 	}
@@ -66,6 +69,8 @@ func (a RepoAttr) String() string {
 		return updated
 	case RepoAttrDeleted:
 		return deleted
+	case RepoAttrStars:
+		return stars
 	case RepoAttrNone:
 		return ""
 	default:
@@ -82,6 +87,7 @@ const (
 	RepoStateGitImport
 	RepoStateMigrateGitPush
 	RepoStateMigrateDataImport
+	RepoStateStorageMove
 )
 
 // String returns the string representation of the RepoState.
@@ -95,6 +101,8 @@ func (state RepoState) String() string {
 		return "migrate-git-push"
 	case RepoStateMigrateDataImport:
 		return "migrate-data-import"
+	case RepoStateStorageMove:
+		return "storage-move"
 	default:
 		return undefined
 	}
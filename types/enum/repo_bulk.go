@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoBulkOperation defines an operation that can be applied to many repositories of a space at once.
+type RepoBulkOperation string
+
+func (RepoBulkOperation) Enum() []interface{} { return toInterfaceSlice(repoBulkOperations) }
+func (o RepoBulkOperation) Sanitize() (RepoBulkOperation, bool) {
+	return Sanitize(o, GetAllRepoBulkOperations)
+}
+func GetAllRepoBulkOperations() ([]RepoBulkOperation, RepoBulkOperation) {
+	return repoBulkOperations, ""
+}
+
+const (
+	RepoBulkOperationArchive   RepoBulkOperation = "archive"
+	RepoBulkOperationUnarchive RepoBulkOperation = "unarchive"
+	RepoBulkOperationDelete    RepoBulkOperation = "delete"
+	RepoBulkOperationExport    RepoBulkOperation = "export"
+	RepoBulkOperationSettings  RepoBulkOperation = "settings"
+)
+
+var repoBulkOperations = sortEnum([]RepoBulkOperation{
+	RepoBulkOperationArchive,
+	RepoBulkOperationUnarchive,
+	RepoBulkOperationDelete,
+	RepoBulkOperationExport,
+	RepoBulkOperationSettings,
+})
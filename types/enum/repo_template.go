@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoTemplateItemKind defines the kind of item of a repository template.
+type RepoTemplateItemKind string
+
+func (RepoTemplateItemKind) Enum() []interface{} {
+	return toInterfaceSlice(repoTemplateItemKinds)
+}
+
+const (
+	RepoTemplateItemKindRule    RepoTemplateItemKind = "rule"
+	RepoTemplateItemKindWebhook RepoTemplateItemKind = "webhook"
+)
+
+var repoTemplateItemKinds = sortEnum([]RepoTemplateItemKind{
+	RepoTemplateItemKindRule,
+	RepoTemplateItemKindWebhook,
+})
+
+// RepoTemplateDriftStatus defines whether a repository matches an item of its repository template.
+type RepoTemplateDriftStatus string
+
+func (RepoTemplateDriftStatus) Enum() []interface{} {
+	return toInterfaceSlice(repoTemplateDriftStatuses)
+}
+
+const (
+	// RepoTemplateDriftStatusInSync means that the repository matches the template item.
+	RepoTemplateDriftStatusInSync RepoTemplateDriftStatus = "in_sync"
+	// RepoTemplateDriftStatusMissing means that the repository doesn't have the template item.
+	RepoTemplateDriftStatusMissing RepoTemplateDriftStatus = "missing"
+	// RepoTemplateDriftStatusModified means that the repository item differs from the template item.
+	RepoTemplateDriftStatusModified RepoTemplateDriftStatus = "modified"
+)
+
+var repoTemplateDriftStatuses = sortEnum([]RepoTemplateDriftStatus{
+	RepoTemplateDriftStatusInSync,
+	RepoTemplateDriftStatusMissing,
+	RepoTemplateDriftStatusModified,
+})
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// GitTransport represents the transport protocol used by a git client to reach the server.
+type GitTransport string
+
+// GitTransport enumeration.
+const (
+	GitTransportHTTP GitTransport = "http"
+	GitTransportSSH  GitTransport = "ssh"
+)
+
+var gitTransports = sortEnum([]GitTransport{
+	GitTransportHTTP,
+	GitTransportSSH,
+})
+
+func (GitTransport) Enum() []interface{}              { return toInterfaceSlice(gitTransports) }
+func (t GitTransport) Sanitize() (GitTransport, bool) { return Sanitize(t, GetAllGitTransports) }
+func GetAllGitTransports() ([]GitTransport, GitTransport) {
+	return gitTransports, ""
+}
+
+// GitTrafficOperation represents the kind of read operation a git client executed against a repository.
+type GitTrafficOperation string
+
+// GitTrafficOperation enumeration.
+const (
+	// GitTrafficOperationClone is a fetch without any objects known to the client.
+	GitTrafficOperationClone GitTrafficOperation = "clone"
+	// GitTrafficOperationFetch is an incremental fetch of a client that already has objects of the repository.
+	GitTrafficOperationFetch GitTrafficOperation = "fetch"
+)
+
+var gitTrafficOperations = sortEnum([]GitTrafficOperation{
+	GitTrafficOperationClone,
+	GitTrafficOperationFetch,
+})
+
+func (GitTrafficOperation) Enum() []interface{} { return toInterfaceSlice(gitTrafficOperations) }
+func (o GitTrafficOperation) Sanitize() (GitTrafficOperation, bool) {
+	return Sanitize(o, GetAllGitTrafficOperations)
+}
+func GetAllGitTrafficOperations() ([]GitTrafficOperation, GitTrafficOperation) {
+	return gitTrafficOperations, ""
+}
@@ -25,6 +25,9 @@ const (
 
 	// ResolverTypeStage is a stage level resolver.
 	ResolverTypeStage ResolverType = "stage"
+
+	// ResolverTypePipeline is a pipeline level resolver.
+	ResolverTypePipeline ResolverType = "pipeline"
 )
 
 func ParseResolverType(s string) (ResolverType, error) {
@@ -33,6 +36,8 @@ func ParseResolverType(s string) (ResolverType, error) {
 		return ResolverTypeStep, nil
 	case "stage":
 		return ResolverTypeStage, nil
+	case "pipeline":
+		return ResolverTypePipeline, nil
 	default:
 		return "", fmt.Errorf("unknown template type provided: %s", s)
 	}
@@ -44,6 +49,8 @@ func (t ResolverType) String() string {
 		return "step"
 	case ResolverTypeStage:
 		return "stage"
+	case ResolverTypePipeline:
+		return "pipeline"
 	default:
 		return undefined
 	}
@@ -30,4 +30,6 @@ const (
 	SSETypePullRequestUpdated SSEType = "pullreq_updated"
 
 	SSETypeLogLineAppended SSEType = "log_line_appended"
+
+	SSETypeBlameHunk SSEType = "blame_hunk"
 )
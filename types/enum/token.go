@@ -27,3 +27,81 @@ const (
 	// TokenTypeSAT is a service account access token.
 	TokenTypeSAT TokenType = "sat"
 )
+
+// TokenScope restricts the permissions granted to a personal access token.
+type TokenScope string
+
+func (TokenScope) Enum() []interface{}              { return toInterfaceSlice(tokenScopes) }
+func (s TokenScope) Sanitize() (TokenScope, bool)   { return Sanitize(s, GetAllTokenScopes) }
+func GetAllTokenScopes() ([]TokenScope, TokenScope) { return tokenScopes, "" }
+
+// TokenScope enumeration.
+const (
+	// TokenScopeRepoRead grants read access to spaces and repositories.
+	TokenScopeRepoRead TokenScope = "repo:read"
+
+	// TokenScopeRepoWrite grants read access and allows to push to, review and edit repositories.
+	TokenScopeRepoWrite TokenScope = "repo:write"
+
+	// TokenScopePipelineExecute allows to view and execute pipelines.
+	TokenScopePipelineExecute TokenScope = "pipeline:execute"
+
+	// TokenScopeAdmin grants all permissions of the user, including the system administration of admins.
+	TokenScopeAdmin TokenScope = "admin"
+
+	// TokenScopeTwoFactorEnrollment restricts the session of a user that has to enable two-factor authentication
+	// to the user itself, so the user can enroll. It's reserved for sessions and can't be used for access tokens.
+	TokenScopeTwoFactorEnrollment TokenScope = "two_factor:enroll"
+)
+
+var tokenScopes = sortEnum([]TokenScope{
+	TokenScopeRepoRead,
+	TokenScopeRepoWrite,
+	TokenScopePipelineExecute,
+	TokenScopeAdmin,
+})
+
+var tokenScopePermissions = map[TokenScope][]Permission{
+	TokenScopeRepoRead: {
+		PermissionSpaceView,
+		PermissionRepoView,
+		PermissionUserView,
+	},
+	TokenScopeRepoWrite: {
+		PermissionSpaceView,
+		PermissionRepoView,
+		PermissionUserView,
+		PermissionRepoEdit,
+		PermissionRepoPush,
+		PermissionRepoReview,
+		PermissionRepoReportCommitCheck,
+		PermissionRepoMerge,
+		PermissionWebhookEdit,
+	},
+	TokenScopePipelineExecute: {
+		PermissionSpaceView,
+		PermissionRepoView,
+		PermissionUserView,
+		PermissionPipelineView,
+		PermissionPipelineExecute,
+	},
+	TokenScopeTwoFactorEnrollment: {
+		PermissionUserView,
+		PermissionUserEdit,
+	},
+}
+
+// Grants returns true if the scope grants the permission.
+func (s TokenScope) Grants(permission Permission) bool {
+	if s == TokenScopeAdmin {
+		return true
+	}
+
+	for _, p := range tokenScopePermissions[s] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
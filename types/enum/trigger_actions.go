@@ -40,6 +40,10 @@ const (
 	TriggerActionPullReqClosed TriggerAction = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged TriggerAction = "pullreq_merged"
+
+	// TriggerActionCron gets triggered by a cron trigger of a pipeline.
+	// It's not part of the actions that can be configured for a trigger.
+	TriggerActionCron TriggerAction = "cron"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -55,6 +59,9 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
 		return TriggerEventTag
 	}
+	if t == TriggerActionCron {
+		return TriggerEventCron
+	}
 	if t == "" {
 		return TriggerEventManual
 	}
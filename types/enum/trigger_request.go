@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TriggerRequestState defines the state of a request to create a pipeline execution for a git event.
+type TriggerRequestState string
+
+func (TriggerRequestState) Enum() []interface{} { return toInterfaceSlice(triggerRequestStates) }
+func (s TriggerRequestState) Sanitize() (TriggerRequestState, bool) {
+	return Sanitize(s, GetAllTriggerRequestStates)
+}
+func GetAllTriggerRequestStates() ([]TriggerRequestState, TriggerRequestState) {
+	return triggerRequestStates, ""
+}
+
+const (
+	// TriggerRequestStateQueued means that the execution is about to be created or the creation is being retried.
+	TriggerRequestStateQueued TriggerRequestState = "queued"
+	// TriggerRequestStateRunning means that the execution is being created.
+	TriggerRequestStateRunning TriggerRequestState = "running"
+	// TriggerRequestStateFailed means that all attempts to create the execution failed.
+	TriggerRequestStateFailed TriggerRequestState = "failed"
+)
+
+var triggerRequestStates = sortEnum([]TriggerRequestState{
+	TriggerRequestStateQueued,
+	TriggerRequestStateRunning,
+	TriggerRequestStateFailed,
+})
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// DiffViewStyle represents the way diffs are displayed to the user.
+type DiffViewStyle string
+
+// DiffViewStyle enumeration.
+const (
+	DiffViewStyleUnified DiffViewStyle = "unified"
+	DiffViewStyleSplit   DiffViewStyle = "split"
+)
+
+var diffViewStyles = sortEnum([]DiffViewStyle{
+	DiffViewStyleUnified,
+	DiffViewStyleSplit,
+})
+
+func (DiffViewStyle) Enum() []interface{} { return toInterfaceSlice(diffViewStyles) }
+func (s DiffViewStyle) Sanitize() (DiffViewStyle, bool) {
+	return Sanitize(s, GetAllDiffViewStyles)
+}
+func GetAllDiffViewStyles() ([]DiffViewStyle, DiffViewStyle) {
+	return diffViewStyles, DiffViewStyleUnified
+}
@@ -160,6 +160,22 @@ const (
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
 	// WebhookTriggerPullReqUpdated gets triggered when a pull request gets updated.
 	WebhookTriggerPullReqUpdated WebhookTrigger = "pullreq_updated"
+	// WebhookTriggerPullReqReviewSLAWarning gets triggered when a pull request review SLA is about to be breached.
+	WebhookTriggerPullReqReviewSLAWarning WebhookTrigger = "pullreq_review_sla_warning"
+	// WebhookTriggerPullReqReviewSLABreached gets triggered when a pull request review SLA got breached.
+	WebhookTriggerPullReqReviewSLABreached WebhookTrigger = "pullreq_review_sla_breached"
+
+	// WebhookTriggerBranchProtectionViolated gets triggered when a push or merge violates branch protection rules.
+	WebhookTriggerBranchProtectionViolated WebhookTrigger = "branch_protection_violated"
+
+	// WebhookTriggerIssueCreated gets triggered when an issue gets created.
+	WebhookTriggerIssueCreated WebhookTrigger = "issue_created"
+	// WebhookTriggerIssueClosed gets triggered when an issue gets closed.
+	WebhookTriggerIssueClosed WebhookTrigger = "issue_closed"
+	// WebhookTriggerIssueReopened gets triggered when an issue gets reopened.
+	WebhookTriggerIssueReopened WebhookTrigger = "issue_reopened"
+	// WebhookTriggerIssueCommentCreated gets triggered when an issue comment gets created.
+	WebhookTriggerIssueCommentCreated WebhookTrigger = "issue_comment_created"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -176,4 +192,11 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqReviewSLAWarning,
+	WebhookTriggerPullReqReviewSLABreached,
+	WebhookTriggerBranchProtectionViolated,
+	WebhookTriggerIssueCreated,
+	WebhookTriggerIssueClosed,
+	WebhookTriggerIssueReopened,
+	WebhookTriggerIssueCommentCreated,
 })
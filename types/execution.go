@@ -45,6 +45,7 @@ type Execution struct {
 	AuthorAvatar string             `json:"author_avatar,omitempty"`
 	Sender       string             `json:"sender,omitempty"`
 	Params       map[string]string  `json:"params,omitempty"`
+	Inputs       map[string]string  `json:"inputs,omitempty"`
 	Cron         string             `json:"cron,omitempty"`
 	Deploy       string             `json:"deploy_to,omitempty"`
 	DeployID     int64              `json:"deploy_id,omitempty"`
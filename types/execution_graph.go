@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// ExecutionGraph is the dependency graph of the stages and steps of an execution.
+// Pipelines with a matrix are expanded into a stage per combination and pipeline templates are resolved.
+type ExecutionGraph struct {
+	Stages []*ExecutionGraphStage `json:"stages"`
+}
+
+// ExecutionGraphStage is a stage of the execution graph.
+// DependsOn holds the numbers of the stages the stage depends on.
+type ExecutionGraphStage struct {
+	Number    int64             `json:"number"`
+	Name      string            `json:"name"`
+	Status    enum.CIStatus     `json:"status"`
+	Matrix    map[string]string `json:"matrix,omitempty"`
+	DependsOn []int64           `json:"depends_on"`
+	// Planned is true if the stage didn't start yet and its steps are resolved from the pipeline YAML.
+	Planned bool                  `json:"planned"`
+	Steps   []*ExecutionGraphStep `json:"steps"`
+}
+
+// ExecutionGraphStep is a step of a stage of the execution graph.
+// DependsOn holds the numbers of the steps of the stage the step depends on.
+type ExecutionGraphStep struct {
+	Number    int64         `json:"number"`
+	Name      string        `json:"name"`
+	Status    enum.CIStatus `json:"status"`
+	Image     string        `json:"image,omitempty"`
+	Detached  bool          `json:"detached"`
+	DependsOn []int64       `json:"depends_on"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// FileTemplate is a custom gitignore or license template that can be added to a new repository.
+// Templates without a space are available in all spaces.
+type FileTemplate struct {
+	ID         int64                 `json:"-"`
+	SpaceID    int64                 `json:"space_id,omitempty"`
+	Type       enum.FileTemplateType `json:"type"`
+	Identifier string                `json:"identifier"`
+	Label      string                `json:"label"`
+	Content    string                `json:"content,omitempty"`
+	CreatedBy  int64                 `json:"created_by"`
+	Created    int64                 `json:"created"`
+	Updated    int64                 `json:"updated"`
+}
+
+// FileTemplateInfo describes a file template available for repository creation.
+type FileTemplateInfo struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
@@ -38,6 +38,8 @@ type CommitFilter struct {
 	Until        int64  `json:"until"`
 	Committer    string `json:"committer"`
 	IncludeStats bool   `json:"include_stats"`
+	// IncludeSignatureStatus requests the verification of the commit signatures.
+	IncludeSignatureStatus bool `json:"include_signature_status"`
 }
 
 // BranchFilter stores branch query parameters.
@@ -58,6 +60,30 @@ type TagFilter struct {
 	Size  int                `json:"size"`
 }
 
+// GrepFilter stores repository grep query parameters.
+type GrepFilter struct {
+	GitRef     string   `json:"git_ref"`
+	Query      string   `json:"q"`
+	Regex      bool     `json:"regex"`
+	IgnoreCase bool     `json:"ignore_case"`
+	Paths      []string `json:"path"`
+	Limit      int      `json:"limit"`
+}
+
+// GrepMatch is a single line of a file that matches the grep query.
+type GrepMatch struct {
+	Path       string `json:"path"`
+	LineNumber int    `json:"line_number"`
+	Column     int    `json:"column"`
+	Line       string `json:"line"`
+}
+
+type GrepResult struct {
+	Matches []GrepMatch `json:"matches"`
+	// Truncated is true in case there are more matches than the requested limit.
+	Truncated bool `json:"truncated"`
+}
+
 type ChangeStats struct {
 	Insertions int64 `json:"insertions"`
 	Deletions  int64 `json:"deletions"`
@@ -84,6 +110,9 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	CoAuthors       []Identity                 `json:"co_authors,omitempty"`
+	SignatureStatus enum.CommitSignatureStatus `json:"signature_status,omitempty"`
 }
 
 type Signature struct {
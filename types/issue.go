@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// Issue represents an issue of a repository.
+type Issue struct {
+	ID      int64 `json:"-"` // not returned, it's an internal field
+	Version int64 `json:"-"` // not returned, it's an internal field
+	RepoID  int64 `json:"repo_id"`
+	Number  int64 `json:"number"`
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Closed    *int64 `json:"closed,omitempty"`
+
+	// ClosedByPullReq is the number of the pull request which closed the issue when it got merged.
+	ClosedByPullReq *int64 `json:"closed_by_pullreq,omitempty"`
+
+	State enum.IssueState `json:"state"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	CommentCount int `json:"comment_count"`
+
+	MilestoneID *int64 `json:"milestone_id,omitempty"`
+
+	Author    PrincipalInfo      `json:"author"`
+	Assignees []*PrincipalInfo   `json:"assignees,omitempty"`
+	Labels    []*LabelAssignment `json:"labels,omitempty"`
+}
+
+// IssueFilter stores issue query parameters.
+type IssueFilter struct {
+	Page        int               `json:"page"`
+	Size        int               `json:"size"`
+	Query       string            `json:"query"`
+	States      []enum.IssueState `json:"state"`
+	CreatedBy   []int64           `json:"created_by"`
+	Assignee    int64             `json:"assignee"`
+	MilestoneID int64             `json:"milestone_id"`
+	Order       enum.Order        `json:"order"`
+}
+
+// IssueComment represents a comment on an issue.
+type IssueComment struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"-"` // not returned, it's an internal field
+	IssueID int64 `json:"-"` // not returned, the comments are always listed per issue
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Deleted   *int64 `json:"deleted,omitempty"`
+
+	Text string `json:"text"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+// IssueLabel is a label assigned to an issue.
+type IssueLabel struct {
+	IssueID   int64  `json:"issue_id"`
+	LabelID   int64  `json:"label_id"`
+	ValueID   *int64 `json:"value_id,omitempty"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	CreatedBy int64  `json:"created_by"`
+	UpdatedBy int64  `json:"updated_by"`
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// LDAPIdentity links a principal to its entry in the LDAP directory.
+type LDAPIdentity struct {
+	ID          int64 `json:"id"`
+	PrincipalID int64 `json:"-"`
+	// Login is the value of the login attribute of the directory entry.
+	Login string `json:"login"`
+	DN    string `json:"dn"`
+	// Deactivated is set if the user was blocked by the sync because it was removed from the directory.
+	Deactivated bool  `json:"deactivated"`
+	Created     int64 `json:"created"`
+	Updated     int64 `json:"updated"`
+}
+
+// LDAPGroupMapping grants a membership of a space to the members of an LDAP group.
+type LDAPGroupMapping struct {
+	ID        int64               `json:"id"`
+	GroupDN   string              `json:"group_dn"`
+	SpaceID   int64               `json:"-"`
+	SpacePath string              `json:"space_path"`
+	Role      enum.MembershipRole `json:"role"`
+	CreatedBy int64               `json:"-"`
+	Created   int64               `json:"created"`
+	Updated   int64               `json:"updated"`
+}
+
+// LDAPSyncReport lists the changes of a sync with the LDAP directory.
+type LDAPSyncReport struct {
+	// DryRun is set if the changes were only computed, but not applied.
+	DryRun bool `json:"dry_run"`
+	// DirectoryUsers is the number of users found in the directory.
+	DirectoryUsers int              `json:"directory_users"`
+	Changes        []LDAPSyncChange `json:"changes"`
+	Started        int64            `json:"started"`
+	Finished       int64            `json:"finished"`
+}
+
+// LDAPSyncChange is a single change of a sync with the LDAP directory.
+type LDAPSyncChange struct {
+	Action      enum.LDAPSyncAction `json:"action"`
+	Login       string              `json:"login"`
+	UserUID     string              `json:"user_uid,omitempty"`
+	Email       string              `json:"email,omitempty"`
+	DisplayName string              `json:"display_name,omitempty"`
+	SpacePath   string              `json:"space_path,omitempty"`
+	Role        enum.MembershipRole `json:"role,omitempty"`
+}
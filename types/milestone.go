@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// Milestone represents a milestone of a repository to which pull requests and issues can be assigned.
+type Milestone struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"-"` // not returned, it's an internal field
+	RepoID  int64 `json:"repo_id"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     *int64 `json:"due_date,omitempty"`
+
+	State  enum.MilestoneState `json:"state"`
+	Closed *int64              `json:"closed,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Progress MilestoneProgress `json:"progress"`
+}
+
+// MilestoneProgress holds the number of open and closed pull requests and issues of a milestone.
+type MilestoneProgress struct {
+	OpenIssues     int64 `json:"open_issues"`
+	ClosedIssues   int64 `json:"closed_issues"`
+	OpenPullReqs   int64 `json:"open_pullreqs"`
+	ClosedPullReqs int64 `json:"closed_pullreqs"`
+
+	// Percentage is the share of the closed items (both pull requests and issues) in the milestone.
+	Percentage int `json:"percentage"`
+}
+
+// MilestoneFilter stores milestone query parameters.
+type MilestoneFilter struct {
+	Page   int                   `json:"page"`
+	Size   int                   `json:"size"`
+	Query  string                `json:"query"`
+	States []enum.MilestoneState `json:"state"`
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// OAuthProvider is an external identity provider users can login with.
+type OAuthProvider struct {
+	ID          int64                  `json:"-"`
+	Identifier  string                 `json:"identifier"`
+	Type        enum.OAuthProviderType `json:"type"`
+	DisplayName string                 `json:"display_name"`
+	ClientID    string                 `json:"client_id"`
+	// ClientSecret is the encrypted client secret of the OAuth application.
+	ClientSecret []byte `json:"-"`
+	// BaseURL is the URL of a self-hosted provider instance (e.g. GitHub Enterprise or GitLab).
+	BaseURL string `json:"base_url,omitempty"`
+	Enabled bool   `json:"enabled"`
+	// AllowSignup allows users without an account to sign up via the provider.
+	AllowSignup bool `json:"allow_signup"`
+	// TrustSecondFactor trusts the provider to enforce multi-factor authentication. Otherwise users
+	// that enabled two-factor authentication can't login via the provider.
+	TrustSecondFactor bool  `json:"trust_second_factor"`
+	Created           int64 `json:"created"`
+	Updated           int64 `json:"updated"`
+}
+
+// OAuthProviderInfo is the public information about an enabled provider, used by the login page.
+type OAuthProviderInfo struct {
+	Identifier  string                 `json:"identifier"`
+	Type        enum.OAuthProviderType `json:"type"`
+	DisplayName string                 `json:"display_name"`
+}
+
+// OAuthIdentity links a principal to the account of the principal with an OAuth provider.
+type OAuthIdentity struct {
+	ID          int64 `json:"id"`
+	PrincipalID int64 `json:"-"`
+	ProviderID  int64 `json:"-"`
+	// ExternalID is the immutable identifier of the account with the provider.
+	ExternalID    string `json:"external_id"`
+	ExternalLogin string `json:"external_login"`
+	Email         string `json:"email"`
+	Created       int64  `json:"created"`
+	Updated       int64  `json:"updated"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PipelineArtifact is a file uploaded by a step of a pipeline execution, e.g. a binary or a test report.
+type PipelineArtifact struct {
+	ID          int64 `json:"-"`
+	RepoID      int64 `json:"repo_id"`
+	ExecutionID int64 `json:"execution_id"`
+	// Name is the path of the artifact, unique within the execution.
+	Name string `json:"name"`
+	// BlobPath is the path of the file in the blob store of the repository.
+	BlobPath string `json:"-"`
+	Size     int64  `json:"size"`
+	Created  int64  `json:"created"`
+	Updated  int64  `json:"updated"`
+}
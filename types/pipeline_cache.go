@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PipelineCache is an entry of the build cache of the pipelines of a repository.
+// Cache steps restore and save the entries by a key, usually including the checksum of lockfiles.
+type PipelineCache struct {
+	ID     int64  `json:"-"`
+	RepoID int64  `json:"repo_id"`
+	Key    string `json:"key"`
+	// BlobPath is the path of the archive in the blob store of the repository.
+	BlobPath string `json:"-"`
+	Size     int64  `json:"size"`
+	Created  int64  `json:"created"`
+	Updated  int64  `json:"updated"`
+	LastUsed int64  `json:"last_used"`
+}
+
+// PipelineCacheUsage is the build cache of the pipelines of a repository along with its size.
+type PipelineCacheUsage struct {
+	Entries []*PipelineCache `json:"entries"`
+	Size    int64            `json:"size"`
+	// Quota is the max total size of the entries, zero if unlimited.
+	Quota int64 `json:"quota"`
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+// PipelineClone configures the implicit clone step of the pipelines of a repository.
+// It can be defined on a repository or a space and is overridden by the clone section of the pipeline YAML.
+type PipelineClone struct {
+	// Disabled skips the clone step, the pipeline has to clone the repository itself if needed.
+	Disabled bool `json:"disabled,omitempty"`
+	// Image is the image of the clone step, e.g. a mirror of drone/git in an air-gapped registry.
+	Image string `json:"image,omitempty"`
+	// Depth limits the clone to the provided number of commits. Zero clones the full history.
+	Depth int `json:"depth,omitempty"`
+	// Submodules initializes and updates the submodules of the repository after cloning it.
+	Submodules bool `json:"submodules,omitempty"`
+}
+
+func (c *PipelineClone) Sanitize() error {
+	c.Image = strings.TrimSpace(c.Image)
+	if strings.ContainsAny(c.Image, " \t\r\n") {
+		return errors.InvalidArgument("Clone image %q is invalid.", c.Image)
+	}
+
+	if c.Depth < 0 {
+		return errors.InvalidArgument("Clone depth can't be negative.")
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PipelineInput is an input parameter of a pipeline, defined in the pipeline YAML.
+// The values of the inputs are provided when an execution is created manually
+// and are available to the steps as environment variables.
+type PipelineInput struct {
+	Name        string                 `json:"name"`
+	Type        enum.PipelineInputType `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	Default     string                 `json:"default,omitempty"`
+	Required    bool                   `json:"required"`
+	Options     []string               `json:"options,omitempty"`
+}
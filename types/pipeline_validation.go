@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PipelineValidation is the result of the validation of a pipeline YAML.
+type PipelineValidation struct {
+	// Valid is true if no errors were found, warnings don't make the YAML invalid.
+	Valid    bool               `json:"valid"`
+	Problems []*PipelineProblem `json:"problems"`
+}
+
+// PipelineProblem is a problem found in a pipeline YAML.
+// Line and column are 1-based, they are zero if the position of the problem is unknown.
+type PipelineProblem struct {
+	Type     enum.PipelineProblemType     `json:"type"`
+	Severity enum.PipelineProblemSeverity `json:"severity"`
+	Line     int                          `json:"line"`
+	Column   int                          `json:"column"`
+	Message  string                       `json:"message"`
+}
@@ -16,6 +16,9 @@
 package types
 
 import (
+	"net/url"
+	"strconv"
+
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -36,6 +39,9 @@ type Principal struct {
 	Blocked bool   `db:"principal_blocked"            json:"blocked"`
 	Salt    string `db:"principal_salt"               json:"-"`
 
+	// Avatar is the file name of the uploaded avatar image (empty if none was uploaded).
+	Avatar string `db:"principal_avatar"             json:"-"`
+
 	// Other info
 	Created int64 `db:"principal_created"                json:"created"`
 	Updated int64 `db:"principal_updated"                json:"updated"`
@@ -48,6 +54,7 @@ func (p *Principal) ToPrincipalInfo() *PrincipalInfo {
 		DisplayName: p.DisplayName,
 		Email:       p.Email,
 		Type:        p.Type,
+		AvatarURL:   PrincipalAvatarPath(p.ID, p.Type, p.Avatar),
 		Created:     p.Created,
 		Updated:     p.Updated,
 	}
@@ -60,10 +67,27 @@ type PrincipalInfo struct {
 	DisplayName string             `json:"display_name"`
 	Email       string             `json:"email"`
 	Type        enum.PrincipalType `json:"type"`
+	AvatarURL   string             `json:"avatar_url,omitempty"`
 	Created     int64              `json:"created"`
 	Updated     int64              `json:"updated"`
 }
 
+// PrincipalAvatarPath returns the path (without scheme and host) from which the avatar of a user can be fetched.
+// The name of the uploaded avatar is added as query parameter to make the path change whenever the avatar does,
+// users without an uploaded avatar get served a gravatar image (if enabled).
+func PrincipalAvatarPath(principalID int64, principalType enum.PrincipalType, avatar string) string {
+	if principalType != enum.PrincipalTypeUser {
+		return ""
+	}
+
+	path := "/api/v1/principals/" + strconv.FormatInt(principalID, 10) + "/avatar"
+	if avatar != "" {
+		path += "?v=" + url.QueryEscape(avatar)
+	}
+
+	return path
+}
+
 func (p *PrincipalInfo) Identifier() int64 {
 	return p.ID
 }
@@ -27,10 +27,16 @@ type PublicKey struct {
 	Content     string              `json:"-"`
 	Comment     string              `json:"comment"`
 	Type        string              `json:"type"`
+
+	Scheme    enum.PublicKeyScheme `json:"scheme"`
+	ValidFrom *int64               `json:"valid_from"`
+	ValidTo   *int64               `json:"valid_to"`
+	Revoked   *int64               `json:"revoked"`
 }
 
 type PublicKeyFilter struct {
 	ListQueryFilter
-	Sort  enum.PublicKeySort
-	Order enum.Order
+	Sort   enum.PublicKeySort
+	Order  enum.Order
+	Usages []enum.PublicKeyUsage
 }
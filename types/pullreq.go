@@ -60,11 +60,15 @@ type PullReq struct {
 	RebaseCheckStatus enum.MergeCheckStatus `json:"rebase_check_status"`
 	RebaseConflicts   []string              `json:"rebase_conflicts,omitempty"`
 
+	MilestoneID *int64 `json:"milestone_id,omitempty"`
+
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
 
 	Labels []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
+
+	Risk *PullReqRisk `json:"risk,omitempty"`
 }
 
 func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []string) {
@@ -142,6 +146,26 @@ type PullReqStats struct {
 	UnresolvedCount int `json:"unresolved_count,omitempty"`
 }
 
+// PullReqRisk holds the size and risk annotation of a pull request.
+type PullReqRisk struct {
+	Size    enum.PullReqSize      `json:"size"`
+	Level   enum.PullReqRiskLevel `json:"level"`
+	Score   int                   `json:"score"`
+	Metrics PullReqRiskMetrics    `json:"metrics"`
+}
+
+// PullReqRiskMetrics holds the metrics used to calculate the risk of a pull request.
+type PullReqRiskMetrics struct {
+	LinesChanged int64 `json:"lines_changed"`
+	FilesChanged int64 `json:"files_changed"`
+	// Modules is the number of distinct top level directories touched by the pull request.
+	Modules int `json:"modules"`
+	// ModuleEntropy is the Shannon entropy (in bits) of the distribution of changed files across modules.
+	ModuleEntropy float64 `json:"module_entropy"`
+	// DefectDensity is the ratio of bug fix commits in the recent history of the touched modules.
+	DefectDensity float64 `json:"defect_density"`
+}
+
 // PullReqFilter stores pull request query parameters.
 type PullReqFilter struct {
 	Page               int                          `json:"page"`
@@ -163,6 +187,7 @@ type PullReqFilter struct {
 	ReviewerID         int64                        `json:"reviewer_id"`
 	ReviewDecisions    []enum.PullReqReviewDecision `json:"review_decisions"`
 	MentionedID        int64                        `json:"mentioned_id"`
+	MilestoneID        int64                        `json:"milestone_id"`
 	IncludeDescription bool                         `json:"include_description"`
 	CreatedFilter
 	UpdatedFilter
@@ -230,15 +255,26 @@ type UserGroupReviewerDecision struct {
 	Reviewer       PrincipalInfo              `json:"reviewer"`
 }
 
+// DefaultReviewer is a principal or a user group that is requested to review every new pull request of a repository.
+type DefaultReviewer struct {
+	PrincipalID int64 `json:"principal_id,omitempty"`
+	UserGroupID int64 `json:"usergroup_id,omitempty"`
+
+	// Paths optionally restricts the reviewer to pull requests that change a file matching one of the patterns.
+	Paths []string `json:"paths,omitempty"`
+}
+
 // PullReqFileView represents a file reviewed entry for a given pr and principal.
 // NOTE: keep api lightweight and don't return unnecessary extra data.
 type PullReqFileView struct {
 	PullReqID   int64 `json:"-"`
 	PrincipalID int64 `json:"-"`
 
-	Path     string `json:"path"`
-	SHA      string `json:"sha"`
-	Obsolete bool   `json:"obsolete"`
+	Path string `json:"path"`
+	SHA  string `json:"sha"`
+	// CommitSHA is the pull request's head commit at which the file was marked as viewed.
+	CommitSHA string `json:"commit_sha"`
+	Obsolete  bool   `json:"obsolete"`
 
 	Created int64 `json:"-"`
 	Updated int64 `json:"-"`
@@ -250,22 +286,24 @@ type MergeResponse struct {
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 
 	// values only returned on dryrun
-	DryRun                              bool               `json:"dry_run,omitempty"`
-	Mergeable                           bool               `json:"mergeable,omitempty"`
-	ConflictFiles                       []string           `json:"conflict_files,omitempty"`
-	AllowedMethods                      []enum.MergeMethod `json:"allowed_methods,omitempty"`
-	MinimumRequiredApprovalsCount       int                `json:"minimum_required_approvals_count,omitempty"`
-	MinimumRequiredApprovalsCountLatest int                `json:"minimum_required_approvals_count_latest,omitempty"`
-	RequiresCodeOwnersApproval          bool               `json:"requires_code_owners_approval,omitempty"`
-	RequiresCodeOwnersApprovalLatest    bool               `json:"requires_code_owners_approval_latest,omitempty"`
-	RequiresCommentResolution           bool               `json:"requires_comment_resolution,omitempty"`
-	RequiresNoChangeRequests            bool               `json:"requires_no_change_requests,omitempty"`
+	DryRun                              bool                    `json:"dry_run,omitempty"`
+	Mergeable                           bool                    `json:"mergeable,omitempty"`
+	ConflictFiles                       []string                `json:"conflict_files,omitempty"`
+	AllowedMethods                      []enum.MergeMethod      `json:"allowed_methods,omitempty"`
+	MinimumRequiredApprovalsCount       int                     `json:"minimum_required_approvals_count,omitempty"`
+	MinimumRequiredApprovalsCountLatest int                     `json:"minimum_required_approvals_count_latest,omitempty"`
+	RequiresCodeOwnersApproval          bool                    `json:"requires_code_owners_approval,omitempty"`
+	RequiresCodeOwnersApprovalLatest    bool                    `json:"requires_code_owners_approval_latest,omitempty"`
+	RequiresCommentResolution           bool                    `json:"requires_comment_resolution,omitempty"`
+	RequiresNoChangeRequests            bool                    `json:"requires_no_change_requests,omitempty"`
+	UnmergedDependencies                []PullReqDependencyNode `json:"unmerged_dependencies,omitempty"`
 }
 
 type MergeViolations struct {
-	Message        string           `json:"message,omitempty"`
-	ConflictFiles  []string         `json:"conflict_files,omitempty"`
-	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+	Message              string                  `json:"message,omitempty"`
+	ConflictFiles        []string                `json:"conflict_files,omitempty"`
+	RuleViolations       []RuleViolations        `json:"rule_violations,omitempty"`
+	UnmergedDependencies []PullReqDependencyNode `json:"unmerged_dependencies,omitempty"`
 }
 
 type PullReqRepo struct {
@@ -61,6 +61,7 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadCommentStatus{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -150,6 +151,15 @@ func (a *PullRequestActivityPayloadReviewerDelete) ActivityType() enum.PullReqAc
 	return enum.PullReqActivityTypeReviewerDelete
 }
 
+type PullRequestActivityPayloadReviewDismiss struct {
+	CommitSHA    string  `json:"commit_sha"`
+	PrincipalIDs []int64 `json:"principal_ids"`
+}
+
+func (a *PullRequestActivityPayloadReviewDismiss) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeReviewDismiss
+}
+
 type PullRequestActivityPayloadBranchUpdate struct {
 	Old    string `json:"old"`
 	New    string `json:"new"`
@@ -178,6 +188,15 @@ func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqAct
 	return enum.PullReqActivityTypeBranchRestore
 }
 
+type PullRequestActivityPayloadCommentStatus struct {
+	CommentID int64                     `json:"comment_id"`
+	Status    enum.PullReqCommentStatus `json:"status"`
+}
+
+func (a *PullRequestActivityPayloadCommentStatus) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeCommentStatus
+}
+
 type PullRequestActivityLabel struct {
 	Label         string                        `json:"label"`
 	LabelColor    enum.LabelColor               `json:"label_color"`
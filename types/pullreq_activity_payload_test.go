@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPullRequestActivityPayloadCommentStatus(t *testing.T) {
+	payload := &PullRequestActivityPayloadCommentStatus{
+		CommentID: 42,
+		Status:    enum.PullReqCommentStatusResolved,
+	}
+
+	act := &PullReqActivity{Type: enum.PullReqActivityTypeCommentStatus}
+	if err := act.SetPayload(payload); err != nil {
+		t.Fatalf("failed to set payload: %v", err)
+	}
+
+	if want := `{"comment_id":42,"status":"resolved"}`; string(act.PayloadRaw) != want {
+		t.Errorf("raw payload = %s, want %s", act.PayloadRaw, want)
+	}
+
+	got, err := act.GetPayload()
+	if err != nil {
+		t.Fatalf("failed to get payload: %v", err)
+	}
+	if status, ok := got.(*PullRequestActivityPayloadCommentStatus); !ok || *status != *payload {
+		t.Errorf("payload = %+v, want %+v", got, payload)
+	}
+
+	// the payload can't be attached to an activity of another type.
+	if err = (&PullReqActivity{Type: enum.PullReqActivityTypeComment}).SetPayload(payload); err == nil {
+		t.Errorf("expected an error for an activity of another type")
+	}
+}
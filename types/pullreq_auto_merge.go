@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullReqAutoMerge is the request to merge a pull request automatically, as soon as it can be merged.
+// The pull request gets merged on behalf of the principal who enabled the auto-merge,
+// only if its source branch still points to the commit at which the auto-merge was enabled.
+type PullReqAutoMerge struct {
+	PullReqID int64            `json:"-"`
+	RepoID    int64            `json:"-"`
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+	CreatedBy int64            `json:"created_by"`
+	Created   int64            `json:"created"`
+
+	EnabledBy *PrincipalInfo `json:"enabled_by,omitempty"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullReqDependency declares that a pull request can't be merged before the pull request it depends on.
+type PullReqDependency struct {
+	PullReqID   int64 `json:"pullreq_id"`
+	DependsOnID int64 `json:"depends_on_id"`
+	CreatedBy   int64 `json:"created_by"`
+	Created     int64 `json:"created"`
+}
+
+// PullReqDependencyNode is a pull request in a dependency graph.
+// Nodes of pull requests in repositories the caller can't view are redacted, only their ID and state are set.
+type PullReqDependencyNode struct {
+	ID       int64             `json:"id"`
+	RepoID   int64             `json:"repo_id,omitempty"`
+	RepoPath string            `json:"repo_path,omitempty"`
+	Number   int64             `json:"number,omitempty"`
+	Title    string            `json:"title,omitempty"`
+	State    enum.PullReqState `json:"state"`
+	Redacted bool              `json:"redacted,omitempty"`
+}
+
+// PullReqDependencyGraph is the graph of all pull requests a pull request (transitively) depends on.
+// The edges point from a pull request to the pull requests it depends on.
+type PullReqDependencyGraph struct {
+	Nodes []PullReqDependencyNode `json:"nodes"`
+	Edges []PullReqDependency     `json:"edges"`
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "time"
+
+// ReviewSLAPolicy defines the review service level agreement of the pull requests of a space.
+// The SLA is measured in business time, as defined by the business calendar.
+type ReviewSLAPolicy struct {
+	Enabled bool `json:"enabled"`
+	// FirstResponseHours is the business time (in hours) in which a pull request has to receive
+	// its first review or comment from anyone but its author.
+	FirstResponseHours int `json:"first_response_hours"`
+	// WarningPercent is the percentage of the SLA after which a warning is emitted (0 disables warnings).
+	WarningPercent int              `json:"warning_percent"`
+	Calendar       BusinessCalendar `json:"calendar"`
+}
+
+// BusinessCalendar defines the business hours used to measure review SLAs.
+type BusinessCalendar struct {
+	// TimeZone is the IANA name of the time zone of the business hours (e.g. Europe/Berlin).
+	TimeZone string `json:"time_zone"`
+	// WorkDays are the business days of the week (0 = Sunday, ..., 6 = Saturday), Monday to Friday by default.
+	WorkDays []time.Weekday `json:"work_days"`
+	// StartHour and EndHour define the business hours of a work day (e.g. 9 to 17), the whole day by default.
+	StartHour int `json:"start_hour"`
+	EndHour   int `json:"end_hour"`
+	// Holidays are dates (formatted as YYYY-MM-DD) that aren't business days.
+	Holidays []string `json:"holidays"`
+}
+
+// SpaceReviewSLA holds the review SLA configuration of a space.
+type SpaceReviewSLA struct {
+	// Policy is the review SLA policy of the space, nil in case the space inherits the policy.
+	Policy *ReviewSLAPolicy `json:"policy"`
+	// EffectivePolicy is the review SLA policy applied to the pull requests of the space.
+	EffectivePolicy *ReviewSLAPolicy `json:"effective_policy"`
+}
+
+// PullReqReviewSLA tracks the review SLA of a single pull request.
+type PullReqReviewSLA struct {
+	PullReqID int64 `json:"-"`
+	RepoID    int64 `json:"-"`
+
+	// Started is the time the SLA started (pull request got opened or reopened).
+	Started int64 `json:"started"`
+	// WarnAt is the time a warning is emitted if the pull request didn't get a response yet (0 = no warning).
+	WarnAt int64 `json:"warn_at"`
+	// DueAt is the time the pull request has to receive its first response by.
+	DueAt int64 `json:"due_at"`
+
+	// Responded is the time of the first review or comment of anyone but the author.
+	Responded *int64 `json:"responded,omitempty"`
+	// ResponseTime is the business time (in milliseconds) it took to receive the first response.
+	ResponseTime *int64 `json:"response_time,omitempty"`
+	// Closed is the time the pull request got closed or merged.
+	Closed *int64 `json:"closed,omitempty"`
+
+	// Warned and Breached are the times the SLA warning and breach were reported.
+	Warned   *int64 `json:"warned,omitempty"`
+	Breached *int64 `json:"breached,omitempty"`
+}
+
+// ReviewSLAStats holds the review SLA compliance of a set of pull requests.
+type ReviewSLAStats struct {
+	// Met is the number of pull requests that got their first response in time.
+	Met int64 `json:"met"`
+	// Breached is the number of pull requests that got their first response late or not at all.
+	Breached int64 `json:"breached"`
+	// Pending is the number of open pull requests that are still waiting for their first response.
+	Pending int64 `json:"pending"`
+	// AvgResponseTime is the average business time (in milliseconds) until the first response.
+	AvgResponseTime int64 `json:"avg_response_time"`
+}
+
+// ReviewSLATeamStats holds the review SLA compliance of the pull requests a team was requested to review.
+type ReviewSLATeamStats struct {
+	// Team is the user group requested as reviewer, nil for pull requests without any user group reviewer.
+	Team *UserGroupInfo `json:"team"`
+	ReviewSLAStats
+}
+
+// ReviewSLAReport is the review SLA compliance report of the pull requests of a space and its sub-spaces.
+type ReviewSLAReport struct {
+	// From and To define the time range (unix millis) in which the reported pull requests were opened.
+	From  int64                `json:"from"`
+	To    int64                `json:"to"`
+	Total ReviewSLAStats       `json:"total"`
+	Teams []ReviewSLATeamStats `json:"teams"`
+}
+
+// ReviewSLAReportFilter stores the review SLA report query parameters.
+type ReviewSLAReportFilter struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
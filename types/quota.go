@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/errors"
+
+// SpaceQuota defines the resource limits of a space. The limits apply to the space and all its sub-spaces.
+// A nil limit means the resource isn't limited by the space.
+type SpaceQuota struct {
+	// MaxRepos is the maximum number of repositories.
+	MaxRepos *int64 `json:"max_repos"`
+	// MaxStorage is the maximum total size of the git repositories in bytes.
+	MaxStorage *int64 `json:"max_storage"`
+	// MaxPipelineMinutes is the maximum number of pipeline execution minutes per calendar month (UTC).
+	MaxPipelineMinutes *int64 `json:"max_pipeline_minutes"`
+}
+
+func (q *SpaceQuota) Sanitize() error {
+	if q.MaxRepos != nil && *q.MaxRepos < 0 {
+		return errors.InvalidArgument("Maximum number of repositories can't be negative.")
+	}
+	if q.MaxStorage != nil && *q.MaxStorage < 0 {
+		return errors.InvalidArgument("Maximum storage can't be negative.")
+	}
+	if q.MaxPipelineMinutes != nil && *q.MaxPipelineMinutes < 0 {
+		return errors.InvalidArgument("Maximum pipeline minutes can't be negative.")
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if the quota doesn't limit any resource.
+func (q *SpaceQuota) IsEmpty() bool {
+	return q.MaxRepos == nil && q.MaxStorage == nil && q.MaxPipelineMinutes == nil
+}
+
+// SpaceUsage holds the resource usage of a space and all its sub-spaces.
+type SpaceUsage struct {
+	// Repos is the number of active repositories.
+	Repos int64 `json:"repos"`
+	// Storage is the total size of the git repositories in bytes.
+	Storage int64 `json:"storage"`
+	// PipelineMinutes is the number of pipeline execution minutes used in the current calendar month.
+	PipelineMinutes int64 `json:"pipeline_minutes"`
+	// PipelinePeriodStart is the start of the current calendar month (unix millis).
+	PipelinePeriodStart int64 `json:"pipeline_period_start"`
+}
+
+// SpaceQuotaUsage holds the quota of a space along with its current resource usage.
+type SpaceQuotaUsage struct {
+	Quota SpaceQuota `json:"quota"`
+	Usage SpaceUsage `json:"usage"`
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	minRateLimitWindow = time.Second
+	maxRateLimitWindow = time.Hour
+)
+
+// RateLimit is the number of requests allowed per window for a class of routes. Zero means no limit.
+type RateLimit struct {
+	// PerPrincipal limits the requests of each authenticated principal.
+	PerPrincipal int `json:"per_principal"`
+	// PerIP limits the anonymous requests of each IP address.
+	PerIP int `json:"per_ip"`
+}
+
+// RateLimits are the rate limits of the instance. They default to the server config and can be tuned by admins.
+type RateLimits struct {
+	Enabled bool `json:"enabled"`
+	// WindowSeconds is the length of the time window the limits apply to.
+	WindowSeconds int64                             `json:"window_seconds"`
+	Classes       map[enum.RateLimitClass]RateLimit `json:"classes"`
+}
+
+// Window returns the time window the limits apply to.
+func (l *RateLimits) Window() time.Duration {
+	return time.Duration(l.WindowSeconds) * time.Second
+}
+
+func (l *RateLimits) Sanitize() error {
+	if window := l.Window(); window < minRateLimitWindow || window > maxRateLimitWindow {
+		return errors.InvalidArgument("Rate limit window must be between %s and %s.",
+			minRateLimitWindow, maxRateLimitWindow)
+	}
+
+	for class, limit := range l.Classes {
+		if _, ok := class.Sanitize(); !ok {
+			return errors.InvalidArgument("Unknown rate limit class %q.", class)
+		}
+		if limit.PerPrincipal < 0 || limit.PerIP < 0 {
+			return errors.InvalidArgument("Rate limits of class %q can't be negative.", class)
+		}
+	}
+
+	return nil
+}
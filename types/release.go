@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Release is a published version of a repository, tied to one of its tags.
+type Release struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"-"` // not returned, it's an internal field
+	RepoID  int64 `json:"repo_id"`
+
+	TagName string `json:"tag_name"`
+	Title   string `json:"title"`
+	Notes   string `json:"notes"`
+
+	IsDraft      bool `json:"is_draft"`
+	IsPrerelease bool `json:"is_prerelease"`
+	// Published is the time the release was published, it's not set for drafts.
+	Published *int64 `json:"published,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Assets []*ReleaseAsset `json:"assets"`
+}
+
+// ReleaseAsset is a binary file attached to a release.
+type ReleaseAsset struct {
+	ID        int64 `json:"id"`
+	ReleaseID int64 `json:"release_id"`
+	RepoID    int64 `json:"repo_id"`
+
+	Name string `json:"name"`
+	// FileName is the name of the file in the blob store.
+	FileName    string `json:"-"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+}
+
+type ReleaseFilter struct {
+	Page  int    `json:"page"`
+	Size  int    `json:"size"`
+	Query string `json:"query"`
+	// IncludeDrafts includes the draft releases in the result.
+	IncludeDrafts bool `json:"include_drafts"`
+}
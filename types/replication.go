@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ReplicationRecord is a single reference update of a repository, recorded for external replication tools.
+// Records are created concurrently and get their sequence number once they are visible to consumers,
+// so a record never shows up after a record with a greater sequence number has been read.
+// Records of the same reference are always sequenced in the order of the reference updates.
+type ReplicationRecord struct {
+	ID         int64  `json:"-"`
+	Seq        int64  `json:"seq"`
+	RepoID     int64  `json:"repo_id"`
+	RepoGitUID string `json:"repo_git_uid"`
+	Ref        string `json:"ref"`
+	OldSHA     string `json:"old_sha"`
+	NewSHA     string `json:"new_sha"`
+	Created    int64  `json:"created"`
+
+	// PackAvailable is true once the push completed and the objects of the new SHA can be fetched
+	// from the repository. Records are recorded before the push completes and are sequenced
+	// only once their pack is available, hence it's always true for records returned to consumers.
+	PackAvailable bool `json:"pack_available"`
+}
+
+// ReplicationRecordFilter stores replication record query parameters.
+type ReplicationRecordFilter struct {
+	// After is the sequence number of the last record the caller has seen,
+	// only records with a greater sequence number are returned.
+	After int64 `json:"after"`
+	Limit int   `json:"limit"`
+}
+
+// ReplicationConsumer is an external replication tool that acknowledges the replication records it applied.
+type ReplicationConsumer struct {
+	ID          int64  `json:"-"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+
+	// AckedSeq is the sequence number of the last replication record the consumer acknowledged.
+	AckedSeq int64 `json:"acked_seq"`
+	// Acked is the time of the last acknowledgment.
+	Acked int64 `json:"acked"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Lag ReplicationLag `json:"lag"`
+}
+
+// ReplicationLag describes how far a replication consumer is behind.
+type ReplicationLag struct {
+	// Records is the number of records the consumer didn't acknowledge yet.
+	Records int64 `json:"records"`
+	// Time is the age (in milliseconds) of the oldest record the consumer didn't acknowledge yet.
+	Time int64 `json:"time"`
+}
@@ -15,6 +15,9 @@
 package types
 
 import (
+	"slices"
+
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -37,12 +40,17 @@ type Repository struct {
 	// SizeUpdated is the time when the Size was last updated.
 	SizeUpdated int64 `json:"size_updated" yaml:"size_updated"`
 
-	GitUID        string `json:"-" yaml:"-"`
-	DefaultBranch string `json:"default_branch" yaml:"default_branch"`
-	ForkID        int64  `json:"fork_id" yaml:"fork_id"`
-	PullReqSeq    int64  `json:"-" yaml:"-"`
+	GitUID      string `json:"-" yaml:"-"`
+	StoragePool string `json:"-" yaml:"-"`
+	// ObjectFormat is the hash algorithm used by the repository (sha1 or sha256).
+	ObjectFormat  sha.ObjectFormat `json:"object_format" yaml:"object_format"`
+	DefaultBranch string           `json:"default_branch" yaml:"default_branch"`
+	ForkID        int64            `json:"fork_id" yaml:"fork_id"`
+	PullReqSeq    int64            `json:"-" yaml:"-"`
+	IssueSeq      int64            `json:"-" yaml:"-"`
 
 	NumForks       int `json:"num_forks" yaml:"num_forks"`
+	NumStars       int `json:"num_stars" yaml:"num_stars"`
 	NumPulls       int `json:"num_pulls" yaml:"num_pulls"`
 	NumClosedPulls int `json:"num_closed_pulls" yaml:"num_closed_pulls"`
 	NumOpenPulls   int `json:"num_open_pulls" yaml:"num_open_pulls"`
@@ -51,6 +59,13 @@ type Repository struct {
 	State   enum.RepoState `json:"state" yaml:"-"`
 	IsEmpty bool           `json:"is_empty,omitempty" yaml:"is_empty"`
 
+	// Archived repositories are read-only, they can't be pushed to or modified.
+	Archived bool `json:"archived" yaml:"archived"`
+	// IsTemplate indicates that new repositories can be generated from the repository's content.
+	IsTemplate bool `json:"is_template" yaml:"is_template"`
+	// Topics are free-form lower case labels used to categorize and discover repositories.
+	Topics []string `json:"topics" yaml:"topics"`
+
 	// git urls
 	GitURL    string `json:"git_url" yaml:"-"`
 	GitSSHURL string `json:"git_ssh_url,omitempty" yaml:"-"`
@@ -64,6 +79,7 @@ func (r Repository) Clone() Repository {
 		deleted = &id
 	}
 	r.Deleted = deleted
+	r.Topics = slices.Clone(r.Topics)
 
 	return r
 }
@@ -81,6 +97,11 @@ func (r Repository) GetGitUID() string {
 	return r.GitUID
 }
 
+// WikiGitUID returns the git uid of the companion git repository that holds the wiki pages of the repository.
+func (r Repository) WikiGitUID() string {
+	return r.GitUID + ".wiki"
+}
+
 // RepoFilter stores repo query parameters.
 type RepoFilter struct {
 	Page              int           `json:"page"`
@@ -90,9 +111,24 @@ type RepoFilter struct {
 	Order             enum.Order    `json:"order"`
 	DeletedAt         *int64        `json:"deleted_at,omitempty"`
 	DeletedBeforeOrAt *int64        `json:"deleted_before_or_at,omitempty"`
+	Archived          *bool         `json:"archived,omitempty"`
+	Topic             string        `json:"topic,omitempty"`
 	Recursive         bool
 }
 
+// RepoTopic is a repository topic with the number of repositories using it.
+type RepoTopic struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// RepoTopicFilter stores repository topic query parameters.
+type RepoTopicFilter struct {
+	Query     string `json:"query"`
+	Size      int    `json:"size"`
+	Recursive bool   `json:"recursive"`
+}
+
 // RepositoryGitInfo holds git info for a repository.
 type RepositoryGitInfo struct {
 	ID       int64
@@ -120,3 +156,12 @@ type RepositoryCount struct {
 	SpaceUID string `json:"space_uid"`
 	Total    int    `json:"total"`
 }
+
+// RepositoryRedirect redirects the former path of a moved repository to the repository.
+type RepositoryRedirect struct {
+	SpaceID    int64  `json:"space_id"`
+	Identifier string `json:"identifier"`
+	RepoID     int64  `json:"repo_id"`
+	CreatedBy  int64  `json:"created_by"`
+	Created    int64  `json:"created"`
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepoInsightsState holds the progress of the incremental computation of the insights of a repository.
+type RepoInsightsState struct {
+	RepoID    int64  `json:"-"`
+	Stale     bool   `json:"stale"`
+	Branch    string `json:"branch"`
+	CommitSHA string `json:"commit_sha"`
+	Created   int64  `json:"-"`
+	Updated   int64  `json:"-"`
+	Processed int64  `json:"processed"`
+}
+
+// RepoInsightsCommitStats holds the commit statistics of an author in a week.
+type RepoInsightsCommitStats struct {
+	Week        int64
+	AuthorEmail string
+	AuthorName  string
+	Commits     int64
+	Additions   int64
+	Deletions   int64
+}
+
+// RepoInsightsWeek holds the commit activity of a repository in a week.
+type RepoInsightsWeek struct {
+	Week      int64 `json:"week" db:"week"`
+	Commits   int64 `json:"commits" db:"commits"`
+	Additions int64 `json:"additions" db:"additions"`
+	Deletions int64 `json:"deletions" db:"deletions"`
+}
+
+// RepoContributor holds the commit statistics of an author over a period of time.
+type RepoContributor struct {
+	Name      string `json:"name" db:"name"`
+	Email     string `json:"email" db:"email"`
+	Commits   int64  `json:"commits" db:"commits"`
+	Additions int64  `json:"additions" db:"additions"`
+	Deletions int64  `json:"deletions" db:"deletions"`
+}
+
+// RepoInsightsPullReqStats holds the number of pull requests opened, merged and closed in a week.
+type RepoInsightsPullReqStats struct {
+	Week   int64 `json:"week" db:"week"`
+	Opened int64 `json:"opened" db:"opened"`
+	Merged int64 `json:"merged" db:"merged"`
+	Closed int64 `json:"closed" db:"closed"`
+}
+
+// RepoInsights holds the statistics of a repository over a period of time.
+// The weeks are identified by the Unix time millis of their start, Monday 00:00 UTC.
+type RepoInsights struct {
+	State             *RepoInsightsState         `json:"state"`
+	Since             int64                      `json:"since"`
+	CommitActivity    []RepoInsightsWeek         `json:"commit_activity"`
+	TopContributors   []RepoContributor          `json:"top_contributors"`
+	PullReqThroughput []RepoInsightsPullReqStats `json:"pullreq_throughput"`
+}
+
+// RepoInsightsFilter stores repository insights query parameters.
+type RepoInsightsFilter struct {
+	Weeks        int `json:"weeks"`
+	Contributors int `json:"contributors"`
+}
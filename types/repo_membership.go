@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoMembershipKey can be used as a key for finding a principal's repository membership info.
+type RepoMembershipKey struct {
+	RepoID      int64
+	PrincipalID int64
+}
+
+// RepoMembership represents a principal's membership of a repository.
+// It grants the permissions of the role on the repository only, in addition to any space memberships.
+type RepoMembership struct {
+	RepoMembershipKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+}
+
+// RepoMembershipUser adds user info to the RepoMembership data.
+type RepoMembershipUser struct {
+	RepoMembership
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
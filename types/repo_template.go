@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoTemplate defines the protection rules and webhooks that are automatically created
+// for every new or imported repository of a space (including repositories of its sub-spaces).
+type RepoTemplate struct {
+	Rules    []RepoTemplateRule    `json:"rules"`
+	Webhooks []RepoTemplateWebhook `json:"webhooks"`
+}
+
+// RepoTemplateRule is a repository-level protection rule defined by a repository template.
+type RepoTemplateRule struct {
+	Identifier  string          `json:"identifier"`
+	Description string          `json:"description"`
+	Type        RuleType        `json:"type"`
+	State       enum.RuleState  `json:"state"`
+	Pattern     json.RawMessage `json:"pattern"`
+	Definition  json.RawMessage `json:"definition"`
+}
+
+// RepoTemplateWebhook is a repository-level webhook defined by a repository template.
+type RepoTemplateWebhook struct {
+	Identifier  string                `json:"identifier"`
+	DisplayName string                `json:"display_name"`
+	Description string                `json:"description"`
+	URL         string                `json:"url"`
+	Enabled     bool                  `json:"enabled"`
+	Insecure    bool                  `json:"insecure"`
+	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// BranchPatterns restricts the webhook to events of branches matching any of the glob patterns.
+	BranchPatterns []string `json:"branch_patterns,omitempty"`
+
+	// Secret is only used as input, it's never returned.
+	Secret    string `json:"secret,omitempty"`
+	HasSecret bool   `json:"has_secret"`
+}
+
+// RepoTemplateDrift describes the difference between a repository and an item of its repository templates.
+type RepoTemplateDrift struct {
+	Kind       enum.RepoTemplateItemKind    `json:"kind"`
+	Identifier string                       `json:"identifier"`
+	SpaceID    int64                        `json:"space_id"`
+	Status     enum.RepoTemplateDriftStatus `json:"status"`
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoTrafficEvent represents a single clone or fetch of a repository.
+type RepoTrafficEvent struct {
+	RepoID      int64
+	PrincipalID int64
+	// Day is the start of the UTC day (in unix milliseconds) the operation happened on.
+	Day       int64
+	Transport enum.GitTransport
+	Operation enum.GitTrafficOperation
+	Shallow   bool
+}
+
+// RepoTrafficFilter stores repository traffic query parameters.
+type RepoTrafficFilter struct {
+	// After and Before are unix milliseconds limiting the reported days (both inclusive).
+	After  int64 `json:"after"`
+	Before int64 `json:"before"`
+}
+
+// RepoTrafficDay contains the clone and fetch statistics of a repository for a single day.
+type RepoTrafficDay struct {
+	Day           int64 `json:"day"`
+	Clones        int64 `json:"clones"`
+	Fetches       int64 `json:"fetches"`
+	UniqueCallers int64 `json:"unique_callers"`
+	Shallow       int64 `json:"shallow"`
+	Full          int64 `json:"full"`
+	HTTP          int64 `json:"http"`
+	SSH           int64 `json:"ssh"`
+}
+
+// RepoTraffic contains the clone and fetch statistics of a repository for a period of time.
+type RepoTraffic struct {
+	Clones        int64            `json:"clones"`
+	Fetches       int64            `json:"fetches"`
+	UniqueCallers int64            `json:"unique_callers"`
+	Days          []RepoTrafficDay `json:"days"`
+}
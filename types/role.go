@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"slices"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Role is a membership role composed of permissions. Besides the predefined roles,
+// admins can define custom roles which are assigned to space and repository members the same way.
+type Role struct {
+	ID          int64             `json:"-"`
+	Identifier  string            `json:"identifier"`
+	DisplayName string            `json:"display_name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+	// BuiltIn is set for the predefined roles, they can't be modified.
+	BuiltIn bool `json:"built_in"`
+
+	CreatedBy int64 `json:"created_by,omitempty"`
+	Created   int64 `json:"created,omitempty"`
+	Updated   int64 `json:"updated,omitempty"`
+}
+
+// HasPermission returns true if the role grants the permission.
+func (r *Role) HasPermission(permission enum.Permission) bool {
+	return slices.Contains(r.Permissions, permission)
+}
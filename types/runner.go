@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "strconv"
+
+// Runner is an external runner that registered with the server to execute pipeline stages.
+// Stages are only delegated to runners that have all the tags the stage runs on.
+type Runner struct {
+	ID         int64    `json:"-"`
+	Identifier string   `json:"identifier"`
+	Tags       []string `json:"tags"`
+	// Capacity is the max number of stages the runner executes concurrently.
+	Capacity int    `json:"capacity"`
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	// TokenHash is the SHA-256 hash of the token the runner authenticates with.
+	TokenHash string `json:"-"`
+	// LastSeen is the time of the last heartbeat of the runner.
+	LastSeen int64 `json:"last_seen"`
+	Created  int64 `json:"created"`
+	Updated  int64 `json:"updated"`
+
+	// Healthy is true if the runner sent a heartbeat within the heartbeat timeout.
+	Healthy bool `json:"healthy"`
+	// Stages is the number of incomplete stages assigned to the runner.
+	Stages int `json:"stages"`
+}
+
+// Machine returns the machine of the stages the runner accepted. It contains the id of the runner, so the stages
+// stay bound to the runner even if another runner registers with the same identifier once it's deleted.
+func (r *Runner) Machine() string {
+	return r.Identifier + "#" + strconv.FormatInt(r.ID, 10)
+}
+
+// RunnerRegistration is returned to a runner once it registered.
+// The token is only returned once, the runner authenticates with it on all subsequent calls.
+type RunnerRegistration struct {
+	Runner *Runner `json:"runner"`
+	Token  string  `json:"token"`
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// SAMLProvider is a SAML 2.0 identity provider users can login with.
+type SAMLProvider struct {
+	ID          int64  `json:"-"`
+	Identifier  string `json:"identifier"`
+	DisplayName string `json:"display_name"`
+	// IdPMetadata is the metadata document uploaded for the identity provider.
+	IdPMetadata string `json:"idp_metadata"`
+	IdPEntityID string `json:"idp_entity_id"`
+	IdPSSOURL   string `json:"idp_sso_url"`
+	// AttributeMapping defines the assertion attributes the user details are read from.
+	AttributeMapping SAMLAttributeMapping `json:"attribute_mapping"`
+	// GroupMappings grant space memberships to the members of the groups of the identity provider.
+	GroupMappings []SAMLGroupMapping `json:"group_mappings"`
+	Enabled       bool               `json:"enabled"`
+	// AllowSignup provisions accounts for users without an account on their first login.
+	AllowSignup bool `json:"allow_signup"`
+	// TrustSecondFactor trusts the provider to enforce multi-factor authentication. Otherwise users
+	// that enabled two-factor authentication can't login via the provider.
+	TrustSecondFactor bool  `json:"trust_second_factor"`
+	Created           int64 `json:"created"`
+	Updated           int64 `json:"updated"`
+}
+
+// SAMLAttributeMapping defines the names of the assertion attributes holding the user details.
+// The email falls back to the name ID of the assertion if the attribute is missing.
+type SAMLAttributeMapping struct {
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Groups string `json:"groups"`
+}
+
+// SAMLGroupMapping grants a membership of a space to the members of a group of the identity provider.
+type SAMLGroupMapping struct {
+	Group     string              `json:"group"`
+	SpaceID   int64               `json:"-"`
+	SpacePath string              `json:"space_path"`
+	Role      enum.MembershipRole `json:"role"`
+}
+
+// SAMLProviderInfo is the public information about an enabled provider, used by the login page.
+type SAMLProviderInfo struct {
+	Identifier  string `json:"identifier"`
+	DisplayName string `json:"display_name"`
+}
+
+// SAMLIdentity links a principal to its identity with a SAML identity provider.
+type SAMLIdentity struct {
+	ID          int64 `json:"id"`
+	PrincipalID int64 `json:"-"`
+	ProviderID  int64 `json:"-"`
+	// NameID is the subject identifier asserted by the identity provider.
+	NameID  string `json:"name_id"`
+	Email   string `json:"email"`
+	Created int64  `json:"created"`
+	Updated int64  `json:"updated"`
+}
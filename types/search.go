@@ -14,6 +14,8 @@
 
 package types
 
+import "github.com/harness/gitness/types/enum"
+
 type (
 	SearchInput struct {
 		Query string `json:"query"`
@@ -35,6 +37,46 @@ type (
 		Recursive bool `json:"recursive"`
 	}
 
+	// CodeSearchFilter stores the parameters of a code search across all indexed repositories.
+	CodeSearchFilter struct {
+		Query      string `json:"q"`
+		Regex      bool   `json:"regex"`
+		IgnoreCase bool   `json:"ignore_case"`
+
+		// Repo restricts the search to the repository with the given path
+		// or to the repositories located under the given space path.
+		Repo string `json:"repo"`
+
+		// File restricts the search to files whose path contains the given string.
+		File string `json:"file"`
+
+		// Language restricts the search to files of the given language.
+		Language string `json:"lang"`
+
+		// Limit is the maximum number of matching files to return.
+		Limit int `json:"limit"`
+	}
+
+	// GlobalSearchFilter stores the parameters of the global search.
+	GlobalSearchFilter struct {
+		Query string `json:"q"`
+
+		// Types restricts the search to the provided result types. All types are searched if empty.
+		Types []enum.SearchResultType `json:"type"`
+
+		// Limit is the maximum number of results returned per result type.
+		Limit int `json:"limit"`
+	}
+
+	// GlobalSearchResult holds the results of the global search, grouped by the result type.
+	GlobalSearchResult struct {
+		Repositories []*Repository    `json:"repositories"`
+		Spaces       []*Space         `json:"spaces"`
+		PullReqs     []PullReqRepo    `json:"pull_requests"`
+		Users        []*PrincipalInfo `json:"users"`
+		Code         []FileMatch      `json:"code"`
+	}
+
 	SearchResult struct {
 		FileMatches []FileMatch `json:"file_matches"`
 		Stats       SearchStats `json:"stats"`
@@ -14,18 +14,36 @@
 
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
 
 type Secret struct {
-	ID          int64  `db:"secret_id"              json:"-"`
-	Description string `db:"secret_description"     json:"description"`
-	SpaceID     int64  `db:"secret_space_id"        json:"space_id"`
-	CreatedBy   int64  `db:"secret_created_by"      json:"created_by"`
-	Identifier  string `db:"secret_uid"             json:"identifier"`
-	Data        string `db:"secret_data"            json:"-"`
-	Created     int64  `db:"secret_created"         json:"created"`
-	Updated     int64  `db:"secret_updated"         json:"updated"`
-	Version     int64  `db:"secret_version"         json:"-"`
+	ID          int64  `json:"-"`
+	Description string `json:"description"`
+	SpaceID     int64  `json:"space_id"`
+	CreatedBy   int64  `json:"created_by"`
+	Identifier  string `json:"identifier"`
+	Data        string `json:"-"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+	Version     int64  `json:"-"`
+
+	// Secrets are available to the pipelines of all repositories in the space and its subspaces,
+	// the restrictions below limit the executions the secret is exposed to.
+
+	// AllowedEvents restricts the secret to executions triggered by the events, all events if empty.
+	AllowedEvents []enum.TriggerEvent `json:"allowed_events"`
+	// AllowedRepos restricts the secret to the repositories with a path matching any of the patterns
+	// (e.g. "space/repo" or "space/*"), all repositories if empty.
+	AllowedRepos []string `json:"allowed_repos"`
+	// AllowForkPRs exposes the secret to executions of pull requests opened from forks, which are untrusted.
+	AllowForkPRs bool `json:"allow_fork_prs"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -51,5 +69,34 @@ func (s *Secret) CopyWithoutData() *Secret {
 		Created:     s.Created,
 		Updated:     s.Updated,
 		Version:     s.Version,
+
+		AllowedEvents: s.AllowedEvents,
+		AllowedRepos:  s.AllowedRepos,
+		AllowForkPRs:  s.AllowForkPRs,
+	}
+}
+
+// IsExposedTo returns true if the restrictions of the secret allow exposing it
+// to the execution of a pipeline of the repository.
+func (s *Secret) IsExposedTo(repoPath string, execution *Execution) bool {
+	if !s.AllowForkPRs && execution.Event == enum.TriggerEventPullRequest && execution.Fork != "" {
+		return false
 	}
+
+	if len(s.AllowedEvents) > 0 && !slices.Contains(s.AllowedEvents, execution.Event) {
+		return false
+	}
+
+	if len(s.AllowedRepos) == 0 {
+		return true
+	}
+	// repository paths are case insensitive.
+	repoPath = strings.ToLower(repoPath)
+	for _, pattern := range s.AllowedRepos {
+		if ok, _ := path.Match(strings.ToLower(pattern), repoPath); ok {
+			return true
+		}
+	}
+
+	return false
 }
@@ -34,6 +34,10 @@ type (
 		// ServiceAccount specific fields
 		ParentType enum.ParentResourceType `db:"principal_sa_parent_type"  json:"parent_type"`
 		ParentID   int64                   `db:"principal_sa_parent_id"    json:"parent_id"`
+
+		// AllowedCIDRs restrict the networks all tokens of the service account can be used from.
+		// The tokens can be used from anywhere if empty.
+		AllowedCIDRs []string `db:"-" json:"allowed_cidrs,omitempty"`
 	}
 
 	// ServiceAccountInput store details used to
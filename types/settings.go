@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SettingSource describes the scope on which the effective value of a setting is defined.
+type SettingSource struct {
+	Scope enum.SettingsScope `json:"scope"`
+	ID    int64              `json:"id,omitempty"`
+	Path  string             `json:"path,omitempty"`
+}
+
+// EffectiveSetting is the value of an inheritable setting as it applies to a space or a repository.
+// Source is nil if no scope defines the setting and the system default applies.
+type EffectiveSetting struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	Inherited bool            `json:"inherited"`
+	Source    *SettingSource  `json:"source,omitempty"`
+}
+
+// SpaceSettings are the inheritable settings of a space.
+// Unset values are inherited from the parent space.
+type SpaceSettings struct {
+	// MergeMethods are the merge methods allowed for pull requests.
+	MergeMethods []enum.MergeMethod `json:"merge_methods,omitempty"`
+	// DefaultBranch is the default branch of new repositories.
+	DefaultBranch *string `json:"default_branch,omitempty"`
+	// FileSizeLimit is the maximum size of the files that can be pushed.
+	FileSizeLimit *int64 `json:"file_size_limit,omitempty"`
+	// CodeOwnersRequestReview requests reviews of pull requests from the code owners of the changed files.
+	CodeOwnersRequestReview *bool `json:"code_owners_request_review,omitempty"`
+	// SecretScanningEnabled enables secret scanning of pushed commits.
+	SecretScanningEnabled *bool `json:"secret_scanning_enabled,omitempty"`
+	// TwoFactorRequired requires the members of the space to use two-factor authentication.
+	TwoFactorRequired *bool `json:"two_factor_required,omitempty"`
+	// TokenMaxLifetime limits the lifetime of access tokens of the members and service accounts of the space.
+	TokenMaxLifetime *time.Duration `json:"token_max_lifetime,omitempty"`
+	// PipelineClone configures the implicit clone step of the pipelines of the repositories.
+	PipelineClone *PipelineClone `json:"pipeline_clone,omitempty"`
+}
+
+func (s *SpaceSettings) Sanitize() error {
+	for _, method := range s.MergeMethods {
+		if _, ok := method.Sanitize(); !ok || method == "" {
+			return errors.InvalidArgument("Unsupported merge method %q.", method)
+		}
+	}
+	if s.MergeMethods != nil && len(s.MergeMethods) == 0 {
+		return errors.InvalidArgument("At least one merge method must be allowed.")
+	}
+	slices.Sort(s.MergeMethods)
+	s.MergeMethods = slices.Compact(s.MergeMethods)
+
+	if s.DefaultBranch != nil {
+		*s.DefaultBranch = strings.TrimSpace(*s.DefaultBranch)
+		if *s.DefaultBranch == "" {
+			return errors.InvalidArgument("Default branch can't be empty.")
+		}
+	}
+
+	if s.FileSizeLimit != nil && *s.FileSizeLimit <= 0 {
+		return errors.InvalidArgument("File size limit must be a positive number.")
+	}
+
+	if s.TokenMaxLifetime != nil && *s.TokenMaxLifetime < 0 {
+		return errors.InvalidArgument("Maximum token lifetime can't be negative.")
+	}
+
+	if s.PipelineClone != nil {
+		if err := s.PipelineClone.Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -44,5 +44,12 @@ type Stage struct {
 	OnFailure   bool              `json:"on_failure"`
 	DependsOn   []string          `json:"depends_on,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
-	Steps       []*Step           `json:"steps,omitempty"`
+	Matrix      map[string]string `json:"matrix,omitempty"`
+	RunsOn      []string          `json:"runs_on,omitempty"`
+	// ConcurrencyGroup is the concurrency group of the stage within the repository.
+	// At most ConcurrencyLimit executions with stages of the same group run in parallel.
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
+	ConcurrencyLimit int    `json:"concurrency_limit,omitempty"`
+
+	Steps []*Step `json:"steps,omitempty"`
 }
@@ -0,0 +1,26 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SpaceStoragePool holds the storage pool configuration of a space.
+// The default storage pool is represented by an empty name.
+type SpaceStoragePool struct {
+	// Pool is the storage pool assigned to the space, empty in case the space inherits the storage pool.
+	Pool string `json:"pool"`
+	// EffectivePool is the storage pool used for the repositories of the space.
+	EffectivePool string `json:"effective_pool"`
+	// Available lists the names of all configured storage pools.
+	Available []string `json:"available"`
+}
@@ -32,6 +32,37 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+
+	// PreviousIssuedAt is the issue time of the secret the token had before it was rotated last.
+	PreviousIssuedAt *int64 `db:"token_previous_issued_at" json:"-"`
+	// PreviousValidUntil is the unix time until which the previous secret of a rotated token is accepted.
+	PreviousValidUntil *int64 `db:"token_previous_valid_until" json:"previous_valid_until,omitempty"`
+	// ExpiryWarnedAt is the unix time at which the owner of the token was warned about its expiry.
+	ExpiryWarnedAt *int64 `db:"token_expiry_warned_at" json:"-"`
+
+	// UserAgent and IP describe the client the token was created from.
+	UserAgent string `db:"token_user_agent" json:"user_agent,omitempty"`
+	IP        string `db:"token_ip"         json:"ip,omitempty"`
+	// LastUsedAt is the unix time at which the token was last used (updated at most every few minutes).
+	LastUsedAt *int64 `db:"token_last_used_at" json:"last_used_at,omitempty"`
+	// LastUsedIP is the IP address of the client that used the token last.
+	LastUsedIP string `db:"token_last_used_ip" json:"last_used_ip,omitempty"`
+	// Current is set when listing sessions for the session that is used by the request.
+	Current bool `db:"-" json:"current,omitempty"`
+
+	// Scopes restrict the permissions of a personal access token, all permissions are granted if empty.
+	Scopes []enum.TokenScope `db:"-" json:"scopes,omitempty"`
+	// Resources restrict a personal access token to spaces and repositories, it isn't restricted if empty.
+	Resources []TokenResource `db:"-" json:"resources,omitempty"`
+	// AllowedCIDRs restrict the networks the token can be used from, it can be used from anywhere if empty.
+	AllowedCIDRs []string `db:"-" json:"allowed_cidrs,omitempty"`
+}
+
+// TokenResource is a space or repository (including its content) a personal access token is restricted to.
+// Restrictions are bound to the path, so a token loses access to a resource if it is moved or renamed.
+type TokenResource struct {
+	Type enum.ParentResourceType `json:"type"`
+	Path string                  `json:"path"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -51,4 +82,7 @@ func (t Token) MarshalJSON() ([]byte, error) {
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	Token       Token  `json:"token"`
+	// TwoFactorEnrollmentRequired is set if two-factor authentication is required for the user,
+	// but the user didn't enable it yet. The session token is then restricted to the enrollment.
+	TwoFactorEnrollmentRequired bool `json:"two_factor_enrollment_required,omitempty"`
 }
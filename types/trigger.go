@@ -48,3 +48,22 @@ func (s Trigger) MarshalJSON() ([]byte, error) {
 		UID:   s.Identifier,
 	})
 }
+
+// TriggerRequest is a request to create an execution of a pipeline for a git event.
+// It's queued when the event is received and removed once the execution is created.
+// Requests that failed all attempts are kept until they are retried or deleted.
+type TriggerRequest struct {
+	ID                 int64                    `json:"id"`
+	RepoID             int64                    `json:"repo_id"`
+	PipelineID         int64                    `json:"pipeline_id"`
+	PipelineIdentifier string                   `json:"pipeline_identifier,omitempty"`
+	Action             enum.TriggerAction       `json:"action"`
+	Ref                string                   `json:"ref"`
+	SHA                string                   `json:"sha"`
+	Hook               json.RawMessage          `json:"-"`
+	State              enum.TriggerRequestState `json:"state"`
+	Attempts           int                      `json:"attempts"`
+	LastError          string                   `json:"last_error,omitempty"`
+	Created            int64                    `json:"created"`
+	Updated            int64                    `json:"updated"`
+}
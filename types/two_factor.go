@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// UserTwoFactor holds the two-factor authentication (TOTP) state of a user.
+type UserTwoFactor struct {
+	PrincipalID int64 `json:"-"`
+	// Secret is the encrypted TOTP secret.
+	Secret []byte `json:"-"`
+	// Enabled is the time the enrollment was confirmed, nil while the enrollment is pending.
+	Enabled *int64 `json:"-"`
+	// RecoveryCodes are the hashes of the unused recovery codes.
+	RecoveryCodes []string `json:"-"`
+	// LastUsedStep is the TOTP time step of the last accepted code, used to reject replayed codes.
+	LastUsedStep int64 `json:"-"`
+	Created      int64 `json:"-"`
+	Updated      int64 `json:"-"`
+}
+
+// TwoFactorStatus describes the two-factor authentication state of a user.
+type TwoFactorStatus struct {
+	// Enabled is true if the user has at least one second factor (TOTP or WebAuthn credential).
+	Enabled bool `json:"enabled"`
+	// Required is true if the instance or a space the user is a member of requires two-factor authentication.
+	Required               bool `json:"required"`
+	TOTPEnabled            bool `json:"totp_enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+	WebAuthnCredentials    int  `json:"webauthn_credentials"`
+}
+
+// TwoFactorEnrollment contains the TOTP secret the user adds to an authenticator app.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	// URI is the otpauth:// URI of the secret, usually rendered as QR code.
+	URI string `json:"uri"`
+}
+
+// TwoFactorRecoveryCodes are the single-use codes that can be used instead of a TOTP code.
+type TwoFactorRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
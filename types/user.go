@@ -30,6 +30,8 @@ type (
 		Admin       bool   `db:"principal_admin"          json:"admin"`
 		Blocked     bool   `db:"principal_blocked"        json:"blocked"`
 		Salt        string `db:"principal_salt"           json:"-"`
+		Avatar      string `db:"principal_avatar"         json:"-"`
+		AvatarURL   string `db:"-"                        json:"avatar_url,omitempty"`
 		Created     int64  `db:"principal_created"        json:"created"`
 		Updated     int64  `db:"principal_updated"        json:"updated"`
 
@@ -66,6 +68,7 @@ func (u *User) ToPrincipal() *Principal {
 		Admin:       u.Admin,
 		Blocked:     u.Blocked,
 		Salt:        u.Salt,
+		Avatar:      u.Avatar,
 		Created:     u.Created,
 		Updated:     u.Updated,
 	}
@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// UserEmail is an email address of a user. The primary email address is stored with the user itself,
+// the additional email addresses must be verified before they're used for commit attribution and verification.
+type UserEmail struct {
+	ID          int64  `json:"-"`
+	PrincipalID int64  `json:"-"`
+	Email       string `json:"email"`
+	Primary     bool   `json:"primary"`
+	Verified    *int64 `json:"verified"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+
+	// VerificationToken is the hash of the token sent in the verification email.
+	VerificationToken *string `json:"-"`
+	// VerificationSent is the time the last verification email has been sent.
+	VerificationSent *int64 `json:"-"`
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// UserPreferences holds the personal preferences of a user.
+type UserPreferences struct {
+	// Timezone is the IANA name of the timezone used to display dates to the user (e.g. Europe/Berlin).
+	Timezone string `json:"timezone"`
+	// Locale is the BCP 47 language tag used to localize content for the user (e.g. en-US).
+	Locale string `json:"locale"`
+	// DefaultMergeMethod is the merge method preselected when merging pull requests (empty for none).
+	DefaultMergeMethod enum.MergeMethod `json:"default_merge_method,omitempty"`
+	// DiffViewStyle is the way diffs are displayed.
+	DiffViewStyle enum.DiffViewStyle `json:"diff_view_style"`
+
+	Notifications NotificationPreferences `json:"notifications"`
+
+	Updated int64 `json:"updated,omitempty"`
+}
+
+// NotificationPreferences defines which notifications the user receives.
+type NotificationPreferences struct {
+	ReviewerAdded       bool `json:"reviewer_added"`
+	CommentCreated      bool `json:"comment_created"`
+	Mentions            bool `json:"mentions"`
+	BranchUpdated       bool `json:"branch_updated"`
+	ReviewSubmitted     bool `json:"review_submitted"`
+	PullReqStateChanged bool `json:"pullreq_state_changed"`
+	ReviewSLA           bool `json:"review_sla"`
+	TokenExpiring       bool `json:"token_expiring"`
+}
+
+// DefaultUserPreferences returns the preferences of users who didn't change any of them.
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{
+		Timezone:      "UTC",
+		Locale:        "en-US",
+		DiffViewStyle: enum.DiffViewStyleUnified,
+		Notifications: NotificationPreferences{
+			ReviewerAdded:       true,
+			CommentCreated:      true,
+			Mentions:            true,
+			BranchUpdated:       true,
+			ReviewSubmitted:     true,
+			PullReqStateChanged: true,
+			ReviewSLA:           true,
+			TokenExpiring:       true,
+		},
+	}
+}
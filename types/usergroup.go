@@ -15,18 +15,23 @@
 // Package types defines common data structures.
 package types
 
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
 type UserGroup struct {
-	ID          int64    `json:"-"`
+	ID          int64    `json:"id"`
 	Identifier  string   `json:"identifier"`
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
 	SpaceID     int64    `json:"-"`
 	Created     int64    `json:"created"`
 	Updated     int64    `json:"updated"`
-	Users       []string // Users are used by the code owners code
+	Users       []string `json:"users,omitempty"` // Users are used by the code owners code
 }
 
 type UserGroupInfo struct {
+	ID          int64  `json:"id"`
 	Identifier  string `json:"identifier"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
@@ -34,8 +39,57 @@ type UserGroupInfo struct {
 
 func (u *UserGroup) ToUserGroupInfo() *UserGroupInfo {
 	return &UserGroupInfo{
+		ID:          u.ID,
 		Identifier:  u.Identifier,
 		Name:        u.Name,
 		Description: u.Description,
 	}
 }
+
+// UserGroupMember represents a direct member of a user group.
+type UserGroupMember struct {
+	UserGroupID int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+	CreatedBy   int64 `json:"-"`
+	Created     int64 `json:"created"`
+}
+
+// UserGroupMemberUser adds user info to the UserGroupMember data.
+type UserGroupMemberUser struct {
+	UserGroupMember
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
+
+// UserGroupSubgroup represents a user group nested in another user group,
+// members of the child group are members of the parent group as well.
+type UserGroupSubgroup struct {
+	ParentID  int64 `json:"-"`
+	ChildID   int64 `json:"-"`
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+}
+
+// UserGroupMembershipKey can be used as a key for finding a user group's space membership.
+type UserGroupMembershipKey struct {
+	SpaceID     int64
+	UserGroupID int64
+}
+
+// UserGroupMembership grants the role in a space to all members of a user group.
+type UserGroupMembership struct {
+	UserGroupMembershipKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+}
+
+// UserGroupMembershipInfo adds user group info to the UserGroupMembership data.
+type UserGroupMembershipInfo struct {
+	UserGroupMembership
+	UserGroup UserGroupInfo `json:"usergroup"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
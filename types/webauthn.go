@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// WebAuthnCredential is a passkey or security key a user registered for authentication.
+type WebAuthnCredential struct {
+	ID          int64 `json:"id"`
+	PrincipalID int64 `json:"-"`
+	// CredentialID is the identifier the authenticator assigned to the credential.
+	CredentialID []byte `json:"-"`
+	Name         string `json:"name"`
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey []byte `json:"-"`
+	// SignCount is the signature counter of the last authentication, used to detect cloned authenticators.
+	SignCount  uint32   `json:"-"`
+	AAGUID     []byte   `json:"-"`
+	Transports []string `json:"transports"`
+	Created    int64    `json:"created"`
+	Updated    int64    `json:"updated"`
+	LastUsed   *int64   `json:"last_used,omitempty"`
+}
+
+// WebAuthnSession holds the challenge of a started WebAuthn ceremony until it's finished.
+type WebAuthnSession struct {
+	ID string
+	// PrincipalID is the user the ceremony is for, it's zero for logins with discoverable credentials.
+	PrincipalID int64
+	Type        enum.WebAuthnSessionType
+	Challenge   []byte
+	Created     int64
+	Expires     int64
+}
+
+// WebAuthnCredentialUpdateInput is used to rename a WebAuthn credential.
+type WebAuthnCredentialUpdateInput struct {
+	Name *string `json:"name"`
+}
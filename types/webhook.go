@@ -38,9 +38,12 @@ type Webhook struct {
 	Description           string                       `json:"description"`
 	URL                   string                       `json:"url"`
 	Secret                string                       `json:"-"`
+	PreviousSecret        string                       `json:"-"`
+	PreviousSecretExpires int64                        `json:"previous_secret_expires,omitempty"`
 	Enabled               bool                         `json:"enabled"`
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
+	BranchPatterns        []string                     `json:"branch_patterns"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
 }
 
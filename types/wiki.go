@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// WikiPage represents a page of the wiki of a repository.
+// Pages are markdown files stored in the companion git repository of the repository.
+type WikiPage struct {
+	// Name identifies the page, it's the path of the file without the markdown extension (e.g. "guides/Setup").
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	SHA   string `json:"sha,omitempty"`
+
+	// Content is the markdown content of the page, it's omitted when listing pages.
+	Content *string `json:"content,omitempty"`
+
+	LatestCommit *Commit `json:"latest_commit,omitempty"`
+}